@@ -39,6 +39,8 @@ import (
 	"storj.io/storj/satellite/accounting/live"
 	"storj.io/storj/satellite/compensation"
 	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/consistency"
+	"storj.io/storj/satellite/metabase/seed"
 	"storj.io/storj/satellite/nodeselection"
 	"storj.io/storj/satellite/payments/stripe"
 	"storj.io/storj/satellite/satellitedb"
@@ -360,6 +362,49 @@ var (
 		Short: "Fix last_net entries in the database for satellites with DistinctIP=false",
 		RunE:  cmdFixLastNets,
 	}
+	metabaseCmd = &cobra.Command{
+		Use:   "metabase",
+		Short: "Metabase maintenance commands",
+	}
+	metabaseExportCmd = &cobra.Command{
+		Use:   "export <project-id> <bucket> <objects-file> <segments-file>",
+		Short: "Export objects and segments of a bucket to Avro files",
+		Long: "Export the committed objects and their segments of a bucket to Avro object container files, " +
+			"e.g. for migrations, tenant offboarding or disaster recovery drills.",
+		Args: cobra.ExactArgs(4),
+		RunE: cmdMetabaseExport,
+	}
+	metabaseImportCmd = &cobra.Command{
+		Use:   "import <objects-file> <segments-file>",
+		Short: "Import objects and segments exported with the export command",
+		Args:  cobra.ExactArgs(2),
+		RunE:  cmdMetabaseImport,
+	}
+	metabaseSeedCmd = &cobra.Command{
+		Use:   "seed",
+		Short: "Generate a realistic dataset for load testing",
+		Long: "Generate projects, buckets, objects and segments with realistic size distributions and version churn " +
+			"directly in the metabase using bulk inserts. The generated segments can't be downloaded, " +
+			"the command is meant only for performance testing of deletion, listing and repair.",
+		Args: cobra.NoArgs,
+		RunE: cmdMetabaseSeed,
+	}
+	metabaseVerifyCmd = &cobra.Command{
+		Use:   "verify",
+		Short: "Cross-check the objects and segments of the metabase",
+		Long: "Cross-check the objects and segments of the metabase and report segment count mismatches, " +
+			"total size mismatches, orphaned segments and stream IDs reused by more than one object as JSON lines. " +
+			"With --repair the total sizes of objects are recalculated and the orphaned segments are deleted. " +
+			"The objects of reused streams are quarantined, they are reported, but never repaired.",
+		Args: cobra.NoArgs,
+		RunE: cmdMetabaseVerify,
+	}
+
+	metabaseExportCreatedAfter  string
+	metabaseExportCreatedBefore string
+	metabaseSeedCfg             seed.Config
+	metabaseVerifyCfg           consistency.Config
+	metabaseVerifyOutput        string
 
 	runCfg   Satellite
 	setupCfg Satellite
@@ -436,6 +481,16 @@ func init() {
 	rootCmd.AddCommand(fetchPiecesCmd)
 	rootCmd.AddCommand(repairSegmentCmd)
 	rootCmd.AddCommand(fixLastNetsCmd)
+	rootCmd.AddCommand(metabaseCmd)
+	metabaseCmd.AddCommand(metabaseExportCmd)
+	metabaseExportCmd.Flags().StringVar(&metabaseExportCreatedAfter, "created-after", "", "Export only objects created at or after this time (RFC3339).")
+	metabaseExportCmd.Flags().StringVar(&metabaseExportCreatedBefore, "created-before", "", "Export only objects created before this time (RFC3339).")
+	metabaseCmd.AddCommand(metabaseImportCmd)
+	metabaseCmd.AddCommand(metabaseSeedCmd)
+	addMetabaseSeedFlags(metabaseSeedCmd, &metabaseSeedCfg)
+	metabaseCmd.AddCommand(metabaseVerifyCmd)
+	addMetabaseVerifyFlags(metabaseVerifyCmd, &metabaseVerifyCfg)
+	metabaseVerifyCmd.Flags().StringVar(&metabaseVerifyOutput, "output", "-", "File the report is written to, - for stdout.")
 	reportsCmd.AddCommand(nodeUsageCmd)
 	reportsCmd.AddCommand(partnerAttributionCmd)
 	reportsCmd.AddCommand(reportsGracefulExitCmd)
@@ -506,6 +561,10 @@ func init() {
 	process.Bind(stripeCustomerCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(consistencyGECleanupCmd, &consistencyGECleanupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(fixLastNetsCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(metabaseExportCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(metabaseImportCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(metabaseSeedCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(metabaseVerifyCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 
 	if err := consistencyGECleanupCmd.MarkFlagRequired("before"); err != nil {
 		panic(err)
@@ -0,0 +1,251 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/process"
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/avroexport"
+	"storj.io/storj/satellite/metabase/consistency"
+	"storj.io/storj/satellite/metabase/seed"
+)
+
+func cmdMetabaseExport(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L()
+
+	opts := avroexport.Options{
+		BucketName: metabase.BucketName(args[1]),
+	}
+	opts.ProjectID, err = uuid.FromString(args[0])
+	if err != nil {
+		return errs.New("invalid project-id (should be in UUID form): %w", err)
+	}
+	opts.CreatedAfter, err = parseOptionalTime(metabaseExportCreatedAfter)
+	if err != nil {
+		return errs.New("invalid created-after: %w", err)
+	}
+	opts.CreatedBefore, err = parseOptionalTime(metabaseExportCreatedBefore)
+	if err != nil {
+		return errs.New("invalid created-before: %w", err)
+	}
+
+	metabaseDB, err := metabase.Open(ctx, log.Named("metabase"), runCfg.Metainfo.DatabaseURL,
+		runCfg.Config.Metainfo.Metabase("satellite-metabase-export"))
+	if err != nil {
+		return errs.New("Error creating metabase connection: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, metabaseDB.Close())
+	}()
+
+	objectsFile, err := os.Create(args[2])
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	defer func() {
+		err = errs.Combine(err, objectsFile.Close())
+	}()
+
+	segmentsFile, err := os.Create(args[3])
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	defer func() {
+		err = errs.Combine(err, segmentsFile.Close())
+	}()
+
+	objects, segments := bufio.NewWriter(objectsFile), bufio.NewWriter(segmentsFile)
+
+	stats, err := avroexport.Export(ctx, metabaseDB, opts, objects, segments)
+	if err != nil {
+		return err
+	}
+	if err := errs.Combine(objects.Flush(), segments.Flush()); err != nil {
+		return err
+	}
+
+	log.Info("Export finished.", zap.Int64("objects", stats.Objects), zap.Int64("segments", stats.Segments))
+	return nil
+}
+
+func cmdMetabaseImport(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L()
+
+	metabaseDB, err := metabase.Open(ctx, log.Named("metabase"), runCfg.Metainfo.DatabaseURL,
+		runCfg.Config.Metainfo.Metabase("satellite-metabase-import"))
+	if err != nil {
+		return errs.New("Error creating metabase connection: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, metabaseDB.Close())
+	}()
+
+	objectsFile, err := os.Open(args[0])
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	defer func() {
+		err = errs.Combine(err, objectsFile.Close())
+	}()
+
+	segmentsFile, err := os.Open(args[1])
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	defer func() {
+		err = errs.Combine(err, segmentsFile.Close())
+	}()
+
+	stats, err := avroexport.Import(ctx, metabaseDB, objectsFile, segmentsFile, 0)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Import finished.", zap.Int64("objects", stats.Objects), zap.Int64("segments", stats.Segments))
+	return nil
+}
+
+func cmdMetabaseSeed(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L()
+
+	metabaseDB, err := metabase.Open(ctx, log.Named("metabase"), runCfg.Metainfo.DatabaseURL,
+		runCfg.Config.Metainfo.Metabase("satellite-metabase-seed"))
+	if err != nil {
+		return errs.New("Error creating metabase connection: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, metabaseDB.Close())
+	}()
+
+	stats, err := seed.Seed(ctx, log.Named("seed"), metabaseDB, metabaseSeedCfg)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Seed finished.",
+		zap.Int64("projects", stats.Projects),
+		zap.Int64("buckets", stats.Buckets),
+		zap.Int64("objects", stats.Objects),
+		zap.Int64("segments", stats.Segments),
+		zap.Stringer("bytes", memory.Size(stats.Bytes)),
+	)
+	return nil
+}
+
+func cmdMetabaseVerify(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L()
+
+	metabaseDB, err := metabase.Open(ctx, log.Named("metabase"), runCfg.Metainfo.DatabaseURL,
+		runCfg.Config.Metainfo.Metabase("satellite-metabase-verify"))
+	if err != nil {
+		return errs.New("Error creating metabase connection: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, metabaseDB.Close())
+	}()
+
+	output := os.Stdout
+	if metabaseVerifyOutput != "-" {
+		output, err = os.Create(metabaseVerifyOutput)
+		if err != nil {
+			return errs.Wrap(err)
+		}
+		defer func() {
+			err = errs.Combine(err, output.Close())
+		}()
+	}
+
+	writer := bufio.NewWriter(output)
+	encoder := json.NewEncoder(writer)
+
+	stats, err := consistency.Check(ctx, log.Named("consistency"), metabaseDB, metabaseVerifyCfg, func(problem consistency.Problem) error {
+		return encoder.Encode(problem)
+	})
+	if err != nil {
+		return errs.Combine(err, writer.Flush())
+	}
+
+	// the last line is the summary of the check.
+	err = encoder.Encode(struct {
+		Stats consistency.Stats `json:"stats"`
+	}{Stats: stats})
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	if err := writer.Flush(); err != nil {
+		return errs.Wrap(err)
+	}
+
+	log.Info("Verify finished.",
+		zap.Int64("objects", stats.Objects),
+		zap.Int64("segments", stats.Segments),
+		zap.Int64("segment count mismatches", stats.SegmentCountMismatches),
+		zap.Int64("total size mismatches", stats.TotalSizeMismatches),
+		zap.Int64("orphaned streams", stats.OrphanedStreams),
+		zap.Int64("stream id reuses", stats.StreamIDReuses),
+		zap.Int64("repaired", stats.Repaired),
+	)
+	return nil
+}
+
+func addMetabaseVerifyFlags(cmd *cobra.Command, config *consistency.Config) {
+	flags := cmd.Flags()
+	flags.IntVar(&config.Ranges, "ranges", 8, "Number of stream ID ranges checked one after another. More ranges need less memory, but iterate the objects more often.")
+	flags.IntVar(&config.BatchSize, "batch-size", 2500, "Number of objects and segments queried at once.")
+	flags.DurationVar(&config.AsOfSystemInterval, "as-of-system-interval", -5*time.Second, "As of system interval of the queries.")
+	flags.BoolVar(&config.Repair, "repair", false, "Recalculate the total sizes of objects and delete the orphaned segments.")
+}
+
+func addMetabaseSeedFlags(cmd *cobra.Command, config *seed.Config) {
+	config.MinObjectSize = memory.KiB
+	config.MaxObjectSize = memory.GiB
+	config.SegmentSize = 64 * memory.MiB
+	config.InlineThreshold = 4 * memory.KiB
+
+	flags := cmd.Flags()
+	flags.IntVar(&config.Projects, "projects", 10, "Number of generated projects.")
+	flags.IntVar(&config.BucketsPerProject, "buckets-per-project", 10, "Number of generated buckets per project.")
+	flags.IntVar(&config.ObjectsPerBucket, "objects-per-bucket", 1000, "Number of generated objects per bucket.")
+	flags.IntVar(&config.MaxVersions, "max-versions", 1, "Maximum number of versions per object.")
+	flags.Float64Var(&config.DeleteMarkerRatio, "delete-marker-ratio", 0.1, "Ratio of versioned objects whose latest version is a delete marker.")
+	flags.Float64Var(&config.PendingRatio, "pending-ratio", 0.01, "Ratio of pending objects.")
+	flags.Float64Var(&config.ExpiringRatio, "expiring-ratio", 0.05, "Ratio of objects with an expiration time.")
+	flags.Var(&config.MinObjectSize, "min-object-size", "Minimum object size.")
+	flags.Var(&config.MaxObjectSize, "max-object-size", "Maximum object size.")
+	flags.Var(&config.SegmentSize, "segment-size", "Maximum segment size.")
+	flags.Var(&config.InlineThreshold, "inline-threshold", "Segments up to this size are inline.")
+	flags.Int16Var(&config.Redundancy.RequiredShares, "rs.min", 29, "Number of pieces required to reconstruct a segment.")
+	flags.Int16Var(&config.Redundancy.RepairShares, "rs.repair", 35, "Repair threshold of a segment.")
+	flags.Int16Var(&config.Redundancy.OptimalShares, "rs.success", 65, "Number of pieces stored per segment.")
+	flags.Int16Var(&config.Redundancy.TotalShares, "rs.total", 110, "Total number of pieces of a segment.")
+	flags.Int32Var(&config.Redundancy.ShareSize, "rs.share-size", 256, "Erasure share size.")
+	flags.IntVar(&config.Nodes, "nodes", 1000, "Number of storage nodes the pieces are placed on.")
+	flags.IntVar(&config.BatchSize, "batch-size", 10000, "Number of objects inserted at once.")
+	flags.Int64Var(&config.Seed, "seed", 1, "Seed of the random generator.")
+
+	config.Redundancy.Algorithm = storj.ReedSolomon
+}
+
+func parseOptionalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,571 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/cfgstruct"
+	"storj.io/common/memory"
+	"storj.io/common/process"
+	"storj.io/storj/storagenode"
+)
+
+const (
+	// piecesArchiveChecksum is the prefix of the entry which follows every archived file
+	// and contains its hex encoded SHA-256 checksum.
+	piecesArchiveChecksum = "STORJ-SHA256/"
+	// piecesArchiveComplete is the name of the last archive entry, which marks a complete export.
+	// It contains the number of exported files.
+	piecesArchiveComplete = "STORJ-EXPORT-COMPLETE"
+	// piecesArchiveTempSuffix is the suffix of the files being imported.
+	piecesArchiveTempSuffix = ".import-tmp"
+	// piecesProgressInterval is the number of files between progress logs.
+	piecesProgressInterval = 10000
+	// piecesDialTimeout is how long to wait for a running storage node to accept a connection.
+	piecesDialTimeout = time.Second
+)
+
+// piecesExportCfg defines configuration for pieces export command.
+type piecesExportCfg struct {
+	storagenode.Config
+
+	After string `help:"resume an interrupted export after the given archive entry" default:""`
+}
+
+// piecesImportCfg defines configuration for pieces import command.
+type piecesImportCfg struct {
+	storagenode.Config
+
+	Overwrite bool `help:"overwrite existing files which differ from the archived ones" default:"false"`
+}
+
+// piecesSection is a directory of the storage node data included in the archive.
+type piecesSection struct {
+	name string
+	dir  string
+	// skip returns whether the directory with the relative path should be skipped.
+	skip func(rel string) bool
+}
+
+// piecesSections returns the directories of the storage node data to migrate.
+func piecesSections(config *storagenode.Config) []piecesSection {
+	sections := []piecesSection{
+		{
+			name: "storage",
+			dir:  config.Storage.Path,
+			skip: func(rel string) bool {
+				// uploads in progress are not migrated
+				return rel == "temp"
+			},
+		},
+	}
+
+	dbdir := config.Storage2.DatabaseDir
+	if dbdir != "" && filepath.Clean(dbdir) != filepath.Clean(config.Storage.Path) {
+		sections = append(sections, piecesSection{name: "databases", dir: dbdir})
+	}
+
+	return append(sections, piecesSection{name: "orders", dir: config.Storage2.Orders.Path})
+}
+
+func newPiecesCmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pieces",
+		Short: "Migrate the storage node data to new hardware",
+		Long: `Export and import the pieces, databases and orders of the storage node.
+
+The storage node must be stopped during the export, and it must not be started on the
+new hardware before the import finishes.
+`,
+		Annotations: map[string]string{"type": "helper"},
+	}
+
+	cmd.AddCommand(
+		newPiecesExportCmd(f),
+		newPiecesImportCmd(f),
+	)
+
+	return cmd
+}
+
+func newPiecesExportCmd(f *Factory) *cobra.Command {
+	var cfg piecesExportCfg
+	cmd := &cobra.Command{
+		Use:   "export <archive>",
+		Short: "Export the storage node data into an archive",
+		Long: `Export the blobs, databases and orders of the storage node into a tar archive.
+
+Every file is followed by its checksum in the archive, which is verified during the import.
+The export refuses to run while the storage node is running.
+Use - as archive to write the archive to the standard output.
+
+When the import of a streamed archive is interrupted, the export can be resumed with
+--after, using the last imported entry reported by the import.
+`,
+		Example: `
+# export into an archive file
+$ storagenode pieces export /mnt/backup/storagenode.tar --config-dir /path/to/configDir
+
+# migrate over the network
+$ storagenode pieces export - --config-dir /path/to/configDir | ssh new-host storagenode pieces import - --config-dir /path/to/configDir
+
+# resume an interrupted migration
+$ storagenode pieces export - --after storage/blobs/<path> --config-dir /path/to/configDir | ssh new-host storagenode pieces import - --config-dir /path/to/configDir
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := process.Ctx(cmd)
+			return cmdPiecesExport(ctx, zap.L(), &cfg, args[0])
+		},
+	}
+
+	process.Bind(cmd, &cfg, f.Defaults, cfgstruct.ConfDir(f.ConfDir), cfgstruct.IdentityDir(f.IdentityDir))
+
+	return cmd
+}
+
+func newPiecesImportCmd(f *Factory) *cobra.Command {
+	var cfg piecesImportCfg
+	cmd := &cobra.Command{
+		Use:   "import <archive>",
+		Short: "Import the storage node data from an archive",
+		Long: `Import the blobs, databases and orders of the storage node from an archive created by export.
+
+The checksum of every file is verified before it's moved to its place. Files which were
+already imported are skipped, so an interrupted import can be restarted.
+The import refuses to run while the storage node is running.
+Use - as archive to read the archive from the standard input.
+`,
+		Example: `
+# import from an archive file
+$ storagenode pieces import /mnt/backup/storagenode.tar --config-dir /path/to/configDir
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := process.Ctx(cmd)
+			return cmdPiecesImport(ctx, zap.L(), &cfg, args[0])
+		},
+	}
+
+	process.Bind(cmd, &cfg, f.Defaults, cfgstruct.ConfDir(f.ConfDir), cfgstruct.IdentityDir(f.IdentityDir))
+
+	return cmd
+}
+
+func cmdPiecesExport(ctx context.Context, log *zap.Logger, cfg *piecesExportCfg, archive string) (err error) {
+	if err := ensureNodeStopped(ctx, cfg.Server.PrivateAddress); err != nil {
+		return err
+	}
+
+	out := io.Writer(os.Stdout)
+	if archive != "-" {
+		file, err := os.Create(archive)
+		if err != nil {
+			return errs.Wrap(err)
+		}
+		defer func() { err = errs.Combine(err, file.Close()) }()
+		out = file
+	}
+
+	return exportPieces(ctx, log, piecesSections(&cfg.Config), cfg.After, out)
+}
+
+func cmdPiecesImport(ctx context.Context, log *zap.Logger, cfg *piecesImportCfg, archive string) (err error) {
+	if err := ensureNodeStopped(ctx, cfg.Server.PrivateAddress); err != nil {
+		return err
+	}
+
+	in := io.Reader(os.Stdin)
+	if archive != "-" {
+		file, err := os.Open(archive)
+		if err != nil {
+			return errs.Wrap(err)
+		}
+		defer func() { err = errs.Combine(err, file.Close()) }()
+		in = file
+	}
+
+	return importPieces(ctx, log, piecesSections(&cfg.Config), cfg.Overwrite, in)
+}
+
+// ensureNodeStopped returns an error when a storage node accepts connections on its
+// private address, because its data would change while it's migrated.
+func ensureNodeStopped(ctx context.Context, privateAddress string) error {
+	if privateAddress == "" {
+		return nil
+	}
+
+	dialer := net.Dialer{Timeout: piecesDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", privateAddress)
+	if err != nil {
+		return nil //nolint: nilerr // the node isn't listening, so it's not running.
+	}
+	_ = conn.Close()
+
+	return errs.New("storage node is running (%s accepts connections), stop it before migrating the data", privateAddress)
+}
+
+// exportPieces writes the files of the sections into a tar archive. When after
+// is not empty, the entries up to and including after are skipped.
+func exportPieces(ctx context.Context, log *zap.Logger, sections []piecesSection, after string, out io.Writer) (err error) {
+	archive := tar.NewWriter(out)
+
+	skipping := after != ""
+	var files, skipped int
+	var size memory.Size
+
+	for _, section := range sections {
+		if _, err := os.Stat(section.dir); errors.Is(err, fs.ErrNotExist) {
+			log.Info("Skipping missing directory.", zap.String("Path", section.dir))
+			continue
+		}
+
+		err := filepath.WalkDir(section.dir, func(fullpath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			rel, err := filepath.Rel(section.dir, fullpath)
+			if err != nil {
+				return err
+			}
+			if rel == "." {
+				return nil
+			}
+			name := path.Join(section.name, filepath.ToSlash(rel))
+
+			if entry.IsDir() {
+				if section.skip != nil && section.skip(rel) {
+					return filepath.SkipDir
+				}
+				if skipping {
+					return nil
+				}
+				return archive.WriteHeader(&tar.Header{
+					Typeflag: tar.TypeDir,
+					Name:     name + "/",
+					Mode:     0700,
+				})
+			}
+
+			if !entry.Type().IsRegular() {
+				log.Warn("Skipping file which is not a regular file.", zap.String("Path", fullpath))
+				return nil
+			}
+
+			if skipping {
+				skipped++
+				if name == after {
+					skipping = false
+				}
+				return nil
+			}
+
+			n, err := exportFile(archive, fullpath, name)
+			if err != nil {
+				return err
+			}
+
+			files++
+			size += memory.Size(n)
+			if files%piecesProgressInterval == 0 {
+				log.Info("Export in progress.", zap.Int("Files", files), zap.Stringer("Size", size), zap.String("Last", name))
+			}
+			return nil
+		})
+		if err != nil {
+			return errs.Wrap(err)
+		}
+	}
+
+	if skipping {
+		return errs.New("entry %q to resume after was not found", after)
+	}
+
+	count := []byte(strconv.Itoa(files + skipped))
+	if err := archive.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     piecesArchiveComplete,
+		Mode:     0600,
+		Size:     int64(len(count)),
+	}); err != nil {
+		return errs.Wrap(err)
+	}
+	if _, err := archive.Write(count); err != nil {
+		return errs.Wrap(err)
+	}
+	if err := archive.Close(); err != nil {
+		return errs.Wrap(err)
+	}
+
+	log.Info("Export finished.", zap.Int("Files", files), zap.Int("Skipped", skipped), zap.Stringer("Size", size))
+	return nil
+}
+
+// exportFile writes a file into the archive followed by an entry with its checksum.
+// The checksum is calculated while the file is archived, so it's read only once.
+func exportFile(archive *tar.Writer, fullpath, name string) (_ int64, err error) {
+	file, err := os.Open(fullpath)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = errs.Combine(err, file.Close()) }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	err = archive.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     int64(info.Mode().Perm()),
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+		Format:   tar.FormatPAX,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(archive, hash), io.LimitReader(file, info.Size()))
+	if err != nil {
+		return n, err
+	}
+	if n != info.Size() {
+		return n, errs.New("%s changed during export", fullpath)
+	}
+
+	checksum := []byte(hex.EncodeToString(hash.Sum(nil)))
+	err = archive.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     piecesArchiveChecksum + name,
+		Mode:     0600,
+		Size:     int64(len(checksum)),
+		Format:   tar.FormatPAX,
+	})
+	if err != nil {
+		return n, err
+	}
+	_, err = archive.Write(checksum)
+	return n, err
+}
+
+// importPieces extracts an archive created by exportPieces into the directories of the sections.
+func importPieces(ctx context.Context, log *zap.Logger, sections []piecesSection, overwrite bool, in io.Reader) (err error) {
+	dirs := make(map[string]string, len(sections))
+	for _, section := range sections {
+		dirs[section.name] = section.dir
+	}
+
+	archive := tar.NewReader(in)
+
+	var files, skipped int
+	var size memory.Size
+	var last string
+
+	defer func() {
+		if err != nil && last != "" {
+			log.Error("Import interrupted. Resume the export with --after.", zap.String("Last imported entry", last))
+		}
+	}()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return errs.New("archive is incomplete")
+		}
+		if err != nil {
+			return errs.Wrap(err)
+		}
+
+		if header.Name == piecesArchiveComplete {
+			data, err := io.ReadAll(archive)
+			if err != nil {
+				return errs.Wrap(err)
+			}
+			expected, err := strconv.Atoi(string(data))
+			if err != nil {
+				return errs.New("invalid archive: %v", err)
+			}
+			// when the export was resumed, the count includes files imported before.
+			if files+skipped > expected {
+				return errs.New("invalid archive: %d files imported, %d exported", files+skipped, expected)
+			}
+
+			log.Info("Import finished.", zap.Int("Files", files), zap.Int("Skipped", skipped), zap.Stringer("Size", size))
+			return nil
+		}
+
+		sectionName, rel, _ := strings.Cut(strings.TrimSuffix(header.Name, "/"), "/")
+		dir, ok := dirs[sectionName]
+		if !ok {
+			return errs.New("invalid archive: unknown entry %q", header.Name)
+		}
+		if !filepath.IsLocal(filepath.FromSlash(rel)) {
+			return errs.New("invalid archive: invalid entry %q", header.Name)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(rel))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0700); err != nil {
+				return errs.Wrap(err)
+			}
+		case tar.TypeReg:
+			imported, err := importFile(archive, header, target, overwrite)
+			if err != nil {
+				return errs.Wrap(err)
+			}
+			if imported {
+				files++
+				size += memory.Size(header.Size)
+			} else {
+				skipped++
+			}
+			last = header.Name
+
+			if (files+skipped)%piecesProgressInterval == 0 {
+				log.Info("Import in progress.", zap.Int("Files", files), zap.Int("Skipped", skipped), zap.Stringer("Size", size), zap.String("Last", last))
+			}
+		default:
+			return errs.New("invalid archive: unsupported entry %q", header.Name)
+		}
+	}
+}
+
+// importFile writes the file of the archive entry to target after verifying its
+// checksum, which is read from the following entry. It returns false when the target
+// already has the same content.
+func importFile(archive *tar.Reader, header *tar.Header, target string, overwrite bool) (imported bool, err error) {
+	existing, err := checksumFile(target)
+	exists := err == nil
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+
+	if exists && !overwrite {
+		// the content is only needed to verify the archive.
+		actual, err := copyWithChecksum(io.Discard, archive, header)
+		if err != nil {
+			return false, err
+		}
+		if err := verifyChecksum(archive, header, actual); err != nil {
+			return false, err
+		}
+		if existing != actual {
+			return false, errs.New("%s already exists with different content, use --overwrite to replace it", target)
+		}
+		return false, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return false, err
+	}
+
+	temp := target + piecesArchiveTempSuffix
+	file, err := os.OpenFile(temp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, fs.FileMode(header.Mode).Perm())
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err != nil || !imported {
+			err = errs.Combine(err, os.Remove(temp))
+		}
+	}()
+
+	actual, err := copyWithChecksum(file, archive, header)
+	err = errs.Combine(err, file.Sync(), file.Close())
+	if err != nil {
+		return false, err
+	}
+	if err := verifyChecksum(archive, header, actual); err != nil {
+		return false, err
+	}
+	if exists && existing == actual {
+		return false, nil
+	}
+
+	if err := os.Chtimes(temp, header.ModTime, header.ModTime); err != nil {
+		return false, err
+	}
+	if err := os.Rename(temp, target); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// copyWithChecksum copies the content of the archive entry to w and returns its checksum.
+func copyWithChecksum(w io.Writer, archive io.Reader, header *tar.Header) (string, error) {
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(w, hash), archive)
+	if err != nil {
+		return "", err
+	}
+	if n != header.Size {
+		return "", errs.New("invalid archive: %q is truncated", header.Name)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// verifyChecksum reads the checksum entry following the file entry and compares it to actual.
+func verifyChecksum(archive *tar.Reader, header *tar.Header, actual string) error {
+	checksumHeader, err := archive.Next()
+	if err != nil {
+		return errs.New("invalid archive: missing checksum of %q: %v", header.Name, err)
+	}
+	if checksumHeader.Name != piecesArchiveChecksum+header.Name {
+		return errs.New("invalid archive: missing checksum of %q", header.Name)
+	}
+
+	expected, err := io.ReadAll(io.LimitReader(archive, int64(hex.EncodedLen(sha256.Size)+1)))
+	if err != nil {
+		return err
+	}
+	if string(expected) != actual {
+		return errs.New("checksum mismatch of %q: expected %s, got %s", header.Name, expected, actual)
+	}
+	return nil
+}
+
+// checksumFile returns the hex encoded SHA-256 checksum of the file.
+func checksumFile(name string) (_ string, err error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer func() { err = errs.Combine(err, file.Close()) }()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,164 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+)
+
+func TestPiecesExportImport(t *testing.T) {
+	ctx := testcontext.New(t)
+	log := zaptest.NewLogger(t)
+
+	files := map[string][]byte{
+		"storage/blobs/satellite/aa/piece1.sj1": testrand.BytesInt(1024),
+		"storage/blobs/satellite/ab/piece2.sj1": testrand.BytesInt(2048),
+		"storage/info.db":                       testrand.BytesInt(512),
+		"orders/unsent/orders-1":                testrand.BytesInt(128),
+	}
+
+	source := []piecesSection{
+		{name: "storage", dir: ctx.Dir("source", "storage"), skip: func(rel string) bool { return rel == "temp" }},
+		{name: "orders", dir: ctx.Dir("source", "orders")},
+	}
+	for name, data := range files {
+		writeTestFile(t, filepath.Join(ctx.Dir("source"), filepath.FromSlash(name)), data)
+	}
+	writeTestFile(t, filepath.Join(ctx.Dir("source", "storage", "temp"), "upload"), testrand.BytesInt(16))
+
+	var archive bytes.Buffer
+	require.NoError(t, exportPieces(ctx, log, source, "", &archive))
+
+	target := []piecesSection{
+		{name: "storage", dir: ctx.Dir("target", "storage")},
+		{name: "orders", dir: ctx.Dir("target", "orders")},
+	}
+
+	t.Run("import", func(t *testing.T) {
+		require.NoError(t, importPieces(ctx, log, target, false, bytes.NewReader(archive.Bytes())))
+		for name, data := range files {
+			imported, err := os.ReadFile(filepath.Join(ctx.Dir("target"), filepath.FromSlash(name)))
+			require.NoError(t, err)
+			require.Equal(t, data, imported)
+		}
+		require.NoDirExists(t, filepath.Join(ctx.Dir("target", "storage"), "temp"))
+
+		// importing again skips the existing files.
+		require.NoError(t, importPieces(ctx, log, target, false, bytes.NewReader(archive.Bytes())))
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		conflicting := filepath.Join(ctx.Dir("target"), "orders", "unsent", "orders-1")
+		writeTestFile(t, conflicting, testrand.BytesInt(128))
+
+		require.Error(t, importPieces(ctx, log, target, false, bytes.NewReader(archive.Bytes())))
+		require.NoError(t, importPieces(ctx, log, target, true, bytes.NewReader(archive.Bytes())))
+
+		imported, err := os.ReadFile(conflicting)
+		require.NoError(t, err)
+		require.Equal(t, files["orders/unsent/orders-1"], imported)
+	})
+
+	t.Run("incomplete", func(t *testing.T) {
+		truncated := archive.Bytes()[:archive.Len()/2]
+		require.Error(t, importPieces(ctx, log, []piecesSection{
+			{name: "storage", dir: ctx.Dir("incomplete", "storage")},
+			{name: "orders", dir: ctx.Dir("incomplete", "orders")},
+		}, false, bytes.NewReader(truncated)))
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		entries := archiveFileEntries(t, archive.Bytes())
+		require.Len(t, entries, len(files))
+		after := entries[1]
+
+		// simulate an import which was interrupted after the entry.
+		resumedTarget := []piecesSection{
+			{name: "storage", dir: ctx.Dir("resumed", "storage")},
+			{name: "orders", dir: ctx.Dir("resumed", "orders")},
+		}
+		for _, name := range entries[:2] {
+			writeTestFile(t, filepath.Join(ctx.Dir("resumed"), filepath.FromSlash(name)), files[name])
+		}
+
+		var resumed bytes.Buffer
+		require.NoError(t, exportPieces(ctx, log, source, after, &resumed))
+		require.Equal(t, entries[2:], archiveFileEntries(t, resumed.Bytes()))
+
+		require.NoError(t, importPieces(ctx, log, resumedTarget, false, bytes.NewReader(resumed.Bytes())))
+		for name, data := range files {
+			imported, err := os.ReadFile(filepath.Join(ctx.Dir("resumed"), filepath.FromSlash(name)))
+			require.NoError(t, err)
+			require.Equal(t, data, imported)
+		}
+
+		require.Error(t, exportPieces(ctx, log, source, "storage/unknown", &bytes.Buffer{}))
+	})
+
+	t.Run("corrupted", func(t *testing.T) {
+		corrupted := bytes.Clone(archive.Bytes())
+		index := bytes.Index(corrupted, files["orders/unsent/orders-1"])
+		require.Positive(t, index)
+		corrupted[index] ^= 0xFF
+
+		dir := ctx.Dir("corrupted")
+		err := importPieces(ctx, log, []piecesSection{
+			{name: "storage", dir: filepath.Join(dir, "storage")},
+			{name: "orders", dir: filepath.Join(dir, "orders")},
+		}, false, bytes.NewReader(corrupted))
+		require.ErrorContains(t, err, "checksum mismatch")
+		require.NoFileExists(t, filepath.Join(dir, "orders", "unsent", "orders-1"))
+		require.NoFileExists(t, filepath.Join(dir, "orders", "unsent", "orders-1"+piecesArchiveTempSuffix))
+	})
+}
+
+func TestEnsureNodeStopped(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+
+	require.Error(t, ensureNodeStopped(ctx, address))
+
+	require.NoError(t, listener.Close())
+	require.NoError(t, ensureNodeStopped(ctx, address))
+}
+
+// archiveFileEntries returns the names of the files in the archive in their order.
+func archiveFileEntries(t *testing.T, data []byte) (names []string) {
+	archive := tar.NewReader(bytes.NewReader(data))
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return names
+		}
+		require.NoError(t, err)
+
+		if header.Typeflag == tar.TypeReg &&
+			header.Name != piecesArchiveComplete &&
+			!strings.HasPrefix(header.Name, piecesArchiveChecksum) {
+			names = append(names, header.Name)
+		}
+	}
+}
+
+func writeTestFile(t *testing.T, name string, data []byte) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(name), 0700))
+	require.NoError(t, os.WriteFile(name, data, 0600))
+}
@@ -14,6 +14,7 @@ import (
 	"storj.io/common/version"
 	"storj.io/storj/private/revocation"
 	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/logging"
 	"storj.io/storj/storagenode/storagenodedb"
 )
 
@@ -45,6 +46,24 @@ func cmdRun(cmd *cobra.Command, cfg *runCfg) (err error) {
 	ctx, _ := process.Ctx(cmd)
 	log := zap.L()
 
+	var logLevels *logging.Levels
+	if cfg.Logging.Enabled {
+		logLevels, err = logging.NewLevels(cfg.Logging)
+		if err != nil {
+			return errs.New("Invalid logging configuration: %+v", err)
+		}
+		var closeLog func() error
+		log, closeLog, err = logging.NewLogger(cfg.Logging, logLevels)
+		if err != nil {
+			return errs.New("Failed to create logger: %+v", err)
+		}
+		defer func() {
+			_ = log.Sync()
+			err = errs.Combine(err, closeLog())
+		}()
+		defer zap.ReplaceGlobals(log)()
+	}
+
 	defer func() {
 		if err != nil && !errs2.IsCanceled(err) {
 			log.Error("failure during run", zap.Error(err))
@@ -73,6 +92,14 @@ func cmdRun(cmd *cobra.Command, cfg *runCfg) (err error) {
 		err = errs.Combine(err, db.Close())
 	}()
 
+	// corrupt databases which can't be rebuilt put the node in read-only mode
+	// instead of preventing it from starting.
+	err = db.Recover(ctx)
+	if err != nil {
+		return errs.New("Error checking integrity of storagenode databases: %+v", err)
+	}
+	readOnly := db.DatabaseHealth().ReadOnly()
+
 	revocationDB, err := revocation.OpenDBFromCfg(ctx, cfg.Server.Config)
 	if err != nil {
 		return errs.New("Error opening revocation database: %+v", err)
@@ -81,7 +108,7 @@ func cmdRun(cmd *cobra.Command, cfg *runCfg) (err error) {
 		err = errs.Combine(err, revocationDB.Close())
 	}()
 
-	peer, err := storagenode.New(log, identity, db, revocationDB, cfg.Config, version.Build, process.AtomicLevel(cmd))
+	peer, err := storagenode.New(log, identity, db, revocationDB, cfg.Config, version.Build, process.AtomicLevel(cmd), logLevels)
 	if err != nil {
 		return errs.New("Failed to create storage node peer: %+v", err)
 	}
@@ -99,12 +126,18 @@ func cmdRun(cmd *cobra.Command, cfg *runCfg) (err error) {
 
 	err = db.MigrateToLatest(ctx)
 	if err != nil {
-		return errs.New("Error migrating tables for database on storagenode: %+v", err)
+		if !readOnly {
+			return errs.New("Error migrating tables for database on storagenode: %+v", err)
+		}
+		log.Error("Failed to migrate storagenode databases, continuing in read-only mode.", zap.Error(err))
 	}
 
 	err = db.CheckVersion(ctx)
 	if err != nil {
-		return errs.New("Error checking version for storagenode database: %+v", err)
+		if !readOnly {
+			return errs.New("Error checking version for storagenode database: %+v", err)
+		}
+		log.Error("Failed to check version of storagenode databases, continuing in read-only mode.", zap.Error(err))
 	}
 
 	preflightEnabled, err := cmd.Flags().GetBool("preflight.database-check")
@@ -114,7 +147,10 @@ func cmdRun(cmd *cobra.Command, cfg *runCfg) (err error) {
 	if preflightEnabled {
 		err = db.Preflight(ctx)
 		if err != nil {
-			return errs.New("Error during preflight check for storagenode databases: %+v", err)
+			if !readOnly {
+				return errs.New("Error during preflight check for storagenode databases: %+v", err)
+			}
+			log.Error("Preflight check for storagenode databases failed, continuing in read-only mode.", zap.Error(err))
 		}
 	}
 
@@ -105,13 +105,13 @@ func createEndpoint(ctx context.Context, satIdent, snIdent *identity.FullIdentit
 
 	self := contact.NodeInfo{ID: snIdent.ID}
 
-	contactService := contact.NewService(log, dialer, self, trustPool, contact.NewQUICStats(false), &pb.SignedNodeTagSets{})
+	contactService := contact.NewService(log, dialer, self, trustPool, contact.NewQUICStats(false), &pb.SignedNodeTagSets{}, nil)
 
-	monitorService := monitor.NewService(log, piecesStore, contactService, 1<<40, time.Hour, func(context.Context) {}, cfg.Storage2.Monitor)
+	trashChore := pieces.NewTrashChore(log, 24*time.Hour, 7*24*time.Hour, trustPool, piecesStore)
 
-	retainService := retain.NewService(log, piecesStore, cfg.Retain)
+	monitorService := monitor.NewService(log, piecesStore, trashChore, contactService, 1<<40, time.Hour, func(context.Context) {}, cfg.Storage2.Monitor, nil)
 
-	trashChore := pieces.NewTrashChore(log, 24*time.Hour, 7*24*time.Hour, trustPool, piecesStore)
+	retainService := retain.NewService(log, piecesStore, cfg.Retain)
 
 	pieceDeleter := pieces.NewDeleter(log, piecesStore, cfg.Storage2.DeleteWorkers, cfg.Storage2.DeleteQueueSize)
 
@@ -120,7 +120,7 @@ func createEndpoint(ctx context.Context, satIdent, snIdent *identity.FullIdentit
 	usedSerials := usedserials.NewTable(cfg.Storage2.MaxUsedSerialsSize)
 
 	bandwidthdbCache := bandwidth.NewCache(snDB.Bandwidth())
-	endpoint := try.E1(piecestore.NewEndpoint(log, snIdent, trustPool, monitorService, retainService, new(contact.PingStats), piecesStore, trashChore, pieceDeleter, ordersStore, bandwidthdbCache, usedSerials, cfg.Storage2))
+	endpoint := try.E1(piecestore.NewEndpoint(log, snIdent, trustPool, monitorService, retainService, new(contact.PingStats), piecesStore, trashChore, pieceDeleter, ordersStore, bandwidthdbCache, usedSerials, nil, cfg.Storage2, nil))
 	collectorService := collector.NewService(log, piecesStore, usedSerials, collector.Config{Interval: 1000 * time.Hour})
 
 	return endpoint, collectorService
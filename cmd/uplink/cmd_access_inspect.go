@@ -5,9 +5,13 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 
 	"github.com/zeebo/clingy"
 	"github.com/zeebo/errs"
@@ -25,6 +29,7 @@ var _ clingy.Command = (*cmdAccessInspect)(nil)
 type cmdAccessInspect struct {
 	ex     ulext.External
 	access *string
+	keys   bool
 }
 
 // newCmdAccessInspect is a constructor for cmdAccessInspect.
@@ -34,6 +39,9 @@ func newCmdAccessInspect(ex ulext.External) clingy.Command {
 
 // Setup is called to define and parse arguments.
 func (c *cmdAccessInspect) Setup(params clingy.Parameters) {
+	c.keys = params.Flag("keys", "Show the structure of the encryption key hierarchy without revealing the keys", false,
+		clingy.Transform(strconv.ParseBool), clingy.Boolean,
+	).(bool)
 	c.access = params.Arg("access", "Inspect access by its name or value.", clingy.Optional).(*string)
 }
 
@@ -61,6 +69,16 @@ func (c *cmdAccessInspect) Execute(ctx context.Context) (err error) {
 		return errs.New("could not parse access: %+v", err)
 	}
 
+	if c.keys {
+		bs, err := json.MarshalIndent(inspectKeys(p.EncryptionAccess), "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(clingy.Stdout(ctx), string(bs))
+		return nil
+	}
+
 	m, err := macaroon.ParseMacaroon(p.ApiKey)
 	if err != nil {
 		return errs.New("could not parse macaroon: %+v", err)
@@ -141,3 +159,58 @@ type accessInspectMacaroon struct {
 	Caveats []macaroon.Caveat `json:"caveats"`
 	Tail    base64url         `json:"tail"`
 }
+
+// accessInspectKeys contains the structure of the encryption key hierarchy of an access.
+// The keys are shown only as fingerprints, which allow comparing the keys of two accesses,
+// e.g. to find out whether they were derived from the same passphrase.
+type accessInspectKeys struct {
+	DefaultKey        string                   `json:"default_key"`
+	DefaultPathCipher string                   `json:"default_path_cipher"`
+	Scopes            []accessInspectKeysScope `json:"scopes"`
+}
+
+// accessInspectKeysScope is a bucket or a prefix with its own encryption key.
+type accessInspectKeysScope struct {
+	Bucket          string    `json:"bucket"`
+	Prefix          string    `json:"prefix"`
+	EncryptedPrefix base64url `json:"encrypted_prefix"`
+	PathCipher      string    `json:"path_cipher"`
+	Key             string    `json:"key"`
+}
+
+// inspectKeys returns the structure of the encryption key hierarchy.
+func inspectKeys(access *pb.EncryptionAccess) accessInspectKeys {
+	keys := accessInspectKeys{
+		DefaultKey:        keyFingerprint(access.GetDefaultKey()),
+		DefaultPathCipher: access.GetDefaultPathCipher().String(),
+		Scopes:            []accessInspectKeysScope{},
+	}
+
+	for _, entry := range access.GetStoreEntries() {
+		keys.Scopes = append(keys.Scopes, accessInspectKeysScope{
+			Bucket:          string(entry.Bucket),
+			Prefix:          string(entry.UnencryptedPath),
+			EncryptedPrefix: entry.EncryptedPath,
+			PathCipher:      entry.PathCipher.String(),
+			Key:             keyFingerprint(entry.Key),
+		})
+	}
+	sort.Slice(keys.Scopes, func(i, k int) bool {
+		if keys.Scopes[i].Bucket != keys.Scopes[k].Bucket {
+			return keys.Scopes[i].Bucket < keys.Scopes[k].Bucket
+		}
+		return keys.Scopes[i].Prefix < keys.Scopes[k].Prefix
+	})
+
+	return keys
+}
+
+// keyFingerprint returns a short hash of the key, which doesn't reveal the key.
+// It returns an empty string when the key is missing.
+func keyFingerprint(key []byte) string {
+	if len(key) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
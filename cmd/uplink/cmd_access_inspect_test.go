@@ -4,8 +4,13 @@
 package main
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/base58"
+	"storj.io/common/pb"
 	"storj.io/storj/cmd/uplink/ultest"
 )
 
@@ -67,4 +72,45 @@ func TestAccessInspect(t *testing.T) {
 	t.Run("try to get unexisting access", func(t *testing.T) {
 		state.Fail(t, "access", "inspect", "unexisting")
 	})
+	t.Run("show key hierarchy", func(t *testing.T) {
+		state.Succeed(t, "access", "inspect", "--keys", "TestAccessA").RequireStdout(t, `
+			{
+				"default_key": "33fb462815ff6449",
+				"default_path_cipher": "ENC_AESGCM",
+				"scopes": []
+			}
+		`)
+	})
+
+	t.Run("show key hierarchy with scopes", func(t *testing.T) {
+		scope, err := parseAccessRaw(accessValue)
+		require.NoError(t, err)
+
+		scope.EncryptionAccess.DefaultKey = nil
+		scope.EncryptionAccess.StoreEntries = []*pb.EncryptionAccess_StoreEntry{{
+			Bucket:          []byte("photos"),
+			UnencryptedPath: []byte("2024"),
+			EncryptedPath:   []byte("encrypted"),
+			Key:             bytes.Repeat([]byte{1}, 32),
+			PathCipher:      pb.CipherSuite_ENC_AESGCM,
+		}}
+		data, err := pb.Marshal(scope)
+		require.NoError(t, err)
+
+		state.Succeed(t, "access", "inspect", "--keys", base58.CheckEncode(data, 0)).RequireStdout(t, `
+			{
+				"default_key": "",
+				"default_path_cipher": "ENC_AESGCM",
+				"scopes": [
+					{
+						"bucket": "photos",
+						"prefix": "2024",
+						"encrypted_prefix": "ZW5jcnlwdGVk",
+						"path_cipher": "ENC_AESGCM",
+						"key": "72cd6e8422c407fb"
+					}
+				]
+			}
+		`)
+	})
 }
@@ -84,7 +84,7 @@ func (c *cmdCp) Setup(params clingy.Parameters) {
 	).(bool)
 	c.byteRange = params.Flag("range", "Downloads the specified range bytes of an object. For more information about the HTTP Range header, see https://www.w3.org/Protocols/rfc2616/rfc2616-sec14.html#sec14.35", "").(string)
 
-	c.parallelism = params.Flag("parallelism", "Controls how many parallel parts to upload/download from a file", 1,
+	c.parallelism = params.Flag("parallelism", "Controls how many parallel parts to upload/download from a file or standard input/output", 1,
 		clingy.Short('p'),
 		clingy.Transform(strconv.Atoi),
 		clingy.Transform(func(n int) (int, error) {
@@ -94,7 +94,7 @@ func (c *cmdCp) Setup(params clingy.Parameters) {
 			return n, nil
 		}),
 	).(int)
-	c.parallelismChunkSize = params.Flag("parallelism-chunk-size", "Set the size of the parts for parallelism, 0 means automatic adjustment", memory.Size(0),
+	c.parallelismChunkSize = params.Flag("parallelism-chunk-size", "Set the size of the parts for parallelism, 0 means automatic adjustment (1GiB when streaming from standard input)", memory.Size(0),
 		clingy.Transform(memory.ParseString),
 		clingy.Transform(func(n int64) (memory.Size, error) {
 			if n < 0 {
@@ -596,6 +596,16 @@ func (c *cmdCp) parallelCopy(
 			break
 		}
 
+		// the length of standard input isn't known upfront, so we only notice that it doesn't
+		// fit into the allowed number of parts when there is still data after the last one.
+		if source.Std() && int64(i) >= maxPartCount {
+			_ = rh.Close()
+
+			addError(errs.New("input is larger than %d parts of %s, use a larger --parallelism-chunk-size",
+				maxPartCount, memory.FormatBytes(chunkSize)))
+			break
+		}
+
 		if i == 0 && bar != nil {
 			info, err := src.Info(ctx)
 			if err == nil {
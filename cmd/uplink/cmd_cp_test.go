@@ -554,6 +554,13 @@ func TestCpStandard(t *testing.T) {
 		)
 	})
 
+	t.Run("StdinToRemoteParallel", func(t *testing.T) {
+		state.Succeed(t, "cp", "-", "sj://user/bar", "--parallelism", "4", "--parallelism-chunk-size", "64MiB").RequireRemoteFiles(t,
+			ultest.File{Loc: "sj://user/foo"},
+			ultest.File{Loc: "sj://user/bar", Contents: "-"},
+		)
+	})
+
 	t.Run("StdinToLocal", func(t *testing.T) {
 		state.Fail(t, "cp", "-", "/home/user/bar").RequireLocalFiles(t,
 			ultest.File{Loc: "/home/user/foo"},
@@ -573,6 +580,13 @@ func TestCpStandard(t *testing.T) {
 		)
 	})
 
+	t.Run("RemoteToStdoutParallel", func(t *testing.T) {
+		state.Succeed(t, "cp", "sj://user/foo", "-", "--parallelism", "4").RequireFiles(t,
+			ultest.File{Loc: "sj://user/foo"},
+			ultest.File{Loc: "/home/user/foo"},
+		)
+	})
+
 	t.Run("LocalToStdout", func(t *testing.T) {
 		state.Fail(t, "cp", "/home/user/foo", "-").RequireFiles(t,
 			ultest.File{Loc: "sj://user/foo"},
@@ -0,0 +1,72 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package maintenance implements the maintenance windows which storage nodes
+// announce to the satellites.
+package maintenance
+
+import (
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// Tag is the name of the node tag which holds the maintenance window declared
+// by the node operator. The value is formatted as <start>/<end> in RFC3339.
+const Tag = "maintenance-window"
+
+// Error is the error class for maintenance windows.
+var Error = errs.Class("maintenance window")
+
+// Window is a period during which the node is expected to be offline.
+type Window struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Parse parses a maintenance window formatted as <start>/<end> in RFC3339.
+func Parse(value string) (Window, error) {
+	start, end, ok := strings.Cut(value, "/")
+	if !ok {
+		return Window{}, Error.New("invalid value %q: expected <start>/<end>", value)
+	}
+
+	var window Window
+	var err error
+	window.Start, err = time.Parse(time.RFC3339, start)
+	if err != nil {
+		return Window{}, Error.New("invalid start: %v", err)
+	}
+	window.End, err = time.Parse(time.RFC3339, end)
+	if err != nil {
+		return Window{}, Error.New("invalid end: %v", err)
+	}
+	if !window.End.After(window.Start) {
+		return Window{}, Error.New("invalid value %q: end must be after start", value)
+	}
+	return window, nil
+}
+
+// String returns the tag value representation of the maintenance window.
+func (window Window) String() string {
+	return window.Start.Format(time.RFC3339) + "/" + window.End.Format(time.RFC3339)
+}
+
+// Limit returns the maintenance window shortened to the maximum duration.
+func (window Window) Limit(maxDuration time.Duration) Window {
+	if window.End.Sub(window.Start) > maxDuration {
+		window.End = window.Start.Add(maxDuration)
+	}
+	return window
+}
+
+// Contains returns whether the time is within the maintenance window.
+func (window Window) Contains(now time.Time) bool {
+	return !now.Before(window.Start) && now.Before(window.End)
+}
+
+// Equal returns whether both maintenance windows cover the same period.
+func (window Window) Equal(other Window) bool {
+	return window.Start.Equal(other.Start) && window.End.Equal(other.End)
+}
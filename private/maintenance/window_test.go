@@ -0,0 +1,46 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package maintenance_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/private/maintenance"
+)
+
+func TestParse(t *testing.T) {
+	window, err := maintenance.Parse("2024-01-01T10:00:00Z/2024-01-01T12:00:00Z")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), window.Start)
+	require.Equal(t, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), window.End)
+	require.Equal(t, "2024-01-01T10:00:00Z/2024-01-01T12:00:00Z", window.String())
+
+	require.True(t, window.Contains(window.Start))
+	require.True(t, window.Contains(window.Start.Add(time.Hour)))
+	require.False(t, window.Contains(window.End))
+	require.False(t, window.Contains(window.Start.Add(-time.Second)))
+
+	limited := window.Limit(time.Hour)
+	require.Equal(t, window.Start.Add(time.Hour), limited.End)
+	require.Equal(t, window, window.Limit(24*time.Hour))
+
+	shifted, err := maintenance.Parse("2024-01-01T12:00:00+02:00/2024-01-01T14:00:00+02:00")
+	require.NoError(t, err)
+	require.True(t, window.Equal(shifted))
+	require.False(t, window.Equal(limited))
+
+	for _, invalid := range []string{
+		"",
+		"2024-01-01T10:00:00Z",
+		"2024-01-01/2024-01-02",
+		"2024-01-01T12:00:00Z/2024-01-01T10:00:00Z",
+		"2024-01-01T10:00:00Z/2024-01-01T10:00:00Z",
+	} {
+		_, err := maintenance.Parse(invalid)
+		require.Error(t, err, invalid)
+	}
+}
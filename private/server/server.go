@@ -36,8 +36,8 @@ import (
 	"storj.io/drpc/drpcmux"
 	"storj.io/drpc/drpcserver"
 	"storj.io/drpc/drpcstats"
-	jaeger "storj.io/monkit-jaeger"
 	"storj.io/storj/private/server/debounce"
+	"storj.io/storj/private/tracing"
 )
 
 const (
@@ -500,7 +500,7 @@ func newEndpointCollection() *endpointCollection {
 			experiment.NewHandler(
 				rpctracing.NewHandler(
 					mux,
-					jaeger.RemoteTraceHandler),
+					tracing.RemoteTraceHandler),
 			),
 			drpcserver.Options{
 				Manager:      rpc.NewDefaultManagerOptions(),
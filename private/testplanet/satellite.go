@@ -446,6 +446,7 @@ func (planet *Planet) newSatellite(ctx context.Context, prefix string, index int
 	config.Reputation.AuditHistory.OfflineDQEnabled = false
 	config.Server.Config.Extensions.Revocation = false
 	config.Orders.OrdersSemaphoreSize = 0
+	config.Orders.SettlementQueuePerNode = 0
 	config.Checker.NodeFailureRate = 0
 	config.Audit.MaxRetriesStatDB = 0
 	config.GarbageCollection.RetainSendTimeout = 0
@@ -503,10 +504,14 @@ func (planet *Planet) newSatellite(ctx context.Context, prefix string, index int
 	}
 
 	metabaseDB, err := satellitedbtest.CreateMetabaseDB(context.TODO(), log.Named("metabase"), planet.config.Name, "M", index, databases.MetabaseDB, metabase.Config{
-		ApplicationName:  "satellite-testplanet",
-		MinPartSize:      config.Metainfo.MinPartSize,
-		MaxNumberOfParts: config.Metainfo.MaxNumberOfParts,
-		ServerSideCopy:   config.Metainfo.ServerSideCopy,
+		ApplicationName:                  "satellite-testplanet",
+		MinPartSize:                      config.Metainfo.MinPartSize,
+		MaxNumberOfParts:                 config.Metainfo.MaxNumberOfParts,
+		ServerSideCopy:                   config.Metainfo.ServerSideCopy,
+		DeferredSegmentDeletionThreshold: config.Metainfo.DeferredSegmentDeletionThreshold,
+		DeleteRateLimit:                  config.Metainfo.Metabase("").DeleteRateLimit,
+		LongQueryThreshold:               config.Metainfo.LongQueryThreshold,
+		MetadataEncryption:               config.Metainfo.Metabase("").MetadataEncryption,
 	})
 	if err != nil {
 		return nil, errs.Wrap(err)
@@ -272,7 +272,7 @@ func (planet *Planet) newStorageNode(ctx context.Context, prefix string, index,
 	}
 	planet.databases = append(planet.databases, revocationDB)
 
-	peer, err := storagenode.New(log, identity, db, revocationDB, config, verisonInfo, nil)
+	peer, err := storagenode.New(log, identity, db, revocationDB, config, verisonInfo, nil, nil)
 	if err != nil {
 		return nil, errs.Wrap(err)
 	}
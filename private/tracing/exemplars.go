@@ -0,0 +1,154 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+
+	"storj.io/common/rpc/rpctracing"
+)
+
+// ExemplarsConfig configures the trace exemplars.
+type ExemplarsConfig struct {
+	Enabled      bool          `help:"attach the trace ids of slow sampled requests to the latency metrics" default:"false"`
+	Threshold    time.Duration `help:"the minimum duration of a span to be recorded as an exemplar" default:"1s"`
+	Scopes       []string      `help:"the monkit scopes whose spans are recorded as exemplars" default:"storj.io/storj/satellite/metainfo,storj.io/storj/satellite/metabase,storj.io/storj/satellite/orders"`
+	LinkTemplate string        `help:"the link to a trace in the tracing UI, where %s is replaced with the trace id, e.g. https://jaeger.example.test/trace/%s" default:""`
+}
+
+// Exemplar is a slow span of a sampled trace.
+type Exemplar struct {
+	Function string
+	TraceID  int64
+	SpanID   int64
+	Duration time.Duration
+	Finished time.Time
+}
+
+// Exemplars records the latest slow span of every function as an exemplar,
+// and reports them next to the latency metrics of the functions, so a slow
+// request can be looked up in the tracing UI.
+//
+// Only the spans of sampled traces are recorded, as only those are sent to
+// the tracing collector.
+//
+// architecture: Service
+type Exemplars struct {
+	registry *monkit.Registry
+	config   ExemplarsConfig
+
+	mu     sync.Mutex
+	latest map[string]Exemplar
+}
+
+// NewExemplars creates a new exemplar recorder, which reports the exemplars
+// in the metrics of the registry.
+func NewExemplars(registry *monkit.Registry, config ExemplarsConfig) *Exemplars {
+	exemplars := &Exemplars{
+		registry: registry,
+		config:   config,
+		latest:   map[string]Exemplar{},
+	}
+	registry.ScopeNamed(mon.Name()).Chain(exemplars)
+	return exemplars
+}
+
+// Run records the exemplars until the context is canceled.
+func (exemplars *Exemplars) Run(ctx context.Context) error {
+	cancel := exemplars.registry.ObserveTraces(func(trace *monkit.Trace) {
+		trace.ObserveSpans(exemplars)
+	})
+	defer cancel()
+
+	<-ctx.Done()
+	return nil
+}
+
+// Start implements monkit.SpanObserver.
+func (exemplars *Exemplars) Start(span *monkit.Span) {}
+
+// Finish implements monkit.SpanObserver.
+func (exemplars *Exemplars) Finish(span *monkit.Span, err error, panicked bool, finish time.Time) {
+	duration := finish.Sub(span.Start())
+	if duration < exemplars.config.Threshold {
+		return
+	}
+	if sampled, _ := span.Trace().Get(rpctracing.Sampled).(bool); !sampled {
+		return
+	}
+	if !exemplars.inScope(span.Func().Scope().Name()) {
+		return
+	}
+
+	function := span.Func().FullName()
+
+	exemplars.mu.Lock()
+	defer exemplars.mu.Unlock()
+
+	exemplars.latest[function] = Exemplar{
+		Function: function,
+		TraceID:  span.Trace().Id(),
+		SpanID:   span.Id(),
+		Duration: duration,
+		Finished: finish,
+	}
+}
+
+func (exemplars *Exemplars) inScope(scope string) bool {
+	if len(exemplars.config.Scopes) == 0 {
+		return true
+	}
+	for _, prefix := range exemplars.config.Scopes {
+		if strings.HasPrefix(scope, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns the latest exemplar of every function, ordered by the function.
+func (exemplars *Exemplars) List() []Exemplar {
+	exemplars.mu.Lock()
+	defer exemplars.mu.Unlock()
+
+	list := make([]Exemplar, 0, len(exemplars.latest))
+	for _, exemplar := range exemplars.latest {
+		list = append(list, exemplar)
+	}
+	sort.Slice(list, func(i, k int) bool {
+		return list[i].Function < list[k].Function
+	})
+	return list
+}
+
+// Link returns the link to the trace of the exemplar in the tracing UI, or
+// an empty string when the link template isn't configured.
+func (exemplars *Exemplars) Link(exemplar Exemplar) string {
+	if exemplars.config.LinkTemplate == "" {
+		return ""
+	}
+	return fmt.Sprintf(exemplars.config.LinkTemplate, FormatTraceID(exemplar.TraceID))
+}
+
+// Stats implements monkit.StatSource.
+func (exemplars *Exemplars) Stats(cb func(key monkit.SeriesKey, field string, val float64)) {
+	for _, exemplar := range exemplars.List() {
+		key := monkit.NewSeriesKey("trace_exemplar").
+			WithTag("function", exemplar.Function).
+			WithTag("trace_id", FormatTraceID(exemplar.TraceID))
+		if link := exemplars.Link(exemplar); link != "" {
+			key = key.WithTag("link", link)
+		}
+
+		cb(key, "duration", exemplar.Duration.Seconds())
+		cb(key, "finished", float64(exemplar.Finished.Unix()))
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package tracing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/rpc/rpctracing"
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/tracing"
+)
+
+func TestExemplars(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	registry := monkit.NewRegistry()
+	exemplars := tracing.NewExemplars(registry, tracing.ExemplarsConfig{
+		Threshold:    10 * time.Millisecond,
+		Scopes:       []string{"storj.io/storj/satellite/metainfo"},
+		LinkTemplate: "https://jaeger.example.test/trace/%s",
+	})
+
+	runCtx, cancel := context.WithCancel(ctx)
+	ctx.Go(func() error { return exemplars.Run(runCtx) })
+	defer cancel()
+
+	metainfo := registry.ScopeNamed("storj.io/storj/satellite/metainfo")
+	other := registry.ScopeNamed("storj.io/storj/satellite/gc")
+
+	call := func(scope *monkit.Scope, name string, traceID int64, sampled bool, duration time.Duration) {
+		trace := monkit.NewTrace(traceID)
+		trace.Set(rpctracing.Sampled, sampled)
+
+		callCtx := context.Background()
+		defer scope.FuncNamed(name).RemoteTrace(&callCtx, 1, trace)(nil)
+		time.Sleep(duration)
+	}
+
+	// wait until the traces are observed.
+	require.Eventually(t, func() bool {
+		call(metainfo, "BeginObject", 100, true, 20*time.Millisecond)
+		return len(exemplars.List()) > 0
+	}, 5*time.Second, time.Millisecond)
+
+	call(metainfo, "BeginObject", 101, true, 20*time.Millisecond)
+	call(metainfo, "CommitObject", 102, true, time.Millisecond)
+	call(metainfo, "DownloadObject", 103, false, 20*time.Millisecond)
+	call(other, "Collect", 104, true, 20*time.Millisecond)
+
+	// only the latest slow span of a sampled trace in the configured scopes is kept.
+	list := exemplars.List()
+	require.Len(t, list, 1)
+	require.Equal(t, "storj.io/storj/satellite/metainfo.BeginObject", list[0].Function)
+	require.Equal(t, int64(101), list[0].TraceID)
+	require.GreaterOrEqual(t, list[0].Duration, 20*time.Millisecond)
+
+	var stats []string
+	registry.Stats(func(key monkit.SeriesKey, field string, val float64) {
+		if key.Measurement == "trace_exemplar" && field == "duration" {
+			stats = append(stats, key.Tags.Get("function")+" "+key.Tags.Get("trace_id")+" "+key.Tags.Get("link"))
+		}
+	})
+	require.Equal(t, []string{
+		"storj.io/storj/satellite/metainfo.BeginObject 0000000000000065 https://jaeger.example.test/trace/0000000000000065",
+	}, stats)
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package tracing implements the trace propagation compatible with
+// OpenTelemetry and the trace exemplars of slow requests.
+package tracing
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/rpc/rpctracing"
+	jaeger "storj.io/monkit-jaeger"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the default error class for the package.
+	Error = errs.Class("tracing")
+)
+
+// TraceParent is the metadata key of the W3C trace context, which is sent by
+// the OpenTelemetry instrumented clients.
+const TraceParent = "traceparent"
+
+// sampledFlag is the flag of the W3C trace context set for sampled traces.
+const sampledFlag = 0x01
+
+// ParseTraceParent parses the W3C trace context, see
+// https://www.w3.org/TR/trace-context/#traceparent-header.
+//
+// Monkit and Jaeger use 64 bit trace IDs, so only the lower 64 bits of the
+// 128 bit trace ID are kept.
+func ParseTraceParent(value string) (traceID, parentID int64, sampled bool, err error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 {
+		return 0, 0, false, Error.New("invalid traceparent %q", value)
+	}
+
+	version, err := hex.DecodeString(parts[0])
+	if err != nil || len(version) != 1 || version[0] == 0xff {
+		return 0, 0, false, Error.New("invalid traceparent version %q", parts[0])
+	}
+	// the future versions may append fields, but version 00 has exactly four.
+	if version[0] == 0 && len(parts) != 4 {
+		return 0, 0, false, Error.New("invalid traceparent %q", value)
+	}
+
+	rawTraceID, err := hex.DecodeString(parts[1])
+	if err != nil || len(rawTraceID) != 16 {
+		return 0, 0, false, Error.New("invalid trace id %q", parts[1])
+	}
+	rawParentID, err := hex.DecodeString(parts[2])
+	if err != nil || len(rawParentID) != 8 {
+		return 0, 0, false, Error.New("invalid parent id %q", parts[2])
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil || len(flags) != 1 {
+		return 0, 0, false, Error.New("invalid trace flags %q", parts[3])
+	}
+
+	traceID = int64(binary.BigEndian.Uint64(rawTraceID[8:]))
+	if traceID == 0 {
+		traceID = int64(binary.BigEndian.Uint64(rawTraceID[:8]))
+	}
+	parentID = int64(binary.BigEndian.Uint64(rawParentID))
+	if traceID == 0 || parentID == 0 {
+		return 0, 0, false, Error.New("invalid traceparent %q: zero id", value)
+	}
+
+	return traceID, parentID, flags[0]&sampledFlag != 0, nil
+}
+
+// FormatTraceParent formats the W3C trace context of the span.
+func FormatTraceParent(traceID, spanID int64, sampled bool) string {
+	var flags byte
+	if sampled {
+		flags = sampledFlag
+	}
+	return fmt.Sprintf("00-%032x-%016x-%02x", uint64(traceID), uint64(spanID), flags)
+}
+
+// FormatTraceID formats the trace ID as it's shown by Jaeger.
+func FormatTraceID(traceID int64) string {
+	return fmt.Sprintf("%016x", uint64(traceID))
+}
+
+// RemoteTraceHandler returns the trace and the parent span ID of a request
+// from its metadata. It uses the W3C trace context, when the client sent one,
+// otherwise the metadata sent by the storj rpc clients.
+//
+// A trace which isn't sampled by the client may still be sampled by the
+// local sampling rate.
+func RemoteTraceHandler(metadata map[string]string) (trace *monkit.Trace, parentID int64) {
+	value, ok := metadata[TraceParent]
+	if !ok {
+		return jaeger.RemoteTraceHandler(metadata)
+	}
+
+	traceID, parentID, sampled, err := ParseTraceParent(value)
+	if err != nil {
+		mon.Event("invalid_traceparent")
+		return jaeger.RemoteTraceHandler(metadata)
+	}
+
+	trace = monkit.NewTrace(traceID)
+	if sampled {
+		trace.Set(rpctracing.Sampled, true)
+	}
+	return trace, parentID
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package tracing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/rpc/rpctracing"
+	"storj.io/storj/private/tracing"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	traceID, parentID, sampled, err := tracing.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	require.NoError(t, err)
+	require.Equal(t, int64(-0x5c316d62f1f1b8ca), traceID)
+	require.Equal(t, int64(0x00f067aa0ba902b7), parentID)
+	require.True(t, sampled)
+
+	_, _, sampled, err = tracing.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	require.NoError(t, err)
+	require.False(t, sampled)
+
+	// the future versions may have more fields.
+	_, _, _, err = tracing.ParseTraceParent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra")
+	require.NoError(t, err)
+
+	for _, invalid := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-xx",
+	} {
+		_, _, _, err := tracing.ParseTraceParent(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestFormatTraceParent(t *testing.T) {
+	value := tracing.FormatTraceParent(-2, 0x00f067aa0ba902b7, true)
+	require.Equal(t, "00-0000000000000000fffffffffffffffe-00f067aa0ba902b7-01", value)
+
+	traceID, parentID, sampled, err := tracing.ParseTraceParent(value)
+	require.NoError(t, err)
+	require.Equal(t, int64(-2), traceID)
+	require.Equal(t, int64(0x00f067aa0ba902b7), parentID)
+	require.True(t, sampled)
+
+	require.Equal(t, "fffffffffffffffe", tracing.FormatTraceID(-2))
+}
+
+func TestRemoteTraceHandler(t *testing.T) {
+	trace, parentID := tracing.RemoteTraceHandler(map[string]string{
+		tracing.TraceParent: tracing.FormatTraceParent(10, 20, true),
+	})
+	require.Equal(t, int64(10), trace.Id())
+	require.Equal(t, int64(20), parentID)
+	require.Equal(t, true, trace.Get(rpctracing.Sampled))
+
+	// the local sampling decides about the traces, which aren't sampled by the client.
+	trace, _ = tracing.RemoteTraceHandler(map[string]string{
+		tracing.TraceParent: tracing.FormatTraceParent(10, 20, false),
+	})
+	require.Nil(t, trace.Get(rpctracing.Sampled))
+
+	// the storj rpc metadata is used without the trace context.
+	trace, parentID = tracing.RemoteTraceHandler(map[string]string{
+		rpctracing.TraceID:  "30",
+		rpctracing.ParentID: "40",
+		rpctracing.Sampled:  "true",
+	})
+	require.Equal(t, int64(30), trace.Id())
+	require.Equal(t, int64(40), parentID)
+	require.Equal(t, true, trace.Get(rpctracing.Sampled))
+}
@@ -77,6 +77,7 @@ type ProjectUsage struct {
 	Egress       int64   `json:"egress"`
 	SegmentCount float64 `json:"segmentCount"`
 	ObjectCount  float64 `json:"objectCount"`
+	MetadataSize float64 `json:"metadataSize"`
 
 	Since  time.Time `json:"since"`
 	Before time.Time `json:"before"`
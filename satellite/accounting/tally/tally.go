@@ -34,6 +34,8 @@ type Config struct {
 
 	ListLimit          int           `help:"how many buckets to query in a batch" default:"2500"`
 	AsOfSystemInterval time.Duration `help:"as of system interval" releaseDefault:"-5m" devDefault:"-1us" testDefault:"-1us"`
+
+	UpdateBucketStats bool `help:"whether to refresh the bucket statistics in metabase with the collected bucket tallies" default:"true"`
 }
 
 // Service is the tally service for data stored on each storage node.
@@ -225,6 +227,7 @@ func (service *Service) Tally(ctx context.Context) (err error) {
 		// monAccounting.IntVal("bucket_remote_segments").Observe(bucket.RemoteSegments) //mon:locked
 
 		monAccounting.IntVal("bucket_bytes").Observe(bucket.Bytes()) //mon:locked
+		monAccounting.IntVal("bucket_metadata_size").Observe(bucket.MetadataSize)
 		// monAccounting.IntVal("bucket_inline_bytes").Observe(bucket.InlineBytes) //mon:locked
 		// monAccounting.IntVal("bucket_remote_bytes").Observe(bucket.RemoteBytes) //mon:locked
 		total.Combine(bucket)
@@ -233,6 +236,7 @@ func (service *Service) Tally(ctx context.Context) (err error) {
 	monAccounting.IntVal("total_segments").Observe(total.Segments()) //mon:locked
 	monAccounting.IntVal("total_bytes").Observe(total.Bytes())       //mon:locked
 	monAccounting.IntVal("total_pending_objects").Observe(total.PendingObjectCount)
+	monAccounting.IntVal("total_metadata_size").Observe(total.MetadataSize)
 
 	return errAtRest.Err()
 }
@@ -324,10 +328,46 @@ func (observer *BucketTallyCollector) fillBucketTallies(ctx context.Context, sta
 			bucket.PendingObjectCount = tally.PendingObjectCount
 		}
 
+		if observer.config.UpdateBucketStats {
+			observer.updateBucketStats(ctx, bucketLocations, tallies)
+		}
+
 		return nil
 	})
 }
 
+// updateBucketStats refreshes the bucket statistics in metabase. Buckets without
+// objects get empty statistics, so they can be told apart from the buckets
+// which weren't tallied yet.
+func (observer *BucketTallyCollector) updateBucketStats(ctx context.Context, bucketLocations []metabase.BucketLocation, tallies []metabase.BucketTally) {
+	defer mon.Task()(&ctx)(nil)
+
+	collected := make(map[metabase.BucketLocation]metabase.BucketTally, len(tallies))
+	for _, tally := range tallies {
+		collected[tally.BucketLocation] = tally
+	}
+
+	stats := make([]metabase.BucketTally, 0, len(bucketLocations))
+	for _, location := range bucketLocations {
+		tally, ok := collected[location]
+		if !ok {
+			tally.BucketLocation = location
+		}
+		stats = append(stats, tally)
+	}
+
+	err := observer.metabase.UpdateBucketStats(ctx, metabase.UpdateBucketStats{
+		From:      bucketLocations[0],
+		To:        bucketLocations[len(bucketLocations)-1],
+		Tallies:   stats,
+		UpdatedAt: observer.Now,
+	})
+	if err != nil {
+		// the bucket statistics are informational, they shouldn't fail the tally.
+		observer.Log.Warn("unable to update bucket stats", zap.Error(err))
+	}
+}
+
 // ensureBucket returns bucket corresponding to the passed in path.
 func (observer *BucketTallyCollector) ensureBucket(location metabase.BucketLocation) *accounting.BucketTally {
 	bucket, exists := observer.Bucket[location]
@@ -0,0 +1,288 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package usagewebhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/console"
+)
+
+var (
+	// Error is the standard error class for usage webhook errors.
+	Error = errs.Class("usage-webhook-chore")
+	mon   = monkit.Package()
+)
+
+const (
+	// SignatureHeader is the header containing the signature of the usage summary.
+	SignatureHeader = "Storj-Signature"
+	// WebhookIDHeader is the header containing the ID of the webhook.
+	WebhookIDHeader = "Storj-Webhook-Id"
+
+	day = 24 * time.Hour
+)
+
+// Config contains configurable values for the usage webhook chore.
+type Config struct {
+	Enabled      bool          `help:"whether to run this chore." default:"false"`
+	Interval     time.Duration `help:"how often to deliver the daily usage summaries of projects" default:"1h"`
+	Delay        time.Duration `help:"how long after the end of a day its usage is delivered, so late settled bandwidth and tallies are included" default:"6h"`
+	Timeout      time.Duration `help:"timeout of a single webhook request" default:"10s"`
+	MaxDays      int           `help:"maximum number of past days delivered to a webhook in a single run" default:"7"`
+	ListLimit    int           `help:"how many webhooks to process in a batch" default:"100"`
+	MaxBodyBytes int64         `help:"maximum number of response body bytes read from a webhook" default:"4096"`
+
+	AllowPrivateAddresses bool `help:"whether webhooks may be delivered to loopback, private or link-local addresses" default:"false" testDefault:"true"`
+}
+
+// Usage is the daily usage summary of a project which is posted to webhooks.
+type Usage struct {
+	WebhookID uuid.UUID `json:"webhookId"`
+	ProjectID uuid.UUID `json:"projectId"`
+	// Day is the UTC day the usage was accumulated in, formatted as YYYY-MM-DD.
+	Day    string    `json:"day"`
+	Since  time.Time `json:"since"`
+	Before time.Time `json:"before"`
+
+	StorageByteHours float64 `json:"storageByteHours"`
+	EgressBytes      int64   `json:"egressBytes"`
+	SegmentHours     float64 `json:"segmentHours"`
+	ObjectHours      float64 `json:"objectHours"`
+}
+
+// Chore posts the daily usage of projects to the webhooks subscribed to them.
+// Every summary is signed with the secret of the webhook, so the receiver can
+// verify it was sent by the satellite.
+//
+// architecture: Chore
+type Chore struct {
+	log        *zap.Logger
+	consoleDB  console.DB
+	accounting accounting.ProjectAccounting
+	client     *http.Client
+	config     Config
+
+	nowFn func() time.Time
+	Loop  *sync2.Cycle
+}
+
+// NewChore is a constructor for Chore.
+func NewChore(log *zap.Logger, consoleDB console.DB, projectAccounting accounting.ProjectAccounting, config Config) *Chore {
+	return &Chore{
+		log:        log,
+		consoleDB:  consoleDB,
+		accounting: projectAccounting,
+		client:     newClient(config),
+		config:     config,
+		nowFn:      time.Now,
+		Loop:       sync2.NewCycle(config.Interval),
+	}
+}
+
+// newClient returns the client posting to the webhooks. It doesn't follow
+// redirects and, unless private addresses are allowed, it refuses to connect
+// to addresses which aren't public. The check is done on the resolved address
+// when dialing, so a host name can't point the satellite to internal services.
+func newClient(config Config) *http.Client {
+	dialer := &net.Dialer{Timeout: config.Timeout}
+	if !config.AllowPrivateAddresses {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return Error.Wrap(err)
+			}
+			if ip := net.ParseIP(host); ip == nil || !console.IsPublicUsageWebhookIP(ip) {
+				return Error.New("webhook address %s is not public", host)
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would connect to the webhook instead of the dialer.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   config.Timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Run runs the chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, func(ctx context.Context) (err error) {
+		if err := chore.RunOnce(ctx); err != nil {
+			chore.log.Error("failed to deliver project usage webhooks", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// RunOnce delivers the usage of the completed days to all webhooks.
+func (chore *Chore) RunOnce(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// the last day which is complete, including the delay for late usage.
+	lastDay := chore.nowFn().UTC().Add(-chore.config.Delay).Truncate(day).Add(-day)
+
+	var cursor console.ProjectUsageWebhookCursor
+	for {
+		webhooks, err := chore.consoleDB.ProjectUsageWebhooks().List(ctx, cursor, chore.config.ListLimit)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		for i := range webhooks {
+			webhook := &webhooks[i]
+			if err := chore.deliver(ctx, webhook, lastDay); err != nil {
+				chore.log.Warn("failed to deliver project usage",
+					zap.Stringer("project", webhook.ProjectID),
+					zap.Stringer("webhook", webhook.ID),
+					zap.Error(err))
+				mon.Counter("usage_webhook_failed").Inc(1)
+			}
+		}
+
+		if len(webhooks) < chore.config.ListLimit {
+			return nil
+		}
+		last := webhooks[len(webhooks)-1]
+		cursor = console.ProjectUsageWebhookCursor{ProjectID: last.ProjectID, ID: last.ID}
+	}
+}
+
+// deliver posts the usage of every day up to lastDay which wasn't delivered
+// to the webhook yet. It stops at the first failure, the remaining days are
+// retried in the next run.
+func (chore *Chore) deliver(ctx context.Context, webhook *console.ProjectUsageWebhook, lastDay time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	first := webhook.CreatedAt.UTC().Truncate(day)
+	if webhook.LastSentDay != nil {
+		first = webhook.LastSentDay.UTC().Truncate(day).Add(day)
+	}
+	if earliest := lastDay.Add(-time.Duration(chore.config.MaxDays-1) * day); first.Before(earliest) {
+		first = earliest
+	}
+	if first.After(lastDay) {
+		return nil
+	}
+
+	project, err := chore.consoleDB.Projects().Get(ctx, webhook.ProjectID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	for since := first; !since.After(lastDay); since = since.Add(day) {
+		before := since.Add(day)
+
+		total, err := chore.accounting.GetProjectTotal(ctx, webhook.ProjectID, since, before)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		err = chore.post(ctx, webhook, Usage{
+			WebhookID:        webhook.ID,
+			ProjectID:        project.PublicID,
+			Day:              since.Format(time.DateOnly),
+			Since:            since,
+			Before:           before,
+			StorageByteHours: total.Storage,
+			EgressBytes:      total.Egress,
+			SegmentHours:     total.SegmentCount,
+			ObjectHours:      total.ObjectCount,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := chore.consoleDB.ProjectUsageWebhooks().UpdateLastSentDay(ctx, webhook.ProjectID, webhook.ID, since); err != nil {
+			return Error.Wrap(err)
+		}
+		mon.Counter("usage_webhook_delivered").Inc(1)
+	}
+	return nil
+}
+
+// post sends the signed usage summary to the webhook.
+func (chore *Chore) post(ctx context.Context, webhook *console.ProjectUsageWebhook, usage Usage) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	body, err := json.Marshal(usage)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookIDHeader, webhook.ID.String())
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, chore.nowFn(), body))
+
+	resp, err := chore.client.Do(req)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, chore.config.MaxBodyBytes))
+		err = errs.Combine(err, resp.Body.Close())
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return Error.New("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the signature header value of a usage summary, which has the
+// form "t=<unix timestamp>,v1=<hex encoded HMAC-SHA256>". The HMAC is computed
+// with the webhook secret over the timestamp, a dot, and the body, so receivers
+// can reject replayed requests.
+func Sign(secret []byte, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte(ts))
+	_, _ = mac.Write([]byte("."))
+	_, _ = mac.Write(body)
+
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// TestSetNow sets nowFn on chore for testing.
+func (chore *Chore) TestSetNow(f func() time.Time) {
+	chore.nowFn = f
+}
+
+// Close closes the chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package usagewebhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient(t *testing.T) {
+	ctx := context.Background()
+
+	var requests atomic.Int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer target.Close()
+
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer redirect.Close()
+
+	post := func(client *http.Client, url string) (int, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode, nil
+	}
+
+	t.Run("private addresses refused", func(t *testing.T) {
+		client := newClient(Config{Timeout: time.Second})
+		_, err := post(client, target.URL)
+		require.Error(t, err)
+		require.Zero(t, requests.Load())
+	})
+
+	t.Run("redirects not followed", func(t *testing.T) {
+		client := newClient(Config{Timeout: time.Second, AllowPrivateAddresses: true})
+		status, err := post(client, redirect.URL)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusTemporaryRedirect, status)
+		require.Zero(t, requests.Load())
+
+		status, err = post(client, target.URL)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, status)
+		require.EqualValues(t, 1, requests.Load())
+	})
+}
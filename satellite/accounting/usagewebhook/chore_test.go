@@ -0,0 +1,171 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package usagewebhook_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/accounting/usagewebhook"
+	"storj.io/storj/satellite/console"
+)
+
+func TestSign(t *testing.T) {
+	secret := []byte("secret")
+	body := []byte(`{"day":"2024-01-20"}`)
+	timestamp := time.Unix(1705708800, 0)
+
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte("1705708800." + string(body)))
+	expected := "t=1705708800,v1=" + hex.EncodeToString(mac.Sum(nil))
+
+	require.Equal(t, expected, usagewebhook.Sign(secret, timestamp, body))
+	require.NotEqual(t, expected, usagewebhook.Sign([]byte("other"), timestamp, body))
+	require.NotEqual(t, expected, usagewebhook.Sign(secret, timestamp.Add(time.Second), body))
+}
+
+func TestUsageWebhookChore(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.UsageWebhook.Enabled = true
+				config.UsageWebhook.MaxDays = 3
+				config.Console.UsageWebhooks.Enabled = true
+				config.Console.UsageWebhooks.MaxPerProject = 1
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+		chore := sat.Core.Accounting.UsageWebhookChore
+
+		chore.Loop.Pause()
+
+		type request struct {
+			signature string
+			body      []byte
+		}
+		var mu sync.Mutex
+		var requests []request
+		fail := false
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if fail {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			requests = append(requests, request{signature: r.Header.Get(usagewebhook.SignatureHeader), body: body})
+		}))
+		defer server.Close()
+
+		received := func() []request {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]request(nil), requests...)
+		}
+		setFail := func(value bool) {
+			mu.Lock()
+			defer mu.Unlock()
+			fail = value
+		}
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "user@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		project, err := sat.AddProject(ctx, user.ID, "test")
+		require.NoError(t, err)
+
+		userCtx, err := sat.UserContext(ctx, user.ID)
+		require.NoError(t, err)
+
+		_, err = service.CreateProjectUsageWebhook(userCtx, project.PublicID, "ftp://example.test")
+		require.True(t, console.ErrValidation.Has(err))
+
+		webhook, err := service.CreateProjectUsageWebhook(userCtx, project.PublicID, server.URL)
+		require.NoError(t, err)
+		secret, err := hex.DecodeString(webhook.Secret)
+		require.NoError(t, err)
+
+		_, err = service.CreateProjectUsageWebhook(userCtx, project.PublicID, server.URL)
+		require.True(t, console.ErrConflict.Has(err))
+
+		webhooks, err := service.GetProjectUsageWebhooks(userCtx, project.PublicID)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 1)
+		require.Equal(t, webhook.ID, webhooks[0].ID)
+		require.Nil(t, webhooks[0].LastSentDay)
+
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		now := today.Add(5 * 24 * time.Hour).Add(12 * time.Hour)
+		chore.TestSetNow(func() time.Time { return now })
+
+		// the days since the creation are limited by MaxDays.
+		require.NoError(t, chore.RunOnce(ctx))
+		require.Len(t, received(), 3)
+
+		for i, req := range received() {
+			require.Equal(t, usagewebhook.Sign(secret, now, req.body), req.signature)
+
+			var usage usagewebhook.Usage
+			require.NoError(t, json.Unmarshal(req.body, &usage))
+			require.Equal(t, webhook.ID, usage.WebhookID)
+			require.Equal(t, project.PublicID, usage.ProjectID)
+
+			day := today.Add(time.Duration(2+i) * 24 * time.Hour)
+			require.Equal(t, day.Format(time.DateOnly), usage.Day)
+			require.True(t, day.Equal(usage.Since))
+			require.True(t, day.Add(24*time.Hour).Equal(usage.Before))
+		}
+
+		webhooks, err = service.GetProjectUsageWebhooks(userCtx, project.PublicID)
+		require.NoError(t, err)
+		require.NotNil(t, webhooks[0].LastSentDay)
+		require.True(t, today.Add(4*24*time.Hour).Equal(*webhooks[0].LastSentDay))
+
+		// delivered days aren't sent again.
+		require.NoError(t, chore.RunOnce(ctx))
+		require.Len(t, received(), 3)
+
+		// failed deliveries are retried in the next run.
+		now = now.Add(24 * time.Hour)
+		setFail(true)
+		require.NoError(t, chore.RunOnce(ctx))
+		require.Len(t, received(), 3)
+
+		setFail(false)
+		require.NoError(t, chore.RunOnce(ctx))
+		require.Len(t, received(), 4)
+		require.Contains(t, string(received()[3].body), today.Add(5*24*time.Hour).Format(time.DateOnly))
+
+		require.NoError(t, service.DeleteProjectUsageWebhook(userCtx, project.PublicID, webhook.ID))
+		require.True(t, console.ErrNoUsageWebhook.Has(service.DeleteProjectUsageWebhook(userCtx, project.PublicID, webhook.ID)))
+
+		webhooks, err = service.GetProjectUsageWebhooks(userCtx, project.PublicID)
+		require.NoError(t, err)
+		require.Empty(t, webhooks)
+	})
+}
@@ -0,0 +1,183 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package warehouse
+
+import (
+	"context"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+)
+
+// Chore exports the finalized hourly rollups and the storage tallies to a data
+// warehouse.
+//
+// Every stream has a marker of the time until which it was exported. The
+// rollups are exported in hourly batches in order and the marker is moved
+// after the sink stored a batch, hence every batch is stored once. When the
+// marker can't be moved, the batch is exported again in the next run with the
+// same ID, so the sink can drop it.
+//
+// architecture: Chore
+type Chore struct {
+	log    *zap.Logger
+	db     DB
+	sink   Sink
+	config Config
+
+	nowFn func() time.Time
+	Loop  *sync2.Cycle
+}
+
+// NewChore is a constructor for Chore.
+func NewChore(log *zap.Logger, db DB, sink Sink, config Config) *Chore {
+	return &Chore{
+		log:    log,
+		db:     db,
+		sink:   sink,
+		config: config,
+		nowFn:  time.Now,
+		Loop:   sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run runs the chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, func(ctx context.Context) (err error) {
+		if err := chore.RunOnce(ctx); err != nil {
+			chore.log.Error("failed to export rollups to the warehouse", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// RunOnce exports the finalized rollups of all streams which weren't exported yet.
+func (chore *Chore) RunOnce(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// the rollups of the hours before finalized don't change anymore.
+	finalized := chore.nowFn().UTC().Add(-chore.config.FinalizedAfter).Truncate(time.Hour)
+
+	var group errs.Group
+	for _, stream := range Streams {
+		if err := chore.export(ctx, stream, finalized); err != nil {
+			chore.log.Warn("failed to export rollups",
+				zap.String("stream", string(stream)),
+				zap.Error(err))
+			mon.Counter("warehouse_export_failed").Inc(1)
+			group.Add(err)
+		}
+	}
+	return group.Err()
+}
+
+// export exports the batches of the stream up to finalized. It stops at the
+// first failure, the remaining batches are exported in the next run.
+func (chore *Chore) export(ctx context.Context, stream Stream, finalized time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	marker, err := chore.db.GetMarker(ctx, stream)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	since := marker.UTC()
+	if marker.IsZero() {
+		since = finalized.Add(-chore.config.Backfill).Truncate(time.Hour)
+	}
+
+	for i := 0; i < chore.config.MaxBatches && since.Before(finalized); i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		before := since.Add(time.Hour)
+		batch, err := chore.read(ctx, stream, since, before)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		// empty hours only move the marker, the warehouse can't be queried
+		// for the missing rows anyway.
+		if len(batch.Rows) > 0 {
+			if err := chore.sink.Export(ctx, batch); err != nil {
+				return Error.Wrap(err)
+			}
+		}
+
+		if err := chore.db.SetMarker(ctx, stream, marker, before); err != nil {
+			return err
+		}
+		mon.Counter("warehouse_export_rows", monkit.NewSeriesTag("stream", string(stream))).Inc(int64(len(batch.Rows)))
+
+		marker, since = before, before
+	}
+	return nil
+}
+
+// read reads the rollups or tallies of the stream in [since, before).
+func (chore *Chore) read(ctx context.Context, stream Stream, since, before time.Time) (batch Batch, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	batch = Batch{
+		ID:     BatchID(stream, since),
+		Stream: stream,
+		Since:  since,
+		Before: before,
+	}
+
+	switch stream {
+	case StreamBucketBandwidth:
+		rollups, err := chore.db.BucketBandwidthRollups(ctx, since, before)
+		if err != nil {
+			return Batch{}, err
+		}
+		for _, rollup := range rollups {
+			batch.Rows = append(batch.Rows, rollup)
+		}
+	case StreamStoragenodeBandwidth:
+		rollups, err := chore.db.StoragenodeBandwidthRollups(ctx, since, before)
+		if err != nil {
+			return Batch{}, err
+		}
+		for _, rollup := range rollups {
+			batch.Rows = append(batch.Rows, rollup)
+		}
+	case StreamBucketStorage:
+		tallies, err := chore.db.BucketStorageTallies(ctx, since, before)
+		if err != nil {
+			return Batch{}, err
+		}
+		for _, tally := range tallies {
+			batch.Rows = append(batch.Rows, tally)
+		}
+	case StreamStoragenodeStorage:
+		tallies, err := chore.db.StoragenodeStorageTallies(ctx, since, before)
+		if err != nil {
+			return Batch{}, err
+		}
+		for _, tally := range tallies {
+			batch.Rows = append(batch.Rows, tally)
+		}
+	default:
+		return Batch{}, Error.New("unknown stream %q", stream)
+	}
+	return batch, nil
+}
+
+// TestSetNow sets the function which returns the current time.
+func (chore *Chore) TestSetNow(nowFn func() time.Time) {
+	chore.nowFn = nowFn
+}
+
+// Close stops the chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
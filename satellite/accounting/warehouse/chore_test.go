@@ -0,0 +1,147 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package warehouse_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/accounting/warehouse"
+	"storj.io/storj/satellite/metabase"
+)
+
+func TestWarehouseExportChore(t *testing.T) {
+	dir := t.TempDir()
+
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.WarehouseExport.Enabled = true
+				config.WarehouseExport.Sink = warehouse.SinkFile
+				config.WarehouseExport.Endpoint = dir
+				config.WarehouseExport.FinalizedAfter = 48 * time.Hour
+				config.WarehouseExport.Backfill = 3 * time.Hour
+				config.WarehouseExport.MaxBatches = 2
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		db := sat.DB.WarehouseExports()
+		chore := sat.Core.Accounting.WarehouseExportChore
+
+		chore.Loop.Pause()
+
+		now := time.Date(2024, 1, 22, 12, 30, 0, 0, time.UTC)
+		chore.TestSetNow(func() time.Time { return now })
+
+		// the finalized hours are [09:00, 12:00) of the 20th.
+		hour := time.Date(2024, 1, 20, 9, 0, 0, 0, time.UTC)
+		projectID := testrand.UUID()
+		nodeID := testrand.NodeID()
+		for i := 0; i < 4; i++ {
+			intervalStart := hour.Add(time.Duration(i) * time.Hour)
+			require.NoError(t, sat.DB.Orders().UpdateBucketBandwidthSettle(ctx, projectID, []byte("bucket"), pb.PieceAction_GET, 100, 0, intervalStart))
+			require.NoError(t, sat.DB.Orders().UpdateStoragenodeBandwidthSettle(ctx, nodeID, pb.PieceAction_GET, 100, intervalStart))
+		}
+
+		// the tallies are exported by their interval, the same way as the rollups.
+		bucket := metabase.BucketLocation{ProjectID: projectID, BucketName: "bucket"}
+		for i := 0; i < 4; i++ {
+			tallyTime := hour.Add(time.Duration(i)*time.Hour + 30*time.Minute)
+			require.NoError(t, sat.DB.ProjectAccounting().SaveTallies(ctx, tallyTime, map[metabase.BucketLocation]*accounting.BucketTally{
+				bucket: {BucketLocation: bucket, ObjectCount: 1, TotalSegments: 2, TotalBytes: 300, MetadataSize: 10},
+			}))
+			require.NoError(t, sat.DB.StoragenodeAccounting().SaveTallies(ctx, tallyTime, []storj.NodeID{nodeID}, []float64{1000}))
+		}
+
+		exported := func(stream warehouse.Stream) []string {
+			entries, err := os.ReadDir(filepath.Join(dir, string(stream)))
+			if os.IsNotExist(err) {
+				return nil
+			}
+			require.NoError(t, err)
+
+			var names []string
+			for _, entry := range entries {
+				names = append(names, entry.Name())
+			}
+			return names
+		}
+
+		// the batches are exported in order, up to MaxBatches in a run.
+		require.NoError(t, chore.RunOnce(ctx))
+		require.Equal(t, []string{
+			warehouse.BatchID(warehouse.StreamBucketBandwidth, hour) + ".ndjson",
+			warehouse.BatchID(warehouse.StreamBucketBandwidth, hour.Add(time.Hour)) + ".ndjson",
+		}, exported(warehouse.StreamBucketBandwidth))
+		require.Len(t, exported(warehouse.StreamStoragenodeBandwidth), 2)
+		require.Len(t, exported(warehouse.StreamBucketStorage), 2)
+		require.Len(t, exported(warehouse.StreamStoragenodeStorage), 2)
+
+		marker, err := db.GetMarker(ctx, warehouse.StreamBucketBandwidth)
+		require.NoError(t, err)
+		require.Equal(t, hour.Add(2*time.Hour), marker)
+
+		// the last finalized hour is exported in the next run, the hour which
+		// isn't finalized yet is skipped.
+		require.NoError(t, chore.RunOnce(ctx))
+		require.Len(t, exported(warehouse.StreamBucketBandwidth), 3)
+		require.Len(t, exported(warehouse.StreamStoragenodeBandwidth), 3)
+		require.Len(t, exported(warehouse.StreamBucketStorage), 3)
+		require.Len(t, exported(warehouse.StreamStoragenodeStorage), 3)
+
+		marker, err = db.GetMarker(ctx, warehouse.StreamStoragenodeBandwidth)
+		require.NoError(t, err)
+		require.Equal(t, hour.Add(3*time.Hour), marker)
+
+		// the marker is moved only from its current position.
+		err = db.SetMarker(ctx, warehouse.StreamStoragenodeBandwidth, hour, hour.Add(4*time.Hour))
+		require.True(t, warehouse.ErrMarkerConflict.Has(err), err)
+		err = db.SetMarker(ctx, warehouse.StreamStoragenodeBandwidth, time.Time{}, hour.Add(4*time.Hour))
+		require.True(t, warehouse.ErrMarkerConflict.Has(err), err)
+
+		rollups, err := db.BucketBandwidthRollups(ctx, hour, hour.Add(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, []warehouse.BucketBandwidthRollup{{
+			ProjectID:     projectID,
+			BucketName:    "bucket",
+			IntervalStart: hour,
+			Action:        pb.PieceAction_GET.String(),
+			Settled:       100,
+		}}, rollups)
+
+		bucketTallies, err := db.BucketStorageTallies(ctx, hour, hour.Add(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, []warehouse.BucketStorageTally{{
+			ProjectID:         projectID,
+			BucketName:        "bucket",
+			IntervalStart:     hour.Add(30 * time.Minute),
+			TotalBytes:        300,
+			TotalSegmentCount: 2,
+			ObjectCount:       1,
+			MetadataSize:      10,
+		}}, bucketTallies)
+
+		nodeTallies, err := db.StoragenodeStorageTallies(ctx, hour, hour.Add(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, []warehouse.StoragenodeStorageTally{{
+			NodeID:          nodeID,
+			IntervalEndTime: hour.Add(30 * time.Minute),
+			DataTotal:       1000,
+		}}, nodeTallies)
+	})
+}
@@ -0,0 +1,224 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package warehouse
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SinkFile writes the batches as newline delimited JSON files into a
+	// directory, e.g. a stage loaded by Snowpipe or BigQuery load jobs.
+	SinkFile = "file"
+	// SinkWebhook posts the batches as JSON to the endpoint.
+	SinkWebhook = "webhook"
+	// SinkBigQuery streams the batches into the tables of a BigQuery dataset.
+	SinkBigQuery = "bigquery"
+
+	// IdempotencyKeyHeader is the header containing the ID of the batch posted to a webhook.
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// bigqueryMaxRows is the maximum number of rows inserted into BigQuery with a single request.
+	bigqueryMaxRows = 500
+
+	maxErrorBodyBytes = 4096
+	maxResponseBytes  = 1 << 20
+)
+
+// Sink stores the exported batches in the warehouse.
+type Sink interface {
+	// Export stores the batch. A batch may be exported more than once when
+	// the marker couldn't be updated after an export, the sink should use
+	// the ID of the batch to store it only once.
+	Export(ctx context.Context, batch Batch) error
+}
+
+// NewSink creates the sink configured by config.
+func NewSink(config Config) (Sink, error) {
+	endpoint := strings.TrimSuffix(config.Endpoint, "/")
+	if endpoint == "" {
+		return nil, Error.New("endpoint of sink %q is missing", config.Sink)
+	}
+
+	switch config.Sink {
+	case SinkFile:
+		return &fileSink{dir: endpoint}, nil
+	case SinkWebhook, SinkBigQuery:
+		return &httpSink{
+			client:    &http.Client{Timeout: config.Timeout},
+			endpoint:  endpoint,
+			authToken: config.AuthToken,
+			bigquery:  config.Sink == SinkBigQuery,
+		}, nil
+	default:
+		return nil, Error.New("unknown sink %q", config.Sink)
+	}
+}
+
+// fileSink writes every batch into its own file named by the ID of the batch,
+// so a batch exported again replaces the previous file.
+type fileSink struct {
+	dir string
+}
+
+// Export implements Sink.
+func (sink *fileSink) Export(ctx context.Context, batch Batch) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dir := filepath.Join(sink.dir, string(batch.Stream))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return Error.Wrap(err)
+	}
+
+	// the batch is written into a temporary file first, so a loader never
+	// sees a partially written batch.
+	tmp, err := os.CreateTemp(dir, "."+batch.ID+".*.tmp")
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	w := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(w)
+	for _, row := range batch.Rows {
+		if err := encoder.Encode(row); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return Error.Wrap(err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return Error.Wrap(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return Error.Wrap(err)
+	}
+
+	return Error.Wrap(os.Rename(tmp.Name(), filepath.Join(dir, batch.ID+".ndjson")))
+}
+
+// httpSink exports the batches with HTTP requests.
+type httpSink struct {
+	client    *http.Client
+	endpoint  string
+	authToken string
+	bigquery  bool
+}
+
+// Export implements Sink.
+func (sink *httpSink) Export(ctx context.Context, batch Batch) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !sink.bigquery {
+		body, err := json.Marshal(struct {
+			ID     string        `json:"id"`
+			Stream Stream        `json:"stream"`
+			Since  time.Time     `json:"since"`
+			Before time.Time     `json:"before"`
+			Rows   []interface{} `json:"rows"`
+		}{
+			ID:     batch.ID,
+			Stream: batch.Stream,
+			Since:  batch.Since.UTC(),
+			Before: batch.Before.UTC(),
+			Rows:   batch.Rows,
+		})
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		_, err = sink.post(ctx, sink.endpoint, batch.ID, body)
+		return err
+	}
+
+	// every row is inserted with an ID derived from the batch, which lets
+	// BigQuery drop the rows inserted again.
+	type insertRow struct {
+		InsertID string      `json:"insertId"`
+		JSON     interface{} `json:"json"`
+	}
+	requestURL := sink.endpoint + "/tables/" + string(batch.Stream) + "/insertAll"
+	for start := 0; start < len(batch.Rows); start += bigqueryMaxRows {
+		end := start + bigqueryMaxRows
+		if end > len(batch.Rows) {
+			end = len(batch.Rows)
+		}
+
+		rows := make([]insertRow, 0, end-start)
+		for i := start; i < end; i++ {
+			rows = append(rows, insertRow{
+				InsertID: batch.ID + "-" + strconv.Itoa(i),
+				JSON:     batch.Rows[i],
+			})
+		}
+
+		body, err := json.Marshal(struct {
+			Rows []insertRow `json:"rows"`
+		}{
+			Rows: rows,
+		})
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		response, err := sink.post(ctx, requestURL, batch.ID, body)
+		if err != nil {
+			return err
+		}
+
+		// BigQuery reports the rejected rows in a successful response.
+		var result struct {
+			InsertErrors []json.RawMessage `json:"insertErrors"`
+		}
+		if err := json.Unmarshal(response, &result); err != nil {
+			return Error.New("invalid response: %w", err)
+		}
+		if len(result.InsertErrors) > 0 {
+			return Error.New("%d rows were rejected: %s", len(result.InsertErrors), result.InsertErrors[0])
+		}
+	}
+	return nil
+}
+
+// post posts the body to the URL and returns the body of the response.
+func (sink *httpSink) post(ctx context.Context, requestURL, idempotencyKey string, body []byte) (_ []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyKeyHeader, idempotencyKey)
+	if sink.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+sink.authToken)
+	}
+
+	resp, err := sink.client.Do(req)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		details, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return nil, Error.New("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(details))
+	}
+
+	response, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	return response, Error.Wrap(err)
+}
@@ -0,0 +1,162 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package warehouse_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/accounting/warehouse"
+)
+
+func testBatch(rows int) warehouse.Batch {
+	since := time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC)
+	batch := warehouse.Batch{
+		ID:     warehouse.BatchID(warehouse.StreamStoragenodeBandwidth, since),
+		Stream: warehouse.StreamStoragenodeBandwidth,
+		Since:  since,
+		Before: since.Add(time.Hour),
+	}
+	for i := 0; i < rows; i++ {
+		batch.Rows = append(batch.Rows, warehouse.StoragenodeBandwidthRollup{
+			NodeID:        testrand.NodeID(),
+			IntervalStart: since,
+			Action:        "GET",
+			Settled:       int64(i),
+		})
+	}
+	return batch
+}
+
+func TestBatchID(t *testing.T) {
+	since := time.Date(2024, 1, 20, 10, 0, 0, 0, time.FixedZone("CET", 3600))
+	require.Equal(t, "bucket_bandwidth_rollups-2024012009", warehouse.BatchID(warehouse.StreamBucketBandwidth, since))
+}
+
+func TestNewSink(t *testing.T) {
+	_, err := warehouse.NewSink(warehouse.Config{Sink: warehouse.SinkFile})
+	require.Error(t, err)
+
+	_, err = warehouse.NewSink(warehouse.Config{Sink: "unknown", Endpoint: "http://localhost"})
+	require.Error(t, err)
+}
+
+func TestFileSink(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	dir := ctx.Dir("warehouse")
+	sink, err := warehouse.NewSink(warehouse.Config{Sink: warehouse.SinkFile, Endpoint: dir})
+	require.NoError(t, err)
+
+	batch := testBatch(3)
+	require.NoError(t, sink.Export(ctx, batch))
+	// exporting the batch again replaces the file.
+	require.NoError(t, sink.Export(ctx, batch))
+
+	entries, err := os.ReadDir(filepath.Join(dir, string(batch.Stream)))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, batch.ID+".ndjson", entries[0].Name())
+
+	data, err := os.ReadFile(filepath.Join(dir, string(batch.Stream), entries[0].Name()))
+	require.NoError(t, err)
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for _, row := range batch.Rows {
+		var got warehouse.StoragenodeBandwidthRollup
+		require.NoError(t, decoder.Decode(&got))
+		require.Equal(t, row, got)
+	}
+	require.False(t, decoder.More())
+}
+
+func TestWebhookSink(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	var keys []string
+	var body struct {
+		ID   string                                 `json:"id"`
+		Rows []warehouse.StoragenodeBandwidthRollup `json:"rows"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		keys = append(keys, r.Header.Get(warehouse.IdempotencyKeyHeader))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+	}))
+	defer server.Close()
+
+	sink, err := warehouse.NewSink(warehouse.Config{
+		Sink:      warehouse.SinkWebhook,
+		Endpoint:  server.URL,
+		AuthToken: "token",
+		Timeout:   time.Minute,
+	})
+	require.NoError(t, err)
+
+	batch := testBatch(2)
+	require.NoError(t, sink.Export(ctx, batch))
+	require.Equal(t, []string{batch.ID}, keys)
+	require.Equal(t, batch.ID, body.ID)
+	require.Len(t, body.Rows, 2)
+}
+
+func TestBigQuerySink(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	var paths []string
+	var insertIDs []string
+	reject := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+
+		var body struct {
+			Rows []struct {
+				InsertID string                               `json:"insertId"`
+				JSON     warehouse.StoragenodeBandwidthRollup `json:"json"`
+			} `json:"rows"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		for _, row := range body.Rows {
+			insertIDs = append(insertIDs, row.InsertID)
+		}
+
+		if reject {
+			_, _ = io.WriteString(w, `{"insertErrors":[{"index":0,"errors":[{"reason":"invalid"}]}]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"kind":"bigquery#tableDataInsertAllResponse"}`)
+	}))
+	defer server.Close()
+
+	sink, err := warehouse.NewSink(warehouse.Config{
+		Sink:     warehouse.SinkBigQuery,
+		Endpoint: server.URL + "/bigquery/v2/projects/finance/datasets/storj/",
+		Timeout:  time.Minute,
+	})
+	require.NoError(t, err)
+
+	batch := testBatch(501)
+	require.NoError(t, sink.Export(ctx, batch))
+
+	// the rows are inserted in chunks.
+	expectedPath := "/bigquery/v2/projects/finance/datasets/storj/tables/storagenode_bandwidth_rollups/insertAll"
+	require.Equal(t, []string{expectedPath, expectedPath}, paths)
+	require.Len(t, insertIDs, 501)
+	require.Equal(t, batch.ID+"-0", insertIDs[0])
+	require.Equal(t, batch.ID+"-500", insertIDs[500])
+
+	reject = true
+	require.Error(t, sink.Export(ctx, testBatch(1)))
+}
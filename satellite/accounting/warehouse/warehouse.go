@@ -0,0 +1,138 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package warehouse
+
+import (
+	"context"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the default error class for the package.
+	Error = errs.Class("warehouse export")
+	// ErrMarkerConflict is returned when the export marker was moved by somebody else.
+	ErrMarkerConflict = errs.Class("warehouse export marker conflict")
+)
+
+// Stream is a kind of rollups or tallies which is exported to the warehouse.
+type Stream string
+
+const (
+	// StreamBucketBandwidth is the stream of the hourly bucket bandwidth rollups.
+	StreamBucketBandwidth Stream = "bucket_bandwidth_rollups"
+	// StreamStoragenodeBandwidth is the stream of the hourly storage node bandwidth rollups.
+	StreamStoragenodeBandwidth Stream = "storagenode_bandwidth_rollups"
+	// StreamBucketStorage is the stream of the bucket storage tallies.
+	StreamBucketStorage Stream = "bucket_storage_tallies"
+	// StreamStoragenodeStorage is the stream of the storage node storage tallies.
+	StreamStoragenodeStorage Stream = "storagenode_storage_tallies"
+)
+
+// Streams are all streams exported to the warehouse.
+var Streams = []Stream{StreamBucketBandwidth, StreamStoragenodeBandwidth, StreamBucketStorage, StreamStoragenodeStorage}
+
+// Config contains configurable values for exporting the rollups to a data warehouse.
+type Config struct {
+	Enabled        bool          `help:"whether to export the finalized rollups to a data warehouse" default:"false"`
+	Interval       time.Duration `help:"how often the finalized rollups are exported" default:"1h"`
+	Sink           string        `help:"where the rollups are exported to: file, webhook or bigquery" default:"file"`
+	Endpoint       string        `help:"the directory of the file sink or the base URL of the webhook and bigquery sinks" default:""`
+	AuthToken      string        `help:"bearer token sent with the export requests" default:""`
+	Timeout        time.Duration `help:"timeout of a single export request" default:"1m"`
+	FinalizedAfter time.Duration `help:"how long after the end of an hour its rollups are final, must be longer than the expiration of the orders" default:"48h"`
+	Backfill       time.Duration `help:"how far back from the finalized rollups the export starts when a stream was never exported" default:"24h"`
+	MaxBatches     int           `help:"maximum number of hourly batches exported per stream in a single run" default:"24"`
+}
+
+// BucketBandwidthRollup is the bandwidth of a bucket for an action in an hour.
+type BucketBandwidthRollup struct {
+	ProjectID     uuid.UUID `json:"projectId"`
+	BucketName    string    `json:"bucketName"`
+	IntervalStart time.Time `json:"intervalStart"`
+	Action        string    `json:"action"`
+	Inline        int64     `json:"inline"`
+	Allocated     int64     `json:"allocated"`
+	Settled       int64     `json:"settled"`
+}
+
+// StoragenodeBandwidthRollup is the bandwidth of a storage node for an action in an hour.
+type StoragenodeBandwidthRollup struct {
+	NodeID        storj.NodeID `json:"nodeId"`
+	IntervalStart time.Time    `json:"intervalStart"`
+	Action        string       `json:"action"`
+	Allocated     int64        `json:"allocated"`
+	Settled       int64        `json:"settled"`
+}
+
+// BucketStorageTally is the data at rest of a bucket measured by a tally.
+type BucketStorageTally struct {
+	ProjectID          uuid.UUID `json:"projectId"`
+	BucketName         string    `json:"bucketName"`
+	IntervalStart      time.Time `json:"intervalStart"`
+	TotalBytes         int64     `json:"totalBytes"`
+	InlineBytes        int64     `json:"inlineBytes"`
+	RemoteBytes        int64     `json:"remoteBytes"`
+	TotalSegmentCount  int64     `json:"totalSegmentCount"`
+	InlineSegmentCount int64     `json:"inlineSegmentCount"`
+	RemoteSegmentCount int64     `json:"remoteSegmentCount"`
+	ObjectCount        int64     `json:"objectCount"`
+	MetadataSize       int64     `json:"metadataSize"`
+}
+
+// StoragenodeStorageTally is the data at rest of a storage node measured by a tally.
+type StoragenodeStorageTally struct {
+	NodeID          storj.NodeID `json:"nodeId"`
+	IntervalEndTime time.Time    `json:"intervalEndTime"`
+	// DataTotal is the estimated byte*hours stored on the node since the previous tally.
+	DataTotal float64 `json:"dataTotal"`
+}
+
+// Batch is the rollups or tallies of a stream for an hour.
+type Batch struct {
+	// ID identifies the batch. It's the same every time the batch is
+	// exported, so the sinks can drop the batches exported again.
+	ID     string
+	Stream Stream
+	Since  time.Time
+	Before time.Time
+
+	// Rows are the rollups or tallies of the batch, one of BucketBandwidthRollup,
+	// StoragenodeBandwidthRollup, BucketStorageTally or StoragenodeStorageTally
+	// depending on the stream.
+	Rows []interface{}
+}
+
+// BatchID returns the ID of the batch of the stream starting at since.
+func BatchID(stream Stream, since time.Time) string {
+	return string(stream) + "-" + since.UTC().Format("2006010215")
+}
+
+// DB stores the export markers and reads the rollups and tallies.
+//
+// architecture: Database
+type DB interface {
+	// GetMarker returns the time until which the stream was exported. It
+	// returns the zero time when the stream was never exported.
+	GetMarker(ctx context.Context, stream Stream) (time.Time, error)
+	// SetMarker moves the marker of the stream from previous to next. It
+	// returns ErrMarkerConflict when the marker isn't at previous anymore.
+	SetMarker(ctx context.Context, stream Stream, previous, next time.Time) error
+
+	// BucketBandwidthRollups returns the bucket bandwidth rollups of the hours in [since, before).
+	BucketBandwidthRollups(ctx context.Context, since, before time.Time) ([]BucketBandwidthRollup, error)
+	// StoragenodeBandwidthRollups returns the storage node bandwidth rollups of the hours in [since, before).
+	StoragenodeBandwidthRollups(ctx context.Context, since, before time.Time) ([]StoragenodeBandwidthRollup, error)
+	// BucketStorageTallies returns the bucket storage tallies started in [since, before).
+	BucketStorageTallies(ctx context.Context, since, before time.Time) ([]BucketStorageTally, error)
+	// StoragenodeStorageTallies returns the storage node storage tallies ended in [since, before).
+	StoragenodeStorageTallies(ctx context.Context, since, before time.Time) ([]StoragenodeStorageTally, error)
+}
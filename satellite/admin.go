@@ -16,6 +16,7 @@ import (
 
 	"storj.io/common/debug"
 	"storj.io/common/identity"
+	"storj.io/common/signing"
 	"storj.io/common/storj"
 	"storj.io/common/version"
 	"storj.io/storj/private/lifecycle"
@@ -24,6 +25,7 @@ import (
 	"storj.io/storj/satellite/admin"
 	backoffice "storj.io/storj/satellite/admin/back-office"
 	"storj.io/storj/satellite/analytics"
+	"storj.io/storj/satellite/bucketevents"
 	"storj.io/storj/satellite/buckets"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/console/restkeys"
@@ -31,6 +33,7 @@ import (
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/payments"
 	"storj.io/storj/satellite/payments/stripe"
+	"storj.io/storj/satellite/snopayouts"
 )
 
 // Admin is the satellite core process that runs chores.
@@ -106,6 +109,10 @@ func NewAdmin(log *zap.Logger, full *identity.FullIdentity, db DB, metabaseDB *m
 		Services: lifecycle.NewGroup(log.Named("services")),
 	}
 
+	if config.BucketEvents.Enabled {
+		metabaseDB.SetDeleteEventsFilter(bucketevents.NewDestinationCache(db.BucketEvents(), config.BucketEvents))
+	}
+
 	{
 		peer.Buckets.Service = buckets.NewService(db.Buckets(), metabaseDB)
 	}
@@ -275,6 +282,10 @@ func NewAdmin(log *zap.Logger, full *identity.FullIdentity, db DB, metabaseDB *m
 			peer.FreezeAccounts.Service,
 			peer.Analytics.Service,
 			peer.Payments.Accounts,
+			snopayouts.NewService(
+				log.Named("payouts:service"),
+				peer.DB.SNOPayouts(),
+				signing.SignerFromFullIdentity(peer.Identity)),
 			peer.Admin.Service,
 			config.Console,
 			adminConfig,
@@ -0,0 +1,153 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+)
+
+// defaultAbuseReportsLimit is the number of abuse reports returned when the limit isn't specified.
+const defaultAbuseReportsLimit = 100
+
+func (server *Server) listAbuseReports(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	status := console.AbuseReportPending
+	if statusParam := r.URL.Query().Get("status"); statusParam != "" {
+		var err error
+		status, err = console.AbuseReportStatusFromString(statusParam)
+		if err != nil {
+			sendJSONError(w, "invalid status",
+				err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	limit := defaultAbuseReportsLimit
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit <= 0 {
+			sendJSONError(w, "invalid limit",
+				"limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	reports, err := server.db.Console().AbuseReports().ListByStatus(ctx, status, limit)
+	if err != nil {
+		sendJSONError(w, "failed to list abuse reports",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(reports)
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) reviewAbuseReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idString, ok := mux.Vars(r)["id"]
+	if !ok {
+		sendJSONError(w, "report-id missing",
+			"", http.StatusBadRequest)
+		return
+	}
+
+	id, err := uuid.FromString(idString)
+	if err != nil {
+		sendJSONError(w, "invalid report-id",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		sendJSONError(w, "failed to read body",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var input struct {
+		Status string `json:"status"`
+	}
+	if err = json.Unmarshal(body, &input); err != nil {
+		sendJSONError(w, "failed to unmarshal request",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	status, err := console.AbuseReportStatusFromString(input.Status)
+	if err != nil || status == console.AbuseReportPending {
+		sendJSONError(w, "invalid status",
+			"status must be either accepted or rejected", http.StatusBadRequest)
+		return
+	}
+
+	report, err := server.db.Console().AbuseReports().Get(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		sendJSONError(w, "abuse report not found",
+			"", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		sendJSONError(w, "failed to get abuse report",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if report.Status != console.AbuseReportPending {
+		sendJSONError(w, "abuse report was already reviewed",
+			"status is "+report.Status.String(), http.StatusConflict)
+		return
+	}
+
+	reviewer := r.Header.Get("X-Forwarded-Email")
+	err = server.db.Console().AbuseReports().UpdateStatus(ctx, id, status, reviewer, time.Now())
+	if console.ErrAbuseReportReviewed.Has(err) {
+		sendJSONError(w, "abuse report was already reviewed",
+			err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		sendJSONError(w, "failed to update abuse report",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	server.log.Named("auditlog").Info("abuse report reviewed",
+		zap.Stringer("report", id),
+		zap.Stringer("status", status),
+		zap.String("reviewer", reviewer))
+
+	// the reported content was frozen when the report was submitted,
+	// hence it's only unfrozen when the report is dismissed.
+	if status == console.AbuseReportRejected {
+		err = console.NotifyAbuseReportWebhook(ctx, server.console.AbuseReports.WebhookURL, console.AbuseReportActionUnfreeze, *report)
+		if err != nil {
+			sendJSONError(w, "failed to unfreeze reported content",
+				err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
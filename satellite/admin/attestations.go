@@ -0,0 +1,254 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/nodeselection"
+	"storj.io/storj/satellite/overlay/attestation"
+)
+
+// nodeAttestation is a verified attestation of a node.
+type nodeAttestation struct {
+	Issuer   storj.NodeID `json:"issuer"`
+	Name     string       `json:"name"`
+	Value    string       `json:"value"`
+	SignedAt time.Time    `json:"signedAt"`
+}
+
+func (server *Server) listAttestationIssuers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	issuers, err := server.attestations.Issuers(ctx)
+	if err != nil {
+		sendJSONError(w, "failed to list attestation issuers",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if issuers == nil {
+		issuers = []attestation.Issuer{}
+	}
+
+	data, err := json.Marshal(issuers)
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) addAttestationIssuer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		sendJSONError(w, "failed to read body",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var input struct {
+		Name     string `json:"name"`
+		Identity string `json:"identity"`
+	}
+	if err = json.Unmarshal(body, &input); err != nil {
+		sendJSONError(w, "failed to unmarshal request",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	issuer, err := server.attestations.AddIssuer(ctx, input.Name, []byte(input.Identity))
+	if err != nil {
+		if attestation.ErrInvalid.Has(err) {
+			sendJSONError(w, "invalid attestation issuer",
+				err.Error(), http.StatusBadRequest)
+			return
+		}
+		sendJSONError(w, "failed to add attestation issuer",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	server.log.Named("auditlog").Info("attestation issuer added",
+		zap.Stringer("issuer", issuer.ID),
+		zap.String("name", issuer.Name),
+		zap.String("updater", r.Header.Get("X-Forwarded-Email")))
+
+	data, err := json.Marshal(issuer)
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) deleteAttestationIssuer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	issuerIDString, ok := mux.Vars(r)["issuer"]
+	if !ok {
+		sendJSONError(w, "issuer missing",
+			"", http.StatusBadRequest)
+		return
+	}
+
+	issuerID, err := storj.NodeIDFromString(issuerIDString)
+	if err != nil {
+		sendJSONError(w, "invalid issuer",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = server.attestations.RemoveIssuer(ctx, issuerID)
+	if err != nil {
+		if attestation.ErrIssuerNotFound.Has(err) {
+			sendJSONError(w, "attestation issuer not found",
+				"", http.StatusNotFound)
+			return
+		}
+		sendJSONError(w, "failed to delete attestation issuer",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	server.log.Named("auditlog").Info("attestation issuer deleted",
+		zap.Stringer("issuer", issuerID),
+		zap.String("updater", r.Header.Get("X-Forwarded-Email")))
+}
+
+func (server *Server) getNodeAttestations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	nodeID, ok := nodeIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	tags, err := server.attestations.NodeAttestations(ctx, nodeID)
+	if err != nil {
+		sendJSONError(w, "failed to get node attestations",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(toNodeAttestations(tags))
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) submitNodeAttestations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	nodeID, ok := nodeIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		sendJSONError(w, "failed to read body",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var input struct {
+		Attestations string `json:"attestations"`
+	}
+	if err = json.Unmarshal(body, &input); err != nil {
+		sendJSONError(w, "failed to unmarshal request",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(input.Attestations)
+	if err != nil {
+		sendJSONError(w, "attestations must be base64 encoded",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var attestations pb.SignedNodeTagSets
+	if err := pb.Unmarshal(raw, &attestations); err != nil {
+		sendJSONError(w, "invalid attestations",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tags, err := server.attestations.Submit(ctx, nodeID, &attestations)
+	if err != nil {
+		if attestation.ErrInvalid.Has(err) || attestation.ErrIssuerNotFound.Has(err) {
+			sendJSONError(w, "invalid attestations",
+				err.Error(), http.StatusBadRequest)
+			return
+		}
+		sendJSONError(w, "failed to submit attestations",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	server.log.Named("auditlog").Info("node attestations submitted",
+		zap.Stringer("node", nodeID),
+		zap.Int("tags", len(tags)),
+		zap.String("updater", r.Header.Get("X-Forwarded-Email")))
+
+	data, err := json.Marshal(toNodeAttestations(tags))
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+// nodeIDFromRequest returns the node ID of the request. It sends an error
+// response and returns false when it's missing or invalid.
+func nodeIDFromRequest(w http.ResponseWriter, r *http.Request) (storj.NodeID, bool) {
+	nodeIDString, ok := mux.Vars(r)["nodeid"]
+	if !ok {
+		sendJSONError(w, "node-id missing",
+			"", http.StatusBadRequest)
+		return storj.NodeID{}, false
+	}
+
+	nodeID, err := storj.NodeIDFromString(nodeIDString)
+	if err != nil {
+		sendJSONError(w, "invalid node-id",
+			err.Error(), http.StatusBadRequest)
+		return storj.NodeID{}, false
+	}
+	return nodeID, true
+}
+
+func toNodeAttestations(tags nodeselection.NodeTags) []nodeAttestation {
+	attestations := make([]nodeAttestation, 0, len(tags))
+	for _, tag := range tags {
+		attestations = append(attestations, nodeAttestation{
+			Issuer:   tag.Signer,
+			Name:     tag.Name,
+			Value:    string(tag.Value),
+			SignedAt: tag.SignedAt,
+		})
+	}
+	return attestations
+}
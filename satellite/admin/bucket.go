@@ -6,10 +6,12 @@ package admin
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/gorilla/mux"
 
+	"storj.io/common/memory"
 	"storj.io/common/storj"
 	"storj.io/common/uuid"
 	"storj.io/storj/satellite/buckets"
@@ -132,3 +134,197 @@ func (server *Server) getBucketInfo(w http.ResponseWriter, r *http.Request) {
 		sendJSONData(w, http.StatusOK, data)
 	}
 }
+
+func (server *Server) setBucketDeletionProtection(w http.ResponseWriter, r *http.Request, enabled bool) {
+	ctx := r.Context()
+
+	project, bucket, err := validateBucketPathParameters(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	err = server.buckets.SetBucketDeletionProtection(ctx, bucket, project.UUID, enabled)
+	if err != nil {
+		if buckets.ErrBucketNotFound.Has(err) {
+			sendJSONError(w, "bucket does not exist", "", http.StatusNotFound)
+		} else {
+			sendJSONError(w, "unable to update deletion protection of bucket", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (server *Server) enableBucketDeletionProtection(w http.ResponseWriter, r *http.Request) {
+	server.setBucketDeletionProtection(w, r, true)
+}
+
+func (server *Server) disableBucketDeletionProtection(w http.ResponseWriter, r *http.Request) {
+	server.setBucketDeletionProtection(w, r, false)
+}
+
+func (server *Server) setBucketDefaults(w http.ResponseWriter, r *http.Request, defaults buckets.Defaults) {
+	ctx := r.Context()
+
+	project, bucket, err := validateBucketPathParameters(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	err = server.buckets.SetBucketDefaults(ctx, bucket, project.UUID, defaults)
+	if err != nil {
+		if buckets.ErrBucketNotFound.Has(err) {
+			sendJSONError(w, "bucket does not exist", "", http.StatusNotFound)
+		} else {
+			sendJSONError(w, "unable to update defaults of bucket", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (server *Server) updateBucketDefaults(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		sendJSONError(w, "failed to read body", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var input struct {
+		SegmentSize string `json:"segmentSize"`
+		Redundancy  *struct {
+			ShareSize      int32 `json:"shareSize"`
+			RequiredShares int16 `json:"requiredShares"`
+			RepairShares   int16 `json:"repairShares"`
+			OptimalShares  int16 `json:"optimalShares"`
+			TotalShares    int16 `json:"totalShares"`
+		} `json:"redundancy"`
+	}
+	if err := json.Unmarshal(body, &input); err != nil {
+		sendJSONError(w, "failed to unmarshal request", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var defaults buckets.Defaults
+	if input.SegmentSize != "" {
+		var segmentSize memory.Size
+		if err := segmentSize.Set(input.SegmentSize); err != nil {
+			sendJSONError(w, "invalid segmentSize", err.Error(), http.StatusBadRequest)
+			return
+		}
+		defaults.SegmentSize = segmentSize.Int64()
+	}
+	if rs := input.Redundancy; rs != nil {
+		defaults.Redundancy = storj.RedundancyScheme{
+			Algorithm:      storj.ReedSolomon,
+			ShareSize:      rs.ShareSize,
+			RequiredShares: rs.RequiredShares,
+			RepairShares:   rs.RepairShares,
+			OptimalShares:  rs.OptimalShares,
+			TotalShares:    rs.TotalShares,
+		}
+	}
+
+	if defaults.IsZero() {
+		sendJSONError(w, "no defaults provided", "segmentSize or redundancy must be set", http.StatusBadRequest)
+		return
+	}
+	if err := defaults.Validate(); err != nil {
+		sendJSONError(w, "invalid defaults", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	server.setBucketDefaults(w, r, defaults)
+}
+
+func (server *Server) deleteBucketDefaults(w http.ResponseWriter, r *http.Request) {
+	server.setBucketDefaults(w, r, buckets.Defaults{})
+}
+
+func (server *Server) getBucketQuota(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	project, bucket, err := validateBucketPathParameters(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	exists, err := server.buckets.HasBucket(ctx, bucket, project.UUID)
+	if err != nil {
+		sendJSONError(w, "unable to check bucket", err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !exists {
+		sendJSONError(w, "bucket does not exist", "", http.StatusNotFound)
+		return
+	}
+
+	quota, err := server.buckets.GetBucketQuota(ctx, bucket, project.UUID)
+	if err != nil {
+		sendJSONError(w, "unable to get quota of bucket", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(quota)
+	if err != nil {
+		sendJSONError(w, "failed to marshal quota", err.Error(), http.StatusInternalServerError)
+	} else {
+		sendJSONData(w, http.StatusOK, data)
+	}
+}
+
+func (server *Server) setBucketQuota(w http.ResponseWriter, r *http.Request, quota buckets.Quota) {
+	ctx := r.Context()
+
+	project, bucket, err := validateBucketPathParameters(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	err = server.buckets.SetBucketQuota(ctx, bucket, project.UUID, quota)
+	if err != nil {
+		if buckets.ErrBucketNotFound.Has(err) {
+			sendJSONError(w, "bucket does not exist", "", http.StatusNotFound)
+		} else {
+			sendJSONError(w, "unable to update quota of bucket", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (server *Server) updateBucketQuota(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		sendJSONError(w, "failed to read body", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var quota buckets.Quota
+	if err := json.Unmarshal(body, &quota); err != nil {
+		sendJSONError(w, "failed to unmarshal request", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if quota.IsZero() {
+		sendJSONError(w, "no quota provided", "maxObjects or maxSegments must be set", http.StatusBadRequest)
+		return
+	}
+	if err := quota.Validate(); err != nil {
+		sendJSONError(w, "invalid quota", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	server.setBucketQuota(w, r, quota)
+}
+
+func (server *Server) deleteBucketQuota(w http.ResponseWriter, r *http.Request) {
+	server.setBucketQuota(w, r, buckets.Quota{})
+}
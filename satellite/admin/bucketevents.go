@@ -0,0 +1,123 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"storj.io/storj/satellite/bucketevents"
+	"storj.io/storj/satellite/buckets"
+)
+
+func (server *Server) getBucketEventDestination(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	project, bucket, err := validateBucketPathParameters(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	destination, err := server.bucketEvents.GetDestination(ctx, project.UUID, string(bucket))
+	if err != nil {
+		if bucketevents.ErrDestinationNotFound.Has(err) {
+			sendJSONError(w, "bucket has no event destination", "", http.StatusNotFound)
+			return
+		}
+		sendJSONError(w, "unable to get event destination of bucket", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(destination)
+	if err != nil {
+		sendJSONError(w, "json encoding failed", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) setBucketEventDestination(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	project, bucket, err := validateBucketPathParameters(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		sendJSONError(w, "failed to read body", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var input struct {
+		Topic string `json:"topic"`
+	}
+	if err := json.Unmarshal(body, &input); err != nil {
+		sendJSONError(w, "failed to unmarshal request", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, err := server.buckets.GetBucket(ctx, bucket, project.UUID); err != nil {
+		if buckets.ErrBucketNotFound.Has(err) {
+			sendJSONError(w, "bucket does not exist", "", http.StatusNotFound)
+		} else {
+			sendJSONError(w, "unable to check bucket", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	destination, err := server.bucketEvents.SetDestination(ctx, project.UUID, string(bucket), input.Topic)
+	if err != nil {
+		if bucketevents.ErrInvalid.Has(err) {
+			sendJSONError(w, "invalid event destination", err.Error(), http.StatusBadRequest)
+			return
+		}
+		sendJSONError(w, "unable to set event destination of bucket", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	server.log.Named("auditlog").Info("bucket event destination set",
+		zap.Stringer("project", project.UUID),
+		zap.String("bucket", string(bucket)),
+		zap.String("topic", destination.Topic),
+		zap.String("updater", r.Header.Get("X-Forwarded-Email")))
+
+	data, err := json.Marshal(destination)
+	if err != nil {
+		sendJSONError(w, "json encoding failed", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) deleteBucketEventDestination(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	project, bucket, err := validateBucketPathParameters(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	if err := server.bucketEvents.DeleteDestination(ctx, project.UUID, string(bucket)); err != nil {
+		sendJSONError(w, "unable to delete event destination of bucket", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	server.log.Named("auditlog").Info("bucket event destination deleted",
+		zap.Stringer("project", project.UUID),
+		zap.String("bucket", string(bucket)),
+		zap.String("updater", r.Header.Get("X-Forwarded-Email")))
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"storj.io/storj/satellite/console"
+)
+
+// defaultDomainsLimit is the number of domains returned when the limit isn't specified.
+const defaultDomainsLimit = 100
+
+func (server *Server) listDomains(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	status := console.DomainCertificatePending
+	if statusParam := r.URL.Query().Get("status"); statusParam != "" {
+		var err error
+		status, err = console.DomainStatusFromString(statusParam)
+		if err != nil {
+			sendJSONError(w, "invalid status",
+				err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	limit := defaultDomainsLimit
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit <= 0 {
+			sendJSONError(w, "invalid limit",
+				"limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	domains, err := server.db.Console().Domains().ListByStatus(ctx, status, limit)
+	if err != nil {
+		sendJSONError(w, "failed to list domains",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	type domain struct {
+		console.Domain
+		ProjectID string `json:"projectId"`
+	}
+	output := make([]domain, 0, len(domains))
+	for _, d := range domains {
+		output = append(output, domain{Domain: d, ProjectID: d.ProjectID.String()})
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) updateDomainStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	subdomain, ok := mux.Vars(r)["subdomain"]
+	if !ok {
+		sendJSONError(w, "subdomain missing",
+			"", http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		sendJSONError(w, "failed to read body",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var input struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}
+	if err = json.Unmarshal(body, &input); err != nil {
+		sendJSONError(w, "failed to unmarshal request",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	status, err := console.DomainStatusFromString(input.Status)
+	if err != nil {
+		sendJSONError(w, "invalid status",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = server.db.Console().Domains().UpdateStatus(ctx, subdomain, status, input.Message)
+	if errors.Is(err, sql.ErrNoRows) {
+		sendJSONError(w, "domain not found",
+			"", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		sendJSONError(w, "failed to update domain",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	server.log.Named("auditlog").Info("domain status updated",
+		zap.String("subdomain", subdomain),
+		zap.Stringer("status", status),
+		zap.String("message", input.Message),
+		zap.String("updater", r.Header.Get("X-Forwarded-Email")))
+}
@@ -0,0 +1,244 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/consoleauth"
+)
+
+// ImpersonationConfig defines configuration for creating console sessions impersonating users.
+type ImpersonationConfig struct {
+	Enabled        bool          `help:"allow admins to create time-boxed console sessions impersonating users for support purposes" default:"false"`
+	RequireConsent bool          `help:"require confirmation that the user consented to the impersonation" default:"true"`
+	MaxDuration    time.Duration `help:"maximum duration of an impersonation session" default:"1h"`
+}
+
+// impersonationRequest is the request body for creating an impersonation session.
+type impersonationRequest struct {
+	// Reason is the reason of the impersonation, e.g. the support ticket.
+	Reason string `json:"reason"`
+	// Consent confirms that the user consented to the impersonation.
+	Consent bool `json:"consent"`
+	// Duration is the duration of the session, MaxDuration when empty.
+	Duration string `json:"duration"`
+}
+
+// impersonationResponse is the response of creating an impersonation session.
+type impersonationResponse struct {
+	SessionID uuid.UUID `json:"sessionID"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func (server *Server) impersonateUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !server.config.Impersonation.Enabled {
+		sendJSONError(w, "impersonation is disabled",
+			"", http.StatusForbidden)
+		return
+	}
+
+	userEmail, ok := mux.Vars(r)["useremail"]
+	if !ok {
+		sendJSONError(w, "user-email missing",
+			"", http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		sendJSONError(w, "failed to read body",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var input impersonationRequest
+	if err = json.Unmarshal(body, &input); err != nil {
+		sendJSONError(w, "failed to unmarshal request",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	input.Reason = strings.TrimSpace(input.Reason)
+	if input.Reason == "" {
+		sendJSONError(w, "reason is required",
+			"", http.StatusBadRequest)
+		return
+	}
+
+	if server.config.Impersonation.RequireConsent && !input.Consent {
+		sendJSONError(w, "user consent is required",
+			"the user must consent to the impersonation", http.StatusBadRequest)
+		return
+	}
+
+	duration := server.config.Impersonation.MaxDuration
+	if input.Duration != "" {
+		duration, err = time.ParseDuration(input.Duration)
+		if err != nil {
+			sendJSONError(w, "invalid duration",
+				err.Error(), http.StatusBadRequest)
+			return
+		}
+		if duration <= 0 || duration > server.config.Impersonation.MaxDuration {
+			sendJSONError(w, "invalid duration",
+				fmt.Sprintf("duration must be positive and at most %s", server.config.Impersonation.MaxDuration), http.StatusBadRequest)
+			return
+		}
+	}
+
+	user, err := server.db.Console().Users().GetByEmail(ctx, userEmail)
+	if errors.Is(err, sql.ErrNoRows) {
+		sendJSONError(w, fmt.Sprintf("user with email %q does not exist", userEmail),
+			"", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		sendJSONError(w, "failed to get user",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if user.Status != console.Active {
+		sendJSONError(w, "user is not active",
+			"", http.StatusConflict)
+		return
+	}
+
+	sessionID, err := uuid.New()
+	if err != nil {
+		sendJSONError(w, "failed to create session",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	now := server.nowFn()
+	expiresAt := now.Add(duration)
+	adminEmail := r.Header.Get("X-Forwarded-Email")
+
+	// the session must never exist without its impersonated status and its audit record.
+	err = server.db.Console().WithTx(ctx, func(ctx context.Context, tx console.DBTx) error {
+		sessions := tx.WebappSessions()
+		if _, err := sessions.Create(ctx, sessionID, user.ID, r.RemoteAddr, "admin impersonation", expiresAt); err != nil {
+			return err
+		}
+		if err := sessions.UpdateStatus(ctx, sessionID, consoleauth.WebappSessionStatusImpersonated); err != nil {
+			return err
+		}
+		return tx.ImpersonationAudits().Insert(ctx, console.ImpersonationAudit{
+			SessionID:  sessionID,
+			UserID:     user.ID,
+			AdminEmail: adminEmail,
+			Reason:     input.Reason,
+			Consent:    input.Consent,
+			CreatedAt:  now,
+			ExpiresAt:  expiresAt,
+		})
+	})
+	if err != nil {
+		sendJSONError(w, "failed to create session",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	token := consoleauth.Token{Payload: sessionID.Bytes()}
+	tokens := consoleauth.NewService(consoleauth.Config{}, &consoleauth.Hmac{Secret: []byte(server.console.AuthTokenSecret)})
+	token.Signature, err = tokens.SignToken(token)
+	if err != nil {
+		sendJSONError(w, "failed to sign token",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	server.log.Info("impersonation session created",
+		zap.String("admin", adminEmail),
+		zap.Stringer("userID", user.ID),
+		zap.Stringer("sessionID", sessionID),
+		zap.String("reason", input.Reason),
+		zap.Bool("consent", input.Consent),
+		zap.Time("expiresAt", expiresAt),
+	)
+
+	data, err := json.Marshal(impersonationResponse{
+		SessionID: sessionID,
+		Token:     token.String(),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) endImpersonation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	sessionIDString, ok := mux.Vars(r)["session"]
+	if !ok {
+		sendJSONError(w, "session-id missing",
+			"", http.StatusBadRequest)
+		return
+	}
+
+	sessionID, err := uuidFromString(sessionIDString)
+	if err != nil {
+		sendJSONError(w, "invalid session-id",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sessions := server.db.Console().WebappSessions()
+	session, err := sessions.GetBySessionID(ctx, sessionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		sendJSONError(w, "session does not exist",
+			"", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		sendJSONError(w, "failed to get session",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if session.Status != consoleauth.WebappSessionStatusImpersonated {
+		sendJSONError(w, "session is not an impersonation session",
+			"", http.StatusConflict)
+		return
+	}
+
+	err = server.db.Console().WithTx(ctx, func(ctx context.Context, tx console.DBTx) error {
+		if err := tx.WebappSessions().DeleteBySessionID(ctx, sessionID); err != nil {
+			return err
+		}
+		return tx.ImpersonationAudits().End(ctx, sessionID, server.nowFn())
+	})
+	if err != nil {
+		sendJSONError(w, "failed to delete session",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	server.log.Info("impersonation session ended",
+		zap.String("admin", r.Header.Get("X-Forwarded-Email")),
+		zap.Stringer("userID", session.UserID),
+		zap.Stringer("sessionID", sessionID),
+	)
+}
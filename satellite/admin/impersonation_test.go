@@ -0,0 +1,140 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/uuid"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/consoleauth"
+)
+
+func TestImpersonateUser(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 0,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(_ *zap.Logger, _ int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+				config.Admin.Impersonation.Enabled = true
+				config.Admin.Impersonation.RequireConsent = true
+				config.Admin.Impersonation.MaxDuration = time.Hour
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		address := sat.Admin.Admin.Listener.Addr()
+		authToken := sat.Config.Console.AuthToken
+
+		user, err := sat.DB.Console().Users().GetByEmail(ctx, planet.Uplinks[0].Projects[0].Owner.Email)
+		require.NoError(t, err)
+
+		link := fmt.Sprintf("http://"+address.String()+"/api/users/%s/impersonate", user.Email)
+
+		t.Run("invalid request", func(t *testing.T) {
+			assertReq(ctx, t, link, http.MethodPost, `{"consent":true}`, http.StatusBadRequest, "", authToken)
+			assertReq(ctx, t, link, http.MethodPost, `{"reason":"ticket"}`, http.StatusBadRequest, "", authToken)
+			assertReq(ctx, t, link, http.MethodPost, `{"reason":"ticket","consent":true,"duration":"2h"}`, http.StatusBadRequest, "", authToken)
+
+			unknown := fmt.Sprintf("http://"+address.String()+"/api/users/%s/impersonate", "unknown@storj.test")
+			assertReq(ctx, t, unknown, http.MethodPost, `{"reason":"ticket","consent":true}`, http.StatusNotFound, "", authToken)
+		})
+
+		t.Run("impersonate", func(t *testing.T) {
+			body := assertReq(ctx, t, link, http.MethodPost, `{"reason":"ticket","consent":true,"duration":"30m"}`, http.StatusOK, "", authToken)
+
+			var output struct {
+				SessionID uuid.UUID `json:"sessionID"`
+				Token     string    `json:"token"`
+				ExpiresAt time.Time `json:"expiresAt"`
+			}
+			require.NoError(t, json.Unmarshal(body, &output))
+			require.WithinDuration(t, time.Now().Add(30*time.Minute), output.ExpiresAt, time.Minute)
+
+			session, err := sat.DB.Console().WebappSessions().GetBySessionID(ctx, output.SessionID)
+			require.NoError(t, err)
+			require.Equal(t, user.ID, session.UserID)
+			require.Equal(t, consoleauth.WebappSessionStatusImpersonated, session.Status)
+
+			audit, err := sat.DB.Console().ImpersonationAudits().Get(ctx, output.SessionID)
+			require.NoError(t, err)
+			require.Equal(t, user.ID, audit.UserID)
+			require.Equal(t, "ticket", audit.Reason)
+			require.True(t, audit.Consent)
+			require.WithinDuration(t, output.ExpiresAt, audit.ExpiresAt, time.Second)
+			require.Nil(t, audit.EndedAt)
+
+			token, err := consoleauth.FromBase64URLString(output.Token)
+			require.NoError(t, err)
+
+			authCtx, err := sat.API.Console.Service.TokenAuth(ctx, token, time.Now())
+			require.NoError(t, err)
+
+			sessionID, ok := console.GetImpersonation(authCtx)
+			require.True(t, ok)
+			require.Equal(t, output.SessionID, sessionID)
+
+			// changing the credentials of the account is not allowed in the session.
+			err = sat.API.Console.Service.ChangePassword(authCtx, "password", "new-password123", &sessionID)
+			require.True(t, console.ErrForbidden.Has(err))
+
+			// nor is creating long-lived REST keys for the user.
+			_, _, err = sat.API.Console.Service.CreateRESTKey(authCtx, time.Hour)
+			require.True(t, console.ErrForbidden.Has(err))
+
+			endLink := "http://" + address.String() + "/api/impersonation/" + output.SessionID.String()
+			assertReq(ctx, t, endLink, http.MethodDelete, "", http.StatusOK, "", authToken)
+
+			_, err = sat.DB.Console().WebappSessions().GetBySessionID(ctx, output.SessionID)
+			require.ErrorIs(t, err, sql.ErrNoRows)
+
+			audit, err = sat.DB.Console().ImpersonationAudits().Get(ctx, output.SessionID)
+			require.NoError(t, err)
+			require.NotNil(t, audit.EndedAt)
+		})
+
+		t.Run("end regular session", func(t *testing.T) {
+			sessionID, err := uuid.New()
+			require.NoError(t, err)
+
+			_, err = sat.DB.Console().WebappSessions().Create(ctx, sessionID, user.ID, "", "", time.Now().Add(time.Hour))
+			require.NoError(t, err)
+
+			endLink := "http://" + address.String() + "/api/impersonation/" + sessionID.String()
+			assertReq(ctx, t, endLink, http.MethodDelete, "", http.StatusConflict, "", authToken)
+		})
+	})
+}
+
+func TestImpersonateUser_Disabled(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 0,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(_ *zap.Logger, _ int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		address := sat.Admin.Admin.Listener.Addr()
+
+		link := fmt.Sprintf("http://"+address.String()+"/api/users/%s/impersonate", planet.Uplinks[0].Projects[0].Owner.Email)
+		assertReq(ctx, t, link, http.MethodPost, `{"reason":"ticket","consent":true}`, http.StatusForbidden, "", sat.Config.Console.AuthToken)
+	})
+}
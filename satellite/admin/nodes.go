@@ -0,0 +1,307 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/overlay"
+)
+
+func (server *Server) getMaintenanceWindow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	nodeIDString, ok := mux.Vars(r)["nodeid"]
+	if !ok {
+		sendJSONError(w, "node-id missing",
+			"", http.StatusBadRequest)
+		return
+	}
+
+	nodeID, err := storj.NodeIDFromString(nodeIDString)
+	if err != nil {
+		sendJSONError(w, "invalid node-id",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tags, err := server.db.OverlayCache().GetNodeTags(ctx, nodeID)
+	if err != nil {
+		sendJSONError(w, "failed to get node tags",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	window, ok := overlay.MaintenanceWindowFromTags(tags)
+	if !ok {
+		sendJSONError(w, "the node didn't declare a maintenance window",
+			"", http.StatusNotFound)
+		return
+	}
+
+	data, err := json.Marshal(window)
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) restoreArchivedNode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	nodeIDString, ok := mux.Vars(r)["nodeid"]
+	if !ok {
+		sendJSONError(w, "node-id missing",
+			"", http.StatusBadRequest)
+		return
+	}
+
+	nodeID, err := storj.NodeIDFromString(nodeIDString)
+	if err != nil {
+		sendJSONError(w, "invalid node-id",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = server.db.OverlayCache().RestoreArchivedNode(ctx, nodeID)
+	if err != nil {
+		switch {
+		case overlay.ErrNodeNotFound.Has(err):
+			sendJSONError(w, "the node isn't archived",
+				err.Error(), http.StatusNotFound)
+		case overlay.ErrNodeExists.Has(err):
+			sendJSONError(w, "the node has checked in again after it was archived",
+				err.Error(), http.StatusConflict)
+		default:
+			sendJSONError(w, "failed to restore the node",
+				err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (server *Server) getNodeDrain(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	nodeID, ok := nodeIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	drain, err := server.db.OverlayCache().GetNodeDrain(ctx, nodeID)
+	if err != nil {
+		if overlay.ErrNodeNotDraining.Has(err) {
+			sendJSONError(w, "the node isn't draining",
+				err.Error(), http.StatusNotFound)
+			return
+		}
+		sendJSONError(w, "failed to get node drain",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(drain)
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) startNodeDrain(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	nodeID, ok := nodeIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		sendJSONError(w, "failed to read body",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var input struct {
+		Reason string `json:"reason"`
+	}
+	if len(body) > 0 {
+		if err = json.Unmarshal(body, &input); err != nil {
+			sendJSONError(w, "failed to unmarshal request",
+				err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	_, err = server.db.OverlayCache().Get(ctx, nodeID)
+	if err != nil {
+		if overlay.ErrNodeNotFound.Has(err) {
+			sendJSONError(w, "node not found",
+				err.Error(), http.StatusNotFound)
+			return
+		}
+		sendJSONError(w, "failed to get node",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = server.db.OverlayCache().SetNodeDrain(ctx, overlay.NodeDrain{
+		NodeID:      nodeID,
+		Reason:      input.Reason,
+		InitiatedAt: server.nowFn().UTC(),
+	})
+	if err != nil {
+		sendJSONError(w, "failed to start node drain",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (server *Server) stopNodeDrain(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	nodeID, ok := nodeIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	err := server.db.OverlayCache().DeleteNodeDrain(ctx, nodeID)
+	if err != nil {
+		if overlay.ErrNodeNotDraining.Has(err) {
+			sendJSONError(w, "the node isn't draining",
+				err.Error(), http.StatusNotFound)
+			return
+		}
+		sendJSONError(w, "failed to stop node drain",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// walletNode is a node associated with an operator wallet.
+type walletNode struct {
+	ID                 storj.NodeID `json:"id"`
+	Address            string       `json:"address"`
+	Email              string       `json:"email"`
+	WalletFeatures     []string     `json:"walletFeatures"`
+	CreatedAt          time.Time    `json:"createdAt"`
+	LastContactSuccess time.Time    `json:"lastContactSuccess"`
+	VettedAt           *time.Time   `json:"vettedAt"`
+	Disqualified       *time.Time   `json:"disqualified"`
+	ExitFinishedAt     *time.Time   `json:"exitFinishedAt"`
+	FreeDisk           int64        `json:"freeDisk"`
+	PieceCount         int64        `json:"pieceCount"`
+}
+
+func (server *Server) getNodesByWallet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	wallet := strings.TrimSpace(mux.Vars(r)["wallet"])
+	if wallet == "" {
+		sendJSONError(w, "wallet missing",
+			"", http.StatusBadRequest)
+		return
+	}
+
+	dossiers, err := server.db.OverlayCache().GetNodesByWallet(ctx, wallet)
+	if err != nil {
+		sendJSONError(w, "failed to get nodes by wallet",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	nodes := make([]walletNode, 0, len(dossiers))
+	for _, dossier := range dossiers {
+		node := walletNode{
+			ID:                 dossier.Id,
+			Email:              dossier.Operator.Email,
+			WalletFeatures:     dossier.Operator.WalletFeatures,
+			CreatedAt:          dossier.CreatedAt,
+			LastContactSuccess: dossier.Reputation.LastContactSuccess,
+			VettedAt:           dossier.Reputation.Status.VettedAt,
+			Disqualified:       dossier.Disqualified,
+			ExitFinishedAt:     dossier.ExitStatus.ExitFinishedAt,
+			FreeDisk:           dossier.Capacity.FreeDisk,
+			PieceCount:         dossier.PieceCount,
+		}
+		if dossier.Address != nil {
+			node.Address = dossier.Address.Address
+		}
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, k int) bool {
+		return nodes[i].CreatedAt.Before(nodes[k].CreatedAt)
+	})
+
+	data, err := json.Marshal(nodes)
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) getNodeUptimeHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	nodeID, ok := nodeIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	now := server.nowFn()
+	since := now.Add(-7 * 24 * time.Hour)
+	if sinceString := r.URL.Query().Get("since"); sinceString != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, sinceString)
+		if err != nil {
+			sendJSONError(w, "invalid since",
+				"since must be an RFC3339 timestamp", http.StatusBadRequest)
+			return
+		}
+		if !since.Before(now) {
+			sendJSONError(w, "invalid since",
+				"since must be in the past", http.StatusBadRequest)
+			return
+		}
+	}
+
+	transitions, err := server.db.OverlayCache().GetNodeUptimeTransitions(ctx, nodeID, since)
+	if err != nil {
+		sendJSONError(w, "failed to get node uptime history",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(overlay.NewNodeUptimeHistory(nodeID, since, now, transitions))
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+)
+
+type objectVersionAsOf struct {
+	Version            metabase.Version    `json:"version"`
+	StreamID           uuid.UUID           `json:"streamId"`
+	Status             string              `json:"status"`
+	CreatedAt          time.Time           `json:"createdAt"`
+	ExpiresAt          *time.Time          `json:"expiresAt,omitempty"`
+	SegmentCount       int32               `json:"segmentCount"`
+	TotalPlainSize     int64               `json:"totalPlainSize"`
+	TotalEncryptedSize int64               `json:"totalEncryptedSize"`
+	Segments           []segmentAsOf       `json:"segments"`
+	Retention          *metabase.Retention `json:"retention,omitempty"`
+}
+
+type segmentAsOf struct {
+	Part          uint32                    `json:"part"`
+	Index         uint32                    `json:"index"`
+	CreatedAt     time.Time                 `json:"createdAt"`
+	ExpiresAt     *time.Time                `json:"expiresAt,omitempty"`
+	RootPieceID   storj.PieceID             `json:"rootPieceId"`
+	EncryptedSize int32                     `json:"encryptedSize"`
+	PlainOffset   int64                     `json:"plainOffset"`
+	PlainSize     int32                     `json:"plainSize"`
+	Placement     storj.PlacementConstraint `json:"placement"`
+	Inline        bool                      `json:"inline"`
+	Pieces        map[uint16]storj.NodeID   `json:"pieces,omitempty"`
+	Redundancy    *storj.RedundancyScheme   `json:"redundancy,omitempty"`
+}
+
+func (server *Server) getObjectVersionsAsOf(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	project, bucket, err := validateBucketPathParameters(mux.Vars(r))
+	if err != nil {
+		sendJSONError(w, err.Error(), "", http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	objectKey, err := base64.StdEncoding.DecodeString(query.Get("key"))
+	if err != nil || len(objectKey) == 0 {
+		sendJSONError(w, "invalid key", "the key must be the base64 encoded encrypted object key", http.StatusBadRequest)
+		return
+	}
+
+	asOf, err := time.Parse(time.RFC3339, query.Get("asOf"))
+	if err != nil {
+		sendJSONError(w, "invalid asOf", "asOf must be an RFC3339 timestamp", http.StatusBadRequest)
+		return
+	}
+
+	now := server.nowFn()
+	if !asOf.Before(now) {
+		sendJSONError(w, "invalid asOf", "asOf must be in the past", http.StatusBadRequest)
+		return
+	}
+	if server.config.TimeTravelWindow > 0 && now.Sub(asOf) > server.config.TimeTravelWindow {
+		sendJSONError(w, "invalid asOf",
+			"asOf can't be older than "+server.config.TimeTravelWindow.String(), http.StatusBadRequest)
+		return
+	}
+
+	versions, err := server.metabaseDB.GetObjectVersionsAsOf(ctx, metabase.GetObjectVersionsAsOf{
+		ObjectLocation: metabase.ObjectLocation{
+			ProjectID:  project.UUID,
+			BucketName: metabase.BucketName(bucket),
+			ObjectKey:  metabase.ObjectKey(objectKey),
+		},
+		AsOf: asOf,
+	})
+	if err != nil {
+		switch {
+		case metabase.ErrInvalidRequest.Has(err):
+			sendJSONError(w, "invalid request", err.Error(), http.StatusBadRequest)
+		case metabase.ErrMethodNotAllowed.Has(err):
+			sendJSONError(w, "time travel reads are not supported", err.Error(), http.StatusNotImplemented)
+		default:
+			sendJSONError(w, "unable to read object versions", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := struct {
+		AsOf     time.Time           `json:"asOf"`
+		Versions []objectVersionAsOf `json:"versions"`
+	}{
+		AsOf:     asOf,
+		Versions: make([]objectVersionAsOf, 0, len(versions)),
+	}
+	for _, version := range versions {
+		item := objectVersionAsOf{
+			Version:            version.Version,
+			StreamID:           version.StreamID,
+			Status:             version.Status.String(),
+			CreatedAt:          version.CreatedAt,
+			ExpiresAt:          version.ExpiresAt,
+			SegmentCount:       version.SegmentCount,
+			TotalPlainSize:     version.TotalPlainSize,
+			TotalEncryptedSize: version.TotalEncryptedSize,
+			Segments:           make([]segmentAsOf, 0, len(version.Segments)),
+		}
+		if version.Retention.Enabled() {
+			retention := version.Retention
+			item.Retention = &retention
+		}
+		for _, segment := range version.Segments {
+			seg := segmentAsOf{
+				Part:          segment.Position.Part,
+				Index:         segment.Position.Index,
+				CreatedAt:     segment.CreatedAt,
+				ExpiresAt:     segment.ExpiresAt,
+				RootPieceID:   segment.RootPieceID,
+				EncryptedSize: segment.EncryptedSize,
+				PlainOffset:   segment.PlainOffset,
+				PlainSize:     segment.PlainSize,
+				Placement:     segment.Placement,
+				Inline:        segment.Inline(),
+			}
+			if !seg.Inline {
+				redundancy := segment.Redundancy
+				seg.Redundancy = &redundancy
+				seg.Pieces = make(map[uint16]storj.NodeID, len(segment.Pieces))
+				for _, piece := range segment.Pieces {
+					seg.Pieces[piece.Number] = piece.StorageNode
+				}
+			}
+			item.Segments = append(item.Segments, seg)
+		}
+		response.Versions = append(response.Versions, item)
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		sendJSONError(w, "json encoding failed", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"storj.io/common/storj"
+	"storj.io/storj/private/date"
+	"storj.io/storj/satellite/snopayouts"
+)
+
+func (server *Server) getPayoutStatement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	nodeIDString, ok := vars["nodeid"]
+	if !ok {
+		sendJSONError(w, "node-id missing",
+			"", http.StatusBadRequest)
+		return
+	}
+
+	nodeID, err := storj.NodeIDFromString(nodeIDString)
+	if err != nil {
+		sendJSONError(w, "invalid node-id",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	period, ok := vars["period"]
+	if !ok {
+		sendJSONError(w, "period missing",
+			"", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := date.PeriodToTime(period); err != nil {
+		sendJSONError(w, "invalid period, expected format YYYY-MM",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	statement, err := server.payouts.GenerateStatement(ctx, nodeID, period)
+	if snopayouts.ErrNoDataForPeriod.Has(err) {
+		sendJSONError(w, "no payout data for the node in the specified period",
+			"", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		sendJSONError(w, "failed to generate payout statement",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(statement)
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "payout-statement-"+nodeID.String()+"-"+period+".json"))
+	sendJSONData(w, http.StatusOK, data)
+}
@@ -19,6 +19,7 @@ import (
 	"storj.io/common/macaroon"
 	"storj.io/common/memory"
 	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
 	"storj.io/common/uuid"
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/satellite"
@@ -610,6 +611,71 @@ func TestProjectLimitUpdate(t *testing.T) {
 	})
 }
 
+func TestProjectLimitBulkUpdate(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 0,
+		UplinkCount:      2,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(_ *zap.Logger, _ int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		address := sat.Admin.Admin.Listener.Addr()
+		authToken := sat.Config.Console.AuthToken
+		link := "http://" + address.String() + "/api/projects/limits/bulk"
+
+		projectA, err := sat.DB.Console().Projects().Get(ctx, planet.Uplinks[0].Projects[0].ID)
+		require.NoError(t, err)
+		projectB, err := sat.DB.Console().Projects().Get(ctx, planet.Uplinks[1].Projects[0].ID)
+		require.NoError(t, err)
+
+		t.Run("invalid header", func(t *testing.T) {
+			body := assertReq(ctx, t, link, http.MethodPost, "id,storage\n", http.StatusBadRequest, "", authToken)
+			require.Contains(t, string(body), "header")
+		})
+
+		missingID := testrand.UUID()
+		csvBody := strings.Join([]string{
+			"project_id,storage_limit,bandwidth_limit,segment_limit",
+			projectA.PublicID.String() + ",50GB,,500",
+			"not-an-id,1GB,1GB,1",
+			projectB.ID.String() + ",,-1GB,",
+			missingID.String() + ",1GB,,",
+			projectB.PublicID.String() + ",,75GB,",
+		}, "\n")
+
+		body := assertReq(ctx, t, link, http.MethodPost, csvBody, http.StatusOK, "", authToken)
+
+		var results []struct {
+			Line      int    `json:"line"`
+			ProjectID string `json:"projectId"`
+			Status    string `json:"status"`
+			Error     string `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(body, &results))
+		require.Len(t, results, 5)
+
+		expectedStatus := []string{"updated", "invalid", "invalid", "failed", "updated"}
+		for i, result := range results {
+			require.Equal(t, i+2, result.Line)
+			require.Equal(t, expectedStatus[i], result.Status, result.Error)
+		}
+		require.Contains(t, results[3].Error, "does not exist")
+
+		projectA, err = sat.DB.Console().Projects().Get(ctx, projectA.ID)
+		require.NoError(t, err)
+		require.Equal(t, 50*memory.GB, *projectA.StorageLimit)
+		require.Equal(t, int64(500), *projectA.SegmentLimit)
+
+		projectB, err = sat.DB.Console().Projects().Get(ctx, projectB.ID)
+		require.NoError(t, err)
+		require.Equal(t, 75*memory.GB, *projectB.BandwidthLimit)
+	})
+}
+
 func TestProjectAdd(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount:   1,
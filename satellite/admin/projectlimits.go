@@ -0,0 +1,255 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/storj/satellite/console"
+)
+
+// maxBulkProjectLimitsRows is the maximum number of rows accepted by a single
+// bulk project limits update.
+const maxBulkProjectLimitsRows = 10000
+
+// bulkProjectLimitsColumns are the expected columns of the bulk project limits CSV.
+var bulkProjectLimitsColumns = []string{"project_id", "storage_limit", "bandwidth_limit", "segment_limit"}
+
+// bulkProjectLimitsRow is a parsed row of the bulk project limits CSV.
+type bulkProjectLimitsRow struct {
+	line      int
+	projectID string
+	storage   *memory.Size
+	bandwidth *memory.Size
+	segments  *int64
+}
+
+// bulkProjectLimitsResult is the result of applying a single row of the bulk
+// project limits CSV.
+type bulkProjectLimitsResult struct {
+	Line      int    `json:"line"`
+	ProjectID string `json:"projectId"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
+
+const (
+	bulkProjectLimitsStatusUpdated = "updated"
+	bulkProjectLimitsStatusInvalid = "invalid"
+	bulkProjectLimitsStatusFailed  = "failed"
+)
+
+func (server *Server) bulkUpdateProjectLimits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reader := csv.NewReader(r.Body)
+	reader.FieldsPerRecord = len(bulkProjectLimitsColumns)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		sendJSONError(w, "failed to read CSV header",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+	for i, column := range bulkProjectLimitsColumns {
+		if strings.TrimSpace(strings.ToLower(header[i])) != column {
+			sendJSONError(w, "invalid CSV header",
+				fmt.Sprintf("expected columns: %s", strings.Join(bulkProjectLimitsColumns, ",")), http.StatusBadRequest)
+			return
+		}
+	}
+
+	var results []bulkProjectLimitsResult
+	var rows []bulkProjectLimitsRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			sendJSONError(w, "failed to read CSV",
+				err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if len(rows)+len(results) >= maxBulkProjectLimitsRows {
+			sendJSONError(w, "too many rows",
+				fmt.Sprintf("at most %d rows are allowed", maxBulkProjectLimitsRows), http.StatusBadRequest)
+			return
+		}
+
+		line, _ := reader.FieldPos(0)
+		row, err := parseBulkProjectLimitsRow(line, record)
+		if err != nil {
+			results = append(results, bulkProjectLimitsResult{
+				Line:      line,
+				ProjectID: record[0],
+				Status:    bulkProjectLimitsStatusInvalid,
+				Error:     err.Error(),
+			})
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	// Each row is applied on its own, hence a failing row doesn't prevent
+	// updating the rest of the projects.
+	for _, row := range rows {
+		result := bulkProjectLimitsResult{
+			Line:      row.line,
+			ProjectID: row.projectID,
+			Status:    bulkProjectLimitsStatusUpdated,
+		}
+		if err := server.applyBulkProjectLimitsRow(ctx, r, row); err != nil {
+			result.Status = bulkProjectLimitsStatusFailed
+			result.Error = err.Error()
+			if errors.Is(err, sql.ErrNoRows) {
+				result.Error = "project with specified uuid does not exist"
+			}
+		}
+		results = append(results, result)
+	}
+
+	// keep the report in the same order as the CSV rows.
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Line < results[j].Line
+	})
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+// parseBulkProjectLimitsRow validates and parses a single CSV record.
+// An empty value means that the corresponding limit isn't changed.
+func parseBulkProjectLimitsRow(line int, record []string) (row bulkProjectLimitsRow, err error) {
+	row.line = line
+	row.projectID = strings.TrimSpace(record[0])
+	if row.projectID == "" {
+		return row, Error.New("project ID missing")
+	}
+	if _, err := uuidFromString(row.projectID); err != nil {
+		return row, Error.New("invalid project ID: %v", err)
+	}
+
+	parseSize := func(name, value string) (*memory.Size, error) {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil, nil
+		}
+		var size memory.Size
+		if err := size.Set(value); err != nil {
+			return nil, Error.New("invalid %s: %v", name, err)
+		}
+		if size < 0 {
+			return nil, Error.New("negative %s", name)
+		}
+		return &size, nil
+	}
+
+	row.storage, err = parseSize("storage limit", record[1])
+	if err != nil {
+		return row, err
+	}
+	row.bandwidth, err = parseSize("bandwidth limit", record[2])
+	if err != nil {
+		return row, err
+	}
+
+	if value := strings.TrimSpace(record[3]); value != "" {
+		segments, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return row, Error.New("invalid segment limit: %v", err)
+		}
+		if segments < 0 {
+			return row, Error.New("negative segment limit")
+		}
+		row.segments = &segments
+	}
+
+	if row.storage == nil && row.bandwidth == nil && row.segments == nil {
+		return row, Error.New("no limits specified")
+	}
+
+	return row, nil
+}
+
+// applyBulkProjectLimitsRow updates the limits of a single project and records
+// the previous and the new values in the audit log.
+func (server *Server) applyBulkProjectLimitsRow(ctx context.Context, r *http.Request, row bulkProjectLimitsRow) error {
+	project, err := server.getProjectByAnyID(ctx, row.projectID)
+	if err != nil {
+		return err
+	}
+
+	fields := []zap.Field{
+		zap.String("user", r.Header.Get("X-Forwarded-Email")),
+		zap.Stringer("project", project.PublicID),
+	}
+
+	toUpdate := []console.Limit{}
+	if row.storage != nil {
+		val := row.storage.Int64()
+		toUpdate = append(toUpdate, console.Limit{
+			Kind:  console.StorageLimit,
+			Value: &val,
+		})
+		fields = append(fields,
+			zap.Stringp("storage-before", sizeString(project.StorageLimit)),
+			zap.Stringer("storage-after", row.storage))
+	}
+	if row.bandwidth != nil {
+		val := row.bandwidth.Int64()
+		toUpdate = append(toUpdate, console.Limit{
+			Kind:  console.BandwidthLimit,
+			Value: &val,
+		})
+		fields = append(fields,
+			zap.Stringp("bandwidth-before", sizeString(project.BandwidthLimit)),
+			zap.Stringer("bandwidth-after", row.bandwidth))
+	}
+	if row.segments != nil {
+		toUpdate = append(toUpdate, console.Limit{
+			Kind:  console.SegmentLimit,
+			Value: row.segments,
+		})
+		fields = append(fields,
+			zap.Int64p("segments-before", project.SegmentLimit),
+			zap.Int64("segments-after", *row.segments))
+	}
+
+	if err := server.db.Console().Projects().UpdateLimitsGeneric(ctx, project.ID, toUpdate); err != nil {
+		return Error.Wrap(err)
+	}
+
+	server.log.Named("auditlog").Info("project limits changed", fields...)
+	return nil
+}
+
+func sizeString(size *memory.Size) *string {
+	if size == nil {
+		return nil
+	}
+	s := size.String()
+	return &s
+}
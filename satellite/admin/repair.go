@@ -0,0 +1,70 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+)
+
+// defaultRepairLeasesLimit is the number of repair leases returned when the limit isn't specified.
+const defaultRepairLeasesLimit = 100
+
+// repairLease is a segment of the repair queue held by a repair worker.
+type repairLease struct {
+	Worker    string                    `json:"worker"`
+	StreamID  uuid.UUID                 `json:"streamId"`
+	Part      uint32                    `json:"part"`
+	Index     uint32                    `json:"index"`
+	Placement storj.PlacementConstraint `json:"placement"`
+	ExpiresAt time.Time                 `json:"expiresAt"`
+}
+
+func (server *Server) listRepairLeases(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit := defaultRepairLeasesLimit
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit <= 0 {
+			sendJSONError(w, "invalid limit",
+				"limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	leases, err := server.db.RepairQueue().Leases(ctx, limit)
+	if err != nil {
+		sendJSONError(w, "failed to list repair leases",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]repairLease, 0, len(leases))
+	for _, lease := range leases {
+		response = append(response, repairLease{
+			Worker:    lease.Worker,
+			StreamID:  lease.StreamID,
+			Part:      lease.Position.Part,
+			Index:     lease.Position.Index,
+			Placement: lease.Placement,
+			ExpiresAt: lease.ExpiresAt,
+		})
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"storj.io/storj/satellite/overlay/selectionfairness"
+)
+
+func (server *Server) getSelectionFairnessReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	status := selectionfairness.Status(r.URL.Query().Get("status"))
+	switch status {
+	case "", selectionfairness.StatusFair, selectionfairness.StatusUnderSelected, selectionfairness.StatusOverSelected:
+	default:
+		sendJSONError(w, "invalid status",
+			"status must be one of fair, under or over", http.StatusBadRequest)
+		return
+	}
+
+	report, err := server.db.SelectionFairness().GetReport(ctx)
+	if err != nil {
+		sendJSONError(w, "failed to get node selection fairness report",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if status != "" {
+		entries := report.Entries[:0]
+		for _, entry := range report.Entries {
+			if entry.Status == status {
+				entries = append(entries, entry)
+			}
+		}
+		report.Entries = entries
+	}
+	if report.Entries == nil {
+		report.Entries = []selectionfairness.Entry{}
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
@@ -25,14 +25,22 @@ import (
 	backoffice "storj.io/storj/satellite/admin/back-office"
 	"storj.io/storj/satellite/analytics"
 	"storj.io/storj/satellite/attribution"
+	"storj.io/storj/satellite/bucketevents"
 	"storj.io/storj/satellite/buckets"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/console/consoleweb"
 	"storj.io/storj/satellite/console/restkeys"
 	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/zombiedeletion"
 	"storj.io/storj/satellite/oidc"
+	"storj.io/storj/satellite/overlay"
+	"storj.io/storj/satellite/overlay/attestation"
+	"storj.io/storj/satellite/overlay/selectionfairness"
 	"storj.io/storj/satellite/payments"
 	"storj.io/storj/satellite/payments/stripe"
+	"storj.io/storj/satellite/repair/queue"
+	"storj.io/storj/satellite/sla"
+	"storj.io/storj/satellite/snopayouts"
 )
 
 // Assets contains either the built admin/back-office/ui or it is nil.
@@ -54,6 +62,8 @@ type Config struct {
 	StaticDir        string `help:"an alternate directory path which contains the static assets to serve. When empty, it uses the embedded assets" releaseDefault:"" devDefault:""`
 	AllowedOauthHost string `help:"the oauth host allowed to bypass token authentication."`
 	Groups           Groups
+	Impersonation    ImpersonationConfig
+	TimeTravelWindow time.Duration `help:"how far in the past the object history can be read, it must not exceed the garbage collection window of the metabase" default:"4h"`
 
 	AuthorizationToken string `internal:"true"`
 	BackOffice         backoffice.Config
@@ -78,6 +88,20 @@ type DB interface {
 	Buckets() buckets.DB
 	// Attribution returns database for value attribution.
 	Attribution() attribution.DB
+	// OverlayCache returns database for caching overlay information.
+	OverlayCache() overlay.DB
+	// SLA returns database for the daily SLA records of placements.
+	SLA() sla.DB
+	// SelectionFairness returns database for the node selection fairness report.
+	SelectionFairness() selectionfairness.DB
+	// AttestationIssuers returns database for the accepted node attestation issuers.
+	AttestationIssuers() attestation.DB
+	// ZombieDeletion returns database for the zombie deletion settings of projects and buckets.
+	ZombieDeletion() zombiedeletion.DB
+	// BucketEvents returns database for the bucket event destinations and the unpublished delete events.
+	BucketEvents() bucketevents.DB
+	// RepairQueue returns the queue of segments waiting for repair.
+	RepairQueue() queue.RepairQueue
 }
 
 // Server provides endpoints for administrative tasks.
@@ -94,6 +118,9 @@ type Server struct {
 	restKeys       *restkeys.Service
 	analytics      *analytics.Service
 	freezeAccounts *console.AccountFreezeService
+	payouts        *snopayouts.Service
+	attestations   *attestation.Service
+	bucketEvents   *bucketevents.Service
 
 	nowFn func() time.Time
 
@@ -112,6 +139,7 @@ func NewServer(
 	freezeAccounts *console.AccountFreezeService,
 	analyticsService *analytics.Service,
 	accounts payments.Accounts,
+	payouts *snopayouts.Service,
 	backOfficeService *backoffice.Service,
 	console consoleweb.Config,
 	config Config,
@@ -128,6 +156,9 @@ func NewServer(
 		restKeys:       restKeys,
 		analytics:      analyticsService,
 		freezeAccounts: freezeAccounts,
+		payouts:        payouts,
+		attestations:   attestation.NewService(log.Named("attestation"), db.AttestationIssuers(), db.OverlayCache()),
+		bucketEvents:   bucketevents.NewService(log.Named("bucketevents"), db.BucketEvents()),
 
 		nowFn: time.Now,
 
@@ -154,6 +185,8 @@ func NewServer(
 	fullAccessAPI.HandleFunc("/users/{useremail}/geofence", server.createGeofenceForAccount).Methods("PATCH")
 	fullAccessAPI.HandleFunc("/users/{useremail}/geofence", server.deleteGeofenceForAccount).Methods("DELETE")
 	fullAccessAPI.HandleFunc("/users/{useremail}/trial-expiration", server.updateFreeTrialExpiration).Methods("PATCH")
+	fullAccessAPI.HandleFunc("/users/{useremail}/impersonate", server.impersonateUser).Methods("POST")
+	fullAccessAPI.HandleFunc("/impersonation/{session}", server.endImpersonation).Methods("DELETE")
 	fullAccessAPI.HandleFunc("/oauth/clients", server.createOAuthClient).Methods("POST")
 	fullAccessAPI.HandleFunc("/oauth/clients/{id}", server.updateOAuthClient).Methods("PUT")
 	fullAccessAPI.HandleFunc("/oauth/clients/{id}", server.deleteOAuthClient).Methods("DELETE")
@@ -167,20 +200,41 @@ func NewServer(
 	fullAccessAPI.HandleFunc("/projects/{project}/buckets/{bucket}", server.getBucketInfo).Methods("GET")
 	fullAccessAPI.HandleFunc("/projects/{project}/buckets/{bucket}/geofence", server.createGeofenceForBucket).Methods("POST")
 	fullAccessAPI.HandleFunc("/projects/{project}/buckets/{bucket}/geofence", server.deleteGeofenceForBucket).Methods("DELETE")
+	fullAccessAPI.HandleFunc("/projects/{project}/buckets/{bucket}/deletion-protection", server.enableBucketDeletionProtection).Methods("PUT")
+	fullAccessAPI.HandleFunc("/projects/{project}/buckets/{bucket}/deletion-protection", server.disableBucketDeletionProtection).Methods("DELETE")
+	fullAccessAPI.HandleFunc("/projects/{project}/buckets/{bucket}/defaults", server.updateBucketDefaults).Methods("PUT")
+	fullAccessAPI.HandleFunc("/projects/{project}/buckets/{bucket}/defaults", server.deleteBucketDefaults).Methods("DELETE")
+	fullAccessAPI.HandleFunc("/projects/{project}/buckets/{bucket}/quota", server.getBucketQuota).Methods("GET")
+	fullAccessAPI.HandleFunc("/projects/{project}/buckets/{bucket}/quota", server.updateBucketQuota).Methods("PUT")
+	fullAccessAPI.HandleFunc("/projects/{project}/buckets/{bucket}/quota", server.deleteBucketQuota).Methods("DELETE")
+	fullAccessAPI.HandleFunc("/projects/{project}/buckets/{bucket}/events", server.getBucketEventDestination).Methods("GET")
+	fullAccessAPI.HandleFunc("/projects/{project}/buckets/{bucket}/events", server.setBucketEventDestination).Methods("PUT")
+	fullAccessAPI.HandleFunc("/projects/{project}/buckets/{bucket}/events", server.deleteBucketEventDestination).Methods("DELETE")
+	fullAccessAPI.HandleFunc("/projects/{project}/buckets/{bucket}/objects/history", server.getObjectVersionsAsOf).Methods("GET")
 	fullAccessAPI.HandleFunc("/projects/{project}/usage", server.checkProjectUsage).Methods("GET")
 	fullAccessAPI.HandleFunc("/projects/{project}/useragent", server.updateProjectsUserAgent).Methods("PATCH")
 	fullAccessAPI.HandleFunc("/projects/{project}/geofence", server.createGeofenceForProject).Methods("PUT")
 	fullAccessAPI.HandleFunc("/projects/{project}/geofence", server.deleteGeofenceForProject).Methods("DELETE")
+	fullAccessAPI.HandleFunc("/projects/{project}/zombie-deletion", server.getZombieDeletion).Methods("GET")
+	fullAccessAPI.HandleFunc("/projects/{project}/zombie-deletion", server.updateZombieDeletion).Methods("PUT")
+	fullAccessAPI.HandleFunc("/projects/{project}/zombie-deletion", server.deleteZombieDeletion).Methods("DELETE")
 	fullAccessAPI.HandleFunc("/apikeys/{apikey}", server.getAPIKey).Methods("GET")
 	fullAccessAPI.HandleFunc("/apikeys/{apikey}", server.deleteAPIKey).Methods("DELETE")
 	fullAccessAPI.HandleFunc("/restkeys/{useremail}", server.addRESTKey).Methods("POST")
 	fullAccessAPI.HandleFunc("/restkeys/{apikey}/revoke", server.revokeRESTKey).Methods("PUT")
+	fullAccessAPI.HandleFunc("/nodes/{nodeid}/restore", server.restoreArchivedNode).Methods("POST")
+	fullAccessAPI.HandleFunc("/nodes/{nodeid}/drain", server.startNodeDrain).Methods("PUT")
+	fullAccessAPI.HandleFunc("/nodes/{nodeid}/drain", server.stopNodeDrain).Methods("DELETE")
+	fullAccessAPI.HandleFunc("/nodes/{nodeid}/attestations", server.submitNodeAttestations).Methods("POST")
+	fullAccessAPI.HandleFunc("/nodes/attestation-issuers", server.addAttestationIssuer).Methods("POST")
+	fullAccessAPI.HandleFunc("/nodes/attestation-issuers/{issuer}", server.deleteAttestationIssuer).Methods("DELETE")
 
 	// limit update access required
 	limitUpdateAPI := api.NewRoute().Subrouter()
 	limitUpdateAPI.Use(server.withAuth([]string{config.Groups.LimitUpdate}, false))
 	limitUpdateAPI.HandleFunc("/users/{useremail}", server.userInfo).Methods("GET")
 	limitUpdateAPI.HandleFunc("/users/{useremail}/limits", server.userLimits).Methods("GET")
+	limitUpdateAPI.HandleFunc("/users/{useremail}/value-report", server.getUserValueReport).Methods("GET")
 	limitUpdateAPI.HandleFunc("/users/{useremail}/limits", server.updateLimits).Methods("PUT")
 	limitUpdateAPI.HandleFunc("/users/{useremail}/billing-freeze", server.billingFreezeUser).Methods("PUT")
 	limitUpdateAPI.HandleFunc("/users/{useremail}/billing-freeze", server.billingUnfreezeUser).Methods("DELETE")
@@ -195,6 +249,21 @@ func NewServer(
 	limitUpdateAPI.HandleFunc("/users/deletion/requested-by-user", server.usersRequestedForDeletion).Methods("GET")
 	limitUpdateAPI.HandleFunc("/projects/{project}/limit", server.getProjectLimit).Methods("GET")
 	limitUpdateAPI.HandleFunc("/projects/{project}/limit", server.putProjectLimit).Methods("PUT")
+	limitUpdateAPI.HandleFunc("/projects/limits/bulk", server.bulkUpdateProjectLimits).Methods("POST")
+	limitUpdateAPI.HandleFunc("/nodes/{nodeid}/payout-statements/{period}", server.getPayoutStatement).Methods("GET")
+	limitUpdateAPI.HandleFunc("/nodes/{nodeid}/maintenance-window", server.getMaintenanceWindow).Methods("GET")
+	limitUpdateAPI.HandleFunc("/nodes/{nodeid}/drain", server.getNodeDrain).Methods("GET")
+	limitUpdateAPI.HandleFunc("/nodes/{nodeid}/uptime-history", server.getNodeUptimeHistory).Methods("GET")
+	limitUpdateAPI.HandleFunc("/nodes/selection-fairness", server.getSelectionFairnessReport).Methods("GET")
+	limitUpdateAPI.HandleFunc("/nodes/wallets/{wallet}", server.getNodesByWallet).Methods("GET")
+	limitUpdateAPI.HandleFunc("/nodes/{nodeid}/attestations", server.getNodeAttestations).Methods("GET")
+	limitUpdateAPI.HandleFunc("/nodes/attestation-issuers", server.listAttestationIssuers).Methods("GET")
+	limitUpdateAPI.HandleFunc("/abuse-reports", server.listAbuseReports).Methods("GET")
+	limitUpdateAPI.HandleFunc("/abuse-reports/{id}", server.reviewAbuseReport).Methods("PUT")
+	limitUpdateAPI.HandleFunc("/domains", server.listDomains).Methods("GET")
+	limitUpdateAPI.HandleFunc("/domains/{subdomain}", server.updateDomainStatus).Methods("PUT")
+	limitUpdateAPI.HandleFunc("/sla/placements", server.getSLAReport).Methods("GET")
+	limitUpdateAPI.HandleFunc("/repair/leases", server.listRepairLeases).Methods("GET")
 
 	// NewServer adds the backoffice.PahtPrefix for the static assets, but not for the API because the
 	// generator already add the PathPrefix to router when the API handlers are hooked.
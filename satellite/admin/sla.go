@@ -0,0 +1,73 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"storj.io/storj/satellite/sla"
+)
+
+// defaultSLAReportDays is the number of days reported when the start of the report isn't specified.
+const defaultSLAReportDays = 30
+
+func (server *Server) getSLAReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	until := sla.Day(time.Now()).AddDate(0, 0, 1)
+	if untilParam := r.URL.Query().Get("until"); untilParam != "" {
+		var err error
+		until, err = time.Parse(time.DateOnly, untilParam)
+		if err != nil {
+			sendJSONError(w, "invalid until",
+				"until must be a date in the format YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+
+	since := until.AddDate(0, 0, -defaultSLAReportDays)
+	if sinceParam := r.URL.Query().Get("since"); sinceParam != "" {
+		var err error
+		since, err = time.Parse(time.DateOnly, sinceParam)
+		if err != nil {
+			sendJSONError(w, "invalid since",
+				"since must be a date in the format YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if !since.Before(until) {
+		sendJSONError(w, "invalid period",
+			"since must be before until", http.StatusBadRequest)
+		return
+	}
+
+	records, err := server.db.SLA().List(ctx, since, until)
+	if err != nil {
+		sendJSONError(w, "failed to list SLA records",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(struct {
+		Since      time.Time         `json:"since"`
+		Until      time.Time         `json:"until"`
+		Placements []sla.Summary     `json:"placements"`
+		Days       []sla.DailyRecord `json:"days"`
+	}{
+		Since:      since,
+		Until:      until,
+		Placements: sla.Summarize(records),
+		Days:       records,
+	})
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
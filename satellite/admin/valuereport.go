@@ -0,0 +1,252 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/payments"
+)
+
+const (
+	// defaultValueReportMonths is the number of months reported when the start of the report isn't specified.
+	defaultValueReportMonths = 6
+	// maxValueReportMonths is the maximum number of months of a value report, because the usage of every
+	// project is queried for every month.
+	maxValueReportMonths = 24
+)
+
+// valueReportUsage is the usage and the invoiced amount of a customer account during a period.
+type valueReportUsage struct {
+	// StorageByteHours is the stored data in byte-hours.
+	StorageByteHours float64 `json:"storageByteHours"`
+	// EgressBytes is the settled egress in bytes.
+	EgressBytes int64 `json:"egressBytes"`
+	// SegmentHours is the number of stored segments in segment-hours.
+	SegmentHours float64 `json:"segmentHours"`
+	// InvoicedCents is the total amount of the invoices of the period in cents.
+	InvoicedCents int64 `json:"invoicedCents"`
+}
+
+func (usage *valueReportUsage) add(other valueReportUsage) {
+	usage.StorageByteHours += other.StorageByteHours
+	usage.EgressBytes += other.EgressBytes
+	usage.SegmentHours += other.SegmentHours
+	usage.InvoicedCents += other.InvoicedCents
+}
+
+// valueReportMonth is the usage of a customer account during a calendar month, and its growth relative
+// to the previous month of the report. The growth compares the hourly averages, because the first and
+// the last months may be truncated to the period. It's nil for the first month and when the previous
+// value is zero.
+type valueReportMonth struct {
+	Since  time.Time `json:"since"`
+	Before time.Time `json:"before"`
+	valueReportUsage
+
+	StorageGrowth  *float64 `json:"storageGrowth"`
+	EgressGrowth   *float64 `json:"egressGrowth"`
+	InvoicedGrowth *float64 `json:"invoicedGrowth"`
+}
+
+// valueReportProject is the usage of a project of the customer account during the whole period.
+// The invoices aren't attributed to the projects.
+type valueReportProject struct {
+	ID               uuid.UUID `json:"id"`
+	PublicID         uuid.UUID `json:"publicId"`
+	Name             string    `json:"name"`
+	StorageByteHours float64   `json:"storageByteHours"`
+	EgressBytes      int64     `json:"egressBytes"`
+	SegmentHours     float64   `json:"segmentHours"`
+}
+
+// valueReportMonths splits the period into calendar months. The first and the last months are
+// truncated to the period.
+func valueReportMonths(since, before time.Time) (months []valueReportMonth) {
+	for start := since; start.Before(before); {
+		end := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location()).AddDate(0, 1, 0)
+		if end.After(before) {
+			end = before
+		}
+		months = append(months, valueReportMonth{Since: start, Before: end})
+		start = end
+	}
+	return months
+}
+
+// growth returns the relative change from previous to current, or nil when previous is zero.
+func growth(previous, current float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	value := (current - previous) / previous
+	return &value
+}
+
+// computeValueReportGrowth sets the growth of every month relative to the previous month. The values
+// are divided by the length of their month, so partial months compare with full months.
+func computeValueReportGrowth(months []valueReportMonth) {
+	for i := 1; i < len(months); i++ {
+		previous, current := &months[i-1], &months[i]
+		previousHours := previous.Before.Sub(previous.Since).Hours()
+		currentHours := current.Before.Sub(current.Since).Hours()
+
+		current.StorageGrowth = growth(previous.StorageByteHours/previousHours, current.StorageByteHours/currentHours)
+		current.EgressGrowth = growth(float64(previous.EgressBytes)/previousHours, float64(current.EgressBytes)/currentHours)
+		current.InvoicedGrowth = growth(float64(previous.InvoicedCents)/previousHours, float64(current.InvoicedCents)/currentHours)
+	}
+}
+
+// addValueReportInvoices adds the amounts of the invoices to the months their billing period starts in.
+// The invoices starting outside of the report are ignored.
+func addValueReportInvoices(months []valueReportMonth, invoices []payments.Invoice) {
+	for _, invoice := range invoices {
+		for i := range months {
+			if !invoice.Start.Before(months[i].Since) && invoice.Start.Before(months[i].Before) {
+				months[i].InvoicedCents += invoice.Amount
+				break
+			}
+		}
+	}
+}
+
+func (server *Server) getUserValueReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	userEmail, ok := vars["useremail"]
+	if !ok {
+		sendJSONError(w, "user-email missing",
+			"", http.StatusBadRequest)
+		return
+	}
+
+	now := server.nowFn().UTC()
+	until := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0)
+	if untilParam := r.URL.Query().Get("until"); untilParam != "" {
+		var err error
+		until, err = time.Parse(time.DateOnly, untilParam)
+		if err != nil {
+			sendJSONError(w, "invalid until",
+				"until must be a date in the format YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+
+	since := until.AddDate(0, -defaultValueReportMonths, 0)
+	if sinceParam := r.URL.Query().Get("since"); sinceParam != "" {
+		var err error
+		since, err = time.Parse(time.DateOnly, sinceParam)
+		if err != nil {
+			sendJSONError(w, "invalid since",
+				"since must be a date in the format YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if !since.Before(until) {
+		sendJSONError(w, "invalid period",
+			"since must be before until", http.StatusBadRequest)
+		return
+	}
+
+	months := valueReportMonths(since, until)
+	if len(months) > maxValueReportMonths {
+		sendJSONError(w, "invalid period",
+			fmt.Sprintf("the period can't span more than %d months", maxValueReportMonths), http.StatusBadRequest)
+		return
+	}
+
+	user, err := server.db.Console().Users().GetByEmail(ctx, userEmail)
+	if errors.Is(err, sql.ErrNoRows) {
+		sendJSONError(w, fmt.Sprintf("user with email %q does not exist", userEmail),
+			"", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		sendJSONError(w, "failed to get user",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	projects, err := server.db.Console().Projects().GetOwn(ctx, user.ID)
+	if err != nil {
+		sendJSONError(w, "failed to get user projects",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	reportProjects := make([]valueReportProject, 0, len(projects))
+	for _, project := range projects {
+		reportProject := valueReportProject{
+			ID:       project.ID,
+			PublicID: project.PublicID,
+			Name:     project.Name,
+		}
+		for i := range months {
+			usage, err := server.db.ProjectAccounting().GetProjectTotal(ctx, project.ID, months[i].Since, months[i].Before)
+			if err != nil {
+				sendJSONError(w, "failed to get project usage",
+					err.Error(), http.StatusInternalServerError)
+				return
+			}
+			months[i].add(valueReportUsage{
+				StorageByteHours: usage.Storage,
+				EgressBytes:      usage.Egress,
+				SegmentHours:     usage.SegmentCount,
+			})
+			reportProject.StorageByteHours += usage.Storage
+			reportProject.EgressBytes += usage.Egress
+			reportProject.SegmentHours += usage.SegmentCount
+		}
+		reportProjects = append(reportProjects, reportProject)
+	}
+
+	invoices, err := server.payments.Invoices().List(ctx, user.ID)
+	if err != nil {
+		sendJSONError(w, "failed to list invoices",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+	addValueReportInvoices(months, invoices)
+	computeValueReportGrowth(months)
+
+	var totals valueReportUsage
+	for _, month := range months {
+		totals.add(month.valueReportUsage)
+	}
+
+	data, err := json.Marshal(struct {
+		UserID   uuid.UUID            `json:"userId"`
+		Email    string               `json:"email"`
+		Since    time.Time            `json:"since"`
+		Until    time.Time            `json:"until"`
+		Totals   valueReportUsage     `json:"totals"`
+		Months   []valueReportMonth   `json:"months"`
+		Projects []valueReportProject `json:"projects"`
+	}{
+		UserID:   user.ID,
+		Email:    user.Email,
+		Since:    since,
+		Until:    until,
+		Totals:   totals,
+		Months:   months,
+		Projects: reportProjects,
+	})
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
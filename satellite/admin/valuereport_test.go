@@ -0,0 +1,67 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/satellite/payments"
+)
+
+func TestValueReportMonths(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	months := valueReportMonths(date(2023, 11, 15), date(2024, 2, 10))
+	require.Len(t, months, 4)
+	require.Equal(t, date(2023, 11, 15), months[0].Since)
+	require.Equal(t, date(2023, 12, 1), months[0].Before)
+	require.Equal(t, date(2023, 12, 1), months[1].Since)
+	require.Equal(t, date(2024, 1, 1), months[1].Before)
+	require.Equal(t, date(2024, 1, 1), months[2].Since)
+	require.Equal(t, date(2024, 2, 1), months[2].Before)
+	require.Equal(t, date(2024, 2, 1), months[3].Since)
+	require.Equal(t, date(2024, 2, 10), months[3].Before)
+
+	require.Empty(t, valueReportMonths(date(2024, 1, 1), date(2024, 1, 1)))
+
+	addValueReportInvoices(months, []payments.Invoice{
+		{Amount: 100, Start: date(2023, 12, 1)},
+		{Amount: 150, Start: date(2024, 1, 1)},
+		{Amount: 50, Start: date(2024, 1, 1)},
+		// outside of the report.
+		{Amount: 1000, Start: date(2023, 10, 1)},
+	})
+	months[0].StorageByteHours = 0
+	months[1].StorageByteHours = 1000
+	months[2].StorageByteHours = 1500
+	months[1].EgressBytes = 6200
+	months[2].EgressBytes = 3100
+	// the same egress per day in the truncated last month.
+	months[3].EgressBytes = 900
+
+	computeValueReportGrowth(months)
+
+	require.Nil(t, months[0].StorageGrowth)
+	// the storage of the previous month is zero.
+	require.Nil(t, months[1].StorageGrowth)
+	require.Nil(t, months[1].InvoicedGrowth)
+
+	require.NotNil(t, months[2].StorageGrowth)
+	require.InDelta(t, 0.5, *months[2].StorageGrowth, 1e-9)
+	require.NotNil(t, months[2].EgressGrowth)
+	require.InDelta(t, -0.5, *months[2].EgressGrowth, 1e-9)
+	require.Equal(t, int64(200), months[2].InvoicedCents)
+	require.NotNil(t, months[2].InvoicedGrowth)
+	require.InDelta(t, 1, *months[2].InvoicedGrowth, 1e-9)
+
+	require.NotNil(t, months[3].StorageGrowth)
+	require.InDelta(t, -1, *months[3].StorageGrowth, 1e-9)
+	require.NotNil(t, months[3].EgressGrowth)
+	require.InDelta(t, 0, *months[3].EgressGrowth, 1e-9)
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/common/testcontext"
+	"storj.io/common/uuid"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+)
+
+func TestUserValueReport(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 0,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(_ *zap.Logger, _ int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		address := sat.Admin.Admin.Listener.Addr()
+		authToken := sat.Config.Console.AuthToken
+		project := planet.Uplinks[0].Projects[0]
+		link := "http://" + address.String() + "/api/users/" + project.Owner.Email + "/value-report"
+
+		// 100 bytes of egress per day in the full month of January and the
+		// truncated month of February.
+		err := sat.DB.Orders().UpdateBucketBandwidthSettle(ctx, project.ID, []byte("bucket"), pb.PieceAction_GET,
+			3100, 0, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		err = sat.DB.Orders().UpdateBucketBandwidthSettle(ctx, project.ID, []byte("bucket"), pb.PieceAction_GET,
+			900, 0, time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+
+		t.Run("OK", func(t *testing.T) {
+			body := assertReq(ctx, t, link, http.MethodGet, "", http.StatusOK, "", authToken,
+				[2]string{"since", "2024-01-01"}, [2]string{"until", "2024-02-10"})
+
+			var report struct {
+				Email  string `json:"email"`
+				Totals struct {
+					EgressBytes int64 `json:"egressBytes"`
+				} `json:"totals"`
+				Months []struct {
+					Since        time.Time `json:"since"`
+					Before       time.Time `json:"before"`
+					EgressBytes  int64     `json:"egressBytes"`
+					EgressGrowth *float64  `json:"egressGrowth"`
+				} `json:"months"`
+				Projects []struct {
+					ID          uuid.UUID `json:"id"`
+					EgressBytes int64     `json:"egressBytes"`
+				} `json:"projects"`
+			}
+			require.NoError(t, json.Unmarshal(body, &report))
+
+			require.Equal(t, project.Owner.Email, report.Email)
+			require.EqualValues(t, 4000, report.Totals.EgressBytes)
+
+			require.Len(t, report.Months, 2)
+			require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), report.Months[0].Since)
+			require.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), report.Months[0].Before)
+			require.EqualValues(t, 3100, report.Months[0].EgressBytes)
+			require.Nil(t, report.Months[0].EgressGrowth)
+
+			require.Equal(t, time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), report.Months[1].Before)
+			require.EqualValues(t, 900, report.Months[1].EgressBytes)
+			// the truncated month has the same egress per day as the full month.
+			require.NotNil(t, report.Months[1].EgressGrowth)
+			require.InDelta(t, 0, *report.Months[1].EgressGrowth, 1e-9)
+
+			require.Len(t, report.Projects, 1)
+			require.Equal(t, project.ID, report.Projects[0].ID)
+			require.EqualValues(t, 4000, report.Projects[0].EgressBytes)
+		})
+
+		t.Run("invalid period", func(t *testing.T) {
+			body := assertReq(ctx, t, link, http.MethodGet, "", http.StatusBadRequest, "", authToken,
+				[2]string{"since", "2024-02-10"}, [2]string{"until", "2024-01-01"})
+			require.Contains(t, string(body), "since must be before until")
+
+			body = assertReq(ctx, t, link, http.MethodGet, "", http.StatusBadRequest, "", authToken,
+				[2]string{"since", "2020-01-01"}, [2]string{"until", "2024-01-01"})
+			require.Contains(t, string(body), "can't span more than")
+
+			body = assertReq(ctx, t, link, http.MethodGet, "", http.StatusBadRequest, "", authToken,
+				[2]string{"since", "01/01/2024"})
+			require.Contains(t, string(body), "invalid since")
+		})
+
+		t.Run("user not found", func(t *testing.T) {
+			body := assertReq(ctx, t, "http://"+address.String()+"/api/users/unknown@mail.test/value-report",
+				http.MethodGet, "", http.StatusNotFound, "", authToken)
+			require.Contains(t, string(body), "does not exist")
+		})
+	})
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/metabase"
+)
+
+func (server *Server) getZombieDeletion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	project, ok := server.zombieDeletionProject(w, r)
+	if !ok {
+		return
+	}
+	bucketName := metabase.BucketName(r.URL.Query().Get("bucket"))
+
+	inactiveFor, ok, err := server.db.ZombieDeletion().Get(ctx, project.ID, bucketName)
+	if err != nil {
+		sendJSONError(w, "failed to get zombie deletion setting",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		sendJSONError(w, "zombie deletion setting not found",
+			"the default inactivity period is used", http.StatusNotFound)
+		return
+	}
+
+	data, err := json.Marshal(struct {
+		ProjectID   string `json:"projectId"`
+		Bucket      string `json:"bucket,omitempty"`
+		InactiveFor string `json:"inactiveFor"`
+	}{
+		ProjectID:   project.PublicID.String(),
+		Bucket:      string(bucketName),
+		InactiveFor: inactiveFor.String(),
+	})
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) updateZombieDeletion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	project, ok := server.zombieDeletionProject(w, r)
+	if !ok {
+		return
+	}
+	bucketName := metabase.BucketName(r.URL.Query().Get("bucket"))
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		sendJSONError(w, "failed to read body",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var input struct {
+		InactiveFor string `json:"inactiveFor"`
+	}
+	if err = json.Unmarshal(body, &input); err != nil {
+		sendJSONError(w, "failed to unmarshal request",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	inactiveFor, err := time.ParseDuration(input.InactiveFor)
+	if err != nil || inactiveFor < time.Second {
+		sendJSONError(w, "invalid inactiveFor",
+			"inactiveFor must be a duration of at least one second, e.g. 720h", http.StatusBadRequest)
+		return
+	}
+
+	err = server.db.ZombieDeletion().Set(ctx, metabase.ZombieDeletionOverride{
+		ProjectID:   project.ID,
+		BucketName:  bucketName,
+		InactiveFor: inactiveFor,
+	})
+	if err != nil {
+		sendJSONError(w, "failed to update zombie deletion setting",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	server.log.Named("auditlog").Info("zombie deletion setting updated",
+		zap.Stringer("project", project.PublicID),
+		zap.String("bucket", string(bucketName)),
+		zap.Duration("inactive for", inactiveFor),
+		zap.String("updater", r.Header.Get("X-Forwarded-Email")))
+}
+
+func (server *Server) deleteZombieDeletion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	project, ok := server.zombieDeletionProject(w, r)
+	if !ok {
+		return
+	}
+	bucketName := metabase.BucketName(r.URL.Query().Get("bucket"))
+
+	err := server.db.ZombieDeletion().Delete(ctx, project.ID, bucketName)
+	if err != nil {
+		sendJSONError(w, "failed to delete zombie deletion setting",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	server.log.Named("auditlog").Info("zombie deletion setting deleted",
+		zap.Stringer("project", project.PublicID),
+		zap.String("bucket", string(bucketName)),
+		zap.String("updater", r.Header.Get("X-Forwarded-Email")))
+}
+
+// zombieDeletionProject returns the project of the request. It sends an error
+// response and returns false when the project can't be found.
+func (server *Server) zombieDeletionProject(w http.ResponseWriter, r *http.Request) (*console.Project, bool) {
+	projectUUIDString, ok := mux.Vars(r)["project"]
+	if !ok {
+		sendJSONError(w, "project-uuid missing",
+			"", http.StatusBadRequest)
+		return nil, false
+	}
+
+	project, err := server.getProjectByAnyID(r.Context(), projectUUIDString)
+	if errors.Is(err, sql.ErrNoRows) {
+		sendJSONError(w, "project with specified uuid does not exist",
+			"", http.StatusNotFound)
+		return nil, false
+	}
+	if err != nil {
+		sendJSONError(w, "error getting project",
+			err.Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+	return project, true
+}
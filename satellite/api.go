@@ -7,7 +7,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
 	"os"
 	"runtime/pprof"
 	"strings"
@@ -30,10 +32,13 @@ import (
 	"storj.io/storj/private/healthcheck"
 	"storj.io/storj/private/lifecycle"
 	"storj.io/storj/private/server"
+	"storj.io/storj/private/tracing"
 	"storj.io/storj/private/version/checker"
 	"storj.io/storj/satellite/abtesting"
 	"storj.io/storj/satellite/accounting"
 	"storj.io/storj/satellite/analytics"
+	"storj.io/storj/satellite/attribution"
+	"storj.io/storj/satellite/bucketevents"
 	"storj.io/storj/satellite/buckets"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/console/consoleauth"
@@ -43,10 +48,13 @@ import (
 	"storj.io/storj/satellite/contact"
 	"storj.io/storj/satellite/emission"
 	"storj.io/storj/satellite/gracefulexit"
+	"storj.io/storj/satellite/internalpb"
 	"storj.io/storj/satellite/kms"
 	"storj.io/storj/satellite/mailservice"
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/satellite/metainfo/piecedeletion"
+	"storj.io/storj/satellite/netstats"
 	"storj.io/storj/satellite/nodeselection"
 	"storj.io/storj/satellite/nodestats"
 	"storj.io/storj/satellite/oidc"
@@ -57,6 +65,7 @@ import (
 	"storj.io/storj/satellite/payments/stripe"
 	"storj.io/storj/satellite/reputation"
 	"storj.io/storj/satellite/snopayouts"
+	"storj.io/storj/satellite/uplinktelemetry"
 )
 
 // API is the satellite API process.
@@ -80,8 +89,9 @@ type API struct {
 	}
 
 	Debug struct {
-		Listener net.Listener
-		Server   *debug.Server
+		Listener       net.Listener
+		Server         *debug.Server
+		TraceExemplars *tracing.Exemplars
 	}
 
 	Contact struct {
@@ -106,8 +116,14 @@ type API struct {
 	}
 
 	Metainfo struct {
-		Metabase *metabase.DB
-		Endpoint *metainfo.Endpoint
+		Metabase      *metabase.DB
+		PieceDeletion *piecedeletion.Service
+		Endpoint      *metainfo.Endpoint
+	}
+
+	Attribution struct {
+		Recorder     *attribution.Recorder
+		ReportServer *attribution.ReportServer
 	}
 
 	Userinfo struct {
@@ -148,6 +164,10 @@ type API struct {
 		AuthTokens *consoleauth.Service
 	}
 
+	NetworkStats struct {
+		Chore *netstats.Chore
+	}
+
 	NodeStats struct {
 		Endpoint *nodestats.Endpoint
 	}
@@ -186,6 +206,11 @@ type API struct {
 		Server *healthcheck.Server
 	}
 
+	UplinkTelemetry struct {
+		Server   *uplinktelemetry.Server
+		Trackers *uplinktelemetry.Trackers
+	}
+
 	SuccessTrackers *metainfo.SuccessTrackers
 }
 
@@ -225,6 +250,14 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			Run:   peer.Debug.Server.Run,
 			Close: peer.Debug.Server.Close,
 		})
+
+		if config.TraceExemplars.Enabled {
+			peer.Debug.TraceExemplars = tracing.NewExemplars(monkit.Default, config.TraceExemplars)
+			peer.Services.Add(lifecycle.Item{
+				Name: "debug:trace-exemplars",
+				Run:  peer.Debug.TraceExemplars.Run,
+			})
+		}
 	}
 
 	var err error
@@ -312,6 +345,32 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			Run:   peer.Overlay.Service.Run,
 			Close: peer.Overlay.Service.Close,
 		})
+
+		uploadSelectionCache := peer.Overlay.Service.UploadSelectionCache
+		peer.Debug.Server.Panel.Add(&debug.ButtonGroup{
+			Name: "Upload Selection Cache",
+			Buttons: []*debug.Button{
+				{
+					Name: "Refresh",
+					Call: func(w io.Writer) error {
+						if err := uploadSelectionCache.Refresh(context.Background()); err != nil {
+							return err
+						}
+						_, _ = fmt.Fprintln(w, "Refreshed")
+						return nil
+					},
+				}, {
+					Name: "Rollback",
+					Call: func(w io.Writer) error {
+						if err := uploadSelectionCache.Rollback(context.Background()); err != nil {
+							return err
+						}
+						_, _ = fmt.Fprintln(w, "Rolled back to the previous node set")
+						return nil
+					},
+				},
+			},
+		})
 	}
 
 	{ // setup reputation
@@ -337,7 +396,7 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			return nil, err
 		}
 
-		peer.Contact.Service = contact.NewService(peer.Log.Named("contact:service"), peer.Overlay.Service, peer.DB.PeerIdentities(), peer.Dialer, authority, config.Contact)
+		peer.Contact.Service = contact.NewService(peer.Log.Named("contact:service"), peer.ID(), peer.Overlay.Service, peer.DB.PeerIdentities(), peer.Dialer, authority, config.Contact)
 		peer.Contact.Endpoint = contact.NewEndpoint(peer.Log.Named("contact:endpoint"), peer.Contact.Service)
 		if err := pb.DRPCRegisterNode(peer.Server.DRPC(), peer.Contact.Endpoint); err != nil {
 			return nil, errs.Combine(err, peer.Close())
@@ -401,8 +460,9 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			satelliteSignee,
 			peer.Orders.DB,
 			peer.DB.NodeAPIVersion(),
-			config.Orders.OrdersSemaphoreSize,
+			config.Orders.SettlementQueue(),
 			peer.Orders.Service,
+			peer.SuccessTrackers,
 		)
 
 		if err := pb.DRPCRegisterOrders(peer.Server.DRPC(), peer.Orders.Endpoint); err != nil {
@@ -412,6 +472,40 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 
 	{ // setup metainfo
 		peer.Metainfo.Metabase = metabaseDB
+		if config.BucketEvents.Enabled {
+			peer.Metainfo.Metabase.SetDeleteEventsFilter(
+				bucketevents.NewDestinationCache(peer.DB.BucketEvents(), config.BucketEvents))
+		}
+
+		if registry := peer.Metainfo.Metabase.QueryRegistry(); registry != nil {
+			peer.Debug.Server.Panel.Add(registry.DebugButtons())
+		}
+
+		peer.Metainfo.PieceDeletion = piecedeletion.NewService(
+			peer.Log.Named("metainfo:piece-deletion"),
+			peer.Dialer,
+			peer.Overlay.Service,
+			config.Metainfo.PieceDeletion,
+		)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "metainfo:piece-deletion",
+			Close: peer.Metainfo.PieceDeletion.Close,
+		})
+
+		if config.Attribution.Enabled {
+			peer.Attribution.Recorder = attribution.NewRecorder(
+				peer.Log.Named("attribution:recorder"),
+				peer.DB.Attribution(),
+				config.Attribution,
+			)
+			peer.Services.Add(lifecycle.Item{
+				Name:  "attribution:recorder",
+				Run:   peer.Attribution.Recorder.Run,
+				Close: peer.Attribution.Recorder.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Attribution Recorder", peer.Attribution.Recorder.Loop))
+		}
 
 		peer.Metainfo.Endpoint, err = metainfo.NewEndpoint(
 			peer.Log.Named("metainfo:endpoint"),
@@ -420,6 +514,7 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			peer.Orders.Service,
 			peer.Overlay.Service,
 			peer.DB.Attribution(),
+			peer.Attribution.Recorder,
 			peer.DB.PeerIdentities(),
 			peer.DB.Console().APIKeys(),
 			peer.Accounting.ProjectUsage,
@@ -429,6 +524,7 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			signing.SignerFromFullIdentity(peer.Identity),
 			peer.DB.Revocation(),
 			peer.SuccessTrackers,
+			peer.Metainfo.PieceDeletion,
 			config.Metainfo,
 			placement,
 		)
@@ -439,6 +535,18 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 		if err := pb.DRPCRegisterMetainfo(peer.Server.DRPC(), peer.Metainfo.Endpoint); err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
+		if err := internalpb.DRPCRegisterBucketCORS(peer.Server.DRPC(), peer.Metainfo.Endpoint); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		if err := internalpb.DRPCRegisterObjectTags(peer.Server.DRPC(), peer.Metainfo.Endpoint); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		if err := internalpb.DRPCRegisterBucketLifecycle(peer.Server.DRPC(), peer.Metainfo.Endpoint); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		if err := internalpb.DRPCRegisterObjectLegalHold(peer.Server.DRPC(), peer.Metainfo.Endpoint); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
 
 		peer.Services.Add(lifecycle.Item{
 			Name:  "metainfo:endpoint",
@@ -491,7 +599,8 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 		peer.SNOPayouts.DB = peer.DB.SNOPayouts()
 		peer.SNOPayouts.Service = snopayouts.NewService(
 			peer.Log.Named("payouts:service"),
-			peer.SNOPayouts.DB)
+			peer.SNOPayouts.DB,
+			signing.SignerFromFullIdentity(peer.Identity))
 		peer.SNOPayouts.Endpoint = snopayouts.NewEndpoint(
 			peer.Log.Named("payouts:endpoint"),
 			peer.DB.StoragenodeAccounting(),
@@ -674,6 +783,7 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 				peer.DB.ProjectAccounting(),
 				peer.Accounting.ProjectUsage,
 				peer.Buckets.Service,
+				metabaseDB,
 				peer.Payments.Accounts,
 				peer.Payments.DepositWallets,
 				peer.DB.Billing(),
@@ -701,6 +811,19 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 				return nil, errs.Combine(err, peer.Close())
 			}
 
+			var networkStats http.Handler
+			if config.NetworkStats.Enabled {
+				peer.NetworkStats.Chore = netstats.NewChore(peer.Log.Named("netstats:chore"), peer.Overlay.Service, peer.LiveAccounting.Cache, config.NetworkStats)
+				peer.Services.Add(lifecycle.Item{
+					Name:  "netstats:chore",
+					Run:   peer.NetworkStats.Chore.Run,
+					Close: peer.NetworkStats.Chore.Close,
+				})
+				peer.Debug.Server.Panel.Add(
+					debug.Cycle("Network Stats Chore", peer.NetworkStats.Chore.Loop))
+				networkStats = peer.NetworkStats.Chore
+			}
+
 			peer.Console.Endpoint = consoleweb.NewServer(
 				peer.Log.Named("console:endpoint"),
 				consoleConfig,
@@ -716,6 +839,7 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 				peer.URL(),
 				config.Analytics,
 				config.Payments.PackagePlans,
+				networkStats,
 			)
 
 			peer.Servers.Add(lifecycle.Item{
@@ -742,6 +866,56 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 				})
 			}
 		}
+
+		{ // setup uplink telemetry
+			if config.UplinkTelemetry.Enabled {
+				if config.UplinkTelemetry.UpdateSuccessTracker {
+					newTracker, ok := metainfo.GetNewSuccessTracker(config.Metainfo.SuccessTrackerKind)
+					if !ok {
+						return nil, errs.Combine(errs.New("Unknown success tracker kind %q", config.Metainfo.SuccessTrackerKind), peer.Close())
+					}
+					peer.UplinkTelemetry.Trackers = uplinktelemetry.NewTrackers(func() uplinktelemetry.SuccessTracker {
+						return newTracker()
+					}, config.UplinkTelemetry.MaxReporters)
+				}
+
+				listener, err := net.Listen("tcp", config.UplinkTelemetry.Address)
+				if err != nil {
+					return nil, errs.Combine(err, peer.Close())
+				}
+
+				srv := uplinktelemetry.NewServer(peer.Log.Named("uplinktelemetry:server"), listener,
+					peer.DB.Console().APIKeys(), peer.DB.Revocation(), peer.UplinkTelemetry.Trackers, config.UplinkTelemetry)
+				peer.UplinkTelemetry.Server = srv
+
+				peer.Servers.Add(lifecycle.Item{
+					Name:  "uplinktelemetry",
+					Run:   srv.Run,
+					Close: srv.Close,
+				})
+			}
+		}
+
+		{ // setup partner reports
+			if config.Attribution.Report.Enabled {
+				listener, err := net.Listen("tcp", config.Attribution.Report.Address)
+				if err != nil {
+					return nil, errs.Combine(err, peer.Close())
+				}
+
+				srv, err := attribution.NewReportServer(peer.Log.Named("attribution:report"), listener, peer.DB.Attribution(), config.Attribution.Report)
+				if err != nil {
+					return nil, errs.Combine(err, listener.Close(), peer.Close())
+				}
+				peer.Attribution.ReportServer = srv
+
+				peer.Servers.Add(lifecycle.Item{
+					Name:  "attribution:report",
+					Run:   srv.Run,
+					Close: srv.Close,
+				})
+			}
+		}
 	}
 
 	return peer, nil
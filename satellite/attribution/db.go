@@ -36,6 +36,17 @@ type BucketUsage struct {
 	Hours        int
 }
 
+// Rollup is the amount of objects and bytes committed to a bucket during a
+// day, attributed to a partner.
+type Rollup struct {
+	ProjectID     uuid.UUID
+	BucketName    []byte
+	Partner       string
+	IntervalStart time.Time
+	Objects       int64
+	Bytes         int64
+}
+
 // DB implements the database for value attribution table.
 //
 // architecture: Database
@@ -50,4 +61,8 @@ type DB interface {
 	QueryAttribution(ctx context.Context, userAgent []byte, start time.Time, end time.Time) ([]*BucketUsage, error)
 	// QueryAllAttribution queries all partner bucket usage data.
 	QueryAllAttribution(ctx context.Context, start time.Time, end time.Time) ([]*BucketUsage, error)
+	// IncrementPartnerRollups adds the objects and bytes of the rollups to the stored rollups.
+	IncrementPartnerRollups(ctx context.Context, rollups []Rollup) error
+	// QueryPartnerRollups queries the rollups of the partner with an interval start in [start, end).
+	QueryPartnerRollups(ctx context.Context, partner string, start, end time.Time) ([]Rollup, error)
 }
@@ -551,3 +551,42 @@ func insertTallyData(ctx *testcontext.Context, projectAccoutingDB accounting.Pro
 	}
 	return tally, nil
 }
+
+func TestPartnerRollups(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		attributionDB := db.Attribution()
+		project1, project2 := testrand.UUID(), testrand.UUID()
+		day1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		day2 := day1.AddDate(0, 0, 1)
+
+		err := attributionDB.IncrementPartnerRollups(ctx, []attribution.Rollup{
+			{ProjectID: project1, BucketName: []byte("alpha"), Partner: "partner1", IntervalStart: day1, Objects: 1, Bytes: 100},
+			{ProjectID: project1, BucketName: []byte("alpha"), Partner: "partner2", IntervalStart: day1, Objects: 1, Bytes: 100},
+			{ProjectID: project2, BucketName: []byte("beta"), Partner: "partner1", IntervalStart: day2, Objects: 2, Bytes: 50},
+		})
+		require.NoError(t, err)
+
+		// incrementing an existing rollup adds to it.
+		err = attributionDB.IncrementPartnerRollups(ctx, []attribution.Rollup{
+			{ProjectID: project1, BucketName: []byte("alpha"), Partner: "partner1", IntervalStart: day1, Objects: 3, Bytes: 300},
+		})
+		require.NoError(t, err)
+
+		rollups, err := attributionDB.QueryPartnerRollups(ctx, "partner1", day1, day2.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		require.ElementsMatch(t, []attribution.Rollup{
+			{ProjectID: project1, BucketName: []byte("alpha"), Partner: "partner1", IntervalStart: day1, Objects: 4, Bytes: 400},
+			{ProjectID: project2, BucketName: []byte("beta"), Partner: "partner1", IntervalStart: day2, Objects: 2, Bytes: 50},
+		}, rollups)
+
+		// the end of the range is exclusive.
+		rollups, err = attributionDB.QueryPartnerRollups(ctx, "partner1", day1, day2)
+		require.NoError(t, err)
+		require.Len(t, rollups, 1)
+		require.Equal(t, project1, rollups[0].ProjectID)
+
+		rollups, err = attributionDB.QueryPartnerRollups(ctx, "unknown", day1, day2)
+		require.NoError(t, err)
+		require.Empty(t, rollups)
+	})
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package attribution
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/errs2"
+	"storj.io/common/sync2"
+	"storj.io/common/uuid"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the error class for this package.
+	Error = errs.Class("attribution")
+)
+
+// Config contains the configurable values of the partner attribution.
+type Config struct {
+	Enabled              bool          `help:"whether the objects committed with a partner user agent are aggregated into daily partner rollups" default:"false"`
+	FlushInterval        time.Duration `help:"how often the aggregated partner rollups are written to the database; the usage since the last flush is lost on a crash" default:"1m" testDefault:"$TESTINTERVAL"`
+	MaxPartnersPerObject int           `help:"the maximum number of user agent products an object is attributed to" default:"3"`
+
+	Report ReportConfig
+}
+
+// rollupKey identifies a rollup.
+type rollupKey struct {
+	projectID     uuid.UUID
+	bucketName    string
+	partner       string
+	intervalStart time.Time
+}
+
+// rollupValue is the aggregated usage of a rollup.
+type rollupValue struct {
+	objects int64
+	bytes   int64
+}
+
+// Recorder aggregates the committed objects of the partners in memory and
+// periodically adds them to the daily partner rollups in the database.
+//
+// Unlike the value attribution of a bucket, which is set once for the first
+// partner, every partner in the user agent of an upload is recorded.
+//
+// The rollups are best-effort: the commit is acknowledged before its usage
+// is written, so the usage aggregated since the last flush is lost when the
+// process crashes. A graceful shutdown flushes it in Close. The rollups are
+// meant for partner reporting and must not be used for billing.
+//
+// architecture: Service
+type Recorder struct {
+	log    *zap.Logger
+	db     DB
+	config Config
+	now    func() time.Time
+
+	Loop *sync2.Cycle
+
+	mu      sync.Mutex
+	rollups map[rollupKey]rollupValue
+}
+
+// NewRecorder creates a new partner rollup recorder.
+func NewRecorder(log *zap.Logger, db DB, config Config) *Recorder {
+	return &Recorder{
+		log:     log,
+		db:      db,
+		config:  config,
+		now:     time.Now,
+		Loop:    sync2.NewCycle(config.FlushInterval),
+		rollups: map[rollupKey]rollupValue{},
+	}
+}
+
+// SetNow sets the function used to get the current time. It's used for testing.
+func (recorder *Recorder) SetNow(now func() time.Time) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	recorder.now = now
+}
+
+// Record records a committed object of the partners. It's a no-op when the
+// recorder is nil.
+func (recorder *Recorder) Record(projectID uuid.UUID, bucketName []byte, partners []string, bytes int64) {
+	if recorder == nil || len(partners) == 0 {
+		return
+	}
+	if limit := recorder.config.MaxPartnersPerObject; limit > 0 && len(partners) > limit {
+		partners = partners[:limit]
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	now := recorder.now().UTC()
+	intervalStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	for _, partner := range partners {
+		key := rollupKey{
+			projectID:     projectID,
+			bucketName:    string(bucketName),
+			partner:       partner,
+			intervalStart: intervalStart,
+		}
+		value := recorder.rollups[key]
+		value.objects++
+		value.bytes += bytes
+		recorder.rollups[key] = value
+	}
+}
+
+// Run periodically writes the aggregated rollups to the database.
+func (recorder *Recorder) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return errs2.IgnoreCanceled(recorder.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := recorder.Flush(ctx); err != nil {
+			recorder.log.Error("failed to flush partner rollups", zap.Error(err))
+		}
+		return nil
+	}))
+}
+
+// Flush writes the aggregated rollups to the database. The rollups are kept
+// in memory when the write fails, so they're retried with the next flush.
+func (recorder *Recorder) Flush(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	recorder.mu.Lock()
+	pending := recorder.rollups
+	recorder.rollups = map[rollupKey]rollupValue{}
+	recorder.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	rollups := make([]Rollup, 0, len(pending))
+	for key, value := range pending {
+		rollups = append(rollups, Rollup{
+			ProjectID:     key.projectID,
+			BucketName:    []byte(key.bucketName),
+			Partner:       key.partner,
+			IntervalStart: key.intervalStart,
+			Objects:       value.objects,
+			Bytes:         value.bytes,
+		})
+	}
+
+	if err := recorder.db.IncrementPartnerRollups(ctx, rollups); err != nil {
+		recorder.mu.Lock()
+		for key, value := range pending {
+			current := recorder.rollups[key]
+			current.objects += value.objects
+			current.bytes += value.bytes
+			recorder.rollups[key] = current
+		}
+		recorder.mu.Unlock()
+		return Error.Wrap(err)
+	}
+
+	mon.IntVal("partner_rollups_flushed").Observe(int64(len(rollups)))
+	return nil
+}
+
+// Close stops the loop and writes the remaining rollups to the database.
+func (recorder *Recorder) Close() error {
+	recorder.Loop.Close()
+	return recorder.Flush(context.Background())
+}
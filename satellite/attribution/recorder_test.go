@@ -0,0 +1,210 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package attribution_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/attribution"
+)
+
+// rollupsDB is an in-memory implementation of the partner rollup methods.
+type rollupsDB struct {
+	attribution.DB
+
+	mu      sync.Mutex
+	fail    bool
+	rollups []attribution.Rollup
+}
+
+func (db *rollupsDB) IncrementPartnerRollups(ctx context.Context, rollups []attribution.Rollup) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.fail {
+		return errors.New("failure")
+	}
+next:
+	for _, rollup := range rollups {
+		for i := range db.rollups {
+			existing := &db.rollups[i]
+			if existing.ProjectID == rollup.ProjectID && string(existing.BucketName) == string(rollup.BucketName) &&
+				existing.Partner == rollup.Partner && existing.IntervalStart.Equal(rollup.IntervalStart) {
+				existing.Objects += rollup.Objects
+				existing.Bytes += rollup.Bytes
+				continue next
+			}
+		}
+		db.rollups = append(db.rollups, rollup)
+	}
+	return nil
+}
+
+func (db *rollupsDB) QueryPartnerRollups(ctx context.Context, partner string, start, end time.Time) (rollups []attribution.Rollup, _ error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, rollup := range db.rollups {
+		if rollup.Partner == partner && !rollup.IntervalStart.Before(start) && rollup.IntervalStart.Before(end) {
+			rollups = append(rollups, rollup)
+		}
+	}
+	sort.Slice(rollups, func(i, k int) bool {
+		return rollups[i].IntervalStart.Before(rollups[k].IntervalStart)
+	})
+	return rollups, nil
+}
+
+func TestRecorder(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	db := &rollupsDB{}
+	recorder := attribution.NewRecorder(zaptest.NewLogger(t), db, attribution.Config{
+		FlushInterval:        time.Hour,
+		MaxPartnersPerObject: 2,
+	})
+
+	day1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := day1.Add(10 * time.Hour)
+	recorder.SetNow(func() time.Time { return now })
+
+	projectID := testrand.UUID()
+	bucket := []byte("bucket")
+
+	recorder.Record(projectID, bucket, []string{"partner1"}, 100)
+	recorder.Record(projectID, bucket, []string{"partner1", "partner2", "partner3"}, 50)
+	recorder.Record(projectID, bucket, nil, 1000)
+
+	// the write fails, so the rollups are kept for the next flush.
+	db.fail = true
+	require.Error(t, recorder.Flush(ctx))
+	require.Empty(t, db.rollups)
+	db.fail = false
+
+	now = day1.Add(25 * time.Hour)
+	recorder.Record(projectID, bucket, []string{"partner1"}, 10)
+	require.NoError(t, recorder.Flush(ctx))
+
+	day2 := day1.AddDate(0, 0, 1)
+	require.ElementsMatch(t, []attribution.Rollup{
+		{ProjectID: projectID, BucketName: bucket, Partner: "partner1", IntervalStart: day1, Objects: 2, Bytes: 150},
+		{ProjectID: projectID, BucketName: bucket, Partner: "partner2", IntervalStart: day1, Objects: 1, Bytes: 50},
+		{ProjectID: projectID, BucketName: bucket, Partner: "partner1", IntervalStart: day2, Objects: 1, Bytes: 10},
+	}, db.rollups)
+
+	// closing flushes the remaining rollups.
+	recorder.Record(projectID, bucket, []string{"partner2"}, 5)
+	require.NoError(t, recorder.Close())
+	require.Len(t, db.rollups, 4)
+
+	// a nil recorder ignores the records.
+	var disabled *attribution.Recorder
+	disabled.Record(projectID, bucket, []string{"partner1"}, 1)
+}
+
+func TestReportServer(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	projectID := testrand.UUID()
+	day1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	db := &rollupsDB{rollups: []attribution.Rollup{
+		{ProjectID: projectID, BucketName: []byte("alpha"), Partner: "partner1", IntervalStart: day1, Objects: 2, Bytes: 150},
+		{ProjectID: projectID, BucketName: []byte("alpha"), Partner: "partner1", IntervalStart: day1.AddDate(0, 0, 1), Objects: 1, Bytes: 10},
+		{ProjectID: projectID, BucketName: []byte("alpha"), Partner: "partner2", IntervalStart: day1, Objects: 1, Bytes: 50},
+	}}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	_, err = attribution.NewReportServer(zaptest.NewLogger(t), listener, db, attribution.ReportConfig{
+		Keys: []string{"partner1"},
+	})
+	require.Error(t, err)
+
+	server, err := attribution.NewReportServer(zaptest.NewLogger(t), listener, db, attribution.ReportConfig{
+		Keys:    []string{"partner1:secret1", "partner2:secret2"},
+		MaxDays: 31,
+	})
+	require.NoError(t, err)
+
+	ctx.Go(func() error { return server.Run(ctx) })
+	defer ctx.Check(server.Close)
+
+	request := func(t *testing.T, partner, key, query string) (int, *attribution.Report) {
+		url := "http://" + server.TestGetAddress() + "/v1/partners/" + partner + "/report?" + query
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		require.NoError(t, err)
+		if key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, resp.Body.Close()) }()
+
+		if resp.StatusCode != http.StatusOK {
+			return resp.StatusCode, nil
+		}
+		var report attribution.Report
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+		return resp.StatusCode, &report
+	}
+
+	const query = "start=2024-01-01&end=2024-01-02"
+
+	t.Run("unauthorized", func(t *testing.T) {
+		status, _ := request(t, "partner1", "", query)
+		require.Equal(t, http.StatusUnauthorized, status)
+		status, _ = request(t, "partner1", "secret2", query)
+		require.Equal(t, http.StatusUnauthorized, status)
+		status, _ = request(t, "unknown", "secret1", query)
+		require.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		for _, query := range []string{
+			"",
+			"start=2024-01-01",
+			"start=2024-01-02&end=2024-01-01",
+			"start=2024-01-01&end=2024-03-01",
+		} {
+			status, _ := request(t, "partner1", "secret1", query)
+			require.Equal(t, http.StatusBadRequest, status, query)
+		}
+	})
+
+	t.Run("report", func(t *testing.T) {
+		status, report := request(t, "partner1", "secret1", query)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, &attribution.Report{
+			Partner: "partner1",
+			Start:   "2024-01-01",
+			End:     "2024-01-02",
+			Rows: []attribution.ReportRow{
+				{ProjectID: projectID, BucketName: "alpha", Date: "2024-01-01", Objects: 2, Bytes: 150},
+			},
+			Objects: 2,
+			Bytes:   150,
+		}, report)
+
+		status, report = request(t, "partner1", "secret1", "start=2024-01-01&end=2024-01-31")
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, report.Rows, 2)
+		require.EqualValues(t, 3, report.Objects)
+		require.EqualValues(t, 160, report.Bytes)
+	})
+}
@@ -0,0 +1,217 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package attribution
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/common/errs2"
+	"storj.io/common/uuid"
+	"storj.io/storj/private/web"
+)
+
+// reportDateFormat is the format of the dates in the partner reports.
+const reportDateFormat = "2006-01-02"
+
+// ReportConfig contains the configurable values of the partner report server.
+type ReportConfig struct {
+	Enabled bool     `help:"whether the partner report server is enabled" default:"false"`
+	Address string   `help:"the address to listen on for partner report requests" default:":10601" testDefault:"127.0.0.1:0"`
+	Keys    []string `help:"comma separated list of partner:key pairs, the key allows the partner to query its reports" default:""`
+	MaxDays int      `help:"the maximum number of days a single report can cover" default:"93"`
+}
+
+// ReportRow is the usage of a bucket during a day in a partner report.
+type ReportRow struct {
+	ProjectID  uuid.UUID `json:"projectId"`
+	BucketName string    `json:"bucketName"`
+	Date       string    `json:"date"`
+	Objects    int64     `json:"objects"`
+	Bytes      int64     `json:"bytes"`
+}
+
+// Report is the usage of a partner in a range of days.
+type Report struct {
+	Partner string      `json:"partner"`
+	Start   string      `json:"start"`
+	End     string      `json:"end"`
+	Rows    []ReportRow `json:"rows"`
+	Objects int64       `json:"objects"`
+	Bytes   int64       `json:"bytes"`
+}
+
+// ReportServer serves the daily partner rollups to the partners.
+//
+// architecture: Endpoint
+type ReportServer struct {
+	log    *zap.Logger
+	db     DB
+	config ReportConfig
+	keys   map[string]string
+
+	listener net.Listener
+	server   http.Server
+}
+
+// NewReportServer creates a new partner report server.
+func NewReportServer(log *zap.Logger, listener net.Listener, db DB, config ReportConfig) (*ReportServer, error) {
+	keys := map[string]string{}
+	for _, pair := range config.Keys {
+		partner, key, ok := strings.Cut(pair, ":")
+		if !ok || partner == "" || key == "" {
+			return nil, Error.New("invalid partner key for %q, expected partner:key", partner)
+		}
+		keys[partner] = key
+	}
+
+	srv := &ReportServer{
+		log:      log,
+		db:       db,
+		config:   config,
+		keys:     keys,
+		listener: listener,
+	}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/v1/partners/{partner}/report", srv.handleReport).Methods(http.MethodGet)
+
+	srv.server = http.Server{
+		Handler: router,
+	}
+
+	return srv, nil
+}
+
+func (s *ReportServer) handleReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	partner := mux.Vars(r)["partner"]
+	if !s.authorized(partner, r.Header.Get("Authorization")) {
+		web.ServeJSONError(ctx, s.log, w, http.StatusUnauthorized, Error.New("unauthorized"))
+		return
+	}
+
+	start, end, err := s.parseRange(r)
+	if err != nil {
+		web.ServeJSONError(ctx, s.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	report, err := s.Report(ctx, partner, start, end)
+	if err != nil {
+		s.log.Error("failed to query partner rollups", zap.String("partner", partner), zap.Error(err))
+		web.ServeJSONError(ctx, s.log, w, http.StatusInternalServerError, Error.New("failed to create the report"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(report); err != nil {
+		s.log.Error("failed to encode partner report", zap.Error(Error.Wrap(err)))
+	}
+}
+
+// authorized returns whether the authorization header contains the key of the partner.
+func (s *ReportServer) authorized(partner, authorization string) bool {
+	key, ok := s.keys[partner]
+	if !ok {
+		return false
+	}
+	sent, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(sent), []byte(key)) == 1
+}
+
+// parseRange parses the start and the exclusive end dates of the report.
+func (s *ReportServer) parseRange(r *http.Request) (start, end time.Time, err error) {
+	query := r.URL.Query()
+	start, err = time.Parse(reportDateFormat, query.Get("start"))
+	if err != nil {
+		return start, end, Error.New("invalid start date %q, expected YYYY-MM-DD", query.Get("start"))
+	}
+	end, err = time.Parse(reportDateFormat, query.Get("end"))
+	if err != nil {
+		return start, end, Error.New("invalid end date %q, expected YYYY-MM-DD", query.Get("end"))
+	}
+	if !start.Before(end) {
+		return start, end, Error.New("start date must be before the end date")
+	}
+	if s.config.MaxDays > 0 && end.Sub(start) > time.Duration(s.config.MaxDays)*24*time.Hour {
+		return start, end, Error.New("report can't cover more than %d days", s.config.MaxDays)
+	}
+	return start, end, nil
+}
+
+// Report creates the report of the partner for the days in [start, end).
+func (s *ReportServer) Report(ctx context.Context, partner string, start, end time.Time) (_ *Report, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rollups, err := s.db.QueryPartnerRollups(ctx, partner, start, end)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	report := &Report{
+		Partner: partner,
+		Start:   start.Format(reportDateFormat),
+		End:     end.Format(reportDateFormat),
+		Rows:    make([]ReportRow, 0, len(rollups)),
+	}
+	for _, rollup := range rollups {
+		report.Rows = append(report.Rows, ReportRow{
+			ProjectID:  rollup.ProjectID,
+			BucketName: string(rollup.BucketName),
+			Date:       rollup.IntervalStart.UTC().Format(reportDateFormat),
+			Objects:    rollup.Objects,
+			Bytes:      rollup.Bytes,
+		})
+		report.Objects += rollup.Objects
+		report.Bytes += rollup.Bytes
+	}
+	return report, nil
+}
+
+// Run starts the partner report server.
+func (s *ReportServer) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	var group errgroup.Group
+	group.Go(func() error {
+		<-ctx.Done()
+		return s.server.Shutdown(context.Background())
+	})
+	group.Go(func() error {
+		defer cancel()
+		err := s.server.Serve(s.listener)
+		if errs2.IsCanceled(err) || errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+		return err
+	})
+
+	return group.Wait()
+}
+
+// Close stops the server.
+func (s *ReportServer) Close() error {
+	return s.server.Close()
+}
+
+// TestGetAddress returns the address of this server for tests.
+func (s *ReportServer) TestGetAddress() string {
+	return s.listener.Addr().String()
+}
@@ -6,6 +6,7 @@ package audit
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
@@ -89,6 +90,8 @@ func (reporter *reporter) RecordAudits(ctx context.Context, req Report) {
 		zap.Int("pending", len(pendingAudits)),
 	)
 
+	offlines = reporter.withoutNodesInMaintenance(ctx, offlines)
+
 	nodesReputation := req.NodesReputation
 
 	reportFailures := func(tries int, resultType string, err error, nodes storj.NodeIDList, pending []*ReverificationJob) {
@@ -122,6 +125,33 @@ func (reporter *reporter) RecordAudits(ctx context.Context, req Report) {
 	}
 }
 
+// withoutNodesInMaintenance removes the nodes which are within their declared
+// maintenance window, so they aren't penalized for being offline.
+func (reporter *reporter) withoutNodesInMaintenance(ctx context.Context, nodeIDs storj.NodeIDList) storj.NodeIDList {
+	if len(nodeIDs) == 0 {
+		return nodeIDs
+	}
+
+	inMaintenance, err := reporter.overlay.NodesInMaintenance(ctx, nodeIDs, time.Now())
+	if err != nil {
+		reporter.log.Warn("failed to check node maintenance windows", zap.Error(err))
+		return nodeIDs
+	}
+	if len(inMaintenance) == 0 {
+		return nodeIDs
+	}
+
+	filtered := make(storj.NodeIDList, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		if inMaintenance[nodeID] {
+			mon.Meter("audit_offline_in_maintenance").Mark(1)
+			continue
+		}
+		filtered = append(filtered, nodeID)
+	}
+	return filtered
+}
+
 func (reporter *reporter) recordAuditStatus(ctx context.Context, nodeIDs storj.NodeIDList, nodesReputation map[storj.NodeID]overlay.ReputationStatus, auditOutcome reputation.AuditType) (failed storj.NodeIDList, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -0,0 +1,166 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bucketevents
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the default error class for the package.
+	Error = errs.Class("bucketevents")
+	// ErrDestinationNotFound is returned when the bucket has no destination.
+	ErrDestinationNotFound = errs.Class("bucket event destination not found")
+	// ErrInvalid is returned when a destination is invalid.
+	ErrInvalid = errs.Class("invalid bucket event destination")
+)
+
+const (
+	// EventObjectRemoved is the name of the event of a removed object version.
+	EventObjectRemoved = "ObjectRemoved:Delete"
+	// EventDeleteMarkerCreated is the name of the event of an added delete marker.
+	EventDeleteMarkerCreated = "ObjectRemoved:DeleteMarkerCreated"
+	// EventLifecycleExpiration is the name of the event of an expired object.
+	EventLifecycleExpiration = "LifecycleExpiration:Delete"
+)
+
+// Config contains configurable values for the bucket delete events.
+type Config struct {
+	Enabled       bool          `help:"whether to record and publish the delete events of the buckets with a destination" default:"false"`
+	Publisher     string        `help:"the event bus the events are published to: webhook, kafka-rest or pubsub" default:"webhook"`
+	Endpoint      string        `help:"the base URL of the event bus" default:""`
+	AuthToken     string        `help:"bearer token sent with the publish requests" default:""`
+	Interval      time.Duration `help:"how often the recorded events are published" default:"10s"`
+	Timeout       time.Duration `help:"timeout of a single publish request" default:"10s"`
+	BatchSize     int           `help:"maximum number of events published in a single run" default:"100"`
+	RetryDelay    time.Duration `help:"delay before the first retry of a failed event, doubled after every failed attempt" default:"30s"`
+	MaxRetryDelay time.Duration `help:"maximum delay between the retries of a failed event" default:"1h"`
+
+	CacheExpiration time.Duration `help:"how long the destinations of the buckets are cached by the peers recording the deletions" default:"1m"`
+	CacheCapacity   int           `help:"number of bucket destinations to cache" default:"10000"`
+}
+
+// Destination is the event bus topic the delete events of a bucket are published to.
+type Destination struct {
+	ProjectID  uuid.UUID `json:"projectId"`
+	BucketName string    `json:"bucketName"`
+	Topic      string    `json:"topic"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// Event is a recorded delete event of an object.
+type Event struct {
+	ID         uuid.UUID
+	Name       string
+	ProjectID  uuid.UUID
+	BucketName string
+	ObjectKey  metabase.ObjectKey
+	Version    metabase.Version
+	StreamID   uuid.UUID
+	Size       int64
+	DeletedAt  time.Time
+	Topic      string
+
+	// Attempts is the number of failed attempts to publish the event.
+	Attempts      int
+	NextAttemptAt time.Time
+}
+
+// DB stores the bucket destinations and the events which weren't published yet.
+//
+// architecture: Database
+type DB interface {
+	// SetDestination inserts or updates the destination of a bucket.
+	SetDestination(ctx context.Context, destination Destination) error
+	// GetDestination returns the destination of a bucket. It returns ErrDestinationNotFound when it doesn't exist.
+	GetDestination(ctx context.Context, projectID uuid.UUID, bucketName string) (Destination, error)
+	// DeleteDestination removes the destination of a bucket.
+	DeleteDestination(ctx context.Context, projectID uuid.UUID, bucketName string) error
+
+	// Insert queues events. The events which are already queued are ignored.
+	Insert(ctx context.Context, events []Event) error
+	// ListDue returns up to limit events whose next attempt is due, the longest waiting first.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]Event, error)
+	// Delete removes published events.
+	Delete(ctx context.Context, ids []uuid.UUID) error
+	// Retry increments the attempts of failed events and postpones their next attempt.
+	Retry(ctx context.Context, ids []uuid.UUID, nextAttemptAt time.Time) error
+}
+
+// Service manages the bucket destinations.
+//
+// architecture: Service
+type Service struct {
+	log *zap.Logger
+	db  DB
+
+	nowFn func() time.Time
+}
+
+// NewService creates a new bucket events service.
+func NewService(log *zap.Logger, db DB) *Service {
+	return &Service{
+		log:   log,
+		db:    db,
+		nowFn: time.Now,
+	}
+}
+
+// SetDestination publishes the delete events of the bucket to the topic. The
+// peers recording the deletions notice the new destination after at most
+// CacheExpiration.
+func (service *Service) SetDestination(ctx context.Context, projectID uuid.UUID, bucketName, topic string) (_ Destination, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	topic = strings.TrimSpace(topic)
+	if topic == "" {
+		return Destination{}, ErrInvalid.New("topic is missing")
+	}
+	if bucketName == "" {
+		return Destination{}, ErrInvalid.New("bucket name is missing")
+	}
+
+	destination := Destination{
+		ProjectID:  projectID,
+		BucketName: bucketName,
+		Topic:      topic,
+		CreatedAt:  service.nowFn(),
+	}
+	if err := service.db.SetDestination(ctx, destination); err != nil {
+		return Destination{}, Error.Wrap(err)
+	}
+	return destination, nil
+}
+
+// GetDestination returns the destination of the bucket.
+func (service *Service) GetDestination(ctx context.Context, projectID uuid.UUID, bucketName string) (_ Destination, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return service.db.GetDestination(ctx, projectID, bucketName)
+}
+
+// DeleteDestination stops publishing the delete events of the bucket. The
+// events which were already queued are still published, the deletions which
+// weren't relayed to the queue yet are dropped.
+func (service *Service) DeleteDestination(ctx context.Context, projectID uuid.UUID, bucketName string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return Error.Wrap(service.db.DeleteDestination(ctx, projectID, bucketName))
+}
+
+// TestSetNow sets the function which returns the current time.
+func (service *Service) TestSetNow(nowFn func() time.Time) {
+	service.nowFn = nowFn
+}
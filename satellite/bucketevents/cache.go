@@ -0,0 +1,49 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bucketevents
+
+import (
+	"context"
+
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/shared/lrucache"
+)
+
+// DestinationCache caches whether the buckets have a destination. It decides
+// which deletions metabase records in its outbox.
+type DestinationCache struct {
+	db    DB
+	cache *lrucache.ExpiringLRUOf[bool]
+}
+
+var _ metabase.DeleteEventsFilter = (*DestinationCache)(nil)
+
+// NewDestinationCache creates a new cache of the bucket destinations.
+func NewDestinationCache(db DB, config Config) *DestinationCache {
+	return &DestinationCache{
+		db: db,
+		cache: lrucache.NewOf[bool](lrucache.Options{
+			Expiration: config.CacheExpiration,
+			Capacity:   config.CacheCapacity,
+			Name:       "bucketevents-destinations",
+		}),
+	}
+}
+
+// RecordsDeleteEvents returns whether the bucket has a destination.
+func (cache *DestinationCache) RecordsDeleteEvents(ctx context.Context, bucket metabase.BucketLocation) (_ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	key := string(bucket.ProjectID.Bytes()) + "/" + bucket.BucketName.String()
+	return cache.cache.Get(ctx, key, func() (bool, error) {
+		_, err := cache.db.GetDestination(ctx, bucket.ProjectID, bucket.BucketName.String())
+		if ErrDestinationNotFound.Has(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, Error.Wrap(err)
+		}
+		return true, nil
+	})
+}
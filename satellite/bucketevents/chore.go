@@ -0,0 +1,284 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bucketevents
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/metabase"
+)
+
+// Chore relays the deletions recorded by metabase to the queue of the events
+// and publishes the queued events to the event bus.
+//
+// A deletion is removed from the metabase outbox only after it was queued and
+// an event is removed from the queue only after the event bus accepted it, the
+// failed events are retried with an exponential backoff. Hence every event is
+// delivered at least once, but it may be delivered more than once.
+//
+// architecture: Chore
+type Chore struct {
+	log       *zap.Logger
+	db        DB
+	metabase  *metabase.DB
+	projects  console.Projects
+	publisher Publisher
+	config    Config
+
+	nowFn func() time.Time
+	Loop  *sync2.Cycle
+}
+
+// NewChore is a constructor for Chore.
+func NewChore(log *zap.Logger, db DB, metabaseDB *metabase.DB, projects console.Projects, publisher Publisher, config Config) *Chore {
+	return &Chore{
+		log:       log,
+		db:        db,
+		metabase:  metabaseDB,
+		projects:  projects,
+		publisher: publisher,
+		config:    config,
+		nowFn:     time.Now,
+		Loop:      sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run runs the chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, func(ctx context.Context) (err error) {
+		if err := chore.RunOnce(ctx); err != nil {
+			chore.log.Error("failed to publish bucket delete events", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// RunOnce relays the recorded deletions and publishes the events whose next
+// attempt is due.
+func (chore *Chore) RunOnce(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	now := chore.nowFn()
+	if err := chore.relay(ctx, now); err != nil {
+		return Error.Wrap(err)
+	}
+
+	events, err := chore.db.ListDue(ctx, now, chore.config.BatchSize)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var topics []string
+	byTopic := map[string][]Event{}
+	for _, event := range events {
+		if _, ok := byTopic[event.Topic]; !ok {
+			topics = append(topics, event.Topic)
+		}
+		byTopic[event.Topic] = append(byTopic[event.Topic], event)
+	}
+
+	publicIDs := map[uuid.UUID]uuid.UUID{}
+	for _, topic := range topics {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		events := byTopic[topic]
+		if err := chore.publish(ctx, topic, events, publicIDs); err != nil {
+			chore.log.Warn("failed to publish bucket delete events",
+				zap.String("topic", topic),
+				zap.Int("events", len(events)),
+				zap.Error(err))
+			mon.Counter("bucket_delete_events_failed").Inc(int64(len(events)))
+
+			if err := chore.retry(ctx, events, now); err != nil {
+				return Error.Wrap(err)
+			}
+			continue
+		}
+		mon.Counter("bucket_delete_events_published").Inc(int64(len(events)))
+	}
+	return nil
+}
+
+// relay moves the deletions recorded by metabase to the queue of the events.
+// The deletions in the buckets without a destination are dropped.
+func (chore *Chore) relay(ctx context.Context, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	deletions, err := chore.metabase.ListDeleteEvents(ctx, metabase.ListDeleteEvents{
+		Limit: chore.config.BatchSize,
+	})
+	if err != nil || len(deletions) == 0 {
+		return err
+	}
+
+	topics := map[metabase.BucketLocation]string{}
+	events := make([]Event, 0, len(deletions))
+	for _, deletion := range deletions {
+		bucket := metabase.BucketLocation{ProjectID: deletion.ProjectID, BucketName: deletion.BucketName}
+		topic, ok := topics[bucket]
+		if !ok {
+			destination, err := chore.db.GetDestination(ctx, deletion.ProjectID, deletion.BucketName.String())
+			if err != nil && !ErrDestinationNotFound.Has(err) {
+				return err
+			}
+			topic = destination.Topic
+			topics[bucket] = topic
+		}
+		if topic == "" {
+			continue
+		}
+
+		events = append(events, Event{
+			ID:            deleteEventID(deletion),
+			Name:          deleteEventName(deletion.Kind),
+			ProjectID:     deletion.ProjectID,
+			BucketName:    deletion.BucketName.String(),
+			ObjectKey:     deletion.ObjectKey,
+			Version:       deletion.Version,
+			StreamID:      deletion.StreamID,
+			Size:          deletion.TotalPlainSize,
+			DeletedAt:     deletion.DeletedAt,
+			Topic:         topic,
+			NextAttemptAt: now,
+		})
+	}
+
+	// the IDs of the events are derived from the deletions, so relaying them
+	// again after a failure doesn't queue them twice.
+	if err := chore.db.Insert(ctx, events); err != nil {
+		return err
+	}
+	mon.Counter("bucket_delete_events_recorded").Inc(int64(len(events)))
+
+	return chore.metabase.RemoveDeleteEvents(ctx, deletions)
+}
+
+// deleteEventID returns the ID of the event of the deletion.
+func deleteEventID(deletion metabase.DeleteEvent) uuid.UUID {
+	hash := sha256.New()
+	_, _ = hash.Write(deletion.ProjectID.Bytes())
+	_, _ = hash.Write([]byte(deletion.BucketName))
+	_, _ = hash.Write([]byte{0})
+	_, _ = hash.Write([]byte(deletion.ObjectKey))
+	_, _ = hash.Write([]byte{0})
+	_ = binary.Write(hash, binary.BigEndian, int64(deletion.Version))
+	_, _ = hash.Write(deletion.StreamID.Bytes())
+	_ = binary.Write(hash, binary.BigEndian, int64(deletion.Kind))
+
+	var id uuid.UUID
+	copy(id[:], hash.Sum(nil))
+	return id
+}
+
+// deleteEventName returns the name of the event of the deletion kind.
+func deleteEventName(kind metabase.DeleteEventKind) string {
+	switch kind {
+	case metabase.DeleteEventMarkerCreated:
+		return EventDeleteMarkerCreated
+	case metabase.DeleteEventExpired:
+		return EventLifecycleExpiration
+	default:
+		return EventObjectRemoved
+	}
+}
+
+// publish publishes the events to the topic and removes them afterwards.
+func (chore *Chore) publish(ctx context.Context, topic string, events []Event, publicIDs map[uuid.UUID]uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ids := make([]uuid.UUID, 0, len(events))
+	messages := make([]Message, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ID)
+
+		publicID, ok := publicIDs[event.ProjectID]
+		if !ok {
+			project, err := chore.projects.Get(ctx, event.ProjectID)
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				// the project was deleted, nobody is interested in the event anymore.
+				chore.log.Info("dropping delete event of deleted project", zap.Stringer("project", event.ProjectID))
+			case err != nil:
+				return err
+			default:
+				publicID = project.PublicID
+			}
+			publicIDs[event.ProjectID] = publicID
+		}
+		if publicID.IsZero() {
+			continue
+		}
+
+		messages = append(messages, Message{
+			ID:        event.ID,
+			EventName: event.Name,
+			EventTime: event.DeletedAt.UTC(),
+			ProjectID: publicID,
+			Bucket:    event.BucketName,
+			ObjectKey: []byte(event.ObjectKey),
+			VersionID: metabase.NewStreamVersionID(event.Version, event.StreamID).Bytes(),
+			Size:      event.Size,
+		})
+	}
+
+	if len(messages) > 0 {
+		if err := chore.publisher.Publish(ctx, topic, messages); err != nil {
+			return err
+		}
+	}
+
+	// a failure here means the events are published again in the next run.
+	return chore.db.Delete(ctx, ids)
+}
+
+// retry postpones the next attempt of the events. The delay doubles with
+// every failed attempt up to MaxRetryDelay.
+func (chore *Chore) retry(ctx context.Context, events []Event, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// the events of a topic are retried together, so the order of their
+	// delivery is kept as much as possible.
+	attempts := 0
+	ids := make([]uuid.UUID, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ID)
+		if event.Attempts > attempts {
+			attempts = event.Attempts
+		}
+	}
+
+	delay := chore.config.RetryDelay
+	for i := 0; i < attempts && delay < chore.config.MaxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > chore.config.MaxRetryDelay {
+		delay = chore.config.MaxRetryDelay
+	}
+
+	return chore.db.Retry(ctx, ids, now.Add(delay))
+}
+
+// TestSetNow sets the function which returns the current time.
+func (chore *Chore) TestSetNow(nowFn func() time.Time) {
+	chore.nowFn = nowFn
+}
+
+// Close stops the chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
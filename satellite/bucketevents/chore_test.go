@@ -0,0 +1,158 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bucketevents_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/bucketevents"
+	"storj.io/storj/satellite/metabase"
+)
+
+func TestBucketDeleteEvents(t *testing.T) {
+	var mu sync.Mutex
+	var topics []string
+	var messages []bucketevents.Message
+	fail := true
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Topic  string                 `json:"topic"`
+			Events []bucketevents.Message `json:"events"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		mu.Lock()
+		defer mu.Unlock()
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		topics = append(topics, body.Topic)
+		messages = append(messages, body.Events...)
+	}))
+	defer server.Close()
+
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.BucketEvents.Enabled = true
+				config.BucketEvents.Endpoint = server.URL
+				config.BucketEvents.RetryDelay = time.Minute
+				config.BucketEvents.MaxRetryDelay = time.Hour
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		uplink := planet.Uplinks[0]
+		project := uplink.Projects[0]
+		db := sat.DB.BucketEvents()
+
+		chore := sat.Core.BucketEvents.Chore
+		chore.Loop.Pause()
+
+		service := bucketevents.NewService(zaptest.NewLogger(t), db)
+		_, err := service.SetDestination(ctx, project.ID, "watched", " ")
+		require.True(t, bucketevents.ErrInvalid.Has(err))
+		_, err = service.SetDestination(ctx, project.ID, "watched", "deletes")
+		require.NoError(t, err)
+
+		destination, err := service.GetDestination(ctx, project.ID, "watched")
+		require.NoError(t, err)
+		require.Equal(t, "deletes", destination.Topic)
+
+		_, err = service.GetDestination(ctx, project.ID, "other")
+		require.True(t, bucketevents.ErrDestinationNotFound.Has(err))
+
+		data := testrand.Bytes(100)
+		require.NoError(t, uplink.Upload(ctx, sat, "watched", "a", data))
+		require.NoError(t, uplink.Upload(ctx, sat, "other", "b", data))
+		require.NoError(t, uplink.DeleteObject(ctx, sat, "watched", "a"))
+		require.NoError(t, uplink.DeleteObject(ctx, sat, "other", "b"))
+
+		// only the deletion in the bucket with a destination is recorded.
+		deletions, err := sat.Metabase.DB.ListDeleteEvents(ctx, metabase.ListDeleteEvents{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, deletions, 1)
+		require.Equal(t, metabase.DeleteEventRemoved, deletions[0].Kind)
+		require.Equal(t, metabase.BucketName("watched"), deletions[0].BucketName)
+
+		now := time.Now()
+		due, err := db.ListDue(ctx, now, 10)
+		require.NoError(t, err)
+		require.Empty(t, due)
+
+		// the deletion is relayed to the queue and the failed event is retried later.
+		require.NoError(t, chore.RunOnce(ctx))
+		deletions, err = sat.Metabase.DB.ListDeleteEvents(ctx, metabase.ListDeleteEvents{Limit: 10})
+		require.NoError(t, err)
+		require.Empty(t, deletions)
+
+		due, err = db.ListDue(ctx, now, 10)
+		require.NoError(t, err)
+		require.Empty(t, due)
+
+		due, err = db.ListDue(ctx, now.Add(time.Hour), 10)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		require.Equal(t, bucketevents.EventObjectRemoved, due[0].Name)
+		require.Equal(t, "watched", due[0].BucketName)
+		require.Equal(t, "deletes", due[0].Topic)
+		require.Equal(t, 1, due[0].Attempts)
+
+		mu.Lock()
+		fail = false
+		mu.Unlock()
+
+		chore.TestSetNow(func() time.Time { return now.Add(time.Hour) })
+		require.NoError(t, chore.RunOnce(ctx))
+
+		mu.Lock()
+		require.Equal(t, []string{"deletes"}, topics)
+		require.Len(t, messages, 1)
+		message := messages[0]
+		mu.Unlock()
+
+		require.Equal(t, due[0].ID, message.ID)
+		require.Equal(t, bucketevents.EventObjectRemoved, message.EventName)
+		require.Equal(t, project.PublicID, message.ProjectID)
+		require.Equal(t, "watched", message.Bucket)
+		require.NotEmpty(t, message.ObjectKey)
+		require.NotEmpty(t, message.VersionID)
+		require.EqualValues(t, len(data), message.Size)
+
+		// the published event is removed.
+		due, err = db.ListDue(ctx, now.Add(2*time.Hour), 10)
+		require.NoError(t, err)
+		require.Empty(t, due)
+
+		// no events are queued after the destination is deleted.
+		require.NoError(t, service.DeleteDestination(ctx, project.ID, "watched"))
+		require.NoError(t, uplink.Upload(ctx, sat, "watched", "c", data))
+		require.NoError(t, uplink.DeleteObject(ctx, sat, "watched", "c"))
+
+		require.NoError(t, chore.RunOnce(ctx))
+		deletions, err = sat.Metabase.DB.ListDeleteEvents(ctx, metabase.ListDeleteEvents{Limit: 10})
+		require.NoError(t, err)
+		require.Empty(t, deletions)
+
+		due, err = db.ListDue(ctx, now.Add(2*time.Hour), 10)
+		require.NoError(t, err)
+		require.Empty(t, due)
+	})
+}
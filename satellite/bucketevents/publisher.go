@@ -0,0 +1,188 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bucketevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"storj.io/common/uuid"
+)
+
+const (
+	// PublisherWebhook posts the events as JSON to the endpoint.
+	PublisherWebhook = "webhook"
+	// PublisherKafkaREST produces the events to Kafka through a Confluent REST proxy.
+	PublisherKafkaREST = "kafka-rest"
+	// PublisherPubSub publishes the events to Google Cloud Pub/Sub.
+	PublisherPubSub = "pubsub"
+
+	defaultPubSubEndpoint = "https://pubsub.googleapis.com"
+
+	maxErrorBodyBytes = 4096
+)
+
+// Message is the published form of a delete event.
+type Message struct {
+	// ID identifies the event. An event may be published more than once, the
+	// consumers should use the ID to deduplicate the events.
+	ID        uuid.UUID `json:"id"`
+	EventName string    `json:"eventName"`
+	EventTime time.Time `json:"eventTime"`
+	// ProjectID is the public ID of the project.
+	ProjectID uuid.UUID `json:"projectId"`
+	Bucket    string    `json:"bucket"`
+	// ObjectKey is the encrypted key of the object.
+	ObjectKey []byte `json:"objectKey"`
+	// VersionID is the version ID of the object as returned to the clients.
+	VersionID []byte `json:"versionId"`
+	Size      int64  `json:"size"`
+}
+
+// Publisher publishes delete events to an event bus.
+type Publisher interface {
+	// Publish publishes the messages to the topic. The messages are either
+	// all accepted by the event bus or an error is returned.
+	Publish(ctx context.Context, topic string, messages []Message) error
+}
+
+// NewPublisher creates the publisher configured by config.
+func NewPublisher(config Config) (Publisher, error) {
+	publisher := &httpPublisher{
+		client:    &http.Client{Timeout: config.Timeout},
+		endpoint:  strings.TrimSuffix(config.Endpoint, "/"),
+		authToken: config.AuthToken,
+	}
+
+	switch config.Publisher {
+	case PublisherWebhook:
+		publisher.encode = encodeWebhook
+	case PublisherKafkaREST:
+		publisher.encode = encodeKafkaREST
+	case PublisherPubSub:
+		if publisher.endpoint == "" {
+			publisher.endpoint = defaultPubSubEndpoint
+		}
+		publisher.encode = encodePubSub
+	default:
+		return nil, Error.New("unknown publisher %q", config.Publisher)
+	}
+
+	if publisher.endpoint == "" {
+		return nil, Error.New("endpoint of publisher %q is missing", config.Publisher)
+	}
+	return publisher, nil
+}
+
+// httpPublisher publishes the events with HTTP requests, encoded by the
+// format of the event bus.
+type httpPublisher struct {
+	client    *http.Client
+	endpoint  string
+	authToken string
+	encode    func(endpoint, topic string, messages []Message) (requestURL, contentType string, body []byte, err error)
+}
+
+// Publish implements Publisher.
+func (publisher *httpPublisher) Publish(ctx context.Context, topic string, messages []Message) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	requestURL, contentType, body, err := publisher.encode(publisher.endpoint, topic, messages)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if publisher.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+publisher.authToken)
+	}
+
+	resp, err := publisher.client.Do(req)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		details, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return Error.New("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(details))
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBodyBytes))
+	return nil
+}
+
+// encodeWebhook posts the topic and the messages as a JSON object to the endpoint.
+func encodeWebhook(endpoint, topic string, messages []Message) (string, string, []byte, error) {
+	body, err := json.Marshal(struct {
+		Topic  string    `json:"topic"`
+		Events []Message `json:"events"`
+	}{
+		Topic:  topic,
+		Events: messages,
+	})
+	return endpoint, "application/json", body, err
+}
+
+// encodeKafkaREST produces the messages as records keyed by their bucket,
+// so the events of a bucket end up in the same partition.
+func encodeKafkaREST(endpoint, topic string, messages []Message) (string, string, []byte, error) {
+	type record struct {
+		Key   string  `json:"key"`
+		Value Message `json:"value"`
+	}
+	records := make([]record, 0, len(messages))
+	for _, message := range messages {
+		records = append(records, record{
+			Key:   message.ProjectID.String() + "/" + message.Bucket,
+			Value: message,
+		})
+	}
+
+	body, err := json.Marshal(struct {
+		Records []record `json:"records"`
+	}{
+		Records: records,
+	})
+	return endpoint + "/topics/" + url.PathEscape(topic), "application/vnd.kafka.json.v2+json", body, err
+}
+
+// encodePubSub publishes the messages to the topic, which is the full name
+// of a Pub/Sub topic, e.g. projects/my-project/topics/deletes.
+func encodePubSub(endpoint, topic string, messages []Message) (string, string, []byte, error) {
+	type pubsubMessage struct {
+		Data       []byte            `json:"data"`
+		Attributes map[string]string `json:"attributes"`
+	}
+	pubsubMessages := make([]pubsubMessage, 0, len(messages))
+	for _, message := range messages {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return "", "", nil, err
+		}
+		pubsubMessages = append(pubsubMessages, pubsubMessage{
+			Data: data,
+			Attributes: map[string]string{
+				"eventName": message.EventName,
+				"bucket":    message.Bucket,
+			},
+		})
+	}
+
+	body, err := json.Marshal(struct {
+		Messages []pubsubMessage `json:"messages"`
+	}{
+		Messages: pubsubMessages,
+	})
+	return endpoint + "/v1/" + topic + ":publish", "application/json", body, err
+}
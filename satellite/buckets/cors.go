@@ -0,0 +1,241 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package buckets
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/zeebo/errs"
+)
+
+const (
+	// MaxCORSRules is the maximum number of CORS rules of a bucket.
+	MaxCORSRules = 100
+	// maxCORSRuleIDLength is the maximum length of a CORS rule ID.
+	maxCORSRuleIDLength = 255
+	// maxCORSMaxAgeSeconds is the maximum time browsers may cache a preflight response.
+	maxCORSMaxAgeSeconds = 86400
+)
+
+// ErrInvalidCORS is used when a CORS configuration is invalid.
+var ErrInvalidCORS = errs.Class("invalid CORS configuration")
+
+// corsMethods are the methods which can be allowed by a CORS rule.
+var corsMethods = map[string]struct{}{
+	http.MethodGet:    {},
+	http.MethodHead:   {},
+	http.MethodPut:    {},
+	http.MethodPost:   {},
+	http.MethodDelete: {},
+}
+
+// CORSConfiguration contains the CORS rules of a bucket. They are applied by
+// the linksharing service to the responses for shared objects of the bucket.
+type CORSConfiguration struct {
+	Rules []CORSRule `json:"rules"`
+}
+
+// CORSRule allows cross-origin requests from the given origins.
+type CORSRule struct {
+	// ID optionally identifies the rule within the bucket.
+	ID string `json:"id,omitempty"`
+	// AllowedOrigins are the origins allowed to make cross-origin requests.
+	// An origin may contain a single "*" wildcard, e.g. "https://*.example.com".
+	AllowedOrigins []string `json:"allowedOrigins"`
+	// AllowedMethods are the HTTP methods the origins are allowed to use.
+	AllowedMethods []string `json:"allowedMethods"`
+	// AllowedHeaders are the headers allowed in a preflight request.
+	// A header may contain a single "*" wildcard.
+	AllowedHeaders []string `json:"allowedHeaders,omitempty"`
+	// ExposeHeaders are the response headers the browser applications are allowed to access.
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+	// MaxAgeSeconds is the time browsers may cache the preflight response.
+	MaxAgeSeconds int `json:"maxAgeSeconds,omitempty"`
+}
+
+// IsEmpty returns whether the configuration has no rules.
+func (config CORSConfiguration) IsEmpty() bool {
+	return len(config.Rules) == 0
+}
+
+// Validate checks whether the CORS configuration is valid.
+func (config CORSConfiguration) Validate() error {
+	if len(config.Rules) > MaxCORSRules {
+		return ErrInvalidCORS.New("too many rules: %d (max %d)", len(config.Rules), MaxCORSRules)
+	}
+
+	ids := make(map[string]struct{}, len(config.Rules))
+	for i, rule := range config.Rules {
+		if err := rule.validate(); err != nil {
+			return ErrInvalidCORS.New("rules[%d]: %v", i, err)
+		}
+
+		if rule.ID == "" {
+			continue
+		}
+		if _, ok := ids[rule.ID]; ok {
+			return ErrInvalidCORS.New("rules[%d]: duplicate ID %q", i, rule.ID)
+		}
+		ids[rule.ID] = struct{}{}
+	}
+
+	return nil
+}
+
+func (rule CORSRule) validate() error {
+	switch {
+	case len(rule.ID) > maxCORSRuleIDLength:
+		return errs.New("ID is too long: %d (max %d)", len(rule.ID), maxCORSRuleIDLength)
+	case len(rule.AllowedOrigins) == 0:
+		return errs.New("allowed origins missing")
+	case len(rule.AllowedMethods) == 0:
+		return errs.New("allowed methods missing")
+	case rule.MaxAgeSeconds < 0 || rule.MaxAgeSeconds > maxCORSMaxAgeSeconds:
+		return errs.New("max age must be between 0 and %d seconds", maxCORSMaxAgeSeconds)
+	}
+
+	for _, origin := range rule.AllowedOrigins {
+		if err := validateCORSOrigin(origin); err != nil {
+			return err
+		}
+	}
+	for _, method := range rule.AllowedMethods {
+		if _, ok := corsMethods[method]; !ok {
+			return errs.New("unsupported method %q", method)
+		}
+	}
+	for _, header := range rule.AllowedHeaders {
+		if err := validateCORSHeader(header, true); err != nil {
+			return err
+		}
+	}
+	for _, header := range rule.ExposeHeaders {
+		if err := validateCORSHeader(header, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCORSOrigin checks whether origin is "*" or a scheme and host,
+// optionally with a port and a single wildcard.
+func validateCORSOrigin(origin string) error {
+	if origin == "*" {
+		return nil
+	}
+	if strings.Count(origin, "*") > 1 {
+		return errs.New("origin %q contains more than one wildcard", origin)
+	}
+
+	u, err := url.Parse(strings.Replace(origin, "*", "wildcard", 1))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return errs.New("origin %q must be a scheme and host", origin)
+	}
+	if (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return errs.New("origin %q must not contain a path, query, fragment or user info", origin)
+	}
+	return nil
+}
+
+// validateCORSHeader checks whether header is a valid header name.
+func validateCORSHeader(header string, allowWildcard bool) error {
+	if header == "" {
+		return errs.New("empty header name")
+	}
+	wildcards := 0
+	for _, r := range header {
+		switch {
+		case r == '*' && allowWildcard:
+			wildcards++
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("!#$%&'+-.^_`|~", r):
+		default:
+			return errs.New("invalid header name %q", header)
+		}
+	}
+	if wildcards > 1 {
+		return errs.New("header %q contains more than one wildcard", header)
+	}
+	return nil
+}
+
+// Match returns the first rule allowing a cross-origin request from origin
+// with the given method and request headers.
+func (config CORSConfiguration) Match(origin, method string, headers []string) (rule CORSRule, ok bool) {
+	for _, rule := range config.Rules {
+		if rule.matches(origin, method, headers) {
+			return rule, true
+		}
+	}
+	return CORSRule{}, false
+}
+
+func (rule CORSRule) matches(origin, method string, headers []string) bool {
+	if !matchesAnyWildcard(rule.AllowedOrigins, origin, false) {
+		return false
+	}
+
+	methodAllowed := false
+	for _, allowed := range rule.AllowedMethods {
+		if allowed == method {
+			methodAllowed = true
+			break
+		}
+	}
+	if !methodAllowed {
+		return false
+	}
+
+	for _, header := range headers {
+		if !matchesAnyWildcard(rule.AllowedHeaders, header, true) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAnyWildcard returns whether value matches any of the patterns,
+// which may contain a single "*" wildcard.
+func matchesAnyWildcard(patterns []string, value string, ignoreCase bool) bool {
+	if ignoreCase {
+		value = strings.ToLower(value)
+	}
+	for _, pattern := range patterns {
+		if ignoreCase {
+			pattern = strings.ToLower(pattern)
+		}
+		prefix, suffix, found := strings.Cut(pattern, "*")
+		if !found {
+			if pattern == value {
+				return true
+			}
+			continue
+		}
+		if len(value) >= len(prefix)+len(suffix) && strings.HasPrefix(value, prefix) && strings.HasSuffix(value, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Marshal encodes the configuration for storing it in the database.
+// An empty configuration is encoded as nil.
+func (config CORSConfiguration) Marshal() ([]byte, error) {
+	if config.IsEmpty() {
+		return nil, nil
+	}
+	return json.Marshal(config)
+}
+
+// UnmarshalCORSConfiguration decodes a configuration stored in the database.
+func UnmarshalCORSConfiguration(data []byte) (config CORSConfiguration, err error) {
+	if len(data) == 0 {
+		return CORSConfiguration{}, nil
+	}
+	err = json.Unmarshal(data, &config)
+	return config, err
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package buckets_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/satellite/buckets"
+)
+
+func TestCORSConfigurationValidate(t *testing.T) {
+	valid := buckets.CORSRule{
+		ID:             "app",
+		AllowedOrigins: []string{"https://app.example.com", "https://*.example.com", "http://localhost:8080"},
+		AllowedMethods: []string{"GET", "HEAD"},
+		AllowedHeaders: []string{"Range", "x-amz-*"},
+		ExposeHeaders:  []string{"Content-Range", "ETag"},
+		MaxAgeSeconds:  3600,
+	}
+
+	require.NoError(t, buckets.CORSConfiguration{}.Validate())
+	require.NoError(t, buckets.CORSConfiguration{Rules: []buckets.CORSRule{valid}}.Validate())
+	require.NoError(t, buckets.CORSConfiguration{Rules: []buckets.CORSRule{{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{"GET"},
+	}}}.Validate())
+
+	withRule := func(fn func(rule *buckets.CORSRule)) buckets.CORSConfiguration {
+		modified := valid
+		fn(&modified)
+		return buckets.CORSConfiguration{Rules: []buckets.CORSRule{modified}}
+	}
+
+	for _, tc := range []struct {
+		name   string
+		config buckets.CORSConfiguration
+	}{
+		{"too many rules", buckets.CORSConfiguration{Rules: make([]buckets.CORSRule, buckets.MaxCORSRules+1)}},
+		{"duplicate ID", buckets.CORSConfiguration{Rules: []buckets.CORSRule{valid, valid}}},
+		{"missing origins", withRule(func(rule *buckets.CORSRule) { rule.AllowedOrigins = nil })},
+		{"missing methods", withRule(func(rule *buckets.CORSRule) { rule.AllowedMethods = nil })},
+		{"unsupported method", withRule(func(rule *buckets.CORSRule) { rule.AllowedMethods = []string{"PATCH"} })},
+		{"lowercase method", withRule(func(rule *buckets.CORSRule) { rule.AllowedMethods = []string{"get"} })},
+		{"origin without scheme", withRule(func(rule *buckets.CORSRule) { rule.AllowedOrigins = []string{"example.com"} })},
+		{"origin with path", withRule(func(rule *buckets.CORSRule) { rule.AllowedOrigins = []string{"https://example.com/app"} })},
+		{"origin with two wildcards", withRule(func(rule *buckets.CORSRule) { rule.AllowedOrigins = []string{"https://*.*.example.com"} })},
+		{"invalid header", withRule(func(rule *buckets.CORSRule) { rule.AllowedHeaders = []string{"x header"} })},
+		{"header with two wildcards", withRule(func(rule *buckets.CORSRule) { rule.AllowedHeaders = []string{"x-*-*"} })},
+		{"wildcard expose header", withRule(func(rule *buckets.CORSRule) { rule.ExposeHeaders = []string{"*"} })},
+		{"negative max age", withRule(func(rule *buckets.CORSRule) { rule.MaxAgeSeconds = -1 })},
+		{"too long max age", withRule(func(rule *buckets.CORSRule) { rule.MaxAgeSeconds = 86401 })},
+	} {
+		err := tc.config.Validate()
+		require.Error(t, err, tc.name)
+		require.True(t, buckets.ErrInvalidCORS.Has(err), tc.name)
+	}
+}
+
+func TestCORSConfigurationMatch(t *testing.T) {
+	config := buckets.CORSConfiguration{
+		Rules: []buckets.CORSRule{
+			{
+				ID:             "app",
+				AllowedOrigins: []string{"https://*.example.com"},
+				AllowedMethods: []string{"GET", "HEAD"},
+				AllowedHeaders: []string{"range", "x-amz-*"},
+			},
+			{
+				ID:             "any",
+				AllowedOrigins: []string{"*"},
+				AllowedMethods: []string{"GET"},
+			},
+		},
+	}
+
+	rule, ok := config.Match("https://app.example.com", "GET", []string{"Range", "X-Amz-Date"})
+	require.True(t, ok)
+	require.Equal(t, "app", rule.ID)
+
+	rule, ok = config.Match("https://other.org", "GET", nil)
+	require.True(t, ok)
+	require.Equal(t, "any", rule.ID)
+
+	// the header isn't allowed by the first rule and the second rule allows no headers.
+	_, ok = config.Match("https://app.example.com", "GET", []string{"Authorization"})
+	require.False(t, ok)
+
+	_, ok = config.Match("https://other.org", "HEAD", nil)
+	require.False(t, ok)
+
+	_, ok = buckets.CORSConfiguration{}.Match("https://app.example.com", "GET", nil)
+	require.False(t, ok)
+}
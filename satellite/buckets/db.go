@@ -55,6 +55,8 @@ type Bucket struct {
 	Placement                   storj.PlacementConstraint
 	Versioning                  Versioning
 	ObjectLockEnabled           bool
+	DeletionProtection          bool
+	OverrideDefaults            bool
 }
 
 // ListDirection specifies listing direction.
@@ -154,4 +156,24 @@ type DB interface {
 	IterateBucketLocations(ctx context.Context, pageSize int, fn func([]metabase.BucketLocation) error) (err error)
 	// GetBucketObjectLockEnabled returns whether a bucket has Object Lock enabled.
 	GetBucketObjectLockEnabled(ctx context.Context, bucketName []byte, projectID uuid.UUID) (enabled bool, err error)
+	// GetBucketDeletionProtection returns whether a bucket has deletion protection enabled.
+	GetBucketDeletionProtection(ctx context.Context, bucketName []byte, projectID uuid.UUID) (enabled bool, err error)
+	// SetBucketDeletionProtection enables or disables the deletion protection of a bucket.
+	SetBucketDeletionProtection(ctx context.Context, bucketName []byte, projectID uuid.UUID, enabled bool) (err error)
+	// SetBucketDefaults sets the segment size and redundancy scheme overriding the placement defaults. Zero defaults remove the override.
+	SetBucketDefaults(ctx context.Context, bucketName []byte, projectID uuid.UUID, defaults Defaults) (err error)
+	// GetBucketLifecycle returns the lifecycle configuration of a bucket.
+	GetBucketLifecycle(ctx context.Context, bucketName []byte, projectID uuid.UUID) (config LifecycleConfiguration, err error)
+	// SetBucketLifecycle sets the lifecycle configuration of a bucket. An empty configuration removes it.
+	SetBucketLifecycle(ctx context.Context, bucketName []byte, projectID uuid.UUID, config LifecycleConfiguration) (err error)
+	// IterateBucketLifecycles iterates through all buckets with a lifecycle configuration with specific page size.
+	IterateBucketLifecycles(ctx context.Context, pageSize int, fn func([]BucketLifecycle) error) (err error)
+	// GetBucketQuota returns the object and segment quota of a bucket. It returns a zero quota when none is set.
+	GetBucketQuota(ctx context.Context, bucketName []byte, projectID uuid.UUID) (quota Quota, err error)
+	// SetBucketQuota sets the object and segment quota of a bucket. A zero quota removes it.
+	SetBucketQuota(ctx context.Context, bucketName []byte, projectID uuid.UUID, quota Quota) (err error)
+	// GetBucketCORS returns the CORS configuration of a bucket.
+	GetBucketCORS(ctx context.Context, bucketName []byte, projectID uuid.UUID) (config CORSConfiguration, err error)
+	// SetBucketCORS sets the CORS configuration of a bucket. An empty configuration removes it.
+	SetBucketCORS(ctx context.Context, bucketName []byte, projectID uuid.UUID, config CORSConfiguration) (err error)
 }
@@ -400,3 +400,176 @@ func TestEnableSuspendBucketVersioning(t *testing.T) {
 		requireBucketVersioning(lockBucketName, buckets.Unversioned)
 	})
 }
+
+func TestBucketLifecycle(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		bucketsDB := db.Buckets()
+
+		project, err := db.Console().Projects().Insert(ctx, &console.Project{
+			ID:   testrand.UUID(),
+			Name: "testproject",
+		})
+		require.NoError(t, err)
+
+		config := buckets.LifecycleConfiguration{
+			Rules: []buckets.LifecycleRule{
+				{ID: "logs", Prefix: "logs/", ExpirationDays: 30},
+				{ID: "tmp", Prefix: "tmp/", ExpirationDays: 1},
+			},
+		}
+
+		_, err = bucketsDB.GetBucketLifecycle(ctx, []byte("unknown"), project.ID)
+		require.True(t, buckets.ErrBucketNotFound.Has(err))
+
+		err = bucketsDB.SetBucketLifecycle(ctx, []byte("unknown"), project.ID, config)
+		require.True(t, buckets.ErrBucketNotFound.Has(err))
+
+		var expected []buckets.BucketLifecycle
+		for _, name := range []string{"aaa", "bbb", "ccc", "ddd"} {
+			bucket, err := bucketsDB.CreateBucket(ctx, newTestBucket(name, project.ID))
+			require.NoError(t, err)
+
+			lifecycle, err := bucketsDB.GetBucketLifecycle(ctx, []byte(bucket.Name), project.ID)
+			require.NoError(t, err)
+			require.True(t, lifecycle.IsEmpty())
+
+			if name == "bbb" {
+				continue
+			}
+
+			require.NoError(t, bucketsDB.SetBucketLifecycle(ctx, []byte(bucket.Name), project.ID, config))
+
+			lifecycle, err = bucketsDB.GetBucketLifecycle(ctx, []byte(bucket.Name), project.ID)
+			require.NoError(t, err)
+			require.Equal(t, config, lifecycle)
+
+			expected = append(expected, buckets.BucketLifecycle{
+				ProjectID:         project.ID,
+				BucketName:        metabase.BucketName(bucket.Name),
+				Versioning:        bucket.Versioning,
+				ObjectLockEnabled: bucket.ObjectLockEnabled,
+				Configuration:     config,
+			})
+		}
+
+		for _, pageSize := range []int{1, 2, 10} {
+			var lifecycles []buckets.BucketLifecycle
+			err := bucketsDB.IterateBucketLifecycles(ctx, pageSize, func(page []buckets.BucketLifecycle) error {
+				lifecycles = append(lifecycles, page...)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, expected, lifecycles)
+		}
+
+		// an empty configuration removes the lifecycle of the bucket.
+		require.NoError(t, bucketsDB.SetBucketLifecycle(ctx, []byte("ccc"), project.ID, buckets.LifecycleConfiguration{}))
+
+		lifecycle, err := bucketsDB.GetBucketLifecycle(ctx, []byte("ccc"), project.ID)
+		require.NoError(t, err)
+		require.True(t, lifecycle.IsEmpty())
+
+		var names []metabase.BucketName
+		err = bucketsDB.IterateBucketLifecycles(ctx, 10, func(page []buckets.BucketLifecycle) error {
+			for _, item := range page {
+				names = append(names, item.BucketName)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []metabase.BucketName{"aaa", "ddd"}, names)
+	})
+}
+
+func TestBucketDefaults(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		bucketsDB := db.Buckets()
+
+		project, err := db.Console().Projects().Insert(ctx, &console.Project{
+			ID:   testrand.UUID(),
+			Name: "testproject",
+		})
+		require.NoError(t, err)
+
+		defaults := buckets.Defaults{
+			SegmentSize: 128 << 20,
+			Redundancy: storj.RedundancyScheme{
+				Algorithm:      storj.ReedSolomon,
+				ShareSize:      256,
+				RequiredShares: 16,
+				RepairShares:   24,
+				OptimalShares:  32,
+				TotalShares:    40,
+			},
+		}
+
+		err = bucketsDB.SetBucketDefaults(ctx, []byte("unknown"), project.ID, defaults)
+		require.True(t, buckets.ErrBucketNotFound.Has(err))
+
+		// values stored by old clients don't override the placement defaults.
+		bucket, err := bucketsDB.CreateBucket(ctx, newTestBucket("bucket", project.ID))
+		require.NoError(t, err)
+		require.True(t, bucket.DefaultsOverride().IsZero())
+
+		require.NoError(t, bucketsDB.SetBucketDefaults(ctx, []byte(bucket.Name), project.ID, defaults))
+
+		bucket, err = bucketsDB.GetBucket(ctx, []byte(bucket.Name), project.ID)
+		require.NoError(t, err)
+		require.Equal(t, defaults, bucket.DefaultsOverride())
+
+		require.NoError(t, bucketsDB.SetBucketDefaults(ctx, []byte(bucket.Name), project.ID, buckets.Defaults{}))
+
+		bucket, err = bucketsDB.GetBucket(ctx, []byte(bucket.Name), project.ID)
+		require.NoError(t, err)
+		require.True(t, bucket.DefaultsOverride().IsZero())
+	})
+}
+
+func TestBucketCORS(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		bucketsDB := db.Buckets()
+
+		project, err := db.Console().Projects().Insert(ctx, &console.Project{
+			ID:   testrand.UUID(),
+			Name: "testproject",
+		})
+		require.NoError(t, err)
+
+		config := buckets.CORSConfiguration{
+			Rules: []buckets.CORSRule{{
+				ID:             "app",
+				AllowedOrigins: []string{"https://app.example.com"},
+				AllowedMethods: []string{"GET", "HEAD"},
+				AllowedHeaders: []string{"Range"},
+				ExposeHeaders:  []string{"Content-Range"},
+				MaxAgeSeconds:  600,
+			}},
+		}
+
+		_, err = bucketsDB.GetBucketCORS(ctx, []byte("unknown"), project.ID)
+		require.True(t, buckets.ErrBucketNotFound.Has(err))
+
+		err = bucketsDB.SetBucketCORS(ctx, []byte("unknown"), project.ID, config)
+		require.True(t, buckets.ErrBucketNotFound.Has(err))
+
+		bucket, err := bucketsDB.CreateBucket(ctx, newTestBucket("testbucket", project.ID))
+		require.NoError(t, err)
+
+		cors, err := bucketsDB.GetBucketCORS(ctx, []byte(bucket.Name), project.ID)
+		require.NoError(t, err)
+		require.True(t, cors.IsEmpty())
+
+		require.NoError(t, bucketsDB.SetBucketCORS(ctx, []byte(bucket.Name), project.ID, config))
+
+		cors, err = bucketsDB.GetBucketCORS(ctx, []byte(bucket.Name), project.ID)
+		require.NoError(t, err)
+		require.Equal(t, config, cors)
+
+		// an empty configuration removes the CORS rules of the bucket.
+		require.NoError(t, bucketsDB.SetBucketCORS(ctx, []byte(bucket.Name), project.ID, buckets.CORSConfiguration{}))
+
+		cors, err = bucketsDB.GetBucketCORS(ctx, []byte(bucket.Name), project.ID)
+		require.NoError(t, err)
+		require.True(t, cors.IsEmpty())
+	})
+}
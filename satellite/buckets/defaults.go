@@ -0,0 +1,84 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package buckets
+
+import (
+	"github.com/zeebo/errs"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+)
+
+// maxTotalShares is the maximum number of pieces the erasure coding supports.
+const maxTotalShares = 256
+
+// MinSegmentSize is the smallest maximum segment size a bucket may set.
+// Uplinks split uploads into 64 MiB segments without asking the satellite, so
+// a smaller maximum would reject their segments.
+const MinSegmentSize = 64 * memory.MiB
+
+// ErrInvalidDefaults is used when bucket defaults are invalid.
+var ErrInvalidDefaults = errs.Class("invalid bucket defaults")
+
+// Defaults contains the segment size and redundancy scheme overriding the
+// placement defaults for new uploads into a bucket. Zero values keep the
+// placement defaults. The segment size can only lower the maximum segment
+// size configured for the satellite.
+type Defaults struct {
+	SegmentSize int64
+	Redundancy  storj.RedundancyScheme
+}
+
+// IsZero returns whether the defaults don't override anything.
+func (defaults Defaults) IsZero() bool {
+	return defaults.SegmentSize == 0 && defaults.Redundancy.IsZero()
+}
+
+// Validate checks whether the defaults are valid.
+func (defaults Defaults) Validate() error {
+	if defaults.SegmentSize < 0 {
+		return ErrInvalidDefaults.New("segment size must not be negative")
+	}
+	if defaults.SegmentSize > 0 && defaults.SegmentSize < MinSegmentSize.Int64() {
+		return ErrInvalidDefaults.New("segment size must not be less than %s", MinSegmentSize)
+	}
+
+	rs := defaults.Redundancy
+	if rs.IsZero() {
+		return nil
+	}
+
+	switch {
+	case rs.Algorithm != storj.ReedSolomon:
+		return ErrInvalidDefaults.New("unsupported redundancy algorithm: %d", rs.Algorithm)
+	case rs.ShareSize <= 0:
+		return ErrInvalidDefaults.New("share size must be positive")
+	case rs.RequiredShares <= 0:
+		return ErrInvalidDefaults.New("required shares must be positive")
+	case rs.RepairShares < rs.RequiredShares:
+		return ErrInvalidDefaults.New("repair shares (%d) must not be less than required shares (%d)", rs.RepairShares, rs.RequiredShares)
+	case rs.OptimalShares <= rs.RepairShares:
+		return ErrInvalidDefaults.New("optimal shares (%d) must be greater than repair shares (%d)", rs.OptimalShares, rs.RepairShares)
+	case rs.TotalShares < rs.OptimalShares:
+		return ErrInvalidDefaults.New("total shares (%d) must not be less than optimal shares (%d)", rs.TotalShares, rs.OptimalShares)
+	case rs.TotalShares > maxTotalShares:
+		return ErrInvalidDefaults.New("total shares (%d) must not be greater than %d", rs.TotalShares, maxTotalShares)
+	}
+	return nil
+}
+
+// DefaultsOverride returns the defaults overriding the placement defaults for
+// new uploads. It's zero when the bucket doesn't override them.
+//
+// The default fields of old buckets may contain values supplied by clients,
+// which are ignored unless OverrideDefaults is set.
+func (bucket Bucket) DefaultsOverride() Defaults {
+	if !bucket.OverrideDefaults {
+		return Defaults{}
+	}
+	return Defaults{
+		SegmentSize: bucket.DefaultSegmentsSize,
+		Redundancy:  bucket.DefaultRedundancyScheme,
+	}
+}
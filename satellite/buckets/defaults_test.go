@@ -0,0 +1,54 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package buckets_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/buckets"
+)
+
+func TestDefaultsValidate(t *testing.T) {
+	rs := storj.RedundancyScheme{
+		Algorithm:      storj.ReedSolomon,
+		ShareSize:      256,
+		RequiredShares: 16,
+		RepairShares:   24,
+		OptimalShares:  32,
+		TotalShares:    40,
+	}
+
+	require.NoError(t, buckets.Defaults{}.Validate())
+	require.NoError(t, buckets.Defaults{SegmentSize: 64 << 20}.Validate())
+	require.NoError(t, buckets.Defaults{Redundancy: rs}.Validate())
+	require.NoError(t, buckets.Defaults{SegmentSize: 64 << 20, Redundancy: rs}.Validate())
+
+	withRS := func(fn func(rs *storj.RedundancyScheme)) buckets.Defaults {
+		modified := rs
+		fn(&modified)
+		return buckets.Defaults{Redundancy: modified}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		defaults buckets.Defaults
+	}{
+		{"negative segment size", buckets.Defaults{SegmentSize: -1}},
+		{"segment size below minimum", buckets.Defaults{SegmentSize: buckets.MinSegmentSize.Int64() - 1}},
+		{"invalid algorithm", withRS(func(rs *storj.RedundancyScheme) { rs.Algorithm = storj.InvalidRedundancyAlgorithm })},
+		{"zero share size", withRS(func(rs *storj.RedundancyScheme) { rs.ShareSize = 0 })},
+		{"zero required shares", withRS(func(rs *storj.RedundancyScheme) { rs.RequiredShares = 0 })},
+		{"repair below required", withRS(func(rs *storj.RedundancyScheme) { rs.RepairShares = 15 })},
+		{"optimal not above repair", withRS(func(rs *storj.RedundancyScheme) { rs.OptimalShares = 24 })},
+		{"total below optimal", withRS(func(rs *storj.RedundancyScheme) { rs.TotalShares = 31 })},
+		{"too many shares", withRS(func(rs *storj.RedundancyScheme) { rs.TotalShares = 300 })},
+	} {
+		err := tc.defaults.Validate()
+		require.Error(t, err, tc.name)
+		require.True(t, buckets.ErrInvalidDefaults.Has(err), tc.name)
+	}
+}
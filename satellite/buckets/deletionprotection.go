@@ -0,0 +1,76 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package buckets
+
+import (
+	"storj.io/common/macaroon"
+	"storj.io/picobuf"
+)
+
+// allowProtectedDeletesField is the protobuf field number of the caveat allowing
+// deletes in buckets with deletion protection enabled. It's far above the field
+// numbers of macaroon.Caveat, so fields added there don't collide with it, and
+// macaroon.Caveat skips it when the caveat is checked.
+const allowProtectedDeletesField = 1 << 20
+
+// protectedDeletesCaveat is a first-party caveat encoded the same way as
+// macaroon.Caveat, which only contains the permission for protected deletes.
+type protectedDeletesCaveat struct {
+	AllowProtectedDeletes bool
+}
+
+// Encode implements picobuf.Message.
+func (caveat *protectedDeletesCaveat) Encode(c *picobuf.Encoder) bool {
+	c.Bool(allowProtectedDeletesField, &caveat.AllowProtectedDeletes)
+	return true
+}
+
+// Decode implements picobuf.Message.
+func (caveat *protectedDeletesCaveat) Decode(c *picobuf.Decoder) {
+	c.Bool(allowProtectedDeletesField, &caveat.AllowProtectedDeletes)
+}
+
+// AllowProtectedDeletes returns a copy of the API key with a caveat allowing
+// deletes in buckets with deletion protection enabled.
+//
+// The caveat doesn't grant any other permission. Anyone holding an API key is
+// able to add it, hence deletion protection prevents accidental deletes, e.g. by
+// automation, but it's not an access control mechanism.
+func AllowProtectedDeletes(key *macaroon.APIKey) (*macaroon.APIKey, error) {
+	mac, err := macaroon.ParseMacaroon(key.SerializeRaw())
+	if err != nil {
+		return nil, ErrBucket.Wrap(err)
+	}
+
+	caveat, err := picobuf.Marshal(&protectedDeletesCaveat{AllowProtectedDeletes: true})
+	if err != nil {
+		return nil, ErrBucket.Wrap(err)
+	}
+
+	mac, err = mac.AddFirstPartyCaveat(caveat)
+	if err != nil {
+		return nil, ErrBucket.Wrap(err)
+	}
+	return macaroon.ParseRawAPIKey(mac.Serialize())
+}
+
+// AllowsProtectedDeletes returns whether any caveat of the API key allows
+// deletes in buckets with deletion protection enabled.
+func AllowsProtectedDeletes(key *macaroon.APIKey) (bool, error) {
+	mac, err := macaroon.ParseMacaroon(key.SerializeRaw())
+	if err != nil {
+		return false, ErrBucket.Wrap(err)
+	}
+
+	for _, data := range mac.Caveats() {
+		var caveat protectedDeletesCaveat
+		if err := picobuf.Unmarshal(data, &caveat); err != nil {
+			return false, ErrBucket.Wrap(err)
+		}
+		if caveat.AllowProtectedDeletes {
+			return true, nil
+		}
+	}
+	return false, nil
+}
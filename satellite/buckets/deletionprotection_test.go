@@ -0,0 +1,60 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package buckets_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/buckets"
+)
+
+func TestAllowProtectedDeletes(t *testing.T) {
+	secret := testrand.BytesInt(32)
+	key, err := macaroon.NewAPIKey(secret)
+	require.NoError(t, err)
+
+	key, err = key.Restrict(macaroon.Caveat{DisallowReads: true})
+	require.NoError(t, err)
+
+	allowed, err := buckets.AllowsProtectedDeletes(key)
+	require.NoError(t, err)
+	require.False(t, allowed)
+
+	allowingKey, err := buckets.AllowProtectedDeletes(key)
+	require.NoError(t, err)
+
+	allowed, err = buckets.AllowsProtectedDeletes(allowingKey)
+	require.NoError(t, err)
+	require.True(t, allowed)
+
+	// macaroon.Caveat doesn't know the caveat.
+	mac, err := macaroon.ParseMacaroon(allowingKey.SerializeRaw())
+	require.NoError(t, err)
+	caveats := mac.Caveats()
+	caveat, err := macaroon.ParseCaveat(caveats[len(caveats)-1])
+	require.NoError(t, err)
+	require.Equal(t, macaroon.Caveat{}, *caveat)
+
+	// the caveat doesn't change the other permissions of the key
+	now := time.Now()
+	action := macaroon.Action{Op: macaroon.ActionDelete, Bucket: []byte("bucket"), Time: now}
+	require.NoError(t, allowingKey.Check(context.Background(), secret, macaroon.APIKeyVersionMin, action, nil))
+
+	action = macaroon.Action{Op: macaroon.ActionRead, Bucket: []byte("bucket"), EncryptedPath: []byte("object"), Time: now}
+	require.Error(t, allowingKey.Check(context.Background(), secret, macaroon.APIKeyVersionMin, action, nil))
+
+	// the caveat is kept when the key is restricted further
+	restricted, err := allowingKey.Restrict(macaroon.Caveat{DisallowLists: true})
+	require.NoError(t, err)
+
+	allowed, err = buckets.AllowsProtectedDeletes(restricted)
+	require.NoError(t, err)
+	require.True(t, allowed)
+}
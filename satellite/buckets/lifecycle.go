@@ -0,0 +1,105 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package buckets
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+)
+
+const (
+	// MaxLifecycleRules is the maximum number of lifecycle rules of a bucket.
+	MaxLifecycleRules = 100
+	// maxLifecycleRuleIDLength is the maximum length of a lifecycle rule ID.
+	maxLifecycleRuleIDLength = 255
+)
+
+// ErrInvalidLifecycle is used when a lifecycle configuration is invalid.
+var ErrInvalidLifecycle = errs.Class("invalid lifecycle configuration")
+
+// LifecycleConfiguration contains the lifecycle rules of a bucket.
+type LifecycleConfiguration struct {
+	Rules []LifecycleRule `json:"rules"`
+}
+
+// LifecycleRule expires the objects with the given key prefix once they are
+// older than the given number of days.
+type LifecycleRule struct {
+	// ID identifies the rule within the bucket.
+	ID string `json:"id"`
+	// Prefix is the object key prefix the rule applies to. Empty prefix matches all objects.
+	Prefix string `json:"prefix"`
+	// ExpirationDays is the age, counted from the object creation, after which
+	// the object is deleted.
+	ExpirationDays int `json:"expirationDays"`
+}
+
+// IsEmpty returns whether the configuration has no rules.
+func (config LifecycleConfiguration) IsEmpty() bool {
+	return len(config.Rules) == 0
+}
+
+// Validate checks whether the lifecycle configuration is valid.
+func (config LifecycleConfiguration) Validate() error {
+	if len(config.Rules) > MaxLifecycleRules {
+		return ErrInvalidLifecycle.New("too many rules: %d (max %d)", len(config.Rules), MaxLifecycleRules)
+	}
+
+	ids := make(map[string]struct{}, len(config.Rules))
+	for i, rule := range config.Rules {
+		switch {
+		case rule.ID == "":
+			return ErrInvalidLifecycle.New("rules[%d]: ID missing", i)
+		case len(rule.ID) > maxLifecycleRuleIDLength:
+			return ErrInvalidLifecycle.New("rules[%d]: ID is too long: %d (max %d)", i, len(rule.ID), maxLifecycleRuleIDLength)
+		case rule.ExpirationDays <= 0:
+			return ErrInvalidLifecycle.New("rules[%d]: expiration days must be positive", i)
+		}
+
+		if _, ok := ids[rule.ID]; ok {
+			return ErrInvalidLifecycle.New("rules[%d]: duplicate ID %q", i, rule.ID)
+		}
+		ids[rule.ID] = struct{}{}
+	}
+
+	return nil
+}
+
+// Marshal encodes the configuration for storing it in the database.
+// An empty configuration is encoded as nil.
+func (config LifecycleConfiguration) Marshal() ([]byte, error) {
+	if config.IsEmpty() {
+		return nil, nil
+	}
+	return json.Marshal(config)
+}
+
+// UnmarshalLifecycleConfiguration decodes a configuration stored in the database.
+func UnmarshalLifecycleConfiguration(data []byte) (config LifecycleConfiguration, err error) {
+	if len(data) == 0 {
+		return LifecycleConfiguration{}, nil
+	}
+	err = json.Unmarshal(data, &config)
+	return config, err
+}
+
+// ExpiredBefore returns the creation time before which the objects matching the rule are expired.
+func (rule LifecycleRule) ExpiredBefore(now time.Time) time.Time {
+	return now.AddDate(0, 0, -rule.ExpirationDays)
+}
+
+// BucketLifecycle contains the lifecycle configuration of a bucket.
+type BucketLifecycle struct {
+	ProjectID          uuid.UUID
+	BucketName         metabase.BucketName
+	Versioning         Versioning
+	ObjectLockEnabled  bool
+	DeletionProtection bool
+	Configuration      LifecycleConfiguration
+}
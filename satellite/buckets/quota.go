@@ -0,0 +1,44 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package buckets
+
+import (
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/satellite/metabase"
+)
+
+// ErrInvalidQuota is used when a bucket quota is invalid.
+var ErrInvalidQuota = errs.Class("invalid bucket quota")
+
+// Quota limits the number of committed objects and segments in a bucket, in
+// addition to the project limits. Zero values are unlimited.
+type Quota struct {
+	MaxObjects  int64 `json:"maxObjects"`
+	MaxSegments int64 `json:"maxSegments"`
+}
+
+// IsZero returns whether the quota doesn't limit anything.
+func (quota Quota) IsZero() bool {
+	return quota.MaxObjects == 0 && quota.MaxSegments == 0
+}
+
+// Validate checks whether the quota is valid.
+func (quota Quota) Validate() error {
+	if quota.MaxObjects < 0 {
+		return ErrInvalidQuota.New("max objects must not be negative")
+	}
+	if quota.MaxSegments < 0 {
+		return ErrInvalidQuota.New("max segments must not be negative")
+	}
+	return nil
+}
+
+// Metabase returns the quota enforced by the metabase commits.
+func (quota Quota) Metabase() metabase.BucketQuota {
+	return metabase.BucketQuota{
+		MaxObjects:  quota.MaxObjects,
+		MaxSegments: quota.MaxSegments,
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package buckets
+
+// IsValid returns true if the versioning state is a known one.
+func (v Versioning) IsValid() bool {
+	return v >= VersioningUnsupported && v <= VersioningSuspended
+}
+
+// TransitionTo checks whether the versioning state of a bucket may change
+// from v to next.
+//
+// Versioning of a bucket goes from unversioned to enabled, and then it can be
+// suspended and enabled again any number of times. It can never return to
+// unversioned, and buckets created before versioning was introduced can't
+// change their state at all. Changing to the current state is a no-op.
+func (v Versioning) TransitionTo(next Versioning) error {
+	if !v.IsValid() || !next.IsValid() {
+		return ErrConflict.New("invalid versioning state transition %d -> %d", v, next)
+	}
+
+	switch {
+	case v == VersioningUnsupported:
+		return ErrConflict.New("versioning is unsupported for this bucket")
+	case v == next:
+		return nil
+	case next == VersioningEnabled:
+		return nil
+	case next == VersioningSuspended:
+		if v != VersioningEnabled {
+			return ErrConflict.New("versioning may only be suspended for buckets with versioning enabled")
+		}
+		return nil
+	default:
+		return ErrConflict.New("versioning may not be disabled once it was enabled")
+	}
+}
+
+// ObjectVersioning returns how objects are versioned in a bucket with this
+// versioning state. Objects in versioned buckets get a new version on every
+// commit and delete. Objects in suspended buckets replace and delete the null
+// version instead, while the older versions are kept.
+func (v Versioning) ObjectVersioning() (versioned, suspended bool) {
+	return v == VersioningEnabled, v == VersioningSuspended
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package buckets_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/satellite/buckets"
+)
+
+func TestVersioningTransitionTo(t *testing.T) {
+	states := []buckets.Versioning{
+		buckets.VersioningUnsupported,
+		buckets.Unversioned,
+		buckets.VersioningEnabled,
+		buckets.VersioningSuspended,
+	}
+
+	allowed := map[[2]buckets.Versioning]bool{
+		{buckets.Unversioned, buckets.Unversioned}:                 true,
+		{buckets.Unversioned, buckets.VersioningEnabled}:           true,
+		{buckets.VersioningEnabled, buckets.VersioningEnabled}:     true,
+		{buckets.VersioningEnabled, buckets.VersioningSuspended}:   true,
+		{buckets.VersioningSuspended, buckets.VersioningSuspended}: true,
+		{buckets.VersioningSuspended, buckets.VersioningEnabled}:   true,
+	}
+
+	for _, from := range states {
+		for _, to := range states {
+			err := from.TransitionTo(to)
+			if allowed[[2]buckets.Versioning{from, to}] {
+				require.NoError(t, err, "%d -> %d", from, to)
+			} else {
+				require.True(t, buckets.ErrConflict.Has(err), "%d -> %d", from, to)
+			}
+		}
+	}
+
+	require.Error(t, buckets.Unversioned.TransitionTo(buckets.Versioning(-1)))
+	require.Error(t, buckets.Versioning(4).TransitionTo(buckets.VersioningEnabled))
+}
+
+func TestVersioningObjectVersioning(t *testing.T) {
+	for _, test := range []struct {
+		state     buckets.Versioning
+		versioned bool
+		suspended bool
+	}{
+		{buckets.VersioningUnsupported, false, false},
+		{buckets.Unversioned, false, false},
+		{buckets.VersioningEnabled, true, false},
+		{buckets.VersioningSuspended, false, true},
+	} {
+		versioned, suspended := test.state.ObjectVersioning()
+		require.Equal(t, test.versioned, versioned, test.state)
+		require.Equal(t, test.suspended, suspended, test.state)
+	}
+}
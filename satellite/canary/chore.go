@@ -0,0 +1,259 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package canary continuously verifies the full upload, download and delete
+// path of every placement with small synthetic objects.
+package canary
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/uplink"
+)
+
+var (
+	// Error is the standard error class for canary errors.
+	Error = errs.Class("canary")
+	mon   = monkit.Package()
+)
+
+// Stage is a step of verifying a canary object.
+type Stage string
+
+const (
+	// StageUpload uploads the canary object.
+	StageUpload Stage = "upload"
+	// StageDownload downloads the canary object and compares the content.
+	StageDownload Stage = "download"
+	// StageDelete deletes the canary object.
+	StageDelete Stage = "delete"
+)
+
+// objectExpiration is the expiration of the canary objects, so the objects
+// left behind by a failed delete are eventually removed.
+const objectExpiration = 24 * time.Hour
+
+// Status is the state of the canary objects of a placement.
+type Status struct {
+	Placement           storj.PlacementConstraint
+	Attempts            int64
+	Failures            int64
+	ConsecutiveFailures int
+	LastSuccess         time.Time
+	LastFailure         time.Time
+	LastError           error
+	// Durations are the durations of the stages of the last attempt.
+	Durations map[Stage]time.Duration
+}
+
+// Chore periodically uploads, downloads and deletes a canary object in every
+// configured placement, and records the success rate and the latencies.
+//
+// architecture: Chore
+type Chore struct {
+	log    *zap.Logger
+	config Config
+
+	mu     sync.Mutex
+	status map[storj.PlacementConstraint]*Status
+
+	Loop *sync2.Cycle
+}
+
+// NewChore creates a new canary chore.
+func NewChore(log *zap.Logger, config Config) *Chore {
+	return &Chore{
+		log:    log,
+		config: config,
+		status: map[storj.PlacementConstraint]*Status{},
+		Loop:   sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run runs the chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		chore.RunOnce(ctx)
+		return nil
+	})
+}
+
+// RunOnce verifies a canary object in every target concurrently.
+func (chore *Chore) RunOnce(ctx context.Context) {
+	defer mon.Task()(&ctx)(nil)
+
+	var wg sync.WaitGroup
+	for _, target := range chore.config.Targets {
+		target := target
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			chore.verify(ctx, target)
+		}()
+	}
+	wg.Wait()
+}
+
+// Status returns the state of the canary objects of every placement.
+func (chore *Chore) Status() []Status {
+	chore.mu.Lock()
+	defer chore.mu.Unlock()
+
+	statuses := make([]Status, 0, len(chore.status))
+	for _, status := range chore.status {
+		copied := *status
+		copied.Durations = make(map[Stage]time.Duration, len(status.Durations))
+		for stage, duration := range status.Durations {
+			copied.Durations[stage] = duration
+		}
+		statuses = append(statuses, copied)
+	}
+	return statuses
+}
+
+// verify verifies a canary object in the target and records the result.
+func (chore *Chore) verify(ctx context.Context, target Target) {
+	ctx, cancel := context.WithTimeout(ctx, chore.config.Timeout)
+	defer cancel()
+
+	placementTag := monkit.NewSeriesTag("placement", strconv.Itoa(int(target.Placement)))
+
+	durations := map[Stage]time.Duration{}
+	stage, err := chore.probe(ctx, target, func(stage Stage, duration time.Duration) {
+		durations[stage] = duration
+		mon.DurationVal("canary_duration", placementTag, monkit.NewSeriesTag("stage", string(stage))).Observe(duration)
+		mon.Counter("canary_success", placementTag, monkit.NewSeriesTag("stage", string(stage))).Inc(1)
+	})
+	if err != nil {
+		mon.Counter("canary_failure", placementTag, monkit.NewSeriesTag("stage", string(stage))).Inc(1)
+	}
+
+	chore.mu.Lock()
+	defer chore.mu.Unlock()
+
+	status, ok := chore.status[target.Placement]
+	if !ok {
+		status = &Status{Placement: target.Placement}
+		chore.status[target.Placement] = status
+	}
+
+	now := time.Now()
+	status.Attempts++
+	status.Durations = durations
+	if err == nil {
+		status.ConsecutiveFailures = 0
+		status.LastSuccess = now
+		mon.IntVal("canary_consecutive_failures", placementTag).Observe(0)
+		return
+	}
+
+	status.Failures++
+	status.ConsecutiveFailures++
+	status.LastFailure = now
+	status.LastError = err
+	mon.IntVal("canary_consecutive_failures", placementTag).Observe(int64(status.ConsecutiveFailures))
+
+	log := chore.log.With(
+		zap.Uint16("placement", uint16(target.Placement)),
+		zap.String("stage", string(stage)),
+		zap.Int("consecutive failures", status.ConsecutiveFailures),
+		zap.Error(err))
+
+	if status.ConsecutiveFailures == chore.config.FailureThreshold {
+		mon.Event("canary_alert", placementTag)
+		log.Error("canary objects are failing in placement")
+		return
+	}
+	log.Warn("canary object verification failed")
+}
+
+// probe uploads, downloads and deletes a canary object. It returns the failed stage in case of an error.
+func (chore *Chore) probe(ctx context.Context, target Target, observe func(Stage, time.Duration)) (_ Stage, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	access, err := uplink.ParseAccess(target.AccessGrant)
+	if err != nil {
+		return StageUpload, Error.Wrap(err)
+	}
+
+	project, err := uplink.OpenProject(ctx, access)
+	if err != nil {
+		return StageUpload, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(project.Close())) }()
+
+	key := fmt.Sprintf("canary/%d/%s", target.Placement, time.Now().UTC().Format(time.RFC3339Nano))
+	data := make([]byte, chore.config.ObjectSize.Int())
+	if _, err := rand.Read(data); err != nil {
+		return StageUpload, Error.Wrap(err)
+	}
+
+	start := time.Now()
+	if err := upload(ctx, project, target.Bucket, key, data); err != nil {
+		return StageUpload, Error.Wrap(err)
+	}
+	observe(StageUpload, time.Since(start))
+
+	start = time.Now()
+	downloaded, err := download(ctx, project, target.Bucket, key)
+	if err == nil && !bytes.Equal(data, downloaded) {
+		err = errs.New("downloaded content doesn't match the uploaded content")
+	}
+	if err != nil {
+		_, deleteErr := project.DeleteObject(ctx, target.Bucket, key)
+		return StageDownload, Error.Wrap(errs.Combine(err, deleteErr))
+	}
+	observe(StageDownload, time.Since(start))
+
+	start = time.Now()
+	if _, err := project.DeleteObject(ctx, target.Bucket, key); err != nil {
+		return StageDelete, Error.Wrap(err)
+	}
+	observe(StageDelete, time.Since(start))
+
+	return "", nil
+}
+
+func upload(ctx context.Context, project *uplink.Project, bucket, key string, data []byte) (err error) {
+	upload, err := project.UploadObject(ctx, bucket, key, &uplink.UploadOptions{
+		Expires: time.Now().Add(objectExpiration),
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := upload.Write(data); err != nil {
+		return errs.Combine(err, upload.Abort())
+	}
+	return upload.Commit()
+}
+
+func download(ctx context.Context, project *uplink.Project, bucket, key string) (_ []byte, err error) {
+	download, err := project.DownloadObject(ctx, bucket, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, download.Close()) }()
+
+	return io.ReadAll(download)
+}
+
+// Close closes the chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
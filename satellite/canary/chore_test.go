@@ -0,0 +1,92 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package canary_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/canary"
+)
+
+func TestChore(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		uplink := planet.Uplinks[0]
+
+		require.NoError(t, uplink.CreateBucket(ctx, sat, "canary"))
+
+		access, err := uplink.Access[sat.ID()].Serialize()
+		require.NoError(t, err)
+
+		var targets canary.Targets
+		require.NoError(t, targets.Set("0:canary:"+access+", 1:missing:"+access))
+		require.Equal(t, canary.Targets{
+			{Placement: storj.DefaultPlacement, Bucket: "canary", AccessGrant: access},
+			{Placement: 1, Bucket: "missing", AccessGrant: access},
+		}, targets)
+
+		chore := canary.NewChore(zaptest.NewLogger(t), canary.Config{
+			Interval:         time.Hour,
+			Targets:          targets,
+			ObjectSize:       16 * memory.KiB,
+			Timeout:          time.Minute,
+			FailureThreshold: 2,
+		})
+		defer ctx.Check(chore.Close)
+
+		chore.RunOnce(ctx)
+		chore.RunOnce(ctx)
+
+		statuses := chore.Status()
+		sort.Slice(statuses, func(i, j int) bool { return statuses[i].Placement < statuses[j].Placement })
+		require.Len(t, statuses, 2)
+
+		healthy := statuses[0]
+		require.Equal(t, storj.DefaultPlacement, healthy.Placement)
+		require.EqualValues(t, 2, healthy.Attempts)
+		require.Zero(t, healthy.Failures)
+		require.NoError(t, healthy.LastError)
+		require.Len(t, healthy.Durations, 3)
+		for _, stage := range []canary.Stage{canary.StageUpload, canary.StageDownload, canary.StageDelete} {
+			require.Contains(t, healthy.Durations, stage)
+		}
+
+		failing := statuses[1]
+		require.EqualValues(t, 2, failing.Attempts)
+		require.EqualValues(t, 2, failing.Failures)
+		require.Equal(t, 2, failing.ConsecutiveFailures)
+		require.Error(t, failing.LastError)
+		require.Empty(t, failing.Durations)
+
+		objects, err := uplink.ListObjects(ctx, sat, "canary")
+		require.NoError(t, err)
+		require.Empty(t, objects)
+	})
+}
+
+func TestTargets(t *testing.T) {
+	var targets canary.Targets
+	require.NoError(t, targets.Set(""))
+	require.Empty(t, targets)
+
+	for _, invalid := range []string{
+		"0:bucket",
+		"x:bucket:access",
+		"0::access",
+		"0:bucket:invalid-access",
+	} {
+		require.Error(t, targets.Set(invalid), invalid)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package canary
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/uplink"
+)
+
+// Config contains configurable values for the canary objects.
+type Config struct {
+	Enabled          bool          `help:"whether to continuously upload, download and delete canary objects" default:"false"`
+	Interval         time.Duration `help:"how often the canary objects are verified" default:"5m"`
+	Targets          Targets       `help:"comma separated list of placement:bucket:access-grant the canary objects are uploaded with; the bucket must be created with the placement" default:""`
+	ObjectSize       memory.Size   `help:"size of the canary objects; should be larger than the inline segment size, so the pieces are stored on the nodes" default:"64KiB"`
+	Timeout          time.Duration `help:"maximum duration of verifying a single canary object" default:"1m"`
+	FailureThreshold int           `help:"number of consecutive failures of a placement before an alert is raised" default:"3"`
+}
+
+// Target is a bucket in a placement, where the canary objects are uploaded.
+type Target struct {
+	Placement   storj.PlacementConstraint
+	Bucket      string
+	AccessGrant string
+}
+
+// String implements pflag.Value.
+func (target Target) String() string {
+	return strconv.Itoa(int(target.Placement)) + ":" + target.Bucket + ":" + target.AccessGrant
+}
+
+// Set sets the value from a "placement:bucket:access-grant" string.
+func (target *Target) Set(s string) error {
+	tokens := strings.SplitN(s, ":", 3)
+	if len(tokens) != 3 {
+		return Error.New("invalid target %q", s)
+	}
+
+	placement, err := strconv.ParseUint(tokens[0], 10, 16)
+	if err != nil {
+		return Error.New("invalid placement %q: %v", tokens[0], err)
+	}
+	if tokens[1] == "" {
+		return Error.New("bucket is missing in target for placement %d", placement)
+	}
+	if _, err := uplink.ParseAccess(tokens[2]); err != nil {
+		return Error.New("invalid access grant for placement %d: %v", placement, err)
+	}
+
+	*target = Target{
+		Placement:   storj.PlacementConstraint(placement),
+		Bucket:      tokens[1],
+		AccessGrant: tokens[2],
+	}
+	return nil
+}
+
+// Targets is a list of canary targets.
+type Targets []Target
+
+// Type implements pflag.Value.
+func (Targets) Type() string { return "canary.Targets" }
+
+// String implements pflag.Value.
+func (targets Targets) String() string {
+	values := make([]string, 0, len(targets))
+	for _, target := range targets {
+		values = append(values, target.String())
+	}
+	return strings.Join(values, ",")
+}
+
+// Set sets the value from a comma delimited list of "placement:bucket:access-grant" strings.
+func (targets *Targets) Set(s string) error {
+	*targets = nil
+	if s == "" {
+		return nil
+	}
+
+	for _, value := range strings.Split(s, ",") {
+		var target Target
+		if err := target.Set(strings.TrimSpace(value)); err != nil {
+			return err
+		}
+		*targets = append(*targets, target)
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"runtime/pprof"
 
 	"github.com/spacemonkeygo/monkit/v3"
@@ -42,6 +43,7 @@ import (
 	"storj.io/storj/satellite/mailservice"
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/satellite/netstats"
 	"storj.io/storj/satellite/nodeselection"
 	"storj.io/storj/satellite/oidc"
 	"storj.io/storj/satellite/orders"
@@ -126,6 +128,10 @@ type ConsoleAPI struct {
 		AuthTokens *consoleauth.Service
 	}
 
+	NetworkStats struct {
+		Chore *netstats.Chore
+	}
+
 	OIDC struct {
 		Service *oidc.Service
 	}
@@ -311,8 +317,9 @@ func NewConsoleAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			satelliteSignee,
 			peer.Orders.DB,
 			peer.DB.NodeAPIVersion(),
-			config.Orders.OrdersSemaphoreSize,
+			config.Orders.SettlementQueue(),
 			peer.Orders.Service,
+			nil,
 		)
 
 		if err := pb.DRPCRegisterOrders(peer.Server.DRPC(), peer.Orders.Endpoint); err != nil {
@@ -480,6 +487,7 @@ func NewConsoleAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			peer.DB.ProjectAccounting(),
 			peer.Accounting.ProjectUsage,
 			peer.Buckets.Service,
+			metabaseDB,
 			peer.Payments.Accounts,
 			peer.Payments.DepositWallets,
 			peer.DB.Billing(),
@@ -507,6 +515,19 @@ func NewConsoleAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			return nil, errs.Combine(err, peer.Close())
 		}
 
+		var networkStats http.Handler
+		if config.NetworkStats.Enabled {
+			peer.NetworkStats.Chore = netstats.NewChore(peer.Log.Named("netstats:chore"), peer.Overlay.Service, peer.LiveAccounting.Cache, config.NetworkStats)
+			peer.Services.Add(lifecycle.Item{
+				Name:  "netstats:chore",
+				Run:   peer.NetworkStats.Chore.Run,
+				Close: peer.NetworkStats.Chore.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Network Stats Chore", peer.NetworkStats.Chore.Loop))
+			networkStats = peer.NetworkStats.Chore
+		}
+
 		peer.Console.Endpoint = consoleweb.NewServer(
 			peer.Log.Named("console:endpoint"),
 			consoleConfig,
@@ -522,6 +543,7 @@ func NewConsoleAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			peer.URL(),
 			config.Analytics,
 			config.Payments.PackagePlans,
+			networkStats,
 		)
 
 		peer.Servers.Add(lifecycle.Item{
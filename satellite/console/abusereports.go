@@ -0,0 +1,241 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+)
+
+// maxAbuseReportReasonLength is the maximum length of the reason of an abuse report.
+const maxAbuseReportReasonLength = 4096
+
+// ErrAbuseReportReviewed is returned when an abuse report, which was already reviewed, is reviewed again.
+var ErrAbuseReportReviewed = errs.Class("abuse report already reviewed")
+
+// AbuseReportsConfig contains configurations for abuse reports.
+type AbuseReportsConfig struct {
+	Enabled    bool   `help:"whether the public abuse report endpoint is enabled" default:"false"`
+	WebhookURL string `help:"url which is notified when the shared content of a report must be frozen or unfrozen, e.g. by the linksharing service" default:""`
+}
+
+// AbuseReports exposes methods to manage abuse reports.
+//
+// architecture: Database
+type AbuseReports interface {
+	// Insert inserts a new abuse report.
+	Insert(ctx context.Context, report AbuseReport) (*AbuseReport, error)
+	// Get returns the abuse report with the specified ID.
+	Get(ctx context.Context, id uuid.UUID) (*AbuseReport, error)
+	// ListByStatus returns the oldest abuse reports with the specified status.
+	ListByStatus(ctx context.Context, status AbuseReportStatus, limit int) ([]AbuseReport, error)
+	// UpdateStatus updates the status of the pending abuse report and records who reviewed it.
+	// It returns ErrAbuseReportReviewed when the report isn't pending anymore.
+	UpdateStatus(ctx context.Context, id uuid.UUID, status AbuseReportStatus, reviewedBy string, reviewedAt time.Time) error
+}
+
+// AbuseReportStatus is the review status of an abuse report.
+type AbuseReportStatus int
+
+const (
+	// AbuseReportPending is the status of a report which is waiting for review.
+	AbuseReportPending AbuseReportStatus = 0
+	// AbuseReportAccepted is the status of a report which was confirmed by the reviewer.
+	AbuseReportAccepted AbuseReportStatus = 1
+	// AbuseReportRejected is the status of a report which was dismissed by the reviewer.
+	AbuseReportRejected AbuseReportStatus = 2
+)
+
+// String returns a string representation of the abuse report status.
+func (status AbuseReportStatus) String() string {
+	switch status {
+	case AbuseReportPending:
+		return "pending"
+	case AbuseReportAccepted:
+		return "accepted"
+	case AbuseReportRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+// AbuseReportStatusFromString parses the string representation of an abuse report status.
+func AbuseReportStatusFromString(s string) (AbuseReportStatus, error) {
+	switch strings.ToLower(s) {
+	case "pending":
+		return AbuseReportPending, nil
+	case "accepted":
+		return AbuseReportAccepted, nil
+	case "rejected":
+		return AbuseReportRejected, nil
+	default:
+		return 0, errs.New("invalid abuse report status %q", s)
+	}
+}
+
+// AbuseReport is a report of abusive content shared through the satellite.
+type AbuseReport struct {
+	ID            uuid.UUID         `json:"id"`
+	URL           string            `json:"url"`
+	AccessKeyID   string            `json:"accessKeyId"`
+	Reason        string            `json:"reason"`
+	ReporterEmail string            `json:"reporterEmail"`
+	Status        AbuseReportStatus `json:"status"`
+	CreatedAt     time.Time         `json:"createdAt"`
+	ReviewedAt    *time.Time        `json:"reviewedAt"`
+	ReviewedBy    string            `json:"reviewedBy"`
+}
+
+// AbuseReportRequest contains the fields of a submitted abuse report.
+type AbuseReportRequest struct {
+	URL           string `json:"url"`
+	AccessKeyID   string `json:"accessKeyId"`
+	Reason        string `json:"reason"`
+	ReporterEmail string `json:"reporterEmail"`
+}
+
+// SubmitAbuseReport stores a new abuse report and requests freezing the reported content until it's reviewed.
+// The reported content is identified either by the shared URL or by the access key ID.
+func (s *Service) SubmitAbuseReport(ctx context.Context, request AbuseReportRequest) (_ *AbuseReport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !s.config.AbuseReports.Enabled {
+		return nil, ErrForbidden.New("abuse reports are not enabled")
+	}
+
+	request.URL = strings.TrimSpace(request.URL)
+	request.AccessKeyID = strings.TrimSpace(request.AccessKeyID)
+	request.Reason = strings.TrimSpace(request.Reason)
+	request.ReporterEmail = strings.TrimSpace(request.ReporterEmail)
+
+	if request.URL == "" && request.AccessKeyID == "" {
+		return nil, ErrValidation.New("either the shared URL or the access key ID is required")
+	}
+	if request.URL != "" {
+		accessKeyID, err := accessKeyIDFromSharedURL(request.URL)
+		if err != nil {
+			return nil, ErrValidation.Wrap(err)
+		}
+		if request.AccessKeyID == "" {
+			request.AccessKeyID = accessKeyID
+		}
+	}
+	if request.Reason == "" {
+		return nil, ErrValidation.New("reason is required")
+	}
+	if len(request.Reason) > maxAbuseReportReasonLength {
+		return nil, ErrValidation.New("reason must be at most %d characters long", maxAbuseReportReasonLength)
+	}
+	if request.ReporterEmail != "" {
+		if _, err := mail.ParseAddress(request.ReporterEmail); err != nil {
+			return nil, ErrValidation.New("invalid reporter email")
+		}
+	}
+
+	id, err := uuid.New()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	report, err := s.store.AbuseReports().Insert(ctx, AbuseReport{
+		ID:            id,
+		URL:           request.URL,
+		AccessKeyID:   request.AccessKeyID,
+		Reason:        request.Reason,
+		ReporterEmail: request.ReporterEmail,
+		Status:        AbuseReportPending,
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	s.auditLog(ctx, "submit abuse report", nil, request.ReporterEmail, zap.Stringer("report", report.ID))
+
+	// Failing to freeze the content must not lose the report, hence the error is only logged.
+	if err := NotifyAbuseReportWebhook(ctx, s.config.AbuseReports.WebhookURL, AbuseReportActionFreeze, *report); err != nil {
+		s.log.Error("failed to freeze reported content", zap.Stringer("report", report.ID), zap.Error(err))
+	}
+
+	return report, nil
+}
+
+// accessKeyIDFromSharedURL extracts the access key ID from a linksharing URL,
+// e.g. https://link.storjshare.io/s/<access key id>/bucket/key.
+func accessKeyIDFromSharedURL(sharedURL string) (string, error) {
+	parsed, err := url.Parse(sharedURL)
+	if err != nil || parsed.Host == "" {
+		return "", errs.New("invalid shared URL")
+	}
+
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) >= 2 && (parts[0] == "s" || parts[0] == "raw") {
+		return parts[1], nil
+	}
+	// custom domains don't include the access key ID in the URL.
+	return "", nil
+}
+
+// AbuseReportAction is the action requested from the abuse report webhook.
+type AbuseReportAction string
+
+const (
+	// AbuseReportActionFreeze requests blocking access to the reported content.
+	AbuseReportActionFreeze AbuseReportAction = "freeze"
+	// AbuseReportActionUnfreeze requests restoring access to the reported content.
+	AbuseReportActionUnfreeze AbuseReportAction = "unfreeze"
+)
+
+// NotifyAbuseReportWebhook sends the action for the reported content to the webhook.
+// It does nothing when the webhook URL isn't configured.
+func NotifyAbuseReportWebhook(ctx context.Context, webhookURL string, action AbuseReportAction, report AbuseReport) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if webhookURL == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(struct {
+		Action      AbuseReportAction `json:"action"`
+		ReportID    uuid.UUID         `json:"reportId"`
+		URL         string            `json:"url"`
+		AccessKeyID string            `json:"accessKeyId"`
+	}{
+		Action:      action,
+		ReportID:    report.ID,
+		URL:         report.URL,
+		AccessKeyID: report.AccessKeyID,
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, resp.Body.Close()) }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return Error.New("abuse report webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
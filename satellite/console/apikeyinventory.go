@@ -0,0 +1,259 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/grant"
+	"storj.io/common/macaroon"
+	"storj.io/common/uuid"
+)
+
+// APIKeyVerb is an operation an API key can authorize.
+type APIKeyVerb string
+
+const (
+	// APIKeyVerbRead allows downloading objects.
+	APIKeyVerbRead APIKeyVerb = "read"
+	// APIKeyVerbWrite allows uploading objects.
+	APIKeyVerbWrite APIKeyVerb = "write"
+	// APIKeyVerbList allows listing buckets and objects.
+	APIKeyVerbList APIKeyVerb = "list"
+	// APIKeyVerbDelete allows deleting buckets and objects.
+	APIKeyVerbDelete APIKeyVerb = "delete"
+	// APIKeyVerbLock allows managing the Object Lock settings.
+	APIKeyVerbLock APIKeyVerb = "lock"
+)
+
+// verbsForVersion returns the verbs an unrestricted API key of the version authorizes.
+func verbsForVersion(version macaroon.APIKeyVersion) []APIKeyVerb {
+	verbs := []APIKeyVerb{APIKeyVerbRead, APIKeyVerbWrite, APIKeyVerbList, APIKeyVerbDelete}
+	if version >= macaroon.APIKeyVersionObjectLock {
+		verbs = append(verbs, APIKeyVerbLock)
+	}
+	return verbs
+}
+
+// APIKeyInventoryItem describes an API key of a project and what it authorizes.
+//
+// The satellite only stores the unrestricted root of every API key. The access
+// grants derived from it by the clients may be further restricted, but the
+// restrictions aren't known until the access grant is inspected.
+type APIKeyInventoryItem struct {
+	ID        uuid.UUID              `json:"id"`
+	Name      string                 `json:"name"`
+	CreatedBy uuid.UUID              `json:"createdBy"`
+	CreatedAt time.Time              `json:"createdAt"`
+	Version   macaroon.APIKeyVersion `json:"version"`
+	// ObjectBrowser is whether the key was created by the satellite UI for the object browser.
+	ObjectBrowser bool `json:"objectBrowser"`
+	// Verbs are the operations the root of the key authorizes.
+	Verbs []APIKeyVerb `json:"verbs"`
+}
+
+// APIKeyPrefix is a prefix an API key is restricted to.
+type APIKeyPrefix struct {
+	Bucket string `json:"bucket"`
+	// EncryptedPrefix is the encrypted object key prefix. The satellite can't
+	// decrypt it, because it doesn't know the encryption key of the access grant.
+	EncryptedPrefix []byte `json:"encryptedPrefix,omitempty"`
+}
+
+// APIKeyCaveat describes a single restriction added to an API key.
+type APIKeyCaveat struct {
+	Verbs        []APIKeyVerb   `json:"verbs"`
+	Prefixes     []APIKeyPrefix `json:"prefixes,omitempty"`
+	NotBefore    *time.Time     `json:"notBefore,omitempty"`
+	NotAfter     *time.Time     `json:"notAfter,omitempty"`
+	MaxObjectTTL *time.Duration `json:"maxObjectTTL,omitempty"`
+}
+
+// APIKeyInspection is the result of inspecting an access grant or an API key.
+type APIKeyInspection struct {
+	// SatelliteAddress is empty when an API key was inspected.
+	SatelliteAddress string              `json:"satelliteAddress,omitempty"`
+	Key              APIKeyInventoryItem `json:"key"`
+	Caveats          []APIKeyCaveat      `json:"caveats"`
+
+	// Verbs, NotBefore and NotAfter are the combined restrictions of all caveats.
+	// Every caveat restricts the prefixes further, so they aren't combined.
+	Verbs     []APIKeyVerb `json:"verbs"`
+	NotBefore *time.Time   `json:"notBefore,omitempty"`
+	NotAfter  *time.Time   `json:"notAfter,omitempty"`
+}
+
+// GetAPIKeyInventory returns all API keys of the project with the operations they authorize.
+func (s *Service) GetAPIKeyInventory(ctx context.Context, reqProjectID uuid.UUID) (_ []APIKeyInventoryItem, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get api key inventory", zap.String("projectID", reqProjectID.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, reqProjectID)
+	if err != nil {
+		return nil, ErrUnauthorized.Wrap(err)
+	}
+
+	var items []APIKeyInventoryItem
+	cursor := APIKeyCursor{
+		Limit:          maxLimit,
+		Page:           1,
+		Order:          CreationDate,
+		OrderDirection: Ascending,
+	}
+	for {
+		page, err := s.store.APIKeys().GetPagedByProjectID(ctx, isMember.project.ID, cursor, "")
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		for _, key := range page.APIKeys {
+			items = append(items, s.apiKeyInventoryItem(key))
+		}
+
+		if cursor.Page >= page.PageCount {
+			return items, nil
+		}
+		cursor.Page++
+	}
+}
+
+// InspectAPIKey decodes the restrictions of an access grant or an API key of the project.
+// The key must be derived from one of the API keys of the project.
+func (s *Service) InspectAPIKey(ctx context.Context, reqProjectID uuid.UUID, serialized string) (_ *APIKeyInspection, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "inspect api key", zap.String("projectID", reqProjectID.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, reqProjectID)
+	if err != nil {
+		return nil, ErrUnauthorized.Wrap(err)
+	}
+
+	var inspection APIKeyInspection
+
+	serialized = strings.TrimSpace(serialized)
+	apiKey, err := macaroon.ParseAPIKey(serialized)
+	if err != nil {
+		access, accessErr := grant.ParseAccess(serialized)
+		if accessErr != nil {
+			return nil, ErrAPIKeyRequest.New("the value is neither an access grant nor an API key")
+		}
+		apiKey = access.APIKey
+		inspection.SatelliteAddress = access.SatelliteAddress
+	}
+
+	mac, err := macaroon.ParseMacaroon(apiKey.SerializeRaw())
+	if err != nil {
+		return nil, ErrAPIKeyRequest.Wrap(err)
+	}
+
+	info, err := s.store.APIKeys().GetByHead(ctx, apiKey.Head())
+	if err != nil || info.ProjectID != isMember.project.ID || !mac.Validate(info.Secret) {
+		return nil, ErrNoAPIKey.New("the key doesn't belong to an API key of the project")
+	}
+
+	inspection.Key = s.apiKeyInventoryItem(*info)
+	inspection.Caveats = []APIKeyCaveat{}
+
+	allowed := map[APIKeyVerb]bool{}
+	for _, verb := range inspection.Key.Verbs {
+		allowed[verb] = true
+	}
+
+	for _, data := range mac.Caveats() {
+		var caveat macaroon.Caveat
+		if err := caveat.UnmarshalBinary(data); err != nil {
+			return nil, ErrAPIKeyRequest.Wrap(err)
+		}
+
+		decoded := decodeCaveat(caveat, info.Version)
+		inspection.Caveats = append(inspection.Caveats, decoded)
+
+		verbs := map[APIKeyVerb]bool{}
+		for _, verb := range decoded.Verbs {
+			verbs[verb] = true
+		}
+		for verb := range allowed {
+			allowed[verb] = allowed[verb] && verbs[verb]
+		}
+
+		if decoded.NotBefore != nil && (inspection.NotBefore == nil || decoded.NotBefore.After(*inspection.NotBefore)) {
+			inspection.NotBefore = decoded.NotBefore
+		}
+		if decoded.NotAfter != nil && (inspection.NotAfter == nil || decoded.NotAfter.Before(*inspection.NotAfter)) {
+			inspection.NotAfter = decoded.NotAfter
+		}
+	}
+
+	inspection.Verbs = []APIKeyVerb{}
+	for _, verb := range inspection.Key.Verbs {
+		if allowed[verb] {
+			inspection.Verbs = append(inspection.Verbs, verb)
+		}
+	}
+
+	return &inspection, nil
+}
+
+// apiKeyInventoryItem converts the stored API key info into an inventory item.
+func (s *Service) apiKeyInventoryItem(info APIKeyInfo) APIKeyInventoryItem {
+	return APIKeyInventoryItem{
+		ID:            info.ID,
+		Name:          info.Name,
+		CreatedBy:     info.CreatedBy,
+		CreatedAt:     info.CreatedAt,
+		Version:       info.Version,
+		ObjectBrowser: s.config.ObjectBrowserKeyNamePrefix != "" && strings.HasPrefix(info.Name, s.config.ObjectBrowserKeyNamePrefix),
+		Verbs:         verbsForVersion(info.Version),
+	}
+}
+
+// decodeCaveat converts the caveat into its displayable form.
+func decodeCaveat(caveat macaroon.Caveat, version macaroon.APIKeyVersion) APIKeyCaveat {
+	decoded := APIKeyCaveat{
+		Verbs:        []APIKeyVerb{},
+		NotBefore:    caveat.NotBefore,
+		NotAfter:     caveat.NotAfter,
+		MaxObjectTTL: caveat.MaxObjectTtl,
+	}
+
+	for _, verb := range verbsForVersion(version) {
+		var disallowed bool
+		switch verb {
+		case APIKeyVerbRead:
+			disallowed = caveat.DisallowReads
+		case APIKeyVerbWrite:
+			disallowed = caveat.DisallowWrites
+		case APIKeyVerbList:
+			disallowed = caveat.DisallowLists
+		case APIKeyVerbDelete:
+			disallowed = caveat.DisallowDeletes
+		case APIKeyVerbLock:
+			disallowed = caveat.DisallowLocks
+		}
+		if !disallowed {
+			decoded.Verbs = append(decoded.Verbs, verb)
+		}
+	}
+
+	for _, path := range caveat.AllowedPaths {
+		decoded.Prefixes = append(decoded.Prefixes, APIKeyPrefix{
+			Bucket:          string(path.Bucket),
+			EncryptedPrefix: path.EncryptedPathPrefix,
+		})
+	}
+
+	return decoded
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/grant"
+	"storj.io/common/macaroon"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/console"
+)
+
+func TestAPIKeyInventory(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 2,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		project, err := sat.DB.Console().Projects().Get(ctx, planet.Uplinks[0].Projects[0].ID)
+		require.NoError(t, err)
+		userCtx, err := sat.UserContext(ctx, project.OwnerID)
+		require.NoError(t, err)
+
+		otherProjectID := planet.Uplinks[1].Projects[0].ID
+		otherUserCtx, err := sat.UserContext(ctx, planet.Uplinks[1].Projects[0].Owner.ID)
+		require.NoError(t, err)
+
+		info, key, err := service.CreateAPIKey(userCtx, project.ID, "inventory key", macaroon.APIKeyVersionObjectLock)
+		require.NoError(t, err)
+
+		inventory, err := service.GetAPIKeyInventory(userCtx, project.PublicID)
+		require.NoError(t, err)
+
+		var found *console.APIKeyInventoryItem
+		for i := range inventory {
+			if inventory[i].ID == info.ID {
+				found = &inventory[i]
+			}
+		}
+		require.NotNil(t, found)
+		require.Equal(t, "inventory key", found.Name)
+		require.Equal(t, []console.APIKeyVerb{
+			console.APIKeyVerbRead, console.APIKeyVerbWrite, console.APIKeyVerbList,
+			console.APIKeyVerbDelete, console.APIKeyVerbLock,
+		}, found.Verbs)
+
+		_, err = service.GetAPIKeyInventory(otherUserCtx, project.ID)
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		notBefore := time.Now().Add(-time.Hour).Truncate(time.Second).UTC()
+		notAfter := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+
+		restricted, err := key.Restrict(macaroon.Caveat{
+			DisallowWrites:  true,
+			DisallowDeletes: true,
+			NotAfter:        &notAfter,
+			AllowedPaths: []*macaroon.Caveat_Path{
+				{Bucket: []byte("bucket"), EncryptedPathPrefix: []byte("prefix")},
+			},
+		})
+		require.NoError(t, err)
+		restricted, err = restricted.Restrict(macaroon.Caveat{
+			DisallowLocks: true,
+			NotBefore:     &notBefore,
+		})
+		require.NoError(t, err)
+
+		access, err := (&grant.Access{
+			SatelliteAddress: sat.NodeURL().String(),
+			APIKey:           restricted,
+			EncAccess:        grant.NewEncryptionAccessWithDefaultKey(&storj.Key{}),
+		}).Serialize()
+		require.NoError(t, err)
+
+		for _, serialized := range []string{access, restricted.Serialize()} {
+			inspection, err := service.InspectAPIKey(userCtx, project.ID, serialized)
+			require.NoError(t, err)
+			require.Equal(t, info.ID, inspection.Key.ID)
+			require.Len(t, inspection.Caveats, 2)
+			require.Equal(t, []console.APIKeyPrefix{
+				{Bucket: "bucket", EncryptedPrefix: []byte("prefix")},
+			}, inspection.Caveats[0].Prefixes)
+			require.Equal(t, []console.APIKeyVerb{console.APIKeyVerbRead, console.APIKeyVerbList}, inspection.Verbs)
+			require.NotNil(t, inspection.NotBefore)
+			require.True(t, notBefore.Equal(*inspection.NotBefore))
+			require.NotNil(t, inspection.NotAfter)
+			require.True(t, notAfter.Equal(*inspection.NotAfter))
+		}
+
+		_, err = service.InspectAPIKey(userCtx, project.ID, "invalid")
+		require.True(t, console.ErrAPIKeyRequest.Has(err))
+
+		unknownKey, err := macaroon.NewAPIKey([]byte("secret"))
+		require.NoError(t, err)
+		_, err = service.InspectAPIKey(userCtx, project.ID, unknownKey.Serialize())
+		require.True(t, console.ErrNoAPIKey.Has(err))
+
+		// the access grant doesn't belong to the other project.
+		_, err = service.InspectAPIKey(otherUserCtx, otherProjectID, access)
+		require.True(t, console.ErrNoAPIKey.Has(err))
+	})
+}
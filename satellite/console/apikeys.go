@@ -41,11 +41,48 @@ type APIKeys interface {
 
 // RESTKeys is an interface for rest key operations.
 type RESTKeys interface {
-	Create(ctx context.Context, userID uuid.UUID, expiration time.Duration) (apiKey string, expiresAt time.Time, err error)
+	Create(ctx context.Context, userID uuid.UUID, expiration time.Duration, scopes ...RESTKeyScope) (apiKey string, expiresAt time.Time, err error)
 	GetUserAndExpirationFromKey(ctx context.Context, apiKey string) (userID uuid.UUID, exp time.Time, err error)
+	GetKeyInfo(ctx context.Context, apiKey string) (info RESTKeyInfo, err error)
 	Revoke(ctx context.Context, apiKey string) (err error)
 }
 
+// RESTKeyScope limits which part of the account management API a REST key can use.
+type RESTKeyScope string
+
+const (
+	// RESTKeyScopeProjects allows managing projects and their limits.
+	RESTKeyScopeProjects RESTKeyScope = "projects"
+	// RESTKeyScopeAPIKeys allows managing the API keys of projects.
+	RESTKeyScopeAPIKeys RESTKeyScope = "apikeys"
+	// RESTKeyScopeUsers allows reading the account information.
+	RESTKeyScopeUsers RESTKeyScope = "users"
+)
+
+// RESTKeyScopes contains all the valid REST key scopes.
+var RESTKeyScopes = []RESTKeyScope{RESTKeyScopeProjects, RESTKeyScopeAPIKeys, RESTKeyScopeUsers}
+
+// RESTKeyInfo contains the information about a REST key.
+type RESTKeyInfo struct {
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+	// Scopes are the scopes the key is restricted to. A key without scopes can use all of them.
+	Scopes []RESTKeyScope
+}
+
+// Allows returns whether the key can be used for the scope.
+func (info RESTKeyInfo) Allows(scope RESTKeyScope) bool {
+	if len(info.Scopes) == 0 {
+		return true
+	}
+	for _, allowed := range info.Scopes {
+		if allowed == scope {
+			return true
+		}
+	}
+	return false
+}
+
 // CreateAPIKeyRequest holds create API key info.
 type CreateAPIKeyRequest struct {
 	ProjectID string `json:"projectID"`
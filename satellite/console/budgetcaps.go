@@ -0,0 +1,254 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/uuid"
+)
+
+// BudgetCapThresholds are the percentages of the budget cap at which the project owner is notified.
+var BudgetCapThresholds = []int{50, 80, 100}
+
+// BudgetCapsConfig contains configurations for project budget caps.
+type BudgetCapsConfig struct {
+	Enabled bool `help:"whether users can set monthly budget caps for their projects" default:"false"`
+}
+
+// ProjectBudgetCaps exposes methods to manage project budget caps.
+//
+// architecture: Database
+type ProjectBudgetCaps interface {
+	// Get returns the budget cap of the project. It returns sql.ErrNoRows when the project has no cap.
+	Get(ctx context.Context, projectID uuid.UUID) (*ProjectBudgetCap, error)
+	// Upsert inserts or updates the budget cap of the project.
+	Upsert(ctx context.Context, budgetCap ProjectBudgetCap) error
+	// Delete deletes the budget cap of the project.
+	Delete(ctx context.Context, projectID uuid.UUID) error
+	// List returns the budget caps of projects with IDs greater than the cursor, ordered by project ID.
+	List(ctx context.Context, cursor uuid.UUID, limit int) ([]ProjectBudgetCap, error)
+}
+
+// ProjectBudgetCap is a monthly spend cap set by the user for a project.
+type ProjectBudgetCap struct {
+	ProjectID uuid.UUID `json:"-"`
+	// CapCents is the maximum estimated spend of the project in a month.
+	CapCents int64 `json:"capCents"`
+	// NotifiedPercent is the highest threshold the owner was notified about in the current period.
+	NotifiedPercent int `json:"notifiedPercent"`
+	// PeriodStart is the beginning of the month NotifiedPercent and the freeze refer to.
+	PeriodStart time.Time `json:"-"`
+	// FrozenAt is set when uploads or egress were frozen because the cap was reached.
+	FrozenAt *time.Time `json:"frozenAt"`
+	// FrozenStorageLimit and FrozenBandwidthLimit are the user specified limits of the
+	// project before it was frozen, which are restored when it's unfrozen.
+	FrozenStorageLimit   *int64    `json:"-"`
+	FrozenBandwidthLimit *int64    `json:"-"`
+	CreatedAt            time.Time `json:"createdAt"`
+	UpdatedAt            time.Time `json:"updatedAt"`
+}
+
+// Frozen returns whether uploads or egress of the project are frozen because the cap was reached.
+func (budgetCap *ProjectBudgetCap) Frozen() bool {
+	return budgetCap.FrozenAt != nil
+}
+
+// BudgetCapPeriodStart returns the beginning of the billing period containing t.
+func BudgetCapPeriodStart(t time.Time) time.Time {
+	year, month, _ := t.UTC().Date()
+	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// FreezeProjectBudgetCap zeroes the user specified storage and/or bandwidth limits of the project,
+// which stops uploads and/or egress. The previous limits are stored in the budget cap.
+func FreezeProjectBudgetCap(ctx context.Context, projects Projects, budgetCaps ProjectBudgetCaps, project *Project, budgetCap *ProjectBudgetCap, uploads, egress bool, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if budgetCap.Frozen() || (!uploads && !egress) {
+		return nil
+	}
+
+	budgetCap.FrozenStorageLimit = nil
+	if project.UserSpecifiedStorageLimit != nil {
+		value := project.UserSpecifiedStorageLimit.Int64()
+		budgetCap.FrozenStorageLimit = &value
+	}
+	budgetCap.FrozenBandwidthLimit = nil
+	if project.UserSpecifiedBandwidthLimit != nil {
+		value := project.UserSpecifiedBandwidthLimit.Int64()
+		budgetCap.FrozenBandwidthLimit = &value
+	}
+	budgetCap.FrozenAt = &now
+
+	// the previous limits are stored first, so they can't get lost.
+	if err := budgetCaps.Upsert(ctx, *budgetCap); err != nil {
+		return Error.Wrap(err)
+	}
+
+	var zero int64
+	var updates []Limit
+	if uploads {
+		updates = append(updates, Limit{Kind: UserSetStorageLimit, Value: &zero})
+	}
+	if egress {
+		updates = append(updates, Limit{Kind: UserSetBandwidthLimit, Value: &zero})
+	}
+	return Error.Wrap(projects.UpdateLimitsGeneric(ctx, project.ID, updates))
+}
+
+// UnfreezeProjectBudgetCap restores the user specified limits the project had before
+// it was frozen because its budget cap was reached.
+func UnfreezeProjectBudgetCap(ctx context.Context, projects Projects, budgetCaps ProjectBudgetCaps, budgetCap *ProjectBudgetCap) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !budgetCap.Frozen() {
+		return nil
+	}
+
+	err = projects.UpdateLimitsGeneric(ctx, budgetCap.ProjectID, []Limit{
+		{Kind: UserSetStorageLimit, Value: budgetCap.FrozenStorageLimit},
+		{Kind: UserSetBandwidthLimit, Value: budgetCap.FrozenBandwidthLimit},
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	budgetCap.FrozenAt = nil
+	budgetCap.FrozenStorageLimit = nil
+	budgetCap.FrozenBandwidthLimit = nil
+	return Error.Wrap(budgetCaps.Upsert(ctx, *budgetCap))
+}
+
+// GetProjectBudgetCap returns the budget cap of the project or nil when the project has no cap.
+// projectID here may be project.PublicID or project.ID.
+func (s *Service) GetProjectBudgetCap(ctx context.Context, projectID uuid.UUID) (_ *ProjectBudgetCap, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get project budget cap", zap.String("projectID", projectID.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	budgetCap, err := s.store.ProjectBudgetCaps().Get(ctx, isMember.project.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, Error.Wrap(err)
+	}
+	return budgetCap, nil
+}
+
+// SetProjectBudgetCap sets the monthly budget cap of the project.
+// Changing the cap unfreezes the project, it's frozen again when the new cap is reached.
+// projectID here may be project.PublicID or project.ID.
+func (s *Service) SetProjectBudgetCap(ctx context.Context, projectID uuid.UUID, capCents int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "set project budget cap", zap.String("projectID", projectID.String()), zap.Int64("capCents", capCents))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if !s.config.BudgetCaps.Enabled {
+		return ErrForbidden.New("budget caps are not enabled")
+	}
+	if capCents <= 0 {
+		return ErrValidation.New("budget cap must be positive")
+	}
+
+	_, project, err := s.isProjectOwner(ctx, user.ID, projectID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	budgetCaps := s.store.ProjectBudgetCaps()
+
+	budgetCap, err := budgetCaps.Get(ctx, project.ID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		budgetCap = &ProjectBudgetCap{ProjectID: project.ID}
+	case err != nil:
+		return Error.Wrap(err)
+	}
+
+	if err := UnfreezeProjectBudgetCap(ctx, s.store.Projects(), budgetCaps, budgetCap); err != nil {
+		return err
+	}
+
+	budgetCap.CapCents = capCents
+	budgetCap.NotifiedPercent = 0
+	budgetCap.PeriodStart = BudgetCapPeriodStart(s.nowFn())
+
+	return Error.Wrap(budgetCaps.Upsert(ctx, *budgetCap))
+}
+
+// RemoveProjectBudgetCap removes the budget cap of the project and unfreezes it.
+// projectID here may be project.PublicID or project.ID.
+func (s *Service) RemoveProjectBudgetCap(ctx context.Context, projectID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "remove project budget cap", zap.String("projectID", projectID.String()))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	_, project, err := s.isProjectOwner(ctx, user.ID, projectID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	budgetCaps := s.store.ProjectBudgetCaps()
+
+	budgetCap, err := budgetCaps.Get(ctx, project.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return Error.Wrap(err)
+	}
+
+	if err := UnfreezeProjectBudgetCap(ctx, s.store.Projects(), budgetCaps, budgetCap); err != nil {
+		return err
+	}
+
+	return Error.Wrap(budgetCaps.Delete(ctx, project.ID))
+}
+
+// checkNotFrozenByBudgetCap returns an error when the project is frozen because its budget cap was reached.
+func (s *Service) checkNotFrozenByBudgetCap(ctx context.Context, projectID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	budgetCap, err := s.store.ProjectBudgetCaps().Get(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return Error.Wrap(err)
+	}
+	if budgetCap.Frozen() {
+		return ErrConflict.New("project limits can't be changed while the project budget cap is reached, raise or remove the budget cap instead")
+	}
+	return nil
+}
+
+// userLimitChanged returns whether the updated user specified limit differs from the current one.
+func userLimitChanged(current, updated *memory.Size) bool {
+	if updated == nil {
+		return false
+	}
+	return current == nil || *current != *updated
+}
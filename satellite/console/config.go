@@ -11,6 +11,7 @@ import (
 
 	"storj.io/common/storj"
 	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console/consoleauth/sso"
 )
 
 // Config keeps track of core console service configuration parameters.
@@ -43,6 +44,12 @@ type Config struct {
 	Captcha                           CaptchaConfig
 	Session                           SessionConfig
 	AccountFreeze                     AccountFreezeConfig
+	ObjectPreview                     ObjectPreviewConfig
+	AbuseReports                      AbuseReportsConfig
+	Domains                           DomainsConfig
+	BudgetCaps                        BudgetCapsConfig
+	UsageWebhooks                     UsageWebhooksConfig
+	SSO                               sso.Config
 }
 
 // CaptchaConfig contains configurations for login/registration captcha system.
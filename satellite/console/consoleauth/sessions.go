@@ -28,6 +28,8 @@ type WebappSessions interface {
 	DeleteAllByUserIDExcept(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) (int64, error)
 	// UpdateExpiration updates the expiration time of the session.
 	UpdateExpiration(ctx context.Context, sessionID uuid.UUID, expiresAt time.Time) error
+	// UpdateStatus updates the status of the session.
+	UpdateStatus(ctx context.Context, sessionID uuid.UUID, status int) error
 	// DeleteExpired deletes all sessions that have expired before the provided timestamp.
 	DeleteExpired(ctx context.Context, now time.Time, asOfSystemTimeInterval time.Duration, pageSize int) error
 }
@@ -43,6 +45,14 @@ type WebappSession struct {
 	IsRequesterCurrentSession bool      `json:"isRequesterCurrentSession"`
 }
 
+const (
+	// WebappSessionStatusDefault is the status of a session created by the user logging in.
+	WebappSessionStatusDefault = 0
+	// WebappSessionStatusImpersonated is the status of a session created by an admin
+	// impersonating the user for support purposes.
+	WebappSessionStatusImpersonated = 1
+)
+
 // WebappSessionsOrder is used for querying webapp sessions in specified order.
 type WebappSessionsOrder int8
 
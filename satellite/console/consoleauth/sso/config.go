@@ -0,0 +1,189 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package sso
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	// ProviderGoogle is the name of the Google provider, which doesn't need an issuer URL.
+	ProviderGoogle = "google"
+	// ProviderGitHub is the name of the GitHub provider. GitHub isn't an OpenID
+	// Connect provider, the identity is read from its REST API.
+	ProviderGitHub = "github"
+
+	googleIssuer = "https://accounts.google.com"
+)
+
+// Config contains the configuration of the sign in with SSO providers.
+type Config struct {
+	Enabled              bool      `help:"whether users can sign in and sign up with SSO providers" default:"false"`
+	Providers            Providers `help:"semicolon-separated SSO providers in the format name,client-id,client-secret[,issuer-url]. google and github don't need an issuer URL, the other providers are OpenID Connect providers discovered from their issuer URL"`
+	AllowSignup          bool      `help:"create accounts for the unknown users signing in with SSO" default:"true"`
+	SignupDomains        []string  `help:"email domains of the users whose accounts may be created when signing in with SSO, empty allows any domain" default:""`
+	LinkExistingAccounts bool      `help:"link SSO identities to the existing accounts with the same verified email, only for the providers and domains listed in link-domains" default:"false"`
+	LinkDomains          []string  `help:"provider:domain pairs, e.g. okta:example.com, whose identities may be linked to the existing accounts with the same verified email" default:""`
+	EnforcedDomains      []string  `help:"email domains whose users must sign in with SSO, password sign in and sign up are rejected for them" default:""`
+}
+
+// SignupAllowed returns whether an account may be created for the email when signing in with SSO.
+func (config Config) SignupAllowed(email string) bool {
+	if !config.AllowSignup {
+		return false
+	}
+	return len(config.SignupDomains) == 0 || domainListed(config.SignupDomains, email)
+}
+
+// LinkAllowed returns whether an identity of the provider may be linked to the
+// existing account with the same email. The provider must be trusted for the
+// domain of the email, otherwise any provider claiming the email could take
+// over the account.
+func (config Config) LinkAllowed(provider, email string) bool {
+	domain, ok := emailDomain(email)
+	if !config.LinkExistingAccounts || !ok {
+		return false
+	}
+	for _, listed := range config.LinkDomains {
+		listedProvider, listedDomain, ok := strings.Cut(listed, ":")
+		if !ok {
+			continue
+		}
+		if strings.ToLower(strings.TrimSpace(listedProvider)) == provider &&
+			strings.ToLower(strings.TrimSpace(listedDomain)) == domain {
+			return true
+		}
+	}
+	return false
+}
+
+// Enforced returns whether the user with the email must sign in with SSO.
+func (config Config) Enforced(email string) bool {
+	return config.Enabled && domainListed(config.EnforcedDomains, email)
+}
+
+// domainListed returns whether the domain of the email is one of the domains.
+func domainListed(domains []string, email string) bool {
+	domain, ok := emailDomain(email)
+	if !ok {
+		return false
+	}
+	for _, listed := range domains {
+		if strings.ToLower(strings.TrimSpace(listed)) == domain {
+			return true
+		}
+	}
+	return false
+}
+
+// emailDomain returns the lowercase domain of the email.
+func emailDomain(email string) (string, bool) {
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return "", false
+	}
+	return strings.ToLower(strings.TrimSpace(email[at+1:])), true
+}
+
+// ProviderConfig contains the OAuth client of a provider.
+type ProviderConfig struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	// IssuerURL is the issuer of an OpenID Connect provider.
+	IssuerURL string
+}
+
+// Providers contains the configured SSO providers by their name.
+type Providers struct {
+	Providers map[string]ProviderConfig
+}
+
+// Type returns the type of the flag.
+func (Providers) Type() string { return "sso.Providers" }
+
+// String returns the string representation of the providers.
+func (p *Providers) String() string {
+	if p == nil {
+		return ""
+	}
+
+	var entries []string
+	for _, name := range p.Names() {
+		provider := p.Providers[name]
+		fields := []string{provider.Name, provider.ClientID, provider.ClientSecret}
+		if provider.IssuerURL != "" {
+			fields = append(fields, provider.IssuerURL)
+		}
+		entries = append(entries, strings.Join(fields, ","))
+	}
+	return strings.Join(entries, ";")
+}
+
+// Set parses the providers from the string.
+func (p *Providers) Set(s string) error {
+	providers := make(map[string]ProviderConfig)
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		fields := strings.Split(entry, ",")
+		if len(fields) != 3 && len(fields) != 4 {
+			return Error.New("invalid provider (expected format name,client-id,client-secret[,issuer-url] got %s)", entry)
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		provider := ProviderConfig{
+			Name:         strings.ToLower(fields[0]),
+			ClientID:     fields[1],
+			ClientSecret: fields[2],
+		}
+		if len(fields) == 4 {
+			provider.IssuerURL = strings.TrimSuffix(fields[3], "/")
+		}
+
+		switch {
+		case provider.Name == "":
+			return Error.New("provider name must not be empty")
+		case provider.ClientID == "" || provider.ClientSecret == "":
+			return Error.New("client ID and secret of provider %q must not be empty", provider.Name)
+		case provider.Name == ProviderGitHub && provider.IssuerURL != "":
+			return Error.New("provider %q doesn't support an issuer URL", provider.Name)
+		case provider.Name == ProviderGoogle && provider.IssuerURL == "":
+			provider.IssuerURL = googleIssuer
+		case provider.Name != ProviderGitHub && provider.IssuerURL == "":
+			return Error.New("issuer URL of provider %q is missing", provider.Name)
+		}
+
+		if provider.IssuerURL != "" {
+			if _, err := url.ParseRequestURI(provider.IssuerURL); err != nil {
+				return Error.New("invalid issuer URL of provider %q: %v", provider.Name, err)
+			}
+		}
+
+		if _, ok := providers[provider.Name]; ok {
+			return Error.New("provider %q is configured more than once", provider.Name)
+		}
+		providers[provider.Name] = provider
+	}
+
+	p.Providers = providers
+	return nil
+}
+
+// Names returns the sorted names of the providers.
+func (p *Providers) Names() []string {
+	names := make([]string, 0, len(p.Providers))
+	for name := range p.Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package sso
+
+import (
+	"context"
+	"strconv"
+
+	"golang.org/x/oauth2"
+)
+
+// githubAPI is the base URL of the GitHub REST API.
+const githubAPI = "https://api.github.com"
+
+// githubIdentity reads the identity of the user from the GitHub REST API. The
+// email is the primary email of the user, it's verified when GitHub verified it.
+func (service *Service) githubIdentity(ctx context.Context, token *oauth2.Token) (_ Identity, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	}
+	if err := getJSON(ctx, service.client, githubAPI+"/user", token.AccessToken, &user); err != nil {
+		return Identity{}, Error.New("unable to get GitHub user: %w", err)
+	}
+	if user.ID == 0 {
+		return Identity{}, ErrInvalidIdentity.New("GitHub user ID is missing")
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := getJSON(ctx, service.client, githubAPI+"/user/emails", token.AccessToken, &emails); err != nil {
+		return Identity{}, Error.New("unable to get GitHub user emails: %w", err)
+	}
+
+	identity := Identity{
+		Subject: strconv.FormatInt(user.ID, 10),
+		Name:    user.Name,
+	}
+	if identity.Name == "" {
+		identity.Name = user.Login
+	}
+	for _, email := range emails {
+		if email.Primary {
+			identity.Email = email.Email
+			identity.EmailVerified = email.Verified
+			break
+		}
+	}
+	return identity, nil
+}
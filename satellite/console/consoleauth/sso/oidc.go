@@ -0,0 +1,282 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package sso
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2/jws"
+)
+
+// maxResponseBytes limits the size of the responses read from the providers.
+const maxResponseBytes = 1 << 20
+
+// minKeysRefetchInterval is the minimum time between fetching the signing keys
+// of a provider, so that tokens with unknown key IDs can't be used to flood it.
+const minKeysRefetchInterval = time.Minute
+
+// oidcProvider is a discovered OpenID Connect provider.
+type oidcProvider struct {
+	issuer                string
+	authorizationEndpoint string
+	tokenEndpoint         string
+	jwksURI               string
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	keysFetched time.Time
+}
+
+// discoverOIDC reads the configuration of the provider from its discovery document.
+func discoverOIDC(ctx context.Context, client *http.Client, issuer string) (_ *oidcProvider, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var document struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		JWKSURI               string `json:"jwks_uri"`
+	}
+	if err := getJSON(ctx, client, issuer+"/.well-known/openid-configuration", "", &document); err != nil {
+		return nil, Error.New("unable to discover provider %q: %w", issuer, err)
+	}
+
+	switch {
+	case strings.TrimSuffix(document.Issuer, "/") != issuer:
+		return nil, Error.New("provider %q returned a different issuer %q", issuer, document.Issuer)
+	case document.AuthorizationEndpoint == "" || document.TokenEndpoint == "" || document.JWKSURI == "":
+		return nil, Error.New("discovery document of provider %q is incomplete", issuer)
+	}
+
+	return &oidcProvider{
+		issuer:                document.Issuer,
+		authorizationEndpoint: document.AuthorizationEndpoint,
+		tokenEndpoint:         document.TokenEndpoint,
+		jwksURI:               document.JWKSURI,
+	}, nil
+}
+
+// audience is the aud claim, which is either a single string or a list of strings.
+type audience []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (aud *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*aud = audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*aud = list
+	return nil
+}
+
+// flexibleBool is a boolean claim, which some providers encode as a string.
+type flexibleBool bool
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	var value bool
+	if err := json.Unmarshal(data, &value); err == nil {
+		*b = flexibleBool(value)
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	*b = flexibleBool(text == "true")
+	return nil
+}
+
+// verify verifies the signature and the claims of the ID token and returns the identity it describes.
+func (provider *oidcProvider) verify(ctx context.Context, client *http.Client, rawIDToken, clientID, nonce string) (_ Identity, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if rawIDToken == "" {
+		return Identity{}, ErrInvalidIdentity.New("ID token is missing")
+	}
+
+	parts := strings.Split(rawIDToken, ".")
+	if len(parts) != 3 {
+		return Identity{}, ErrInvalidIdentity.New("malformed ID token")
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return Identity{}, ErrInvalidIdentity.New("malformed ID token header: %v", err)
+	}
+	if header.Algorithm != "RS256" {
+		return Identity{}, ErrInvalidIdentity.New("unsupported ID token algorithm %q", header.Algorithm)
+	}
+
+	key, err := provider.key(ctx, client, header.KeyID)
+	if err != nil {
+		return Identity{}, err
+	}
+	if err := jws.Verify(rawIDToken, key); err != nil {
+		return Identity{}, ErrInvalidIdentity.New("invalid ID token signature")
+	}
+
+	var claims struct {
+		Issuer        string       `json:"iss"`
+		Subject       string       `json:"sub"`
+		Audience      audience     `json:"aud"`
+		Expiry        int64        `json:"exp"`
+		Nonce         string       `json:"nonce"`
+		Email         string       `json:"email"`
+		EmailVerified flexibleBool `json:"email_verified"`
+		Name          string       `json:"name"`
+	}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return Identity{}, ErrInvalidIdentity.New("malformed ID token claims: %v", err)
+	}
+
+	audienceMatches := false
+	for _, aud := range claims.Audience {
+		if aud == clientID {
+			audienceMatches = true
+			break
+		}
+	}
+
+	switch {
+	case claims.Issuer != provider.issuer:
+		return Identity{}, ErrInvalidIdentity.New("unexpected ID token issuer %q", claims.Issuer)
+	case !audienceMatches:
+		return Identity{}, ErrInvalidIdentity.New("ID token isn't issued for the client")
+	case time.Unix(claims.Expiry, 0).Before(time.Now()):
+		return Identity{}, ErrInvalidIdentity.New("ID token expired")
+	case claims.Nonce != nonce:
+		return Identity{}, ErrInvalidIdentity.New("ID token nonce doesn't match")
+	case claims.Subject == "":
+		return Identity{}, ErrInvalidIdentity.New("ID token subject is missing")
+	}
+
+	return Identity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
+}
+
+// key returns the signing key with the ID. The keys are fetched again when
+// the key is unknown, since the providers rotate their keys, but at most once
+// per minKeysRefetchInterval. Unknown keys are rejected in between.
+func (provider *oidcProvider) key(ctx context.Context, client *http.Client, keyID string) (_ *rsa.PublicKey, err error) {
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	if key, ok := provider.keys[keyID]; ok {
+		return key, nil
+	}
+
+	if time.Since(provider.keysFetched) < minKeysRefetchInterval {
+		return nil, ErrInvalidIdentity.New("unknown ID token signing key %q", keyID)
+	}
+	// the failed fetches count too, so that an unavailable provider isn't retried for every token.
+	provider.keysFetched = time.Now()
+
+	keys, err := fetchKeys(ctx, client, provider.jwksURI)
+	if err != nil {
+		return nil, err
+	}
+	provider.keys = keys
+
+	key, ok := keys[keyID]
+	if !ok {
+		return nil, ErrInvalidIdentity.New("unknown ID token signing key %q", keyID)
+	}
+	return key, nil
+}
+
+// fetchKeys fetches the RSA signing keys of the provider by their ID.
+func fetchKeys(ctx context.Context, client *http.Client, jwksURI string) (_ map[string]*rsa.PublicKey, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var set struct {
+		Keys []struct {
+			KeyType string `json:"kty"`
+			KeyID   string `json:"kid"`
+			Use     string `json:"use"`
+			N       string `json:"n"`
+			E       string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := getJSON(ctx, client, jwksURI, "", &set); err != nil {
+		return nil, Error.New("unable to fetch signing keys: %w", err)
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, key := range set.Keys {
+		if key.KeyType != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, Error.New("invalid modulus of key %q: %w", key.KeyID, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, Error.New("invalid exponent of key %q: %w", key.KeyID, err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, Error.New("invalid exponent of key %q", key.KeyID)
+		}
+		keys[key.KeyID] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(exponent.Int64()),
+		}
+	}
+	return keys, nil
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a JWT.
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// getJSON requests the URL and decodes the JSON response into v.
+func getJSON(ctx context.Context, client *http.Client, requestURL, accessToken string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return Error.New("unexpected status %d from %s", resp.StatusCode, requestURL)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(v)
+}
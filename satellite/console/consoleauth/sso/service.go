@@ -0,0 +1,188 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package sso
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the default error class for the package.
+	Error = errs.Class("sso")
+	// ErrUnknownProvider is returned when the provider isn't configured.
+	ErrUnknownProvider = errs.Class("unknown sso provider")
+	// ErrInvalidIdentity is returned when the identity returned by the provider can't be trusted.
+	ErrInvalidIdentity = errs.Class("invalid sso identity")
+)
+
+// Identity is a user authenticated by an SSO provider.
+type Identity struct {
+	Provider string
+	// Subject identifies the user within the provider, it doesn't change
+	// when the user changes their email.
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Service authenticates users with the configured SSO providers using the
+// OAuth 2.0 authorization code flow.
+//
+// architecture: Service
+type Service struct {
+	log       *zap.Logger
+	config    Config
+	client    *http.Client
+	callbacks string
+
+	mu        sync.Mutex
+	providers map[string]*oidcProvider
+}
+
+// NewService creates a new SSO service. The callback of a provider is
+// served under externalAddress at api/v0/auth/sso/{provider}/callback.
+func NewService(log *zap.Logger, config Config, externalAddress string) *Service {
+	return &Service{
+		log:       log,
+		config:    config,
+		client:    &http.Client{Timeout: 30 * time.Second},
+		callbacks: externalAddress + "api/v0/auth/sso/",
+		providers: map[string]*oidcProvider{},
+	}
+}
+
+// Config returns the configuration of the service.
+func (service *Service) Config() Config {
+	return service.config
+}
+
+// Providers returns the sorted names of the enabled providers.
+func (service *Service) Providers() []string {
+	if !service.config.Enabled {
+		return nil
+	}
+	return service.config.Providers.Names()
+}
+
+// AuthCodeURL returns the URL the user is redirected to for signing in with
+// the provider. The state and the nonce must be checked on the callback.
+func (service *Service) AuthCodeURL(ctx context.Context, provider, state, nonce string) (_ string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	oauthConfig, _, err := service.oauthConfig(ctx, provider)
+	if err != nil {
+		return "", err
+	}
+
+	return oauthConfig.AuthCodeURL(state, oauth2.SetAuthURLParam("nonce", nonce)), nil
+}
+
+// Exchange exchanges the authorization code returned to the callback for the
+// identity of the user.
+func (service *Service) Exchange(ctx context.Context, provider, code, nonce string) (_ Identity, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	oauthConfig, oidc, err := service.oauthConfig(ctx, provider)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, service.client)
+	token, err := oauthConfig.Exchange(ctx, code)
+	if err != nil {
+		return Identity{}, Error.New("unable to exchange the authorization code: %w", err)
+	}
+
+	var identity Identity
+	if oidc == nil {
+		identity, err = service.githubIdentity(ctx, token)
+	} else {
+		rawIDToken, _ := token.Extra("id_token").(string)
+		identity, err = oidc.verify(ctx, service.client, rawIDToken, oauthConfig.ClientID, nonce)
+	}
+	if err != nil {
+		return Identity{}, err
+	}
+
+	identity.Provider = provider
+	return identity, nil
+}
+
+// oauthConfig returns the OAuth client of the provider, together with the
+// discovered OpenID Connect provider unless it's GitHub.
+func (service *Service) oauthConfig(ctx context.Context, provider string) (_ *oauth2.Config, _ *oidcProvider, err error) {
+	config, ok := service.config.Providers.Providers[provider]
+	if !service.config.Enabled || !ok {
+		return nil, nil, ErrUnknownProvider.New("%q", provider)
+	}
+
+	oauthConfig := &oauth2.Config{
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		RedirectURL:  service.callbacks + url.PathEscape(provider) + "/callback",
+	}
+
+	if provider == ProviderGitHub {
+		oauthConfig.Endpoint = oauth2.Endpoint{
+			AuthURL:  "https://github.com/login/oauth/authorize",
+			TokenURL: "https://github.com/login/oauth/access_token",
+		}
+		oauthConfig.Scopes = []string{"read:user", "user:email"}
+		return oauthConfig, nil, nil
+	}
+
+	oidc, err := service.discover(ctx, config.IssuerURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	oauthConfig.Endpoint = oauth2.Endpoint{
+		AuthURL:  oidc.authorizationEndpoint,
+		TokenURL: oidc.tokenEndpoint,
+	}
+	oauthConfig.Scopes = []string{"openid", "email", "profile"}
+	return oauthConfig, oidc, nil
+}
+
+// discover returns the OpenID Connect provider of the issuer. The discovered
+// providers are kept for the lifetime of the service.
+func (service *Service) discover(ctx context.Context, issuer string) (_ *oidcProvider, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	service.mu.Lock()
+	provider, ok := service.providers[issuer]
+	service.mu.Unlock()
+	if ok {
+		return provider, nil
+	}
+
+	provider, err = discoverOIDC(ctx, service.client, issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	if existing, ok := service.providers[issuer]; ok {
+		return existing, nil
+	}
+	service.providers[issuer] = provider
+	return provider, nil
+}
+
+// TestSetHTTPClient sets the HTTP client used for the requests to the providers.
+func (service *Service) TestSetHTTPClient(client *http.Client) {
+	service.client = client
+}
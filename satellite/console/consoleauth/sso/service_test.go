@@ -0,0 +1,184 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package sso_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/oauth2/jws"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/console/consoleauth/sso"
+)
+
+func TestProvidersSet(t *testing.T) {
+	var providers sso.Providers
+	require.NoError(t, providers.Set("google,id,secret; github,gh-id,gh-secret;okta,okta-id,okta-secret,https://example.okta.com/"))
+	require.Equal(t, []string{"github", "google", "okta"}, providers.Names())
+	require.Equal(t, "https://accounts.google.com", providers.Providers["google"].IssuerURL)
+	require.Equal(t, "https://example.okta.com", providers.Providers["okta"].IssuerURL)
+	require.Equal(t, "github,gh-id,gh-secret;google,id,secret,https://accounts.google.com;okta,okta-id,okta-secret,https://example.okta.com", providers.String())
+
+	for _, invalid := range []string{
+		"google,id",
+		"okta,id,secret",
+		"github,id,secret,https://github.com",
+		"google,,secret",
+		"google,id,secret;google,id2,secret2",
+	} {
+		require.Error(t, providers.Set(invalid), invalid)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	config := sso.Config{
+		Enabled:         true,
+		AllowSignup:     true,
+		SignupDomains:   []string{"example.com"},
+		EnforcedDomains: []string{"Corp.example"},
+	}
+	require.True(t, config.SignupAllowed("user@EXAMPLE.com"))
+	require.False(t, config.SignupAllowed("user@other.com"))
+	require.True(t, config.Enforced("user@corp.example"))
+	require.False(t, config.Enforced("user@example.com"))
+
+	config.Enabled = false
+	require.False(t, config.Enforced("user@corp.example"))
+
+	config.LinkDomains = []string{"okta:Example.com"}
+	require.False(t, config.LinkAllowed("okta", "user@example.com"))
+
+	config.LinkExistingAccounts = true
+	require.True(t, config.LinkAllowed("okta", "user@EXAMPLE.com"))
+	require.False(t, config.LinkAllowed("google", "user@example.com"))
+	require.False(t, config.LinkAllowed("okta", "user@other.com"))
+}
+
+func TestExchangeOIDC(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var issuer string
+	claims := map[string]interface{}{}
+	keyID := "key"
+	keysFetched := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer,
+			"authorization_endpoint": issuer + "/authorize",
+			"token_endpoint":         issuer + "/token",
+			"jwks_uri":               issuer + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		keysFetched++
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "key",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != "code" {
+			http.Error(w, "invalid code", http.StatusBadRequest)
+			return
+		}
+
+		claimSet := &jws.ClaimSet{
+			Iss:           issuer,
+			Aud:           "client-id",
+			Exp:           time.Now().Add(time.Hour).Unix(),
+			Sub:           "subject",
+			PrivateClaims: claims,
+		}
+		idToken, err := jws.Encode(&jws.Header{Algorithm: "RS256", Typ: "JWT", KeyID: keyID}, claimSet, key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	issuer = server.URL
+
+	var providers sso.Providers
+	require.NoError(t, providers.Set("okta,client-id,client-secret,"+issuer))
+
+	service := sso.NewService(zaptest.NewLogger(t), sso.Config{
+		Enabled:   true,
+		Providers: providers,
+	}, "https://satellite.example/")
+	service.TestSetHTTPClient(server.Client())
+
+	require.Equal(t, []string{"okta"}, service.Providers())
+
+	authURL, err := service.AuthCodeURL(ctx, "okta", "state", "nonce")
+	require.NoError(t, err)
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	require.Equal(t, issuer+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	require.Equal(t, "state", parsed.Query().Get("state"))
+	require.Equal(t, "nonce", parsed.Query().Get("nonce"))
+	require.Equal(t, "https://satellite.example/api/v0/auth/sso/okta/callback", parsed.Query().Get("redirect_uri"))
+
+	_, err = service.AuthCodeURL(ctx, "unknown", "state", "nonce")
+	require.True(t, sso.ErrUnknownProvider.Has(err), err)
+
+	claims["nonce"] = "nonce"
+	claims["email"] = "user@example.com"
+	claims["email_verified"] = "true"
+	claims["name"] = "User"
+
+	identity, err := service.Exchange(ctx, "okta", "code", "nonce")
+	require.NoError(t, err)
+	require.Equal(t, sso.Identity{
+		Provider:      "okta",
+		Subject:       "subject",
+		Email:         "user@example.com",
+		EmailVerified: true,
+		Name:          "User",
+	}, identity)
+
+	_, err = service.Exchange(ctx, "okta", "code", "other-nonce")
+	require.True(t, sso.ErrInvalidIdentity.Has(err), err)
+
+	_, err = service.Exchange(ctx, "okta", "invalid-code", "nonce")
+	require.Error(t, err)
+
+	// unknown signing keys don't fetch the keys again right away.
+	keyID = "unknown"
+	for i := 0; i < 3; i++ {
+		_, err = service.Exchange(ctx, "okta", "code", "nonce")
+		require.True(t, sso.ErrInvalidIdentity.Has(err), err)
+	}
+	require.Equal(t, 1, keysFetched)
+}
@@ -71,6 +71,7 @@ type FrontendConfig struct {
 	AltObjBrowserPagingThreshold      int                   `json:"altObjBrowserPagingThreshold"`
 	DomainsPageEnabled                bool                  `json:"domainsPageEnabled"`
 	ActiveSessionsViewEnabled         bool                  `json:"activeSessionsViewEnabled"`
+	ObjectPreviewEnabled              bool                  `json:"objectPreviewEnabled"`
 }
 
 // Satellites is a configuration value that contains a list of satellite names and addresses.
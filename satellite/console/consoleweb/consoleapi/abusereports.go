@@ -0,0 +1,85 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/storj/private/web"
+	"storj.io/storj/satellite/console"
+)
+
+// maxAbuseReportBodySize is the maximum size of the body of an abuse report request.
+const maxAbuseReportBodySize = 16 * memory.KiB
+
+// AbuseReports is an api controller that exposes the public abuse report intake.
+type AbuseReports struct {
+	log     *zap.Logger
+	service *console.Service
+}
+
+// NewAbuseReports is a constructor for AbuseReports controller.
+func NewAbuseReports(log *zap.Logger, service *console.Service) *AbuseReports {
+	return &AbuseReports{
+		log:     log,
+		service: service,
+	}
+}
+
+// Submit stores a new abuse report. It doesn't require authentication.
+func (a *AbuseReports) Submit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	// the intake doesn't require authentication, hence the body is limited
+	// to what a valid report needs, regardless of the API body size limit.
+	var request console.AbuseReportRequest
+	if err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAbuseReportBodySize.Int64())).Decode(&request); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			a.serveJSONError(ctx, w, http.StatusRequestEntityTooLarge, errs.New("Request body is too large"))
+			return
+		}
+		a.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	report, err := a.service.SubmitAbuseReport(ctx, request)
+	if err != nil {
+		switch {
+		case console.ErrValidation.Has(err):
+			a.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		case console.ErrForbidden.Has(err):
+			a.serveJSONError(ctx, w, http.StatusForbidden, err)
+		default:
+			a.serveJSONError(ctx, w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(struct {
+		ID string `json:"id"`
+	}{
+		ID: report.ID.String(),
+	})
+	if err != nil {
+		a.log.Error("failed to write json abuse report response", zap.Error(ErrUtils.Wrap(err)))
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (a *AbuseReports) serveJSONError(ctx context.Context, w http.ResponseWriter, status int, err error) {
+	web.ServeJSONError(ctx, a.log, w, status, err)
+}
@@ -223,6 +223,90 @@ func (keys *APIKeys) GetAllAPIKeyNames(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetAPIKeyInventory returns all API keys of the project with the operations they authorize.
+func (keys *APIKeys) GetAPIKeyInventory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	projectIDString := r.URL.Query().Get("projectID")
+	if projectIDString == "" {
+		keys.serveJSONError(ctx, w, http.StatusBadRequest, errs.New("Project ID was not provided."))
+		return
+	}
+
+	projectID, err := uuid.FromString(projectIDString)
+	if err != nil {
+		keys.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	inventory, err := keys.service.GetAPIKeyInventory(ctx, projectID)
+	if err != nil {
+		if console.ErrUnauthorized.Has(err) {
+			keys.serveJSONError(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+
+		keys.serveJSONError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(inventory)
+	if err != nil {
+		keys.log.Error("failed to write json api key inventory response", zap.Error(ErrAPIKeysAPI.Wrap(err)))
+	}
+}
+
+// InspectAPIKey decodes the restrictions of an access grant or an API key of the project.
+func (keys *APIKeys) InspectAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	projectIDString := r.URL.Query().Get("projectID")
+	if projectIDString == "" {
+		keys.serveJSONError(ctx, w, http.StatusBadRequest, errs.New("Project ID was not provided."))
+		return
+	}
+
+	projectID, err := uuid.FromString(projectIDString)
+	if err != nil {
+		keys.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	var data struct {
+		Access string `json:"access"`
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&data)
+	if err != nil {
+		keys.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	inspection, err := keys.service.InspectAPIKey(ctx, projectID, data.Access)
+	if err != nil {
+		switch {
+		case console.ErrUnauthorized.Has(err):
+			keys.serveJSONError(ctx, w, http.StatusUnauthorized, err)
+		case console.ErrAPIKeyRequest.Has(err):
+			keys.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		case console.ErrNoAPIKey.Has(err):
+			keys.serveJSONError(ctx, w, http.StatusNotFound, err)
+		default:
+			keys.serveJSONError(ctx, w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(inspection)
+	if err != nil {
+		keys.log.Error("failed to write json api key inspection response", zap.Error(ErrAPIKeysAPI.Wrap(err)))
+	}
+}
+
 // DeleteByIDs deletes API keys by given IDs.
 func (keys *APIKeys) DeleteByIDs(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -170,6 +170,77 @@ func (a *Auth) TokenByAPIKey(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// CreateRESTKey creates a personal access token for the account management API.
+func (a *Auth) CreateRESTKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	var request struct {
+		Expiration string                 `json:"expiration"`
+		Scopes     []console.RESTKeyScope `json:"scopes"`
+	}
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		a.serveJSONError(ctx, w, console.ErrValidation.Wrap(err))
+		return
+	}
+
+	var expiration time.Duration
+	if request.Expiration != "" {
+		expiration, err = time.ParseDuration(request.Expiration)
+		if err != nil {
+			a.serveJSONError(ctx, w, console.ErrValidation.Wrap(err))
+			return
+		}
+		if expiration < 0 {
+			a.serveJSONError(ctx, w, console.ErrValidation.New("expiration can't be negative"))
+			return
+		}
+	}
+
+	apiKey, expiresAt, err := a.service.CreateRESTKey(ctx, expiration, request.Scopes...)
+	if err != nil {
+		a.serveJSONError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(struct {
+		APIKey    string    `json:"apikey"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	}{apiKey, expiresAt})
+	if err != nil {
+		a.log.Error("could not encode rest key", zap.Error(ErrAuthAPI.Wrap(err)))
+	}
+}
+
+// RevokeRESTKey revokes a personal access token of the user.
+// The key is sent in the request body so that it doesn't end up in access logs.
+func (a *Auth) RevokeRESTKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	var request struct {
+		APIKey string `json:"apikey"`
+	}
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		a.serveJSONError(ctx, w, console.ErrValidation.Wrap(err))
+		return
+	}
+	if request.APIKey == "" {
+		a.serveJSONError(ctx, w, console.ErrValidation.New("apikey can't be empty"))
+		return
+	}
+
+	err = a.service.RevokeRESTKey(ctx, request.APIKey)
+	if err != nil {
+		a.serveJSONError(ctx, w, err)
+	}
+}
+
 // getSessionID gets the session ID from the request.
 func (a *Auth) getSessionID(r *http.Request) (id uuid.UUID, err error) {
 	tokenInfo, err := a.cookieAuth.GetToken(r)
@@ -1555,7 +1626,7 @@ func (a *Auth) getStatusCode(err error) int {
 		return http.StatusUnauthorized
 	case console.ErrEmailUsed.Has(err), console.ErrMFAConflict.Has(err), console.ErrMFAEnabled.Has(err):
 		return http.StatusConflict
-	case console.ErrLoginRestricted.Has(err), console.ErrTooManyAttempts.Has(err), console.ErrForbidden.Has(err):
+	case console.ErrLoginRestricted.Has(err), console.ErrTooManyAttempts.Has(err), console.ErrForbidden.Has(err), console.ErrSSORequired.Has(err):
 		return http.StatusForbidden
 	case errors.Is(err, errNotImplemented):
 		return http.StatusNotImplemented
@@ -1598,6 +1669,8 @@ func (a *Auth) getUserErrorMessage(err error) string {
 		return "Your login credentials are incorrect, please try again"
 	case console.ErrLoginRestricted.Has(err):
 		return "You can't be authenticated. Please contact support"
+	case console.ErrSSORequired.Has(err):
+		return "Your organization requires signing in with SSO"
 	case console.ErrValidation.Has(err), console.ErrChangePassword.Has(err), console.ErrInvalidProjectLimit.Has(err), console.ErrNotPaidTier.Has(err), console.ErrTooManyAttempts.Has(err), console.ErrMFAEnabled.Has(err), console.ErrForbidden.Has(err):
 		return err.Error()
 	case errors.Is(err, errNotImplemented):
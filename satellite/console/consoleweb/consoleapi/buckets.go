@@ -16,7 +16,9 @@ import (
 	"storj.io/common/uuid"
 	"storj.io/storj/private/web"
 	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/buckets"
 	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/metabase"
 )
 
 const (
@@ -210,6 +212,187 @@ func (b *Buckets) GetBucketTotals(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetBucketStats returns the object statistics of a bucket.
+func (b *Buckets) GetBucketStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, bucketName, ok := b.parseBucketParams(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	stats, err := b.service.GetBucketStats(ctx, projectID, bucketName)
+	if err != nil {
+		switch {
+		case console.ErrUnauthorized.Has(err):
+			b.serveJSONError(ctx, w, http.StatusUnauthorized, err)
+		case metabase.ErrBucketStatsNotFound.Has(err):
+			b.serveJSONError(ctx, w, http.StatusNotFound, err)
+		default:
+			b.serveJSONError(ctx, w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(BucketStats{
+		ObjectCount:        stats.ObjectCount,
+		PendingObjectCount: stats.PendingObjectCount,
+		SegmentCount:       stats.TotalSegments,
+		StorageBytes:       stats.TotalBytes,
+		UpdatedAt:          stats.UpdatedAt,
+	})
+	if err != nil {
+		b.log.Error("failed to write json bucket stats response", zap.Error(ErrBucketsAPI.Wrap(err)))
+	}
+}
+
+// BucketStats is the JSON response of the bucket stats request.
+type BucketStats struct {
+	ObjectCount        int64     `json:"objectCount"`
+	PendingObjectCount int64     `json:"pendingObjectCount"`
+	SegmentCount       int64     `json:"segmentCount"`
+	StorageBytes       int64     `json:"storageBytes"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+}
+
+// GetBucketLifecycle returns the lifecycle configuration of a bucket.
+func (b *Buckets) GetBucketLifecycle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, bucketName, ok := b.parseBucketParams(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	config, err := b.service.GetBucketLifecycle(ctx, projectID, bucketName)
+	if err != nil {
+		b.serveBucketConfigError(ctx, w, err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(config)
+	if err != nil {
+		b.log.Error("failed to write json bucket lifecycle response", zap.Error(ErrBucketsAPI.Wrap(err)))
+	}
+}
+
+// UpdateBucketLifecycle sets the lifecycle configuration of a bucket.
+func (b *Buckets) UpdateBucketLifecycle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	projectID, bucketName, ok := b.parseBucketParams(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	var config buckets.LifecycleConfiguration
+	if err = json.NewDecoder(r.Body).Decode(&config); err != nil {
+		b.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = b.service.UpdateBucketLifecycle(ctx, projectID, bucketName, config)
+	if err != nil {
+		b.serveBucketConfigError(ctx, w, err)
+		return
+	}
+}
+
+// GetBucketCORS returns the CORS configuration of a bucket.
+func (b *Buckets) GetBucketCORS(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, bucketName, ok := b.parseBucketParams(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	config, err := b.service.GetBucketCORS(ctx, projectID, bucketName)
+	if err != nil {
+		b.serveBucketConfigError(ctx, w, err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(config)
+	if err != nil {
+		b.log.Error("failed to write json bucket cors response", zap.Error(ErrBucketsAPI.Wrap(err)))
+	}
+}
+
+// UpdateBucketCORS sets the CORS configuration of a bucket.
+func (b *Buckets) UpdateBucketCORS(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	projectID, bucketName, ok := b.parseBucketParams(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	var config buckets.CORSConfiguration
+	if err = json.NewDecoder(r.Body).Decode(&config); err != nil {
+		b.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = b.service.UpdateBucketCORS(ctx, projectID, bucketName, config)
+	if err != nil {
+		b.serveBucketConfigError(ctx, w, err)
+		return
+	}
+}
+
+// parseBucketParams parses the projectID and bucketName query parameters.
+func (b *Buckets) parseBucketParams(ctx context.Context, w http.ResponseWriter, r *http.Request) (projectID uuid.UUID, bucketName string, ok bool) {
+	projectIDString := r.URL.Query().Get("projectID")
+	if projectIDString == "" {
+		b.serveJSONError(ctx, w, http.StatusBadRequest, errs.New(missingParamErrMsg, "projectID"))
+		return uuid.UUID{}, "", false
+	}
+	projectID, err := uuid.FromString(projectIDString)
+	if err != nil {
+		b.serveJSONError(ctx, w, http.StatusBadRequest, errs.New(invalidParamErrMsg, projectIDString, "projectID", err))
+		return uuid.UUID{}, "", false
+	}
+
+	bucketName = r.URL.Query().Get("bucketName")
+	if bucketName == "" {
+		b.serveJSONError(ctx, w, http.StatusBadRequest, errs.New(missingParamErrMsg, "bucketName"))
+		return uuid.UUID{}, "", false
+	}
+
+	return projectID, bucketName, true
+}
+
+// serveBucketConfigError writes the JSON error of a bucket configuration request.
+func (b *Buckets) serveBucketConfigError(ctx context.Context, w http.ResponseWriter, err error) {
+	switch {
+	case console.ErrUnauthorized.Has(err):
+		b.serveJSONError(ctx, w, http.StatusUnauthorized, err)
+	case console.ErrValidation.Has(err):
+		b.serveJSONError(ctx, w, http.StatusBadRequest, err)
+	case buckets.ErrBucketNotFound.Has(err):
+		b.serveJSONError(ctx, w, http.StatusNotFound, err)
+	default:
+		b.serveJSONError(ctx, w, http.StatusInternalServerError, err)
+	}
+}
+
 // serveJSONError writes JSON error to response output stream.
 func (b *Buckets) serveJSONError(ctx context.Context, w http.ResponseWriter, status int, err error) {
 	web.ServeJSONError(ctx, b.log, w, status, err)
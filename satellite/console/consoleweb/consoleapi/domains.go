@@ -12,9 +12,11 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/gorilla/mux"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
+	"storj.io/common/uuid"
 	"storj.io/storj/private/web"
 	"storj.io/storj/satellite/console"
 )
@@ -95,6 +97,153 @@ func (d *Domains) CheckDNSRecords(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// domainResponse is a custom domain with the DNS records which have to be configured for it.
+type domainResponse struct {
+	console.Domain
+	Records console.DomainDNSRecords `json:"records"`
+}
+
+// CreateDomain registers a custom domain for the project.
+func (d *Domains) CreateDomain(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, ok := d.projectID(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	var request console.DomainRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		d.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	domain, err := d.service.CreateDomain(ctx, projectID, request)
+	if err != nil {
+		d.serveDomainError(ctx, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(domainResponse{Domain: *domain, Records: d.service.DomainDNSRecords(*domain)})
+	if err != nil {
+		d.log.Error("failed to write json create domain response", zap.Error(ErrUtils.Wrap(err)))
+	}
+}
+
+// GetProjectDomains returns the custom domains of the project.
+func (d *Domains) GetProjectDomains(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, ok := d.projectID(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	domains, err := d.service.GetProjectDomains(ctx, projectID)
+	if err != nil {
+		d.serveDomainError(ctx, w, err)
+		return
+	}
+
+	response := make([]domainResponse, 0, len(domains))
+	for _, domain := range domains {
+		response = append(response, domainResponse{Domain: domain, Records: d.service.DomainDNSRecords(domain)})
+	}
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		d.log.Error("failed to write json project domains response", zap.Error(ErrUtils.Wrap(err)))
+	}
+}
+
+// VerifyDomain verifies the DNS records of the custom domain.
+func (d *Domains) VerifyDomain(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	projectID, ok := d.projectID(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	domain, err := d.service.VerifyDomain(ctx, projectID, mux.Vars(r)["subdomain"])
+	if err != nil {
+		d.serveDomainError(ctx, w, err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(domainResponse{Domain: *domain, Records: d.service.DomainDNSRecords(*domain)})
+	if err != nil {
+		d.log.Error("failed to write json verify domain response", zap.Error(ErrUtils.Wrap(err)))
+	}
+}
+
+// DeleteDomain removes the custom domain of the project.
+func (d *Domains) DeleteDomain(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	projectID, ok := d.projectID(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	err = d.service.DeleteDomain(ctx, projectID, mux.Vars(r)["subdomain"])
+	if err != nil {
+		d.serveDomainError(ctx, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// projectID parses the project ID route param. It writes the error response when the param is invalid.
+func (d *Domains) projectID(ctx context.Context, w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	idParam, ok := mux.Vars(r)["projectID"]
+	if !ok {
+		d.serveJSONError(ctx, w, http.StatusBadRequest, errs.New("missing projectID route param"))
+		return uuid.UUID{}, false
+	}
+
+	projectID, err := uuid.FromString(idParam)
+	if err != nil {
+		d.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return uuid.UUID{}, false
+	}
+	return projectID, true
+}
+
+// serveDomainError writes the JSON error matching the class of the service error.
+func (d *Domains) serveDomainError(ctx context.Context, w http.ResponseWriter, err error) {
+	switch {
+	case console.ErrUnauthorized.Has(err):
+		d.serveJSONError(ctx, w, http.StatusUnauthorized, err)
+	case console.ErrValidation.Has(err):
+		d.serveJSONError(ctx, w, http.StatusBadRequest, err)
+	case console.ErrForbidden.Has(err):
+		d.serveJSONError(ctx, w, http.StatusForbidden, err)
+	case console.ErrNoDomain.Has(err):
+		d.serveJSONError(ctx, w, http.StatusNotFound, err)
+	case console.ErrConflict.Has(err):
+		d.serveJSONError(ctx, w, http.StatusConflict, err)
+	default:
+		d.serveJSONError(ctx, w, http.StatusInternalServerError, err)
+	}
+}
+
 // serveJSONError writes JSON error to response output stream.
 func (d *Domains) serveJSONError(ctx context.Context, w http.ResponseWriter, status int, err error) {
 	web.ServeJSONError(ctx, d.log, w, status, err)
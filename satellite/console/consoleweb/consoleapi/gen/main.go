@@ -28,7 +28,7 @@ func main() {
 	a := &apigen.API{
 		Version:     "v1",
 		BasePath:    "/public",
-		Description: "Interacts with projects",
+		Description: "Manages projects, API keys and the account. Requests are authenticated with a personal access token created with POST /api/v0/auth/rest-keys, sent as 'Authorization: Bearer <token>'. A token restricted to scopes (projects, apikeys, users) can only access the endpoint groups of the same name",
 		PackagePath: "storj.io/storj/satellite/console/consoleweb/consoleapi",
 	}
 
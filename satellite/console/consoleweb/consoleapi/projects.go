@@ -17,6 +17,7 @@ import (
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
+	"storj.io/common/storj"
 	"storj.io/common/uuid"
 	"storj.io/storj/private/web"
 	"storj.io/storj/satellite/console"
@@ -27,6 +28,7 @@ import (
 type Projects struct {
 	log     *zap.Logger
 	service *console.Service
+	nodeURL storj.NodeURL
 }
 
 // ProjectMembersPage contains information about a page of project members and invitations.
@@ -61,10 +63,11 @@ type Invitation struct {
 }
 
 // NewProjects is a constructor for api analytics controller.
-func NewProjects(log *zap.Logger, service *console.Service) *Projects {
+func NewProjects(log *zap.Logger, service *console.Service, nodeURL storj.NodeURL) *Projects {
 	return &Projects{
 		log:     log,
 		service: service,
+		nodeURL: nodeURL,
 	}
 }
 
@@ -250,6 +253,11 @@ func (p *Projects) UpdateUserSpecifiedLimits(w http.ResponseWriter, r *http.Requ
 			return
 		}
 
+		if console.ErrConflict.Has(err) {
+			p.serveJSONError(ctx, w, http.StatusConflict, err)
+			return
+		}
+
 		if console.ErrInvalidProjectLimit.Has(err) || console.ErrValidation.Has(err) {
 			p.serveJSONError(ctx, w, http.StatusBadRequest, err)
 			return
@@ -776,6 +784,297 @@ func (p *Projects) GetConfig(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetBudgetCap returns the monthly budget cap of a project.
+func (p *Projects) GetBudgetCap(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	idParam, ok := mux.Vars(r)["id"]
+	if !ok {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, errs.New("missing id route param"))
+		return
+	}
+
+	id, err := uuid.FromString(idParam)
+	if err != nil {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	budgetCap, err := p.service.GetProjectBudgetCap(ctx, id)
+	if err != nil {
+		if console.ErrUnauthorized.Has(err) || console.ErrNoMembership.Has(err) {
+			p.serveJSONError(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+		p.serveJSONError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(budgetCap)
+	if err != nil {
+		p.serveJSONError(ctx, w, http.StatusInternalServerError, err)
+	}
+}
+
+// SetBudgetCap sets the monthly budget cap of a project.
+func (p *Projects) SetBudgetCap(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	idParam, ok := mux.Vars(r)["id"]
+	if !ok {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, errs.New("missing id route param"))
+		return
+	}
+
+	id, err := uuid.FromString(idParam)
+	if err != nil {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload struct {
+		CapCents int64 `json:"capCents"`
+	}
+	err = json.NewDecoder(r.Body).Decode(&payload)
+	if err != nil {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = p.service.SetProjectBudgetCap(ctx, id, payload.CapCents)
+	if err != nil {
+		switch {
+		case console.ErrUnauthorized.Has(err) || console.ErrNoMembership.Has(err):
+			p.serveJSONError(ctx, w, http.StatusUnauthorized, err)
+		case console.ErrForbidden.Has(err):
+			p.serveJSONError(ctx, w, http.StatusForbidden, err)
+		case console.ErrValidation.Has(err):
+			p.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		default:
+			p.serveJSONError(ctx, w, http.StatusInternalServerError, err)
+		}
+	}
+}
+
+// RemoveBudgetCap removes the monthly budget cap of a project.
+func (p *Projects) RemoveBudgetCap(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	idParam, ok := mux.Vars(r)["id"]
+	if !ok {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, errs.New("missing id route param"))
+		return
+	}
+
+	id, err := uuid.FromString(idParam)
+	if err != nil {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = p.service.RemoveProjectBudgetCap(ctx, id)
+	if err != nil {
+		switch {
+		case console.ErrUnauthorized.Has(err) || console.ErrNoMembership.Has(err):
+			p.serveJSONError(ctx, w, http.StatusUnauthorized, err)
+		case console.ErrForbidden.Has(err):
+			p.serveJSONError(ctx, w, http.StatusForbidden, err)
+		default:
+			p.serveJSONError(ctx, w, http.StatusInternalServerError, err)
+		}
+	}
+}
+
+// GetUsageWebhooks returns the usage webhooks of a project.
+func (p *Projects) GetUsageWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	idParam, ok := mux.Vars(r)["id"]
+	if !ok {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, errs.New("missing id route param"))
+		return
+	}
+
+	id, err := uuid.FromString(idParam)
+	if err != nil {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	webhooks, err := p.service.GetProjectUsageWebhooks(ctx, id)
+	if err != nil {
+		if console.ErrUnauthorized.Has(err) || console.ErrNoMembership.Has(err) {
+			p.serveJSONError(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+		p.serveJSONError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if webhooks == nil {
+		webhooks = []console.ProjectUsageWebhook{}
+	}
+
+	err = json.NewEncoder(w).Encode(webhooks)
+	if err != nil {
+		p.serveJSONError(ctx, w, http.StatusInternalServerError, err)
+	}
+}
+
+// CreateUsageWebhook subscribes a webhook to the daily usage of a project.
+// The response contains the signing secret, which isn't revealed again.
+func (p *Projects) CreateUsageWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	idParam, ok := mux.Vars(r)["id"]
+	if !ok {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, errs.New("missing id route param"))
+		return
+	}
+
+	id, err := uuid.FromString(idParam)
+	if err != nil {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload struct {
+		URL string `json:"url"`
+	}
+	err = json.NewDecoder(r.Body).Decode(&payload)
+	if err != nil {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	webhook, err := p.service.CreateProjectUsageWebhook(ctx, id, payload.URL)
+	if err != nil {
+		switch {
+		case console.ErrUnauthorized.Has(err) || console.ErrNoMembership.Has(err):
+			p.serveJSONError(ctx, w, http.StatusUnauthorized, err)
+		case console.ErrForbidden.Has(err):
+			p.serveJSONError(ctx, w, http.StatusForbidden, err)
+		case console.ErrValidation.Has(err):
+			p.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		case console.ErrConflict.Has(err):
+			p.serveJSONError(ctx, w, http.StatusConflict, err)
+		default:
+			p.serveJSONError(ctx, w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(webhook)
+	if err != nil {
+		p.serveJSONError(ctx, w, http.StatusInternalServerError, err)
+	}
+}
+
+// DeleteUsageWebhook unsubscribes a webhook from the usage of a project.
+func (p *Projects) DeleteUsageWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	idParam, ok := mux.Vars(r)["id"]
+	if !ok {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, errs.New("missing id route param"))
+		return
+	}
+
+	id, err := uuid.FromString(idParam)
+	if err != nil {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	webhookID, err := uuid.FromString(mux.Vars(r)["webhookID"])
+	if err != nil {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = p.service.DeleteProjectUsageWebhook(ctx, id, webhookID)
+	if err != nil {
+		switch {
+		case console.ErrUnauthorized.Has(err) || console.ErrNoMembership.Has(err):
+			p.serveJSONError(ctx, w, http.StatusUnauthorized, err)
+		case console.ErrNoUsageWebhook.Has(err):
+			p.serveJSONError(ctx, w, http.StatusNotFound, err)
+		default:
+			p.serveJSONError(ctx, w, http.StatusInternalServerError, err)
+		}
+	}
+}
+
+// CreateObjectPreviewURL creates a short-lived URL for previewing an object.
+func (p *Projects) CreateObjectPreviewURL(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	idParam, ok := mux.Vars(r)["id"]
+	if !ok {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, errs.New("missing id route param"))
+		return
+	}
+
+	id, err := uuid.FromString(idParam)
+	if err != nil {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	var payload struct {
+		Bucket string `json:"bucket"`
+		Key    string `json:"key"`
+	}
+	if err = json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		p.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	previewURL, err := p.service.CreateObjectPreviewURL(ctx, id, p.nodeURL.String(), payload.Bucket, payload.Key)
+	if err != nil {
+		switch {
+		case console.ErrUnauthorized.Has(err) || console.ErrNoMembership.Has(err):
+			p.serveJSONError(ctx, w, http.StatusUnauthorized, err)
+		case console.ErrForbidden.Has(err):
+			p.serveJSONError(ctx, w, http.StatusForbidden, err)
+		case console.ErrValidation.Has(err):
+			p.serveJSONError(ctx, w, http.StatusBadRequest, err)
+		default:
+			p.serveJSONError(ctx, w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(struct {
+		URL string `json:"url"`
+	}{URL: previewURL})
+	if err != nil {
+		p.serveJSONError(ctx, w, http.StatusInternalServerError, err)
+	}
+}
+
 // InviteUser sends a project invitation to a user.
 func (p *Projects) InviteUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -0,0 +1,314 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/private/web"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/consoleauth/sso"
+	"storj.io/storj/satellite/console/consoleweb/consolewebauth"
+)
+
+const (
+	// ssoStateCookie keeps the state and the nonce of a sign in with an SSO provider.
+	ssoStateCookie = "sso_state"
+	// ssoStateDuration is how long the user has to sign in with the provider.
+	ssoStateDuration = 10 * time.Minute
+	// ssoMFACookie keeps the MFA token of a sign in with an SSO provider, which
+	// is completed after the user enters their MFA passcode.
+	ssoMFACookie = "sso_mfa"
+	// ssoMFADuration is how long the user has to enter their MFA passcode.
+	ssoMFADuration = 5 * time.Minute
+)
+
+// ErrSSOAPI - console sso api error type.
+var ErrSSOAPI = errs.Class("console sso")
+
+// SSO is an api controller that signs users in with SSO providers.
+type SSO struct {
+	log             *zap.Logger
+	service         *console.Service
+	ssoService      *sso.Service
+	cookieAuth      *consolewebauth.CookieAuth
+	externalAddress string
+}
+
+// NewSSO is a constructor for SSO controller.
+func NewSSO(log *zap.Logger, service *console.Service, ssoService *sso.Service, cookieAuth *consolewebauth.CookieAuth, externalAddress string) *SSO {
+	return &SSO{
+		log:             log,
+		service:         service,
+		ssoService:      ssoService,
+		cookieAuth:      cookieAuth,
+		externalAddress: externalAddress,
+	}
+}
+
+// GetProviders returns the names of the SSO providers users can sign in with.
+func (s *SSO) GetProviders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	providers := s.ssoService.Providers()
+	if providers == nil {
+		providers = []string{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(providers)
+	if err != nil {
+		s.log.Error("failed to write json response", zap.Error(ErrSSOAPI.Wrap(err)))
+	}
+}
+
+// BeginLogin redirects the user to the provider for signing in.
+func (s *SSO) BeginLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	provider := mux.Vars(r)["provider"]
+
+	state, err := randomToken()
+	if err != nil {
+		web.ServeJSONError(ctx, s.log, w, http.StatusInternalServerError, ErrSSOAPI.Wrap(err))
+		return
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		web.ServeJSONError(ctx, s.log, w, http.StatusInternalServerError, ErrSSOAPI.Wrap(err))
+		return
+	}
+
+	authURL, err := s.ssoService.AuthCodeURL(ctx, provider, state, nonce)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if sso.ErrUnknownProvider.Has(err) {
+			status = http.StatusNotFound
+		}
+		web.ServeJSONError(ctx, s.log, w, status, ErrSSOAPI.Wrap(err))
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoStateCookie,
+		Value:    state + "." + nonce + "." + provider,
+		Path:     "/api/v0/auth/sso/",
+		Expires:  time.Now().Add(ssoStateDuration),
+		HttpOnly: true,
+		Secure:   r.TLS != nil || strings.HasPrefix(s.externalAddress, "https://"),
+		// the cookie must be sent when the provider redirects back to the callback.
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// Callback signs in the user returning from the provider and redirects them to the console.
+func (s *SSO) Callback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	provider := mux.Vars(r)["provider"]
+
+	nonce, err := s.checkState(w, r, provider)
+	if err != nil {
+		s.redirectWithError(w, r, err)
+		return
+	}
+
+	if providerErr := r.URL.Query().Get("error"); providerErr != "" {
+		err = ErrSSOAPI.New("provider returned an error: %s", providerErr)
+		s.redirectWithError(w, r, err)
+		return
+	}
+
+	identity, err := s.ssoService.Exchange(ctx, provider, r.URL.Query().Get("code"), nonce)
+	if err != nil {
+		s.redirectWithError(w, r, err)
+		return
+	}
+
+	ip, err := web.GetRequestIP(r)
+	if err != nil {
+		s.redirectWithError(w, r, err)
+		return
+	}
+
+	tokenInfo, mfaToken, err := s.service.LoginWithSSO(ctx, identity, ip, r.UserAgent())
+	if err != nil {
+		s.redirectWithError(w, r, err)
+		return
+	}
+
+	if mfaToken != "" {
+		http.SetCookie(w, &http.Cookie{
+			Name:     ssoMFACookie,
+			Value:    mfaToken,
+			Path:     "/api/v0/auth/sso/",
+			Expires:  time.Now().Add(ssoMFADuration),
+			HttpOnly: true,
+			Secure:   r.TLS != nil || strings.HasPrefix(s.externalAddress, "https://"),
+			SameSite: http.SameSiteStrictMode,
+		})
+		// the login page asks for the MFA passcode and completes the sign in with CompleteMFA.
+		http.Redirect(w, r, s.externalAddress+"login?sso_mfa=required", http.StatusFound)
+		return
+	}
+
+	s.cookieAuth.SetTokenCookie(w, *tokenInfo)
+
+	http.Redirect(w, r, s.externalAddress, http.StatusFound)
+}
+
+// CompleteMFA completes the sign in with SSO of a user with MFA enabled after
+// verifying their MFA passcode or recovery code.
+func (s *SSO) CompleteMFA(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	cookie, err := r.Cookie(ssoMFACookie)
+	if err != nil {
+		web.ServeJSONError(ctx, s.log, w, http.StatusUnauthorized, ErrSSOAPI.New("sign in with SSO expired"))
+		return
+	}
+
+	var request struct {
+		MFAPasscode     string `json:"mfaPasscode"`
+		MFARecoveryCode string `json:"mfaRecoveryCode"`
+	}
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		web.ServeJSONError(ctx, s.log, w, http.StatusBadRequest, ErrSSOAPI.Wrap(err))
+		return
+	}
+
+	ip, err := web.GetRequestIP(r)
+	if err != nil {
+		web.ServeJSONError(ctx, s.log, w, http.StatusInternalServerError, ErrSSOAPI.Wrap(err))
+		return
+	}
+
+	tokenInfo, err := s.service.LoginWithSSOMFA(ctx, cookie.Value, request.MFAPasscode, request.MFARecoveryCode, ip, r.UserAgent())
+	if err != nil {
+		s.serveMFAError(w, r, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoMFACookie,
+		Value:    "",
+		Path:     "/api/v0/auth/sso/",
+		Expires:  time.Unix(0, 0),
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	s.cookieAuth.SetTokenCookie(w, *tokenInfo)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(struct {
+		console.TokenInfo
+		Token string `json:"token"`
+	}{*tokenInfo, tokenInfo.Token.String()})
+	if err != nil {
+		s.log.Error("failed to write json response", zap.Error(ErrSSOAPI.Wrap(err)))
+	}
+}
+
+// serveMFAError serves an error of completing a sign in with SSO with a user-friendly message.
+func (s *SSO) serveMFAError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+	message := "Signing in with SSO was unsuccessful, please try again"
+	switch {
+	case console.ErrMFAMissing.Has(err):
+		status, message = http.StatusBadRequest, "A MFA passcode or recovery code is required"
+	case console.ErrMFAConflict.Has(err):
+		status, message = http.StatusConflict, "Expected either passcode or recovery code, but got both"
+	case console.ErrMFAPasscode.Has(err):
+		status, message = http.StatusBadRequest, "The MFA passcode is not valid or has expired"
+	case console.ErrMFARecoveryCode.Has(err):
+		status, message = http.StatusBadRequest, "The MFA recovery code is not valid or has been previously used"
+	case console.ErrTokenInvalid.Has(err), console.ErrTokenExpiration.Has(err):
+		status, message = http.StatusUnauthorized, "Sign in with SSO expired, please try again"
+	case console.ErrLoginCredentials.Has(err):
+		status, message = http.StatusUnauthorized, "Your login credentials are incorrect, please try again"
+	case console.ErrLoginRestricted.Has(err):
+		status, message = http.StatusForbidden, "You can't be authenticated. Please contact support"
+	}
+
+	web.ServeCustomJSONError(r.Context(), s.log, w, status, ErrSSOAPI.Wrap(err), message)
+}
+
+// checkState checks the state returned by the provider against the state
+// cookie, removes the cookie and returns the nonce of the sign in.
+func (s *SSO) checkState(w http.ResponseWriter, r *http.Request, provider string) (nonce string, err error) {
+	cookie, err := r.Cookie(ssoStateCookie)
+	if err != nil {
+		return "", ErrSSOAPI.New("sign in with SSO expired")
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoStateCookie,
+		Value:    "",
+		Path:     "/api/v0/auth/sso/",
+		Expires:  time.Unix(0, 0),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	// the state and the nonce are base64url encoded, the provider may contain dots.
+	parts := strings.SplitN(cookie.Value, ".", 3)
+	if len(parts) != 3 || parts[2] != provider {
+		return "", ErrSSOAPI.New("invalid SSO state")
+	}
+	if subtle.ConstantTimeCompare([]byte(parts[0]), []byte(r.URL.Query().Get("state"))) != 1 {
+		return "", ErrSSOAPI.New("invalid SSO state")
+	}
+	return parts[1], nil
+}
+
+// redirectWithError redirects the user to the login page with a user-friendly error.
+func (s *SSO) redirectWithError(w http.ResponseWriter, r *http.Request, err error) {
+	s.log.Info("Error signing in with SSO", zap.Error(ErrSSOAPI.Wrap(err)))
+
+	var message string
+	switch {
+	case console.ErrSSOLogin.Has(err):
+		message = errs.Unwrap(err).Error()
+	case console.ErrEmailUsed.Has(err):
+		message = "An account with this email already exists, sign in with your password"
+	case console.ErrLoginRestricted.Has(err):
+		message = "You can't be authenticated. Please contact support"
+	case sso.ErrUnknownProvider.Has(err):
+		message = "The SSO provider is not available"
+	default:
+		message = "Signing in with SSO was unsuccessful, please try again"
+	}
+
+	http.Redirect(w, r, s.externalAddress+"login?sso_error="+url.QueryEscape(message), http.StatusFound)
+}
+
+// randomToken returns a random URL-safe token.
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -37,6 +37,7 @@ import (
 	"storj.io/storj/satellite/abtesting"
 	"storj.io/storj/satellite/analytics"
 	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/consoleauth/sso"
 	"storj.io/storj/satellite/console/consoleweb/consoleapi"
 	"storj.io/storj/satellite/console/consoleweb/consolewebauth"
 	"storj.io/storj/satellite/mailservice"
@@ -202,7 +203,8 @@ func (a *apiAuth) cookieAuth(ctx context.Context, r *http.Request) (context.Cont
 	return a.server.service.TokenAuth(ctx, tokenInfo.Token, time.Now())
 }
 
-// cookieAuth returns an authenticated context by api key.
+// keyAuth returns an authenticated context by api key.
+// The key must be allowed to access the endpoint group of the request.
 func (a *apiAuth) keyAuth(ctx context.Context, r *http.Request) (context.Context, error) {
 	authToken := r.Header.Get("Authorization")
 	split := strings.Split(authToken, "Bearer ")
@@ -210,7 +212,9 @@ func (a *apiAuth) keyAuth(ctx context.Context, r *http.Request) (context.Context
 		return ctx, errs.New("authorization key format is incorrect. Should be 'Bearer <key>'")
 	}
 
-	return a.server.service.KeyAuth(ctx, split[1], time.Now())
+	group, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/public/v1/"), "/")
+
+	return a.server.service.KeyAuth(ctx, split[1], console.RESTKeyScope(group), time.Now())
 }
 
 // RemoveAuthCookie indicates to the client that the authentication cookie should be removed.
@@ -219,7 +223,7 @@ func (a *apiAuth) RemoveAuthCookie(w http.ResponseWriter) {
 }
 
 // NewServer creates new instance of console server.
-func NewServer(logger *zap.Logger, config Config, service *console.Service, oidcService *oidc.Service, mailService *mailservice.Service, analytics *analytics.Service, abTesting *abtesting.Service, accountFreezeService *console.AccountFreezeService, listener net.Listener, stripePublicKey string, neededTokenPaymentConfirmations int, nodeURL storj.NodeURL, analyticsConfig analytics.Config, packagePlans paymentsconfig.PackagePlans) *Server {
+func NewServer(logger *zap.Logger, config Config, service *console.Service, oidcService *oidc.Service, mailService *mailservice.Service, analytics *analytics.Service, abTesting *abtesting.Service, accountFreezeService *console.AccountFreezeService, listener net.Listener, stripePublicKey string, neededTokenPaymentConfirmations int, nodeURL storj.NodeURL, analyticsConfig analytics.Config, packagePlans paymentsconfig.PackagePlans, networkStats http.Handler) *Server {
 	initAdditionalMimeTypes()
 
 	server := Server{
@@ -282,7 +286,16 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, oidc
 	router.HandleFunc("/registrationToken/", server.createRegistrationTokenHandler)
 	router.HandleFunc("/robots.txt", server.seoHandler)
 
-	projectsController := consoleapi.NewProjects(logger, service)
+	if networkStats != nil {
+		router.Handle("/api/v0/network/stats", server.ipRateLimiter.Limit(networkStats)).Methods(http.MethodGet, http.MethodOptions)
+	}
+
+	if server.config.AbuseReports.Enabled {
+		abuseReportsController := consoleapi.NewAbuseReports(logger, service)
+		router.Handle("/api/v0/abuse-reports", server.ipRateLimiter.Limit(http.HandlerFunc(abuseReportsController.Submit))).Methods(http.MethodPost, http.MethodOptions)
+	}
+
+	projectsController := consoleapi.NewProjects(logger, service, nodeURL)
 	projectsRouter := router.PathPrefix("/api/v0/projects").Subrouter()
 	projectsRouter.Use(server.withCORS)
 	projectsRouter.Use(server.withAuth)
@@ -302,6 +315,13 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, oidc
 	projectsRouter.Handle("/{id}/invite-link", http.HandlerFunc(projectsController.GetInviteLink)).Methods(http.MethodGet, http.MethodOptions)
 	projectsRouter.Handle("/{id}/emission", http.HandlerFunc(projectsController.GetEmissionImpact)).Methods(http.MethodGet, http.MethodOptions)
 	projectsRouter.Handle("/{id}/config", http.HandlerFunc(projectsController.GetConfig)).Methods(http.MethodGet, http.MethodOptions)
+	projectsRouter.Handle("/{id}/budget-cap", http.HandlerFunc(projectsController.GetBudgetCap)).Methods(http.MethodGet, http.MethodOptions)
+	projectsRouter.Handle("/{id}/budget-cap", http.HandlerFunc(projectsController.SetBudgetCap)).Methods(http.MethodPut, http.MethodOptions)
+	projectsRouter.Handle("/{id}/budget-cap", http.HandlerFunc(projectsController.RemoveBudgetCap)).Methods(http.MethodDelete, http.MethodOptions)
+	projectsRouter.Handle("/{id}/usage-webhooks", http.HandlerFunc(projectsController.GetUsageWebhooks)).Methods(http.MethodGet, http.MethodOptions)
+	projectsRouter.Handle("/{id}/usage-webhooks", http.HandlerFunc(projectsController.CreateUsageWebhook)).Methods(http.MethodPost, http.MethodOptions)
+	projectsRouter.Handle("/{id}/usage-webhooks/{webhookID}", http.HandlerFunc(projectsController.DeleteUsageWebhook)).Methods(http.MethodDelete, http.MethodOptions)
+	projectsRouter.Handle("/{id}/object-preview", http.HandlerFunc(projectsController.CreateObjectPreviewURL)).Methods(http.MethodPost, http.MethodOptions)
 	projectsRouter.Handle("/{id}/versioning-opt-{status}", http.HandlerFunc(projectsController.OptInToVersioning)).Methods(http.MethodPatch, http.MethodOptions)
 	projectsRouter.Handle("/invitations", http.HandlerFunc(projectsController.GetUserInvitations)).Methods(http.MethodGet, http.MethodOptions)
 	projectsRouter.Handle("/invitations/{id}/respond", http.HandlerFunc(projectsController.RespondToInvitation)).Methods(http.MethodPost, http.MethodOptions)
@@ -337,6 +357,8 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, oidc
 	authRouter.Handle("/logout", server.withAuth(http.HandlerFunc(authController.Logout))).Methods(http.MethodPost, http.MethodOptions)
 	authRouter.Handle("/token", server.ipRateLimiter.Limit(http.HandlerFunc(authController.Token))).Methods(http.MethodPost, http.MethodOptions)
 	authRouter.Handle("/token-by-api-key", server.ipRateLimiter.Limit(http.HandlerFunc(authController.TokenByAPIKey))).Methods(http.MethodPost, http.MethodOptions)
+	authRouter.Handle("/rest-keys", server.withAuth(http.HandlerFunc(authController.CreateRESTKey))).Methods(http.MethodPost, http.MethodOptions)
+	authRouter.Handle("/rest-keys/revoke", server.withAuth(http.HandlerFunc(authController.RevokeRESTKey))).Methods(http.MethodPost, http.MethodOptions)
 	authRouter.Handle("/register", server.ipRateLimiter.Limit(http.HandlerFunc(authController.Register))).Methods(http.MethodPost, http.MethodOptions)
 	authRouter.Handle("/code-activation", server.ipRateLimiter.Limit(http.HandlerFunc(authController.ActivateAccount))).Methods(http.MethodPatch, http.MethodOptions)
 	authRouter.Handle("/forgot-password", server.ipRateLimiter.Limit(http.HandlerFunc(authController.ForgotPassword))).Methods(http.MethodPost, http.MethodOptions)
@@ -348,11 +370,26 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, oidc
 	authRouter.Handle("/limit-increase", server.withAuth(http.HandlerFunc(authController.RequestLimitIncrease))).Methods(http.MethodPatch, http.MethodOptions)
 	authRouter.Handle("/change-email", server.withAuth(http.HandlerFunc(authController.ChangeEmail))).Methods(http.MethodPost, http.MethodOptions)
 
+	if config.SSO.Enabled {
+		ssoService := sso.NewService(logger.Named("sso"), config.SSO, server.config.ExternalAddress)
+		ssoController := consoleapi.NewSSO(logger, service, ssoService, server.cookieAuth, server.config.ExternalAddress)
+		authRouter.Handle("/sso", http.HandlerFunc(ssoController.GetProviders)).Methods(http.MethodGet, http.MethodOptions)
+		authRouter.Handle("/sso/mfa", server.ipRateLimiter.Limit(http.HandlerFunc(ssoController.CompleteMFA))).Methods(http.MethodPost, http.MethodOptions)
+		authRouter.Handle("/sso/{provider}", server.ipRateLimiter.Limit(http.HandlerFunc(ssoController.BeginLogin))).Methods(http.MethodGet)
+		authRouter.Handle("/sso/{provider}/callback", server.ipRateLimiter.Limit(http.HandlerFunc(ssoController.Callback))).Methods(http.MethodGet)
+	}
+
 	domainsController := consoleapi.NewDomains(logger, service)
 	domainsRouter := router.PathPrefix("/api/v0/domains").Subrouter()
 	domainsRouter.Use(server.withCORS)
 	domainsRouter.Use(server.withAuth)
 	domainsRouter.Handle("/check-dns", http.HandlerFunc(domainsController.CheckDNSRecords)).Methods(http.MethodPost, http.MethodOptions)
+	if config.Domains.Enabled {
+		domainsRouter.Handle("/project/{projectID}", http.HandlerFunc(domainsController.GetProjectDomains)).Methods(http.MethodGet, http.MethodOptions)
+		domainsRouter.Handle("/project/{projectID}", http.HandlerFunc(domainsController.CreateDomain)).Methods(http.MethodPost, http.MethodOptions)
+		domainsRouter.Handle("/project/{projectID}/{subdomain}/verify", http.HandlerFunc(domainsController.VerifyDomain)).Methods(http.MethodPost, http.MethodOptions)
+		domainsRouter.Handle("/project/{projectID}/{subdomain}", http.HandlerFunc(domainsController.DeleteDomain)).Methods(http.MethodDelete, http.MethodOptions)
+	}
 
 	if config.ABTesting.Enabled {
 		abController := consoleapi.NewABTesting(logger, abTesting)
@@ -411,6 +448,11 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, oidc
 	bucketsRouter.HandleFunc("/bucket-placements", bucketsController.GetBucketMetadata).Methods(http.MethodGet, http.MethodOptions)
 	bucketsRouter.HandleFunc("/bucket-metadata", bucketsController.GetBucketMetadata).Methods(http.MethodGet, http.MethodOptions)
 	bucketsRouter.HandleFunc("/usage-totals", bucketsController.GetBucketTotals).Methods(http.MethodGet, http.MethodOptions)
+	bucketsRouter.HandleFunc("/stats", bucketsController.GetBucketStats).Methods(http.MethodGet, http.MethodOptions)
+	bucketsRouter.HandleFunc("/lifecycle", bucketsController.GetBucketLifecycle).Methods(http.MethodGet, http.MethodOptions)
+	bucketsRouter.HandleFunc("/lifecycle", bucketsController.UpdateBucketLifecycle).Methods(http.MethodPut, http.MethodOptions)
+	bucketsRouter.HandleFunc("/cors", bucketsController.GetBucketCORS).Methods(http.MethodGet, http.MethodOptions)
+	bucketsRouter.HandleFunc("/cors", bucketsController.UpdateBucketCORS).Methods(http.MethodPut, http.MethodOptions)
 
 	apiKeysController := consoleapi.NewAPIKeys(logger, service)
 	apiKeysRouter := router.PathPrefix("/api/v0/api-keys").Subrouter()
@@ -421,6 +463,8 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, oidc
 	apiKeysRouter.Handle("/delete-by-ids", http.HandlerFunc(apiKeysController.DeleteByIDs)).Methods(http.MethodDelete, http.MethodOptions)
 	apiKeysRouter.HandleFunc("/list-paged", apiKeysController.GetProjectAPIKeys).Methods(http.MethodGet, http.MethodOptions)
 	apiKeysRouter.HandleFunc("/api-key-names", apiKeysController.GetAllAPIKeyNames).Methods(http.MethodGet, http.MethodOptions)
+	apiKeysRouter.HandleFunc("/inventory", apiKeysController.GetAPIKeyInventory).Methods(http.MethodGet, http.MethodOptions)
+	apiKeysRouter.HandleFunc("/inspect", apiKeysController.InspectAPIKey).Methods(http.MethodPost, http.MethodOptions)
 
 	analyticsController := consoleapi.NewAnalytics(logger, service, server.analytics)
 
@@ -898,6 +942,7 @@ func (server *Server) frontendConfigHandler(w http.ResponseWriter, r *http.Reque
 		AltObjBrowserPagingThreshold:      server.config.AltObjBrowserPagingThreshold,
 		DomainsPageEnabled:                server.config.DomainsPageEnabled,
 		ActiveSessionsViewEnabled:         server.config.ActiveSessionsViewEnabled,
+		ObjectPreviewEnabled:              server.config.ObjectPreview.Enabled,
 	}
 
 	err := json.NewEncoder(w).Encode(&cfg)
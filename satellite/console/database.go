@@ -29,8 +29,20 @@ type DB interface {
 	ResetPasswordTokens() ResetPasswordTokens
 	// WebappSessions is a getter for WebappSessions repository.
 	WebappSessions() consoleauth.WebappSessions
+	// ImpersonationAudits is a getter for ImpersonationAudits repository.
+	ImpersonationAudits() ImpersonationAudits
 	// AccountFreezeEvents is a getter for AccountFreezeEvents repository.
 	AccountFreezeEvents() AccountFreezeEvents
+	// AbuseReports is a getter for AbuseReports repository.
+	AbuseReports() AbuseReports
+	// Domains is a getter for Domains repository.
+	Domains() Domains
+	// ProjectBudgetCaps is a getter for ProjectBudgetCaps repository.
+	ProjectBudgetCaps() ProjectBudgetCaps
+	// ProjectUsageWebhooks is a getter for ProjectUsageWebhooks repository.
+	ProjectUsageWebhooks() ProjectUsageWebhooks
+	// SSOIdentities is a getter for SSOIdentities repository.
+	SSOIdentities() SSOIdentities
 
 	// WithTx is a method for executing transactions with retrying as necessary.
 	WithTx(ctx context.Context, fn func(ctx context.Context, tx DBTx) error) error
@@ -124,5 +124,39 @@ func TestChore(t *testing.T) {
 			require.Error(t, err)
 			require.Nil(t, createdKey2)
 		})
+
+		t.Run("delete old project invitations", func(t *testing.T) {
+			chore.Loop.Pause()
+
+			invites := db.Console().ProjectInvitations()
+
+			oldInvite, err := invites.Upsert(ctx, &console.ProjectInvitation{
+				ProjectID: pr1.ID,
+				Email:     "old@mail.test",
+				InviterID: &user1.ID,
+			})
+			require.NoError(t, err)
+			newInvite, err := invites.Upsert(ctx, &console.ProjectInvitation{
+				ProjectID: pr2.ID,
+				Email:     "new@mail.test",
+				InviterID: &user2.ID,
+			})
+			require.NoError(t, err)
+
+			createdAt := time.Now().Add(-cfg.ConsoleDBCleanup.MaxProjectInvitationAge).Add(-time.Hour)
+			_, err = db.Testing().RawDB().ExecContext(ctx,
+				"UPDATE project_invitations SET created_at = $1 WHERE project_id = $2 AND email = $3",
+				createdAt, oldInvite.ProjectID, oldInvite.Email)
+			require.NoError(t, err)
+
+			chore.Loop.TriggerWait()
+			chore.Loop.Pause()
+
+			_, err = invites.Get(ctx, oldInvite.ProjectID, oldInvite.Email)
+			require.Error(t, err)
+
+			_, err = invites.Get(ctx, newInvite.ProjectID, newInvite.Email)
+			require.NoError(t, err)
+		})
 	})
 }
@@ -0,0 +1,383 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+)
+
+// ErrNoDomain is error type that occurs when there is no domain found.
+var ErrNoDomain = errs.Class("no domain found")
+
+// DomainsConfig contains configurations for custom linksharing domains.
+type DomainsConfig struct {
+	Enabled              bool   `help:"whether custom domains can be registered for shared links" default:"false"`
+	LinksharingHost      string `help:"host the CNAME record of a custom domain has to point to" default:"link.storjshare.io"`
+	MaxDomainsPerProject int    `help:"maximum number of custom domains per project" default:"10"`
+
+	PendingExpiration time.Duration `help:"how long a domain may stay pending verification before another project can register it" default:"72h"`
+}
+
+// Domains exposes methods to manage the custom linksharing domains.
+//
+// architecture: Database
+type Domains interface {
+	// Insert inserts a new domain.
+	Insert(ctx context.Context, domain Domain) (*Domain, error)
+	// Get returns the domain with the specified subdomain.
+	Get(ctx context.Context, subdomain string) (*Domain, error)
+	// ListByProjectID returns the domains of the project.
+	ListByProjectID(ctx context.Context, projectID uuid.UUID) ([]Domain, error)
+	// ListByStatus returns the least recently updated domains with the specified status.
+	ListByStatus(ctx context.Context, status DomainStatus, limit int) ([]Domain, error)
+	// UpdateStatus updates the status of the domain.
+	UpdateStatus(ctx context.Context, subdomain string, status DomainStatus, message string) error
+	// Delete deletes the domain.
+	Delete(ctx context.Context, subdomain string) error
+}
+
+// DomainStatus is the setup status of a custom domain.
+type DomainStatus int
+
+const (
+	// DomainPendingVerification is the status of a domain whose DNS records weren't verified yet.
+	DomainPendingVerification DomainStatus = 0
+	// DomainCertificatePending is the status of a verified domain which is waiting for a TLS certificate.
+	DomainCertificatePending DomainStatus = 1
+	// DomainActive is the status of a domain which is served by the linksharing service.
+	DomainActive DomainStatus = 2
+	// DomainCertificateFailed is the status of a domain for which the TLS certificate couldn't be issued.
+	DomainCertificateFailed DomainStatus = 3
+)
+
+// String returns a string representation of the domain status.
+func (status DomainStatus) String() string {
+	switch status {
+	case DomainPendingVerification:
+		return "pending-verification"
+	case DomainCertificatePending:
+		return "certificate-pending"
+	case DomainActive:
+		return "active"
+	case DomainCertificateFailed:
+		return "certificate-failed"
+	default:
+		return "unknown"
+	}
+}
+
+// DomainStatusFromString parses the string representation of a domain status.
+func DomainStatusFromString(s string) (DomainStatus, error) {
+	switch strings.ToLower(s) {
+	case "pending-verification":
+		return DomainPendingVerification, nil
+	case "certificate-pending":
+		return DomainCertificatePending, nil
+	case "active":
+		return DomainActive, nil
+	case "certificate-failed":
+		return DomainCertificateFailed, nil
+	default:
+		return 0, errs.New("invalid domain status %q", s)
+	}
+}
+
+// Domain is a custom domain mapped to a prefix shared with an access grant.
+type Domain struct {
+	Subdomain         string       `json:"subdomain"`
+	ProjectID         uuid.UUID    `json:"-"`
+	CreatedBy         uuid.UUID    `json:"createdBy"`
+	Prefix            string       `json:"prefix"`
+	AccessID          string       `json:"accessId"`
+	VerificationToken string       `json:"verificationToken"`
+	Status            DomainStatus `json:"status"`
+	StatusMessage     string       `json:"statusMessage"`
+	CreatedAt         time.Time    `json:"createdAt"`
+	UpdatedAt         time.Time    `json:"updatedAt"`
+}
+
+// DomainRequest contains the fields of a domain registration.
+type DomainRequest struct {
+	Subdomain string `json:"subdomain"`
+	Prefix    string `json:"prefix"`
+	AccessID  string `json:"accessId"`
+}
+
+// DomainDNSRecords are the DNS records which have to be configured for a custom domain.
+type DomainDNSRecords struct {
+	CNAMEName  string   `json:"cnameName"`
+	CNAMEValue string   `json:"cnameValue"`
+	TXTName    string   `json:"txtName"`
+	TXTValues  []string `json:"txtValues"`
+}
+
+// DomainResolver looks up the DNS records of the custom domains.
+type DomainResolver interface {
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// DomainDNSRecords returns the DNS records which have to be configured for the domain.
+// The TXT records are the ones read by the linksharing service, and the ownership token.
+func (s *Service) DomainDNSRecords(domain Domain) DomainDNSRecords {
+	return DomainDNSRecords{
+		CNAMEName:  domain.Subdomain,
+		CNAMEValue: s.config.Domains.LinksharingHost,
+		TXTName:    "txt-" + domain.Subdomain,
+		TXTValues: []string{
+			"storj-root:" + domain.Prefix,
+			"storj-access:" + domain.AccessID,
+			"storj-verification:" + domain.VerificationToken,
+		},
+	}
+}
+
+// CreateDomain registers a custom domain for the project. The domain has to be verified before it's served.
+// A domain which stays pending verification for longer than the configured expiration
+// can be registered by another project.
+func (s *Service) CreateDomain(ctx context.Context, projectID uuid.UUID, request DomainRequest) (_ *Domain, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "create domain", zap.String("projectID", projectID.String()), zap.String("subdomain", request.Subdomain))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if !s.config.Domains.Enabled {
+		return nil, ErrForbidden.New("custom domains are not enabled")
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return nil, ErrUnauthorized.Wrap(err)
+	}
+
+	request.Subdomain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(request.Subdomain)), ".")
+	request.Prefix = strings.Trim(strings.TrimSpace(request.Prefix), "/")
+	request.AccessID = strings.TrimSpace(request.AccessID)
+
+	if !isValidDomainName(request.Subdomain) {
+		return nil, ErrValidation.New("invalid domain name")
+	}
+	if request.Prefix == "" {
+		return nil, ErrValidation.New("bucket is required")
+	}
+	if request.AccessID == "" {
+		return nil, ErrValidation.New("access key ID is required")
+	}
+
+	existing, err := s.store.Domains().ListByProjectID(ctx, isMember.project.ID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if len(existing) >= s.config.Domains.MaxDomainsPerProject {
+		return nil, ErrConflict.New("project can have at most %d custom domains", s.config.Domains.MaxDomainsPerProject)
+	}
+
+	registered, err := s.store.Domains().Get(ctx, request.Subdomain)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return nil, Error.Wrap(err)
+	case s.isExpiredDomainRegistration(registered):
+		// the ownership of the domain was never proven, hence the registration
+		// doesn't block the domain anymore.
+		s.log.Info("removing expired domain registration",
+			zap.String("subdomain", registered.Subdomain),
+			zap.Stringer("projectID", registered.ProjectID))
+		if err := s.store.Domains().Delete(ctx, registered.Subdomain); err != nil {
+			return nil, Error.Wrap(err)
+		}
+	default:
+		return nil, ErrConflict.New("domain %q is already registered", request.Subdomain)
+	}
+
+	var token [16]byte
+	if _, err := rand.Read(token[:]); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	domain, err := s.store.Domains().Insert(ctx, Domain{
+		Subdomain:         request.Subdomain,
+		ProjectID:         isMember.project.ID,
+		CreatedBy:         user.ID,
+		Prefix:            request.Prefix,
+		AccessID:          request.AccessID,
+		VerificationToken: hex.EncodeToString(token[:]),
+		Status:            DomainPendingVerification,
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return domain, nil
+}
+
+// isExpiredDomainRegistration returns whether the domain was pending verification
+// for longer than allowed.
+func (s *Service) isExpiredDomainRegistration(domain *Domain) bool {
+	return domain.Status == DomainPendingVerification &&
+		s.config.Domains.PendingExpiration > 0 &&
+		s.nowFn().Sub(domain.CreatedAt) > s.config.Domains.PendingExpiration
+}
+
+// GetProjectDomains returns the custom domains of the project.
+func (s *Service) GetProjectDomains(ctx context.Context, projectID uuid.UUID) (_ []Domain, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get project domains", zap.String("projectID", projectID.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return nil, ErrUnauthorized.Wrap(err)
+	}
+
+	domains, err := s.store.Domains().ListByProjectID(ctx, isMember.project.ID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return domains, nil
+}
+
+// VerifyDomain checks the DNS records of the custom domain. When the records are
+// configured the domain is waiting for the TLS certificate to be issued.
+func (s *Service) VerifyDomain(ctx context.Context, projectID uuid.UUID, subdomain string) (_ *Domain, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "verify domain", zap.String("projectID", projectID.String()), zap.String("subdomain", subdomain))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	_, domain, err := s.getProjectDomain(ctx, user.ID, projectID, subdomain)
+	if err != nil {
+		return nil, err
+	}
+
+	if domain.Status != DomainPendingVerification {
+		return domain, nil
+	}
+
+	records := s.DomainDNSRecords(*domain)
+
+	cname, err := s.domainResolver.LookupCNAME(ctx, records.CNAMEName)
+	if err != nil {
+		return nil, ErrConflict.New("CNAME record of %q can't be resolved", records.CNAMEName)
+	}
+	if !strings.EqualFold(strings.TrimSuffix(cname, "."), strings.TrimSuffix(records.CNAMEValue, ".")) {
+		return nil, ErrConflict.New("CNAME record of %q points to %q instead of %q", records.CNAMEName, cname, records.CNAMEValue)
+	}
+
+	txt, err := s.domainResolver.LookupTXT(ctx, records.TXTName)
+	if err != nil {
+		return nil, ErrConflict.New("TXT records of %q can't be resolved", records.TXTName)
+	}
+	for _, expected := range records.TXTValues {
+		if !containsString(txt, expected) {
+			return nil, ErrConflict.New("TXT record %q of %q is missing", expected, records.TXTName)
+		}
+	}
+
+	err = s.store.Domains().UpdateStatus(ctx, domain.Subdomain, DomainCertificatePending, "")
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	domain.Status = DomainCertificatePending
+	domain.StatusMessage = ""
+	return domain, nil
+}
+
+// DeleteDomain removes the custom domain of the project. Only the project owner,
+// a project admin or the user who registered the domain may remove it.
+func (s *Service) DeleteDomain(ctx context.Context, projectID uuid.UUID, subdomain string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "delete domain", zap.String("projectID", projectID.String()), zap.String("subdomain", subdomain))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	isMember, domain, err := s.getProjectDomain(ctx, user.ID, projectID, subdomain)
+	if err != nil {
+		return err
+	}
+
+	if isMember.membership.Role != RoleAdmin && isMember.project.OwnerID != user.ID && domain.CreatedBy != user.ID {
+		return ErrForbidden.New("you do not have permission to delete this domain")
+	}
+
+	return Error.Wrap(s.store.Domains().Delete(ctx, domain.Subdomain))
+}
+
+// getProjectDomain returns the domain when it belongs to the project and the user is a member of the project.
+func (s *Service) getProjectDomain(ctx context.Context, userID, projectID uuid.UUID, subdomain string) (isProjectMember, *Domain, error) {
+	isMember, err := s.isProjectMember(ctx, userID, projectID)
+	if err != nil {
+		return isProjectMember{}, nil, ErrUnauthorized.Wrap(err)
+	}
+
+	domain, err := s.store.Domains().Get(ctx, strings.TrimSuffix(strings.ToLower(subdomain), "."))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return isProjectMember{}, nil, ErrNoDomain.New("%s", subdomain)
+		}
+		return isProjectMember{}, nil, Error.Wrap(err)
+	}
+	if domain.ProjectID != isMember.project.ID {
+		return isProjectMember{}, nil, ErrNoDomain.New("%s", subdomain)
+	}
+	return isMember, domain, nil
+}
+
+// isValidDomainName returns whether the name is a fully qualified domain name.
+func isValidDomainName(name string) bool {
+	if len(name) > 253 || net.ParseIP(name) != nil {
+		return false
+	}
+	labels := strings.Split(name, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// TestSetDomainResolver allows tests to replace the resolver used for verifying the custom domains.
+func (s *Service) TestSetDomainResolver(resolver DomainResolver) {
+	s.domainResolver = resolver
+}
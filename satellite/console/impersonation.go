@@ -0,0 +1,37 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+
+	"storj.io/common/uuid"
+)
+
+// impersonationKey is context key for the impersonated session ID.
+const impersonationKey key = 2
+
+const impersonationForbiddenErrMsg = "This action is not allowed in a support session"
+
+// WithImpersonation creates new context marking that the request is made in
+// a session created by an admin impersonating the user.
+func WithImpersonation(ctx context.Context, sessionID uuid.UUID) context.Context {
+	return context.WithValue(ctx, impersonationKey, sessionID)
+}
+
+// GetImpersonation returns the ID of the impersonated session the request is made in.
+func GetImpersonation(ctx context.Context) (sessionID uuid.UUID, ok bool) {
+	sessionID, ok = ctx.Value(impersonationKey).(uuid.UUID)
+	return sessionID, ok
+}
+
+// checkNotImpersonated returns an error when the request is made in an impersonated session.
+// It guards the operations an admin must not do on behalf of the user, such as changing
+// the credentials of the account.
+func checkNotImpersonated(ctx context.Context) error {
+	if _, ok := GetImpersonation(ctx); ok {
+		return ErrForbidden.New(impersonationForbiddenErrMsg)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/uuid"
+)
+
+// ImpersonationAudits exposes methods to record the sessions created by admins impersonating users.
+//
+// architecture: Database
+type ImpersonationAudits interface {
+	// Insert records a new impersonation session.
+	Insert(ctx context.Context, audit ImpersonationAudit) error
+	// Get returns the audit record of the impersonation session.
+	Get(ctx context.Context, sessionID uuid.UUID) (*ImpersonationAudit, error)
+	// End records when the impersonation session was ended.
+	End(ctx context.Context, sessionID uuid.UUID, endedAt time.Time) error
+}
+
+// ImpersonationAudit is the audit record of a console session created by an admin impersonating a user.
+type ImpersonationAudit struct {
+	SessionID  uuid.UUID
+	UserID     uuid.UUID
+	AdminEmail string
+	Reason     string
+	Consent    bool
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	EndedAt    *time.Time
+}
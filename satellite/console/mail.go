@@ -289,3 +289,26 @@ func (b *BillingFreezeNotificationEmail) Subject() string {
 	}
 	return title + " - Act now to continue!"
 }
+
+// BudgetCapNotificationEmail is an email sent to notify project owners that the
+// estimated spend of their project reached a threshold of its budget cap.
+type BudgetCapNotificationEmail struct {
+	ProjectName   string
+	Cap           string
+	Percent       int
+	Frozen        bool
+	FrozenActions string
+	SignInLink    string
+	SupportLink   string
+}
+
+// Template returns email template name.
+func (*BudgetCapNotificationEmail) Template() string { return "BudgetCapNotification" }
+
+// Subject gets email subject.
+func (b *BudgetCapNotificationEmail) Subject() string {
+	if b.Percent >= 100 {
+		return "Your project has reached its budget cap"
+	}
+	return "Your project is approaching its budget cap"
+}
@@ -94,6 +94,10 @@ func (s *Service) EnableUserMFA(ctx context.Context, passcode string, t time.Tim
 		return Error.Wrap(err)
 	}
 
+	if err := checkNotImpersonated(ctx); err != nil {
+		return err
+	}
+
 	if user.MFAEnabled {
 		return ErrMFAEnabled.New("")
 	}
@@ -126,6 +130,10 @@ func (s *Service) DisableUserMFA(ctx context.Context, passcode string, t time.Ti
 		return Error.Wrap(err)
 	}
 
+	if err := checkNotImpersonated(ctx); err != nil {
+		return err
+	}
+
 	if !user.MFAEnabled {
 		return nil
 	}
@@ -204,6 +212,10 @@ func (s *Service) ResetMFASecretKey(ctx context.Context) (key string, err error)
 		return "", Error.Wrap(err)
 	}
 
+	if err := checkNotImpersonated(ctx); err != nil {
+		return "", err
+	}
+
 	key, err = NewMFASecretKey()
 	if err != nil {
 		return "", Error.Wrap(err)
@@ -231,6 +243,10 @@ func (s *Service) ResetMFARecoveryCodes(ctx context.Context, requireCode bool, p
 		return nil, Error.Wrap(err)
 	}
 
+	if err := checkNotImpersonated(ctx); err != nil {
+		return nil, err
+	}
+
 	if !user.MFAEnabled {
 		return nil, ErrUnauthorized.New(mfaRecoveryGenerationErrMsg)
 	}
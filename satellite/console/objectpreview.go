@@ -0,0 +1,288 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/encryption"
+	"storj.io/common/grant"
+	"storj.io/common/macaroon"
+	"storj.io/common/paths"
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+)
+
+// ObjectPreviewConfig contains configurations for object previews generated by the satellite.
+type ObjectPreviewConfig struct {
+	Enabled        bool          `help:"whether the satellite generates object preview URLs for projects with satellite managed encryption" default:"false"`
+	AuthServiceURL string        `help:"url of the auth service used for registering object preview access grants" default:"https://auth.storjsatelliteshare.io" devDefault:"http://localhost:8000"`
+	LinksharingURL string        `help:"url of the linksharing service used for serving object previews" default:"https://link.storjsatelliteshare.io" devDefault:"http://localhost:8001"`
+	Expiration     time.Duration `help:"duration for which an object preview URL remains valid" default:"15m"`
+	Timeout        time.Duration `help:"timeout for registering an object preview access grant with the auth service" default:"10s"`
+}
+
+// newObjectPreviewClient creates the client for registering the object preview access grants.
+func newObjectPreviewClient(config ObjectPreviewConfig) *http.Client {
+	return &http.Client{
+		Timeout:   config.Timeout,
+		Transport: http.DefaultTransport.(*http.Transport).Clone(),
+	}
+}
+
+// objectPreviewKeyName is the name of the API key used for object previews. It starts with the object
+// browser key prefix, so it's hidden from the user and it's removed once it gets old.
+func (s *Service) objectPreviewKeyName(userID uuid.UUID) string {
+	return s.config.ObjectBrowserKeyNamePrefix + "preview-" + userID.String()
+}
+
+// CreateObjectPreviewURL creates a short-lived linksharing URL for previewing an object in the object browser.
+// The URL is only available for projects whose encryption passphrase is managed by the satellite.
+func (s *Service) CreateObjectPreviewURL(ctx context.Context, projectID uuid.UUID, satelliteNodeURL, bucket, key string) (previewURL string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "create object preview url", zap.String("projectID", projectID.String()), zap.String("bucket", bucket))
+	if err != nil {
+		return "", ErrUnauthorized.Wrap(err)
+	}
+
+	if !s.config.ObjectPreview.Enabled || s.kmsService == nil {
+		return "", ErrForbidden.New("object previews are not enabled")
+	}
+
+	if bucket == "" || key == "" {
+		return "", ErrValidation.New("bucket name and object key are required")
+	}
+	if strings.HasSuffix(key, "/") {
+		return "", ErrValidation.New("object key must not be a prefix")
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return "", ErrNoMembership.Wrap(err)
+	}
+	project := isMember.project
+
+	passphraseEnc, encKeyID, err := s.store.Projects().GetEncryptedPassphrase(ctx, project.ID)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	if passphraseEnc == nil || encKeyID == nil {
+		return "", ErrForbidden.New("object previews are only available for projects with satellite managed encryption")
+	}
+
+	passphrase, err := s.kmsService.DecryptPassphrase(ctx, *encKeyID, passphraseEnc)
+	if err != nil {
+		s.log.Error("failed to decrypt passphrase", zap.Error(err))
+		return "", Error.New("Failed to retrieve passphrase")
+	}
+
+	salt, err := s.store.Projects().GetSalt(ctx, project.ID)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	apiKey, err := s.getOrCreateObjectPreviewKey(ctx, user, project.ID)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	const concurrency = 8
+	rootKey, err := encryption.DeriveRootKey(passphrase, salt, "", concurrency)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	pathCipher := storj.EncAESGCM
+	if project.PathEncryption != nil && !*project.PathEncryption {
+		pathCipher = storj.EncNull
+	}
+
+	access, err := objectPreviewAccess(satelliteNodeURL, apiKey, rootKey, pathCipher, bucket, key, s.nowFn(), s.config.ObjectPreview.Expiration)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	serializedAccess, err := access.Serialize()
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	authServiceURL := s.config.ObjectPreview.AuthServiceURL
+	linksharingURL := s.config.ObjectPreview.LinksharingURL
+	if edgeURLs, ok := s.config.PlacementEdgeURLOverrides.Get(project.DefaultPlacement); ok {
+		if edgeURLs.AuthService != "" {
+			authServiceURL = edgeURLs.AuthService
+		}
+		if edgeURLs.InternalLinksharing != "" {
+			linksharingURL = edgeURLs.InternalLinksharing
+		}
+	}
+
+	accessKeyID, err := registerPublicAccess(ctx, s.objectPreviewClient, authServiceURL, serializedAccess)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	segments := []string{url.PathEscape(accessKeyID), url.PathEscape(bucket)}
+	for _, part := range strings.Split(key, "/") {
+		segments = append(segments, url.PathEscape(part))
+	}
+
+	return strings.TrimSuffix(linksharingURL, "/") + "/raw/" + strings.Join(segments, "/"), nil
+}
+
+// objectPreviewAccess creates a download only access grant for the object.
+//
+// Caveats only restrict the paths by their encrypted prefix, so the grant is restricted to
+// the encrypted object key and its encryption access contains only the key derived for the
+// object instead of the root key. The other objects of the bucket can't be decrypted, except
+// for the objects nested under the object key as if it was a folder, and none can be listed.
+func objectPreviewAccess(satelliteNodeURL string, apiKey *macaroon.APIKey, rootKey *storj.Key, pathCipher storj.CipherSuite, bucket, key string, now time.Time, expiration time.Duration) (_ *grant.Access, err error) {
+	store := encryption.NewStore()
+	store.SetDefaultKey(rootKey)
+	store.SetDefaultPathCipher(pathCipher)
+
+	unencPath := paths.NewUnencrypted(key)
+	encPath, err := encryption.EncryptPathWithStoreCipher(bucket, unencPath, store)
+	if err != nil {
+		return nil, err
+	}
+	pathKey, err := encryption.DerivePathKey(bucket, unencPath, store)
+	if err != nil {
+		return nil, err
+	}
+
+	notBefore, notAfter := now.Add(-time.Minute), now.Add(expiration)
+	restrictedKey, err := apiKey.Restrict(macaroon.WithNonce(macaroon.Caveat{
+		DisallowWrites:  true,
+		DisallowLists:   true,
+		DisallowDeletes: true,
+		DisallowLocks:   true,
+		NotBefore:       &notBefore,
+		NotAfter:        &notAfter,
+		AllowedPaths: []*macaroon.Caveat_Path{{
+			Bucket:              []byte(bucket),
+			EncryptedPathPrefix: []byte(encPath.Raw()),
+		}},
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	encAccess := grant.NewEncryptionAccess()
+	encAccess.SetDefaultPathCipher(pathCipher)
+	if err := encAccess.Store.AddWithCipher(bucket, unencPath, encPath, *pathKey, pathCipher); err != nil {
+		return nil, err
+	}
+
+	return &grant.Access{
+		SatelliteAddress: satelliteNodeURL,
+		APIKey:           restrictedKey,
+		EncAccess:        encAccess,
+	}, nil
+}
+
+// getOrCreateObjectPreviewKey returns the API key used for object previews of the user in the project.
+func (s *Service) getOrCreateObjectPreviewKey(ctx context.Context, user *User, projectID uuid.UUID) (_ *macaroon.APIKey, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	name := s.objectPreviewKeyName(user.ID)
+
+	info, err := s.store.APIKeys().GetByNameAndProjectID(ctx, name, projectID)
+	switch {
+	case err == nil:
+		// The key is removed by the cleanup chore when it reaches the object browser key lifetime,
+		// hence it's replaced early enough for the preview to stay valid until it expires.
+		keyExpiration := info.CreatedAt.Add(s.config.ObjectBrowserKeyLifetime)
+		if s.config.ObjectBrowserKeyLifetime <= 0 || s.nowFn().Add(s.config.ObjectPreview.Expiration).Before(keyExpiration) {
+			return macaroon.FromParts(info.Head, info.Secret)
+		}
+		if err := s.store.APIKeys().Delete(ctx, info.ID); err != nil {
+			return nil, err
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+
+	secret, err := macaroon.NewSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := macaroon.NewAPIKey(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.store.APIKeys().Create(ctx, key.Head(), APIKeyInfo{
+		Name:      name,
+		ProjectID: projectID,
+		CreatedBy: user.ID,
+		Secret:    secret,
+		UserAgent: user.UserAgent,
+		Version:   macaroon.APIKeyVersionMin,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// registerPublicAccess registers the access grant with the auth service and returns its access key ID.
+func registerPublicAccess(ctx context.Context, client *http.Client, authServiceURL, accessGrant string) (accessKeyID string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	body, err := json.Marshal(struct {
+		AccessGrant string `json:"access_grant"`
+		Public      bool   `json:"public"`
+	}{
+		AccessGrant: accessGrant,
+		Public:      true,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := strings.TrimSuffix(authServiceURL, "/") + "/v1/access"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { err = errs.Combine(err, resp.Body.Close()) }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errs.New("auth service responded with status %d", resp.StatusCode)
+	}
+
+	var credentials struct {
+		AccessKeyID string `json:"access_key_id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&credentials); err != nil {
+		return "", err
+	}
+	if credentials.AccessKeyID == "" {
+		return "", errs.New("auth service returned an empty access key ID")
+	}
+
+	return credentials.AccessKeyID, nil
+}
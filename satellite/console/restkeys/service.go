@@ -8,12 +8,14 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
 	"github.com/zeebo/errs"
 
 	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/oidc"
 )
 
@@ -51,16 +53,24 @@ func NewService(db oidc.OAuthTokens, config Config) *Service {
 	}
 }
 
-// Create creates and inserts an rest key into the db.
-func (s *Service) Create(ctx context.Context, userID uuid.UUID, expiration time.Duration) (apiKey string, expiresAt time.Time, err error) {
+// Create creates and inserts an rest key into the db. The key is restricted to the
+// scopes, a key without scopes can use all of them.
+func (s *Service) Create(ctx context.Context, userID uuid.UUID, expiration time.Duration, scopes ...console.RESTKeyScope) (apiKey string, expiresAt time.Time, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	apiKey, hash, err := s.GenerateNewKey(ctx)
 	if err != nil {
 		return "", time.Time{}, Error.Wrap(err)
 	}
+
+	scopeNames := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scopeNames = append(scopeNames, string(scope))
+	}
+
 	expiresAt, err = s.InsertIntoDB(ctx, oidc.OAuthToken{
 		UserID: userID,
+		Scope:  strings.Join(scopeNames, " "),
 		Kind:   oidc.KindRESTTokenV0,
 		Token:  hash,
 	}, time.Now(), expiration)
@@ -140,18 +150,35 @@ func (s *Service) InsertIntoDB(ctx context.Context, oAuthToken oidc.OAuthToken,
 func (s *Service) GetUserAndExpirationFromKey(ctx context.Context, apiKey string) (userID uuid.UUID, exp time.Time, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	hash, err := s.HashKey(ctx, apiKey)
+	info, err := s.GetKeyInfo(ctx, apiKey)
 	if err != nil {
 		return uuid.UUID{}, time.Now(), err
 	}
+	return info.UserID, info.ExpiresAt, nil
+}
+
+// GetKeyInfo gets the user, expiration date and scopes attached to an account management api key.
+func (s *Service) GetKeyInfo(ctx context.Context, apiKey string) (info console.RESTKeyInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	hash, err := s.HashKey(ctx, apiKey)
+	if err != nil {
+		return console.RESTKeyInfo{}, err
+	}
 	keyInfo, err := s.db.Get(ctx, oidc.KindRESTTokenV0, hash)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return uuid.UUID{}, time.Now(), Error.Wrap(ErrInvalidKey.New("invalid account management api key"))
+			return console.RESTKeyInfo{}, Error.Wrap(ErrInvalidKey.New("invalid account management api key"))
 		}
-		return uuid.UUID{}, time.Now(), err
+		return console.RESTKeyInfo{}, err
+	}
+
+	info.UserID = keyInfo.UserID
+	info.ExpiresAt = keyInfo.ExpiresAt
+	for _, scope := range strings.Fields(keyInfo.Scope) {
+		info.Scopes = append(info.Scopes, console.RESTKeyScope(scope))
 	}
-	return keyInfo.UserID, keyInfo.ExpiresAt, err
+	return info, nil
 }
 
 // Revoke revokes an account management api key.
@@ -13,6 +13,7 @@ import (
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/console/restkeys"
 	"storj.io/storj/satellite/oidc"
 )
@@ -61,6 +62,17 @@ func TestRESTKeys(t *testing.T) {
 		// test GetUserFromKey non existent key
 		_, _, err = service.GetUserAndExpirationFromKey(ctx, nonexistent)
 		require.True(t, restkeys.ErrInvalidKey.Has(err))
+
+		// test scopes are stored with the key
+		scopedKey, _, err := service.Create(ctx, id, expires, console.RESTKeyScopeProjects, console.RESTKeyScopeAPIKeys)
+		require.NoError(t, err)
+
+		info, err := service.GetKeyInfo(ctx, scopedKey)
+		require.NoError(t, err)
+		require.Equal(t, id, info.UserID)
+		require.Equal(t, []console.RESTKeyScope{console.RESTKeyScopeProjects, console.RESTKeyScopeAPIKeys}, info.Scopes)
+		require.True(t, info.Allows(console.RESTKeyScopeAPIKeys))
+		require.False(t, info.Allows(console.RESTKeyScopeUsers))
 	})
 }
 
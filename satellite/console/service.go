@@ -14,6 +14,7 @@ import (
 	"math"
 	"math/big"
 	mathrand "math/rand"
+	"net"
 	"net/http"
 	"sort"
 	"strconv"
@@ -43,6 +44,7 @@ import (
 	"storj.io/storj/satellite/emission"
 	"storj.io/storj/satellite/kms"
 	"storj.io/storj/satellite/mailservice"
+	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/nodeselection"
 	"storj.io/storj/satellite/payments"
 	"storj.io/storj/satellite/payments/billing"
@@ -211,6 +213,7 @@ type Service struct {
 	projectAccounting          accounting.ProjectAccounting
 	projectUsage               *accounting.Service
 	buckets                    buckets.DB
+	metabase                   *metabase.DB
 	placements                 nodeselection.PlacementDefinitions
 	accounts                   payments.Accounts
 	depositWallets             payments.DepositWallets
@@ -237,6 +240,10 @@ type Service struct {
 	versioningConfig VersioningConfig
 	objectLockConfig ObjectLockConfig
 
+	domainResolver DomainResolver
+
+	objectPreviewClient *http.Client
+
 	nowFn func() time.Time
 }
 
@@ -259,7 +266,7 @@ type Payments struct {
 
 // NewService returns new instance of Service.
 func NewService(log *zap.Logger, store DB, restKeys RESTKeys, projectAccounting accounting.ProjectAccounting,
-	projectUsage *accounting.Service, buckets buckets.DB, accounts payments.Accounts, depositWallets payments.DepositWallets,
+	projectUsage *accounting.Service, buckets buckets.DB, metabaseDB *metabase.DB, accounts payments.Accounts, depositWallets payments.DepositWallets,
 	billingDb billing.TransactionsDB, analytics *analytics.Service, tokens *consoleauth.Service, mailService *mailservice.Service,
 	accountFreezeService *AccountFreezeService, emission *emission.Service, kmsService *kms.Service, satelliteAddress string,
 	satelliteName string, maxProjectBuckets int, placements nodeselection.PlacementDefinitions,
@@ -329,6 +336,7 @@ func NewService(log *zap.Logger, store DB, restKeys RESTKeys, projectAccounting
 		projectAccounting:          projectAccounting,
 		projectUsage:               projectUsage,
 		buckets:                    buckets,
+		metabase:                   metabaseDB,
 		placements:                 placements,
 		accounts:                   accounts,
 		depositWallets:             depositWallets,
@@ -349,6 +357,8 @@ func NewService(log *zap.Logger, store DB, restKeys RESTKeys, projectAccounting
 		versioningConfig:           versioning,
 		objectLockConfig:           objectLock,
 		paymentSourceChainIDs:      paymentSourceChainIDs,
+		domainResolver:             net.DefaultResolver,
+		objectPreviewClient:        newObjectPreviewClient(config.ObjectPreview),
 		nowFn:                      time.Now,
 	}, nil
 }
@@ -377,6 +387,9 @@ func (s *Service) auditLog(ctx context.Context, operation string, userID *uuid.U
 	if requestID := requestid.FromContext(ctx); requestID != "" {
 		fields = append(fields, zap.String("requestID", requestID))
 	}
+	if sessionID, ok := GetImpersonation(ctx); ok {
+		fields = append(fields, zap.Bool("impersonated", true), zap.String("sessionID", sessionID.String()))
+	}
 
 	fields = append(fields, extra...)
 	s.auditLogger.Info("console activity", fields...)
@@ -968,6 +981,11 @@ func (s *Service) CreateUser(ctx context.Context, user CreateUser, tokenSecret R
 		return nil, err
 	}
 
+	if s.config.SSO.Enforced(user.Email) {
+		mon.Counter("create_user_sso_required").Inc(1)
+		return nil, ErrSSORequired.New(ssoRequiredErrMsg)
+	}
+
 	registrationToken, err := s.checkRegistrationSecret(ctx, tokenSecret)
 	if err != nil {
 		return nil, ErrRegToken.Wrap(err)
@@ -1445,6 +1463,12 @@ func (s *Service) Token(ctx context.Context, request AuthUser) (response *TokenI
 		captchaSkipped = false
 	}
 
+	if s.config.SSO.Enforced(request.Email) {
+		mon.Counter("login_sso_required").Inc(1)
+		s.auditLog(ctx, "login: failed sso required", nil, request.Email)
+		return nil, ErrSSORequired.New(ssoRequiredErrMsg)
+	}
+
 	user, nonActiveUsers, err := s.store.Users().GetByEmailWithUnverified(ctx, request.Email)
 	if user == nil {
 		shouldProceed := false
@@ -1640,15 +1664,19 @@ func (s *Service) logInVerifyMFA(ctx context.Context, user *User, request AuthUs
 }
 
 // TokenByAPIKey authenticates User by API Key and returns session token.
+// Keys restricted to scopes can't be exchanged for a session token.
 func (s *Service) TokenByAPIKey(ctx context.Context, userAgent string, ip string, apiKey string) (response *TokenInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	userID, _, err := s.restKeys.GetUserAndExpirationFromKey(ctx, apiKey)
+	info, err := s.restKeys.GetKeyInfo(ctx, apiKey)
 	if err != nil {
 		return nil, ErrUnauthorized.New(apiKeyCredentialsErrMsg)
 	}
+	if len(info.Scopes) > 0 {
+		return nil, ErrForbidden.New("scoped rest keys can't be exchanged for a session token")
+	}
 
-	user, err := s.store.Users().Get(ctx, userID)
+	user, err := s.store.Users().Get(ctx, info.UserID)
 	if err != nil {
 		return nil, Error.New(failedToRetrieveUserErrMsg)
 	}
@@ -1820,6 +1848,10 @@ func (s *Service) DeleteAccount(ctx context.Context, step AccountActionStep, dat
 		return Error.Wrap(err)
 	}
 
+	if err := checkNotImpersonated(ctx); err != nil {
+		return err
+	}
+
 	if user.Status == LegalHold {
 		return ErrForbidden.New("account can't be deleted")
 	}
@@ -1877,6 +1909,10 @@ func (s *Service) ChangeEmail(ctx context.Context, step AccountActionStep, data
 		return Error.Wrap(err)
 	}
 
+	if err := checkNotImpersonated(ctx); err != nil {
+		return err
+	}
+
 	if user.LoginLockoutExpiration.After(s.nowFn()) {
 		mon.Counter("change_email_locked_out").Inc(1) //mon:locked
 		s.auditLog(ctx, "change email: failed account locked out", &user.ID, user.Email)
@@ -2475,6 +2511,10 @@ func (s *Service) ChangePassword(ctx context.Context, pass, newPass string, sess
 		return Error.Wrap(err)
 	}
 
+	if err := checkNotImpersonated(ctx); err != nil {
+		return err
+	}
+
 	err = bcrypt.CompareHashAndPassword(user.PasswordHash, []byte(pass))
 	if err != nil {
 		return ErrChangePassword.New(changePasswordErrMsg)
@@ -3034,6 +3074,12 @@ func (s *Service) UpdateProject(ctx context.Context, projectID uuid.UUID, update
 		if err != nil {
 			return nil, err
 		}
+		if userLimitChanged(project.UserSpecifiedStorageLimit, updatedProject.StorageLimit) ||
+			userLimitChanged(project.UserSpecifiedBandwidthLimit, updatedProject.BandwidthLimit) {
+			if err = s.checkNotFrozenByBudgetCap(ctx, project.ID); err != nil {
+				return nil, err
+			}
+		}
 		if updatedProject.StorageLimit != nil {
 			project.UserSpecifiedStorageLimit = updatedProject.StorageLimit
 		}
@@ -3074,6 +3120,11 @@ func (s *Service) UpdateUserSpecifiedLimits(ctx context.Context, projectID uuid.
 		return err
 	}
 
+	err = s.checkNotFrozenByBudgetCap(ctx, project.ID)
+	if err != nil {
+		return err
+	}
+
 	if updatedLimits.StorageLimit != nil {
 		limit := new(int64)
 		*limit = updatedLimits.StorageLimit.Int64()
@@ -3916,8 +3967,9 @@ func (s *Service) GetAPIKeys(ctx context.Context, reqProjectID uuid.UUID, cursor
 	return page, err
 }
 
-// CreateRESTKey creates a satellite rest key.
-func (s *Service) CreateRESTKey(ctx context.Context, expiration time.Duration) (apiKey string, expiresAt time.Time, err error) {
+// CreateRESTKey creates a satellite rest key restricted to the scopes.
+// A key without scopes can use all of them.
+func (s *Service) CreateRESTKey(ctx context.Context, expiration time.Duration, scopes ...RESTKeyScope) (apiKey string, expiresAt time.Time, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	user, err := s.getUserAndAuditLog(ctx, "create rest key")
@@ -3925,7 +3977,21 @@ func (s *Service) CreateRESTKey(ctx context.Context, expiration time.Duration) (
 		return "", time.Time{}, Error.Wrap(err)
 	}
 
-	apiKey, expiresAt, err = s.restKeys.Create(ctx, user.ID, expiration)
+	if err := checkNotImpersonated(ctx); err != nil {
+		return "", time.Time{}, err
+	}
+
+	for _, scope := range scopes {
+		valid := false
+		for _, known := range RESTKeyScopes {
+			valid = valid || scope == known
+		}
+		if !valid {
+			return "", time.Time{}, ErrValidation.New("invalid rest key scope %q", scope)
+		}
+	}
+
+	apiKey, expiresAt, err = s.restKeys.Create(ctx, user.ID, expiration, scopes...)
 	if err != nil {
 		return "", time.Time{}, Error.Wrap(err)
 	}
@@ -3936,11 +4002,19 @@ func (s *Service) CreateRESTKey(ctx context.Context, expiration time.Duration) (
 func (s *Service) RevokeRESTKey(ctx context.Context, apiKey string) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	_, err = s.getUserAndAuditLog(ctx, "revoke rest key")
+	user, err := s.getUserAndAuditLog(ctx, "revoke rest key")
 	if err != nil {
 		return Error.Wrap(err)
 	}
 
+	info, err := s.restKeys.GetKeyInfo(ctx, apiKey)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if info.UserID != user.ID {
+		return ErrUnauthorized.New(unauthorizedErrMsg)
+	}
+
 	err = s.restKeys.Revoke(ctx, apiKey)
 	if err != nil {
 		return Error.Wrap(err)
@@ -4079,6 +4153,124 @@ func (s *Service) GetBucketMetadata(ctx context.Context, projectID uuid.UUID) (l
 	return list, nil
 }
 
+// GetBucketStats returns the object statistics of a bucket, which are refreshed periodically by the tally.
+// projectID here may be Project.ID or Project.PublicID.
+func (s *Service) GetBucketStats(ctx context.Context, projectID uuid.UUID, bucketName string) (_ metabase.BucketStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get bucket stats", zap.String("projectID", projectID.String()), zap.String("bucketName", bucketName))
+	if err != nil {
+		return metabase.BucketStats{}, Error.Wrap(err)
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return metabase.BucketStats{}, ErrUnauthorized.Wrap(err)
+	}
+
+	stats, err := s.metabase.GetBucketStats(ctx, metabase.GetBucketStats{
+		BucketLocation: metabase.BucketLocation{
+			ProjectID:  isMember.project.ID,
+			BucketName: metabase.BucketName(bucketName),
+		},
+	})
+	if err != nil {
+		return metabase.BucketStats{}, Error.Wrap(err)
+	}
+
+	return stats, nil
+}
+
+// GetBucketLifecycle returns the lifecycle configuration of a bucket.
+// projectID here may be Project.ID or Project.PublicID.
+func (s *Service) GetBucketLifecycle(ctx context.Context, projectID uuid.UUID, bucketName string) (_ buckets.LifecycleConfiguration, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get bucket lifecycle", zap.String("projectID", projectID.String()), zap.String("bucketName", bucketName))
+	if err != nil {
+		return buckets.LifecycleConfiguration{}, Error.Wrap(err)
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return buckets.LifecycleConfiguration{}, ErrUnauthorized.Wrap(err)
+	}
+
+	config, err := s.buckets.GetBucketLifecycle(ctx, []byte(bucketName), isMember.project.ID)
+	if err != nil {
+		return buckets.LifecycleConfiguration{}, Error.Wrap(err)
+	}
+
+	return config, nil
+}
+
+// UpdateBucketLifecycle sets the lifecycle configuration of a bucket. An empty configuration removes it.
+// projectID here may be Project.ID or Project.PublicID.
+func (s *Service) UpdateBucketLifecycle(ctx context.Context, projectID uuid.UUID, bucketName string, config buckets.LifecycleConfiguration) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "update bucket lifecycle", zap.String("projectID", projectID.String()), zap.String("bucketName", bucketName))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return ErrUnauthorized.Wrap(err)
+	}
+
+	if err = config.Validate(); err != nil {
+		return ErrValidation.Wrap(err)
+	}
+
+	return Error.Wrap(s.buckets.SetBucketLifecycle(ctx, []byte(bucketName), isMember.project.ID, config))
+}
+
+// GetBucketCORS returns the CORS configuration of a bucket.
+// projectID here may be Project.ID or Project.PublicID.
+func (s *Service) GetBucketCORS(ctx context.Context, projectID uuid.UUID, bucketName string) (_ buckets.CORSConfiguration, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get bucket cors", zap.String("projectID", projectID.String()), zap.String("bucketName", bucketName))
+	if err != nil {
+		return buckets.CORSConfiguration{}, Error.Wrap(err)
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return buckets.CORSConfiguration{}, ErrUnauthorized.Wrap(err)
+	}
+
+	config, err := s.buckets.GetBucketCORS(ctx, []byte(bucketName), isMember.project.ID)
+	if err != nil {
+		return buckets.CORSConfiguration{}, Error.Wrap(err)
+	}
+
+	return config, nil
+}
+
+// UpdateBucketCORS sets the CORS configuration of a bucket. An empty configuration removes it.
+// projectID here may be Project.ID or Project.PublicID.
+func (s *Service) UpdateBucketCORS(ctx context.Context, projectID uuid.UUID, bucketName string, config buckets.CORSConfiguration) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "update bucket cors", zap.String("projectID", projectID.String()), zap.String("bucketName", bucketName))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return ErrUnauthorized.Wrap(err)
+	}
+
+	if err = config.Validate(); err != nil {
+		return ErrValidation.Wrap(err)
+	}
+
+	return Error.Wrap(s.buckets.SetBucketCORS(ctx, []byte(bucketName), isMember.project.ID, config))
+}
+
 // GetUsageReport retrieves usage rollups for every bucket of a single or all the user owned projects for a given period.
 func (s *Service) GetUsageReport(ctx context.Context, since, before time.Time, projectID uuid.UUID) ([]accounting.ProjectReportItem, error) {
 	var err error
@@ -4406,21 +4598,28 @@ func (s *Service) TokenAuth(ctx context.Context, token consoleauth.Token, authTi
 		return nil, err
 	}
 
+	if session.Status == consoleauth.WebappSessionStatusImpersonated {
+		ctx = WithImpersonation(ctx, sessionID)
+	}
+
 	return ctx, nil
 }
 
 // KeyAuth returns an authenticated context by api key.
-func (s *Service) KeyAuth(ctx context.Context, apikey string, authTime time.Time) (_ context.Context, err error) {
+func (s *Service) KeyAuth(ctx context.Context, apikey string, scope RESTKeyScope, authTime time.Time) (_ context.Context, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	ctx = consoleauth.WithAPIKey(ctx, []byte(apikey))
 
-	userID, exp, err := s.restKeys.GetUserAndExpirationFromKey(ctx, apikey)
+	info, err := s.restKeys.GetKeyInfo(ctx, apikey)
 	if err != nil {
 		return nil, err
 	}
+	if !info.Allows(scope) {
+		return nil, ErrForbidden.New("rest key is not allowed to access %q", scope)
+	}
 
-	ctx, err = s.authorize(ctx, userID, exp, authTime)
+	ctx, err = s.authorize(ctx, info.UserID, info.ExpiresAt, authTime)
 	if err != nil {
 		return nil, err
 	}
@@ -4970,6 +5169,10 @@ func (s *Service) RefreshSession(ctx context.Context, sessionID uuid.UUID) (expi
 		return time.Time{}, Error.Wrap(err)
 	}
 
+	if err := checkNotImpersonated(ctx); err != nil {
+		return time.Time{}, err
+	}
+
 	duration := time.Duration(s.config.Session.InactivityTimerDuration) * time.Second
 	settings, err := s.store.Users().GetSettings(ctx, user.ID)
 	if err != nil && !errs.Is(err, sql.ErrNoRows) {
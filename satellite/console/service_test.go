@@ -10,6 +10,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
 	"sort"
 	"strings"
 	"testing"
@@ -23,8 +25,10 @@ import (
 	"golang.org/x/crypto/bcrypt"
 
 	"storj.io/common/currency"
+	"storj.io/common/grant"
 	"storj.io/common/macaroon"
 	"storj.io/common/memory"
+	"storj.io/common/paths"
 	"storj.io/common/pb"
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
@@ -1054,6 +1058,75 @@ func TestService(t *testing.T) {
 				}
 			})
 
+			t.Run("BucketLifecycle", func(t *testing.T) {
+				list, err := sat.DB.Buckets().ListBuckets(ctx, up2Proj.ID, buckets.ListOptions{Direction: buckets.DirectionForward}, macaroon.AllowedBuckets{All: true})
+				require.NoError(t, err)
+				require.NotEmpty(t, list.Items)
+				bucketName := list.Items[0].Name
+
+				config := buckets.LifecycleConfiguration{
+					Rules: []buckets.LifecycleRule{{ID: "logs", Prefix: "logs/", ExpirationDays: 7}},
+				}
+
+				err = service.UpdateBucketLifecycle(userCtx2, up2Proj.ID, bucketName, buckets.LifecycleConfiguration{
+					Rules: []buckets.LifecycleRule{{ID: "logs", Prefix: "logs/"}},
+				})
+				require.True(t, console.ErrValidation.Has(err))
+
+				require.NoError(t, service.UpdateBucketLifecycle(userCtx2, up2Proj.ID, bucketName, config))
+
+				lifecycle, err := service.GetBucketLifecycle(userCtx2, up2Proj.PublicID, bucketName)
+				require.NoError(t, err)
+				require.Equal(t, config, lifecycle)
+
+				_, err = service.GetBucketLifecycle(userCtx2, up2Proj.ID, "unknown")
+				require.True(t, buckets.ErrBucketNotFound.Has(err))
+
+				// Accessing someone else buckets should not work
+				_, err = service.GetBucketLifecycle(userCtx1, up2Proj.ID, bucketName)
+				require.True(t, console.ErrUnauthorized.Has(err))
+				err = service.UpdateBucketLifecycle(userCtx1, up2Proj.ID, bucketName, buckets.LifecycleConfiguration{})
+				require.True(t, console.ErrUnauthorized.Has(err))
+
+				require.NoError(t, service.UpdateBucketLifecycle(userCtx2, up2Proj.ID, bucketName, buckets.LifecycleConfiguration{}))
+			})
+
+			t.Run("BucketCORS", func(t *testing.T) {
+				list, err := sat.DB.Buckets().ListBuckets(ctx, up2Proj.ID, buckets.ListOptions{Direction: buckets.DirectionForward}, macaroon.AllowedBuckets{All: true})
+				require.NoError(t, err)
+				require.NotEmpty(t, list.Items)
+				bucketName := list.Items[0].Name
+
+				config := buckets.CORSConfiguration{
+					Rules: []buckets.CORSRule{{
+						AllowedOrigins: []string{"https://app.example.com"},
+						AllowedMethods: []string{"GET", "HEAD"},
+					}},
+				}
+
+				err = service.UpdateBucketCORS(userCtx2, up2Proj.ID, bucketName, buckets.CORSConfiguration{
+					Rules: []buckets.CORSRule{{AllowedOrigins: []string{"app.example.com"}, AllowedMethods: []string{"GET"}}},
+				})
+				require.True(t, console.ErrValidation.Has(err))
+
+				require.NoError(t, service.UpdateBucketCORS(userCtx2, up2Proj.ID, bucketName, config))
+
+				cors, err := service.GetBucketCORS(userCtx2, up2Proj.PublicID, bucketName)
+				require.NoError(t, err)
+				require.Equal(t, config, cors)
+
+				_, err = service.GetBucketCORS(userCtx2, up2Proj.ID, "unknown")
+				require.True(t, buckets.ErrBucketNotFound.Has(err))
+
+				// Accessing someone else buckets should not work
+				_, err = service.GetBucketCORS(userCtx1, up2Proj.ID, bucketName)
+				require.True(t, console.ErrUnauthorized.Has(err))
+				err = service.UpdateBucketCORS(userCtx1, up2Proj.ID, bucketName, buckets.CORSConfiguration{})
+				require.True(t, console.ErrUnauthorized.Has(err))
+
+				require.NoError(t, service.UpdateBucketCORS(userCtx2, up2Proj.ID, bucketName, buckets.CORSConfiguration{}))
+			})
+
 			t.Run("DeleteAPIKeyByNameAndProjectID", func(t *testing.T) {
 				secret, err := macaroon.NewSecret()
 				require.NoError(t, err)
@@ -2847,6 +2920,37 @@ func TestRESTKeys(t *testing.T) {
 		nonexistent := testrand.UUID()
 		err = service.RevokeRESTKey(userCtx, nonexistent.String())
 		require.Error(t, err)
+
+		// test unknown scope
+		_, _, err = service.CreateRESTKey(userCtx, expires, "unknown")
+		require.True(t, console.ErrValidation.Has(err))
+
+		// test scoped key is only allowed to access its scopes
+		scopedKey, _, err := service.CreateRESTKey(userCtx, expires, console.RESTKeyScopeProjects)
+		require.NoError(t, err)
+
+		_, err = service.KeyAuth(ctx, scopedKey, console.RESTKeyScopeProjects, time.Now())
+		require.NoError(t, err)
+		_, err = service.KeyAuth(ctx, scopedKey, console.RESTKeyScopeAPIKeys, time.Now())
+		require.True(t, console.ErrForbidden.Has(err))
+
+		_, err = service.TokenByAPIKey(ctx, "", "127.0.0.1", scopedKey)
+		require.True(t, console.ErrForbidden.Has(err))
+
+		// test another user can't revoke the key
+		otherUser, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Other User",
+			Email:    "other@mail.test",
+		}, 1)
+		require.NoError(t, err)
+		otherCtx, err := sat.UserContext(ctx, otherUser.ID)
+		require.NoError(t, err)
+
+		err = service.RevokeRESTKey(otherCtx, scopedKey)
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		_, err = service.KeyAuth(ctx, scopedKey, console.RESTKeyScopeProjects, time.Now())
+		require.NoError(t, err)
 	})
 }
 
@@ -3373,6 +3477,117 @@ func TestSatelliteManagedProjectWithDisabledAndConfig(t *testing.T) {
 	})
 }
 
+func TestCreateObjectPreviewURL(t *testing.T) {
+	var registeredGrant string
+	authService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			AccessGrant string `json:"access_grant"`
+			Public      bool   `json:"public"`
+		}
+		if r.URL.Path != "/v1/access" || json.NewDecoder(r.Body).Decode(&request) != nil || !request.Public {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		registeredGrant = request.AccessGrant
+		_, _ = w.Write([]byte(`{"access_key_id":"accesskeyid","secret_key":"secret","endpoint":""}`))
+	}))
+	defer authService.Close()
+
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Console.SatelliteManagedEncryptionEnabled = true
+				config.Console.ObjectPreview.Enabled = true
+				config.Console.ObjectPreview.AuthServiceURL = authService.URL
+				config.Console.ObjectPreview.LinksharingURL = "https://link.example.test"
+				config.KeyManagement.KeyInfos = kms.KeyInfos{
+					Values: map[int]kms.KeyInfo{
+						1: {
+							SecretVersion: "secretversion1", SecretChecksum: 12345,
+						},
+					},
+				}
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		srv := sat.API.Console.Service
+		nodeURL := sat.NodeURL().String()
+
+		existingUser, _, err := srv.GetUserByEmailWithUnverified(ctx, planet.Uplinks[0].User[sat.ID()].Email)
+		require.NoError(t, err)
+
+		userCtx, err := sat.UserContext(ctx, existingUser.ID)
+		require.NoError(t, err)
+
+		project, err := srv.CreateProject(userCtx, console.UpsertProjectInfo{
+			Name: "Test Project",
+		})
+		require.NoError(t, err)
+
+		// previews aren't available when the passphrase isn't managed by the satellite.
+		_, err = srv.CreateObjectPreviewURL(userCtx, project.ID, nodeURL, "bucket", "image.png")
+		require.True(t, console.ErrForbidden.Has(err))
+
+		managedProject, err := srv.CreateProject(userCtx, console.UpsertProjectInfo{
+			Name:             "Test Project2",
+			ManagePassphrase: true,
+		})
+		require.NoError(t, err)
+
+		_, err = srv.CreateObjectPreviewURL(userCtx, managedProject.ID, nodeURL, "bucket", "")
+		require.True(t, console.ErrValidation.Has(err))
+
+		_, err = srv.CreateObjectPreviewURL(userCtx, managedProject.ID, nodeURL, "bucket", "some dir/")
+		require.True(t, console.ErrValidation.Has(err))
+
+		previewURL, err := srv.CreateObjectPreviewURL(userCtx, managedProject.ID, nodeURL, "bucket", "some dir/image.png")
+		require.NoError(t, err)
+		require.Equal(t, "https://link.example.test/raw/accesskeyid/bucket/some%20dir/image.png", previewURL)
+		require.NotEmpty(t, registeredGrant)
+
+		access, err := grant.ParseAccess(registeredGrant)
+		require.NoError(t, err)
+		require.Equal(t, nodeURL, access.SatelliteAddress)
+
+		// the grant only contains the key of the object.
+		require.Nil(t, access.EncAccess.Store.GetDefaultKey())
+		var storedPaths []string
+		require.NoError(t, access.EncAccess.Store.Iterate(func(bucket string, unenc paths.Unencrypted, enc paths.Encrypted, key storj.Key) error {
+			storedPaths = append(storedPaths, bucket+"/"+unenc.Raw())
+			return nil
+		}))
+		require.Equal(t, []string{"bucket/some dir/image.png"}, storedPaths)
+
+		// the grant is download only and restricted to the object.
+		mac, err := macaroon.ParseMacaroon(access.APIKey.SerializeRaw())
+		require.NoError(t, err)
+		caveats := mac.Caveats()
+		var caveat macaroon.Caveat
+		require.NoError(t, caveat.UnmarshalBinary(caveats[len(caveats)-1]))
+		require.False(t, caveat.DisallowReads)
+		require.True(t, caveat.DisallowWrites)
+		require.True(t, caveat.DisallowLists)
+		require.True(t, caveat.DisallowDeletes)
+		require.Len(t, caveat.AllowedPaths, 1)
+		require.Equal(t, "bucket", string(caveat.AllowedPaths[0].Bucket))
+
+		// the preview key is reused for the following previews.
+		keyNames, err := sat.DB.Console().APIKeys().GetAllNamesByProjectID(ctx, managedProject.ID)
+		require.NoError(t, err)
+		require.Len(t, keyNames, 1)
+		require.True(t, strings.HasPrefix(keyNames[0], sat.Config.Console.ObjectBrowserKeyNamePrefix))
+
+		_, err = srv.CreateObjectPreviewURL(userCtx, managedProject.ID, nodeURL, "bucket", "other.pdf")
+		require.NoError(t, err)
+
+		keyNames, err = sat.DB.Console().APIKeys().GetAllNamesByProjectID(ctx, managedProject.ID)
+		require.NoError(t, err)
+		require.Len(t, keyNames, 1)
+	})
+}
+
 func TestPaymentsWalletPayments(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
@@ -4329,3 +4544,236 @@ func TestDelayedBotFreeze(t *testing.T) {
 		require.Nil(t, event)
 	})
 }
+
+func TestAbuseReports(t *testing.T) {
+	actions := make(chan string, 10)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Action      string `json:"action"`
+			AccessKeyID string `json:"accessKeyId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		actions <- body.Action + ":" + body.AccessKeyID
+	}))
+	defer webhook.Close()
+
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Console.AbuseReports.Enabled = true
+				config.Console.AbuseReports.WebhookURL = webhook.URL
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		for _, request := range []console.AbuseReportRequest{
+			{Reason: "malware"},
+			{URL: "not a url", Reason: "malware"},
+			{AccessKeyID: "accesskey"},
+			{AccessKeyID: "accesskey", Reason: "malware", ReporterEmail: "invalid"},
+		} {
+			_, err := service.SubmitAbuseReport(ctx, request)
+			require.True(t, console.ErrValidation.Has(err), request)
+		}
+
+		report, err := service.SubmitAbuseReport(ctx, console.AbuseReportRequest{
+			URL:           "https://link.test/s/jwaohtj3dhixxfpzhwj522x7z3pb/bucket/key",
+			Reason:        "malware",
+			ReporterEmail: "reporter@mail.test",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "jwaohtj3dhixxfpzhwj522x7z3pb", report.AccessKeyID)
+		require.Equal(t, console.AbuseReportPending, report.Status)
+		require.Equal(t, "freeze:jwaohtj3dhixxfpzhwj522x7z3pb", <-actions)
+
+		reports := sat.DB.Console().AbuseReports()
+
+		pending, err := reports.ListByStatus(ctx, console.AbuseReportPending, 10)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		require.Equal(t, report.ID, pending[0].ID)
+		require.Equal(t, "reporter@mail.test", pending[0].ReporterEmail)
+
+		require.NoError(t, reports.UpdateStatus(ctx, report.ID, console.AbuseReportRejected, "admin@mail.test", time.Now()))
+
+		reviewed, err := reports.Get(ctx, report.ID)
+		require.NoError(t, err)
+		require.Equal(t, console.AbuseReportRejected, reviewed.Status)
+		require.Equal(t, "admin@mail.test", reviewed.ReviewedBy)
+		require.NotNil(t, reviewed.ReviewedAt)
+
+		pending, err = reports.ListByStatus(ctx, console.AbuseReportPending, 10)
+		require.NoError(t, err)
+		require.Empty(t, pending)
+
+		err = reports.UpdateStatus(ctx, report.ID, console.AbuseReportAccepted, "other@mail.test", time.Now())
+		require.True(t, console.ErrAbuseReportReviewed.Has(err), err)
+
+		reviewed, err = reports.Get(ctx, report.ID)
+		require.NoError(t, err)
+		require.Equal(t, console.AbuseReportRejected, reviewed.Status)
+		require.Equal(t, "admin@mail.test", reviewed.ReviewedBy)
+
+		err = reports.UpdateStatus(ctx, testrand.UUID(), console.AbuseReportAccepted, "admin@mail.test", time.Now())
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+type fakeDomainResolver struct {
+	cnames map[string]string
+	txts   map[string][]string
+}
+
+func (r *fakeDomainResolver) LookupCNAME(ctx context.Context, host string) (string, error) {
+	cname, ok := r.cnames[host]
+	if !ok {
+		return "", errs.New("no such host")
+	}
+	return cname, nil
+}
+
+func (r *fakeDomainResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	txt, ok := r.txts[name]
+	if !ok {
+		return nil, errs.New("no such host")
+	}
+	return txt, nil
+}
+
+func TestDomains(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, UplinkCount: 2,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Console.Domains.Enabled = true
+				config.Console.Domains.LinksharingHost = "link.test"
+				config.Console.Domains.MaxDomainsPerProject = 2
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		resolver := &fakeDomainResolver{cnames: map[string]string{}, txts: map[string][]string{}}
+		service.TestSetDomainResolver(resolver)
+
+		project := planet.Uplinks[0].Projects[0]
+		userCtx, err := sat.UserContext(ctx, project.Owner.ID)
+		require.NoError(t, err)
+
+		otherProject := planet.Uplinks[1].Projects[0]
+		otherCtx, err := sat.UserContext(ctx, otherProject.Owner.ID)
+		require.NoError(t, err)
+
+		for _, request := range []console.DomainRequest{
+			{Subdomain: "localhost", Prefix: "bucket", AccessID: "accesskey"},
+			{Subdomain: "127.0.0.1", Prefix: "bucket", AccessID: "accesskey"},
+			{Subdomain: "under_score.test", Prefix: "bucket", AccessID: "accesskey"},
+			{Subdomain: "files.example.test", AccessID: "accesskey"},
+			{Subdomain: "files.example.test", Prefix: "bucket"},
+		} {
+			_, err := service.CreateDomain(userCtx, project.ID, request)
+			require.True(t, console.ErrValidation.Has(err), request)
+		}
+
+		_, err = service.CreateDomain(otherCtx, project.ID, console.DomainRequest{Subdomain: "files.example.test", Prefix: "bucket", AccessID: "accesskey"})
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		domain, err := service.CreateDomain(userCtx, project.ID, console.DomainRequest{
+			Subdomain: "Files.Example.Test.",
+			Prefix:    "/bucket/public/",
+			AccessID:  "jwaohtj3dhixxfpzhwj522x7z3pb",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "files.example.test", domain.Subdomain)
+		require.Equal(t, "bucket/public", domain.Prefix)
+		require.Equal(t, console.DomainPendingVerification, domain.Status)
+		require.NotEmpty(t, domain.VerificationToken)
+
+		_, err = service.CreateDomain(otherCtx, otherProject.ID, console.DomainRequest{Subdomain: "files.example.test", Prefix: "bucket", AccessID: "accesskey"})
+		require.True(t, console.ErrConflict.Has(err))
+
+		_, err = service.CreateDomain(userCtx, project.ID, console.DomainRequest{Subdomain: "other.example.test", Prefix: "bucket", AccessID: "accesskey"})
+		require.NoError(t, err)
+		_, err = service.CreateDomain(userCtx, project.ID, console.DomainRequest{Subdomain: "third.example.test", Prefix: "bucket", AccessID: "accesskey"})
+		require.True(t, console.ErrConflict.Has(err))
+
+		domains, err := service.GetProjectDomains(userCtx, project.ID)
+		require.NoError(t, err)
+		require.Len(t, domains, 2)
+		require.Equal(t, "files.example.test", domains[0].Subdomain)
+
+		// an expired pending registration doesn't block other projects.
+		service.TestSetNow(func() time.Time {
+			return time.Now().Add(sat.Config.Console.Domains.PendingExpiration + time.Hour)
+		})
+		claimed, err := service.CreateDomain(otherCtx, otherProject.ID, console.DomainRequest{Subdomain: "other.example.test", Prefix: "bucket", AccessID: "accesskey"})
+		require.NoError(t, err)
+		require.Equal(t, otherProject.ID, claimed.ProjectID)
+		service.TestSetNow(time.Now)
+
+		domains, err = service.GetProjectDomains(userCtx, project.ID)
+		require.NoError(t, err)
+		require.Len(t, domains, 1)
+		require.Equal(t, "files.example.test", domains[0].Subdomain)
+		require.NoError(t, service.DeleteDomain(otherCtx, otherProject.ID, claimed.Subdomain))
+
+		_, err = service.VerifyDomain(otherCtx, otherProject.ID, domain.Subdomain)
+		require.True(t, console.ErrNoDomain.Has(err))
+
+		records := service.DomainDNSRecords(*domain)
+		require.Equal(t, "link.test", records.CNAMEValue)
+		require.Equal(t, "txt-files.example.test", records.TXTName)
+
+		// the records aren't published yet.
+		_, err = service.VerifyDomain(userCtx, project.ID, domain.Subdomain)
+		require.True(t, console.ErrConflict.Has(err))
+
+		resolver.cnames[records.CNAMEName] = "link.test."
+		resolver.txts[records.TXTName] = records.TXTValues[:2]
+		_, err = service.VerifyDomain(userCtx, project.ID, domain.Subdomain)
+		require.True(t, console.ErrConflict.Has(err))
+
+		resolver.txts[records.TXTName] = append([]string{"unrelated"}, records.TXTValues...)
+		verified, err := service.VerifyDomain(userCtx, project.ID, domain.Subdomain)
+		require.NoError(t, err)
+		require.Equal(t, console.DomainCertificatePending, verified.Status)
+
+		// a verified domain can't be registered by other projects, even after the expiration.
+		service.TestSetNow(func() time.Time {
+			return time.Now().Add(sat.Config.Console.Domains.PendingExpiration + time.Hour)
+		})
+		_, err = service.CreateDomain(otherCtx, otherProject.ID, console.DomainRequest{Subdomain: "files.example.test", Prefix: "bucket", AccessID: "accesskey"})
+		require.True(t, console.ErrConflict.Has(err))
+		service.TestSetNow(time.Now)
+
+		db := sat.DB.Console().Domains()
+
+		pending, err := db.ListByStatus(ctx, console.DomainCertificatePending, 10)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		require.Equal(t, domain.Subdomain, pending[0].Subdomain)
+		require.Equal(t, project.ID, pending[0].ProjectID)
+
+		require.NoError(t, db.UpdateStatus(ctx, domain.Subdomain, console.DomainCertificateFailed, "rate limited"))
+		failed, err := db.Get(ctx, domain.Subdomain)
+		require.NoError(t, err)
+		require.Equal(t, console.DomainCertificateFailed, failed.Status)
+		require.Equal(t, "rate limited", failed.StatusMessage)
+
+		err = db.UpdateStatus(ctx, "missing.example.test", console.DomainActive, "")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		require.True(t, console.ErrNoDomain.Has(service.DeleteDomain(otherCtx, otherProject.ID, domain.Subdomain)))
+		require.NoError(t, service.DeleteDomain(userCtx, project.ID, domain.Subdomain))
+
+		_, err = db.Get(ctx, domain.Subdomain)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
@@ -0,0 +1,360 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console/consoleauth"
+	"storj.io/storj/satellite/console/consoleauth/sso"
+)
+
+var (
+	// ErrSSORequired occurs when a user who must sign in with SSO signs in or signs up with a password.
+	ErrSSORequired = errs.Class("sso required")
+
+	// ErrSSOLogin occurs when a user can't be signed in with the identity of an SSO provider.
+	ErrSSOLogin = errs.Class("sso login")
+)
+
+const (
+	ssoRequiredErrMsg = "Your organization requires signing in with SSO"
+
+	// ssoMFATokenPurpose distinguishes the SSO MFA tokens from the other tokens
+	// signed by the service.
+	ssoMFATokenPurpose = "sso-mfa"
+	// ssoMFATokenDuration is how long the user has to enter the MFA passcode
+	// after signing in with the provider.
+	ssoMFATokenDuration = 5 * time.Minute
+)
+
+// ssoMFAClaims is the payload of a token which completes a sign in with SSO
+// after the MFA passcode of the user is verified.
+type ssoMFAClaims struct {
+	Purpose    string    `json:"purpose"`
+	UserID     uuid.UUID `json:"userId"`
+	Provider   string    `json:"provider"`
+	Expiration time.Time `json:"expires"`
+}
+
+// SSOIdentities exposes methods to manage the identities of the SSO providers linked to users.
+//
+// architecture: Database
+type SSOIdentities interface {
+	// Get returns the identity. It returns sql.ErrNoRows when it isn't linked to any user.
+	Get(ctx context.Context, provider, subject string) (*SSOIdentity, error)
+	// Insert links the identity to its user.
+	Insert(ctx context.Context, identity SSOIdentity) error
+	// Delete unlinks the identity.
+	Delete(ctx context.Context, provider, subject string) error
+}
+
+// SSOIdentity is an identity of an SSO provider linked to a user.
+type SSOIdentity struct {
+	Provider string
+	Subject  string
+	UserID   uuid.UUID
+	// Email is the email of the identity when it was linked.
+	Email     string
+	CreatedAt time.Time
+}
+
+// LoginWithSSO signs in the user linked to the identity and returns a session token.
+// When the user has MFA enabled, it returns an MFA token instead, which must be
+// exchanged for the session token with LoginWithSSOMFA.
+//
+// An unlinked identity is linked to the active user with the same email when
+// the provider is trusted for the domain of the email, and an account is
+// created for it when the signup policy allows its email. Only identities whose
+// email was verified by the provider are linked or provisioned.
+func (s *Service) LoginWithSSO(ctx context.Context, identity sso.Identity, ip, userAgent string) (_ *TokenInfo, mfaToken string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !s.config.SSO.Enabled {
+		return nil, "", ErrSSOLogin.New("sign in with SSO is disabled")
+	}
+
+	user, err := s.ssoUser(ctx, identity)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if user.Status != Active {
+		mon.Counter("sso_login_restricted").Inc(1)
+		s.auditLog(ctx, "sso login: failed user not active", &user.ID, user.Email, zap.String("provider", identity.Provider))
+		return nil, "", ErrLoginRestricted.New("")
+	}
+
+	if user.LoginLockoutExpiration.After(s.nowFn()) {
+		mon.Counter("sso_login_locked_out").Inc(1)
+		s.auditLog(ctx, "sso login: failed account locked out", &user.ID, user.Email, zap.String("provider", identity.Provider))
+		return nil, "", ErrLoginCredentials.New(credentialsErrMsg)
+	}
+
+	if user.MFAEnabled {
+		// the provider doesn't prove the second factor of the account.
+		mfaToken, err = s.createSSOMFAToken(user.ID, identity.Provider)
+		if err != nil {
+			return nil, "", Error.Wrap(err)
+		}
+		mon.Counter("sso_login_mfa_required").Inc(1)
+		return nil, mfaToken, nil
+	}
+
+	response, err := s.GenerateSessionToken(ctx, user.ID, user.Email, ip, userAgent, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	mon.Counter("sso_login_success").Inc(1)
+
+	return response, "", nil
+}
+
+// LoginWithSSOMFA completes the sign in with SSO of a user with MFA enabled and
+// returns a session token. The MFA token is returned by LoginWithSSO.
+func (s *Service) LoginWithSSOMFA(ctx context.Context, mfaToken, mfaPasscode, mfaRecoveryCode, ip, userAgent string) (_ *TokenInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	claims, err := s.parseSSOMFAToken(mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.Users().Get(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrLoginCredentials.New(credentialsErrMsg)
+		}
+		return nil, Error.Wrap(err)
+	}
+
+	if user.Status != Active {
+		mon.Counter("sso_login_restricted").Inc(1)
+		s.auditLog(ctx, "sso login: failed user not active", &user.ID, user.Email, zap.String("provider", claims.Provider))
+		return nil, ErrLoginRestricted.New("")
+	}
+
+	if user.LoginLockoutExpiration.After(s.nowFn()) {
+		mon.Counter("sso_login_locked_out").Inc(1)
+		s.auditLog(ctx, "sso login: failed account locked out", &user.ID, user.Email, zap.String("provider", claims.Provider))
+		return nil, ErrLoginCredentials.New(credentialsErrMsg)
+	}
+
+	if user.MFAEnabled {
+		err = s.logInVerifyMFA(ctx, user, AuthUser{
+			MFAPasscode:     mfaPasscode,
+			MFARecoveryCode: mfaRecoveryCode,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := s.GenerateSessionToken(ctx, user.ID, user.Email, ip, userAgent, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	mon.Counter("sso_login_success").Inc(1)
+
+	return response, nil
+}
+
+// createSSOMFAToken creates a signed token for completing the sign in with SSO of the user.
+func (s *Service) createSSOMFAToken(userID uuid.UUID, provider string) (string, error) {
+	payload, err := json.Marshal(ssoMFAClaims{
+		Purpose:    ssoMFATokenPurpose,
+		UserID:     userID,
+		Provider:   provider,
+		Expiration: s.nowFn().Add(ssoMFATokenDuration),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	token := consoleauth.Token{Payload: payload}
+	token.Signature, err = s.tokens.SignToken(token)
+	if err != nil {
+		return "", err
+	}
+	return token.String(), nil
+}
+
+// parseSSOMFAToken verifies the token created by createSSOMFAToken and returns its claims.
+func (s *Service) parseSSOMFAToken(mfaToken string) (_ ssoMFAClaims, err error) {
+	token, err := consoleauth.FromBase64URLString(mfaToken)
+	if err != nil {
+		return ssoMFAClaims{}, ErrTokenInvalid.Wrap(err)
+	}
+
+	valid, err := s.tokens.ValidateToken(token)
+	if err != nil {
+		return ssoMFAClaims{}, Error.Wrap(err)
+	}
+	if !valid {
+		return ssoMFAClaims{}, ErrTokenInvalid.New("incorrect signature")
+	}
+
+	var claims ssoMFAClaims
+	if err := json.Unmarshal(token.Payload, &claims); err != nil {
+		return ssoMFAClaims{}, ErrTokenInvalid.New("JSON decoder: %w", err)
+	}
+	if claims.Purpose != ssoMFATokenPurpose {
+		return ssoMFAClaims{}, ErrTokenInvalid.New("not an SSO MFA token")
+	}
+	if s.nowFn().After(claims.Expiration) {
+		return ssoMFAClaims{}, ErrTokenExpiration.New("SSO MFA token expired")
+	}
+	return claims, nil
+}
+
+// ssoUser returns the user linked to the identity, linking or provisioning
+// one when the identity isn't linked yet.
+func (s *Service) ssoUser(ctx context.Context, identity sso.Identity) (_ *User, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	identities := s.store.SSOIdentities()
+
+	linked, err := identities.Get(ctx, identity.Provider, identity.Subject)
+	switch {
+	case err == nil:
+		user, err := s.store.Users().Get(ctx, linked.UserID)
+		if err == nil && user.Status != Deleted {
+			return user, nil
+		}
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, Error.Wrap(err)
+		}
+
+		// the linked user doesn't exist anymore, the identity is linked again below.
+		if err := identities.Delete(ctx, identity.Provider, identity.Subject); err != nil {
+			return nil, Error.Wrap(err)
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, Error.Wrap(err)
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		mon.Counter("sso_login_email_unverified").Inc(1)
+		return nil, ErrSSOLogin.New("the email of the identity isn't verified by the provider")
+	}
+
+	verified, unverified, err := s.store.Users().GetByEmailWithUnverified(ctx, identity.Email)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if verified != nil {
+		if !s.config.SSO.LinkAllowed(identity.Provider, identity.Email) {
+			return nil, ErrEmailUsed.New(emailUsedErrMsg)
+		}
+		if verified.MFAEnabled {
+			// linking would allow bypassing the second factor of the account.
+			s.auditLog(ctx, "sso login: failed mfa enabled", &verified.ID, verified.Email, zap.String("provider", identity.Provider))
+			return nil, ErrSSOLogin.New("sign in with your password to use an account with MFA enabled")
+		}
+
+		err = identities.Insert(ctx, SSOIdentity{
+			Provider: identity.Provider,
+			Subject:  identity.Subject,
+			UserID:   verified.ID,
+			Email:    identity.Email,
+		})
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		s.auditLog(ctx, "sso login: linked existing account", &verified.ID, verified.Email, zap.String("provider", identity.Provider))
+		return verified, nil
+	}
+
+	for _, other := range unverified {
+		if other.Status == PendingBotVerification || other.Status == LegalHold {
+			return nil, ErrLoginRestricted.New("")
+		}
+	}
+
+	if !s.config.SSO.SignupAllowed(identity.Email) {
+		mon.Counter("sso_signup_rejected").Inc(1)
+		return nil, ErrSSOLogin.New("signing up with SSO isn't allowed for this email")
+	}
+
+	return s.createSSOUser(ctx, identity)
+}
+
+// createSSOUser creates an active user for the identity and links the identity to it.
+func (s *Service) createSSOUser(ctx context.Context, identity sso.Identity) (_ *User, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// the user signs in with the provider, the random password can only be
+	// replaced by resetting it.
+	password := make([]byte, 32)
+	if _, err := rand.Read(password); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(hex.EncodeToString(password)), s.config.PasswordCost)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	userID, err := uuid.New()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	newUser := &User{
+		ID:                    userID,
+		Email:                 identity.Email,
+		FullName:              identity.Name,
+		PasswordHash:          hash,
+		Status:                Inactive,
+		ProjectLimit:          s.config.UsageLimits.Project.Free,
+		ProjectStorageLimit:   s.config.UsageLimits.Storage.Free.Int64(),
+		ProjectBandwidthLimit: s.config.UsageLimits.Bandwidth.Free.Int64(),
+		ProjectSegmentLimit:   s.config.UsageLimits.Segment.Free,
+	}
+	if s.config.FreeTrialDuration != 0 {
+		expiration := s.nowFn().Add(s.config.FreeTrialDuration)
+		newUser.TrialExpiration = &expiration
+	}
+
+	var user *User
+	err = s.store.WithTx(ctx, func(ctx context.Context, tx DBTx) error {
+		user, err = tx.Users().Insert(ctx, newUser)
+		if err != nil {
+			return err
+		}
+		return tx.SSOIdentities().Insert(ctx, SSOIdentity{
+			Provider: identity.Provider,
+			Subject:  identity.Subject,
+			UserID:   user.ID,
+			Email:    identity.Email,
+		})
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	s.auditLog(ctx, "create user", &user.ID, user.Email, zap.String("provider", identity.Provider))
+	mon.Counter("sso_signup_success").Inc(1)
+
+	// the provider verified the email, so the account doesn't need to be activated.
+	if err := s.SetAccountActive(ctx, user); err != nil {
+		return nil, err
+	}
+	user.Status = Active
+
+	return user, nil
+}
@@ -0,0 +1,198 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/consoleauth/sso"
+)
+
+func TestLoginWithSSO(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Console.SSO.Enabled = true
+				config.Console.SSO.AllowSignup = true
+				config.Console.SSO.SignupDomains = []string{"example.com"}
+				config.Console.SSO.LinkExistingAccounts = true
+				config.Console.SSO.LinkDomains = []string{"okta:other.example"}
+				config.Console.SSO.EnforcedDomains = []string{"corp.example"}
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		t.Run("signup", func(t *testing.T) {
+			identity := sso.Identity{
+				Provider:      "google",
+				Subject:       "new-user",
+				Email:         "new@example.com",
+				EmailVerified: true,
+				Name:          "New User",
+			}
+
+			_, _, err := service.LoginWithSSO(ctx, identity, "127.0.0.1", "")
+			require.NoError(t, err)
+
+			user, err := sat.DB.Console().Users().GetByEmail(ctx, identity.Email)
+			require.NoError(t, err)
+			require.Equal(t, console.Active, user.Status)
+			require.Equal(t, "New User", user.FullName)
+
+			linked, err := sat.DB.Console().SSOIdentities().Get(ctx, "google", "new-user")
+			require.NoError(t, err)
+			require.Equal(t, user.ID, linked.UserID)
+
+			// the linked identity signs in even after the email changed.
+			identity.Email = "changed@other.example"
+			_, _, err = service.LoginWithSSO(ctx, identity, "127.0.0.1", "")
+			require.NoError(t, err)
+		})
+
+		t.Run("signup policy", func(t *testing.T) {
+			_, _, err := service.LoginWithSSO(ctx, sso.Identity{
+				Provider:      "google",
+				Subject:       "other-domain",
+				Email:         "user@other.example",
+				EmailVerified: true,
+			}, "127.0.0.1", "")
+			require.True(t, console.ErrSSOLogin.Has(err), err)
+
+			_, _, err = service.LoginWithSSO(ctx, sso.Identity{
+				Provider: "google",
+				Subject:  "unverified",
+				Email:    "unverified@example.com",
+			}, "127.0.0.1", "")
+			require.True(t, console.ErrSSOLogin.Has(err), err)
+		})
+
+		t.Run("link existing account", func(t *testing.T) {
+			user, err := sat.AddUser(ctx, console.CreateUser{
+				FullName: "Existing User",
+				Email:    "existing@other.example",
+			}, 1)
+			require.NoError(t, err)
+
+			// only the providers trusted for the domain of the email are linked.
+			_, _, err = service.LoginWithSSO(ctx, sso.Identity{
+				Provider:      "google",
+				Subject:       "existing",
+				Email:         user.Email,
+				EmailVerified: true,
+			}, "127.0.0.1", "")
+			require.True(t, console.ErrEmailUsed.Has(err), err)
+
+			_, _, err = service.LoginWithSSO(ctx, sso.Identity{
+				Provider:      "okta",
+				Subject:       "existing",
+				Email:         user.Email,
+				EmailVerified: true,
+			}, "127.0.0.1", "")
+			require.NoError(t, err)
+
+			linked, err := sat.DB.Console().SSOIdentities().Get(ctx, "okta", "existing")
+			require.NoError(t, err)
+			require.Equal(t, user.ID, linked.UserID)
+		})
+
+		t.Run("locked out", func(t *testing.T) {
+			identity := sso.Identity{
+				Provider:      "google",
+				Subject:       "locked-user",
+				Email:         "locked@example.com",
+				EmailVerified: true,
+			}
+			_, _, err := service.LoginWithSSO(ctx, identity, "127.0.0.1", "")
+			require.NoError(t, err)
+
+			user, err := sat.DB.Console().Users().GetByEmail(ctx, identity.Email)
+			require.NoError(t, err)
+
+			lockoutExpiration := time.Now().Add(time.Hour)
+			lockoutExpirationPtr := &lockoutExpiration
+			require.NoError(t, sat.DB.Console().Users().Update(ctx, user.ID, console.UpdateUserRequest{
+				LoginLockoutExpiration: &lockoutExpirationPtr,
+			}))
+
+			_, _, err = service.LoginWithSSO(ctx, identity, "127.0.0.1", "")
+			require.True(t, console.ErrLoginCredentials.Has(err), err)
+		})
+
+		t.Run("mfa", func(t *testing.T) {
+			identity := sso.Identity{
+				Provider:      "google",
+				Subject:       "mfa-user",
+				Email:         "mfa@example.com",
+				EmailVerified: true,
+			}
+			_, _, err := service.LoginWithSSO(ctx, identity, "127.0.0.1", "")
+			require.NoError(t, err)
+
+			user, err := sat.DB.Console().Users().GetByEmail(ctx, identity.Email)
+			require.NoError(t, err)
+
+			userCtx, err := sat.UserContext(ctx, user.ID)
+			require.NoError(t, err)
+			key, err := service.ResetMFASecretKey(userCtx)
+			require.NoError(t, err)
+
+			userCtx, err = sat.UserContext(ctx, user.ID)
+			require.NoError(t, err)
+			now := time.Now()
+			passcode, err := console.NewMFAPasscode(key, now)
+			require.NoError(t, err)
+			require.NoError(t, service.EnableUserMFA(userCtx, passcode, now))
+
+			// the provider doesn't replace the second factor.
+			tokenInfo, mfaToken, err := service.LoginWithSSO(ctx, identity, "127.0.0.1", "")
+			require.NoError(t, err)
+			require.Nil(t, tokenInfo)
+			require.NotEmpty(t, mfaToken)
+
+			_, err = service.LoginWithSSOMFA(ctx, mfaToken, "", "", "127.0.0.1", "")
+			require.True(t, console.ErrMFAMissing.Has(err), err)
+
+			_, err = service.LoginWithSSOMFA(ctx, mfaToken, "000000", "", "127.0.0.1", "")
+			require.True(t, console.ErrMFAPasscode.Has(err), err)
+
+			_, err = service.LoginWithSSOMFA(ctx, mfaToken+"x", passcode, "", "127.0.0.1", "")
+			require.True(t, console.ErrTokenInvalid.Has(err), err)
+
+			passcode, err = console.NewMFAPasscode(key, time.Now())
+			require.NoError(t, err)
+			tokenInfo, err = service.LoginWithSSOMFA(ctx, mfaToken, passcode, "", "127.0.0.1", "")
+			require.NoError(t, err)
+			require.NotNil(t, tokenInfo)
+
+			// other tokens signed by the service aren't accepted.
+			activationToken, err := service.GenerateActivationToken(ctx, user.ID, user.Email)
+			require.NoError(t, err)
+			_, err = service.LoginWithSSOMFA(ctx, activationToken, passcode, "", "127.0.0.1", "")
+			require.True(t, console.ErrTokenInvalid.Has(err), err)
+		})
+
+		t.Run("enforced domains", func(t *testing.T) {
+			_, err := service.Token(ctx, console.AuthUser{Email: "enforced@corp.example", Password: "password123"})
+			require.True(t, console.ErrSSORequired.Has(err), err)
+
+			_, err = service.CreateUser(ctx, console.CreateUser{
+				FullName: "Another User",
+				Email:    "another@corp.example",
+				Password: "password123",
+			}, console.RegistrationSecret{})
+			require.True(t, console.ErrSSORequired.Has(err), err)
+		})
+	})
+}
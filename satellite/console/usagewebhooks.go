@@ -0,0 +1,247 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+)
+
+// ErrNoUsageWebhook is error type that occurs when there is no usage webhook found.
+var ErrNoUsageWebhook = errs.Class("no usage webhook found")
+
+// UsageWebhooksConfig contains configurations for project usage webhooks.
+type UsageWebhooksConfig struct {
+	Enabled       bool `help:"whether users can subscribe webhooks to the daily usage of their projects" default:"false"`
+	MaxPerProject int  `help:"maximum number of usage webhooks per project" default:"3"`
+	AllowHTTP     bool `help:"whether usage webhooks may use plain http URLs" default:"false" testDefault:"true"`
+
+	AllowPrivateAddresses bool `help:"whether usage webhook URLs may point to loopback, private or link-local addresses" default:"false" testDefault:"true"`
+}
+
+// ProjectUsageWebhooks exposes methods to manage project usage webhooks.
+//
+// architecture: Database
+type ProjectUsageWebhooks interface {
+	// Insert inserts a new webhook.
+	Insert(ctx context.Context, webhook ProjectUsageWebhook) error
+	// Get returns the webhook of the project. It returns sql.ErrNoRows when it doesn't exist.
+	Get(ctx context.Context, projectID, id uuid.UUID) (*ProjectUsageWebhook, error)
+	// ListByProjectID returns the webhooks of the project.
+	ListByProjectID(ctx context.Context, projectID uuid.UUID) ([]ProjectUsageWebhook, error)
+	// List returns the webhooks after the cursor, ordered by project ID and ID.
+	List(ctx context.Context, cursor ProjectUsageWebhookCursor, limit int) ([]ProjectUsageWebhook, error)
+	// UpdateLastSentDay updates the last day whose usage was delivered by the webhook.
+	UpdateLastSentDay(ctx context.Context, projectID, id uuid.UUID, day time.Time) error
+	// Delete deletes the webhook of the project.
+	Delete(ctx context.Context, projectID, id uuid.UUID) error
+}
+
+// ProjectUsageWebhookCursor is the position to continue listing webhooks from.
+type ProjectUsageWebhookCursor struct {
+	ProjectID uuid.UUID
+	ID        uuid.UUID
+}
+
+// ProjectUsageWebhook is an endpoint the daily usage summaries of a project are pushed to.
+type ProjectUsageWebhook struct {
+	ID        uuid.UUID `json:"id"`
+	ProjectID uuid.UUID `json:"-"`
+	URL       string    `json:"url"`
+	// Secret is the key the summaries are signed with using HMAC-SHA256.
+	Secret    []byte    `json:"-"`
+	CreatedBy uuid.UUID `json:"-"`
+	// LastSentDay is the last day whose usage summary was delivered successfully.
+	LastSentDay *time.Time `json:"lastSentDay"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// CreatedProjectUsageWebhook is a newly created webhook together with its secret,
+// which is only revealed on creation.
+type CreatedProjectUsageWebhook struct {
+	ProjectUsageWebhook
+	Secret string `json:"secret"`
+}
+
+// CreateProjectUsageWebhook subscribes a webhook to the daily usage of the project.
+// projectID here may be project.PublicID or project.ID.
+func (s *Service) CreateProjectUsageWebhook(ctx context.Context, projectID uuid.UUID, webhookURL string) (_ *CreatedProjectUsageWebhook, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "create project usage webhook", zap.String("projectID", projectID.String()), zap.String("url", webhookURL))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if !s.config.UsageWebhooks.Enabled {
+		return nil, ErrForbidden.New("usage webhooks are not enabled")
+	}
+
+	_, project, err := s.isProjectOwner(ctx, user.ID, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	webhookURL = strings.TrimSpace(webhookURL)
+	if err := s.validateUsageWebhookURL(webhookURL); err != nil {
+		return nil, err
+	}
+
+	webhooks := s.store.ProjectUsageWebhooks()
+
+	existing, err := webhooks.ListByProjectID(ctx, project.ID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if len(existing) >= s.config.UsageWebhooks.MaxPerProject {
+		return nil, ErrConflict.New("project can have at most %d usage webhooks", s.config.UsageWebhooks.MaxPerProject)
+	}
+
+	id, err := uuid.New()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	webhook := ProjectUsageWebhook{
+		ID:        id,
+		ProjectID: project.ID,
+		URL:       webhookURL,
+		Secret:    secret,
+		CreatedBy: user.ID,
+		CreatedAt: s.nowFn(),
+	}
+	if err := webhooks.Insert(ctx, webhook); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return &CreatedProjectUsageWebhook{
+		ProjectUsageWebhook: webhook,
+		Secret:              hex.EncodeToString(secret),
+	}, nil
+}
+
+// GetProjectUsageWebhooks returns the usage webhooks of the project.
+// projectID here may be project.PublicID or project.ID.
+func (s *Service) GetProjectUsageWebhooks(ctx context.Context, projectID uuid.UUID) (_ []ProjectUsageWebhook, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get project usage webhooks", zap.String("projectID", projectID.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	isMember, err := s.isProjectMember(ctx, user.ID, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	webhooks, err := s.store.ProjectUsageWebhooks().ListByProjectID(ctx, isMember.project.ID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return webhooks, nil
+}
+
+// DeleteProjectUsageWebhook unsubscribes a webhook from the usage of the project.
+// projectID here may be project.PublicID or project.ID.
+func (s *Service) DeleteProjectUsageWebhook(ctx context.Context, projectID, webhookID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "delete project usage webhook", zap.String("projectID", projectID.String()), zap.String("webhookID", webhookID.String()))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	_, project, err := s.isProjectOwner(ctx, user.ID, projectID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	webhooks := s.store.ProjectUsageWebhooks()
+
+	_, err = webhooks.Get(ctx, project.ID, webhookID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNoUsageWebhook.New("%s", webhookID)
+		}
+		return Error.Wrap(err)
+	}
+
+	return Error.Wrap(webhooks.Delete(ctx, project.ID, webhookID))
+}
+
+// validateUsageWebhookURL checks whether the usage summaries can be posted to the URL.
+func (s *Service) validateUsageWebhookURL(webhookURL string) error {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || parsed.Host == "" {
+		return ErrValidation.New("invalid webhook URL")
+	}
+
+	switch parsed.Scheme {
+	case "https":
+	case "http":
+		if !s.config.UsageWebhooks.AllowHTTP {
+			return ErrValidation.New("webhook URL must use https")
+		}
+	default:
+		return ErrValidation.New("webhook URL must use https")
+	}
+
+	if parsed.User != nil {
+		return ErrValidation.New("webhook URL must not contain credentials")
+	}
+
+	// host names are checked again when the chore connects to the resolved
+	// addresses, this only rejects the obvious cases early.
+	if !s.config.UsageWebhooks.AllowPrivateAddresses {
+		host := strings.ToLower(strings.TrimSuffix(parsed.Hostname(), "."))
+		if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+			return ErrValidation.New("webhook URL must use a public address")
+		}
+		if ip := net.ParseIP(host); ip != nil && !IsPublicUsageWebhookIP(ip) {
+			return ErrValidation.New("webhook URL must use a public address")
+		}
+	}
+	return nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, which isn't covered by
+// net.IP.IsPrivate.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsPublicUsageWebhookIP returns whether usage summaries may be posted to the
+// IP. Loopback, private, link-local (including cloud metadata services),
+// multicast and unspecified addresses are rejected, so customers can't make
+// the satellite send requests to internal services.
+func IsPublicUsageWebhookIP(ip net.IP) bool {
+	switch {
+	case ip.IsLoopback(), ip.IsPrivate(), ip.IsUnspecified(),
+		ip.IsLinkLocalUnicast(), ip.IsLinkLocalMulticast(),
+		ip.IsInterfaceLocalMulticast(), ip.IsMulticast():
+		return false
+	case sharedAddressSpace.Contains(ip):
+		return false
+	case ip.To4() != nil && ip.To4()[0] == 0:
+		// 0.0.0.0/8 reaches the local host on some systems.
+		return false
+	}
+	return true
+}
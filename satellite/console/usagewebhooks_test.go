@@ -0,0 +1,27 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/satellite/console"
+)
+
+func TestIsPublicUsageWebhookIP(t *testing.T) {
+	for _, address := range []string{"8.8.8.8", "100.128.0.1", "2001:4860:4860::8888"} {
+		require.True(t, console.IsPublicUsageWebhookIP(net.ParseIP(address)), address)
+	}
+
+	for _, address := range []string{
+		"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1",
+		"169.254.169.254", "100.64.0.1", "0.0.0.0", "0.1.2.3", "224.0.0.1",
+		"::1", "::", "fd00::1", "fe80::1", "::ffff:127.0.0.1", "::ffff:169.254.169.254",
+	} {
+		require.False(t, console.IsPublicUsageWebhookIP(net.ParseIP(address)), address)
+	}
+}
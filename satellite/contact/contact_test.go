@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"storj.io/common/identity/testidentity"
 	"storj.io/common/nodetag"
@@ -21,6 +22,9 @@ import (
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	satcontact "storj.io/storj/satellite/contact"
+	"storj.io/storj/satellite/nodeselection"
 	"storj.io/storj/storagenode"
 	"storj.io/storj/storagenode/contact"
 )
@@ -106,6 +110,58 @@ func TestSatelliteContactEndpoint_QUIC_Unreachable(t *testing.T) {
 	})
 }
 
+func TestSatelliteContactEndpoint_Reachability(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Contact.RecordReachability = true
+			},
+			StorageNode: func(index int, config *storagenode.Config) {
+				config.Server.DisableQUIC = true
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		nodeInfo := planet.StorageNodes[0].Contact.Service.Local()
+		ident := planet.StorageNodes[0].Identity
+
+		peer := rpcpeer.Peer{
+			Addr: &net.TCPAddr{
+				IP:   net.ParseIP(nodeInfo.Address),
+				Port: 5,
+			},
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{ident.Leaf, ident.CA},
+			},
+		}
+		peerCtx := rpcpeer.NewContext(ctx, &peer)
+		checkIn := func() nodeselection.NodeTag {
+			resp, err := planet.Satellites[0].Contact.Endpoint.CheckIn(peerCtx, &pb.CheckInRequest{
+				Address:  nodeInfo.Address,
+				Version:  &nodeInfo.Version,
+				Capacity: &nodeInfo.Capacity,
+				Operator: &nodeInfo.Operator,
+			})
+			require.NoError(t, err)
+			require.True(t, resp.PingNodeSuccess)
+			require.False(t, resp.PingNodeSuccessQuic)
+
+			tags, err := planet.Satellites[0].DB.OverlayCache().GetNodeTags(ctx, ident.ID)
+			require.NoError(t, err)
+
+			tag, err := tags.FindBySignerAndName(planet.Satellites[0].ID(), satcontact.ReachabilityTag)
+			require.NoError(t, err)
+			require.Equal(t, string(satcontact.ReachabilityTCPOnly), string(tag.Value))
+			return tag
+		}
+
+		first := checkIn()
+		// the tag isn't rewritten when the classification doesn't change.
+		second := checkIn()
+		require.True(t, first.SignedAt.Equal(second.SignedAt))
+	})
+}
+
 func TestSatellitePingBack_Failure(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
@@ -104,6 +104,14 @@ func (endpoint *Endpoint) CheckIn(ctx context.Context, req *pb.CheckInRequest) (
 		return nil, endpoint.checkPingRPCErr(err, nodeurl)
 	}
 
+	reachability := classifyReachability(pingNodeSuccess, pingNodeSuccessQUIC)
+	if endpoint.service.recordReachability {
+		err = endpoint.service.updateReachability(ctx, nodeID, reachability)
+		if err != nil {
+			endpoint.log.Info("failed to update node reachability", zap.String("node address", req.Address), zap.Stringer("Node ID", nodeID), zap.Error(err))
+		}
+	}
+
 	// check wallet features
 	if req.Operator != nil {
 		if err := nodeoperator.DefaultWalletFeaturesValidation.Validate(req.Operator.WalletFeatures); err != nil {
@@ -144,7 +152,7 @@ func (endpoint *Endpoint) CheckIn(ctx context.Context, req *pb.CheckInRequest) (
 		return nil, rpcstatus.Error(rpcstatus.Internal, Error.Wrap(err).Error())
 	}
 
-	endpoint.log.Debug("checking in", zap.Stringer("Node ID", nodeID), zap.String("node addr", req.Address), zap.Bool("ping node success", pingNodeSuccess), zap.String("ping node err msg", pingErrorMessage))
+	endpoint.log.Debug("checking in", zap.Stringer("Node ID", nodeID), zap.String("node addr", req.Address), zap.Bool("ping node success", pingNodeSuccess), zap.String("reachability", string(reachability)), zap.String("ping node err msg", pingErrorMessage))
 	return &pb.CheckInResponse{
 		PingNodeSuccess:     pingNodeSuccess,
 		PingNodeSuccessQuic: pingNodeSuccessQUIC,
@@ -172,6 +180,7 @@ func emitEventkitEvent(ctx context.Context, req *pb.CheckInRequest, pingNodeTCPS
 		eventkit.String("country", nodeInfo.CountryCode.String()),
 		eventkit.Bool("ping-tpc-success", pingNodeTCPSuccess),
 		eventkit.Bool("ping-quic-success", pingNodeQUICSuccess),
+		eventkit.String("reachability", string(classifyReachability(pingNodeTCPSuccess, pingNodeQUICSuccess))),
 	}
 
 	if nodeInfo.Capacity != nil {
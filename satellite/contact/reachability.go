@@ -0,0 +1,70 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package contact
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/nodeselection"
+)
+
+// ReachabilityTag is the name of the node tag which holds the reachability of the node.
+// The tag is signed by the satellite, hence it can be used in placement rules
+// as tag("<satellite-id>", "reachability", "direct"), or to prefer directly reachable
+// nodes with prefer(tag("<satellite-id>", "reachability", "direct"), random()).
+//
+// Only the classification is implemented. Nodes which are unreachable, e.g. behind
+// CGNAT, still can't serve traffic, as NAT hole punching requires support from the
+// storage nodes and the uplinks.
+const ReachabilityTag = "reachability"
+
+// Reachability classifies how the node can be reached by the satellite.
+type Reachability string
+
+const (
+	// ReachabilityDirect is used for nodes which are reachable with both TCP and QUIC.
+	ReachabilityDirect Reachability = "direct"
+	// ReachabilityTCPOnly is used for nodes which are reachable only with TCP, e.g. UDP is blocked by a firewall or NAT.
+	ReachabilityTCPOnly Reachability = "tcp"
+	// ReachabilityUnreachable is used for nodes which can't be reached, e.g. they are behind CGNAT.
+	ReachabilityUnreachable Reachability = "unreachable"
+)
+
+// classifyReachability returns the reachability of the node based on the ping back results.
+func classifyReachability(pingNodeSuccess, pingNodeSuccessQUIC bool) Reachability {
+	switch {
+	case pingNodeSuccess && pingNodeSuccessQUIC:
+		return ReachabilityDirect
+	case pingNodeSuccess:
+		return ReachabilityTCPOnly
+	default:
+		return ReachabilityUnreachable
+	}
+}
+
+// updateReachability stores the reachability of the node as a node tag signed by the satellite.
+// The tag is only written when the classification changed since the previous check-in.
+func (service *Service) updateReachability(ctx context.Context, nodeID storj.NodeID, reachability Reachability) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	tags, err := service.overlay.GetNodeTags(ctx, nodeID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if tag, err := tags.FindBySignerAndName(service.self, ReachabilityTag); err == nil && string(tag.Value) == string(reachability) {
+		return nil
+	}
+
+	return Error.Wrap(service.overlay.UpdateNodeTags(ctx, nodeselection.NodeTags{
+		{
+			NodeID:   nodeID,
+			Name:     ReachabilityTag,
+			Value:    []byte(reachability),
+			SignedAt: time.Now(),
+			Signer:   service.self,
+		},
+	}))
+}
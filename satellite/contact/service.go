@@ -31,6 +31,8 @@ type Config struct {
 	RateLimitInterval  time.Duration `help:"the amount of time that should happen between contact attempts usually" releaseDefault:"10m0s" devDefault:"1ns"`
 	RateLimitBurst     int           `help:"the maximum burst size for the contact rate limit token bucket" releaseDefault:"2" devDefault:"1000"`
 	RateLimitCacheSize int           `help:"the number of nodes or addresses to keep token buckets for" default:"1000"`
+
+	RecordReachability bool `help:"whether to store the reachability of the nodes as node tags signed by the satellite, which can be used in placement rules" default:"false"`
 }
 
 // Service is the contact service between storage nodes and satellites.
@@ -39,7 +41,8 @@ type Config struct {
 //
 // architecture: Service
 type Service struct {
-	log *zap.Logger
+	log  *zap.Logger
+	self storj.NodeID
 
 	overlay *overlay.Service
 	peerIDs overlay.PeerIdentities
@@ -49,20 +52,24 @@ type Service struct {
 	idLimiter      *RateLimiter
 	allowPrivateIP bool
 
+	recordReachability bool
+
 	nodeTagAuthority nodetag.Authority
 }
 
 // NewService creates a new contact service.
-func NewService(log *zap.Logger, overlay *overlay.Service, peerIDs overlay.PeerIdentities, dialer rpc.Dialer, authority nodetag.Authority, config Config) *Service {
+func NewService(log *zap.Logger, self storj.NodeID, overlay *overlay.Service, peerIDs overlay.PeerIdentities, dialer rpc.Dialer, authority nodetag.Authority, config Config) *Service {
 	return &Service{
-		log:              log,
-		overlay:          overlay,
-		peerIDs:          peerIDs,
-		dialer:           dialer,
-		timeout:          config.Timeout,
-		idLimiter:        NewRateLimiter(config.RateLimitInterval, config.RateLimitBurst, config.RateLimitCacheSize),
-		allowPrivateIP:   config.AllowPrivateIP,
-		nodeTagAuthority: authority,
+		log:                log,
+		self:               self,
+		overlay:            overlay,
+		peerIDs:            peerIDs,
+		dialer:             dialer,
+		timeout:            config.Timeout,
+		idLimiter:          NewRateLimiter(config.RateLimitInterval, config.RateLimitBurst, config.RateLimitCacheSize),
+		allowPrivateIP:     config.AllowPrivateIP,
+		recordReachability: config.RecordReachability,
+		nodeTagAuthority:   authority,
 	}
 }
 
@@ -28,8 +28,12 @@ import (
 	"storj.io/storj/satellite/accounting/rollup"
 	"storj.io/storj/satellite/accounting/rolluparchive"
 	"storj.io/storj/satellite/accounting/tally"
+	"storj.io/storj/satellite/accounting/usagewebhook"
+	"storj.io/storj/satellite/accounting/warehouse"
 	"storj.io/storj/satellite/analytics"
 	"storj.io/storj/satellite/audit"
+	"storj.io/storj/satellite/bucketevents"
+	"storj.io/storj/satellite/canary"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/console/consoleauth"
 	"storj.io/storj/satellite/console/dbcleanup"
@@ -38,19 +42,25 @@ import (
 	"storj.io/storj/satellite/gc/sender"
 	"storj.io/storj/satellite/mailservice"
 	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/deferreddeletion"
+	"storj.io/storj/satellite/metabase/metadatarotation"
 	"storj.io/storj/satellite/metabase/zombiedeletion"
 	"storj.io/storj/satellite/metainfo/expireddeletion"
+	"storj.io/storj/satellite/metainfo/lifecycledeletion"
 	"storj.io/storj/satellite/nodeevents"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/overlay/offlinenodes"
+	"storj.io/storj/satellite/overlay/selectionfairness"
 	"storj.io/storj/satellite/overlay/straynodes"
 	"storj.io/storj/satellite/payments"
 	"storj.io/storj/satellite/payments/accountfreeze"
 	"storj.io/storj/satellite/payments/billing"
+	"storj.io/storj/satellite/payments/budgetcap"
 	"storj.io/storj/satellite/payments/storjscan"
 	"storj.io/storj/satellite/payments/stripe"
 	"storj.io/storj/satellite/repair/repairer"
 	"storj.io/storj/satellite/reputation"
+	"storj.io/storj/satellite/sla"
 )
 
 // Core is the satellite core process that runs chores.
@@ -92,6 +102,9 @@ type Core struct {
 		Service           *overlay.Service
 		OfflineNodeEmails *offlinenodes.Chore
 		DQStrayNodes      *straynodes.Chore
+		SelectionFairness *selectionfairness.Chore
+		UptimeHistory     *overlay.UptimeHistoryCleanup
+		UptimeOffline     *overlay.UptimeHistoryOffline
 	}
 
 	NodeEvents struct {
@@ -122,11 +135,29 @@ type Core struct {
 		Chore *zombiedeletion.Chore
 	}
 
+	DeferredDeletion struct {
+		Chore *deferreddeletion.Chore
+	}
+
+	MetadataRotation struct {
+		Chore *metadatarotation.Chore
+	}
+
+	LifecycleDeletion struct {
+		Chore *lifecycledeletion.Chore
+	}
+
+	BucketEvents struct {
+		Chore *bucketevents.Chore
+	}
+
 	Accounting struct {
 		Tally                 *tally.Service
 		Rollup                *rollup.Service
 		RollupArchiveChore    *rolluparchive.Chore
 		ProjectBWCleanupChore *projectbwcleanup.Chore
+		UsageWebhookChore     *usagewebhook.Chore
+		WarehouseExportChore  *warehouse.Chore
 	}
 
 	LiveAccounting struct {
@@ -137,6 +168,7 @@ type Core struct {
 		AccountFreeze    *accountfreeze.Chore
 		Accounts         payments.Accounts
 		BillingChore     *billing.Chore
+		BudgetCap        *budgetcap.Chore
 		StorjscanClient  *storjscan.Client
 		StorjscanService *storjscan.Service
 		StorjscanChore   *storjscan.Chore
@@ -153,6 +185,14 @@ type Core struct {
 	RepairQueueStat struct {
 		Chore *repairer.QueueStat
 	}
+
+	SLA struct {
+		Chore *sla.Chore
+	}
+
+	Canary struct {
+		Chore *canary.Chore
+	}
 }
 
 // New creates a new satellite.
@@ -270,6 +310,26 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 			Close: peer.Overlay.Service.Close,
 		})
 
+		if config.Overlay.UptimeHistory.Enabled {
+			peer.Overlay.UptimeHistory = overlay.NewUptimeHistoryCleanup(peer.Log.Named("overlay:uptime-history"), peer.Overlay.DB, config.Overlay.UptimeHistory)
+			peer.Services.Add(lifecycle.Item{
+				Name:  "overlay:uptime-history",
+				Run:   peer.Overlay.UptimeHistory.Run,
+				Close: peer.Overlay.UptimeHistory.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Overlay Uptime History Cleanup", peer.Overlay.UptimeHistory.Loop))
+
+			peer.Overlay.UptimeOffline = overlay.NewUptimeHistoryOffline(peer.Log.Named("overlay:uptime-offline"), peer.Overlay.DB, config.Overlay.UptimeHistory, config.Overlay.Node.OnlineWindow)
+			peer.Services.Add(lifecycle.Item{
+				Name:  "overlay:uptime-offline",
+				Run:   peer.Overlay.UptimeOffline.Run,
+				Close: peer.Overlay.UptimeOffline.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Overlay Uptime History Offline", peer.Overlay.UptimeOffline.Loop))
+		}
+
 		if config.Overlay.SendNodeEmails {
 			peer.Overlay.OfflineNodeEmails = offlinenodes.NewChore(log.Named("overlay:offline-node-emails"), peer.Mail.Service, peer.Overlay.Service, config.OfflineNodes)
 			peer.Services.Add(lifecycle.Item{
@@ -291,6 +351,24 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 			peer.Debug.Server.Panel.Add(
 				debug.Cycle("Overlay DQ Stray Nodes", peer.Overlay.DQStrayNodes.Loop))
 		}
+
+		if config.SelectionFairness.Enabled {
+			peer.Overlay.SelectionFairness = selectionfairness.NewChore(
+				peer.Log.Named("overlay:selection-fairness"),
+				peer.DB.SelectionFairness(),
+				peer.Overlay.DB,
+				peer.DB.StoragenodeAccounting(),
+				config.Overlay.Node.OnlineWindow,
+				config.SelectionFairness,
+			)
+			peer.Services.Add(lifecycle.Item{
+				Name:  "overlay:selection-fairness",
+				Run:   peer.Overlay.SelectionFairness.Run,
+				Close: peer.Overlay.SelectionFairness.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Overlay Selection Fairness", peer.Overlay.SelectionFairness.Loop))
+		}
 	}
 
 	{ // setup node events
@@ -322,6 +400,14 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 
 	{ // setup metainfo
 		peer.Metainfo.Metabase = metabaseDB
+		if config.BucketEvents.Enabled {
+			peer.Metainfo.Metabase.SetDeleteEventsFilter(
+				bucketevents.NewDestinationCache(peer.DB.BucketEvents(), config.BucketEvents))
+		}
+
+		if registry := peer.Metainfo.Metabase.QueryRegistry(); registry != nil {
+			peer.Debug.Server.Panel.Add(registry.DebugButtons())
+		}
 	}
 
 	{ // setup reputation
@@ -384,6 +470,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 			peer.Log.Named("core-zombie-deletion"),
 			config.ZombieDeletion,
 			peer.Metainfo.Metabase,
+			peer.DB.ZombieDeletion(),
 		)
 		peer.Services.Add(lifecycle.Item{
 			Name:  "zombiedeletion:chore",
@@ -394,6 +481,75 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 			debug.Cycle("Zombie Objects Chore", peer.ZombieDeletion.Chore.Loop))
 	}
 
+	{ // setup deferred segment deletion
+		peer.DeferredDeletion.Chore = deferreddeletion.NewChore(
+			peer.Log.Named("core-deferred-deletion"),
+			config.DeferredDeletion,
+			peer.Metainfo.Metabase,
+		)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "deferreddeletion:chore",
+			Run:   peer.DeferredDeletion.Chore.Run,
+			Close: peer.DeferredDeletion.Chore.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Deferred Segment Deletion Chore", peer.DeferredDeletion.Chore.Loop))
+	}
+
+	if config.BucketEvents.Enabled { // setup bucket delete events publishing
+		publisher, err := bucketevents.NewPublisher(config.BucketEvents)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
+		peer.BucketEvents.Chore = bucketevents.NewChore(
+			peer.Log.Named("core-bucket-events"),
+			peer.DB.BucketEvents(),
+			peer.Metainfo.Metabase,
+			peer.DB.Console().Projects(),
+			publisher,
+			config.BucketEvents,
+		)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "bucketevents:chore",
+			Run:   peer.BucketEvents.Chore.Run,
+			Close: peer.BucketEvents.Chore.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Bucket Delete Events Chore", peer.BucketEvents.Chore.Loop))
+	}
+
+	{ // setup metadata encryption key rotation
+		peer.MetadataRotation.Chore = metadatarotation.NewChore(
+			peer.Log.Named("core-metadata-rotation"),
+			config.MetadataRotation,
+			peer.Metainfo.Metabase,
+		)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "metadatarotation:chore",
+			Run:   peer.MetadataRotation.Chore.Run,
+			Close: peer.MetadataRotation.Chore.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Metadata Encryption Rotation Chore", peer.MetadataRotation.Chore.Loop))
+	}
+
+	{ // setup bucket lifecycle expiration
+		peer.LifecycleDeletion.Chore = lifecycledeletion.NewChore(
+			peer.Log.Named("core-lifecycle-deletion"),
+			config.LifecycleDeletion,
+			peer.DB.Buckets(),
+			peer.Metainfo.Metabase,
+		)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "lifecycledeletion:chore",
+			Run:   peer.LifecycleDeletion.Chore.Run,
+			Close: peer.LifecycleDeletion.Chore.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Bucket Lifecycle Deletion Chore", peer.LifecycleDeletion.Chore.Loop))
+	}
+
 	{ // setup accounting
 		peer.Accounting.Tally = tally.New(peer.Log.Named("accounting:tally"), peer.DB.StoragenodeAccounting(), peer.DB.ProjectAccounting(), peer.LiveAccounting.Cache, peer.Metainfo.Metabase, peer.DB.Buckets(), config.Tally)
 		peer.Services.Add(lifecycle.Item{
@@ -436,6 +592,33 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 		} else {
 			peer.Log.Named("rolluparchive").Info("disabled")
 		}
+
+		if config.UsageWebhook.Enabled {
+			peer.Accounting.UsageWebhookChore = usagewebhook.NewChore(peer.Log.Named("accounting:usage-webhook"), peer.DB.Console(), peer.DB.ProjectAccounting(), config.UsageWebhook)
+			peer.Services.Add(lifecycle.Item{
+				Name:  "accounting:usage-webhook",
+				Run:   peer.Accounting.UsageWebhookChore.Run,
+				Close: peer.Accounting.UsageWebhookChore.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Accounting Usage Webhook", peer.Accounting.UsageWebhookChore.Loop))
+		}
+
+		if config.WarehouseExport.Enabled {
+			sink, err := warehouse.NewSink(config.WarehouseExport)
+			if err != nil {
+				return nil, errs.Combine(err, peer.Close())
+			}
+
+			peer.Accounting.WarehouseExportChore = warehouse.NewChore(peer.Log.Named("accounting:warehouse-export"), peer.DB.WarehouseExports(), sink, config.WarehouseExport)
+			peer.Services.Add(lifecycle.Item{
+				Name:  "accounting:warehouse-export",
+				Run:   peer.Accounting.WarehouseExportChore.Run,
+				Close: peer.Accounting.WarehouseExportChore.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Accounting Warehouse Export", peer.Accounting.WarehouseExportChore.Loop))
+		}
 	}
 
 	{ // setup analytics service
@@ -584,6 +767,26 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 		}
 	}
 
+	{ // setup project budget caps
+		if config.BudgetCap.Enabled {
+			peer.Payments.BudgetCap = budgetcap.NewChore(
+				peer.Log.Named("payments.budgetcap:chore"),
+				peer.DB.Console(),
+				peer.Payments.Accounts,
+				peer.Mail.Service,
+				config.BudgetCap,
+				config.Console.ExternalAddress,
+				config.Console.GeneralRequestURL,
+			)
+
+			peer.Services.Add(lifecycle.Item{
+				Name:  "budgetcap:chore",
+				Run:   peer.Payments.BudgetCap.Run,
+				Close: peer.Payments.BudgetCap.Close,
+			})
+		}
+	}
+
 	// setup console DB cleanup service
 	if config.ConsoleDBCleanup.Enabled {
 		peer.ConsoleDBCleanup.Chore = dbcleanup.NewChore(
@@ -611,12 +814,51 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 		}
 	}
 
+	{ // setup placement SLA tracking
+		if config.SLA.Enabled {
+			peer.SLA.Chore = sla.NewChore(
+				peer.Log.Named("sla:chore"),
+				peer.DB.SLA(),
+				peer.Overlay.Service,
+				peer.DB.RepairQueue(),
+				placement.SupportedPlacements(),
+				config.SLA,
+			)
+
+			peer.Services.Add(lifecycle.Item{
+				Name:  "sla:chore",
+				Run:   peer.SLA.Chore.Run,
+				Close: peer.SLA.Chore.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("SLA Chore", peer.SLA.Chore.Loop))
+		}
+	}
+
+	{ // setup canary objects
+		if config.Canary.Enabled {
+			peer.Canary.Chore = canary.NewChore(
+				peer.Log.Named("canary:chore"),
+				config.Canary,
+			)
+
+			peer.Services.Add(lifecycle.Item{
+				Name:  "canary:chore",
+				Run:   peer.Canary.Chore.Run,
+				Close: peer.Canary.Chore.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Canary Chore", peer.Canary.Chore.Loop))
+		}
+	}
+
 	{ // setup garbage collection
 		peer.GarbageCollection.Sender = sender.NewService(
 			peer.Log.Named("gc-sender"),
 			config.GarbageCollection,
 			peer.Dialer,
 			peer.Overlay.DB,
+			peer.Metainfo.Metabase,
 		)
 
 		peer.Services.Add(lifecycle.Item{
@@ -129,6 +129,7 @@ func NewGarbageCollection(log *zap.Logger, full *identity.FullIdentity, db DB,
 			config.GarbageCollection,
 			peer.Dialer,
 			peer.Overlay.DB,
+			metabaseDB,
 		)
 
 		peer.Services.Add(lifecycle.Item{
@@ -18,6 +18,14 @@ Package gc/sender reads the retain instructions from the bucket and sends
 them to the storage nodes. It is intended to run on a satellite connected
 to the live database.
 
+When garbage-collection.verify-segments-per-node is set, gc/sender also
+verifies the runs whose retain filters were sent at least
+garbage-collection.verify-delay ago. It samples segments of every node, which
+were created before the retain filter of the node, and checks with the Exists
+endpoint whether the node still stores their pieces. The safety report of
+the run is stored under the verified- prefix of the bucket, before the trash
+of the nodes expires.
+
 Should we ever delete all segments from the satellite's metainfo, then no
 bloom filters will be generated, because GC only considers NodeID's inside
 the segments table. There is also an explicit check that stops sending out
@@ -20,6 +20,7 @@ import (
 	"storj.io/common/sync2"
 	"storj.io/storj/satellite/gc/bloomfilter"
 	"storj.io/storj/satellite/internalpb"
+	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/uplink"
 	"storj.io/uplink/private/piecestore"
@@ -43,17 +44,22 @@ type Config struct {
 	AccessGrant string        `help:"Access to download the bloom filters. Needs read and write permission."`
 	Bucket      string        `help:"bucket where retain info is stored" default:"" testDefault:"gc-queue"`
 	ExpireIn    time.Duration `help:"Expiration of newly created objects in the bucket. These objects are under the prefix error-[timestamp] and store error messages." default:"336h"`
+
+	VerifySegmentsPerNode    int           `help:"the number of sampled pieces per node to check for erroneously trashed pieces after a garbage collection run, 0 disables the verification" default:"0"`
+	VerifyDelay              time.Duration `help:"the minimum age of the sent retain filters before they are verified, to give the nodes time to process them" default:"48h" testDefault:"0"`
+	VerifyMaxScannedSegments int           `help:"the maximum number of segments listed to find the sampled pieces of a garbage collection run" default:"1000000"`
 }
 
 // NewService creates a new instance of the gc sender service.
-func NewService(log *zap.Logger, config Config, dialer rpc.Dialer, overlay overlay.DB) *Service {
+func NewService(log *zap.Logger, config Config, dialer rpc.Dialer, overlay overlay.DB, metabase *metabase.DB) *Service {
 	return &Service{
 		log:    log,
 		Config: config,
 		Loop:   sync2.NewCycle(config.Interval),
 
-		dialer:  dialer,
-		overlay: overlay,
+		dialer:   dialer,
+		overlay:  overlay,
+		metabase: metabase,
 	}
 }
 
@@ -70,8 +76,9 @@ type Service struct {
 	Config Config
 	Loop   *sync2.Cycle
 
-	dialer  rpc.Dialer
-	overlay overlay.DB
+	dialer   rpc.Dialer
+	overlay  overlay.DB
+	metabase *metabase.DB
 }
 
 // Run continuously polls for new retain filters and sends them out.
@@ -109,6 +116,11 @@ func (service *Service) RunOnce(ctx context.Context) (err error) {
 		err = errs.Combine(err, project.Close())
 	}()
 
+	err = service.VerifyOnce(ctx, project)
+	if err != nil {
+		return err
+	}
+
 	download, err := project.DownloadObject(ctx, service.Config.Bucket, bloomfilter.LATEST, nil)
 	if err != nil {
 		if errors.Is(err, uplink.ErrObjectNotFound) {
@@ -0,0 +1,413 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package sender
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/common/rpc/rpcpool"
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/uplink"
+)
+
+const (
+	sentPrefix     = "sent-"
+	verifiedPrefix = "verified-"
+
+	// VerificationReportName is the name of the verification report stored
+	// under the verified prefix of a garbage collection run.
+	VerificationReportName = "verification-report.json"
+
+	verifyListBatchSize = 1000
+)
+
+// VerificationReport is the safety report of a garbage collection run. It lists the
+// sampled pieces which should have been kept by the nodes, but which are missing.
+type VerificationReport struct {
+	Run           string             `json:"run"`
+	VerifiedAt    time.Time          `json:"verifiedAt"`
+	CheckedPieces int                `json:"checkedPieces"`
+	MissingPieces int                `json:"missingPieces"`
+	Nodes         []NodeVerification `json:"nodes"`
+}
+
+// NodeVerification contains the result of verifying the sampled pieces of a node.
+type NodeVerification struct {
+	NodeID  storj.NodeID   `json:"nodeID"`
+	Checked int            `json:"checked"`
+	Missing []MissingPiece `json:"missing,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// MissingPiece is a piece which is still referenced by a segment, but which isn't
+// stored by the node anymore.
+type MissingPiece struct {
+	StreamID uuid.UUID     `json:"streamID"`
+	Position uint64        `json:"position"`
+	PieceID  storj.PieceID `json:"pieceID"`
+}
+
+// verifySample is a piece sampled for the verification of a node.
+type verifySample struct {
+	StreamID uuid.UUID
+	Position metabase.SegmentPosition
+	PieceID  storj.PieceID
+}
+
+// VerifyOnce verifies the garbage collection runs whose retain filters were sent
+// and created at least VerifyDelay ago. A report is stored for each run and its
+// retain filters are moved to the verified prefix.
+func (service *Service) VerifyOnce(ctx context.Context, project *uplink.Project) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if service.Config.VerifySegmentsPerNode <= 0 || service.metabase == nil {
+		return nil
+	}
+
+	runs := map[string][]string{}
+	objects := project.ListObjects(ctx, service.Config.Bucket, &uplink.ListObjectsOptions{
+		System:    true,
+		Recursive: true,
+		Prefix:    sentPrefix,
+	})
+	for objects.Next() {
+		object := objects.Item()
+		if !strings.HasSuffix(object.Key, ".zip") {
+			continue
+		}
+		if time.Since(object.System.Created) < service.Config.VerifyDelay {
+			continue
+		}
+		run := path.Dir(strings.TrimPrefix(object.Key, sentPrefix))
+		runs[run] = append(runs[run], object.Key)
+	}
+	if err := objects.Err(); err != nil {
+		return Error.Wrap(err)
+	}
+
+	for run, objectKeys := range runs {
+		if err := service.verifyRun(ctx, project, run, objectKeys); err != nil {
+			service.log.Warn("Error verifying garbage collection run", zap.String("run", run), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// verifyRun verifies the nodes of the retain filters stored under objectKeys.
+func (service *Service) verifyRun(ctx context.Context, project *uplink.Project, run string, objectKeys []string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	createdAt := map[storj.NodeID]time.Time{}
+	for _, objectKey := range objectKeys {
+		err := IterateZipContent(ctx, *project, service.Config.Bucket, objectKey, func(zipEntry *zip.File) error {
+			retainInfo, err := UnpackZipEntry(zipEntry)
+			if err != nil {
+				service.log.Warn("Skipping retain filter entry", zap.Error(err))
+				return nil
+			}
+			createdAt[retainInfo.StorageNodeId] = retainInfo.CreationDate
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	report, err := service.Verify(ctx, createdAt)
+	if err != nil {
+		return err
+	}
+	report.Run = run
+
+	if report.MissingPieces > 0 {
+		service.log.Error("Garbage collection verification found missing pieces",
+			zap.String("run", run), zap.Int("missing", report.MissingPieces), zap.Int("checked", report.CheckedPieces))
+	} else {
+		service.log.Info("Garbage collection verification succeeded",
+			zap.String("run", run), zap.Int("checked", report.CheckedPieces))
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	err = service.uploadReport(ctx, project, verifiedPrefix+run+"/"+VerificationReportName, data)
+	if err != nil {
+		return err
+	}
+
+	for _, objectKey := range objectKeys {
+		newObjectKey := verifiedPrefix + strings.TrimPrefix(objectKey, sentPrefix)
+		err := project.MoveObject(ctx, service.Config.Bucket, objectKey, service.Config.Bucket, newObjectKey, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uploadReport stores the report under an object key.
+func (service *Service) uploadReport(ctx context.Context, project *uplink.Project, objectKey string, data []byte) (err error) {
+	upload, err := project.UploadObject(ctx, service.Config.Bucket, objectKey, &uplink.UploadOptions{
+		Expires: time.Now().Add(service.Config.ExpireIn),
+	})
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errs.Combine(err, upload.Abort())
+		}
+	}()
+
+	_, err = upload.Write(data)
+	if err != nil {
+		return err
+	}
+
+	return upload.Commit()
+}
+
+// Verify samples segments of the nodes, which were created before the retain filter
+// of the node, and checks whether the nodes still store their pieces.
+func (service *Service) Verify(ctx context.Context, createdAt map[storj.NodeID]time.Time) (report VerificationReport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	report.VerifiedAt = time.Now().UTC()
+
+	samples, err := service.sampleSegments(ctx, createdAt)
+	if err != nil {
+		return report, Error.Wrap(err)
+	}
+
+	var mu sync.Mutex
+	limiter := sync2.NewLimiter(service.Config.ConcurrentSends)
+	for nodeID, nodeSamples := range samples {
+		nodeID, nodeSamples := nodeID, nodeSamples
+		limiter.Go(ctx, func() {
+			verification := service.verifyNode(ctx, nodeID, nodeSamples)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Nodes = append(report.Nodes, verification)
+			report.CheckedPieces += verification.Checked
+			report.MissingPieces += len(verification.Missing)
+		})
+	}
+	limiter.Wait()
+
+	sort.Slice(report.Nodes, func(i, j int) bool {
+		return report.Nodes[i].NodeID.Less(report.Nodes[j].NodeID)
+	})
+
+	mon.IntVal("gc_verify_checked_pieces").Observe(int64(report.CheckedPieces))
+	mon.IntVal("gc_verify_missing_pieces").Observe(int64(report.MissingPieces))
+
+	return report, nil
+}
+
+// sampleSegments collects up to VerifySegmentsPerNode pieces for each node by listing
+// the segments starting from a random position.
+func (service *Service) sampleSegments(ctx context.Context, createdAt map[storj.NodeID]time.Time) (_ map[storj.NodeID][]verifySample, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	aliasMap, err := service.metabase.LatestNodesAliasMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdBefore time.Time
+	nodes := map[metabase.NodeAlias]storj.NodeID{}
+	for nodeID, created := range createdAt {
+		if alias, ok := aliasMap.Alias(nodeID); ok {
+			nodes[alias] = nodeID
+		}
+		if created.After(createdBefore) {
+			createdBefore = created
+		}
+	}
+
+	samples := make(map[storj.NodeID][]verifySample, len(createdAt))
+	for _, nodeID := range nodes {
+		samples[nodeID] = nil
+	}
+	complete := 0
+
+	start, err := uuid.New()
+	if err != nil {
+		return nil, err
+	}
+	cursor := metabase.ListVerifySegments{
+		CursorStreamID: start,
+		Limit:          verifyListBatchSize,
+		CreatedBefore:  &createdBefore,
+	}
+	wrapped := false
+	for scanned := 0; scanned < service.Config.VerifyMaxScannedSegments && complete < len(nodes); {
+		result, err := service.metabase.ListVerifySegments(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, segment := range result.Segments {
+			if wrapped && segment.StreamID.Compare(start) >= 0 {
+				return samples, nil
+			}
+			scanned++
+
+			for _, piece := range segment.AliasPieces {
+				nodeID, ok := nodes[piece.Alias]
+				if !ok || len(samples[nodeID]) >= service.Config.VerifySegmentsPerNode {
+					continue
+				}
+				// pieces of newer segments aren't included in the retain filter, but they are kept anyway.
+				if !segment.CreatedAt.Before(createdAt[nodeID]) {
+					continue
+				}
+				samples[nodeID] = append(samples[nodeID], verifySample{
+					StreamID: segment.StreamID,
+					Position: segment.Position,
+					PieceID:  segment.RootPieceID.Derive(nodeID, int32(piece.Number)),
+				})
+				if len(samples[nodeID]) == service.Config.VerifySegmentsPerNode {
+					complete++
+				}
+			}
+		}
+
+		if len(result.Segments) < cursor.Limit {
+			if wrapped {
+				break
+			}
+			// continue from the beginning up to the random start.
+			wrapped = true
+			cursor.CursorStreamID = uuid.UUID{}
+			cursor.CursorPosition = metabase.SegmentPosition{}
+			continue
+		}
+
+		last := result.Segments[len(result.Segments)-1]
+		cursor.CursorStreamID = last.StreamID
+		cursor.CursorPosition = last.Position
+	}
+
+	return samples, nil
+}
+
+// verifyNode checks whether the node stores the sampled pieces using the Exists endpoint.
+func (service *Service) verifyNode(ctx context.Context, nodeID storj.NodeID, samples []verifySample) (verification NodeVerification) {
+	defer mon.Task()(&ctx)(nil)
+
+	verification.NodeID = nodeID
+	if len(samples) == 0 {
+		return verification
+	}
+
+	missing, err := service.checkExists(ctx, nodeID, samples)
+	if err != nil {
+		verification.Error = err.Error()
+		return verification
+	}
+	verification.Checked = len(samples)
+
+	for _, index := range missing {
+		if int(index) >= len(samples) {
+			continue
+		}
+		sample := samples[index]
+
+		// the segment could have been deleted or repaired since the listing.
+		segment, err := service.metabase.GetSegmentByPosition(ctx, metabase.GetSegmentByPosition{
+			StreamID: sample.StreamID,
+			Position: sample.Position,
+		})
+		if err != nil {
+			if !metabase.ErrSegmentNotFound.Has(err) {
+				service.log.Warn("Failed to recheck missing piece", zap.Stringer("NodeID", nodeID), zap.Error(err))
+			}
+			continue
+		}
+		if !segmentHasPiece(segment, nodeID, sample.PieceID) {
+			continue
+		}
+
+		verification.Missing = append(verification.Missing, MissingPiece{
+			StreamID: sample.StreamID,
+			Position: sample.Position.Encode(),
+			PieceID:  sample.PieceID,
+		})
+	}
+
+	if len(verification.Missing) > 0 {
+		service.log.Warn("Node is missing pieces after garbage collection",
+			zap.Stringer("NodeID", nodeID), zap.Int("missing", len(verification.Missing)), zap.Int("checked", verification.Checked))
+	}
+	return verification
+}
+
+// checkExists returns the indexes of the samples which aren't stored by the node.
+func (service *Service) checkExists(ctx context.Context, nodeID storj.NodeID, samples []verifySample) (_ []uint32, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dossier, err := service.overlay.Get(ctx, nodeID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if dossier.Disqualified != nil || dossier.ExitStatus.ExitSuccess {
+		return nil, Error.New("node is disqualified or exited")
+	}
+
+	if service.Config.RetainSendTimeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, service.Config.RetainSendTimeout)
+		defer cancel()
+	}
+
+	conn, err := service.dialer.DialNodeURL(rpcpool.WithForceDial(ctx), storj.NodeURL{
+		ID:      nodeID,
+		Address: dossier.Address.Address,
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(conn.Close())) }()
+
+	pieceIDs := make([]storj.PieceID, 0, len(samples))
+	for _, sample := range samples {
+		pieceIDs = append(pieceIDs, sample.PieceID)
+	}
+
+	response, err := pb.NewDRPCPiecestoreClient(conn).Exists(ctx, &pb.ExistsRequest{
+		PieceIds: pieceIDs,
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return response.Missing, nil
+}
+
+// segmentHasPiece returns whether the segment still references the piece on the node.
+func segmentHasPiece(segment metabase.Segment, nodeID storj.NodeID, pieceID storj.PieceID) bool {
+	for _, piece := range segment.Pieces {
+		if piece.StorageNode == nodeID && segment.RootPieceID.Derive(nodeID, int32(piece.Number)) == pieceID {
+			return true
+		}
+	}
+	return false
+}
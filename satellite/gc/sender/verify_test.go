@@ -0,0 +1,77 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package sender_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+)
+
+func TestVerify(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.ReconfigureRS(2, 3, 4, 4),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+
+		err := planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
+		require.NoError(t, err)
+
+		segments, err := satellite.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		segment := segments[0]
+
+		gcsender := satellite.GarbageCollection.Sender
+		gcsender.Config.VerifySegmentsPerNode = 10
+
+		createdAt := map[storj.NodeID]time.Time{}
+		for _, node := range planet.StorageNodes {
+			createdAt[node.ID()] = time.Now()
+		}
+
+		report, err := gcsender.Verify(ctx, createdAt)
+		require.NoError(t, err)
+		require.Len(t, report.Nodes, len(planet.StorageNodes))
+		require.Equal(t, len(segment.Pieces), report.CheckedPieces)
+		require.Zero(t, report.MissingPieces)
+
+		// a piece removed from a node is reported as missing.
+		piece := segment.Pieces[0]
+		node := planet.FindNode(piece.StorageNode)
+		pieceID := segment.RootPieceID.Derive(piece.StorageNode, int32(piece.Number))
+		require.NoError(t, node.Storage2.Store.Delete(ctx, satellite.ID(), pieceID))
+
+		report, err = gcsender.Verify(ctx, createdAt)
+		require.NoError(t, err)
+		require.Equal(t, 1, report.MissingPieces)
+		for _, verification := range report.Nodes {
+			if verification.NodeID != piece.StorageNode {
+				require.Empty(t, verification.Missing)
+				continue
+			}
+			require.Len(t, verification.Missing, 1)
+			require.Equal(t, pieceID, verification.Missing[0].PieceID)
+			require.Equal(t, segment.StreamID, verification.Missing[0].StreamID)
+		}
+
+		// segments created after the retain filter aren't sampled.
+		for nodeID := range createdAt {
+			createdAt[nodeID] = segment.CreatedAt.Add(-time.Hour)
+		}
+		report, err = gcsender.Verify(ctx, createdAt)
+		require.NoError(t, err)
+		require.Zero(t, report.CheckedPieces)
+	})
+}
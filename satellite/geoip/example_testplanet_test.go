@@ -7,16 +7,19 @@ import (
 	"fmt"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"storj.io/common/storj"
 	"storj.io/common/storj/location"
 	"storj.io/common/testcontext"
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/geoip"
+	"storj.io/storj/satellite/nodeselection"
 	"storj.io/storj/storagenode"
 )
 
@@ -76,3 +79,60 @@ func TestGeoIPMock(t *testing.T) {
 		},
 	)
 }
+
+func TestASNMock(t *testing.T) {
+	if runtime.GOOS == "darwin" {
+		t.Skip("Test does not work with macOS")
+	}
+	testplanet.Run(t,
+		testplanet.Config{
+			SatelliteCount: 1, StorageNodeCount: 3, UplinkCount: 0,
+			Reconfigure: testplanet.Reconfigure{
+				Satellite: func(logger *zap.Logger, index int, config *satellite.Config) {
+					config.Overlay.GeoIP.MockASN = []string{"64500", "64501"}
+					config.Overlay.NodeCheckInWaitPeriod = 0
+				},
+				StorageNode: func(index int, config *storagenode.Config) {
+					config.Server.Address = fmt.Sprintf("127.0.201.%d:0", index+1)
+				},
+			},
+		},
+		func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+			// ensure storage nodes checked in with satellite
+			for _, node := range planet.StorageNodes {
+				node.Contact.Chore.TriggerWait(ctx)
+			}
+
+			// expected autonomous system numbers per node index
+			asns := map[int]string{
+				0: "64501",
+				1: "64500",
+				2: "64501",
+			}
+
+			signedAt := map[int]time.Time{}
+			for i, node := range planet.StorageNodes {
+				tags, err := planet.Satellites[0].API.Overlay.DB.GetNodeTags(ctx, node.ID())
+				require.NoError(t, err)
+
+				tag, err := tags.FindBySignerAndName(storj.NodeID{}, nodeselection.ASNTag)
+				require.NoError(t, err)
+				assert.Equal(t, asns[i], string(tag.Value))
+				signedAt[i] = tag.SignedAt
+			}
+
+			// the tag isn't rewritten when the autonomous system didn't change
+			for _, node := range planet.StorageNodes {
+				node.Contact.Chore.TriggerWait(ctx)
+			}
+			for i, node := range planet.StorageNodes {
+				tags, err := planet.Satellites[0].API.Overlay.DB.GetNodeTags(ctx, node.ID())
+				require.NoError(t, err)
+
+				tag, err := tags.FindBySignerAndName(storj.NodeID{}, nodeselection.ASNTag)
+				require.NoError(t, err)
+				assert.True(t, signedAt[i].Equal(tag.SignedAt))
+			}
+		},
+	)
+}
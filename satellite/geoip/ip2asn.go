@@ -0,0 +1,10 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information
+
+package geoip
+
+// IPToASN defines an abstraction for resolving the autonomous system number given the string representation of an IP address.
+type IPToASN interface {
+	Close() error
+	LookupASN(address string) (uint32, error)
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information
+
+package geoip_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/satellite/geoip"
+)
+
+func TestIP2ASNMock(t *testing.T) {
+	_, err := geoip.NewMockIPToASN([]string{"AS64500"})
+	require.Error(t, err)
+
+	ipLookup, err := geoip.NewMockIPToASN([]string{"64500", "64501", "64502"})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name        string
+		address     string
+		asn         uint32
+		errExpected bool
+	}{
+		{"first IP in the pool", "127.0.0.1:1234", 64501, false},
+		{"second IP in the pool", "127.0.0.2:1234", 64502, false},
+		{"third IP in the pool", "127.0.0.3:1234", 64500, false},
+		{"ipv6", "[2001:0db8:85a3:0000:0000:8a2e:0370:7334]:1234", 64501, false},
+		{"not an ip address", "not at all", 0, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			asn, err := ipLookup.LookupASN(tc.address)
+			if tc.errExpected {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.asn, asn)
+			}
+		})
+	}
+
+	asn, err := geoip.MockIPToASN{}.LookupASN("127.0.0.1:1234")
+	require.NoError(t, err)
+	require.Zero(t, asn)
+}
@@ -30,12 +30,17 @@ type country struct {
 	IsoCode string `maxminddb:"iso_code"`
 }
 
+type asnInfo struct {
+	AutonomousSystemNumber uint32 `maxminddb:"autonomous_system_number"`
+}
+
 // MaxmindDB provides access to GeoIP data via the maxmind geoip databases.
 type MaxmindDB struct {
 	db *maxminddb.Reader
 }
 
 var _ IPToCountry = &MaxmindDB{}
+var _ IPToASN = &MaxmindDB{}
 
 // Close will disconnect the underlying connection to the database.
 func (m *MaxmindDB) Close() error {
@@ -58,6 +63,22 @@ func (m *MaxmindDB) LookupISOCountryCode(address string) (location.CountryCode,
 	return toCountryCode(info), nil
 }
 
+// LookupASN accepts an IP address. It requires an ASN database (e.g. GeoLite2-ASN).
+func (m *MaxmindDB) LookupASN(address string) (uint32, error) {
+	ip, err := addressToIP(address)
+	if err != nil || ip == nil {
+		return 0, err
+	}
+
+	info := &asnInfo{}
+	err = m.db.Lookup(ip, info)
+	if err != nil {
+		return 0, err
+	}
+
+	return info.AutonomousSystemNumber, nil
+}
+
 func toCountryCode(info *ipInfo) location.CountryCode {
 	// it's a tricky situation when represented_country is returned (like an embassy or military base).
 	// we have only 1-2 such nodes. it's more safe to exclude them from geofencing.
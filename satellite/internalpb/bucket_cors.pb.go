@@ -0,0 +1,220 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: bucket_cors.proto
+
+package internalpb
+
+import (
+	fmt "fmt"
+	math "math"
+
+	proto "github.com/gogo/protobuf/proto"
+
+	pb "storj.io/common/pb"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetBucketCORSRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Name                 []byte            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetBucketCORSRequest) Reset()         { *m = GetBucketCORSRequest{} }
+func (m *GetBucketCORSRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBucketCORSRequest) ProtoMessage()    {}
+func (*GetBucketCORSRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7b64b0dd454dd30a, []int{0}
+}
+func (m *GetBucketCORSRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBucketCORSRequest.Unmarshal(m, b)
+}
+func (m *GetBucketCORSRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBucketCORSRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBucketCORSRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBucketCORSRequest.Merge(m, src)
+}
+func (m *GetBucketCORSRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBucketCORSRequest.Size(m)
+}
+func (m *GetBucketCORSRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBucketCORSRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBucketCORSRequest proto.InternalMessageInfo
+
+func (m *GetBucketCORSRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetBucketCORSRequest) GetName() []byte {
+	if m != nil {
+		return m.Name
+	}
+	return nil
+}
+
+type GetBucketCORSResponse struct {
+	Rules                []*CORSRule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GetBucketCORSResponse) Reset()         { *m = GetBucketCORSResponse{} }
+func (m *GetBucketCORSResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBucketCORSResponse) ProtoMessage()    {}
+func (*GetBucketCORSResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7b64b0dd454dd30a, []int{1}
+}
+func (m *GetBucketCORSResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBucketCORSResponse.Unmarshal(m, b)
+}
+func (m *GetBucketCORSResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBucketCORSResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBucketCORSResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBucketCORSResponse.Merge(m, src)
+}
+func (m *GetBucketCORSResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBucketCORSResponse.Size(m)
+}
+func (m *GetBucketCORSResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBucketCORSResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBucketCORSResponse proto.InternalMessageInfo
+
+func (m *GetBucketCORSResponse) GetRules() []*CORSRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+type CORSRule struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AllowedOrigins       []string `protobuf:"bytes,2,rep,name=allowed_origins,json=allowedOrigins,proto3" json:"allowed_origins,omitempty"`
+	AllowedMethods       []string `protobuf:"bytes,3,rep,name=allowed_methods,json=allowedMethods,proto3" json:"allowed_methods,omitempty"`
+	AllowedHeaders       []string `protobuf:"bytes,4,rep,name=allowed_headers,json=allowedHeaders,proto3" json:"allowed_headers,omitempty"`
+	ExposeHeaders        []string `protobuf:"bytes,5,rep,name=expose_headers,json=exposeHeaders,proto3" json:"expose_headers,omitempty"`
+	MaxAgeSeconds        int32    `protobuf:"varint,6,opt,name=max_age_seconds,json=maxAgeSeconds,proto3" json:"max_age_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CORSRule) Reset()         { *m = CORSRule{} }
+func (m *CORSRule) String() string { return proto.CompactTextString(m) }
+func (*CORSRule) ProtoMessage()    {}
+func (*CORSRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7b64b0dd454dd30a, []int{2}
+}
+func (m *CORSRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CORSRule.Unmarshal(m, b)
+}
+func (m *CORSRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CORSRule.Marshal(b, m, deterministic)
+}
+func (m *CORSRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CORSRule.Merge(m, src)
+}
+func (m *CORSRule) XXX_Size() int {
+	return xxx_messageInfo_CORSRule.Size(m)
+}
+func (m *CORSRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_CORSRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CORSRule proto.InternalMessageInfo
+
+func (m *CORSRule) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CORSRule) GetAllowedOrigins() []string {
+	if m != nil {
+		return m.AllowedOrigins
+	}
+	return nil
+}
+
+func (m *CORSRule) GetAllowedMethods() []string {
+	if m != nil {
+		return m.AllowedMethods
+	}
+	return nil
+}
+
+func (m *CORSRule) GetAllowedHeaders() []string {
+	if m != nil {
+		return m.AllowedHeaders
+	}
+	return nil
+}
+
+func (m *CORSRule) GetExposeHeaders() []string {
+	if m != nil {
+		return m.ExposeHeaders
+	}
+	return nil
+}
+
+func (m *CORSRule) GetMaxAgeSeconds() int32 {
+	if m != nil {
+		return m.MaxAgeSeconds
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*GetBucketCORSRequest)(nil), "satellite.bucket_cors.GetBucketCORSRequest")
+	proto.RegisterType((*GetBucketCORSResponse)(nil), "satellite.bucket_cors.GetBucketCORSResponse")
+	proto.RegisterType((*CORSRule)(nil), "satellite.bucket_cors.CORSRule")
+}
+
+func init() { proto.RegisterFile("bucket_cors.proto", fileDescriptor_7b64b0dd454dd30a) }
+
+var fileDescriptor_7b64b0dd454dd30a = []byte{
+	// 350 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x5f, 0x4b, 0xf3, 0x30,
+	0x14, 0x87, 0xdf, 0x76, 0x7f, 0x78, 0x97, 0xbd, 0xeb, 0x78, 0x83, 0xc3, 0xb2, 0x1b, 0x4b, 0x65,
+	0x5a, 0x50, 0x5a, 0x98, 0xf8, 0x01, 0x9c, 0x17, 0x7a, 0xa3, 0x83, 0xec, 0x4e, 0x2f, 0x4a, 0xb6,
+	0x1e, 0xb7, 0x68, 0xda, 0xd4, 0x24, 0xc5, 0xe1, 0xe7, 0xf5, 0x83, 0x08, 0xc9, 0x36, 0x37, 0x99,
+	0xe0, 0xdd, 0xe1, 0x39, 0xcf, 0x29, 0xa7, 0xbf, 0x13, 0xf4, 0x7f, 0x5a, 0xcd, 0x5e, 0x40, 0xa7,
+	0x33, 0x21, 0x55, 0x5c, 0x4a, 0xa1, 0x05, 0xee, 0x29, 0xaa, 0x81, 0x73, 0xa6, 0x21, 0xde, 0x6a,
+	0xf6, 0xbd, 0x1c, 0x34, 0x65, 0xc5, 0x93, 0xb0, 0x5a, 0xf8, 0x88, 0x0e, 0x6e, 0x40, 0x8f, 0x8c,
+	0x71, 0x3d, 0x26, 0x13, 0x02, 0xaf, 0x15, 0x28, 0x8d, 0x13, 0xd4, 0x5c, 0x00, 0xcd, 0x40, 0xfa,
+	0x4e, 0xe0, 0x44, 0xed, 0xe1, 0x61, 0xbc, 0x19, 0x5c, 0x29, 0xb7, 0xa6, 0x4d, 0x56, 0x1a, 0xc6,
+	0xa8, 0x5e, 0xd0, 0x1c, 0x7c, 0x37, 0x70, 0xa2, 0x7f, 0xc4, 0xd4, 0xe1, 0x3d, 0xea, 0x7d, 0xfb,
+	0xb8, 0x2a, 0x45, 0xa1, 0x00, 0x5f, 0xa2, 0x86, 0xac, 0x38, 0x28, 0xdf, 0x09, 0x6a, 0x51, 0x7b,
+	0x78, 0x14, 0xef, 0x5d, 0x36, 0x36, 0x33, 0x15, 0x07, 0x62, 0xed, 0xf0, 0xc3, 0x41, 0x7f, 0xd7,
+	0x0c, 0x7b, 0xc8, 0x65, 0x99, 0xd9, 0xae, 0x45, 0x5c, 0x96, 0xe1, 0x53, 0xd4, 0xa5, 0x9c, 0x8b,
+	0x37, 0xc8, 0x52, 0x21, 0xd9, 0x9c, 0x15, 0xca, 0x77, 0x83, 0x5a, 0xd4, 0x22, 0xde, 0x0a, 0x8f,
+	0x2d, 0xdd, 0x16, 0x73, 0xd0, 0x0b, 0x91, 0x29, 0xbf, 0xb6, 0x23, 0xde, 0x59, 0xba, 0x2d, 0xda,
+	0x9f, 0x54, 0x7e, 0x7d, 0x47, 0xb4, 0x11, 0x28, 0x3c, 0x40, 0x1e, 0x2c, 0x4b, 0xa1, 0x60, 0xe3,
+	0x35, 0x8c, 0xd7, 0xb1, 0x74, 0xad, 0x9d, 0xa0, 0x6e, 0x4e, 0x97, 0x29, 0x9d, 0x43, 0xaa, 0x60,
+	0x26, 0x8a, 0x4c, 0xf9, 0xcd, 0xc0, 0x89, 0x1a, 0xa4, 0x93, 0xd3, 0xe5, 0xd5, 0x1c, 0x26, 0x16,
+	0x0e, 0xdf, 0x11, 0xfa, 0xca, 0x0c, 0x73, 0xd4, 0xd9, 0x09, 0x11, 0x9f, 0xfd, 0x90, 0xd6, 0xbe,
+	0x3b, 0xf6, 0xcf, 0x7f, 0x27, 0xdb, 0xbb, 0x84, 0x7f, 0x46, 0x83, 0x87, 0x63, 0xa5, 0x85, 0x7c,
+	0x8e, 0x99, 0x48, 0x4c, 0x91, 0x6c, 0xe6, 0x13, 0x56, 0x68, 0x90, 0x05, 0xe5, 0xe5, 0x74, 0xda,
+	0x34, 0xaf, 0xe7, 0xe2, 0x73, 0x00, 0x75, 0x1b, 0xdc, 0xc5, 0x79, 0x02, 0x00, 0x00,
+}
@@ -0,0 +1,119 @@
+// Code generated by protoc-gen-go-drpc. DO NOT EDIT.
+// protoc-gen-go-drpc version: v0.0.35-0.20240709171858-0075ac871661
+// source: bucket_cors.proto
+
+package internalpb
+
+import (
+	bytes "bytes"
+	context "context"
+	errors "errors"
+
+	jsonpb "github.com/gogo/protobuf/jsonpb"
+	proto "github.com/gogo/protobuf/proto"
+
+	drpc "storj.io/drpc"
+	drpcerr "storj.io/drpc/drpcerr"
+)
+
+type drpcEncoding_File_bucket_cors_proto struct{}
+
+func (drpcEncoding_File_bucket_cors_proto) Marshal(msg drpc.Message) ([]byte, error) {
+	return proto.Marshal(msg.(proto.Message))
+}
+
+func (drpcEncoding_File_bucket_cors_proto) Unmarshal(buf []byte, msg drpc.Message) error {
+	return proto.Unmarshal(buf, msg.(proto.Message))
+}
+
+func (drpcEncoding_File_bucket_cors_proto) JSONMarshal(msg drpc.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	err := new(jsonpb.Marshaler).Marshal(&buf, msg.(proto.Message))
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (drpcEncoding_File_bucket_cors_proto) JSONUnmarshal(buf []byte, msg drpc.Message) error {
+	return jsonpb.Unmarshal(bytes.NewReader(buf), msg.(proto.Message))
+}
+
+type DRPCBucketCORSClient interface {
+	DRPCConn() drpc.Conn
+
+	GetBucketCORS(ctx context.Context, in *GetBucketCORSRequest) (*GetBucketCORSResponse, error)
+}
+
+type drpcBucketCORSClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCBucketCORSClient(cc drpc.Conn) DRPCBucketCORSClient {
+	return &drpcBucketCORSClient{cc}
+}
+
+func (c *drpcBucketCORSClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcBucketCORSClient) GetBucketCORS(ctx context.Context, in *GetBucketCORSRequest) (*GetBucketCORSResponse, error) {
+	out := new(GetBucketCORSResponse)
+	err := c.cc.Invoke(ctx, "/satellite.bucket_cors.BucketCORS/GetBucketCORS", drpcEncoding_File_bucket_cors_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCBucketCORSServer interface {
+	GetBucketCORS(context.Context, *GetBucketCORSRequest) (*GetBucketCORSResponse, error)
+}
+
+type DRPCBucketCORSUnimplementedServer struct{}
+
+func (s *DRPCBucketCORSUnimplementedServer) GetBucketCORS(context.Context, *GetBucketCORSRequest) (*GetBucketCORSResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+type DRPCBucketCORSDescription struct{}
+
+func (DRPCBucketCORSDescription) NumMethods() int { return 1 }
+
+func (DRPCBucketCORSDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/satellite.bucket_cors.BucketCORS/GetBucketCORS", drpcEncoding_File_bucket_cors_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCBucketCORSServer).
+					GetBucketCORS(
+						ctx,
+						in1.(*GetBucketCORSRequest),
+					)
+			}, DRPCBucketCORSServer.GetBucketCORS, true
+	default:
+		return "", nil, nil, nil, false
+	}
+}
+
+func DRPCRegisterBucketCORS(mux drpc.Mux, impl DRPCBucketCORSServer) error {
+	return mux.Register(impl, DRPCBucketCORSDescription{})
+}
+
+type DRPCBucketCORS_GetBucketCORSStream interface {
+	drpc.Stream
+	SendAndClose(*GetBucketCORSResponse) error
+}
+
+type drpcBucketCORS_GetBucketCORSStream struct {
+	drpc.Stream
+}
+
+func (x *drpcBucketCORS_GetBucketCORSStream) GetStream() drpc.Stream {
+	return x.Stream
+}
+
+func (x *drpcBucketCORS_GetBucketCORSStream) SendAndClose(m *GetBucketCORSResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_bucket_cors_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
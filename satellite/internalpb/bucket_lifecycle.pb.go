@@ -0,0 +1,281 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: bucket_lifecycle.proto
+
+package internalpb
+
+import (
+	fmt "fmt"
+	math "math"
+
+	proto "github.com/gogo/protobuf/proto"
+
+	pb "storj.io/common/pb"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetBucketLifecycleRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Name                 []byte            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetBucketLifecycleRequest) Reset()         { *m = GetBucketLifecycleRequest{} }
+func (m *GetBucketLifecycleRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBucketLifecycleRequest) ProtoMessage()    {}
+func (*GetBucketLifecycleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_48f9c940b8ebf6dc, []int{0}
+}
+func (m *GetBucketLifecycleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBucketLifecycleRequest.Unmarshal(m, b)
+}
+func (m *GetBucketLifecycleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBucketLifecycleRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBucketLifecycleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBucketLifecycleRequest.Merge(m, src)
+}
+func (m *GetBucketLifecycleRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBucketLifecycleRequest.Size(m)
+}
+func (m *GetBucketLifecycleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBucketLifecycleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBucketLifecycleRequest proto.InternalMessageInfo
+
+func (m *GetBucketLifecycleRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetBucketLifecycleRequest) GetName() []byte {
+	if m != nil {
+		return m.Name
+	}
+	return nil
+}
+
+type GetBucketLifecycleResponse struct {
+	Rules                []*LifecycleRule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetBucketLifecycleResponse) Reset()         { *m = GetBucketLifecycleResponse{} }
+func (m *GetBucketLifecycleResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBucketLifecycleResponse) ProtoMessage()    {}
+func (*GetBucketLifecycleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_48f9c940b8ebf6dc, []int{1}
+}
+func (m *GetBucketLifecycleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBucketLifecycleResponse.Unmarshal(m, b)
+}
+func (m *GetBucketLifecycleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBucketLifecycleResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBucketLifecycleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBucketLifecycleResponse.Merge(m, src)
+}
+func (m *GetBucketLifecycleResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBucketLifecycleResponse.Size(m)
+}
+func (m *GetBucketLifecycleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBucketLifecycleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBucketLifecycleResponse proto.InternalMessageInfo
+
+func (m *GetBucketLifecycleResponse) GetRules() []*LifecycleRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+type SetBucketLifecycleRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Name                 []byte            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Rules                []*LifecycleRule  `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SetBucketLifecycleRequest) Reset()         { *m = SetBucketLifecycleRequest{} }
+func (m *SetBucketLifecycleRequest) String() string { return proto.CompactTextString(m) }
+func (*SetBucketLifecycleRequest) ProtoMessage()    {}
+func (*SetBucketLifecycleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_48f9c940b8ebf6dc, []int{2}
+}
+func (m *SetBucketLifecycleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetBucketLifecycleRequest.Unmarshal(m, b)
+}
+func (m *SetBucketLifecycleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetBucketLifecycleRequest.Marshal(b, m, deterministic)
+}
+func (m *SetBucketLifecycleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBucketLifecycleRequest.Merge(m, src)
+}
+func (m *SetBucketLifecycleRequest) XXX_Size() int {
+	return xxx_messageInfo_SetBucketLifecycleRequest.Size(m)
+}
+func (m *SetBucketLifecycleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBucketLifecycleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetBucketLifecycleRequest proto.InternalMessageInfo
+
+func (m *SetBucketLifecycleRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SetBucketLifecycleRequest) GetName() []byte {
+	if m != nil {
+		return m.Name
+	}
+	return nil
+}
+
+func (m *SetBucketLifecycleRequest) GetRules() []*LifecycleRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+type SetBucketLifecycleResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetBucketLifecycleResponse) Reset()         { *m = SetBucketLifecycleResponse{} }
+func (m *SetBucketLifecycleResponse) String() string { return proto.CompactTextString(m) }
+func (*SetBucketLifecycleResponse) ProtoMessage()    {}
+func (*SetBucketLifecycleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_48f9c940b8ebf6dc, []int{3}
+}
+func (m *SetBucketLifecycleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetBucketLifecycleResponse.Unmarshal(m, b)
+}
+func (m *SetBucketLifecycleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetBucketLifecycleResponse.Marshal(b, m, deterministic)
+}
+func (m *SetBucketLifecycleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBucketLifecycleResponse.Merge(m, src)
+}
+func (m *SetBucketLifecycleResponse) XXX_Size() int {
+	return xxx_messageInfo_SetBucketLifecycleResponse.Size(m)
+}
+func (m *SetBucketLifecycleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBucketLifecycleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetBucketLifecycleResponse proto.InternalMessageInfo
+
+type LifecycleRule struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Prefix               string   `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	ExpirationDays       int32    `protobuf:"varint,3,opt,name=expiration_days,json=expirationDays,proto3" json:"expiration_days,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LifecycleRule) Reset()         { *m = LifecycleRule{} }
+func (m *LifecycleRule) String() string { return proto.CompactTextString(m) }
+func (*LifecycleRule) ProtoMessage()    {}
+func (*LifecycleRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_48f9c940b8ebf6dc, []int{4}
+}
+func (m *LifecycleRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LifecycleRule.Unmarshal(m, b)
+}
+func (m *LifecycleRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LifecycleRule.Marshal(b, m, deterministic)
+}
+func (m *LifecycleRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LifecycleRule.Merge(m, src)
+}
+func (m *LifecycleRule) XXX_Size() int {
+	return xxx_messageInfo_LifecycleRule.Size(m)
+}
+func (m *LifecycleRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_LifecycleRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LifecycleRule proto.InternalMessageInfo
+
+func (m *LifecycleRule) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *LifecycleRule) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *LifecycleRule) GetExpirationDays() int32 {
+	if m != nil {
+		return m.ExpirationDays
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*GetBucketLifecycleRequest)(nil), "satellite.bucket_lifecycle.GetBucketLifecycleRequest")
+	proto.RegisterType((*GetBucketLifecycleResponse)(nil), "satellite.bucket_lifecycle.GetBucketLifecycleResponse")
+	proto.RegisterType((*SetBucketLifecycleRequest)(nil), "satellite.bucket_lifecycle.SetBucketLifecycleRequest")
+	proto.RegisterType((*SetBucketLifecycleResponse)(nil), "satellite.bucket_lifecycle.SetBucketLifecycleResponse")
+	proto.RegisterType((*LifecycleRule)(nil), "satellite.bucket_lifecycle.LifecycleRule")
+}
+
+func init() { proto.RegisterFile("bucket_lifecycle.proto", fileDescriptor_48f9c940b8ebf6dc) }
+
+var fileDescriptor_48f9c940b8ebf6dc = []byte{
+	// 335 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x93, 0x41, 0x4f, 0xfa, 0x30,
+	0x18, 0xc6, 0xd9, 0xf8, 0x43, 0xc2, 0xcb, 0x5f, 0x48, 0x7a, 0x40, 0x58, 0x3c, 0x90, 0x19, 0xe3,
+	0xbc, 0x6c, 0x09, 0x46, 0xaf, 0x26, 0xc4, 0x44, 0x0f, 0x9e, 0xba, 0x9b, 0x89, 0x81, 0xc2, 0x5e,
+	0x62, 0xb5, 0xac, 0xb3, 0xed, 0x12, 0xf8, 0x00, 0x7e, 0x10, 0xbe, 0xa9, 0xb1, 0x4c, 0x88, 0x28,
+	0x24, 0x90, 0x78, 0x7b, 0xb7, 0x3e, 0x4f, 0xdf, 0xdf, 0xd3, 0xb7, 0x85, 0xd6, 0x28, 0x1f, 0xbf,
+	0xa2, 0x19, 0x08, 0x3e, 0xc1, 0xf1, 0x7c, 0x2c, 0x30, 0xcc, 0x94, 0x34, 0x92, 0x78, 0x9a, 0x19,
+	0x14, 0x82, 0x1b, 0x0c, 0x37, 0x15, 0x5e, 0x63, 0x8a, 0x86, 0xf1, 0x74, 0x22, 0x97, 0x5a, 0x7f,
+	0x08, 0x9d, 0x3b, 0x34, 0x7d, 0x2b, 0x7b, 0xf8, 0x52, 0x51, 0x7c, 0xcb, 0x51, 0x1b, 0x12, 0x41,
+	0xf5, 0x19, 0x59, 0x82, 0xaa, 0xed, 0x74, 0x9d, 0xa0, 0xde, 0x3b, 0x0e, 0x57, 0xee, 0x42, 0x72,
+	0x6f, 0x97, 0x69, 0x21, 0x23, 0x04, 0xfe, 0xa5, 0x6c, 0x8a, 0x6d, 0xb7, 0xeb, 0x04, 0xff, 0xa9,
+	0xad, 0xfd, 0x27, 0xf0, 0x7e, 0xeb, 0xa0, 0x33, 0x99, 0x6a, 0x24, 0x37, 0x50, 0x51, 0xb9, 0x40,
+	0xdd, 0x76, 0xba, 0xe5, 0xa0, 0xde, 0xbb, 0x08, 0xb7, 0xb3, 0x87, 0x6b, 0x77, 0x2e, 0x90, 0x2e,
+	0x7d, 0xfe, 0xc2, 0x81, 0x4e, 0xfc, 0xa7, 0x09, 0xd6, 0x8c, 0xe5, 0x03, 0x19, 0x4f, 0xc0, 0x8b,
+	0xb7, 0x1e, 0x81, 0x3f, 0x84, 0xa3, 0x6f, 0x2e, 0xd2, 0x00, 0x97, 0x27, 0x16, 0xb8, 0x46, 0x5d,
+	0x9e, 0x90, 0x16, 0x54, 0x33, 0x85, 0x13, 0x3e, 0xb3, 0x54, 0x35, 0x5a, 0x7c, 0x91, 0x73, 0x68,
+	0xe2, 0x2c, 0xe3, 0x8a, 0x19, 0x2e, 0xd3, 0x41, 0xc2, 0xe6, 0x9f, 0x84, 0x4e, 0x50, 0xa1, 0x8d,
+	0xf5, 0xef, 0x5b, 0x36, 0xd7, 0xbd, 0x85, 0x0b, 0xcd, 0x8d, 0xee, 0xe4, 0xdd, 0x01, 0xf2, 0x73,
+	0x2e, 0xe4, 0x6a, 0x57, 0xb8, 0xad, 0x37, 0xc5, 0xbb, 0xde, 0xd7, 0x56, 0x64, 0x2f, 0x59, 0x8e,
+	0x78, 0x4f, 0x8e, 0xf8, 0x30, 0x8e, 0x1d, 0x33, 0x28, 0xf5, 0xcf, 0x1e, 0x4f, 0xb5, 0x91, 0xea,
+	0x25, 0xe4, 0x32, 0xb2, 0x45, 0xb4, 0xda, 0x29, 0xe2, 0xa9, 0x41, 0x95, 0x32, 0x91, 0x8d, 0x46,
+	0x55, 0xfb, 0x6c, 0x2e, 0x3f, 0x06, 0x00, 0x1b, 0x25, 0xb7, 0xde, 0x7c, 0x03, 0x00, 0x00,
+}
@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go-drpc. DO NOT EDIT.
+// protoc-gen-go-drpc version: v0.0.35-0.20240709171858-0075ac871661
+// source: bucket_lifecycle.proto
+
+package internalpb
+
+import (
+	bytes "bytes"
+	context "context"
+	errors "errors"
+
+	jsonpb "github.com/gogo/protobuf/jsonpb"
+	proto "github.com/gogo/protobuf/proto"
+
+	drpc "storj.io/drpc"
+	drpcerr "storj.io/drpc/drpcerr"
+)
+
+type drpcEncoding_File_bucket_lifecycle_proto struct{}
+
+func (drpcEncoding_File_bucket_lifecycle_proto) Marshal(msg drpc.Message) ([]byte, error) {
+	return proto.Marshal(msg.(proto.Message))
+}
+
+func (drpcEncoding_File_bucket_lifecycle_proto) Unmarshal(buf []byte, msg drpc.Message) error {
+	return proto.Unmarshal(buf, msg.(proto.Message))
+}
+
+func (drpcEncoding_File_bucket_lifecycle_proto) JSONMarshal(msg drpc.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	err := new(jsonpb.Marshaler).Marshal(&buf, msg.(proto.Message))
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (drpcEncoding_File_bucket_lifecycle_proto) JSONUnmarshal(buf []byte, msg drpc.Message) error {
+	return jsonpb.Unmarshal(bytes.NewReader(buf), msg.(proto.Message))
+}
+
+type DRPCBucketLifecycleClient interface {
+	DRPCConn() drpc.Conn
+
+	GetBucketLifecycle(ctx context.Context, in *GetBucketLifecycleRequest) (*GetBucketLifecycleResponse, error)
+	SetBucketLifecycle(ctx context.Context, in *SetBucketLifecycleRequest) (*SetBucketLifecycleResponse, error)
+}
+
+type drpcBucketLifecycleClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCBucketLifecycleClient(cc drpc.Conn) DRPCBucketLifecycleClient {
+	return &drpcBucketLifecycleClient{cc}
+}
+
+func (c *drpcBucketLifecycleClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcBucketLifecycleClient) GetBucketLifecycle(ctx context.Context, in *GetBucketLifecycleRequest) (*GetBucketLifecycleResponse, error) {
+	out := new(GetBucketLifecycleResponse)
+	err := c.cc.Invoke(ctx, "/satellite.bucket_lifecycle.BucketLifecycle/GetBucketLifecycle", drpcEncoding_File_bucket_lifecycle_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcBucketLifecycleClient) SetBucketLifecycle(ctx context.Context, in *SetBucketLifecycleRequest) (*SetBucketLifecycleResponse, error) {
+	out := new(SetBucketLifecycleResponse)
+	err := c.cc.Invoke(ctx, "/satellite.bucket_lifecycle.BucketLifecycle/SetBucketLifecycle", drpcEncoding_File_bucket_lifecycle_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCBucketLifecycleServer interface {
+	GetBucketLifecycle(context.Context, *GetBucketLifecycleRequest) (*GetBucketLifecycleResponse, error)
+	SetBucketLifecycle(context.Context, *SetBucketLifecycleRequest) (*SetBucketLifecycleResponse, error)
+}
+
+type DRPCBucketLifecycleUnimplementedServer struct{}
+
+func (s *DRPCBucketLifecycleUnimplementedServer) GetBucketLifecycle(context.Context, *GetBucketLifecycleRequest) (*GetBucketLifecycleResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCBucketLifecycleUnimplementedServer) SetBucketLifecycle(context.Context, *SetBucketLifecycleRequest) (*SetBucketLifecycleResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+type DRPCBucketLifecycleDescription struct{}
+
+func (DRPCBucketLifecycleDescription) NumMethods() int { return 2 }
+
+func (DRPCBucketLifecycleDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/satellite.bucket_lifecycle.BucketLifecycle/GetBucketLifecycle", drpcEncoding_File_bucket_lifecycle_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCBucketLifecycleServer).
+					GetBucketLifecycle(
+						ctx,
+						in1.(*GetBucketLifecycleRequest),
+					)
+			}, DRPCBucketLifecycleServer.GetBucketLifecycle, true
+	case 1:
+		return "/satellite.bucket_lifecycle.BucketLifecycle/SetBucketLifecycle", drpcEncoding_File_bucket_lifecycle_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCBucketLifecycleServer).
+					SetBucketLifecycle(
+						ctx,
+						in1.(*SetBucketLifecycleRequest),
+					)
+			}, DRPCBucketLifecycleServer.SetBucketLifecycle, true
+	default:
+		return "", nil, nil, nil, false
+	}
+}
+
+func DRPCRegisterBucketLifecycle(mux drpc.Mux, impl DRPCBucketLifecycleServer) error {
+	return mux.Register(impl, DRPCBucketLifecycleDescription{})
+}
+
+type DRPCBucketLifecycle_GetBucketLifecycleStream interface {
+	drpc.Stream
+	SendAndClose(*GetBucketLifecycleResponse) error
+}
+
+type drpcBucketLifecycle_GetBucketLifecycleStream struct {
+	drpc.Stream
+}
+
+func (x *drpcBucketLifecycle_GetBucketLifecycleStream) GetStream() drpc.Stream {
+	return x.Stream
+}
+
+func (x *drpcBucketLifecycle_GetBucketLifecycleStream) SendAndClose(m *GetBucketLifecycleResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_bucket_lifecycle_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCBucketLifecycle_SetBucketLifecycleStream interface {
+	drpc.Stream
+	SendAndClose(*SetBucketLifecycleResponse) error
+}
+
+type drpcBucketLifecycle_SetBucketLifecycleStream struct {
+	drpc.Stream
+}
+
+func (x *drpcBucketLifecycle_SetBucketLifecycleStream) GetStream() drpc.Stream {
+	return x.Stream
+}
+
+func (x *drpcBucketLifecycle_SetBucketLifecycleStream) SendAndClose(m *SetBucketLifecycleResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_bucket_lifecycle_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
@@ -37,6 +37,9 @@ type StreamID struct {
 	StreamId             []byte                   `protobuf:"bytes,10,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`
 	Placement            int32                    `protobuf:"varint,13,opt,name=placement,proto3" json:"placement,omitempty"`
 	Versioned            bool                     `protobuf:"varint,15,opt,name=versioned,proto3" json:"versioned,omitempty"`
+	ProjectId            []byte                   `protobuf:"bytes,16,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Redundancy           *pb.RedundancyScheme     `protobuf:"bytes,17,opt,name=redundancy,proto3" json:"redundancy,omitempty"`
+	MaxSegmentSize       int64                    `protobuf:"varint,18,opt,name=max_segment_size,json=maxSegmentSize,proto3" json:"max_segment_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
 	XXX_unrecognized     []byte                   `json:"-"`
 	XXX_sizecache        int32                    `json:"-"`
@@ -143,6 +146,27 @@ func (m *StreamID) GetVersioned() bool {
 	return false
 }
 
+func (m *StreamID) GetProjectId() []byte {
+	if m != nil {
+		return m.ProjectId
+	}
+	return nil
+}
+
+func (m *StreamID) GetRedundancy() *pb.RedundancyScheme {
+	if m != nil {
+		return m.Redundancy
+	}
+	return nil
+}
+
+func (m *StreamID) GetMaxSegmentSize() int64 {
+	if m != nil {
+		return m.MaxSegmentSize
+	}
+	return 0
+}
+
 type SegmentID struct {
 	StreamId             *StreamID                 `protobuf:"bytes,1,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`
 	PartNumber           int32                     `protobuf:"varint,2,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
@@ -230,41 +254,45 @@ func init() {
 func init() { proto.RegisterFile("metainfo_sat.proto", fileDescriptor_47c60bd892d94aaf) }
 
 var fileDescriptor_47c60bd892d94aaf = []byte{
-	// 563 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x52, 0xcb, 0x6e, 0xd3, 0x40,
-	0x14, 0xc5, 0xa4, 0x49, 0x9d, 0x49, 0x9a, 0x44, 0xd3, 0x14, 0x8d, 0x42, 0x51, 0xac, 0x22, 0x24,
-	0xb3, 0xb1, 0x51, 0xbb, 0x62, 0x49, 0x14, 0x16, 0xe1, 0xd5, 0xe2, 0xc0, 0x86, 0x8d, 0x35, 0xb6,
-	0x6f, 0xad, 0x69, 0x6d, 0x8f, 0x35, 0x33, 0x41, 0xcd, 0x92, 0x3f, 0x60, 0xcd, 0x17, 0xf1, 0x0d,
-	0x2c, 0xca, 0xaf, 0x20, 0x8f, 0x5f, 0x91, 0x68, 0x17, 0xb0, 0x9b, 0x7b, 0xee, 0x99, 0x3b, 0x77,
-	0xce, 0x39, 0x08, 0xa7, 0xa0, 0x28, 0xcb, 0x2e, 0xb9, 0x2f, 0xa9, 0x72, 0x72, 0xc1, 0x15, 0xc7,
-	0x58, 0x52, 0x05, 0x49, 0xc2, 0x14, 0x38, 0x75, 0x77, 0x36, 0x81, 0x2c, 0x14, 0xdb, 0x5c, 0x31,
-	0x9e, 0x95, 0xac, 0x19, 0x8a, 0x79, 0xcc, 0xab, 0xf3, 0x3c, 0xe6, 0x3c, 0x4e, 0xc0, 0xd5, 0x55,
-	0xb0, 0xb9, 0x74, 0x15, 0x4b, 0x41, 0x2a, 0x9a, 0xe6, 0x15, 0x61, 0x54, 0x0f, 0x2a, 0xeb, 0x93,
-	0x1f, 0x7b, 0xc8, 0x5c, 0x2b, 0x01, 0x34, 0x5d, 0x2d, 0xf1, 0x23, 0xd4, 0x0b, 0x36, 0xe1, 0x35,
-	0x28, 0x62, 0x58, 0x86, 0x3d, 0xf4, 0xaa, 0x0a, 0xbf, 0x40, 0xd3, 0xea, 0x55, 0x88, 0x7c, 0x1e,
-	0x5c, 0x41, 0xa8, 0xfc, 0x6b, 0xd8, 0x92, 0x87, 0x9a, 0x85, 0x9b, 0xde, 0xb9, 0x6e, 0xbd, 0x85,
-	0x2d, 0x26, 0x68, 0xff, 0x2b, 0x08, 0xc9, 0x78, 0x46, 0x3a, 0x96, 0x61, 0x77, 0xbc, 0xba, 0xc4,
-	0x9f, 0xd1, 0x51, 0xfb, 0x03, 0x3f, 0xa7, 0x82, 0xa6, 0xa0, 0x40, 0x48, 0x32, 0xb4, 0x0c, 0x7b,
-	0x70, 0x6a, 0x39, 0x3b, 0xff, 0x7b, 0xdd, 0x1c, 0x2f, 0x1a, 0x9e, 0x37, 0x85, 0x3b, 0x50, 0xbc,
-	0x42, 0x07, 0xa1, 0x00, 0xaa, 0x87, 0x46, 0x54, 0x01, 0xe9, 0xea, 0x71, 0x33, 0xa7, 0x14, 0xc4,
-	0xa9, 0x05, 0x71, 0x3e, 0xd5, 0x82, 0x2c, 0xcc, 0x9f, 0xb7, 0xf3, 0x07, 0xdf, 0x7f, 0xcf, 0x0d,
-	0x6f, 0x58, 0x5f, 0x5d, 0x52, 0x05, 0xf8, 0x3d, 0x1a, 0xc3, 0x4d, 0xce, 0xc4, 0xce, 0xb0, 0xde,
-	0x3f, 0x0c, 0x1b, 0xb5, 0x97, 0xf5, 0xb8, 0xe7, 0x68, 0x92, 0x6e, 0x12, 0xc5, 0x72, 0x2a, 0x54,
-	0x25, 0x1e, 0x19, 0x58, 0x86, 0x6d, 0x7a, 0xe3, 0x06, 0x2f, 0x85, 0xc3, 0x2e, 0x3a, 0x6c, 0x1c,
-	0xf7, 0x25, 0x8b, 0x33, 0xaa, 0x36, 0x02, 0x48, 0xbf, 0x94, 0xb9, 0x69, 0xad, 0xeb, 0x0e, 0x7e,
-	0x8c, 0xfa, 0x52, 0x9b, 0xe7, 0xb3, 0x88, 0x20, 0x4d, 0x33, 0x4b, 0x60, 0x15, 0xe1, 0x63, 0xd4,
-	0xcf, 0x13, 0x1a, 0x42, 0x0a, 0x99, 0x22, 0x07, 0x96, 0x61, 0x77, 0xbd, 0x16, 0x28, 0xba, 0x95,
-	0x25, 0x10, 0x91, 0xb1, 0xde, 0xa7, 0x05, 0xde, 0xec, 0x99, 0xa3, 0xc9, 0xf8, 0xe4, 0x5b, 0x07,
-	0xf5, 0xd7, 0x10, 0x17, 0xfc, 0xd5, 0x12, 0xbf, 0xdc, 0x7d, 0xcc, 0xd0, 0x8a, 0x1c, 0x3b, 0x7f,
-	0x27, 0xd4, 0xa9, 0xe3, 0xb4, 0xb3, 0xca, 0x1c, 0x0d, 0xf4, 0xf7, 0xb3, 0x4d, 0x1a, 0x80, 0xd0,
-	0xb9, 0xe9, 0x7a, 0xa8, 0x80, 0x3e, 0x68, 0x04, 0x4f, 0x51, 0x97, 0x65, 0x11, 0xdc, 0xe8, 0xb4,
-	0x74, 0xbd, 0xb2, 0xc0, 0x67, 0xe8, 0x40, 0x70, 0xae, 0xfc, 0x9c, 0x41, 0x08, 0xc5, 0xab, 0x85,
-	0xa9, 0xc3, 0xc5, 0xb8, 0xd0, 0xfa, 0xd7, 0xed, 0x7c, 0xff, 0xa2, 0xc0, 0x57, 0x4b, 0x6f, 0x50,
-	0xb0, 0xca, 0x22, 0xc2, 0x1f, 0xd1, 0x11, 0x17, 0x2c, 0x66, 0x19, 0x4d, 0x7c, 0x2e, 0x22, 0x10,
-	0x7e, 0xc2, 0x52, 0xa6, 0x24, 0xe9, 0x59, 0x1d, 0x7b, 0x70, 0xfa, 0xa4, 0x5d, 0xf4, 0x55, 0x14,
-	0x09, 0x90, 0x12, 0xa2, 0xf3, 0x82, 0xf6, 0xae, 0x60, 0x79, 0x87, 0xf5, 0xdd, 0x16, 0xbb, 0x23,
-	0x5c, 0xfb, 0xff, 0x1d, 0xae, 0x7b, 0x2c, 0x36, 0xef, 0xb3, 0x78, 0xf1, 0xec, 0xcb, 0x53, 0xa9,
-	0xb8, 0xb8, 0x72, 0x18, 0x77, 0xf5, 0xc1, 0x6d, 0x48, 0x2e, 0xcb, 0x14, 0x88, 0x8c, 0x26, 0x79,
-	0x10, 0xf4, 0xf4, 0x0e, 0x67, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x0b, 0x41, 0x2d, 0x1d, 0x47,
-	0x04, 0x00, 0x00,
+	// 638 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0xcd, 0x6e, 0xd3, 0x4c,
+	0x14, 0xfd, 0xfc, 0xe5, 0x4b, 0x9a, 0x4c, 0xd2, 0x24, 0xdf, 0xb4, 0x45, 0xa3, 0xfe, 0x28, 0x56,
+	0x11, 0x92, 0xd9, 0x38, 0xa8, 0x5d, 0x21, 0x56, 0x54, 0x61, 0x11, 0xfe, 0x5a, 0x1c, 0xd8, 0xb0,
+	0xb1, 0xc6, 0x9e, 0x5b, 0x33, 0xad, 0xed, 0xb1, 0x66, 0x26, 0x28, 0xe9, 0x8e, 0x37, 0xe0, 0x11,
+	0x78, 0x1c, 0x9e, 0x81, 0x45, 0x79, 0x15, 0xe4, 0xf1, 0x5f, 0x24, 0xda, 0x05, 0xec, 0xe6, 0x9e,
+	0x7b, 0xe6, 0xf8, 0xce, 0xb9, 0xc7, 0x08, 0x27, 0xa0, 0x29, 0x4f, 0x2f, 0x85, 0xaf, 0xa8, 0x76,
+	0x33, 0x29, 0xb4, 0xc0, 0x58, 0x51, 0x0d, 0x71, 0xcc, 0x35, 0xb8, 0x55, 0x77, 0x7f, 0x0c, 0x69,
+	0x28, 0xd7, 0x99, 0xe6, 0x22, 0x2d, 0x58, 0xfb, 0x28, 0x12, 0x91, 0x28, 0xcf, 0x93, 0x48, 0x88,
+	0x28, 0x86, 0xa9, 0xa9, 0x82, 0xe5, 0xe5, 0x54, 0xf3, 0x04, 0x94, 0xa6, 0x49, 0x56, 0x12, 0x86,
+	0x95, 0x50, 0x59, 0x8f, 0x32, 0xc1, 0x53, 0x0d, 0x92, 0x05, 0x05, 0x70, 0xfc, 0xad, 0x8d, 0xba,
+	0x0b, 0x2d, 0x81, 0x26, 0xf3, 0x19, 0x7e, 0x80, 0x3a, 0xc1, 0x32, 0xbc, 0x06, 0x4d, 0x2c, 0xdb,
+	0x72, 0x06, 0x5e, 0x59, 0xe1, 0x27, 0x68, 0xb7, 0x1c, 0x03, 0x98, 0x2f, 0x82, 0x2b, 0x08, 0xb5,
+	0x7f, 0x0d, 0x6b, 0xf2, 0xaf, 0x61, 0xe1, 0xba, 0x77, 0x6e, 0x5a, 0xaf, 0x60, 0x8d, 0x09, 0xda,
+	0xfa, 0x0c, 0x52, 0x71, 0x91, 0x92, 0x96, 0x6d, 0x39, 0x2d, 0xaf, 0x2a, 0xf1, 0x07, 0xb4, 0xd7,
+	0x3c, 0xc9, 0xcf, 0xa8, 0xa4, 0x09, 0x68, 0x90, 0x8a, 0x0c, 0x6c, 0xcb, 0xe9, 0x9f, 0xd8, 0xee,
+	0xc6, 0x83, 0x5f, 0xd4, 0xc7, 0x8b, 0x9a, 0xe7, 0xed, 0xc2, 0x1d, 0x28, 0x9e, 0xa3, 0xed, 0x50,
+	0x02, 0x35, 0xa2, 0x8c, 0x6a, 0x20, 0x6d, 0x23, 0xb7, 0xef, 0x16, 0x0e, 0xb9, 0x95, 0x43, 0xee,
+	0xfb, 0xca, 0xa1, 0xb3, 0xee, 0xf7, 0xdb, 0xc9, 0x3f, 0x5f, 0x7f, 0x4e, 0x2c, 0x6f, 0x50, 0x5d,
+	0x9d, 0x51, 0x0d, 0xf8, 0x0d, 0x1a, 0xc1, 0x2a, 0xe3, 0x72, 0x43, 0xac, 0xf3, 0x07, 0x62, 0xc3,
+	0xe6, 0xb2, 0x91, 0x7b, 0x8c, 0xc6, 0xc9, 0x32, 0xd6, 0x3c, 0xa3, 0x52, 0x97, 0xe6, 0x91, 0xbe,
+	0x6d, 0x39, 0x5d, 0x6f, 0x54, 0xe3, 0x85, 0x71, 0x78, 0x8a, 0x76, 0xea, 0x08, 0xf8, 0x8a, 0x47,
+	0x29, 0xd5, 0x4b, 0x09, 0xa4, 0x57, 0xd8, 0x5c, 0xb7, 0x16, 0x55, 0x07, 0x1f, 0xa0, 0x9e, 0x32,
+	0xcb, 0xf3, 0x39, 0x23, 0xc8, 0xd0, 0xba, 0x05, 0x30, 0x67, 0xf8, 0x10, 0xf5, 0xb2, 0x98, 0x86,
+	0x90, 0x40, 0xaa, 0xc9, 0xb6, 0x6d, 0x39, 0x6d, 0xaf, 0x01, 0xf2, 0x6e, 0xb9, 0x12, 0x60, 0x64,
+	0x64, 0xe6, 0x69, 0x00, 0x7c, 0x84, 0x50, 0x26, 0x85, 0x59, 0x34, 0x67, 0x64, 0x6c, 0x94, 0x7b,
+	0x25, 0x32, 0x67, 0xf8, 0x19, 0x42, 0x12, 0xd8, 0x32, 0x65, 0x34, 0x0d, 0xd7, 0xe4, 0x7f, 0xe3,
+	0xce, 0x81, 0xdb, 0x64, 0xcb, 0xab, 0x9b, 0x8b, 0xf0, 0x13, 0x24, 0xe0, 0x6d, 0xd0, 0xb1, 0x83,
+	0xc6, 0x09, 0x5d, 0xf9, 0x0a, 0xa2, 0x7c, 0x10, 0x5f, 0xf1, 0x1b, 0x20, 0xd8, 0x84, 0x64, 0x98,
+	0xd0, 0xd5, 0xa2, 0x80, 0x17, 0xfc, 0x06, 0x5e, 0xfe, 0xd7, 0x1d, 0x8e, 0x47, 0xc7, 0x5f, 0x5a,
+	0xa8, 0x57, 0xa2, 0xf3, 0x19, 0x7e, 0xba, 0xf9, 0x64, 0xcb, 0x7c, 0xf9, 0xd0, 0xfd, 0xfd, 0xc7,
+	0x71, 0xab, 0x50, 0x6f, 0x18, 0x32, 0x41, 0x7d, 0xb3, 0x84, 0x74, 0x99, 0x04, 0x20, 0x4d, 0x7a,
+	0xdb, 0x1e, 0xca, 0xa1, 0xb7, 0x06, 0xc1, 0xbb, 0xa8, 0xcd, 0x53, 0x06, 0x2b, 0x93, 0xd9, 0xb6,
+	0x57, 0x14, 0xf8, 0x14, 0x6d, 0x4b, 0x21, 0xb4, 0x9f, 0x71, 0x08, 0x21, 0xff, 0x6a, 0x1e, 0xad,
+	0xc1, 0xd9, 0x28, 0xdf, 0xf8, 0x8f, 0xdb, 0xc9, 0xd6, 0x45, 0x8e, 0xcf, 0x67, 0x5e, 0x3f, 0x67,
+	0x15, 0x05, 0xc3, 0xef, 0xd0, 0x9e, 0x90, 0x3c, 0xe2, 0x29, 0x8d, 0x7d, 0x21, 0x19, 0x48, 0x3f,
+	0xe6, 0x09, 0xd7, 0x8a, 0x74, 0xec, 0x96, 0xd3, 0x3f, 0x39, 0x6a, 0x06, 0x7d, 0xce, 0x98, 0x04,
+	0xa5, 0x80, 0x9d, 0xe7, 0xb4, 0xd7, 0x39, 0xcb, 0xdb, 0xa9, 0xee, 0x36, 0xd8, 0x1d, 0x11, 0xdf,
+	0xfa, 0xeb, 0x88, 0xdf, 0x13, 0xb4, 0xee, 0x7d, 0x41, 0x3b, 0x7b, 0xf4, 0xf1, 0xa1, 0xd2, 0x42,
+	0x5e, 0xb9, 0x5c, 0x4c, 0xcd, 0x61, 0x5a, 0x93, 0xa6, 0x66, 0xe9, 0x29, 0x8d, 0xb3, 0x20, 0xe8,
+	0x98, 0x19, 0x4e, 0x7f, 0x0d, 0x00, 0x09, 0xb7, 0x41, 0x47, 0xde, 0x04, 0x00, 0x00,
 }
@@ -0,0 +1,258 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: object_legal_hold.proto
+
+package internalpb
+
+import (
+	fmt "fmt"
+	math "math"
+
+	proto "github.com/gogo/protobuf/proto"
+
+	pb "storj.io/common/pb"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetObjectLegalHoldRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey   []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	ObjectVersion        []byte            `protobuf:"bytes,4,opt,name=object_version,json=objectVersion,proto3" json:"object_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetObjectLegalHoldRequest) Reset()         { *m = GetObjectLegalHoldRequest{} }
+func (m *GetObjectLegalHoldRequest) String() string { return proto.CompactTextString(m) }
+func (*GetObjectLegalHoldRequest) ProtoMessage()    {}
+func (*GetObjectLegalHoldRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c17b98af4b894aa1, []int{0}
+}
+func (m *GetObjectLegalHoldRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetObjectLegalHoldRequest.Unmarshal(m, b)
+}
+func (m *GetObjectLegalHoldRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetObjectLegalHoldRequest.Marshal(b, m, deterministic)
+}
+func (m *GetObjectLegalHoldRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetObjectLegalHoldRequest.Merge(m, src)
+}
+func (m *GetObjectLegalHoldRequest) XXX_Size() int {
+	return xxx_messageInfo_GetObjectLegalHoldRequest.Size(m)
+}
+func (m *GetObjectLegalHoldRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetObjectLegalHoldRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetObjectLegalHoldRequest proto.InternalMessageInfo
+
+func (m *GetObjectLegalHoldRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetObjectLegalHoldRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *GetObjectLegalHoldRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *GetObjectLegalHoldRequest) GetObjectVersion() []byte {
+	if m != nil {
+		return m.ObjectVersion
+	}
+	return nil
+}
+
+type GetObjectLegalHoldResponse struct {
+	Enabled              bool     `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetObjectLegalHoldResponse) Reset()         { *m = GetObjectLegalHoldResponse{} }
+func (m *GetObjectLegalHoldResponse) String() string { return proto.CompactTextString(m) }
+func (*GetObjectLegalHoldResponse) ProtoMessage()    {}
+func (*GetObjectLegalHoldResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c17b98af4b894aa1, []int{1}
+}
+func (m *GetObjectLegalHoldResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetObjectLegalHoldResponse.Unmarshal(m, b)
+}
+func (m *GetObjectLegalHoldResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetObjectLegalHoldResponse.Marshal(b, m, deterministic)
+}
+func (m *GetObjectLegalHoldResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetObjectLegalHoldResponse.Merge(m, src)
+}
+func (m *GetObjectLegalHoldResponse) XXX_Size() int {
+	return xxx_messageInfo_GetObjectLegalHoldResponse.Size(m)
+}
+func (m *GetObjectLegalHoldResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetObjectLegalHoldResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetObjectLegalHoldResponse proto.InternalMessageInfo
+
+func (m *GetObjectLegalHoldResponse) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+type SetObjectLegalHoldRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey   []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	ObjectVersion        []byte            `protobuf:"bytes,4,opt,name=object_version,json=objectVersion,proto3" json:"object_version,omitempty"`
+	Enabled              bool              `protobuf:"varint,5,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SetObjectLegalHoldRequest) Reset()         { *m = SetObjectLegalHoldRequest{} }
+func (m *SetObjectLegalHoldRequest) String() string { return proto.CompactTextString(m) }
+func (*SetObjectLegalHoldRequest) ProtoMessage()    {}
+func (*SetObjectLegalHoldRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c17b98af4b894aa1, []int{2}
+}
+func (m *SetObjectLegalHoldRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetObjectLegalHoldRequest.Unmarshal(m, b)
+}
+func (m *SetObjectLegalHoldRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetObjectLegalHoldRequest.Marshal(b, m, deterministic)
+}
+func (m *SetObjectLegalHoldRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetObjectLegalHoldRequest.Merge(m, src)
+}
+func (m *SetObjectLegalHoldRequest) XXX_Size() int {
+	return xxx_messageInfo_SetObjectLegalHoldRequest.Size(m)
+}
+func (m *SetObjectLegalHoldRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetObjectLegalHoldRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetObjectLegalHoldRequest proto.InternalMessageInfo
+
+func (m *SetObjectLegalHoldRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SetObjectLegalHoldRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *SetObjectLegalHoldRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *SetObjectLegalHoldRequest) GetObjectVersion() []byte {
+	if m != nil {
+		return m.ObjectVersion
+	}
+	return nil
+}
+
+func (m *SetObjectLegalHoldRequest) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+type SetObjectLegalHoldResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetObjectLegalHoldResponse) Reset()         { *m = SetObjectLegalHoldResponse{} }
+func (m *SetObjectLegalHoldResponse) String() string { return proto.CompactTextString(m) }
+func (*SetObjectLegalHoldResponse) ProtoMessage()    {}
+func (*SetObjectLegalHoldResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c17b98af4b894aa1, []int{3}
+}
+func (m *SetObjectLegalHoldResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetObjectLegalHoldResponse.Unmarshal(m, b)
+}
+func (m *SetObjectLegalHoldResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetObjectLegalHoldResponse.Marshal(b, m, deterministic)
+}
+func (m *SetObjectLegalHoldResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetObjectLegalHoldResponse.Merge(m, src)
+}
+func (m *SetObjectLegalHoldResponse) XXX_Size() int {
+	return xxx_messageInfo_SetObjectLegalHoldResponse.Size(m)
+}
+func (m *SetObjectLegalHoldResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetObjectLegalHoldResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetObjectLegalHoldResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*GetObjectLegalHoldRequest)(nil), "satellite.object_legal_hold.GetObjectLegalHoldRequest")
+	proto.RegisterType((*GetObjectLegalHoldResponse)(nil), "satellite.object_legal_hold.GetObjectLegalHoldResponse")
+	proto.RegisterType((*SetObjectLegalHoldRequest)(nil), "satellite.object_legal_hold.SetObjectLegalHoldRequest")
+	proto.RegisterType((*SetObjectLegalHoldResponse)(nil), "satellite.object_legal_hold.SetObjectLegalHoldResponse")
+}
+
+func init() { proto.RegisterFile("object_legal_hold.proto", fileDescriptor_c17b98af4b894aa1) }
+
+var fileDescriptor_c17b98af4b894aa1 = []byte{
+	// 326 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x93, 0xd1, 0x4a, 0xf3, 0x30,
+	0x14, 0xc7, 0xbf, 0xec, 0xd3, 0x29, 0x47, 0x9d, 0x10, 0xc4, 0x75, 0xd5, 0x8b, 0x51, 0x19, 0xec,
+	0x2a, 0x95, 0x09, 0xf3, 0xde, 0x1b, 0x07, 0x0a, 0x42, 0x0b, 0x5e, 0x78, 0x33, 0xd2, 0xf5, 0xe8,
+	0xba, 0xc5, 0xa4, 0x26, 0x99, 0xb0, 0x27, 0xf0, 0x49, 0x7c, 0x0c, 0x9f, 0xc3, 0xd7, 0x11, 0xd3,
+	0x3a, 0xd4, 0xb9, 0xc1, 0xbc, 0xf3, 0xae, 0xc9, 0xf9, 0x9d, 0xf2, 0xfb, 0x1f, 0x4e, 0xa0, 0xae,
+	0x92, 0x11, 0x0e, 0x6c, 0x5f, 0xe0, 0x1d, 0x17, 0xfd, 0xa1, 0x12, 0x29, 0xcb, 0xb5, 0xb2, 0x8a,
+	0x1e, 0x18, 0x6e, 0x51, 0x88, 0xcc, 0x22, 0x9b, 0x43, 0xfc, 0xda, 0x3d, 0x5a, 0x9e, 0xc9, 0x5b,
+	0x55, 0xc0, 0xc1, 0x0b, 0x81, 0xc6, 0x39, 0xda, 0x2b, 0x07, 0x5e, 0xbe, 0x73, 0x3d, 0x25, 0xd2,
+	0x08, 0x1f, 0x26, 0x68, 0x2c, 0x0d, 0xa1, 0x3a, 0x44, 0x9e, 0xa2, 0xf6, 0x48, 0x93, 0xb4, 0xb7,
+	0x3a, 0x75, 0x36, 0x6b, 0x2f, 0x91, 0x9e, 0x2b, 0x47, 0x25, 0x46, 0xf7, 0xa1, 0x9a, 0x4c, 0x06,
+	0x63, 0xb4, 0x5e, 0xa5, 0x49, 0xda, 0xdb, 0x51, 0x79, 0xa2, 0xc7, 0xb0, 0x87, 0x72, 0xa0, 0xa7,
+	0xb9, 0xc5, 0xb4, 0x5f, 0x5a, 0x8d, 0x71, 0xea, 0xfd, 0x77, 0x14, 0x9d, 0xd5, 0x0a, 0x8f, 0x0b,
+	0x9c, 0xd2, 0x16, 0xd4, 0x4a, 0xee, 0x11, 0xb5, 0xc9, 0x94, 0xf4, 0xd6, 0x1c, 0xbb, 0x53, 0xdc,
+	0x5e, 0x17, 0x97, 0x41, 0x17, 0xfc, 0x9f, 0xf4, 0x4d, 0xae, 0xa4, 0x41, 0xea, 0xc1, 0x06, 0x4a,
+	0x9e, 0x08, 0x4c, 0x5d, 0x80, 0xcd, 0xe8, 0xe3, 0x18, 0xbc, 0x12, 0x68, 0xc4, 0x7f, 0x37, 0xf7,
+	0xe7, 0x64, 0xeb, 0x5f, 0x93, 0x1d, 0x82, 0x1f, 0x2f, 0x9c, 0x48, 0xe7, 0xb9, 0x02, 0xbb, 0xdf,
+	0x6a, 0xf4, 0x89, 0x00, 0x9d, 0x1f, 0x22, 0xed, 0xb2, 0x25, 0x8b, 0xc4, 0x16, 0x2e, 0x8d, 0x7f,
+	0xba, 0x72, 0x5f, 0xe1, 0x16, 0xfc, 0x73, 0x26, 0xf1, 0xaa, 0x26, 0xf1, 0x2f, 0x4d, 0xe2, 0x25,
+	0x26, 0x67, 0xad, 0x9b, 0x23, 0x63, 0x95, 0x1e, 0xb1, 0x4c, 0x85, 0xee, 0x23, 0x9c, 0xfd, 0x2a,
+	0xcc, 0xa4, 0x45, 0x2d, 0xb9, 0xc8, 0x93, 0xa4, 0xea, 0x5e, 0xd1, 0xc9, 0xdb, 0x00, 0x45, 0xc8,
+	0xdb, 0xa8, 0x8d, 0x03, 0x00, 0x00,
+}
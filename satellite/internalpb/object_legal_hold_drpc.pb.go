@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go-drpc. DO NOT EDIT.
+// protoc-gen-go-drpc version: v0.0.35-0.20240709171858-0075ac871661
+// source: object_legal_hold.proto
+
+package internalpb
+
+import (
+	bytes "bytes"
+	context "context"
+	errors "errors"
+
+	jsonpb "github.com/gogo/protobuf/jsonpb"
+	proto "github.com/gogo/protobuf/proto"
+
+	drpc "storj.io/drpc"
+	drpcerr "storj.io/drpc/drpcerr"
+)
+
+type drpcEncoding_File_object_legal_hold_proto struct{}
+
+func (drpcEncoding_File_object_legal_hold_proto) Marshal(msg drpc.Message) ([]byte, error) {
+	return proto.Marshal(msg.(proto.Message))
+}
+
+func (drpcEncoding_File_object_legal_hold_proto) Unmarshal(buf []byte, msg drpc.Message) error {
+	return proto.Unmarshal(buf, msg.(proto.Message))
+}
+
+func (drpcEncoding_File_object_legal_hold_proto) JSONMarshal(msg drpc.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	err := new(jsonpb.Marshaler).Marshal(&buf, msg.(proto.Message))
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (drpcEncoding_File_object_legal_hold_proto) JSONUnmarshal(buf []byte, msg drpc.Message) error {
+	return jsonpb.Unmarshal(bytes.NewReader(buf), msg.(proto.Message))
+}
+
+type DRPCObjectLegalHoldClient interface {
+	DRPCConn() drpc.Conn
+
+	GetObjectLegalHold(ctx context.Context, in *GetObjectLegalHoldRequest) (*GetObjectLegalHoldResponse, error)
+	SetObjectLegalHold(ctx context.Context, in *SetObjectLegalHoldRequest) (*SetObjectLegalHoldResponse, error)
+}
+
+type drpcObjectLegalHoldClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCObjectLegalHoldClient(cc drpc.Conn) DRPCObjectLegalHoldClient {
+	return &drpcObjectLegalHoldClient{cc}
+}
+
+func (c *drpcObjectLegalHoldClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcObjectLegalHoldClient) GetObjectLegalHold(ctx context.Context, in *GetObjectLegalHoldRequest) (*GetObjectLegalHoldResponse, error) {
+	out := new(GetObjectLegalHoldResponse)
+	err := c.cc.Invoke(ctx, "/satellite.object_legal_hold.ObjectLegalHold/GetObjectLegalHold", drpcEncoding_File_object_legal_hold_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcObjectLegalHoldClient) SetObjectLegalHold(ctx context.Context, in *SetObjectLegalHoldRequest) (*SetObjectLegalHoldResponse, error) {
+	out := new(SetObjectLegalHoldResponse)
+	err := c.cc.Invoke(ctx, "/satellite.object_legal_hold.ObjectLegalHold/SetObjectLegalHold", drpcEncoding_File_object_legal_hold_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCObjectLegalHoldServer interface {
+	GetObjectLegalHold(context.Context, *GetObjectLegalHoldRequest) (*GetObjectLegalHoldResponse, error)
+	SetObjectLegalHold(context.Context, *SetObjectLegalHoldRequest) (*SetObjectLegalHoldResponse, error)
+}
+
+type DRPCObjectLegalHoldUnimplementedServer struct{}
+
+func (s *DRPCObjectLegalHoldUnimplementedServer) GetObjectLegalHold(context.Context, *GetObjectLegalHoldRequest) (*GetObjectLegalHoldResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCObjectLegalHoldUnimplementedServer) SetObjectLegalHold(context.Context, *SetObjectLegalHoldRequest) (*SetObjectLegalHoldResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+type DRPCObjectLegalHoldDescription struct{}
+
+func (DRPCObjectLegalHoldDescription) NumMethods() int { return 2 }
+
+func (DRPCObjectLegalHoldDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/satellite.object_legal_hold.ObjectLegalHold/GetObjectLegalHold", drpcEncoding_File_object_legal_hold_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCObjectLegalHoldServer).
+					GetObjectLegalHold(
+						ctx,
+						in1.(*GetObjectLegalHoldRequest),
+					)
+			}, DRPCObjectLegalHoldServer.GetObjectLegalHold, true
+	case 1:
+		return "/satellite.object_legal_hold.ObjectLegalHold/SetObjectLegalHold", drpcEncoding_File_object_legal_hold_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCObjectLegalHoldServer).
+					SetObjectLegalHold(
+						ctx,
+						in1.(*SetObjectLegalHoldRequest),
+					)
+			}, DRPCObjectLegalHoldServer.SetObjectLegalHold, true
+	default:
+		return "", nil, nil, nil, false
+	}
+}
+
+func DRPCRegisterObjectLegalHold(mux drpc.Mux, impl DRPCObjectLegalHoldServer) error {
+	return mux.Register(impl, DRPCObjectLegalHoldDescription{})
+}
+
+type DRPCObjectLegalHold_GetObjectLegalHoldStream interface {
+	drpc.Stream
+	SendAndClose(*GetObjectLegalHoldResponse) error
+}
+
+type drpcObjectLegalHold_GetObjectLegalHoldStream struct {
+	drpc.Stream
+}
+
+func (x *drpcObjectLegalHold_GetObjectLegalHoldStream) GetStream() drpc.Stream {
+	return x.Stream
+}
+
+func (x *drpcObjectLegalHold_GetObjectLegalHoldStream) SendAndClose(m *GetObjectLegalHoldResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_object_legal_hold_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCObjectLegalHold_SetObjectLegalHoldStream interface {
+	drpc.Stream
+	SendAndClose(*SetObjectLegalHoldResponse) error
+}
+
+type drpcObjectLegalHold_SetObjectLegalHoldStream struct {
+	drpc.Stream
+}
+
+func (x *drpcObjectLegalHold_SetObjectLegalHoldStream) GetStream() drpc.Stream {
+	return x.Stream
+}
+
+func (x *drpcObjectLegalHold_SetObjectLegalHoldStream) SendAndClose(m *SetObjectLegalHoldResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_object_legal_hold_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
@@ -0,0 +1,307 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: object_tags.proto
+
+package internalpb
+
+import (
+	fmt "fmt"
+	math "math"
+
+	proto "github.com/gogo/protobuf/proto"
+
+	pb "storj.io/common/pb"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetObjectTaggingRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey   []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	ObjectVersion        []byte            `protobuf:"bytes,4,opt,name=object_version,json=objectVersion,proto3" json:"object_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetObjectTaggingRequest) Reset()         { *m = GetObjectTaggingRequest{} }
+func (m *GetObjectTaggingRequest) String() string { return proto.CompactTextString(m) }
+func (*GetObjectTaggingRequest) ProtoMessage()    {}
+func (*GetObjectTaggingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_45a4ed5c15812858, []int{0}
+}
+func (m *GetObjectTaggingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetObjectTaggingRequest.Unmarshal(m, b)
+}
+func (m *GetObjectTaggingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetObjectTaggingRequest.Marshal(b, m, deterministic)
+}
+func (m *GetObjectTaggingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetObjectTaggingRequest.Merge(m, src)
+}
+func (m *GetObjectTaggingRequest) XXX_Size() int {
+	return xxx_messageInfo_GetObjectTaggingRequest.Size(m)
+}
+func (m *GetObjectTaggingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetObjectTaggingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetObjectTaggingRequest proto.InternalMessageInfo
+
+func (m *GetObjectTaggingRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetObjectTaggingRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *GetObjectTaggingRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *GetObjectTaggingRequest) GetObjectVersion() []byte {
+	if m != nil {
+		return m.ObjectVersion
+	}
+	return nil
+}
+
+type GetObjectTaggingResponse struct {
+	Tags                 []*ObjectTag `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetObjectTaggingResponse) Reset()         { *m = GetObjectTaggingResponse{} }
+func (m *GetObjectTaggingResponse) String() string { return proto.CompactTextString(m) }
+func (*GetObjectTaggingResponse) ProtoMessage()    {}
+func (*GetObjectTaggingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_45a4ed5c15812858, []int{1}
+}
+func (m *GetObjectTaggingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetObjectTaggingResponse.Unmarshal(m, b)
+}
+func (m *GetObjectTaggingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetObjectTaggingResponse.Marshal(b, m, deterministic)
+}
+func (m *GetObjectTaggingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetObjectTaggingResponse.Merge(m, src)
+}
+func (m *GetObjectTaggingResponse) XXX_Size() int {
+	return xxx_messageInfo_GetObjectTaggingResponse.Size(m)
+}
+func (m *GetObjectTaggingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetObjectTaggingResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetObjectTaggingResponse proto.InternalMessageInfo
+
+func (m *GetObjectTaggingResponse) GetTags() []*ObjectTag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type PutObjectTaggingRequest struct {
+	Header               *pb.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket               []byte            `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	EncryptedObjectKey   []byte            `protobuf:"bytes,3,opt,name=encrypted_object_key,json=encryptedObjectKey,proto3" json:"encrypted_object_key,omitempty"`
+	ObjectVersion        []byte            `protobuf:"bytes,4,opt,name=object_version,json=objectVersion,proto3" json:"object_version,omitempty"`
+	Tags                 []*ObjectTag      `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PutObjectTaggingRequest) Reset()         { *m = PutObjectTaggingRequest{} }
+func (m *PutObjectTaggingRequest) String() string { return proto.CompactTextString(m) }
+func (*PutObjectTaggingRequest) ProtoMessage()    {}
+func (*PutObjectTaggingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_45a4ed5c15812858, []int{2}
+}
+func (m *PutObjectTaggingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutObjectTaggingRequest.Unmarshal(m, b)
+}
+func (m *PutObjectTaggingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutObjectTaggingRequest.Marshal(b, m, deterministic)
+}
+func (m *PutObjectTaggingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutObjectTaggingRequest.Merge(m, src)
+}
+func (m *PutObjectTaggingRequest) XXX_Size() int {
+	return xxx_messageInfo_PutObjectTaggingRequest.Size(m)
+}
+func (m *PutObjectTaggingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutObjectTaggingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutObjectTaggingRequest proto.InternalMessageInfo
+
+func (m *PutObjectTaggingRequest) GetHeader() *pb.RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *PutObjectTaggingRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *PutObjectTaggingRequest) GetEncryptedObjectKey() []byte {
+	if m != nil {
+		return m.EncryptedObjectKey
+	}
+	return nil
+}
+
+func (m *PutObjectTaggingRequest) GetObjectVersion() []byte {
+	if m != nil {
+		return m.ObjectVersion
+	}
+	return nil
+}
+
+func (m *PutObjectTaggingRequest) GetTags() []*ObjectTag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type PutObjectTaggingResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutObjectTaggingResponse) Reset()         { *m = PutObjectTaggingResponse{} }
+func (m *PutObjectTaggingResponse) String() string { return proto.CompactTextString(m) }
+func (*PutObjectTaggingResponse) ProtoMessage()    {}
+func (*PutObjectTaggingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_45a4ed5c15812858, []int{3}
+}
+func (m *PutObjectTaggingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutObjectTaggingResponse.Unmarshal(m, b)
+}
+func (m *PutObjectTaggingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutObjectTaggingResponse.Marshal(b, m, deterministic)
+}
+func (m *PutObjectTaggingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutObjectTaggingResponse.Merge(m, src)
+}
+func (m *PutObjectTaggingResponse) XXX_Size() int {
+	return xxx_messageInfo_PutObjectTaggingResponse.Size(m)
+}
+func (m *PutObjectTaggingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutObjectTaggingResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutObjectTaggingResponse proto.InternalMessageInfo
+
+type ObjectTag struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ObjectTag) Reset()         { *m = ObjectTag{} }
+func (m *ObjectTag) String() string { return proto.CompactTextString(m) }
+func (*ObjectTag) ProtoMessage()    {}
+func (*ObjectTag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_45a4ed5c15812858, []int{4}
+}
+func (m *ObjectTag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ObjectTag.Unmarshal(m, b)
+}
+func (m *ObjectTag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ObjectTag.Marshal(b, m, deterministic)
+}
+func (m *ObjectTag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectTag.Merge(m, src)
+}
+func (m *ObjectTag) XXX_Size() int {
+	return xxx_messageInfo_ObjectTag.Size(m)
+}
+func (m *ObjectTag) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectTag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ObjectTag proto.InternalMessageInfo
+
+func (m *ObjectTag) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ObjectTag) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*GetObjectTaggingRequest)(nil), "satellite.object_tags.GetObjectTaggingRequest")
+	proto.RegisterType((*GetObjectTaggingResponse)(nil), "satellite.object_tags.GetObjectTaggingResponse")
+	proto.RegisterType((*PutObjectTaggingRequest)(nil), "satellite.object_tags.PutObjectTaggingRequest")
+	proto.RegisterType((*PutObjectTaggingResponse)(nil), "satellite.object_tags.PutObjectTaggingResponse")
+	proto.RegisterType((*ObjectTag)(nil), "satellite.object_tags.ObjectTag")
+}
+
+func init() { proto.RegisterFile("object_tags.proto", fileDescriptor_45a4ed5c15812858) }
+
+var fileDescriptor_45a4ed5c15812858 = []byte{
+	// 353 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x93, 0xcf, 0x6a, 0xea, 0x40,
+	0x14, 0xc6, 0xcd, 0xf5, 0x0f, 0x78, 0xbc, 0x57, 0xbc, 0x83, 0xf7, 0x1a, 0x5c, 0x85, 0x14, 0xc1,
+	0xd5, 0xa4, 0x68, 0x9f, 0xa0, 0x9b, 0x16, 0xba, 0x68, 0x08, 0xa5, 0x8b, 0x6e, 0x64, 0xa2, 0xa7,
+	0x69, 0x34, 0x9d, 0x49, 0x33, 0x33, 0x82, 0xcf, 0xd6, 0xa7, 0xea, 0xb6, 0xab, 0x92, 0x49, 0x18,
+	0x4a, 0xab, 0xa0, 0xcb, 0xee, 0x66, 0xce, 0xf9, 0x9d, 0x9c, 0xef, 0xfb, 0x92, 0xc0, 0x5f, 0x11,
+	0xaf, 0x71, 0xa9, 0x16, 0x8a, 0x25, 0x92, 0xe6, 0x85, 0x50, 0x82, 0xfc, 0x93, 0x4c, 0x61, 0x96,
+	0xa5, 0x0a, 0xe9, 0xa7, 0xe6, 0xb8, 0xff, 0x8c, 0x8a, 0xa5, 0xfc, 0x51, 0x54, 0x98, 0xff, 0xea,
+	0xc0, 0xe8, 0x0a, 0xd5, 0xad, 0x41, 0xee, 0x58, 0x92, 0xa4, 0x3c, 0x89, 0xf0, 0x45, 0xa3, 0x54,
+	0x24, 0x80, 0xce, 0x13, 0xb2, 0x15, 0x16, 0xae, 0xe3, 0x39, 0xd3, 0xde, 0x6c, 0x44, 0xed, 0x70,
+	0x8d, 0x5c, 0x9b, 0x76, 0x54, 0x63, 0xe4, 0x3f, 0x74, 0x62, 0xbd, 0xdc, 0xa0, 0x72, 0x7f, 0x79,
+	0xce, 0xf4, 0x77, 0x54, 0xdf, 0xc8, 0x39, 0x0c, 0x91, 0x2f, 0x8b, 0x5d, 0xae, 0x70, 0xb5, 0xa8,
+	0xd5, 0x6c, 0x70, 0xe7, 0x36, 0x0d, 0x45, 0x6c, 0xaf, 0x52, 0x71, 0x83, 0x3b, 0x32, 0x81, 0x7e,
+	0xcd, 0x6d, 0xb1, 0x90, 0xa9, 0xe0, 0x6e, 0xcb, 0xb0, 0x7f, 0xaa, 0xea, 0x7d, 0x55, 0xf4, 0x43,
+	0x70, 0xbf, 0x8b, 0x97, 0xb9, 0xe0, 0x12, 0xc9, 0x05, 0xb4, 0x4a, 0xc7, 0xae, 0xe3, 0x35, 0xa7,
+	0xbd, 0x99, 0x47, 0xf7, 0xe6, 0x41, 0xed, 0x6c, 0x64, 0x68, 0xff, 0xcd, 0x81, 0x51, 0xa8, 0x7f,
+	0x68, 0x1e, 0xd6, 0x73, 0xfb, 0x24, 0xcf, 0x63, 0x70, 0x43, 0xbd, 0x3f, 0x45, 0x7f, 0x0e, 0x5d,
+	0xdb, 0x20, 0x03, 0x68, 0x96, 0x32, 0x4b, 0xf7, 0xdd, 0xa8, 0x3c, 0x92, 0x21, 0xb4, 0xb7, 0x2c,
+	0xd3, 0x68, 0x0c, 0x76, 0xa3, 0xea, 0x32, 0x7b, 0x77, 0x00, 0xec, 0x94, 0x24, 0x1a, 0x06, 0x5f,
+	0xdf, 0x12, 0xa1, 0x07, 0xb4, 0x1d, 0xf8, 0x16, 0xc7, 0xc1, 0xd1, 0x7c, 0x2d, 0xbc, 0x51, 0xae,
+	0x0d, 0xf5, 0x91, 0x6b, 0x43, 0x7d, 0xda, 0xda, 0x83, 0x79, 0x35, 0x2e, 0x27, 0x0f, 0x67, 0x52,
+	0x89, 0x62, 0x4d, 0x53, 0x11, 0x98, 0x43, 0x60, 0x1f, 0x11, 0xa4, 0x5c, 0x61, 0xc1, 0x59, 0x96,
+	0xc7, 0x71, 0xc7, 0xfc, 0x7f, 0xf3, 0x8f, 0x01, 0x00, 0x96, 0x3a, 0x23, 0xb0, 0xbb, 0x03, 0x00,
+	0x00,
+}
@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go-drpc. DO NOT EDIT.
+// protoc-gen-go-drpc version: v0.0.35-0.20240709171858-0075ac871661
+// source: object_tags.proto
+
+package internalpb
+
+import (
+	bytes "bytes"
+	context "context"
+	errors "errors"
+
+	jsonpb "github.com/gogo/protobuf/jsonpb"
+	proto "github.com/gogo/protobuf/proto"
+
+	drpc "storj.io/drpc"
+	drpcerr "storj.io/drpc/drpcerr"
+)
+
+type drpcEncoding_File_object_tags_proto struct{}
+
+func (drpcEncoding_File_object_tags_proto) Marshal(msg drpc.Message) ([]byte, error) {
+	return proto.Marshal(msg.(proto.Message))
+}
+
+func (drpcEncoding_File_object_tags_proto) Unmarshal(buf []byte, msg drpc.Message) error {
+	return proto.Unmarshal(buf, msg.(proto.Message))
+}
+
+func (drpcEncoding_File_object_tags_proto) JSONMarshal(msg drpc.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	err := new(jsonpb.Marshaler).Marshal(&buf, msg.(proto.Message))
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (drpcEncoding_File_object_tags_proto) JSONUnmarshal(buf []byte, msg drpc.Message) error {
+	return jsonpb.Unmarshal(bytes.NewReader(buf), msg.(proto.Message))
+}
+
+type DRPCObjectTagsClient interface {
+	DRPCConn() drpc.Conn
+
+	GetObjectTagging(ctx context.Context, in *GetObjectTaggingRequest) (*GetObjectTaggingResponse, error)
+	PutObjectTagging(ctx context.Context, in *PutObjectTaggingRequest) (*PutObjectTaggingResponse, error)
+}
+
+type drpcObjectTagsClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCObjectTagsClient(cc drpc.Conn) DRPCObjectTagsClient {
+	return &drpcObjectTagsClient{cc}
+}
+
+func (c *drpcObjectTagsClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcObjectTagsClient) GetObjectTagging(ctx context.Context, in *GetObjectTaggingRequest) (*GetObjectTaggingResponse, error) {
+	out := new(GetObjectTaggingResponse)
+	err := c.cc.Invoke(ctx, "/satellite.object_tags.ObjectTags/GetObjectTagging", drpcEncoding_File_object_tags_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcObjectTagsClient) PutObjectTagging(ctx context.Context, in *PutObjectTaggingRequest) (*PutObjectTaggingResponse, error) {
+	out := new(PutObjectTaggingResponse)
+	err := c.cc.Invoke(ctx, "/satellite.object_tags.ObjectTags/PutObjectTagging", drpcEncoding_File_object_tags_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCObjectTagsServer interface {
+	GetObjectTagging(context.Context, *GetObjectTaggingRequest) (*GetObjectTaggingResponse, error)
+	PutObjectTagging(context.Context, *PutObjectTaggingRequest) (*PutObjectTaggingResponse, error)
+}
+
+type DRPCObjectTagsUnimplementedServer struct{}
+
+func (s *DRPCObjectTagsUnimplementedServer) GetObjectTagging(context.Context, *GetObjectTaggingRequest) (*GetObjectTaggingResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCObjectTagsUnimplementedServer) PutObjectTagging(context.Context, *PutObjectTaggingRequest) (*PutObjectTaggingResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+type DRPCObjectTagsDescription struct{}
+
+func (DRPCObjectTagsDescription) NumMethods() int { return 2 }
+
+func (DRPCObjectTagsDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/satellite.object_tags.ObjectTags/GetObjectTagging", drpcEncoding_File_object_tags_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCObjectTagsServer).
+					GetObjectTagging(
+						ctx,
+						in1.(*GetObjectTaggingRequest),
+					)
+			}, DRPCObjectTagsServer.GetObjectTagging, true
+	case 1:
+		return "/satellite.object_tags.ObjectTags/PutObjectTagging", drpcEncoding_File_object_tags_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCObjectTagsServer).
+					PutObjectTagging(
+						ctx,
+						in1.(*PutObjectTaggingRequest),
+					)
+			}, DRPCObjectTagsServer.PutObjectTagging, true
+	default:
+		return "", nil, nil, nil, false
+	}
+}
+
+func DRPCRegisterObjectTags(mux drpc.Mux, impl DRPCObjectTagsServer) error {
+	return mux.Register(impl, DRPCObjectTagsDescription{})
+}
+
+type DRPCObjectTags_GetObjectTaggingStream interface {
+	drpc.Stream
+	SendAndClose(*GetObjectTaggingResponse) error
+}
+
+type drpcObjectTags_GetObjectTaggingStream struct {
+	drpc.Stream
+}
+
+func (x *drpcObjectTags_GetObjectTaggingStream) GetStream() drpc.Stream {
+	return x.Stream
+}
+
+func (x *drpcObjectTags_GetObjectTaggingStream) SendAndClose(m *GetObjectTaggingResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_object_tags_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCObjectTags_PutObjectTaggingStream interface {
+	drpc.Stream
+	SendAndClose(*PutObjectTaggingResponse) error
+}
+
+type drpcObjectTags_PutObjectTaggingStream struct {
+	drpc.Stream
+}
+
+func (x *drpcObjectTags_PutObjectTaggingStream) GetStream() drpc.Stream {
+	return x.Stream
+}
+
+func (x *drpcObjectTags_PutObjectTaggingStream) SendAndClose(m *PutObjectTaggingResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_object_tags_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
@@ -10,6 +10,7 @@ import (
 	"cloud.google.com/go/spanner"
 	"golang.org/x/exp/slices"
 
+	"storj.io/common/uuid"
 	"storj.io/storj/shared/dbutil/spannerutil"
 	"storj.io/storj/shared/tagsql"
 )
@@ -24,6 +25,8 @@ type BucketTally struct {
 	TotalSegments int64
 	TotalBytes    int64
 
+	// MetadataSize is the size of the metadata as sent by the clients, without the
+	// satellite side encryption envelope.
 	MetadataSize int64
 }
 
@@ -51,6 +54,9 @@ func (opts *CollectBucketTallies) Verify() error {
 func (db *DB) CollectBucketTallies(ctx context.Context, opts CollectBucketTallies) (result []BucketTally, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	ctx, done := db.queries.track(ctx, "collect-bucket-tallies", uuid.UUID{})
+	defer done()
+
 	if err := opts.Verify(); err != nil {
 		return []BucketTally{}, err
 	}
@@ -80,7 +86,11 @@ func (p *PostgresAdapter) CollectBucketTallies(ctx context.Context, opts Collect
 	err = withRows(p.db.QueryContext(ctx, `
 			SELECT
 				project_id, bucket_name,
-				SUM(total_encrypted_size), SUM(segment_count), COALESCE(SUM(length(encrypted_metadata)), 0),
+				SUM(total_encrypted_size), SUM(segment_count),
+				COALESCE(SUM(
+					length(encrypted_metadata) -
+					CASE WHEN encrypted_metadata_key_id <> 0 THEN $6::INT8 ELSE 0 END
+				), 0),
 				count(*), count(*) FILTER (WHERE status = `+statusPending+`)
 			FROM objects
 			`+LimitedAsOfSystemTime(p.impl, time.Now(), opts.AsOfSystemTime, opts.AsOfSystemInterval)+`
@@ -88,7 +98,8 @@ func (p *PostgresAdapter) CollectBucketTallies(ctx context.Context, opts Collect
 			(expires_at IS NULL OR expires_at > $5)
 			GROUP BY (project_id, bucket_name)
 			ORDER BY (project_id, bucket_name) ASC
-		`, opts.From.ProjectID, opts.From.BucketName, opts.To.ProjectID, opts.To.BucketName, opts.Now))(func(rows tagsql.Rows) error {
+		`, opts.From.ProjectID, opts.From.BucketName, opts.To.ProjectID, opts.To.BucketName, opts.Now,
+		envelopeOverhead))(func(rows tagsql.Rows) error {
 		for rows.Next() {
 			var bucketTally BucketTally
 
@@ -118,7 +129,9 @@ func (s *SpannerAdapter) CollectBucketTallies(ctx context.Context, opts CollectB
 	return spannerutil.CollectRows(s.client.Single().Query(ctx, spanner.Statement{
 		SQL: `
 			WITH counts AS (
-				SELECT project_id, bucket_name, segment_count, total_encrypted_size, length(encrypted_metadata) AS encrypted_bytes, status
+				SELECT project_id, bucket_name, segment_count, total_encrypted_size,
+					length(encrypted_metadata) - IF(encrypted_metadata_key_id <> 0, @envelope_overhead, 0) AS encrypted_bytes,
+					status
 				FROM objects
 				WHERE
 					` + TupleGreaterThanSQL([]string{"project_id", "bucket_name"}, []string{"@from_project_id", "@from_bucket_name"}, true) + `
@@ -137,11 +150,12 @@ func (s *SpannerAdapter) CollectBucketTallies(ctx context.Context, opts CollectB
 			ORDER BY project_id ASC, bucket_name ASC
 		`,
 		Params: map[string]any{
-			"from_project_id":  opts.From.ProjectID,
-			"from_bucket_name": opts.From.BucketName,
-			"to_project_id":    opts.To.ProjectID,
-			"to_bucket_name":   opts.To.BucketName,
-			"when":             opts.Now,
+			"from_project_id":   opts.From.ProjectID,
+			"from_bucket_name":  opts.From.BucketName,
+			"to_project_id":     opts.To.ProjectID,
+			"to_bucket_name":    opts.To.BucketName,
+			"when":              opts.Now,
+			"envelope_overhead": int64(envelopeOverhead),
 		},
 	}), func(row *spanner.Row, bucketTally *BucketTally) error {
 		return row.Columns(
@@ -24,8 +24,12 @@ type Adapter interface {
 
 	BeginObjectNextVersion(context.Context, BeginObjectNextVersion, *Object) error
 	GetObjectLastCommitted(ctx context.Context, opts GetObjectLastCommitted) (Object, error)
+	GetObjectsLastCommitted(ctx context.Context, locations []ObjectLocation) ([]Object, error)
+	GetObjectWithSegment(ctx context.Context, opts GetObjectWithSegment) (_ ObjectWithSegment, aliasPieces AliasPieces, err error)
+	GetObjectVersionsAsOf(ctx context.Context, opts GetObjectVersionsAsOf, aliasCache *NodeAliasCache) ([]ObjectVersionAsOf, error)
 	IterateLoopSegments(ctx context.Context, aliasCache *NodeAliasCache, opts IterateLoopSegments, fn func(context.Context, LoopSegmentsIterator) error) error
 	PendingObjectExists(ctx context.Context, opts BeginSegment) (exists bool, err error)
+	StreamIDInUse(ctx context.Context, streamID uuid.UUID) (inUse bool, err error)
 	CommitPendingObjectSegment(ctx context.Context, opts CommitSegment, aliasPieces AliasPieces) error
 	CommitInlineSegment(ctx context.Context, opts CommitInlineSegment) error
 	TestingBeginObjectExactVersion(ctx context.Context, opts BeginObjectExactVersion, object *Object) error
@@ -35,6 +39,16 @@ type Adapter interface {
 	SetObjectExactVersionRetention(ctx context.Context, opts SetObjectExactVersionRetention) error
 	SetObjectLastCommittedRetention(ctx context.Context, opts SetObjectLastCommittedRetention) error
 
+	GetObjectExactVersionTags(ctx context.Context, opts GetObjectExactVersionTags) (tags ObjectTags, err error)
+	GetObjectLastCommittedTags(ctx context.Context, opts GetObjectLastCommittedTags) (tags ObjectTags, err error)
+	SetObjectExactVersionTags(ctx context.Context, opts SetObjectExactVersionTags) (affected int64, err error)
+	SetObjectLastCommittedTags(ctx context.Context, opts SetObjectLastCommittedTags) (affected int64, err error)
+
+	GetObjectExactVersionLegalHold(ctx context.Context, opts GetObjectExactVersionLegalHold) (enabled bool, err error)
+	GetObjectLastCommittedLegalHold(ctx context.Context, opts GetObjectLastCommittedLegalHold) (enabled bool, err error)
+	SetObjectExactVersionLegalHold(ctx context.Context, opts SetObjectExactVersionLegalHold) error
+	SetObjectLastCommittedLegalHold(ctx context.Context, opts SetObjectLastCommittedLegalHold) error
+
 	GetTableStats(ctx context.Context, opts GetTableStats) (result TableStats, err error)
 	UpdateTableStats(ctx context.Context) error
 	BucketEmpty(ctx context.Context, opts BucketEmpty) (empty bool, err error)
@@ -42,6 +56,8 @@ type Adapter interface {
 	WithTx(ctx context.Context, f func(context.Context, TransactionAdapter) error) error
 
 	CollectBucketTallies(ctx context.Context, opts CollectBucketTallies) (result []BucketTally, err error)
+	GetBucketStats(ctx context.Context, opts GetBucketStats) (BucketStats, error)
+	UpdateBucketStats(ctx context.Context, opts UpdateBucketStats) error
 
 	GetSegmentByPosition(ctx context.Context, opts GetSegmentByPosition) (segment Segment, aliasPieces AliasPieces, err error)
 	GetObjectExactVersion(ctx context.Context, opts GetObjectExactVersion) (_ Object, err error)
@@ -49,37 +65,61 @@ type Adapter interface {
 	GetLatestObjectLastSegment(ctx context.Context, opts GetLatestObjectLastSegment) (segment Segment, aliasPieces AliasPieces, err error)
 
 	ListObjects(ctx context.Context, opts ListObjects) (result ListObjectsResult, err error)
+	ListObjectChanges(ctx context.Context, opts ListObjectChanges) (result ListObjectChangesResult, err error)
 	ListSegments(ctx context.Context, opts ListSegments, aliasCache *NodeAliasCache) (result ListSegmentsResult, err error)
+	ListStreamsSegments(ctx context.Context, opts ListStreamsSegments, aliasCache *NodeAliasCache) (segments []RawSegment, err error)
 	ListStreamPositions(ctx context.Context, opts ListStreamPositions) (result ListStreamPositionsResult, err error)
 	ListVerifySegments(ctx context.Context, opts ListVerifySegments) (segments []VerifySegment, err error)
 	ListBucketsStreamIDs(ctx context.Context, opts ListBucketsStreamIDs, bucketNamesBytes [][]byte, projectIDs []uuid.UUID) (result ListBucketsStreamIDsResult, err error)
 
 	UpdateSegmentPieces(ctx context.Context, opts UpdateSegmentPieces, oldPieces, newPieces AliasPieces) (resultPieces AliasPieces, err error)
+	UpdateSegmentHealthyPieces(ctx context.Context, opts UpdateSegmentHealthyPieces) error
+	ListLowHealthSegments(ctx context.Context, opts ListLowHealthSegments) (segments []LowHealthSegment, err error)
 	UpdateObjectLastCommittedMetadata(ctx context.Context, opts UpdateObjectLastCommittedMetadata) (affected int64, err error)
+	UpdateObjectExactVersionMetadata(ctx context.Context, opts UpdateObjectExactVersionMetadata) (affected int64, err error)
 
-	DeleteObjectExactVersion(ctx context.Context, opts DeleteObjectExactVersion) (result DeleteObjectResult, err error)
+	DeleteObjectExactVersion(ctx context.Context, opts DeleteObjectExactVersion) (result DeleteObjectResult, deleted []deletedSegment, err error)
 	DeletePendingObject(ctx context.Context, opts DeletePendingObject) (result DeleteObjectResult, err error)
+	DeleteObjectVersions(ctx context.Context, opts DeleteObjectVersions) (removed []Object, locked []DeleteObjectVersionsItem, err error)
 
-	DeleteObjectLastCommittedPlain(ctx context.Context, opts DeleteObjectLastCommitted) (result DeleteObjectResult, err error)
+	DeleteObjectLastCommittedPlain(ctx context.Context, opts DeleteObjectLastCommitted) (result DeleteObjectResult, deleted []deletedSegment, err error)
 	DeleteObjectLastCommittedSuspended(ctx context.Context, opts DeleteObjectLastCommitted, deleterMarkerStreamID uuid.UUID) (result DeleteObjectResult, err error)
 	DeleteObjectLastCommittedVersioned(ctx context.Context, opts DeleteObjectLastCommitted, deleterMarkerStreamID uuid.UUID) (result DeleteObjectResult, err error)
+	ListDeleteMarkers(ctx context.Context, opts ListDeleteMarkers) (result ListDeleteMarkersResult, err error)
+	DeleteDeleteMarker(ctx context.Context, opts DeleteDeleteMarker) (result DeleteObjectResult, err error)
 
 	FindExpiredObjects(ctx context.Context, opts DeleteExpiredObjects, startAfter ObjectStream, batchSize int) (expiredObjects []ObjectStream, err error)
-	DeleteObjectsAndSegments(ctx context.Context, objects []ObjectStream) (objectsDeleted, segmentsDeleted int64, err error)
+	DeleteObjectsAndSegments(ctx context.Context, objects []ObjectStream, recordDeleteEvents map[BucketLocation]bool) (objectsDeleted, segmentsDeleted int64, err error)
 	FindZombieObjects(ctx context.Context, opts DeleteZombieObjects, startAfter ObjectStream, batchSize int) (objects []ObjectStream, err error)
 	DeleteInactiveObjectsAndSegments(ctx context.Context, objects []ObjectStream, opts DeleteZombieObjects) (objectsDeleted, segmentsDeleted int64, err error)
 	DeleteBucketObjects(ctx context.Context, opts DeleteBucketObjects) (deletedObjectCount, deletedSegmentCount int64, err error)
+	DeleteStreamSegmentsBatch(ctx context.Context, opts DeleteStreamSegments) (deleted int64, last SegmentPosition, err error)
+	ListObjectStreams(ctx context.Context, opts FindOrphanedStreams, startAfter ObjectStream, batchSize int) (streams []ObjectStream, err error)
+	DeleteOrphanedSegments(ctx context.Context, opts DeleteOrphanedSegments) (deleted int64, err error)
+	RecalculateObjectTotalSizes(ctx context.Context, opts RecalculateObjectTotalSizes) (updated int64, err error)
+	ListStoredObjectMetadata(ctx context.Context, startAfter ObjectStream, batchSize int) (objects []StoredObjectMetadata, err error)
+	UpdateStoredObjectMetadata(ctx context.Context, object StoredObjectMetadata, encryptedMetadata, encryptedMetadataEncryptedKey []byte, encryptedMetadataKeyID uint16) (updated bool, err error)
+	GetMetadataRotationCursor(ctx context.Context, activeKeyID uint16) (MetadataRotationCursor, error)
+	SaveMetadataRotationCursor(ctx context.Context, cursor MetadataRotationCursor) error
+	ListDeferredSegmentDeletions(ctx context.Context, opts ListDeferredSegmentDeletions) (deletions []DeferredSegmentDeletion, err error)
+	RemoveDeferredSegmentDeletion(ctx context.Context, streamID uuid.UUID) (err error)
+	ListDeferredSegmentDeletionStreamIDs(ctx context.Context, afterStreamID, endStreamID uuid.UUID, limit int) (streamIDs []uuid.UUID, err error)
+	GetDeferredSegmentDeletionBacklog(ctx context.Context) (backlog DeferredSegmentDeletionBacklog, err error)
+	ListDeleteEvents(ctx context.Context, opts ListDeleteEvents) (events []DeleteEvent, err error)
+	RemoveDeleteEvents(ctx context.Context, events []DeleteEvent) (err error)
 
 	EnsureNodeAliases(ctx context.Context, opts EnsureNodeAliases) error
 	ListNodeAliases(ctx context.Context) (entries []NodeAliasEntry, err error)
 	GetNodeAliasEntries(ctx context.Context, opts GetNodeAliasEntries) (entries []NodeAliasEntry, err error)
 	GetStreamPieceCountByAlias(ctx context.Context, opts GetStreamPieceCountByNodeID) (result map[NodeAlias]int64, err error)
 
+	ImportObjects(ctx context.Context, objects []importObject) (err error)
+	ImportSegments(ctx context.Context, aliasCache *NodeAliasCache, segments []RawSegment) (err error)
+
 	doNextQueryAllVersionsWithStatus(ctx context.Context, it *objectsIterator) (_ tagsql.Rows, err error)
 	doNextQueryAllVersionsWithStatusAscending(ctx context.Context, it *objectsIterator) (_ tagsql.Rows, err error)
 	doNextQueryPendingObjectsByKey(ctx context.Context, it *objectsIterator) (_ tagsql.Rows, err error)
 
-	TestingBatchInsertSegments(ctx context.Context, aliasCache *NodeAliasCache, segments []RawSegment) (err error)
 	TestingGetAllObjects(ctx context.Context) (_ []RawObject, err error)
 	TestingGetAllSegments(ctx context.Context, aliasCache *NodeAliasCache) (_ []RawSegment, err error)
 	TestingDeleteAll(ctx context.Context) (err error)
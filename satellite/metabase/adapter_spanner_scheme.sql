@@ -16,8 +16,12 @@ CREATE TABLE IF NOT EXISTS segments
     inline_data         BYTES(MAX),
     remote_alias_pieces BYTES(MAX),
     placement           INT64,
+    healthy_pieces      INT64,
+    checksum            BYTES(MAX),
 ) PRIMARY KEY(stream_id, position);
 
+CREATE NULL_FILTERED INDEX IF NOT EXISTS segments_healthy_pieces_index ON segments(healthy_pieces);
+
 CREATE TABLE IF NOT EXISTS objects
 (
     project_id                       BYTES(16) NOT NULL,
@@ -39,8 +43,15 @@ CREATE TABLE IF NOT EXISTS objects
     zombie_deletion_deadline         TIMESTAMP,
     retention_mode                   INT64,
     retain_until                     TIMESTAMP,
+    tags                             BYTES(MAX),
+    legal_hold                       BOOL      NOT NULL DEFAULT (false),
+    checksum                         BYTES(MAX),
+    prepared_commit_deadline         TIMESTAMP,
+    encrypted_metadata_key_id        INT64     NOT NULL DEFAULT (0),
 ) PRIMARY KEY (project_id, bucket_name, object_key, version);
 
+CREATE INDEX IF NOT EXISTS objects_project_id_bucket_name_created_at_index ON objects(project_id, bucket_name, created_at);
+
 CREATE TABLE IF NOT EXISTS node_aliases
 (
     node_id     BYTES(32)  NOT NULL,
@@ -48,3 +59,48 @@ CREATE TABLE IF NOT EXISTS node_aliases
 ) PRIMARY KEY (node_id);
 
 CREATE UNIQUE INDEX IF NOT EXISTS node_aliases_node_alias_key ON node_aliases(node_alias);
+
+CREATE TABLE IF NOT EXISTS deferred_segment_deletions
+(
+    stream_id     BYTES(16) NOT NULL,
+    project_id    BYTES(16) NOT NULL,
+    segment_count INT64     NOT NULL DEFAULT (0),
+    created_at    TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP()),
+) PRIMARY KEY (stream_id);
+
+CREATE TABLE IF NOT EXISTS bucket_stats
+(
+    project_id           BYTES(16)   NOT NULL,
+    bucket_name          STRING(MAX) NOT NULL,
+    object_count         INT64       NOT NULL DEFAULT (0),
+    pending_object_count INT64       NOT NULL DEFAULT (0),
+    total_segments       INT64       NOT NULL DEFAULT (0),
+    total_bytes          INT64       NOT NULL DEFAULT (0),
+    metadata_size        INT64       NOT NULL DEFAULT (0),
+    updated_at           TIMESTAMP   NOT NULL DEFAULT (CURRENT_TIMESTAMP()),
+) PRIMARY KEY (project_id, bucket_name);
+
+CREATE TABLE IF NOT EXISTS metadata_rotation_cursors
+(
+    active_key_id INT64       NOT NULL,
+    project_id    BYTES(16)   NOT NULL,
+    bucket_name   STRING(MAX) NOT NULL,
+    object_key    BYTES(MAX)  NOT NULL,
+    version       INT64       NOT NULL,
+    finished      BOOL        NOT NULL DEFAULT (false),
+    updated_at    TIMESTAMP   NOT NULL DEFAULT (CURRENT_TIMESTAMP()),
+) PRIMARY KEY (active_key_id);
+
+CREATE TABLE IF NOT EXISTS object_delete_events
+(
+    project_id       BYTES(16)   NOT NULL,
+    bucket_name      STRING(MAX) NOT NULL,
+    object_key       BYTES(MAX)  NOT NULL,
+    version          INT64       NOT NULL,
+    stream_id        BYTES(16)   NOT NULL,
+    kind             INT64       NOT NULL,
+    total_plain_size INT64       NOT NULL DEFAULT (0),
+    deleted_at       TIMESTAMP   NOT NULL DEFAULT (CURRENT_TIMESTAMP()),
+) PRIMARY KEY (project_id, bucket_name, object_key, version, stream_id, kind);
+
+CREATE INDEX IF NOT EXISTS object_delete_events_deleted_at_index ON object_delete_events (deleted_at);
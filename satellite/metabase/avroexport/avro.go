@@ -0,0 +1,378 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package avroexport
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/zeebo/errs"
+)
+
+// This file implements the subset of the Avro object container file format
+// (https://avro.apache.org/docs/1.11.1/specification/#object-container-files)
+// needed for exporting metabase rows: uncompressed blocks of records with a
+// fixed schema.
+
+var containerMagic = []byte{'O', 'b', 'j', 1}
+
+const (
+	syncMarkerSize = 16
+	// blockRecords is the number of records written in a single block.
+	blockRecords = 1000
+	// maxBlockSize limits the memory used for reading a single block.
+	maxBlockSize = 256 << 20
+)
+
+// encoder appends Avro binary encoded values to a buffer.
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) long(v int64) {
+	// Avro uses the same zig-zag variable length encoding as encoding/binary.
+	e.buf = binary.AppendVarint(e.buf, v)
+}
+
+func (e *encoder) bytes(v []byte) {
+	e.long(int64(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+func (e *encoder) string(v string) {
+	e.long(int64(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+func (e *encoder) boolean(v bool) {
+	if v {
+		e.buf = append(e.buf, 1)
+	} else {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+// optionalBytes encodes a ["null", "bytes"] union, a nil slice is encoded as null.
+func (e *encoder) optionalBytes(v []byte) {
+	if v == nil {
+		e.long(0)
+		return
+	}
+	e.long(1)
+	e.bytes(v)
+}
+
+// optionalLong encodes a ["null", "long"] union.
+func (e *encoder) optionalLong(v int64, valid bool) {
+	if !valid {
+		e.long(0)
+		return
+	}
+	e.long(1)
+	e.long(v)
+}
+
+// arrayLen starts an array of n items. The items have to be followed by arrayEnd.
+func (e *encoder) arrayLen(n int) {
+	if n > 0 {
+		e.long(int64(n))
+	}
+}
+
+func (e *encoder) arrayEnd() { e.long(0) }
+
+// decoder reads Avro binary encoded values from a buffer.
+// The first error is kept and makes all the following reads return zero values.
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) fail(format string, args ...interface{}) {
+	if d.err == nil {
+		d.err = Error.New(format, args...)
+	}
+}
+
+func (d *decoder) long() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		d.fail("invalid long")
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) int32() int32 {
+	v := d.long()
+	if v < math.MinInt32 || v > math.MaxInt32 {
+		d.fail("int out of range: %d", v)
+		return 0
+	}
+	return int32(v)
+}
+
+func (d *decoder) bytes() []byte {
+	n := d.long()
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || n > int64(len(d.buf)) {
+		d.fail("invalid bytes length: %d", n)
+		return nil
+	}
+	v := make([]byte, n)
+	copy(v, d.buf[:n])
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) boolean() bool {
+	if d.err != nil {
+		return false
+	}
+	if len(d.buf) == 0 || d.buf[0] > 1 {
+		d.fail("invalid boolean")
+		return false
+	}
+	v := d.buf[0] == 1
+	d.buf = d.buf[1:]
+	return v
+}
+
+func (d *decoder) string() string {
+	return string(d.bytes())
+}
+
+func (d *decoder) unionIndex() int64 {
+	index := d.long()
+	if index != 0 && index != 1 {
+		d.fail("invalid union index: %d", index)
+	}
+	return index
+}
+
+func (d *decoder) optionalBytes() []byte {
+	if d.unionIndex() != 1 {
+		return nil
+	}
+	return d.bytes()
+}
+
+func (d *decoder) optionalLong() (v int64, valid bool) {
+	if d.unionIndex() != 1 {
+		return 0, false
+	}
+	return d.long(), d.err == nil
+}
+
+// array calls fn for every item of an array.
+func (d *decoder) array(fn func()) {
+	for d.err == nil {
+		n := d.long()
+		if n == 0 {
+			return
+		}
+		if n < 0 {
+			// a negative count is followed by the size of the block in bytes.
+			n = -n
+			_ = d.long()
+		}
+		for i := int64(0); i < n && d.err == nil; i++ {
+			fn()
+		}
+	}
+}
+
+// containerWriter writes records to an Avro object container file.
+type containerWriter struct {
+	w     io.Writer
+	sync  [syncMarkerSize]byte
+	block encoder
+	count int64
+}
+
+// newContainerWriter writes the file header for the schema.
+func newContainerWriter(w io.Writer, schema string) (_ *containerWriter, err error) {
+	cw := &containerWriter{w: w}
+	if _, err := rand.Read(cw.sync[:]); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var header encoder
+	header.buf = append(header.buf, containerMagic...)
+	header.arrayLen(2)
+	header.string("avro.schema")
+	header.bytes([]byte(schema))
+	header.string("avro.codec")
+	header.bytes([]byte("null"))
+	header.arrayEnd()
+	header.buf = append(header.buf, cw.sync[:]...)
+
+	if _, err := w.Write(header.buf); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return cw, nil
+}
+
+// append encodes a single record with fn.
+func (cw *containerWriter) append(fn func(e *encoder)) error {
+	fn(&cw.block)
+	cw.count++
+	if cw.count >= blockRecords {
+		return cw.flush()
+	}
+	return nil
+}
+
+// flush writes the buffered records as a block.
+func (cw *containerWriter) flush() error {
+	if cw.count == 0 {
+		return nil
+	}
+
+	var block encoder
+	block.long(cw.count)
+	block.long(int64(len(cw.block.buf)))
+	block.buf = append(block.buf, cw.block.buf...)
+	block.buf = append(block.buf, cw.sync[:]...)
+
+	cw.block.buf = cw.block.buf[:0]
+	cw.count = 0
+
+	_, err := cw.w.Write(block.buf)
+	return Error.Wrap(err)
+}
+
+// Close writes the remaining records. It doesn't close the underlying writer.
+func (cw *containerWriter) Close() error {
+	return cw.flush()
+}
+
+// containerReader reads records from an Avro object container file.
+type containerReader struct {
+	r         *bufio.Reader
+	sync      [syncMarkerSize]byte
+	block     decoder
+	remaining int64
+}
+
+// newContainerReader reads the file header and verifies it uses the schema.
+func newContainerReader(r io.Reader, schema string) (_ *containerReader, err error) {
+	cr := &containerReader{r: bufio.NewReader(r)}
+
+	magic := make([]byte, len(containerMagic))
+	if _, err := io.ReadFull(cr.r, magic); err != nil {
+		return nil, Error.New("unable to read header: %w", err)
+	}
+	if !bytes.Equal(magic, containerMagic) {
+		return nil, Error.New("not an avro object container file")
+	}
+
+	metadata := map[string][]byte{}
+	for {
+		n, err := binary.ReadVarint(cr.r)
+		if err != nil {
+			return nil, Error.New("unable to read header: %w", err)
+		}
+		if n == 0 {
+			break
+		}
+		if n < 0 {
+			n = -n
+			if _, err := binary.ReadVarint(cr.r); err != nil {
+				return nil, Error.New("unable to read header: %w", err)
+			}
+		}
+		for i := int64(0); i < n; i++ {
+			key, err := cr.readBytes()
+			if err != nil {
+				return nil, err
+			}
+			value, err := cr.readBytes()
+			if err != nil {
+				return nil, err
+			}
+			metadata[string(key)] = value
+		}
+	}
+
+	if codec, ok := metadata["avro.codec"]; ok && string(codec) != "null" {
+		return nil, Error.New("unsupported codec %q", codec)
+	}
+	if string(metadata["avro.schema"]) != schema {
+		return nil, Error.New("unexpected schema %q", metadata["avro.schema"])
+	}
+
+	if _, err := io.ReadFull(cr.r, cr.sync[:]); err != nil {
+		return nil, Error.New("unable to read header: %w", err)
+	}
+	return cr, nil
+}
+
+func (cr *containerReader) readBytes() ([]byte, error) {
+	n, err := binary.ReadVarint(cr.r)
+	if err != nil {
+		return nil, Error.New("unable to read header: %w", err)
+	}
+	if n < 0 || n > maxBlockSize {
+		return nil, Error.New("invalid header length: %d", n)
+	}
+	v := make([]byte, n)
+	if _, err := io.ReadFull(cr.r, v); err != nil {
+		return nil, Error.New("unable to read header: %w", err)
+	}
+	return v, nil
+}
+
+// next returns the decoder positioned at the next record. It returns false
+// when there are no more records.
+func (cr *containerReader) next() (_ *decoder, ok bool, err error) {
+	if cr.block.err != nil {
+		return nil, false, cr.block.err
+	}
+
+	for cr.remaining == 0 {
+		if len(cr.block.buf) > 0 {
+			return nil, false, Error.New("block contains trailing data")
+		}
+
+		count, err := binary.ReadVarint(cr.r)
+		if err != nil {
+			if errs.Is(err, io.EOF) {
+				return nil, false, nil
+			}
+			return nil, false, Error.New("unable to read block: %w", err)
+		}
+		size, err := binary.ReadVarint(cr.r)
+		if err != nil {
+			return nil, false, Error.New("unable to read block: %w", err)
+		}
+		if count < 0 || size < 0 || size > maxBlockSize {
+			return nil, false, Error.New("invalid block: %d records, %d bytes", count, size)
+		}
+
+		data := make([]byte, size+syncMarkerSize)
+		if _, err := io.ReadFull(cr.r, data); err != nil {
+			return nil, false, Error.New("unable to read block: %w", err)
+		}
+		if !bytes.Equal(data[size:], cr.sync[:]) {
+			return nil, false, Error.New("invalid sync marker")
+		}
+
+		cr.block = decoder{buf: data[:size]}
+		cr.remaining = count
+	}
+
+	cr.remaining--
+	return &cr.block, true, nil
+}
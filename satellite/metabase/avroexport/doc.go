@@ -0,0 +1,12 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+/*
+Package avroexport exports and imports metabase objects and segments as Avro
+object container files.
+
+An export consists of two files, one with the objects and one with the
+segments of these objects, which can be imported into another metabase, e.g.
+for migrations, tenant offboarding or disaster recovery drills.
+*/
+package avroexport
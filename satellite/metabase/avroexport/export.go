@@ -0,0 +1,310 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package avroexport
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+)
+
+var (
+	// Error is the default error class for avroexport package.
+	Error = errs.Class("avroexport")
+	mon   = monkit.Package()
+)
+
+// Options contains the arguments for exporting objects.
+type Options struct {
+	ProjectID  uuid.UUID
+	BucketName metabase.BucketName
+
+	// CreatedAfter and CreatedBefore limit the export to the objects created
+	// in [CreatedAfter, CreatedBefore). Zero values disable the filter.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	BatchSize int
+}
+
+// Verify verifies the export options.
+func (opts Options) Verify() error {
+	switch {
+	case opts.ProjectID.IsZero():
+		return Error.New("ProjectID missing")
+	case opts.BucketName == "":
+		return Error.New("BucketName missing")
+	case opts.BatchSize < 0:
+		return Error.New("BatchSize is negative")
+	case !opts.CreatedAfter.IsZero() && !opts.CreatedBefore.IsZero() && !opts.CreatedAfter.Before(opts.CreatedBefore):
+		return Error.New("CreatedAfter has to be before CreatedBefore")
+	}
+	return nil
+}
+
+func (opts Options) includes(createdAt time.Time) bool {
+	if !opts.CreatedAfter.IsZero() && createdAt.Before(opts.CreatedAfter) {
+		return false
+	}
+	if !opts.CreatedBefore.IsZero() && !createdAt.Before(opts.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// Stats contains the number of exported or imported rows.
+type Stats struct {
+	Objects  int64
+	Segments int64
+}
+
+// defaultBatchSize is the number of objects, whose segments are listed together.
+const defaultBatchSize = 1000
+
+// Export writes the committed objects of a bucket to objects and their
+// segments to segments.
+//
+// Pending objects are not exported, since they can't be completed after an
+// import anyway.
+func Export(ctx context.Context, db *metabase.DB, opts Options, objects, segments io.Writer) (stats Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return Stats{}, err
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	objectsWriter, err := newContainerWriter(objects, ObjectSchema)
+	if err != nil {
+		return Stats{}, err
+	}
+	segmentsWriter, err := newContainerWriter(segments, SegmentSchema)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	batch := make([]Object, 0, batchSize)
+	flush := func() error {
+		streamIDs := make([]uuid.UUID, 0, len(batch))
+		for _, object := range batch {
+			object := object
+			if err := objectsWriter.append(func(e *encoder) { encodeObject(e, object) }); err != nil {
+				return err
+			}
+			stats.Objects++
+
+			if !object.Status.IsDeleteMarker() {
+				streamIDs = append(streamIDs, object.StreamID)
+			}
+		}
+		batch = batch[:0]
+
+		exported, err := exportSegments(ctx, db, opts.ProjectID, streamIDs, batchSize, segmentsWriter)
+		stats.Segments += exported
+		return err
+	}
+
+	err = db.IterateObjectsAllVersionsWithStatus(ctx, metabase.IterateObjectsWithStatus{
+		ProjectID:             opts.ProjectID,
+		BucketName:            opts.BucketName,
+		Recursive:             true,
+		BatchSize:             batchSize,
+		Pending:               false,
+		IncludeCustomMetadata: true,
+		IncludeSystemMetadata: true,
+		IncludeObjectLock:     true,
+	}, func(ctx context.Context, it metabase.ObjectsIterator) error {
+		var entry metabase.ObjectEntry
+		for it.Next(ctx, &entry) {
+			if !opts.includes(entry.CreatedAt) {
+				continue
+			}
+
+			batch = append(batch, exportObject(opts, entry))
+			if len(batch) >= batchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		return flush()
+	})
+	if err != nil {
+		return stats, Error.Wrap(err)
+	}
+
+	return stats, errs.Combine(objectsWriter.Close(), segmentsWriter.Close())
+}
+
+// exportObject converts the listed object to its full representation.
+func exportObject(opts Options, entry metabase.ObjectEntry) Object {
+	return Object{
+		RawObject: metabase.RawObject{
+			ObjectStream: metabase.ObjectStream{
+				ProjectID:  opts.ProjectID,
+				BucketName: opts.BucketName,
+				ObjectKey:  entry.ObjectKey,
+				Version:    entry.Version,
+				StreamID:   entry.StreamID,
+			},
+			CreatedAt:    entry.CreatedAt,
+			ExpiresAt:    entry.ExpiresAt,
+			Status:       entry.Status,
+			SegmentCount: entry.SegmentCount,
+
+			EncryptedMetadataNonce:        entry.EncryptedMetadataNonce,
+			EncryptedMetadata:             entry.EncryptedMetadata,
+			EncryptedMetadataEncryptedKey: entry.EncryptedMetadataEncryptedKey,
+
+			TotalPlainSize:     entry.TotalPlainSize,
+			TotalEncryptedSize: entry.TotalEncryptedSize,
+			FixedSegmentSize:   entry.FixedSegmentSize,
+
+			Encryption: entry.Encryption,
+
+			Retention: entry.Retention,
+		},
+		LegalHold: entry.LegalHold,
+	}
+}
+
+// exportSegments writes all the segments of the streams.
+func exportSegments(ctx context.Context, db *metabase.DB, projectID uuid.UUID, streamIDs []uuid.UUID, batchSize int, w *containerWriter) (exported int64, err error) {
+	opts := metabase.ListStreamsSegments{
+		ProjectID: projectID,
+		StreamIDs: streamIDs,
+		Limit:     batchSize,
+	}
+	for len(opts.StreamIDs) > 0 {
+		result, err := db.ListStreamsSegments(ctx, opts)
+		if err != nil {
+			return exported, Error.Wrap(err)
+		}
+
+		for _, segment := range result.Segments {
+			segment := segment
+			if err := w.append(func(e *encoder) { encodeSegment(e, segment) }); err != nil {
+				return exported, err
+			}
+			exported++
+		}
+
+		if !result.More || len(result.Segments) == 0 {
+			break
+		}
+		last := result.Segments[len(result.Segments)-1]
+		opts.CursorStreamID, opts.CursorPosition = last.StreamID, last.Position
+	}
+	return exported, nil
+}
+
+// Import inserts the objects and segments written by Export.
+//
+// The objects and segments are inserted as they are, hence the import fails
+// when any of them already exists in the database.
+func Import(ctx context.Context, db *metabase.DB, objects, segments io.Reader, batchSize int) (stats Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	objectsReader, err := newContainerReader(objects, ObjectSchema)
+	if err != nil {
+		return Stats{}, err
+	}
+	segmentsReader, err := newContainerReader(segments, SegmentSchema)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	var projectID uuid.UUID
+	batch := make([]metabase.ImportObject, 0, batchSize)
+	flushObjects := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := db.ImportObjects(ctx, batch); err != nil {
+			return Error.Wrap(err)
+		}
+		stats.Objects += int64(len(batch))
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		d, ok, err := objectsReader.next()
+		if err != nil {
+			return stats, err
+		}
+		if !ok {
+			break
+		}
+		object, err := decodeObject(d)
+		if err != nil {
+			return stats, err
+		}
+
+		if projectID.IsZero() {
+			projectID = object.ProjectID
+		} else if object.ProjectID != projectID {
+			return stats, Error.New("objects of multiple projects can't be imported together")
+		}
+
+		batch = append(batch, metabase.ImportObject(object))
+		if len(batch) >= batchSize {
+			if err := flushObjects(); err != nil {
+				return stats, err
+			}
+		}
+	}
+	if err := flushObjects(); err != nil {
+		return stats, err
+	}
+
+	segmentBatch := make([]metabase.RawSegment, 0, batchSize)
+	flushSegments := func() error {
+		if len(segmentBatch) == 0 {
+			return nil
+		}
+		if err := db.ImportSegments(ctx, projectID, segmentBatch); err != nil {
+			return Error.Wrap(err)
+		}
+		stats.Segments += int64(len(segmentBatch))
+		segmentBatch = segmentBatch[:0]
+		return nil
+	}
+
+	for {
+		d, ok, err := segmentsReader.next()
+		if err != nil {
+			return stats, err
+		}
+		if !ok {
+			break
+		}
+		segment, err := decodeSegment(d)
+		if err != nil {
+			return stats, err
+		}
+
+		segmentBatch = append(segmentBatch, segment)
+		if len(segmentBatch) >= batchSize {
+			if err := flushSegments(); err != nil {
+				return stats, err
+			}
+		}
+	}
+	return stats, flushSegments()
+}
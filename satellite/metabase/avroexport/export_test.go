@@ -0,0 +1,184 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package avroexport_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/avroexport"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestExportImport(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+		obj := metabasetest.RandObjectStream()
+
+		var objects []metabase.Object
+		for i := 0; i < 3; i++ {
+			stream := obj
+			stream.ObjectKey = metabasetest.RandObjectKey()
+			stream.StreamID = testrand.UUID()
+			objects = append(objects, metabasetest.CreateObject(ctx, t, db, stream, byte(i+1)))
+		}
+
+		require.NoError(t, db.SetObjectExactVersionLegalHold(ctx, metabase.SetObjectExactVersionLegalHold{
+			ObjectLocation: objects[0].Location(),
+			Version:        objects[0].Version,
+			Enabled:        true,
+		}))
+
+		// objects of other buckets are not exported.
+		metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 1)
+
+		state, err := db.TestingGetState(ctx)
+		require.NoError(t, err)
+
+		var expected metabase.RawState
+		for _, object := range state.Objects {
+			if object.BucketName == obj.BucketName {
+				expected.Objects = append(expected.Objects, object)
+			}
+		}
+		for _, segment := range state.Segments {
+			for _, object := range objects {
+				if segment.StreamID == object.StreamID {
+					expected.Segments = append(expected.Segments, segment)
+				}
+			}
+		}
+
+		var objectsFile, segmentsFile bytes.Buffer
+		stats, err := avroexport.Export(ctx, db, avroexport.Options{
+			ProjectID:  obj.ProjectID,
+			BucketName: obj.BucketName,
+			BatchSize:  2,
+		}, &objectsFile, &segmentsFile)
+		require.NoError(t, err)
+		require.Equal(t, avroexport.Stats{Objects: 3, Segments: 6}, stats)
+
+		require.NoError(t, db.TestingDeleteAll(ctx))
+
+		stats, err = avroexport.Import(ctx, db, &objectsFile, &segmentsFile, 2)
+		require.NoError(t, err)
+		require.Equal(t, avroexport.Stats{Objects: 3, Segments: 6}, stats)
+
+		metabasetest.Verify(expected).Check(ctx, t, db)
+
+		legalHold, err := db.GetObjectExactVersionLegalHold(ctx, metabase.GetObjectExactVersionLegalHold{
+			ObjectLocation: objects[0].Location(),
+			Version:        objects[0].Version,
+		})
+		require.NoError(t, err)
+		require.True(t, legalHold)
+
+		// importing again fails, since the objects already exist.
+		_, err = avroexport.Export(ctx, db, avroexport.Options{
+			ProjectID:  obj.ProjectID,
+			BucketName: obj.BucketName,
+		}, &objectsFile, &segmentsFile)
+		require.NoError(t, err)
+		_, err = avroexport.Import(ctx, db, &objectsFile, &segmentsFile, 0)
+		require.Error(t, err)
+	})
+}
+
+func TestExportImportMetadataEncryption(t *testing.T) {
+	metabasetest.RunWithConfig(t, metabase.Config{
+		ApplicationName: "metabase-tests",
+		MetadataEncryption: metabase.MetadataEncryptionConfig{
+			Keys:        map[uint16]storj.Key{1: testrand.Key()},
+			ActiveKeyID: 1,
+		},
+	}, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+		metadata := testrand.Bytes(64)
+		encryptedKey := testrand.Bytes(32)
+		nonce := testrand.Nonce()
+
+		obj := metabasetest.RandObjectStream()
+		metabasetest.CreatePendingObject(ctx, t, db, obj, 0)
+		_, err := db.CommitObject(ctx, metabase.CommitObject{
+			ObjectStream:                  obj,
+			OverrideEncryptedMetadata:     true,
+			EncryptedMetadata:             metadata,
+			EncryptedMetadataNonce:        nonce[:],
+			EncryptedMetadataEncryptedKey: encryptedKey,
+		})
+		require.NoError(t, err)
+
+		var objectsFile, segmentsFile bytes.Buffer
+		_, err = avroexport.Export(ctx, db, avroexport.Options{
+			ProjectID:  obj.ProjectID,
+			BucketName: obj.BucketName,
+		}, &objectsFile, &segmentsFile)
+		require.NoError(t, err)
+
+		// the export contains the metadata as sent by the client.
+		require.True(t, bytes.Contains(objectsFile.Bytes(), metadata))
+		require.True(t, bytes.Contains(objectsFile.Bytes(), encryptedKey))
+
+		require.NoError(t, db.TestingDeleteAll(ctx))
+
+		_, err = avroexport.Import(ctx, db, &objectsFile, &segmentsFile, 0)
+		require.NoError(t, err)
+
+		// the imported metadata is encrypted again.
+		state, err := db.TestingGetState(ctx)
+		require.NoError(t, err)
+		require.Len(t, state.Objects, 1)
+		require.NotEqual(t, metadata, state.Objects[0].EncryptedMetadata)
+		require.NotEqual(t, encryptedKey, state.Objects[0].EncryptedMetadataEncryptedKey)
+
+		object, err := db.GetObjectLastCommitted(ctx, metabase.GetObjectLastCommitted{
+			ObjectLocation: obj.Location(),
+		})
+		require.NoError(t, err)
+		require.Equal(t, metadata, object.EncryptedMetadata)
+		require.Equal(t, encryptedKey, object.EncryptedMetadataEncryptedKey)
+	})
+}
+
+func TestExportCreatedFilter(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+		object := metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 1)
+
+		export := func(createdAfter, createdBefore time.Time) avroexport.Stats {
+			var objectsFile, segmentsFile bytes.Buffer
+			stats, err := avroexport.Export(ctx, db, avroexport.Options{
+				ProjectID:     object.ProjectID,
+				BucketName:    object.BucketName,
+				CreatedAfter:  createdAfter,
+				CreatedBefore: createdBefore,
+			}, &objectsFile, &segmentsFile)
+			require.NoError(t, err)
+			return stats
+		}
+
+		require.Equal(t, avroexport.Stats{Objects: 1, Segments: 1}, export(object.CreatedAt, time.Time{}))
+		require.Equal(t, avroexport.Stats{Objects: 1, Segments: 1}, export(time.Time{}, object.CreatedAt.Add(time.Second)))
+		require.Equal(t, avroexport.Stats{}, export(object.CreatedAt.Add(time.Second), time.Time{}))
+		require.Equal(t, avroexport.Stats{}, export(time.Time{}, object.CreatedAt))
+
+		_, err := avroexport.Export(ctx, db, avroexport.Options{
+			ProjectID:     object.ProjectID,
+			BucketName:    object.BucketName,
+			CreatedAfter:  object.CreatedAt,
+			CreatedBefore: object.CreatedAt,
+		}, &bytes.Buffer{}, &bytes.Buffer{})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,272 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package avroexport
+
+import (
+	"time"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+)
+
+// ObjectSchema is the Avro schema of the exported objects.
+//
+// Timestamps are stored as microseconds since the Unix epoch.
+const ObjectSchema = `{"type":"record","name":"Object","namespace":"io.storj.metabase","fields":[` +
+	`{"name":"project_id","type":"bytes"},` +
+	`{"name":"bucket_name","type":"string"},` +
+	`{"name":"object_key","type":"bytes"},` +
+	`{"name":"version","type":"long"},` +
+	`{"name":"stream_id","type":"bytes"},` +
+	`{"name":"created_at","type":{"type":"long","logicalType":"timestamp-micros"}},` +
+	`{"name":"expires_at","type":["null",{"type":"long","logicalType":"timestamp-micros"}]},` +
+	`{"name":"status","type":"long"},` +
+	`{"name":"segment_count","type":"long"},` +
+	`{"name":"encrypted_metadata_nonce","type":["null","bytes"]},` +
+	`{"name":"encrypted_metadata","type":["null","bytes"]},` +
+	`{"name":"encrypted_metadata_encrypted_key","type":["null","bytes"]},` +
+	`{"name":"total_plain_size","type":"long"},` +
+	`{"name":"total_encrypted_size","type":"long"},` +
+	`{"name":"fixed_segment_size","type":"long"},` +
+	`{"name":"encryption_cipher_suite","type":"long"},` +
+	`{"name":"encryption_block_size","type":"long"},` +
+	`{"name":"zombie_deletion_deadline","type":["null",{"type":"long","logicalType":"timestamp-micros"}]},` +
+	`{"name":"retention_mode","type":"long"},` +
+	`{"name":"retain_until","type":["null",{"type":"long","logicalType":"timestamp-micros"}]},` +
+	`{"name":"legal_hold","type":"boolean"}` +
+	`]}`
+
+// SegmentSchema is the Avro schema of the exported segments.
+const SegmentSchema = `{"type":"record","name":"Segment","namespace":"io.storj.metabase","fields":[` +
+	`{"name":"stream_id","type":"bytes"},` +
+	`{"name":"position","type":"long"},` +
+	`{"name":"created_at","type":{"type":"long","logicalType":"timestamp-micros"}},` +
+	`{"name":"repaired_at","type":["null",{"type":"long","logicalType":"timestamp-micros"}]},` +
+	`{"name":"expires_at","type":["null",{"type":"long","logicalType":"timestamp-micros"}]},` +
+	`{"name":"root_piece_id","type":"bytes"},` +
+	`{"name":"encrypted_key_nonce","type":["null","bytes"]},` +
+	`{"name":"encrypted_key","type":["null","bytes"]},` +
+	`{"name":"encrypted_size","type":"long"},` +
+	`{"name":"plain_offset","type":"long"},` +
+	`{"name":"plain_size","type":"long"},` +
+	`{"name":"encrypted_etag","type":["null","bytes"]},` +
+	`{"name":"redundancy_algorithm","type":"long"},` +
+	`{"name":"redundancy_share_size","type":"long"},` +
+	`{"name":"redundancy_required_shares","type":"long"},` +
+	`{"name":"redundancy_repair_shares","type":"long"},` +
+	`{"name":"redundancy_optimal_shares","type":"long"},` +
+	`{"name":"redundancy_total_shares","type":"long"},` +
+	`{"name":"inline_data","type":["null","bytes"]},` +
+	`{"name":"pieces","type":{"type":"array","items":{"type":"record","name":"Piece","fields":[` +
+	`{"name":"number","type":"long"},` +
+	`{"name":"storage_node","type":"bytes"}` +
+	`]}}},` +
+	`{"name":"placement","type":"long"}` +
+	`]}`
+
+// Object is an exported object.
+type Object struct {
+	metabase.RawObject
+
+	LegalHold bool
+}
+
+func encodeTime(e *encoder, t time.Time) {
+	e.long(t.UnixMicro())
+}
+
+func encodeOptionalTime(e *encoder, t *time.Time) {
+	if t == nil {
+		e.optionalLong(0, false)
+		return
+	}
+	e.optionalLong(t.UnixMicro(), true)
+}
+
+func encodeOptionalZeroTime(e *encoder, t time.Time) {
+	e.optionalLong(t.UnixMicro(), !t.IsZero())
+}
+
+func decodeTime(d *decoder) time.Time {
+	return time.UnixMicro(d.long()).UTC()
+}
+
+func decodeOptionalTime(d *decoder) *time.Time {
+	v, ok := d.optionalLong()
+	if !ok {
+		return nil
+	}
+	t := time.UnixMicro(v).UTC()
+	return &t
+}
+
+func decodeOptionalZeroTime(d *decoder) time.Time {
+	if t := decodeOptionalTime(d); t != nil {
+		return *t
+	}
+	return time.Time{}
+}
+
+func decodeUUID(d *decoder) uuid.UUID {
+	b := d.bytes()
+	if d.err != nil {
+		return uuid.UUID{}
+	}
+	id, err := uuid.FromBytes(b)
+	if err != nil {
+		d.fail("invalid uuid: %v", err)
+	}
+	return id
+}
+
+func encodeObject(e *encoder, object Object) {
+	e.bytes(object.ProjectID.Bytes())
+	e.string(string(object.BucketName))
+	e.bytes([]byte(object.ObjectKey))
+	e.long(int64(object.Version))
+	e.bytes(object.StreamID.Bytes())
+
+	encodeTime(e, object.CreatedAt)
+	encodeOptionalTime(e, object.ExpiresAt)
+
+	e.long(int64(object.Status))
+	e.long(int64(object.SegmentCount))
+
+	e.optionalBytes(object.EncryptedMetadataNonce)
+	e.optionalBytes(object.EncryptedMetadata)
+	e.optionalBytes(object.EncryptedMetadataEncryptedKey)
+
+	e.long(object.TotalPlainSize)
+	e.long(object.TotalEncryptedSize)
+	e.long(int64(object.FixedSegmentSize))
+
+	e.long(int64(object.Encryption.CipherSuite))
+	e.long(int64(object.Encryption.BlockSize))
+
+	encodeOptionalTime(e, object.ZombieDeletionDeadline)
+
+	e.long(int64(object.Retention.Mode))
+	encodeOptionalZeroTime(e, object.Retention.RetainUntil)
+
+	e.boolean(object.LegalHold)
+}
+
+func decodeObject(d *decoder) (object Object, err error) {
+	object.ProjectID = decodeUUID(d)
+	object.BucketName = metabase.BucketName(d.string())
+	object.ObjectKey = metabase.ObjectKey(d.bytes())
+	object.Version = metabase.Version(d.long())
+	object.StreamID = decodeUUID(d)
+
+	object.CreatedAt = decodeTime(d)
+	object.ExpiresAt = decodeOptionalTime(d)
+
+	object.Status = metabase.ObjectStatus(d.long())
+	object.SegmentCount = d.int32()
+
+	object.EncryptedMetadataNonce = d.optionalBytes()
+	object.EncryptedMetadata = d.optionalBytes()
+	object.EncryptedMetadataEncryptedKey = d.optionalBytes()
+
+	object.TotalPlainSize = d.long()
+	object.TotalEncryptedSize = d.long()
+	object.FixedSegmentSize = d.int32()
+
+	object.Encryption.CipherSuite = storj.CipherSuite(d.long())
+	object.Encryption.BlockSize = d.int32()
+
+	object.ZombieDeletionDeadline = decodeOptionalTime(d)
+
+	object.Retention.Mode = storj.RetentionMode(d.long())
+	object.Retention.RetainUntil = decodeOptionalZeroTime(d)
+
+	object.LegalHold = d.boolean()
+
+	return object, d.err
+}
+
+func encodeSegment(e *encoder, segment metabase.RawSegment) {
+	e.bytes(segment.StreamID.Bytes())
+	e.long(int64(segment.Position.Encode()))
+
+	encodeTime(e, segment.CreatedAt)
+	encodeOptionalTime(e, segment.RepairedAt)
+	encodeOptionalTime(e, segment.ExpiresAt)
+
+	e.bytes(segment.RootPieceID.Bytes())
+	e.optionalBytes(segment.EncryptedKeyNonce)
+	e.optionalBytes(segment.EncryptedKey)
+
+	e.long(int64(segment.EncryptedSize))
+	e.long(segment.PlainOffset)
+	e.long(int64(segment.PlainSize))
+	e.optionalBytes(segment.EncryptedETag)
+
+	e.long(int64(segment.Redundancy.Algorithm))
+	e.long(int64(segment.Redundancy.ShareSize))
+	e.long(int64(segment.Redundancy.RequiredShares))
+	e.long(int64(segment.Redundancy.RepairShares))
+	e.long(int64(segment.Redundancy.OptimalShares))
+	e.long(int64(segment.Redundancy.TotalShares))
+
+	e.optionalBytes(segment.InlineData)
+
+	e.arrayLen(len(segment.Pieces))
+	for _, piece := range segment.Pieces {
+		e.long(int64(piece.Number))
+		e.bytes(piece.StorageNode.Bytes())
+	}
+	e.arrayEnd()
+
+	e.long(int64(segment.Placement))
+}
+
+func decodeSegment(d *decoder) (segment metabase.RawSegment, err error) {
+	segment.StreamID = decodeUUID(d)
+	segment.Position = metabase.SegmentPositionFromEncoded(uint64(d.long()))
+
+	segment.CreatedAt = decodeTime(d)
+	segment.RepairedAt = decodeOptionalTime(d)
+	segment.ExpiresAt = decodeOptionalTime(d)
+
+	if rootPieceID := d.bytes(); d.err == nil {
+		segment.RootPieceID, err = storj.PieceIDFromBytes(rootPieceID)
+		if err != nil {
+			d.fail("invalid root piece id: %v", err)
+		}
+	}
+	segment.EncryptedKeyNonce = d.optionalBytes()
+	segment.EncryptedKey = d.optionalBytes()
+
+	segment.EncryptedSize = d.int32()
+	segment.PlainOffset = d.long()
+	segment.PlainSize = d.int32()
+	segment.EncryptedETag = d.optionalBytes()
+
+	segment.Redundancy.Algorithm = storj.RedundancyAlgorithm(d.long())
+	segment.Redundancy.ShareSize = d.int32()
+	segment.Redundancy.RequiredShares = int16(d.long())
+	segment.Redundancy.RepairShares = int16(d.long())
+	segment.Redundancy.OptimalShares = int16(d.long())
+	segment.Redundancy.TotalShares = int16(d.long())
+
+	segment.InlineData = d.optionalBytes()
+
+	d.array(func() {
+		var piece metabase.Piece
+		piece.Number = uint16(d.long())
+		if storageNode := d.bytes(); d.err == nil {
+			piece.StorageNode, err = storj.NodeIDFromBytes(storageNode)
+			if err != nil {
+				d.fail("invalid storage node id: %v", err)
+			}
+		}
+		segment.Pieces = append(segment.Pieces, piece)
+	})
+
+	segment.Placement = storj.PlacementConstraint(d.long())
+
+	return segment, d.err
+}
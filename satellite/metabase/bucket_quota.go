@@ -0,0 +1,59 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+)
+
+// ErrBucketQuotaExceeded is returned when committing an object would exceed the quota of its bucket.
+var ErrBucketQuotaExceeded = errs.Class("metabase: bucket quota exceeded")
+
+// BucketQuota limits the number of committed objects and their segments in a bucket.
+// Zero means that the corresponding value is unlimited.
+type BucketQuota struct {
+	MaxObjects  int64
+	MaxSegments int64
+}
+
+// IsZero returns whether the quota doesn't limit anything.
+func (quota BucketQuota) IsZero() bool {
+	return quota.MaxObjects <= 0 && quota.MaxSegments <= 0
+}
+
+// checkBucketQuota verifies that the bucket is able to hold the additional objects
+// and segments. The objects and segments replaced by the commit may be passed as
+// negative values.
+//
+// The usage is taken from the bucket statistics instead of counting the objects
+// table, so the check doesn't get slower with the size of the bucket and doesn't
+// conflict with the concurrent commits. The statistics are refreshed only
+// periodically, hence the quota is a soft limit: the objects committed since the
+// last refresh aren't counted and a bucket without statistics counts as empty.
+func (db *DB) checkBucketQuota(ctx context.Context, bucket BucketLocation, quota BucketQuota, objects, segments int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if quota.IsZero() {
+		return nil
+	}
+
+	stats, err := db.ChooseAdapter(bucket.ProjectID).GetBucketStats(ctx, GetBucketStats{BucketLocation: bucket})
+	if err != nil && !ErrBucketStatsNotFound.Has(err) {
+		return err
+	}
+	usedObjects := stats.ObjectCount - stats.PendingObjectCount
+	usedSegments := stats.TotalSegments
+
+	if quota.MaxObjects > 0 && usedObjects+objects > quota.MaxObjects {
+		mon.Meter("bucket_quota_objects_exceeded").Mark(1)
+		return ErrBucketQuotaExceeded.New("bucket object limit %d reached", quota.MaxObjects)
+	}
+	if quota.MaxSegments > 0 && usedSegments+segments > quota.MaxSegments {
+		mon.Meter("bucket_quota_segments_exceeded").Mark(1)
+		return ErrBucketQuotaExceeded.New("bucket segment limit %d reached", quota.MaxSegments)
+	}
+	return nil
+}
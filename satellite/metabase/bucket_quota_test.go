@@ -0,0 +1,135 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestBucketQuota(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		inlineObject := func(obj metabase.ObjectStream, quota metabase.BucketQuota) metabase.CommitSingleObject {
+			return metabase.CommitSingleObject{
+				ObjectStream:      obj,
+				Encryption:        metabasetest.DefaultEncryption,
+				EncryptedKey:      testrand.Bytes(32),
+				EncryptedKeyNonce: testrand.Bytes(32),
+				PlainSize:         512,
+				InlineData:        testrand.Bytes(100),
+				Quota:             quota,
+			}
+		}
+
+		inBucket := func(bucket metabase.ObjectStream) metabase.ObjectStream {
+			obj := metabasetest.RandObjectStream()
+			obj.ProjectID = bucket.ProjectID
+			obj.BucketName = bucket.BucketName
+			return obj
+		}
+
+		// the usage is taken from the bucket statistics, not from the objects table.
+		setUsage := func(bucket metabase.ObjectStream, objects, segments int64) {
+			location := bucket.Location().Bucket()
+			require.NoError(t, db.UpdateBucketStats(ctx, metabase.UpdateBucketStats{
+				From: location,
+				To:   location,
+				Tallies: []metabase.BucketTally{{
+					BucketLocation: location,
+					ObjectCount:    objects,
+					TotalSegments:  segments,
+				}},
+			}))
+		}
+
+		t.Run("max objects", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			quota := metabase.BucketQuota{MaxObjects: 2}
+			first := metabasetest.RandObjectStream()
+
+			// a bucket without statistics counts as empty.
+			_, err := db.CommitSingleObject(ctx, inlineObject(first, quota))
+			require.NoError(t, err)
+			_, err = db.CommitSingleObject(ctx, inlineObject(inBucket(first), quota))
+			require.NoError(t, err)
+
+			setUsage(first, 2, 2)
+			_, err = db.CommitSingleObject(ctx, inlineObject(inBucket(first), quota))
+			require.True(t, metabase.ErrBucketQuotaExceeded.Has(err), err)
+
+			// the replaced object isn't counted.
+			overwrite := first
+			overwrite.StreamID = testrand.UUID()
+			_, err = db.CommitSingleObject(ctx, inlineObject(overwrite, quota))
+			require.NoError(t, err)
+
+			// other buckets aren't affected.
+			_, err = db.CommitSingleObject(ctx, inlineObject(metabasetest.RandObjectStream(), quota))
+			require.NoError(t, err)
+		})
+
+		t.Run("max segments", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			quota := metabase.BucketQuota{MaxSegments: 3}
+			first := metabasetest.RandObjectStream()
+
+			_, err := db.CommitSingleObject(ctx, inlineObject(first, quota))
+			require.NoError(t, err)
+			setUsage(first, 1, 1)
+
+			obj := inBucket(first)
+			metabasetest.CreatePendingObject(ctx, t, db, obj, 3)
+			_, err = db.CommitObject(ctx, metabase.CommitObject{
+				ObjectStream: obj,
+				Quota:        quota,
+			})
+			require.True(t, metabase.ErrBucketQuotaExceeded.Has(err), err)
+
+			obj = inBucket(first)
+			metabasetest.CreatePendingObject(ctx, t, db, obj, 2)
+			_, err = db.CommitObject(ctx, metabase.CommitObject{
+				ObjectStream: obj,
+				Quota:        quota,
+			})
+			require.NoError(t, err)
+		})
+
+		t.Run("inline objects batch", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			quota := metabase.BucketQuota{MaxObjects: 3}
+			first := metabasetest.RandObjectStream()
+
+			_, err := db.CommitSingleObject(ctx, inlineObject(first, quota))
+			require.NoError(t, err)
+			setUsage(first, 1, 1)
+
+			_, err = db.CommitInlineObjects(ctx, metabase.CommitInlineObjects{
+				Objects: []metabase.CommitSingleObject{
+					inlineObject(inBucket(first), quota),
+					inlineObject(inBucket(first), quota),
+					inlineObject(inBucket(first), quota),
+				},
+			})
+			require.True(t, metabase.ErrBucketQuotaExceeded.Has(err), err)
+
+			objects, err := db.CommitInlineObjects(ctx, metabase.CommitInlineObjects{
+				Objects: []metabase.CommitSingleObject{
+					inlineObject(inBucket(first), quota),
+					inlineObject(inBucket(first), quota),
+				},
+			})
+			require.NoError(t, err)
+			require.Len(t, objects, 2)
+		})
+	})
+}
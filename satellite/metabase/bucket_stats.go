@@ -0,0 +1,285 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/zeebo/errs"
+	"google.golang.org/api/iterator"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/shared/dbutil/pgutil"
+	"storj.io/storj/shared/dbutil/spannerutil"
+	"storj.io/storj/shared/dbutil/txutil"
+	"storj.io/storj/shared/tagsql"
+)
+
+// ErrBucketStatsNotFound is returned when the statistics of a bucket weren't collected yet.
+var ErrBucketStatsNotFound = errs.Class("bucket stats not found")
+
+// BucketStats contains the object statistics of a bucket, as of UpdatedAt.
+type BucketStats struct {
+	BucketLocation
+
+	ObjectCount        int64
+	PendingObjectCount int64
+
+	TotalSegments int64
+	TotalBytes    int64
+
+	MetadataSize int64
+
+	UpdatedAt time.Time
+}
+
+// GetBucketStats contains arguments necessary for fetching the statistics of a bucket.
+type GetBucketStats struct {
+	BucketLocation
+}
+
+// Verify verifies get bucket stats request fields.
+func (opts *GetBucketStats) Verify() error {
+	switch {
+	case opts.ProjectID.IsZero():
+		return ErrInvalidRequest.New("ProjectID missing")
+	case opts.BucketName == "":
+		return ErrInvalidRequest.New("BucketName missing")
+	}
+	return nil
+}
+
+// GetBucketStats returns the statistics of a bucket. Unlike counting the objects, it doesn't
+// scan the objects table, the statistics are refreshed periodically with UpdateBucketStats.
+func (db *DB) GetBucketStats(ctx context.Context, opts GetBucketStats) (_ BucketStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return BucketStats{}, err
+	}
+
+	return db.ChooseAdapter(opts.ProjectID).GetBucketStats(ctx, opts)
+}
+
+// GetBucketStats implements Adapter.
+func (p *PostgresAdapter) GetBucketStats(ctx context.Context, opts GetBucketStats) (stats BucketStats, err error) {
+	stats.BucketLocation = opts.BucketLocation
+
+	err = p.db.QueryRowContext(ctx, `
+		SELECT
+			object_count, pending_object_count,
+			total_segments, total_bytes, metadata_size,
+			updated_at
+		FROM bucket_stats
+		WHERE (project_id, bucket_name) = ($1, $2)
+	`, opts.ProjectID, opts.BucketName).Scan(
+		&stats.ObjectCount, &stats.PendingObjectCount,
+		&stats.TotalSegments, &stats.TotalBytes, &stats.MetadataSize,
+		&stats.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return BucketStats{}, ErrBucketStatsNotFound.Wrap(Error.Wrap(err))
+		}
+		return BucketStats{}, Error.New("unable to query bucket stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetBucketStats implements Adapter.
+func (s *SpannerAdapter) GetBucketStats(ctx context.Context, opts GetBucketStats) (stats BucketStats, err error) {
+	stats, err = spannerutil.CollectRow(s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT
+				object_count, pending_object_count,
+				total_segments, total_bytes, metadata_size,
+				updated_at
+			FROM bucket_stats
+			WHERE project_id = @project_id AND bucket_name = @bucket_name
+		`,
+		Params: map[string]any{
+			"project_id":  opts.ProjectID,
+			"bucket_name": opts.BucketName,
+		},
+	}), func(row *spanner.Row, stats *BucketStats) error {
+		stats.BucketLocation = opts.BucketLocation
+		return Error.Wrap(row.Columns(
+			&stats.ObjectCount, &stats.PendingObjectCount,
+			&stats.TotalSegments, &stats.TotalBytes, &stats.MetadataSize,
+			&stats.UpdatedAt,
+		))
+	})
+	if err != nil {
+		if errors.Is(err, iterator.Done) {
+			return BucketStats{}, ErrBucketStatsNotFound.Wrap(Error.Wrap(sql.ErrNoRows))
+		}
+		return BucketStats{}, Error.New("unable to query bucket stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// UpdateBucketStats contains arguments necessary for refreshing the statistics
+// of the buckets between From and To (inclusive).
+type UpdateBucketStats struct {
+	From BucketLocation
+	To   BucketLocation
+
+	// Tallies are the current statistics of the buckets in the range.
+	// The statistics of the buckets in the range which are missing
+	// from Tallies are removed.
+	Tallies []BucketTally
+
+	UpdatedAt time.Time
+}
+
+// Verify verifies update bucket stats request fields.
+func (opts *UpdateBucketStats) Verify() error {
+	if opts.To.Compare(opts.From) < 0 {
+		return ErrInvalidRequest.New("To is before From")
+	}
+	for i, tally := range opts.Tallies {
+		if tally.Compare(opts.From) < 0 || tally.Compare(opts.To) > 0 {
+			return ErrInvalidRequest.New("Tallies[%d] is outside of the range", i)
+		}
+	}
+	return nil
+}
+
+// UpdateBucketStats replaces the statistics of the buckets in the range with the provided tallies.
+func (db *DB) UpdateBucketStats(ctx context.Context, opts UpdateBucketStats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	if opts.UpdatedAt.IsZero() {
+		opts.UpdatedAt = time.Now()
+	}
+
+	// every adapter removes the stale statistics of the range, but only the adapter
+	// owning the project of a bucket stores its statistics.
+	owned := make(map[Adapter][]BucketTally, len(db.adapters))
+	for _, tally := range opts.Tallies {
+		adapter := db.ChooseAdapter(tally.ProjectID)
+		owned[adapter] = append(owned[adapter], tally)
+	}
+
+	for _, adapter := range db.adapters {
+		adapterOpts := opts
+		adapterOpts.Tallies = owned[adapter]
+		if err := adapter.UpdateBucketStats(ctx, adapterOpts); err != nil {
+			return err
+		}
+	}
+
+	mon.IntVal("update_bucket_stats_tallies").Observe(int64(len(opts.Tallies)))
+
+	return nil
+}
+
+// UpdateBucketStats implements Adapter.
+func (p *PostgresAdapter) UpdateBucketStats(ctx context.Context, opts UpdateBucketStats) (err error) {
+	projectIDs := make([]uuid.UUID, len(opts.Tallies))
+	bucketNames := make([][]byte, len(opts.Tallies))
+	objectCounts := make([]int64, len(opts.Tallies))
+	pendingObjectCounts := make([]int64, len(opts.Tallies))
+	totalSegments := make([]int64, len(opts.Tallies))
+	totalBytes := make([]int64, len(opts.Tallies))
+	metadataSizes := make([]int64, len(opts.Tallies))
+	for i, tally := range opts.Tallies {
+		projectIDs[i] = tally.ProjectID
+		bucketNames[i] = []byte(tally.BucketName)
+		objectCounts[i] = tally.ObjectCount
+		pendingObjectCounts[i] = tally.PendingObjectCount
+		totalSegments[i] = tally.TotalSegments
+		totalBytes[i] = tally.TotalBytes
+		metadataSizes[i] = tally.MetadataSize
+	}
+
+	err = txutil.WithTx(ctx, p.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			DELETE FROM bucket_stats
+			WHERE (project_id, bucket_name) BETWEEN ($1, $2) AND ($3, $4)
+		`, opts.From.ProjectID, opts.From.BucketName, opts.To.ProjectID, opts.To.BucketName)
+		if err != nil {
+			return err
+		}
+
+		if len(opts.Tallies) == 0 {
+			return nil
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO bucket_stats (
+				project_id, bucket_name,
+				object_count, pending_object_count,
+				total_segments, total_bytes, metadata_size,
+				updated_at
+			)
+			SELECT
+				unnest($1::BYTEA[]), unnest($2::BYTEA[]),
+				unnest($3::INT8[]), unnest($4::INT8[]),
+				unnest($5::INT8[]), unnest($6::INT8[]), unnest($7::INT8[]),
+				$8
+		`, pgutil.UUIDArray(projectIDs), pgutil.ByteaArray(bucketNames),
+			pgutil.Int8Array(objectCounts), pgutil.Int8Array(pendingObjectCounts),
+			pgutil.Int8Array(totalSegments), pgutil.Int8Array(totalBytes), pgutil.Int8Array(metadataSizes),
+			opts.UpdatedAt,
+		)
+		return err
+	})
+	if err != nil {
+		return Error.New("unable to update bucket stats: %w", err)
+	}
+	return nil
+}
+
+// UpdateBucketStats implements Adapter.
+func (s *SpannerAdapter) UpdateBucketStats(ctx context.Context, opts UpdateBucketStats) (err error) {
+	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		_, err := tx.Update(ctx, spanner.Statement{
+			SQL: `
+				DELETE FROM bucket_stats
+				WHERE
+					` + TupleGreaterThanSQL([]string{"project_id", "bucket_name"}, []string{"@from_project_id", "@from_bucket_name"}, true) + `
+					AND ` + TupleGreaterThanSQL([]string{"@to_project_id", "@to_bucket_name"}, []string{"project_id", "bucket_name"}, true) + `
+			`,
+			Params: map[string]any{
+				"from_project_id":  opts.From.ProjectID,
+				"from_bucket_name": opts.From.BucketName,
+				"to_project_id":    opts.To.ProjectID,
+				"to_bucket_name":   opts.To.BucketName,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		mutations := make([]*spanner.Mutation, 0, len(opts.Tallies))
+		for _, tally := range opts.Tallies {
+			mutations = append(mutations, spanner.InsertOrUpdateMap("bucket_stats", map[string]any{
+				"project_id":           tally.ProjectID,
+				"bucket_name":          tally.BucketName,
+				"object_count":         tally.ObjectCount,
+				"pending_object_count": tally.PendingObjectCount,
+				"total_segments":       tally.TotalSegments,
+				"total_bytes":          tally.TotalBytes,
+				"metadata_size":        tally.MetadataSize,
+				"updated_at":           opts.UpdatedAt,
+			}))
+		}
+		return tx.BufferWrite(mutations)
+	})
+	if err != nil {
+		return Error.New("unable to update bucket stats: %w", err)
+	}
+	return nil
+}
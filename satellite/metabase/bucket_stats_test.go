@@ -0,0 +1,102 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestBucketStats(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+		bucket := metabase.BucketLocation{ProjectID: obj.ProjectID, BucketName: obj.BucketName}
+
+		t.Run("invalid", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			_, err := db.GetBucketStats(ctx, metabase.GetBucketStats{})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+			require.ErrorContains(t, err, "ProjectID missing")
+
+			_, err = db.GetBucketStats(ctx, metabase.GetBucketStats{BucketLocation: metabase.BucketLocation{ProjectID: obj.ProjectID}})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+			require.ErrorContains(t, err, "BucketName missing")
+
+			err = db.UpdateBucketStats(ctx, metabase.UpdateBucketStats{From: bucket})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+			require.ErrorContains(t, err, "To is before From")
+
+			err = db.UpdateBucketStats(ctx, metabase.UpdateBucketStats{
+				From:    bucket,
+				To:      bucket,
+				Tallies: []metabase.BucketTally{{BucketLocation: metabase.BucketLocation{ProjectID: obj.ProjectID, BucketName: "other"}}},
+			})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+			require.ErrorContains(t, err, "Tallies[0] is outside of the range")
+		})
+
+		t.Run("update and get", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			_, err := db.GetBucketStats(ctx, metabase.GetBucketStats{BucketLocation: bucket})
+			require.True(t, metabase.ErrBucketStatsNotFound.Has(err))
+
+			metabasetest.CreateObject(ctx, t, db, obj, 2)
+
+			now := time.Now()
+			tallies, err := db.CollectBucketTallies(ctx, metabase.CollectBucketTallies{
+				From: bucket,
+				To:   bucket,
+				Now:  now,
+			})
+			require.NoError(t, err)
+			require.Len(t, tallies, 1)
+
+			other := metabase.BucketLocation{ProjectID: obj.ProjectID, BucketName: obj.BucketName + "-other"}
+			full := metabase.BucketLocation{ProjectID: uuid.Max(), BucketName: "z"}
+
+			require.NoError(t, db.UpdateBucketStats(ctx, metabase.UpdateBucketStats{
+				From:      metabase.BucketLocation{},
+				To:        full,
+				Tallies:   append(tallies, metabase.BucketTally{BucketLocation: other, ObjectCount: 5}),
+				UpdatedAt: now,
+			}))
+
+			stats, err := db.GetBucketStats(ctx, metabase.GetBucketStats{BucketLocation: bucket})
+			require.NoError(t, err)
+			require.Equal(t, bucket, stats.BucketLocation)
+			require.EqualValues(t, 1, stats.ObjectCount)
+			require.EqualValues(t, 0, stats.PendingObjectCount)
+			require.EqualValues(t, 2, stats.TotalSegments)
+			require.Equal(t, tallies[0].TotalBytes, stats.TotalBytes)
+			require.Equal(t, tallies[0].MetadataSize, stats.MetadataSize)
+			require.WithinDuration(t, now, stats.UpdatedAt, time.Second)
+
+			stats, err = db.GetBucketStats(ctx, metabase.GetBucketStats{BucketLocation: other})
+			require.NoError(t, err)
+			require.EqualValues(t, 5, stats.ObjectCount)
+
+			// buckets in the range which are missing from the tallies are removed.
+			require.NoError(t, db.UpdateBucketStats(ctx, metabase.UpdateBucketStats{
+				From:    other,
+				To:      other,
+				Tallies: nil,
+			}))
+
+			_, err = db.GetBucketStats(ctx, metabase.GetBucketStats{BucketLocation: other})
+			require.True(t, metabase.ErrBucketStatsNotFound.Has(err))
+
+			_, err = db.GetBucketStats(ctx, metabase.GetBucketStats{BucketLocation: bucket})
+			require.NoError(t, err)
+		})
+	})
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+)
+
+// ChecksumAlgorithm is the algorithm used to calculate a content checksum.
+type ChecksumAlgorithm byte
+
+const (
+	// ChecksumNone means that the checksum wasn't provided.
+	ChecksumNone = ChecksumAlgorithm(0)
+	// ChecksumCRC32C is the CRC32 checksum with the Castagnoli polynomial.
+	ChecksumCRC32C = ChecksumAlgorithm(1)
+	// ChecksumSHA256 is the SHA-256 hash.
+	ChecksumSHA256 = ChecksumAlgorithm(2)
+)
+
+// Size returns the size of the checksum value calculated by the algorithm.
+func (algorithm ChecksumAlgorithm) Size() int {
+	switch algorithm {
+	case ChecksumCRC32C:
+		return 4
+	case ChecksumSHA256:
+		return 32
+	default:
+		return 0
+	}
+}
+
+// String returns the name of the algorithm.
+func (algorithm ChecksumAlgorithm) String() string {
+	switch algorithm {
+	case ChecksumNone:
+		return "none"
+	case ChecksumCRC32C:
+		return "CRC32C"
+	case ChecksumSHA256:
+		return "SHA256"
+	default:
+		return "unknown"
+	}
+}
+
+// Checksum is the checksum of the plain content of an object or a segment,
+// provided by the client at commit time, so the S3 checksum APIs can be served.
+//
+// The satellite doesn't see the plain content, so the checksum is not verified.
+type Checksum struct {
+	Algorithm ChecksumAlgorithm
+	Sum       []byte
+}
+
+// IsZero returns whether the checksum wasn't provided.
+func (checksum Checksum) IsZero() bool {
+	return checksum.Algorithm == ChecksumNone
+}
+
+// Verify verifies the checksum fields.
+func (checksum Checksum) Verify() error {
+	switch {
+	case checksum.Algorithm == ChecksumNone:
+		if len(checksum.Sum) != 0 {
+			return ErrInvalidRequest.New("Checksum.Algorithm missing")
+		}
+		return nil
+	case checksum.Algorithm.Size() == 0:
+		return ErrInvalidRequest.New("Checksum.Algorithm %d is not supported", checksum.Algorithm)
+	case len(checksum.Sum) != checksum.Algorithm.Size():
+		return ErrInvalidRequest.New("Checksum.Sum should be %d bytes for %s, but was %d bytes",
+			checksum.Algorithm.Size(), checksum.Algorithm, len(checksum.Sum))
+	}
+	return nil
+}
+
+// Value implements sql/driver.Valuer interface.
+func (checksum Checksum) Value() (driver.Value, error) {
+	if checksum.IsZero() {
+		return nil, nil
+	}
+	return append([]byte{byte(checksum.Algorithm)}, checksum.Sum...), nil
+}
+
+// Scan implements sql.Scanner interface.
+func (checksum *Checksum) Scan(value any) error {
+	switch value := value.(type) {
+	case nil:
+		*checksum = Checksum{}
+		return nil
+	case []byte:
+		if len(value) == 0 {
+			*checksum = Checksum{}
+			return nil
+		}
+		*checksum = Checksum{
+			Algorithm: ChecksumAlgorithm(value[0]),
+			Sum:       append([]byte(nil), value[1:]...),
+		}
+		return nil
+	default:
+		return Error.New("unable to scan %T into Checksum", value)
+	}
+}
+
+// EncodeSpanner implements spanner.Encoder interface.
+func (checksum Checksum) EncodeSpanner() (any, error) {
+	value, err := checksum.Value()
+	if value == nil {
+		return []byte(nil), err
+	}
+	return value, err
+}
+
+// DecodeSpanner implements spanner.Decoder interface.
+func (checksum *Checksum) DecodeSpanner(value any) (err error) {
+	switch v := value.(type) {
+	case *string:
+		if v == nil {
+			*checksum = Checksum{}
+			return nil
+		}
+		value, err = base64.StdEncoding.DecodeString(*v)
+	case string:
+		value, err = base64.StdEncoding.DecodeString(v)
+	}
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	return checksum.Scan(value)
+}
@@ -0,0 +1,164 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestChecksumVerify(t *testing.T) {
+	for _, test := range []struct {
+		checksum metabase.Checksum
+		err      string
+	}{
+		{checksum: metabase.Checksum{}},
+		{checksum: metabase.Checksum{Algorithm: metabase.ChecksumCRC32C, Sum: testrand.Bytes(4)}},
+		{checksum: metabase.Checksum{Algorithm: metabase.ChecksumSHA256, Sum: testrand.Bytes(32)}},
+		{
+			checksum: metabase.Checksum{Sum: testrand.Bytes(4)},
+			err:      "Checksum.Algorithm missing",
+		},
+		{
+			checksum: metabase.Checksum{Algorithm: 100, Sum: testrand.Bytes(4)},
+			err:      "Checksum.Algorithm 100 is not supported",
+		},
+		{
+			checksum: metabase.Checksum{Algorithm: metabase.ChecksumSHA256, Sum: testrand.Bytes(4)},
+			err:      "Checksum.Sum should be 32 bytes for SHA256, but was 4 bytes",
+		},
+	} {
+		err := test.checksum.Verify()
+		if test.err == "" {
+			require.NoError(t, err)
+			continue
+		}
+		require.True(t, metabase.ErrInvalidRequest.Has(err))
+		require.EqualError(t, err, metabase.ErrInvalidRequest.New(test.err).Error())
+	}
+}
+
+func TestCommitWithChecksum(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+
+		segmentChecksum := metabase.Checksum{Algorithm: metabase.ChecksumCRC32C, Sum: testrand.Bytes(4)}
+		objectChecksum := metabase.Checksum{Algorithm: metabase.ChecksumSHA256, Sum: testrand.Bytes(32)}
+
+		metabasetest.BeginObjectExactVersion{
+			Opts: metabase.BeginObjectExactVersion{
+				ObjectStream: obj,
+				Encryption:   metabasetest.DefaultEncryption,
+			},
+		}.Check(ctx, t, db)
+
+		commitSegment := metabase.CommitSegment{
+			ObjectStream: obj,
+			RootPieceID:  testrand.PieceID(),
+			Pieces:       metabase.Pieces{{Number: 0, StorageNode: testrand.NodeID()}},
+
+			EncryptedKey:      testrand.Bytes(32),
+			EncryptedKeyNonce: testrand.Bytes(32),
+
+			EncryptedSize: 1024,
+			PlainSize:     512,
+			Redundancy:    metabasetest.DefaultRedundancy,
+
+			Checksum: metabase.Checksum{Algorithm: metabase.ChecksumCRC32C, Sum: testrand.Bytes(32)},
+		}
+		metabasetest.CommitSegment{
+			Opts:     commitSegment,
+			ErrClass: &metabase.ErrInvalidRequest,
+			ErrText:  "Checksum.Sum should be 4 bytes for CRC32C, but was 32 bytes",
+		}.Check(ctx, t, db)
+
+		commitSegment.Checksum = segmentChecksum
+		metabasetest.CommitSegment{
+			Opts: commitSegment,
+		}.Check(ctx, t, db)
+
+		metabasetest.CommitObject{
+			Opts: metabase.CommitObject{
+				ObjectStream: obj,
+				Checksum:     metabase.Checksum{Algorithm: metabase.ChecksumSHA256},
+			},
+			ErrClass: &metabase.ErrInvalidRequest,
+			ErrText:  "Checksum.Sum should be 32 bytes for SHA256, but was 0 bytes",
+		}.Check(ctx, t, db)
+
+		object := metabasetest.CommitObject{
+			Opts: metabase.CommitObject{
+				ObjectStream: obj,
+				Checksum:     objectChecksum,
+			},
+		}.Check(ctx, t, db)
+		require.Equal(t, objectChecksum, object.Checksum)
+
+		objects, err := db.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		require.Equal(t, objectChecksum, objects[0].Checksum)
+
+		segments, err := db.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		require.Equal(t, segmentChecksum, segments[0].Checksum)
+
+		exact, err := db.GetObjectExactVersion(ctx, metabase.GetObjectExactVersion{
+			ObjectLocation: obj.Location(),
+			Version:        obj.Version,
+		})
+		require.NoError(t, err)
+		require.Equal(t, objectChecksum, exact.Checksum)
+
+		latest, err := db.GetObjectLastCommitted(ctx, metabase.GetObjectLastCommitted{
+			ObjectLocation: obj.Location(),
+		})
+		require.NoError(t, err)
+		require.Equal(t, objectChecksum, latest.Checksum)
+
+		segment, err := db.GetSegmentByPosition(ctx, metabase.GetSegmentByPosition{
+			StreamID: obj.StreamID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, segmentChecksum, segment.Checksum)
+
+		result, err := db.ListObjects(ctx, metabase.ListObjects{
+			ProjectID:             obj.ProjectID,
+			BucketName:            obj.BucketName,
+			Recursive:             true,
+			Limit:                 10,
+			IncludeSystemMetadata: true,
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Objects, 1)
+		require.Equal(t, objectChecksum, result.Objects[0].Checksum)
+
+		copyStreamID := testrand.UUID()
+		copied, err := db.FinishCopyObject(ctx, metabase.FinishCopyObject{
+			ObjectStream:          obj,
+			NewBucket:             obj.BucketName,
+			NewEncryptedObjectKey: metabasetest.RandObjectKey(),
+			NewStreamID:           copyStreamID,
+			NewSegmentKeys: []metabase.EncryptedKeyAndNonce{{
+				EncryptedKey:      testrand.Bytes(32),
+				EncryptedKeyNonce: testrand.Bytes(32),
+			}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, objectChecksum, copied.Checksum)
+
+		copiedSegment, err := db.GetSegmentByPosition(ctx, metabase.GetSegmentByPosition{
+			StreamID: copyStreamID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, segmentChecksum, copiedSegment.Checksum)
+	})
+}
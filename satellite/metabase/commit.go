@@ -42,9 +42,11 @@ var (
 type commitObjectTransactionAdapter interface {
 	updateSegmentOffsets(ctx context.Context, streamID uuid.UUID, updates []segmentToCommit) (err error)
 	finalizeObjectCommit(ctx context.Context, opts CommitObject, nextStatus ObjectStatus, nextVersion Version, finalSegments []segmentInfoForCommit, totalPlainSize int64, totalEncryptedSize int64, fixedSegmentSize int32, object *Object) error
-	finalizeInlineObjectCommit(ctx context.Context, object *Object, segment *Segment) (err error)
+	finalizeInlineObjectCommit(ctx context.Context, object *Object, encryptedMetadataKeyID uint16, segment *Segment) (err error)
+	finalizeSingleObjectCommit(ctx context.Context, object *Object, encryptedMetadataKeyID uint16, segment *Segment, aliasPieces AliasPieces) (err error)
 
 	precommitTransactionAdapter
+	prepareCommitTransactionAdapter
 }
 
 // BeginObjectNextVersion contains arguments necessary for starting an object upload.
@@ -61,6 +63,9 @@ type BeginObjectNextVersion struct {
 	Encryption storj.EncryptionParameters
 
 	Retention Retention // optional
+
+	// encryptedMetadataKeyID is the key the metadata is wrapped with, set by DB.
+	encryptedMetadataKeyID uint16
 }
 
 // Verify verifies get object request fields.
@@ -99,6 +104,11 @@ func (db *DB) BeginObjectNextVersion(ctx context.Context, opts BeginObjectNextVe
 		return Object{}, err
 	}
 
+	opts.encryptedMetadataKeyID, err = db.metadataEncryption.encryptMetadata(opts.ProjectID, &opts.EncryptedMetadata, &opts.EncryptedMetadataEncryptedKey)
+	if err != nil {
+		return Object{}, err
+	}
+
 	if opts.ZombieDeletionDeadline == nil {
 		deadline := time.Now().Add(defaultZombieDeletionPeriod)
 		opts.ZombieDeletionDeadline = &deadline
@@ -117,7 +127,19 @@ func (db *DB) BeginObjectNextVersion(ctx context.Context, opts BeginObjectNextVe
 		Retention:              opts.Retention,
 	}
 
-	err = db.ChooseAdapter(opts.ProjectID).BeginObjectNextVersion(ctx, opts, &object)
+	adapter := db.ChooseAdapter(opts.ProjectID)
+
+	// a reused stream ID would cross-link the segments of the objects.
+	inUse, err := adapter.StreamIDInUse(ctx, opts.StreamID)
+	if err != nil {
+		return Object{}, err
+	}
+	if inUse {
+		mon.Meter("object_begin_stream_id_in_use").Mark(1)
+		return Object{}, ErrStreamIDInUse.New("%s", opts.StreamID)
+	}
+
+	err = adapter.BeginObjectNextVersion(ctx, opts, &object)
 	if err != nil {
 		return Object{}, Error.New("unable to insert object: %w", err)
 	}
@@ -135,6 +157,7 @@ func (p *PostgresAdapter) BeginObjectNextVersion(ctx context.Context, opts Begin
 				expires_at, encryption,
 				zombie_deletion_deadline,
 				encrypted_metadata, encrypted_metadata_nonce, encrypted_metadata_encrypted_key,
+				encrypted_metadata_key_id,
 				retention_mode, retain_until
 			) VALUES (
 				$1, $2, $3,
@@ -148,6 +171,7 @@ func (p *PostgresAdapter) BeginObjectNextVersion(ctx context.Context, opts Begin
 				$4, $5, $6,
 				$7,
 				$8, $9, $10,
+				$13,
 				$11, $12
 			)
 			RETURNING status, version, created_at
@@ -156,6 +180,7 @@ func (p *PostgresAdapter) BeginObjectNextVersion(ctx context.Context, opts Begin
 		opts.ZombieDeletionDeadline,
 		opts.EncryptedMetadata, opts.EncryptedMetadataNonce, opts.EncryptedMetadataEncryptedKey,
 		retentionModeWrapper{&opts.Retention.Mode}, timeWrapper{&opts.Retention.RetainUntil},
+		opts.encryptedMetadataKeyID,
 	).Scan(&object.Status, &object.Version, &object.CreatedAt)
 }
 
@@ -173,6 +198,7 @@ func (s *SpannerAdapter) BeginObjectNextVersion(ctx context.Context, opts BeginO
 					expires_at, encryption,
 					zombie_deletion_deadline,
 					encrypted_metadata, encrypted_metadata_nonce, encrypted_metadata_encrypted_key,
+					encrypted_metadata_key_id,
 					retention_mode, retain_until
 				) VALUES (
                   	@project_id, @bucket_name, @object_key,
@@ -186,6 +212,7 @@ func (s *SpannerAdapter) BeginObjectNextVersion(ctx context.Context, opts BeginO
 					@stream_id, @expires_at,
 					@encryption, @zombie_deletion_deadline,
 					@encrypted_metadata, @encrypted_metadata_nonce, @encrypted_metadata_encrypted_key,
+					@encrypted_metadata_key_id,
 					@retention_mode, @retain_until
 				)
                 THEN RETURN status,version,created_at`,
@@ -200,6 +227,7 @@ func (s *SpannerAdapter) BeginObjectNextVersion(ctx context.Context, opts BeginO
 				"encrypted_metadata":               opts.EncryptedMetadata,
 				"encrypted_metadata_nonce":         opts.EncryptedMetadataNonce,
 				"encrypted_metadata_encrypted_key": opts.EncryptedMetadataEncryptedKey,
+				"encrypted_metadata_key_id":        int64(opts.encryptedMetadataKeyID),
 				"retention_mode":                   retentionModeWrapper{&opts.Retention.Mode},
 				"retain_until":                     timeWrapper{&opts.Retention.RetainUntil},
 			},
@@ -229,6 +257,9 @@ type BeginObjectExactVersion struct {
 	// validation of this struct's fields. This is useful for inserting intentionally
 	// malformed or unexpected data into the database and testing that we handle it properly.
 	TestingBypassVerify bool
+
+	// encryptedMetadataKeyID is the key the metadata is wrapped with, set by DB.
+	encryptedMetadataKeyID uint16
 }
 
 // Verify verifies get object reqest fields.
@@ -269,6 +300,11 @@ func (db *DB) TestingBeginObjectExactVersion(ctx context.Context, opts BeginObje
 		}
 	}
 
+	opts.encryptedMetadataKeyID, err = db.metadataEncryption.encryptMetadata(opts.ProjectID, &opts.EncryptedMetadata, &opts.EncryptedMetadataEncryptedKey)
+	if err != nil {
+		return Object{}, err
+	}
+
 	if opts.ZombieDeletionDeadline == nil {
 		deadline := time.Now().Add(defaultZombieDeletionPeriod)
 		opts.ZombieDeletionDeadline = &deadline
@@ -309,19 +345,22 @@ func (p *PostgresAdapter) TestingBeginObjectExactVersion(ctx context.Context, op
 			expires_at, encryption,
 			zombie_deletion_deadline,
 			encrypted_metadata, encrypted_metadata_nonce, encrypted_metadata_encrypted_key,
+			encrypted_metadata_key_id,
 			retention_mode, retain_until
 		) VALUES (
 			$1, $2, $3, $4, $5,
 			$6, $7,
 			$8,
 			$9, $10, $11,
-			$12, $13
+			$12,
+			$13, $14
 		)
 		RETURNING status, created_at
 		`, opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.Version, opts.StreamID,
 		opts.ExpiresAt, encryptionParameters{&opts.Encryption},
 		opts.ZombieDeletionDeadline,
 		opts.EncryptedMetadata, opts.EncryptedMetadataNonce, opts.EncryptedMetadataEncryptedKey,
+		opts.encryptedMetadataKeyID,
 		retentionModeWrapper{&opts.Retention.Mode}, timeWrapper{&opts.Retention.RetainUntil},
 	).Scan(
 		&object.Status, &object.CreatedAt,
@@ -343,12 +382,14 @@ func (s *SpannerAdapter) TestingBeginObjectExactVersion(ctx context.Context, opt
 				expires_at, encryption,
 				zombie_deletion_deadline,
 				encrypted_metadata, encrypted_metadata_nonce, encrypted_metadata_encrypted_key,
+				encrypted_metadata_key_id,
 				retention_mode, retain_until
 			) VALUES (
 				@project_id, @bucket_name, @object_key, @version, @stream_id,
 				@expires_at, @encryption,
 				@zombie_deletion_deadline,
 				@encrypted_metadata, @encrypted_metadata_nonce, @encrypted_metadata_encrypted_key,
+				@encrypted_metadata_key_id,
 				@retention_mode, @retain_until
 			) THEN RETURN status, created_at`,
 			Params: map[string]interface{}{
@@ -363,6 +404,7 @@ func (s *SpannerAdapter) TestingBeginObjectExactVersion(ctx context.Context, opt
 				"encrypted_metadata":               opts.EncryptedMetadata,
 				"encrypted_metadata_nonce":         opts.EncryptedMetadataNonce,
 				"encrypted_metadata_encrypted_key": opts.EncryptedMetadataEncryptedKey,
+				"encrypted_metadata_key_id":        int64(opts.encryptedMetadataKeyID),
 				"retention_mode":                   retentionModeWrapper{&opts.Retention.Mode},
 				"retain_until":                     timeWrapper{&opts.Retention.RetainUntil},
 			},
@@ -498,6 +540,9 @@ type CommitSegment struct {
 
 	Placement storj.PlacementConstraint
 
+	// Checksum is an optional checksum of the plain segment content.
+	Checksum Checksum
+
 	mode string
 }
 
@@ -513,6 +558,10 @@ func (db *DB) CommitSegment(ctx context.Context, opts CommitSegment) (err error)
 		return err
 	}
 
+	if err := opts.Checksum.Verify(); err != nil {
+		return err
+	}
+
 	switch {
 	case opts.RootPieceID.IsZero():
 		return ErrInvalidRequest.New("RootPieceID missing")
@@ -566,7 +615,7 @@ func (p *PostgresAdapter) CommitPendingObjectSegment(ctx context.Context, opts C
 			encrypted_size, plain_offset, plain_size, encrypted_etag,
 			redundancy,
 			remote_alias_pieces,
-			placement
+			placement, checksum
 		) VALUES (
 			(
 				SELECT stream_id
@@ -578,7 +627,7 @@ func (p *PostgresAdapter) CommitPendingObjectSegment(ctx context.Context, opts C
 			$6, $7, $8, $9,
 			$10,
 			$11,
-			$17
+			$17, $18
 		)
 		ON CONFLICT(stream_id, position)
 		DO UPDATE SET
@@ -587,14 +636,14 @@ func (p *PostgresAdapter) CommitPendingObjectSegment(ctx context.Context, opts C
 			encrypted_size = $6, plain_offset = $7, plain_size = $8, encrypted_etag = $9,
 			redundancy = $10,
 			remote_alias_pieces = $11,
-			placement = $17
+			placement = $17, checksum = $18
 		`, opts.Position, opts.ExpiresAt,
 		opts.RootPieceID, opts.EncryptedKeyNonce, opts.EncryptedKey,
 		opts.EncryptedSize, opts.PlainOffset, opts.PlainSize, opts.EncryptedETag,
 		redundancyScheme{&opts.Redundancy},
 		aliasPieces,
 		opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.Version, opts.StreamID,
-		opts.Placement,
+		opts.Placement, opts.Checksum,
 	)
 	if err != nil {
 		if code := pgerrcode.FromError(err); code == pgxerrcode.NotNullViolation {
@@ -637,19 +686,19 @@ func (p *CockroachAdapter) CommitPendingObjectSegment(ctx context.Context, opts
 					encrypted_size, plain_offset, plain_size, encrypted_etag,
 					redundancy,
 					remote_alias_pieces,
-					placement
+					placement, checksum
 				) VALUES (
 					$1, $2,
 					$3, $4, $5,
 					$6, $7, $8, $9,
 					$10, $11, $12,
-					$13
+					$13, $14
 				)`, opts.StreamID, opts.Position, opts.ExpiresAt,
 				opts.RootPieceID, opts.EncryptedKeyNonce, opts.EncryptedKey,
 				opts.EncryptedSize, opts.PlainOffset, opts.PlainSize, opts.EncryptedETag,
 				redundancyScheme{&opts.Redundancy},
 				aliasPieces,
-				opts.Placement,
+				opts.Placement, opts.Checksum,
 			)
 			return errs.Wrap(err)
 		})
@@ -661,19 +710,19 @@ func (p *CockroachAdapter) CommitPendingObjectSegment(ctx context.Context, opts
 				encrypted_size, plain_offset, plain_size, encrypted_etag,
 				redundancy,
 				remote_alias_pieces,
-				placement
+				placement, checksum
 			) VALUES (
 				$1, $2,
 				$3, $4, $5,
 				$6, $7, $8, $9,
 				$10, $11, $12,
-				$13
+				$13, $14
 			)`, opts.StreamID, opts.Position, opts.ExpiresAt,
 			opts.RootPieceID, opts.EncryptedKeyNonce, opts.EncryptedKey,
 			opts.EncryptedSize, opts.PlainOffset, opts.PlainSize, opts.EncryptedETag,
 			redundancyScheme{&opts.Redundancy},
 			aliasPieces,
-			opts.Placement,
+			opts.Placement, opts.Checksum,
 		)
 	default:
 		// Verify that object exists and is partial.
@@ -684,7 +733,7 @@ func (p *CockroachAdapter) CommitPendingObjectSegment(ctx context.Context, opts
 				encrypted_size, plain_offset, plain_size, encrypted_etag,
 				redundancy,
 				remote_alias_pieces,
-				placement
+				placement, checksum
 			) VALUES (
 				(
 					SELECT stream_id
@@ -696,14 +745,14 @@ func (p *CockroachAdapter) CommitPendingObjectSegment(ctx context.Context, opts
 				$6, $7, $8, $9,
 				$10,
 				$11,
-				$17
+				$17, $18
 			)`, opts.Position, opts.ExpiresAt,
 			opts.RootPieceID, opts.EncryptedKeyNonce, opts.EncryptedKey,
 			opts.EncryptedSize, opts.PlainOffset, opts.PlainSize, opts.EncryptedETag,
 			redundancyScheme{&opts.Redundancy},
 			aliasPieces,
 			opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.Version, opts.StreamID,
-			opts.Placement,
+			opts.Placement, opts.Checksum,
 		)
 		if err != nil {
 			if code := pgerrcode.FromError(err); code == pgxerrcode.NotNullViolation {
@@ -729,7 +778,7 @@ func (s *SpannerAdapter) CommitPendingObjectSegment(ctx context.Context, opts Co
 					encrypted_size, plain_offset, plain_size, encrypted_etag,
 					redundancy,
 					remote_alias_pieces,
-					placement
+					placement, checksum
 				) VALUES (
 					(
 						SELECT stream_id
@@ -741,7 +790,7 @@ func (s *SpannerAdapter) CommitPendingObjectSegment(ctx context.Context, opts Co
 					@encrypted_size, @plain_offset, @plain_size, @encrypted_etag,
 					@redundancy,
 					@alias_pieces,
-					@placement
+					@placement, @checksum
 				)
 			`,
 			Params: map[string]interface{}{
@@ -762,6 +811,7 @@ func (s *SpannerAdapter) CommitPendingObjectSegment(ctx context.Context, opts Co
 				"version":             opts.Version,
 				"stream_id":           opts.StreamID.Bytes(),
 				"placement":           int64(opts.Placement),
+				"checksum":            opts.Checksum,
 			},
 		}
 		numRows, err = txn.Update(ctx, stmt)
@@ -1059,6 +1109,15 @@ type CommitObject struct {
 	// UseObjectLock, if enabled, prevents the deletion of committed object versions
 	// with active Object Lock configurations.
 	UseObjectLock bool
+
+	// Checksum is an optional checksum of the whole plain object content.
+	Checksum Checksum
+
+	// Quota is the optional quota of the bucket the object is committed to.
+	Quota BucketQuota
+
+	// encryptedMetadataKeyID is the key the metadata is wrapped with, set by DB.
+	encryptedMetadataKeyID uint16
 }
 
 // Verify verifies request fields.
@@ -1067,6 +1126,10 @@ func (c *CommitObject) Verify() error {
 		return err
 	}
 
+	if err := c.Checksum.Verify(); err != nil {
+		return err
+	}
+
 	if c.Encryption.CipherSuite != storj.EncUnspecified && c.Encryption.BlockSize <= 0 {
 		return ErrInvalidRequest.New("Encryption.BlockSize is negative or zero")
 	}
@@ -1103,10 +1166,23 @@ func (s *SpannerAdapter) WithTx(ctx context.Context, f func(context.Context, Tra
 func (db *DB) CommitObject(ctx context.Context, opts CommitObject) (object Object, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	return db.commitObject(ctx, opts, nil)
+}
+
+// commitObject commits the pending object. When prepared is set, the commit
+// succeeds only if it matches the prepared commit of the object.
+func (db *DB) commitObject(ctx context.Context, opts CommitObject, prepared *PreparedCommit) (object Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
 	if err := opts.Verify(); err != nil {
 		return Object{}, err
 	}
 
+	opts.encryptedMetadataKeyID, err = db.metadataEncryption.encryptMetadata(opts.ProjectID, &opts.EncryptedMetadata, &opts.EncryptedMetadataEncryptedKey)
+	if err != nil {
+		return Object{}, err
+	}
+
 	var precommit PrecommitConstraintResult
 	err = db.ChooseAdapter(opts.ProjectID).WithTx(ctx, func(ctx context.Context, adapter TransactionAdapter) error {
 		segments, err := adapter.fetchSegmentsForCommit(ctx, opts.StreamID)
@@ -1118,6 +1194,12 @@ func (db *DB) CommitObject(ctx context.Context, opts CommitObject) (object Objec
 			return err
 		}
 
+		if prepared != nil {
+			if err := db.checkPreparedCommit(ctx, adapter, opts.ObjectStream, *prepared, segments); err != nil {
+				return err
+			}
+		}
+
 		finalSegments := convertToFinalSegments(segments)
 		if err := adapter.updateSegmentOffsets(ctx, opts.StreamID, finalSegments); err != nil {
 			return Error.New("failed to update segments: %w", err)
@@ -1156,6 +1238,12 @@ func (db *DB) CommitObject(ctx context.Context, opts CommitObject) (object Objec
 			return err
 		}
 
+		err = db.checkBucketQuota(ctx, opts.Location().Bucket(), opts.Quota,
+			1-int64(precommit.DeletedObjectCount), int64(len(finalSegments)-precommit.DeletedSegmentCount))
+		if err != nil {
+			return err
+		}
+
 		nextVersion := opts.Version
 		if nextVersion < precommit.HighestVersion {
 			nextVersion = precommit.HighestVersion + 1
@@ -1163,6 +1251,13 @@ func (db *DB) CommitObject(ctx context.Context, opts CommitObject) (object Objec
 
 		err = adapter.finalizeObjectCommit(ctx, opts, nextStatus, nextVersion, segments, totalPlainSize, totalEncryptedSize, fixedSegmentSize, &object)
 		if err != nil {
+			if prepared == nil && ErrObjectNotFound.Has(err) {
+				// objects with a prepared commit can be committed only with
+				// the confirmation.
+				if deadline, derr := adapter.preparedCommitDeadline(ctx, opts.ObjectStream); derr == nil && deadline != nil {
+					return ErrPreparedCommitInvalid.New("object commit is prepared and must be confirmed")
+				}
+			}
 			return err
 		}
 
@@ -1176,11 +1271,15 @@ func (db *DB) CommitObject(ctx context.Context, opts CommitObject) (object Objec
 		object.TotalPlainSize = totalPlainSize
 		object.TotalEncryptedSize = totalEncryptedSize
 		object.FixedSegmentSize = fixedSegmentSize
+		object.Checksum = opts.Checksum
 		return nil
 	})
 	if err != nil {
 		return Object{}, err
 	}
+	if err := db.metadataEncryption.decryptObject(&object); err != nil {
+		return Object{}, err
+	}
 
 	precommit.submitMetrics()
 
@@ -1204,7 +1303,7 @@ func (ptx *postgresTransactionAdapter) finalizeObjectCommit(ctx context.Context,
 		encryptionParameters{&opts.Encryption},
 	}
 
-	args = append(args, nextVersion)
+	args = append(args, nextVersion, opts.Checksum)
 
 	metadataColumns := ""
 	if opts.OverrideEncryptedMetadata {
@@ -1212,11 +1311,13 @@ func (ptx *postgresTransactionAdapter) finalizeObjectCommit(ctx context.Context,
 			opts.EncryptedMetadataNonce,
 			opts.EncryptedMetadata,
 			opts.EncryptedMetadataEncryptedKey,
+			opts.encryptedMetadataKeyID,
 		)
 		metadataColumns = `,
-				encrypted_metadata_nonce         = $13,
-				encrypted_metadata               = $14,
-				encrypted_metadata_encrypted_key = $15
+				encrypted_metadata_nonce         = $14,
+				encrypted_metadata               = $15,
+				encrypted_metadata_encrypted_key = $16,
+				encrypted_metadata_key_id        = $17
 			`
 	}
 	err = ptx.tx.QueryRowContext(ctx, `
@@ -1229,6 +1330,7 @@ func (ptx *postgresTransactionAdapter) finalizeObjectCommit(ctx context.Context,
 				total_encrypted_size = $9,
 				fixed_segment_size   = $10,
 				zombie_deletion_deadline = NULL,
+				checksum             = $13,
 
 				-- TODO should we allow to override existing encryption parameters or return error if don't match with opts?
 				encryption = CASE
@@ -1238,7 +1340,8 @@ func (ptx *postgresTransactionAdapter) finalizeObjectCommit(ctx context.Context,
 				END
 				`+metadataColumns+`
 			WHERE (project_id, bucket_name, object_key, version, stream_id) = ($1, $2, $3, $4, $5) AND
-				status       = `+statusPending+`
+				status       = `+statusPending+` AND
+				prepared_commit_deadline IS NULL
 			RETURNING
 				created_at, expires_at,
 				encrypted_metadata, encrypted_metadata_encrypted_key, encrypted_metadata_nonce,
@@ -1278,6 +1381,7 @@ func (stx *spannerTransactionAdapter) finalizeObjectCommit(ctx context.Context,
 		oldEncryptedMetadata             []byte
 		oldEncryptedMetadataEncryptedKey []byte
 		oldEncryptedMetadataNonce        []byte
+		oldEncryptedMetadataKeyID        int64
 		oldEncryptionParameters          storj.EncryptionParameters
 	)
 	retentionMode := retentionModeWrapper{&object.Retention.Mode}
@@ -1296,9 +1400,11 @@ func (stx *spannerTransactionAdapter) finalizeObjectCommit(ctx context.Context,
 					AND version     = @version
 					AND stream_id   = @stream_id
 					AND status      = ` + statusPending + `
+					AND prepared_commit_deadline IS NULL
 				THEN RETURN
 					created_at, expires_at,
 					encrypted_metadata, encrypted_metadata_encrypted_key, encrypted_metadata_nonce,
+					encrypted_metadata_key_id,
 					encryption,
 					retention_mode, retain_until
 			`,
@@ -1314,6 +1420,7 @@ func (stx *spannerTransactionAdapter) finalizeObjectCommit(ctx context.Context,
 		return Error.Wrap(row.Columns(
 			&object.CreatedAt, &object.ExpiresAt,
 			&oldEncryptedMetadata, &oldEncryptedMetadataEncryptedKey, &oldEncryptedMetadataNonce,
+			&oldEncryptedMetadataKeyID,
 			encryptionParameters{&oldEncryptionParameters}, retentionMode, retainUntil,
 		))
 	})
@@ -1343,6 +1450,7 @@ func (stx *spannerTransactionAdapter) finalizeObjectCommit(ctx context.Context,
 		oldEncryptedMetadataNonce = opts.EncryptedMetadataNonce
 		oldEncryptedMetadata = opts.EncryptedMetadata
 		oldEncryptedMetadataEncryptedKey = opts.EncryptedMetadataEncryptedKey
+		oldEncryptedMetadataKeyID = int64(opts.encryptedMetadataKeyID)
 	}
 	args := map[string]interface{}{
 		"project_id":                       opts.ProjectID,
@@ -1357,6 +1465,7 @@ func (stx *spannerTransactionAdapter) finalizeObjectCommit(ctx context.Context,
 		"encrypted_metadata_nonce":         oldEncryptedMetadataNonce,
 		"encrypted_metadata":               oldEncryptedMetadata,
 		"encrypted_metadata_encrypted_key": oldEncryptedMetadataEncryptedKey,
+		"encrypted_metadata_key_id":        oldEncryptedMetadataKeyID,
 		"total_plain_size":                 totalPlainSize,
 		"total_encrypted_size":             totalEncryptedSize,
 		"fixed_segment_size":               int64(fixedSegmentSize),
@@ -1364,6 +1473,7 @@ func (stx *spannerTransactionAdapter) finalizeObjectCommit(ctx context.Context,
 		"retention_mode":                   retentionMode,
 		"retain_until":                     retainUntil,
 		"next_version":                     nextVersion,
+		"checksum":                         opts.Checksum,
 	}
 
 	_, err = stx.tx.Update(ctx, spanner.Statement{
@@ -1372,16 +1482,20 @@ func (stx *spannerTransactionAdapter) finalizeObjectCommit(ctx context.Context,
 			    project_id, bucket_name, object_key, version,
 				stream_id, created_at, expires_at, status, segment_count,
 				encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
+				encrypted_metadata_key_id,
 			    total_plain_size, total_encrypted_size, fixed_segment_size,
 			    encryption, zombie_deletion_deadline,
-				retention_mode, retain_until
+				retention_mode, retain_until,
+				checksum
 			) VALUES (
 			    @project_id, @bucket_name, @object_key, @version,
 				@stream_id, @created_at, @expires_at, @status, @segment_count,
 				@encrypted_metadata_nonce, @encrypted_metadata, @encrypted_metadata_encrypted_key,
+				@encrypted_metadata_key_id,
 				@total_plain_size, @total_encrypted_size, @fixed_segment_size,
 				@encryption, NULL,
-				@retention_mode, @retain_until
+				@retention_mode, @retain_until,
+				@checksum
 			)
 		`,
 		Params: args,
@@ -1447,6 +1561,9 @@ type CommitInlineObject struct {
 
 	// Versioned indicates whether an object is allowed to have multiple versions.
 	Versioned bool
+
+	// Quota is the optional quota of the bucket the object is committed to.
+	Quota BucketQuota
 }
 
 // Verify verifies reqest fields.
@@ -1489,6 +1606,11 @@ func (db *DB) CommitInlineObject(ctx context.Context, opts CommitInlineObject) (
 		return Object{}, err
 	}
 
+	encryptedMetadataKeyID, err := db.metadataEncryption.encryptMetadata(opts.ProjectID, &opts.EncryptedMetadata, &opts.EncryptedMetadataEncryptedKey)
+	if err != nil {
+		return Object{}, err
+	}
+
 	var precommit PrecommitConstraintResult
 	err = db.ChooseAdapter(opts.ProjectID).WithTx(ctx, func(ctx context.Context, adapter TransactionAdapter) error {
 		precommit, err = db.PrecommitConstraint(ctx, PrecommitConstraint{
@@ -1500,6 +1622,12 @@ func (db *DB) CommitInlineObject(ctx context.Context, opts CommitInlineObject) (
 			return err
 		}
 
+		err = db.checkBucketQuota(ctx, opts.Location().Bucket(), opts.Quota,
+			1-int64(precommit.DeletedObjectCount), 1-int64(precommit.DeletedSegmentCount))
+		if err != nil {
+			return err
+		}
+
 		nextVersion := precommit.HighestVersion + 1
 		nextStatus := committedWhereVersioned(opts.Versioned)
 
@@ -1531,11 +1659,14 @@ func (db *DB) CommitInlineObject(ctx context.Context, opts CommitInlineObject) (
 			InlineData:        opts.InlineData,
 		}
 
-		return adapter.finalizeInlineObjectCommit(ctx, &object, segment)
+		return adapter.finalizeInlineObjectCommit(ctx, &object, encryptedMetadataKeyID, segment)
 	})
 	if err != nil {
 		return Object{}, err
 	}
+	if err := db.metadataEncryption.decryptObject(&object); err != nil {
+		return Object{}, err
+	}
 
 	precommit.submitMetrics()
 
@@ -1546,7 +1677,7 @@ func (db *DB) CommitInlineObject(ctx context.Context, opts CommitInlineObject) (
 	return object, nil
 }
 
-func (ptx *postgresTransactionAdapter) finalizeInlineObjectCommit(ctx context.Context, object *Object, segment *Segment) (err error) {
+func (ptx *postgresTransactionAdapter) finalizeInlineObjectCommit(ctx context.Context, object *Object, encryptedMetadataKeyID uint16, segment *Segment) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	// TODO should we put this into single query
@@ -1557,6 +1688,7 @@ func (ptx *postgresTransactionAdapter) finalizeInlineObjectCommit(ctx context.Co
 			total_plain_size, total_encrypted_size,
 			zombie_deletion_deadline,
 			encrypted_metadata, encrypted_metadata_nonce, encrypted_metadata_encrypted_key,
+			encrypted_metadata_key_id,
 			retention_mode, retain_until
 		) VALUES (
 			$1, $2, $3, $4, $5,
@@ -1564,7 +1696,8 @@ func (ptx *postgresTransactionAdapter) finalizeInlineObjectCommit(ctx context.Co
 			$10, $11,
 			$12,
 			$13, $14, $15,
-			$16, $17
+			$16,
+			$17, $18
 		)
 		RETURNING created_at`,
 		object.ProjectID, object.BucketName, object.ObjectKey, object.Version, object.StreamID,
@@ -1572,6 +1705,7 @@ func (ptx *postgresTransactionAdapter) finalizeInlineObjectCommit(ctx context.Co
 		object.TotalPlainSize, object.TotalEncryptedSize,
 		nil,
 		object.EncryptedMetadata, object.EncryptedMetadataNonce, object.EncryptedMetadataEncryptedKey,
+		encryptedMetadataKeyID,
 		retentionModeWrapper{&object.Retention.Mode}, timeWrapper{&object.Retention.RetainUntil},
 	).Scan(&object.CreatedAt)
 	if err != nil {
@@ -1604,7 +1738,7 @@ func (ptx *postgresTransactionAdapter) finalizeInlineObjectCommit(ctx context.Co
 	return nil
 }
 
-func (stx *spannerTransactionAdapter) finalizeInlineObjectCommit(ctx context.Context, object *Object, segment *Segment) (err error) {
+func (stx *spannerTransactionAdapter) finalizeInlineObjectCommit(ctx context.Context, object *Object, encryptedMetadataKeyID uint16, segment *Segment) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	// TODO(spanner) should we perform these two inserts as a Migration
@@ -1616,6 +1750,7 @@ func (stx *spannerTransactionAdapter) finalizeInlineObjectCommit(ctx context.Con
 				total_plain_size, total_encrypted_size,
 				zombie_deletion_deadline,
 				encrypted_metadata, encrypted_metadata_nonce, encrypted_metadata_encrypted_key,
+				encrypted_metadata_key_id,
 				retention_mode, retain_until
 			) VALUES (
 				@project_id, @bucket_name, @object_key, @version, @stream_id,
@@ -1623,6 +1758,7 @@ func (stx *spannerTransactionAdapter) finalizeInlineObjectCommit(ctx context.Con
 				@total_plain_size, @total_encrypted_size,
 				@zombie_deletion_deadline,
 				@encrypted_metadata, @encrypted_metadata_nonce, @encrypted_metadata_encrypted_key,
+				@encrypted_metadata_key_id,
 				@retention_mode, @retain_until
 			)
 			THEN RETURN created_at
@@ -1643,6 +1779,7 @@ func (stx *spannerTransactionAdapter) finalizeInlineObjectCommit(ctx context.Con
 			"encrypted_metadata":               object.EncryptedMetadata,
 			"encrypted_metadata_nonce":         object.EncryptedMetadataNonce,
 			"encrypted_metadata_encrypted_key": object.EncryptedMetadataEncryptedKey,
+			"encrypted_metadata_key_id":        int64(encryptedMetadataKeyID),
 			"retention_mode":                   retentionModeWrapper{&object.Retention.Mode},
 			"retain_until":                     timeWrapper{&object.Retention.RetainUntil},
 		},
@@ -45,6 +45,9 @@ type CommitObjectWithSegments struct {
 
 	// Versioned indicates whether an object is allowed to have multiple versions.
 	Versioned bool
+
+	// encryptedMetadataKeyID is the key the metadata is wrapped with, set by DB.
+	encryptedMetadataKeyID uint16
 }
 
 // CommitObjectWithSegments commits pending object to the database.
@@ -60,6 +63,11 @@ func (db *DB) CommitObjectWithSegments(ctx context.Context, opts CommitObjectWit
 		return Object{}, err
 	}
 
+	opts.encryptedMetadataKeyID, err = db.metadataEncryption.encryptMetadata(opts.ProjectID, &opts.EncryptedMetadata, &opts.EncryptedMetadataEncryptedKey)
+	if err != nil {
+		return Object{}, err
+	}
+
 	var deletedSegmentCount int64
 	var precommit PrecommitConstraintResult
 	err = db.ChooseAdapter(opts.ProjectID).WithTx(ctx, func(ctx context.Context, adapter TransactionAdapter) error {
@@ -147,6 +155,9 @@ func (db *DB) CommitObjectWithSegments(ctx context.Context, opts CommitObjectWit
 	if err != nil {
 		return Object{}, err
 	}
+	if err := db.metadataEncryption.decryptObject(&object); err != nil {
+		return Object{}, err
+	}
 
 	precommit.submitMetrics()
 
@@ -170,13 +181,15 @@ func (ptx *postgresTransactionAdapter) finalizeObjectCommitWithSegments(ctx cont
 				encrypted_metadata_nonce         = $8,
 				encrypted_metadata               = $9,
 				encrypted_metadata_encrypted_key = $10,
+				encrypted_metadata_key_id        = $15,
 
 				total_plain_size     = $11,
 				total_encrypted_size = $12,
 				fixed_segment_size   = $13,
 				zombie_deletion_deadline = NULL
 			WHERE (project_id, bucket_name, object_key, version, stream_id) = ($1, $2, $3, $4, $5) AND
-				status = `+statusPending+`
+				status = `+statusPending+` AND
+				prepared_commit_deadline IS NULL
 			RETURNING
 				created_at, expires_at,
 				encryption;
@@ -187,6 +200,7 @@ func (ptx *postgresTransactionAdapter) finalizeObjectCommitWithSegments(ctx cont
 		totalEncryptedSize,
 		fixedSegmentSize,
 		nextVersion,
+		opts.encryptedMetadataKeyID,
 	).
 		Scan(
 			&object.CreatedAt, &object.ExpiresAt,
@@ -218,6 +232,7 @@ func (stx *spannerTransactionAdapter) finalizeObjectCommitWithSegments(ctx conte
 				AND version     = @previous_version
 				AND stream_id   = @stream_id
 				AND status      = ` + statusPending + `
+				AND prepared_commit_deadline IS NULL
 			THEN RETURN
 				created_at, expires_at, encryption
 		`,
@@ -251,6 +266,7 @@ func (stx *spannerTransactionAdapter) finalizeObjectCommitWithSegments(ctx conte
 				created_at, expires_at, status,
 			    segment_count,
 				encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
+				encrypted_metadata_key_id,
 			    total_plain_size, total_encrypted_size, fixed_segment_size,
 				encryption, zombie_deletion_deadline
 			) VALUES (
@@ -259,6 +275,7 @@ func (stx *spannerTransactionAdapter) finalizeObjectCommitWithSegments(ctx conte
 				@created_at, @expires_at, @status,
 			    @segment_count,
 				@encrypted_metadata_nonce, @encrypted_metadata, @encrypted_metadata_encrypted_key,
+				@encrypted_metadata_key_id,
 			    @total_plain_size, @total_encrypted_size, @fixed_segment_size,
 				@encryption, NULL
 			)
@@ -276,6 +293,7 @@ func (stx *spannerTransactionAdapter) finalizeObjectCommitWithSegments(ctx conte
 			"encrypted_metadata_nonce":         opts.EncryptedMetadataNonce,
 			"encrypted_metadata":               opts.EncryptedMetadata,
 			"encrypted_metadata_encrypted_key": opts.EncryptedMetadataEncryptedKey,
+			"encrypted_metadata_key_id":        int64(opts.encryptedMetadataKeyID),
 			"total_plain_size":                 totalPlainSize,
 			"total_encrypted_size":             totalEncryptedSize,
 			"fixed_segment_size":               int64(fixedSegmentSize),
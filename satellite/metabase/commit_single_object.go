@@ -0,0 +1,517 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/spanner"
+
+	"storj.io/common/storj"
+)
+
+// CommitSingleObject contains arguments necessary for creating and committing an object
+// together with its only segment. The segment is inline when Redundancy and Pieces
+// aren't set, otherwise it's remote.
+type CommitSingleObject struct {
+	ObjectStream
+
+	ExpiresAt  *time.Time
+	Encryption storj.EncryptionParameters
+
+	EncryptedMetadata             []byte // optional
+	EncryptedMetadataNonce        []byte // optional
+	EncryptedMetadataEncryptedKey []byte // optional
+
+	Retention Retention // optional
+
+	DisallowDelete bool
+
+	// Versioned indicates whether an object is allowed to have multiple versions.
+	Versioned bool
+
+	Position SegmentPosition
+
+	EncryptedKeyNonce []byte
+	EncryptedKey      []byte
+
+	PlainSize     int32 // size before encryption
+	EncryptedSize int32 // segment size after encryption, ignored for inline segments
+
+	EncryptedETag []byte
+
+	// InlineData is the content of an inline segment.
+	InlineData []byte
+
+	// RootPieceID, Redundancy, Pieces and Placement describe a remote segment.
+	RootPieceID storj.PieceID
+	Redundancy  storj.RedundancyScheme
+	Pieces      Pieces
+	Placement   storj.PlacementConstraint
+
+	// Quota is the optional quota of the bucket the object is committed to.
+	Quota BucketQuota
+}
+
+// Inline returns whether the segment of the object is inline.
+func (c *CommitSingleObject) Inline() bool {
+	return c.Redundancy.IsZero() && len(c.Pieces) == 0
+}
+
+// Verify verifies request fields.
+func (c *CommitSingleObject) Verify() error {
+	if err := c.ObjectStream.Verify(); err != nil {
+		return err
+	}
+
+	switch {
+	case len(c.EncryptedKey) == 0:
+		return ErrInvalidRequest.New("EncryptedKey missing")
+	case len(c.EncryptedKeyNonce) == 0:
+		return ErrInvalidRequest.New("EncryptedKeyNonce missing")
+	case c.PlainSize <= 0 && ValidatePlainSize:
+		return ErrInvalidRequest.New("PlainSize negative or zero")
+	}
+
+	if c.Inline() {
+		if !c.RootPieceID.IsZero() {
+			return ErrInvalidRequest.New("RootPieceID must not be set for inline segment")
+		}
+	} else {
+		if err := c.Pieces.Verify(); err != nil {
+			return err
+		}
+
+		switch {
+		case len(c.InlineData) > 0:
+			return ErrInvalidRequest.New("InlineData must not be set for remote segment")
+		case c.RootPieceID.IsZero():
+			return ErrInvalidRequest.New("RootPieceID missing")
+		case c.EncryptedSize <= 0:
+			return ErrInvalidRequest.New("EncryptedSize negative or zero")
+		case c.Redundancy.IsZero():
+			return ErrInvalidRequest.New("Redundancy zero")
+		case len(c.Pieces) < int(c.Redundancy.OptimalShares):
+			return ErrInvalidRequest.New("number of pieces is less than redundancy optimal shares value")
+		}
+	}
+
+	if c.Encryption.CipherSuite != storj.EncUnspecified && c.Encryption.BlockSize <= 0 {
+		return ErrInvalidRequest.New("Encryption.BlockSize is negative or zero")
+	}
+
+	if c.EncryptedMetadata == nil && (c.EncryptedMetadataNonce != nil || c.EncryptedMetadataEncryptedKey != nil) {
+		return ErrInvalidRequest.New("EncryptedMetadataNonce and EncryptedMetadataEncryptedKey must be not set if EncryptedMetadata is not set")
+	} else if c.EncryptedMetadata != nil && (c.EncryptedMetadataNonce == nil || c.EncryptedMetadataEncryptedKey == nil) {
+		return ErrInvalidRequest.New("EncryptedMetadataNonce and EncryptedMetadataEncryptedKey must be set if EncryptedMetadata is set")
+	}
+
+	if err := c.Retention.Verify(); err != nil {
+		return ErrInvalidRequest.Wrap(err)
+	}
+
+	if c.Retention.Enabled() && c.ExpiresAt != nil {
+		return ErrInvalidRequest.New("ExpiresAt must not be set if Retention is set")
+	}
+
+	return nil
+}
+
+// CommitSingleObject creates and commits an object with a single inline or remote segment
+// without a separate begin and commit round trip. If another committed object is under
+// target location it will be deleted.
+func (db *DB) CommitSingleObject(ctx context.Context, opts CommitSingleObject) (object Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return Object{}, err
+	}
+
+	commit, err := db.prepareSingleObjectCommit(ctx, opts)
+	if err != nil {
+		return Object{}, err
+	}
+
+	var precommit PrecommitConstraintResult
+	err = db.ChooseAdapter(opts.ProjectID).WithTx(ctx, func(ctx context.Context, adapter TransactionAdapter) error {
+		object, precommit, err = db.commitSingleObject(ctx, adapter, commit)
+		return err
+	})
+	if err != nil {
+		return Object{}, err
+	}
+	if err := db.metadataEncryption.decryptObject(&object); err != nil {
+		return Object{}, err
+	}
+
+	precommit.submitMetrics()
+
+	mon.Meter("object_commit").Mark(1)
+	mon.Meter("object_commit_single").Mark(1)
+	mon.IntVal("object_commit_segments").Observe(int64(object.SegmentCount))
+	mon.IntVal("object_commit_encrypted_size").Observe(object.TotalEncryptedSize)
+
+	return object, nil
+}
+
+// CommitInlineObjects contains arguments necessary for creating and committing
+// multiple objects with a single inline segment each.
+type CommitInlineObjects struct {
+	Objects []CommitSingleObject
+}
+
+// Verify verifies request fields.
+func (c *CommitInlineObjects) Verify() error {
+	if len(c.Objects) == 0 {
+		return ErrInvalidRequest.New("Objects missing")
+	}
+
+	locations := make(map[ObjectLocation]struct{}, len(c.Objects))
+	for i := range c.Objects {
+		object := &c.Objects[i]
+		if err := object.Verify(); err != nil {
+			return err
+		}
+
+		switch {
+		case !object.Inline():
+			return ErrInvalidRequest.New("object %d has a remote segment", i)
+		case object.ProjectID != c.Objects[0].ProjectID:
+			return ErrInvalidRequest.New("all objects must belong to the same project")
+		}
+
+		// the segments are buffered on Spanner, so a later object can't replace
+		// an object committed earlier in the same transaction.
+		location := object.Location()
+		if _, ok := locations[location]; ok {
+			return ErrInvalidRequest.New("object %d has a duplicated location", i)
+		}
+		locations[location] = struct{}{}
+	}
+
+	return nil
+}
+
+// CommitInlineObjects creates and commits multiple objects with a single inline segment
+// each in one transaction. Either all objects are committed or none of them. Committed
+// objects under the target locations are deleted the same way as with CommitSingleObject.
+func (db *DB) CommitInlineObjects(ctx context.Context, opts CommitInlineObjects) (objects []Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return nil, err
+	}
+
+	var buckets []BucketLocation
+	quotas := map[BucketLocation]BucketQuota{}
+	counts := map[BucketLocation]int64{}
+
+	commits := make([]singleObjectCommit, 0, len(opts.Objects))
+	for _, object := range opts.Objects {
+		bucket := object.Location().Bucket()
+		if _, ok := counts[bucket]; !ok {
+			buckets = append(buckets, bucket)
+			quotas[bucket] = object.Quota
+		}
+		counts[bucket]++
+
+		// the quotas are checked for the whole batch at once, because the bucket
+		// statistics don't include the objects committed earlier in the batch.
+		object.Quota = BucketQuota{}
+
+		commit, err := db.prepareSingleObjectCommit(ctx, object)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, commit)
+	}
+
+	var precommits []PrecommitConstraintResult
+	err = db.ChooseAdapter(opts.Objects[0].ProjectID).WithTx(ctx, func(ctx context.Context, adapter TransactionAdapter) error {
+		// the transaction may be retried.
+		objects = make([]Object, 0, len(commits))
+		precommits = make([]PrecommitConstraintResult, 0, len(commits))
+
+		// the objects replaced by the batch are still counted here, hence the
+		// check is stricter than for the objects committed one by one.
+		for _, bucket := range buckets {
+			// every inline object has a single segment.
+			if err := db.checkBucketQuota(ctx, bucket, quotas[bucket], counts[bucket], counts[bucket]); err != nil {
+				return err
+			}
+		}
+
+		for _, commit := range commits {
+			object, precommit, err := db.commitSingleObject(ctx, adapter, commit)
+			if err != nil {
+				return err
+			}
+			objects = append(objects, object)
+			precommits = append(precommits, precommit)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range objects {
+		if err := db.metadataEncryption.decryptObject(&objects[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, precommit := range precommits {
+		precommit.submitMetrics()
+	}
+
+	mon.Meter("object_commit").Mark(len(objects))
+	mon.Meter("object_commit_inline_batch").Mark(1)
+	mon.IntVal("object_commit_inline_batch_size").Observe(int64(len(objects)))
+	for _, object := range objects {
+		mon.IntVal("object_commit_segments").Observe(int64(object.SegmentCount))
+		mon.IntVal("object_commit_encrypted_size").Observe(object.TotalEncryptedSize)
+	}
+
+	return objects, nil
+}
+
+// singleObjectCommit contains the verified arguments of an object commit together
+// with the segment that is inserted.
+type singleObjectCommit struct {
+	opts                   CommitSingleObject
+	encryptedMetadataKeyID uint16
+	segment                *Segment
+	aliasPieces            AliasPieces
+}
+
+// prepareSingleObjectCommit encrypts the object metadata and prepares the segment of
+// the object. It's done outside of the transaction to keep it as short as possible.
+func (db *DB) prepareSingleObjectCommit(ctx context.Context, opts CommitSingleObject) (_ singleObjectCommit, err error) {
+	encryptedMetadataKeyID, err := db.metadataEncryption.encryptMetadata(opts.ProjectID, &opts.EncryptedMetadata, &opts.EncryptedMetadataEncryptedKey)
+	if err != nil {
+		return singleObjectCommit{}, err
+	}
+
+	segment := &Segment{
+		StreamID:          opts.StreamID,
+		Position:          opts.Position,
+		ExpiresAt:         opts.ExpiresAt,
+		EncryptedKey:      opts.EncryptedKey,
+		EncryptedKeyNonce: opts.EncryptedKeyNonce,
+		EncryptedETag:     opts.EncryptedETag,
+		PlainSize:         opts.PlainSize,
+		EncryptedSize:     opts.EncryptedSize,
+		RootPieceID:       opts.RootPieceID,
+		Redundancy:        opts.Redundancy,
+		Pieces:            opts.Pieces,
+		Placement:         opts.Placement,
+	}
+
+	var aliasPieces AliasPieces
+	if opts.Inline() {
+		segment.EncryptedSize = int32(len(opts.InlineData))
+		segment.InlineData = opts.InlineData
+	} else {
+		aliasPieces, err = db.aliasCache.EnsurePiecesToAliases(ctx, opts.Pieces)
+		if err != nil {
+			return singleObjectCommit{}, Error.New("unable to convert pieces to aliases: %w", err)
+		}
+	}
+
+	return singleObjectCommit{
+		opts:                   opts,
+		encryptedMetadataKeyID: encryptedMetadataKeyID,
+		segment:                segment,
+		aliasPieces:            aliasPieces,
+	}, nil
+}
+
+// commitSingleObject checks the precommit constraint and inserts the object with its segment.
+func (db *DB) commitSingleObject(ctx context.Context, adapter TransactionAdapter, commit singleObjectCommit) (object Object, precommit PrecommitConstraintResult, err error) {
+	opts, segment := commit.opts, commit.segment
+
+	precommit, err = db.PrecommitConstraint(ctx, PrecommitConstraint{
+		Location:       opts.Location(),
+		Versioned:      opts.Versioned,
+		DisallowDelete: opts.DisallowDelete,
+	}, adapter)
+	if err != nil {
+		return Object{}, PrecommitConstraintResult{}, err
+	}
+
+	err = db.checkBucketQuota(ctx, opts.Location().Bucket(), opts.Quota,
+		1-int64(precommit.DeletedObjectCount), 1-int64(precommit.DeletedSegmentCount))
+	if err != nil {
+		return Object{}, PrecommitConstraintResult{}, err
+	}
+
+	object.StreamID = opts.StreamID
+	object.ProjectID = opts.ProjectID
+	object.BucketName = opts.BucketName
+	object.ObjectKey = opts.ObjectKey
+	object.Version = precommit.HighestVersion + 1
+	object.Status = committedWhereVersioned(opts.Versioned)
+	object.SegmentCount = 1
+	object.TotalPlainSize = int64(segment.PlainSize)
+	object.TotalEncryptedSize = int64(segment.EncryptedSize)
+	if !opts.Inline() {
+		// the same as for objects committed with CommitObject.
+		object.FixedSegmentSize = -1
+		if opts.Position == (SegmentPosition{}) {
+			object.FixedSegmentSize = segment.PlainSize
+		}
+	}
+	object.ExpiresAt = opts.ExpiresAt
+	object.Encryption = opts.Encryption
+	object.EncryptedMetadata = opts.EncryptedMetadata
+	object.EncryptedMetadataEncryptedKey = opts.EncryptedMetadataEncryptedKey
+	object.EncryptedMetadataNonce = opts.EncryptedMetadataNonce
+	object.Retention = opts.Retention
+
+	if err := adapter.finalizeSingleObjectCommit(ctx, &object, commit.encryptedMetadataKeyID, segment, commit.aliasPieces); err != nil {
+		return Object{}, PrecommitConstraintResult{}, err
+	}
+	return object, precommit, nil
+}
+
+func (ptx *postgresTransactionAdapter) finalizeSingleObjectCommit(ctx context.Context, object *Object, encryptedMetadataKeyID uint16, segment *Segment, aliasPieces AliasPieces) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = ptx.tx.QueryRowContext(ctx, `
+		WITH new_object AS (
+			INSERT INTO objects (
+				project_id, bucket_name, object_key, version, stream_id,
+				status, segment_count, expires_at, encryption,
+				total_plain_size, total_encrypted_size, fixed_segment_size,
+				zombie_deletion_deadline,
+				encrypted_metadata, encrypted_metadata_nonce, encrypted_metadata_encrypted_key,
+				encrypted_metadata_key_id,
+				retention_mode, retain_until
+			) VALUES (
+				$1, $2, $3, $4, $5,
+				$6, $7, $8, $9,
+				$10, $11, $12,
+				NULL,
+				$13, $14, $15,
+				$29,
+				$16, $17
+			)
+			RETURNING created_at
+		), new_segment AS (
+			INSERT INTO segments (
+				stream_id, position, expires_at,
+				root_piece_id, encrypted_key_nonce, encrypted_key,
+				encrypted_size, encrypted_etag, plain_size, plain_offset,
+				redundancy, remote_alias_pieces, placement,
+				inline_data
+			) VALUES (
+				$5, $18, $8,
+				$19, $20, $21,
+				$22, $23, $24, 0, -- plain_offset is 0
+				$25, $26, $27,
+				$28
+			)
+		)
+		SELECT created_at FROM new_object`,
+		object.ProjectID, object.BucketName, object.ObjectKey, object.Version, object.StreamID,
+		object.Status, object.SegmentCount, object.ExpiresAt, encryptionParameters{&object.Encryption},
+		object.TotalPlainSize, object.TotalEncryptedSize, object.FixedSegmentSize,
+		object.EncryptedMetadata, object.EncryptedMetadataNonce, object.EncryptedMetadataEncryptedKey,
+		retentionModeWrapper{&object.Retention.Mode}, timeWrapper{&object.Retention.RetainUntil},
+		segment.Position,
+		segment.RootPieceID, segment.EncryptedKeyNonce, segment.EncryptedKey,
+		segment.EncryptedSize, segment.EncryptedETag, segment.PlainSize,
+		redundancyScheme{&segment.Redundancy}, aliasPieces, segment.Placement,
+		segment.InlineData,
+		encryptedMetadataKeyID,
+	).Scan(&object.CreatedAt)
+	if err != nil {
+		return Error.New("failed to create object: %w", err)
+	}
+
+	return nil
+}
+
+func (stx *spannerTransactionAdapter) finalizeSingleObjectCommit(ctx context.Context, object *Object, encryptedMetadataKeyID uint16, segment *Segment, aliasPieces AliasPieces) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// The segment is buffered as a mutation, so that the object and the segment are
+	// written without an additional round trip.
+	err = stx.tx.BufferWrite([]*spanner.Mutation{
+		spanner.Insert("segments",
+			[]string{
+				"stream_id", "position", "expires_at",
+				"root_piece_id", "encrypted_key_nonce", "encrypted_key",
+				"encrypted_size", "encrypted_etag", "plain_size", "plain_offset",
+				"redundancy", "remote_alias_pieces", "placement",
+				"inline_data",
+			}, []any{
+				segment.StreamID, segment.Position, segment.ExpiresAt,
+				segment.RootPieceID.Bytes(), segment.EncryptedKeyNonce, segment.EncryptedKey,
+				int64(segment.EncryptedSize), segment.EncryptedETag, int64(segment.PlainSize), int64(0),
+				redundancyScheme{&segment.Redundancy}, aliasPieces, int64(segment.Placement),
+				segment.InlineData,
+			},
+		),
+	})
+	if err != nil {
+		return Error.New("failed to create segment: %w", err)
+	}
+
+	err = stx.tx.Query(ctx, spanner.Statement{
+		SQL: `
+			INSERT INTO objects (
+				project_id, bucket_name, object_key, version, stream_id,
+				status, segment_count, expires_at, encryption,
+				total_plain_size, total_encrypted_size, fixed_segment_size,
+				zombie_deletion_deadline,
+				encrypted_metadata, encrypted_metadata_nonce, encrypted_metadata_encrypted_key,
+				encrypted_metadata_key_id,
+				retention_mode, retain_until
+			) VALUES (
+				@project_id, @bucket_name, @object_key, @version, @stream_id,
+				@status, @segment_count, @expires_at, @encryption_parameters,
+				@total_plain_size, @total_encrypted_size, @fixed_segment_size,
+				NULL,
+				@encrypted_metadata, @encrypted_metadata_nonce, @encrypted_metadata_encrypted_key,
+				@encrypted_metadata_key_id,
+				@retention_mode, @retain_until
+			)
+			THEN RETURN created_at
+		`,
+		Params: map[string]interface{}{
+			"project_id":                       object.ProjectID,
+			"bucket_name":                      object.BucketName,
+			"object_key":                       []byte(object.ObjectKey),
+			"version":                          object.Version,
+			"stream_id":                        object.StreamID,
+			"status":                           object.Status,
+			"segment_count":                    int64(object.SegmentCount),
+			"expires_at":                       object.ExpiresAt,
+			"encryption_parameters":            encryptionParameters{&object.Encryption},
+			"total_plain_size":                 object.TotalPlainSize,
+			"total_encrypted_size":             object.TotalEncryptedSize,
+			"fixed_segment_size":               int64(object.FixedSegmentSize),
+			"encrypted_metadata":               object.EncryptedMetadata,
+			"encrypted_metadata_nonce":         object.EncryptedMetadataNonce,
+			"encrypted_metadata_encrypted_key": object.EncryptedMetadataEncryptedKey,
+			"encrypted_metadata_key_id":        int64(encryptedMetadataKeyID),
+			"retention_mode":                   retentionModeWrapper{&object.Retention.Mode},
+			"retain_until":                     timeWrapper{&object.Retention.RetainUntil},
+		},
+	}).Do(func(row *spanner.Row) error {
+		err := row.Columns(&object.CreatedAt)
+		if err != nil {
+			return Error.New("failed to read object created_at: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return Error.New("failed to create object: %w", err)
+	}
+
+	return nil
+}
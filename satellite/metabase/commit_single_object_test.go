@@ -0,0 +1,456 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+	"time"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestCommitSingleObject(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+		obj.Version = 0
+
+		for _, test := range metabasetest.InvalidObjectStreams(obj) {
+			test := test
+			t.Run(test.Name, func(t *testing.T) {
+				defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+				metabasetest.CommitSingleObject{
+					Opts: metabase.CommitSingleObject{
+						ObjectStream: test.ObjectStream,
+					},
+					ErrClass: test.ErrClass,
+					ErrText:  test.ErrText,
+				}.Check(ctx, t, db)
+				metabasetest.Verify{}.Check(ctx, t, db)
+			})
+		}
+
+		t.Run("invalid request", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			for _, test := range []struct {
+				opts    metabase.CommitSingleObject
+				errText string
+			}{
+				{
+					opts:    metabase.CommitSingleObject{},
+					errText: "EncryptedKey missing",
+				},
+				{
+					opts: metabase.CommitSingleObject{
+						EncryptedKey: testrand.Bytes(32),
+					},
+					errText: "EncryptedKeyNonce missing",
+				},
+				{
+					opts: metabase.CommitSingleObject{
+						EncryptedKey:      testrand.Bytes(32),
+						EncryptedKeyNonce: testrand.Bytes(32),
+						PlainSize:         512,
+						RootPieceID:       testrand.PieceID(),
+						InlineData:        testrand.Bytes(100),
+					},
+					errText: "RootPieceID must not be set for inline segment",
+				},
+				{
+					opts: metabase.CommitSingleObject{
+						EncryptedKey:      testrand.Bytes(32),
+						EncryptedKeyNonce: testrand.Bytes(32),
+						PlainSize:         512,
+						Redundancy:        metabasetest.DefaultRedundancy,
+						Pieces:            metabase.Pieces{{Number: 0, StorageNode: testrand.NodeID()}},
+						InlineData:        testrand.Bytes(100),
+					},
+					errText: "InlineData must not be set for remote segment",
+				},
+				{
+					opts: metabase.CommitSingleObject{
+						EncryptedKey:      testrand.Bytes(32),
+						EncryptedKeyNonce: testrand.Bytes(32),
+						PlainSize:         512,
+						Redundancy:        metabasetest.DefaultRedundancy,
+						Pieces:            metabase.Pieces{{Number: 0, StorageNode: testrand.NodeID()}},
+					},
+					errText: "RootPieceID missing",
+				},
+				{
+					opts: metabase.CommitSingleObject{
+						EncryptedKey:      testrand.Bytes(32),
+						EncryptedKeyNonce: testrand.Bytes(32),
+						PlainSize:         512,
+						RootPieceID:       testrand.PieceID(),
+						Redundancy:        metabasetest.DefaultRedundancy,
+						Pieces:            metabase.Pieces{{Number: 0, StorageNode: testrand.NodeID()}},
+					},
+					errText: "EncryptedSize negative or zero",
+				},
+				{
+					opts: metabase.CommitSingleObject{
+						EncryptedKey:      testrand.Bytes(32),
+						EncryptedKeyNonce: testrand.Bytes(32),
+						PlainSize:         512,
+						EncryptedSize:     1024,
+						RootPieceID:       testrand.PieceID(),
+						Pieces:            metabase.Pieces{{Number: 0, StorageNode: testrand.NodeID()}},
+					},
+					errText: "Redundancy zero",
+				},
+			} {
+				test.opts.ObjectStream = obj
+				metabasetest.CommitSingleObject{
+					Opts:     test.opts,
+					ErrClass: &metabase.ErrInvalidRequest,
+					ErrText:  test.errText,
+				}.Check(ctx, t, db)
+			}
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("commit inline object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			now := time.Now()
+			encryptedKey := testrand.Bytes(32)
+			encryptedKeyNonce := testrand.Bytes(32)
+			inlineData := testrand.Bytes(100)
+
+			object := metabasetest.CommitSingleObject{
+				Opts: metabase.CommitSingleObject{
+					ObjectStream:      obj,
+					Encryption:        metabasetest.DefaultEncryption,
+					EncryptedKey:      encryptedKey,
+					EncryptedKeyNonce: encryptedKeyNonce,
+					PlainSize:         512,
+					InlineData:        inlineData,
+				},
+				ExpectVersion: 1,
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(object),
+				},
+				Segments: []metabase.RawSegment{
+					{
+						StreamID:  obj.StreamID,
+						CreatedAt: now,
+
+						EncryptedKey:      encryptedKey,
+						EncryptedKeyNonce: encryptedKeyNonce,
+
+						EncryptedSize: int32(len(inlineData)),
+						PlainSize:     512,
+						InlineData:    inlineData,
+					},
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("commit remote object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			now := time.Now()
+			segment := metabasetest.DefaultRawSegment(obj, metabase.SegmentPosition{})
+			segment.Placement = storj.EU
+
+			object := metabasetest.CommitSingleObject{
+				Opts: metabase.CommitSingleObject{
+					ObjectStream:      obj,
+					Encryption:        metabasetest.DefaultEncryption,
+					EncryptedKey:      segment.EncryptedKey,
+					EncryptedKeyNonce: segment.EncryptedKeyNonce,
+					EncryptedETag:     segment.EncryptedETag,
+					PlainSize:         segment.PlainSize,
+					EncryptedSize:     segment.EncryptedSize,
+					RootPieceID:       segment.RootPieceID,
+					Redundancy:        segment.Redundancy,
+					Pieces:            segment.Pieces,
+					Placement:         segment.Placement,
+				},
+				ExpectVersion: 1,
+			}.Check(ctx, t, db)
+
+			segment.CreatedAt = now
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					{
+						ObjectStream: object.ObjectStream,
+						CreatedAt:    now,
+						Status:       metabase.CommittedUnversioned,
+
+						SegmentCount:       1,
+						TotalPlainSize:     int64(segment.PlainSize),
+						TotalEncryptedSize: int64(segment.EncryptedSize),
+						FixedSegmentSize:   segment.PlainSize,
+
+						Encryption: metabasetest.DefaultEncryption,
+					},
+				},
+				Segments: []metabase.RawSegment{segment},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("overwrite", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			objA := obj
+			objA.Version = 123
+			metabasetest.CreateObject(ctx, t, db, objA, 2)
+
+			objB := obj
+			objB.StreamID = testrand.UUID()
+
+			now := time.Now()
+			encryptedKey := testrand.Bytes(32)
+			encryptedKeyNonce := testrand.Bytes(32)
+			inlineData := testrand.Bytes(100)
+
+			object := metabasetest.CommitSingleObject{
+				Opts: metabase.CommitSingleObject{
+					ObjectStream:      objB,
+					Encryption:        metabasetest.DefaultEncryption,
+					EncryptedKey:      encryptedKey,
+					EncryptedKeyNonce: encryptedKeyNonce,
+					PlainSize:         512,
+					InlineData:        inlineData,
+				},
+				ExpectVersion: objA.Version + 1,
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(object),
+				},
+				Segments: []metabase.RawSegment{
+					{
+						StreamID:  objB.StreamID,
+						CreatedAt: now,
+
+						EncryptedKey:      encryptedKey,
+						EncryptedKeyNonce: encryptedKeyNonce,
+
+						EncryptedSize: int32(len(inlineData)),
+						PlainSize:     512,
+						InlineData:    inlineData,
+					},
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("versioned", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			objA := obj
+			objA.Version = 5
+			objectA := metabasetest.CreateObjectVersioned(ctx, t, db, objA, 1)
+
+			objB := obj
+			objB.StreamID = testrand.UUID()
+
+			now := time.Now()
+			inlineData := testrand.Bytes(100)
+			objectB := metabasetest.CommitSingleObject{
+				Opts: metabase.CommitSingleObject{
+					ObjectStream:      objB,
+					Encryption:        metabasetest.DefaultEncryption,
+					EncryptedKey:      []byte{3},
+					EncryptedKeyNonce: []byte{4},
+					PlainSize:         512,
+					InlineData:        inlineData,
+					Versioned:         true,
+				},
+				ExpectVersion: objectA.Version + 1,
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(objectA),
+					metabase.RawObject(objectB),
+				},
+				Segments: []metabase.RawSegment{
+					metabasetest.DefaultRawSegment(objA, metabase.SegmentPosition{}),
+					{
+						StreamID:  objB.StreamID,
+						CreatedAt: now,
+
+						EncryptedKey:      []byte{3},
+						EncryptedKeyNonce: []byte{4},
+
+						EncryptedSize: int32(len(inlineData)),
+						PlainSize:     512,
+						InlineData:    inlineData,
+					},
+				},
+			}.Check(ctx, t, db)
+		})
+	})
+}
+
+func TestCommitInlineObjects(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		inlineObject := func(obj metabase.ObjectStream) metabase.CommitSingleObject {
+			return metabase.CommitSingleObject{
+				ObjectStream:      obj,
+				Encryption:        metabasetest.DefaultEncryption,
+				EncryptedKey:      testrand.Bytes(32),
+				EncryptedKeyNonce: testrand.Bytes(32),
+				PlainSize:         512,
+				InlineData:        testrand.Bytes(100),
+			}
+		}
+
+		inlineSegment := func(opts metabase.CommitSingleObject, now time.Time) metabase.RawSegment {
+			return metabase.RawSegment{
+				StreamID:  opts.StreamID,
+				CreatedAt: now,
+
+				EncryptedKey:      opts.EncryptedKey,
+				EncryptedKeyNonce: opts.EncryptedKeyNonce,
+
+				EncryptedSize: int32(len(opts.InlineData)),
+				PlainSize:     opts.PlainSize,
+				InlineData:    opts.InlineData,
+			}
+		}
+
+		t.Run("invalid request", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			otherProject := metabasetest.RandObjectStream()
+			sameLocation := obj
+			sameLocation.StreamID = testrand.UUID()
+
+			remoteObj := obj
+			remoteObj.ObjectKey = metabasetest.RandObjectKey()
+			segment := metabasetest.DefaultRawSegment(remoteObj, metabase.SegmentPosition{})
+			remote := inlineObject(remoteObj)
+			remote.InlineData = nil
+			remote.EncryptedSize = segment.EncryptedSize
+			remote.RootPieceID = segment.RootPieceID
+			remote.Redundancy = segment.Redundancy
+			remote.Pieces = segment.Pieces
+
+			for _, test := range []struct {
+				objects []metabase.CommitSingleObject
+				errText string
+			}{
+				{
+					objects: nil,
+					errText: "Objects missing",
+				},
+				{
+					objects: []metabase.CommitSingleObject{inlineObject(obj), {ObjectStream: otherProject}},
+					errText: "EncryptedKey missing",
+				},
+				{
+					objects: []metabase.CommitSingleObject{inlineObject(obj), remote},
+					errText: "object 1 has a remote segment",
+				},
+				{
+					objects: []metabase.CommitSingleObject{inlineObject(obj), inlineObject(otherProject)},
+					errText: "all objects must belong to the same project",
+				},
+				{
+					objects: []metabase.CommitSingleObject{inlineObject(obj), inlineObject(sameLocation)},
+					errText: "object 1 has a duplicated location",
+				},
+			} {
+				metabasetest.CommitInlineObjects{
+					Opts:     metabase.CommitInlineObjects{Objects: test.objects},
+					ErrClass: &metabase.ErrInvalidRequest,
+					ErrText:  test.errText,
+				}.Check(ctx, t, db)
+			}
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("commit multiple objects", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			// overwritten by the batch
+			objA := metabasetest.RandObjectStream()
+			objA.Version = 7
+			metabasetest.CreateObject(ctx, t, db, objA, 2)
+
+			now := time.Now()
+
+			var opts []metabase.CommitSingleObject
+			var versions []metabase.Version
+			for i := 0; i < 5; i++ {
+				obj := metabasetest.RandObjectStream()
+				obj.ProjectID = objA.ProjectID
+				obj.BucketName = objA.BucketName
+				obj.Version = 1
+				if i == 0 {
+					obj.ObjectKey = objA.ObjectKey
+					obj.Version = objA.Version + 1
+				}
+				opts = append(opts, inlineObject(obj))
+				versions = append(versions, obj.Version)
+			}
+
+			objects := metabasetest.CommitInlineObjects{
+				Opts:           metabase.CommitInlineObjects{Objects: opts},
+				ExpectVersions: versions,
+			}.Check(ctx, t, db)
+
+			var rawObjects []metabase.RawObject
+			var rawSegments []metabase.RawSegment
+			for i, object := range objects {
+				rawObjects = append(rawObjects, metabase.RawObject(object))
+				rawSegments = append(rawSegments, inlineSegment(opts[i], now))
+			}
+
+			metabasetest.Verify{
+				Objects:  rawObjects,
+				Segments: rawSegments,
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("all or nothing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			locked := metabasetest.RandObjectStream()
+			lockedObject := metabasetest.CreateObject(ctx, t, db, locked, 1)
+
+			obj := metabasetest.RandObjectStream()
+			obj.ProjectID = locked.ProjectID
+			obj.BucketName = locked.BucketName
+
+			overwrite := locked
+			overwrite.StreamID = testrand.UUID()
+
+			overwriteOpts := inlineObject(overwrite)
+			overwriteOpts.DisallowDelete = true
+
+			metabasetest.CommitInlineObjects{
+				Opts: metabase.CommitInlineObjects{
+					Objects: []metabase.CommitSingleObject{inlineObject(obj), overwriteOpts},
+				},
+				ErrClass: &metabase.ErrPermissionDenied,
+				ErrText:  "no permissions to delete existing object",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(lockedObject),
+				},
+				Segments: []metabase.RawSegment{
+					metabasetest.DefaultRawSegment(locked, metabase.SegmentPosition{}),
+				},
+			}.Check(ctx, t, db)
+		})
+	})
+}
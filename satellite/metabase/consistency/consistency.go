@@ -0,0 +1,399 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consistency
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/rangedloop"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the error class for this package.
+	Error = errs.Class("metabase consistency")
+)
+
+// Config contains the parameters of the check.
+type Config struct {
+	// Ranges is the number of stream ID ranges, which are checked one after
+	// another. Only the segments of a single range are kept in memory, but the
+	// objects are iterated once per range.
+	Ranges    int
+	BatchSize int
+
+	AsOfSystemInterval time.Duration
+
+	// Repair enables fixing the object sizes and deleting the orphaned segments.
+	Repair bool
+}
+
+// Verify verifies the config fields.
+func (config *Config) Verify() error {
+	switch {
+	case config.Ranges <= 0:
+		return Error.New("Ranges should be positive")
+	case config.BatchSize <= 0:
+		return Error.New("BatchSize should be positive")
+	}
+	return nil
+}
+
+// ProblemType is the type of an inconsistency.
+type ProblemType string
+
+const (
+	// SegmentCountMismatch is reported when the segment count of an object doesn't match
+	// the number of its segments.
+	SegmentCountMismatch = ProblemType("segment_count_mismatch")
+	// TotalSizeMismatch is reported when the total encrypted size of an object doesn't
+	// match the sum of the sizes of its segments.
+	TotalSizeMismatch = ProblemType("total_size_mismatch")
+	// OrphanedSegments is reported for a stream which has segments, but no object.
+	OrphanedSegments = ProblemType("orphaned_segments")
+	// StreamIDReuse is reported for a stream which has segments and is referenced by
+	// more than one object.
+	StreamIDReuse = ProblemType("stream_id_reuse")
+)
+
+// Problem is a single inconsistency found by the check.
+type Problem struct {
+	Type     ProblemType `json:"type"`
+	StreamID uuid.UUID   `json:"streamId"`
+
+	// The object fields are empty for orphaned segments and reused streams.
+	ProjectID  uuid.UUID           `json:"projectId"`
+	BucketName metabase.BucketName `json:"bucketName,omitempty"`
+	ObjectKey  []byte              `json:"objectKey,omitempty"`
+	Version    metabase.Version    `json:"version,omitempty"`
+
+	ObjectSegmentCount       int32 `json:"objectSegmentCount"`
+	ObjectTotalEncryptedSize int64 `json:"objectTotalEncryptedSize"`
+	SegmentCount             int32 `json:"segmentCount"`
+	SegmentsEncryptedSize    int64 `json:"segmentsEncryptedSize"`
+
+	// Objects are the objects referencing a reused stream.
+	Objects []metabase.ObjectStream `json:"objects,omitempty"`
+
+	Repaired bool `json:"repaired"`
+	// RepairError is the reason the repair failed.
+	RepairError string `json:"repairError,omitempty"`
+}
+
+// Stats contains the summary of the check.
+type Stats struct {
+	Objects  int64 `json:"objects"`
+	Segments int64 `json:"segments"`
+
+	SegmentCountMismatches int64 `json:"segmentCountMismatches"`
+	TotalSizeMismatches    int64 `json:"totalSizeMismatches"`
+	OrphanedStreams        int64 `json:"orphanedStreams"`
+	OrphanedSegments       int64 `json:"orphanedSegments"`
+	StreamIDReuses         int64 `json:"streamIdReuses"`
+
+	Repaired int64 `json:"repaired"`
+}
+
+// streamStats is the aggregate of the segments of a stream.
+type streamStats struct {
+	segmentCount  int32
+	encryptedSize int64
+	// owners is the number of objects referencing the stream.
+	owners int32
+	// sizeMismatch is the total size mismatch of the first object referencing
+	// the stream. Its repair is postponed until it's known whether the stream
+	// is reused.
+	sizeMismatch *Problem
+}
+
+// Check cross-checks the objects and segments of the metabase and calls report for every problem.
+//
+// The stream ID ranges are checked one after another: the segments of a range
+// are aggregated in memory, and the objects referencing them are found by
+// iterating all objects. More ranges need less memory, but iterate the objects
+// more often. The problems are reported at the end of every range.
+func Check(ctx context.Context, log *zap.Logger, db *metabase.DB, config Config, report func(Problem) error) (stats Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := config.Verify(); err != nil {
+		return stats, err
+	}
+
+	started := time.Now()
+
+	splitter := rangedloop.NewMetabaseRangeSplitter(db, config.AsOfSystemInterval, config.BatchSize)
+	ranges, err := splitter.CreateRanges(config.Ranges, config.BatchSize)
+	if err != nil {
+		return stats, Error.Wrap(err)
+	}
+
+	for i, segmentRange := range ranges {
+		if err := checkRange(ctx, db, config, started, segmentRange, &stats, report); err != nil {
+			return stats, err
+		}
+		log.Info("Range checked.",
+			zap.Int("range", i+1),
+			zap.Int("ranges", len(ranges)),
+			zap.Int64("objects", stats.Objects),
+			zap.Int64("segments", stats.Segments))
+	}
+
+	return stats, nil
+}
+
+// checkRange checks the objects and segments whose stream ID is in the range.
+func checkRange(ctx context.Context, db *metabase.DB, config Config, started time.Time, segmentRange rangedloop.SegmentProvider, stats *Stats, report func(Problem) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	streams, err := collectStreams(ctx, segmentRange)
+	if err != nil {
+		return err
+	}
+	for _, stream := range streams {
+		stats.Segments += int64(stream.segmentCount)
+	}
+
+	uuidRange := segmentRange.Range()
+	var reused []uuid.UUID
+
+	err = db.IterateLoopObjects(ctx, metabase.IterateLoopObjects{
+		BatchSize:          config.BatchSize,
+		AsOfSystemTime:     started,
+		AsOfSystemInterval: config.AsOfSystemInterval,
+	}, func(ctx context.Context, it metabase.LoopObjectsIterator) error {
+		var entry metabase.LoopObjectEntry
+		for it.Next(ctx, &entry) {
+			if !inRange(uuidRange, entry.StreamID) {
+				continue
+			}
+			stats.Objects++
+
+			stream, ok := streams[entry.StreamID]
+			if ok {
+				stream.owners++
+				streams[entry.StreamID] = stream
+
+				if stream.owners > 1 {
+					// the stream is checked with the first object referencing it.
+					if stream.owners == 2 {
+						reused = append(reused, entry.StreamID)
+					}
+					continue
+				}
+			}
+
+			// pending objects get their segment count and sizes on commit, and
+			// the segments of objects created during the check may be missed.
+			if entry.Status == metabase.Pending || !entry.CreatedAt.Before(started) {
+				continue
+			}
+
+			problem := Problem{
+				StreamID:   entry.StreamID,
+				ProjectID:  entry.ProjectID,
+				BucketName: entry.BucketName,
+				ObjectKey:  []byte(entry.ObjectKey),
+				Version:    entry.Version,
+
+				ObjectSegmentCount:       entry.SegmentCount,
+				ObjectTotalEncryptedSize: entry.TotalEncryptedSize,
+				SegmentCount:             stream.segmentCount,
+				SegmentsEncryptedSize:    stream.encryptedSize,
+			}
+
+			switch {
+			case entry.SegmentCount != stream.segmentCount:
+				problem.Type = SegmentCountMismatch
+				stats.SegmentCountMismatches++
+			case entry.TotalEncryptedSize != stream.encryptedSize:
+				problem.Type = TotalSizeMismatch
+				stats.TotalSizeMismatches++
+				if ok {
+					stream.sizeMismatch = &problem
+					streams[entry.StreamID] = stream
+					continue
+				}
+				// a stream without segments can't be reused.
+				repairTotalSize(ctx, db, config, &problem, stats)
+			default:
+				continue
+			}
+
+			if err := report(problem); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	// the reused streams are quarantined: all their objects are reported and
+	// none of them is repaired, as it's not known which object the segments
+	// belong to.
+	if len(reused) > 0 {
+		sort.Slice(reused, func(i, k int) bool {
+			return reused[i].Less(reused[k])
+		})
+
+		violations, err := db.VerifyStreamOwners(ctx, metabase.VerifyStreamOwners{
+			StreamIDs:          reused,
+			BatchSize:          config.BatchSize,
+			AsOfSystemInterval: config.AsOfSystemInterval,
+		})
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		for _, violation := range violations {
+			stream := streams[violation.StreamID]
+			if problem := stream.sizeMismatch; problem != nil {
+				// the size mismatch is reported without a repair.
+				stream.sizeMismatch = nil
+				streams[violation.StreamID] = stream
+				if err := report(*problem); err != nil {
+					return Error.Wrap(err)
+				}
+			}
+
+			// the objects may have been deleted since the iteration.
+			if len(violation.Objects) < 2 {
+				continue
+			}
+			stats.StreamIDReuses++
+
+			problem := Problem{
+				Type:                  StreamIDReuse,
+				StreamID:              violation.StreamID,
+				SegmentCount:          stream.segmentCount,
+				SegmentsEncryptedSize: stream.encryptedSize,
+				Objects:               violation.Objects,
+			}
+			if err := report(problem); err != nil {
+				return Error.Wrap(err)
+			}
+		}
+	}
+
+	streamIDs := make([]uuid.UUID, 0, len(streams))
+	for streamID := range streams {
+		streamIDs = append(streamIDs, streamID)
+	}
+	sort.Slice(streamIDs, func(i, k int) bool {
+		return streamIDs[i].Less(streamIDs[k])
+	})
+
+	for _, streamID := range streamIDs {
+		stream := streams[streamID]
+
+		if problem := stream.sizeMismatch; problem != nil {
+			repairTotalSize(ctx, db, config, problem, stats)
+			if err := report(*problem); err != nil {
+				return Error.Wrap(err)
+			}
+		}
+
+		// the remaining streams aren't referenced by any object.
+		if stream.owners > 0 {
+			continue
+		}
+		stats.OrphanedStreams++
+		stats.OrphanedSegments += int64(stream.segmentCount)
+
+		problem := Problem{
+			Type:                  OrphanedSegments,
+			StreamID:              streamID,
+			SegmentCount:          stream.segmentCount,
+			SegmentsEncryptedSize: stream.encryptedSize,
+		}
+		if config.Repair {
+			// segments uploaded after the check started are protected.
+			_, repairErr := db.DeleteOrphanedSegments(ctx, metabase.DeleteOrphanedSegments{
+				StreamIDs:     []uuid.UUID{streamID},
+				CreatedBefore: started,
+			})
+			problem.setRepaired(repairErr)
+			if problem.Repaired {
+				stats.Repaired++
+			}
+		}
+		if err := report(problem); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// repairTotalSize recalculates the total size of the object of a size
+// mismatch, when repairs are enabled.
+func repairTotalSize(ctx context.Context, db *metabase.DB, config Config, problem *Problem, stats *Stats) {
+	if !config.Repair {
+		return
+	}
+
+	repairErr := db.RecalculateObjectTotalSizes(ctx, metabase.RecalculateObjectTotalSizes{
+		ObjectStream: metabase.ObjectStream{
+			ProjectID:  problem.ProjectID,
+			BucketName: problem.BucketName,
+			ObjectKey:  metabase.ObjectKey(problem.ObjectKey),
+			Version:    problem.Version,
+			StreamID:   problem.StreamID,
+		},
+	})
+	problem.setRepaired(repairErr)
+	if problem.Repaired {
+		stats.Repaired++
+	}
+}
+
+// inRange returns whether the stream ID is in the range iterated by the segment provider.
+func inRange(uuidRange rangedloop.UUIDRange, streamID uuid.UUID) bool {
+	if uuidRange.Start != nil && !uuidRange.Start.Less(streamID) {
+		return false
+	}
+	if uuidRange.End != nil && uuidRange.End.Less(streamID) {
+		return false
+	}
+	return true
+}
+
+// setRepaired records the result of the repair.
+func (problem *Problem) setRepaired(err error) {
+	if err != nil {
+		problem.RepairError = err.Error()
+		return
+	}
+	problem.Repaired = true
+}
+
+// collectStreams aggregates the segments of the range per stream.
+func collectStreams(ctx context.Context, segmentRange rangedloop.SegmentProvider) (_ map[uuid.UUID]streamStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	streams := map[uuid.UUID]streamStats{}
+	err = segmentRange.Iterate(ctx, func(segments []rangedloop.Segment) error {
+		for _, segment := range segments {
+			stream := streams[segment.StreamID]
+			stream.segmentCount++
+			stream.encryptedSize += int64(segment.EncryptedSize)
+			streams[segment.StreamID] = stream
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return streams, nil
+}
@@ -0,0 +1,163 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consistency_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/consistency"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestCheck(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		createdAt := time.Now().Add(-time.Hour)
+
+		var objects []metabase.RawObject
+		var segments []metabase.RawSegment
+
+		addSegments := func(obj metabase.ObjectStream, count int) {
+			for i := 0; i < count; i++ {
+				segment := metabasetest.DefaultRawSegment(obj, metabase.SegmentPosition{Index: uint32(i)})
+				segment.CreatedAt = createdAt
+				segments = append(segments, segment)
+			}
+		}
+		addObject := func(status metabase.ObjectStatus, segmentCount int32, totalEncryptedSize int64) metabase.ObjectStream {
+			obj := metabasetest.RandObjectStream()
+			objects = append(objects, metabase.RawObject{
+				ObjectStream:       obj,
+				CreatedAt:          createdAt,
+				Status:             status,
+				SegmentCount:       segmentCount,
+				TotalEncryptedSize: totalEncryptedSize,
+				Encryption:         metabasetest.DefaultEncryption,
+			})
+			return obj
+		}
+
+		// the default raw segment has an encrypted size of 1024.
+		valid := addObject(metabase.CommittedUnversioned, 2, 2048)
+		addSegments(valid, 2)
+
+		pending := addObject(metabase.Pending, 0, 0)
+		addSegments(pending, 1)
+
+		wrongSize := addObject(metabase.CommittedVersioned, 2, 100)
+		addSegments(wrongSize, 2)
+
+		wrongCount := addObject(metabase.CommittedUnversioned, 3, 3072)
+		addSegments(wrongCount, 2)
+
+		orphan := metabasetest.RandObjectStream()
+		addSegments(orphan, 3)
+
+		// an object reusing the stream ID of another object.
+		original := addObject(metabase.CommittedUnversioned, 1, 1024)
+		addSegments(original, 1)
+		reused := addObject(metabase.CommittedUnversioned, 1, 1024)
+		reused.StreamID = original.StreamID
+		objects[len(objects)-1].StreamID = original.StreamID
+
+		require.NoError(t, db.TestingBatchInsertObjects(ctx, objects))
+		require.NoError(t, db.TestingBatchInsertSegments(ctx, segments))
+
+		config := consistency.Config{
+			Ranges:    2,
+			BatchSize: 2,
+		}
+
+		check := func(config consistency.Config) (consistency.Stats, map[consistency.ProblemType]consistency.Problem) {
+			problems := map[consistency.ProblemType]consistency.Problem{}
+			stats, err := consistency.Check(ctx, zaptest.NewLogger(t), db, config, func(problem consistency.Problem) error {
+				require.NotContains(t, problems, problem.Type)
+				problems[problem.Type] = problem
+				return nil
+			})
+			require.NoError(t, err)
+			return stats, problems
+		}
+
+		t.Run("invalid config", func(t *testing.T) {
+			_, err := consistency.Check(ctx, zaptest.NewLogger(t), db, consistency.Config{}, nil)
+			require.Error(t, err)
+		})
+
+		t.Run("report", func(t *testing.T) {
+			stats, problems := check(config)
+			require.Equal(t, consistency.Stats{
+				Objects:                6,
+				Segments:               11,
+				SegmentCountMismatches: 1,
+				TotalSizeMismatches:    1,
+				OrphanedStreams:        1,
+				OrphanedSegments:       3,
+				StreamIDReuses:         1,
+			}, stats)
+			require.Len(t, problems, 4)
+
+			sizeMismatch := problems[consistency.TotalSizeMismatch]
+			require.Equal(t, wrongSize.StreamID, sizeMismatch.StreamID)
+			require.Equal(t, wrongSize.ProjectID, sizeMismatch.ProjectID)
+			require.Equal(t, []byte(wrongSize.ObjectKey), sizeMismatch.ObjectKey)
+			require.EqualValues(t, 100, sizeMismatch.ObjectTotalEncryptedSize)
+			require.EqualValues(t, 2048, sizeMismatch.SegmentsEncryptedSize)
+			require.False(t, sizeMismatch.Repaired)
+
+			countMismatch := problems[consistency.SegmentCountMismatch]
+			require.Equal(t, wrongCount.StreamID, countMismatch.StreamID)
+			require.EqualValues(t, 3, countMismatch.ObjectSegmentCount)
+			require.EqualValues(t, 2, countMismatch.SegmentCount)
+
+			orphaned := problems[consistency.OrphanedSegments]
+			require.Equal(t, orphan.StreamID, orphaned.StreamID)
+			require.EqualValues(t, 3, orphaned.SegmentCount)
+
+			reuse := problems[consistency.StreamIDReuse]
+			require.Equal(t, original.StreamID, reuse.StreamID)
+			require.EqualValues(t, 1, reuse.SegmentCount)
+			require.ElementsMatch(t, []metabase.ObjectStream{original, reused}, reuse.Objects)
+		})
+
+		t.Run("repair", func(t *testing.T) {
+			repair := config
+			repair.Repair = true
+
+			stats, problems := check(repair)
+			require.EqualValues(t, 2, stats.Repaired)
+			require.Len(t, problems, 4)
+			require.True(t, problems[consistency.TotalSizeMismatch].Repaired)
+			require.True(t, problems[consistency.OrphanedSegments].Repaired)
+			require.False(t, problems[consistency.SegmentCountMismatch].Repaired)
+
+			require.False(t, problems[consistency.StreamIDReuse].Repaired)
+
+			// the segment count mismatch and the reused stream can't be repaired.
+			stats, problems = check(config)
+			require.Equal(t, consistency.Stats{
+				Objects:                6,
+				Segments:               8,
+				SegmentCountMismatches: 1,
+				StreamIDReuses:         1,
+			}, stats)
+			require.Len(t, problems, 2)
+			require.Contains(t, problems, consistency.SegmentCountMismatch)
+			require.Contains(t, problems, consistency.StreamIDReuse)
+
+			object, err := db.GetObjectExactVersion(ctx, metabase.GetObjectExactVersion{
+				ObjectLocation: wrongSize.Location(),
+				Version:        wrongSize.Version,
+			})
+			require.NoError(t, err)
+			require.EqualValues(t, 2048, object.TotalEncryptedSize)
+			require.EqualValues(t, 1024, object.TotalPlainSize)
+		})
+	})
+}
@@ -0,0 +1,31 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+/*
+Package consistency cross-checks the objects and segments of the metabase.
+
+The stream IDs are split into ranges, which are checked one after another.
+The segments of a range are aggregated per stream, the same way the ranged
+loop does, and afterwards the objects are iterated and every object whose
+stream is in the range is compared with the aggregate of its stream. Only
+the streams of a single range are kept in memory, and the problems are
+reported at the end of every range. The following problems are reported:
+
+  - the segment count of an object doesn't match the number of its segments,
+  - the total encrypted size of an object doesn't match the sum of the sizes
+    of its segments,
+  - segments whose stream isn't referenced by any object,
+  - segments whose stream is referenced by more than one object, i.e. an
+    object reused the stream ID of another object.
+
+The size of objects and orphaned segments can be repaired, a mismatching
+segment count means a segment is missing or superfluous, which needs a
+closer look. Reused streams are quarantined: all objects referencing them
+are reported and none of them is repaired, as deleting either of the
+objects deletes the segments of the other.
+
+Objects and segments which are modified while the check is running may be
+reported, the repairs verify the state of the database again before
+changing anything.
+*/
+package consistency
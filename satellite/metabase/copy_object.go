@@ -20,7 +20,7 @@ import (
 
 type copyObjectTransactionAdapter interface {
 	getSegmentsForCopy(ctx context.Context, object Object) (segments transposedSegmentList, err error)
-	finalizeObjectCopy(ctx context.Context, opts FinishCopyObject, nextVersion Version, newStatus ObjectStatus, sourceObject Object, copyMetadata []byte, newSegments transposedSegmentList) (newObject Object, err error)
+	finalizeObjectCopy(ctx context.Context, opts FinishCopyObject, nextVersion Version, newStatus ObjectStatus, sourceObject Object, copyMetadata []byte, copyMetadataKeyID uint16, newSegments transposedSegmentList) (newObject Object, err error)
 	getObjectNonPendingExactVersion(ctx context.Context, opts FinishCopyObject) (_ Object, err error)
 }
 
@@ -50,6 +50,9 @@ func (db *DB) BeginCopyObject(ctx context.Context, opts BeginCopyObject) (_ Begi
 // FinishCopyObject holds all data needed to finish object copy.
 type FinishCopyObject struct {
 	ObjectStream
+	// NewProjectID is the project of the copy. The copy is created in the
+	// project of the source object, when it's not set.
+	NewProjectID          uuid.UUID
 	NewBucket             BucketName
 	NewEncryptedObjectKey ObjectKey
 	NewStreamID           uuid.UUID
@@ -78,8 +81,12 @@ type FinishCopyObject struct {
 
 // NewLocation returns the new object location.
 func (finishCopy FinishCopyObject) NewLocation() ObjectLocation {
+	projectID := finishCopy.ProjectID
+	if !finishCopy.NewProjectID.IsZero() {
+		projectID = finishCopy.NewProjectID
+	}
 	return ObjectLocation{
-		ProjectID:  finishCopy.ProjectID,
+		ProjectID:  projectID,
 		BucketName: finishCopy.NewBucket,
 		ObjectKey:  finishCopy.NewEncryptedObjectKey,
 	}
@@ -148,6 +155,9 @@ type transposedSegmentList struct {
 	PiecesLists [][]byte
 
 	Placements []storj.PlacementConstraint
+
+	// Checksums holds the encoded checksums, nil when the segment doesn't have one.
+	Checksums [][]byte
 }
 
 // FinishCopyObject accepts new encryption keys for copied object and insert the corresponding new object ObjectKey and segments EncryptedKey.
@@ -162,8 +172,19 @@ func (db *DB) FinishCopyObject(ctx context.Context, opts FinishCopyObject) (obje
 	newObject := Object{}
 	var copyMetadata []byte
 
+	newEncryptedMetadataKey := opts.NewEncryptedMetadataKey
+	opts.NewEncryptedMetadataKey, err = db.metadataEncryption.encrypt(opts.NewLocation().ProjectID, opts.NewEncryptedMetadataKey)
+	if err != nil {
+		return Object{}, err
+	}
+
+	sourceAdapter := db.ChooseAdapter(opts.ProjectID)
+	if db.ChooseAdapter(opts.NewLocation().ProjectID) != sourceAdapter {
+		return Object{}, ErrMethodNotAllowed.New("copying objects between projects in different databases is not supported")
+	}
+
 	var precommit PrecommitConstraintResult
-	err = db.ChooseAdapter(opts.ProjectID).WithTx(ctx, func(ctx context.Context, adapter TransactionAdapter) error {
+	err = sourceAdapter.WithTx(ctx, func(ctx context.Context, adapter TransactionAdapter) error {
 		sourceObject, err := adapter.getObjectNonPendingExactVersion(ctx, opts)
 		if err != nil {
 			if ErrObjectNotFound.Has(err) {
@@ -211,7 +232,17 @@ func (db *DB) FinishCopyObject(ctx context.Context, opts FinishCopyObject) (obje
 		if opts.OverrideMetadata {
 			copyMetadata = opts.NewEncryptedMetadata
 		} else {
-			copyMetadata = sourceObject.EncryptedMetadata
+			// the copy may be in another project, hence the metadata is
+			// encrypted again with the key of the destination project.
+			copyMetadata, err = db.metadataEncryption.decrypt(sourceObject.ProjectID, sourceObject.EncryptedMetadata)
+			if err != nil {
+				return err
+			}
+		}
+
+		storedMetadata, err := db.metadataEncryption.encrypt(opts.NewLocation().ProjectID, copyMetadata)
+		if err != nil {
+			return err
 		}
 
 		precommit, err = db.PrecommitConstraint(ctx, PrecommitConstraint{
@@ -225,7 +256,8 @@ func (db *DB) FinishCopyObject(ctx context.Context, opts FinishCopyObject) (obje
 
 		newStatus := committedWhereVersioned(opts.NewVersioned)
 
-		newObject, err = adapter.finalizeObjectCopy(ctx, opts, precommit.HighestVersion+1, newStatus, sourceObject, copyMetadata, newSegments)
+		storedMetadataKeyID := db.metadataEncryption.metadataKeyID(storedMetadata)
+		newObject, err = adapter.finalizeObjectCopy(ctx, opts, precommit.HighestVersion+1, newStatus, sourceObject, storedMetadata, storedMetadataKeyID, newSegments)
 		return err
 	})
 
@@ -234,10 +266,11 @@ func (db *DB) FinishCopyObject(ctx context.Context, opts FinishCopyObject) (obje
 	}
 
 	newObject.StreamID = opts.NewStreamID
+	newObject.ProjectID = opts.NewLocation().ProjectID
 	newObject.BucketName = opts.NewBucket
 	newObject.ObjectKey = opts.NewEncryptedObjectKey
 	newObject.EncryptedMetadata = copyMetadata
-	newObject.EncryptedMetadataEncryptedKey = opts.NewEncryptedMetadataKey
+	newObject.EncryptedMetadataEncryptedKey = newEncryptedMetadataKey
 	if !opts.NewEncryptedMetadataKeyNonce.IsZero() {
 		newObject.EncryptedMetadataNonce = opts.NewEncryptedMetadataKeyNonce[:]
 	}
@@ -263,6 +296,7 @@ func (ptx *postgresTransactionAdapter) getSegmentsForCopy(ctx context.Context, s
 	segments.PiecesLists = make([][]byte, sourceObject.SegmentCount)
 
 	segments.RedundancySchemes = make([]int64, sourceObject.SegmentCount)
+	segments.Checksums = make([][]byte, sourceObject.SegmentCount)
 
 	err = withRows(ptx.tx.QueryContext(ctx, `
 				SELECT
@@ -273,7 +307,8 @@ func (ptx *postgresTransactionAdapter) getSegmentsForCopy(ctx context.Context, s
 					redundancy,
 					remote_alias_pieces,
 					placement,
-					inline_data
+					inline_data,
+					checksum
 				FROM segments
 				WHERE stream_id = $1
 				ORDER BY position ASC
@@ -290,6 +325,7 @@ func (ptx *postgresTransactionAdapter) getSegmentsForCopy(ctx context.Context, s
 				&segments.PiecesLists[index],
 				&segments.Placements[index],
 				&segments.InlineDatas[index],
+				&segments.Checksums[index],
 			)
 			if err != nil {
 				return err
@@ -324,6 +360,7 @@ func (stx *spannerTransactionAdapter) getSegmentsForCopy(ctx context.Context, so
 	segments.PiecesLists = make([][]byte, sourceObject.SegmentCount)
 
 	segments.RedundancySchemes = make([]int64, sourceObject.SegmentCount)
+	segments.Checksums = make([][]byte, sourceObject.SegmentCount)
 
 	index := 0
 	err = stx.tx.Query(ctx, spanner.Statement{
@@ -336,7 +373,8 @@ func (stx *spannerTransactionAdapter) getSegmentsForCopy(ctx context.Context, so
 				redundancy,
 				remote_alias_pieces,
 				placement,
-				COALESCE(inline_data, B'') AS inline_data
+				COALESCE(inline_data, B'') AS inline_data,
+				checksum
 			FROM segments
 			WHERE stream_id = @stream_id
 			ORDER BY position ASC
@@ -356,6 +394,7 @@ func (stx *spannerTransactionAdapter) getSegmentsForCopy(ctx context.Context, so
 			&segments.PiecesLists[index],
 			&segments.Placements[index],
 			&segments.InlineDatas[index],
+			&segments.Checksums[index],
 		)
 		if err != nil {
 			return Error.New("could not read segments for copy: %w", err)
@@ -375,7 +414,7 @@ func (stx *spannerTransactionAdapter) getSegmentsForCopy(ctx context.Context, so
 	return segments, err
 }
 
-func (ptx *postgresTransactionAdapter) finalizeObjectCopy(ctx context.Context, opts FinishCopyObject, nextVersion Version, newStatus ObjectStatus, sourceObject Object, copyMetadata []byte, newSegments transposedSegmentList) (newObject Object, err error) {
+func (ptx *postgresTransactionAdapter) finalizeObjectCopy(ctx context.Context, opts FinishCopyObject, nextVersion Version, newStatus ObjectStatus, sourceObject Object, copyMetadata []byte, copyMetadataKeyID uint16, newSegments transposedSegmentList) (newObject Object, err error) {
 	// TODO we need to handle metadata correctly (copy from original object or replace)
 	row := ptx.tx.QueryRowContext(ctx, `
 			INSERT INTO objects (
@@ -383,26 +422,32 @@ func (ptx *postgresTransactionAdapter) finalizeObjectCopy(ctx context.Context, o
 				status, expires_at, segment_count,
 				encryption,
 				encrypted_metadata, encrypted_metadata_nonce, encrypted_metadata_encrypted_key,
+				encrypted_metadata_key_id,
 				total_plain_size, total_encrypted_size, fixed_segment_size,
 				zombie_deletion_deadline,
-				retention_mode, retain_until
+				retention_mode, retain_until,
+				checksum
 			) VALUES (
 				$1, $2, $3, $4, $5,
 				$6, $7, $8,
 				$9,
 				$10, $11, $12,
+				$19,
 				$13, $14, $15,
 				null,
-				$16, $17
+				$16, $17,
+				$18
 			)
 			RETURNING
 				created_at`,
-		opts.ProjectID, opts.NewBucket, opts.NewEncryptedObjectKey, nextVersion, opts.NewStreamID,
+		opts.NewLocation().ProjectID, opts.NewBucket, opts.NewEncryptedObjectKey, nextVersion, opts.NewStreamID,
 		newStatus, sourceObject.ExpiresAt, sourceObject.SegmentCount,
 		encryptionParameters{&sourceObject.Encryption},
 		copyMetadata, opts.NewEncryptedMetadataKeyNonce, opts.NewEncryptedMetadataKey,
 		sourceObject.TotalPlainSize, sourceObject.TotalEncryptedSize, sourceObject.FixedSegmentSize,
 		retentionModeWrapper{&opts.Retention.Mode}, timeWrapper{&opts.Retention.RetainUntil},
+		sourceObject.Checksum,
+		copyMetadataKeyID,
 	)
 
 	newObject = sourceObject
@@ -422,7 +467,8 @@ func (ptx *postgresTransactionAdapter) finalizeObjectCopy(ctx context.Context, o
 				redundancy,
 				encrypted_size, plain_offset, plain_size,
 				remote_alias_pieces, placement,
-				inline_data
+				inline_data,
+				checksum
 			) SELECT
 				$1, UNNEST($2::INT8[]), UNNEST($3::timestamptz[]),
 				UNNEST($4::BYTEA[]), UNNEST($5::BYTEA[]),
@@ -430,7 +476,8 @@ func (ptx *postgresTransactionAdapter) finalizeObjectCopy(ctx context.Context, o
 				UNNEST($7::INT8[]),
 				UNNEST($8::INT4[]), UNNEST($9::INT8[]),	UNNEST($10::INT4[]),
 				UNNEST($11::BYTEA[]), UNNEST($12::INT2[]),
-				UNNEST($13::BYTEA[])
+				UNNEST($13::BYTEA[]),
+				UNNEST($14::BYTEA[])
 		`, opts.NewStreamID, pgutil.Int8Array(newSegments.Positions), pgutil.NullTimestampTZArray(newSegments.ExpiresAts),
 		pgutil.ByteaArray(newSegments.EncryptedKeyNonces), pgutil.ByteaArray(newSegments.EncryptedKeys),
 		pgutil.ByteaArray(newSegments.RootPieceIDs),
@@ -438,6 +485,7 @@ func (ptx *postgresTransactionAdapter) finalizeObjectCopy(ctx context.Context, o
 		pgutil.Int4Array(newSegments.EncryptedSizes), pgutil.Int8Array(newSegments.PlainOffsets), pgutil.Int4Array(newSegments.PlainSizes),
 		pgutil.ByteaArray(newSegments.PiecesLists), pgutil.PlacementConstraintArray(newSegments.Placements),
 		pgutil.ByteaArray(newSegments.InlineDatas),
+		pgutil.NullByteaArray(newSegments.Checksums),
 	)
 	if err != nil {
 		return Object{}, Error.New("unable to copy segments: %w", err)
@@ -446,7 +494,7 @@ func (ptx *postgresTransactionAdapter) finalizeObjectCopy(ctx context.Context, o
 	return newObject, nil
 }
 
-func (stx *spannerTransactionAdapter) finalizeObjectCopy(ctx context.Context, opts FinishCopyObject, nextVersion Version, newStatus ObjectStatus, sourceObject Object, copyMetadata []byte, newSegments transposedSegmentList) (newObject Object, err error) {
+func (stx *spannerTransactionAdapter) finalizeObjectCopy(ctx context.Context, opts FinishCopyObject, nextVersion Version, newStatus ObjectStatus, sourceObject Object, copyMetadata []byte, copyMetadataKeyID uint16, newSegments transposedSegmentList) (newObject Object, err error) {
 	// TODO we need to handle metadata correctly (copy from original object or replace)
 
 	newObject = sourceObject
@@ -458,23 +506,27 @@ func (stx *spannerTransactionAdapter) finalizeObjectCopy(ctx context.Context, op
 				status, expires_at, segment_count,
 				encryption,
 				encrypted_metadata, encrypted_metadata_nonce, encrypted_metadata_encrypted_key,
+				encrypted_metadata_key_id,
 				total_plain_size, total_encrypted_size, fixed_segment_size,
 				zombie_deletion_deadline,
-				retention_mode, retain_until
+				retention_mode, retain_until,
+				checksum
 			) VALUES (
 				@project_id, @bucket_name, @object_key, @version, @stream_id,
 				@status, @expires_at, @segment_count,
 				@encryption,
 				@encrypted_metadata, @encrypted_metadata_nonce, @encrypted_metadata_encrypted_key,
+				@encrypted_metadata_key_id,
 				@total_plain_size, @total_encrypted_size, @fixed_segment_size,
 				NULL,
-				@retention_mode, @retain_until
+				@retention_mode, @retain_until,
+				@checksum
 			)
 			THEN RETURN
 				created_at
 		`,
 		Params: map[string]interface{}{
-			"project_id":                       opts.ProjectID,
+			"project_id":                       opts.NewLocation().ProjectID,
 			"bucket_name":                      opts.NewBucket,
 			"object_key":                       opts.NewEncryptedObjectKey,
 			"version":                          nextVersion,
@@ -486,11 +538,13 @@ func (stx *spannerTransactionAdapter) finalizeObjectCopy(ctx context.Context, op
 			"encrypted_metadata":               copyMetadata,
 			"encrypted_metadata_nonce":         opts.NewEncryptedMetadataKeyNonce,
 			"encrypted_metadata_encrypted_key": opts.NewEncryptedMetadataKey,
+			"encrypted_metadata_key_id":        int64(copyMetadataKeyID),
 			"total_plain_size":                 sourceObject.TotalPlainSize,
 			"total_encrypted_size":             sourceObject.TotalEncryptedSize,
 			"fixed_segment_size":               int64(sourceObject.FixedSegmentSize),
 			"retention_mode":                   retentionModeWrapper{&opts.Retention.Mode},
 			"retain_until":                     timeWrapper{&opts.Retention.RetainUntil},
+			"checksum":                         sourceObject.Checksum,
 		},
 	}).Do(func(row *spanner.Row) error {
 		err := row.Columns(&newObject.CreatedAt)
@@ -520,6 +574,7 @@ func (stx *spannerTransactionAdapter) finalizeObjectCopy(ctx context.Context, op
 				"encrypted_size", "plain_offset", "plain_size",
 				"remote_alias_pieces", "placement",
 				"inline_data",
+				"checksum",
 			}, []any{
 				opts.NewStreamID, newSegments.Positions[i], newSegments.ExpiresAts[i],
 				newSegments.EncryptedKeyNonces[i], newSegments.EncryptedKeys[i],
@@ -528,6 +583,7 @@ func (stx *spannerTransactionAdapter) finalizeObjectCopy(ctx context.Context, op
 				int64(newSegments.EncryptedSizes[i]), newSegments.PlainOffsets[i], int64(newSegments.PlainSizes[i]),
 				newSegments.PiecesLists[i], int64(newSegments.Placements[i]),
 				newSegments.InlineDatas[i],
+				newSegments.Checksums[i],
 			},
 		)
 	}
@@ -553,7 +609,8 @@ func (ptx *postgresTransactionAdapter) getObjectNonPendingExactVersion(ctx conte
 			segment_count,
 			encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
 			total_plain_size, total_encrypted_size, fixed_segment_size,
-			encryption
+			encryption,
+			checksum
 		FROM objects
 		WHERE
 			(project_id, bucket_name, object_key, version) = ($1, $2, $3, $4) AND
@@ -567,6 +624,7 @@ func (ptx *postgresTransactionAdapter) getObjectNonPendingExactVersion(ctx conte
 			&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
 			&object.TotalPlainSize, &object.TotalEncryptedSize, &object.FixedSegmentSize,
 			encryptionParameters{&object.Encryption},
+			&object.Checksum,
 		)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -596,7 +654,8 @@ func (stx *spannerTransactionAdapter) getObjectNonPendingExactVersion(ctx contex
 				segment_count,
 				encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
 				total_plain_size, total_encrypted_size, fixed_segment_size,
-				encryption
+				encryption,
+				checksum
 			FROM objects
 			WHERE
 				(project_id, bucket_name, object_key, version) = (@project_id, @bucket_name, @object_key, @version) AND
@@ -617,6 +676,7 @@ func (stx *spannerTransactionAdapter) getObjectNonPendingExactVersion(ctx contex
 			&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
 			&object.TotalPlainSize, &object.TotalEncryptedSize, spannerutil.Int(&object.FixedSegmentSize),
 			encryptionParameters{&object.Encryption},
+			&object.Checksum,
 		)
 		if err != nil {
 			return Error.New("unable to scan object: %w", err)
@@ -532,6 +532,66 @@ func TestFinishCopyObject(t *testing.T) {
 			}.Check(ctx, t, db)
 		})
 
+		t.Run("copy object to another project", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			objStream := metabasetest.RandObjectStream()
+			copyStream := metabasetest.RandObjectStream()
+
+			originalObj, originalSegments := metabasetest.CreateTestObject{
+				CommitObject: &metabase.CommitObject{
+					ObjectStream:                  objStream,
+					EncryptedMetadata:             testrand.Bytes(64),
+					EncryptedMetadataNonce:        testrand.Nonce().Bytes(),
+					EncryptedMetadataEncryptedKey: testrand.Bytes(265),
+				},
+			}.Run(ctx, t, db, objStream, 2)
+
+			metadataNonce := testrand.Nonce()
+			expectedCopyObject := originalObj
+			expectedCopyObject.ProjectID = copyStream.ProjectID
+			expectedCopyObject.BucketName = copyStream.BucketName
+			expectedCopyObject.ObjectKey = copyStream.ObjectKey
+			expectedCopyObject.StreamID = copyStream.StreamID
+			expectedCopyObject.Version = metabase.DefaultVersion
+			expectedCopyObject.EncryptedMetadataEncryptedKey = testrand.Bytes(32)
+			expectedCopyObject.EncryptedMetadataNonce = metadataNonce.Bytes()
+
+			newSegmentKeys := make([]metabase.EncryptedKeyAndNonce, len(originalSegments))
+			expectedCopySegments := make([]metabase.RawSegment, len(originalSegments))
+			for i, segment := range originalSegments {
+				newSegmentKeys[i] = metabasetest.RandEncryptedKeyAndNonce(i)
+
+				expectedCopySegments[i] = metabase.RawSegment(segment)
+				expectedCopySegments[i].StreamID = copyStream.StreamID
+				expectedCopySegments[i].EncryptedKey = newSegmentKeys[i].EncryptedKey
+				expectedCopySegments[i].EncryptedKeyNonce = newSegmentKeys[i].EncryptedKeyNonce
+				expectedCopySegments[i].EncryptedETag = nil
+			}
+
+			metabasetest.FinishCopyObject{
+				Opts: metabase.FinishCopyObject{
+					ObjectStream:                 objStream,
+					NewProjectID:                 copyStream.ProjectID,
+					NewBucket:                    copyStream.BucketName,
+					NewStreamID:                  copyStream.StreamID,
+					NewEncryptedObjectKey:        copyStream.ObjectKey,
+					NewSegmentKeys:               newSegmentKeys,
+					NewEncryptedMetadataKey:      expectedCopyObject.EncryptedMetadataEncryptedKey,
+					NewEncryptedMetadataKeyNonce: metadataNonce,
+				},
+				Result: expectedCopyObject,
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(originalObj),
+					metabase.RawObject(expectedCopyObject),
+				},
+				Segments: append(metabasetest.SegmentsToRaw(originalSegments), expectedCopySegments...),
+			}.Check(ctx, t, db)
+		})
+
 		t.Run("finish copy object with existing metadata", func(t *testing.T) {
 			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
 
@@ -48,6 +48,20 @@ type Config struct {
 
 	NodeAliasCacheFullRefresh bool
 
+	// DeferredSegmentDeletionThreshold is the segment count above which deleting an exact
+	// object version queues its segments for deferred deletion. 0 disables deferring.
+	DeferredSegmentDeletionThreshold int
+
+	// DeleteRateLimit limits how many objects a project can delete per second.
+	DeleteRateLimit DeleteRateLimitConfig
+
+	// LongQueryThreshold is the duration after which an in-flight operation is
+	// listed as long-running by the query registry. 0 disables the tracking.
+	LongQueryThreshold time.Duration
+
+	// MetadataEncryption configures the encryption at rest of the object metadata.
+	MetadataEncryption MetadataEncryptionConfig
+
 	TestingUniqueUnversioned   bool
 	TestingCommitSegmentMode   string
 	TestingPrecommitDeleteMode TestingPrecommitDeleteMode
@@ -69,6 +83,11 @@ type DB struct {
 
 	config Config
 
+	deleteLimiter      *deleteRateLimiter
+	queries            *QueryRegistry
+	metadataEncryption *metadataEncryption
+	deleteEvents       DeleteEventsFilter
+
 	adapters []Adapter
 }
 
@@ -95,6 +114,11 @@ func Open(ctx context.Context, log *zap.Logger, connstr string, config Config) (
 		return nil, Error.Wrap(err)
 	}
 
+	metadataEncryption, err := newMetadataEncryption(config.MetadataEncryption)
+	if err != nil {
+		return nil, err
+	}
+
 	var rawdb tagsql.DB
 	if driverName != "" {
 		rawdb, err = tagsql.Open(ctx, driverName, connstr)
@@ -112,6 +136,10 @@ func Open(ctx context.Context, log *zap.Logger, connstr string, config Config) (
 		impl:        impl,
 		testCleanup: func() error { return nil },
 		config:      config,
+
+		deleteLimiter:      newDeleteRateLimiter(config.DeleteRateLimit),
+		queries:            newQueryRegistry(config.LongQueryThreshold),
+		metadataEncryption: metadataEncryption,
 	}
 	db.aliasCache = NewNodeAliasCache(db, config.NodeAliasCacheFullRefresh)
 	switch impl {
@@ -151,6 +179,10 @@ func Open(ctx context.Context, log *zap.Logger, connstr string, config Config) (
 	return db, nil
 }
 
+// QueryRegistry returns the registry of in-flight operations. It's nil when
+// the tracking of long-running operations is disabled.
+func (db *DB) QueryRegistry() *QueryRegistry { return db.queries }
+
 // Implementation rturns the database implementation.
 func (db *DB) Implementation() dbutil.Implementation { return db.impl }
 
@@ -662,6 +694,177 @@ func (db *DB) PostgresMigration() *migrate.Migration {
 					`DROP TABLE IF EXISTS segment_copies`,
 				},
 			},
+			{
+				DB:          &db.db,
+				Description: "add healthy_pieces column to segments table",
+				Version:     21,
+				Action: migrate.SQL{
+					`ALTER TABLE segments ADD COLUMN healthy_pieces INT2`,
+					`CREATE INDEX segments_healthy_pieces_index ON segments (healthy_pieces) WHERE healthy_pieces IS NOT NULL`,
+					`
+					COMMENT ON COLUMN segments.healthy_pieces is 'healthy_pieces is the number of healthy pieces when the segment was last checked. NULL means unknown.';
+				`},
+			},
+			{
+				DB:          &db.db,
+				Description: "add deferred_segment_deletions table",
+				Version:     22,
+				Action: migrate.SQL{
+					`CREATE TABLE deferred_segment_deletions (
+						stream_id     BYTEA NOT NULL,
+						project_id    BYTEA NOT NULL,
+						segment_count INT4 NOT NULL default 0,
+						created_at    TIMESTAMPTZ NOT NULL default now(),
+						PRIMARY KEY (stream_id)
+					)`,
+					`
+					COMMENT ON TABLE  deferred_segment_deletions               is 'deferred_segment_deletions table contains the streams of deleted objects whose segments are yet to be deleted.';
+					COMMENT ON COLUMN deferred_segment_deletions.stream_id     is 'stream_id is the stream of the deleted object.';
+					COMMENT ON COLUMN deferred_segment_deletions.project_id    is 'project_id is the project the deleted object belonged to.';
+					COMMENT ON COLUMN deferred_segment_deletions.segment_count is 'segment_count is the number of segments of the deleted object.';
+					COMMENT ON COLUMN deferred_segment_deletions.created_at    is 'created_at is the time when the object was deleted.';
+				`},
+			},
+			{
+				DB:          &db.db,
+				Description: "add tags column to objects table",
+				Version:     23,
+				Action: migrate.SQL{
+					`ALTER TABLE objects ADD COLUMN tags BYTEA`,
+					`
+					COMMENT ON COLUMN objects.tags is 'tags contains the key-value tags of an object version, encoded as length-prefixed keys and values.';
+				`},
+			},
+			{
+				DB:          &db.db,
+				Description: "add legal_hold column to objects table",
+				Version:     24,
+				Action: migrate.SQL{
+					`ALTER TABLE objects ADD COLUMN legal_hold BOOLEAN NOT NULL DEFAULT false`,
+					`
+					COMMENT ON COLUMN objects.legal_hold is 'legal_hold specifies whether an object version is under an Object Lock legal hold.';
+				`},
+			},
+			{
+				DB:          &db.db,
+				Description: "add bucket_stats table",
+				Version:     25,
+				Action: migrate.SQL{
+					`CREATE TABLE bucket_stats (
+						project_id           BYTEA NOT NULL,
+						bucket_name          BYTEA NOT NULL,
+						object_count         INT8 NOT NULL default 0,
+						pending_object_count INT8 NOT NULL default 0,
+						total_segments       INT8 NOT NULL default 0,
+						total_bytes          INT8 NOT NULL default 0,
+						metadata_size        INT8 NOT NULL default 0,
+						updated_at           TIMESTAMPTZ NOT NULL default now(),
+						PRIMARY KEY (project_id, bucket_name)
+					)`,
+					`
+					COMMENT ON TABLE  bucket_stats                      is 'bucket_stats table contains the object statistics of buckets, periodically refreshed from the objects table.';
+					COMMENT ON COLUMN bucket_stats.project_id           is 'project_id is the project the bucket belongs to.';
+					COMMENT ON COLUMN bucket_stats.bucket_name          is 'bucket_name is the name of the bucket.';
+					COMMENT ON COLUMN bucket_stats.object_count         is 'object_count is the number of objects, including the pending ones.';
+					COMMENT ON COLUMN bucket_stats.pending_object_count is 'pending_object_count is the number of pending objects.';
+					COMMENT ON COLUMN bucket_stats.total_segments       is 'total_segments is the number of segments of the objects.';
+					COMMENT ON COLUMN bucket_stats.total_bytes          is 'total_bytes is the total encrypted size of the objects.';
+					COMMENT ON COLUMN bucket_stats.metadata_size        is 'metadata_size is the total size of the encrypted metadata of the objects.';
+					COMMENT ON COLUMN bucket_stats.updated_at           is 'updated_at is the time when the statistics were last refreshed.';
+				`},
+			},
+			{
+				DB:          &db.db,
+				Description: "add checksum columns to objects and segments",
+				Version:     26,
+				Action: migrate.SQL{
+					`ALTER TABLE objects ADD COLUMN checksum BYTEA default NULL`,
+					`COMMENT ON COLUMN objects.checksum is 'checksum is the client provided checksum of the plain content, prefixed with the metabase.ChecksumAlgorithm.'`,
+					`ALTER TABLE segments ADD COLUMN checksum BYTEA default NULL`,
+					`COMMENT ON COLUMN segments.checksum is 'checksum is the client provided checksum of the plain segment content, prefixed with the metabase.ChecksumAlgorithm.'`,
+				},
+			},
+			{
+				DB:          &db.db,
+				Description: "add index on objects creation time for listing object changes",
+				Version:     27,
+				Action: migrate.SQL{
+					`CREATE INDEX objects_project_id_bucket_name_created_at_index ON objects (project_id, bucket_name, created_at)`,
+				},
+			},
+			{
+				DB:          &db.db,
+				Description: "add metadata_rotation_cursors table",
+				Version:     28,
+				Action: migrate.SQL{
+					`CREATE TABLE metadata_rotation_cursors (
+						active_key_id INT4 NOT NULL,
+						project_id    BYTEA NOT NULL,
+						bucket_name   BYTEA NOT NULL,
+						object_key    BYTEA NOT NULL,
+						version       INT8 NOT NULL,
+						finished      BOOLEAN NOT NULL default false,
+						updated_at    TIMESTAMPTZ NOT NULL default now(),
+						PRIMARY KEY (active_key_id)
+					)`,
+					`
+					COMMENT ON TABLE  metadata_rotation_cursors               is 'metadata_rotation_cursors table contains the progress of re-encrypting the object metadata with the active key.';
+					COMMENT ON COLUMN metadata_rotation_cursors.active_key_id is 'active_key_id is the metadata encryption key the objects are re-encrypted with.';
+					COMMENT ON COLUMN metadata_rotation_cursors.project_id    is 'project_id is the project of the last processed object.';
+					COMMENT ON COLUMN metadata_rotation_cursors.bucket_name   is 'bucket_name is the bucket of the last processed object.';
+					COMMENT ON COLUMN metadata_rotation_cursors.object_key    is 'object_key is the key of the last processed object.';
+					COMMENT ON COLUMN metadata_rotation_cursors.version       is 'version is the version of the last processed object.';
+					COMMENT ON COLUMN metadata_rotation_cursors.finished      is 'finished specifies whether all objects were processed with the key.';
+					COMMENT ON COLUMN metadata_rotation_cursors.updated_at    is 'updated_at is the time when the progress was last saved.';
+				`},
+			},
+			{
+				DB:          &db.db,
+				Description: "add object_delete_events table",
+				Version:     29,
+				Action: migrate.SQL{
+					`CREATE TABLE object_delete_events (
+						project_id       BYTEA NOT NULL,
+						bucket_name      BYTEA NOT NULL,
+						object_key       BYTEA NOT NULL,
+						version          INT8 NOT NULL,
+						stream_id        BYTEA NOT NULL,
+						kind             INT2 NOT NULL,
+						total_plain_size INT8 NOT NULL default 0,
+						deleted_at       TIMESTAMPTZ NOT NULL default now(),
+						PRIMARY KEY (project_id, bucket_name, object_key, version, stream_id, kind)
+					)`,
+					`CREATE INDEX object_delete_events_deleted_at_index ON object_delete_events (deleted_at)`,
+					`
+					COMMENT ON TABLE  object_delete_events                  is 'object_delete_events table contains the object deletions, which are yet to be published as bucket events.';
+					COMMENT ON COLUMN object_delete_events.project_id       is 'project_id is the project of the deleted object.';
+					COMMENT ON COLUMN object_delete_events.bucket_name      is 'bucket_name is the bucket of the deleted object.';
+					COMMENT ON COLUMN object_delete_events.object_key       is 'object_key is the key of the deleted object.';
+					COMMENT ON COLUMN object_delete_events.version          is 'version is the version of the deleted object or of the created delete marker.';
+					COMMENT ON COLUMN object_delete_events.stream_id        is 'stream_id is the stream of the deleted object or of the created delete marker.';
+					COMMENT ON COLUMN object_delete_events.kind             is 'kind specifies how the object was deleted, see metabase.DeleteEventKind.';
+					COMMENT ON COLUMN object_delete_events.total_plain_size is 'total_plain_size is the plain size of the deleted object.';
+					COMMENT ON COLUMN object_delete_events.deleted_at       is 'deleted_at is the time when the object was deleted.';
+				`},
+			},
+			{
+				DB:          &db.db,
+				Description: "add prepared_commit_deadline column to objects table",
+				Version:     30,
+				Action: migrate.SQL{
+					`ALTER TABLE objects ADD COLUMN prepared_commit_deadline TIMESTAMPTZ default NULL`,
+					`COMMENT ON COLUMN objects.prepared_commit_deadline is 'prepared_commit_deadline is the time until the prepared commit of a pending object can be confirmed. Pending objects with a prepared commit can not be committed without the confirmation.'`,
+				},
+			},
+			{
+				DB:          &db.db,
+				Description: "add encrypted_metadata_key_id column to objects table",
+				Version:     31,
+				Action: migrate.SQL{
+					`ALTER TABLE objects ADD COLUMN encrypted_metadata_key_id INT4 NOT NULL DEFAULT 0`,
+					`COMMENT ON COLUMN objects.encrypted_metadata_key_id is 'encrypted_metadata_key_id is the metadata encryption key encrypted_metadata is wrapped with, 0 when it is not wrapped.'`,
+				},
+			},
 		},
 	}
 }
@@ -0,0 +1,277 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/shared/dbutil/spannerutil"
+	"storj.io/storj/shared/tagsql"
+)
+
+// DeferredSegmentDeletion is a stream of a deleted object whose segments are yet to be deleted.
+type DeferredSegmentDeletion struct {
+	StreamID     uuid.UUID
+	ProjectID    uuid.UUID
+	SegmentCount int64
+	CreatedAt    time.Time
+}
+
+// ListDeferredSegmentDeletions contains arguments for listing the deferred segment deletions.
+type ListDeferredSegmentDeletions struct {
+	Limit int
+}
+
+// ListDeferredSegmentDeletions lists the oldest deferred segment deletions.
+func (db *DB) ListDeferredSegmentDeletions(ctx context.Context, opts ListDeferredSegmentDeletions) (deletions []DeferredSegmentDeletion, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if opts.Limit < 0 {
+		return nil, ErrInvalidRequest.New("Invalid limit: %d", opts.Limit)
+	}
+	ListLimit.Ensure(&opts.Limit)
+
+	for _, adapter := range db.adapters {
+		adapterDeletions, err := adapter.ListDeferredSegmentDeletions(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		deletions = append(deletions, adapterDeletions...)
+	}
+
+	sort.SliceStable(deletions, func(i, k int) bool {
+		return deletions[i].CreatedAt.Before(deletions[k].CreatedAt)
+	})
+	if len(deletions) > opts.Limit {
+		deletions = deletions[:opts.Limit]
+	}
+	return deletions, nil
+}
+
+// ListDeferredSegmentDeletions lists the oldest deferred segment deletions.
+func (p *PostgresAdapter) ListDeferredSegmentDeletions(ctx context.Context, opts ListDeferredSegmentDeletions) (deletions []DeferredSegmentDeletion, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = withRows(p.db.QueryContext(ctx, `
+		SELECT stream_id, project_id, segment_count, created_at
+		FROM deferred_segment_deletions
+		ORDER BY created_at ASC
+		LIMIT $1
+	`, opts.Limit))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var deletion DeferredSegmentDeletion
+			err := rows.Scan(&deletion.StreamID, &deletion.ProjectID, &deletion.SegmentCount, &deletion.CreatedAt)
+			if err != nil {
+				return err
+			}
+			deletions = append(deletions, deletion)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to list deferred segment deletions: %w", err)
+	}
+	return deletions, nil
+}
+
+// ListDeferredSegmentDeletions lists the oldest deferred segment deletions.
+func (s *SpannerAdapter) ListDeferredSegmentDeletions(ctx context.Context, opts ListDeferredSegmentDeletions) (deletions []DeferredSegmentDeletion, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	deletions, err = spannerutil.CollectRows(s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT stream_id, project_id, segment_count, created_at
+			FROM deferred_segment_deletions
+			ORDER BY created_at ASC
+			LIMIT @limit
+		`,
+		Params: map[string]any{
+			"limit": int64(opts.Limit),
+		},
+	}), func(row *spanner.Row, deletion *DeferredSegmentDeletion) error {
+		return row.Columns(&deletion.StreamID, &deletion.ProjectID, &deletion.SegmentCount, &deletion.CreatedAt)
+	})
+	if err != nil {
+		return nil, Error.New("unable to list deferred segment deletions: %w", err)
+	}
+	return deletions, nil
+}
+
+// ListDeferredSegmentDeletionStreamIDs returns up to limit deferred streams after
+// afterStreamID and up to endStreamID (inclusive), ordered by the stream ID.
+func (p *PostgresAdapter) ListDeferredSegmentDeletionStreamIDs(ctx context.Context, afterStreamID, endStreamID uuid.UUID, limit int) (streamIDs []uuid.UUID, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = withRows(p.db.QueryContext(ctx, `
+		SELECT stream_id
+		FROM deferred_segment_deletions
+		WHERE stream_id > $1 AND stream_id <= $2
+		ORDER BY stream_id
+		LIMIT $3
+	`, afterStreamID, endStreamID, limit))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var streamID uuid.UUID
+			if err := rows.Scan(&streamID); err != nil {
+				return err
+			}
+			streamIDs = append(streamIDs, streamID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to list deferred segment deletion streams: %w", err)
+	}
+	return streamIDs, nil
+}
+
+// ListDeferredSegmentDeletionStreamIDs returns up to limit deferred streams after
+// afterStreamID and up to endStreamID (inclusive), ordered by the stream ID.
+func (s *SpannerAdapter) ListDeferredSegmentDeletionStreamIDs(ctx context.Context, afterStreamID, endStreamID uuid.UUID, limit int) (streamIDs []uuid.UUID, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT stream_id
+			FROM deferred_segment_deletions
+			WHERE stream_id > @after_stream_id AND stream_id <= @end_stream_id
+			ORDER BY stream_id
+			LIMIT @limit
+		`,
+		Params: map[string]any{
+			"after_stream_id": afterStreamID,
+			"end_stream_id":   endStreamID,
+			"limit":           int64(limit),
+		},
+	}).Do(func(row *spanner.Row) error {
+		var streamID uuid.UUID
+		if err := row.Columns(&streamID); err != nil {
+			return err
+		}
+		streamIDs = append(streamIDs, streamID)
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to list deferred segment deletion streams: %w", err)
+	}
+	return streamIDs, nil
+}
+
+// RemoveDeferredSegmentDeletion removes the stream from the deferred segment deletions
+// after all of its segments have been deleted.
+func (db *DB) RemoveDeferredSegmentDeletion(ctx context.Context, deletion DeferredSegmentDeletion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if deletion.StreamID.IsZero() {
+		return ErrInvalidRequest.New("StreamID missing")
+	}
+
+	return db.ChooseAdapter(deletion.ProjectID).RemoveDeferredSegmentDeletion(ctx, deletion.StreamID)
+}
+
+// RemoveDeferredSegmentDeletion removes the stream from the deferred segment deletions.
+func (p *PostgresAdapter) RemoveDeferredSegmentDeletion(ctx context.Context, streamID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = p.db.ExecContext(ctx, `
+		DELETE FROM deferred_segment_deletions WHERE stream_id = $1
+	`, streamID)
+	if err != nil {
+		return Error.New("unable to remove deferred segment deletion: %w", err)
+	}
+	return nil
+}
+
+// RemoveDeferredSegmentDeletion removes the stream from the deferred segment deletions.
+func (s *SpannerAdapter) RemoveDeferredSegmentDeletion(ctx context.Context, streamID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		_, err := tx.Update(ctx, spanner.Statement{
+			SQL: `DELETE FROM deferred_segment_deletions WHERE stream_id = @stream_id`,
+			Params: map[string]any{
+				"stream_id": streamID,
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return Error.New("unable to remove deferred segment deletion: %w", err)
+	}
+	return nil
+}
+
+// DeferredSegmentDeletionBacklog describes the deferred segment deletions waiting to be processed.
+type DeferredSegmentDeletionBacklog struct {
+	Streams  int64
+	Segments int64
+	// Oldest is the time when the oldest waiting stream was queued, zero when there's none.
+	Oldest time.Time
+}
+
+// GetDeferredSegmentDeletionBacklog returns the size of the deferred segment deletion backlog.
+func (db *DB) GetDeferredSegmentDeletionBacklog(ctx context.Context) (backlog DeferredSegmentDeletionBacklog, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for _, adapter := range db.adapters {
+		adapterBacklog, err := adapter.GetDeferredSegmentDeletionBacklog(ctx)
+		if err != nil {
+			return DeferredSegmentDeletionBacklog{}, err
+		}
+		backlog.Streams += adapterBacklog.Streams
+		backlog.Segments += adapterBacklog.Segments
+		if !adapterBacklog.Oldest.IsZero() && (backlog.Oldest.IsZero() || adapterBacklog.Oldest.Before(backlog.Oldest)) {
+			backlog.Oldest = adapterBacklog.Oldest
+		}
+	}
+	return backlog, nil
+}
+
+// GetDeferredSegmentDeletionBacklog returns the size of the deferred segment deletion backlog.
+func (p *PostgresAdapter) GetDeferredSegmentDeletionBacklog(ctx context.Context) (backlog DeferredSegmentDeletionBacklog, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var oldest *time.Time
+	err = p.db.QueryRowContext(ctx, `
+		SELECT count(*), COALESCE(sum(segment_count), 0), min(created_at)
+		FROM deferred_segment_deletions
+	`).Scan(&backlog.Streams, &backlog.Segments, &oldest)
+	if err != nil {
+		return DeferredSegmentDeletionBacklog{}, Error.New("unable to query deferred segment deletion backlog: %w", err)
+	}
+	if oldest != nil {
+		backlog.Oldest = *oldest
+	}
+	return backlog, nil
+}
+
+// GetDeferredSegmentDeletionBacklog returns the size of the deferred segment deletion backlog.
+func (s *SpannerAdapter) GetDeferredSegmentDeletionBacklog(ctx context.Context) (backlog DeferredSegmentDeletionBacklog, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	backlog, err = spannerutil.CollectRow(s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT count(*), COALESCE(sum(segment_count), 0), min(created_at)
+			FROM deferred_segment_deletions
+		`,
+	}), func(row *spanner.Row, backlog *DeferredSegmentDeletionBacklog) error {
+		var oldest spanner.NullTime
+		if err := row.Columns(&backlog.Streams, &backlog.Segments, &oldest); err != nil {
+			return errs.Wrap(err)
+		}
+		if oldest.Valid {
+			backlog.Oldest = oldest.Time
+		}
+		return nil
+	})
+	if err != nil {
+		return DeferredSegmentDeletionBacklog{}, Error.New("unable to query deferred segment deletion backlog: %w", err)
+	}
+	return backlog, nil
+}
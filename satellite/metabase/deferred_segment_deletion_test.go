@@ -0,0 +1,190 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestDeferredSegmentDeletion(t *testing.T) {
+	metabasetest.RunWithConfig(t, metabase.Config{
+		ApplicationName:                  "metabase-tests",
+		DeferredSegmentDeletionThreshold: 2,
+	}, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		t.Run("small object is deleted right away", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 2)
+
+			metabasetest.DeleteObjectExactVersion{
+				Opts: metabase.DeleteObjectExactVersion{
+					ObjectLocation: object.Location(),
+					Version:        object.Version,
+				},
+				Result: metabase.DeleteObjectResult{
+					Removed: []metabase.Object{object},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+
+			backlog, err := db.GetDeferredSegmentDeletionBacklog(ctx)
+			require.NoError(t, err)
+			require.Zero(t, backlog)
+		})
+
+		t.Run("large object defers segment deletion", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			small := metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 1)
+			large := metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 5)
+
+			for _, object := range []metabase.Object{small, large} {
+				metabasetest.DeleteObjectExactVersion{
+					Opts: metabase.DeleteObjectExactVersion{
+						ObjectLocation: object.Location(),
+						Version:        object.Version,
+					},
+					Result: metabase.DeleteObjectResult{
+						Removed: []metabase.Object{object},
+					},
+				}.Check(ctx, t, db)
+			}
+
+			state, err := db.TestingGetState(ctx)
+			require.NoError(t, err)
+			require.Empty(t, state.Objects)
+			require.Len(t, state.Segments, 5)
+			for _, segment := range state.Segments {
+				require.Equal(t, large.StreamID, segment.StreamID)
+			}
+
+			backlog, err := db.GetDeferredSegmentDeletionBacklog(ctx)
+			require.NoError(t, err)
+			require.EqualValues(t, 1, backlog.Streams)
+			require.EqualValues(t, 5, backlog.Segments)
+			require.False(t, backlog.Oldest.IsZero())
+
+			deletions, err := db.ListDeferredSegmentDeletions(ctx, metabase.ListDeferredSegmentDeletions{})
+			require.NoError(t, err)
+			require.Len(t, deletions, 1)
+			require.Equal(t, large.StreamID, deletions[0].StreamID)
+			require.Equal(t, large.ProjectID, deletions[0].ProjectID)
+			require.EqualValues(t, 5, deletions[0].SegmentCount)
+
+			deleted, err := db.DeleteStreamSegments(ctx, metabase.DeleteStreamSegments{
+				ProjectID: deletions[0].ProjectID,
+				StreamID:  deletions[0].StreamID,
+				BatchSize: 2,
+			}, nil)
+			require.NoError(t, err)
+			require.EqualValues(t, 5, deleted)
+
+			require.NoError(t, db.RemoveDeferredSegmentDeletion(ctx, deletions[0]))
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+
+			backlog, err = db.GetDeferredSegmentDeletionBacklog(ctx)
+			require.NoError(t, err)
+			require.Zero(t, backlog)
+
+			deletions, err = db.ListDeferredSegmentDeletions(ctx, metabase.ListDeferredSegmentDeletions{})
+			require.NoError(t, err)
+			require.Empty(t, deletions)
+		})
+
+		t.Run("last committed and object lock paths defer segment deletion", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			plain := metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 3)
+			locked := metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 4)
+
+			metabasetest.DeleteObjectLastCommitted{
+				Opts: metabase.DeleteObjectLastCommitted{
+					ObjectLocation: plain.Location(),
+				},
+				Result: metabase.DeleteObjectResult{
+					Removed: []metabase.Object{plain},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.DeleteObjectLastCommitted{
+				Opts: metabase.DeleteObjectLastCommitted{
+					ObjectLocation: locked.Location(),
+					UseObjectLock:  true,
+				},
+				Result: metabase.DeleteObjectResult{
+					Removed: []metabase.Object{locked},
+				},
+			}.Check(ctx, t, db)
+
+			state, err := db.TestingGetState(ctx)
+			require.NoError(t, err)
+			require.Empty(t, state.Objects)
+			require.Len(t, state.Segments, 7)
+
+			backlog, err := db.GetDeferredSegmentDeletionBacklog(ctx)
+			require.NoError(t, err)
+			require.EqualValues(t, 2, backlog.Streams)
+			require.EqualValues(t, 7, backlog.Segments)
+		})
+
+		t.Run("segment loop skips deferred streams", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			var kept []uuid.UUID
+			for i := 0; i < 3; i++ {
+				object := metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 1)
+				kept = append(kept, object.StreamID)
+			}
+			sort.Slice(kept, func(i, j int) bool { return kept[i].Less(kept[j]) })
+
+			for i := 0; i < 3; i++ {
+				deleted := metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 5)
+				metabasetest.DeleteObjectExactVersion{
+					Opts: metabase.DeleteObjectExactVersion{
+						ObjectLocation: deleted.Location(),
+						Version:        deleted.Version,
+					},
+					Result: metabase.DeleteObjectResult{
+						Removed: []metabase.Object{deleted},
+					},
+				}.Check(ctx, t, db)
+			}
+
+			// the deferred streams are read in batches of the same size as the segments.
+			for _, batchSize := range []int{1, 2, 100} {
+				var streamIDs []uuid.UUID
+				err := db.IterateLoopSegments(ctx, metabase.IterateLoopSegments{
+					BatchSize: batchSize,
+				}, func(ctx context.Context, it metabase.LoopSegmentsIterator) error {
+					var entry metabase.LoopSegmentEntry
+					for it.Next(ctx, &entry) {
+						streamIDs = append(streamIDs, entry.StreamID)
+					}
+					return nil
+				})
+				require.NoError(t, err)
+				require.Equal(t, kept, streamIDs, "batch size %d", batchSize)
+			}
+		})
+
+		t.Run("invalid request", func(t *testing.T) {
+			_, err := db.ListDeferredSegmentDeletions(ctx, metabase.ListDeferredSegmentDeletions{Limit: -1})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+
+			err = db.RemoveDeferredSegmentDeletion(ctx, metabase.DeferredSegmentDeletion{})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+		})
+	})
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package deferreddeletion
+
+import (
+	"context"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+	"storj.io/storj/satellite/metabase"
+)
+
+var (
+	// Error defines the deferreddeletion chore errors class.
+	Error = errs.Class("deferred deletion chore")
+	mon   = monkit.Package()
+)
+
+// Config contains configurable values for deferred segment deletion.
+type Config struct {
+	Interval  time.Duration `help:"the time between each attempt to delete the segments of deleted objects" releaseDefault:"1m" devDefault:"10s"`
+	Enabled   bool          `help:"set if deferred segment deletion is enabled or not" default:"true"`
+	ListLimit int           `help:"how many streams to process in a single iteration" default:"100"`
+	BatchSize int           `help:"how many segments to delete in a single statement" default:"1000"`
+}
+
+// Chore implements the deferred segment deletion chore.
+//
+// architecture: Chore
+type Chore struct {
+	log      *zap.Logger
+	config   Config
+	metabase *metabase.DB
+
+	nowFn func() time.Time
+	Loop  *sync2.Cycle
+}
+
+// NewChore creates a new instance of the deferreddeletion chore.
+func NewChore(log *zap.Logger, config Config, metabase *metabase.DB) *Chore {
+	return &Chore{
+		log:      log,
+		config:   config,
+		metabase: metabase,
+
+		nowFn: time.Now,
+		Loop:  sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run starts the deferreddeletion loop service.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !chore.config.Enabled {
+		return nil
+	}
+
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := chore.RunOnce(ctx); err != nil {
+			chore.log.Error("failed to delete deferred segments", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// Close stops the deferreddeletion chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
+
+// TestingSetNow allows tests to have the server act as if the current time is whatever they want.
+func (chore *Chore) TestingSetNow(nowFn func() time.Time) {
+	chore.nowFn = nowFn
+}
+
+// RunOnce reports the backlog and deletes the segments of a batch of queued streams.
+func (chore *Chore) RunOnce(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	backlog, err := chore.metabase.GetDeferredSegmentDeletionBacklog(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	mon.IntVal("deferred_segment_deletion_backlog_streams").Observe(backlog.Streams)
+	mon.IntVal("deferred_segment_deletion_backlog_segments").Observe(backlog.Segments)
+	if !backlog.Oldest.IsZero() {
+		mon.FloatVal("deferred_segment_deletion_backlog_age_seconds").Observe(chore.nowFn().Sub(backlog.Oldest).Seconds())
+	}
+
+	if backlog.Streams == 0 {
+		return nil
+	}
+
+	deletions, err := chore.metabase.ListDeferredSegmentDeletions(ctx, metabase.ListDeferredSegmentDeletions{
+		Limit: chore.config.ListLimit,
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var group errs.Group
+	for _, deletion := range deletions {
+		if err := chore.deleteSegments(ctx, deletion); err != nil {
+			chore.log.Warn("unable to delete deferred segments",
+				zap.Stringer("Project ID", deletion.ProjectID),
+				zap.Stringer("Stream ID", deletion.StreamID),
+				zap.Error(err))
+			group.Add(err)
+		}
+	}
+	return Error.Wrap(group.Err())
+}
+
+func (chore *Chore) deleteSegments(ctx context.Context, deletion metabase.DeferredSegmentDeletion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	deleted, err := chore.metabase.DeleteStreamSegments(ctx, metabase.DeleteStreamSegments{
+		ProjectID: deletion.ProjectID,
+		StreamID:  deletion.StreamID,
+		BatchSize: chore.config.BatchSize,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	mon.Meter("deferred_segment_deletion_segments").Mark64(deleted)
+
+	return chore.metabase.RemoveDeferredSegmentDeletion(ctx, deletion)
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package deferreddeletion_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestDeferredDeletion(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Metainfo.DeferredSegmentDeletionThreshold = 2
+				config.DeferredDeletion.BatchSize = 2
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		db := sat.Metabase.DB
+		chore := sat.Core.DeferredDeletion.Chore
+		chore.Loop.Pause()
+
+		// nothing to do with an empty queue.
+		require.NoError(t, chore.RunOnce(ctx))
+
+		small := metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 2)
+		large := metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 5)
+
+		for _, object := range []metabase.Object{small, large} {
+			_, err := db.DeleteObjectExactVersion(ctx, metabase.DeleteObjectExactVersion{
+				ObjectLocation: object.Location(),
+				Version:        object.Version,
+			})
+			require.NoError(t, err)
+		}
+
+		segments, err := db.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, 5)
+
+		require.NoError(t, chore.RunOnce(ctx))
+
+		segments, err = db.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Empty(t, segments)
+
+		backlog, err := db.GetDeferredSegmentDeletionBacklog(ctx)
+		require.NoError(t, err)
+		require.Zero(t, backlog)
+	})
+}
@@ -0,0 +1,11 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+/*
+Package deferreddeletion contains the functions needed to run the deferred segment deletion chore.
+
+Objects with many segments can be deleted without deleting their segments in the same
+statement. Their streams are queued instead, and the deferreddeletion chore periodically
+deletes the segments of the queued streams in bounded batches.
+*/
+package deferreddeletion
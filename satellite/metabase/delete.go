@@ -7,12 +7,14 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"cloud.google.com/go/spanner"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	"google.golang.org/api/iterator"
 
+	"storj.io/common/storj"
 	"storj.io/common/uuid"
 	"storj.io/storj/shared/dbutil/spannerutil"
 	"storj.io/storj/shared/tagsql"
@@ -20,6 +22,7 @@ import (
 
 const (
 	objectLockedErrMsg              = "object has an active retention period"
+	objectLegalHoldErrMsg           = "object is under legal hold"
 	multipleCommittedVersionsErrMsg = "internal error: multiple committed unversioned objects"
 )
 
@@ -27,6 +30,10 @@ var (
 	// ErrObjectLock is used when an object's Object Lock configuration prevents
 	// an operation from succeeding.
 	ErrObjectLock = errs.Class("object lock")
+
+	// ErrDeletionProtected is used when the deletion protection of a bucket
+	// prevents an object from being deleted.
+	ErrDeletionProtected = errs.Class("deletion protected")
 )
 
 // DeleteObjectExactVersion contains arguments necessary for deleting an exact version of object.
@@ -37,6 +44,20 @@ type DeleteObjectExactVersion struct {
 	// UseObjectLock, if enabled, prevents the deletion of committed object versions
 	// with active Object Lock configurations.
 	UseObjectLock bool
+
+	// DeletionProtected, if enabled, prevents the deletion because the bucket
+	// has deletion protection enabled.
+	DeletionProtected bool
+
+	// ReturnDeletedSegments, if enabled, returns the remote segments that were deleted
+	// together with the object in DeleteObjectResult.DeletedSegments.
+	ReturnDeletedSegments bool
+
+	// deferSegmentsAbove is the segment count above which the segments of the
+	// deleted object are queued for deferred deletion. 0 disables deferring.
+	deferSegmentsAbove int
+	// recordDeleteEvents records the deletion in the object_delete_events outbox.
+	recordDeleteEvents bool
 }
 
 // Verify delete object fields.
@@ -56,38 +77,157 @@ type DeleteObjectResult struct {
 	Removed []Object
 	// Markers contains the delete markers that were added.
 	Markers []Object
+	// DeletedSegments contains the remote segments that were deleted together with
+	// the objects. It's only populated when ReturnDeletedSegments is requested.
+	DeletedSegments []DeletedSegmentInfo
+}
+
+// DeletedSegmentInfo info about a deleted remote segment.
+type DeletedSegmentInfo struct {
+	RootPieceID storj.PieceID
+	Pieces      Pieces
+}
+
+// deletedSegment is a deleted remote segment as returned by the adapters.
+type deletedSegment struct {
+	RootPieceID storj.PieceID
+	AliasPieces AliasPieces
+}
+
+// convertDeletedSegments converts the alias pieces of the deleted segments.
+func (db *DB) convertDeletedSegments(ctx context.Context, deleted []deletedSegment) (_ []DeletedSegmentInfo, err error) {
+	if len(deleted) == 0 {
+		return nil, nil
+	}
+
+	segments := make([]DeletedSegmentInfo, 0, len(deleted))
+	for _, segment := range deleted {
+		pieces, err := db.aliasCache.ConvertAliasesToPieces(ctx, segment.AliasPieces)
+		if err != nil {
+			return nil, Error.New("unable to convert aliases to pieces: %w", err)
+		}
+		segments = append(segments, DeletedSegmentInfo{
+			RootPieceID: segment.RootPieceID,
+			Pieces:      pieces,
+		})
+	}
+	return segments, nil
 }
 
 // DeleteObjectExactVersion deletes an exact object version.
+//
+// When Config.DeferredSegmentDeletionThreshold is set, the segments of an object with more
+// segments than the threshold are not deleted together with the object. Instead, the stream
+// is queued in the deferred_segment_deletions table and its segments are deleted in batches
+// by the deferred deletion chore. Such segments are not part of DeleteObjectResult.DeletedSegments.
 func (db *DB) DeleteObjectExactVersion(ctx context.Context, opts DeleteObjectExactVersion) (result DeleteObjectResult, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	if err := opts.Verify(); err != nil {
 		return DeleteObjectResult{}, err
 	}
-	result, err = db.ChooseAdapter(opts.ProjectID).DeleteObjectExactVersion(ctx, opts)
+	if opts.DeletionProtected {
+		return DeleteObjectResult{}, ErrDeletionProtected.New("bucket has deletion protection enabled")
+	}
+	if err := db.deleteLimiter.check(ctx, opts.ProjectID); err != nil {
+		return DeleteObjectResult{}, err
+	}
+	opts.deferSegmentsAbove = db.config.DeferredSegmentDeletionThreshold
+	opts.recordDeleteEvents = db.recordsDeleteEvents(ctx, opts.Bucket())
+
+	result, deleted, err := db.ChooseAdapter(opts.ProjectID).DeleteObjectExactVersion(ctx, opts)
 	if err != nil {
 		return DeleteObjectResult{}, err
 	}
+	db.deleteLimiter.charge(ctx, opts.ProjectID, int64(len(result.Removed)))
 
-	mon.Meter("object_delete").Mark(len(result.Removed))
-	for _, object := range result.Removed {
-		mon.Meter("segment_delete").Mark(int(object.SegmentCount))
+	result.DeletedSegments, err = db.convertDeletedSegments(ctx, deleted)
+	if err != nil {
+		return DeleteObjectResult{}, err
+	}
+
+	if err := db.metadataEncryption.decryptObjects(result.Removed); err != nil {
+		return DeleteObjectResult{}, err
 	}
+
+	submitObjectDeletionMetrics(result.Removed, opts.deferSegmentsAbove)
 	return result, nil
 }
 
+// defersSegmentDeletion returns whether the segments of the deleted object are queued for deferred deletion.
+func defersSegmentDeletion(object Object, deferSegmentsAbove int) bool {
+	return deferSegmentsAbove > 0 && int(object.SegmentCount) > deferSegmentsAbove
+}
+
+func submitObjectDeletionMetrics(removed []Object, deferSegmentsAbove int) {
+	mon.Meter("object_delete").Mark(len(removed))
+	for _, object := range removed {
+		if defersSegmentDeletion(object, deferSegmentsAbove) {
+			mon.Meter("object_delete_deferred").Mark(1)
+			continue
+		}
+		mon.Meter("segment_delete").Mark(int(object.SegmentCount))
+	}
+}
+
+// postgresDeferSegmentsQuery returns the condition selecting the deleted objects whose segments are
+// deleted right away, and the CTE queueing the segments of the other deleted objects for deferred
+// deletion. The arguments are the placeholders of the threshold and the project ID.
+func postgresDeferSegmentsQuery(deferSegmentsAbove, projectID string) (condition, deferred string) {
+	return `
+				WHERE deleted_objects.segment_count <= ` + deferSegmentsAbove, `, deferred_deletions AS (
+				INSERT INTO deferred_segment_deletions (stream_id, project_id, segment_count)
+				SELECT deleted_objects.stream_id, ` + projectID + `, deleted_objects.segment_count FROM deleted_objects
+				WHERE deleted_objects.segment_count > ` + deferSegmentsAbove + `
+				RETURNING deferred_segment_deletions.stream_id
+			)`
+}
+
+// deferSegmentDeletionsSpanner queues the segments of the deleted objects with more segments than
+// the threshold for deferred deletion. It returns the streams whose segments are deleted right away.
+func deferSegmentDeletionsSpanner(tx *spanner.ReadWriteTransaction, projectID uuid.UUID, objects []Object, deferSegmentsAbove int) (streamIDs [][]byte, err error) {
+	streamIDs = make([][]byte, 0, len(objects))
+	for _, object := range objects {
+		if !defersSegmentDeletion(object, deferSegmentsAbove) {
+			streamIDs = append(streamIDs, object.StreamID.Bytes())
+			continue
+		}
+		err := tx.BufferWrite([]*spanner.Mutation{
+			spanner.Insert("deferred_segment_deletions",
+				[]string{"stream_id", "project_id", "segment_count"},
+				[]any{object.StreamID, projectID, int64(object.SegmentCount)}),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return streamIDs, nil
+}
+
 // DeleteObjectExactVersion deletes an exact object version.
-func (p *PostgresAdapter) DeleteObjectExactVersion(ctx context.Context, opts DeleteObjectExactVersion) (DeleteObjectResult, error) {
+func (p *PostgresAdapter) DeleteObjectExactVersion(ctx context.Context, opts DeleteObjectExactVersion) (DeleteObjectResult, []deletedSegment, error) {
 	if opts.UseObjectLock {
 		return p.deleteObjectExactVersionUsingObjectLock(ctx, opts)
 	}
 	return p.deleteObjectExactVersion(ctx, opts)
 }
 
-func (p *PostgresAdapter) deleteObjectExactVersion(ctx context.Context, opts DeleteObjectExactVersion) (result DeleteObjectResult, err error) {
+func (p *PostgresAdapter) deleteObjectExactVersion(ctx context.Context, opts DeleteObjectExactVersion) (result DeleteObjectResult, deleted []deletedSegment, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	args := []any{opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.Version}
+	var condition, deferred string
+	if opts.deferSegmentsAbove > 0 {
+		condition, deferred = postgresDeferSegmentsQuery("$5", "$1")
+		args = append(args, opts.deferSegmentsAbove)
+	}
+
+	var recorded string
+	if opts.recordDeleteEvents {
+		recorded = postgresDeleteEventsQuery(DeleteEventRemoved, "deleted_objects", "$1", "$2", "$3", "deleted_objects.total_plain_size")
+	}
+
+	returning, columns, join := postgresDeletedSegmentsQuery(opts.ReturnDeletedSegments)
 	err = withRows(
 		p.db.QueryContext(ctx, `
 			WITH deleted_objects AS (
@@ -100,61 +240,64 @@ func (p *PostgresAdapter) deleteObjectExactVersion(ctx context.Context, opts Del
 					retention_mode, retain_until
 			), deleted_segments AS (
 				DELETE FROM segments
-				WHERE segments.stream_id IN (SELECT deleted_objects.stream_id FROM deleted_objects)
-				RETURNING segments.stream_id
-			)
+				WHERE segments.stream_id IN (
+					SELECT deleted_objects.stream_id FROM deleted_objects`+condition+`
+				)
+				RETURNING segments.stream_id`+returning+`
+			)`+deferred+recorded+`
 			SELECT
 				version, stream_id, created_at, expires_at, status, segment_count, encrypted_metadata_nonce,
 				encrypted_metadata, encrypted_metadata_encrypted_key, total_plain_size, total_encrypted_size,
 				fixed_segment_size, encryption,
-				retention_mode, retain_until
-			FROM deleted_objects`,
-			opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.Version),
+				retention_mode, retain_until`+columns+`
+			FROM deleted_objects `+join,
+			args...),
 	)(func(rows tagsql.Rows) error {
+		if opts.ReturnDeletedSegments {
+			result.Removed, deleted, err = scanObjectDeletionWithSegmentsPostgres(ctx, opts.ObjectLocation, rows)
+			return err
+		}
 		result.Removed, err = scanObjectDeletionPostgres(ctx, opts.ObjectLocation, rows)
 		return err
 	})
-	return result, err
+	return result, deleted, err
 }
 
-func (p *PostgresAdapter) deleteObjectExactVersionUsingObjectLock(ctx context.Context, opts DeleteObjectExactVersion) (result DeleteObjectResult, err error) {
+func (p *PostgresAdapter) deleteObjectExactVersionUsingObjectLock(ctx context.Context, opts DeleteObjectExactVersion) (result DeleteObjectResult, deleted []deletedSegment, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var retention Retention
+	var info objectLockInfo
 
 	err = p.db.QueryRowContext(ctx, `
-		SELECT retention_mode, retain_until
+		SELECT retention_mode, retain_until, legal_hold
 		FROM objects
 		WHERE (project_id, bucket_name, object_key, version) = ($1, $2, $3, $4)
 		`, opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.Version,
-	).Scan(retentionModeWrapper{&retention.Mode}, timeWrapper{&retention.RetainUntil})
+	).Scan(retentionModeWrapper{&info.Retention.Mode}, timeWrapper{&info.Retention.RetainUntil}, &info.LegalHold)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return DeleteObjectResult{}, nil
+			return DeleteObjectResult{}, nil, nil
 		}
-		return DeleteObjectResult{}, Error.Wrap(err)
+		return DeleteObjectResult{}, nil, Error.Wrap(err)
 	}
 
-	if err = retention.Verify(); err != nil {
-		return DeleteObjectResult{}, Error.Wrap(err)
-	}
-	if retention.Active() {
-		return DeleteObjectResult{}, ErrObjectLock.New(objectLockedErrMsg)
+	if err = info.verifyDeletion(); err != nil {
+		return DeleteObjectResult{}, nil, err
 	}
 
-	result, err = p.deleteObjectExactVersion(ctx, opts)
-	return result, errs.Wrap(err)
+	result, deleted, err = p.deleteObjectExactVersion(ctx, opts)
+	return result, deleted, errs.Wrap(err)
 }
 
 // DeleteObjectExactVersion deletes an exact object version.
-func (s *SpannerAdapter) DeleteObjectExactVersion(ctx context.Context, opts DeleteObjectExactVersion) (DeleteObjectResult, error) {
+func (s *SpannerAdapter) DeleteObjectExactVersion(ctx context.Context, opts DeleteObjectExactVersion) (DeleteObjectResult, []deletedSegment, error) {
 	if opts.UseObjectLock {
-		return s.deleteObjectExactVersion(ctx, opts)
+		return s.deleteObjectExactVersionUsingObjectLock(ctx, opts)
 	}
-	return s.deleteObjectExactVersionUsingObjectLock(ctx, opts)
+	return s.deleteObjectExactVersion(ctx, opts)
 }
 
-func (s *SpannerAdapter) deleteObjectExactVersion(ctx context.Context, opts DeleteObjectExactVersion) (result DeleteObjectResult, err error) {
+func (s *SpannerAdapter) deleteObjectExactVersion(ctx context.Context, opts DeleteObjectExactVersion) (result DeleteObjectResult, deleted []deletedSegment, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
@@ -175,31 +318,28 @@ func (s *SpannerAdapter) deleteObjectExactVersion(ctx context.Context, opts Dele
 			return Error.Wrap(err)
 		}
 
-		streamIDs := make([][]byte, 0, len(result.Removed))
-		for _, object := range result.Removed {
-			streamIDs = append(streamIDs, object.StreamID.Bytes())
+		if opts.recordDeleteEvents {
+			if err := insertDeleteEventsSpanner(tx, DeleteEventRemoved, result.Removed); err != nil {
+				return err
+			}
 		}
-		segmentDeletion := spanner.Statement{
-			SQL: `
-				DELETE FROM segments
-				WHERE ARRAY_INCLUDES(@stream_ids, stream_id)
-			`,
-			Params: map[string]interface{}{
-				"stream_ids": streamIDs,
-			},
+
+		streamIDs, err := deferSegmentDeletionsSpanner(tx, opts.ProjectID, result.Removed, opts.deferSegmentsAbove)
+		if err != nil {
+			return Error.Wrap(err)
 		}
-		_, err = tx.Update(ctx, segmentDeletion)
+		deleted, err = deleteSegmentsSpanner(ctx, tx, streamIDs, opts.ReturnDeletedSegments)
 		return Error.Wrap(err)
 	})
-	return result, err
+	return result, deleted, err
 }
 
-func (s *SpannerAdapter) deleteObjectExactVersionUsingObjectLock(ctx context.Context, opts DeleteObjectExactVersion) (result DeleteObjectResult, err error) {
+func (s *SpannerAdapter) deleteObjectExactVersionUsingObjectLock(ctx context.Context, opts DeleteObjectExactVersion) (result DeleteObjectResult, deleted []deletedSegment, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	retention, err := spannerutil.CollectRow(s.client.Single().Query(ctx, spanner.Statement{
+	info, err := spannerutil.CollectRow(s.client.Single().Query(ctx, spanner.Statement{
 		SQL: `
-			SELECT retention_mode, retain_until
+			SELECT retention_mode, retain_until, legal_hold
 			FROM objects
 			WHERE (project_id, bucket_name, object_key, version) = (@project_id, @bucket_name, @object_key, @version)
 		`,
@@ -209,30 +349,35 @@ func (s *SpannerAdapter) deleteObjectExactVersionUsingObjectLock(ctx context.Con
 			"object_key":  opts.ObjectKey,
 			"version":     opts.Version,
 		},
-	}), func(row *spanner.Row, item *Retention) error {
-		return errs.Wrap(row.Columns(retentionModeWrapper{&item.Mode}, timeWrapper{&item.RetainUntil}))
+	}), func(row *spanner.Row, item *objectLockInfo) error {
+		return errs.Wrap(row.Columns(
+			retentionModeWrapper{&item.Retention.Mode},
+			timeWrapper{&item.Retention.RetainUntil},
+			&item.LegalHold))
 	})
 	if err != nil {
 		if errs.Is(err, iterator.Done) {
-			return DeleteObjectResult{}, nil
+			return DeleteObjectResult{}, nil, nil
 		}
-		return DeleteObjectResult{}, Error.Wrap(err)
+		return DeleteObjectResult{}, nil, Error.Wrap(err)
 	}
 
-	if err = retention.Verify(); err != nil {
-		return DeleteObjectResult{}, Error.Wrap(err)
-	}
-	if retention.Active() {
-		return DeleteObjectResult{}, ErrObjectLock.New(objectLockedErrMsg)
+	if err = info.verifyDeletion(); err != nil {
+		return DeleteObjectResult{}, nil, err
 	}
 
-	result, err = s.deleteObjectExactVersion(ctx, opts)
-	return result, errs.Wrap(err)
+	result, deleted, err = s.deleteObjectExactVersion(ctx, opts)
+	return result, deleted, errs.Wrap(err)
 }
 
 // DeletePendingObject contains arguments necessary for deleting a pending object.
 type DeletePendingObject struct {
 	ObjectStream
+
+	// NoCommittedSegmentsSince, when set, prevents the deletion when a segment of
+	// the object was committed at or after the time. It protects multipart uploads
+	// which are still progressing from being aborted by stale client retries.
+	NoCommittedSegmentsSince time.Time
 }
 
 // Verify verifies delete pending object fields validity.
@@ -250,32 +395,47 @@ func (db *DB) DeletePendingObject(ctx context.Context, opts DeletePendingObject)
 	if err := opts.Verify(); err != nil {
 		return DeleteObjectResult{}, err
 	}
+	if err := db.deleteLimiter.check(ctx, opts.ProjectID); err != nil {
+		return DeleteObjectResult{}, err
+	}
 
 	result, err = db.ChooseAdapter(opts.ProjectID).DeletePendingObject(ctx, opts)
 	if err != nil {
 		return DeleteObjectResult{}, err
 	}
+	db.deleteLimiter.charge(ctx, opts.ProjectID, int64(len(result.Removed)))
 
 	if len(result.Removed) == 0 {
 		return DeleteObjectResult{}, ErrObjectNotFound.Wrap(Error.New("no rows deleted"))
 	}
 
-	mon.Meter("object_delete").Mark(len(result.Removed))
-	for _, object := range result.Removed {
-		mon.Meter("segment_delete").Mark(int(object.SegmentCount))
+	if err := db.metadataEncryption.decryptObjects(result.Removed); err != nil {
+		return DeleteObjectResult{}, err
 	}
 
+	// pending objects never defer their segments.
+	submitObjectDeletionMetrics(result.Removed, 0)
+
 	return result, nil
 }
 
 // DeletePendingObject deletes a pending object with specified version and streamID.
 func (p *PostgresAdapter) DeletePendingObject(ctx context.Context, opts DeletePendingObject) (result DeleteObjectResult, err error) {
+	var committedSince *time.Time
+	if !opts.NoCommittedSegmentsSince.IsZero() {
+		committedSince = &opts.NoCommittedSegmentsSince
+	}
+
 	err = withRows(p.db.QueryContext(ctx, `
 			WITH deleted_objects AS (
 				DELETE FROM objects
 				WHERE
 					(project_id, bucket_name, object_key, version, stream_id) = ($1, $2, $3, $4, $5) AND
-					status = `+statusPending+`
+					status = `+statusPending+` AND
+					($6::TIMESTAMPTZ IS NULL OR NOT EXISTS (
+						SELECT 1 FROM segments
+						WHERE segments.stream_id = $5 AND segments.created_at >= $6
+					))
 				RETURNING
 					version, stream_id, created_at, expires_at, status, segment_count,
 					encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
@@ -292,16 +452,58 @@ func (p *PostgresAdapter) DeletePendingObject(ctx context.Context, opts DeletePe
 				total_plain_size, total_encrypted_size, fixed_segment_size, encryption,
 				retention_mode, retain_until
 			FROM deleted_objects
-		`, opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.Version, opts.StreamID))(func(rows tagsql.Rows) error {
+		`, opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.Version, opts.StreamID, committedSince))(func(rows tagsql.Rows) error {
 		result.Removed, err = scanObjectDeletionPostgres(ctx, opts.Location(), rows)
 		return err
 	})
-	return result, err
+	if err != nil || len(result.Removed) > 0 || committedSince == nil {
+		return result, err
+	}
+
+	// find out whether nothing was deleted because of a recently committed segment.
+	var active bool
+	err = p.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM segments
+			WHERE stream_id = $1 AND created_at >= $2
+		)
+	`, opts.StreamID, opts.NoCommittedSegmentsSince).Scan(&active)
+	if err != nil {
+		return result, Error.Wrap(err)
+	}
+	if active {
+		return result, ErrFailedPrecondition.New("pending object has segments committed since %s", opts.NoCommittedSegmentsSince.Format(time.RFC3339))
+	}
+	return result, nil
 }
 
 // DeletePendingObject deletes a pending object with specified version and streamID.
 func (s *SpannerAdapter) DeletePendingObject(ctx context.Context, opts DeletePendingObject) (result DeleteObjectResult, err error) {
 	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		if !opts.NoCommittedSegmentsSince.IsZero() {
+			var active bool
+			err := tx.Query(ctx, spanner.Statement{
+				SQL: `
+					SELECT EXISTS (
+						SELECT 1 FROM segments
+						WHERE stream_id = @stream_id AND created_at >= @committed_since
+					)
+				`,
+				Params: map[string]interface{}{
+					"stream_id":       opts.StreamID,
+					"committed_since": opts.NoCommittedSegmentsSince,
+				},
+			}).Do(func(row *spanner.Row) error {
+				return row.Columns(&active)
+			})
+			if err != nil {
+				return Error.Wrap(err)
+			}
+			if active {
+				return ErrFailedPrecondition.New("pending object has segments committed since %s", opts.NoCommittedSegmentsSince.Format(time.RFC3339))
+			}
+		}
+
 		result.Removed, err = collectDeletedObjectsSpanner(ctx, opts.Location(), tx.Query(ctx, spanner.Statement{
 			SQL: `
 				DELETE FROM objects
@@ -368,6 +570,116 @@ func scanObjectDeletionPostgres(ctx context.Context, location ObjectLocation, ro
 	return objects, nil
 }
 
+// scanObjectDeletionWithSegmentsPostgres reads in the results of an object deletion joined
+// with the deleted segments, see postgresDeletedSegmentsQuery.
+func scanObjectDeletionWithSegmentsPostgres(ctx context.Context, location ObjectLocation, rows tagsql.Rows) (objects []Object, segments []deletedSegment, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	objects = make([]Object, 0, 10)
+
+	scanned := map[uuid.UUID]struct{}{}
+	for rows.Next() {
+		var object Object
+		var rootPieceID []byte
+		var aliasPieces AliasPieces
+
+		object.ProjectID = location.ProjectID
+		object.BucketName = location.BucketName
+		object.ObjectKey = location.ObjectKey
+
+		err = rows.Scan(&object.Version, &object.StreamID,
+			&object.CreatedAt, &object.ExpiresAt,
+			&object.Status, &object.SegmentCount,
+			&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
+			&object.TotalPlainSize, &object.TotalEncryptedSize, &object.FixedSegmentSize,
+			encryptionParameters{&object.Encryption},
+			retentionModeWrapper{&object.Retention.Mode}, timeWrapper{&object.Retention.RetainUntil},
+			&rootPieceID, &aliasPieces,
+		)
+		if err != nil {
+			return nil, nil, Error.New("unable to delete object: %w", err)
+		}
+
+		if _, ok := scanned[object.StreamID]; !ok {
+			scanned[object.StreamID] = struct{}{}
+			objects = append(objects, object)
+		}
+
+		// inline segments don't have any pieces to delete.
+		if len(aliasPieces) == 0 {
+			continue
+		}
+
+		segment := deletedSegment{AliasPieces: aliasPieces}
+		segment.RootPieceID, err = storj.PieceIDFromBytes(rootPieceID)
+		if err != nil {
+			return nil, nil, Error.New("unable to delete object: %w", err)
+		}
+		segments = append(segments, segment)
+	}
+
+	return objects, segments, nil
+}
+
+// postgresDeletedSegmentsQuery returns the query fragments for returning the deleted
+// segments together with the deleted objects. The deleted objects are joined with the
+// deleted segments, hence an object is returned once for every segment it had.
+func postgresDeletedSegmentsQuery(returnDeletedSegments bool) (returning, columns, join string) {
+	if !returnDeletedSegments {
+		return "", "", ""
+	}
+	return ", segments.root_piece_id, segments.remote_alias_pieces",
+		", deleted_segments.root_piece_id, deleted_segments.remote_alias_pieces",
+		"LEFT JOIN deleted_segments USING (stream_id)"
+}
+
+// deleteSegmentsSpanner deletes the segments of the specified streams. When returnDeleted is
+// set, the deleted remote segments are returned.
+func deleteSegmentsSpanner(ctx context.Context, tx *spanner.ReadWriteTransaction, streamIDs [][]byte, returnDeleted bool) (deleted []deletedSegment, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !returnDeleted {
+		_, err = tx.Update(ctx, spanner.Statement{
+			SQL: `
+				DELETE FROM segments
+				WHERE ARRAY_INCLUDES(@stream_ids, stream_id)
+			`,
+			Params: map[string]interface{}{
+				"stream_ids": streamIDs,
+			},
+		})
+		return nil, err
+	}
+
+	err = tx.Query(ctx, spanner.Statement{
+		SQL: `
+			DELETE FROM segments
+			WHERE ARRAY_INCLUDES(@stream_ids, stream_id)
+			THEN RETURN root_piece_id, remote_alias_pieces
+		`,
+		Params: map[string]interface{}{
+			"stream_ids": streamIDs,
+		},
+	}).Do(func(row *spanner.Row) error {
+		var rootPieceID []byte
+		var segment deletedSegment
+		if err := row.Columns(&rootPieceID, &segment.AliasPieces); err != nil {
+			return err
+		}
+		// inline segments don't have any pieces to delete.
+		if len(segment.AliasPieces) == 0 {
+			return nil
+		}
+		segment.RootPieceID, err = storj.PieceIDFromBytes(rootPieceID)
+		if err != nil {
+			return err
+		}
+		deleted = append(deleted, segment)
+		return nil
+	})
+	return deleted, err
+}
+
 const collectDeletedObjectsSpannerFields = " " +
 	`version, stream_id, created_at, expires_at, status, segment_count, encrypted_metadata_nonce,
 	encrypted_metadata, encrypted_metadata_encrypted_key, total_plain_size, total_encrypted_size,
@@ -416,6 +728,27 @@ type DeleteObjectLastCommitted struct {
 	// UseObjectLock, if enabled, prevents the deletion of committed object versions with
 	// active Object Lock configurations.
 	UseObjectLock bool
+
+	// DeletionProtected, if enabled, prevents the deletion because the bucket has
+	// deletion protection enabled. Inserting a delete marker into a versioned bucket
+	// is still allowed, since it doesn't remove any data.
+	DeletionProtected bool
+
+	// ReturnDeletedSegments, if enabled, returns the remote segments that were deleted
+	// together with the object in DeleteObjectResult.DeletedSegments. It only applies
+	// when neither Versioned nor Suspended is set.
+	ReturnDeletedSegments bool
+
+	// IfLatestVersion, if set, inserts the delete marker only when the given version
+	// is still the latest version of the object. Otherwise ErrValueChanged is returned.
+	// It requires Versioned or Suspended to be set.
+	IfLatestVersion Version
+
+	// deferSegmentsAbove is the segment count above which the segments of the
+	// deleted object are queued for deferred deletion. 0 disables deferring.
+	deferSegmentsAbove int
+	// recordDeleteEvents records the deletion in the object_delete_events outbox.
+	recordDeleteEvents bool
 }
 
 // Verify delete object last committed fields.
@@ -423,10 +756,15 @@ func (obj *DeleteObjectLastCommitted) Verify() error {
 	if obj.Versioned && obj.Suspended {
 		return ErrInvalidRequest.New("versioned and suspended cannot be enabled at the same time")
 	}
+	if obj.IfLatestVersion != 0 && !obj.Versioned && !obj.Suspended {
+		return ErrInvalidRequest.New("IfLatestVersion requires versioned or suspended")
+	}
 	return obj.ObjectLocation.Verify()
 }
 
 // DeleteObjectLastCommitted deletes an object last committed version.
+//
+// The segments of a deleted object are deferred the same way as by DeleteObjectExactVersion.
 func (db *DB) DeleteObjectLastCommitted(
 	ctx context.Context, opts DeleteObjectLastCommitted,
 ) (result DeleteObjectResult, err error) {
@@ -435,6 +773,14 @@ func (db *DB) DeleteObjectLastCommitted(
 	if err := opts.Verify(); err != nil {
 		return DeleteObjectResult{}, err
 	}
+	if opts.DeletionProtected && !opts.Versioned {
+		return DeleteObjectResult{}, ErrDeletionProtected.New("bucket has deletion protection enabled")
+	}
+	if err := db.deleteLimiter.check(ctx, opts.ProjectID); err != nil {
+		return DeleteObjectResult{}, err
+	}
+	opts.deferSegmentsAbove = db.config.DeferredSegmentDeletionThreshold
+	opts.recordDeleteEvents = db.recordsDeleteEvents(ctx, opts.Bucket())
 
 	if opts.Suspended {
 		deleterMarkerStreamID, err := generateDeleteMarkerStreamID()
@@ -454,10 +800,20 @@ func (db *DB) DeleteObjectLastCommitted(
 		return db.ChooseAdapter(opts.ProjectID).DeleteObjectLastCommittedVersioned(ctx, opts, deleterMarkerStreamID)
 	}
 
-	result, err = db.ChooseAdapter(opts.ProjectID).DeleteObjectLastCommittedPlain(ctx, opts)
+	result, deleted, err := db.ChooseAdapter(opts.ProjectID).DeleteObjectLastCommittedPlain(ctx, opts)
 	if err != nil {
 		return DeleteObjectResult{}, err
 	}
+	db.deleteLimiter.charge(ctx, opts.ProjectID, int64(len(result.Removed)))
+
+	result.DeletedSegments, err = db.convertDeletedSegments(ctx, deleted)
+	if err != nil {
+		return DeleteObjectResult{}, err
+	}
+
+	if err := db.metadataEncryption.decryptObjects(result.Removed); err != nil {
+		return DeleteObjectResult{}, err
+	}
 
 	mon.Meter("object_delete").Mark(len(result.Removed))
 	for _, object := range result.Removed {
@@ -469,17 +825,29 @@ func (db *DB) DeleteObjectLastCommitted(
 
 // DeleteObjectLastCommittedPlain deletes an object last committed version when
 // opts.Suspended and opts.Versioned are both false.
-func (p *PostgresAdapter) DeleteObjectLastCommittedPlain(ctx context.Context, opts DeleteObjectLastCommitted) (DeleteObjectResult, error) {
+func (p *PostgresAdapter) DeleteObjectLastCommittedPlain(ctx context.Context, opts DeleteObjectLastCommitted) (DeleteObjectResult, []deletedSegment, error) {
 	if opts.UseObjectLock {
 		return p.deleteObjectLastCommittedPlainUsingObjectLock(ctx, opts)
 	}
 	return p.deleteObjectLastCommittedPlain(ctx, opts)
 }
 
-func (p *PostgresAdapter) deleteObjectLastCommittedPlain(ctx context.Context, opts DeleteObjectLastCommitted) (result DeleteObjectResult, err error) {
+func (p *PostgresAdapter) deleteObjectLastCommittedPlain(ctx context.Context, opts DeleteObjectLastCommitted) (result DeleteObjectResult, deleted []deletedSegment, err error) {
 	defer mon.Task()(&ctx)(&err)
 	// TODO(ver): do we need to pretend here that `expires_at` matters?
 	// TODO(ver): should this report an error when the object doesn't exist?
+	args := []any{opts.ProjectID, opts.BucketName, opts.ObjectKey}
+	var condition, deferred string
+	if opts.deferSegmentsAbove > 0 {
+		condition, deferred = postgresDeferSegmentsQuery("$4", "$1")
+		args = append(args, opts.deferSegmentsAbove)
+	}
+	var recorded string
+	if opts.recordDeleteEvents {
+		recorded = postgresDeleteEventsQuery(DeleteEventRemoved, "deleted_objects", "$1", "$2", "$3", "deleted_objects.total_plain_size")
+	}
+
+	returning, columns, join := postgresDeletedSegmentsQuery(opts.ReturnDeletedSegments)
 	err = withRows(
 		p.db.QueryContext(ctx, `
 			WITH deleted_objects AS (
@@ -498,34 +866,40 @@ func (p *PostgresAdapter) deleteObjectLastCommittedPlain(ctx context.Context, op
 					retention_mode, retain_until
 			), deleted_segments AS (
 				DELETE FROM segments
-				WHERE segments.stream_id IN (SELECT deleted_objects.stream_id FROM deleted_objects)
-				RETURNING segments.stream_id
-			)
+				WHERE segments.stream_id IN (
+					SELECT deleted_objects.stream_id FROM deleted_objects`+condition+`
+				)
+				RETURNING segments.stream_id`+returning+`
+			)`+deferred+recorded+`
 			SELECT
 				version, stream_id, created_at, expires_at, status, segment_count, encrypted_metadata_nonce,
 				encrypted_metadata, encrypted_metadata_encrypted_key, total_plain_size, total_encrypted_size,
 				fixed_segment_size, encryption,
-				retention_mode, retain_until
-			FROM deleted_objects`,
-			opts.ProjectID, opts.BucketName, opts.ObjectKey),
+				retention_mode, retain_until`+columns+`
+			FROM deleted_objects `+join,
+			args...),
 	)(func(rows tagsql.Rows) error {
+		if opts.ReturnDeletedSegments {
+			result.Removed, deleted, err = scanObjectDeletionWithSegmentsPostgres(ctx, opts.ObjectLocation, rows)
+			return err
+		}
 		result.Removed, err = scanObjectDeletionPostgres(ctx, opts.ObjectLocation, rows)
 		return err
 	})
-	return result, err
+	return result, deleted, err
 }
 
-func (p *PostgresAdapter) deleteObjectLastCommittedPlainUsingObjectLock(ctx context.Context, opts DeleteObjectLastCommitted) (result DeleteObjectResult, err error) {
+func (p *PostgresAdapter) deleteObjectLastCommittedPlainUsingObjectLock(ctx context.Context, opts DeleteObjectLastCommitted) (result DeleteObjectResult, deleted []deletedSegment, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	var (
-		version   Version
-		retention Retention
-		scanned   bool
+		version Version
+		info    objectLockInfo
+		scanned bool
 	)
 
 	err = withRows(p.db.QueryContext(ctx, `
-		SELECT version, retention_mode, retain_until
+		SELECT version, retention_mode, retain_until, legal_hold
 		FROM objects
 		WHERE
 			(project_id, bucket_name, object_key) = ($1, $2, $3)
@@ -538,7 +912,7 @@ func (p *PostgresAdapter) deleteObjectLastCommittedPlainUsingObjectLock(ctx cont
 			return nil
 		}
 
-		err := rows.Scan(&version, retentionModeWrapper{&retention.Mode}, timeWrapper{&retention.RetainUntil})
+		err := rows.Scan(&version, retentionModeWrapper{&info.Retention.Mode}, timeWrapper{&info.Retention.RetainUntil}, &info.LegalHold)
 		if err != nil {
 			return errs.Wrap(err)
 		}
@@ -552,36 +926,36 @@ func (p *PostgresAdapter) deleteObjectLastCommittedPlainUsingObjectLock(ctx cont
 		return nil
 	})
 	if err != nil {
-		return DeleteObjectResult{}, Error.Wrap(err)
+		return DeleteObjectResult{}, nil, Error.Wrap(err)
 	}
 	if !scanned {
-		return DeleteObjectResult{}, nil
+		return DeleteObjectResult{}, nil, nil
 	}
 
-	if err = retention.Verify(); err != nil {
-		return DeleteObjectResult{}, errs.Wrap(err)
-	}
-	if retention.Active() {
-		return DeleteObjectResult{}, ErrObjectLock.New(objectLockedErrMsg)
+	if err = info.verifyDeletion(); err != nil {
+		return DeleteObjectResult{}, nil, err
 	}
 
-	result, err = p.DeleteObjectExactVersion(ctx, DeleteObjectExactVersion{
-		ObjectLocation: opts.ObjectLocation,
-		Version:        version,
+	result, deleted, err = p.DeleteObjectExactVersion(ctx, DeleteObjectExactVersion{
+		ObjectLocation:        opts.ObjectLocation,
+		Version:               version,
+		ReturnDeletedSegments: opts.ReturnDeletedSegments,
+		deferSegmentsAbove:    opts.deferSegmentsAbove,
+		recordDeleteEvents:    opts.recordDeleteEvents,
 	})
-	return result, errs.Wrap(err)
+	return result, deleted, errs.Wrap(err)
 }
 
 // DeleteObjectLastCommittedPlain deletes an object last committed version when
 // opts.Suspended and opts.Versioned are both false.
-func (s *SpannerAdapter) DeleteObjectLastCommittedPlain(ctx context.Context, opts DeleteObjectLastCommitted) (DeleteObjectResult, error) {
+func (s *SpannerAdapter) DeleteObjectLastCommittedPlain(ctx context.Context, opts DeleteObjectLastCommitted) (DeleteObjectResult, []deletedSegment, error) {
 	if opts.UseObjectLock {
 		return s.deleteObjectLastCommittedPlainUsingObjectLock(ctx, opts)
 	}
 	return s.deleteObjectLastCommittedPlain(ctx, opts)
 }
 
-func (s *SpannerAdapter) deleteObjectLastCommittedPlain(ctx context.Context, opts DeleteObjectLastCommitted) (result DeleteObjectResult, err error) {
+func (s *SpannerAdapter) deleteObjectLastCommittedPlain(ctx context.Context, opts DeleteObjectLastCommitted) (result DeleteObjectResult, deleted []deletedSegment, err error) {
 	defer mon.Task()(&ctx)(&err)
 	// TODO(ver): do we need to pretend here that `expires_at` matters?
 	// TODO(ver): should this report an error when the object doesn't exist?
@@ -606,37 +980,34 @@ func (s *SpannerAdapter) deleteObjectLastCommittedPlain(ctx context.Context, opt
 			return Error.Wrap(err)
 		}
 
-		streamIDs := make([][]byte, 0, len(result.Removed))
-		for _, object := range result.Removed {
-			streamIDs = append(streamIDs, object.StreamID.Bytes())
+		if opts.recordDeleteEvents {
+			if err := insertDeleteEventsSpanner(tx, DeleteEventRemoved, result.Removed); err != nil {
+				return err
+			}
 		}
-		// TODO(spanner): make sure this is an efficient query
-		segmentDeletion := spanner.Statement{
-			SQL: `
-				DELETE FROM segments
-				WHERE ARRAY_INCLUDES(@stream_ids, stream_id)
-			`,
-			Params: map[string]interface{}{
-				"stream_ids": streamIDs,
-			},
+
+		streamIDs, err := deferSegmentDeletionsSpanner(tx, opts.ProjectID, result.Removed, opts.deferSegmentsAbove)
+		if err != nil {
+			return Error.Wrap(err)
 		}
-		_, err = tx.Update(ctx, segmentDeletion)
+		// TODO(spanner): make sure this is an efficient query
+		deleted, err = deleteSegmentsSpanner(ctx, tx, streamIDs, opts.ReturnDeletedSegments)
 		return Error.Wrap(err)
 	})
-	return result, err
+	return result, deleted, err
 }
 
-func (s *SpannerAdapter) deleteObjectLastCommittedPlainUsingObjectLock(ctx context.Context, opts DeleteObjectLastCommitted) (result DeleteObjectResult, err error) {
+func (s *SpannerAdapter) deleteObjectLastCommittedPlainUsingObjectLock(ctx context.Context, opts DeleteObjectLastCommitted) (result DeleteObjectResult, deleted []deletedSegment, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	type versionAndRetention struct {
-		version   Version
-		retention Retention
+	type versionAndLock struct {
+		version Version
+		objectLockInfo
 	}
 
 	info, err := spannerutil.CollectRow(s.client.Single().Query(ctx, spanner.Statement{
 		SQL: `
-			SELECT version, retention_mode, retain_until
+			SELECT version, retention_mode, retain_until, legal_hold
 			FROM objects
 			WHERE
 				(project_id, bucket_name, object_key) = (@project_id, @bucket_name, @object_key)
@@ -649,35 +1020,44 @@ func (s *SpannerAdapter) deleteObjectLastCommittedPlainUsingObjectLock(ctx conte
 			"bucket_name": opts.BucketName,
 			"object_key":  opts.ObjectKey,
 		},
-	}), func(row *spanner.Row, item *versionAndRetention) error {
+	}), func(row *spanner.Row, item *versionAndLock) error {
 		return errs.Wrap(row.Columns(
 			&item.version,
-			retentionModeWrapper{&item.retention.Mode},
-			timeWrapper{&item.retention.RetainUntil}))
+			retentionModeWrapper{&item.Retention.Mode},
+			timeWrapper{&item.Retention.RetainUntil},
+			&item.LegalHold))
 	})
 	switch {
 	case err == nil:
 	case errors.Is(err, iterator.Done):
-		return DeleteObjectResult{}, nil
+		return DeleteObjectResult{}, nil, nil
 	case spannerutil.ErrMultipleRows.Has(err):
 		logMultipleCommittedVersionsError(s.log, opts.ObjectLocation)
-		return DeleteObjectResult{}, Error.New(multipleCommittedVersionsErrMsg)
+		return DeleteObjectResult{}, nil, Error.New(multipleCommittedVersionsErrMsg)
 	default:
-		return DeleteObjectResult{}, Error.Wrap(err)
+		return DeleteObjectResult{}, nil, Error.Wrap(err)
 	}
 
-	if err = info.retention.Verify(); err != nil {
-		return DeleteObjectResult{}, errs.Wrap(err)
-	}
-	if info.retention.Active() {
-		return DeleteObjectResult{}, ErrObjectLock.New(objectLockedErrMsg)
+	if err = info.verifyDeletion(); err != nil {
+		return DeleteObjectResult{}, nil, err
 	}
 
-	result, err = s.DeleteObjectExactVersion(ctx, DeleteObjectExactVersion{
-		ObjectLocation: opts.ObjectLocation,
-		Version:        info.version,
+	result, deleted, err = s.DeleteObjectExactVersion(ctx, DeleteObjectExactVersion{
+		ObjectLocation:        opts.ObjectLocation,
+		Version:               info.version,
+		ReturnDeletedSegments: opts.ReturnDeletedSegments,
+		deferSegmentsAbove:    opts.deferSegmentsAbove,
+		recordDeleteEvents:    opts.recordDeleteEvents,
 	})
-	return result, errs.Wrap(err)
+	return result, deleted, errs.Wrap(err)
+}
+
+// insertDeleteResultEvents records the removed objects and the added delete markers within the transaction.
+func insertDeleteResultEvents(ctx context.Context, tx precommitTransactionAdapter, result DeleteObjectResult) error {
+	if err := tx.insertDeleteEvents(ctx, DeleteEventRemoved, result.Removed); err != nil {
+		return err
+	}
+	return tx.insertDeleteEvents(ctx, DeleteEventMarkerCreated, result.Markers)
 }
 
 type deleteTransactionAdapter interface {
@@ -692,6 +1072,12 @@ type PrecommitDeleteUnversionedWithNonPending struct {
 	// UseObjectLock, if enabled, prevents the deletion of committed object versions with
 	// active Object Lock configurations.
 	UseObjectLock bool
+
+	// deferSegmentsAbove is the segment count above which the segments of the
+	// deleted object are queued for deferred deletion. 0 disables deferring.
+	deferSegmentsAbove int
+	// recordDeleteEvents records the deletion in the object_delete_events outbox.
+	recordDeleteEvents bool
 }
 
 // DeleteObjectLastCommittedSuspended deletes an object last committed version when opts.Suspended is true.
@@ -699,8 +1085,9 @@ func (p *PostgresAdapter) DeleteObjectLastCommittedSuspended(ctx context.Context
 	var precommit PrecommitConstraintWithNonPendingResult
 	err = p.WithTx(ctx, func(ctx context.Context, tx TransactionAdapter) (err error) {
 		precommit, err = tx.PrecommitDeleteUnversionedWithNonPending(ctx, PrecommitDeleteUnversionedWithNonPending{
-			ObjectLocation: opts.ObjectLocation,
-			UseObjectLock:  opts.UseObjectLock,
+			ObjectLocation:     opts.ObjectLocation,
+			UseObjectLock:      opts.UseObjectLock,
+			deferSegmentsAbove: opts.deferSegmentsAbove,
 		})
 		if err != nil {
 			return errs.Wrap(err)
@@ -709,6 +1096,9 @@ func (p *PostgresAdapter) DeleteObjectLastCommittedSuspended(ctx context.Context
 			// an object didn't exist in the first place
 			return ErrObjectNotFound.New("unable to delete object")
 		}
+		if opts.IfLatestVersion != 0 && precommit.HighestVersion != opts.IfLatestVersion {
+			return ErrValueChanged.New("latest object version changed")
+		}
 
 		row := tx.(*postgresTransactionAdapter).tx.QueryRowContext(ctx, `
 				INSERT INTO objects (
@@ -739,6 +1129,9 @@ func (p *PostgresAdapter) DeleteObjectLastCommittedSuspended(ctx context.Context
 
 		result.Markers = append(result.Markers, marker)
 		result.Removed = precommit.Deleted
+		if opts.recordDeleteEvents {
+			return insertDeleteResultEvents(ctx, tx, result)
+		}
 		return nil
 	})
 	if err != nil {
@@ -755,8 +1148,9 @@ func (s *SpannerAdapter) DeleteObjectLastCommittedSuspended(ctx context.Context,
 		stx := atx.(*spannerTransactionAdapter)
 
 		precommit, err = stx.PrecommitDeleteUnversionedWithNonPending(ctx, PrecommitDeleteUnversionedWithNonPending{
-			ObjectLocation: opts.ObjectLocation,
-			UseObjectLock:  opts.UseObjectLock,
+			ObjectLocation:     opts.ObjectLocation,
+			UseObjectLock:      opts.UseObjectLock,
+			deferSegmentsAbove: opts.deferSegmentsAbove,
 		})
 		if err != nil {
 			return errs.Wrap(err)
@@ -765,6 +1159,9 @@ func (s *SpannerAdapter) DeleteObjectLastCommittedSuspended(ctx context.Context,
 			// an object didn't exist in the first place
 			return ErrObjectNotFound.New("unable to delete object")
 		}
+		if opts.IfLatestVersion != 0 && precommit.HighestVersion != opts.IfLatestVersion {
+			return ErrValueChanged.New("latest object version changed")
+		}
 
 		marker, err := spannerutil.CollectRow(
 			stx.tx.Query(ctx, spanner.Statement{
@@ -807,6 +1204,9 @@ func (s *SpannerAdapter) DeleteObjectLastCommittedSuspended(ctx context.Context,
 
 		result.Markers = append(result.Markers, marker)
 		result.Removed = precommit.Deleted
+		if opts.recordDeleteEvents {
+			return insertDeleteResultEvents(ctx, stx, result)
+		}
 		return nil
 	})
 
@@ -819,26 +1219,41 @@ func (s *SpannerAdapter) DeleteObjectLastCommittedSuspended(ctx context.Context,
 
 // DeleteObjectLastCommittedVersioned deletes an object last committed version when opts.Versioned is true.
 func (p *PostgresAdapter) DeleteObjectLastCommittedVersioned(ctx context.Context, opts DeleteObjectLastCommitted, deleterMarkerStreamID uuid.UUID) (result DeleteObjectResult, err error) {
+	var recorded string
+	if opts.recordDeleteEvents {
+		recorded = postgresDeleteEventsQuery(DeleteEventMarkerCreated, "inserted_markers", "$1", "$2", "$3", "0")
+	}
+
 	row := p.db.QueryRowContext(ctx, `
-			INSERT INTO objects (
-				project_id, bucket_name, object_key, version, stream_id,
-				status,
-				zombie_deletion_deadline
-			)
-			SELECT
-				$1, $2, $3,
-					coalesce((
-						SELECT version + 1
-						FROM objects
-						WHERE (project_id, bucket_name, object_key) = ($1, $2, $3)
-						ORDER BY version DESC
-						LIMIT 1
-					), 1),
-				$4,
-				`+statusDeleteMarkerVersioned+`,
-				NULL
-			RETURNING version, created_at
-		`, opts.ProjectID, opts.BucketName, opts.ObjectKey, deleterMarkerStreamID)
+			WITH inserted_markers AS (
+				INSERT INTO objects (
+					project_id, bucket_name, object_key, version, stream_id,
+					status,
+					zombie_deletion_deadline
+				)
+				SELECT
+					$1, $2, $3,
+						coalesce((
+							SELECT version + 1
+							FROM objects
+							WHERE (project_id, bucket_name, object_key) = ($1, $2, $3)
+							ORDER BY version DESC
+							LIMIT 1
+						), 1),
+					$4,
+					`+statusDeleteMarkerVersioned+`,
+					NULL
+				WHERE $5::INT8 = 0 OR $5::INT8 = coalesce((
+					SELECT version
+					FROM objects
+					WHERE (project_id, bucket_name, object_key) = ($1, $2, $3)
+					ORDER BY version DESC
+					LIMIT 1
+				), 0)
+				RETURNING version, stream_id, created_at
+			)`+recorded+`
+			SELECT version, created_at FROM inserted_markers
+		`, opts.ProjectID, opts.BucketName, opts.ObjectKey, deleterMarkerStreamID, opts.IfLatestVersion)
 
 	var deleted Object
 	deleted.ProjectID = opts.ProjectID
@@ -850,6 +1265,9 @@ func (p *PostgresAdapter) DeleteObjectLastCommittedVersioned(ctx context.Context
 	err = row.Scan(&deleted.Version, &deleted.CreatedAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
+			if opts.IfLatestVersion != 0 {
+				return DeleteObjectResult{}, ErrValueChanged.New("latest object version changed")
+			}
 			return DeleteObjectResult{}, ErrObjectNotFound.Wrap(Error.New("object does not exist"))
 		}
 		return DeleteObjectResult{}, Error.Wrap(err)
@@ -860,6 +1278,31 @@ func (p *PostgresAdapter) DeleteObjectLastCommittedVersioned(ctx context.Context
 // DeleteObjectLastCommittedVersioned deletes an object last committed version when opts.Versioned is true.
 func (s *SpannerAdapter) DeleteObjectLastCommittedVersioned(ctx context.Context, opts DeleteObjectLastCommitted, deleterMarkerStreamID uuid.UUID) (result DeleteObjectResult, err error) {
 	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		if opts.IfLatestVersion != 0 {
+			latest, err := spannerutil.CollectRow(
+				tx.Query(ctx, spanner.Statement{
+					SQL: `
+						SELECT version
+						FROM objects
+						WHERE (project_id, bucket_name, object_key) = (@project_id, @bucket_name, @object_key)
+						ORDER BY version DESC
+						LIMIT 1
+					`,
+					Params: map[string]interface{}{
+						"project_id":  opts.ProjectID,
+						"bucket_name": opts.BucketName,
+						"object_key":  opts.ObjectKey,
+					},
+				}), func(row *spanner.Row, item *Version) error {
+					return Error.Wrap(row.Columns(item))
+				})
+			if err != nil && !errors.Is(err, iterator.Done) {
+				return Error.Wrap(err)
+			}
+			if latest != opts.IfLatestVersion {
+				return ErrValueChanged.New("latest object version changed")
+			}
+		}
 
 		deleted, err := spannerutil.CollectRow(
 			tx.Query(ctx, spanner.Statement{
@@ -907,6 +1350,9 @@ func (s *SpannerAdapter) DeleteObjectLastCommittedVersioned(ctx context.Context,
 
 		result.Markers = []Object{deleted}
 
+		if opts.recordDeleteEvents {
+			return insertDeleteEventsSpanner(tx, DeleteEventMarkerCreated, result.Markers)
+		}
 		return nil
 	})
 	if err != nil {
@@ -5,6 +5,10 @@ package metabase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"time"
 
 	"cloud.google.com/go/spanner"
 
@@ -19,6 +23,15 @@ const (
 type DeleteBucketObjects struct {
 	Bucket    BucketLocation
 	BatchSize int
+
+	// RequireEmpty, if enabled, prevents deleting the objects of a non-empty bucket,
+	// unless ForceToken matches the token returned by BucketDeletionForceToken.
+	RequireEmpty bool
+	// ForceToken acknowledges the number of objects in the bucket.
+	ForceToken string
+
+	// recordDeleteEvents records the deletions in the object_delete_events outbox.
+	recordDeleteEvents bool
 }
 
 // DeleteBucketObjects deletes all objects in the specified bucket.
@@ -28,20 +41,34 @@ type DeleteBucketObjects struct {
 func (db *DB) DeleteBucketObjects(ctx context.Context, opts DeleteBucketObjects) (deletedObjectCount int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	ctx, done := db.queries.track(ctx, "delete-bucket-objects", opts.Bucket.ProjectID)
+	defer done()
+
 	if err := opts.Bucket.Verify(); err != nil {
 		return 0, err
 	}
 
+	if opts.RequireEmpty {
+		if err := db.verifyBucketDeletion(ctx, opts); err != nil {
+			return 0, err
+		}
+	}
+
 	deleteBatchSizeLimit.Ensure(&opts.BatchSize)
+	opts.recordDeleteEvents = db.recordsDeleteEvents(ctx, opts.Bucket)
 
 	deletedBatchObjectCount := int64(opts.BatchSize)
 	for deletedBatchObjectCount > 0 {
 		if err := ctx.Err(); err != nil {
 			return deletedObjectCount, err
 		}
+		if err := db.deleteLimiter.check(ctx, opts.Bucket.ProjectID); err != nil {
+			return deletedObjectCount, err
+		}
 
 		var deletedBatchSegmentCount int64
 		deletedBatchObjectCount, deletedBatchSegmentCount, err = db.ChooseAdapter(opts.Bucket.ProjectID).DeleteBucketObjects(ctx, opts)
+		db.deleteLimiter.charge(ctx, opts.Bucket.ProjectID, deletedBatchObjectCount)
 
 		mon.Meter("object_delete").Mark64(deletedBatchObjectCount)
 		mon.Meter("segment_delete").Mark64(deletedBatchSegmentCount)
@@ -55,6 +82,84 @@ func (db *DB) DeleteBucketObjects(ctx context.Context, opts DeleteBucketObjects)
 	return deletedObjectCount, nil
 }
 
+// BucketDeletionForceToken returns the token that allows deleting the objects of
+// a non-empty bucket with DeleteBucketObjects.RequireEmpty enabled, and the number
+// of objects the token acknowledges.
+//
+// The token is derived from the number of objects in the bucket, so it stops being
+// valid when objects are added or removed. It isn't a secret, it ensures the caller
+// has seen how many objects are going to be deleted.
+func (db *DB) BucketDeletionForceToken(ctx context.Context, bucket BucketLocation) (token string, objectCount int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := bucket.Verify(); err != nil {
+		return "", 0, err
+	}
+
+	objectCount, err = db.countBucketObjects(ctx, bucket)
+	if err != nil {
+		return "", 0, err
+	}
+	return bucketDeletionForceToken(bucket, objectCount), objectCount, nil
+}
+
+// verifyBucketDeletion checks that the bucket is empty or the deletion was acknowledged.
+func (db *DB) verifyBucketDeletion(ctx context.Context, opts DeleteBucketObjects) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	empty, err := db.BucketEmpty(ctx, BucketEmpty{
+		ProjectID:  opts.Bucket.ProjectID,
+		BucketName: opts.Bucket.BucketName,
+	})
+	if err != nil {
+		return err
+	}
+	if empty {
+		return nil
+	}
+
+	if opts.ForceToken == "" {
+		return ErrFailedPrecondition.New("bucket is not empty")
+	}
+
+	objectCount, err := db.countBucketObjects(ctx, opts.Bucket)
+	if err != nil {
+		return err
+	}
+	if opts.ForceToken != bucketDeletionForceToken(opts.Bucket, objectCount) {
+		return ErrFailedPrecondition.New("force token doesn't match the %d objects of the bucket", objectCount)
+	}
+	return nil
+}
+
+// countBucketObjects returns the number of objects in the bucket.
+func (db *DB) countBucketObjects(ctx context.Context, bucket BucketLocation) (count int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	tallies, err := db.ChooseAdapter(bucket.ProjectID).CollectBucketTallies(ctx, CollectBucketTallies{
+		From: bucket,
+		To:   bucket,
+		// objects which already expired are deleted as well.
+		Now: time.Time{},
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, tally := range tallies {
+		count += tally.ObjectCount
+	}
+	return count, nil
+}
+
+// bucketDeletionForceToken derives the force token from the bucket location and its object count.
+func bucketDeletionForceToken(bucket BucketLocation, objectCount int64) string {
+	h := sha256.New()
+	_, _ = h.Write(bucket.ProjectID.Bytes())
+	_, _ = h.Write([]byte(bucket.BucketName))
+	_ = binary.Write(h, binary.BigEndian, objectCount)
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
 // DeleteBucketObjects deletes all objects in the specified bucket.
 // Deletion performs in batches, so in case of error while processing,
 // this method will return the number of objects deleted to the moment
@@ -62,6 +167,11 @@ func (db *DB) DeleteBucketObjects(ctx context.Context, opts DeleteBucketObjects)
 func (p *PostgresAdapter) DeleteBucketObjects(ctx context.Context, opts DeleteBucketObjects) (deletedObjectCount, deletedSegmentCount int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	var recorded string
+	if opts.recordDeleteEvents {
+		recorded = postgresDeleteEventsQuery(DeleteEventRemoved, "deleted_objects", "$1", "$2", "deleted_objects.object_key", "deleted_objects.total_plain_size")
+	}
+
 	query := `
 		WITH deleted_objects AS (
 			DELETE FROM objects
@@ -70,12 +180,12 @@ func (p *PostgresAdapter) DeleteBucketObjects(ctx context.Context, opts DeleteBu
 				WHERE (project_id, bucket_name) = ($1, $2)
 				LIMIT $3
 			)
-			RETURNING objects.stream_id, objects.segment_count
+			RETURNING objects.object_key, objects.version, objects.stream_id, objects.segment_count, objects.total_plain_size
 		), deleted_segments AS (
 			DELETE FROM segments
 			WHERE segments.stream_id IN (SELECT deleted_objects.stream_id FROM deleted_objects)
 			RETURNING segments.stream_id
-		)
+		)` + recorded + `
 		SELECT COUNT(1), COALESCE(SUM(segment_count), 0) FROM deleted_objects
 	`
 
@@ -93,17 +203,22 @@ func (p *PostgresAdapter) DeleteBucketObjects(ctx context.Context, opts DeleteBu
 func (c *CockroachAdapter) DeleteBucketObjects(ctx context.Context, opts DeleteBucketObjects) (deletedObjectCount, deletedSegmentCount int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	var recorded string
+	if opts.recordDeleteEvents {
+		recorded = postgresDeleteEventsQuery(DeleteEventRemoved, "deleted_objects", "$1", "$2", "deleted_objects.object_key", "deleted_objects.total_plain_size")
+	}
+
 	query := `
 		WITH deleted_objects AS (
 			DELETE FROM objects
 			WHERE (project_id, bucket_name) = ($1, $2)
 			LIMIT $3
-			RETURNING objects.stream_id, objects.segment_count
+			RETURNING objects.object_key, objects.version, objects.stream_id, objects.segment_count, objects.total_plain_size
 		), deleted_segments AS (
 			DELETE FROM segments
 			WHERE segments.stream_id IN (SELECT deleted_objects.stream_id FROM deleted_objects)
 			RETURNING segments.stream_id
-		)
+		)` + recorded + `
 		SELECT COUNT(1), COALESCE(SUM(segment_count), 0) FROM deleted_objects
 	`
 
@@ -123,7 +238,7 @@ func (s *SpannerAdapter) DeleteBucketObjects(ctx context.Context, opts DeleteBuc
 
 	// TODO(spanner): see if it would be better to avoid batching altogether here.
 	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
-		streamIDs, err := spannerutil.CollectRows(tx.Query(ctx, spanner.Statement{
+		deleted, err := spannerutil.CollectRows(tx.Query(ctx, spanner.Statement{
 			SQL: `
 				DELETE FROM objects
 				WHERE stream_id IN (
@@ -132,24 +247,37 @@ func (s *SpannerAdapter) DeleteBucketObjects(ctx context.Context, opts DeleteBuc
 					ORDER BY project_id, bucket_name
 					LIMIT @delete_limit
 				)
-				THEN RETURN stream_id
+				THEN RETURN object_key, version, stream_id, total_plain_size
 			`,
 			Params: map[string]interface{}{
 				"project_id":   opts.Bucket.ProjectID,
 				"bucket_name":  opts.Bucket.BucketName,
 				"delete_limit": opts.BatchSize,
 			},
-		}), func(row *spanner.Row, streamID *[]byte) error {
-			return row.Columns(streamID)
+		}), func(row *spanner.Row, object *Object) error {
+			object.ProjectID = opts.Bucket.ProjectID
+			object.BucketName = opts.Bucket.BucketName
+			return row.Columns(&object.ObjectKey, &object.Version, &object.StreamID, &object.TotalPlainSize)
 		})
 		if err != nil {
 			return Error.Wrap(err)
 		}
-		deletedObjectCount = int64(len(streamIDs))
-		if len(streamIDs) == 0 {
+		deletedObjectCount = int64(len(deleted))
+		if len(deleted) == 0 {
 			return nil
 		}
 
+		if opts.recordDeleteEvents {
+			if err := insertDeleteEventsSpanner(tx, DeleteEventRemoved, deleted); err != nil {
+				return err
+			}
+		}
+
+		streamIDs := make([][]byte, 0, len(deleted))
+		for _, object := range deleted {
+			streamIDs = append(streamIDs, object.StreamID.Bytes())
+		}
+
 		deletedSegmentCount, err = tx.Update(ctx, spanner.Statement{
 			SQL: `
 				DELETE FROM segments
@@ -74,6 +74,87 @@ func TestDeleteBucketObjects(t *testing.T) {
 			metabasetest.Verify{}.Check(ctx, t, db)
 		})
 
+		t.Run("require empty", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			bucket := obj1.Location().Bucket()
+
+			metabasetest.DeleteBucketObjects{
+				Opts: metabase.DeleteBucketObjects{
+					Bucket:       bucket,
+					RequireEmpty: true,
+				},
+				Deleted: 0,
+			}.Check(ctx, t, db)
+
+			object1 := metabasetest.CreateObject(ctx, t, db, obj1, 0)
+			object2 := metabasetest.CreateObject(ctx, t, db, obj2, 0)
+
+			metabasetest.DeleteBucketObjects{
+				Opts: metabase.DeleteBucketObjects{
+					Bucket:       bucket,
+					RequireEmpty: true,
+				},
+				ErrClass: &metabase.ErrFailedPrecondition,
+				ErrText:  "bucket is not empty",
+			}.Check(ctx, t, db)
+
+			token, count, err := db.BucketDeletionForceToken(ctx, bucket)
+			require.NoError(t, err)
+			require.EqualValues(t, 2, count)
+			require.NotEmpty(t, token)
+
+			// the token of another bucket isn't accepted.
+			otherToken, _, err := db.BucketDeletionForceToken(ctx, objX.Location().Bucket())
+			require.NoError(t, err)
+
+			metabasetest.DeleteBucketObjects{
+				Opts: metabase.DeleteBucketObjects{
+					Bucket:       bucket,
+					RequireEmpty: true,
+					ForceToken:   otherToken,
+				},
+				ErrClass: &metabase.ErrFailedPrecondition,
+				ErrText:  "force token doesn't match the 2 objects of the bucket",
+			}.Check(ctx, t, db)
+
+			// the token stops being valid when the bucket changes.
+			object3 := metabasetest.CreateObject(ctx, t, db, obj3, 0)
+
+			metabasetest.DeleteBucketObjects{
+				Opts: metabase.DeleteBucketObjects{
+					Bucket:       bucket,
+					RequireEmpty: true,
+					ForceToken:   token,
+				},
+				ErrClass: &metabase.ErrFailedPrecondition,
+				ErrText:  "force token doesn't match the 3 objects of the bucket",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(object1),
+					metabase.RawObject(object2),
+					metabase.RawObject(object3),
+				},
+			}.Check(ctx, t, db)
+
+			token, count, err = db.BucketDeletionForceToken(ctx, bucket)
+			require.NoError(t, err)
+			require.EqualValues(t, 3, count)
+
+			metabasetest.DeleteBucketObjects{
+				Opts: metabase.DeleteBucketObjects{
+					Bucket:       bucket,
+					RequireEmpty: true,
+					ForceToken:   token,
+				},
+				Deleted: 3,
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
 		t.Run("one object", func(t *testing.T) {
 			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
 
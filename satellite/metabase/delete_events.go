@@ -0,0 +1,314 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/shared/dbutil/pgutil"
+	"storj.io/storj/shared/dbutil/spannerutil"
+	"storj.io/storj/shared/tagsql"
+)
+
+// DeleteEventKind is the kind of a recorded object deletion.
+type DeleteEventKind int
+
+const (
+	// DeleteEventRemoved is a removal of an object version by a delete request,
+	// an overwrite or a bucket deletion.
+	DeleteEventRemoved DeleteEventKind = 1
+	// DeleteEventMarkerCreated is a delete marker added by a delete request.
+	DeleteEventMarkerCreated DeleteEventKind = 2
+	// DeleteEventExpired is a removal of an expired object or of an upload
+	// whose zombie deletion deadline passed.
+	DeleteEventExpired DeleteEventKind = 3
+)
+
+// DeleteEvent is an object deletion recorded in the object_delete_events outbox.
+type DeleteEvent struct {
+	ProjectID  uuid.UUID
+	BucketName BucketName
+	ObjectKey  ObjectKey
+	Version    Version
+	StreamID   uuid.UUID
+	Kind       DeleteEventKind
+
+	// TotalPlainSize is the size of the removed object version. It's zero for
+	// the delete markers and the uploads which were never committed.
+	TotalPlainSize int64
+	DeletedAt      time.Time
+}
+
+// DeleteEventsFilter decides which buckets record the deletions of their objects.
+type DeleteEventsFilter interface {
+	// RecordsDeleteEvents returns whether the deletions in the bucket are recorded.
+	RecordsDeleteEvents(ctx context.Context, bucket BucketLocation) (bool, error)
+}
+
+// SetDeleteEventsFilter sets the filter of the buckets whose object deletions
+// are recorded in the object_delete_events outbox. A deletion is recorded in the
+// same transaction as the deletion itself, so no deletion is lost. It must be
+// called before the DB is used.
+func (db *DB) SetDeleteEventsFilter(filter DeleteEventsFilter) {
+	db.deleteEvents = filter
+}
+
+// recordsDeleteEvents returns whether the deletions in the bucket are recorded.
+func (db *DB) recordsDeleteEvents(ctx context.Context, bucket BucketLocation) bool {
+	if db.deleteEvents == nil {
+		return false
+	}
+	record, err := db.deleteEvents.RecordsDeleteEvents(ctx, bucket)
+	if err != nil {
+		// the events of a bucket without a destination are dropped before
+		// they are published, so recording too much is harmless.
+		mon.Counter("delete_events_filter_failures").Inc(1)
+		db.log.Warn("unable to check whether bucket records delete events",
+			zap.Stringer("project", bucket.ProjectID), zap.Stringer("bucket", bucket.BucketName), zap.Error(err))
+		return true
+	}
+	return record
+}
+
+// deleteEventBuckets returns the buckets of the objects which record their deletions.
+func (db *DB) deleteEventBuckets(ctx context.Context, objects []ObjectStream) map[BucketLocation]bool {
+	if db.deleteEvents == nil {
+		return nil
+	}
+	buckets := map[BucketLocation]bool{}
+	for _, object := range objects {
+		bucket := object.Location().Bucket()
+		if _, ok := buckets[bucket]; !ok {
+			buckets[bucket] = db.recordsDeleteEvents(ctx, bucket)
+		}
+	}
+	return buckets
+}
+
+// ListDeleteEvents contains arguments for listing the recorded deletions.
+type ListDeleteEvents struct {
+	Limit int
+}
+
+// ListDeleteEvents lists the oldest recorded deletions.
+func (db *DB) ListDeleteEvents(ctx context.Context, opts ListDeleteEvents) (events []DeleteEvent, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if opts.Limit < 0 {
+		return nil, ErrInvalidRequest.New("Invalid limit: %d", opts.Limit)
+	}
+	ListLimit.Ensure(&opts.Limit)
+
+	for _, adapter := range db.adapters {
+		adapterEvents, err := adapter.ListDeleteEvents(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, adapterEvents...)
+	}
+
+	sort.SliceStable(events, func(i, k int) bool {
+		return events[i].DeletedAt.Before(events[k].DeletedAt)
+	})
+	if len(events) > opts.Limit {
+		events = events[:opts.Limit]
+	}
+	return events, nil
+}
+
+// RemoveDeleteEvents removes the recorded deletions after they were handed over.
+func (db *DB) RemoveDeleteEvents(ctx context.Context, events []DeleteEvent) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	byAdapter := map[Adapter][]DeleteEvent{}
+	for _, event := range events {
+		adapter := db.ChooseAdapter(event.ProjectID)
+		byAdapter[adapter] = append(byAdapter[adapter], event)
+	}
+	for adapter, events := range byAdapter {
+		if err := adapter.RemoveDeleteEvents(ctx, events); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListDeleteEvents lists the oldest recorded deletions.
+func (p *PostgresAdapter) ListDeleteEvents(ctx context.Context, opts ListDeleteEvents) (events []DeleteEvent, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = withRows(p.db.QueryContext(ctx, `
+		SELECT project_id, bucket_name, object_key, version, stream_id, kind, total_plain_size, deleted_at
+		FROM object_delete_events
+		ORDER BY deleted_at ASC
+		LIMIT $1
+	`, opts.Limit))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var event DeleteEvent
+			err := rows.Scan(&event.ProjectID, &event.BucketName, &event.ObjectKey, &event.Version, &event.StreamID,
+				&event.Kind, &event.TotalPlainSize, &event.DeletedAt)
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to list delete events: %w", err)
+	}
+	return events, nil
+}
+
+// ListDeleteEvents lists the oldest recorded deletions.
+func (s *SpannerAdapter) ListDeleteEvents(ctx context.Context, opts ListDeleteEvents) (events []DeleteEvent, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	events, err = spannerutil.CollectRows(s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT project_id, bucket_name, object_key, version, stream_id, kind, total_plain_size, deleted_at
+			FROM object_delete_events
+			ORDER BY deleted_at ASC
+			LIMIT @limit
+		`,
+		Params: map[string]any{
+			"limit": int64(opts.Limit),
+		},
+	}), func(row *spanner.Row, event *DeleteEvent) error {
+		var kind int64
+		err := row.Columns(&event.ProjectID, &event.BucketName, &event.ObjectKey, &event.Version, &event.StreamID,
+			&kind, &event.TotalPlainSize, &event.DeletedAt)
+		event.Kind = DeleteEventKind(kind)
+		return err
+	})
+	if err != nil {
+		return nil, Error.New("unable to list delete events: %w", err)
+	}
+	return events, nil
+}
+
+// RemoveDeleteEvents removes the recorded deletions.
+func (p *PostgresAdapter) RemoveDeleteEvents(ctx context.Context, events []DeleteEvent) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	var projectIDs []uuid.UUID
+	var bucketNames, objectKeys [][]byte
+	var versions, kinds []int64
+	var streamIDs []uuid.UUID
+	for _, event := range events {
+		projectIDs = append(projectIDs, event.ProjectID)
+		bucketNames = append(bucketNames, []byte(event.BucketName))
+		objectKeys = append(objectKeys, []byte(event.ObjectKey))
+		versions = append(versions, int64(event.Version))
+		streamIDs = append(streamIDs, event.StreamID)
+		kinds = append(kinds, int64(event.Kind))
+	}
+
+	_, err = p.db.ExecContext(ctx, `
+		DELETE FROM object_delete_events
+		WHERE (project_id, bucket_name, object_key, version, stream_id, kind) IN (
+			SELECT unnest($1::BYTEA[]), unnest($2::BYTEA[]), unnest($3::BYTEA[]),
+				unnest($4::INT8[]), unnest($5::BYTEA[]), unnest($6::INT8[])
+		)
+	`, pgutil.UUIDArray(projectIDs), pgutil.ByteaArray(bucketNames), pgutil.ByteaArray(objectKeys),
+		pgutil.Int8Array(versions), pgutil.UUIDArray(streamIDs), pgutil.Int8Array(kinds))
+	if err != nil {
+		return Error.New("unable to remove delete events: %w", err)
+	}
+	return nil
+}
+
+// RemoveDeleteEvents removes the recorded deletions.
+func (s *SpannerAdapter) RemoveDeleteEvents(ctx context.Context, events []DeleteEvent) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	mutations := make([]*spanner.Mutation, 0, len(events))
+	for _, event := range events {
+		mutations = append(mutations, spanner.Delete("object_delete_events", spanner.Key{
+			event.ProjectID.Bytes(), event.BucketName.String(), []byte(event.ObjectKey),
+			int64(event.Version), event.StreamID.Bytes(), int64(event.Kind),
+		}))
+	}
+	_, err = s.client.Apply(ctx, mutations)
+	if err != nil {
+		return Error.New("unable to remove delete events: %w", err)
+	}
+	return nil
+}
+
+// postgresDeleteEventsQuery returns the CTE recording the rows of the from CTE in the
+// object_delete_events outbox. The from CTE must return the version and the stream_id
+// of the objects, the other arguments are the expressions of the remaining columns.
+func postgresDeleteEventsQuery(kind DeleteEventKind, from, projectID, bucketName, objectKey, totalPlainSize string) string {
+	return `, recorded_delete_events AS (
+				INSERT INTO object_delete_events (project_id, bucket_name, object_key, version, stream_id, kind, total_plain_size)
+				SELECT ` + projectID + `, ` + bucketName + `, ` + objectKey + `, ` + from + `.version, ` + from + `.stream_id, ` +
+		strconv.Itoa(int(kind)) + `, ` + totalPlainSize + ` FROM ` + from + `
+				RETURNING object_delete_events.stream_id
+			)`
+}
+
+// insertDeleteEvents records the deletion of the objects within the transaction.
+func (ptx *postgresTransactionAdapter) insertDeleteEvents(ctx context.Context, kind DeleteEventKind, objects []Object) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for _, object := range objects {
+		_, err := ptx.tx.ExecContext(ctx, `
+			INSERT INTO object_delete_events (project_id, bucket_name, object_key, version, stream_id, kind, total_plain_size)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, object.ProjectID, object.BucketName, object.ObjectKey, object.Version, object.StreamID, int(kind), object.TotalPlainSize)
+		if err != nil {
+			return Error.New("unable to record delete event: %w", err)
+		}
+	}
+	return nil
+}
+
+// insertDeleteEvents records the deletion of the objects within the transaction.
+func (stx *spannerTransactionAdapter) insertDeleteEvents(ctx context.Context, kind DeleteEventKind, objects []Object) error {
+	return insertDeleteEventsSpanner(stx.tx, kind, objects)
+}
+
+// insertDeleteEventsSpanner records the deletion of the objects within the transaction.
+func insertDeleteEventsSpanner(tx *spanner.ReadWriteTransaction, kind DeleteEventKind, objects []Object) error {
+	if len(objects) == 0 {
+		return nil
+	}
+
+	mutations := make([]*spanner.Mutation, 0, len(objects))
+	for _, object := range objects {
+		mutations = append(mutations, spanner.Insert("object_delete_events",
+			[]string{"project_id", "bucket_name", "object_key", "version", "stream_id", "kind", "total_plain_size"},
+			[]any{object.ProjectID, object.BucketName, object.ObjectKey, object.Version, object.StreamID, int64(kind), object.TotalPlainSize}))
+	}
+	if err := tx.BufferWrite(mutations); err != nil {
+		return Error.New("unable to record delete events: %w", err)
+	}
+	return nil
+}
+
+// spannerDeleteEventsFromObjectsQuery returns the statement recording the objects matched by
+// the appended WHERE clause in the object_delete_events outbox. It must be executed before
+// the objects are deleted, within the same transaction.
+func spannerDeleteEventsFromObjectsQuery(kind DeleteEventKind) string {
+	return `
+		INSERT INTO object_delete_events (project_id, bucket_name, object_key, version, stream_id, kind, total_plain_size)
+		SELECT project_id, bucket_name, object_key, version, stream_id, ` + strconv.Itoa(int(kind)) + `, total_plain_size
+		FROM objects`
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+type bucketDeleteEventsFilter map[metabase.BucketLocation]bool
+
+func (filter bucketDeleteEventsFilter) RecordsDeleteEvents(ctx context.Context, bucket metabase.BucketLocation) (bool, error) {
+	return filter[bucket], nil
+}
+
+func TestDeleteEvents(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		recorded := metabasetest.RandObjectStream()
+		db.SetDeleteEventsFilter(bucketDeleteEventsFilter{recorded.Location().Bucket(): true})
+
+		listEvents := func(t *testing.T) []metabase.DeleteEvent {
+			events, err := db.ListDeleteEvents(ctx, metabase.ListDeleteEvents{Limit: 100})
+			require.NoError(t, err)
+			sort.Slice(events, func(i, k int) bool { return events[i].ObjectKey < events[k].ObjectKey })
+			return events
+		}
+
+		removeEvents := func(t *testing.T, events []metabase.DeleteEvent) {
+			require.NoError(t, db.RemoveDeleteEvents(ctx, events))
+			require.Empty(t, listEvents(t))
+		}
+
+		t.Run("bucket without destination", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			metabasetest.CreateObject(ctx, t, db, obj, 1)
+
+			_, err := db.DeleteObjectLastCommitted(ctx, metabase.DeleteObjectLastCommitted{
+				ObjectLocation: obj.Location(),
+			})
+			require.NoError(t, err)
+			require.Empty(t, listEvents(t))
+		})
+
+		t.Run("removed and marker", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			removed := recorded
+			removed.ObjectKey = "a"
+			removed.StreamID = testrand.UUID()
+			object := metabasetest.CreateObject(ctx, t, db, removed, 1)
+
+			_, err := db.DeleteObjectLastCommitted(ctx, metabase.DeleteObjectLastCommitted{
+				ObjectLocation: removed.Location(),
+			})
+			require.NoError(t, err)
+
+			marked := recorded
+			marked.ObjectKey = "b"
+			marked.StreamID = testrand.UUID()
+			metabasetest.CreateObject(ctx, t, db, marked, 1)
+
+			result, err := db.DeleteObjectLastCommitted(ctx, metabase.DeleteObjectLastCommitted{
+				ObjectLocation: marked.Location(),
+				Versioned:      true,
+			})
+			require.NoError(t, err)
+			require.Len(t, result.Markers, 1)
+
+			events := listEvents(t)
+			require.Len(t, events, 2)
+
+			require.Equal(t, metabase.DeleteEventRemoved, events[0].Kind)
+			require.Equal(t, removed.Location(), metabase.ObjectLocation{
+				ProjectID: events[0].ProjectID, BucketName: events[0].BucketName, ObjectKey: events[0].ObjectKey,
+			})
+			require.Equal(t, removed.Version, events[0].Version)
+			require.Equal(t, removed.StreamID, events[0].StreamID)
+			require.Equal(t, object.TotalPlainSize, events[0].TotalPlainSize)
+			require.WithinDuration(t, time.Now(), events[0].DeletedAt, time.Minute)
+
+			require.Equal(t, metabase.DeleteEventMarkerCreated, events[1].Kind)
+			require.Equal(t, marked.ObjectKey, events[1].ObjectKey)
+			require.Equal(t, result.Markers[0].Version, events[1].Version)
+			require.Equal(t, result.Markers[0].StreamID, events[1].StreamID)
+			require.Zero(t, events[1].TotalPlainSize)
+
+			removeEvents(t, events)
+		})
+
+		t.Run("bucket deletion", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			for _, key := range []metabase.ObjectKey{"a", "b", "c"} {
+				obj := recorded
+				obj.ObjectKey = key
+				obj.StreamID = testrand.UUID()
+				metabasetest.CreateObject(ctx, t, db, obj, 1)
+			}
+
+			_, err := db.DeleteBucketObjects(ctx, metabase.DeleteBucketObjects{
+				Bucket:    recorded.Location().Bucket(),
+				BatchSize: 2,
+			})
+			require.NoError(t, err)
+
+			events := listEvents(t)
+			require.Len(t, events, 3)
+			for i, key := range []metabase.ObjectKey{"a", "b", "c"} {
+				require.Equal(t, metabase.DeleteEventRemoved, events[i].Kind)
+				require.Equal(t, key, events[i].ObjectKey)
+			}
+
+			removeEvents(t, events)
+		})
+
+		t.Run("expired", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := recorded
+			obj.ObjectKey = "expired"
+			obj.StreamID = testrand.UUID()
+			metabasetest.CreateExpiredObject(ctx, t, db, obj, 1, time.Now().Add(-time.Hour))
+
+			require.NoError(t, db.DeleteExpiredObjects(ctx, metabase.DeleteExpiredObjects{
+				ExpiredBefore: time.Now(),
+				BatchSize:     10,
+			}))
+
+			events := listEvents(t)
+			require.Len(t, events, 1)
+			require.Equal(t, metabase.DeleteEventExpired, events[0].Kind)
+			require.Equal(t, obj.ObjectKey, events[0].ObjectKey)
+			require.Equal(t, obj.StreamID, events[0].StreamID)
+
+			removeEvents(t, events)
+		})
+	})
+}
@@ -0,0 +1,247 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+
+	"cloud.google.com/go/spanner"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/shared/dbutil/spannerutil"
+	"storj.io/storj/shared/tagsql"
+)
+
+// ListDeleteMarkersCursor is the position after which ListDeleteMarkers continues listing.
+type ListDeleteMarkersCursor struct {
+	ObjectKey ObjectKey
+	Version   Version
+}
+
+// ListDeleteMarkers contains arguments necessary for listing the delete markers of a bucket.
+//
+// Delete markers are inserted by DeleteObjectLastCommitted instead of removing
+// the object when the bucket has versioning enabled or suspended.
+type ListDeleteMarkers struct {
+	ProjectID  uuid.UUID
+	BucketName BucketName
+	Cursor     ListDeleteMarkersCursor
+	Limit      int
+}
+
+// Verify verifies list delete markers request fields.
+func (opts *ListDeleteMarkers) Verify() error {
+	switch {
+	case opts.ProjectID.IsZero():
+		return ErrInvalidRequest.New("ProjectID missing")
+	case opts.BucketName == "":
+		return ErrInvalidRequest.New("BucketName missing")
+	case opts.Limit < 0:
+		return ErrInvalidRequest.New("Invalid limit: %d", opts.Limit)
+	}
+	return nil
+}
+
+// ListDeleteMarkersResult result of listing delete markers.
+type ListDeleteMarkersResult struct {
+	// Markers are ordered by object key and version.
+	Markers []ObjectEntry
+	More    bool
+}
+
+// ListDeleteMarkers lists the delete markers of the bucket.
+func (db *DB) ListDeleteMarkers(ctx context.Context, opts ListDeleteMarkers) (result ListDeleteMarkersResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return ListDeleteMarkersResult{}, err
+	}
+
+	ListLimit.Ensure(&opts.Limit)
+
+	return db.ChooseAdapter(opts.ProjectID).ListDeleteMarkers(ctx, opts)
+}
+
+// ListDeleteMarkers implements Adapter.
+func (p *PostgresAdapter) ListDeleteMarkers(ctx context.Context, opts ListDeleteMarkers) (result ListDeleteMarkersResult, err error) {
+	err = withRows(p.db.QueryContext(ctx, `
+		SELECT object_key, version, stream_id, status, created_at
+		FROM objects
+		WHERE
+			(project_id, bucket_name) = ($1, $2)
+			AND status IN `+statusesDeleteMarker+`
+			AND (object_key, version) > ($3, $4)
+		ORDER BY object_key ASC, version ASC
+		LIMIT $5
+	`, opts.ProjectID, opts.BucketName, opts.Cursor.ObjectKey, opts.Cursor.Version, opts.Limit+1,
+	))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var entry ObjectEntry
+			err := rows.Scan(&entry.ObjectKey, &entry.Version, &entry.StreamID, &entry.Status, &entry.CreatedAt)
+			if err != nil {
+				return Error.Wrap(err)
+			}
+			result.Markers = append(result.Markers, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return ListDeleteMarkersResult{}, Error.New("unable to list delete markers: %w", err)
+	}
+
+	if len(result.Markers) > opts.Limit {
+		result.More = true
+		result.Markers = result.Markers[:opts.Limit]
+	}
+	return result, nil
+}
+
+// ListDeleteMarkers implements Adapter.
+func (s *SpannerAdapter) ListDeleteMarkers(ctx context.Context, opts ListDeleteMarkers) (result ListDeleteMarkersResult, err error) {
+	result.Markers, err = spannerutil.CollectRows(s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT object_key, version, stream_id, status, created_at
+			FROM objects
+			WHERE
+				project_id = @project_id AND bucket_name = @bucket_name
+				AND status IN ` + statusesDeleteMarker + `
+				AND ` + TupleGreaterThanSQL(
+			[]string{"object_key", "version"},
+			[]string{"@cursor_key", "@cursor_version"},
+			false) + `
+			ORDER BY object_key ASC, version ASC
+			LIMIT @limit
+		`,
+		Params: map[string]any{
+			"project_id":     opts.ProjectID,
+			"bucket_name":    opts.BucketName,
+			"cursor_key":     opts.Cursor.ObjectKey,
+			"cursor_version": opts.Cursor.Version,
+			"limit":          int64(opts.Limit + 1),
+		},
+	}), func(row *spanner.Row, entry *ObjectEntry) error {
+		return Error.Wrap(row.Columns(&entry.ObjectKey, &entry.Version, &entry.StreamID, &entry.Status, &entry.CreatedAt))
+	})
+	if err != nil {
+		return ListDeleteMarkersResult{}, Error.New("unable to list delete markers: %w", err)
+	}
+
+	if len(result.Markers) > opts.Limit {
+		result.More = true
+		result.Markers = result.Markers[:opts.Limit]
+	}
+	return result, nil
+}
+
+// DeleteDeleteMarker contains arguments necessary for permanently removing a delete marker.
+type DeleteDeleteMarker struct {
+	ObjectLocation
+	Version Version
+
+	// recordDeleteEvents records the removal in the object_delete_events outbox.
+	recordDeleteEvents bool
+}
+
+// Verify verifies delete delete marker request fields.
+func (opts *DeleteDeleteMarker) Verify() error {
+	if err := opts.ObjectLocation.Verify(); err != nil {
+		return err
+	}
+	if opts.Version <= 0 {
+		return ErrInvalidRequest.New("Version invalid: %v", opts.Version)
+	}
+	return nil
+}
+
+// DeleteDeleteMarker permanently removes a delete marker. Removing the latest
+// delete marker of an object makes its previous version visible again.
+//
+// It returns ErrObjectNotFound when the version doesn't exist or isn't a delete marker.
+func (db *DB) DeleteDeleteMarker(ctx context.Context, opts DeleteDeleteMarker) (result DeleteObjectResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return DeleteObjectResult{}, err
+	}
+	opts.recordDeleteEvents = db.recordsDeleteEvents(ctx, opts.Bucket())
+
+	result, err = db.ChooseAdapter(opts.ProjectID).DeleteDeleteMarker(ctx, opts)
+	if err != nil {
+		return DeleteObjectResult{}, err
+	}
+	if len(result.Removed) == 0 {
+		return DeleteObjectResult{}, ErrObjectNotFound.Wrap(Error.New("no delete marker found"))
+	}
+
+	mon.Meter("delete_marker_delete").Mark(len(result.Removed))
+
+	return result, nil
+}
+
+// DeleteDeleteMarker implements Adapter.
+func (p *PostgresAdapter) DeleteDeleteMarker(ctx context.Context, opts DeleteDeleteMarker) (result DeleteObjectResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var recorded string
+	if opts.recordDeleteEvents {
+		recorded = postgresDeleteEventsQuery(DeleteEventRemoved, "deleted_objects", "$1", "$2", "$3", "0")
+	}
+
+	// delete markers don't have segments, so there's nothing else to delete.
+	err = withRows(p.db.QueryContext(ctx, `
+		WITH deleted_objects AS (
+			DELETE FROM objects
+			WHERE
+				(project_id, bucket_name, object_key, version) = ($1, $2, $3, $4) AND
+				status IN `+statusesDeleteMarker+`
+			RETURNING
+				version, stream_id, created_at, expires_at, status, segment_count, encrypted_metadata_nonce,
+				encrypted_metadata, encrypted_metadata_encrypted_key, total_plain_size, total_encrypted_size,
+				fixed_segment_size, encryption,
+				retention_mode, retain_until
+		)`+recorded+`
+		SELECT
+			version, stream_id, created_at, expires_at, status, segment_count, encrypted_metadata_nonce,
+			encrypted_metadata, encrypted_metadata_encrypted_key, total_plain_size, total_encrypted_size,
+			fixed_segment_size, encryption,
+			retention_mode, retain_until
+		FROM deleted_objects
+	`, opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.Version))(func(rows tagsql.Rows) error {
+		result.Removed, err = scanObjectDeletionPostgres(ctx, opts.ObjectLocation, rows)
+		return err
+	})
+	return result, err
+}
+
+// DeleteDeleteMarker implements Adapter.
+func (s *SpannerAdapter) DeleteDeleteMarker(ctx context.Context, opts DeleteDeleteMarker) (result DeleteObjectResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// delete markers don't have segments, so there's nothing else to delete.
+	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		result.Removed, err = collectDeletedObjectsSpanner(ctx, opts.ObjectLocation,
+			tx.Query(ctx, spanner.Statement{
+				SQL: `
+					DELETE FROM objects
+					WHERE
+						(project_id, bucket_name, object_key, version) = (@project_id, @bucket_name, @object_key, @version) AND
+						status IN ` + statusesDeleteMarker + `
+					THEN RETURN` + collectDeletedObjectsSpannerFields,
+				Params: map[string]any{
+					"project_id":  opts.ProjectID,
+					"bucket_name": opts.BucketName,
+					"object_key":  opts.ObjectKey,
+					"version":     opts.Version,
+				},
+			}))
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if opts.recordDeleteEvents {
+			return insertDeleteEventsSpanner(tx, DeleteEventRemoved, result.Removed)
+		}
+		return nil
+	})
+	return result, err
+}
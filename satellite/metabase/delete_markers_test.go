@@ -0,0 +1,168 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+	"time"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestDeleteMarkers(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+
+		t.Run("invalid request", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			for _, tc := range []struct {
+				opts    metabase.ListDeleteMarkers
+				errText string
+			}{
+				{opts: metabase.ListDeleteMarkers{}, errText: "ProjectID missing"},
+				{opts: metabase.ListDeleteMarkers{ProjectID: obj.ProjectID}, errText: "BucketName missing"},
+				{opts: metabase.ListDeleteMarkers{ProjectID: obj.ProjectID, BucketName: obj.BucketName, Limit: -1}, errText: "Invalid limit: -1"},
+			} {
+				metabasetest.ListDeleteMarkers{
+					Opts:     tc.opts,
+					ErrClass: &metabase.ErrInvalidRequest,
+					ErrText:  tc.errText,
+				}.Check(ctx, t, db)
+			}
+
+			metabasetest.DeleteDeleteMarker{
+				Opts: metabase.DeleteDeleteMarker{
+					ObjectLocation: obj.Location(),
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "Version invalid: 0",
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("list and remove", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			first := obj
+			first.ObjectKey = "a"
+			first.Version = 1
+			firstObject := metabasetest.CreateObjectVersioned(ctx, t, db, first, 0)
+
+			second := obj
+			second.ObjectKey = "b"
+			second.Version = 1
+			secondObject := metabasetest.CreateObjectVersioned(ctx, t, db, second, 0)
+
+			deleteVersioned := func(object metabase.Object) metabase.Object {
+				result := metabasetest.DeleteObjectLastCommitted{
+					Opts: metabase.DeleteObjectLastCommitted{
+						ObjectLocation: object.Location(),
+						Versioned:      true,
+					},
+					Result: metabase.DeleteObjectResult{
+						Markers: []metabase.Object{
+							{
+								ObjectStream: metabase.ObjectStream{
+									ProjectID:  object.ProjectID,
+									BucketName: object.BucketName,
+									ObjectKey:  object.ObjectKey,
+									Version:    object.Version + 1,
+								},
+								CreatedAt: time.Now(),
+								Status:    metabase.DeleteMarkerVersioned,
+							},
+						},
+					},
+				}.Check(ctx, t, db)
+				return result.Markers[0]
+			}
+
+			firstMarker := deleteVersioned(firstObject)
+			secondMarker := deleteVersioned(secondObject)
+
+			markers := []metabase.ObjectEntry{
+				deleteMarkerEntry(firstMarker),
+				deleteMarkerEntry(secondMarker),
+			}
+
+			metabasetest.ListDeleteMarkers{
+				Opts: metabase.ListDeleteMarkers{
+					ProjectID:  obj.ProjectID,
+					BucketName: obj.BucketName,
+				},
+				Result: metabase.ListDeleteMarkersResult{Markers: markers},
+			}.Check(ctx, t, db)
+
+			// continue from the cursor
+			metabasetest.ListDeleteMarkers{
+				Opts: metabase.ListDeleteMarkers{
+					ProjectID:  obj.ProjectID,
+					BucketName: obj.BucketName,
+					Limit:      1,
+				},
+				Result: metabase.ListDeleteMarkersResult{Markers: markers[:1], More: true},
+			}.Check(ctx, t, db)
+
+			metabasetest.ListDeleteMarkers{
+				Opts: metabase.ListDeleteMarkers{
+					ProjectID:  obj.ProjectID,
+					BucketName: obj.BucketName,
+					Cursor: metabase.ListDeleteMarkersCursor{
+						ObjectKey: firstMarker.ObjectKey,
+						Version:   firstMarker.Version,
+					},
+				},
+				Result: metabase.ListDeleteMarkersResult{Markers: markers[1:]},
+			}.Check(ctx, t, db)
+
+			// objects aren't removed as delete markers
+			metabasetest.DeleteDeleteMarker{
+				Opts: metabase.DeleteDeleteMarker{
+					ObjectLocation: firstObject.Location(),
+					Version:        firstObject.Version,
+				},
+				ErrClass: &metabase.ErrObjectNotFound,
+				ErrText:  "metabase: no delete marker found",
+			}.Check(ctx, t, db)
+
+			metabasetest.DeleteDeleteMarker{
+				Opts: metabase.DeleteDeleteMarker{
+					ObjectLocation: firstMarker.Location(),
+					Version:        firstMarker.Version,
+				},
+				Result: metabase.DeleteObjectResult{
+					Removed: []metabase.Object{firstMarker},
+				},
+			}.Check(ctx, t, db)
+
+			// the previous version is visible again
+			metabasetest.GetObjectLastCommitted{
+				Opts: metabase.GetObjectLastCommitted{
+					ObjectLocation: firstObject.Location(),
+				},
+				Result: firstObject,
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(firstObject),
+					metabase.RawObject(secondObject),
+					metabase.RawObject(secondMarker),
+				},
+			}.Check(ctx, t, db)
+		})
+	})
+}
+
+func deleteMarkerEntry(marker metabase.Object) metabase.ObjectEntry {
+	return metabase.ObjectEntry{
+		ObjectKey: marker.ObjectKey,
+		Version:   marker.Version,
+		StreamID:  marker.StreamID,
+		CreatedAt: marker.CreatedAt,
+		Status:    marker.Status,
+	}
+}
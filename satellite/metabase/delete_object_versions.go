@@ -0,0 +1,514 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+
+	"cloud.google.com/go/spanner"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/shared/dbutil/pgutil"
+	"storj.io/storj/shared/dbutil/txutil"
+	"storj.io/storj/shared/tagsql"
+)
+
+// DeleteObjectVersionsItem identifies an object version to delete.
+type DeleteObjectVersionsItem struct {
+	ObjectKey ObjectKey
+	Version   Version
+}
+
+// DeleteObjectVersions contains arguments necessary for deleting multiple
+// exact object versions from the same bucket.
+type DeleteObjectVersions struct {
+	ProjectID  uuid.UUID
+	BucketName BucketName
+	Items      []DeleteObjectVersionsItem
+
+	// UseObjectLock, if enabled, prevents the deletion of object versions
+	// with active Object Lock configurations.
+	UseObjectLock bool
+
+	// DeletionProtected, if enabled, prevents the deletion because the bucket
+	// has deletion protection enabled.
+	DeletionProtected bool
+
+	// BatchSize is the maximum number of object versions deleted by a single statement.
+	BatchSize int
+
+	// recordDeleteEvents records the deletions in the object_delete_events outbox.
+	recordDeleteEvents bool
+}
+
+// Verify verifies delete object versions request fields.
+func (opts *DeleteObjectVersions) Verify() error {
+	switch {
+	case opts.ProjectID.IsZero():
+		return ErrInvalidRequest.New("ProjectID missing")
+	case opts.BucketName == "":
+		return ErrInvalidRequest.New("BucketName missing")
+	case len(opts.Items) == 0:
+		return ErrInvalidRequest.New("Items missing")
+	case opts.BatchSize < 0:
+		return ErrInvalidRequest.New("BatchSize is negative")
+	}
+
+	// duplicates would share the removed object in the result.
+	seen := make(map[DeleteObjectVersionsItem]struct{}, len(opts.Items))
+	for i, item := range opts.Items {
+		if item.ObjectKey == "" {
+			return ErrInvalidRequest.New("Items[%d].ObjectKey missing", i)
+		}
+		if item.Version <= 0 {
+			return ErrInvalidRequest.New("Items[%d].Version invalid: %v", i, item.Version)
+		}
+		if _, ok := seen[item]; ok {
+			return ErrInvalidRequest.New("Items[%d] is duplicated", i)
+		}
+		seen[item] = struct{}{}
+	}
+	return nil
+}
+
+// DeleteObjectVersionStatus is the outcome of deleting a single object version.
+type DeleteObjectVersionStatus int
+
+const (
+	// DeleteObjectVersionNotFound is used when the object version doesn't exist.
+	DeleteObjectVersionNotFound DeleteObjectVersionStatus = iota
+	// DeleteObjectVersionDeleted is used when the object version was deleted.
+	DeleteObjectVersionDeleted
+	// DeleteObjectVersionLocked is used when the object version wasn't deleted
+	// because of its active Object Lock configuration.
+	DeleteObjectVersionLocked
+)
+
+// DeleteObjectVersionsResultItem is the outcome of deleting a single requested object version.
+type DeleteObjectVersionsResultItem struct {
+	DeleteObjectVersionsItem
+	Status DeleteObjectVersionStatus
+	// Removed is the deleted object when Status is DeleteObjectVersionDeleted.
+	Removed *Object
+}
+
+// DeleteObjectVersionsResult is the result of deleting multiple object versions.
+type DeleteObjectVersionsResult struct {
+	// Items contains an entry for every requested object version, in the requested order.
+	Items []DeleteObjectVersionsResultItem
+}
+
+// DeleteObjectVersionsProgress describes the progress of deleting multiple object versions.
+type DeleteObjectVersionsProgress struct {
+	// Processed is the number of requested items processed so far.
+	// The deletion can be resumed by requesting Items[Processed:].
+	Processed int
+	// Deleted is the number of object versions deleted so far.
+	Deleted int64
+}
+
+// DeleteObjectVersions deletes multiple exact object versions from the same bucket.
+//
+// Unlike DeleteObjectExactVersion, a version which can't be deleted because
+// of its Object Lock configuration doesn't fail the whole request, it's
+// reported in the result instead. The Object Lock configurations are checked
+// within the same transaction as the deletion.
+//
+// The items are deleted in batches of up to opts.BatchSize, each batch in its own
+// transaction. The progress callback, when specified, is called after every batch.
+// The deletion stops when the callback returns an error. In case of error, this
+// method returns the result of the batches processed before the error occurred.
+func (db *DB) DeleteObjectVersions(ctx context.Context, opts DeleteObjectVersions, progress func(DeleteObjectVersionsProgress) error) (result DeleteObjectVersionsResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, done := db.queries.track(ctx, "delete-object-versions", opts.ProjectID)
+	defer done()
+
+	if err := opts.Verify(); err != nil {
+		return DeleteObjectVersionsResult{}, err
+	}
+
+	if opts.DeletionProtected {
+		return DeleteObjectVersionsResult{}, ErrDeletionProtected.New("bucket has deletion protection enabled")
+	}
+
+	deleteBatchsizeLimit.Ensure(&opts.BatchSize)
+	opts.recordDeleteEvents = db.recordsDeleteEvents(ctx, BucketLocation{ProjectID: opts.ProjectID, BucketName: opts.BucketName})
+
+	adapter := db.ChooseAdapter(opts.ProjectID)
+	result.Items = make([]DeleteObjectVersionsResultItem, 0, len(opts.Items))
+
+	var deleted int64
+	for start := 0; start < len(opts.Items); start += opts.BatchSize {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		if err := db.deleteLimiter.check(ctx, opts.ProjectID); err != nil {
+			return result, err
+		}
+
+		end := start + opts.BatchSize
+		if end > len(opts.Items) {
+			end = len(opts.Items)
+		}
+
+		batch := opts
+		batch.Items = opts.Items[start:end]
+
+		items, batchDeleted, err := deleteObjectVersionsBatch(ctx, adapter, batch)
+		if err != nil {
+			return result, err
+		}
+		for _, item := range items {
+			if item.Removed == nil {
+				continue
+			}
+			if err := db.metadataEncryption.decryptMetadata(opts.ProjectID, &item.Removed.EncryptedMetadata, &item.Removed.EncryptedMetadataEncryptedKey); err != nil {
+				return result, err
+			}
+		}
+		result.Items = append(result.Items, items...)
+		deleted += batchDeleted
+		db.deleteLimiter.charge(ctx, opts.ProjectID, batchDeleted)
+
+		if progress != nil {
+			if err := progress(DeleteObjectVersionsProgress{Processed: end, Deleted: deleted}); err != nil {
+				return result, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// deleteObjectVersionsBatch deletes a single batch of object versions and
+// returns the outcome of every item of the batch.
+func deleteObjectVersionsBatch(ctx context.Context, adapter Adapter, opts DeleteObjectVersions) (items []DeleteObjectVersionsResultItem, deleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	removed, lockedItems, err := adapter.DeleteObjectVersions(ctx, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	removedByItem := make(map[DeleteObjectVersionsItem]*Object, len(removed))
+	for i := range removed {
+		object := &removed[i]
+		removedByItem[DeleteObjectVersionsItem{ObjectKey: object.ObjectKey, Version: object.Version}] = object
+	}
+
+	locked := make(map[DeleteObjectVersionsItem]bool, len(lockedItems))
+	for _, item := range lockedItems {
+		locked[item] = true
+	}
+
+	items = make([]DeleteObjectVersionsResultItem, 0, len(opts.Items))
+	for _, item := range opts.Items {
+		resultItem := DeleteObjectVersionsResultItem{DeleteObjectVersionsItem: item}
+		switch object, ok := removedByItem[item]; {
+		case ok:
+			resultItem.Status = DeleteObjectVersionDeleted
+			resultItem.Removed = object
+		case locked[item]:
+			resultItem.Status = DeleteObjectVersionLocked
+		default:
+			resultItem.Status = DeleteObjectVersionNotFound
+		}
+		items = append(items, resultItem)
+	}
+
+	mon.Meter("object_delete").Mark(len(removed))
+	for _, object := range removed {
+		mon.Meter("segment_delete").Mark(int(object.SegmentCount))
+	}
+	return items, int64(len(removed)), nil
+}
+
+func (opts *DeleteObjectVersions) keysAndVersions() (keys [][]byte, versions []int64) {
+	keys = make([][]byte, 0, len(opts.Items))
+	versions = make([]int64, 0, len(opts.Items))
+	for _, item := range opts.Items {
+		keys = append(keys, []byte(item.ObjectKey))
+		versions = append(versions, int64(item.Version))
+	}
+	return keys, versions
+}
+
+// withoutLocked returns the request without the versions whose Object Lock
+// configuration prohibits their deletion, and the locked versions.
+func (opts DeleteObjectVersions) withoutLocked(infos map[DeleteObjectVersionsItem]objectLockInfo) (_ DeleteObjectVersions, locked []DeleteObjectVersionsItem, err error) {
+	items := make([]DeleteObjectVersionsItem, 0, len(opts.Items))
+	for _, item := range opts.Items {
+		if info, ok := infos[item]; ok {
+			if err := info.verifyDeletion(); err != nil {
+				if !ErrObjectLock.Has(err) {
+					return DeleteObjectVersions{}, nil, err
+				}
+				locked = append(locked, item)
+				continue
+			}
+		}
+		items = append(items, item)
+	}
+	opts.Items = items
+	return opts, locked, nil
+}
+
+// postgresQueryer is implemented by both tagsql.DB and tagsql.Tx.
+type postgresQueryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (tagsql.Rows, error)
+}
+
+// DeleteObjectVersions deletes the requested object versions and their segments.
+//
+// When opts.UseObjectLock is set, the requested versions are locked for the
+// duration of the transaction, so their Object Lock configurations can't change
+// between the check and the deletion.
+func (p *PostgresAdapter) DeleteObjectVersions(ctx context.Context, opts DeleteObjectVersions) (removed []Object, locked []DeleteObjectVersionsItem, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !opts.UseObjectLock {
+		removed, err = p.deleteObjectVersions(ctx, p.db, opts)
+		return removed, nil, err
+	}
+
+	err = txutil.WithTx(ctx, p.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		infos, err := p.getObjectVersionsLockInfo(ctx, tx, opts)
+		if err != nil {
+			return err
+		}
+
+		toDelete, lockedItems, err := opts.withoutLocked(infos)
+		if err != nil {
+			return err
+		}
+
+		removed, locked = nil, lockedItems
+		if len(toDelete.Items) == 0 {
+			return nil
+		}
+		removed, err = p.deleteObjectVersions(ctx, tx, toDelete)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return removed, locked, nil
+}
+
+// getObjectVersionsLockInfo returns the Object Lock configurations of the existing requested
+// object versions and locks them until the end of the transaction.
+func (p *PostgresAdapter) getObjectVersionsLockInfo(ctx context.Context, tx tagsql.Tx, opts DeleteObjectVersions) (infos map[DeleteObjectVersionsItem]objectLockInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keys, versions := opts.keysAndVersions()
+
+	infos = make(map[DeleteObjectVersionsItem]objectLockInfo, len(opts.Items))
+	err = withRows(tx.QueryContext(ctx, `
+		SELECT object_key, version, retention_mode, retain_until, legal_hold
+		FROM objects
+		WHERE
+			(project_id, bucket_name) = ($1, $2)
+			AND (object_key, version) IN (SELECT unnest($3::BYTEA[]), unnest($4::INT8[]))
+		FOR UPDATE
+	`, opts.ProjectID, opts.BucketName, pgutil.ByteaArray(keys), pgutil.Int8Array(versions),
+	))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var item DeleteObjectVersionsItem
+			var info objectLockInfo
+			err := rows.Scan(&item.ObjectKey, &item.Version,
+				retentionModeWrapper{&info.Retention.Mode}, timeWrapper{&info.Retention.RetainUntil}, &info.LegalHold)
+			if err != nil {
+				return err
+			}
+			infos[item] = info
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to query object lock configuration: %w", err)
+	}
+	return infos, nil
+}
+
+// deleteObjectVersions deletes the requested object versions and their segments in a single query.
+func (p *PostgresAdapter) deleteObjectVersions(ctx context.Context, db postgresQueryer, opts DeleteObjectVersions) (removed []Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keys, versions := opts.keysAndVersions()
+
+	var recorded string
+	if opts.recordDeleteEvents {
+		recorded = postgresDeleteEventsQuery(DeleteEventRemoved, "deleted_objects", "$1", "$2", "deleted_objects.object_key", "deleted_objects.total_plain_size")
+	}
+
+	err = withRows(db.QueryContext(ctx, `
+		WITH deleted_objects AS (
+			DELETE FROM objects
+			WHERE
+				(project_id, bucket_name) = ($1, $2)
+				AND (object_key, version) IN (SELECT unnest($3::BYTEA[]), unnest($4::INT8[]))
+			RETURNING
+				object_key, version, stream_id, created_at, expires_at, status, segment_count, encrypted_metadata_nonce,
+				encrypted_metadata, encrypted_metadata_encrypted_key, total_plain_size, total_encrypted_size,
+				fixed_segment_size, encryption,
+				retention_mode, retain_until
+		), deleted_segments AS (
+			DELETE FROM segments
+			WHERE segments.stream_id IN (SELECT deleted_objects.stream_id FROM deleted_objects)
+			RETURNING segments.stream_id
+		)`+recorded+`
+		SELECT
+			object_key, version, stream_id, created_at, expires_at, status, segment_count, encrypted_metadata_nonce,
+			encrypted_metadata, encrypted_metadata_encrypted_key, total_plain_size, total_encrypted_size,
+			fixed_segment_size, encryption,
+			retention_mode, retain_until
+		FROM deleted_objects
+	`, opts.ProjectID, opts.BucketName, pgutil.ByteaArray(keys), pgutil.Int8Array(versions),
+	))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			object := Object{}
+			object.ProjectID = opts.ProjectID
+			object.BucketName = opts.BucketName
+
+			err := rows.Scan(&object.ObjectKey, &object.Version, &object.StreamID,
+				&object.CreatedAt, &object.ExpiresAt,
+				&object.Status, &object.SegmentCount,
+				&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
+				&object.TotalPlainSize, &object.TotalEncryptedSize, &object.FixedSegmentSize,
+				encryptionParameters{&object.Encryption},
+				retentionModeWrapper{&object.Retention.Mode}, timeWrapper{&object.Retention.RetainUntil},
+			)
+			if err != nil {
+				return err
+			}
+			removed = append(removed, object)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to delete object versions: %w", err)
+	}
+	return removed, nil
+}
+
+// DeleteObjectVersions deletes the requested object versions and their segments in a single transaction.
+//
+// When opts.UseObjectLock is set, the Object Lock configurations are read within
+// the same transaction, so they can't change between the check and the deletion.
+func (s *SpannerAdapter) DeleteObjectVersions(ctx context.Context, opts DeleteObjectVersions) (removed []Object, locked []DeleteObjectVersionsItem, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		removed, locked = removed[:0], nil
+
+		toDelete := opts
+		if opts.UseObjectLock {
+			infos, err := s.getObjectVersionsLockInfo(ctx, tx, opts)
+			if err != nil {
+				return err
+			}
+			toDelete, locked, err = opts.withoutLocked(infos)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, item := range toDelete.Items {
+			objects, err := collectDeletedObjectsSpanner(ctx,
+				ObjectLocation{ProjectID: opts.ProjectID, BucketName: opts.BucketName, ObjectKey: item.ObjectKey},
+				tx.Query(ctx, spanner.Statement{
+					SQL: `
+						DELETE FROM objects
+						WHERE (project_id, bucket_name, object_key, version) = (@project_id, @bucket_name, @object_key, @version)
+						THEN RETURN` + collectDeletedObjectsSpannerFields,
+					Params: map[string]interface{}{
+						"project_id":  opts.ProjectID,
+						"bucket_name": opts.BucketName,
+						"object_key":  item.ObjectKey,
+						"version":     item.Version,
+					},
+				}))
+			if err != nil {
+				return err
+			}
+			removed = append(removed, objects...)
+		}
+
+		if len(removed) == 0 {
+			return nil
+		}
+
+		if opts.recordDeleteEvents {
+			if err := insertDeleteEventsSpanner(tx, DeleteEventRemoved, removed); err != nil {
+				return err
+			}
+		}
+
+		streamIDs := make([][]byte, 0, len(removed))
+		for _, object := range removed {
+			streamIDs = append(streamIDs, object.StreamID.Bytes())
+		}
+		_, err := tx.Update(ctx, spanner.Statement{
+			SQL: `
+				DELETE FROM segments
+				WHERE ARRAY_INCLUDES(@stream_ids, stream_id)
+			`,
+			Params: map[string]interface{}{
+				"stream_ids": streamIDs,
+			},
+		})
+		return Error.Wrap(err)
+	})
+	if err != nil {
+		return nil, nil, Error.New("unable to delete object versions: %w", err)
+	}
+	return removed, locked, nil
+}
+
+// getObjectVersionsLockInfo returns the Object Lock configurations of the existing requested object versions.
+func (s *SpannerAdapter) getObjectVersionsLockInfo(ctx context.Context, tx *spanner.ReadWriteTransaction, opts DeleteObjectVersions) (infos map[DeleteObjectVersionsItem]objectLockInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keys, _ := opts.keysAndVersions()
+
+	requested := make(map[DeleteObjectVersionsItem]struct{}, len(opts.Items))
+	for _, item := range opts.Items {
+		requested[item] = struct{}{}
+	}
+
+	infos = make(map[DeleteObjectVersionsItem]objectLockInfo, len(opts.Items))
+	err = tx.Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT object_key, version, retention_mode, retain_until, legal_hold
+			FROM objects
+			WHERE
+				project_id = @project_id AND bucket_name = @bucket_name
+				AND object_key IN UNNEST(@object_keys)
+		`,
+		Params: map[string]any{
+			"project_id":  opts.ProjectID,
+			"bucket_name": opts.BucketName,
+			"object_keys": keys,
+		},
+	}).Do(func(row *spanner.Row) error {
+		var item DeleteObjectVersionsItem
+		var info objectLockInfo
+		err := row.Columns(&item.ObjectKey, &item.Version,
+			retentionModeWrapper{&info.Retention.Mode}, timeWrapper{&info.Retention.RetainUntil}, &info.LegalHold)
+		if err != nil {
+			return errs.Wrap(err)
+		}
+		// the query returns all versions of the requested keys.
+		if _, ok := requested[item]; ok {
+			infos[item] = info
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to query object lock configuration: %w", err)
+	}
+	return infos, nil
+}
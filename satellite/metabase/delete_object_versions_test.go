@@ -0,0 +1,364 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestDeleteObjectVersions(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+
+		t.Run("invalid request", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			for _, test := range []struct {
+				opts    metabase.DeleteObjectVersions
+				errText string
+			}{
+				{
+					opts:    metabase.DeleteObjectVersions{BucketName: obj.BucketName},
+					errText: "ProjectID missing",
+				},
+				{
+					opts:    metabase.DeleteObjectVersions{ProjectID: obj.ProjectID},
+					errText: "BucketName missing",
+				},
+				{
+					opts:    metabase.DeleteObjectVersions{ProjectID: obj.ProjectID, BucketName: obj.BucketName},
+					errText: "Items missing",
+				},
+				{
+					opts: metabase.DeleteObjectVersions{
+						ProjectID:  obj.ProjectID,
+						BucketName: obj.BucketName,
+						Items:      []metabase.DeleteObjectVersionsItem{{ObjectKey: obj.ObjectKey, Version: 1}},
+						BatchSize:  -1,
+					},
+					errText: "BatchSize is negative",
+				},
+				{
+					opts: metabase.DeleteObjectVersions{
+						ProjectID:  obj.ProjectID,
+						BucketName: obj.BucketName,
+						Items:      []metabase.DeleteObjectVersionsItem{{Version: 1}},
+					},
+					errText: "Items[0].ObjectKey missing",
+				},
+				{
+					opts: metabase.DeleteObjectVersions{
+						ProjectID:  obj.ProjectID,
+						BucketName: obj.BucketName,
+						Items: []metabase.DeleteObjectVersionsItem{
+							{ObjectKey: obj.ObjectKey, Version: 1},
+							{ObjectKey: obj.ObjectKey, Version: 2},
+							{ObjectKey: obj.ObjectKey, Version: 1},
+						},
+					},
+					errText: "Items[2] is duplicated",
+				},
+				{
+					opts: metabase.DeleteObjectVersions{
+						ProjectID:  obj.ProjectID,
+						BucketName: obj.BucketName,
+						Items:      []metabase.DeleteObjectVersionsItem{{ObjectKey: obj.ObjectKey}},
+					},
+					errText: "Items[0].Version invalid: 0",
+				},
+			} {
+				metabasetest.DeleteObjectVersions{
+					Opts:     test.opts,
+					ErrClass: &metabase.ErrInvalidRequest,
+					ErrText:  test.errText,
+				}.Check(ctx, t, db)
+			}
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("found and missing versions", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			first := obj
+			first.ObjectKey = metabasetest.RandObjectKey()
+			first.StreamID = testrand.UUID()
+			firstObject := metabasetest.CreateObjectVersioned(ctx, t, db, first, 2)
+
+			second := first
+			second.Version++
+			second.StreamID = testrand.UUID()
+			secondObject := metabasetest.CreateObjectVersioned(ctx, t, db, second, 1)
+
+			other := obj
+			other.ObjectKey = metabasetest.RandObjectKey()
+			other.StreamID = testrand.UUID()
+			otherObject := metabasetest.CreateObjectVersioned(ctx, t, db, other, 3)
+
+			missing := metabase.DeleteObjectVersionsItem{ObjectKey: other.ObjectKey, Version: other.Version + 10}
+
+			metabasetest.DeleteObjectVersions{
+				Opts: metabase.DeleteObjectVersions{
+					ProjectID:  obj.ProjectID,
+					BucketName: obj.BucketName,
+					Items: []metabase.DeleteObjectVersionsItem{
+						{ObjectKey: first.ObjectKey, Version: first.Version},
+						missing,
+						{ObjectKey: other.ObjectKey, Version: other.Version},
+					},
+				},
+				Result: metabase.DeleteObjectVersionsResult{
+					Items: []metabase.DeleteObjectVersionsResultItem{
+						{
+							DeleteObjectVersionsItem: metabase.DeleteObjectVersionsItem{ObjectKey: first.ObjectKey, Version: first.Version},
+							Status:                   metabase.DeleteObjectVersionDeleted,
+							Removed:                  &firstObject,
+						},
+						{
+							DeleteObjectVersionsItem: missing,
+							Status:                   metabase.DeleteObjectVersionNotFound,
+						},
+						{
+							DeleteObjectVersionsItem: metabase.DeleteObjectVersionsItem{ObjectKey: other.ObjectKey, Version: other.Version},
+							Status:                   metabase.DeleteObjectVersionDeleted,
+							Removed:                  &otherObject,
+						},
+					},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{metabase.RawObject(secondObject)},
+				Segments: []metabase.RawSegment{
+					metabasetest.DefaultRawSegment(second, metabase.SegmentPosition{}),
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("server-side copies", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			original := obj
+			original.ObjectKey = metabasetest.RandObjectKey()
+			original.StreamID = testrand.UUID()
+			originalObject, _ := metabasetest.CreateTestObject{}.Run(ctx, t, db, original, 2)
+
+			copyStream := metabasetest.RandObjectStream()
+			copyStream.ProjectID = obj.ProjectID
+			copyStream.BucketName = obj.BucketName
+			copyObject, _, copySegments := metabasetest.CreateObjectCopy{
+				OriginalObject:   originalObject,
+				CopyObjectStream: &copyStream,
+			}.Run(ctx, t, db)
+
+			originalItem := metabase.DeleteObjectVersionsItem{ObjectKey: originalObject.ObjectKey, Version: originalObject.Version}
+
+			metabasetest.DeleteObjectVersions{
+				Opts: metabase.DeleteObjectVersions{
+					ProjectID:  obj.ProjectID,
+					BucketName: obj.BucketName,
+					Items:      []metabase.DeleteObjectVersionsItem{originalItem},
+				},
+				Result: metabase.DeleteObjectVersionsResult{
+					Items: []metabase.DeleteObjectVersionsResultItem{
+						{
+							DeleteObjectVersionsItem: originalItem,
+							Status:                   metabase.DeleteObjectVersionDeleted,
+							Removed:                  &originalObject,
+						},
+					},
+				},
+			}.Check(ctx, t, db)
+
+			// the copy keeps its own segments.
+			metabasetest.Verify{
+				Objects:  []metabase.RawObject{metabase.RawObject(copyObject)},
+				Segments: copySegments,
+			}.Check(ctx, t, db)
+
+			copyItem := metabase.DeleteObjectVersionsItem{ObjectKey: copyObject.ObjectKey, Version: copyObject.Version}
+
+			metabasetest.DeleteObjectVersions{
+				Opts: metabase.DeleteObjectVersions{
+					ProjectID:  obj.ProjectID,
+					BucketName: obj.BucketName,
+					Items:      []metabase.DeleteObjectVersionsItem{copyItem},
+				},
+				Result: metabase.DeleteObjectVersionsResult{
+					Items: []metabase.DeleteObjectVersionsResultItem{
+						{
+							DeleteObjectVersionsItem: copyItem,
+							Status:                   metabase.DeleteObjectVersionDeleted,
+							Removed:                  &copyObject,
+						},
+					},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("object lock", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			lockedStream := obj
+			lockedStream.ObjectKey = metabasetest.RandObjectKey()
+			locked, lockedSegments := metabasetest.CreateObjectWithRetention(ctx, t, db, lockedStream, 1, time.Now().Add(time.Hour))
+
+			expiredStream := obj
+			expiredStream.ObjectKey = metabasetest.RandObjectKey()
+			expiredStream.StreamID = testrand.UUID()
+			expired, _ := metabasetest.CreateObjectWithRetention(ctx, t, db, expiredStream, 1, time.Now().Add(-time.Minute))
+
+			lockedItem := metabase.DeleteObjectVersionsItem{ObjectKey: locked.ObjectKey, Version: locked.Version}
+			expiredItem := metabase.DeleteObjectVersionsItem{ObjectKey: expired.ObjectKey, Version: expired.Version}
+
+			metabasetest.DeleteObjectVersions{
+				Opts: metabase.DeleteObjectVersions{
+					ProjectID:     obj.ProjectID,
+					BucketName:    obj.BucketName,
+					Items:         []metabase.DeleteObjectVersionsItem{lockedItem, expiredItem},
+					UseObjectLock: true,
+				},
+				Result: metabase.DeleteObjectVersionsResult{
+					Items: []metabase.DeleteObjectVersionsResultItem{
+						{
+							DeleteObjectVersionsItem: lockedItem,
+							Status:                   metabase.DeleteObjectVersionLocked,
+						},
+						{
+							DeleteObjectVersionsItem: expiredItem,
+							Status:                   metabase.DeleteObjectVersionDeleted,
+							Removed:                  &expired,
+						},
+					},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects:  []metabase.RawObject{metabase.RawObject(locked)},
+				Segments: metabasetest.SegmentsToRaw(lockedSegments),
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("deletion protection", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.CreateObject(ctx, t, db, obj, 1)
+
+			before, err := db.TestingGetState(ctx)
+			require.NoError(t, err)
+
+			metabasetest.DeleteObjectVersions{
+				Opts: metabase.DeleteObjectVersions{
+					ProjectID:         obj.ProjectID,
+					BucketName:        obj.BucketName,
+					Items:             []metabase.DeleteObjectVersionsItem{{ObjectKey: obj.ObjectKey, Version: obj.Version}},
+					DeletionProtected: true,
+				},
+				ErrClass: &metabase.ErrDeletionProtected,
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify(*before).Check(ctx, t, db)
+		})
+
+		t.Run("batches", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			var items []metabase.DeleteObjectVersionsItem
+			for i := 0; i < 5; i++ {
+				stream := obj
+				stream.ObjectKey = metabasetest.RandObjectKey()
+				stream.StreamID = testrand.UUID()
+				object := metabasetest.CreateObject(ctx, t, db, stream, 0)
+				items = append(items, metabase.DeleteObjectVersionsItem{ObjectKey: object.ObjectKey, Version: object.Version})
+			}
+			// more than the default batch size, none of them exist.
+			for i := 0; i < 1500; i++ {
+				items = append(items, metabase.DeleteObjectVersionsItem{ObjectKey: metabasetest.RandObjectKey(), Version: 1})
+			}
+
+			var progress []metabase.DeleteObjectVersionsProgress
+			result, err := db.DeleteObjectVersions(ctx, metabase.DeleteObjectVersions{
+				ProjectID:  obj.ProjectID,
+				BucketName: obj.BucketName,
+				Items:      items,
+				BatchSize:  1000,
+			}, func(p metabase.DeleteObjectVersionsProgress) error {
+				progress = append(progress, p)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Len(t, result.Items, len(items))
+			for i, item := range result.Items {
+				require.Equal(t, items[i], item.DeleteObjectVersionsItem)
+				if i < 5 {
+					require.Equal(t, metabase.DeleteObjectVersionDeleted, item.Status)
+				} else {
+					require.Equal(t, metabase.DeleteObjectVersionNotFound, item.Status)
+				}
+			}
+			require.Equal(t, []metabase.DeleteObjectVersionsProgress{
+				{Processed: 1000, Deleted: 5},
+				{Processed: 1505, Deleted: 5},
+			}, progress)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("stop by progress", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			var items []metabase.DeleteObjectVersionsItem
+			var objects []metabase.Object
+			for i := 0; i < 3; i++ {
+				stream := obj
+				stream.ObjectKey = metabasetest.RandObjectKey()
+				stream.StreamID = testrand.UUID()
+				object := metabasetest.CreateObject(ctx, t, db, stream, 0)
+				objects = append(objects, object)
+				items = append(items, metabase.DeleteObjectVersionsItem{ObjectKey: object.ObjectKey, Version: object.Version})
+			}
+
+			stop := errs.New("stop")
+			result, err := db.DeleteObjectVersions(ctx, metabase.DeleteObjectVersions{
+				ProjectID:  obj.ProjectID,
+				BucketName: obj.BucketName,
+				Items:      items,
+				BatchSize:  2,
+			}, func(p metabase.DeleteObjectVersionsProgress) error {
+				require.Equal(t, metabase.DeleteObjectVersionsProgress{Processed: 2, Deleted: 2}, p)
+				return stop
+			})
+			require.ErrorIs(t, err, stop)
+			require.Len(t, result.Items, 2)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{metabase.RawObject(objects[2])},
+			}.Check(ctx, t, db)
+
+			// resume the deletion with the remaining items.
+			result, err = db.DeleteObjectVersions(ctx, metabase.DeleteObjectVersions{
+				ProjectID:  obj.ProjectID,
+				BucketName: obj.BucketName,
+				Items:      items[2:],
+				BatchSize:  2,
+			}, nil)
+			require.NoError(t, err)
+			require.Len(t, result.Items, 1)
+			require.Equal(t, metabase.DeleteObjectVersionDeleted, result.Items[0].Status)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+	})
+}
@@ -7,13 +7,16 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/spanner"
 	"github.com/jackc/pgx/v5"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 
+	"storj.io/common/uuid"
 	"storj.io/storj/shared/dbutil/pgxutil"
 	"storj.io/storj/shared/dbutil/spannerutil"
 	"storj.io/storj/shared/tagsql"
@@ -28,14 +31,30 @@ type DeleteExpiredObjects struct {
 	ExpiredBefore      time.Time
 	AsOfSystemInterval time.Duration
 	BatchSize          int
+
+	// MaxBatchSize is the upper bound of the batch size when the batch size
+	// is adjusted. When it's not set, BatchSize is used.
+	MaxBatchSize int
+	// TargetBatchDuration is the expected duration of querying and deleting
+	// a single batch. When set, the batch size is doubled while the batches
+	// take less than half of the target and halved when they take longer.
+	TargetBatchDuration time.Duration
+	// DeleteConcurrency is the number of projects whose expired objects are
+	// deleted concurrently within a batch.
+	DeleteConcurrency int
 }
 
 // DeleteExpiredObjects deletes all objects that expired before expiredBefore.
 func (db *DB) DeleteExpiredObjects(ctx context.Context, opts DeleteExpiredObjects) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	ctx, done := db.queries.track(ctx, "delete-expired-objects", uuid.UUID{})
+	defer done()
+
 	for _, a := range db.adapters {
-		err = db.deleteObjectsAndSegmentsBatch(ctx, opts.BatchSize, func(startAfter ObjectStream, batchsize int) (last ObjectStream, err error) {
+		var found int64
+		batchSize := newBatchSizer(opts.BatchSize, opts.MaxBatchSize, opts.TargetBatchDuration)
+		err = db.deleteObjectsAndSegmentsBatch(ctx, batchSize, func(startAfter ObjectStream, batchsize int) (last ObjectStream, err error) {
 			expiredObjects, err := a.FindExpiredObjects(ctx, opts, startAfter, batchsize)
 			if err != nil {
 				return ObjectStream{}, Error.New("unable to select expired objects for deletion: %w", err)
@@ -44,14 +63,17 @@ func (db *DB) DeleteExpiredObjects(ctx context.Context, opts DeleteExpiredObject
 			if len(expiredObjects) == 0 {
 				return ObjectStream{}, nil
 			}
+			found += int64(len(expiredObjects))
 
-			objectsDeleted, segmentsDeleted, err := a.DeleteObjectsAndSegments(ctx, expiredObjects)
+			recordDeleteEvents := db.deleteEventBuckets(ctx, expiredObjects)
+			objectsDeleted, segmentsDeleted, err := deleteObjectsAndSegmentsPerProject(ctx, a, expiredObjects, recordDeleteEvents, opts.DeleteConcurrency)
 
 			mon.Meter("object_delete").Mark64(objectsDeleted)
 			mon.Meter("segment_delete").Mark64(segmentsDeleted)
 
 			return expiredObjects[len(expiredObjects)-1], err
 		})
+		mon.IntVal("expired_objects_found").Observe(found)
 		if err != nil {
 			db.log.Error("failed to delete expired objects from DB", zap.Error(err), zap.String("adapter", fmt.Sprintf("%T", a)))
 		}
@@ -59,6 +81,47 @@ func (db *DB) DeleteExpiredObjects(ctx context.Context, opts DeleteExpiredObject
 	return nil
 }
 
+// deleteObjectsAndSegmentsPerProject deletes the objects of different projects
+// concurrently, deleting the objects of at most concurrency projects at a time.
+func deleteObjectsAndSegmentsPerProject(ctx context.Context, a Adapter, objects []ObjectStream, recordDeleteEvents map[BucketLocation]bool, concurrency int) (objectsDeleted, segmentsDeleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if concurrency <= 1 {
+		return a.DeleteObjectsAndSegments(ctx, objects, recordDeleteEvents)
+	}
+
+	// objects are sorted by project, so the objects of a project are next to each other.
+	var projects [][]ObjectStream
+	for i := 0; i < len(objects); {
+		end := i + 1
+		for end < len(objects) && objects[end].ProjectID == objects[i].ProjectID {
+			end++
+		}
+		projects = append(projects, objects[i:end])
+		i = end
+	}
+
+	var mu sync.Mutex
+	var group errgroup.Group
+	group.SetLimit(concurrency)
+	for _, projectObjects := range projects {
+		projectObjects := projectObjects
+		group.Go(func() error {
+			objects, segments, err := a.DeleteObjectsAndSegments(ctx, projectObjects, recordDeleteEvents)
+
+			mu.Lock()
+			objectsDeleted += objects
+			segmentsDeleted += segments
+			mu.Unlock()
+
+			return err
+		})
+	}
+	err = group.Wait()
+
+	return objectsDeleted, segmentsDeleted, err
+}
+
 // FindExpiredObjects finds up to batchSize objects that expired before opts.ExpiredBefore.
 func (p *PostgresAdapter) FindExpiredObjects(ctx context.Context, opts DeleteExpiredObjects, startAfter ObjectStream, batchSize int) (expiredObjects []ObjectStream, err error) {
 	query := `
@@ -167,6 +230,62 @@ type DeleteZombieObjects struct {
 	InactiveDeadline   time.Time
 	AsOfSystemInterval time.Duration
 	BatchSize          int
+
+	// Overrides replace the inactivity period of the pending objects in
+	// specific projects and buckets.
+	Overrides []ZombieDeletionOverride
+
+	// requireInactiveObject additionally requires the object to be created
+	// before InactiveDeadline, not only its segments.
+	requireInactiveObject bool
+	// recordDeleteEvents contains the buckets whose deletions are recorded
+	// in the object_delete_events outbox.
+	recordDeleteEvents map[BucketLocation]bool
+}
+
+// ZombieDeletionOverride is the inactivity period after which the pending
+// objects of a project or a bucket are deleted.
+//
+// The pending objects of an overridden scope are deleted only when both the
+// object and all of its segments were created more than InactiveFor before
+// DeadlineBefore, which allows keeping multipart uploads pending for longer
+// than the default zombie deletion deadline.
+type ZombieDeletionOverride struct {
+	ProjectID uuid.UUID
+	// BucketName is empty when the override applies to the whole project.
+	BucketName  BucketName
+	InactiveFor time.Duration
+}
+
+// zombieDeletionOverrides finds the override of a bucket. A bucket override
+// takes precedence over the override of its project.
+type zombieDeletionOverrides struct {
+	projects map[uuid.UUID]time.Duration
+	buckets  map[BucketLocation]time.Duration
+}
+
+func newZombieDeletionOverrides(overrides []ZombieDeletionOverride) zombieDeletionOverrides {
+	result := zombieDeletionOverrides{
+		projects: map[uuid.UUID]time.Duration{},
+		buckets:  map[BucketLocation]time.Duration{},
+	}
+	for _, override := range overrides {
+		if override.BucketName == "" {
+			result.projects[override.ProjectID] = override.InactiveFor
+			continue
+		}
+		result.buckets[BucketLocation{ProjectID: override.ProjectID, BucketName: override.BucketName}] = override.InactiveFor
+	}
+	return result
+}
+
+// inactiveFor returns the inactivity period of the bucket, if it's overridden.
+func (overrides zombieDeletionOverrides) inactiveFor(projectID uuid.UUID, bucketName BucketName) (time.Duration, bool) {
+	if inactiveFor, ok := overrides.buckets[BucketLocation{ProjectID: projectID, BucketName: bucketName}]; ok {
+		return inactiveFor, true
+	}
+	inactiveFor, ok := overrides.projects[projectID]
+	return inactiveFor, ok
 }
 
 // DeleteZombieObjects deletes all objects that zombie deletion deadline passed.
@@ -174,8 +293,13 @@ type DeleteZombieObjects struct {
 func (db *DB) DeleteZombieObjects(ctx context.Context, opts DeleteZombieObjects) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	ctx, done := db.queries.track(ctx, "delete-zombie-objects", uuid.UUID{})
+	defer done()
+
+	overrides := newZombieDeletionOverrides(opts.Overrides)
+
 	for _, a := range db.adapters {
-		err = db.deleteObjectsAndSegmentsBatch(ctx, opts.BatchSize, func(startAfter ObjectStream, batchsize int) (last ObjectStream, err error) {
+		err = db.deleteObjectsAndSegmentsBatch(ctx, newBatchSizer(opts.BatchSize, 0, 0), func(startAfter ObjectStream, batchsize int) (last ObjectStream, err error) {
 			objects, err := a.FindZombieObjects(ctx, opts, startAfter, batchsize)
 			if err != nil {
 				return ObjectStream{}, Error.Wrap(err)
@@ -184,11 +308,40 @@ func (db *DB) DeleteZombieObjects(ctx context.Context, opts DeleteZombieObjects)
 			if len(objects) == 0 {
 				return ObjectStream{}, nil
 			}
-			objectsDeleted, segmentsDeleted, err := a.DeleteInactiveObjectsAndSegments(ctx, objects, opts)
+
+			batchOpts := opts
+			batchOpts.recordDeleteEvents = db.deleteEventBuckets(ctx, objects)
+
+			// the objects of the overridden buckets are deleted separately
+			// for every inactivity period.
+			byInactiveFor := map[time.Duration][]ObjectStream{}
+			defaults := make([]ObjectStream, 0, len(objects))
+			for _, object := range objects {
+				if inactiveFor, ok := overrides.inactiveFor(object.ProjectID, object.BucketName); ok {
+					byInactiveFor[inactiveFor] = append(byInactiveFor[inactiveFor], object)
+					continue
+				}
+				defaults = append(defaults, object)
+			}
+
+			objectsDeleted, segmentsDeleted, err := a.DeleteInactiveObjectsAndSegments(ctx, defaults, batchOpts)
 			if err != nil {
 				return ObjectStream{}, Error.Wrap(err)
 			}
 
+			for inactiveFor, overridden := range byInactiveFor {
+				overrideOpts := batchOpts
+				overrideOpts.InactiveDeadline = opts.DeadlineBefore.Add(-inactiveFor)
+				overrideOpts.requireInactiveObject = true
+
+				overriddenObjects, overriddenSegments, err := a.DeleteInactiveObjectsAndSegments(ctx, overridden, overrideOpts)
+				if err != nil {
+					return ObjectStream{}, Error.Wrap(err)
+				}
+				objectsDeleted += overriddenObjects
+				segmentsDeleted += overriddenSegments
+			}
+
 			mon.Meter("zombie_object_delete").Mark64(objectsDeleted)
 			mon.Meter("object_delete").Mark64(objectsDeleted)
 			mon.Meter("zombie_segment_delete").Mark64(segmentsDeleted)
@@ -301,14 +454,13 @@ func (s *SpannerAdapter) FindZombieObjects(ctx context.Context, opts DeleteZombi
 	return objects, nil
 }
 
-func (db *DB) deleteObjectsAndSegmentsBatch(ctx context.Context, batchsize int, deleteBatch func(startAfter ObjectStream, batchsize int) (last ObjectStream, err error)) (err error) {
+func (db *DB) deleteObjectsAndSegmentsBatch(ctx context.Context, batchSize *batchSizer, deleteBatch func(startAfter ObjectStream, batchsize int) (last ObjectStream, err error)) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	deleteBatchsizeLimit.Ensure(&batchsize)
-
 	var startAfter ObjectStream
 	for {
-		lastDeleted, err := deleteBatch(startAfter, batchsize)
+		start := time.Now()
+		lastDeleted, err := deleteBatch(startAfter, batchSize.Size())
 		if err != nil {
 			return err
 		}
@@ -316,11 +468,62 @@ func (db *DB) deleteObjectsAndSegmentsBatch(ctx context.Context, batchsize int,
 			return nil
 		}
 		startAfter = lastDeleted
+
+		batchSize.Observe(time.Since(start))
 	}
 }
 
-// DeleteObjectsAndSegments deletes expired objects and associated segments.
-func (p *PostgresAdapter) DeleteObjectsAndSegments(ctx context.Context, objects []ObjectStream) (objectsDeleted, segmentsDeleted int64, err error) {
+// batchSizer adjusts the size of the deletion batches based on how long the
+// previous batches took, so the deletion neither falls behind on a fast
+// database nor overloads a slow one.
+type batchSizer struct {
+	size   int
+	max    int
+	target time.Duration
+}
+
+// newBatchSizer creates a batch sizer starting with the initial batch size.
+// The batch size is fixed when target is zero or maxSize isn't larger than initial.
+func newBatchSizer(initial, maxSize int, target time.Duration) *batchSizer {
+	deleteBatchsizeLimit.Ensure(&initial)
+	if maxSize < initial {
+		maxSize = initial
+	}
+	if maxSize > deleteBatchsizeLimit.Max() {
+		maxSize = deleteBatchsizeLimit.Max()
+	}
+	return &batchSizer{
+		size:   initial,
+		max:    maxSize,
+		target: target,
+	}
+}
+
+// Size returns the size of the next batch.
+func (b *batchSizer) Size() int { return b.size }
+
+// Observe adjusts the batch size based on the duration of the last batch.
+func (b *batchSizer) Observe(duration time.Duration) {
+	if b.target <= 0 {
+		return
+	}
+
+	switch {
+	case duration > b.target && b.size > 1:
+		b.size /= 2
+	case duration < b.target/2 && b.size < b.max:
+		b.size *= 2
+		if b.size > b.max {
+			b.size = b.max
+		}
+	}
+
+	mon.IntVal("delete_batch_size").Observe(int64(b.size))
+}
+
+// DeleteObjectsAndSegments deletes expired objects and associated segments. The deletions
+// of the objects whose bucket is in recordDeleteEvents are recorded in the same statement.
+func (p *PostgresAdapter) DeleteObjectsAndSegments(ctx context.Context, objects []ObjectStream, recordDeleteEvents map[BucketLocation]bool) (objectsDeleted, segmentsDeleted int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	if len(objects) == 0 {
@@ -332,12 +535,17 @@ func (p *PostgresAdapter) DeleteObjectsAndSegments(ctx context.Context, objects
 		for _, obj := range objects {
 			obj := obj
 
+			var recorded string
+			if recordDeleteEvents[obj.Location().Bucket()] {
+				recorded = postgresDeleteEventsQuery(DeleteEventExpired, "deleted_objects", "$1::BYTEA", "$2", "$3", "deleted_objects.total_plain_size")
+			}
+
 			batch.Queue(`
 				WITH deleted_objects AS (
 					DELETE FROM objects
 					WHERE (project_id, bucket_name, object_key, version, stream_id) = ($1::BYTEA, $2, $3, $4, $5::BYTEA)
-					RETURNING stream_id
-				)
+					RETURNING version, stream_id, total_plain_size
+				)`+recorded+`
 				DELETE FROM segments
 				WHERE segments.stream_id = $5::BYTEA
 			`, obj.ProjectID, obj.BucketName, []byte(obj.ObjectKey), obj.Version, obj.StreamID)
@@ -369,8 +577,9 @@ func (p *PostgresAdapter) DeleteObjectsAndSegments(ctx context.Context, objects
 	return objectsDeleted, segmentsDeleted, nil
 }
 
-// DeleteObjectsAndSegments deletes expired objects and associated segments.
-func (s *SpannerAdapter) DeleteObjectsAndSegments(ctx context.Context, objects []ObjectStream) (objectsDeleted, segmentsDeleted int64, err error) {
+// DeleteObjectsAndSegments deletes expired objects and associated segments. The deletions
+// of the objects whose bucket is in recordDeleteEvents are recorded in the same transaction.
+func (s *SpannerAdapter) DeleteObjectsAndSegments(ctx context.Context, objects []ObjectStream, recordDeleteEvents map[BucketLocation]bool) (objectsDeleted, segmentsDeleted int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	if len(objects) == 0 {
@@ -381,28 +590,39 @@ func (s *SpannerAdapter) DeleteObjectsAndSegments(ctx context.Context, objects [
 		// can't use Mutations here, since we only want to delete objects by the specified keys
 		// if and only if the stream_id matches.
 		var statements []spanner.Statement
+		var deletes []int
 		for _, obj := range objects {
 			obj := obj
+			params := map[string]interface{}{
+				"project_id":  obj.ProjectID,
+				"bucket_name": obj.BucketName,
+				"object_key":  obj.ObjectKey,
+				"version":     obj.Version,
+				"stream_id":   obj.StreamID,
+			}
+			if recordDeleteEvents[obj.Location().Bucket()] {
+				statements = append(statements, spanner.Statement{
+					SQL: spannerDeleteEventsFromObjectsQuery(DeleteEventExpired) + `
+						WHERE (project_id, bucket_name, object_key, version, stream_id) = (@project_id, @bucket_name, @object_key, @version, @stream_id)
+					`,
+					Params: params,
+				})
+			}
+			deletes = append(deletes, len(statements))
 			statements = append(statements, spanner.Statement{
 				SQL: `
 					DELETE FROM objects
 					WHERE (project_id, bucket_name, object_key, version, stream_id) = (@project_id, @bucket_name, @object_key, @version, @stream_id)
 				`,
-				Params: map[string]interface{}{
-					"project_id":  obj.ProjectID,
-					"bucket_name": obj.BucketName,
-					"object_key":  obj.ObjectKey,
-					"version":     obj.Version,
-					"stream_id":   obj.StreamID,
-				},
+				Params: params,
 			})
 		}
-		numDeleteds, err := tx.BatchUpdate(ctx, statements)
+		rowCounts, err := tx.BatchUpdate(ctx, statements)
 		if err != nil {
 			return Error.Wrap(err)
 		}
-		for _, numDeleted := range numDeleteds {
-			objectsDeleted += numDeleted
+		for _, i := range deletes {
+			objectsDeleted += rowCounts[i]
 		}
 		streamIDs := make([][]byte, 0, len(objects))
 		for _, obj := range objects {
@@ -437,9 +657,19 @@ func (p *PostgresAdapter) DeleteInactiveObjectsAndSegments(ctx context.Context,
 		return 0, 0, nil
 	}
 
+	inactiveObject := ""
+	if opts.requireInactiveObject {
+		inactiveObject = "AND created_at <= $6"
+	}
+
 	err = pgxutil.Conn(ctx, p.db, func(conn *pgx.Conn) error {
 		var batch pgx.Batch
 		for _, obj := range objects {
+			var recorded string
+			if opts.recordDeleteEvents[obj.Location().Bucket()] {
+				recorded = postgresDeleteEventsQuery(DeleteEventExpired, "deleted_objects", "$1::BYTEA", "$2::BYTEA", "$3::BYTEA", "deleted_objects.total_plain_size")
+			}
+
 			batch.Queue(`
 				WITH check_segments AS (
 					SELECT 1 FROM segments
@@ -449,8 +679,9 @@ func (p *PostgresAdapter) DeleteInactiveObjectsAndSegments(ctx context.Context,
 					WHERE
 						(project_id, bucket_name, object_key, version) = ($1::BYTEA, $2::BYTEA, $3::BYTEA, $4) AND
 						NOT EXISTS (SELECT 1 FROM check_segments)
-					RETURNING stream_id
-				)
+						`+inactiveObject+`
+					RETURNING version, stream_id, total_plain_size
+				)`+recorded+`
 				DELETE FROM segments
 				WHERE segments.stream_id IN (SELECT stream_id FROM deleted_objects)
 			`, obj.ProjectID, obj.BucketName, []byte(obj.ObjectKey), obj.Version, obj.StreamID, opts.InactiveDeadline)
@@ -486,52 +717,70 @@ func (s *SpannerAdapter) DeleteInactiveObjectsAndSegments(ctx context.Context, o
 		return 0, 0, nil
 	}
 
+	inactiveObject := ""
+	if opts.requireInactiveObject {
+		inactiveObject = "AND objects.created_at <= @inactive_deadline"
+	}
+
 	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
 		// can't use Mutations here, since we only want to delete objects by the specified keys
 		// if and only if the stream_id matches and no associated segments were uploaded after
 		// opts.InactiveDeadline.
+		where := `
+			WHERE
+				(project_id, bucket_name, object_key, version, stream_id) = (@project_id, @bucket_name, @object_key, @version, @stream_id)
+				AND NOT EXISTS (
+					SELECT 1 FROM segments
+					WHERE
+						segments.stream_id = objects.stream_id
+						AND segments.created_at > @inactive_deadline
+				)
+				` + inactiveObject + `
+		`
+
 		var statements []spanner.Statement
+		var deletes []int
 		for _, obj := range objects {
 			obj := obj
+			params := map[string]interface{}{
+				"project_id":        obj.ProjectID,
+				"bucket_name":       obj.BucketName,
+				"object_key":        obj.ObjectKey,
+				"version":           obj.Version,
+				"stream_id":         obj.StreamID,
+				"inactive_deadline": opts.InactiveDeadline,
+			}
+			if opts.recordDeleteEvents[obj.Location().Bucket()] {
+				statements = append(statements, spanner.Statement{
+					SQL:    spannerDeleteEventsFromObjectsQuery(DeleteEventExpired) + where,
+					Params: params,
+				})
+			}
+			deletes = append(deletes, len(statements))
 			statements = append(statements, spanner.Statement{
-				SQL: `
-					DELETE FROM objects
-					WHERE
-						(project_id, bucket_name, object_key, version, stream_id) = (@project_id, @bucket_name, @object_key, @version, @stream_id)
-						AND NOT EXISTS (
-							SELECT 1 FROM segments
-							WHERE
-								segments.stream_id = objects.stream_id
-								AND segments.created_at > @inactive_deadline
-						)
-				`,
-				Params: map[string]interface{}{
-					"project_id":        obj.ProjectID,
-					"bucket_name":       obj.BucketName,
-					"object_key":        obj.ObjectKey,
-					"version":           obj.Version,
-					"stream_id":         obj.StreamID,
-					"inactive_deadline": opts.InactiveDeadline,
-				},
+				SQL:    `DELETE FROM objects` + where,
+				Params: params,
 			})
 		}
-		numDeleteds, err := tx.BatchUpdate(ctx, statements)
+		rowCounts, err := tx.BatchUpdate(ctx, statements)
 		if err != nil {
 			return Error.Wrap(err)
 		}
-		for _, numDeleted := range numDeleteds {
+
+		// the row counts are in the order of the statements, hence only the
+		// segments of the actually deleted objects are deleted.
+		streamIDs := make([][]byte, 0, len(objects))
+		for i, statement := range deletes {
+			numDeleted := rowCounts[statement]
 			objectsDeleted += numDeleted
+			if numDeleted > 0 {
+				streamIDs = append(streamIDs, objects[i].StreamID.Bytes())
+			}
 		}
-		streamIDs := make([][]byte, 0, len(objects))
-		for _, obj := range objects {
-			streamIDs = append(streamIDs, obj.StreamID.Bytes())
+		if len(streamIDs) == 0 {
+			return nil
 		}
 
-		// TODO(spanner): this is not quite correct, instead of assuming how the previous check went,
-		// we should delete the segments based on what we actually deleted.
-		//
-		// Alternatively, we might be able to do a single statement per object to delete both segments
-		// and object itself at the same time.
 		numSegments, err := tx.Update(ctx, spanner.Statement{
 			SQL: `
 				DELETE FROM segments
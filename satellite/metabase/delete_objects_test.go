@@ -111,6 +111,30 @@ func TestDeleteExpiredObjects(t *testing.T) {
 			metabasetest.Verify{}.Check(ctx, t, db)
 		})
 
+		t.Run("adaptive batch size and concurrency", func(t *testing.T) {
+			expiresAt := time.Now().Add(-30 * 24 * time.Hour)
+			for i := 0; i < 32; i++ {
+				obj := metabasetest.RandObjectStream()
+				_ = metabasetest.CreateExpiredObject(ctx, t, db, obj, 2, expiresAt)
+
+				// second object in the same project
+				obj.ObjectKey = metabasetest.RandObjectKey()
+				obj.StreamID = testrand.UUID()
+				_ = metabasetest.CreateExpiredObject(ctx, t, db, obj, 1, expiresAt)
+			}
+			metabasetest.DeleteExpiredObjects{
+				Opts: metabase.DeleteExpiredObjects{
+					ExpiredBefore:       time.Now().Add(time.Hour),
+					BatchSize:           1,
+					MaxBatchSize:        16,
+					TargetBatchDuration: time.Hour,
+					DeleteConcurrency:   4,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
 		t.Run("committed objects", func(t *testing.T) {
 			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
 
@@ -0,0 +1,156 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/shared/lrucache"
+)
+
+// ErrDeleteRateLimited is used when a project exceeded its delete rate limit.
+var ErrDeleteRateLimited = errs.Class("metabase: delete rate limit exceeded")
+
+// DeleteRateLimitConfig configures the per-project rate limiting of object deletions.
+type DeleteRateLimitConfig struct {
+	// Rate is the number of objects a project can delete per second. 0 disables the rate limiting.
+	Rate float64
+	// Burst is the number of objects a project can delete at once.
+	Burst int
+
+	CacheCapacity   int
+	CacheExpiration time.Duration
+}
+
+// deleteRateLimitedError carries how long the project has to wait before deleting again.
+type deleteRateLimitedError struct {
+	retryAfter time.Duration
+}
+
+func (err *deleteRateLimitedError) Error() string {
+	return fmt.Sprintf("retry after %s", err.retryAfter)
+}
+
+// DeleteRateLimitRetryAfter returns how long the caller has to wait before retrying
+// a deletion which failed with ErrDeleteRateLimited.
+func DeleteRateLimitRetryAfter(err error) (retryAfter time.Duration, ok bool) {
+	var limited *deleteRateLimitedError
+	if !errors.As(err, &limited) {
+		return 0, false
+	}
+	return limited.retryAfter, true
+}
+
+// deleteRateLimiter is a per-project token bucket of deleted objects.
+//
+// A deletion is allowed as long as the project has at least one token left.
+// The deleted objects are charged afterwards, since their number isn't known in
+// advance, hence a large deletion can put the project in debt, which blocks its
+// deletions until the bucket refills.
+type deleteRateLimiter struct {
+	config  DeleteRateLimitConfig
+	now     func() time.Time
+	buckets *lrucache.ExpiringLRUOf[*deleteTokenBucket]
+}
+
+// deleteTokenBucket contains the tokens of a single project.
+type deleteTokenBucket struct {
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// newDeleteRateLimiter returns a new delete rate limiter, or nil when the rate limiting is disabled.
+func newDeleteRateLimiter(config DeleteRateLimitConfig) *deleteRateLimiter {
+	if config.Rate <= 0 {
+		return nil
+	}
+	if config.Burst <= 0 {
+		// a rate below 1 must still allow a single deletion.
+		config.Burst = int(math.Ceil(config.Rate))
+		if config.Burst < 1 {
+			config.Burst = 1
+		}
+	}
+	if config.CacheCapacity <= 0 {
+		config.CacheCapacity = 10000
+	}
+	return &deleteRateLimiter{
+		config: config,
+		now:    time.Now,
+		buckets: lrucache.NewOf[*deleteTokenBucket](lrucache.Options{
+			Capacity:   config.CacheCapacity,
+			Expiration: config.CacheExpiration,
+			Name:       "metabase-delete-ratelimit",
+		}),
+	}
+}
+
+// bucket returns the refilled token bucket of the project, locked.
+func (limiter *deleteRateLimiter) bucket(ctx context.Context, projectID uuid.UUID) (_ *deleteTokenBucket, err error) {
+	now := limiter.now()
+	bucket, err := limiter.buckets.Get(ctx, projectID.String(), func() (*deleteTokenBucket, error) {
+		return &deleteTokenBucket{tokens: float64(limiter.config.Burst), last: now}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bucket.mu.Lock()
+	if elapsed := now.Sub(bucket.last); elapsed > 0 {
+		bucket.tokens += elapsed.Seconds() * limiter.config.Rate
+		if bucket.tokens > float64(limiter.config.Burst) {
+			bucket.tokens = float64(limiter.config.Burst)
+		}
+		bucket.last = now
+	}
+	return bucket, nil
+}
+
+// check returns ErrDeleteRateLimited when the project can't delete objects at the moment.
+func (limiter *deleteRateLimiter) check(ctx context.Context, projectID uuid.UUID) error {
+	if limiter == nil {
+		return nil
+	}
+
+	bucket, err := limiter.bucket(ctx, projectID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer bucket.mu.Unlock()
+
+	if bucket.tokens >= 1 {
+		return nil
+	}
+
+	mon.Event("metabase_delete_rate_limit_exceeded")
+
+	retryAfter := time.Duration((1 - bucket.tokens) / limiter.config.Rate * float64(time.Second))
+	return ErrDeleteRateLimited.Wrap(&deleteRateLimitedError{retryAfter: retryAfter})
+}
+
+// charge takes the deleted objects from the token bucket of the project.
+func (limiter *deleteRateLimiter) charge(ctx context.Context, projectID uuid.UUID, deleted int64) {
+	if limiter == nil || deleted <= 0 {
+		return
+	}
+
+	mon.Meter("metabase_delete_rate_limit_charged").Mark64(deleted)
+
+	bucket, err := limiter.bucket(ctx, projectID)
+	if err != nil {
+		return
+	}
+	defer bucket.mu.Unlock()
+
+	bucket.tokens -= float64(deleted)
+}
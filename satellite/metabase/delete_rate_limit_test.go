@@ -0,0 +1,122 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestDeleteRateLimit(t *testing.T) {
+	metabasetest.RunWithConfig(t, metabase.Config{
+		ApplicationName: "metabase-tests",
+		DeleteRateLimit: metabase.DeleteRateLimitConfig{
+			// the bucket practically doesn't refill during the test.
+			Rate:  0.001,
+			Burst: 2,
+		},
+	}, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+		obj := metabasetest.RandObjectStream()
+
+		var objects []metabase.Object
+		for i := 0; i < 3; i++ {
+			stream := obj
+			stream.ObjectKey = metabasetest.RandObjectKey()
+			stream.StreamID = testrand.UUID()
+			objects = append(objects, metabasetest.CreateObject(ctx, t, db, stream, 0))
+		}
+
+		deleteObject := func(object metabase.Object) error {
+			_, err := db.DeleteObjectExactVersion(ctx, metabase.DeleteObjectExactVersion{
+				ObjectLocation: object.Location(),
+				Version:        object.Version,
+			})
+			return err
+		}
+
+		require.NoError(t, deleteObject(objects[0]))
+		require.NoError(t, deleteObject(objects[1]))
+
+		err := deleteObject(objects[2])
+		require.True(t, metabase.ErrDeleteRateLimited.Has(err))
+		retryAfter, ok := metabase.DeleteRateLimitRetryAfter(err)
+		require.True(t, ok)
+		require.Positive(t, retryAfter)
+
+		metabasetest.Verify{
+			Objects: []metabase.RawObject{metabase.RawObject(objects[2])},
+		}.Check(ctx, t, db)
+
+		// other projects aren't affected.
+		other := metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 0)
+		require.NoError(t, deleteObject(other))
+
+		// a deletion which is allowed can put the project in debt.
+		otherBucket := metabasetest.RandObjectStream()
+		for i := 0; i < 3; i++ {
+			stream := otherBucket
+			stream.ObjectKey = metabasetest.RandObjectKey()
+			stream.StreamID = testrand.UUID()
+			metabasetest.CreateObject(ctx, t, db, stream, 0)
+		}
+
+		// the next batch is rejected, even though the bucket is already empty.
+		deleted, err := db.DeleteBucketObjects(ctx, metabase.DeleteBucketObjects{
+			Bucket: otherBucket.Location().Bucket(),
+		})
+		require.True(t, metabase.ErrDeleteRateLimited.Has(err))
+		require.EqualValues(t, 3, deleted)
+
+		stream := otherBucket
+		stream.ObjectKey = metabasetest.RandObjectKey()
+		stream.StreamID = testrand.UUID()
+		inDebt := metabasetest.CreateObject(ctx, t, db, stream, 0)
+		require.True(t, metabase.ErrDeleteRateLimited.Has(deleteObject(inDebt)))
+
+		metabasetest.Verify{
+			Objects: []metabase.RawObject{
+				metabase.RawObject(objects[2]),
+				metabase.RawObject(inDebt),
+			},
+		}.Check(ctx, t, db)
+	})
+}
+
+func TestDeleteRateLimit_DefaultBurst(t *testing.T) {
+	metabasetest.RunWithConfig(t, metabase.Config{
+		ApplicationName: "metabase-tests",
+		DeleteRateLimit: metabase.DeleteRateLimitConfig{
+			// the burst defaults to the rate, which is below a single object.
+			Rate: 0.001,
+		},
+	}, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+		var objects []metabase.Object
+		for i := 0; i < 2; i++ {
+			objects = append(objects, metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 0))
+		}
+
+		_, err := db.DeleteObjectExactVersion(ctx, metabase.DeleteObjectExactVersion{
+			ObjectLocation: objects[0].Location(),
+			Version:        objects[0].Version,
+		})
+		require.NoError(t, err)
+
+		// the project is different, so it has its own bucket.
+		_, err = db.DeleteObjectExactVersion(ctx, metabase.DeleteObjectExactVersion{
+			ObjectLocation: objects[1].Location(),
+			Version:        objects[1].Version,
+		})
+		require.NoError(t, err)
+	})
+}
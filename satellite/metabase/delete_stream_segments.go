@@ -0,0 +1,208 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+
+	"cloud.google.com/go/spanner"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/shared/dbutil/spannerutil"
+	"storj.io/storj/shared/tagsql"
+)
+
+// deleteStreamSegmentsBatchLimit is the maximum number of segments deleted by a single statement.
+const deleteStreamSegmentsBatchLimit = intLimitRange(1000)
+
+// DeleteStreamSegments contains arguments for deleting the segments of a stream in bounded batches.
+//
+// Deleting all segments of an object with tens of thousands of segments with a single
+// statement may exceed the transaction size limits of the database, hence the segments
+// are deleted in ranges ordered by (stream_id, position).
+type DeleteStreamSegments struct {
+	ProjectID uuid.UUID
+	StreamID  uuid.UUID
+
+	// Cursor is the position after which the segments are deleted.
+	// It allows resuming an interrupted deletion.
+	Cursor SegmentPosition
+	// BatchSize is the maximum number of segments deleted by a single statement.
+	BatchSize int
+}
+
+// Verify verifies delete stream segments request fields.
+func (opts *DeleteStreamSegments) Verify() error {
+	switch {
+	case opts.ProjectID.IsZero():
+		return ErrInvalidRequest.New("ProjectID missing")
+	case opts.StreamID.IsZero():
+		return ErrInvalidRequest.New("StreamID missing")
+	case opts.BatchSize < 0:
+		return ErrInvalidRequest.New("BatchSize is negative")
+	}
+	return nil
+}
+
+// DeleteStreamSegmentsProgress describes the progress of deleting the segments of a stream.
+type DeleteStreamSegmentsProgress struct {
+	// Cursor is the position of the last deleted segment.
+	Cursor SegmentPosition
+	// Deleted is the number of segments deleted so far.
+	Deleted int64
+}
+
+// DeleteStreamSegments deletes the segments of a stream using bounded range deletes.
+//
+// The progress callback, when specified, is called after every batch. The deletion
+// stops when the callback returns an error. In case of error, this method returns the
+// number of segments deleted to the moment when the error occurred.
+func (db *DB) DeleteStreamSegments(ctx context.Context, opts DeleteStreamSegments, progress func(DeleteStreamSegmentsProgress) error) (deleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return 0, err
+	}
+
+	deleteStreamSegmentsBatchLimit.Ensure(&opts.BatchSize)
+
+	adapter := db.ChooseAdapter(opts.ProjectID)
+	for {
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+
+		batchDeleted, last, err := adapter.DeleteStreamSegmentsBatch(ctx, opts)
+		if err != nil {
+			return deleted, err
+		}
+		if batchDeleted == 0 {
+			return deleted, nil
+		}
+
+		mon.Meter("segment_delete").Mark64(batchDeleted)
+
+		deleted += batchDeleted
+		opts.Cursor = last
+
+		if progress != nil {
+			if err := progress(DeleteStreamSegmentsProgress{Cursor: last, Deleted: deleted}); err != nil {
+				return deleted, err
+			}
+		}
+
+		if batchDeleted < int64(opts.BatchSize) {
+			return deleted, nil
+		}
+	}
+}
+
+// DeleteStreamSegmentsBatch deletes a single range of stream segments after the cursor.
+func (p *PostgresAdapter) DeleteStreamSegmentsBatch(ctx context.Context, opts DeleteStreamSegments) (deleted int64, last SegmentPosition, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = withRows(p.db.QueryContext(ctx, `
+		DELETE FROM segments
+		WHERE (stream_id, position) IN (
+			SELECT stream_id, position
+			FROM segments
+			WHERE
+				stream_id = $1 AND
+				($2 = 0::INT8 OR position > $2)
+			ORDER BY stream_id, position ASC
+			LIMIT $3
+		)
+		RETURNING position
+	`, opts.StreamID, opts.Cursor, opts.BatchSize))(func(rows tagsql.Rows) error {
+		return scanDeletedSegmentPositions(rows, &deleted, &last)
+	})
+	if err != nil {
+		return 0, SegmentPosition{}, Error.New("unable to delete segments: %w", err)
+	}
+	return deleted, last, nil
+}
+
+// DeleteStreamSegmentsBatch deletes a single range of stream segments after the cursor.
+func (c *CockroachAdapter) DeleteStreamSegmentsBatch(ctx context.Context, opts DeleteStreamSegments) (deleted int64, last SegmentPosition, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = withRows(c.db.QueryContext(ctx, `
+		DELETE FROM segments
+		WHERE
+			stream_id = $1 AND
+			($2 = 0::INT8 OR position > $2)
+		ORDER BY stream_id, position ASC
+		LIMIT $3
+		RETURNING position
+	`, opts.StreamID, opts.Cursor, opts.BatchSize))(func(rows tagsql.Rows) error {
+		return scanDeletedSegmentPositions(rows, &deleted, &last)
+	})
+	if err != nil {
+		return 0, SegmentPosition{}, Error.New("unable to delete segments: %w", err)
+	}
+	return deleted, last, nil
+}
+
+// scanDeletedSegmentPositions counts the deleted segments and finds the last deleted position.
+func scanDeletedSegmentPositions(rows tagsql.Rows, deleted *int64, last *SegmentPosition) error {
+	for rows.Next() {
+		var position SegmentPosition
+		if err := rows.Scan(&position); err != nil {
+			return err
+		}
+		*deleted++
+		if position.Encode() > last.Encode() {
+			*last = position
+		}
+	}
+	return nil
+}
+
+// DeleteStreamSegmentsBatch deletes a single range of stream segments after the cursor.
+func (s *SpannerAdapter) DeleteStreamSegmentsBatch(ctx context.Context, opts DeleteStreamSegments) (deleted int64, last SegmentPosition, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		positions, err := spannerutil.CollectRows(tx.Query(ctx, spanner.Statement{
+			SQL: `
+				DELETE FROM segments
+				WHERE
+					stream_id = @stream_id AND
+					position IN (
+						SELECT position
+						FROM segments
+						WHERE
+							stream_id = @stream_id AND
+							(@position = 0 OR position > @position)
+						ORDER BY stream_id, position ASC
+						LIMIT @limit
+					)
+				THEN RETURN position
+			`,
+			Params: map[string]any{
+				"stream_id": opts.StreamID,
+				"position":  opts.Cursor,
+				"limit":     opts.BatchSize,
+			},
+		}), func(row *spanner.Row, position *SegmentPosition) error {
+			return row.Columns(position)
+		})
+		if err != nil {
+			return err
+		}
+
+		deleted, last = 0, SegmentPosition{}
+		for _, position := range positions {
+			deleted++
+			if position.Encode() > last.Encode() {
+				last = position
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, SegmentPosition{}, Error.New("unable to delete segments: %w", err)
+	}
+	return deleted, last, nil
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestDeleteStreamSegments(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+
+		t.Run("invalid request", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			_, err := db.DeleteStreamSegments(ctx, metabase.DeleteStreamSegments{StreamID: obj.StreamID}, nil)
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+			require.ErrorContains(t, err, "ProjectID missing")
+
+			_, err = db.DeleteStreamSegments(ctx, metabase.DeleteStreamSegments{ProjectID: obj.ProjectID}, nil)
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+			require.ErrorContains(t, err, "StreamID missing")
+
+			_, err = db.DeleteStreamSegments(ctx, metabase.DeleteStreamSegments{
+				ProjectID: obj.ProjectID,
+				StreamID:  obj.StreamID,
+				BatchSize: -1,
+			}, nil)
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+			require.ErrorContains(t, err, "BatchSize is negative")
+		})
+
+		t.Run("delete in batches", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 10)
+
+			var progress []metabase.DeleteStreamSegmentsProgress
+			deleted, err := db.DeleteStreamSegments(ctx, metabase.DeleteStreamSegments{
+				ProjectID: obj.ProjectID,
+				StreamID:  obj.StreamID,
+				BatchSize: 3,
+			}, func(p metabase.DeleteStreamSegmentsProgress) error {
+				progress = append(progress, p)
+				return nil
+			})
+			require.NoError(t, err)
+			require.EqualValues(t, 10, deleted)
+			require.Equal(t, []metabase.DeleteStreamSegmentsProgress{
+				{Cursor: metabase.SegmentPosition{Index: 2}, Deleted: 3},
+				{Cursor: metabase.SegmentPosition{Index: 5}, Deleted: 6},
+				{Cursor: metabase.SegmentPosition{Index: 8}, Deleted: 9},
+				{Cursor: metabase.SegmentPosition{Index: 9}, Deleted: 10},
+			}, progress)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{metabase.RawObject(object)},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("resume after interruption", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 5)
+
+			errInterrupted := errors.New("interrupted")
+
+			var cursor metabase.SegmentPosition
+			deleted, err := db.DeleteStreamSegments(ctx, metabase.DeleteStreamSegments{
+				ProjectID: obj.ProjectID,
+				StreamID:  obj.StreamID,
+				BatchSize: 2,
+			}, func(p metabase.DeleteStreamSegmentsProgress) error {
+				cursor = p.Cursor
+				return errInterrupted
+			})
+			require.ErrorIs(t, err, errInterrupted)
+			require.EqualValues(t, 2, deleted)
+
+			deleted, err = db.DeleteStreamSegments(ctx, metabase.DeleteStreamSegments{
+				ProjectID: obj.ProjectID,
+				StreamID:  obj.StreamID,
+				Cursor:    cursor,
+				BatchSize: 2,
+			}, nil)
+			require.NoError(t, err)
+			require.EqualValues(t, 3, deleted)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{metabase.RawObject(object)},
+			}.Check(ctx, t, db)
+		})
+	})
+}
@@ -248,6 +248,56 @@ func TestDeletePendingObject(t *testing.T) {
 
 			metabasetest.Verify{}.Check(ctx, t, db)
 		})
+
+		t.Run("with recently committed segments", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.CreatePendingObject(ctx, t, db, obj, 2)
+
+			metabasetest.DeletePendingObject{
+				Opts: metabase.DeletePendingObject{
+					ObjectStream:             obj,
+					NoCommittedSegmentsSince: time.Now().Add(-time.Hour),
+				},
+				ErrClass: &metabase.ErrFailedPrecondition,
+				ErrText:  "pending object has segments committed since",
+			}.Check(ctx, t, db)
+
+			// the segments were committed before the time, hence the object can be deleted.
+			metabasetest.DeletePendingObject{
+				Opts: metabase.DeletePendingObject{
+					ObjectStream:             obj,
+					NoCommittedSegmentsSince: time.Now().Add(time.Hour),
+				},
+				Result: metabase.DeleteObjectResult{
+					Removed: []metabase.Object{
+						{
+							ObjectStream: obj,
+							CreatedAt:    now,
+							Status:       metabase.Pending,
+							Encryption:   metabasetest.DefaultEncryption,
+						},
+					},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("object missing with committed segments check", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.DeletePendingObject{
+				Opts: metabase.DeletePendingObject{
+					ObjectStream:             obj,
+					NoCommittedSegmentsSince: time.Now().Add(-time.Hour),
+				},
+				ErrClass: &metabase.ErrObjectNotFound,
+				ErrText:  "metabase: no rows deleted",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
 	})
 }
 
@@ -393,6 +443,30 @@ func TestDeleteObjectExactVersion(t *testing.T) {
 			metabasetest.Verify{}.Check(ctx, t, db)
 		})
 
+		t.Run("Delete object returning deleted segments", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 2)
+
+			segment := metabasetest.DefaultRawSegment(obj, metabase.SegmentPosition{})
+			metabasetest.DeleteObjectExactVersion{
+				Opts: metabase.DeleteObjectExactVersion{
+					ObjectLocation:        location,
+					Version:               obj.Version,
+					ReturnDeletedSegments: true,
+				},
+				Result: metabase.DeleteObjectResult{
+					Removed: []metabase.Object{object},
+					DeletedSegments: []metabase.DeletedSegmentInfo{
+						{RootPieceID: segment.RootPieceID, Pieces: segment.Pieces},
+						{RootPieceID: segment.RootPieceID, Pieces: segment.Pieces},
+					},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
 		t.Run("Delete object with inline segment", func(t *testing.T) {
 			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
 
@@ -437,6 +511,111 @@ func TestDeleteObjectExactVersion(t *testing.T) {
 			metabasetest.Verify{}.Check(ctx, t, db)
 		})
 
+		t.Run("Deletion protected", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 0)
+
+			metabasetest.DeleteObjectExactVersion{
+				Opts: metabase.DeleteObjectExactVersion{
+					ObjectLocation:    location,
+					Version:           obj.Version,
+					DeletionProtected: true,
+				},
+				ErrClass: &metabase.ErrDeletionProtected,
+				ErrText:  "bucket has deletion protection enabled",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{metabase.RawObject(object)},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("Delete object with retention", func(t *testing.T) {
+			t.Run("Active retention", func(t *testing.T) {
+				defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+				object, segments := metabasetest.CreateObjectWithRetention(ctx, t, db, obj, 1, time.Now().Add(time.Hour))
+
+				metabasetest.DeleteObjectExactVersion{
+					Opts: metabase.DeleteObjectExactVersion{
+						ObjectLocation: location,
+						Version:        obj.Version,
+						UseObjectLock:  true,
+					},
+					ErrClass: &metabase.ErrObjectLock,
+					ErrText:  "object has an active retention period",
+				}.Check(ctx, t, db)
+
+				metabasetest.Verify{
+					Objects:  []metabase.RawObject{metabase.RawObject(object)},
+					Segments: metabasetest.SegmentsToRaw(segments),
+				}.Check(ctx, t, db)
+			})
+
+			t.Run("Expired retention", func(t *testing.T) {
+				defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+				object, _ := metabasetest.CreateObjectWithRetention(ctx, t, db, obj, 1, time.Now().Add(-time.Minute))
+
+				metabasetest.DeleteObjectExactVersion{
+					Opts: metabase.DeleteObjectExactVersion{
+						ObjectLocation: location,
+						Version:        obj.Version,
+						UseObjectLock:  true,
+					},
+					Result: metabase.DeleteObjectResult{
+						Removed: []metabase.Object{object},
+					},
+				}.Check(ctx, t, db)
+
+				metabasetest.Verify{}.Check(ctx, t, db)
+			})
+		})
+
+		t.Run("Delete object under legal hold", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObjectVersioned(ctx, t, db, obj, 1)
+
+			metabasetest.SetObjectExactVersionLegalHold{
+				Opts: metabase.SetObjectExactVersionLegalHold{
+					ObjectLocation: location,
+					Version:        obj.Version,
+					Enabled:        true,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.DeleteObjectExactVersion{
+				Opts: metabase.DeleteObjectExactVersion{
+					ObjectLocation: location,
+					Version:        obj.Version,
+					UseObjectLock:  true,
+				},
+				ErrClass: &metabase.ErrObjectLock,
+				ErrText:  "object is under legal hold",
+			}.Check(ctx, t, db)
+
+			metabasetest.SetObjectExactVersionLegalHold{
+				Opts: metabase.SetObjectExactVersionLegalHold{
+					ObjectLocation: location,
+					Version:        obj.Version,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.DeleteObjectExactVersion{
+				Opts: metabase.DeleteObjectExactVersion{
+					ObjectLocation: location,
+					Version:        obj.Version,
+					UseObjectLock:  true,
+				},
+				Result: metabase.DeleteObjectResult{
+					Removed: []metabase.Object{object},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
 	})
 }
 
@@ -1055,6 +1234,29 @@ func TestDeleteObjectLastCommitted(t *testing.T) {
 			metabasetest.Verify{}.Check(ctx, t, db)
 		})
 
+		t.Run("Delete object returning deleted segments", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 2)
+
+			segment := metabasetest.DefaultRawSegment(obj, metabase.SegmentPosition{})
+			metabasetest.DeleteObjectLastCommitted{
+				Opts: metabase.DeleteObjectLastCommitted{
+					ObjectLocation:        location,
+					ReturnDeletedSegments: true,
+				},
+				Result: metabase.DeleteObjectResult{
+					Removed: []metabase.Object{object},
+					DeletedSegments: []metabase.DeletedSegmentInfo{
+						{RootPieceID: segment.RootPieceID, Pieces: segment.Pieces},
+						{RootPieceID: segment.RootPieceID, Pieces: segment.Pieces},
+					},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
 		t.Run("Delete object with inline segment", func(t *testing.T) {
 			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
 
@@ -1160,6 +1362,87 @@ func TestDeleteObjectLastCommitted(t *testing.T) {
 			}.Check(ctx, t, db)
 		})
 
+		t.Run("Delete if latest version", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.DeleteObjectLastCommitted{
+				Opts: metabase.DeleteObjectLastCommitted{
+					ObjectLocation:  location,
+					IfLatestVersion: obj.Version,
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "IfLatestVersion requires versioned or suspended",
+			}.Check(ctx, t, db)
+
+			first := metabasetest.CreateObjectVersioned(ctx, t, db, obj, 0)
+
+			secondObjStream := obj
+			secondObjStream.Version++
+			secondObjStream.StreamID = testrand.UUID()
+			second := metabasetest.CreateObjectVersioned(ctx, t, db, secondObjStream, 0)
+
+			for _, suspended := range []bool{false, true} {
+				metabasetest.DeleteObjectLastCommitted{
+					Opts: metabase.DeleteObjectLastCommitted{
+						ObjectLocation:  location,
+						Versioned:       !suspended,
+						Suspended:       suspended,
+						IfLatestVersion: first.Version,
+					},
+					ErrClass: &metabase.ErrValueChanged,
+					ErrText:  "latest object version changed",
+				}.Check(ctx, t, db)
+			}
+
+			markerObjStream := secondObjStream
+			markerObjStream.Version++
+
+			deleted := metabasetest.DeleteObjectLastCommitted{
+				Opts: metabase.DeleteObjectLastCommitted{
+					ObjectLocation:  location,
+					Versioned:       true,
+					IfLatestVersion: second.Version,
+				},
+				Result: metabase.DeleteObjectResult{
+					Markers: []metabase.Object{{
+						ObjectStream: markerObjStream,
+						CreatedAt:    time.Now(),
+						Status:       metabase.DeleteMarkerVersioned,
+					}},
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(first),
+					metabase.RawObject(second),
+					metabase.RawObject(deleted.Markers[0]),
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("Deletion protected", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 0)
+
+			for _, suspended := range []bool{false, true} {
+				metabasetest.DeleteObjectLastCommitted{
+					Opts: metabase.DeleteObjectLastCommitted{
+						ObjectLocation:    location,
+						Suspended:         suspended,
+						DeletionProtected: true,
+					},
+					ErrClass: &metabase.ErrDeletionProtected,
+					ErrText:  "bucket has deletion protection enabled",
+				}.Check(ctx, t, db)
+			}
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{metabase.RawObject(object)},
+			}.Check(ctx, t, db)
+		})
+
 		t.Run("Delete object with retention", func(t *testing.T) {
 			t.Run("Suspended", func(t *testing.T) {
 				t.Run("Active retention", func(t *testing.T) {
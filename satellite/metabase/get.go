@@ -13,6 +13,7 @@ import (
 	"github.com/zeebo/errs"
 	"google.golang.org/api/iterator"
 
+	"storj.io/common/storj"
 	"storj.io/common/uuid"
 	"storj.io/storj/shared/dbutil/spannerutil"
 )
@@ -87,6 +88,9 @@ func (db *DB) GetObjectExactVersion(ctx context.Context, opts GetObjectExactVers
 	if err != nil {
 		return Object{}, err
 	}
+	if err := db.metadataEncryption.decryptMetadata(opts.ProjectID, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey); err != nil {
+		return Object{}, err
+	}
 	return object, nil
 }
 
@@ -101,7 +105,8 @@ func (p *PostgresAdapter) GetObjectExactVersion(ctx context.Context, opts GetObj
 			encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
 			total_plain_size, total_encrypted_size, fixed_segment_size,
 			encryption,
-			retention_mode, retain_until
+			retention_mode, retain_until,
+			checksum
 		FROM objects
 		WHERE
 			(project_id, bucket_name, object_key, version) = ($1, $2, $3, $4) AND
@@ -116,6 +121,7 @@ func (p *PostgresAdapter) GetObjectExactVersion(ctx context.Context, opts GetObj
 			&object.TotalPlainSize, &object.TotalEncryptedSize, &object.FixedSegmentSize,
 			encryptionParameters{&object.Encryption},
 			retentionModeWrapper{&object.Retention.Mode}, timeWrapper{&object.Retention.RetainUntil},
+			&object.Checksum,
 		)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -147,7 +153,8 @@ func (s *SpannerAdapter) GetObjectExactVersion(ctx context.Context, opts GetObje
 				encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
 				total_plain_size, total_encrypted_size, fixed_segment_size,
 				encryption,
-				retention_mode, retain_until
+				retention_mode, retain_until,
+				checksum
 			FROM objects
 			WHERE
 				(project_id, bucket_name, object_key, version) = (@project_id, @bucket_name, @object_key, @version) AND
@@ -173,6 +180,7 @@ func (s *SpannerAdapter) GetObjectExactVersion(ctx context.Context, opts GetObje
 			&object.TotalPlainSize, &object.TotalEncryptedSize, spannerutil.Int(&object.FixedSegmentSize),
 			encryptionParameters{&object.Encryption},
 			retentionModeWrapper{&object.Retention.Mode}, timeWrapper{&object.Retention.RetainUntil},
+			&object.Checksum,
 		))
 	})
 
@@ -204,7 +212,14 @@ func (db *DB) GetObjectLastCommitted(ctx context.Context, opts GetObjectLastComm
 		return Object{}, err
 	}
 
-	return db.ChooseAdapter(opts.ProjectID).GetObjectLastCommitted(ctx, opts)
+	object, err := db.ChooseAdapter(opts.ProjectID).GetObjectLastCommitted(ctx, opts)
+	if err != nil {
+		return Object{}, err
+	}
+	if err := db.metadataEncryption.decryptMetadata(opts.ProjectID, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey); err != nil {
+		return Object{}, err
+	}
+	return object, nil
 }
 
 // GetObjectLastCommitted implements Adapter.
@@ -221,7 +236,8 @@ func (p *PostgresAdapter) GetObjectLastCommitted(ctx context.Context, opts GetOb
 			encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
 			total_plain_size, total_encrypted_size, fixed_segment_size,
 			encryption,
-			retention_mode, retain_until
+			retention_mode, retain_until,
+			checksum
 		FROM objects
 		WHERE
 			(project_id, bucket_name, object_key) = ($1, $2, $3) AND
@@ -238,6 +254,7 @@ func (p *PostgresAdapter) GetObjectLastCommitted(ctx context.Context, opts GetOb
 		&object.TotalPlainSize, &object.TotalEncryptedSize, &object.FixedSegmentSize,
 		encryptionParameters{&object.Encryption},
 		retentionModeWrapper{&object.Retention.Mode}, timeWrapper{&object.Retention.RetainUntil},
+		&object.Checksum,
 	)
 
 	if errors.Is(err, sql.ErrNoRows) || object.Status.IsDeleteMarker() {
@@ -265,7 +282,8 @@ func (s *SpannerAdapter) GetObjectLastCommitted(ctx context.Context, opts GetObj
 				encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
 				total_plain_size, total_encrypted_size, fixed_segment_size,
 				encryption,
-				retention_mode, retain_until
+				retention_mode, retain_until,
+				checksum
 			FROM objects
 			WHERE
 				project_id = @project_id AND
@@ -293,6 +311,7 @@ func (s *SpannerAdapter) GetObjectLastCommitted(ctx context.Context, opts GetObj
 			&object.TotalPlainSize, &object.TotalEncryptedSize, spannerutil.Int(&object.FixedSegmentSize),
 			encryptionParameters{&object.Encryption},
 			retentionModeWrapper{&object.Retention.Mode}, timeWrapper{&object.Retention.RetainUntil},
+			&object.Checksum,
 		))
 	})
 	if err != nil {
@@ -312,6 +331,235 @@ func (s *SpannerAdapter) GetObjectLastCommitted(ctx context.Context, opts GetObj
 	return object, nil
 }
 
+// GetObjectWithSegment contains arguments necessary for fetching the last committed
+// version of an object together with its segment.
+type GetObjectWithSegment struct {
+	ObjectLocation
+}
+
+// ObjectWithSegment is an object with its segment.
+type ObjectWithSegment struct {
+	Object
+	// Segment is set only when the object consists of a single segment.
+	Segment *Segment
+}
+
+// GetObjectWithSegment returns the last committed version of an object. When the object
+// consists of a single segment, the segment is returned as well, which saves a separate
+// segment query for the common case of small objects.
+func (db *DB) GetObjectWithSegment(ctx context.Context, opts GetObjectWithSegment) (_ ObjectWithSegment, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return ObjectWithSegment{}, err
+	}
+
+	result, aliasPieces, err := db.ChooseAdapter(opts.ProjectID).GetObjectWithSegment(ctx, opts)
+	if err != nil {
+		return ObjectWithSegment{}, err
+	}
+	if err := db.metadataEncryption.decryptMetadata(opts.ProjectID, &result.EncryptedMetadata, &result.EncryptedMetadataEncryptedKey); err != nil {
+		return ObjectWithSegment{}, err
+	}
+
+	if result.Segment != nil && len(aliasPieces) > 0 {
+		result.Segment.Pieces, err = db.aliasCache.ConvertAliasesToPieces(ctx, aliasPieces)
+		if err != nil {
+			return ObjectWithSegment{}, Error.New("unable to convert aliases to pieces: %w", err)
+		}
+	}
+
+	mon.Meter("object_get_with_segment").Mark(1)
+	if result.Segment != nil {
+		mon.Meter("object_get_with_segment_hit").Mark(1)
+	}
+
+	return result, nil
+}
+
+// GetObjectWithSegment implements Adapter.
+func (p *PostgresAdapter) GetObjectWithSegment(ctx context.Context, opts GetObjectWithSegment) (result ObjectWithSegment, aliasPieces AliasPieces, err error) {
+	object := &result.Object
+	object.ProjectID = opts.ProjectID
+	object.BucketName = opts.BucketName
+	object.ObjectKey = opts.ObjectKey
+
+	var hasSegment bool
+	var segment Segment
+	var rootPieceID []byte
+	err = p.db.QueryRowContext(ctx, `
+		SELECT
+			o.stream_id, o.version, o.status,
+			o.created_at, o.expires_at,
+			o.segment_count,
+			o.encrypted_metadata_nonce, o.encrypted_metadata, o.encrypted_metadata_encrypted_key,
+			o.total_plain_size, o.total_encrypted_size, o.fixed_segment_size,
+			o.encryption,
+			o.retention_mode, o.retain_until,
+			o.checksum,
+			s.stream_id IS NOT NULL,
+			COALESCE(s.position, 0),
+			COALESCE(s.created_at, o.created_at), s.repaired_at, s.expires_at,
+			s.root_piece_id, s.encrypted_key_nonce, s.encrypted_key,
+			COALESCE(s.encrypted_size, 0), COALESCE(s.plain_offset, 0), COALESCE(s.plain_size, 0),
+			s.encrypted_etag,
+			COALESCE(s.redundancy, 0),
+			s.inline_data, s.remote_alias_pieces,
+			COALESCE(s.placement, 0), s.checksum
+		FROM (
+			SELECT *
+			FROM objects
+			WHERE
+				(project_id, bucket_name, object_key) = ($1, $2, $3) AND
+				status <> `+statusPending+` AND
+				(expires_at IS NULL OR expires_at > now())
+			ORDER BY version DESC
+			LIMIT 1
+		) AS o
+		LEFT JOIN segments AS s ON s.stream_id = o.stream_id AND o.segment_count = 1`,
+		opts.ProjectID, opts.BucketName, opts.ObjectKey,
+	).Scan(
+		&object.StreamID, &object.Version, &object.Status,
+		&object.CreatedAt, &object.ExpiresAt,
+		&object.SegmentCount,
+		&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
+		&object.TotalPlainSize, &object.TotalEncryptedSize, &object.FixedSegmentSize,
+		encryptionParameters{&object.Encryption},
+		retentionModeWrapper{&object.Retention.Mode}, timeWrapper{&object.Retention.RetainUntil},
+		&object.Checksum,
+		&hasSegment,
+		&segment.Position,
+		&segment.CreatedAt, &segment.RepairedAt, &segment.ExpiresAt,
+		&rootPieceID, &segment.EncryptedKeyNonce, &segment.EncryptedKey,
+		&segment.EncryptedSize, &segment.PlainOffset, &segment.PlainSize,
+		&segment.EncryptedETag,
+		redundancyScheme{&segment.Redundancy},
+		&segment.InlineData, &aliasPieces,
+		&segment.Placement, &segment.Checksum,
+	)
+
+	if errors.Is(err, sql.ErrNoRows) || object.Status.IsDeleteMarker() {
+		return ObjectWithSegment{}, nil, ErrObjectNotFound.Wrap(Error.Wrap(sql.ErrNoRows))
+	}
+	if err != nil {
+		return ObjectWithSegment{}, nil, Error.Wrap(err)
+	}
+
+	if err = object.Retention.Verify(); err != nil {
+		return ObjectWithSegment{}, nil, Error.Wrap(err)
+	}
+
+	if hasSegment {
+		segment.StreamID = object.StreamID
+		if len(rootPieceID) > 0 {
+			segment.RootPieceID, err = storj.PieceIDFromBytes(rootPieceID)
+			if err != nil {
+				return ObjectWithSegment{}, nil, Error.Wrap(err)
+			}
+		}
+		result.Segment = &segment
+	}
+
+	return result, aliasPieces, nil
+}
+
+// GetObjectWithSegment implements Adapter.
+func (s *SpannerAdapter) GetObjectWithSegment(ctx context.Context, opts GetObjectWithSegment) (result ObjectWithSegment, aliasPieces AliasPieces, err error) {
+	var hasSegment bool
+	var segment Segment
+	var rootPieceID []byte
+	result, err = spannerutil.CollectRow(s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT
+				o.stream_id, o.version, o.status,
+				o.created_at, o.expires_at,
+				o.segment_count,
+				o.encrypted_metadata_nonce, o.encrypted_metadata, o.encrypted_metadata_encrypted_key,
+				o.total_plain_size, o.total_encrypted_size, o.fixed_segment_size,
+				o.encryption,
+				o.retention_mode, o.retain_until,
+				o.checksum,
+				s.stream_id IS NOT NULL,
+				COALESCE(s.position, 0),
+				COALESCE(s.created_at, o.created_at), s.repaired_at, s.expires_at,
+				s.root_piece_id, s.encrypted_key_nonce, s.encrypted_key,
+				COALESCE(s.encrypted_size, 0), COALESCE(s.plain_offset, 0), COALESCE(s.plain_size, 0),
+				s.encrypted_etag,
+				COALESCE(s.redundancy, 0),
+				s.inline_data, s.remote_alias_pieces,
+				COALESCE(s.placement, 0), s.checksum
+			FROM (
+				SELECT *
+				FROM objects
+				WHERE
+					project_id = @project_id AND
+					bucket_name = @bucket_name AND
+					object_key = @object_key AND
+					status <> ` + statusPending + ` AND
+					(expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+				ORDER BY version DESC
+				LIMIT 1
+			) AS o
+			LEFT JOIN segments AS s ON s.stream_id = o.stream_id AND o.segment_count = 1`,
+		Params: map[string]interface{}{
+			"project_id":  opts.ProjectID,
+			"bucket_name": opts.BucketName,
+			"object_key":  opts.ObjectKey,
+		},
+	}), func(row *spanner.Row, result *ObjectWithSegment) error {
+		object := &result.Object
+		object.ProjectID = opts.ProjectID
+		object.BucketName = opts.BucketName
+		object.ObjectKey = opts.ObjectKey
+
+		return Error.Wrap(row.Columns(
+			&object.StreamID, &object.Version, &object.Status,
+			&object.CreatedAt, &object.ExpiresAt,
+			spannerutil.Int(&object.SegmentCount),
+			&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
+			&object.TotalPlainSize, &object.TotalEncryptedSize, spannerutil.Int(&object.FixedSegmentSize),
+			encryptionParameters{&object.Encryption},
+			retentionModeWrapper{&object.Retention.Mode}, timeWrapper{&object.Retention.RetainUntil},
+			&object.Checksum,
+			&hasSegment,
+			&segment.Position,
+			&segment.CreatedAt, &segment.RepairedAt, &segment.ExpiresAt,
+			&rootPieceID, &segment.EncryptedKeyNonce, &segment.EncryptedKey,
+			spannerutil.Int(&segment.EncryptedSize), &segment.PlainOffset, spannerutil.Int(&segment.PlainSize),
+			&segment.EncryptedETag,
+			redundancyScheme{&segment.Redundancy},
+			&segment.InlineData, &aliasPieces,
+			&segment.Placement, &segment.Checksum,
+		))
+	})
+	if err != nil {
+		if errors.Is(err, iterator.Done) {
+			return ObjectWithSegment{}, nil, ErrObjectNotFound.Wrap(Error.Wrap(sql.ErrNoRows))
+		}
+		return ObjectWithSegment{}, nil, Error.Wrap(err)
+	}
+	if result.Status.IsDeleteMarker() {
+		return ObjectWithSegment{}, nil, ErrObjectNotFound.Wrap(Error.Wrap(sql.ErrNoRows))
+	}
+
+	if err = result.Retention.Verify(); err != nil {
+		return ObjectWithSegment{}, nil, Error.Wrap(err)
+	}
+
+	if hasSegment {
+		segment.StreamID = result.StreamID
+		if len(rootPieceID) > 0 {
+			segment.RootPieceID, err = storj.PieceIDFromBytes(rootPieceID)
+			if err != nil {
+				return ObjectWithSegment{}, nil, Error.Wrap(err)
+			}
+		}
+		result.Segment = &segment
+	}
+
+	return result, aliasPieces, nil
+}
+
 // GetSegmentByPosition contains arguments necessary for fetching a segment on specific position.
 type GetSegmentByPosition struct {
 	StreamID uuid.UUID
@@ -361,7 +609,7 @@ func (p *PostgresAdapter) GetSegmentByPosition(ctx context.Context, opts GetSegm
 			encrypted_etag,
 			redundancy,
 			inline_data, remote_alias_pieces,
-			placement
+			placement, checksum
 		FROM segments
 		WHERE (stream_id, position) = ($1, $2)
 	`, opts.StreamID, opts.Position.Encode()).
@@ -372,7 +620,7 @@ func (p *PostgresAdapter) GetSegmentByPosition(ctx context.Context, opts GetSegm
 			&segment.EncryptedETag,
 			redundancyScheme{&segment.Redundancy},
 			&segment.InlineData, &aliasPieces,
-			&segment.Placement,
+			&segment.Placement, &segment.Checksum,
 		)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -395,7 +643,7 @@ func (s *SpannerAdapter) GetSegmentByPosition(ctx context.Context, opts GetSegme
 				encrypted_etag,
 				redundancy,
 				inline_data, remote_alias_pieces,
-				placement
+				placement, checksum
 			FROM segments
 			WHERE (stream_id, position) = (@stream_id, @position)
 		`,
@@ -411,7 +659,7 @@ func (s *SpannerAdapter) GetSegmentByPosition(ctx context.Context, opts GetSegme
 			&segment.EncryptedETag,
 			redundancyScheme{&segment.Redundancy},
 			&segment.InlineData, &aliasPieces,
-			&segment.Placement,
+			&segment.Placement, &segment.Checksum,
 		))
 	})
 	if err != nil {
@@ -463,7 +711,7 @@ func (p *PostgresAdapter) GetLatestObjectLastSegment(ctx context.Context, opts G
 			encrypted_etag,
 			redundancy,
 			inline_data, remote_alias_pieces,
-			placement
+			placement, checksum
 		FROM segments
 		WHERE
 			stream_id IN (
@@ -486,7 +734,7 @@ func (p *PostgresAdapter) GetLatestObjectLastSegment(ctx context.Context, opts G
 			&segment.EncryptedETag,
 			redundancyScheme{&segment.Redundancy},
 			&segment.InlineData, &aliasPieces,
-			&segment.Placement,
+			&segment.Placement, &segment.Checksum,
 		)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -509,7 +757,7 @@ func (s *SpannerAdapter) GetLatestObjectLastSegment(ctx context.Context, opts Ge
 				encrypted_etag,
 				redundancy,
 				inline_data, remote_alias_pieces,
-				placement
+				placement, checksum
 			FROM segments
 			WHERE
 				stream_id IN (
@@ -538,7 +786,7 @@ func (s *SpannerAdapter) GetLatestObjectLastSegment(ctx context.Context, opts Ge
 			&segment.EncryptedETag,
 			redundancyScheme{&segment.Redundancy},
 			&segment.InlineData, &aliasPieces,
-			&segment.Placement,
+			&segment.Placement, &segment.Checksum,
 		))
 	})
 
@@ -0,0 +1,269 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/spanner"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/shared/dbutil/pgutil"
+	"storj.io/storj/shared/dbutil/spannerutil"
+	"storj.io/storj/shared/tagsql"
+)
+
+// GetObjectVersionsAsOf contains arguments necessary for fetching all the
+// versions of an object and their segments as they were at a past time.
+//
+// It's meant for support investigations. The past state is only available
+// within the garbage collection window of the database, e.g. gc.ttlseconds
+// on CockroachDB and the version retention period on Spanner.
+type GetObjectVersionsAsOf struct {
+	ObjectLocation
+	AsOf time.Time
+}
+
+// Verify verifies the request fields.
+func (opts *GetObjectVersionsAsOf) Verify(now time.Time) error {
+	if err := opts.ObjectLocation.Verify(); err != nil {
+		return err
+	}
+	if opts.AsOf.IsZero() {
+		return ErrInvalidRequest.New("AsOf missing")
+	}
+	if !opts.AsOf.Before(now) {
+		return ErrInvalidRequest.New("AsOf must be in the past: %v", opts.AsOf)
+	}
+	return nil
+}
+
+// ObjectVersionAsOf is a version of an object with its segments at a past time.
+type ObjectVersionAsOf struct {
+	Object
+	Segments []Segment
+}
+
+// GetObjectVersionsAsOf returns all the versions of an object, including the
+// pending ones and the delete markers, with their segments as they were at
+// opts.AsOf. The encrypted metadata of the objects isn't returned.
+func (db *DB) GetObjectVersionsAsOf(ctx context.Context, opts GetObjectVersionsAsOf) (_ []ObjectVersionAsOf, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(time.Now()); err != nil {
+		return nil, err
+	}
+
+	return db.ChooseAdapter(opts.ProjectID).GetObjectVersionsAsOf(ctx, opts, db.aliasCache)
+}
+
+// GetObjectVersionsAsOf implements Adapter. Postgres doesn't keep the past
+// versions of the rows, so it's not supported.
+func (p *PostgresAdapter) GetObjectVersionsAsOf(ctx context.Context, opts GetObjectVersionsAsOf, aliasCache *NodeAliasCache) (_ []ObjectVersionAsOf, err error) {
+	return nil, ErrMethodNotAllowed.New("time travel reads are not supported on %s", p.impl)
+}
+
+// GetObjectVersionsAsOf implements Adapter.
+func (c *CockroachAdapter) GetObjectVersionsAsOf(ctx context.Context, opts GetObjectVersionsAsOf, aliasCache *NodeAliasCache) (_ []ObjectVersionAsOf, err error) {
+	// both queries use the same timestamp, so they read a consistent state.
+	asOf := c.impl.AsOfSystemTime(opts.AsOf)
+
+	var versions []ObjectVersionAsOf
+	var streamIDs []uuid.UUID
+	err = withRows(c.db.QueryContext(ctx, `
+		SELECT
+			version, stream_id, status,
+			created_at, expires_at,
+			segment_count,
+			total_plain_size, total_encrypted_size, fixed_segment_size,
+			encryption,
+			retention_mode, retain_until
+		FROM objects `+asOf+`
+		WHERE (project_id, bucket_name, object_key) = ($1, $2, $3)
+		ORDER BY version ASC
+	`, opts.ProjectID, opts.BucketName, opts.ObjectKey))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var version ObjectVersionAsOf
+			err := rows.Scan(
+				&version.Version, &version.StreamID, &version.Status,
+				&version.CreatedAt, &version.ExpiresAt,
+				&version.SegmentCount,
+				&version.TotalPlainSize, &version.TotalEncryptedSize, &version.FixedSegmentSize,
+				encryptionParameters{&version.Encryption},
+				retentionModeWrapper{&version.Retention.Mode}, timeWrapper{&version.Retention.RetainUntil},
+			)
+			if err != nil {
+				return Error.New("failed to scan objects: %w", err)
+			}
+			version.ObjectStream = ObjectStream{
+				ProjectID:  opts.ProjectID,
+				BucketName: opts.BucketName,
+				ObjectKey:  opts.ObjectKey,
+				Version:    version.Version,
+				StreamID:   version.StreamID,
+			}
+			versions = append(versions, version)
+			streamIDs = append(streamIDs, version.StreamID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to query object versions: %w", err)
+	}
+	if len(versions) == 0 {
+		return nil, nil
+	}
+
+	segments := map[uuid.UUID][]Segment{}
+	err = withRows(c.db.QueryContext(ctx, `
+		SELECT
+			stream_id, position, created_at, expires_at, root_piece_id,
+			encrypted_key_nonce, encrypted_key, encrypted_size,
+			plain_offset, plain_size, encrypted_etag, redundancy,
+			inline_data, remote_alias_pieces, placement
+		FROM segments `+asOf+`
+		WHERE stream_id = ANY($1)
+		ORDER BY stream_id, position ASC
+	`, pgutil.UUIDArray(streamIDs)))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var segment Segment
+			var aliasPieces AliasPieces
+			err := rows.Scan(
+				&segment.StreamID, &segment.Position,
+				&segment.CreatedAt, &segment.ExpiresAt,
+				&segment.RootPieceID, &segment.EncryptedKeyNonce, &segment.EncryptedKey,
+				&segment.EncryptedSize, &segment.PlainOffset, &segment.PlainSize,
+				&segment.EncryptedETag,
+				redundancyScheme{&segment.Redundancy},
+				&segment.InlineData, &aliasPieces,
+				&segment.Placement,
+			)
+			if err != nil {
+				return Error.New("failed to scan segments: %w", err)
+			}
+
+			segment.Pieces, err = aliasCache.ConvertAliasesToPieces(ctx, aliasPieces)
+			if err != nil {
+				return Error.New("failed to convert aliases to pieces: %w", err)
+			}
+			segments[segment.StreamID] = append(segments[segment.StreamID], segment)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to query object segments: %w", err)
+	}
+
+	for i := range versions {
+		versions[i].Segments = segments[versions[i].StreamID]
+	}
+	return versions, nil
+}
+
+// GetObjectVersionsAsOf implements Adapter.
+func (s *SpannerAdapter) GetObjectVersionsAsOf(ctx context.Context, opts GetObjectVersionsAsOf, aliasCache *NodeAliasCache) (_ []ObjectVersionAsOf, err error) {
+	// a read-only transaction reads a consistent state at its timestamp.
+	tx := s.client.ReadOnlyTransaction().WithTimestampBound(spanner.ReadTimestamp(opts.AsOf))
+	defer tx.Close()
+
+	versions, err := spannerutil.CollectRows(tx.Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT
+				version, stream_id, status,
+				created_at, expires_at,
+				segment_count,
+				total_plain_size, total_encrypted_size, fixed_segment_size,
+				encryption,
+				retention_mode, retain_until
+			FROM objects
+			WHERE (project_id, bucket_name, object_key) = (@project_id, @bucket_name, @object_key)
+			ORDER BY version ASC
+		`,
+		Params: map[string]any{
+			"project_id":  opts.ProjectID,
+			"bucket_name": opts.BucketName,
+			"object_key":  opts.ObjectKey,
+		},
+	}), func(row *spanner.Row, version *ObjectVersionAsOf) error {
+		err := row.Columns(
+			&version.Version, &version.StreamID, &version.Status,
+			&version.CreatedAt, &version.ExpiresAt,
+			spannerutil.Int(&version.SegmentCount),
+			&version.TotalPlainSize, &version.TotalEncryptedSize, spannerutil.Int(&version.FixedSegmentSize),
+			encryptionParameters{&version.Encryption},
+			retentionModeWrapper{&version.Retention.Mode}, timeWrapper{&version.Retention.RetainUntil},
+		)
+		if err != nil {
+			return Error.New("failed to read objects: %w", err)
+		}
+		version.ObjectStream = ObjectStream{
+			ProjectID:  opts.ProjectID,
+			BucketName: opts.BucketName,
+			ObjectKey:  opts.ObjectKey,
+			Version:    version.Version,
+			StreamID:   version.StreamID,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to query object versions: %w", err)
+	}
+	if len(versions) == 0 {
+		return nil, nil
+	}
+
+	streamIDs := make([][]byte, 0, len(versions))
+	for _, version := range versions {
+		streamIDs = append(streamIDs, version.StreamID.Bytes())
+	}
+
+	segments := map[uuid.UUID][]Segment{}
+	err = tx.Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT
+				stream_id, position, created_at, expires_at, root_piece_id,
+				encrypted_key_nonce, encrypted_key, encrypted_size,
+				plain_offset, plain_size, encrypted_etag, redundancy,
+				inline_data, remote_alias_pieces, placement
+			FROM segments
+			WHERE stream_id IN UNNEST(@stream_ids)
+			ORDER BY stream_id, position ASC
+		`,
+		Params: map[string]any{
+			"stream_ids": streamIDs,
+		},
+	}).Do(func(row *spanner.Row) error {
+		var segment Segment
+		var aliasPieces AliasPieces
+		err := row.Columns(
+			&segment.StreamID, &segment.Position,
+			&segment.CreatedAt, &segment.ExpiresAt,
+			&segment.RootPieceID, &segment.EncryptedKeyNonce, &segment.EncryptedKey,
+			spannerutil.Int(&segment.EncryptedSize), &segment.PlainOffset, spannerutil.Int(&segment.PlainSize),
+			&segment.EncryptedETag,
+			redundancyScheme{&segment.Redundancy},
+			&segment.InlineData, &aliasPieces,
+			spannerutil.Int(&segment.Placement),
+		)
+		if err != nil {
+			return Error.New("failed to read segments: %w", err)
+		}
+
+		segment.Pieces, err = aliasCache.ConvertAliasesToPieces(ctx, aliasPieces)
+		if err != nil {
+			return Error.New("failed to convert aliases to pieces: %w", err)
+		}
+		segments[segment.StreamID] = append(segments[segment.StreamID], segment)
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to query object segments: %w", err)
+	}
+
+	for i := range versions {
+		versions[i].Segments = segments[versions[i].StreamID]
+	}
+	return versions, nil
+}
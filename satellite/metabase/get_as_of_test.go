@@ -0,0 +1,113 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+	"storj.io/storj/shared/dbutil"
+)
+
+func TestGetObjectVersionsAsOf(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+		location := obj.Location()
+
+		for _, test := range metabasetest.InvalidObjectLocations(location) {
+			test := test
+			t.Run(test.Name, func(t *testing.T) {
+				defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+				_, err := db.GetObjectVersionsAsOf(ctx, metabase.GetObjectVersionsAsOf{
+					ObjectLocation: test.ObjectLocation,
+					AsOf:           time.Now().Add(-time.Minute),
+				})
+				require.True(t, test.ErrClass.Has(err), err)
+				require.ErrorContains(t, err, test.ErrText)
+			})
+		}
+
+		t.Run("invalid as of", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			_, err := db.GetObjectVersionsAsOf(ctx, metabase.GetObjectVersionsAsOf{
+				ObjectLocation: location,
+			})
+			require.True(t, metabase.ErrInvalidRequest.Has(err), err)
+
+			_, err = db.GetObjectVersionsAsOf(ctx, metabase.GetObjectVersionsAsOf{
+				ObjectLocation: location,
+				AsOf:           time.Now().Add(time.Hour),
+			})
+			require.True(t, metabase.ErrInvalidRequest.Has(err), err)
+		})
+
+		if db.Implementation() == dbutil.Postgres {
+			t.Run("not supported", func(t *testing.T) {
+				defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+				_, err := db.GetObjectVersionsAsOf(ctx, metabase.GetObjectVersionsAsOf{
+					ObjectLocation: location,
+					AsOf:           time.Now().Add(-time.Second),
+				})
+				require.True(t, metabase.ErrMethodNotAllowed.Has(err), err)
+			})
+			return
+		}
+
+		t.Run("deleted object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			beforeCreate := time.Now()
+			time.Sleep(time.Second)
+
+			object, segments := metabasetest.CreateTestObject{}.Run(ctx, t, db, obj, 2)
+
+			time.Sleep(time.Second)
+			beforeDelete := time.Now()
+			time.Sleep(time.Second)
+
+			_, err := db.DeleteObjectExactVersion(ctx, metabase.DeleteObjectExactVersion{
+				ObjectLocation: location,
+				Version:        obj.Version,
+			})
+			require.NoError(t, err)
+
+			versions, err := db.GetObjectVersionsAsOf(ctx, metabase.GetObjectVersionsAsOf{
+				ObjectLocation: location,
+				AsOf:           beforeCreate,
+			})
+			require.NoError(t, err)
+			require.Empty(t, versions)
+
+			versions, err = db.GetObjectVersionsAsOf(ctx, metabase.GetObjectVersionsAsOf{
+				ObjectLocation: location,
+				AsOf:           beforeDelete,
+			})
+			require.NoError(t, err)
+			require.Len(t, versions, 1)
+
+			version := versions[0]
+			require.Equal(t, object.ObjectStream, version.ObjectStream)
+			require.Equal(t, object.Status, version.Status)
+			require.Equal(t, object.SegmentCount, version.SegmentCount)
+			require.Equal(t, object.TotalEncryptedSize, version.TotalEncryptedSize)
+			require.WithinDuration(t, object.CreatedAt, version.CreatedAt, time.Second)
+
+			require.Len(t, version.Segments, len(segments))
+			for i, segment := range version.Segments {
+				require.Equal(t, segments[i].Position, segment.Position)
+				require.Equal(t, segments[i].RootPieceID, segment.RootPieceID)
+				require.Equal(t, segments[i].EncryptedSize, segment.EncryptedSize)
+				require.Equal(t, segments[i].Pieces, segment.Pieces)
+			}
+		})
+	})
+}
@@ -0,0 +1,212 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+
+	"cloud.google.com/go/spanner"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/shared/dbutil/pgutil"
+	"storj.io/storj/shared/dbutil/spannerutil"
+	"storj.io/storj/shared/tagsql"
+)
+
+// GetObjectsLastCommitted contains arguments necessary for fetching
+// the last committed versions of multiple objects.
+type GetObjectsLastCommitted struct {
+	Locations []ObjectLocation
+}
+
+// Verify verifies get objects last committed request fields.
+func (opts *GetObjectsLastCommitted) Verify() error {
+	switch {
+	case len(opts.Locations) == 0:
+		return ErrInvalidRequest.New("Locations missing")
+	case len(opts.Locations) > ListLimit.Max():
+		return ErrInvalidRequest.New("Locations should not contain more than %d items", ListLimit.Max())
+	}
+
+	for i, location := range opts.Locations {
+		if err := location.Verify(); err != nil {
+			return ErrInvalidRequest.New("Locations[%d]: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// GetObjectsLastCommitted returns the last committed versions of multiple objects
+// using a single query per adapter. It's the batched equivalent of GetObjectLastCommitted.
+//
+// Objects which don't exist, or whose last committed version is a delete marker,
+// are missing from the result.
+func (db *DB) GetObjectsLastCommitted(ctx context.Context, opts GetObjectsLastCommitted) (_ map[ObjectLocation]Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return nil, err
+	}
+
+	var adapters []Adapter
+	locations := map[Adapter][]ObjectLocation{}
+	for _, location := range opts.Locations {
+		adapter := db.ChooseAdapter(location.ProjectID)
+		if _, ok := locations[adapter]; !ok {
+			adapters = append(adapters, adapter)
+		}
+		locations[adapter] = append(locations[adapter], location)
+	}
+
+	result := make(map[ObjectLocation]Object, len(opts.Locations))
+	for _, adapter := range adapters {
+		objects, err := adapter.GetObjectsLastCommitted(ctx, locations[adapter])
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range objects {
+			if object.Status.IsDeleteMarker() {
+				continue
+			}
+			if err := object.Retention.Verify(); err != nil {
+				return nil, Error.Wrap(err)
+			}
+			if err := db.metadataEncryption.decryptObject(&object); err != nil {
+				return nil, err
+			}
+			result[object.Location()] = object
+		}
+	}
+
+	return result, nil
+}
+
+// GetObjectsLastCommitted implements Adapter.
+func (p *PostgresAdapter) GetObjectsLastCommitted(ctx context.Context, locations []ObjectLocation) (objects []Object, err error) {
+	projectIDs := make([]uuid.UUID, len(locations))
+	bucketNames := make([][]byte, len(locations))
+	objectKeys := make([][]byte, len(locations))
+	for i, location := range locations {
+		projectIDs[i] = location.ProjectID
+		bucketNames[i] = []byte(location.BucketName)
+		objectKeys[i] = []byte(location.ObjectKey)
+	}
+
+	err = withRows(p.db.QueryContext(ctx, `
+		SELECT DISTINCT ON (project_id, bucket_name, object_key)
+			project_id, bucket_name, object_key,
+			stream_id, version, status,
+			created_at, expires_at,
+			segment_count,
+			encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
+			total_plain_size, total_encrypted_size, fixed_segment_size,
+			encryption,
+			retention_mode, retain_until,
+			checksum
+		FROM objects
+		WHERE
+			(project_id, bucket_name, object_key) IN (SELECT unnest($1::BYTEA[]), unnest($2::BYTEA[]), unnest($3::BYTEA[])) AND
+			status <> `+statusPending+` AND
+			(expires_at IS NULL OR expires_at > now())
+		ORDER BY project_id, bucket_name, object_key, version DESC
+	`, pgutil.UUIDArray(projectIDs), pgutil.ByteaArray(bucketNames), pgutil.ByteaArray(objectKeys),
+	))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var object Object
+			err := rows.Scan(
+				&object.ProjectID, &object.BucketName, &object.ObjectKey,
+				&object.StreamID, &object.Version, &object.Status,
+				&object.CreatedAt, &object.ExpiresAt,
+				&object.SegmentCount,
+				&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
+				&object.TotalPlainSize, &object.TotalEncryptedSize, &object.FixedSegmentSize,
+				encryptionParameters{&object.Encryption},
+				retentionModeWrapper{&object.Retention.Mode}, timeWrapper{&object.Retention.RetainUntil},
+				&object.Checksum,
+			)
+			if err != nil {
+				return err
+			}
+			objects = append(objects, object)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to query objects: %w", err)
+	}
+	return objects, nil
+}
+
+// GetObjectsLastCommitted implements Adapter.
+func (s *SpannerAdapter) GetObjectsLastCommitted(ctx context.Context, locations []ObjectLocation) (objects []Object, err error) {
+	type objectLocation struct {
+		ProjectID  uuid.UUID
+		BucketName BucketName
+		ObjectKey  []byte
+	}
+
+	params := make([]objectLocation, len(locations))
+	for i, location := range locations {
+		params[i] = objectLocation{
+			ProjectID:  location.ProjectID,
+			BucketName: location.BucketName,
+			ObjectKey:  []byte(location.ObjectKey),
+		}
+	}
+
+	err = s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT
+				project_id, bucket_name, object_key,
+				stream_id, version, status,
+				created_at, expires_at,
+				segment_count,
+				encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
+				total_plain_size, total_encrypted_size, fixed_segment_size,
+				encryption,
+				retention_mode, retain_until,
+				checksum
+			FROM objects
+			WHERE
+				STRUCT<ProjectID BYTES, BucketName STRING, ObjectKey BYTES>(project_id, bucket_name, object_key) IN UNNEST(@locations) AND
+				status <> ` + statusPending + ` AND
+				(expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP) AND
+				version = (
+					SELECT MAX(o.version)
+					FROM objects AS o
+					WHERE
+						o.project_id = objects.project_id AND
+						o.bucket_name = objects.bucket_name AND
+						o.object_key = objects.object_key AND
+						o.status <> ` + statusPending + ` AND
+						(o.expires_at IS NULL OR o.expires_at > CURRENT_TIMESTAMP)
+				)
+		`,
+		Params: map[string]any{
+			"locations": params,
+		},
+	}).Do(func(row *spanner.Row) error {
+		var object Object
+		err := row.Columns(
+			&object.ProjectID, &object.BucketName, &object.ObjectKey,
+			&object.StreamID, &object.Version, &object.Status,
+			&object.CreatedAt, &object.ExpiresAt,
+			spannerutil.Int(&object.SegmentCount),
+			&object.EncryptedMetadataNonce, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey,
+			&object.TotalPlainSize, &object.TotalEncryptedSize, spannerutil.Int(&object.FixedSegmentSize),
+			encryptionParameters{&object.Encryption},
+			retentionModeWrapper{&object.Retention.Mode}, timeWrapper{&object.Retention.RetainUntil},
+			&object.Checksum,
+		)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		objects = append(objects, object)
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to query objects: %w", err)
+	}
+	return objects, nil
+}
@@ -559,6 +559,250 @@ func TestGetObjectLastCommitted(t *testing.T) {
 	})
 }
 
+func TestGetObjectsLastCommitted(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+
+		t.Run("invalid", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.GetObjectsLastCommitted{
+				Opts:     metabase.GetObjectsLastCommitted{},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "Locations missing",
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectsLastCommitted{
+				Opts: metabase.GetObjectsLastCommitted{
+					Locations: []metabase.ObjectLocation{obj.Location(), {ProjectID: obj.ProjectID}},
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "Locations[1]: BucketName missing",
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectsLastCommitted{
+				Opts: metabase.GetObjectsLastCommitted{
+					Locations: make([]metabase.ObjectLocation, metabase.ListLimit.Max()+1),
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "Locations should not contain more than 1000 items",
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("get objects", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			unversioned := obj
+			unversioned.ObjectKey = "a"
+			unversionedObject := metabasetest.CreateObject(ctx, t, db, unversioned, 1)
+
+			versioned := obj
+			versioned.ObjectKey = "b"
+			versioned.Version = 1
+			metabasetest.CreateObjectVersioned(ctx, t, db, versioned, 0)
+			versioned.Version = 2
+			versioned.StreamID = testrand.UUID()
+			versionedObject := metabasetest.CreateObjectVersioned(ctx, t, db, versioned, 0)
+
+			pending := obj
+			pending.ObjectKey = "c"
+			metabasetest.BeginObjectExactVersion{
+				Opts: metabase.BeginObjectExactVersion{
+					ObjectStream: pending,
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+			}.Check(ctx, t, db)
+
+			deleted := obj
+			deleted.ObjectKey = "d"
+			deleted.Version = 1
+			metabasetest.CreateObjectVersioned(ctx, t, db, deleted, 0)
+			_, err := db.DeleteObjectLastCommitted(ctx, metabase.DeleteObjectLastCommitted{
+				ObjectLocation: deleted.Location(),
+				Versioned:      true,
+			})
+			require.NoError(t, err)
+
+			missing := obj
+			missing.ObjectKey = "e"
+
+			otherProject := metabasetest.RandObjectStream()
+			otherProjectObject := metabasetest.CreateObject(ctx, t, db, otherProject, 0)
+
+			metabasetest.GetObjectsLastCommitted{
+				Opts: metabase.GetObjectsLastCommitted{
+					Locations: []metabase.ObjectLocation{
+						unversioned.Location(),
+						versioned.Location(),
+						pending.Location(),
+						deleted.Location(),
+						missing.Location(),
+						otherProject.Location(),
+						unversioned.Location(),
+					},
+				},
+				Result: map[metabase.ObjectLocation]metabase.Object{
+					unversioned.Location():  unversionedObject,
+					versioned.Location():    versionedObject,
+					otherProject.Location(): otherProjectObject,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectsLastCommitted{
+				Opts: metabase.GetObjectsLastCommitted{
+					Locations: []metabase.ObjectLocation{missing.Location()},
+				},
+				Result: map[metabase.ObjectLocation]metabase.Object{},
+			}.Check(ctx, t, db)
+		})
+	})
+}
+
+func TestGetObjectWithSegment(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+		location := obj.Location()
+
+		for _, test := range metabasetest.InvalidObjectLocations(location) {
+			test := test
+			t.Run(test.Name, func(t *testing.T) {
+				defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+				metabasetest.GetObjectWithSegment{
+					Opts: metabase.GetObjectWithSegment{
+						ObjectLocation: test.ObjectLocation,
+					},
+					ErrClass: test.ErrClass,
+					ErrText:  test.ErrText,
+				}.Check(ctx, t, db)
+				metabasetest.Verify{}.Check(ctx, t, db)
+			})
+		}
+
+		t.Run("Object missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+			metabasetest.GetObjectWithSegment{
+				Opts: metabase.GetObjectWithSegment{
+					ObjectLocation: location,
+				},
+				ErrClass: &metabase.ErrObjectNotFound,
+				ErrText:  "metabase: sql: no rows in result set",
+			}.Check(ctx, t, db)
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("Get single remote segment object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 1)
+			segment := metabase.Segment(metabasetest.DefaultRawSegment(obj, metabase.SegmentPosition{}))
+
+			metabasetest.GetObjectWithSegment{
+				Opts: metabase.GetObjectWithSegment{
+					ObjectLocation: location,
+				},
+				Result: metabase.ObjectWithSegment{
+					Object:  object,
+					Segment: &segment,
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("Get single inline segment object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			inlineData := testrand.Bytes(100)
+			object := metabasetest.CommitSingleObject{
+				Opts: metabase.CommitSingleObject{
+					ObjectStream:      obj,
+					Encryption:        metabasetest.DefaultEncryption,
+					EncryptedKey:      []byte{3},
+					EncryptedKeyNonce: []byte{4},
+					PlainSize:         512,
+					InlineData:        inlineData,
+				},
+				ExpectVersion: 1,
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectWithSegment{
+				Opts: metabase.GetObjectWithSegment{
+					ObjectLocation: location,
+				},
+				Result: metabase.ObjectWithSegment{
+					Object: object,
+					Segment: &metabase.Segment{
+						StreamID:  obj.StreamID,
+						CreatedAt: time.Now(),
+
+						EncryptedKey:      []byte{3},
+						EncryptedKeyNonce: []byte{4},
+
+						EncryptedSize: int32(len(inlineData)),
+						PlainSize:     512,
+						InlineData:    inlineData,
+					},
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("Get multiple segment object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 2)
+
+			metabasetest.GetObjectWithSegment{
+				Opts: metabase.GetObjectWithSegment{
+					ObjectLocation: location,
+				},
+				Result: metabase.ObjectWithSegment{
+					Object: object,
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("Get latest version", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.CreateObjectVersioned(ctx, t, db, obj, 1)
+
+			newer := obj
+			newer.Version = obj.Version + 1
+			newer.StreamID = testrand.UUID()
+			object := metabasetest.CreateObjectVersioned(ctx, t, db, newer, 1)
+			segment := metabase.Segment(metabasetest.DefaultRawSegment(newer, metabase.SegmentPosition{}))
+
+			metabasetest.GetObjectWithSegment{
+				Opts: metabase.GetObjectWithSegment{
+					ObjectLocation: location,
+				},
+				Result: metabase.ObjectWithSegment{
+					Object:  object,
+					Segment: &segment,
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("Get delete marker", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.CreateObjectVersioned(ctx, t, db, obj, 1)
+
+			_, err := db.DeleteObjectLastCommitted(ctx, metabase.DeleteObjectLastCommitted{
+				ObjectLocation: location,
+				Versioned:      true,
+			})
+			require.NoError(t, err)
+
+			metabasetest.GetObjectWithSegment{
+				Opts: metabase.GetObjectWithSegment{
+					ObjectLocation: location,
+				},
+				ErrClass: &metabase.ErrObjectNotFound,
+				ErrText:  "metabase: sql: no rows in result set",
+			}.Check(ctx, t, db)
+		})
+	})
+}
+
 func TestGetSegmentByPosition(t *testing.T) {
 	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
 		obj := metabasetest.RandObjectStream()
@@ -0,0 +1,148 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+
+	"cloud.google.com/go/spanner"
+	"github.com/jackc/pgx/v5"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/shared/dbutil/pgxutil"
+)
+
+// ImportObject is an object version inserted as it is by ImportObjects.
+type ImportObject struct {
+	RawObject
+
+	LegalHold bool
+}
+
+// importObject is an ImportObject with its metadata wrapped for storing.
+type importObject struct {
+	ImportObject
+
+	// encryptedMetadataKeyID is the key the metadata is wrapped with.
+	encryptedMetadataKeyID uint16
+}
+
+// ImportObjects inserts the objects as they are, including their retention and
+// legal hold, e.g. when restoring them from an export.
+//
+// The objects aren't verified and the import fails when any of them already exists.
+// The metadata is expected without the envelope, as it's exported, and it's
+// encrypted with the active metadata encryption key.
+func (db *DB) ImportObjects(ctx context.Context, objects []ImportObject) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	byAdapter := make(map[Adapter][]importObject)
+	for _, imported := range objects {
+		object := importObject{ImportObject: imported}
+
+		var err error
+		object.encryptedMetadataKeyID, err = db.metadataEncryption.encryptMetadata(object.ProjectID, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		adapter := db.ChooseAdapter(object.ProjectID)
+		byAdapter[adapter] = append(byAdapter[adapter], object)
+	}
+	for adapter, objects := range byAdapter {
+		if err := adapter.ImportObjects(ctx, objects); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// ImportSegments inserts the segments of the project's objects as they are, e.g.
+// when restoring them from an export.
+//
+// The segments aren't verified and the import fails when any of them already exists.
+func (db *DB) ImportSegments(ctx context.Context, projectID uuid.UUID, segments []RawSegment) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return Error.Wrap(db.ChooseAdapter(projectID).ImportSegments(ctx, db.aliasCache, segments))
+}
+
+// ImportObjects implements Adapter.
+func (p *PostgresAdapter) ImportObjects(ctx context.Context, objects []importObject) (err error) {
+	if len(objects) == 0 {
+		return nil
+	}
+
+	return Error.Wrap(pgxutil.Conn(ctx, p.db, func(conn *pgx.Conn) error {
+		source := newCopyFromImportObjects(objects)
+		_, err := conn.CopyFrom(ctx, pgx.Identifier{"objects"}, source.Columns(), source)
+		return err
+	}))
+}
+
+// ImportObjects implements Adapter.
+func (s *SpannerAdapter) ImportObjects(ctx context.Context, objects []importObject) (err error) {
+	if len(objects) == 0 {
+		return nil
+	}
+
+	source := newCopyFromImportObjects(objects)
+	mutations := make([]*spanner.Mutation, 0, len(objects))
+	for source.Next() {
+		values, err := source.Values()
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		for i := range values {
+			if v, ok := values[i].(int32); ok {
+				values[i] = int64(v)
+			}
+		}
+		mutations = append(mutations, spanner.Insert("objects", source.Columns(), values))
+	}
+
+	_, err = s.client.Apply(ctx, mutations)
+	return Error.Wrap(err)
+}
+
+// copyFromImportObjects extends the raw objects with their object lock columns.
+type copyFromImportObjects struct {
+	*copyFromRawObjects
+	rows []importObject
+}
+
+func newCopyFromImportObjects(rows []importObject) *copyFromImportObjects {
+	objects := make([]RawObject, len(rows))
+	for i := range rows {
+		objects[i] = rows[i].RawObject
+	}
+	return &copyFromImportObjects{
+		copyFromRawObjects: newCopyFromRawObjects(objects),
+		rows:               rows,
+	}
+}
+
+func (ctr *copyFromImportObjects) Columns() []string {
+	return append(ctr.copyFromRawObjects.Columns(),
+		"retention_mode",
+		"retain_until",
+		"legal_hold",
+		"encrypted_metadata_key_id",
+	)
+}
+
+func (ctr *copyFromImportObjects) Values() ([]any, error) {
+	values, err := ctr.copyFromRawObjects.Values()
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &ctr.rows[ctr.idx]
+	return append(values,
+		retentionModeWrapper{&obj.Retention.Mode},
+		timeWrapper{&obj.Retention.RetainUntil},
+		obj.LegalHold,
+		int32(obj.encryptedMetadataKeyID),
+	), nil
+}
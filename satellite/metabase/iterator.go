@@ -28,6 +28,8 @@ type objectsIterator struct {
 	recursive             bool
 	includeCustomMetadata bool
 	includeSystemMetadata bool
+	includeTags           bool
+	includeObjectLock     bool
 
 	curIndex int
 	curRows  tagsql.Rows
@@ -63,6 +65,8 @@ func iterateAllVersionsWithStatusDescending(ctx context.Context, adapter Adapter
 		recursive:             opts.Recursive,
 		includeCustomMetadata: opts.IncludeCustomMetadata,
 		includeSystemMetadata: opts.IncludeSystemMetadata,
+		includeTags:           opts.IncludeTags,
+		includeObjectLock:     opts.IncludeObjectLock,
 
 		curIndex: 0,
 		cursor:   FirstIterateCursor(opts.Recursive, opts.Cursor, opts.Prefix),
@@ -95,6 +99,8 @@ func iterateAllVersionsWithStatusAscending(ctx context.Context, adapter Adapter,
 		recursive:             opts.Recursive,
 		includeCustomMetadata: opts.IncludeCustomMetadata,
 		includeSystemMetadata: opts.IncludeSystemMetadata,
+		includeTags:           opts.IncludeTags,
+		includeObjectLock:     opts.IncludeObjectLock,
 
 		curIndex: 0,
 		cursor:   FirstIterateCursor(opts.Recursive, opts.Cursor, opts.Prefix),
@@ -620,6 +626,18 @@ func querySelectorFields(objectKeyColumn string, it *objectsIterator) string {
 			,encrypted_metadata_encrypted_key`
 	}
 
+	if it.includeTags {
+		querySelectFields += `
+			,tags`
+	}
+
+	if it.includeObjectLock {
+		querySelectFields += `
+			,retention_mode
+			,retain_until
+			,legal_hold`
+	}
+
 	return querySelectFields
 }
 
@@ -714,6 +732,18 @@ func (it *objectsIterator) scanItem(item *ObjectEntry) (err error) {
 		)
 	}
 
+	if it.includeTags {
+		fields = append(fields, &item.Tags)
+	}
+
+	if it.includeObjectLock {
+		fields = append(fields,
+			retentionModeWrapper{&item.Retention.Mode},
+			timeWrapper{&item.Retention.RetainUntil},
+			&item.LegalHold,
+		)
+	}
+
 	err = it.curRows.Scan(fields...)
 
 	if err != nil {
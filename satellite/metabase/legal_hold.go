@@ -0,0 +1,445 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/zeebo/errs"
+	"google.golang.org/api/iterator"
+
+	"storj.io/storj/shared/dbutil/spannerutil"
+)
+
+// objectLockInfo contains the Object Lock configuration of an object version.
+type objectLockInfo struct {
+	Retention Retention
+	LegalHold bool
+}
+
+// verifyDeletion returns an error if the Object Lock configuration prohibits
+// the deletion of the object version.
+func (info *objectLockInfo) verifyDeletion() error {
+	if err := info.Retention.Verify(); err != nil {
+		return Error.Wrap(err)
+	}
+	switch {
+	case info.LegalHold:
+		return ErrObjectLock.New(objectLegalHoldErrMsg)
+	case info.Retention.Active():
+		return ErrObjectLock.New(objectLockedErrMsg)
+	}
+	return nil
+}
+
+// GetObjectExactVersionLegalHold contains arguments necessary for retrieving
+// the legal hold status of an exact version of an object.
+type GetObjectExactVersionLegalHold struct {
+	ObjectLocation
+	Version Version
+}
+
+// GetObjectExactVersionLegalHold returns whether an exact version of an object is under legal hold.
+func (db *DB) GetObjectExactVersionLegalHold(ctx context.Context, opts GetObjectExactVersionLegalHold) (enabled bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return false, err
+	}
+
+	return db.ChooseAdapter(opts.ProjectID).GetObjectExactVersionLegalHold(ctx, opts)
+}
+
+// GetObjectExactVersionLegalHold returns whether an exact version of an object is under legal hold.
+func (p *PostgresAdapter) GetObjectExactVersionLegalHold(ctx context.Context, opts GetObjectExactVersionLegalHold) (enabled bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = p.db.QueryRowContext(ctx, `
+		SELECT legal_hold
+		FROM objects
+		WHERE
+			(project_id, bucket_name, object_key, version) = ($1, $2, $3, $4)
+			AND status <> `+statusPending,
+		opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.Version,
+	).Scan(&enabled)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, ErrObjectNotFound.Wrap(Error.Wrap(err))
+		}
+		return false, Error.New("unable to query object legal hold: %w", err)
+	}
+
+	return enabled, nil
+}
+
+// GetObjectExactVersionLegalHold returns whether an exact version of an object is under legal hold.
+func (s *SpannerAdapter) GetObjectExactVersionLegalHold(ctx context.Context, opts GetObjectExactVersionLegalHold) (enabled bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	enabled, err = spannerutil.CollectRow(s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT legal_hold
+			FROM objects
+			WHERE
+				(project_id, bucket_name, object_key, version) = (@project_id, @bucket_name, @object_key, @version)
+				AND status <> ` + statusPending,
+		Params: map[string]interface{}{
+			"project_id":  opts.ProjectID,
+			"bucket_name": opts.BucketName,
+			"object_key":  opts.ObjectKey,
+			"version":     opts.Version,
+		},
+	}), func(row *spanner.Row, enabled *bool) error {
+		return Error.Wrap(row.Columns(enabled))
+	})
+	if err != nil {
+		if errors.Is(err, iterator.Done) {
+			return false, ErrObjectNotFound.Wrap(Error.Wrap(sql.ErrNoRows))
+		}
+		return false, Error.New("unable to query object legal hold: %w", err)
+	}
+
+	return enabled, nil
+}
+
+// GetObjectLastCommittedLegalHold contains arguments necessary for retrieving
+// the legal hold status of the most recently committed version of an object.
+type GetObjectLastCommittedLegalHold struct {
+	ObjectLocation
+}
+
+// GetObjectLastCommittedLegalHold returns whether the most recently committed
+// version of an object is under legal hold.
+func (db *DB) GetObjectLastCommittedLegalHold(ctx context.Context, opts GetObjectLastCommittedLegalHold) (enabled bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return false, err
+	}
+
+	return db.ChooseAdapter(opts.ProjectID).GetObjectLastCommittedLegalHold(ctx, opts)
+}
+
+// GetObjectLastCommittedLegalHold returns whether the most recently committed
+// version of an object is under legal hold.
+func (p *PostgresAdapter) GetObjectLastCommittedLegalHold(ctx context.Context, opts GetObjectLastCommittedLegalHold) (enabled bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = p.db.QueryRowContext(ctx, `
+		SELECT legal_hold
+		FROM objects
+		WHERE
+			(project_id, bucket_name, object_key) = ($1, $2, $3)
+			AND status <> `+statusPending+`
+		ORDER BY version DESC
+		LIMIT 1
+		`, opts.ProjectID, opts.BucketName, opts.ObjectKey,
+	).Scan(&enabled)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, ErrObjectNotFound.Wrap(Error.Wrap(err))
+		}
+		return false, Error.New("unable to query object legal hold: %w", err)
+	}
+
+	return enabled, nil
+}
+
+// GetObjectLastCommittedLegalHold returns whether the most recently committed
+// version of an object is under legal hold.
+func (s *SpannerAdapter) GetObjectLastCommittedLegalHold(ctx context.Context, opts GetObjectLastCommittedLegalHold) (enabled bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	enabled, err = spannerutil.CollectRow(s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT legal_hold
+			FROM objects
+			WHERE
+				(project_id, bucket_name, object_key) = (@project_id, @bucket_name, @object_key)
+				AND status <> ` + statusPending + `
+			ORDER BY version DESC
+			LIMIT 1
+		`,
+		Params: map[string]interface{}{
+			"project_id":  opts.ProjectID,
+			"bucket_name": opts.BucketName,
+			"object_key":  opts.ObjectKey,
+		},
+	}), func(row *spanner.Row, enabled *bool) error {
+		return Error.Wrap(row.Columns(enabled))
+	})
+	if err != nil {
+		if errors.Is(err, iterator.Done) {
+			return false, ErrObjectNotFound.Wrap(Error.Wrap(sql.ErrNoRows))
+		}
+		return false, Error.New("unable to query object legal hold: %w", err)
+	}
+
+	return enabled, nil
+}
+
+// preUpdateLegalHoldInfo contains information about an object that is collected
+// before updating the object's legal hold status.
+type preUpdateLegalHoldInfo struct {
+	Status    ObjectStatus
+	ExpiresAt *time.Time
+}
+
+// verify returns an error if the object's legal hold status shouldn't be updated.
+func (info *preUpdateLegalHoldInfo) verify() error {
+	if !info.Status.IsCommitted() {
+		return ErrObjectStatus.New(noLockOnUncommittedErrMsg)
+	}
+	if info.ExpiresAt != nil {
+		return ErrObjectExpiration.New(noLockWithExpirationErrMsg)
+	}
+	return nil
+}
+
+// SetObjectExactVersionLegalHold contains arguments necessary for placing
+// or removing a legal hold on an exact version of an object.
+type SetObjectExactVersionLegalHold struct {
+	ObjectLocation
+	Version Version
+
+	Enabled bool
+}
+
+// SetObjectExactVersionLegalHold places or removes a legal hold on an exact version of an object.
+//
+// Unlike a retention period, a legal hold has no expiration and can be removed at any time.
+func (db *DB) SetObjectExactVersionLegalHold(ctx context.Context, opts SetObjectExactVersionLegalHold) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	return db.ChooseAdapter(opts.ProjectID).SetObjectExactVersionLegalHold(ctx, opts)
+}
+
+// SetObjectExactVersionLegalHold places or removes a legal hold on an exact version of an object.
+func (p *PostgresAdapter) SetObjectExactVersionLegalHold(ctx context.Context, opts SetObjectExactVersionLegalHold) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var info preUpdateLegalHoldInfo
+	err = p.db.QueryRowContext(ctx, `
+		SELECT status, expires_at
+		FROM objects
+		WHERE
+			(project_id, bucket_name, object_key, version) = ($1, $2, $3, $4)
+		`, opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.Version,
+	).Scan(&info.Status, &info.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrObjectNotFound.New("")
+		}
+		return Error.New("unable to query object info before setting legal hold: %w", err)
+	}
+
+	if err = info.verify(); err != nil {
+		return errs.Wrap(err)
+	}
+
+	return errs.Wrap(p.setObjectExactVersionLegalHold(ctx, opts))
+}
+
+func (p *PostgresAdapter) setObjectExactVersionLegalHold(ctx context.Context, opts SetObjectExactVersionLegalHold) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	res, err := p.db.ExecContext(ctx, `
+		UPDATE objects
+		SET legal_hold = $5
+		WHERE
+			(project_id, bucket_name, object_key, version) = ($1, $2, $3, $4)
+		`, opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.Version, opts.Enabled,
+	)
+	if err != nil {
+		return Error.New("unable to update object legal hold: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return Error.New("unable to get number of affected objects: %w", err)
+	}
+	if affected == 0 {
+		return ErrObjectNotFound.New("")
+	}
+
+	return nil
+}
+
+// SetObjectExactVersionLegalHold places or removes a legal hold on an exact version of an object.
+func (s *SpannerAdapter) SetObjectExactVersionLegalHold(ctx context.Context, opts SetObjectExactVersionLegalHold) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	info, err := spannerutil.CollectRow(s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT status, expires_at
+			FROM objects
+			WHERE
+				(project_id, bucket_name, object_key, version) = (@project_id, @bucket_name, @object_key, @version)
+		`,
+		Params: map[string]interface{}{
+			"project_id":  opts.ProjectID,
+			"bucket_name": opts.BucketName,
+			"object_key":  opts.ObjectKey,
+			"version":     opts.Version,
+		},
+	}), func(row *spanner.Row, item *preUpdateLegalHoldInfo) error {
+		return Error.Wrap(row.Columns(&item.Status, &item.ExpiresAt))
+	})
+	if err != nil {
+		if errors.Is(err, iterator.Done) {
+			return ErrObjectNotFound.New("")
+		}
+		return Error.New("unable to query object info before setting legal hold: %w", err)
+	}
+
+	if err = info.verify(); err != nil {
+		return errs.Wrap(err)
+	}
+
+	return errs.Wrap(s.setObjectExactVersionLegalHold(ctx, opts))
+}
+
+func (s *SpannerAdapter) setObjectExactVersionLegalHold(ctx context.Context, opts SetObjectExactVersionLegalHold) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var affected int64
+	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		affected, err = tx.Update(ctx, spanner.Statement{
+			SQL: `
+				UPDATE objects
+				SET legal_hold = @legal_hold
+				WHERE
+					(project_id, bucket_name, object_key, version) = (@project_id, @bucket_name, @object_key, @version)
+			`,
+			Params: map[string]interface{}{
+				"project_id":  opts.ProjectID,
+				"bucket_name": opts.BucketName,
+				"object_key":  opts.ObjectKey,
+				"version":     opts.Version,
+				"legal_hold":  opts.Enabled,
+			},
+		})
+		return errs.Wrap(err)
+	})
+	if err != nil {
+		return Error.New("unable to update object legal hold: %w", err)
+	}
+
+	if affected == 0 {
+		return ErrObjectNotFound.New("")
+	}
+
+	return nil
+}
+
+// SetObjectLastCommittedLegalHold contains arguments necessary for placing
+// or removing a legal hold on the most recently committed version of an object.
+type SetObjectLastCommittedLegalHold struct {
+	ObjectLocation
+
+	Enabled bool
+}
+
+// SetObjectLastCommittedLegalHold places or removes a legal hold on the most
+// recently committed version of an object.
+func (db *DB) SetObjectLastCommittedLegalHold(ctx context.Context, opts SetObjectLastCommittedLegalHold) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	return db.ChooseAdapter(opts.ProjectID).SetObjectLastCommittedLegalHold(ctx, opts)
+}
+
+// SetObjectLastCommittedLegalHold places or removes a legal hold on the most
+// recently committed version of an object.
+func (p *PostgresAdapter) SetObjectLastCommittedLegalHold(ctx context.Context, opts SetObjectLastCommittedLegalHold) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var (
+		version Version
+		info    preUpdateLegalHoldInfo
+	)
+	err = p.db.QueryRowContext(ctx, `
+		SELECT version, status, expires_at
+		FROM objects
+		WHERE
+			(project_id, bucket_name, object_key) = ($1, $2, $3)
+			AND status IN `+statusesCommitted+`
+		ORDER BY version DESC
+		LIMIT 1
+		`, opts.ProjectID, opts.BucketName, opts.ObjectKey,
+	).Scan(&version, &info.Status, &info.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrObjectNotFound.New("")
+		}
+		return Error.New("unable to query object info before setting legal hold: %w", err)
+	}
+
+	if err = info.verify(); err != nil {
+		return errs.Wrap(err)
+	}
+
+	return errs.Wrap(p.setObjectExactVersionLegalHold(ctx, SetObjectExactVersionLegalHold{
+		ObjectLocation: opts.ObjectLocation,
+		Version:        version,
+		Enabled:        opts.Enabled,
+	}))
+}
+
+// SetObjectLastCommittedLegalHold places or removes a legal hold on the most
+// recently committed version of an object.
+func (s *SpannerAdapter) SetObjectLastCommittedLegalHold(ctx context.Context, opts SetObjectLastCommittedLegalHold) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	type info struct {
+		version Version
+		preUpdateLegalHoldInfo
+	}
+
+	result, err := spannerutil.CollectRow(s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT version, status, expires_at
+			FROM objects
+			WHERE
+				(project_id, bucket_name, object_key) = (@project_id, @bucket_name, @object_key)
+				AND status IN ` + statusesCommitted + `
+			ORDER BY version DESC
+			LIMIT 1
+		`,
+		Params: map[string]interface{}{
+			"project_id":  opts.ProjectID,
+			"bucket_name": opts.BucketName,
+			"object_key":  opts.ObjectKey,
+		},
+	}), func(row *spanner.Row, item *info) error {
+		return Error.Wrap(row.Columns(&item.version, &item.Status, &item.ExpiresAt))
+	})
+	if err != nil {
+		if errors.Is(err, iterator.Done) {
+			return ErrObjectNotFound.New("")
+		}
+		return Error.New("unable to query object info before setting legal hold: %w", err)
+	}
+
+	if err = result.verify(); err != nil {
+		return errs.Wrap(err)
+	}
+
+	return errs.Wrap(s.setObjectExactVersionLegalHold(ctx, SetObjectExactVersionLegalHold{
+		ObjectLocation: opts.ObjectLocation,
+		Version:        result.version,
+		Enabled:        opts.Enabled,
+	}))
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestObjectLegalHold(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+		loc := obj.Location()
+
+		t.Run("Missing object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.SetObjectLastCommittedLegalHold{
+				Opts: metabase.SetObjectLastCommittedLegalHold{
+					ObjectLocation: loc,
+					Enabled:        true,
+				},
+				ErrClass: &metabase.ErrObjectNotFound,
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectExactVersionLegalHold{
+				Opts: metabase.GetObjectExactVersionLegalHold{
+					ObjectLocation: loc,
+					Version:        obj.Version,
+				},
+				ErrClass: &metabase.ErrObjectNotFound,
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("Pending object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.CreatePendingObject(ctx, t, db, obj, 0)
+
+			metabasetest.SetObjectExactVersionLegalHold{
+				Opts: metabase.SetObjectExactVersionLegalHold{
+					ObjectLocation: loc,
+					Version:        obj.Version,
+					Enabled:        true,
+				},
+				ErrClass: &metabase.ErrObjectStatus,
+				ErrText:  "Object Lock settings must only be placed on committed objects",
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("Expiring object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.CreateExpiredObject(ctx, t, db, obj, 0, time.Now().Add(time.Hour))
+
+			metabasetest.SetObjectExactVersionLegalHold{
+				Opts: metabase.SetObjectExactVersionLegalHold{
+					ObjectLocation: loc,
+					Version:        obj.Version,
+					Enabled:        true,
+				},
+				ErrClass: &metabase.ErrObjectExpiration,
+				ErrText:  "Object Lock settings must not be placed on an object with an expiration date",
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("Place and remove legal hold", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			first := obj
+			metabasetest.CreateObjectVersioned(ctx, t, db, first, 0)
+			second := obj
+			second.Version++
+			metabasetest.CreateObjectVersioned(ctx, t, db, second, 0)
+
+			metabasetest.SetObjectLastCommittedLegalHold{
+				Opts: metabase.SetObjectLastCommittedLegalHold{
+					ObjectLocation: loc,
+					Enabled:        true,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectLastCommittedLegalHold{
+				Opts: metabase.GetObjectLastCommittedLegalHold{
+					ObjectLocation: loc,
+				},
+				Result: true,
+			}.Check(ctx, t, db)
+
+			// the previous version is not affected.
+			metabasetest.GetObjectExactVersionLegalHold{
+				Opts: metabase.GetObjectExactVersionLegalHold{
+					ObjectLocation: loc,
+					Version:        first.Version,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.SetObjectExactVersionLegalHold{
+				Opts: metabase.SetObjectExactVersionLegalHold{
+					ObjectLocation: loc,
+					Version:        second.Version,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectLastCommittedLegalHold{
+				Opts: metabase.GetObjectLastCommittedLegalHold{
+					ObjectLocation: loc,
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("Deletion", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object, segments := metabasetest.CreateTestObject{}.Run(ctx, t, db, obj, 1)
+
+			metabasetest.SetObjectLastCommittedLegalHold{
+				Opts: metabase.SetObjectLastCommittedLegalHold{
+					ObjectLocation: loc,
+					Enabled:        true,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.DeleteObjectLastCommitted{
+				Opts: metabase.DeleteObjectLastCommitted{
+					ObjectLocation: loc,
+					UseObjectLock:  true,
+				},
+				ErrClass: &metabase.ErrObjectLock,
+				ErrText:  "object is under legal hold",
+			}.Check(ctx, t, db)
+
+			result, err := db.DeleteObjectVersions(ctx, metabase.DeleteObjectVersions{
+				ProjectID:     obj.ProjectID,
+				BucketName:    obj.BucketName,
+				Items:         []metabase.DeleteObjectVersionsItem{{ObjectKey: obj.ObjectKey, Version: obj.Version}},
+				UseObjectLock: true,
+			}, nil)
+			require.NoError(t, err)
+			require.Len(t, result.Items, 1)
+			require.Equal(t, metabase.DeleteObjectVersionLocked, result.Items[0].Status)
+
+			metabasetest.Verify{
+				Objects:  []metabase.RawObject{metabase.RawObject(object)},
+				Segments: metabasetest.SegmentsToRaw(segments),
+			}.Check(ctx, t, db)
+		})
+	})
+}
@@ -37,6 +37,15 @@ type ObjectEntry struct {
 	FixedSegmentSize   int32
 
 	Encryption storj.EncryptionParameters
+
+	Checksum Checksum
+
+	// Tags are set only when listing with a tag filter.
+	Tags ObjectTags
+
+	// Retention and LegalHold are set only when iterating with IncludeObjectLock.
+	Retention Retention
+	LegalHold bool
 }
 
 // StreamVersionID returns byte representation of object stream version id.
@@ -99,15 +108,21 @@ type IterateObjectsWithStatus struct {
 	Pending               bool
 	IncludeCustomMetadata bool
 	IncludeSystemMetadata bool
+	IncludeTags           bool
+	IncludeObjectLock     bool
 }
 
 // IterateObjectsAllVersionsWithStatus iterates through all versions of all objects with specified status.
 func (db *DB) IterateObjectsAllVersionsWithStatus(ctx context.Context, opts IterateObjectsWithStatus, fn func(context.Context, ObjectsIterator) error) (err error) {
 	defer mon.Task()(&ctx)(&err)
+
+	ctx, done := db.queries.track(ctx, "iterate-objects-all-versions", opts.ProjectID)
+	defer done()
+
 	if err = opts.Verify(); err != nil {
 		return err
 	}
-	return iterateAllVersionsWithStatusDescending(ctx, db.ChooseAdapter(opts.ProjectID), opts, fn)
+	return iterateAllVersionsWithStatusDescending(ctx, db.ChooseAdapter(opts.ProjectID), opts, db.metadataEncryption.decryptingIterate(opts.ProjectID, fn))
 }
 
 // IterateObjectsAllVersionsWithStatusAscending iterates through all versions of all objects with specified status. Ordered from oldest to latest.
@@ -115,10 +130,14 @@ func (db *DB) IterateObjectsAllVersionsWithStatus(ctx context.Context, opts Iter
 // when problem with metabase.ListObject will be fixed.
 func (db *DB) IterateObjectsAllVersionsWithStatusAscending(ctx context.Context, opts IterateObjectsWithStatus, fn func(context.Context, ObjectsIterator) error) (err error) {
 	defer mon.Task()(&ctx)(&err)
+
+	ctx, done := db.queries.track(ctx, "iterate-objects-all-versions-ascending", opts.ProjectID)
+	defer done()
+
 	if err = opts.Verify(); err != nil {
 		return err
 	}
-	return iterateAllVersionsWithStatusAscending(ctx, db.ChooseAdapter(opts.ProjectID), opts, fn)
+	return iterateAllVersionsWithStatusAscending(ctx, db.ChooseAdapter(opts.ProjectID), opts, db.metadataEncryption.decryptingIterate(opts.ProjectID, fn))
 }
 
 // Verify verifies get object request fields.
@@ -163,6 +182,7 @@ func (db *DB) ListObjectsWithIterator(ctx context.Context, opts ListObjects) (re
 			Pending:               false,
 			IncludeCustomMetadata: opts.IncludeCustomMetadata,
 			IncludeSystemMetadata: opts.IncludeSystemMetadata,
+			IncludeTags:           len(opts.TagFilter) > 0,
 		}, func(ctx context.Context, it ObjectsIterator) error {
 			var previousLatestSet bool
 			var entry, previousLatest ObjectEntry
@@ -171,6 +191,8 @@ func (db *DB) ListObjectsWithIterator(ctx context.Context, opts ListObjects) (re
 				prefix += "/"
 			}
 
+			var scanned int
+			var lastScannedKey ObjectKey
 			for len(result.Objects) < opts.Limit && it.Next(ctx, &entry) {
 				objectKey := prefix + entry.ObjectKey
 				if opts.Cursor.Key == objectKey && opts.Cursor.Version >= entry.Version {
@@ -179,6 +201,17 @@ func (db *DB) ListObjectsWithIterator(ctx context.Context, opts ListObjects) (re
 					continue
 				}
 
+				if len(opts.TagFilter) > 0 {
+					scanned++
+					if scanned > tagFilterScanLimit {
+						// the next page continues after the last fully scanned object.
+						result.More = true
+						result.Cursor = ListObjectsCursor{Key: lastScannedKey, Version: MaxVersion}
+						return nil
+					}
+					lastScannedKey = objectKey
+				}
+
 				if entry.Status.IsDeleteMarker() && (!previousLatestSet || prefix+previousLatest.ObjectKey != objectKey) {
 					previousLatestSet = true
 					previousLatest = entry
@@ -189,11 +222,20 @@ func (db *DB) ListObjectsWithIterator(ctx context.Context, opts ListObjects) (re
 					previousLatestSet = true
 					previousLatest = entry
 
+					if !entry.IsPrefix && !entry.Tags.Match(opts.TagFilter) {
+						continue
+					}
 					result.Objects = append(result.Objects, entry)
 				}
 			}
 
 			result.More = it.Next(ctx, &entry)
+			if result.More && len(opts.TagFilter) > 0 && len(result.Objects) > 0 {
+				result.Cursor = ListObjectsCursor{
+					Key:     prefix + result.Objects[len(result.Objects)-1].ObjectKey,
+					Version: MaxVersion,
+				}
+			}
 			return nil
 		},
 	)
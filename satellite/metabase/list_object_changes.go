@@ -0,0 +1,180 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/spanner"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/shared/dbutil/spannerutil"
+	"storj.io/storj/shared/tagsql"
+)
+
+// ListObjectChangesCursor is the position after which ListObjectChanges continues listing.
+type ListObjectChangesCursor struct {
+	CreatedAt time.Time
+	ObjectKey ObjectKey
+	Version   Version
+}
+
+// ListObjectChanges contains arguments necessary for listing the objects changed
+// in a bucket since the specified time.
+//
+// Changes are the committed objects and, for buckets where versioning is enabled
+// or suspended, the delete markers. Objects deleted from unversioned buckets
+// don't leave any trace, hence they aren't listed.
+type ListObjectChanges struct {
+	ProjectID  uuid.UUID
+	BucketName BucketName
+	// Since is the inclusive lower bound of the object creation time.
+	Since  time.Time
+	Cursor ListObjectChangesCursor
+	Limit  int
+}
+
+// Verify verifies list object changes request fields.
+func (opts *ListObjectChanges) Verify() error {
+	switch {
+	case opts.ProjectID.IsZero():
+		return ErrInvalidRequest.New("ProjectID missing")
+	case opts.BucketName == "":
+		return ErrInvalidRequest.New("BucketName missing")
+	case opts.Since.IsZero():
+		return ErrInvalidRequest.New("Since missing")
+	case opts.Limit < 0:
+		return ErrInvalidRequest.New("Invalid limit: %d", opts.Limit)
+	}
+	return nil
+}
+
+// ListObjectChangesResult result of listing object changes.
+type ListObjectChangesResult struct {
+	// Changes are ordered by creation time, object key and version.
+	Changes []ObjectEntry
+	More    bool
+}
+
+// ListObjectChanges lists the objects and delete markers created in the bucket since the specified time.
+//
+// The listing is ordered by the creation time, hence to continue listing the
+// cursor should be set to the last returned entry.
+//
+// The creation time is set when the upload begins, not when the object is
+// committed, and the objects table doesn't keep the commit time. An object,
+// which is committed after the listing has passed its creation time, isn't
+// listed. Callers polling for changes should start the next listing early
+// enough to cover the uploads in progress, e.g. by the zombie deletion
+// deadline of pending objects.
+func (db *DB) ListObjectChanges(ctx context.Context, opts ListObjectChanges) (result ListObjectChangesResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, done := db.queries.track(ctx, "list-object-changes", opts.ProjectID)
+	defer done()
+
+	if err := opts.Verify(); err != nil {
+		return ListObjectChangesResult{}, err
+	}
+
+	ListLimit.Ensure(&opts.Limit)
+
+	if opts.Cursor.CreatedAt.Before(opts.Since) {
+		opts.Cursor = ListObjectChangesCursor{CreatedAt: opts.Since}
+	}
+
+	return db.ChooseAdapter(opts.ProjectID).ListObjectChanges(ctx, opts)
+}
+
+// ListObjectChanges implements Adapter.
+func (p *PostgresAdapter) ListObjectChanges(ctx context.Context, opts ListObjectChanges) (result ListObjectChangesResult, err error) {
+	err = withRows(p.db.QueryContext(ctx, `
+		SELECT
+			object_key, version, stream_id, status,
+			created_at, expires_at,
+			total_encrypted_size
+		FROM objects
+		WHERE
+			(project_id, bucket_name) = ($1, $2)
+			AND status <> `+statusPending+`
+			AND created_at >= $3
+			AND (created_at, object_key, version) > ($4, $5, $6)
+		ORDER BY created_at ASC, object_key ASC, version ASC
+		LIMIT $7
+	`, opts.ProjectID, opts.BucketName, opts.Since,
+		opts.Cursor.CreatedAt, opts.Cursor.ObjectKey, opts.Cursor.Version,
+		opts.Limit+1,
+	))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var entry ObjectEntry
+			err := rows.Scan(
+				&entry.ObjectKey, &entry.Version, &entry.StreamID, &entry.Status,
+				&entry.CreatedAt, &entry.ExpiresAt,
+				&entry.TotalEncryptedSize,
+			)
+			if err != nil {
+				return Error.Wrap(err)
+			}
+			result.Changes = append(result.Changes, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return ListObjectChangesResult{}, Error.New("unable to list object changes: %w", err)
+	}
+
+	if len(result.Changes) > opts.Limit {
+		result.More = true
+		result.Changes = result.Changes[:opts.Limit]
+	}
+	return result, nil
+}
+
+// ListObjectChanges implements Adapter.
+func (s *SpannerAdapter) ListObjectChanges(ctx context.Context, opts ListObjectChanges) (result ListObjectChangesResult, err error) {
+	result.Changes, err = spannerutil.CollectRows(s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT
+				object_key, version, stream_id, status,
+				created_at, expires_at,
+				total_encrypted_size
+			FROM objects
+			WHERE
+				project_id = @project_id AND bucket_name = @bucket_name
+				AND status <> ` + statusPending + `
+				AND created_at >= @since
+				AND ` + TupleGreaterThanSQL(
+			[]string{"created_at", "object_key", "version"},
+			[]string{"@cursor_created_at", "@cursor_key", "@cursor_version"},
+			false) + `
+			ORDER BY created_at ASC, object_key ASC, version ASC
+			LIMIT @limit
+		`,
+		Params: map[string]any{
+			"project_id":        opts.ProjectID,
+			"bucket_name":       opts.BucketName,
+			"since":             opts.Since,
+			"cursor_created_at": opts.Cursor.CreatedAt,
+			"cursor_key":        opts.Cursor.ObjectKey,
+			"cursor_version":    opts.Cursor.Version,
+			"limit":             int64(opts.Limit + 1),
+		},
+	}), func(row *spanner.Row, entry *ObjectEntry) error {
+		return Error.Wrap(row.Columns(
+			&entry.ObjectKey, &entry.Version, &entry.StreamID, &entry.Status,
+			&entry.CreatedAt, &entry.ExpiresAt,
+			&entry.TotalEncryptedSize,
+		))
+	})
+	if err != nil {
+		return ListObjectChangesResult{}, Error.New("unable to list object changes: %w", err)
+	}
+
+	if len(result.Changes) > opts.Limit {
+		result.More = true
+		result.Changes = result.Changes[:opts.Limit]
+	}
+	return result, nil
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+	"time"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestListObjectChanges(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+		since := time.Now().Add(-time.Hour)
+
+		t.Run("invalid request", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			for _, tc := range []struct {
+				opts    metabase.ListObjectChanges
+				errText string
+			}{
+				{opts: metabase.ListObjectChanges{}, errText: "ProjectID missing"},
+				{opts: metabase.ListObjectChanges{ProjectID: obj.ProjectID}, errText: "BucketName missing"},
+				{opts: metabase.ListObjectChanges{ProjectID: obj.ProjectID, BucketName: obj.BucketName}, errText: "Since missing"},
+				{opts: metabase.ListObjectChanges{ProjectID: obj.ProjectID, BucketName: obj.BucketName, Since: since, Limit: -1}, errText: "Invalid limit: -1"},
+			} {
+				metabasetest.ListObjectChanges{
+					Opts:     tc.opts,
+					ErrClass: &metabase.ErrInvalidRequest,
+					ErrText:  tc.errText,
+				}.Check(ctx, t, db)
+			}
+		})
+
+		t.Run("empty bucket", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.ListObjectChanges{
+				Opts: metabase.ListObjectChanges{
+					ProjectID:  obj.ProjectID,
+					BucketName: obj.BucketName,
+					Since:      since,
+				},
+				Result: metabase.ListObjectChangesResult{},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("objects and delete markers", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			first := metabasetest.RandObjectStream()
+			first.ProjectID, first.BucketName = obj.ProjectID, obj.BucketName
+			first.Version = 1
+			firstObject := metabasetest.CreateObjectVersioned(ctx, t, db, first, 0)
+
+			second := metabasetest.RandObjectStream()
+			second.ProjectID, second.BucketName = obj.ProjectID, obj.BucketName
+			second.Version = 1
+			secondObject := metabasetest.CreateObjectVersioned(ctx, t, db, second, 0)
+
+			// pending objects aren't changes
+			pending := metabasetest.RandObjectStream()
+			pending.ProjectID, pending.BucketName = obj.ProjectID, obj.BucketName
+			metabasetest.CreatePendingObject(ctx, t, db, pending, 0)
+
+			// objects in other buckets aren't listed
+			other := metabasetest.RandObjectStream()
+			other.ProjectID = obj.ProjectID
+			metabasetest.CreateObject(ctx, t, db, other, 0)
+
+			deleted := metabasetest.DeleteObjectLastCommitted{
+				Opts: metabase.DeleteObjectLastCommitted{
+					ObjectLocation: first.Location(),
+					Versioned:      true,
+				},
+				Result: metabase.DeleteObjectResult{
+					Markers: []metabase.Object{
+						{
+							ObjectStream: metabase.ObjectStream{
+								ProjectID:  first.ProjectID,
+								BucketName: first.BucketName,
+								ObjectKey:  first.ObjectKey,
+								Version:    2,
+							},
+							CreatedAt: time.Now(),
+							Status:    metabase.DeleteMarkerVersioned,
+						},
+					},
+				},
+			}.Check(ctx, t, db)
+			marker := deleted.Markers[0]
+
+			entries := []metabase.ObjectEntry{
+				objectChange(firstObject),
+				objectChange(secondObject),
+				objectChange(marker),
+			}
+
+			metabasetest.ListObjectChanges{
+				Opts: metabase.ListObjectChanges{
+					ProjectID:  obj.ProjectID,
+					BucketName: obj.BucketName,
+					Since:      since,
+				},
+				Result: metabase.ListObjectChangesResult{
+					Changes: entries,
+				},
+			}.Check(ctx, t, db)
+
+			// continue from the cursor
+			result := metabasetest.ListObjectChanges{
+				Opts: metabase.ListObjectChanges{
+					ProjectID:  obj.ProjectID,
+					BucketName: obj.BucketName,
+					Since:      since,
+					Limit:      2,
+				},
+				Result: metabase.ListObjectChangesResult{
+					Changes: entries[:2],
+					More:    true,
+				},
+			}.Check(ctx, t, db)
+
+			last := result.Changes[len(result.Changes)-1]
+			metabasetest.ListObjectChanges{
+				Opts: metabase.ListObjectChanges{
+					ProjectID:  obj.ProjectID,
+					BucketName: obj.BucketName,
+					Since:      since,
+					Cursor: metabase.ListObjectChangesCursor{
+						CreatedAt: last.CreatedAt,
+						ObjectKey: last.ObjectKey,
+						Version:   last.Version,
+					},
+					Limit: 2,
+				},
+				Result: metabase.ListObjectChangesResult{
+					Changes: entries[2:],
+				},
+			}.Check(ctx, t, db)
+
+			// nothing changed since the delete marker was created
+			metabasetest.ListObjectChanges{
+				Opts: metabase.ListObjectChanges{
+					ProjectID:  obj.ProjectID,
+					BucketName: obj.BucketName,
+					Since:      marker.CreatedAt.Add(time.Second),
+				},
+				Result: metabase.ListObjectChangesResult{},
+			}.Check(ctx, t, db)
+
+			// other projects don't see the changes
+			metabasetest.ListObjectChanges{
+				Opts: metabase.ListObjectChanges{
+					ProjectID:  uuid.UUID{1},
+					BucketName: obj.BucketName,
+					Since:      since,
+				},
+				Result: metabase.ListObjectChangesResult{},
+			}.Check(ctx, t, db)
+		})
+	})
+}
+
+func objectChange(object metabase.Object) metabase.ObjectEntry {
+	return metabase.ObjectEntry{
+		ObjectKey:          object.ObjectKey,
+		Version:            object.Version,
+		StreamID:           object.StreamID,
+		CreatedAt:          object.CreatedAt,
+		ExpiresAt:          object.ExpiresAt,
+		Status:             object.Status,
+		TotalEncryptedSize: object.TotalEncryptedSize,
+	}
+}
@@ -39,6 +39,10 @@ type ListObjects struct {
 	AllVersions           bool
 	IncludeCustomMetadata bool
 	IncludeSystemMetadata bool
+
+	// TagFilter lists only the objects having all the given tags.
+	// Prefixes are listed regardless of the filter.
+	TagFilter ObjectTags
 }
 
 // Verify verifies get object request fields.
@@ -52,19 +56,27 @@ func (opts *ListObjects) Verify() error {
 		return ErrInvalidRequest.New("Invalid limit: %d", opts.Limit)
 	}
 
-	return nil
+	return opts.TagFilter.Verify()
 }
 
 // ListObjectsResult result of listing objects.
 type ListObjectsResult struct {
 	Objects []ObjectEntry
 	More    bool
+
+	// Cursor is set when listing with TagFilter and there are more entries.
+	// The listing must be continued from Cursor, because the listing may
+	// stop before filling the page, after scanning too many filtered out entries.
+	Cursor ListObjectsCursor
 }
 
 // ListObjects lists objects.
 func (db *DB) ListObjects(ctx context.Context, opts ListObjects) (result ListObjectsResult, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	ctx, done := db.queries.track(ctx, "list-objects", opts.ProjectID)
+	defer done()
+
 	if db.config.UseListObjectsIterator {
 		return db.ListObjectsWithIterator(ctx, opts)
 	}
@@ -75,7 +87,17 @@ func (db *DB) ListObjects(ctx context.Context, opts ListObjects) (result ListObj
 
 	ListLimit.Ensure(&opts.Limit)
 
-	return db.ChooseAdapter(opts.ProjectID).ListObjects(ctx, opts)
+	result, err = db.ChooseAdapter(opts.ProjectID).ListObjects(ctx, opts)
+	if err != nil {
+		return ListObjectsResult{}, err
+	}
+	for i := range result.Objects {
+		entry := &result.Objects[i]
+		if err := db.metadataEncryption.decryptMetadata(opts.ProjectID, &entry.EncryptedMetadata, &entry.EncryptedMetadataEncryptedKey); err != nil {
+			return ListObjectsResult{}, err
+		}
+	}
+	return result, nil
 }
 
 // ListObjects lists objects.
@@ -90,6 +112,9 @@ func (p *PostgresAdapter) ListObjects(ctx context.Context, opts ListObjects) (re
 	const minQuerySize = 100
 
 	// requeryLimit is a safety net for invalid implementation.
+	//
+	// With TagFilter it also bounds how much of the bucket is scanned. When
+	// the listing stops early, the result contains the cursor to continue from.
 	requeryLimit := opts.Limit + 10 // we do some extra queries, but, roughly at most we should have one query per entry
 
 	// extraSkipEntries to avoid requerying in the common case of !AllVersions.
@@ -220,10 +245,18 @@ func (p *PostgresAdapter) ListObjects(ctx context.Context, opts ListObjects) (re
 				continue
 			}
 
+			if !entry.IsPrefix && !entry.Tags.Match(opts.TagFilter) {
+				continue
+			}
+
 			result.Objects = append(result.Objects, entry)
 			if len(result.Objects) >= opts.Limit+1 {
 				result.More = true
 				result.Objects = result.Objects[:opts.Limit]
+				if len(opts.TagFilter) > 0 {
+					last := result.Objects[len(result.Objects)-1]
+					result.Cursor = opts.nextCursor(last.ObjectKey, last.Version, last.IsPrefix)
+				}
 				return result, Error.Wrap(errs.Combine(err, rows.Err(), rows.Close()))
 			}
 		}
@@ -259,6 +292,12 @@ func (p *PostgresAdapter) ListObjects(ctx context.Context, opts ListObjects) (re
 		}
 	}
 
+	if len(opts.TagFilter) > 0 {
+		result.More = true
+		result.Cursor = opts.nextCursor(lastEntry.ObjectKey, lastEntry.Version, lastEntry.IsPrefix)
+		return result, nil
+	}
+
 	panic("too many requeries")
 }
 
@@ -277,6 +316,9 @@ func (s *SpannerAdapter) ListObjects(ctx context.Context, opts ListObjects) (res
 	const minQuerySize = 100
 
 	// requeryLimit is a safety net for invalid implementation.
+	//
+	// With TagFilter it also bounds how much of the bucket is scanned. When
+	// the listing stops early, the result contains the cursor to continue from.
 	requeryLimit := opts.Limit + 10 // we do some extra queries, but, roughly at most we should have one query per entry
 
 	// extraSkipEntries to avoid requerying in the common case of !AllVersions.
@@ -424,10 +466,18 @@ func (s *SpannerAdapter) ListObjects(ctx context.Context, opts ListObjects) (res
 					continue
 				}
 
+				if !entry.IsPrefix && !entry.Tags.Match(opts.TagFilter) {
+					continue
+				}
+
 				result.Objects = append(result.Objects, entry)
 				if len(result.Objects) >= opts.Limit+1 {
 					result.More = true
 					result.Objects = result.Objects[:opts.Limit]
+					if len(opts.TagFilter) > 0 {
+						last := result.Objects[len(result.Objects)-1]
+						result.Cursor = opts.nextCursor(last.ObjectKey, last.Version, last.IsPrefix)
+					}
 					done = true
 					return nil
 				}
@@ -468,6 +518,12 @@ func (s *SpannerAdapter) ListObjects(ctx context.Context, opts ListObjects) (res
 		}
 	}
 
+	if len(opts.TagFilter) > 0 {
+		result.More = true
+		result.Cursor = opts.nextCursor(lastEntry.ObjectKey, lastEntry.Version, lastEntry.IsPrefix)
+		return result, nil
+	}
+
 	panic("too many requeries")
 }
 
@@ -546,6 +602,16 @@ func (opts *ListObjects) FirstVersion() Version {
 	}
 }
 
+// nextCursor returns the cursor for continuing the listing after the entry
+// with the given key, relative to the prefix.
+func (opts *ListObjects) nextCursor(key ObjectKey, version Version, isPrefix bool) ListObjectsCursor {
+	cursor := ListObjectsCursor{Key: opts.Prefix + key, Version: version}
+	if isPrefix || !opts.AllVersions {
+		cursor.Version = opts.lastVersion()
+	}
+	return cursor
+}
+
 func (opts *ListObjects) lastVersion() Version {
 	if opts.VersionAscending() {
 		return MaxVersion
@@ -580,7 +646,8 @@ func (opts ListObjects) selectedFields() (selectedFields string) {
 		,segment_count
 		,total_plain_size
 		,total_encrypted_size
-		,fixed_segment_size`
+		,fixed_segment_size
+		,checksum`
 	}
 
 	if opts.IncludeCustomMetadata {
@@ -590,6 +657,11 @@ func (opts ListObjects) selectedFields() (selectedFields string) {
 		,encrypted_metadata_encrypted_key`
 	}
 
+	if len(opts.TagFilter) > 0 {
+		selectedFields += `
+		,tags`
+	}
+
 	return selectedFields
 }
 
@@ -654,6 +726,7 @@ func scanListObjectsEntryPostgres(rows tagsql.Rows, opts *ListObjects) (item Obj
 			&item.TotalPlainSize,
 			&item.TotalEncryptedSize,
 			&item.FixedSegmentSize,
+			&item.Checksum,
 		)
 	}
 
@@ -665,6 +738,10 @@ func scanListObjectsEntryPostgres(rows tagsql.Rows, opts *ListObjects) (item Obj
 		)
 	}
 
+	if len(opts.TagFilter) > 0 {
+		fields = append(fields, &item.Tags)
+	}
+
 	if err := rows.Scan(fields...); err != nil {
 		return item, err
 	}
@@ -704,6 +781,7 @@ func scanListObjectsEntrySpanner(row *spanner.Row, opts *ListObjects) (item Obje
 			&item.TotalPlainSize,
 			&item.TotalEncryptedSize,
 			spannerutil.Int(&item.FixedSegmentSize),
+			&item.Checksum,
 		)
 	}
 
@@ -715,6 +793,10 @@ func scanListObjectsEntrySpanner(row *spanner.Row, opts *ListObjects) (item Obje
 		)
 	}
 
+	if len(opts.TagFilter) > 0 {
+		fields = append(fields, &item.Tags)
+	}
+
 	if err := row.Columns(fields...); err != nil {
 		return item, err
 	}
@@ -12,6 +12,7 @@ import (
 	"cloud.google.com/go/spanner"
 
 	"storj.io/common/uuid"
+	"storj.io/storj/shared/dbutil/pgutil"
 	"storj.io/storj/shared/dbutil/spannerutil"
 	"storj.io/storj/shared/tagsql"
 )
@@ -222,6 +223,160 @@ func (s *SpannerAdapter) ListSegments(ctx context.Context, opts ListSegments, al
 	return result, nil
 }
 
+// ListStreamsSegments contains arguments necessary for listing the segments of multiple streams.
+type ListStreamsSegments struct {
+	ProjectID uuid.UUID
+	StreamIDs []uuid.UUID
+
+	// CursorStreamID and CursorPosition define the last listed segment, exclusive.
+	CursorStreamID uuid.UUID
+	CursorPosition SegmentPosition
+
+	Limit int
+}
+
+// ListStreamsSegmentsResult result of listing the segments of multiple streams.
+type ListStreamsSegmentsResult struct {
+	// Segments are ordered by stream ID and position.
+	Segments []RawSegment
+	More     bool
+}
+
+// ListStreamsSegments lists the segments of the specified streams, ordered by stream ID and position.
+func (db *DB) ListStreamsSegments(ctx context.Context, opts ListStreamsSegments) (result ListStreamsSegmentsResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if opts.ProjectID.IsZero() {
+		return ListStreamsSegmentsResult{}, ErrInvalidRequest.New("ProjectID missing")
+	}
+	if opts.Limit < 0 {
+		return ListStreamsSegmentsResult{}, ErrInvalidRequest.New("Invalid limit: %d", opts.Limit)
+	}
+	if len(opts.StreamIDs) == 0 {
+		return ListStreamsSegmentsResult{}, nil
+	}
+
+	ListLimit.Ensure(&opts.Limit)
+
+	result.Segments, err = db.ChooseAdapter(opts.ProjectID).ListStreamsSegments(ctx, opts, db.aliasCache)
+	if err != nil {
+		return ListStreamsSegmentsResult{}, err
+	}
+
+	if len(result.Segments) > opts.Limit {
+		result.More = true
+		result.Segments = result.Segments[:len(result.Segments)-1]
+	}
+	return result, nil
+}
+
+// ListStreamsSegments implements Adapter.
+func (p *PostgresAdapter) ListStreamsSegments(ctx context.Context, opts ListStreamsSegments, aliasCache *NodeAliasCache) (segments []RawSegment, err error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT
+			stream_id, position,
+			created_at, repaired_at, expires_at,
+			root_piece_id, encrypted_key_nonce, encrypted_key,
+			encrypted_size, plain_offset, plain_size,
+			encrypted_etag, redundancy,
+			inline_data, remote_alias_pieces,
+			placement, checksum
+		FROM segments
+		WHERE
+			stream_id = ANY($1) AND
+			(stream_id, position) > ($2, $3)
+		ORDER BY stream_id ASC, position ASC
+		LIMIT $4
+	`, pgutil.UUIDArray(opts.StreamIDs), opts.CursorStreamID, opts.CursorPosition, opts.Limit+1)
+
+	err = withRows(rows, err)(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var segment RawSegment
+			var aliasPieces AliasPieces
+			err := rows.Scan(
+				&segment.StreamID, &segment.Position,
+				&segment.CreatedAt, &segment.RepairedAt, &segment.ExpiresAt,
+				&segment.RootPieceID, &segment.EncryptedKeyNonce, &segment.EncryptedKey,
+				&segment.EncryptedSize, &segment.PlainOffset, &segment.PlainSize,
+				&segment.EncryptedETag, redundancyScheme{&segment.Redundancy},
+				&segment.InlineData, &aliasPieces,
+				&segment.Placement, &segment.Checksum,
+			)
+			if err != nil {
+				return Error.New("failed to scan segments: %w", err)
+			}
+
+			segment.Pieces, err = aliasCache.ConvertAliasesToPieces(ctx, aliasPieces)
+			if err != nil {
+				return Error.New("failed to convert aliases to pieces: %w", err)
+			}
+			segments = append(segments, segment)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("unable to fetch stream segments: %w", err)
+	}
+	return segments, nil
+}
+
+// ListStreamsSegments implements Adapter.
+func (s *SpannerAdapter) ListStreamsSegments(ctx context.Context, opts ListStreamsSegments, aliasCache *NodeAliasCache) (segments []RawSegment, err error) {
+	streamIDs := make([][]byte, len(opts.StreamIDs))
+	for i, streamID := range opts.StreamIDs {
+		streamIDs[i] = streamID.Bytes()
+	}
+
+	segments, err = spannerutil.CollectRows(s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT
+				stream_id, position,
+				created_at, repaired_at, expires_at,
+				root_piece_id, encrypted_key_nonce, encrypted_key,
+				encrypted_size, plain_offset, plain_size,
+				encrypted_etag, redundancy,
+				inline_data, remote_alias_pieces,
+				placement, checksum
+			FROM segments
+			WHERE
+				stream_id IN UNNEST(@stream_ids)
+				AND ` + TupleGreaterThanSQL([]string{"stream_id", "position"}, []string{"@cursor_stream_id", "@cursor_position"}, false) + `
+			ORDER BY stream_id ASC, position ASC
+			LIMIT @limit
+		`,
+		Params: map[string]any{
+			"stream_ids":       streamIDs,
+			"cursor_stream_id": opts.CursorStreamID,
+			"cursor_position":  opts.CursorPosition,
+			"limit":            int64(opts.Limit + 1),
+		},
+	}), func(row *spanner.Row, segment *RawSegment) error {
+		var aliasPieces AliasPieces
+		err := row.Columns(
+			&segment.StreamID, &segment.Position,
+			&segment.CreatedAt, &segment.RepairedAt, &segment.ExpiresAt,
+			&segment.RootPieceID, &segment.EncryptedKeyNonce, &segment.EncryptedKey,
+			spannerutil.Int(&segment.EncryptedSize), &segment.PlainOffset, spannerutil.Int(&segment.PlainSize),
+			&segment.EncryptedETag, redundancyScheme{&segment.Redundancy},
+			&segment.InlineData, &aliasPieces,
+			spannerutil.Int(&segment.Placement), &segment.Checksum,
+		)
+		if err != nil {
+			return Error.New("failed to read segments: %w", err)
+		}
+
+		segment.Pieces, err = aliasCache.ConvertAliasesToPieces(ctx, aliasPieces)
+		if err != nil {
+			return Error.New("failed to convert aliases to pieces: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.New("failed to list stream segments: %w", err)
+	}
+	return segments, nil
+}
+
 // ListStreamPositions contains arguments necessary for listing stream segments.
 type ListStreamPositions struct {
 	ProjectID uuid.UUID
@@ -7,9 +7,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
+
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
+	"storj.io/common/uuid"
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/metabase/metabasetest"
 )
@@ -337,6 +340,67 @@ func TestListSegments(t *testing.T) {
 	})
 }
 
+func TestListStreamsSegments(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+		obj := metabasetest.RandObjectStream()
+
+		_, err := db.ListStreamsSegments(ctx, metabase.ListStreamsSegments{
+			StreamIDs: []uuid.UUID{obj.StreamID},
+		})
+		require.True(t, metabase.ErrInvalidRequest.Has(err))
+
+		var streamIDs []uuid.UUID
+		for i := 0; i < 3; i++ {
+			stream := obj
+			stream.ObjectKey = metabasetest.RandObjectKey()
+			stream.StreamID = testrand.UUID()
+			metabasetest.CreateObject(ctx, t, db, stream, byte(i+1))
+			streamIDs = append(streamIDs, stream.StreamID)
+		}
+
+		// segments of other streams are not listed.
+		other := obj
+		other.ObjectKey = metabasetest.RandObjectKey()
+		other.StreamID = testrand.UUID()
+		metabasetest.CreateObject(ctx, t, db, other, 2)
+
+		state, err := db.TestingGetState(ctx)
+		require.NoError(t, err)
+
+		var expected []metabase.RawSegment
+		for _, segment := range state.Segments {
+			if segment.StreamID != other.StreamID {
+				expected = append(expected, segment)
+			}
+		}
+
+		for _, limit := range []int{1, 2, 100} {
+			opts := metabase.ListStreamsSegments{
+				ProjectID: obj.ProjectID,
+				StreamIDs: streamIDs,
+				Limit:     limit,
+			}
+
+			var listed []metabase.RawSegment
+			for {
+				result, err := db.ListStreamsSegments(ctx, opts)
+				require.NoError(t, err)
+				require.LessOrEqual(t, len(result.Segments), limit)
+
+				listed = append(listed, result.Segments...)
+				if !result.More {
+					break
+				}
+				last := result.Segments[len(result.Segments)-1]
+				opts.CursorStreamID, opts.CursorPosition = last.StreamID, last.Position
+			}
+			require.Equal(t, expected, listed)
+		}
+	})
+}
+
 func TestListStreamPositions(t *testing.T) {
 	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
 		obj := metabasetest.RandObjectStream()
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"strconv"
 	"time"
 
 	"cloud.google.com/go/spanner"
@@ -49,7 +50,7 @@ type LoopObjectEntry struct {
 	ExpiresAt             *time.Time   // tally
 	SegmentCount          int32        // metrics
 	TotalEncryptedSize    int64        // tally
-	EncryptedMetadataSize int          // tally
+	EncryptedMetadataSize int          // tally, without the metadata encryption envelope
 }
 
 // Expired checks if object is expired relative to now.
@@ -173,7 +174,8 @@ func (it *loopIterator) doNextQuery(ctx context.Context) (_ tagsql.Rows, err err
 			status,
 			created_at, expires_at,
 			segment_count, total_encrypted_size,
-			LENGTH(COALESCE(encrypted_metadata,''))
+			LENGTH(COALESCE(encrypted_metadata,'')) -
+			CASE WHEN encrypted_metadata_key_id <> 0 THEN $6::INT8 ELSE 0 END
 		FROM objects
 		`+it.db.asOfTime(it.asOfSystemTime, it.asOfSystemInterval)+`
 		WHERE (project_id, bucket_name, object_key, version) > ($1, $2, $3, $4)
@@ -182,6 +184,7 @@ func (it *loopIterator) doNextQuery(ctx context.Context) (_ tagsql.Rows, err err
 		`, it.cursor.ProjectID, it.cursor.BucketName,
 		[]byte(it.cursor.ObjectKey), int(it.cursor.Version),
 		it.batchSize,
+		envelopeOverhead,
 	)
 }
 
@@ -215,6 +218,7 @@ type LoopSegmentEntry struct {
 	Redundancy    storj.RedundancyScheme
 	Pieces        Pieces
 	Placement     storj.PlacementConstraint
+	HealthyPieces *int // checker, nil when unknown
 }
 
 // Inline returns true if segment is inline.
@@ -234,6 +238,44 @@ type IterateLoopSegments struct {
 	AsOfSystemInterval time.Duration
 	StartStreamID      uuid.UUID
 	EndStreamID        uuid.UUID
+
+	Filter LoopSegmentsFilter
+}
+
+// LoopSegmentsFilter restricts the segments returned by IterateLoopSegments,
+// so observers interested in a subset of segments don't need to go through
+// all of them. Zero values don't filter.
+type LoopSegmentsFilter struct {
+	// MinPieces and MaxPieces limit the number of remote pieces of the segment.
+	MinPieces int
+	MaxPieces int
+	// Placement limits the segments to a single placement.
+	Placement *storj.PlacementConstraint
+	// CreatedBefore limits the segments to those created before the time.
+	CreatedBefore time.Time
+}
+
+// Match returns whether the segment passes the filter.
+func (filter LoopSegmentsFilter) Match(segment *LoopSegmentEntry) bool {
+	if filter.MinPieces > 0 && len(segment.AliasPieces) < filter.MinPieces {
+		return false
+	}
+	if filter.MaxPieces > 0 && len(segment.AliasPieces) > filter.MaxPieces {
+		return false
+	}
+	if filter.Placement != nil && segment.Placement != *filter.Placement {
+		return false
+	}
+	if !filter.CreatedBefore.IsZero() && !segment.CreatedAt.Before(filter.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// filtersPieces returns whether the filter depends on the number of pieces,
+// which can't be checked by the database as the pieces are stored encoded.
+func (filter LoopSegmentsFilter) filtersPieces() bool {
+	return filter.MinPieces > 0 || filter.MaxPieces > 0
 }
 
 // Verify verifies segments request fields.
@@ -241,6 +283,15 @@ func (opts *IterateLoopSegments) Verify() error {
 	if opts.BatchSize < 0 {
 		return ErrInvalidRequest.New("BatchSize is negative")
 	}
+	if opts.Filter.MinPieces < 0 {
+		return ErrInvalidRequest.New("Filter.MinPieces is negative")
+	}
+	if opts.Filter.MaxPieces < 0 {
+		return ErrInvalidRequest.New("Filter.MaxPieces is negative")
+	}
+	if opts.Filter.MaxPieces > 0 && opts.Filter.MaxPieces < opts.Filter.MinPieces {
+		return ErrInvalidRequest.New("Filter.MaxPieces is smaller than Filter.MinPieces")
+	}
 	if !opts.EndStreamID.IsZero() {
 		if opts.EndStreamID.Less(opts.StartStreamID) {
 			return ErrInvalidRequest.New("EndStreamID is smaller than StartStreamID")
@@ -262,8 +313,200 @@ func (db *DB) IterateLoopSegments(ctx context.Context, opts IterateLoopSegments,
 
 	loopIteratorBatchSizeLimit.Ensure(&opts.BatchSize)
 
-	// TODO(spanner): this needs to iterate across multiple backends.
-	return db.ChooseAdapter(uuid.UUID{}).IterateLoopSegments(ctx, db.aliasCache, opts, fn)
+	// Segments of deleted objects whose deletion has been deferred are still in the
+	// segments table, but they must not be checked, repaired, audited or retained.
+	// Queue entries that were created for them before the deletion become stale and
+	// are removed once the segments are gone.
+	endStreamID := opts.EndStreamID
+	if endStreamID.IsZero() {
+		endStreamID = uuid.Max()
+	}
+
+	return iterateLoopSegments(ctx, db.adapters, db.aliasCache, opts, func(ctx context.Context, it LoopSegmentsIterator) error {
+		skip := &skipDeferredLoopSegmentsIterator{it: it}
+		for _, adapter := range db.adapters {
+			skip.deferred = append(skip.deferred, &deferredStreamIDs{
+				adapter:   adapter,
+				after:     opts.StartStreamID,
+				end:       endStreamID,
+				batchSize: opts.BatchSize,
+			})
+		}
+		it = skip
+		if opts.Filter.filtersPieces() {
+			it = &filterLoopSegmentsIterator{it: it, filter: opts.Filter}
+		}
+		return errs.Combine(fn(ctx, it), skip.err)
+	})
+}
+
+// iterateLoopSegments iterates the segments of all adapters. With multiple adapters,
+// e.g. while migrating between Postgres and Spanner, the segments are merged in the
+// stream ID and position order, which the ranges of the segments loop rely on.
+func iterateLoopSegments(ctx context.Context, adapters []Adapter, aliasCache *NodeAliasCache, opts IterateLoopSegments, fn func(context.Context, LoopSegmentsIterator) error) error {
+	if len(adapters) == 1 {
+		return adapters[0].IterateLoopSegments(ctx, aliasCache, opts, fn)
+	}
+
+	iterators := make([]LoopSegmentsIterator, 0, len(adapters))
+	var open func(ctx context.Context, index int) error
+	open = func(ctx context.Context, index int) error {
+		if index == len(adapters) {
+			return fn(ctx, &mergedLoopSegmentsIterator{
+				iterators: iterators,
+				heads:     make([]LoopSegmentEntry, len(iterators)),
+				valid:     make([]bool, len(iterators)),
+			})
+		}
+		return adapters[index].IterateLoopSegments(ctx, aliasCache, opts, func(ctx context.Context, it LoopSegmentsIterator) error {
+			iterators = append(iterators, it)
+			return open(ctx, index+1)
+		})
+	}
+	return open(ctx, 0)
+}
+
+// mergedLoopSegmentsIterator merges the ordered segments of multiple iterators.
+type mergedLoopSegmentsIterator struct {
+	iterators []LoopSegmentsIterator
+	heads     []LoopSegmentEntry
+	valid     []bool
+	started   bool
+	done      bool
+	last      int
+}
+
+// Next returns the segment with the smallest stream ID and position among the iterators.
+func (it *mergedLoopSegmentsIterator) Next(ctx context.Context, item *LoopSegmentEntry) bool {
+	if it.done {
+		return false
+	}
+	if !it.started {
+		it.started = true
+		for i, iterator := range it.iterators {
+			it.valid[i] = iterator.Next(ctx, &it.heads[i])
+		}
+	} else {
+		// the returned segment may share memory with the iterator,
+		// hence the iterator is advanced only on the next call.
+		it.valid[it.last] = it.iterators[it.last].Next(ctx, &it.heads[it.last])
+	}
+
+	next := -1
+	for i := range it.heads {
+		if !it.valid[i] {
+			continue
+		}
+		if next < 0 || loopSegmentEntryLess(it.heads[i], it.heads[next]) {
+			next = i
+		}
+	}
+	if next < 0 {
+		it.done = true
+		return false
+	}
+
+	it.last = next
+	*item = it.heads[next]
+	return true
+}
+
+func loopSegmentEntryLess(a, b LoopSegmentEntry) bool {
+	if a.StreamID != b.StreamID {
+		return a.StreamID.Less(b.StreamID)
+	}
+	return a.Position.Less(b.Position)
+}
+
+// skipDeferredLoopSegmentsIterator skips the segments of streams with deferred segment deletion.
+//
+// The segments are iterated in the stream ID order, hence the deferred streams are
+// read in the same order, one batch at a time, instead of being loaded all at once.
+type skipDeferredLoopSegmentsIterator struct {
+	it       LoopSegmentsIterator
+	deferred []*deferredStreamIDs
+	err      error
+}
+
+// Next returns the next segment that is not waiting for deletion.
+func (it *skipDeferredLoopSegmentsIterator) Next(ctx context.Context, item *LoopSegmentEntry) bool {
+	if it.err != nil {
+		return false
+	}
+next:
+	for it.it.Next(ctx, item) {
+		for _, deferred := range it.deferred {
+			isDeferred, err := deferred.contains(ctx, item.StreamID)
+			if err != nil {
+				it.err = err
+				return false
+			}
+			if isDeferred {
+				continue next
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// deferredStreamIDs reads the deferred streams of an adapter in batches.
+type deferredStreamIDs struct {
+	adapter   Adapter
+	after     uuid.UUID
+	end       uuid.UUID
+	batchSize int
+
+	batch []uuid.UUID
+	done  bool
+}
+
+// contains returns whether the stream is deferred. The stream IDs must be
+// passed in ascending order.
+func (deferred *deferredStreamIDs) contains(ctx context.Context, streamID uuid.UUID) (bool, error) {
+	for {
+		for len(deferred.batch) > 0 && deferred.batch[0].Less(streamID) {
+			deferred.batch = deferred.batch[1:]
+		}
+		if len(deferred.batch) > 0 {
+			return deferred.batch[0] == streamID, nil
+		}
+		if deferred.done || !deferred.after.Less(streamID) {
+			return false, nil
+		}
+
+		batch, err := deferred.adapter.ListDeferredSegmentDeletionStreamIDs(ctx, deferred.after, deferred.end, deferred.batchSize)
+		if err != nil {
+			return false, err
+		}
+		if len(batch) < deferred.batchSize {
+			deferred.done = true
+		}
+		if len(batch) > 0 {
+			deferred.after = batch[len(batch)-1]
+		}
+		deferred.batch = batch
+		if len(batch) == 0 {
+			return false, nil
+		}
+	}
+}
+
+// filterLoopSegmentsIterator skips the segments which don't match the filter.
+// The adapters already filter by the conditions the database can check.
+type filterLoopSegmentsIterator struct {
+	it     LoopSegmentsIterator
+	filter LoopSegmentsFilter
+}
+
+// Next returns the next segment matching the filter.
+func (it *filterLoopSegmentsIterator) Next(ctx context.Context, item *LoopSegmentEntry) bool {
+	for it.it.Next(ctx, item) {
+		if it.filter.Match(item) {
+			return true
+		}
+	}
+	return false
 }
 
 // IterateLoopSegments implements Adapter.
@@ -276,6 +519,7 @@ func (p *PostgresAdapter) IterateLoopSegments(ctx context.Context, aliasCache *N
 		asOfSystemInterval: opts.AsOfSystemInterval,
 		batchSize:          opts.BatchSize,
 		batchPieces:        make([]Pieces, opts.BatchSize),
+		filter:             opts.Filter,
 
 		curIndex: 0,
 		cursor: loopSegmentIteratorCursor{
@@ -325,6 +569,8 @@ type postgresLoopSegmentIterator struct {
 	asOfSystemTime     time.Time
 	asOfSystemInterval time.Duration
 
+	filter LoopSegmentsFilter
+
 	curIndex int
 	curRows  tagsql.Rows
 	cursor   loopSegmentIteratorCursor
@@ -379,6 +625,21 @@ func (it *postgresLoopSegmentIterator) Next(ctx context.Context, item *LoopSegme
 func (it *postgresLoopSegmentIterator) doNextQuery(ctx context.Context) (_ tagsql.Rows, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	args := []interface{}{
+		it.cursor.StartStreamID, it.cursor.StartPosition.Encode(),
+		it.batchSize, it.cursor.EndStreamID,
+	}
+
+	var filter string
+	if it.filter.Placement != nil {
+		args = append(args, *it.filter.Placement)
+		filter += ` AND COALESCE(placement, 0) = $` + strconv.Itoa(len(args))
+	}
+	if !it.filter.CreatedBefore.IsZero() {
+		args = append(args, it.filter.CreatedBefore)
+		filter += ` AND created_at < $` + strconv.Itoa(len(args))
+	}
+
 	return it.db.db.QueryContext(ctx, `
 		SELECT
 			stream_id, position,
@@ -388,15 +649,15 @@ func (it *postgresLoopSegmentIterator) doNextQuery(ctx context.Context) (_ tagsq
 			plain_offset, plain_size,
 			redundancy,
 			remote_alias_pieces,
-			placement
+			placement,
+			healthy_pieces
 		FROM segments
 		`+it.db.impl.AsOfSystemInterval(it.asOfSystemInterval)+`
 		WHERE
-			(stream_id, position) > ($1, $2) AND stream_id <= $4
+			(stream_id, position) > ($1, $2) AND stream_id <= $4`+filter+`
 		ORDER BY (stream_id, position) ASC
 		LIMIT $3
-		`, it.cursor.StartStreamID, it.cursor.StartPosition.Encode(),
-		it.batchSize, it.cursor.EndStreamID,
+		`, args...,
 	)
 }
 
@@ -411,6 +672,7 @@ func (it *postgresLoopSegmentIterator) scanItem(ctx context.Context, item *LoopS
 		redundancyScheme{&item.Redundancy},
 		&item.AliasPieces,
 		&item.Placement,
+		&item.HealthyPieces,
 	)
 	if err != nil {
 		return Error.New("failed to scan segments: %w", err)
@@ -435,6 +697,7 @@ type spannerLoopSegmentIterator struct {
 	db *SpannerAdapter
 
 	batchSize int
+	filter    LoopSegmentsFilter
 	// TODO(spanner) would be nice to have it at some point
 	// batchPieces are reused between result pages to reduce memory consumption
 	// batchPieces []Pieces
@@ -493,6 +756,23 @@ func (it *spannerLoopSegmentIterator) Next(ctx context.Context, item *LoopSegmen
 }
 
 func (it *spannerLoopSegmentIterator) doNextQuery(ctx context.Context) (_ *spanner.RowIterator) {
+	params := map[string]interface{}{
+		"streamid":    it.cursor.StartStreamID.Bytes(),
+		"position":    int64(it.cursor.StartPosition.Encode()),
+		"endstreamid": it.cursor.EndStreamID.Bytes(),
+		"batchsize":   it.batchSize,
+	}
+
+	var filter string
+	if it.filter.Placement != nil {
+		params["placement"] = int64(*it.filter.Placement)
+		filter += ` AND COALESCE(placement, 0) = @placement`
+	}
+	if !it.filter.CreatedBefore.IsZero() {
+		params["createdbefore"] = it.filter.CreatedBefore
+		filter += ` AND created_at < @createdbefore`
+	}
+
 	stmt := spanner.Statement{
 		SQL: `
 			SELECT
@@ -503,19 +783,16 @@ func (it *spannerLoopSegmentIterator) doNextQuery(ctx context.Context) (_ *spann
 				plain_offset, plain_size,
 				redundancy,
 				remote_alias_pieces,
-				placement
+				placement,
+				healthy_pieces
 			FROM segments
 			WHERE
-				(stream_id > @streamid OR (stream_id = @streamid AND position > @position)) AND stream_id <= @endstreamid
+				(stream_id > @streamid OR (stream_id = @streamid AND position > @position)) AND stream_id <= @endstreamid` + filter + `
 			ORDER BY stream_id ASC, position ASC
 			LIMIT @batchsize
 		`,
-		Params: map[string]interface{}{
-			"streamid":    it.cursor.StartStreamID.Bytes(),
-			"position":    int64(it.cursor.StartPosition.Encode()),
-			"endstreamid": it.cursor.EndStreamID.Bytes(),
-			"batchsize":   it.batchSize,
-		}}
+		Params: params,
+	}
 	return it.db.client.Single().Query(ctx, stmt)
 }
 
@@ -526,6 +803,7 @@ func (s *SpannerAdapter) IterateLoopSegments(ctx context.Context, aliasCache *No
 		aliasCache: aliasCache,
 
 		batchSize: opts.BatchSize,
+		filter:    opts.Filter,
 
 		curIndex: 0,
 		cursor: loopSegmentIteratorCursor{
@@ -555,6 +833,7 @@ func (it *spannerLoopSegmentIterator) scanItem(ctx context.Context, item *LoopSe
 	var position int64
 	var createdAt time.Time
 	var repairedAt, expiresAt spanner.NullTime
+	var healthyPieces spanner.NullInt64
 	var encryptedSize, plainOffset, plainSize, placement int64
 	var streamID, rootPieceID []byte
 	var aliasPieces AliasPieces
@@ -565,6 +844,7 @@ func (it *spannerLoopSegmentIterator) scanItem(ctx context.Context, item *LoopSe
 		redundancyScheme{&item.Redundancy},
 		&aliasPieces,
 		&placement,
+		&healthyPieces,
 	); err != nil {
 		return Error.New("failed to scan segment: %w", err)
 	}
@@ -591,6 +871,12 @@ func (it *spannerLoopSegmentIterator) scanItem(ctx context.Context, item *LoopSe
 	item.AliasPieces = aliasPieces
 
 	item.Placement = storj.PlacementConstraint(placement)
+	if healthyPieces.Valid {
+		value := int(healthyPieces.Int64)
+		item.HealthyPieces = &value
+	} else {
+		item.HealthyPieces = nil
+	}
 	item.Pieces, err = it.aliasCache.ConvertAliasesToPieces(ctx, item.AliasPieces)
 	if err != nil {
 		return Error.New("failed to scan segment: %w", err)
@@ -0,0 +1,100 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+	"storj.io/common/uuid"
+)
+
+type sliceLoopSegmentsIterator struct {
+	segments []LoopSegmentEntry
+}
+
+func (it *sliceLoopSegmentsIterator) Next(ctx context.Context, item *LoopSegmentEntry) bool {
+	if len(it.segments) == 0 {
+		return false
+	}
+	*item = it.segments[0]
+	it.segments = it.segments[1:]
+	return true
+}
+
+func TestMergedLoopSegmentsIterator(t *testing.T) {
+	ctx := context.Background()
+
+	streamIDs := []uuid.UUID{testrand.UUID(), testrand.UUID(), testrand.UUID(), testrand.UUID()}
+	sort.Slice(streamIDs, func(i, k int) bool { return streamIDs[i].Less(streamIDs[k]) })
+
+	segment := func(stream int, index uint32) LoopSegmentEntry {
+		return LoopSegmentEntry{
+			StreamID: streamIDs[stream],
+			Position: SegmentPosition{Index: index},
+		}
+	}
+
+	collect := func(sources ...[]LoopSegmentEntry) []LoopSegmentEntry {
+		merged := &mergedLoopSegmentsIterator{
+			heads: make([]LoopSegmentEntry, len(sources)),
+			valid: make([]bool, len(sources)),
+		}
+		for _, source := range sources {
+			merged.iterators = append(merged.iterators, &sliceLoopSegmentsIterator{segments: source})
+		}
+
+		var result []LoopSegmentEntry
+		var entry LoopSegmentEntry
+		for merged.Next(ctx, &entry) {
+			result = append(result, entry)
+		}
+		// an exhausted iterator stays exhausted.
+		require.False(t, merged.Next(ctx, &entry))
+		return result
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		require.Empty(t, collect())
+		require.Empty(t, collect(nil, nil))
+	})
+
+	t.Run("single", func(t *testing.T) {
+		segments := []LoopSegmentEntry{segment(0, 0), segment(0, 1), segment(2, 0)}
+		require.Equal(t, segments, collect(nil, segments))
+		require.Equal(t, segments, collect(segments, nil))
+	})
+
+	t.Run("interleaved streams", func(t *testing.T) {
+		require.Equal(t,
+			[]LoopSegmentEntry{segment(0, 0), segment(0, 1), segment(1, 0), segment(2, 0), segment(2, 1), segment(3, 0)},
+			collect(
+				[]LoopSegmentEntry{segment(0, 0), segment(0, 1), segment(2, 0), segment(2, 1)},
+				[]LoopSegmentEntry{segment(1, 0), segment(3, 0)},
+			))
+	})
+
+	t.Run("positions of the same stream", func(t *testing.T) {
+		require.Equal(t,
+			[]LoopSegmentEntry{segment(1, 0), segment(1, 1), segment(1, 2), segment(1, 3), segment(2, 0)},
+			collect(
+				[]LoopSegmentEntry{segment(1, 1), segment(1, 3)},
+				[]LoopSegmentEntry{segment(1, 0), segment(1, 2), segment(2, 0)},
+			))
+	})
+
+	t.Run("three sources", func(t *testing.T) {
+		require.Equal(t,
+			[]LoopSegmentEntry{segment(0, 0), segment(1, 0), segment(1, 1), segment(2, 0), segment(3, 0), segment(3, 1)},
+			collect(
+				[]LoopSegmentEntry{segment(3, 0)},
+				[]LoopSegmentEntry{segment(1, 1), segment(3, 1)},
+				[]LoopSegmentEntry{segment(0, 0), segment(1, 0), segment(2, 0)},
+			))
+	})
+}
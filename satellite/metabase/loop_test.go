@@ -708,6 +708,91 @@ func TestIterateLoopSegmentsWithSpanner(t *testing.T) {
 				Segments: expectedRaw,
 			}.Check(ctx, t, db)
 		})
+
+		t.Run("filter", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			now := time.Now()
+			placement := storj.PlacementConstraint(3)
+
+			committed := metabasetest.RandObjectStream()
+			var expected []metabase.LoopSegmentEntry
+			var expectedRaw []metabase.RawSegment
+			for i, segment := range []struct {
+				pieces    int
+				placement storj.PlacementConstraint
+				createdAt time.Time
+			}{
+				{pieces: 1, placement: 0, createdAt: now.Add(-time.Hour)},
+				{pieces: 3, placement: 0, createdAt: now},
+				{pieces: 3, placement: placement, createdAt: now.Add(-time.Hour)},
+				{pieces: 5, placement: placement, createdAt: now},
+				{pieces: 5, placement: 0, createdAt: now.Add(-time.Hour)},
+			} {
+				rawSegment := metabasetest.DefaultRawSegment(committed, metabase.SegmentPosition{0, uint32(i)})
+				rawSegment.Placement = segment.placement
+				rawSegment.CreatedAt = segment.createdAt
+				rawSegment.Pieces = nil
+				for k := 0; k < segment.pieces; k++ {
+					rawSegment.Pieces = append(rawSegment.Pieces, metabase.Piece{Number: uint16(k), StorageNode: testrand.NodeID()})
+				}
+
+				expectedRaw = append(expectedRaw, rawSegment)
+				expected = append(expected, metabase.LoopSegmentEntry{
+					StreamID:      rawSegment.StreamID,
+					Position:      rawSegment.Position,
+					RootPieceID:   rawSegment.RootPieceID,
+					Pieces:        rawSegment.Pieces,
+					CreatedAt:     rawSegment.CreatedAt,
+					EncryptedSize: rawSegment.EncryptedSize,
+					PlainSize:     rawSegment.PlainSize,
+					PlainOffset:   rawSegment.PlainOffset,
+					Redundancy:    rawSegment.Redundancy,
+					Placement:     rawSegment.Placement,
+				})
+			}
+
+			err := db.TestingBatchInsertSegments(ctx, expectedRaw)
+			require.NoError(t, err)
+
+			metabasetest.IterateLoopSegments{
+				Opts: metabase.IterateLoopSegments{
+					Filter: metabase.LoopSegmentsFilter{MinPieces: 5, MaxPieces: 3},
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "Filter.MaxPieces is smaller than Filter.MinPieces",
+			}.Check(ctx, t, db)
+
+			for _, tc := range []struct {
+				name     string
+				filter   metabase.LoopSegmentsFilter
+				expected []metabase.LoopSegmentEntry
+			}{
+				{"none", metabase.LoopSegmentsFilter{}, expected},
+				{"min pieces", metabase.LoopSegmentsFilter{MinPieces: 3}, expected[1:]},
+				{"max pieces", metabase.LoopSegmentsFilter{MaxPieces: 3}, expected[:3]},
+				{"min and max pieces", metabase.LoopSegmentsFilter{MinPieces: 2, MaxPieces: 4}, expected[1:3]},
+				{"placement", metabase.LoopSegmentsFilter{Placement: &placement}, expected[2:4]},
+				{"created before", metabase.LoopSegmentsFilter{CreatedBefore: now.Add(-time.Minute)}, []metabase.LoopSegmentEntry{expected[0], expected[2], expected[4]}},
+				{"combined", metabase.LoopSegmentsFilter{MinPieces: 5, CreatedBefore: now.Add(-time.Minute)}, expected[4:]},
+			} {
+				t.Run(tc.name, func(t *testing.T) {
+					for _, batchSize := range []int{1, 2, 10} {
+						metabasetest.IterateLoopSegments{
+							Opts: metabase.IterateLoopSegments{
+								BatchSize: batchSize,
+								Filter:    tc.filter,
+							},
+							Result: append([]metabase.LoopSegmentEntry(nil), tc.expected...),
+						}.Check(ctx, t, db)
+					}
+				})
+			}
+
+			metabasetest.Verify{
+				Segments: expectedRaw,
+			}.Check(ctx, t, db)
+		})
 	})
 }
 
@@ -4,6 +4,7 @@
 package metabasetest
 
 import (
+	"bytes"
 	"sort"
 	"testing"
 	"time"
@@ -51,6 +52,12 @@ func sortObjects(objects []metabase.Object) {
 	})
 }
 
+func sortDeletedSegments(segments []metabase.DeletedSegmentInfo) {
+	sort.Slice(segments, func(i, j int) bool {
+		return bytes.Compare(segments[i].RootPieceID[:], segments[j].RootPieceID[:]) < 0
+	})
+}
+
 func sortBucketTallies(tallies []metabase.BucketTally) {
 	sort.Slice(tallies, func(i, j int) bool {
 		if tallies[i].ProjectID == tallies[j].ProjectID {
@@ -151,6 +151,52 @@ func (step CommitInlineObject) Check(ctx *testcontext.Context, t require.Testing
 	return object
 }
 
+// CommitSingleObject is for testing metabase.CommitSingleObject.
+type CommitSingleObject struct {
+	Opts          metabase.CommitSingleObject
+	ExpectVersion metabase.Version
+	ErrClass      *errs.Class
+	ErrText       string
+}
+
+// Check runs the test.
+func (step CommitSingleObject) Check(ctx *testcontext.Context, t require.TestingT, db *metabase.DB) metabase.Object {
+	object, err := db.CommitSingleObject(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+	if err == nil {
+		if step.ExpectVersion != 0 {
+			step.Opts.ObjectStream.Version = step.ExpectVersion
+		}
+		require.Equal(t, step.Opts.ObjectStream, object.ObjectStream)
+	}
+	return object
+}
+
+// CommitInlineObjects is for testing metabase.CommitInlineObjects.
+type CommitInlineObjects struct {
+	Opts           metabase.CommitInlineObjects
+	ExpectVersions []metabase.Version
+	ErrClass       *errs.Class
+	ErrText        string
+}
+
+// Check runs the test.
+func (step CommitInlineObjects) Check(ctx *testcontext.Context, t require.TestingT, db *metabase.DB) []metabase.Object {
+	objects, err := db.CommitInlineObjects(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+	if err == nil {
+		require.Len(t, objects, len(step.Opts.Objects))
+		for i, object := range objects {
+			expected := step.Opts.Objects[i].ObjectStream
+			if len(step.ExpectVersions) > 0 {
+				expected.Version = step.ExpectVersions[i]
+			}
+			require.Equal(t, expected, object.ObjectStream)
+		}
+	}
+	return objects
+}
+
 // BeginSegment is for testing metabase.BeginSegment.
 type BeginSegment struct {
 	Opts     metabase.BeginSegment
@@ -218,6 +264,19 @@ func (step UpdateObjectLastCommittedMetadata) Check(ctx *testcontext.Context, t
 	checkError(t, err, step.ErrClass, step.ErrText)
 }
 
+// UpdateObjectExactVersionMetadata is for testing metabase.UpdateObjectExactVersionMetadata.
+type UpdateObjectExactVersionMetadata struct {
+	Opts     metabase.UpdateObjectExactVersionMetadata
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step UpdateObjectExactVersionMetadata) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	err := db.UpdateObjectExactVersionMetadata(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+}
+
 // UpdateSegmentPieces is for testing metabase.UpdateSegmentPieces.
 type UpdateSegmentPieces struct {
 	Opts     metabase.UpdateSegmentPieces
@@ -264,6 +323,38 @@ func (step GetObjectLastCommitted) Check(ctx *testcontext.Context, t testing.TB,
 	require.Zero(t, diff)
 }
 
+// GetObjectsLastCommitted is for testing metabase.GetObjectsLastCommitted.
+type GetObjectsLastCommitted struct {
+	Opts     metabase.GetObjectsLastCommitted
+	Result   map[metabase.ObjectLocation]metabase.Object
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step GetObjectsLastCommitted) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.GetObjectsLastCommitted(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+	diff := cmp.Diff(step.Result, result, DefaultTimeDiff(), cmpopts.EquateEmpty())
+	require.Zero(t, diff)
+}
+
+// GetObjectWithSegment is for testing metabase.GetObjectWithSegment.
+type GetObjectWithSegment struct {
+	Opts     metabase.GetObjectWithSegment
+	Result   metabase.ObjectWithSegment
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step GetObjectWithSegment) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.GetObjectWithSegment(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+	diff := cmp.Diff(step.Result, result, DefaultTimeDiff())
+	require.Zero(t, diff)
+}
+
 // GetSegmentByPosition is for testing metabase.GetSegmentByPosition.
 type GetSegmentByPosition struct {
 	Opts     metabase.GetSegmentByPosition
@@ -369,6 +460,42 @@ func (step ListObjects) Check(ctx *testcontext.Context, t testing.TB, db *metaba
 	require.Zero(t, diff)
 }
 
+// ListObjectChanges is for testing metabase.ListObjectChanges.
+type ListObjectChanges struct {
+	Opts     metabase.ListObjectChanges
+	Result   metabase.ListObjectChangesResult
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step ListObjectChanges) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) metabase.ListObjectChangesResult {
+	result, err := db.ListObjectChanges(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+
+	diff := cmp.Diff(step.Result, result, DefaultTimeDiff(), cmpopts.EquateEmpty())
+	require.Zero(t, diff)
+	return result
+}
+
+// ListDeleteMarkers is for testing metabase.ListDeleteMarkers.
+type ListDeleteMarkers struct {
+	Opts     metabase.ListDeleteMarkers
+	Result   metabase.ListDeleteMarkersResult
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step ListDeleteMarkers) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) metabase.ListDeleteMarkersResult {
+	result, err := db.ListDeleteMarkers(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+
+	diff := cmp.Diff(step.Result, result, DefaultTimeDiff(), cmpopts.EquateEmpty())
+	require.Zero(t, diff)
+	return result
+}
+
 // ListStreamPositions is for testing metabase.ListStreamPositions.
 type ListStreamPositions struct {
 	Opts     metabase.ListStreamPositions
@@ -463,6 +590,9 @@ func compareDeleteObjectResult(t testing.TB, got, exp metabase.DeleteObjectResul
 	sortObjects(got.Removed)
 	sortObjects(exp.Removed)
 
+	sortDeletedSegments(got.DeletedSegments)
+	sortDeletedSegments(exp.DeletedSegments)
+
 	diff := cmp.Diff(exp, got, DefaultTimeDiff(), cmpopts.EquateEmpty())
 	require.Zero(t, diff)
 }
@@ -474,6 +604,23 @@ func (step DeleteObjectExactVersion) Check(ctx *testcontext.Context, t testing.T
 	compareDeleteObjectResult(t, result, step.Result)
 }
 
+// DeleteObjectVersions is for testing metabase.DeleteObjectVersions.
+type DeleteObjectVersions struct {
+	Opts     metabase.DeleteObjectVersions
+	Result   metabase.DeleteObjectVersionsResult
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step DeleteObjectVersions) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.DeleteObjectVersions(ctx, step.Opts, nil)
+	checkError(t, err, step.ErrClass, step.ErrText)
+
+	diff := cmp.Diff(step.Result, result, DefaultTimeDiff(), cmpopts.EquateEmpty())
+	require.Zero(t, diff)
+}
+
 // DeletePendingObject is for testing metabase.DeletePendingObject.
 type DeletePendingObject struct {
 	Opts     metabase.DeletePendingObject
@@ -787,6 +934,21 @@ func (step DeleteObjectLastCommitted) Check(ctx *testcontext.Context, t testing.
 	return result
 }
 
+// DeleteDeleteMarker is for testing metabase.DeleteDeleteMarker.
+type DeleteDeleteMarker struct {
+	Opts     metabase.DeleteDeleteMarker
+	Result   metabase.DeleteObjectResult
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step DeleteDeleteMarker) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.DeleteDeleteMarker(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+	compareDeleteObjectResult(t, result, step.Result)
+}
+
 // CollectBucketTallies is for testing metabase.CollectBucketTallies.
 type CollectBucketTallies struct {
 	Opts     metabase.CollectBucketTallies
@@ -866,3 +1028,119 @@ func (step SetObjectLastCommittedRetention) Check(ctx *testcontext.Context, t te
 	err := db.SetObjectLastCommittedRetention(ctx, step.Opts)
 	checkError(t, err, step.ErrClass, step.ErrText)
 }
+
+// GetObjectExactVersionLegalHold is for testing metabase.GetObjectExactVersionLegalHold.
+type GetObjectExactVersionLegalHold struct {
+	Opts     metabase.GetObjectExactVersionLegalHold
+	Result   bool
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step GetObjectExactVersionLegalHold) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.GetObjectExactVersionLegalHold(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+	require.Equal(t, step.Result, result)
+}
+
+// GetObjectLastCommittedLegalHold is for testing metabase.GetObjectLastCommittedLegalHold.
+type GetObjectLastCommittedLegalHold struct {
+	Opts     metabase.GetObjectLastCommittedLegalHold
+	Result   bool
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step GetObjectLastCommittedLegalHold) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.GetObjectLastCommittedLegalHold(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+	require.Equal(t, step.Result, result)
+}
+
+// SetObjectExactVersionLegalHold is for testing metabase.SetObjectExactVersionLegalHold.
+type SetObjectExactVersionLegalHold struct {
+	Opts     metabase.SetObjectExactVersionLegalHold
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step SetObjectExactVersionLegalHold) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	err := db.SetObjectExactVersionLegalHold(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+}
+
+// SetObjectLastCommittedLegalHold is for testing metabase.SetObjectLastCommittedLegalHold.
+type SetObjectLastCommittedLegalHold struct {
+	Opts     metabase.SetObjectLastCommittedLegalHold
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step SetObjectLastCommittedLegalHold) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	err := db.SetObjectLastCommittedLegalHold(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+}
+
+// GetObjectExactVersionTags is for testing metabase.GetObjectExactVersionTags.
+type GetObjectExactVersionTags struct {
+	Opts     metabase.GetObjectExactVersionTags
+	Result   metabase.ObjectTags
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step GetObjectExactVersionTags) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.GetObjectExactVersionTags(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+
+	diff := cmp.Diff(step.Result, result, cmpopts.EquateEmpty())
+	require.Zero(t, diff)
+}
+
+// GetObjectLastCommittedTags is for testing metabase.GetObjectLastCommittedTags.
+type GetObjectLastCommittedTags struct {
+	Opts     metabase.GetObjectLastCommittedTags
+	Result   metabase.ObjectTags
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step GetObjectLastCommittedTags) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	result, err := db.GetObjectLastCommittedTags(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+
+	diff := cmp.Diff(step.Result, result, cmpopts.EquateEmpty())
+	require.Zero(t, diff)
+}
+
+// SetObjectExactVersionTags is for testing metabase.SetObjectExactVersionTags.
+type SetObjectExactVersionTags struct {
+	Opts     metabase.SetObjectExactVersionTags
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step SetObjectExactVersionTags) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	err := db.SetObjectExactVersionTags(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+}
+
+// SetObjectLastCommittedTags is for testing metabase.SetObjectLastCommittedTags.
+type SetObjectLastCommittedTags struct {
+	Opts     metabase.SetObjectLastCommittedTags
+	ErrClass *errs.Class
+	ErrText  string
+}
+
+// Check runs the test.
+func (step SetObjectLastCommittedTags) Check(ctx *testcontext.Context, t testing.TB, db *metabase.DB) {
+	err := db.SetObjectLastCommittedTags(ctx, step.Opts)
+	checkError(t, err, step.ErrClass, step.ErrText)
+}
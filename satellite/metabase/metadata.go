@@ -4,6 +4,7 @@
 package metabase
 
 import (
+	"bytes"
 	"context"
 
 	"cloud.google.com/go/spanner"
@@ -20,6 +21,19 @@ type UpdateObjectLastCommittedMetadata struct {
 	EncryptedMetadata             []byte
 	EncryptedMetadataNonce        []byte
 	EncryptedMetadataEncryptedKey []byte
+
+	// IfMetadataUnchanged enables compare-and-swap semantics: the metadata is
+	// replaced only when the current metadata nonce of the object matches
+	// ExpectedEncryptedMetadataNonce. Every metadata update must use a new
+	// nonce, hence it identifies the metadata which was read by the caller.
+	//
+	// When the object was replaced or its metadata was changed in the meantime,
+	// the update fails with ErrConflict.
+	IfMetadataUnchanged            bool
+	ExpectedEncryptedMetadataNonce []byte
+
+	// encryptedMetadataKeyID is the key the metadata is wrapped with, set by DB.
+	encryptedMetadataKeyID uint16
 }
 
 // Verify object stream fields.
@@ -30,6 +44,13 @@ func (obj *UpdateObjectLastCommittedMetadata) Verify() error {
 	if obj.StreamID.IsZero() {
 		return ErrInvalidRequest.New("StreamID missing")
 	}
+	if !obj.IfMetadataUnchanged && len(obj.ExpectedEncryptedMetadataNonce) > 0 {
+		return ErrInvalidRequest.New("ExpectedEncryptedMetadataNonce is set but IfMetadataUnchanged is not")
+	}
+	if obj.IfMetadataUnchanged && len(obj.EncryptedMetadataNonce) > 0 && bytes.Equal(obj.EncryptedMetadataNonce, obj.ExpectedEncryptedMetadataNonce) {
+		// reusing the nonce would hide the update from other compare-and-swap callers.
+		return ErrInvalidRequest.New("EncryptedMetadataNonce must differ from ExpectedEncryptedMetadataNonce")
+	}
 	return nil
 }
 
@@ -41,11 +62,34 @@ func (db *DB) UpdateObjectLastCommittedMetadata(ctx context.Context, opts Update
 		return err
 	}
 
+	if len(opts.ExpectedEncryptedMetadataNonce) == 0 {
+		// metadata without nonce is stored as NULL.
+		opts.ExpectedEncryptedMetadataNonce = nil
+	}
+
+	opts.encryptedMetadataKeyID, err = db.metadataEncryption.encryptMetadata(opts.ProjectID, &opts.EncryptedMetadata, &opts.EncryptedMetadataEncryptedKey)
+	if err != nil {
+		return err
+	}
+
 	affected, err := db.ChooseAdapter(opts.ProjectID).UpdateObjectLastCommittedMetadata(ctx, opts)
 	if err != nil {
 		return err
 	}
 	if affected == 0 {
+		if opts.IfMetadataUnchanged {
+			// distinguish between a missing object and a concurrent modification.
+			_, err := db.GetObjectLastCommitted(ctx, GetObjectLastCommitted{
+				ObjectLocation: opts.ObjectLocation,
+			})
+			switch {
+			case err == nil:
+				mon.Meter("object_update_metadata_conflict").Mark(1)
+				return ErrConflict.New("object or its metadata was modified concurrently")
+			case !ErrObjectNotFound.Has(err):
+				return err
+			}
+		}
 		return ErrObjectNotFound.New("object with specified version and committed status is missing")
 	}
 
@@ -74,7 +118,8 @@ func (p *PostgresAdapter) UpdateObjectLastCommittedMetadata(ctx context.Context,
 		UPDATE objects SET
 			encrypted_metadata_nonce         = $5,
 			encrypted_metadata               = $6,
-			encrypted_metadata_encrypted_key = $7
+			encrypted_metadata_encrypted_key = $7,
+			encrypted_metadata_key_id        = $10
 		WHERE
 			(project_id, bucket_name, object_key) = ($1, $2, $3) AND
 			version IN (SELECT version FROM objects WHERE
@@ -85,9 +130,12 @@ func (p *PostgresAdapter) UpdateObjectLastCommittedMetadata(ctx context.Context,
 				LIMIT 1
 			) AND
 			stream_id    = $4 AND
-			status       IN `+statusesCommitted,
+			status       IN `+statusesCommitted+` AND
+			(NOT $8::BOOL OR COALESCE(encrypted_metadata_nonce, ''::BYTEA) = COALESCE($9::BYTEA, ''::BYTEA))`,
 		opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.StreamID,
-		opts.EncryptedMetadataNonce, opts.EncryptedMetadata, opts.EncryptedMetadataEncryptedKey)
+		opts.EncryptedMetadataNonce, opts.EncryptedMetadata, opts.EncryptedMetadataEncryptedKey,
+		opts.IfMetadataUnchanged, opts.ExpectedEncryptedMetadataNonce,
+		opts.encryptedMetadataKeyID)
 	if err != nil {
 		return 0, Error.New("unable to update object metadata: %w", err)
 	}
@@ -114,7 +162,8 @@ func (s *SpannerAdapter) UpdateObjectLastCommittedMetadata(ctx context.Context,
 				UPDATE objects SET
 					encrypted_metadata_nonce         = @encrypted_metadata_nonce,
 					encrypted_metadata               = @encrypted_metadata,
-					encrypted_metadata_encrypted_key = @encrypted_metadata_encrypted_key
+					encrypted_metadata_encrypted_key = @encrypted_metadata_encrypted_key,
+					encrypted_metadata_key_id        = @encrypted_metadata_key_id
 				WHERE
 					(project_id, bucket_name, object_key) = (@project_id, @bucket_name, @object_key) AND
 					version IN (SELECT version FROM objects WHERE
@@ -125,16 +174,169 @@ func (s *SpannerAdapter) UpdateObjectLastCommittedMetadata(ctx context.Context,
 						LIMIT 1
 					) AND
 					stream_id    = @stream_id AND
-					status       IN ` + statusesCommitted + `
+					status       IN ` + statusesCommitted + ` AND
+					(NOT @if_metadata_unchanged OR COALESCE(encrypted_metadata_nonce, b'') = COALESCE(@expected_encrypted_metadata_nonce, b''))
+			`,
+			Params: map[string]interface{}{
+				"project_id":                        opts.ProjectID,
+				"bucket_name":                       opts.BucketName,
+				"object_key":                        []byte(opts.ObjectKey),
+				"stream_id":                         opts.StreamID,
+				"encrypted_metadata_nonce":          opts.EncryptedMetadataNonce,
+				"encrypted_metadata":                opts.EncryptedMetadata,
+				"encrypted_metadata_encrypted_key":  opts.EncryptedMetadataEncryptedKey,
+				"encrypted_metadata_key_id":         int64(opts.encryptedMetadataKeyID),
+				"if_metadata_unchanged":             opts.IfMetadataUnchanged,
+				"expected_encrypted_metadata_nonce": opts.ExpectedEncryptedMetadataNonce,
+			},
+		})
+		if err != nil {
+			return Error.New("unable to update object metadata: %w", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+	return affected, nil
+}
+
+// UpdateObjectExactVersionMetadata contains arguments necessary for updating
+// parts of the metadata of an exact object version.
+//
+// The update is applied only while the object still has the specified version
+// and stream ID, hence the version acts as an optimistic concurrency check.
+type UpdateObjectExactVersionMetadata struct {
+	ObjectStream
+
+	// SetEncryptedMetadata replaces the encrypted user metadata.
+	SetEncryptedMetadata bool
+	EncryptedMetadata    []byte
+
+	// SetEncryptedMetadataKey replaces the nonce and the encrypted key of the metadata.
+	SetEncryptedMetadataKey       bool
+	EncryptedMetadataNonce        []byte
+	EncryptedMetadataEncryptedKey []byte
+
+	// encryptedMetadataKeyID is the key the metadata is wrapped with, set by DB.
+	encryptedMetadataKeyID uint16
+}
+
+// Verify verifies the update fields.
+func (opts *UpdateObjectExactVersionMetadata) Verify() error {
+	if err := opts.ObjectStream.Verify(); err != nil {
+		return err
+	}
+	if !opts.SetEncryptedMetadata && !opts.SetEncryptedMetadataKey {
+		return ErrInvalidRequest.New("nothing to update")
+	}
+	if opts.SetEncryptedMetadataKey && (len(opts.EncryptedMetadataNonce) == 0) != (len(opts.EncryptedMetadataEncryptedKey) == 0) {
+		return ErrInvalidRequest.New("EncryptedMetadataNonce and EncryptedMetadataEncryptedKey must be set together")
+	}
+	return nil
+}
+
+// UpdateObjectExactVersionMetadata updates only the specified parts of the
+// metadata of a committed object version, e.g. for metadata-only copies.
+//
+// When the object version doesn't exist anymore, but the location has another
+// committed object, the update fails with ErrConflict.
+func (db *DB) UpdateObjectExactVersionMetadata(ctx context.Context, opts UpdateObjectExactVersionMetadata) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	opts.encryptedMetadataKeyID, err = db.metadataEncryption.encryptMetadata(opts.ProjectID, &opts.EncryptedMetadata, &opts.EncryptedMetadataEncryptedKey)
+	if err != nil {
+		return err
+	}
+
+	affected, err := db.ChooseAdapter(opts.ProjectID).UpdateObjectExactVersionMetadata(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		// distinguish between a missing object and a concurrent modification.
+		_, err := db.GetObjectLastCommitted(ctx, GetObjectLastCommitted{
+			ObjectLocation: opts.Location(),
+		})
+		switch {
+		case err == nil:
+			mon.Meter("object_update_metadata_conflict").Mark(1)
+			return ErrConflict.New("object was modified concurrently")
+		case !ErrObjectNotFound.Has(err):
+			return err
+		}
+		return ErrObjectNotFound.New("object with specified version and committed status is missing")
+	}
+
+	mon.Meter("object_update_metadata_partial").Mark(int(affected))
+
+	return nil
+}
+
+// UpdateObjectExactVersionMetadata updates parts of an object metadata.
+func (p *PostgresAdapter) UpdateObjectExactVersionMetadata(ctx context.Context, opts UpdateObjectExactVersionMetadata) (affected int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE objects SET
+			encrypted_metadata_nonce         = CASE WHEN $6::BOOL THEN $7::BYTEA ELSE encrypted_metadata_nonce END,
+			encrypted_metadata_encrypted_key = CASE WHEN $6::BOOL THEN $8::BYTEA ELSE encrypted_metadata_encrypted_key END,
+			encrypted_metadata               = CASE WHEN $9::BOOL THEN $10::BYTEA ELSE encrypted_metadata END,
+			encrypted_metadata_key_id        = CASE WHEN $9::BOOL THEN $11::INT4 ELSE encrypted_metadata_key_id END
+		WHERE
+			(project_id, bucket_name, object_key, version) = ($1, $2, $3, $4) AND
+			stream_id = $5 AND
+			status    IN `+statusesCommitted+` AND
+			(expires_at IS NULL OR expires_at > now())`,
+		opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.Version, opts.StreamID,
+		opts.SetEncryptedMetadataKey, opts.EncryptedMetadataNonce, opts.EncryptedMetadataEncryptedKey,
+		opts.SetEncryptedMetadata, opts.EncryptedMetadata, opts.encryptedMetadataKeyID)
+	if err != nil {
+		return 0, Error.New("unable to update object metadata: %w", err)
+	}
+
+	affected, err = result.RowsAffected()
+	if err != nil {
+		return 0, Error.New("failed to get rows affected: %w", err)
+	}
+	return affected, nil
+}
+
+// UpdateObjectExactVersionMetadata updates parts of an object metadata.
+func (s *SpannerAdapter) UpdateObjectExactVersionMetadata(ctx context.Context, opts UpdateObjectExactVersionMetadata) (affected int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		affected, err = tx.Update(ctx, spanner.Statement{
+			SQL: `
+				UPDATE objects SET
+					encrypted_metadata_nonce         = CASE WHEN @set_encrypted_metadata_key THEN @encrypted_metadata_nonce ELSE encrypted_metadata_nonce END,
+					encrypted_metadata_encrypted_key = CASE WHEN @set_encrypted_metadata_key THEN @encrypted_metadata_encrypted_key ELSE encrypted_metadata_encrypted_key END,
+					encrypted_metadata               = CASE WHEN @set_encrypted_metadata THEN @encrypted_metadata ELSE encrypted_metadata END,
+					encrypted_metadata_key_id        = CASE WHEN @set_encrypted_metadata THEN @encrypted_metadata_key_id ELSE encrypted_metadata_key_id END
+				WHERE
+					(project_id, bucket_name, object_key, version) = (@project_id, @bucket_name, @object_key, @version) AND
+					stream_id = @stream_id AND
+					status    IN ` + statusesCommitted + ` AND
+					(expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
 			`,
 			Params: map[string]interface{}{
 				"project_id":                       opts.ProjectID,
 				"bucket_name":                      opts.BucketName,
 				"object_key":                       []byte(opts.ObjectKey),
+				"version":                          opts.Version,
 				"stream_id":                        opts.StreamID,
+				"set_encrypted_metadata_key":       opts.SetEncryptedMetadataKey,
 				"encrypted_metadata_nonce":         opts.EncryptedMetadataNonce,
-				"encrypted_metadata":               opts.EncryptedMetadata,
 				"encrypted_metadata_encrypted_key": opts.EncryptedMetadataEncryptedKey,
+				"set_encrypted_metadata":           opts.SetEncryptedMetadata,
+				"encrypted_metadata":               opts.EncryptedMetadata,
+				"encrypted_metadata_key_id":        int64(opts.encryptedMetadataKeyID),
 			},
 		})
 		if err != nil {
@@ -0,0 +1,240 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+)
+
+// ErrMetadataEncryption is used when the metadata envelope can't be encrypted or decrypted.
+var ErrMetadataEncryption = errs.Class("metadata encryption")
+
+// MetadataEncryptionConfig configures the encryption at rest of the object metadata columns.
+//
+// The metadata is already encrypted by the clients. The satellite additionally
+// wraps encrypted_metadata and encrypted_metadata_encrypted_key into an envelope
+// encrypted with a key derived from a master key and the project ID, so a leaked
+// database dump doesn't contain the client ciphertext.
+type MetadataEncryptionConfig struct {
+	// Keys are the master keys by their identifier. All keys, which may still
+	// be used by an envelope, have to be configured.
+	Keys map[uint16]storj.Key
+	// ActiveKeyID is the identifier of the key used for encrypting the new
+	// metadata. 0 disables encrypting the new metadata.
+	ActiveKeyID uint16
+}
+
+// envelopeMagic marks the values wrapped into a metadata envelope. It's long
+// enough that client ciphertext practically never starts with it.
+var envelopeMagic = []byte{0xff, 's', 'm', 'e', 't', 'a', 0xff, 1}
+
+const (
+	envelopeKeyIDSize  = 2
+	envelopeHeaderSize = 8 + envelopeKeyIDSize
+	envelopeNonceSize  = 12
+	envelopeTagSize    = 16
+
+	// envelopeOverhead is the number of bytes an envelope adds to the value.
+	// It's not accounted to the users.
+	envelopeOverhead = envelopeHeaderSize + envelopeNonceSize + envelopeTagSize
+)
+
+// metadataEncryption encrypts and decrypts the metadata envelopes. A nil
+// metadataEncryption leaves the values unchanged.
+type metadataEncryption struct {
+	keys     map[uint16]storj.Key
+	activeID uint16
+}
+
+func newMetadataEncryption(config MetadataEncryptionConfig) (*metadataEncryption, error) {
+	if len(config.Keys) == 0 {
+		if config.ActiveKeyID != 0 {
+			return nil, ErrMetadataEncryption.New("active key %d is not configured", config.ActiveKeyID)
+		}
+		return nil, nil
+	}
+	if _, ok := config.Keys[0]; ok {
+		return nil, ErrMetadataEncryption.New("key identifier 0 is reserved")
+	}
+	if _, ok := config.Keys[config.ActiveKeyID]; config.ActiveKeyID != 0 && !ok {
+		return nil, ErrMetadataEncryption.New("active key %d is not configured", config.ActiveKeyID)
+	}
+	return &metadataEncryption{
+		keys:     config.Keys,
+		activeID: config.ActiveKeyID,
+	}, nil
+}
+
+// TestingSetMetadataEncryption replaces the metadata encryption configuration, e.g. for simulating a key rotation.
+func (db *DB) TestingSetMetadataEncryption(config MetadataEncryptionConfig) error {
+	enc, err := newMetadataEncryption(config)
+	if err != nil {
+		return err
+	}
+	db.metadataEncryption = enc
+	return nil
+}
+
+// aead returns the cipher of the project for the key.
+func (enc *metadataEncryption) aead(keyID uint16, projectID uuid.UUID) (cipher.AEAD, error) {
+	master, ok := enc.keys[keyID]
+	if !ok {
+		return nil, ErrMetadataEncryption.New("key %d is not configured", keyID)
+	}
+
+	mac := hmac.New(sha256.New, master[:])
+	_, _ = mac.Write([]byte("metadata"))
+	_, _ = mac.Write(projectID[:])
+
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, ErrMetadataEncryption.Wrap(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, ErrMetadataEncryption.Wrap(err)
+	}
+	return aead, nil
+}
+
+// envelopeKeyID returns the identifier of the key the value is encrypted with, 0 when the value isn't an envelope.
+func envelopeKeyID(value []byte) uint16 {
+	if len(value) < envelopeHeaderSize || !bytes.Equal(value[:len(envelopeMagic)], envelopeMagic) {
+		return 0
+	}
+	return binary.BigEndian.Uint16(value[len(envelopeMagic):envelopeHeaderSize])
+}
+
+// encrypt wraps the value into an envelope encrypted with the active key.
+func (enc *metadataEncryption) encrypt(projectID uuid.UUID, value []byte) ([]byte, error) {
+	if enc == nil || enc.activeID == 0 || len(value) == 0 {
+		return value, nil
+	}
+
+	aead, err := enc.aead(enc.activeID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope := make([]byte, envelopeHeaderSize+envelopeNonceSize, envelopeHeaderSize+envelopeNonceSize+len(value)+aead.Overhead())
+	copy(envelope, envelopeMagic)
+	binary.BigEndian.PutUint16(envelope[len(envelopeMagic):], enc.activeID)
+
+	nonce := envelope[envelopeHeaderSize:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, ErrMetadataEncryption.Wrap(err)
+	}
+
+	return aead.Seal(envelope, nonce, value, projectID[:]), nil
+}
+
+// decrypt unwraps the value from the envelope. Values which aren't envelopes are returned unchanged.
+func (enc *metadataEncryption) decrypt(projectID uuid.UUID, value []byte) ([]byte, error) {
+	keyID := envelopeKeyID(value)
+	if keyID == 0 {
+		return value, nil
+	}
+	if enc == nil {
+		return nil, ErrMetadataEncryption.New("metadata is encrypted with key %d, but metadata encryption is not configured", keyID)
+	}
+
+	aead, err := enc.aead(keyID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(value) < envelopeHeaderSize+envelopeNonceSize {
+		return nil, ErrMetadataEncryption.New("envelope is too short")
+	}
+	nonce := value[envelopeHeaderSize : envelopeHeaderSize+envelopeNonceSize]
+	plain, err := aead.Open(nil, nonce, value[envelopeHeaderSize+envelopeNonceSize:], projectID[:])
+	if err != nil {
+		return nil, ErrMetadataEncryption.Wrap(err)
+	}
+	return plain, nil
+}
+
+// encryptMetadata wraps the metadata and its encrypted key into envelopes. It
+// returns the identifier of the key the metadata is encrypted with, 0 when the
+// metadata isn't wrapped. It's stored in the encrypted_metadata_key_id column,
+// so the envelope doesn't have to be recognized by its content.
+func (enc *metadataEncryption) encryptMetadata(projectID uuid.UUID, metadata, encryptedKey *[]byte) (keyID uint16, err error) {
+	if *metadata, err = enc.encrypt(projectID, *metadata); err != nil {
+		return 0, err
+	}
+	if *encryptedKey, err = enc.encrypt(projectID, *encryptedKey); err != nil {
+		return 0, err
+	}
+	return enc.metadataKeyID(*metadata), nil
+}
+
+// metadataKeyID returns the identifier of the key the metadata encrypted by
+// encryptMetadata is wrapped with.
+func (enc *metadataEncryption) metadataKeyID(metadata []byte) uint16 {
+	if enc == nil || len(metadata) == 0 {
+		return 0
+	}
+	return enc.activeID
+}
+
+// decryptMetadata unwraps the metadata and its encrypted key from the envelopes.
+func (enc *metadataEncryption) decryptMetadata(projectID uuid.UUID, metadata, encryptedKey *[]byte) (err error) {
+	if *metadata, err = enc.decrypt(projectID, *metadata); err != nil {
+		return err
+	}
+	*encryptedKey, err = enc.decrypt(projectID, *encryptedKey)
+	return err
+}
+
+// decryptObject unwraps the metadata of the object.
+func (enc *metadataEncryption) decryptObject(object *Object) error {
+	return enc.decryptMetadata(object.ProjectID, &object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey)
+}
+
+// decryptObjects unwraps the metadata of the objects.
+func (enc *metadataEncryption) decryptObjects(objects []Object) error {
+	for i := range objects {
+		if err := enc.decryptObject(&objects[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decryptingObjectsIterator unwraps the metadata of the iterated entries.
+type decryptingObjectsIterator struct {
+	ObjectsIterator
+	enc       *metadataEncryption
+	projectID uuid.UUID
+	err       error
+}
+
+// Next implements ObjectsIterator. It stops the iteration when the metadata can't be decrypted.
+func (it *decryptingObjectsIterator) Next(ctx context.Context, item *ObjectEntry) bool {
+	if it.err != nil || !it.ObjectsIterator.Next(ctx, item) {
+		return false
+	}
+	it.err = it.enc.decryptMetadata(it.projectID, &item.EncryptedMetadata, &item.EncryptedMetadataEncryptedKey)
+	return it.err == nil
+}
+
+// decryptingIterate wraps the iterator passed to fn, so the metadata of the entries is unwrapped.
+func (enc *metadataEncryption) decryptingIterate(projectID uuid.UUID, fn func(context.Context, ObjectsIterator) error) func(context.Context, ObjectsIterator) error {
+	return func(ctx context.Context, it ObjectsIterator) error {
+		decrypting := &decryptingObjectsIterator{ObjectsIterator: it, enc: enc, projectID: projectID}
+		return errs.Combine(fn(ctx, decrypting), decrypting.err)
+	}
+}
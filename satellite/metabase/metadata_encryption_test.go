@@ -0,0 +1,245 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestMetadataEncryption(t *testing.T) {
+	keys := map[uint16]storj.Key{
+		1: testrand.Key(),
+		2: testrand.Key(),
+	}
+
+	metabasetest.RunWithConfig(t, metabase.Config{
+		ApplicationName: "metabase-tests",
+		MetadataEncryption: metabase.MetadataEncryptionConfig{
+			Keys:        keys,
+			ActiveKeyID: 1,
+		},
+	}, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+		metadata := testrand.Bytes(64)
+		encryptedKey := testrand.Bytes(32)
+		nonce := testrand.Nonce()
+
+		obj := metabasetest.RandObjectStream()
+		metabasetest.CreatePendingObject(ctx, t, db, obj, 0)
+
+		committed, err := db.CommitObject(ctx, metabase.CommitObject{
+			ObjectStream:                  obj,
+			OverrideEncryptedMetadata:     true,
+			EncryptedMetadata:             metadata,
+			EncryptedMetadataNonce:        nonce[:],
+			EncryptedMetadataEncryptedKey: encryptedKey,
+		})
+		require.NoError(t, err)
+		require.Equal(t, metadata, committed.EncryptedMetadata)
+		require.Equal(t, encryptedKey, committed.EncryptedMetadataEncryptedKey)
+
+		requireStored := func(encrypted bool) {
+			state, err := db.TestingGetState(ctx)
+			require.NoError(t, err)
+			require.Len(t, state.Objects, 1)
+
+			stored := state.Objects[0]
+			require.Equal(t, nonce[:], stored.EncryptedMetadataNonce)
+			if encrypted {
+				require.NotEqual(t, metadata, stored.EncryptedMetadata)
+				require.NotEqual(t, encryptedKey, stored.EncryptedMetadataEncryptedKey)
+			} else {
+				require.Equal(t, metadata, stored.EncryptedMetadata)
+				require.Equal(t, encryptedKey, stored.EncryptedMetadataEncryptedKey)
+			}
+		}
+
+		requireReadable := func() {
+			object, err := db.GetObjectLastCommitted(ctx, metabase.GetObjectLastCommitted{
+				ObjectLocation: obj.Location(),
+			})
+			require.NoError(t, err)
+			require.Equal(t, metadata, object.EncryptedMetadata)
+			require.Equal(t, encryptedKey, object.EncryptedMetadataEncryptedKey)
+
+			result, err := db.ListObjects(ctx, metabase.ListObjects{
+				ProjectID:             obj.ProjectID,
+				BucketName:            obj.BucketName,
+				Recursive:             true,
+				Limit:                 10,
+				IncludeCustomMetadata: true,
+			})
+			require.NoError(t, err)
+			require.Len(t, result.Objects, 1)
+			require.Equal(t, metadata, result.Objects[0].EncryptedMetadata)
+			require.Equal(t, encryptedKey, result.Objects[0].EncryptedMetadataEncryptedKey)
+		}
+
+		requireStored(true)
+		requireReadable()
+
+		// the envelope isn't accounted as the metadata of the bucket.
+		tallies, err := db.CollectBucketTallies(ctx, metabase.CollectBucketTallies{
+			From: obj.Location().Bucket(),
+			To:   obj.Location().Bucket(),
+		})
+		require.NoError(t, err)
+		require.Len(t, tallies, 1)
+		require.EqualValues(t, len(metadata), tallies[0].MetadataSize)
+
+		rotate := func(expectedScanned, expectedRotated, expectedFailed int64) {
+			var progressCalls int
+			result, err := db.RotateMetadataEncryption(ctx, metabase.RotateMetadataEncryption{
+				BatchSize: 1,
+			}, func(metabase.RotateMetadataEncryptionProgress) error {
+				progressCalls++
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, expectedScanned, result.Scanned)
+			require.Equal(t, expectedRotated, result.Rotated)
+			require.Equal(t, expectedFailed, result.Failed)
+			require.Zero(t, result.Conflicts)
+			if expectedScanned > 0 {
+				require.Equal(t, committed.ObjectStream, result.Cursor)
+				require.Positive(t, progressCalls)
+			} else {
+				require.Zero(t, progressCalls)
+			}
+		}
+
+		// the metadata encrypted with the previous key stays readable.
+		require.NoError(t, db.TestingSetMetadataEncryption(metabase.MetadataEncryptionConfig{
+			Keys:        keys,
+			ActiveKeyID: 2,
+		}))
+		requireReadable()
+
+		rotate(1, 1, 0)
+		// the finished rotation isn't repeated until the active key changes.
+		rotate(0, 0, 0)
+
+		// the previous key isn't needed after the rotation.
+		require.NoError(t, db.TestingSetMetadataEncryption(metabase.MetadataEncryptionConfig{
+			Keys:        map[uint16]storj.Key{2: keys[2]},
+			ActiveKeyID: 2,
+		}))
+		requireStored(true)
+		requireReadable()
+
+		// without an active key the rotation removes the envelopes.
+		require.NoError(t, db.TestingSetMetadataEncryption(metabase.MetadataEncryptionConfig{
+			Keys: map[uint16]storj.Key{2: keys[2]},
+		}))
+		rotate(1, 1, 0)
+		requireStored(false)
+		requireReadable()
+
+		require.NoError(t, db.TestingSetMetadataEncryption(metabase.MetadataEncryptionConfig{
+			Keys:        map[uint16]storj.Key{2: keys[2]},
+			ActiveKeyID: 2,
+		}))
+		rotate(1, 1, 0)
+		requireStored(true)
+
+		// metadata, which can't be decrypted, is skipped.
+		require.NoError(t, db.TestingSetMetadataEncryption(metabase.MetadataEncryptionConfig{
+			Keys:        map[uint16]storj.Key{1: keys[1]},
+			ActiveKeyID: 1,
+		}))
+		rotate(1, 0, 1)
+		requireStored(true)
+	})
+}
+
+func TestMetadataEncryptionCopy(t *testing.T) {
+	metabasetest.RunWithConfig(t, metabase.Config{
+		ApplicationName: "metabase-tests",
+		MetadataEncryption: metabase.MetadataEncryptionConfig{
+			Keys:        map[uint16]storj.Key{1: testrand.Key()},
+			ActiveKeyID: 1,
+		},
+	}, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+		metadata := testrand.Bytes(64)
+		encryptedKey := testrand.Bytes(32)
+		nonce := testrand.Nonce()
+
+		obj := metabasetest.RandObjectStream()
+		metabasetest.CreatePendingObject(ctx, t, db, obj, 0)
+		committed, err := db.CommitObject(ctx, metabase.CommitObject{
+			ObjectStream:                  obj,
+			OverrideEncryptedMetadata:     true,
+			EncryptedMetadata:             metadata,
+			EncryptedMetadataNonce:        nonce[:],
+			EncryptedMetadataEncryptedKey: encryptedKey,
+		})
+		require.NoError(t, err)
+
+		newEncryptedKey := testrand.Bytes(32)
+		newNonce := testrand.Nonce()
+
+		copyObj := metabasetest.RandObjectStream()
+		copied, err := db.FinishCopyObject(ctx, metabase.FinishCopyObject{
+			ObjectStream:                 committed.ObjectStream,
+			NewProjectID:                 copyObj.ProjectID,
+			NewBucket:                    copyObj.BucketName,
+			NewEncryptedObjectKey:        copyObj.ObjectKey,
+			NewStreamID:                  copyObj.StreamID,
+			NewEncryptedMetadataKey:      newEncryptedKey,
+			NewEncryptedMetadataKeyNonce: newNonce,
+		})
+		require.NoError(t, err)
+		require.Equal(t, metadata, copied.EncryptedMetadata)
+		require.Equal(t, newEncryptedKey, copied.EncryptedMetadataEncryptedKey)
+
+		// the copy is encrypted with the key of the destination project.
+		object, err := db.GetObjectLastCommitted(ctx, metabase.GetObjectLastCommitted{
+			ObjectLocation: copied.Location(),
+		})
+		require.NoError(t, err)
+		require.Equal(t, metadata, object.EncryptedMetadata)
+		require.Equal(t, newEncryptedKey, object.EncryptedMetadataEncryptedKey)
+	})
+}
+
+func TestMetadataEncryptionClientEnvelopePrefix(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+		// client metadata, which starts like an envelope, is accounted in full.
+		metadata := append([]byte{0xff, 's', 'm', 'e', 't', 'a', 0xff, 1, 0, 1}, testrand.Bytes(64)...)
+		nonce := testrand.Nonce()
+
+		obj := metabasetest.RandObjectStream()
+		metabasetest.CreatePendingObject(ctx, t, db, obj, 0)
+
+		_, err := db.CommitObject(ctx, metabase.CommitObject{
+			ObjectStream:                  obj,
+			OverrideEncryptedMetadata:     true,
+			EncryptedMetadata:             metadata,
+			EncryptedMetadataNonce:        nonce[:],
+			EncryptedMetadataEncryptedKey: testrand.Bytes(32),
+		})
+		require.NoError(t, err)
+
+		tallies, err := db.CollectBucketTallies(ctx, metabase.CollectBucketTallies{
+			From: obj.Location().Bucket(),
+			To:   obj.Location().Bucket(),
+		})
+		require.NoError(t, err)
+		require.Len(t, tallies, 1)
+		require.EqualValues(t, len(metadata), tallies[0].MetadataSize)
+	})
+}
@@ -0,0 +1,440 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+
+	"storj.io/storj/shared/dbutil/spannerutil"
+	"storj.io/storj/shared/dbutil/txutil"
+	"storj.io/storj/shared/tagsql"
+)
+
+// StoredObjectMetadata is the metadata of an object, as it's stored in the database.
+type StoredObjectMetadata struct {
+	ObjectStream
+	EncryptedMetadata             []byte
+	EncryptedMetadataEncryptedKey []byte
+	// EncryptedMetadataKeyID is the key EncryptedMetadata is wrapped with, 0 when it isn't wrapped.
+	EncryptedMetadataKeyID uint16
+}
+
+// RotateMetadataEncryption contains arguments for re-encrypting the object metadata with the active key.
+type RotateMetadataEncryption struct {
+	BatchSize int
+}
+
+// Verify verifies rotate metadata encryption request fields.
+func (opts *RotateMetadataEncryption) Verify() error {
+	if opts.BatchSize < 0 {
+		return ErrInvalidRequest.New("BatchSize is negative")
+	}
+	return nil
+}
+
+// RotateMetadataEncryptionProgress is the progress of re-encrypting the object metadata.
+type RotateMetadataEncryptionProgress struct {
+	// Cursor is the last object of the processed range.
+	Cursor ObjectStream
+	// Scanned is the number of objects processed so far.
+	Scanned int64
+	// Rotated is the number of objects re-encrypted so far.
+	Rotated int64
+	// Conflicts is the number of objects, whose metadata was changed concurrently.
+	// Such objects already use the active key.
+	Conflicts int64
+	// Failed is the number of objects, whose metadata couldn't be decrypted,
+	// e.g. because their key isn't configured anymore. They are left unchanged.
+	Failed int64
+}
+
+// MetadataRotationCursor is the persisted progress of re-encrypting the object
+// metadata of a database with a key.
+type MetadataRotationCursor struct {
+	ActiveKeyID uint16
+	// StartAfter is the last processed object.
+	StartAfter ObjectStream
+	// Finished is set, when all objects were processed with the key.
+	Finished bool
+}
+
+// RotateMetadataEncryption re-encrypts the metadata of all objects, which isn't
+// encrypted with the active key. Objects are processed in ranges of opts.BatchSize
+// and progress is called after every range.
+//
+// The progress is saved in every database after every range, so an interrupted
+// rotation continues where it stopped. Once all objects of a database were
+// processed with the active key, the database isn't scanned again until the
+// active key changes. Objects, whose metadata can't be decrypted, are skipped
+// and counted as failed.
+//
+// When there's no active key, the envelopes are removed, which allows decommissioning
+// the metadata encryption.
+func (db *DB) RotateMetadataEncryption(ctx context.Context, opts RotateMetadataEncryption, progress func(RotateMetadataEncryptionProgress) error) (result RotateMetadataEncryptionProgress, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return result, err
+	}
+	enc := db.metadataEncryption
+	if enc == nil {
+		return result, ErrMetadataEncryption.New("metadata encryption is not configured")
+	}
+
+	loopIteratorBatchSizeLimit.Ensure(&opts.BatchSize)
+
+	for _, a := range db.adapters {
+		cursor, err := a.GetMetadataRotationCursor(ctx, enc.activeID)
+		if err != nil {
+			return result, err
+		}
+
+		for !cursor.Finished {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+
+			objects, err := a.ListStoredObjectMetadata(ctx, cursor.StartAfter, opts.BatchSize)
+			if err != nil {
+				return result, err
+			}
+
+			for _, object := range objects {
+				result.Scanned++
+				if !enc.needsRotation(object) {
+					continue
+				}
+
+				metadata, encryptedKey := object.EncryptedMetadata, object.EncryptedMetadataEncryptedKey
+				if err := enc.decryptMetadata(object.ProjectID, &metadata, &encryptedKey); err != nil {
+					result.Failed++
+					db.log.Warn("unable to decrypt object metadata for rotation",
+						zap.Stringer("project_id", object.ProjectID),
+						zap.Stringer("bucket_name", object.BucketName),
+						zap.Stringer("stream_id", object.StreamID),
+						zap.Error(err))
+					continue
+				}
+				keyID, err := enc.encryptMetadata(object.ProjectID, &metadata, &encryptedKey)
+				if err != nil {
+					return result, err
+				}
+
+				updated, err := a.UpdateStoredObjectMetadata(ctx, object, metadata, encryptedKey, keyID)
+				if err != nil {
+					return result, err
+				}
+				if updated {
+					result.Rotated++
+				} else {
+					result.Conflicts++
+				}
+			}
+
+			if len(objects) > 0 {
+				cursor.StartAfter = objects[len(objects)-1].ObjectStream
+				result.Cursor = cursor.StartAfter
+			}
+			cursor.Finished = len(objects) < opts.BatchSize
+
+			if err := a.SaveMetadataRotationCursor(ctx, cursor); err != nil {
+				return result, err
+			}
+
+			mon.Meter("metadata_rotation_objects").Mark(len(objects))
+
+			if progress != nil {
+				if err := progress(result); err != nil {
+					return result, err
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// needsRotation returns whether the metadata of the object has to be re-encrypted
+// with the active key. The metadata is also rewritten when its stored key ID
+// doesn't match, e.g. for envelopes written before the key ID was stored.
+func (enc *metadataEncryption) needsRotation(object StoredObjectMetadata) bool {
+	return enc.needsValueRotation(object.EncryptedMetadata) ||
+		enc.needsValueRotation(object.EncryptedMetadataEncryptedKey) ||
+		object.EncryptedMetadataKeyID != enc.metadataKeyID(object.EncryptedMetadata)
+}
+
+// needsValueRotation returns whether the value has to be re-encrypted with the active key.
+func (enc *metadataEncryption) needsValueRotation(value []byte) bool {
+	return len(value) > 0 && envelopeKeyID(value) != enc.activeID
+}
+
+// ListStoredObjectMetadata lists the stored metadata of up to batchSize objects after startAfter.
+func (p *PostgresAdapter) ListStoredObjectMetadata(ctx context.Context, startAfter ObjectStream, batchSize int) (objects []StoredObjectMetadata, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	objects = make([]StoredObjectMetadata, 0, batchSize)
+	err = withRows(p.db.QueryContext(ctx, `
+		SELECT
+			project_id, bucket_name, object_key, version, stream_id,
+			encrypted_metadata, encrypted_metadata_encrypted_key, encrypted_metadata_key_id
+		FROM objects
+		WHERE
+			(project_id, bucket_name, object_key, version) > ($1, $2, $3, $4)
+		ORDER BY project_id, bucket_name, object_key, version
+		LIMIT $5
+	`, startAfter.ProjectID, startAfter.BucketName, []byte(startAfter.ObjectKey), startAfter.Version,
+		batchSize),
+	)(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var object StoredObjectMetadata
+			err := rows.Scan(
+				&object.ProjectID, &object.BucketName, &object.ObjectKey, &object.Version, &object.StreamID,
+				&object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey, &object.EncryptedMetadataKeyID,
+			)
+			if err != nil {
+				return err
+			}
+			objects = append(objects, object)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return objects, nil
+}
+
+// ListStoredObjectMetadata lists the stored metadata of up to batchSize objects after startAfter.
+func (s *SpannerAdapter) ListStoredObjectMetadata(ctx context.Context, startAfter ObjectStream, batchSize int) (objects []StoredObjectMetadata, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	objects, err = spannerutil.CollectRows(s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT
+				project_id, bucket_name, object_key, version, stream_id,
+				encrypted_metadata, encrypted_metadata_encrypted_key, encrypted_metadata_key_id
+			FROM objects
+			WHERE
+				project_id > @project_id
+				OR (project_id = @project_id AND bucket_name > @bucket_name)
+				OR (project_id = @project_id AND bucket_name = @bucket_name AND object_key > @object_key)
+				OR (project_id = @project_id AND bucket_name = @bucket_name AND object_key = @object_key AND version > @version)
+			ORDER BY project_id, bucket_name, object_key, version
+			LIMIT @batch_size
+		`, Params: map[string]interface{}{
+			"project_id":  startAfter.ProjectID,
+			"bucket_name": startAfter.BucketName,
+			"object_key":  startAfter.ObjectKey,
+			"version":     startAfter.Version,
+			"batch_size":  batchSize,
+		},
+	}), func(row *spanner.Row, object *StoredObjectMetadata) error {
+		var keyID int64
+		err := row.Columns(
+			&object.ProjectID, &object.BucketName, &object.ObjectKey, &object.Version, &object.StreamID,
+			&object.EncryptedMetadata, &object.EncryptedMetadataEncryptedKey, &keyID,
+		)
+		object.EncryptedMetadataKeyID = uint16(keyID)
+		return err
+	})
+	return objects, Error.Wrap(err)
+}
+
+// UpdateStoredObjectMetadata replaces the stored metadata of the object, when it wasn't changed since it was listed.
+func (p *PostgresAdapter) UpdateStoredObjectMetadata(ctx context.Context, object StoredObjectMetadata, encryptedMetadata, encryptedMetadataEncryptedKey []byte, encryptedMetadataKeyID uint16) (updated bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE objects SET
+			encrypted_metadata               = $6,
+			encrypted_metadata_encrypted_key = $7,
+			encrypted_metadata_key_id        = $10
+		WHERE
+			(project_id, bucket_name, object_key, version) = ($1, $2, $3, $4) AND
+			stream_id = $5 AND
+			encrypted_metadata IS NOT DISTINCT FROM $8::BYTEA AND
+			encrypted_metadata_encrypted_key IS NOT DISTINCT FROM $9::BYTEA
+	`, object.ProjectID, object.BucketName, object.ObjectKey, object.Version, object.StreamID,
+		encryptedMetadata, encryptedMetadataEncryptedKey,
+		object.EncryptedMetadata, object.EncryptedMetadataEncryptedKey,
+		encryptedMetadataKeyID)
+	if err != nil {
+		return false, Error.New("unable to update object metadata: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, Error.New("unable to update object metadata: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// UpdateStoredObjectMetadata replaces the stored metadata of the object, when it wasn't changed since it was listed.
+func (s *SpannerAdapter) UpdateStoredObjectMetadata(ctx context.Context, object StoredObjectMetadata, encryptedMetadata, encryptedMetadataEncryptedKey []byte, encryptedMetadataKeyID uint16) (updated bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var affected int64
+	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		affected, err = tx.Update(ctx, spanner.Statement{
+			SQL: `
+				UPDATE objects SET
+					encrypted_metadata               = @new_encrypted_metadata,
+					encrypted_metadata_encrypted_key = @new_encrypted_metadata_encrypted_key,
+					encrypted_metadata_key_id        = @new_encrypted_metadata_key_id
+				WHERE
+					project_id = @project_id AND
+					bucket_name = @bucket_name AND
+					object_key = @object_key AND
+					version = @version AND
+					stream_id = @stream_id AND
+					COALESCE(encrypted_metadata, b'') = COALESCE(@encrypted_metadata, b'') AND
+					COALESCE(encrypted_metadata_encrypted_key, b'') = COALESCE(@encrypted_metadata_encrypted_key, b'')
+			`,
+			Params: map[string]interface{}{
+				"project_id":                           object.ProjectID,
+				"bucket_name":                          object.BucketName,
+				"object_key":                           object.ObjectKey,
+				"version":                              object.Version,
+				"stream_id":                            object.StreamID,
+				"encrypted_metadata":                   object.EncryptedMetadata,
+				"encrypted_metadata_encrypted_key":     object.EncryptedMetadataEncryptedKey,
+				"new_encrypted_metadata":               encryptedMetadata,
+				"new_encrypted_metadata_encrypted_key": encryptedMetadataEncryptedKey,
+				"new_encrypted_metadata_key_id":        int64(encryptedMetadataKeyID),
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return false, Error.New("unable to update object metadata: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// GetMetadataRotationCursor returns the saved progress of rotating the metadata to the key.
+func (p *PostgresAdapter) GetMetadataRotationCursor(ctx context.Context, activeKeyID uint16) (cursor MetadataRotationCursor, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	cursor.ActiveKeyID = activeKeyID
+	err = p.db.QueryRowContext(ctx, `
+		SELECT project_id, bucket_name, object_key, version, finished
+		FROM metadata_rotation_cursors
+		WHERE active_key_id = $1
+	`, int(activeKeyID)).Scan(
+		&cursor.StartAfter.ProjectID, &cursor.StartAfter.BucketName, &cursor.StartAfter.ObjectKey, &cursor.StartAfter.Version,
+		&cursor.Finished,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return cursor, nil
+	}
+	if err != nil {
+		return MetadataRotationCursor{}, Error.New("unable to get metadata rotation cursor: %w", err)
+	}
+	return cursor, nil
+}
+
+// GetMetadataRotationCursor returns the saved progress of rotating the metadata to the key.
+func (s *SpannerAdapter) GetMetadataRotationCursor(ctx context.Context, activeKeyID uint16) (cursor MetadataRotationCursor, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	cursor.ActiveKeyID = activeKeyID
+	row, err := s.client.Single().ReadRow(ctx, "metadata_rotation_cursors",
+		spanner.Key{int64(activeKeyID)},
+		[]string{"project_id", "bucket_name", "object_key", "version", "finished"})
+	if spanner.ErrCode(err) == codes.NotFound {
+		return cursor, nil
+	}
+	if err != nil {
+		return MetadataRotationCursor{}, Error.New("unable to get metadata rotation cursor: %w", err)
+	}
+
+	err = row.Columns(
+		&cursor.StartAfter.ProjectID, &cursor.StartAfter.BucketName, &cursor.StartAfter.ObjectKey, &cursor.StartAfter.Version,
+		&cursor.Finished,
+	)
+	if err != nil {
+		return MetadataRotationCursor{}, Error.New("unable to get metadata rotation cursor: %w", err)
+	}
+	return cursor, nil
+}
+
+// SaveMetadataRotationCursor saves the progress of rotating the metadata and
+// removes the progress saved for other keys.
+func (p *PostgresAdapter) SaveMetadataRotationCursor(ctx context.Context, cursor MetadataRotationCursor) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = txutil.WithTx(ctx, p.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			DELETE FROM metadata_rotation_cursors
+			WHERE active_key_id <> $1
+		`, int(cursor.ActiveKeyID))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO metadata_rotation_cursors (
+				active_key_id, project_id, bucket_name, object_key, version, finished, updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, now())
+			ON CONFLICT (active_key_id) DO UPDATE SET
+				project_id  = EXCLUDED.project_id,
+				bucket_name = EXCLUDED.bucket_name,
+				object_key  = EXCLUDED.object_key,
+				version     = EXCLUDED.version,
+				finished    = EXCLUDED.finished,
+				updated_at  = EXCLUDED.updated_at
+		`, int(cursor.ActiveKeyID),
+			cursor.StartAfter.ProjectID, cursor.StartAfter.BucketName, []byte(cursor.StartAfter.ObjectKey), cursor.StartAfter.Version,
+			cursor.Finished)
+		return err
+	})
+	if err != nil {
+		return Error.New("unable to save metadata rotation cursor: %w", err)
+	}
+	return nil
+}
+
+// SaveMetadataRotationCursor saves the progress of rotating the metadata and
+// removes the progress saved for other keys.
+func (s *SpannerAdapter) SaveMetadataRotationCursor(ctx context.Context, cursor MetadataRotationCursor) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		_, err := tx.Update(ctx, spanner.Statement{
+			SQL: `
+				DELETE FROM metadata_rotation_cursors
+				WHERE active_key_id != @active_key_id
+			`,
+			Params: map[string]any{
+				"active_key_id": int64(cursor.ActiveKeyID),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		return tx.BufferWrite([]*spanner.Mutation{
+			spanner.InsertOrUpdateMap("metadata_rotation_cursors", map[string]any{
+				"active_key_id": int64(cursor.ActiveKeyID),
+				"project_id":    cursor.StartAfter.ProjectID,
+				"bucket_name":   cursor.StartAfter.BucketName,
+				"object_key":    cursor.StartAfter.ObjectKey,
+				"version":       cursor.StartAfter.Version,
+				"finished":      cursor.Finished,
+				"updated_at":    time.Now(),
+			}),
+		})
+	})
+	if err != nil {
+		return Error.New("unable to save metadata rotation cursor: %w", err)
+	}
+	return nil
+}
@@ -389,5 +389,272 @@ func TestUpdateObjectLastCommittedMetadata(t *testing.T) {
 				},
 			}.Check(ctx, t, db)
 		})
+
+		t.Run("compare and swap", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			object := metabasetest.CreateObject(ctx, t, db, obj, 0)
+
+			firstNonce := testrand.Nonce()
+			secondNonce := testrand.Nonce()
+			encryptedMetadata := testrand.Bytes(1024)
+			encryptedMetadataKey := testrand.Bytes(265)
+
+			metabasetest.UpdateObjectLastCommittedMetadata{
+				Opts: metabase.UpdateObjectLastCommittedMetadata{
+					ObjectLocation:                 object.Location(),
+					StreamID:                       object.StreamID,
+					ExpectedEncryptedMetadataNonce: firstNonce[:],
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "ExpectedEncryptedMetadataNonce is set but IfMetadataUnchanged is not",
+			}.Check(ctx, t, db)
+
+			metabasetest.UpdateObjectLastCommittedMetadata{
+				Opts: metabase.UpdateObjectLastCommittedMetadata{
+					ObjectLocation:                 object.Location(),
+					StreamID:                       object.StreamID,
+					EncryptedMetadata:              encryptedMetadata,
+					EncryptedMetadataNonce:         firstNonce[:],
+					EncryptedMetadataEncryptedKey:  encryptedMetadataKey,
+					IfMetadataUnchanged:            true,
+					ExpectedEncryptedMetadataNonce: firstNonce[:],
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "EncryptedMetadataNonce must differ from ExpectedEncryptedMetadataNonce",
+			}.Check(ctx, t, db)
+
+			// the object doesn't have metadata yet
+			metabasetest.UpdateObjectLastCommittedMetadata{
+				Opts: metabase.UpdateObjectLastCommittedMetadata{
+					ObjectLocation:                object.Location(),
+					StreamID:                      object.StreamID,
+					EncryptedMetadata:             encryptedMetadata,
+					EncryptedMetadataNonce:        firstNonce[:],
+					EncryptedMetadataEncryptedKey: encryptedMetadataKey,
+					IfMetadataUnchanged:           true,
+				},
+			}.Check(ctx, t, db)
+
+			// the metadata was changed since it was read
+			metabasetest.UpdateObjectLastCommittedMetadata{
+				Opts: metabase.UpdateObjectLastCommittedMetadata{
+					ObjectLocation:                object.Location(),
+					StreamID:                      object.StreamID,
+					EncryptedMetadata:             testrand.Bytes(1024),
+					EncryptedMetadataNonce:        secondNonce[:],
+					EncryptedMetadataEncryptedKey: testrand.Bytes(265),
+					IfMetadataUnchanged:           true,
+				},
+				ErrClass: &metabase.ErrConflict,
+				ErrText:  "object or its metadata was modified concurrently",
+			}.Check(ctx, t, db)
+
+			// the object was replaced since it was read
+			metabasetest.UpdateObjectLastCommittedMetadata{
+				Opts: metabase.UpdateObjectLastCommittedMetadata{
+					ObjectLocation:                 object.Location(),
+					StreamID:                       testrand.UUID(),
+					EncryptedMetadata:              testrand.Bytes(1024),
+					EncryptedMetadataNonce:         secondNonce[:],
+					EncryptedMetadataEncryptedKey:  testrand.Bytes(265),
+					IfMetadataUnchanged:            true,
+					ExpectedEncryptedMetadataNonce: firstNonce[:],
+				},
+				ErrClass: &metabase.ErrConflict,
+				ErrText:  "object or its metadata was modified concurrently",
+			}.Check(ctx, t, db)
+
+			// the object doesn't exist
+			missing := metabasetest.RandObjectStream()
+			metabasetest.UpdateObjectLastCommittedMetadata{
+				Opts: metabase.UpdateObjectLastCommittedMetadata{
+					ObjectLocation:      missing.Location(),
+					StreamID:            missing.StreamID,
+					IfMetadataUnchanged: true,
+				},
+				ErrClass: &metabase.ErrObjectNotFound,
+				ErrText:  "object with specified version and committed status is missing",
+			}.Check(ctx, t, db)
+
+			metabasetest.UpdateObjectLastCommittedMetadata{
+				Opts: metabase.UpdateObjectLastCommittedMetadata{
+					ObjectLocation:                 object.Location(),
+					StreamID:                       object.StreamID,
+					EncryptedMetadata:              encryptedMetadata,
+					EncryptedMetadataNonce:         secondNonce[:],
+					EncryptedMetadataEncryptedKey:  encryptedMetadataKey,
+					IfMetadataUnchanged:            true,
+					ExpectedEncryptedMetadataNonce: firstNonce[:],
+				},
+			}.Check(ctx, t, db)
+
+			object.EncryptedMetadata = encryptedMetadata
+			object.EncryptedMetadataNonce = secondNonce[:]
+			object.EncryptedMetadataEncryptedKey = encryptedMetadataKey
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(object),
+				},
+			}.Check(ctx, t, db)
+		})
+	})
+}
+
+func TestUpdateObjectExactVersionMetadata(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+		for _, test := range metabasetest.InvalidObjectStreams(obj) {
+			test := test
+			t.Run(test.Name, func(t *testing.T) {
+				defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+				metabasetest.UpdateObjectExactVersionMetadata{
+					Opts: metabase.UpdateObjectExactVersionMetadata{
+						ObjectStream:         test.ObjectStream,
+						SetEncryptedMetadata: true,
+					},
+					ErrClass: test.ErrClass,
+					ErrText:  test.ErrText,
+				}.Check(ctx, t, db)
+				metabasetest.Verify{}.Check(ctx, t, db)
+			})
+		}
+
+		t.Run("nothing to update", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.UpdateObjectExactVersionMetadata{
+				Opts: metabase.UpdateObjectExactVersionMetadata{
+					ObjectStream: obj,
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "nothing to update",
+			}.Check(ctx, t, db)
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("key without nonce", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.UpdateObjectExactVersionMetadata{
+				Opts: metabase.UpdateObjectExactVersionMetadata{
+					ObjectStream:                  obj,
+					SetEncryptedMetadataKey:       true,
+					EncryptedMetadataEncryptedKey: testrand.Bytes(32),
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  "EncryptedMetadataNonce and EncryptedMetadataEncryptedKey must be set together",
+			}.Check(ctx, t, db)
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("object missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.UpdateObjectExactVersionMetadata{
+				Opts: metabase.UpdateObjectExactVersionMetadata{
+					ObjectStream:         obj,
+					SetEncryptedMetadata: true,
+					EncryptedMetadata:    testrand.Bytes(32),
+				},
+				ErrClass: &metabase.ErrObjectNotFound,
+				ErrText:  "object with specified version and committed status is missing",
+			}.Check(ctx, t, db)
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("update only user metadata", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object, _ := metabasetest.CreateTestObject{
+				CommitObject: &metabase.CommitObject{
+					ObjectStream:                  obj,
+					OverrideEncryptedMetadata:     true,
+					EncryptedMetadata:             testrand.Bytes(64),
+					EncryptedMetadataNonce:        testrand.Nonce().Bytes(),
+					EncryptedMetadataEncryptedKey: testrand.Bytes(32),
+				},
+			}.Run(ctx, t, db, obj, 0)
+
+			encryptedMetadata := testrand.Bytes(1024)
+			metabasetest.UpdateObjectExactVersionMetadata{
+				Opts: metabase.UpdateObjectExactVersionMetadata{
+					ObjectStream:         object.ObjectStream,
+					SetEncryptedMetadata: true,
+					EncryptedMetadata:    encryptedMetadata,
+				},
+			}.Check(ctx, t, db)
+
+			object.EncryptedMetadata = encryptedMetadata
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(object),
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("update only metadata key", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object, _ := metabasetest.CreateTestObject{
+				CommitObject: &metabase.CommitObject{
+					ObjectStream:                  obj,
+					OverrideEncryptedMetadata:     true,
+					EncryptedMetadata:             testrand.Bytes(64),
+					EncryptedMetadataNonce:        testrand.Nonce().Bytes(),
+					EncryptedMetadataEncryptedKey: testrand.Bytes(32),
+				},
+			}.Run(ctx, t, db, obj, 0)
+
+			encryptedMetadataNonce := testrand.Nonce()
+			encryptedMetadataKey := testrand.Bytes(32)
+			metabasetest.UpdateObjectExactVersionMetadata{
+				Opts: metabase.UpdateObjectExactVersionMetadata{
+					ObjectStream:                  object.ObjectStream,
+					SetEncryptedMetadataKey:       true,
+					EncryptedMetadataNonce:        encryptedMetadataNonce[:],
+					EncryptedMetadataEncryptedKey: encryptedMetadataKey,
+				},
+			}.Check(ctx, t, db)
+
+			object.EncryptedMetadataNonce = encryptedMetadataNonce[:]
+			object.EncryptedMetadataEncryptedKey = encryptedMetadataKey
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(object),
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("object replaced", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			object := metabasetest.CreateObject(ctx, t, db, obj, 0)
+
+			obj2 := obj
+			obj2.Version++
+			obj2.StreamID = testrand.UUID()
+			object2 := metabasetest.CreateObject(ctx, t, db, obj2, 0)
+
+			metabasetest.UpdateObjectExactVersionMetadata{
+				Opts: metabase.UpdateObjectExactVersionMetadata{
+					ObjectStream:         object.ObjectStream,
+					SetEncryptedMetadata: true,
+					EncryptedMetadata:    testrand.Bytes(32),
+				},
+				ErrClass: &metabase.ErrConflict,
+				ErrText:  "object was modified concurrently",
+			}.Check(ctx, t, db)
+
+			metabasetest.Verify{
+				Objects: []metabase.RawObject{
+					metabase.RawObject(object2),
+				},
+			}.Check(ctx, t, db)
+		})
 	})
 }
@@ -0,0 +1,136 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metadatarotation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+	"storj.io/storj/satellite/metabase"
+)
+
+var (
+	// Error defines the metadatarotation chore errors class.
+	Error = errs.Class("metadata rotation chore")
+	mon   = monkit.Package()
+)
+
+// Config contains configurable values for the metadata key rotation.
+type Config struct {
+	Enabled   bool          `help:"set if re-encrypting the object metadata with the active metadata encryption key is enabled or not" default:"false"`
+	Interval  time.Duration `help:"the time between each pass over the objects" releaseDefault:"24h" devDefault:"1m"`
+	BatchSize int           `help:"how many objects to process in a single range" default:"1000"`
+}
+
+// Progress is the progress of the current or the last pass over the objects.
+type Progress struct {
+	metabase.RotateMetadataEncryptionProgress
+
+	Running  bool
+	Started  time.Time
+	Finished time.Time
+	Error    error
+}
+
+// Chore implements the metadata key rotation chore.
+//
+// architecture: Chore
+type Chore struct {
+	log      *zap.Logger
+	config   Config
+	metabase *metabase.DB
+
+	mu       sync.Mutex
+	progress Progress
+
+	Loop *sync2.Cycle
+}
+
+// NewChore creates a new instance of the metadatarotation chore.
+func NewChore(log *zap.Logger, config Config, metabase *metabase.DB) *Chore {
+	return &Chore{
+		log:      log,
+		config:   config,
+		metabase: metabase,
+
+		Loop: sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run starts the metadatarotation loop service.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !chore.config.Enabled {
+		return nil
+	}
+
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := chore.RunOnce(ctx); err != nil {
+			chore.log.Error("failed to rotate metadata encryption", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// Close stops the metadatarotation chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
+
+// Progress returns the progress of the current or the last pass over the objects.
+func (chore *Chore) Progress() Progress {
+	chore.mu.Lock()
+	defer chore.mu.Unlock()
+	return chore.progress
+}
+
+// RunOnce re-encrypts the metadata of all objects, which isn't encrypted with the active key.
+// It continues from the progress saved by the previous passes.
+func (chore *Chore) RunOnce(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	chore.mu.Lock()
+	chore.progress = Progress{Running: true, Started: time.Now()}
+	chore.mu.Unlock()
+
+	result, err := chore.metabase.RotateMetadataEncryption(ctx, metabase.RotateMetadataEncryption{
+		BatchSize: chore.config.BatchSize,
+	}, func(progress metabase.RotateMetadataEncryptionProgress) error {
+		chore.mu.Lock()
+		chore.progress.RotateMetadataEncryptionProgress = progress
+		chore.mu.Unlock()
+		return nil
+	})
+
+	chore.mu.Lock()
+	chore.progress.RotateMetadataEncryptionProgress = result
+	chore.progress.Running = false
+	chore.progress.Finished = time.Now()
+	chore.progress.Error = err
+	chore.mu.Unlock()
+
+	mon.IntVal("metadata_rotation_scanned").Observe(result.Scanned)
+	mon.IntVal("metadata_rotation_rotated").Observe(result.Rotated)
+	mon.IntVal("metadata_rotation_conflicts").Observe(result.Conflicts)
+	mon.IntVal("metadata_rotation_failed").Observe(result.Failed)
+
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	chore.log.Info("metadata encryption rotated",
+		zap.Int64("scanned", result.Scanned),
+		zap.Int64("rotated", result.Rotated),
+		zap.Int64("conflicts", result.Conflicts),
+		zap.Int64("failed", result.Failed))
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metadatarotation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metadatarotation"
+	"storj.io/uplink"
+)
+
+func TestChore(t *testing.T) {
+	keys := map[uint16]storj.Key{
+		1: testrand.Key(),
+		2: testrand.Key(),
+	}
+
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Metainfo.MetadataEncryption.Keys = keys
+				config.Metainfo.MetadataEncryption.ActiveKey = 1
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+
+		project, err := planet.Uplinks[0].OpenProject(ctx, sat)
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		_, err = project.CreateBucket(ctx, "testbucket")
+		require.NoError(t, err)
+
+		custom := uplink.CustomMetadata{"color": "blue"}
+		for _, key := range []string{"a", "b", "c"} {
+			upload, err := project.UploadObject(ctx, "testbucket", key, nil)
+			require.NoError(t, err)
+			_, err = upload.Write(testrand.Bytes(100))
+			require.NoError(t, err)
+			require.NoError(t, upload.SetCustomMetadata(ctx, custom))
+			require.NoError(t, upload.Commit())
+		}
+
+		// switch to the new key, the existing metadata is encrypted with the previous one.
+		require.NoError(t, sat.Metabase.DB.TestingSetMetadataEncryption(metabase.MetadataEncryptionConfig{
+			Keys:        keys,
+			ActiveKeyID: 2,
+		}))
+
+		chore := metadatarotation.NewChore(zaptest.NewLogger(t), metadatarotation.Config{
+			BatchSize: 2,
+		}, sat.Metabase.DB)
+
+		require.NoError(t, chore.RunOnce(ctx))
+
+		progress := chore.Progress()
+		require.False(t, progress.Running)
+		require.NoError(t, progress.Error)
+		require.EqualValues(t, 3, progress.Scanned)
+		require.EqualValues(t, 3, progress.Rotated)
+		require.False(t, progress.Finished.Before(progress.Started))
+
+		// the previous key can be removed after the rotation.
+		require.NoError(t, sat.Metabase.DB.TestingSetMetadataEncryption(metabase.MetadataEncryptionConfig{
+			Keys:        map[uint16]storj.Key{2: keys[2]},
+			ActiveKeyID: 2,
+		}))
+
+		for _, key := range []string{"a", "b", "c"} {
+			object, err := project.StatObject(ctx, "testbucket", key)
+			require.NoError(t, err)
+			require.Equal(t, custom, object.Custom)
+		}
+
+		// the objects aren't scanned again until the active key changes.
+		require.NoError(t, chore.RunOnce(ctx))
+		require.Zero(t, chore.Progress().Scanned)
+		require.Zero(t, chore.Progress().Rotated)
+	})
+}
@@ -0,0 +1,12 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+/*
+Package metadatarotation contains the functions needed to run the metadata key rotation chore.
+
+The satellite encrypts the object metadata at rest with a key derived from a master key
+and the project ID. After the active master key is changed, the metadatarotation chore
+re-encrypts the metadata of the existing objects with the active key in ranges, so the
+old master keys can be removed from the configuration eventually.
+*/
+package metadatarotation
@@ -217,6 +217,11 @@ func (db *DB) FinishMoveObject(ctx context.Context, opts FinishMoveObject) (err
 		return err
 	}
 
+	opts.NewEncryptedMetadataKey, err = db.metadataEncryption.encrypt(opts.ProjectID, opts.NewEncryptedMetadataKey)
+	if err != nil {
+		return err
+	}
+
 	var precommit PrecommitConstraintResult
 	err = db.ChooseAdapter(opts.ProjectID).WithTx(ctx, func(ctx context.Context, adapter TransactionAdapter) error {
 		precommit, err = db.PrecommitConstraint(ctx, PrecommitConstraint{
@@ -343,6 +348,7 @@ func (stx *spannerTransactionAdapter) objectMove(ctx context.Context, opts Finis
 		encryptedMetadataNonce        []byte
 		encryptedMetadata             []byte
 		encryptedMetadataEncryptedKey []byte
+		encryptedMetadataKeyID        int64
 		totalPlainSize                int64
 		totalEncryptedSize            int64
 		fixedSegmentSize              int64
@@ -358,6 +364,7 @@ func (stx *spannerTransactionAdapter) objectMove(ctx context.Context, opts Finis
 			THEN RETURN
 				stream_id, created_at, expires_at, status, segment_count,
 				encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
+				encrypted_metadata_key_id,
 				total_plain_size, total_encrypted_size, fixed_segment_size,
 				encryption,
 				zombie_deletion_deadline
@@ -373,6 +380,7 @@ func (stx *spannerTransactionAdapter) objectMove(ctx context.Context, opts Finis
 		err := row.Columns(
 			&streamID, &createdAt, &expiresAt, &oldStatus, &segmentCount,
 			&encryptedMetadataNonce, &encryptedMetadata, &encryptedMetadataEncryptedKey,
+			&encryptedMetadataKeyID,
 			&totalPlainSize, &totalEncryptedSize, &fixedSegmentSize,
 			encryptionParameters{&encryption},
 			&zombieDeletionDeadline,
@@ -402,6 +410,7 @@ func (stx *spannerTransactionAdapter) objectMove(ctx context.Context, opts Finis
 			    project_id, bucket_name, object_key, version,
 				stream_id, created_at, expires_at, status, segment_count,
 			    encrypted_metadata_nonce, encrypted_metadata, encrypted_metadata_encrypted_key,
+				encrypted_metadata_key_id,
 				total_plain_size, total_encrypted_size, fixed_segment_size,
 				encryption,
 				zombie_deletion_deadline
@@ -409,6 +418,7 @@ func (stx *spannerTransactionAdapter) objectMove(ctx context.Context, opts Finis
 			    @project_id, @bucket_name, @object_key, @version,
 				@stream_id, @created_at, @expires_at, @status, @segment_count,
 			    @encrypted_metadata_nonce, @encrypted_metadata, @encrypted_metadata_encrypted_key,
+				@encrypted_metadata_key_id,
 				@total_plain_size, @total_encrypted_size, @fixed_segment_size,
 				@encryption,
 				@zombie_deletion_deadline
@@ -427,6 +437,7 @@ func (stx *spannerTransactionAdapter) objectMove(ctx context.Context, opts Finis
 			"encrypted_metadata_nonce":         encryptedMetadataNonce,
 			"encrypted_metadata":               encryptedMetadata,
 			"encrypted_metadata_encrypted_key": encryptedMetadataEncryptedKey,
+			"encrypted_metadata_key_id":        encryptedMetadataKeyID,
 			"total_plain_size":                 totalPlainSize,
 			"total_encrypted_size":             totalEncryptedSize,
 			"fixed_segment_size":               fixedSegmentSize,
@@ -0,0 +1,132 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+
+	"cloud.google.com/go/spanner"
+)
+
+// RecalculateObjectTotalSizes contains arguments for recalculating the total sizes of an object.
+type RecalculateObjectTotalSizes struct {
+	ObjectStream
+}
+
+// Verify verifies recalculate object total sizes request fields.
+func (opts *RecalculateObjectTotalSizes) Verify() error {
+	return opts.ObjectStream.Verify()
+}
+
+// RecalculateObjectTotalSizes sets the total encrypted and plain size of a committed object
+// to the sum of the sizes of its segments.
+//
+// The sizes are only updated when the segment count of the object matches the number of
+// its segments, because a missing or superfluous segment isn't something which can be
+// fixed by updating the object. ErrFailedPrecondition is returned in that case, or when
+// the committed object doesn't exist.
+func (db *DB) RecalculateObjectTotalSizes(ctx context.Context, opts RecalculateObjectTotalSizes) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	updated, err := db.ChooseAdapter(opts.ProjectID).RecalculateObjectTotalSizes(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return ErrFailedPrecondition.New("committed object with matching segment count not found")
+	}
+	return nil
+}
+
+// RecalculateObjectTotalSizes sets the total sizes of a committed object to the sum of the sizes of its segments.
+func (p *PostgresAdapter) RecalculateObjectTotalSizes(ctx context.Context, opts RecalculateObjectTotalSizes) (updated int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE objects SET
+			total_encrypted_size = s.encrypted_size,
+			total_plain_size     = s.plain_size
+		FROM (
+			SELECT
+				COUNT(*)                         AS segment_count,
+				COALESCE(SUM(encrypted_size), 0) AS encrypted_size,
+				COALESCE(SUM(plain_size), 0)     AS plain_size
+			FROM segments
+			WHERE stream_id = $5
+		) AS s
+		WHERE
+			(objects.project_id, objects.bucket_name, objects.object_key, objects.version) = ($1, $2, $3, $4)
+			AND objects.stream_id = $5
+			AND objects.status IN `+statusesCommitted+`
+			AND objects.segment_count = s.segment_count
+	`, opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.Version, opts.StreamID)
+	if err != nil {
+		return 0, Error.New("unable to recalculate object sizes: %w", err)
+	}
+
+	updated, err = result.RowsAffected()
+	if err != nil {
+		return 0, Error.New("unable to recalculate object sizes: %w", err)
+	}
+	return updated, nil
+}
+
+// RecalculateObjectTotalSizes sets the total sizes of a committed object to the sum of the sizes of its segments.
+func (s *SpannerAdapter) RecalculateObjectTotalSizes(ctx context.Context, opts RecalculateObjectTotalSizes) (updated int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		var segmentCount, encryptedSize, plainSize int64
+		err := tx.Query(ctx, spanner.Statement{
+			SQL: `
+				SELECT
+					COUNT(*),
+					COALESCE(SUM(encrypted_size), 0),
+					COALESCE(SUM(plain_size), 0)
+				FROM segments
+				WHERE stream_id = @stream_id
+			`,
+			Params: map[string]interface{}{
+				"stream_id": opts.StreamID,
+			},
+		}).Do(func(row *spanner.Row) error {
+			return row.Columns(&segmentCount, &encryptedSize, &plainSize)
+		})
+		if err != nil {
+			return err
+		}
+
+		updated, err = tx.Update(ctx, spanner.Statement{
+			SQL: `
+				UPDATE objects SET
+					total_encrypted_size = @encrypted_size,
+					total_plain_size     = @plain_size
+				WHERE
+					(project_id, bucket_name, object_key, version) = (@project_id, @bucket_name, @object_key, @version)
+					AND stream_id = @stream_id
+					AND status IN ` + statusesCommitted + `
+					AND segment_count = @segment_count
+			`,
+			Params: map[string]interface{}{
+				"project_id":     opts.ProjectID,
+				"bucket_name":    opts.BucketName,
+				"object_key":     opts.ObjectKey,
+				"version":        opts.Version,
+				"stream_id":      opts.StreamID,
+				"segment_count":  segmentCount,
+				"encrypted_size": encryptedSize,
+				"plain_size":     plainSize,
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return 0, Error.New("unable to recalculate object sizes: %w", err)
+	}
+	return updated, nil
+}
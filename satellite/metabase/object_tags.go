@@ -0,0 +1,533 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"unicode/utf8"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+
+	"storj.io/storj/shared/dbutil/spannerutil"
+)
+
+const (
+	// MaxObjectTags is the maximum number of tags of an object.
+	MaxObjectTags = 10
+	// MaxObjectTagKeyLength is the maximum length of an object tag key.
+	MaxObjectTagKeyLength = 128
+	// MaxObjectTagValueLength is the maximum length of an object tag value.
+	MaxObjectTagValueLength = 256
+
+	// tagFilterScanLimit is the number of entries an iterator based listing
+	// with a tag filter scans, before returning a partial page.
+	tagFilterScanLimit = 10000
+)
+
+// ObjectTag is a key-value pair attached to an object.
+type ObjectTag struct {
+	Key   string
+	Value string
+}
+
+// ObjectTags is the set of tags attached to an object.
+type ObjectTags []ObjectTag
+
+var _ encoderDecoder = (*ObjectTags)(nil)
+
+// Verify checks whether the tags are valid.
+func (tags ObjectTags) Verify() error {
+	if len(tags) > MaxObjectTags {
+		return ErrInvalidRequest.New("too many tags: %d (max %d)", len(tags), MaxObjectTags)
+	}
+
+	keys := make(map[string]struct{}, len(tags))
+	for i, tag := range tags {
+		switch {
+		case tag.Key == "":
+			return ErrInvalidRequest.New("tag %d: key missing", i)
+		case len(tag.Key) > MaxObjectTagKeyLength:
+			return ErrInvalidRequest.New("tag %d: key is too long: %d (max %d)", i, len(tag.Key), MaxObjectTagKeyLength)
+		case len(tag.Value) > MaxObjectTagValueLength:
+			return ErrInvalidRequest.New("tag %d: value is too long: %d (max %d)", i, len(tag.Value), MaxObjectTagValueLength)
+		case !utf8.ValidString(tag.Key) || !utf8.ValidString(tag.Value):
+			return ErrInvalidRequest.New("tag %d: invalid UTF-8", i)
+		}
+
+		if _, ok := keys[tag.Key]; ok {
+			return ErrInvalidRequest.New("tag %d: duplicate key %q", i, tag.Key)
+		}
+		keys[tag.Key] = struct{}{}
+	}
+	return nil
+}
+
+// Get returns the value of the tag with the given key.
+func (tags ObjectTags) Get(key string) (value string, ok bool) {
+	for _, tag := range tags {
+		if tag.Key == key {
+			return tag.Value, true
+		}
+	}
+	return "", false
+}
+
+// Match returns whether tags contain all the tags of the filter.
+func (tags ObjectTags) Match(filter ObjectTags) bool {
+	for _, expected := range filter {
+		value, ok := tags.Get(expected.Key)
+		if !ok || value != expected.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// Bytes encodes the tags as a sequence of length-prefixed keys and values.
+func (tags ObjectTags) Bytes() []byte {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	var data []byte
+	for _, tag := range tags {
+		data = binary.AppendUvarint(data, uint64(len(tag.Key)))
+		data = append(data, tag.Key...)
+		data = binary.AppendUvarint(data, uint64(len(tag.Value)))
+		data = append(data, tag.Value...)
+	}
+	return data
+}
+
+// SetBytes decodes the tags from the format created by Bytes.
+func (tags *ObjectTags) SetBytes(data []byte) error {
+	*tags = nil
+
+	next := func() (string, error) {
+		length, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < length {
+			return "", Error.New("invalid object tags encoding")
+		}
+		value := string(data[n : n+int(length)])
+		data = data[n+int(length):]
+		return value, nil
+	}
+
+	for len(data) > 0 {
+		key, err := next()
+		if err != nil {
+			return err
+		}
+		value, err := next()
+		if err != nil {
+			return err
+		}
+		*tags = append(*tags, ObjectTag{Key: key, Value: value})
+	}
+	return nil
+}
+
+// Scan implements the database/sql Scanner interface.
+func (tags *ObjectTags) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*tags = nil
+		return nil
+	case []byte:
+		return tags.SetBytes(src)
+	default:
+		return Error.New("invalid type for ObjectTags: %T", src)
+	}
+}
+
+// Value implements the database/sql/driver Valuer interface.
+func (tags ObjectTags) Value() (driver.Value, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	return tags.Bytes(), nil
+}
+
+// DecodeSpanner implements spanner.Decoder.
+func (tags *ObjectTags) DecodeSpanner(val any) (err error) {
+	// spanner returns BYTES as base64
+	if v, ok := val.(string); ok {
+		val, err = base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return err
+		}
+	}
+	return tags.Scan(val)
+}
+
+// EncodeSpanner implements spanner.Encoder.
+func (tags ObjectTags) EncodeSpanner() (any, error) {
+	// nil bytes are stored as NULL.
+	return tags.Bytes(), nil
+}
+
+// GetObjectExactVersionTags contains arguments necessary for retrieving
+// the tags of an exact version of an object.
+type GetObjectExactVersionTags struct {
+	ObjectLocation
+	Version Version
+}
+
+// GetObjectExactVersionTags returns the tags of an exact version of an object.
+func (db *DB) GetObjectExactVersionTags(ctx context.Context, opts GetObjectExactVersionTags) (tags ObjectTags, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return nil, err
+	}
+
+	return db.ChooseAdapter(opts.ProjectID).GetObjectExactVersionTags(ctx, opts)
+}
+
+// GetObjectExactVersionTags returns the tags of an exact version of an object.
+func (p *PostgresAdapter) GetObjectExactVersionTags(ctx context.Context, opts GetObjectExactVersionTags) (tags ObjectTags, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = p.db.QueryRowContext(ctx, `
+		SELECT tags
+		FROM objects
+		WHERE
+			(project_id, bucket_name, object_key, version) = ($1, $2, $3, $4)
+			AND status IN `+statusesCommitted+`
+			AND (expires_at IS NULL OR expires_at > now())`,
+		opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.Version,
+	).Scan(&tags)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrObjectNotFound.Wrap(Error.Wrap(err))
+		}
+		return nil, Error.New("unable to query object tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// GetObjectExactVersionTags returns the tags of an exact version of an object.
+func (s *SpannerAdapter) GetObjectExactVersionTags(ctx context.Context, opts GetObjectExactVersionTags) (tags ObjectTags, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	tags, err = spannerutil.CollectRow(s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT tags
+			FROM objects
+			WHERE
+				(project_id, bucket_name, object_key, version) = (@project_id, @bucket_name, @object_key, @version)
+				AND status IN ` + statusesCommitted + `
+				AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)`,
+		Params: map[string]interface{}{
+			"project_id":  opts.ProjectID,
+			"bucket_name": opts.BucketName,
+			"object_key":  opts.ObjectKey,
+			"version":     opts.Version,
+		},
+	}), func(row *spanner.Row, tags *ObjectTags) error {
+		return Error.Wrap(row.Columns(tags))
+	})
+	if err != nil {
+		if errors.Is(err, iterator.Done) {
+			return nil, ErrObjectNotFound.Wrap(Error.Wrap(sql.ErrNoRows))
+		}
+		return nil, Error.New("unable to query object tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// GetObjectLastCommittedTags contains arguments necessary for retrieving
+// the tags of the most recently committed version of an object.
+type GetObjectLastCommittedTags struct {
+	ObjectLocation
+}
+
+// GetObjectLastCommittedTags returns the tags of the most recently committed version of an object.
+func (db *DB) GetObjectLastCommittedTags(ctx context.Context, opts GetObjectLastCommittedTags) (tags ObjectTags, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return nil, err
+	}
+
+	return db.ChooseAdapter(opts.ProjectID).GetObjectLastCommittedTags(ctx, opts)
+}
+
+// GetObjectLastCommittedTags returns the tags of the most recently committed version of an object.
+func (p *PostgresAdapter) GetObjectLastCommittedTags(ctx context.Context, opts GetObjectLastCommittedTags) (tags ObjectTags, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var status ObjectStatus
+	err = p.db.QueryRowContext(ctx, `
+		SELECT status, tags
+		FROM objects
+		WHERE
+			(project_id, bucket_name, object_key) = ($1, $2, $3)
+			AND status <> `+statusPending+`
+			AND (expires_at IS NULL OR expires_at > now())
+		ORDER BY version DESC
+		LIMIT 1`,
+		opts.ProjectID, opts.BucketName, opts.ObjectKey,
+	).Scan(&status, &tags)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrObjectNotFound.Wrap(Error.Wrap(err))
+		}
+		return nil, Error.New("unable to query object tags: %w", err)
+	}
+	if status.IsDeleteMarker() {
+		return nil, ErrObjectNotFound.Wrap(Error.Wrap(sql.ErrNoRows))
+	}
+
+	return tags, nil
+}
+
+// GetObjectLastCommittedTags returns the tags of the most recently committed version of an object.
+func (s *SpannerAdapter) GetObjectLastCommittedTags(ctx context.Context, opts GetObjectLastCommittedTags) (tags ObjectTags, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	type result struct {
+		status ObjectStatus
+		tags   ObjectTags
+	}
+
+	latest, err := spannerutil.CollectRow(s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT status, tags
+			FROM objects
+			WHERE
+				(project_id, bucket_name, object_key) = (@project_id, @bucket_name, @object_key)
+				AND status <> ` + statusPending + `
+				AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+			ORDER BY version DESC
+			LIMIT 1
+		`,
+		Params: map[string]interface{}{
+			"project_id":  opts.ProjectID,
+			"bucket_name": opts.BucketName,
+			"object_key":  opts.ObjectKey,
+		},
+	}), func(row *spanner.Row, item *result) error {
+		return Error.Wrap(row.Columns(&item.status, &item.tags))
+	})
+	if err != nil {
+		if errors.Is(err, iterator.Done) {
+			return nil, ErrObjectNotFound.Wrap(Error.Wrap(sql.ErrNoRows))
+		}
+		return nil, Error.New("unable to query object tags: %w", err)
+	}
+	if latest.status.IsDeleteMarker() {
+		return nil, ErrObjectNotFound.Wrap(Error.Wrap(sql.ErrNoRows))
+	}
+
+	return latest.tags, nil
+}
+
+// SetObjectExactVersionTags contains arguments necessary for replacing
+// the tags of an exact version of an object.
+type SetObjectExactVersionTags struct {
+	ObjectLocation
+	Version Version
+
+	Tags ObjectTags
+}
+
+// Verify verifies the request fields.
+func (opts *SetObjectExactVersionTags) Verify() error {
+	if err := opts.ObjectLocation.Verify(); err != nil {
+		return err
+	}
+	return opts.Tags.Verify()
+}
+
+// SetObjectExactVersionTags replaces the tags of an exact version of an object.
+// Empty tags remove all the tags of the object.
+func (db *DB) SetObjectExactVersionTags(ctx context.Context, opts SetObjectExactVersionTags) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	affected, err := db.ChooseAdapter(opts.ProjectID).SetObjectExactVersionTags(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrObjectNotFound.New("")
+	}
+
+	mon.Meter("object_set_tags").Mark(1)
+	return nil
+}
+
+// SetObjectExactVersionTags replaces the tags of an exact version of an object.
+func (p *PostgresAdapter) SetObjectExactVersionTags(ctx context.Context, opts SetObjectExactVersionTags) (affected int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE objects SET
+			tags = $5
+		WHERE
+			(project_id, bucket_name, object_key, version) = ($1, $2, $3, $4)
+			AND status IN `+statusesCommitted+`
+			AND (expires_at IS NULL OR expires_at > now())`,
+		opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.Version, opts.Tags,
+	)
+	if err != nil {
+		return 0, Error.New("unable to update object tags: %w", err)
+	}
+
+	affected, err = result.RowsAffected()
+	if err != nil {
+		return 0, Error.New("failed to get rows affected: %w", err)
+	}
+	return affected, nil
+}
+
+// SetObjectExactVersionTags replaces the tags of an exact version of an object.
+func (s *SpannerAdapter) SetObjectExactVersionTags(ctx context.Context, opts SetObjectExactVersionTags) (affected int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		affected, err = tx.Update(ctx, spanner.Statement{
+			SQL: `
+				UPDATE objects SET
+					tags = @tags
+				WHERE
+					(project_id, bucket_name, object_key, version) = (@project_id, @bucket_name, @object_key, @version)
+					AND status IN ` + statusesCommitted + `
+					AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+			`,
+			Params: map[string]interface{}{
+				"project_id":  opts.ProjectID,
+				"bucket_name": opts.BucketName,
+				"object_key":  opts.ObjectKey,
+				"version":     opts.Version,
+				"tags":        opts.Tags,
+			},
+		})
+		if err != nil {
+			return Error.New("unable to update object tags: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+	return affected, nil
+}
+
+// SetObjectLastCommittedTags contains arguments necessary for replacing
+// the tags of the most recently committed version of an object.
+type SetObjectLastCommittedTags struct {
+	ObjectLocation
+
+	Tags ObjectTags
+}
+
+// Verify verifies the request fields.
+func (opts *SetObjectLastCommittedTags) Verify() error {
+	if err := opts.ObjectLocation.Verify(); err != nil {
+		return err
+	}
+	return opts.Tags.Verify()
+}
+
+// SetObjectLastCommittedTags replaces the tags of the most recently committed version of an object.
+// Empty tags remove all the tags of the object.
+func (db *DB) SetObjectLastCommittedTags(ctx context.Context, opts SetObjectLastCommittedTags) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	affected, err := db.ChooseAdapter(opts.ProjectID).SetObjectLastCommittedTags(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrObjectNotFound.New("")
+	}
+
+	mon.Meter("object_set_tags").Mark(1)
+	return nil
+}
+
+// SetObjectLastCommittedTags replaces the tags of the most recently committed version of an object.
+func (p *PostgresAdapter) SetObjectLastCommittedTags(ctx context.Context, opts SetObjectLastCommittedTags) (affected int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE objects SET
+			tags = $4
+		WHERE
+			(project_id, bucket_name, object_key) = ($1, $2, $3) AND
+			version IN (SELECT version FROM objects WHERE
+				(project_id, bucket_name, object_key) = ($1, $2, $3) AND
+				status <> `+statusPending+` AND
+				(expires_at IS NULL OR expires_at > now())
+				ORDER BY version DESC
+				LIMIT 1
+			) AND
+			status IN `+statusesCommitted,
+		opts.ProjectID, opts.BucketName, opts.ObjectKey, opts.Tags,
+	)
+	if err != nil {
+		return 0, Error.New("unable to update object tags: %w", err)
+	}
+
+	affected, err = result.RowsAffected()
+	if err != nil {
+		return 0, Error.New("failed to get rows affected: %w", err)
+	}
+	return affected, nil
+}
+
+// SetObjectLastCommittedTags replaces the tags of the most recently committed version of an object.
+func (s *SpannerAdapter) SetObjectLastCommittedTags(ctx context.Context, opts SetObjectLastCommittedTags) (affected int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		affected, err = tx.Update(ctx, spanner.Statement{
+			SQL: `
+				UPDATE objects SET
+					tags = @tags
+				WHERE
+					(project_id, bucket_name, object_key) = (@project_id, @bucket_name, @object_key) AND
+					version IN (SELECT version FROM objects WHERE
+						(project_id, bucket_name, object_key) = (@project_id, @bucket_name, @object_key) AND
+						status <> ` + statusPending + ` AND
+						(expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+						ORDER BY version DESC
+						LIMIT 1
+					) AND
+					status IN ` + statusesCommitted + `
+			`,
+			Params: map[string]interface{}{
+				"project_id":  opts.ProjectID,
+				"bucket_name": opts.BucketName,
+				"object_key":  opts.ObjectKey,
+				"tags":        opts.Tags,
+			},
+		})
+		if err != nil {
+			return Error.New("unable to update object tags: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+	return affected, nil
+}
@@ -0,0 +1,286 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestObjectTags_Encoding(t *testing.T) {
+	for _, tags := range []metabase.ObjectTags{
+		{{Key: "a", Value: ""}},
+		{{Key: "project", Value: "storj"}, {Key: "state", Value: "archived"}},
+		{{Key: strings.Repeat("k", metabase.MaxObjectTagKeyLength), Value: strings.Repeat("v", metabase.MaxObjectTagValueLength)}},
+	} {
+		var decoded metabase.ObjectTags
+		require.NoError(t, decoded.SetBytes(tags.Bytes()))
+		require.Equal(t, tags, decoded)
+	}
+
+	require.Nil(t, metabase.ObjectTags{}.Bytes())
+
+	var decoded metabase.ObjectTags
+	require.Error(t, decoded.SetBytes([]byte{5, 'a'}))
+}
+
+func TestObjectTags_Verify(t *testing.T) {
+	tooMany := make(metabase.ObjectTags, metabase.MaxObjectTags+1)
+	for i := range tooMany {
+		tooMany[i] = metabase.ObjectTag{Key: string(rune('a' + i))}
+	}
+
+	for _, tc := range []struct {
+		tags    metabase.ObjectTags
+		errText string
+	}{
+		{tags: nil},
+		{tags: tooMany, errText: "too many tags"},
+		{tags: metabase.ObjectTags{{Value: "v"}}, errText: "key missing"},
+		{tags: metabase.ObjectTags{{Key: strings.Repeat("k", metabase.MaxObjectTagKeyLength+1)}}, errText: "key is too long"},
+		{tags: metabase.ObjectTags{{Key: "k", Value: strings.Repeat("v", metabase.MaxObjectTagValueLength+1)}}, errText: "value is too long"},
+		{tags: metabase.ObjectTags{{Key: "k"}, {Key: "k"}}, errText: "duplicate key"},
+	} {
+		err := tc.tags.Verify()
+		if tc.errText == "" {
+			require.NoError(t, err)
+			continue
+		}
+		require.True(t, metabase.ErrInvalidRequest.Has(err))
+		require.Contains(t, err.Error(), tc.errText)
+	}
+}
+
+func TestObjectTags(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		obj := metabasetest.RandObjectStream()
+		loc := obj.Location()
+
+		tags := metabase.ObjectTags{
+			{Key: "project", Value: "storj"},
+			{Key: "state", Value: "archived"},
+		}
+
+		t.Run("Invalid tags", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.SetObjectLastCommittedTags{
+				Opts: metabase.SetObjectLastCommittedTags{
+					ObjectLocation: loc,
+					Tags:           metabase.ObjectTags{{Key: "a"}, {Key: "a"}},
+				},
+				ErrClass: &metabase.ErrInvalidRequest,
+				ErrText:  `tag 1: duplicate key "a"`,
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("Missing object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.SetObjectLastCommittedTags{
+				Opts: metabase.SetObjectLastCommittedTags{
+					ObjectLocation: loc,
+					Tags:           tags,
+				},
+				ErrClass: &metabase.ErrObjectNotFound,
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectExactVersionTags{
+				Opts: metabase.GetObjectExactVersionTags{
+					ObjectLocation: loc,
+					Version:        obj.Version,
+				},
+				ErrClass: &metabase.ErrObjectNotFound,
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("Set and remove tags", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.CreateObject(ctx, t, db, obj, 0)
+
+			metabasetest.GetObjectLastCommittedTags{
+				Opts: metabase.GetObjectLastCommittedTags{
+					ObjectLocation: loc,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.SetObjectLastCommittedTags{
+				Opts: metabase.SetObjectLastCommittedTags{
+					ObjectLocation: loc,
+					Tags:           tags,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectLastCommittedTags{
+				Opts: metabase.GetObjectLastCommittedTags{
+					ObjectLocation: loc,
+				},
+				Result: tags,
+			}.Check(ctx, t, db)
+
+			metabasetest.SetObjectLastCommittedTags{
+				Opts: metabase.SetObjectLastCommittedTags{
+					ObjectLocation: loc,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectLastCommittedTags{
+				Opts: metabase.GetObjectLastCommittedTags{
+					ObjectLocation: loc,
+				},
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("Exact version", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			first := obj
+			metabasetest.CreateObjectVersioned(ctx, t, db, first, 0)
+			second := obj
+			second.Version++
+			metabasetest.CreateObjectVersioned(ctx, t, db, second, 0)
+
+			metabasetest.SetObjectExactVersionTags{
+				Opts: metabase.SetObjectExactVersionTags{
+					ObjectLocation: loc,
+					Version:        first.Version,
+					Tags:           tags,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.GetObjectExactVersionTags{
+				Opts: metabase.GetObjectExactVersionTags{
+					ObjectLocation: loc,
+					Version:        first.Version,
+				},
+				Result: tags,
+			}.Check(ctx, t, db)
+
+			// the latest version is not affected.
+			metabasetest.GetObjectLastCommittedTags{
+				Opts: metabase.GetObjectLastCommittedTags{
+					ObjectLocation: loc,
+				},
+			}.Check(ctx, t, db)
+
+			metabasetest.SetObjectExactVersionTags{
+				Opts: metabase.SetObjectExactVersionTags{
+					ObjectLocation: loc,
+					Version:        second.Version + 1,
+					Tags:           tags,
+				},
+				ErrClass: &metabase.ErrObjectNotFound,
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("Delete marker", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			metabasetest.CreateObjectVersioned(ctx, t, db, obj, 0)
+
+			result, err := db.DeleteObjectLastCommitted(ctx, metabase.DeleteObjectLastCommitted{
+				ObjectLocation: loc,
+				Versioned:      true,
+			})
+			require.NoError(t, err)
+			require.Len(t, result.Markers, 1)
+
+			metabasetest.GetObjectLastCommittedTags{
+				Opts: metabase.GetObjectLastCommittedTags{
+					ObjectLocation: loc,
+				},
+				ErrClass: &metabase.ErrObjectNotFound,
+			}.Check(ctx, t, db)
+
+			metabasetest.SetObjectLastCommittedTags{
+				Opts: metabase.SetObjectLastCommittedTags{
+					ObjectLocation: loc,
+					Tags:           tags,
+				},
+				ErrClass: &metabase.ErrObjectNotFound,
+			}.Check(ctx, t, db)
+
+			metabasetest.SetObjectExactVersionTags{
+				Opts: metabase.SetObjectExactVersionTags{
+					ObjectLocation: loc,
+					Version:        result.Markers[0].Version,
+					Tags:           tags,
+				},
+				ErrClass: &metabase.ErrObjectNotFound,
+			}.Check(ctx, t, db)
+		})
+
+		t.Run("List with tag filter", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			keys := []metabase.ObjectKey{"a", "b", "c", "dir/d", "e"}
+			for i, key := range keys {
+				stream := obj
+				stream.ObjectKey = key
+				stream.StreamID = testrand.UUID()
+				metabasetest.CreateObject(ctx, t, db, stream, 0)
+
+				if i%2 == 0 {
+					metabasetest.SetObjectLastCommittedTags{
+						Opts: metabase.SetObjectLastCommittedTags{
+							ObjectLocation: stream.Location(),
+							Tags:           tags,
+						},
+					}.Check(ctx, t, db)
+				}
+			}
+
+			for _, listObjects := range []func(context.Context, metabase.ListObjects) (metabase.ListObjectsResult, error){
+				db.ListObjects,
+				db.ListObjectsWithIterator,
+			} {
+				list := func(recursive bool, limit int, filter metabase.ObjectTags) (listed []metabase.ObjectKey, more bool) {
+					result, err := listObjects(ctx, metabase.ListObjects{
+						ProjectID:  obj.ProjectID,
+						BucketName: obj.BucketName,
+						Recursive:  recursive,
+						Limit:      limit,
+						TagFilter:  filter,
+					})
+					require.NoError(t, err)
+					for _, entry := range result.Objects {
+						listed = append(listed, entry.ObjectKey)
+					}
+					return listed, result.More
+				}
+
+				listed, more := list(true, 10, metabase.ObjectTags{{Key: "project", Value: "storj"}})
+				require.Equal(t, []metabase.ObjectKey{"a", "c", "e"}, listed)
+				require.False(t, more)
+
+				listed, more = list(true, 1, metabase.ObjectTags{{Key: "state", Value: "archived"}})
+				require.Equal(t, []metabase.ObjectKey{"a"}, listed)
+				require.True(t, more)
+
+				// prefixes are listed regardless of the filter.
+				listed, _ = list(false, 10, tags)
+				require.Equal(t, []metabase.ObjectKey{"a", "c", "dir/", "e"}, listed)
+
+				listed, _ = list(true, 10, metabase.ObjectTags{{Key: "project", Value: "other"}})
+				require.Empty(t, listed)
+			}
+
+			_, err := db.ListObjects(ctx, metabase.ListObjects{
+				ProjectID:  obj.ProjectID,
+				BucketName: obj.BucketName,
+				TagFilter:  metabase.ObjectTags{{Value: "storj"}},
+			})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+		})
+	})
+}
@@ -0,0 +1,229 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/spanner"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/shared/dbutil/pgutil"
+	"storj.io/storj/shared/dbutil/spannerutil"
+	"storj.io/storj/shared/tagsql"
+)
+
+// FindOrphanedStreams contains arguments for finding the streams which have segments, but no object.
+//
+// Server-side copies used to share the segments of the original object (the ancestor)
+// and deleting the ancestor promoted one of the copies. Copies duplicate the segments
+// nowadays, but failed promotions and interrupted deletions of the past may have left
+// segments whose stream isn't referenced by any object.
+type FindOrphanedStreams struct {
+	BatchSize          int
+	AsOfSystemInterval time.Duration
+}
+
+// Verify verifies find orphaned streams request fields.
+func (opts *FindOrphanedStreams) Verify() error {
+	if opts.BatchSize < 0 {
+		return ErrInvalidRequest.New("BatchSize is negative")
+	}
+	return nil
+}
+
+// FindOrphanedStreams removes from the candidates every stream which is referenced by an object.
+// The remaining candidates are orphaned.
+//
+// All objects are iterated, hence the candidates should only contain streams whose segments
+// were created before the objects snapshot used by AsOfSystemInterval.
+func (db *DB) FindOrphanedStreams(ctx context.Context, opts FindOrphanedStreams, candidates map[uuid.UUID]struct{}) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	loopIteratorBatchSizeLimit.Ensure(&opts.BatchSize)
+
+	for _, a := range db.adapters {
+		var startAfter ObjectStream
+		for {
+			streams, err := a.ListObjectStreams(ctx, opts, startAfter, opts.BatchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, stream := range streams {
+				delete(candidates, stream.StreamID)
+			}
+
+			if len(candidates) == 0 {
+				return nil
+			}
+			if len(streams) < opts.BatchSize {
+				break
+			}
+			startAfter = streams[len(streams)-1]
+		}
+	}
+	return nil
+}
+
+// ListObjectStreams lists up to batchSize object streams after startAfter.
+func (p *PostgresAdapter) ListObjectStreams(ctx context.Context, opts FindOrphanedStreams, startAfter ObjectStream, batchSize int) (streams []ObjectStream, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	streams = make([]ObjectStream, 0, batchSize)
+	err = withRows(p.db.QueryContext(ctx, `
+		SELECT
+			project_id, bucket_name, object_key, version, stream_id
+		FROM objects
+		`+p.impl.AsOfSystemInterval(opts.AsOfSystemInterval)+`
+		WHERE
+			(project_id, bucket_name, object_key, version) > ($1, $2, $3, $4)
+		ORDER BY project_id, bucket_name, object_key, version
+		LIMIT $5
+	`, startAfter.ProjectID, startAfter.BucketName, []byte(startAfter.ObjectKey), startAfter.Version,
+		batchSize),
+	)(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var stream ObjectStream
+			err := rows.Scan(&stream.ProjectID, &stream.BucketName, &stream.ObjectKey, &stream.Version, &stream.StreamID)
+			if err != nil {
+				return err
+			}
+			streams = append(streams, stream)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return streams, nil
+}
+
+// ListObjectStreams lists up to batchSize object streams after startAfter.
+func (s *SpannerAdapter) ListObjectStreams(ctx context.Context, opts FindOrphanedStreams, startAfter ObjectStream, batchSize int) (streams []ObjectStream, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	streams, err = spannerutil.CollectRows(s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT
+				project_id, bucket_name, object_key, version, stream_id
+			FROM objects
+			WHERE
+				project_id > @project_id
+				OR (project_id = @project_id AND bucket_name > @bucket_name)
+				OR (project_id = @project_id AND bucket_name = @bucket_name AND object_key > @object_key)
+				OR (project_id = @project_id AND bucket_name = @bucket_name AND object_key = @object_key AND version > @version)
+			ORDER BY project_id, bucket_name, object_key, version
+			LIMIT @batch_size
+		`, Params: map[string]interface{}{
+			"project_id":  startAfter.ProjectID,
+			"bucket_name": startAfter.BucketName,
+			"object_key":  startAfter.ObjectKey,
+			"version":     startAfter.Version,
+			"batch_size":  batchSize,
+		},
+	}), func(row *spanner.Row, stream *ObjectStream) error {
+		return row.Columns(&stream.ProjectID, &stream.BucketName, &stream.ObjectKey, &stream.Version, &stream.StreamID)
+	})
+	return streams, Error.Wrap(err)
+}
+
+// DeleteOrphanedSegments contains arguments for deleting the segments of orphaned streams.
+type DeleteOrphanedSegments struct {
+	StreamIDs []uuid.UUID
+	// CreatedBefore protects the segments uploaded after the streams were found to be orphaned.
+	CreatedBefore time.Time
+}
+
+// Verify verifies delete orphaned segments request fields.
+func (opts *DeleteOrphanedSegments) Verify() error {
+	if opts.CreatedBefore.IsZero() {
+		return ErrInvalidRequest.New("CreatedBefore missing")
+	}
+	return nil
+}
+
+// DeleteOrphanedSegments deletes the segments of the orphaned streams, which were created before opts.CreatedBefore.
+// The streams should be found with FindOrphanedStreams.
+func (db *DB) DeleteOrphanedSegments(ctx context.Context, opts DeleteOrphanedSegments) (deleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return 0, err
+	}
+	if len(opts.StreamIDs) == 0 {
+		return 0, nil
+	}
+
+	for _, a := range db.adapters {
+		adapterDeleted, err := a.DeleteOrphanedSegments(ctx, opts)
+		deleted += adapterDeleted
+		if err != nil {
+			return deleted, err
+		}
+	}
+
+	mon.Meter("segment_delete").Mark64(deleted)
+
+	return deleted, nil
+}
+
+// DeleteOrphanedSegments deletes the segments of the orphaned streams.
+func (p *PostgresAdapter) DeleteOrphanedSegments(ctx context.Context, opts DeleteOrphanedSegments) (deleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := p.db.ExecContext(ctx, `
+		DELETE FROM segments
+		WHERE
+			stream_id = ANY($1::BYTEA[]) AND
+			created_at < $2
+	`, pgutil.UUIDArray(opts.StreamIDs), opts.CreatedBefore)
+	if err != nil {
+		return 0, Error.New("unable to delete orphaned segments: %w", err)
+	}
+
+	deleted, err = result.RowsAffected()
+	if err != nil {
+		return 0, Error.New("unable to delete orphaned segments: %w", err)
+	}
+	return deleted, nil
+}
+
+// DeleteOrphanedSegments deletes the segments of the orphaned streams.
+func (s *SpannerAdapter) DeleteOrphanedSegments(ctx context.Context, opts DeleteOrphanedSegments) (deleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	streamIDs := make([][]byte, 0, len(opts.StreamIDs))
+	for _, streamID := range opts.StreamIDs {
+		streamIDs = append(streamIDs, streamID.Bytes())
+	}
+
+	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		deleted, err = tx.Update(ctx, spanner.Statement{
+			SQL: `
+				DELETE FROM segments
+				WHERE
+					ARRAY_INCLUDES(@stream_ids, stream_id) AND
+					created_at < @created_before
+			`,
+			Params: map[string]interface{}{
+				"stream_ids":     streamIDs,
+				"created_before": opts.CreatedBefore,
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return 0, Error.New("unable to delete orphaned segments: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestOrphanedSegments(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		now := time.Now()
+
+		obj := metabasetest.RandObjectStream()
+		metabasetest.CreateObject(ctx, t, db, obj, 2)
+
+		oldOrphan := metabasetest.RandObjectStream()
+		newOrphan := metabasetest.RandObjectStream()
+
+		var orphans []metabase.RawSegment
+		for i := 0; i < 2; i++ {
+			segment := metabasetest.DefaultRawSegment(oldOrphan, metabase.SegmentPosition{Index: uint32(i)})
+			segment.CreatedAt = now.Add(-time.Hour)
+			orphans = append(orphans, segment)
+		}
+		orphans = append(orphans, metabasetest.DefaultRawSegment(newOrphan, metabase.SegmentPosition{}))
+		require.NoError(t, db.TestingBatchInsertSegments(ctx, orphans))
+
+		t.Run("find", func(t *testing.T) {
+			candidates := map[uuid.UUID]struct{}{
+				obj.StreamID:       {},
+				oldOrphan.StreamID: {},
+				newOrphan.StreamID: {},
+				testrand.UUID():    {},
+			}
+
+			err := db.FindOrphanedStreams(ctx, metabase.FindOrphanedStreams{BatchSize: 1}, candidates)
+			require.NoError(t, err)
+			require.NotContains(t, candidates, obj.StreamID)
+			require.Contains(t, candidates, oldOrphan.StreamID)
+			require.Contains(t, candidates, newOrphan.StreamID)
+		})
+
+		t.Run("delete", func(t *testing.T) {
+			_, err := db.DeleteOrphanedSegments(ctx, metabase.DeleteOrphanedSegments{
+				StreamIDs: []uuid.UUID{oldOrphan.StreamID},
+			})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+
+			deleted, err := db.DeleteOrphanedSegments(ctx, metabase.DeleteOrphanedSegments{
+				StreamIDs:     []uuid.UUID{oldOrphan.StreamID, newOrphan.StreamID},
+				CreatedBefore: now.Add(-10 * time.Minute),
+			})
+			require.NoError(t, err)
+			require.EqualValues(t, 2, deleted)
+
+			segments, err := db.TestingAllSegments(ctx)
+			require.NoError(t, err)
+			require.Len(t, segments, 3)
+			for _, segment := range segments {
+				require.NotEqual(t, oldOrphan.StreamID, segment.StreamID)
+			}
+		})
+	})
+}
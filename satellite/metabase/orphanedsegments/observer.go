@@ -0,0 +1,244 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package orphanedsegments deletes the segments, which aren't referenced by any object.
+//
+// Server-side copies used to reference the segments of their ancestor object.
+// Deleting the ancestor could leave segments, whose stream doesn't belong to
+// any object, and which are never deleted by the regular object deletion.
+package orphanedsegments
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/rangedloop"
+)
+
+var (
+	// Error is a standard error class for this package.
+	Error = errs.Class("orphanedsegments")
+	mon   = monkit.Package()
+
+	// check if Observer and Partial interfaces are satisfied.
+	_ rangedloop.Observer = (*Observer)(nil)
+	_ rangedloop.Partial  = (*observerFork)(nil)
+)
+
+// Config contains configurable values for deleting orphaned segments.
+type Config struct {
+	Enabled            bool          `help:"whether to find the segments, which aren't referenced by any object, with the ranged loop" default:"false"`
+	DryRun             bool          `help:"only report the orphaned segments without deleting them" default:"true"`
+	MinimumAge         time.Duration `help:"segments created less than this before the start of the loop are not considered orphaned" default:"24h0m0s" testDefault:"0"`
+	Pages              int           `help:"number of parts the stream IDs are split into, every loop collects the streams of the next part only" default:"16" testDefault:"1"`
+	MaxCandidates      int           `help:"maximum number of streams collected during a single loop; the rest is handled when the loops get to the same part again" default:"1000000"`
+	BatchSize          int           `help:"number of streams whose segments are deleted with a single query" default:"100"`
+	AsOfSystemInterval time.Duration `help:"as of system interval" releaseDefault:"-5m" devDefault:"-1us" testDefault:"-1us"`
+}
+
+// Observer implements a ranged loop observer, which deletes orphaned segments.
+//
+// The observer collects the streams of the segments created at least MinimumAge
+// before the start of the loop. At the end of the loop the streams referenced by
+// an object are removed and the segments of the remaining streams are deleted.
+// Objects are created before their segments, so a stream without an object can't
+// get an object later.
+//
+// To bound the memory, every loop only collects the streams of a single part
+// (page) of the stream IDs, and at most MaxCandidates of them.
+type Observer struct {
+	log        *zap.Logger
+	config     Config
+	metabaseDB *metabase.DB
+
+	nextPage int
+
+	page          streamPage
+	createdBefore time.Time
+	// collected is the number of streams collected by all the forks.
+	collected *atomic.Int64
+	// candidates contains the number of segments of every collected stream.
+	candidates map[uuid.UUID]int64
+}
+
+// NewObserver creates new orphaned segments ranged loop observer.
+func NewObserver(log *zap.Logger, metabaseDB *metabase.DB, config Config) *Observer {
+	return &Observer{
+		log:        log,
+		config:     config,
+		metabaseDB: metabaseDB,
+		collected:  new(atomic.Int64),
+		candidates: map[uuid.UUID]int64{},
+	}
+}
+
+// Start implements ranged loop observer start method.
+func (observer *Observer) Start(ctx context.Context, startTime time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	observer.page = newStreamPage(observer.nextPage, observer.config.Pages)
+	observer.nextPage = (observer.nextPage + 1) % observer.page.count
+
+	observer.createdBefore = startTime.Add(-observer.config.MinimumAge)
+	observer.collected = new(atomic.Int64)
+	observer.candidates = map[uuid.UUID]int64{}
+	return nil
+}
+
+// Fork implements ranged loop observer fork method.
+func (observer *Observer) Fork(ctx context.Context) (_ rangedloop.Partial, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return &observerFork{
+		page:          observer.page,
+		createdBefore: observer.createdBefore,
+		collected:     observer.collected,
+		maxCandidates: int64(observer.config.MaxCandidates),
+		candidates:    map[uuid.UUID]int64{},
+	}, nil
+}
+
+// Join merges the streams collected by the partial into the observer.
+func (observer *Observer) Join(ctx context.Context, partial rangedloop.Partial) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	fork, ok := partial.(*observerFork)
+	if !ok {
+		return Error.New("expected %T but got %T", fork, partial)
+	}
+
+	for streamID, segments := range fork.candidates {
+		observer.candidates[streamID] += segments
+	}
+	return nil
+}
+
+// Finish finds the orphaned streams and deletes their segments, unless running in dry-run mode.
+func (observer *Observer) Finish(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	orphaned := make(map[uuid.UUID]struct{}, len(observer.candidates))
+	for streamID := range observer.candidates {
+		orphaned[streamID] = struct{}{}
+	}
+
+	err = observer.metabaseDB.FindOrphanedStreams(ctx, metabase.FindOrphanedStreams{
+		AsOfSystemInterval: observer.config.AsOfSystemInterval,
+	}, orphaned)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var orphanedSegments int64
+	streamIDs := make([]uuid.UUID, 0, len(orphaned))
+	for streamID := range orphaned {
+		streamIDs = append(streamIDs, streamID)
+		orphanedSegments += observer.candidates[streamID]
+	}
+
+	if observer.collected.Load() > int64(observer.config.MaxCandidates) {
+		observer.log.Warn("too many streams in the page, only a part of them was checked",
+			zap.Int("page", observer.page.index),
+			zap.Int("max candidates", observer.config.MaxCandidates))
+	}
+
+	mon.IntVal("orphaned_streams").Observe(int64(len(streamIDs)))
+	mon.IntVal("orphaned_segments").Observe(orphanedSegments)
+
+	if len(streamIDs) == 0 {
+		return nil
+	}
+
+	if observer.config.DryRun {
+		observer.log.Info("found orphaned segments (dry run)",
+			zap.Int("streams", len(streamIDs)),
+			zap.Int64("segments", orphanedSegments))
+		return nil
+	}
+
+	var deleted int64
+	for len(streamIDs) > 0 {
+		batch := streamIDs
+		if len(batch) > observer.config.BatchSize {
+			batch = batch[:observer.config.BatchSize]
+		}
+		streamIDs = streamIDs[len(batch):]
+
+		count, err := observer.metabaseDB.DeleteOrphanedSegments(ctx, metabase.DeleteOrphanedSegments{
+			StreamIDs:     batch,
+			CreatedBefore: observer.createdBefore,
+		})
+		deleted += count
+		if err != nil {
+			mon.IntVal("orphaned_segments_deleted").Observe(deleted)
+			return Error.Wrap(err)
+		}
+	}
+
+	mon.IntVal("orphaned_segments_deleted").Observe(deleted)
+
+	observer.log.Info("deleted orphaned segments",
+		zap.Int("streams", len(orphaned)),
+		zap.Int64("segments", deleted))
+	return nil
+}
+
+type observerFork struct {
+	page          streamPage
+	createdBefore time.Time
+	collected     *atomic.Int64
+	maxCandidates int64
+	candidates    map[uuid.UUID]int64
+}
+
+// Process collects the streams of the page, whose segments were created before the minimum age.
+func (fork *observerFork) Process(ctx context.Context, segments []rangedloop.Segment) error {
+	for _, segment := range segments {
+		if !segment.CreatedAt.Before(fork.createdBefore) || !fork.page.contains(segment.StreamID) {
+			continue
+		}
+		if _, ok := fork.candidates[segment.StreamID]; !ok {
+			// the counter keeps growing past the limit, so Finish can tell that streams were skipped.
+			if fork.collected.Add(1) > fork.maxCandidates {
+				continue
+			}
+		}
+		fork.candidates[segment.StreamID]++
+	}
+	return nil
+}
+
+// streamPage is a part of the stream IDs, split by the first 8 bytes of the ID.
+type streamPage struct {
+	index int
+	count int
+	size  uint64
+}
+
+func newStreamPage(index, count int) streamPage {
+	if count <= 1 {
+		return streamPage{index: 0, count: 1}
+	}
+	return streamPage{
+		index: index % count,
+		count: count,
+		size:  math.MaxUint64/uint64(count) + 1,
+	}
+}
+
+// contains returns whether the stream ID belongs to the page.
+func (page streamPage) contains(streamID uuid.UUID) bool {
+	if page.count == 1 {
+		return true
+	}
+	return binary.BigEndian.Uint64(streamID[:8])/page.size == uint64(page.index)
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package orphanedsegments_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+	"storj.io/storj/satellite/metabase/orphanedsegments"
+	"storj.io/storj/satellite/metabase/rangedloop"
+)
+
+func TestObserverDeletesOrphanedSegments(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.RangedLoop.Parallelism = 2
+				config.RangedLoop.BatchSize = 2
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+
+		err := planet.Uplinks[0].Upload(ctx, sat, "testbucket", "object", testrand.Bytes(10*memory.KiB))
+		require.NoError(t, err)
+
+		var orphans []metabase.RawSegment
+		for i := 0; i < 3; i++ {
+			segment := metabasetest.DefaultRawSegment(metabasetest.RandObjectStream(), metabase.SegmentPosition{Index: uint32(i)})
+			segment.CreatedAt = time.Now().Add(-time.Hour)
+			orphans = append(orphans, segment)
+		}
+		// orphaned segments younger than the minimum age aren't deleted.
+		orphans = append(orphans, metabasetest.DefaultRawSegment(metabasetest.RandObjectStream(), metabase.SegmentPosition{}))
+		require.NoError(t, sat.Metabase.DB.TestingBatchInsertSegments(ctx, orphans))
+
+		segmentsBefore, err := sat.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+
+		runObserver := func(config orphanedsegments.Config, loops int) {
+			observer := orphanedsegments.NewObserver(zaptest.NewLogger(t), sat.Metabase.DB, config)
+
+			rangedloopConfig := sat.Config.RangedLoop
+			provider := rangedloop.NewMetabaseRangeSplitter(sat.Metabase.DB, rangedloopConfig.AsOfSystemInterval, rangedloopConfig.BatchSize)
+			service := rangedloop.NewService(zap.NewNop(), rangedloopConfig, provider, []rangedloop.Observer{observer})
+
+			for i := 0; i < loops; i++ {
+				_, err := service.RunOnce(ctx)
+				require.NoError(t, err)
+			}
+		}
+
+		config := orphanedsegments.Config{
+			Enabled:            true,
+			DryRun:             true,
+			MinimumAge:         time.Minute,
+			Pages:              1,
+			MaxCandidates:      100,
+			BatchSize:          1,
+			AsOfSystemInterval: sat.Config.OrphanedSegments.AsOfSystemInterval,
+		}
+		runObserver(config, 1)
+
+		segments, err := sat.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, len(segmentsBefore), "dry run must not delete segments")
+
+		// every loop checks a single page of the streams, all the pages are
+		// checked after as many loops as there are pages.
+		config.DryRun = false
+		config.Pages = 4
+		runObserver(config, config.Pages)
+
+		segments, err = sat.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, len(segmentsBefore)-3)
+		for _, segment := range segments {
+			for _, orphan := range orphans[:3] {
+				require.NotEqual(t, orphan.StreamID, segment.StreamID)
+			}
+		}
+
+		objects, err := sat.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+	})
+}
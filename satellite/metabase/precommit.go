@@ -24,6 +24,7 @@ type precommitTransactionAdapter interface {
 	precommitDeleteUnversioned(ctx context.Context, loc ObjectLocation) (result PrecommitConstraintResult, err error)
 	precommitDeleteUnversionedWithSQLCheck(ctx context.Context, loc ObjectLocation) (result PrecommitConstraintResult, err error)
 	precommitDeleteUnversionedWithVersionCheck(ctx context.Context, loc ObjectLocation) (result PrecommitConstraintResult, err error)
+	insertDeleteEvents(ctx context.Context, kind DeleteEventKind, objects []Object) error
 }
 
 // PrecommitConstraint is arguments to ensure that a single unversioned object or delete marker exists in the
@@ -114,14 +115,25 @@ func (db *DB) PrecommitConstraint(ctx context.Context, opts PrecommitConstraint,
 
 	switch opts.TestingPrecommitDeleteMode {
 	case DefaultUnversionedPrecommitMode:
-		return adapter.precommitDeleteUnversioned(ctx, opts.Location)
+		result, err = adapter.precommitDeleteUnversioned(ctx, opts.Location)
 	case WithPrecheckSQLUnversionedPrecommitMode:
-		return adapter.precommitDeleteUnversionedWithSQLCheck(ctx, opts.Location)
+		result, err = adapter.precommitDeleteUnversionedWithSQLCheck(ctx, opts.Location)
 	case WithVersionPrecheckUnversionedPrecommitMode:
-		return adapter.precommitDeleteUnversionedWithVersionCheck(ctx, opts.Location)
+		result, err = adapter.precommitDeleteUnversionedWithVersionCheck(ctx, opts.Location)
 	default:
 		return PrecommitConstraintResult{}, Error.New("Invalid precommit delete mode version: %d", opts.TestingPrecommitDeleteMode)
 	}
+	if err != nil {
+		return result, err
+	}
+
+	// the overwritten object is recorded in the same transaction as the new one is committed.
+	if len(result.Deleted) > 0 && db.recordsDeleteEvents(ctx, opts.Location.Bucket()) {
+		if err := adapter.insertDeleteEvents(ctx, DeleteEventRemoved, result.Deleted); err != nil {
+			return PrecommitConstraintResult{}, err
+		}
+	}
+	return result, nil
 }
 
 // precommitQueryHighest queries the highest version for a given object.
@@ -749,12 +761,12 @@ func (ptx *postgresTransactionAdapter) PrecommitDeleteUnversionedWithNonPending(
 	}
 
 	if opts.UseObjectLock {
-		return ptx.precommitDeleteUnversionedWithNonPendingUsingObjectLock(ctx, opts.ObjectLocation)
+		return ptx.precommitDeleteUnversionedWithNonPendingUsingObjectLock(ctx, opts.ObjectLocation, opts.deferSegmentsAbove)
 	}
-	return ptx.precommitDeleteUnversionedWithNonPending(ctx, opts.ObjectLocation)
+	return ptx.precommitDeleteUnversionedWithNonPending(ctx, opts.ObjectLocation, opts.deferSegmentsAbove)
 }
 
-func (ptx *postgresTransactionAdapter) precommitDeleteUnversionedWithNonPending(ctx context.Context, loc ObjectLocation) (result PrecommitConstraintWithNonPendingResult, err error) {
+func (ptx *postgresTransactionAdapter) precommitDeleteUnversionedWithNonPending(ctx context.Context, loc ObjectLocation, deferSegmentsAbove int) (result PrecommitConstraintWithNonPendingResult, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	var deleted Object
@@ -770,6 +782,13 @@ func (ptx *postgresTransactionAdapter) precommitDeleteUnversionedWithNonPending(
 	var encryptionParams nullableValue[encryptionParameters]
 	encryptionParams.value.EncryptionParameters = &deleted.Encryption
 
+	args := []any{loc.ProjectID, loc.BucketName, loc.ObjectKey}
+	var condition, deferred string
+	if deferSegmentsAbove > 0 {
+		condition, deferred = postgresDeferSegmentsQuery("$4", "$1")
+		args = append(args, deferSegmentsAbove)
+	}
+
 	err = ptx.tx.QueryRowContext(ctx, `
 		WITH highest_object AS (
 			SELECT version
@@ -798,9 +817,11 @@ func (ptx *postgresTransactionAdapter) precommitDeleteUnversionedWithNonPending(
 				encryption
 		), deleted_segments AS (
 			DELETE FROM segments
-			WHERE segments.stream_id IN (SELECT deleted_objects.stream_id FROM deleted_objects)
+			WHERE segments.stream_id IN (
+				SELECT deleted_objects.stream_id FROM deleted_objects`+condition+`
+			)
 			RETURNING segments.stream_id
-		)
+		)`+deferred+`
 		SELECT
 			(SELECT version FROM deleted_objects),
 			(SELECT stream_id FROM deleted_objects),
@@ -819,7 +840,7 @@ func (ptx *postgresTransactionAdapter) precommitDeleteUnversionedWithNonPending(
 			(SELECT count(*) FROM deleted_segments),
 			coalesce((SELECT version FROM highest_object), 0),
 			coalesce((SELECT version FROM highest_non_pending_object), 0)
-	`, loc.ProjectID, loc.BucketName, loc.ObjectKey).
+	`, args...).
 		Scan(
 			&version,
 			&streamID,
@@ -878,21 +899,21 @@ func (ptx *postgresTransactionAdapter) precommitDeleteUnversionedWithNonPending(
 // precommitDeleteUnversionedWithNonPendingUsingObjectLock deletes the unversioned object at loc
 // and also returns the highest version and highest committed version. It returns an error if the
 // object's Object Lock configuration prohibits its deletion.
-func (ptx *postgresTransactionAdapter) precommitDeleteUnversionedWithNonPendingUsingObjectLock(ctx context.Context, loc ObjectLocation) (result PrecommitConstraintWithNonPendingResult, err error) {
+func (ptx *postgresTransactionAdapter) precommitDeleteUnversionedWithNonPendingUsingObjectLock(ctx context.Context, loc ObjectLocation, deferSegmentsAbove int) (result PrecommitConstraintWithNonPendingResult, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	type versionAndRetention struct {
-		version   Version
-		retention Retention
+	type versionAndLock struct {
+		version Version
+		objectLockInfo
 	}
 
 	var (
 		highestVersionScanned, highestNonPendingVersionScanned bool
-		objectToDelete                                         *versionAndRetention
+		objectToDelete                                         *versionAndLock
 	)
 
 	err = withRows(ptx.tx.QueryContext(ctx, `
-		SELECT version, status, retention_mode, retain_until
+		SELECT version, status, retention_mode, retain_until, legal_hold
 		FROM objects
 		WHERE (project_id, bucket_name, object_key) = ($1, $2, $3)
 		ORDER BY version DESC
@@ -900,11 +921,11 @@ func (ptx *postgresTransactionAdapter) precommitDeleteUnversionedWithNonPendingU
 	))(func(rows tagsql.Rows) error {
 		for rows.Next() {
 			var (
-				version   Version
-				status    ObjectStatus
-				retention Retention
+				version Version
+				status  ObjectStatus
+				info    objectLockInfo
 			)
-			err := rows.Scan(&version, &status, retentionModeWrapper{&retention.Mode}, timeWrapper{&retention.RetainUntil})
+			err := rows.Scan(&version, &status, retentionModeWrapper{&info.Retention.Mode}, timeWrapper{&info.Retention.RetainUntil}, &info.LegalHold)
 			if err != nil {
 				return errs.Wrap(err)
 			}
@@ -924,9 +945,9 @@ func (ptx *postgresTransactionAdapter) precommitDeleteUnversionedWithNonPendingU
 					logMultipleCommittedVersionsError(ptx.postgresAdapter.log, loc)
 					return errs.New(multipleCommittedVersionsErrMsg)
 				}
-				objectToDelete = &versionAndRetention{
-					version:   version,
-					retention: retention,
+				objectToDelete = &versionAndLock{
+					version:        version,
+					objectLockInfo: info,
 				}
 			}
 		}
@@ -942,11 +963,8 @@ func (ptx *postgresTransactionAdapter) precommitDeleteUnversionedWithNonPendingU
 		return result, nil
 	}
 
-	if err = objectToDelete.retention.Verify(); err != nil {
-		return PrecommitConstraintWithNonPendingResult{}, Error.Wrap(err)
-	}
-	if objectToDelete.retention.Active() {
-		return PrecommitConstraintWithNonPendingResult{}, ErrObjectLock.New(objectLockedErrMsg)
+	if err = objectToDelete.verifyDeletion(); err != nil {
+		return PrecommitConstraintWithNonPendingResult{}, err
 	}
 
 	deleted := Object{
@@ -958,6 +976,13 @@ func (ptx *postgresTransactionAdapter) precommitDeleteUnversionedWithNonPendingU
 		},
 	}
 
+	args := []any{loc.ProjectID, loc.BucketName, loc.ObjectKey, objectToDelete.version}
+	var condition, deferred string
+	if deferSegmentsAbove > 0 {
+		condition, deferred = postgresDeferSegmentsQuery("$5", "$1")
+		args = append(args, deferSegmentsAbove)
+	}
+
 	err = ptx.tx.QueryRowContext(ctx, `
 		WITH deleted_objects AS (
 			DELETE FROM objects
@@ -973,12 +998,14 @@ func (ptx *postgresTransactionAdapter) precommitDeleteUnversionedWithNonPendingU
 				retention_mode, retain_until
 		), deleted_segments AS (
 			DELETE FROM segments
-			WHERE segments.stream_id IN (SELECT deleted_objects.stream_id FROM deleted_objects)
+			WHERE segments.stream_id IN (
+				SELECT deleted_objects.stream_id FROM deleted_objects`+condition+`
+			)
 			RETURNING segments.stream_id
-		)
+		)`+deferred+`
 		SELECT *, (SELECT count(*) FROM deleted_segments)
 		FROM deleted_objects
-		`, loc.ProjectID, loc.BucketName, loc.ObjectKey, objectToDelete.version,
+		`, args...,
 	).Scan(
 		&deleted.StreamID,
 		&deleted.CreatedAt,
@@ -1025,12 +1052,12 @@ func (stx *spannerTransactionAdapter) PrecommitDeleteUnversionedWithNonPending(c
 	}
 
 	if opts.UseObjectLock {
-		return stx.precommitDeleteUnversionedWithNonPendingUsingObjectLock(ctx, opts.ObjectLocation)
+		return stx.precommitDeleteUnversionedWithNonPendingUsingObjectLock(ctx, opts.ObjectLocation, opts.deferSegmentsAbove)
 	}
-	return stx.precommitDeleteUnversionedWithNonPending(ctx, opts.ObjectLocation)
+	return stx.precommitDeleteUnversionedWithNonPending(ctx, opts.ObjectLocation, opts.deferSegmentsAbove)
 }
 
-func (stx *spannerTransactionAdapter) precommitDeleteUnversionedWithNonPending(ctx context.Context, loc ObjectLocation) (result PrecommitConstraintWithNonPendingResult, err error) {
+func (stx *spannerTransactionAdapter) precommitDeleteUnversionedWithNonPending(ctx context.Context, loc ObjectLocation, deferSegmentsAbove int) (result PrecommitConstraintWithNonPendingResult, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	result, err = spannerutil.CollectRow(stx.tx.Query(ctx, spanner.Statement{
@@ -1084,9 +1111,9 @@ func (stx *spannerTransactionAdapter) precommitDeleteUnversionedWithNonPending(c
 		return PrecommitConstraintWithNonPendingResult{}, Error.Wrap(err)
 	}
 
-	streamIDs := make([][]byte, 0, len(result.Deleted))
-	for _, object := range result.Deleted {
-		streamIDs = append(streamIDs, object.StreamID.Bytes())
+	streamIDs, err := deferSegmentDeletionsSpanner(stx.tx, loc.ProjectID, result.Deleted, deferSegmentsAbove)
+	if err != nil {
+		return PrecommitConstraintWithNonPendingResult{}, Error.Wrap(err)
 	}
 
 	// TODO(spanner): make sure this is an efficient query
@@ -1124,22 +1151,22 @@ func (stx *spannerTransactionAdapter) precommitDeleteUnversionedWithNonPending(c
 	return result, nil
 }
 
-func (stx *spannerTransactionAdapter) precommitDeleteUnversionedWithNonPendingUsingObjectLock(ctx context.Context, loc ObjectLocation) (result PrecommitConstraintWithNonPendingResult, err error) {
+func (stx *spannerTransactionAdapter) precommitDeleteUnversionedWithNonPendingUsingObjectLock(ctx context.Context, loc ObjectLocation, deferSegmentsAbove int) (result PrecommitConstraintWithNonPendingResult, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	type versionAndRetention struct {
-		version   Version
-		retention Retention
+	type versionAndLock struct {
+		version Version
+		objectLockInfo
 	}
 
 	var (
 		highestVersionScanned, highestNonPendingVersionScanned bool
-		objectToDelete                                         *versionAndRetention
+		objectToDelete                                         *versionAndLock
 	)
 
 	err = stx.tx.Query(ctx, spanner.Statement{
 		SQL: `
-			SELECT version, status, retention_mode, retain_until
+			SELECT version, status, retention_mode, retain_until, legal_hold
 			FROM objects
 			WHERE (project_id, bucket_name, object_key) = (@project_id, @bucket_name, @object_key)
 			ORDER BY version DESC
@@ -1151,11 +1178,11 @@ func (stx *spannerTransactionAdapter) precommitDeleteUnversionedWithNonPendingUs
 		},
 	}).Do(func(row *spanner.Row) error {
 		var (
-			version   Version
-			status    ObjectStatus
-			retention Retention
+			version Version
+			status  ObjectStatus
+			info    objectLockInfo
 		)
-		err := row.Columns(&version, &status, retentionModeWrapper{&retention.Mode}, timeWrapper{&retention.RetainUntil})
+		err := row.Columns(&version, &status, retentionModeWrapper{&info.Retention.Mode}, timeWrapper{&info.Retention.RetainUntil}, &info.LegalHold)
 		if err != nil {
 			return errs.Wrap(err)
 		}
@@ -1175,9 +1202,9 @@ func (stx *spannerTransactionAdapter) precommitDeleteUnversionedWithNonPendingUs
 				logMultipleCommittedVersionsError(stx.spannerAdapter.log, loc)
 				return errs.New(multipleCommittedVersionsErrMsg)
 			}
-			objectToDelete = &versionAndRetention{
-				version:   version,
-				retention: retention,
+			objectToDelete = &versionAndLock{
+				version:        version,
+				objectLockInfo: info,
 			}
 		}
 
@@ -1190,11 +1217,8 @@ func (stx *spannerTransactionAdapter) precommitDeleteUnversionedWithNonPendingUs
 		return result, nil
 	}
 
-	if err = objectToDelete.retention.Verify(); err != nil {
-		return PrecommitConstraintWithNonPendingResult{}, Error.Wrap(err)
-	}
-	if objectToDelete.retention.Active() {
-		return PrecommitConstraintWithNonPendingResult{}, ErrObjectLock.New(objectLockedErrMsg)
+	if err = objectToDelete.verifyDeletion(); err != nil {
+		return PrecommitConstraintWithNonPendingResult{}, err
 	}
 
 	// TODO(spanner): is there a better way to combine these deletes from different tables?
@@ -1232,14 +1256,19 @@ func (stx *spannerTransactionAdapter) precommitDeleteUnversionedWithNonPendingUs
 		return result, nil
 	}
 
+	streamIDs, err := deferSegmentDeletionsSpanner(stx.tx, loc.ProjectID, result.Deleted, deferSegmentsAbove)
+	if err != nil {
+		return PrecommitConstraintWithNonPendingResult{}, Error.Wrap(err)
+	}
+
 	// TODO(spanner): make sure this is an efficient query
 	segmentDeletion := spanner.Statement{
 		SQL: `
 			DELETE FROM segments
-			WHERE stream_id = @stream_id
+			WHERE ARRAY_INCLUDES(@stream_ids, stream_id)
 		`,
 		Params: map[string]interface{}{
-			"stream_id": result.Deleted[0].StreamID,
+			"stream_ids": streamIDs,
 		},
 	}
 	segmentsDeleted, err := stx.tx.Update(ctx, segmentDeletion)
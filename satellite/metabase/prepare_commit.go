@@ -0,0 +1,343 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/zeebo/errs"
+	"google.golang.org/api/iterator"
+
+	"storj.io/storj/shared/dbutil/spannerutil"
+)
+
+// DefaultPreparedCommitTTL is how long a prepared commit can be confirmed when no TTL is given.
+const DefaultPreparedCommitTTL = time.Hour
+
+// ErrPreparedCommitInvalid is returned when a prepared commit can't be confirmed.
+var ErrPreparedCommitInvalid = errs.Class("metabase: prepared commit invalid")
+
+// PrepareCommitObject contains arguments necessary for preparing the commit of a pending object.
+type PrepareCommitObject struct {
+	ObjectStream
+
+	// TTL is how long the prepared commit can be confirmed.
+	TTL time.Duration
+
+	// Quota is the optional quota of the bucket the object will be committed to.
+	Quota BucketQuota
+}
+
+// Verify verifies request fields.
+func (p *PrepareCommitObject) Verify() error {
+	if err := p.ObjectStream.Verify(); err != nil {
+		return err
+	}
+	if p.TTL < 0 {
+		return ErrInvalidRequest.New("TTL is negative")
+	}
+	return nil
+}
+
+// PreparedCommit describes the pending object whose commit was prepared. It must
+// be passed back unchanged to ConfirmCommitObject.
+type PreparedCommit struct {
+	// Deadline is the time until the commit can be confirmed. It's stored with
+	// the pending object and also used as its zombie deletion deadline, so an
+	// unconfirmed commit is cleaned up like any other abandoned upload.
+	Deadline time.Time
+
+	SegmentCount       int32
+	TotalEncryptedSize int64
+}
+
+// ConfirmCommitObject contains arguments necessary for confirming a prepared commit.
+type ConfirmCommitObject struct {
+	CommitObject
+
+	PreparedCommit PreparedCommit
+}
+
+type prepareCommitTransactionAdapter interface {
+	setPreparedCommitDeadline(ctx context.Context, stream ObjectStream, deadline time.Time) (err error)
+	clearPreparedCommitDeadline(ctx context.Context, stream ObjectStream, deadline time.Time) (cleared bool, err error)
+	preparedCommitDeadline(ctx context.Context, stream ObjectStream) (deadline *time.Time, err error)
+}
+
+// PrepareCommitObject prepares the commit of a pending object for coordinating it with
+// an external transactional system. The object stays pending and invisible until
+// the commit is confirmed with ConfirmCommitObject before the returned deadline,
+// CommitObject fails for an object with a prepared commit.
+//
+// The segments of the object are verified the same way as when committing, hence
+// the confirmation fails only when the object is modified or the deadline passes.
+func (db *DB) PrepareCommitObject(ctx context.Context, opts PrepareCommitObject) (prepared PreparedCommit, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return PreparedCommit{}, err
+	}
+
+	ttl := opts.TTL
+	if ttl == 0 {
+		ttl = DefaultPreparedCommitTTL
+	}
+	// the deadline identifies the preparation, it's truncated to the precision
+	// of the databases so it can be compared when confirming.
+	deadline := time.Now().Add(ttl).Truncate(time.Microsecond)
+
+	err = db.ChooseAdapter(opts.ProjectID).WithTx(ctx, func(ctx context.Context, adapter TransactionAdapter) error {
+		segments, err := adapter.fetchSegmentsForCommit(ctx, opts.StreamID)
+		if err != nil {
+			return Error.New("failed to fetch segments: %w", err)
+		}
+
+		if err = db.validateParts(segments); err != nil {
+			return err
+		}
+
+		// the objects replaced by the commit aren't known yet, so the check is stricter
+		// than the one done when confirming.
+		err = db.checkBucketQuota(ctx, opts.Location().Bucket(), opts.Quota, 1, int64(len(segments)))
+		if err != nil {
+			return err
+		}
+
+		if err := adapter.setPreparedCommitDeadline(ctx, opts.ObjectStream, deadline); err != nil {
+			return err
+		}
+
+		prepared = PreparedCommit{
+			Deadline:     deadline,
+			SegmentCount: int32(len(segments)),
+		}
+		for _, segment := range segments {
+			prepared.TotalEncryptedSize += int64(segment.EncryptedSize)
+		}
+		return nil
+	})
+	if err != nil {
+		return PreparedCommit{}, err
+	}
+
+	mon.Meter("object_commit_prepare").Mark(1)
+
+	return prepared, nil
+}
+
+// ConfirmCommitObject commits the pending object whose commit was prepared with
+// PrepareCommitObject. It fails when the deadline of the prepared commit passed
+// or the segments of the object changed since it was prepared.
+func (db *DB) ConfirmCommitObject(ctx context.Context, opts ConfirmCommitObject) (object Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	object, err = db.commitObject(ctx, opts.CommitObject, &opts.PreparedCommit)
+	if err != nil {
+		return Object{}, err
+	}
+
+	mon.Meter("object_commit_confirm").Mark(1)
+
+	return object, nil
+}
+
+// checkPreparedCommit verifies that the pending object is in the state it was
+// prepared in and that the prepared commit didn't expire. It clears the prepared
+// commit deadline of the object, so the object can be finalized.
+func (db *DB) checkPreparedCommit(ctx context.Context, adapter TransactionAdapter, stream ObjectStream, prepared PreparedCommit, segments []segmentInfoForCommit) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !time.Now().Before(prepared.Deadline) {
+		mon.Meter("object_commit_prepare_expired").Mark(1)
+		return ErrPreparedCommitInvalid.New("prepared commit expired")
+	}
+
+	// clearing the deadline allows finalizing the commit, it's rolled back
+	// together with the transaction when the commit fails.
+	cleared, err := adapter.clearPreparedCommitDeadline(ctx, stream, prepared.Deadline)
+	if err != nil {
+		return err
+	}
+	if !cleared {
+		return ErrPreparedCommitInvalid.New("object commit isn't prepared")
+	}
+
+	var totalEncryptedSize int64
+	for _, segment := range segments {
+		totalEncryptedSize += int64(segment.EncryptedSize)
+	}
+	if int32(len(segments)) != prepared.SegmentCount || totalEncryptedSize != prepared.TotalEncryptedSize {
+		return ErrPreparedCommitInvalid.New("object segments changed since the commit was prepared")
+	}
+	return nil
+}
+
+func (ptx *postgresTransactionAdapter) setPreparedCommitDeadline(ctx context.Context, stream ObjectStream, deadline time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := ptx.tx.ExecContext(ctx, `
+		UPDATE objects SET
+			zombie_deletion_deadline = $6,
+			prepared_commit_deadline = $6
+		WHERE
+			(project_id, bucket_name, object_key, version, stream_id) = ($1, $2, $3, $4, $5)
+			AND status = `+statusPending+`
+	`, stream.ProjectID, stream.BucketName, stream.ObjectKey, stream.Version, stream.StreamID, deadline)
+	if err != nil {
+		return Error.New("unable to update object: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Error.New("unable to update object: %w", err)
+	}
+	if affected == 0 {
+		return ErrPendingObjectMissing.New("")
+	}
+	return nil
+}
+
+func (stx *spannerTransactionAdapter) setPreparedCommitDeadline(ctx context.Context, stream ObjectStream, deadline time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	affected, err := stx.tx.Update(ctx, spanner.Statement{
+		SQL: `
+			UPDATE objects SET
+				zombie_deletion_deadline = @deadline,
+				prepared_commit_deadline = @deadline
+			WHERE
+				project_id      = @project_id
+				AND bucket_name = @bucket_name
+				AND object_key  = @object_key
+				AND version     = @version
+				AND stream_id   = @stream_id
+				AND status      = ` + statusPending + `
+		`,
+		Params: map[string]interface{}{
+			"project_id":  stream.ProjectID,
+			"bucket_name": stream.BucketName,
+			"object_key":  stream.ObjectKey,
+			"version":     stream.Version,
+			"stream_id":   stream.StreamID,
+			"deadline":    deadline,
+		},
+	})
+	if err != nil {
+		return Error.New("unable to update object: %w", err)
+	}
+	if affected == 0 {
+		return ErrPendingObjectMissing.New("")
+	}
+	return nil
+}
+
+func (ptx *postgresTransactionAdapter) clearPreparedCommitDeadline(ctx context.Context, stream ObjectStream, deadline time.Time) (cleared bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := ptx.tx.ExecContext(ctx, `
+		UPDATE objects SET
+			prepared_commit_deadline = NULL
+		WHERE
+			(project_id, bucket_name, object_key, version, stream_id) = ($1, $2, $3, $4, $5)
+			AND status = `+statusPending+`
+			AND prepared_commit_deadline = $6
+	`, stream.ProjectID, stream.BucketName, stream.ObjectKey, stream.Version, stream.StreamID, deadline)
+	if err != nil {
+		return false, Error.New("unable to update object: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, Error.New("unable to update object: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func (stx *spannerTransactionAdapter) clearPreparedCommitDeadline(ctx context.Context, stream ObjectStream, deadline time.Time) (cleared bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	affected, err := stx.tx.Update(ctx, spanner.Statement{
+		SQL: `
+			UPDATE objects SET
+				prepared_commit_deadline = NULL
+			WHERE
+				project_id      = @project_id
+				AND bucket_name = @bucket_name
+				AND object_key  = @object_key
+				AND version     = @version
+				AND stream_id   = @stream_id
+				AND status      = ` + statusPending + `
+				AND prepared_commit_deadline = @deadline
+		`,
+		Params: map[string]interface{}{
+			"project_id":  stream.ProjectID,
+			"bucket_name": stream.BucketName,
+			"object_key":  stream.ObjectKey,
+			"version":     stream.Version,
+			"stream_id":   stream.StreamID,
+			"deadline":    deadline,
+		},
+	})
+	if err != nil {
+		return false, Error.New("unable to update object: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func (ptx *postgresTransactionAdapter) preparedCommitDeadline(ctx context.Context, stream ObjectStream) (deadline *time.Time, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = ptx.tx.QueryRowContext(ctx, `
+		SELECT prepared_commit_deadline
+		FROM objects
+		WHERE
+			(project_id, bucket_name, object_key, version, stream_id) = ($1, $2, $3, $4, $5)
+			AND status = `+statusPending+`
+	`, stream.ProjectID, stream.BucketName, stream.ObjectKey, stream.Version, stream.StreamID).Scan(&deadline)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPendingObjectMissing.New("")
+		}
+		return nil, Error.New("unable to query object: %w", err)
+	}
+	return deadline, nil
+}
+
+func (stx *spannerTransactionAdapter) preparedCommitDeadline(ctx context.Context, stream ObjectStream) (deadline *time.Time, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	deadline, err = spannerutil.CollectRow(stx.tx.Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT prepared_commit_deadline
+			FROM objects
+			WHERE
+				project_id      = @project_id
+				AND bucket_name = @bucket_name
+				AND object_key  = @object_key
+				AND version     = @version
+				AND stream_id   = @stream_id
+				AND status      = ` + statusPending + `
+		`,
+		Params: map[string]interface{}{
+			"project_id":  stream.ProjectID,
+			"bucket_name": stream.BucketName,
+			"object_key":  stream.ObjectKey,
+			"version":     stream.Version,
+			"stream_id":   stream.StreamID,
+		},
+	}), func(row *spanner.Row, item **time.Time) error {
+		return row.Columns(item)
+	})
+	if err != nil {
+		if errors.Is(err, iterator.Done) {
+			return nil, ErrPendingObjectMissing.New("")
+		}
+		return nil, Error.New("unable to query object: %w", err)
+	}
+	return deadline, nil
+}
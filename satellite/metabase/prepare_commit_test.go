@@ -0,0 +1,174 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestPrepareCommitObject(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		t.Run("confirm", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			metabasetest.CreatePendingObject(ctx, t, db, obj, 2)
+
+			prepared, err := db.PrepareCommitObject(ctx, metabase.PrepareCommitObject{
+				ObjectStream: obj,
+				TTL:          time.Hour,
+			})
+			require.NoError(t, err)
+			require.EqualValues(t, 2, prepared.SegmentCount)
+			require.WithinDuration(t, time.Now().Add(time.Hour), prepared.Deadline, time.Minute)
+
+			// the prepared object isn't visible until confirmed.
+			_, err = db.GetObjectLastCommitted(ctx, metabase.GetObjectLastCommitted{
+				ObjectLocation: obj.Location(),
+			})
+			require.True(t, metabase.ErrObjectNotFound.Has(err), err)
+
+			object, err := db.ConfirmCommitObject(ctx, metabase.ConfirmCommitObject{
+				CommitObject: metabase.CommitObject{
+					ObjectStream: obj,
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+				PreparedCommit: prepared,
+			})
+			require.NoError(t, err)
+			require.EqualValues(t, 2, object.SegmentCount)
+			require.Nil(t, object.ZombieDeletionDeadline)
+
+			_, err = db.GetObjectLastCommitted(ctx, metabase.GetObjectLastCommitted{
+				ObjectLocation: obj.Location(),
+			})
+			require.NoError(t, err)
+		})
+
+		t.Run("commit without confirmation", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			metabasetest.CreatePendingObject(ctx, t, db, obj, 1)
+
+			prepared, err := db.PrepareCommitObject(ctx, metabase.PrepareCommitObject{
+				ObjectStream: obj,
+			})
+			require.NoError(t, err)
+
+			_, err = db.CommitObject(ctx, metabase.CommitObject{
+				ObjectStream: obj,
+				Encryption:   metabasetest.DefaultEncryption,
+			})
+			require.True(t, metabase.ErrPreparedCommitInvalid.Has(err), err)
+
+			_, err = db.GetObjectLastCommitted(ctx, metabase.GetObjectLastCommitted{
+				ObjectLocation: obj.Location(),
+			})
+			require.True(t, metabase.ErrObjectNotFound.Has(err), err)
+
+			// the prepared commit can still be confirmed.
+			_, err = db.ConfirmCommitObject(ctx, metabase.ConfirmCommitObject{
+				CommitObject: metabase.CommitObject{
+					ObjectStream: obj,
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+				PreparedCommit: prepared,
+			})
+			require.NoError(t, err)
+		})
+
+		t.Run("not prepared", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			metabasetest.CreatePendingObject(ctx, t, db, obj, 1)
+
+			_, err := db.ConfirmCommitObject(ctx, metabase.ConfirmCommitObject{
+				CommitObject: metabase.CommitObject{
+					ObjectStream: obj,
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+				PreparedCommit: metabase.PreparedCommit{
+					Deadline:     time.Now().Add(time.Hour),
+					SegmentCount: 1,
+				},
+			})
+			require.True(t, metabase.ErrPreparedCommitInvalid.Has(err), err)
+		})
+
+		t.Run("expired", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			metabasetest.CreatePendingObject(ctx, t, db, obj, 1)
+
+			prepared, err := db.PrepareCommitObject(ctx, metabase.PrepareCommitObject{
+				ObjectStream: obj,
+				TTL:          time.Microsecond,
+			})
+			require.NoError(t, err)
+
+			time.Sleep(time.Millisecond)
+
+			_, err = db.ConfirmCommitObject(ctx, metabase.ConfirmCommitObject{
+				CommitObject: metabase.CommitObject{
+					ObjectStream: obj,
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+				PreparedCommit: prepared,
+			})
+			require.True(t, metabase.ErrPreparedCommitInvalid.Has(err), err)
+
+			// the abandoned upload is deleted as a zombie object.
+			err = db.DeleteZombieObjects(ctx, metabase.DeleteZombieObjects{
+				DeadlineBefore:   time.Now(),
+				InactiveDeadline: time.Now(),
+				BatchSize:        10,
+			})
+			require.NoError(t, err)
+
+			metabasetest.Verify{}.Check(ctx, t, db)
+		})
+
+		t.Run("segments changed", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			metabasetest.CreatePendingObject(ctx, t, db, obj, 1)
+
+			prepared, err := db.PrepareCommitObject(ctx, metabase.PrepareCommitObject{
+				ObjectStream: obj,
+			})
+			require.NoError(t, err)
+
+			prepared.TotalEncryptedSize++
+
+			_, err = db.ConfirmCommitObject(ctx, metabase.ConfirmCommitObject{
+				CommitObject: metabase.CommitObject{
+					ObjectStream: obj,
+					Encryption:   metabasetest.DefaultEncryption,
+				},
+				PreparedCommit: prepared,
+			})
+			require.True(t, metabase.ErrPreparedCommitInvalid.Has(err), err)
+		})
+
+		t.Run("missing pending object", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			_, err := db.PrepareCommitObject(ctx, metabase.PrepareCommitObject{
+				ObjectStream: metabasetest.RandObjectStream(),
+			})
+			require.True(t, metabase.ErrPendingObjectMissing.Has(err), err)
+		})
+	})
+}
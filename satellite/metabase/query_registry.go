@@ -0,0 +1,219 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+
+	"storj.io/common/debug"
+	"storj.io/common/uuid"
+)
+
+// RunningQuery describes an in-flight metabase operation.
+type RunningQuery struct {
+	ID        uint64
+	Operation string
+	ProjectID uuid.UUID
+	StartedAt time.Time
+	Elapsed   time.Duration
+	// Cancelled indicates whether the operation was cancelled, but didn't return yet.
+	Cancelled bool
+}
+
+// QueryRegistry tracks the in-flight metabase operations, so that the ones
+// running longer than a threshold can be inspected and cancelled.
+//
+// Every operation has its own state, so tracking operations doesn't serialize them.
+type QueryRegistry struct {
+	threshold time.Duration
+	now       func() time.Time
+
+	nextID  atomic.Uint64
+	queries sync.Map // uint64 -> *trackedQuery
+}
+
+type trackedQuery struct {
+	operation string
+	projectID uuid.UUID
+	startedAt time.Time
+	cancel    context.CancelFunc
+	cancelled atomic.Bool
+}
+
+// newQueryRegistry returns a new query registry, or nil when tracking is disabled.
+func newQueryRegistry(threshold time.Duration) *QueryRegistry {
+	if threshold <= 0 {
+		return nil
+	}
+	return &QueryRegistry{
+		threshold: threshold,
+		now:       time.Now,
+	}
+}
+
+// Threshold returns the duration after which an operation is considered long-running.
+func (registry *QueryRegistry) Threshold() time.Duration {
+	if registry == nil {
+		return 0
+	}
+	return registry.threshold
+}
+
+// track registers an operation until done is called. The returned context is
+// cancelled when the operation is cancelled through the registry.
+func (registry *QueryRegistry) track(ctx context.Context, operation string, projectID uuid.UUID) (_ context.Context, done func()) {
+	if registry == nil {
+		return ctx, func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	query := &trackedQuery{
+		operation: operation,
+		projectID: projectID,
+		startedAt: registry.now(),
+		cancel:    cancel,
+	}
+
+	id := registry.nextID.Add(1)
+	registry.queries.Store(id, query)
+
+	return ctx, func() {
+		registry.queries.Delete(id)
+		cancel()
+
+		if registry.now().Sub(query.startedAt) >= registry.threshold {
+			mon.Event("metabase_long_running_query", monkit.NewSeriesTag("operation", operation))
+		}
+	}
+}
+
+// LongRunning returns the in-flight operations running longer than the
+// threshold, the longest running first.
+func (registry *QueryRegistry) LongRunning() []RunningQuery {
+	if registry == nil {
+		return nil
+	}
+
+	now := registry.now()
+
+	var running []RunningQuery
+	registry.queries.Range(func(key, value any) bool {
+		query := value.(*trackedQuery)
+		elapsed := now.Sub(query.startedAt)
+		if elapsed < registry.threshold {
+			return true
+		}
+		running = append(running, RunningQuery{
+			ID:        key.(uint64),
+			Operation: query.operation,
+			ProjectID: query.projectID,
+			StartedAt: query.startedAt,
+			Elapsed:   elapsed,
+			Cancelled: query.cancelled.Load(),
+		})
+		return true
+	})
+
+	sort.Slice(running, func(i, j int) bool {
+		if running[i].Elapsed == running[j].Elapsed {
+			return running[i].ID < running[j].ID
+		}
+		return running[i].Elapsed > running[j].Elapsed
+	})
+	return running
+}
+
+// Cancel cancels the in-flight operation with the specified ID. It returns
+// false when the operation isn't running anymore or was already cancelled.
+func (registry *QueryRegistry) Cancel(id uint64) bool {
+	if registry == nil {
+		return false
+	}
+
+	value, ok := registry.queries.Load(id)
+	if !ok {
+		return false
+	}
+
+	query := value.(*trackedQuery)
+	if !query.cancelled.CompareAndSwap(false, true) {
+		return false
+	}
+	query.cancel()
+	mon.Event("metabase_query_cancelled", monkit.NewSeriesTag("operation", query.operation))
+	return true
+}
+
+// CancelLongest cancels the longest running operation, which wasn't cancelled
+// yet, and returns it. It returns false when there is no such operation.
+func (registry *QueryRegistry) CancelLongest() (_ RunningQuery, ok bool) {
+	for _, query := range registry.LongRunning() {
+		if !query.Cancelled && registry.Cancel(query.ID) {
+			query.Cancelled = true
+			return query, true
+		}
+	}
+	return RunningQuery{}, false
+}
+
+// CancelLongRunning cancels all the in-flight operations running longer than
+// the threshold and returns how many were cancelled.
+func (registry *QueryRegistry) CancelLongRunning() (cancelled int) {
+	for _, query := range registry.LongRunning() {
+		if registry.Cancel(query.ID) {
+			cancelled++
+		}
+	}
+	return cancelled
+}
+
+// DebugButtons returns the debug control panel buttons for listing and
+// cancelling the long-running operations.
+func (registry *QueryRegistry) DebugButtons() *debug.ButtonGroup {
+	return &debug.ButtonGroup{
+		Name: "Long-running Metabase Queries",
+		Buttons: []*debug.Button{
+			{
+				Name: "List",
+				Call: func(w io.Writer) error {
+					running := registry.LongRunning()
+					_, _ = fmt.Fprintf(w, "%d operations running longer than %s\n", len(running), registry.Threshold())
+					for _, query := range running {
+						status := "running"
+						if query.Cancelled {
+							status = "cancelled"
+						}
+						_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", query.ID, query.Operation, query.ProjectID, query.Elapsed.Round(time.Millisecond), status)
+					}
+					return nil
+				},
+			}, {
+				Name: "Cancel Longest",
+				Call: func(w io.Writer) error {
+					query, ok := registry.CancelLongest()
+					if !ok {
+						_, _ = fmt.Fprintln(w, "No operation to cancel")
+						return nil
+					}
+					_, _ = fmt.Fprintf(w, "Cancelled %d\t%s\t%s\t%s\n", query.ID, query.Operation, query.ProjectID, query.Elapsed.Round(time.Millisecond))
+					return nil
+				},
+			}, {
+				Name: "Cancel All",
+				Call: func(w io.Writer) error {
+					_, _ = fmt.Fprintf(w, "Cancelled %d operations\n", registry.CancelLongRunning())
+					return nil
+				},
+			},
+		},
+	}
+}
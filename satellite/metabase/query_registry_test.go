@@ -0,0 +1,90 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestQueryRegistry(t *testing.T) {
+	metabasetest.RunWithConfig(t, metabase.Config{
+		ApplicationName:    "metabase-tests",
+		LongQueryThreshold: time.Nanosecond,
+	}, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+		registry := db.QueryRegistry()
+		require.NotNil(t, registry)
+		require.Empty(t, registry.LongRunning())
+
+		object := metabasetest.CreateObject(ctx, t, db, metabasetest.RandObjectStream(), 0)
+
+		err := db.IterateObjectsAllVersionsWithStatus(ctx, metabase.IterateObjectsWithStatus{
+			ProjectID:  object.ProjectID,
+			BucketName: object.BucketName,
+			Recursive:  true,
+		}, func(ctx context.Context, it metabase.ObjectsIterator) error {
+			time.Sleep(time.Millisecond)
+
+			running := registry.LongRunning()
+			require.Len(t, running, 1)
+			require.Equal(t, "iterate-objects-all-versions", running[0].Operation)
+			require.Equal(t, object.ProjectID, running[0].ProjectID)
+			require.Positive(t, running[0].Elapsed)
+
+			require.False(t, running[0].Cancelled)
+
+			require.True(t, registry.Cancel(running[0].ID))
+			require.Error(t, ctx.Err())
+
+			// the cancelled operation is listed until it returns, but can't be cancelled again.
+			running = registry.LongRunning()
+			require.Len(t, running, 1)
+			require.True(t, running[0].Cancelled)
+			require.False(t, registry.Cancel(running[0].ID))
+			_, ok := registry.CancelLongest()
+			require.False(t, ok)
+
+			return ctx.Err()
+		})
+		require.True(t, errors.Is(err, context.Canceled))
+
+		require.Empty(t, registry.LongRunning())
+		require.False(t, registry.Cancel(1))
+		require.Zero(t, registry.CancelLongRunning())
+
+		// the longest running operation is cancelled alone.
+		err = db.IterateObjectsAllVersionsWithStatus(ctx, metabase.IterateObjectsWithStatus{
+			ProjectID:  object.ProjectID,
+			BucketName: object.BucketName,
+			Recursive:  true,
+		}, func(ctx context.Context, it metabase.ObjectsIterator) error {
+			time.Sleep(time.Millisecond)
+
+			query, ok := registry.CancelLongest()
+			require.True(t, ok)
+			require.Equal(t, "iterate-objects-all-versions", query.Operation)
+			require.True(t, query.Cancelled)
+			return ctx.Err()
+		})
+		require.True(t, errors.Is(err, context.Canceled))
+	})
+
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		// tracking is disabled by default.
+		registry := db.QueryRegistry()
+		require.Nil(t, registry)
+		require.Empty(t, registry.LongRunning())
+		require.Zero(t, registry.CancelLongRunning())
+	})
+}
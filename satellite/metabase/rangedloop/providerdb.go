@@ -17,6 +17,7 @@ type MetabaseRangeSplitter struct {
 
 	asOfSystemInterval time.Duration
 	batchSize          int
+	filter             metabase.LoopSegmentsFilter
 }
 
 // MetabaseSegmentProvider implements SegmentProvider.
@@ -27,6 +28,7 @@ type MetabaseSegmentProvider struct {
 	asOfSystemTime     time.Time
 	asOfSystemInterval time.Duration
 	batchSize          int
+	filter             metabase.LoopSegmentsFilter
 }
 
 // NewMetabaseRangeSplitter creates the segment provider.
@@ -38,6 +40,15 @@ func NewMetabaseRangeSplitter(db *metabase.DB, asOfSystemInterval time.Duration,
 	}
 }
 
+// NewMetabaseRangeSplitterWithFilter creates the segment provider, which only
+// returns the segments matching the filter. It allows observers interested in
+// a subset of segments to run a targeted loop.
+func NewMetabaseRangeSplitterWithFilter(db *metabase.DB, asOfSystemInterval time.Duration, batchSize int, filter metabase.LoopSegmentsFilter) *MetabaseRangeSplitter {
+	splitter := NewMetabaseRangeSplitter(db, asOfSystemInterval, batchSize)
+	splitter.filter = filter
+	return splitter
+}
+
 // CreateRanges splits the segment table into chunks.
 func (provider *MetabaseRangeSplitter) CreateRanges(nRanges int, batchSize int) ([]SegmentProvider, error) {
 	uuidRanges, err := CreateUUIDRanges(uint32(nRanges))
@@ -55,6 +66,7 @@ func (provider *MetabaseRangeSplitter) CreateRanges(nRanges int, batchSize int)
 			asOfSystemTime:     asOfSystemTime,
 			asOfSystemInterval: provider.asOfSystemInterval,
 			batchSize:          batchSize,
+			filter:             provider.filter,
 		})
 	}
 
@@ -84,6 +96,7 @@ func (provider *MetabaseSegmentProvider) Iterate(ctx context.Context, fn func([]
 		AsOfSystemInterval: provider.asOfSystemInterval,
 		StartStreamID:      startStreamID,
 		EndStreamID:        endStreamID,
+		Filter:             provider.filter,
 	}, func(ctx context.Context, iterator metabase.LoopSegmentsIterator) error {
 		segments := make([]Segment, 0, provider.batchSize)
 
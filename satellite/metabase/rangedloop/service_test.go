@@ -418,6 +418,7 @@ func TestAllInOne(t *testing.T) {
 			checker.NewObserver(
 				log.Named("repair:checker"),
 				satellite.DB.RepairQueue(),
+				satellite.Metabase.DB,
 				satellite.Overlay.Service,
 				nodeselection.TestPlacementDefinitions(),
 				satellite.Config.Checker,
@@ -48,6 +48,8 @@ type RawObject struct {
 	ZombieDeletionDeadline *time.Time
 
 	Retention Retention
+
+	Checksum Checksum
 }
 
 // RawSegment defines the full segment that is stored in the database. It should be rarely used directly.
@@ -76,6 +78,8 @@ type RawSegment struct {
 	Pieces     Pieces
 
 	Placement storj.PlacementConstraint
+
+	Checksum Checksum
 }
 
 // RawCopy contains a copy that is stored in the database.
@@ -145,6 +149,10 @@ func (p *PostgresAdapter) TestingDeleteAll(ctx context.Context) (err error) {
 		WITH ignore_full_scan_for_test AS (SELECT 1) DELETE FROM objects;
 		WITH ignore_full_scan_for_test AS (SELECT 1) DELETE FROM segments;
 		WITH ignore_full_scan_for_test AS (SELECT 1) DELETE FROM node_aliases;
+		WITH ignore_full_scan_for_test AS (SELECT 1) DELETE FROM deferred_segment_deletions;
+		WITH ignore_full_scan_for_test AS (SELECT 1) DELETE FROM bucket_stats;
+		WITH ignore_full_scan_for_test AS (SELECT 1) DELETE FROM metadata_rotation_cursors;
+		WITH ignore_full_scan_for_test AS (SELECT 1) DELETE FROM object_delete_events;
 		WITH ignore_full_scan_for_test AS (SELECT 1) SELECT setval('node_alias_seq', 1, false);
 	`)
 	return Error.Wrap(err)
@@ -156,6 +164,10 @@ func (s *SpannerAdapter) TestingDeleteAll(ctx context.Context) (err error) {
 		spanner.Delete("objects", spanner.AllKeys()),
 		spanner.Delete("segments", spanner.AllKeys()),
 		spanner.Delete("node_aliases", spanner.AllKeys()),
+		spanner.Delete("deferred_segment_deletions", spanner.AllKeys()),
+		spanner.Delete("bucket_stats", spanner.AllKeys()),
+		spanner.Delete("metadata_rotation_cursors", spanner.AllKeys()),
+		spanner.Delete("object_delete_events", spanner.AllKeys()),
 	})
 	return Error.Wrap(err)
 }
@@ -174,7 +186,8 @@ func (p *PostgresAdapter) TestingGetAllObjects(ctx context.Context) (_ []RawObje
 			total_plain_size, total_encrypted_size, fixed_segment_size,
 			encryption,
 			zombie_deletion_deadline,
-			retention_mode, retain_until
+			retention_mode, retain_until,
+			checksum
 		FROM objects
 		ORDER BY project_id ASC, bucket_name ASC, object_key ASC, version ASC
 	`)
@@ -209,6 +222,7 @@ func (p *PostgresAdapter) TestingGetAllObjects(ctx context.Context) (_ []RawObje
 			&obj.ZombieDeletionDeadline,
 			retentionModeWrapper{&obj.Retention.Mode},
 			timeWrapper{&obj.Retention.RetainUntil},
+			&obj.Checksum,
 		)
 		if err != nil {
 			return nil, Error.New("testingGetAllObjects scan failed: %w", err)
@@ -242,7 +256,8 @@ func (s *SpannerAdapter) TestingGetAllObjects(ctx context.Context) (_ []RawObjec
 				total_plain_size, total_encrypted_size, fixed_segment_size,
 				encryption,
 				zombie_deletion_deadline,
-				retention_mode, retain_until
+				retention_mode, retain_until,
+				checksum
 			FROM objects
 			ORDER BY project_id ASC, bucket_name ASC, object_key ASC, version ASC
 		`,
@@ -272,6 +287,7 @@ func (s *SpannerAdapter) TestingGetAllObjects(ctx context.Context) (_ []RawObjec
 			&obj.ZombieDeletionDeadline,
 			retentionModeWrapper{&obj.Retention.Mode},
 			timeWrapper{&obj.Retention.RetainUntil},
+			&obj.Checksum,
 		)
 		if err != nil {
 			return Error.Wrap(err)
@@ -411,6 +427,8 @@ func (ctr *copyFromRawObjects) Columns() []string {
 
 		"encryption",
 		"zombie_deletion_deadline",
+
+		"checksum",
 	}
 }
 
@@ -439,6 +457,8 @@ func (ctr *copyFromRawObjects) Values() ([]any, error) {
 
 		encryptionParameters{&obj.Encryption},
 		obj.ZombieDeletionDeadline,
+
+		obj.Checksum,
 	}, nil
 }
 
@@ -459,7 +479,8 @@ func (p *PostgresAdapter) TestingGetAllSegments(ctx context.Context, aliasCache
 			encrypted_etag,
 			redundancy,
 			inline_data, remote_alias_pieces,
-			placement
+			placement,
+			checksum
 		FROM segments
 		ORDER BY stream_id ASC, position ASC
 	`)
@@ -493,6 +514,7 @@ func (p *PostgresAdapter) TestingGetAllSegments(ctx context.Context, aliasCache
 			&seg.InlineData,
 			&aliasPieces,
 			&seg.Placement,
+			&seg.Checksum,
 		)
 		if err != nil {
 			return nil, Error.New("testingGetAllSegments scan failed: %w", err)
@@ -526,7 +548,8 @@ func (s *SpannerAdapter) TestingGetAllSegments(ctx context.Context, aliasCache *
 			encrypted_etag,
 			redundancy,
 			inline_data, remote_alias_pieces,
-			placement
+			placement,
+			checksum
 		FROM segments
 		ORDER BY stream_id ASC, position ASC
 	`}), func(row *spanner.Row, segment *RawSegment) error {
@@ -541,6 +564,7 @@ func (s *SpannerAdapter) TestingGetAllSegments(ctx context.Context, aliasCache *
 			redundancyScheme{&segment.Redundancy},
 			&segment.InlineData, &aliasPieces,
 			&segment.Placement,
+			&segment.Checksum,
 		)
 		if err != nil {
 			return Error.Wrap(err)
@@ -558,11 +582,11 @@ func (s *SpannerAdapter) TestingGetAllSegments(ctx context.Context, aliasCache *
 // TestingBatchInsertSegments batch inserts segments for testing.
 // This implementation does no verification on the correctness of segments.
 func (db *DB) TestingBatchInsertSegments(ctx context.Context, segments []RawSegment) (err error) {
-	return db.ChooseAdapter(uuid.UUID{}).TestingBatchInsertSegments(ctx, db.aliasCache, segments)
+	return db.ChooseAdapter(uuid.UUID{}).ImportSegments(ctx, db.aliasCache, segments)
 }
 
-// TestingBatchInsertSegments implements postgres adapter.
-func (p *PostgresAdapter) TestingBatchInsertSegments(ctx context.Context, aliasCache *NodeAliasCache, segments []RawSegment) (err error) {
+// ImportSegments implements postgres adapter.
+func (p *PostgresAdapter) ImportSegments(ctx context.Context, aliasCache *NodeAliasCache, segments []RawSegment) (err error) {
 	const maxRowsPerCopy = 250000
 
 	minLength := len(segments)
@@ -623,6 +647,8 @@ var rawSegmentColumns = []string{
 	"inline_data",
 	"remote_alias_pieces",
 	"placement",
+
+	"checksum",
 }
 
 type copyFromRawSegments struct {
@@ -678,14 +704,16 @@ func (ctr *copyFromRawSegments) Values() ([]any, error) {
 		obj.InlineData,
 		aliasPieces,
 		obj.Placement,
+
+		obj.Checksum,
 	)
 	return ctr.row, nil
 }
 
 func (ctr *copyFromRawSegments) Err() error { return nil }
 
-// TestingBatchInsertSegments implements SpannerAdapter.
-func (s *SpannerAdapter) TestingBatchInsertSegments(ctx context.Context, aliasCache *NodeAliasCache, segments []RawSegment) (err error) {
+// ImportSegments implements SpannerAdapter.
+func (s *SpannerAdapter) ImportSegments(ctx context.Context, aliasCache *NodeAliasCache, segments []RawSegment) (err error) {
 	mutations := make([]*spanner.Mutation, len(segments))
 	for i, segment := range segments {
 		aliasPieces, err := aliasCache.EnsurePiecesToAliases(ctx, segment.Pieces)
@@ -715,6 +743,8 @@ func (s *SpannerAdapter) TestingBatchInsertSegments(ctx context.Context, aliasCa
 			segment.InlineData,
 			aliasPieces,
 			int64(segment.Placement),
+
+			segment.Checksum,
 		)
 
 		mutations[i] = spanner.InsertOrUpdate("segments", rawSegmentColumns, vals)
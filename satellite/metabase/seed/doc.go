@@ -0,0 +1,14 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+/*
+Package seed generates realistic metabase datasets for load testing.
+
+The objects and segments are inserted directly with bulk inserts, so the
+performance of deletion, listing and repair can be tested on large datasets
+without uploading the data through the whole stack. The generated segments
+point to made up storage nodes and can't be downloaded.
+
+The generation is deterministic for the same configuration and random seed.
+*/
+package seed
@@ -0,0 +1,386 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package seed
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the error class for this package.
+	Error = errs.Class("metabase seed")
+)
+
+// Config contains the parameters of the generated dataset.
+type Config struct {
+	Projects          int
+	BucketsPerProject int
+	ObjectsPerBucket  int
+
+	// MaxVersions is the maximum number of versions of an object. The number
+	// of versions is chosen uniformly between 1 and MaxVersions, objects
+	// with more than one version are versioned.
+	MaxVersions int
+	// DeleteMarkerRatio is the ratio of versioned objects whose latest
+	// version is a delete marker.
+	DeleteMarkerRatio float64
+	// PendingRatio is the ratio of objects which are pending uploads.
+	PendingRatio float64
+	// ExpiringRatio is the ratio of objects with an expiration time, half
+	// of them already expired.
+	ExpiringRatio float64
+
+	// Object sizes are log-uniformly distributed between MinObjectSize and
+	// MaxObjectSize, which resembles the real world where small objects are
+	// much more common than large ones.
+	MinObjectSize memory.Size
+	MaxObjectSize memory.Size
+
+	SegmentSize     memory.Size
+	InlineThreshold memory.Size
+	Redundancy      storj.RedundancyScheme
+
+	// Nodes is the number of distinct storage nodes the pieces are placed on.
+	Nodes int
+
+	// BatchSize is the number of objects inserted at once.
+	BatchSize int
+	// Seed is the seed of the random generator.
+	Seed int64
+}
+
+// Verify verifies the config fields.
+func (config *Config) Verify() error {
+	switch {
+	case config.Projects <= 0:
+		return Error.New("Projects should be positive")
+	case config.BucketsPerProject <= 0:
+		return Error.New("BucketsPerProject should be positive")
+	case config.ObjectsPerBucket <= 0:
+		return Error.New("ObjectsPerBucket should be positive")
+	case config.MaxVersions <= 0:
+		return Error.New("MaxVersions should be positive")
+	case config.MinObjectSize <= 0 || config.MaxObjectSize < config.MinObjectSize:
+		return Error.New("invalid object size range %s - %s", config.MinObjectSize, config.MaxObjectSize)
+	case config.SegmentSize <= 0 || config.SegmentSize > math.MaxInt32:
+		return Error.New("invalid segment size %s", config.SegmentSize)
+	case config.InlineThreshold < 0 || config.InlineThreshold > config.SegmentSize:
+		return Error.New("invalid inline threshold %s", config.InlineThreshold)
+	case config.Redundancy.RequiredShares <= 0 ||
+		config.Redundancy.OptimalShares < config.Redundancy.RequiredShares ||
+		config.Redundancy.TotalShares < config.Redundancy.OptimalShares:
+		return Error.New("invalid redundancy %v", config.Redundancy)
+	case config.Nodes < int(config.Redundancy.TotalShares):
+		return Error.New("Nodes should be at least the total shares %d", config.Redundancy.TotalShares)
+	case config.BatchSize <= 0:
+		return Error.New("BatchSize should be positive")
+	}
+
+	for _, ratio := range []float64{config.DeleteMarkerRatio, config.PendingRatio, config.ExpiringRatio} {
+		if ratio < 0 || ratio > 1 {
+			return Error.New("ratios should be between 0 and 1")
+		}
+	}
+	return nil
+}
+
+// Stats contains the statistics of the generated dataset.
+type Stats struct {
+	Projects int64
+	Buckets  int64
+	Objects  int64
+	Segments int64
+	Bytes    int64
+}
+
+// Buckets returns the buckets of the generated dataset. The buckets themselves
+// aren't created, as they're stored in the satellite database.
+func Buckets(config Config) []metabase.BucketLocation {
+	rng := rand.New(rand.NewSource(config.Seed))
+
+	var buckets []metabase.BucketLocation
+	for p := 0; p < config.Projects; p++ {
+		projectID := randUUID(rng)
+		for b := 0; b < config.BucketsPerProject; b++ {
+			buckets = append(buckets, metabase.BucketLocation{
+				ProjectID:  projectID,
+				BucketName: metabase.BucketName(fmt.Sprintf("seed-bucket-%d", b)),
+			})
+		}
+	}
+	return buckets
+}
+
+// Seed generates the dataset described by the config and inserts it into the metabase.
+func Seed(ctx context.Context, log *zap.Logger, db *metabase.DB, config Config) (stats Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := config.Verify(); err != nil {
+		return Stats{}, err
+	}
+
+	g := &generator{
+		config: config,
+		// the buckets are derived from the same seed, so they're generated
+		// separately from the objects.
+		buckets: Buckets(config),
+		rng:     rand.New(rand.NewSource(config.Seed + 1)),
+		now:     time.Now(),
+	}
+	g.nodes = make([]storj.NodeID, config.Nodes)
+	for i := range g.nodes {
+		g.nodes[i] = randNodeID(g.rng)
+	}
+
+	stats.Projects = int64(config.Projects)
+	stats.Buckets = int64(len(g.buckets))
+
+	var objects []metabase.RawObject
+	var segments []metabase.RawSegment
+	flush := func() error {
+		if err := db.TestingBatchInsertObjects(ctx, objects); err != nil {
+			return Error.Wrap(err)
+		}
+		if err := db.TestingBatchInsertSegments(ctx, segments); err != nil {
+			return Error.Wrap(err)
+		}
+
+		stats.Objects += int64(len(objects))
+		stats.Segments += int64(len(segments))
+		log.Info("seeded", zap.Int64("objects", stats.Objects), zap.Int64("segments", stats.Segments))
+
+		objects, segments = objects[:0], segments[:0]
+		return nil
+	}
+
+	for _, bucket := range g.buckets {
+		for i := 0; i < config.ObjectsPerBucket; i++ {
+			objectKey := g.objectKey(i)
+			for _, object := range g.versions(bucket, objectKey) {
+				objects = append(objects, object)
+
+				segments = append(segments, g.segments(object)...)
+				stats.Bytes += object.TotalEncryptedSize
+			}
+
+			if len(objects) >= config.BatchSize {
+				if err := flush(); err != nil {
+					return stats, err
+				}
+			}
+		}
+	}
+
+	if len(objects) > 0 {
+		if err := flush(); err != nil {
+			return stats, err
+		}
+	}
+
+	return stats, nil
+}
+
+// generator generates the objects and segments of the dataset.
+type generator struct {
+	config  Config
+	buckets []metabase.BucketLocation
+	nodes   []storj.NodeID
+	rng     *rand.Rand
+	now     time.Time
+}
+
+// objectKey returns a key, which resembles a file path, for the i-th object of a bucket.
+func (g *generator) objectKey(i int) metabase.ObjectKey {
+	return metabase.ObjectKey(fmt.Sprintf("dir-%d/subdir-%d/object-%d", g.rng.Intn(16), g.rng.Intn(256), i))
+}
+
+// versions returns all versions of an object.
+func (g *generator) versions(bucket metabase.BucketLocation, objectKey metabase.ObjectKey) []metabase.RawObject {
+	count := 1 + g.rng.Intn(g.config.MaxVersions)
+	pending := g.rng.Float64() < g.config.PendingRatio
+	deleteMarker := count > 1 && g.rng.Float64() < g.config.DeleteMarkerRatio
+
+	var expiresAt *time.Time
+	if g.rng.Float64() < g.config.ExpiringRatio {
+		// half of the expiring objects are already expired.
+		t := g.now.Add(time.Duration(g.rng.Int63n(int64(60*24*time.Hour))) - 30*24*time.Hour)
+		expiresAt = &t
+	}
+
+	// objects were created during the last 90 days, versions are newer than the previous ones.
+	createdAt := g.now.Add(-time.Duration(g.rng.Int63n(int64(90 * 24 * time.Hour))))
+
+	versions := make([]metabase.RawObject, 0, count)
+	for v := 1; v <= count; v++ {
+		object := metabase.RawObject{
+			ObjectStream: metabase.ObjectStream{
+				ProjectID:  bucket.ProjectID,
+				BucketName: bucket.BucketName,
+				ObjectKey:  objectKey,
+				Version:    metabase.Version(v),
+				StreamID:   randUUID(g.rng),
+			},
+			CreatedAt: createdAt,
+			ExpiresAt: expiresAt,
+			Status:    metabase.CommittedUnversioned,
+
+			Encryption: storj.EncryptionParameters{
+				CipherSuite: storj.EncAESGCM,
+				BlockSize:   29 * 256,
+			},
+		}
+		if count > 1 {
+			object.Status = metabase.CommittedVersioned
+		}
+
+		last := v == count
+		switch {
+		case last && pending:
+			object.Status = metabase.Pending
+			deadline := createdAt.Add(24 * time.Hour)
+			object.ZombieDeletionDeadline = &deadline
+		case last && deleteMarker:
+			object.Status = metabase.DeleteMarkerVersioned
+		default:
+			g.fillCommitted(&object)
+		}
+
+		versions = append(versions, object)
+		createdAt = createdAt.Add(time.Duration(g.rng.Int63n(int64(time.Hour))))
+	}
+
+	return versions
+}
+
+// fillCommitted fills the size and metadata of a committed object.
+func (g *generator) fillCommitted(object *metabase.RawObject) {
+	minSize, maxSize := math.Log(float64(g.config.MinObjectSize)), math.Log(float64(g.config.MaxObjectSize))
+	size := int64(math.Exp(minSize + g.rng.Float64()*(maxSize-minSize)))
+
+	segmentSize := g.config.SegmentSize.Int64()
+	object.SegmentCount = int32((size + segmentSize - 1) / segmentSize)
+	object.TotalPlainSize = size
+	object.TotalEncryptedSize = size
+	object.FixedSegmentSize = int32(segmentSize)
+	if object.SegmentCount == 1 {
+		object.FixedSegmentSize = int32(size)
+	}
+
+	object.EncryptedMetadataNonce = randBytes(g.rng, 24)
+	object.EncryptedMetadata = randBytes(g.rng, 32+g.rng.Intn(224))
+	object.EncryptedMetadataEncryptedKey = randBytes(g.rng, 48)
+}
+
+// segments returns the segments of an object.
+func (g *generator) segments(object metabase.RawObject) []metabase.RawSegment {
+	segments := make([]metabase.RawSegment, 0, object.SegmentCount)
+
+	var offset int64
+	for i := int32(0); i < object.SegmentCount; i++ {
+		size := object.TotalPlainSize - offset
+		if size > g.config.SegmentSize.Int64() {
+			size = g.config.SegmentSize.Int64()
+		}
+
+		segment := metabase.RawSegment{
+			StreamID:  object.StreamID,
+			Position:  metabase.SegmentPosition{Index: uint32(i)},
+			CreatedAt: object.CreatedAt,
+			ExpiresAt: object.ExpiresAt,
+
+			EncryptedKeyNonce: randBytes(g.rng, 24),
+			EncryptedKey:      randBytes(g.rng, 48),
+
+			EncryptedSize: int32(size),
+			PlainSize:     int32(size),
+			PlainOffset:   offset,
+			EncryptedETag: randBytes(g.rng, 32),
+		}
+
+		if size <= g.config.InlineThreshold.Int64() {
+			segment.InlineData = randBytes(g.rng, int(size))
+		} else {
+			segment.RootPieceID = randPieceID(g.rng)
+			segment.Redundancy = g.config.Redundancy
+			segment.Pieces = g.pieces()
+		}
+
+		segments = append(segments, segment)
+		offset += size
+	}
+
+	return segments
+}
+
+// pieces returns the pieces of a remote segment placed on distinct nodes.
+// Some segments have less than the optimal number of pieces, so the repair
+// has something to do.
+func (g *generator) pieces() metabase.Pieces {
+	count := int(g.config.Redundancy.OptimalShares)
+	if g.rng.Intn(100) == 0 {
+		count = int(g.config.Redundancy.RequiredShares) + g.rng.Intn(count-int(g.config.Redundancy.RequiredShares)+1)
+	}
+
+	numbers := g.rng.Perm(int(g.config.Redundancy.TotalShares))[:count]
+
+	used := make(map[int]struct{}, count)
+	pieces := make(metabase.Pieces, count)
+	for i := range pieces {
+		node := g.rng.Intn(len(g.nodes))
+		for {
+			if _, ok := used[node]; !ok {
+				break
+			}
+			node = g.rng.Intn(len(g.nodes))
+		}
+		used[node] = struct{}{}
+
+		pieces[i] = metabase.Piece{
+			Number:      uint16(numbers[i]),
+			StorageNode: g.nodes[node],
+		}
+	}
+	sort.Sort(pieces)
+	return pieces
+}
+
+func randBytes(rng *rand.Rand, n int) []byte {
+	data := make([]byte, n)
+	_, _ = rng.Read(data)
+	return data
+}
+
+func randUUID(rng *rand.Rand) (id uuid.UUID) {
+	_, _ = rng.Read(id[:])
+	// mark it as a version 4 UUID.
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+	return id
+}
+
+func randNodeID(rng *rand.Rand) (id storj.NodeID) {
+	_, _ = rng.Read(id[:])
+	return id
+}
+
+func randPieceID(rng *rand.Rand) (id storj.PieceID) {
+	_, _ = rng.Read(id[:])
+	return id
+}
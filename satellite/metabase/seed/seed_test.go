@@ -0,0 +1,86 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package seed_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+	"storj.io/storj/satellite/metabase/seed"
+)
+
+func TestSeed(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		config := seed.Config{
+			Projects:          2,
+			BucketsPerProject: 2,
+			ObjectsPerBucket:  10,
+
+			MaxVersions:       3,
+			DeleteMarkerRatio: 0.2,
+			PendingRatio:      0.1,
+			ExpiringRatio:     0.1,
+
+			MinObjectSize:   memory.KiB,
+			MaxObjectSize:   200 * memory.MiB,
+			SegmentSize:     64 * memory.MiB,
+			InlineThreshold: 4 * memory.KiB,
+			Redundancy: storj.RedundancyScheme{
+				Algorithm:      storj.ReedSolomon,
+				ShareSize:      256,
+				RequiredShares: 2,
+				RepairShares:   3,
+				OptimalShares:  4,
+				TotalShares:    5,
+			},
+			Nodes: 10,
+
+			BatchSize: 7,
+			Seed:      1,
+		}
+
+		t.Run("invalid", func(t *testing.T) {
+			invalid := config
+			invalid.Nodes = 4
+			_, err := seed.Seed(ctx, zaptest.NewLogger(t), db, invalid)
+			require.Error(t, err)
+		})
+
+		stats, err := seed.Seed(ctx, zaptest.NewLogger(t), db, config)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, stats.Projects)
+		require.EqualValues(t, 4, stats.Buckets)
+		require.GreaterOrEqual(t, stats.Objects, int64(40))
+
+		objects, err := db.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, int(stats.Objects))
+
+		segments, err := db.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, int(stats.Segments))
+
+		buckets := map[metabase.BucketLocation]struct{}{}
+		for _, bucket := range seed.Buckets(config) {
+			buckets[bucket] = struct{}{}
+		}
+		for _, object := range objects {
+			require.Contains(t, buckets, object.Location().Bucket())
+		}
+		for _, segment := range segments {
+			if segment.Inline() {
+				continue
+			}
+			require.NoError(t, segment.Pieces.Verify())
+			require.GreaterOrEqual(t, len(segment.Pieces), int(config.Redundancy.RequiredShares))
+		}
+	})
+}
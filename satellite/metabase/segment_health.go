@@ -0,0 +1,209 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"cloud.google.com/go/spanner"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/shared/dbutil/spannerutil"
+	"storj.io/storj/shared/tagsql"
+)
+
+// ListLowHealthSegmentsLimit is the maximum number of items the client can request for listing.
+const ListLowHealthSegmentsLimit = intLimitRange(10000)
+
+// UpdateSegmentHealthyPieces contains arguments necessary for updating
+// the denormalized healthy piece count of a segment.
+type UpdateSegmentHealthyPieces struct {
+	StreamID uuid.UUID
+	Position SegmentPosition
+
+	// HealthyPieces is the new healthy piece count, nil marks it as unknown.
+	HealthyPieces *int
+}
+
+// Verify verifies update segment healthy pieces request fields.
+func (opts *UpdateSegmentHealthyPieces) Verify() error {
+	if opts.StreamID.IsZero() {
+		return ErrInvalidRequest.New("StreamID missing")
+	}
+	if opts.HealthyPieces != nil && (*opts.HealthyPieces < 0 || *opts.HealthyPieces > math.MaxInt16) {
+		return ErrInvalidRequest.New("HealthyPieces is out of range: %d", *opts.HealthyPieces)
+	}
+	return nil
+}
+
+// UpdateSegmentHealthyPieces updates the healthy piece count of the specified segment.
+//
+// The value is only a hint for prioritizing the checker. It's cleared whenever
+// the segment pieces are updated and it's maintained by the checker, hence it may
+// be stale until the next checker iteration, e.g. after a node is disqualified.
+func (db *DB) UpdateSegmentHealthyPieces(ctx context.Context, opts UpdateSegmentHealthyPieces) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return err
+	}
+
+	for _, adapter := range db.adapters {
+		err = adapter.UpdateSegmentHealthyPieces(ctx, opts)
+		if err != nil {
+			if ErrSegmentNotFound.Has(err) {
+				continue
+			}
+			return err
+		}
+		return nil
+	}
+	return ErrSegmentNotFound.New("segment missing")
+}
+
+// UpdateSegmentHealthyPieces implements Adapter.
+func (p *PostgresAdapter) UpdateSegmentHealthyPieces(ctx context.Context, opts UpdateSegmentHealthyPieces) (err error) {
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE segments SET healthy_pieces = $3
+		WHERE stream_id = $1 AND position = $2
+	`, opts.StreamID, opts.Position, opts.HealthyPieces)
+	if err != nil {
+		return Error.New("unable to update segment healthy pieces: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Error.New("unable to update segment healthy pieces: %w", err)
+	}
+	if affected == 0 {
+		return ErrSegmentNotFound.New("segment missing")
+	}
+	return nil
+}
+
+// UpdateSegmentHealthyPieces implements Adapter.
+func (s *SpannerAdapter) UpdateSegmentHealthyPieces(ctx context.Context, opts UpdateSegmentHealthyPieces) (err error) {
+	var healthyPieces spanner.NullInt64
+	if opts.HealthyPieces != nil {
+		healthyPieces = spanner.NullInt64{Int64: int64(*opts.HealthyPieces), Valid: true}
+	}
+
+	var affected int64
+	_, err = s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		affected, err = tx.Update(ctx, spanner.Statement{
+			SQL: `
+				UPDATE segments SET healthy_pieces = @healthy_pieces
+				WHERE stream_id = @stream_id AND position = @position
+			`,
+			Params: map[string]any{
+				"stream_id":      opts.StreamID,
+				"position":       opts.Position,
+				"healthy_pieces": healthyPieces,
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return Error.New("unable to update segment healthy pieces: %w", err)
+	}
+	if affected == 0 {
+		return ErrSegmentNotFound.New("segment missing")
+	}
+	return nil
+}
+
+// ListLowHealthSegments contains arguments necessary for listing segments
+// with a low healthy piece count.
+type ListLowHealthSegments struct {
+	// MaxHealthyPieces is the inclusive upper bound of the healthy piece count.
+	MaxHealthyPieces int
+	Limit            int
+}
+
+// LowHealthSegment is a segment returned by ListLowHealthSegments.
+type LowHealthSegment struct {
+	StreamID uuid.UUID
+	Position SegmentPosition
+
+	HealthyPieces int
+}
+
+// ListLowHealthSegments lists segments which have at most the specified healthy
+// piece count, ordered by the count. Segments where the count is unknown are not listed.
+func (db *DB) ListLowHealthSegments(ctx context.Context, opts ListLowHealthSegments) (segments []LowHealthSegment, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if opts.Limit <= 0 {
+		return nil, ErrInvalidRequest.New("invalid limit: %d", opts.Limit)
+	}
+	ListLowHealthSegmentsLimit.Ensure(&opts.Limit)
+
+	for _, adapter := range db.adapters {
+		adapterSegments, err := adapter.ListLowHealthSegments(ctx, opts)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		segments = append(segments, adapterSegments...)
+	}
+
+	if len(db.adapters) > 1 {
+		sort.SliceStable(segments, func(i, k int) bool {
+			return segments[i].HealthyPieces < segments[k].HealthyPieces
+		})
+		if len(segments) > opts.Limit {
+			segments = segments[:opts.Limit]
+		}
+	}
+	return segments, nil
+}
+
+// ListLowHealthSegments implements Adapter.
+func (p *PostgresAdapter) ListLowHealthSegments(ctx context.Context, opts ListLowHealthSegments) (segments []LowHealthSegment, err error) {
+	err = withRows(p.db.QueryContext(ctx, `
+		SELECT stream_id, position, healthy_pieces
+		FROM segments
+		WHERE healthy_pieces IS NOT NULL AND healthy_pieces <= $1
+		ORDER BY healthy_pieces ASC
+		LIMIT $2
+	`, opts.MaxHealthyPieces, opts.Limit))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var segment LowHealthSegment
+			if err := rows.Scan(&segment.StreamID, &segment.Position, &segment.HealthyPieces); err != nil {
+				return Error.Wrap(err)
+			}
+			segments = append(segments, segment)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return segments, nil
+}
+
+// ListLowHealthSegments implements Adapter.
+func (s *SpannerAdapter) ListLowHealthSegments(ctx context.Context, opts ListLowHealthSegments) (segments []LowHealthSegment, err error) {
+	return spannerutil.CollectRows(s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT stream_id, position, healthy_pieces
+			FROM segments
+			WHERE healthy_pieces IS NOT NULL AND healthy_pieces <= @max_healthy_pieces
+			ORDER BY healthy_pieces ASC
+			LIMIT @limit
+		`,
+		Params: map[string]any{
+			"max_healthy_pieces": int64(opts.MaxHealthyPieces),
+			"limit":              int64(opts.Limit),
+		},
+	}), func(row *spanner.Row, segment *LowHealthSegment) error {
+		var healthyPieces int64
+		if err := row.Columns(&segment.StreamID, &segment.Position, &healthyPieces); err != nil {
+			return Error.Wrap(err)
+		}
+		segment.HealthyPieces = int(healthyPieces)
+		return nil
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestSegmentHealthyPieces(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		t.Run("StreamID missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			err := db.UpdateSegmentHealthyPieces(ctx, metabase.UpdateSegmentHealthyPieces{})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+		})
+
+		t.Run("HealthyPieces out of range", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			for _, healthy := range []int{-1, math.MaxInt16 + 1} {
+				err := db.UpdateSegmentHealthyPieces(ctx, metabase.UpdateSegmentHealthyPieces{
+					StreamID:      obj.StreamID,
+					HealthyPieces: intPtr(healthy),
+				})
+				require.True(t, metabase.ErrInvalidRequest.Has(err))
+			}
+		})
+
+		t.Run("segment missing", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			err := db.UpdateSegmentHealthyPieces(ctx, metabase.UpdateSegmentHealthyPieces{
+				StreamID:      obj.StreamID,
+				HealthyPieces: intPtr(1),
+			})
+			require.True(t, metabase.ErrSegmentNotFound.Has(err))
+		})
+
+		t.Run("invalid limit", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			_, err := db.ListLowHealthSegments(ctx, metabase.ListLowHealthSegments{})
+			require.True(t, metabase.ErrInvalidRequest.Has(err))
+		})
+
+		t.Run("list by healthy pieces", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			metabasetest.CreateObject(ctx, t, db, obj, 3)
+
+			// segments without a known count are not listed
+			segments, err := db.ListLowHealthSegments(ctx, metabase.ListLowHealthSegments{
+				MaxHealthyPieces: 100,
+				Limit:            10,
+			})
+			require.NoError(t, err)
+			require.Empty(t, segments)
+
+			for index, healthy := range []int{10, 5, 20} {
+				require.NoError(t, db.UpdateSegmentHealthyPieces(ctx, metabase.UpdateSegmentHealthyPieces{
+					StreamID:      obj.StreamID,
+					Position:      metabase.SegmentPosition{Index: uint32(index)},
+					HealthyPieces: intPtr(healthy),
+				}))
+			}
+
+			segments, err = db.ListLowHealthSegments(ctx, metabase.ListLowHealthSegments{
+				MaxHealthyPieces: 10,
+				Limit:            10,
+			})
+			require.NoError(t, err)
+			require.Equal(t, []metabase.LowHealthSegment{
+				{StreamID: obj.StreamID, Position: metabase.SegmentPosition{Index: 1}, HealthyPieces: 5},
+				{StreamID: obj.StreamID, Position: metabase.SegmentPosition{Index: 0}, HealthyPieces: 10},
+			}, segments)
+
+			segments, err = db.ListLowHealthSegments(ctx, metabase.ListLowHealthSegments{
+				MaxHealthyPieces: 100,
+				Limit:            1,
+			})
+			require.NoError(t, err)
+			require.Equal(t, []metabase.LowHealthSegment{
+				{StreamID: obj.StreamID, Position: metabase.SegmentPosition{Index: 1}, HealthyPieces: 5},
+			}, segments)
+		})
+
+		t.Run("cleared", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			metabasetest.CreateObject(ctx, t, db, obj, 2)
+
+			for index := 0; index < 2; index++ {
+				require.NoError(t, db.UpdateSegmentHealthyPieces(ctx, metabase.UpdateSegmentHealthyPieces{
+					StreamID:      obj.StreamID,
+					Position:      metabase.SegmentPosition{Index: uint32(index)},
+					HealthyPieces: intPtr(1),
+				}))
+			}
+
+			// explicitly marked as unknown
+			require.NoError(t, db.UpdateSegmentHealthyPieces(ctx, metabase.UpdateSegmentHealthyPieces{
+				StreamID: obj.StreamID,
+				Position: metabase.SegmentPosition{Index: 0},
+			}))
+
+			// updating the pieces makes the count unknown
+			segment, err := db.GetSegmentByPosition(ctx, metabase.GetSegmentByPosition{
+				StreamID: obj.StreamID,
+				Position: metabase.SegmentPosition{Index: 1},
+			})
+			require.NoError(t, err)
+			require.NoError(t, db.UpdateSegmentPieces(ctx, metabase.UpdateSegmentPieces{
+				StreamID:      segment.StreamID,
+				Position:      segment.Position,
+				OldPieces:     segment.Pieces,
+				NewRedundancy: segment.Redundancy,
+				NewPieces:     segment.Pieces,
+			}))
+
+			segments, err := db.ListLowHealthSegments(ctx, metabase.ListLowHealthSegments{
+				MaxHealthyPieces: 100,
+				Limit:            10,
+			})
+			require.NoError(t, err)
+			require.Empty(t, segments)
+		})
+	})
+}
+
+func intPtr(v int) *int { return &v }
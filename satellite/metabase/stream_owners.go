@@ -0,0 +1,148 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+)
+
+// ErrStreamIDInUse is returned when a new object would reuse the stream ID of another object.
+var ErrStreamIDInUse = errs.Class("metabase: stream ID in use")
+
+// VerifyStreamOwners contains arguments for verifying that the streams are referenced by exactly one object.
+//
+// A stream ID is generated for every new object, so the segments of a stream belong to a single object.
+// An object reusing the stream ID of another (e.g. deleted) object would be cross-linked with its segments,
+// and deleting either of the objects would delete the segments of both.
+type VerifyStreamOwners struct {
+	StreamIDs []uuid.UUID
+
+	BatchSize          int
+	AsOfSystemInterval time.Duration
+}
+
+// Verify verifies verify stream owners request fields.
+func (opts *VerifyStreamOwners) Verify() error {
+	if opts.BatchSize < 0 {
+		return ErrInvalidRequest.New("BatchSize is negative")
+	}
+	return nil
+}
+
+// StreamOwners contains the objects which reference a stream.
+type StreamOwners struct {
+	StreamID uuid.UUID
+	Objects  []ObjectStream
+}
+
+// VerifyStreamOwners returns the streams, which aren't referenced by exactly one object, with the objects
+// referencing them. The streams are returned in the order of opts.StreamIDs.
+//
+// The objects table isn't indexed by the stream ID, hence all objects are iterated. The streams should
+// be collected in advance, e.g. from the segments, and verified in a single call.
+func (db *DB) VerifyStreamOwners(ctx context.Context, opts VerifyStreamOwners) (violations []StreamOwners, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Verify(); err != nil {
+		return nil, err
+	}
+	if len(opts.StreamIDs) == 0 {
+		return nil, nil
+	}
+
+	loopIteratorBatchSizeLimit.Ensure(&opts.BatchSize)
+
+	owners := make(map[uuid.UUID][]ObjectStream, len(opts.StreamIDs))
+	for _, streamID := range opts.StreamIDs {
+		owners[streamID] = nil
+	}
+
+	listOpts := FindOrphanedStreams{
+		BatchSize:          opts.BatchSize,
+		AsOfSystemInterval: opts.AsOfSystemInterval,
+	}
+	for _, a := range db.adapters {
+		var startAfter ObjectStream
+		for {
+			streams, err := a.ListObjectStreams(ctx, listOpts, startAfter, opts.BatchSize)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, stream := range streams {
+				if objects, ok := owners[stream.StreamID]; ok {
+					owners[stream.StreamID] = append(objects, stream)
+				}
+			}
+
+			if len(streams) < opts.BatchSize {
+				break
+			}
+			startAfter = streams[len(streams)-1]
+		}
+	}
+
+	for _, streamID := range opts.StreamIDs {
+		objects, ok := owners[streamID]
+		if !ok {
+			// the stream ID was listed more than once.
+			continue
+		}
+		delete(owners, streamID)
+
+		if len(objects) != 1 {
+			mon.Meter("stream_owners_violation").Mark(1)
+			violations = append(violations, StreamOwners{
+				StreamID: streamID,
+				Objects:  objects,
+			})
+		}
+	}
+	return violations, nil
+}
+
+// StreamIDInUse checks whether the stream already has segments. It's checked before
+// beginning a new object, so a reused stream ID can't cross-link the new object with
+// the segments of another one.
+//
+// The objects table isn't indexed by the stream ID, hence the objects without segments
+// aren't checked. Such reuse is reported by VerifyStreamOwners.
+func (p *PostgresAdapter) StreamIDInUse(ctx context.Context, streamID uuid.UUID) (inUse bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = p.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM segments
+			WHERE stream_id = $1
+		)`, streamID).Scan(&inUse)
+	return inUse, Error.Wrap(err)
+}
+
+// StreamIDInUse checks whether the stream already has segments.
+func (s *SpannerAdapter) StreamIDInUse(ctx context.Context, streamID uuid.UUID) (inUse bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = s.client.Single().Query(ctx, spanner.Statement{
+		SQL: `
+			SELECT EXISTS (
+				SELECT 1
+				FROM segments
+				WHERE stream_id = @stream_id
+			)
+		`,
+		Params: map[string]interface{}{
+			"stream_id": streamID,
+		},
+	}).Do(func(row *spanner.Row) error {
+		return Error.Wrap(row.Columns(&inUse))
+	})
+	return inUse, Error.Wrap(err)
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestVerifyStreamOwners(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		valid := metabasetest.RandObjectStream()
+		metabasetest.CreateObject(ctx, t, db, valid, 1)
+
+		// an object reusing the stream ID of another object.
+		original := metabasetest.RandObjectStream()
+		reused := metabasetest.RandObjectStream()
+		reused.StreamID = original.StreamID
+
+		var objects []metabase.RawObject
+		for _, obj := range []metabase.ObjectStream{original, reused} {
+			objects = append(objects, metabase.RawObject{
+				ObjectStream: obj,
+				CreatedAt:    time.Now(),
+				Status:       metabase.CommittedUnversioned,
+				SegmentCount: 1,
+				Encryption:   metabasetest.DefaultEncryption,
+			})
+		}
+		require.NoError(t, db.TestingBatchInsertObjects(ctx, objects))
+		require.NoError(t, db.TestingBatchInsertSegments(ctx, []metabase.RawSegment{
+			metabasetest.DefaultRawSegment(original, metabase.SegmentPosition{}),
+		}))
+
+		orphan := testrand.UUID()
+
+		_, err := db.VerifyStreamOwners(ctx, metabase.VerifyStreamOwners{BatchSize: -1})
+		require.True(t, metabase.ErrInvalidRequest.Has(err))
+
+		violations, err := db.VerifyStreamOwners(ctx, metabase.VerifyStreamOwners{
+			StreamIDs: []uuid.UUID{valid.StreamID, original.StreamID, orphan},
+			BatchSize: 1,
+		})
+		require.NoError(t, err)
+		require.Len(t, violations, 2)
+
+		require.Equal(t, original.StreamID, violations[0].StreamID)
+		require.ElementsMatch(t, []metabase.ObjectStream{original, reused}, violations[0].Objects)
+
+		require.Equal(t, orphan, violations[1].StreamID)
+		require.Empty(t, violations[1].Objects)
+	})
+}
+
+func TestBeginObjectStreamIDInUse(t *testing.T) {
+	metabasetest.Run(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		t.Run("stream with segments", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			metabasetest.CreateObject(ctx, t, db, obj, 1)
+
+			reused := metabasetest.RandObjectStream()
+			reused.StreamID = obj.StreamID
+			reused.Version = metabase.NextVersion
+			_, err := db.BeginObjectNextVersion(ctx, metabase.BeginObjectNextVersion{
+				ObjectStream: reused,
+				Encryption:   metabasetest.DefaultEncryption,
+			})
+			require.True(t, metabase.ErrStreamIDInUse.Has(err), err)
+		})
+
+		t.Run("stream without segments", func(t *testing.T) {
+			defer metabasetest.DeleteAll{}.Check(ctx, t, db)
+
+			obj := metabasetest.RandObjectStream()
+			obj.Version = metabase.NextVersion
+			_, err := db.BeginObjectNextVersion(ctx, metabase.BeginObjectNextVersion{
+				ObjectStream: obj,
+				Encryption:   metabasetest.DefaultEncryption,
+			})
+			require.NoError(t, err)
+		})
+	})
+}
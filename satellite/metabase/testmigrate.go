@@ -21,7 +21,7 @@ func (p *PostgresAdapter) TestMigrateToLatest(ctx context.Context) error {
 			{
 				DB:          &p.db,
 				Description: "Test snapshot",
-				Version:     20,
+				Version:     31,
 				Action: migrate.SQL{
 					`CREATE TABLE objects (
 						project_id   BYTEA NOT NULL,
@@ -51,9 +51,21 @@ func (p *PostgresAdapter) TestMigrateToLatest(ctx context.Context) error {
 						retention_mode INT2,
 						retain_until   TIMESTAMPTZ,
 
+						tags BYTEA,
+
+						legal_hold BOOLEAN NOT NULL default false,
+
+						checksum BYTEA default NULL,
+
+						prepared_commit_deadline TIMESTAMPTZ default NULL,
+
+						encrypted_metadata_key_id INT4 NOT NULL default 0,
+
 						PRIMARY KEY (project_id, bucket_name, object_key, version)
 					);
 
+					CREATE INDEX objects_project_id_bucket_name_created_at_index ON objects (project_id, bucket_name, created_at);
+
 					COMMENT ON TABLE  objects             is 'Objects table contains information about path and streams.';
 					COMMENT ON COLUMN objects.project_id  is 'project_id is a uuid referring to project.id.';
 					COMMENT ON COLUMN objects.bucket_name is 'bucket_name is a alpha-numeric string referring to bucket_metainfo.name.';
@@ -82,6 +94,16 @@ func (p *PostgresAdapter) TestMigrateToLatest(ctx context.Context) error {
 					COMMENT ON COLUMN objects.retention_mode is 'retention_mode specifies an object version''s retention mode: NULL/0=none, and 1=compliance.';
 					COMMENT ON COLUMN objects.retain_until   is 'retain_until specifies when an object version''s retention period ends.';
 
+					COMMENT ON COLUMN objects.tags is 'tags contains the key-value tags of an object version, encoded as length-prefixed keys and values.';
+
+					COMMENT ON COLUMN objects.legal_hold is 'legal_hold specifies whether an object version is under an Object Lock legal hold.';
+
+					COMMENT ON COLUMN objects.checksum is 'checksum is the client provided checksum of the plain content, prefixed with the metabase.ChecksumAlgorithm.';
+
+					COMMENT ON COLUMN objects.prepared_commit_deadline is 'prepared_commit_deadline is the time until the prepared commit of a pending object can be confirmed. Pending objects with a prepared commit can not be committed without the confirmation.';
+
+					COMMENT ON COLUMN objects.encrypted_metadata_key_id is 'encrypted_metadata_key_id is the metadata encryption key encrypted_metadata is wrapped with, 0 when it is not wrapped.';
+
 					CREATE TABLE segments (
 						stream_id  BYTEA NOT NULL,
 						position   INT8  NOT NULL,
@@ -106,9 +128,15 @@ func (p *PostgresAdapter) TestMigrateToLatest(ctx context.Context) error {
 						placement integer,
 						encrypted_etag BYTEA default NULL,
 
+						healthy_pieces INT2,
+
+						checksum BYTEA default NULL,
+
 						PRIMARY KEY (stream_id, position)
 					);
 
+					CREATE INDEX segments_healthy_pieces_index ON segments (healthy_pieces) WHERE healthy_pieces IS NOT NULL;
+
 					COMMENT ON TABLE  segments            is 'segments table contains where segment data is located and other metadata about them.';
 					COMMENT ON COLUMN segments.stream_id  is 'stream_id is a uuid referring to segments that belong to the same object.';
 					COMMENT ON COLUMN segments.position   is 'position is a segment sequence number, determining the order they should be read in. It is represented as uint64, where the upper 32bits indicate the part-number and the lower 32bits indicate the index inside the part.';
@@ -133,6 +161,10 @@ func (p *PostgresAdapter) TestMigrateToLatest(ctx context.Context) error {
 					COMMENT ON COLUMN segments.placement is 'placement is the country or region restriction for the segment data. See storj.PlacementConstraint for the values.';
 					COMMENT ON COLUMN segments.encrypted_etag is 'encrypted_etag is etag that has been encrypted.';
 
+					COMMENT ON COLUMN segments.healthy_pieces is 'healthy_pieces is the number of healthy pieces when the segment was last checked. NULL means unknown.';
+
+					COMMENT ON COLUMN segments.checksum is 'checksum is the client provided checksum of the plain segment content, prefixed with the metabase.ChecksumAlgorithm.';
+
 					CREATE SEQUENCE node_alias_seq
 						INCREMENT BY 1
 						MINVALUE 1 MAXVALUE 2147483647 -- MaxInt32
@@ -144,7 +176,86 @@ func (p *PostgresAdapter) TestMigrateToLatest(ctx context.Context) error {
 
 					COMMENT ON TABLE  node_aliases            is 'node_aliases table contains unique identifiers (aliases) for storagenodes that take less space than a NodeID.';
 					COMMENT ON COLUMN node_aliases.node_id    is 'node_id refers to the storj.NodeID';
-					COMMENT ON COLUMN node_aliases.node_alias is 'node_alias is a unique integer value assigned for the node_id. It is used for compressing segments.remote_alias_pieces.';`,
+					COMMENT ON COLUMN node_aliases.node_alias is 'node_alias is a unique integer value assigned for the node_id. It is used for compressing segments.remote_alias_pieces.';
+
+					CREATE TABLE deferred_segment_deletions (
+						stream_id     BYTEA NOT NULL,
+						project_id    BYTEA NOT NULL,
+						segment_count INT4 NOT NULL default 0,
+						created_at    TIMESTAMPTZ NOT NULL default now(),
+						PRIMARY KEY (stream_id)
+					);
+
+					COMMENT ON TABLE  deferred_segment_deletions               is 'deferred_segment_deletions table contains the streams of deleted objects whose segments are yet to be deleted.';
+					COMMENT ON COLUMN deferred_segment_deletions.stream_id     is 'stream_id is the stream of the deleted object.';
+					COMMENT ON COLUMN deferred_segment_deletions.project_id    is 'project_id is the project the deleted object belonged to.';
+					COMMENT ON COLUMN deferred_segment_deletions.segment_count is 'segment_count is the number of segments of the deleted object.';
+					COMMENT ON COLUMN deferred_segment_deletions.created_at    is 'created_at is the time when the object was deleted.';
+
+					CREATE TABLE bucket_stats (
+						project_id           BYTEA NOT NULL,
+						bucket_name          BYTEA NOT NULL,
+						object_count         INT8 NOT NULL default 0,
+						pending_object_count INT8 NOT NULL default 0,
+						total_segments       INT8 NOT NULL default 0,
+						total_bytes          INT8 NOT NULL default 0,
+						metadata_size        INT8 NOT NULL default 0,
+						updated_at           TIMESTAMPTZ NOT NULL default now(),
+						PRIMARY KEY (project_id, bucket_name)
+					);
+
+					COMMENT ON TABLE  bucket_stats                      is 'bucket_stats table contains the object statistics of buckets, periodically refreshed from the objects table.';
+					COMMENT ON COLUMN bucket_stats.project_id           is 'project_id is the project the bucket belongs to.';
+					COMMENT ON COLUMN bucket_stats.bucket_name          is 'bucket_name is the name of the bucket.';
+					COMMENT ON COLUMN bucket_stats.object_count         is 'object_count is the number of objects, including the pending ones.';
+					COMMENT ON COLUMN bucket_stats.pending_object_count is 'pending_object_count is the number of pending objects.';
+					COMMENT ON COLUMN bucket_stats.total_segments       is 'total_segments is the number of segments of the objects.';
+					COMMENT ON COLUMN bucket_stats.total_bytes          is 'total_bytes is the total encrypted size of the objects.';
+					COMMENT ON COLUMN bucket_stats.metadata_size        is 'metadata_size is the total size of the encrypted metadata of the objects.';
+					COMMENT ON COLUMN bucket_stats.updated_at           is 'updated_at is the time when the statistics were last refreshed.';
+
+					CREATE TABLE metadata_rotation_cursors (
+						active_key_id INT4 NOT NULL,
+						project_id    BYTEA NOT NULL,
+						bucket_name   BYTEA NOT NULL,
+						object_key    BYTEA NOT NULL,
+						version       INT8 NOT NULL,
+						finished      BOOLEAN NOT NULL default false,
+						updated_at    TIMESTAMPTZ NOT NULL default now(),
+						PRIMARY KEY (active_key_id)
+					);
+
+					COMMENT ON TABLE  metadata_rotation_cursors               is 'metadata_rotation_cursors table contains the progress of re-encrypting the object metadata with the active key.';
+					COMMENT ON COLUMN metadata_rotation_cursors.active_key_id is 'active_key_id is the metadata encryption key the objects are re-encrypted with.';
+					COMMENT ON COLUMN metadata_rotation_cursors.project_id    is 'project_id is the project of the last processed object.';
+					COMMENT ON COLUMN metadata_rotation_cursors.bucket_name   is 'bucket_name is the bucket of the last processed object.';
+					COMMENT ON COLUMN metadata_rotation_cursors.object_key    is 'object_key is the key of the last processed object.';
+					COMMENT ON COLUMN metadata_rotation_cursors.version       is 'version is the version of the last processed object.';
+					COMMENT ON COLUMN metadata_rotation_cursors.finished      is 'finished specifies whether all objects were processed with the key.';
+					COMMENT ON COLUMN metadata_rotation_cursors.updated_at    is 'updated_at is the time when the progress was last saved.';
+
+					CREATE TABLE object_delete_events (
+						project_id       BYTEA NOT NULL,
+						bucket_name      BYTEA NOT NULL,
+						object_key       BYTEA NOT NULL,
+						version          INT8 NOT NULL,
+						stream_id        BYTEA NOT NULL,
+						kind             INT2 NOT NULL,
+						total_plain_size INT8 NOT NULL default 0,
+						deleted_at       TIMESTAMPTZ NOT NULL default now(),
+						PRIMARY KEY (project_id, bucket_name, object_key, version, stream_id, kind)
+					);
+					CREATE INDEX object_delete_events_deleted_at_index ON object_delete_events (deleted_at);
+
+					COMMENT ON TABLE  object_delete_events                  is 'object_delete_events table contains the object deletions, which are yet to be published as bucket events.';
+					COMMENT ON COLUMN object_delete_events.project_id       is 'project_id is the project of the deleted object.';
+					COMMENT ON COLUMN object_delete_events.bucket_name      is 'bucket_name is the bucket of the deleted object.';
+					COMMENT ON COLUMN object_delete_events.object_key       is 'object_key is the key of the deleted object.';
+					COMMENT ON COLUMN object_delete_events.version          is 'version is the version of the deleted object or of the created delete marker.';
+					COMMENT ON COLUMN object_delete_events.stream_id        is 'stream_id is the stream of the deleted object or of the created delete marker.';
+					COMMENT ON COLUMN object_delete_events.kind             is 'kind specifies how the object was deleted, see metabase.DeleteEventKind.';
+					COMMENT ON COLUMN object_delete_events.total_plain_size is 'total_plain_size is the plain size of the deleted object.';
+					COMMENT ON COLUMN object_delete_events.deleted_at       is 'deleted_at is the time when the object was deleted.';`,
 				},
 			},
 		},
@@ -155,7 +266,7 @@ func (p *PostgresAdapter) TestMigrateToLatest(ctx context.Context) error {
 		migration.Steps = append(migration.Steps, &migrate.Step{
 			DB:          &p.db,
 			Description: "Constraint for ensuring our metabase correctness.",
-			Version:     21,
+			Version:     32,
 			Action: migrate.SQL{
 				`CREATE UNIQUE INDEX objects_one_unversioned_per_location ON objects (project_id, bucket_name, object_key) WHERE status IN ` + statusesUnversioned + `;`,
 			},
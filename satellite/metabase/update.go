@@ -52,7 +52,8 @@ type UpdateSegmentPieces struct {
 }
 
 // UpdateSegmentPieces updates pieces for specified segment. If provided old pieces
-// won't match current database state update will fail.
+// won't match current database state update will fail. The stored healthy piece
+// count of the segment is cleared, see UpdateSegmentHealthyPieces.
 func (db *DB) UpdateSegmentPieces(ctx context.Context, opts UpdateSegmentPieces) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -138,6 +139,10 @@ func (p *PostgresAdapter) UpdateSegmentPieces(ctx context.Context, opts UpdateSe
 			repaired_at = CASE
 				WHEN remote_alias_pieces = $3 AND $7 = true THEN $6
 				ELSE repaired_at
+			END,
+			healthy_pieces = CASE
+				WHEN remote_alias_pieces = $3 THEN NULL
+				ELSE healthy_pieces
 			END
 		WHERE
 			stream_id     = $1 AND
@@ -173,6 +178,10 @@ func (s *SpannerAdapter) UpdateSegmentPieces(ctx context.Context, opts UpdateSeg
 					repaired_at = CASE
 						WHEN remote_alias_pieces = @old_pieces AND @update_repaired_at = true THEN @new_repaired_at
 						ELSE repaired_at
+					END,
+					healthy_pieces = CASE
+						WHEN remote_alias_pieces = @old_pieces THEN NULL
+						ELSE healthy_pieces
 					END
 				WHERE
 					stream_id     = @stream_id AND
@@ -12,6 +12,7 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/common/sync2"
+	"storj.io/common/uuid"
 	"storj.io/storj/satellite/metabase"
 )
 
@@ -30,6 +31,21 @@ type Config struct {
 	AsOfSystemInterval time.Duration `help:"as of system interval" releaseDefault:"-5m" devDefault:"-1us" testDefault:"-1us"`
 }
 
+// DB stores the zombie deletion settings of projects and buckets.
+//
+// architecture: Database
+type DB interface {
+	// Set stores the inactivity period of the project or the bucket. An empty
+	// bucket name configures the whole project.
+	Set(ctx context.Context, override metabase.ZombieDeletionOverride) error
+	// Get returns the inactivity period of the project or the bucket.
+	Get(ctx context.Context, projectID uuid.UUID, bucketName metabase.BucketName) (_ time.Duration, ok bool, err error)
+	// Delete removes the inactivity period of the project or the bucket, so the default is used.
+	Delete(ctx context.Context, projectID uuid.UUID, bucketName metabase.BucketName) error
+	// List returns all the configured inactivity periods.
+	List(ctx context.Context) ([]metabase.ZombieDeletionOverride, error)
+}
+
 // Chore implements the zombie objects cleanup chore.
 //
 // architecture: Chore
@@ -37,17 +53,20 @@ type Chore struct {
 	log      *zap.Logger
 	config   Config
 	metabase *metabase.DB
+	settings DB
 
 	nowFn func() time.Time
 	Loop  *sync2.Cycle
 }
 
 // NewChore creates a new instance of the zombiedeletion chore.
-func NewChore(log *zap.Logger, config Config, metabase *metabase.DB) *Chore {
+// The settings are optional, without them the configured inactivity period is used for all objects.
+func NewChore(log *zap.Logger, config Config, metabase *metabase.DB, settings DB) *Chore {
 	return &Chore{
 		log:      log,
 		config:   config,
 		metabase: metabase,
+		settings: settings,
 
 		nowFn: time.Now,
 		Loop:  sync2.NewCycle(config.Interval),
@@ -80,11 +99,20 @@ func (chore *Chore) deleteZombieObjects(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
 	chore.log.Debug("deleting zombie objects")
 
+	var overrides []metabase.ZombieDeletionOverride
+	if chore.settings != nil {
+		overrides, err = chore.settings.List(ctx)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
 	opts := metabase.DeleteZombieObjects{
 		DeadlineBefore:     chore.nowFn(),
 		InactiveDeadline:   chore.nowFn().Add(-chore.config.InactiveFor),
 		BatchSize:          chore.config.ListLimit,
 		AsOfSystemInterval: chore.config.AsOfSystemInterval,
+		Overrides:          overrides,
 	}
 
 	err = chore.metabase.DeleteZombieObjects(ctx, opts)
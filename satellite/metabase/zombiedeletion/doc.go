@@ -6,5 +6,9 @@ Package zombiedeletion contains the functions needed to run zombie objects delet
 
 The zombiedeletion chore will periodically query metabase for zombie objects
 and delete them with their segments.
+
+The inactivity period after which the pending objects are deleted can be
+overridden per project and per bucket, e.g. for customers keeping multipart
+uploads pending for weeks. The overrides are stored in the satellite database.
 */
 package zombiedeletion
@@ -170,3 +170,81 @@ func TestZombieDeletion_LastSegmentActive(t *testing.T) {
 		require.Zero(t, cmp.Diff(segments, afterSegments, cmpopts.EquateApproxTime(1*time.Second)))
 	})
 }
+
+func TestZombieDeletion_Overrides(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.ZombieDeletion.Interval = 500 * time.Millisecond
+				config.ZombieDeletion.AsOfSystemInterval = -1 * time.Microsecond
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		upl := planet.Uplinks[0]
+		projectID := upl.Projects[0].ID
+		zombieChore := sat.Core.ZombieDeletion.Chore
+
+		zombieChore.Loop.Pause()
+
+		for _, bucket := range []string{"default", "long"} {
+			require.NoError(t, upl.CreateBucket(ctx, sat, bucket))
+		}
+
+		project, err := upl.OpenProject(ctx, sat)
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		for _, bucket := range []string{"default", "long"} {
+			info, err := project.BeginUpload(ctx, bucket, "pending", nil)
+			require.NoError(t, err)
+			partUpload, err := project.UploadPart(ctx, bucket, "pending", info.UploadID, 1)
+			require.NoError(t, err)
+			_, err = partUpload.Write(testrand.Bytes(1 * memory.KiB))
+			require.NoError(t, err)
+			require.NoError(t, partUpload.Commit())
+		}
+
+		settings := sat.DB.ZombieDeletion()
+		require.NoError(t, settings.Set(ctx, metabase.ZombieDeletionOverride{
+			ProjectID:   projectID,
+			BucketName:  "long",
+			InactiveFor: 30 * 24 * time.Hour,
+		}))
+
+		inactiveFor, ok, err := settings.Get(ctx, projectID, "long")
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, 30*24*time.Hour, inactiveFor)
+
+		_, ok, err = settings.Get(ctx, projectID, "")
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		// the default deadline only deletes the pending object of the bucket without override
+		zombieChore.TestingSetNow(func() time.Time {
+			return time.Now().Add(25 * time.Hour)
+		})
+		zombieChore.Loop.TriggerWait()
+
+		objects, err := sat.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		require.Equal(t, metabase.BucketName("long"), objects[0].BucketName)
+
+		zombieChore.TestingSetNow(func() time.Time {
+			return time.Now().Add(31 * 24 * time.Hour)
+		})
+		zombieChore.Loop.TriggerWait()
+
+		objects, err = sat.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Empty(t, objects)
+
+		require.NoError(t, settings.Delete(ctx, projectID, "long"))
+		overrides, err := settings.List(ctx)
+		require.NoError(t, err)
+		require.Empty(t, overrides)
+	})
+}
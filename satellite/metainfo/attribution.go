@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
 
 	"storj.io/common/errs2"
 	"storj.io/common/pb"
@@ -19,6 +20,7 @@ import (
 	"storj.io/storj/satellite/attribution"
 	"storj.io/storj/satellite/buckets"
 	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/metabase"
 )
 
 // MaxUserAgentLength is the maximum allowable length of the User Agent.
@@ -115,6 +117,40 @@ func TrimUserAgent(userAgent []byte) ([]byte, error) {
 	return userAgent, nil
 }
 
+// recordPartnerUsage records the committed object for the daily partner
+// rollups. Every partner of the API key and the request user agent is
+// recorded, not only the partner the bucket is attributed to.
+func (endpoint *Endpoint) recordPartnerUsage(keyInfo *console.APIKeyInfo, header *pb.RequestHeader, bucketName []byte, object *metabase.Object) {
+	if endpoint.attributionRecorder == nil {
+		return
+	}
+
+	partners := attributionPartners(keyInfo.UserAgent, header.GetUserAgent())
+	endpoint.attributionRecorder.Record(object.ProjectID, bucketName, partners, object.TotalEncryptedSize)
+}
+
+// attributionPartners returns the distinct products of the user agents,
+// after removing the libraries used by the uplinks.
+func attributionPartners(userAgents ...[]byte) []string {
+	var partners []string
+	for _, userAgent := range userAgents {
+		userAgent, err := TrimUserAgent(userAgent)
+		if err != nil || len(userAgent) == 0 {
+			continue
+		}
+		entries, err := useragent.ParseEntries(userAgent)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.Product != "" && !slices.Contains(partners, entry.Product) {
+				partners = append(partners, entry.Product)
+			}
+		}
+	}
+	return partners
+}
+
 func (endpoint *Endpoint) tryUpdateBucketAttribution(ctx context.Context, header *pb.RequestHeader, projectID uuid.UUID, bucketName []byte, userAgent []byte, forceBucketUpdate bool) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -13,6 +13,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
 	"storj.io/common/macaroon"
@@ -21,6 +22,7 @@ import (
 	"storj.io/common/testrand"
 	"storj.io/common/uuid"
 	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/attribution"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/metainfo"
@@ -525,3 +527,65 @@ func TestAttributionBeginObject(t *testing.T) {
 		}
 	})
 }
+
+func TestAttributionPartnerRollups(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Attribution.Enabled = true
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		upl := planet.Uplinks[0]
+		projectID := upl.Projects[0].ID
+
+		sat.API.Attribution.Recorder.Loop.Pause()
+
+		upload := func(userAgent, bucketName, key string, data []byte) {
+			config := uplink.Config{UserAgent: userAgent}
+			project, err := config.OpenProject(ctx, upl.Access[sat.ID()])
+			require.NoError(t, err)
+			defer ctx.Check(project.Close)
+
+			_, err = project.EnsureBucket(ctx, bucketName)
+			require.NoError(t, err)
+
+			upload, err := project.UploadObject(ctx, bucketName, key, nil)
+			require.NoError(t, err)
+			_, err = upload.Write(data)
+			require.NoError(t, err)
+			require.NoError(t, upload.Commit())
+		}
+
+		// the bucket is attributed to the first partner, but every partner
+		// uploading to it is part of the rollups.
+		upload("partner1/1.0", "bucket", "first", testrand.Bytes(memory.KiB))
+		upload("partner2/1.0 partner1/1.0", "bucket", "second", testrand.Bytes(memory.KiB))
+		upload("", "bucket", "third", testrand.Bytes(memory.KiB))
+
+		attr, err := sat.API.DB.Attribution().Get(ctx, projectID, []byte("bucket"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("partner1/1.0"), attr.UserAgent)
+
+		require.NoError(t, sat.API.Attribution.Recorder.Flush(ctx))
+
+		now := time.Now().UTC()
+		end := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+		start := end.AddDate(0, 0, -2)
+
+		rollups, err := sat.API.DB.Attribution().QueryPartnerRollups(ctx, "partner1", start, end)
+		require.NoError(t, err)
+		require.Len(t, rollups, 1)
+		require.Equal(t, projectID, rollups[0].ProjectID)
+		require.Equal(t, []byte("bucket"), rollups[0].BucketName)
+		require.EqualValues(t, 2, rollups[0].Objects)
+		require.Positive(t, rollups[0].Bytes)
+
+		rollups, err = sat.API.DB.Attribution().QueryPartnerRollups(ctx, "partner2", start, end)
+		require.NoError(t, err)
+		require.Len(t, rollups, 1)
+		require.EqualValues(t, 1, rollups[0].Objects)
+	})
+}
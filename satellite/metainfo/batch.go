@@ -187,6 +187,44 @@ func (endpoint *Endpoint) Batch(ctx context.Context, req *pb.BatchRequest) (resp
 		case *pb.BatchRequestItem_ObjectBegin:
 			singleRequest.ObjectBegin.Header = req.Header
 
+			if endpoint.config.TestOptimizedInlineObjectUpload {
+				if uploads := collectInlineObjects(i, req.Requests, endpoint.config.InlineObjectsBatchSize); len(uploads) > 1 {
+					for _, upload := range uploads {
+						upload.BeginObject.Header = req.Header
+						upload.MakeInlineSegment.Header = req.Header
+						upload.CommitObject.Header = req.Header
+					}
+
+					responses, err := endpoint.CommitInlineObjects(ctx, uploads)
+					if err != nil {
+						return resp, err
+					}
+
+					for _, response := range responses {
+						resp.Responses = append(resp.Responses,
+							&pb.BatchResponseItem{
+								Response: &pb.BatchResponseItem_ObjectBegin{
+									ObjectBegin: response.BeginObject,
+								},
+							},
+							&pb.BatchResponseItem{
+								Response: &pb.BatchResponseItem_SegmentMakeInline{
+									SegmentMakeInline: response.MakeInlineSegment,
+								},
+							},
+							&pb.BatchResponseItem{
+								Response: &pb.BatchResponseItem_ObjectCommit{
+									ObjectCommit: response.CommitObject,
+								},
+							},
+						)
+					}
+
+					i += 3*len(uploads) - 1
+					continue
+				}
+			}
+
 			if makeInlineSeg, commitObj, should := shouldDoInlineObject(i, req.Requests); should && endpoint.config.TestOptimizedInlineObjectUpload {
 				makeInlineSeg.Header = req.Header
 				commitObj.Header = req.Header
@@ -566,3 +604,40 @@ func shouldDoInlineObject(index int, requests []*pb.BatchRequestItem) (_ *pb.Seg
 
 	return makeInlineSegReq.SegmentMakeInline, commitObjReq.ObjectCommit, true
 }
+
+// collectInlineObjects returns the consecutive full inline object uploads starting at
+// index, up to limit uploads. It stops before an upload to an object uploaded
+// earlier in the batch, which has to overwrite the earlier object.
+func collectInlineObjects(index int, requests []*pb.BatchRequestItem, limit int) (uploads []InlineObjectUpload) {
+	type location struct{ bucket, key string }
+	locations := map[location]struct{}{}
+
+	for len(uploads) < limit && index < len(requests) {
+		beginObjReq, ok := requests[index].Request.(*pb.BatchRequestItem_ObjectBegin)
+		if !ok {
+			break
+		}
+
+		loc := location{
+			bucket: string(beginObjReq.ObjectBegin.Bucket),
+			key:    string(beginObjReq.ObjectBegin.EncryptedObjectKey),
+		}
+		if _, ok := locations[loc]; ok {
+			break
+		}
+		locations[loc] = struct{}{}
+
+		makeInlineSegReq, commitObjReq, should := shouldDoInlineObject(index, requests)
+		if !should {
+			break
+		}
+
+		uploads = append(uploads, InlineObjectUpload{
+			BeginObject:       beginObjReq.ObjectBegin,
+			MakeInlineSegment: makeInlineSegReq,
+			CommitObject:      commitObjReq,
+		})
+		index += 3
+	}
+	return uploads
+}
@@ -4,15 +4,19 @@
 package metainfo
 
 import (
+	"encoding/hex"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"storj.io/common/memory"
+	"storj.io/common/storj"
 	"storj.io/common/uuid"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metainfo/piecedeletion"
 	"storj.io/storj/satellite/nodeselection"
 	"storj.io/uplink/private/eestream"
 )
@@ -137,6 +141,80 @@ type RateLimiterConfig struct {
 	CacheExpiration time.Duration `help:"how long to cache the projects limiter." releaseDefault:"10m" devDefault:"10s"`
 }
 
+// DeleteRateLimiterConfig is a configuration struct for limiting the number of objects a project can delete.
+type DeleteRateLimiterConfig struct {
+	Rate            float64       `help:"number of objects a project can delete per second, 0 disables the delete rate limiting." default:"0"`
+	Burst           int           `help:"number of objects a project can delete at once, 0 means the same as the rate." default:"0"`
+	CacheCapacity   int           `help:"number of projects to cache." releaseDefault:"10000" devDefault:"10" testDefault:"100"`
+	CacheExpiration time.Duration `help:"how long to cache the projects delete limiter." releaseDefault:"10m" devDefault:"10s"`
+}
+
+// MetadataEncryptionConfig is a configuration struct for encrypting the object metadata at rest.
+type MetadataEncryptionConfig struct {
+	Keys      MetadataEncryptionKeys `help:"comma separated list of id=hex(key) master keys for encrypting the object metadata at rest; every key still used by the stored metadata must be listed" default:""`
+	ActiveKey int                    `help:"identifier of the key used for encrypting new metadata, 0 stores new metadata without the additional encryption" default:"0"`
+}
+
+// MetadataEncryptionKeys are the master keys for encrypting the object metadata at rest.
+//
+// Can be used as a flag.
+type MetadataEncryptionKeys map[uint16]storj.Key
+
+// Type implements pflag.Value.
+func (MetadataEncryptionKeys) Type() string { return "metainfo.MetadataEncryptionKeys" }
+
+// String is required for pflag.Value.
+func (keys MetadataEncryptionKeys) String() string {
+	ids := make([]int, 0, len(keys))
+	for id := range keys {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		key := keys[uint16(id)]
+		values = append(values, strconv.Itoa(id)+"="+hex.EncodeToString(key[:]))
+	}
+	return strings.Join(values, ",")
+}
+
+// Set sets the value from a comma delimited list of "id=hex(key)" strings.
+func (keys *MetadataEncryptionKeys) Set(s string) error {
+	*keys = MetadataEncryptionKeys{}
+	if s == "" {
+		return nil
+	}
+
+	for _, value := range strings.Split(s, ",") {
+		tokens := strings.SplitN(strings.TrimSpace(value), "=", 2)
+		if len(tokens) != 2 {
+			return Error.New("invalid metadata encryption key definition %q", value)
+		}
+
+		id, err := strconv.ParseUint(tokens[0], 10, 16)
+		if err != nil || id == 0 {
+			return Error.New("invalid metadata encryption key id %q", tokens[0])
+		}
+		if _, ok := (*keys)[uint16(id)]; ok {
+			return Error.New("duplicate metadata encryption key id %d", id)
+		}
+
+		decoded, err := hex.DecodeString(tokens[1])
+		if err != nil {
+			return Error.New("invalid metadata encryption key %d: %w", id, err)
+		}
+		var key storj.Key
+		if len(decoded) != len(key) {
+			return Error.New("invalid metadata encryption key %d: expected %d bytes, got %d", id, len(key), len(decoded))
+		}
+		copy(key[:], decoded)
+
+		(*keys)[uint16(id)] = key
+	}
+	return nil
+}
+
 // UploadLimiterConfig is a configuration struct for endpoint upload limiting.
 type UploadLimiterConfig struct {
 	Enabled           bool          `help:"whether rate limiting is enabled." releaseDefault:"true" devDefault:"true"`
@@ -182,10 +260,21 @@ type Config struct {
 	// TODO remove this flag when server-side copy implementation will be finished
 	ServerSideCopy         bool `help:"enable code for server-side copy, deprecated. please leave this to true." default:"true"`
 	ServerSideCopyDisabled bool `help:"disable already enabled server-side copy. this is because once server side copy is enabled, delete code should stay changed, even if you want to disable server side copy" default:"false"`
+	CrossProjectCopy       bool `help:"allow server-side copy between projects, when the copy is begun with an API key of the source project and finished with an API key of the destination project" default:"false"`
 	UseListObjectsIterator bool `help:"switch to iterator based implementation." default:"false"`
 
 	NodeAliasCacheFullRefresh bool `help:"node alias cache does a full refresh when a value is missing" default:"false"`
 
+	DeferredSegmentDeletionThreshold int `help:"objects with more segments than this are deleted right away while their segments are deleted in the background by the deferred deletion chore, 0 disables deferring" default:"0"`
+
+	DeleteRateLimiter DeleteRateLimiterConfig `help:"object delete rate limiter configuration"`
+
+	LongQueryThreshold time.Duration `help:"metabase operations running longer than this are listed on the debug control panel, where they can be cancelled, 0 disables tracking" default:"1m"`
+
+	MetadataEncryption MetadataEncryptionConfig `help:"object metadata encryption at rest configuration"`
+
+	PieceDeletion piecedeletion.Config `help:"piece deletion configuration"`
+
 	UseBucketLevelObjectVersioning bool `help:"enable the use of bucket level object versioning" default:"false"`
 	// flag to simplify testing by enabling bucket level versioning feature only for specific projects
 	UseBucketLevelObjectVersioningProjects []string `help:"list of projects which will have UseBucketLevelObjectVersioning feature flag enabled" default:"" hidden:"true"`
@@ -193,6 +282,14 @@ type Config struct {
 	UseBucketLevelObjectLock         bool     `help:"enable the use of bucket-level Object Lock" default:"false"`
 	UseBucketLevelObjectLockProjects []string `help:"list of project IDs for which bucket-level Object Lock functionality is enabled" default:"" hidden:"true"`
 
+	BucketDeletionProtection bool `help:"reject deletes in buckets with deletion protection enabled, unless the API key allows them" default:"false"`
+
+	BucketQuotas bool `help:"enforce the object and segment quotas of the buckets when committing objects" default:"false"`
+
+	PendingObjectDeletionGracePeriod time.Duration `help:"pending objects with a segment committed within this period can't be deleted, which protects progressing uploads from stale aborts, 0 disables the check" default:"0"`
+
+	InlineObjectsBatchSize int `help:"maximum number of consecutive inline objects of a batch request committed in a single transaction when the inline object upload optimization is enabled" default:"100"`
+
 	UserInfoValidation UserInfoValidationConfig `help:"Config for user info validation"`
 
 	// TODO remove when we benchmarking are done and decision is made.
@@ -205,13 +302,25 @@ type Config struct {
 // Metabase constructs Metabase configuration based on Metainfo configuration with specific application name.
 func (c Config) Metabase(applicationName string) metabase.Config {
 	return metabase.Config{
-		ApplicationName:            applicationName,
-		MinPartSize:                c.MinPartSize,
-		MaxNumberOfParts:           c.MaxNumberOfParts,
-		ServerSideCopy:             c.ServerSideCopy,
-		NodeAliasCacheFullRefresh:  c.NodeAliasCacheFullRefresh,
-		TestingCommitSegmentMode:   c.TestCommitSegmentMode,
-		TestingPrecommitDeleteMode: metabase.TestingPrecommitDeleteMode(c.TestingPrecommitDeleteMode),
+		ApplicationName:                  applicationName,
+		MinPartSize:                      c.MinPartSize,
+		MaxNumberOfParts:                 c.MaxNumberOfParts,
+		ServerSideCopy:                   c.ServerSideCopy,
+		NodeAliasCacheFullRefresh:        c.NodeAliasCacheFullRefresh,
+		DeferredSegmentDeletionThreshold: c.DeferredSegmentDeletionThreshold,
+		TestingCommitSegmentMode:         c.TestCommitSegmentMode,
+		TestingPrecommitDeleteMode:       metabase.TestingPrecommitDeleteMode(c.TestingPrecommitDeleteMode),
+		DeleteRateLimit: metabase.DeleteRateLimitConfig{
+			Rate:            c.DeleteRateLimiter.Rate,
+			Burst:           c.DeleteRateLimiter.Burst,
+			CacheCapacity:   c.DeleteRateLimiter.CacheCapacity,
+			CacheExpiration: c.DeleteRateLimiter.CacheExpiration,
+		},
+		LongQueryThreshold: c.LongQueryThreshold,
+		MetadataEncryption: metabase.MetadataEncryptionConfig{
+			Keys:        c.MetadataEncryption.Keys,
+			ActiveKeyID: uint16(c.MetadataEncryption.ActiveKey),
+		},
 	}
 }
 
@@ -30,12 +30,14 @@ import (
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/internalpb"
 	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metainfo/piecedeletion"
 	"storj.io/storj/satellite/metainfo/pointerverification"
 	"storj.io/storj/satellite/nodeselection"
 	"storj.io/storj/satellite/orders"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/revocation"
 	"storj.io/storj/shared/lrucache"
+	"storj.io/storj/shared/throttle"
 )
 
 const (
@@ -73,7 +75,9 @@ type Endpoint struct {
 	orders                 *orders.Service
 	overlay                *overlay.Service
 	attributions           attribution.DB
+	attributionRecorder    *attribution.Recorder
 	pointerVerification    *pointerverification.Service
+	pieceDeletion          *piecedeletion.Service
 	projectUsage           *accounting.Service
 	projects               console.Projects
 	projectMembers         console.ProjectMembers
@@ -95,9 +99,10 @@ type Endpoint struct {
 
 // NewEndpoint creates new metainfo endpoint instance.
 func NewEndpoint(log *zap.Logger, buckets *buckets.Service, metabaseDB *metabase.DB,
-	orders *orders.Service, cache *overlay.Service, attributions attribution.DB, peerIdentities overlay.PeerIdentities,
+	orders *orders.Service, cache *overlay.Service, attributions attribution.DB, attributionRecorder *attribution.Recorder, peerIdentities overlay.PeerIdentities,
 	apiKeys APIKeys, projectUsage *accounting.Service, projects console.Projects, projectMembers console.ProjectMembers, users console.Users,
-	satellite signing.Signer, revocations revocation.DB, successTrackers *SuccessTrackers, config Config, placement nodeselection.PlacementDefinitions) (*Endpoint, error) {
+	satellite signing.Signer, revocations revocation.DB, successTrackers *SuccessTrackers, pieceDeletion *piecedeletion.Service,
+	config Config, placement nodeselection.PlacementDefinitions) (*Endpoint, error) {
 
 	// TODO do something with too many params
 
@@ -126,6 +131,10 @@ func NewEndpoint(log *zap.Logger, buckets *buckets.Service, metabaseDB *metabase
 		return nil, errs.Wrap(err)
 	}
 
+	if pieceDeletion.Enabled() && config.ServerSideCopy {
+		log.Warn("piece deletion is ignored, because server-side copies may share pieces with deleted objects")
+	}
+
 	return &Endpoint{
 		log:                 log,
 		buckets:             buckets,
@@ -133,7 +142,9 @@ func NewEndpoint(log *zap.Logger, buckets *buckets.Service, metabaseDB *metabase
 		orders:              orders,
 		overlay:             cache,
 		attributions:        attributions,
+		attributionRecorder: attributionRecorder,
 		pointerVerification: pointerverification.NewService(peerIdentities),
+		pieceDeletion:       pieceDeletion,
 		apiKeys:             apiKeys,
 		projectUsage:        projectUsage,
 		projects:            projects,
@@ -374,6 +385,14 @@ func (endpoint *Endpoint) unmarshalSatSegmentID(ctx context.Context, segmentID s
 	return satSegmentID, nil
 }
 
+// sendsPieceDeletions returns whether the pieces of the deleted segments are
+// sent to the storage nodes right away. Server-side copies share the pieces
+// with their source, so the pieces are left to garbage collection whenever
+// copies may exist.
+func (endpoint *Endpoint) sendsPieceDeletions() bool {
+	return endpoint.pieceDeletion.Enabled() && !endpoint.config.ServerSideCopy
+}
+
 // ConvertMetabaseErr converts domain errors from metabase to appropriate rpc statuses errors.
 func (endpoint *Endpoint) ConvertMetabaseErr(err error) error {
 	switch {
@@ -398,16 +417,27 @@ func (endpoint *Endpoint) ConvertMetabaseErr(err error) error {
 		return rpcstatus.Error(rpcstatus.NotFound, "segment not found: "+message)
 	case metabase.ErrObjectLock.Has(err):
 		return rpcstatus.Error(rpcstatus.PermissionDenied, unauthorizedErrMsg)
+	case metabase.ErrDeletionProtected.Has(err):
+		return rpcstatus.Error(rpcstatus.PermissionDenied, err.Error())
 	case metabase.ErrInvalidRequest.Has(err):
 		return rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
 	case metabase.ErrFailedPrecondition.Has(err):
 		return rpcstatus.Error(rpcstatus.FailedPrecondition, err.Error())
+	case metabase.ErrConflict.Has(err):
+		return rpcstatus.Error(rpcstatus.FailedPrecondition, err.Error())
 	case metabase.ErrObjectAlreadyExists.Has(err):
 		return rpcstatus.Error(rpcstatus.AlreadyExists, err.Error())
+	case metabase.ErrStreamIDInUse.Has(err):
+		return rpcstatus.Error(rpcstatus.AlreadyExists, err.Error())
 	case metabase.ErrPendingObjectMissing.Has(err):
 		return rpcstatus.Error(rpcstatus.NotFound, err.Error())
 	case metabase.ErrPermissionDenied.Has(err):
 		return rpcstatus.Error(rpcstatus.PermissionDenied, err.Error())
+	case metabase.ErrBucketQuotaExceeded.Has(err):
+		return rpcstatus.Error(rpcstatus.ResourceExhausted, err.Error())
+	case metabase.ErrDeleteRateLimited.Has(err):
+		retryAfter, _ := metabase.DeleteRateLimitRetryAfter(err)
+		return throttle.Error(throttle.KindDeleteRate, retryAfter, "Too Many Requests")
 	default:
 		endpoint.log.Error("internal", zap.Error(err))
 		return rpcstatus.Error(rpcstatus.Internal, "internal error")
@@ -434,3 +464,63 @@ func (endpoint *Endpoint) getRSProto(placementID storj.PlacementConstraint) *pb.
 		ErasureShareSize: rs.ErasureShareSize.Int32(),
 	}
 }
+
+// getStreamRSProto returns the redundancy scheme of the stream. It's the
+// bucket override stored in the stream ID when set, the placement default otherwise.
+func (endpoint *Endpoint) getStreamRSProto(streamID *internalpb.StreamID) *pb.RedundancyScheme {
+	if streamID.Redundancy != nil {
+		return streamID.Redundancy
+	}
+	return endpoint.getRSProto(storj.PlacementConstraint(streamID.Placement))
+}
+
+// getStreamMaxSegmentSize returns the maximum size of the segments of the
+// stream. It's the bucket override stored in the stream ID when set, the
+// configured maximum otherwise.
+func (endpoint *Endpoint) getStreamMaxSegmentSize(streamID *internalpb.StreamID) int64 {
+	if streamID.MaxSegmentSize > 0 {
+		return streamID.MaxSegmentSize
+	}
+	return endpoint.config.MaxSegmentSize.Int64()
+}
+
+// getOverrideMaxSegmentSize returns the maximum segment size the bucket
+// defaults set for new uploads, or 0 when they don't set it. The override is
+// bounded to [buckets.MinSegmentSize, MaxSegmentSize], so it can't raise the
+// configured maximum.
+func (endpoint *Endpoint) getOverrideMaxSegmentSize(overrides buckets.Defaults) int64 {
+	if overrides.SegmentSize <= 0 {
+		return 0
+	}
+
+	size := overrides.SegmentSize
+	if size < buckets.MinSegmentSize.Int64() {
+		size = buckets.MinSegmentSize.Int64()
+	}
+	if size > endpoint.config.MaxSegmentSize.Int64() {
+		size = endpoint.config.MaxSegmentSize.Int64()
+	}
+	return size
+}
+
+func redundancyToProto(rs storj.RedundancyScheme) *pb.RedundancyScheme {
+	return &pb.RedundancyScheme{
+		Type:             pb.RedundancyScheme_SchemeType(rs.Algorithm),
+		MinReq:           int32(rs.RequiredShares),
+		RepairThreshold:  int32(rs.RepairShares),
+		SuccessThreshold: int32(rs.OptimalShares),
+		Total:            int32(rs.TotalShares),
+		ErasureShareSize: rs.ShareSize,
+	}
+}
+
+func redundancyFromProto(rs *pb.RedundancyScheme) storj.RedundancyScheme {
+	return storj.RedundancyScheme{
+		Algorithm:      storj.RedundancyAlgorithm(rs.Type),
+		RequiredShares: int16(rs.MinReq),
+		RepairShares:   int16(rs.RepairThreshold),
+		OptimalShares:  int16(rs.SuccessThreshold),
+		TotalShares:    int16(rs.Total),
+		ShareSize:      rs.ErasureShareSize,
+	}
+}
@@ -36,7 +36,7 @@ func (endpoint *Endpoint) GetBucket(ctx context.Context, req *pb.BucketGetReques
 	}
 	endpoint.usageTracking(keyInfo, req.Header, fmt.Sprintf("%T", req))
 
-	bucket, err := endpoint.buckets.GetMinimalBucket(ctx, req.GetName(), keyInfo.ProjectID)
+	bucket, err := endpoint.buckets.GetBucket(ctx, req.GetName(), keyInfo.ProjectID)
 	if err != nil {
 		if buckets.ErrBucketNotFound.Has(err) {
 			return nil, rpcstatus.Error(rpcstatus.NotFound, err.Error())
@@ -45,8 +45,24 @@ func (endpoint *Endpoint) GetBucket(ctx context.Context, req *pb.BucketGetReques
 		return nil, rpcstatus.Error(rpcstatus.Internal, "unable to get bucket metadata")
 	}
 
-	// override RS to fit satellite settings
-	convBucket, err := convertMinimalBucketToProto(bucket, endpoint.getRSProto(bucket.Placement), endpoint.config.MaxSegmentSize)
+	// override RS to fit satellite settings, the bucket may override them
+	// for new uploads.
+	rs := endpoint.getRSProto(bucket.Placement)
+	maxSegmentSize := endpoint.config.MaxSegmentSize
+	overrides := bucket.DefaultsOverride()
+	if !overrides.Redundancy.IsZero() {
+		rs = redundancyToProto(overrides.Redundancy)
+	}
+	if size := endpoint.getOverrideMaxSegmentSize(overrides); size > 0 {
+		maxSegmentSize = memory.Size(size)
+	}
+
+	convBucket, err := convertMinimalBucketToProto(buckets.MinimalBucket{
+		Name:      []byte(bucket.Name),
+		CreatedBy: bucket.CreatedBy,
+		CreatedAt: bucket.Created,
+		Placement: bucket.Placement,
+	}, rs, maxSegmentSize)
 	if err != nil {
 		return resp, err
 	}
@@ -372,6 +388,17 @@ func (endpoint *Endpoint) DeleteBucket(ctx context.Context, req *pb.BucketDelete
 		return nil, rpcstatus.Error(rpcstatus.PermissionDenied, unauthorizedErrMsg)
 	}
 
+	protected, err := endpoint.isDeletionProtected(ctx, req.Header, keyInfo.ProjectID, req.Name)
+	if err != nil {
+		if buckets.ErrBucketNotFound.Has(err) {
+			return nil, rpcstatus.Error(rpcstatus.NotFound, err.Error())
+		}
+		return nil, err
+	}
+	if protected {
+		return nil, rpcstatus.Error(rpcstatus.PermissionDenied, "bucket has deletion protection enabled")
+	}
+
 	var convBucket *pb.Bucket
 	if canRead || canList {
 		// Info about deleted bucket is returned only if either Read, or List permission is granted.
@@ -432,6 +459,62 @@ func (endpoint *Endpoint) deleteBucket(ctx context.Context, bucketName []byte, p
 	return endpoint.buckets.DeleteBucket(ctx, bucketName, projectID)
 }
 
+// isDeletionProtected returns whether deletes in the bucket have to be rejected, because
+// the bucket has deletion protection enabled and the API key doesn't allow protected deletes.
+func (endpoint *Endpoint) isDeletionProtected(ctx context.Context, header *pb.RequestHeader, projectID uuid.UUID, bucketName []byte) (_ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !endpoint.config.BucketDeletionProtection {
+		return false, nil
+	}
+
+	protected, err := endpoint.buckets.GetBucketDeletionProtection(ctx, bucketName, projectID)
+	if err != nil {
+		if buckets.ErrBucketNotFound.Has(err) {
+			return false, err
+		}
+		endpoint.log.Error("unable to get bucket's deletion protection", zap.Error(err))
+		return false, rpcstatus.Error(rpcstatus.Internal, "unable to get bucket's deletion protection")
+	}
+	return endpoint.checkDeletionProtection(ctx, header, protected)
+}
+
+// checkDeletionProtection returns whether deletes in a bucket with the given deletion
+// protection have to be rejected, because the API key doesn't allow protected deletes.
+func (endpoint *Endpoint) checkDeletionProtection(ctx context.Context, header *pb.RequestHeader, protected bool) (bool, error) {
+	if !endpoint.config.BucketDeletionProtection || !protected {
+		return false, nil
+	}
+
+	key, err := getAPIKey(ctx, header)
+	if err != nil {
+		return false, rpcstatus.Errorf(rpcstatus.InvalidArgument, "Invalid API credentials: %v", err)
+	}
+	allowed, err := buckets.AllowsProtectedDeletes(key)
+	if err != nil {
+		return false, rpcstatus.Errorf(rpcstatus.InvalidArgument, "Invalid API credentials: %v", err)
+	}
+	return !allowed, nil
+}
+
+// allowsOverwrite returns whether committing an object may delete the object it
+// replaces. Overwriting is a delete, hence it's disallowed in buckets with deletion
+// protection, unless the API key allows protected deletes.
+func (endpoint *Endpoint) allowsOverwrite(ctx context.Context, header *pb.RequestHeader, projectID uuid.UUID, bucketName []byte, allowDelete bool) (bool, error) {
+	if !allowDelete {
+		return false, nil
+	}
+	protected, err := endpoint.isDeletionProtected(ctx, header, projectID, bucketName)
+	if err != nil {
+		if buckets.ErrBucketNotFound.Has(err) {
+			// committing into a missing bucket fails the same way as without deletion protection.
+			return true, nil
+		}
+		return false, err
+	}
+	return !protected, nil
+}
+
 // isBucketEmpty returns whether bucket is empty.
 func (endpoint *Endpoint) isBucketEmpty(ctx context.Context, projectID uuid.UUID, bucketName []byte) (bool, error) {
 	empty, err := endpoint.metabase.BucketEmpty(ctx, metabase.BucketEmpty{
@@ -446,6 +529,9 @@ func (endpoint *Endpoint) isBucketEmpty(ctx context.Context, projectID uuid.UUID
 func (endpoint *Endpoint) deleteBucketNotEmpty(ctx context.Context, projectID uuid.UUID, bucketName []byte) ([]byte, int64, error) {
 	deletedCount, err := endpoint.deleteBucketObjects(ctx, projectID, bucketName)
 	if err != nil {
+		if metabase.ErrDeleteRateLimited.Has(err) {
+			return nil, deletedCount, endpoint.ConvertMetabaseErr(err)
+		}
 		endpoint.log.Error("internal", zap.Error(err))
 		return nil, 0, rpcstatus.Error(rpcstatus.Internal, "internal error")
 	}
@@ -0,0 +1,61 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metainfo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/storj/satellite/buckets"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/internalpb"
+)
+
+var _ internalpb.DRPCBucketCORSServer = (*Endpoint)(nil)
+
+// GetBucketCORS returns the CORS configuration of a bucket. Any API key allowed
+// to read the bucket may read its CORS configuration, so the linksharing and
+// auth services can apply it with the access grant of the shared object.
+func (endpoint *Endpoint) GetBucketCORS(ctx context.Context, req *internalpb.GetBucketCORSRequest) (_ *internalpb.GetBucketCORSResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	endpoint.versionCollector.collect(req.Header.UserAgent, mon.Func().ShortName())
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:     macaroon.ActionRead,
+		Bucket: req.Name,
+		Time:   time.Now(),
+	}, console.RateLimitHead)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.usageTracking(keyInfo, req.Header, fmt.Sprintf("%T", req))
+
+	config, err := endpoint.buckets.GetBucketCORS(ctx, req.Name, keyInfo.ProjectID)
+	if err != nil {
+		if buckets.ErrBucketNotFound.Has(err) {
+			return nil, rpcstatus.Error(rpcstatus.NotFound, err.Error())
+		}
+		endpoint.log.Error("internal", zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Internal, "unable to get CORS configuration for the bucket")
+	}
+
+	resp := &internalpb.GetBucketCORSResponse{}
+	for _, rule := range config.Rules {
+		resp.Rules = append(resp.Rules, &internalpb.CORSRule{
+			Id:             rule.ID,
+			AllowedOrigins: rule.AllowedOrigins,
+			AllowedMethods: rule.AllowedMethods,
+			AllowedHeaders: rule.AllowedHeaders,
+			ExposeHeaders:  rule.ExposeHeaders,
+			MaxAgeSeconds:  int32(rule.MaxAgeSeconds),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metainfo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/pb"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/rpc/rpctest"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/buckets"
+	"storj.io/storj/satellite/internalpb"
+)
+
+func TestEndpoint_GetBucketCORS(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		project := planet.Uplinks[0].Projects[0]
+		header := &pb.RequestHeader{ApiKey: planet.Uplinks[0].APIKey[sat.ID()].SerializeRaw()}
+
+		// the linksharing and auth services call the endpoint over DRPC.
+		conn, err := planet.Uplinks[0].Dialer.DialNodeURL(ctx, sat.NodeURL())
+		require.NoError(t, err)
+		defer ctx.Check(conn.Close)
+		client := internalpb.NewDRPCBucketCORSClient(conn)
+
+		bucketName := testrand.BucketName()
+		_, err = sat.DB.Buckets().CreateBucket(ctx, buckets.Bucket{
+			Name:      bucketName,
+			ProjectID: project.ID,
+		})
+		require.NoError(t, err)
+
+		resp, err := client.GetBucketCORS(ctx, &internalpb.GetBucketCORSRequest{
+			Header: header,
+			Name:   []byte(bucketName),
+		})
+		require.NoError(t, err)
+		require.Empty(t, resp.Rules)
+
+		config := buckets.CORSConfiguration{
+			Rules: []buckets.CORSRule{{
+				AllowedOrigins: []string{"https://app.example.com"},
+				AllowedMethods: []string{"GET", "HEAD"},
+				MaxAgeSeconds:  3600,
+			}},
+		}
+		require.NoError(t, sat.DB.Buckets().SetBucketCORS(ctx, []byte(bucketName), project.ID, config))
+
+		resp, err = client.GetBucketCORS(ctx, &internalpb.GetBucketCORSRequest{
+			Header: header,
+			Name:   []byte(bucketName),
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Rules, 1)
+		require.Equal(t, []string{"https://app.example.com"}, resp.Rules[0].AllowedOrigins)
+		require.Equal(t, []string{"GET", "HEAD"}, resp.Rules[0].AllowedMethods)
+		require.EqualValues(t, 3600, resp.Rules[0].MaxAgeSeconds)
+
+		_, err = client.GetBucketCORS(ctx, &internalpb.GetBucketCORSRequest{
+			Header: header,
+			Name:   []byte("missing"),
+		})
+		rpctest.RequireCode(t, err, rpcstatus.NotFound)
+	})
+}
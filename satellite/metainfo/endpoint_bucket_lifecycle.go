@@ -0,0 +1,110 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metainfo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/storj/satellite/buckets"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/internalpb"
+)
+
+var _ internalpb.DRPCBucketLifecycleServer = (*Endpoint)(nil)
+
+// GetBucketLifecycle returns the lifecycle configuration of a bucket.
+func (endpoint *Endpoint) GetBucketLifecycle(ctx context.Context, req *internalpb.GetBucketLifecycleRequest) (_ *internalpb.GetBucketLifecycleResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	endpoint.versionCollector.collect(req.Header.UserAgent, mon.Func().ShortName())
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:     macaroon.ActionRead,
+		Bucket: req.Name,
+		Time:   time.Now(),
+	}, console.RateLimitHead)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.usageTracking(keyInfo, req.Header, fmt.Sprintf("%T", req))
+
+	config, err := endpoint.buckets.GetBucketLifecycle(ctx, req.Name, keyInfo.ProjectID)
+	if err != nil {
+		if buckets.ErrBucketNotFound.Has(err) {
+			return nil, rpcstatus.Error(rpcstatus.NotFound, err.Error())
+		}
+		endpoint.log.Error("internal", zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Internal, "unable to get lifecycle configuration for the bucket")
+	}
+
+	resp := &internalpb.GetBucketLifecycleResponse{}
+	for _, rule := range config.Rules {
+		resp.Rules = append(resp.Rules, &internalpb.LifecycleRule{
+			Id:             rule.ID,
+			Prefix:         rule.Prefix,
+			ExpirationDays: int32(rule.ExpirationDays),
+		})
+	}
+	return resp, nil
+}
+
+// SetBucketLifecycle replaces the lifecycle configuration of a bucket. Empty
+// rules remove the configuration.
+func (endpoint *Endpoint) SetBucketLifecycle(ctx context.Context, req *internalpb.SetBucketLifecycleRequest) (_ *internalpb.SetBucketLifecycleResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	endpoint.versionCollector.collect(req.Header.UserAgent, mon.Func().ShortName())
+
+	// expiration deletes objects, so it requires the delete permission in addition to write.
+	keyInfo, err := endpoint.ValidateAuthN(ctx, req.Header, console.RateLimitPut,
+		VerifyPermission{
+			Action: macaroon.Action{
+				Op:     macaroon.ActionWrite,
+				Bucket: req.Name,
+				Time:   time.Now(),
+			},
+		},
+		VerifyPermission{
+			Action: macaroon.Action{
+				Op:     macaroon.ActionDelete,
+				Bucket: req.Name,
+				Time:   time.Now(),
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.usageTracking(keyInfo, req.Header, fmt.Sprintf("%T", req))
+
+	var config buckets.LifecycleConfiguration
+	for _, rule := range req.Rules {
+		config.Rules = append(config.Rules, buckets.LifecycleRule{
+			ID:             rule.Id,
+			Prefix:         rule.Prefix,
+			ExpirationDays: int(rule.ExpirationDays),
+		})
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	err = endpoint.buckets.SetBucketLifecycle(ctx, req.Name, keyInfo.ProjectID, config)
+	if err != nil {
+		if buckets.ErrBucketNotFound.Has(err) {
+			return nil, rpcstatus.Error(rpcstatus.NotFound, err.Error())
+		}
+		endpoint.log.Error("internal", zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Internal, "unable to set lifecycle configuration for the bucket")
+	}
+
+	return &internalpb.SetBucketLifecycleResponse{}, nil
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metainfo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/pb"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/rpc/rpctest"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/buckets"
+	"storj.io/storj/satellite/internalpb"
+)
+
+func TestEndpoint_BucketLifecycle(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		project := planet.Uplinks[0].Projects[0]
+		header := &pb.RequestHeader{ApiKey: planet.Uplinks[0].APIKey[sat.ID()].SerializeRaw()}
+
+		conn, err := planet.Uplinks[0].Dialer.DialNodeURL(ctx, sat.NodeURL())
+		require.NoError(t, err)
+		defer ctx.Check(conn.Close)
+		client := internalpb.NewDRPCBucketLifecycleClient(conn)
+
+		bucketName := testrand.BucketName()
+		_, err = sat.DB.Buckets().CreateBucket(ctx, buckets.Bucket{
+			Name:      bucketName,
+			ProjectID: project.ID,
+		})
+		require.NoError(t, err)
+
+		_, err = client.SetBucketLifecycle(ctx, &internalpb.SetBucketLifecycleRequest{
+			Header: header,
+			Name:   []byte(bucketName),
+			Rules: []*internalpb.LifecycleRule{
+				{Id: "logs", Prefix: "logs/", ExpirationDays: 30},
+			},
+		})
+		require.NoError(t, err)
+
+		config, err := sat.DB.Buckets().GetBucketLifecycle(ctx, []byte(bucketName), project.ID)
+		require.NoError(t, err)
+		require.Equal(t, buckets.LifecycleConfiguration{
+			Rules: []buckets.LifecycleRule{
+				{ID: "logs", Prefix: "logs/", ExpirationDays: 30},
+			},
+		}, config)
+
+		resp, err := client.GetBucketLifecycle(ctx, &internalpb.GetBucketLifecycleRequest{
+			Header: header,
+			Name:   []byte(bucketName),
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Rules, 1)
+		require.Equal(t, "logs", resp.Rules[0].Id)
+		require.Equal(t, "logs/", resp.Rules[0].Prefix)
+		require.EqualValues(t, 30, resp.Rules[0].ExpirationDays)
+
+		_, err = client.SetBucketLifecycle(ctx, &internalpb.SetBucketLifecycleRequest{
+			Header: header,
+			Name:   []byte(bucketName),
+			Rules:  []*internalpb.LifecycleRule{{Id: "invalid"}},
+		})
+		rpctest.RequireCode(t, err, rpcstatus.InvalidArgument)
+
+		// empty rules remove the configuration.
+		_, err = client.SetBucketLifecycle(ctx, &internalpb.SetBucketLifecycleRequest{
+			Header: header,
+			Name:   []byte(bucketName),
+		})
+		require.NoError(t, err)
+
+		resp, err = client.GetBucketLifecycle(ctx, &internalpb.GetBucketLifecycleRequest{
+			Header: header,
+			Name:   []byte(bucketName),
+		})
+		require.NoError(t, err)
+		require.Empty(t, resp.Rules)
+
+		_, err = client.GetBucketLifecycle(ctx, &internalpb.GetBucketLifecycleRequest{
+			Header: header,
+			Name:   []byte("missing"),
+		})
+		rpctest.RequireCode(t, err, rpcstatus.NotFound)
+	})
+}
@@ -1031,3 +1031,69 @@ func TestGetBucketObjectLockConfiguration(t *testing.T) {
 		})
 	})
 }
+
+func TestDeletionProtection(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Metainfo.BucketDeletionProtection = true
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		upl := planet.Uplinks[0]
+		projectID := upl.Projects[0].ID
+		apiKey := upl.APIKey[sat.ID()]
+		bucketName := []byte("protected")
+
+		require.NoError(t, upl.Upload(ctx, sat, string(bucketName), "object", testrand.Bytes(memory.KiB)))
+		require.NoError(t, sat.API.Buckets.Service.SetBucketDeletionProtection(ctx, bucketName, projectID, true))
+
+		objects, err := sat.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+
+		metainfoClient, err := upl.DialMetainfo(ctx, sat, apiKey)
+		require.NoError(t, err)
+		defer ctx.Check(metainfoClient.Close)
+
+		_, err = metainfoClient.BeginDeleteObject(ctx, metaclient.BeginDeleteObjectParams{
+			Bucket:             bucketName,
+			EncryptedObjectKey: []byte(objects[0].ObjectKey),
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.PermissionDenied))
+
+		_, err = metainfoClient.DeleteBucket(ctx, metaclient.DeleteBucketParams{
+			Name:      bucketName,
+			DeleteAll: true,
+		})
+		require.True(t, errs2.IsRPC(err, rpcstatus.PermissionDenied))
+
+		// overwriting deletes the previous object, both for inline and remote objects.
+		require.Error(t, upl.Upload(ctx, sat, string(bucketName), "object", testrand.Bytes(memory.KiB)))
+		require.Error(t, upl.Upload(ctx, sat, string(bucketName), "object", testrand.Bytes(10*memory.KiB)))
+		require.NoError(t, upl.Upload(ctx, sat, string(bucketName), "other", testrand.Bytes(memory.KiB)))
+
+		objects, err = sat.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+
+		allowingKey, err := buckets.AllowProtectedDeletes(apiKey)
+		require.NoError(t, err)
+
+		allowingClient, err := upl.DialMetainfo(ctx, sat, allowingKey)
+		require.NoError(t, err)
+		defer ctx.Check(allowingClient.Close)
+
+		_, err = allowingClient.DeleteBucket(ctx, metaclient.DeleteBucketParams{
+			Name:      bucketName,
+			DeleteAll: true,
+		})
+		require.NoError(t, err)
+
+		objects, err = sat.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Empty(t, objects)
+	})
+}
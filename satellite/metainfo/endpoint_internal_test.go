@@ -29,6 +29,7 @@ func TestEndpoint_ConvertMetabaseErr(t *testing.T) {
 		{err: wrapClass.Wrap(metabase.ErrObjectNotFound.New("sql")), expect: "object not found: wrap: object not found: sql"},
 		{err: metabase.ErrSegmentNotFound.New("sql"), expect: "segment not found: sql"},
 		{err: wrapClass.Wrap(metabase.ErrSegmentNotFound.New("sql")), expect: "segment not found: wrap: segment not found: sql"},
+		{err: metabase.ErrConflict.New("modified"), expect: "metabase: conflict: modified"},
 	} {
 		out := endpoint.ConvertMetabaseErr(tc.err)
 		assert.Equal(t, tc.expect, out.Error())
@@ -210,7 +210,7 @@ func (endpoint *Endpoint) BeginObject(ctx context.Context, req *pb.ObjectBeginRe
 		return nil, endpoint.ConvertMetabaseErr(err)
 	}
 
-	satStreamID, err := endpoint.packStreamID(ctx, &internalpb.StreamID{
+	internalStreamID := &internalpb.StreamID{
 		Bucket:               []byte(object.BucketName),
 		EncryptedObjectKey:   []byte(object.ObjectKey),
 		Version:              int64(object.Version),
@@ -221,7 +221,16 @@ func (endpoint *Endpoint) BeginObject(ctx context.Context, req *pb.ObjectBeginRe
 		EncryptionParameters: req.EncryptionParameters,
 		Placement:            int32(bucket.Placement),
 		Versioned:            bucket.Versioning == buckets.VersioningEnabled,
-	})
+	}
+
+	// the bucket may override the placement defaults, e.g. for higher durability.
+	overrides := bucket.DefaultsOverride()
+	if !overrides.Redundancy.IsZero() {
+		internalStreamID.Redundancy = redundancyToProto(overrides.Redundancy)
+	}
+	internalStreamID.MaxSegmentSize = endpoint.getOverrideMaxSegmentSize(overrides)
+
+	satStreamID, err := endpoint.packStreamID(ctx, internalStreamID)
 	if err != nil {
 		endpoint.log.Error("internal", zap.Error(err))
 		return nil, rpcstatus.Error(rpcstatus.Internal, "unable to create stream id")
@@ -234,7 +243,7 @@ func (endpoint *Endpoint) BeginObject(ctx context.Context, req *pb.ObjectBeginRe
 		Bucket:             req.Bucket,
 		EncryptedObjectKey: req.EncryptedObjectKey,
 		StreamId:           satStreamID,
-		RedundancyScheme:   endpoint.getRSProto(bucket.Placement),
+		RedundancyScheme:   endpoint.getStreamRSProto(internalStreamID),
 	}, nil
 }
 
@@ -316,6 +325,9 @@ func (endpoint *Endpoint) CommitObject(ctx context.Context, req *pb.ObjectCommit
 			}
 		}
 		endpoint.usageTracking(keyInfo, req.Header, fmt.Sprintf("%T", req), tags...)
+		if committedObject != nil {
+			endpoint.recordPartnerUsage(keyInfo, req.Header, streamID.Bucket, committedObject)
+		}
 	}()
 
 	id, err := uuid.FromBytes(streamID.StreamId)
@@ -335,6 +347,25 @@ func (endpoint *Endpoint) CommitObject(ctx context.Context, req *pb.ObjectCommit
 		encryption.BlockSize = streamMeta.EncryptionBlockSize
 	}
 
+	// the versioning of the bucket may have changed since the upload began, e.g.
+	// during a long multipart upload. Like S3, the commit follows the current state.
+	versioning, err := endpoint.buckets.GetBucketVersioningState(ctx, streamID.Bucket, keyInfo.ProjectID)
+	if err != nil {
+		if buckets.ErrBucketNotFound.Has(err) {
+			return nil, rpcstatus.Errorf(rpcstatus.NotFound, "bucket not found: %s", streamID.Bucket)
+		}
+		endpoint.log.Error("unable to get bucket versioning state", zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Internal, "unable to get bucket versioning state")
+	}
+	versioned, _ := versioning.ObjectVersioning()
+
+	if !versioned {
+		allowDelete, err = endpoint.allowsOverwrite(ctx, req.Header, keyInfo.ProjectID, streamID.Bucket, allowDelete)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	request := metabase.CommitObject{
 		ObjectStream: metabase.ObjectStream{
 			ProjectID:  keyInfo.ProjectID,
@@ -347,9 +378,14 @@ func (endpoint *Endpoint) CommitObject(ctx context.Context, req *pb.ObjectCommit
 
 		DisallowDelete: !allowDelete,
 
-		Versioned:     streamID.Versioned,
+		Versioned:     versioned,
 		UseObjectLock: endpoint.config.ObjectLockEnabled(keyInfo.ProjectID),
 	}
+
+	request.Quota, err = endpoint.bucketQuota(ctx, streamID.Bucket, keyInfo.ProjectID)
+	if err != nil {
+		return nil, err
+	}
 	// uplink can send empty metadata with not empty key/nonce
 	// we need to fix it on uplink side but that part will be
 	// needed for backward compatibility
@@ -402,11 +438,166 @@ func (endpoint *Endpoint) CommitInlineObject(ctx context.Context, beginObjectReq
 ) {
 	defer mon.Task()(&ctx)(&err)
 
-	retention, err := protobufRetentionToMetabase(beginObjectReq.Retention)
+	upload, err := endpoint.validateInlineObject(ctx, beginObjectReq, makeInlineSegReq, commitObjectReq)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
+	var committedObject *metabase.Object
+	defer func() { endpoint.trackInlineObjectUsage(upload, committedObject) }()
+
+	// the object and its only segment are committed in a single transaction.
+	object, err := endpoint.metabase.CommitSingleObject(ctx, upload.opts)
+	if err != nil {
+		return nil, nil, nil, endpoint.ConvertMetabaseErr(err)
+	}
+	committedObject = &object
+
+	err = endpoint.orders.UpdatePutInlineOrder(ctx, metabase.BucketLocation{
+		ProjectID: upload.keyInfo.ProjectID, BucketName: upload.opts.BucketName,
+	}, upload.inlineUsed)
+	if err != nil {
+		endpoint.log.Error("internal", zap.Error(err))
+		return nil, nil, nil, rpcstatus.Error(rpcstatus.Internal, "unable to update PUT inline order")
+	}
+
+	if err := endpoint.addSegmentToUploadLimits(ctx, upload.keyInfo.ProjectID, upload.inlineUsed); err != nil {
+		return nil, nil, nil, err
+	}
+
+	beginObjectResp, makeInlineSegResp, commitObjectResp, err := endpoint.inlineObjectResponses(ctx, object)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	endpoint.log.Debug("Object Inline Upload", zap.Stringer("Project ID", upload.keyInfo.ProjectID), zap.String("operation", "put"), zap.String("type", "object"))
+	mon.Meter("req_put_inline_object").Mark(1)
+
+	return beginObjectResp, makeInlineSegResp, commitObjectResp, nil
+}
+
+// InlineObjectUpload contains the requests of a full inline object upload.
+type InlineObjectUpload struct {
+	BeginObject       *pb.ObjectBeginRequest
+	MakeInlineSegment *pb.SegmentMakeInlineRequest
+	CommitObject      *pb.ObjectCommitRequest
+}
+
+// InlineObjectUploadResponse contains the responses of a full inline object upload.
+type InlineObjectUploadResponse struct {
+	BeginObject       *pb.ObjectBeginResponse
+	MakeInlineSegment *pb.SegmentMakeInlineResponse
+	CommitObject      *pb.ObjectCommitResponse
+}
+
+// CommitInlineObjects commits multiple full inline objects of a single project in one
+// metabase transaction. Either all objects are committed or none of them.
+func (endpoint *Endpoint) CommitInlineObjects(ctx context.Context, uploads []InlineObjectUpload) (_ []InlineObjectUploadResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(uploads) == 0 {
+		return nil, nil
+	}
+
+	validated := make([]*inlineObjectUpload, 0, len(uploads))
+	opts := make([]metabase.CommitSingleObject, 0, len(uploads))
+	for _, upload := range uploads {
+		v, err := endpoint.validateInlineObject(ctx, upload.BeginObject, upload.MakeInlineSegment, upload.CommitObject)
+		if err != nil {
+			return nil, err
+		}
+		if len(validated) > 0 && v.keyInfo.ProjectID != validated[0].keyInfo.ProjectID {
+			return nil, rpcstatus.Error(rpcstatus.InvalidArgument, "all objects must belong to the same project")
+		}
+		validated = append(validated, v)
+		opts = append(opts, v.opts)
+	}
+
+	var committedObjects []metabase.Object
+	defer func() {
+		for i, upload := range validated {
+			var committedObject *metabase.Object
+			if i < len(committedObjects) {
+				committedObject = &committedObjects[i]
+			}
+			endpoint.trackInlineObjectUsage(upload, committedObject)
+		}
+	}()
+
+	objects, err := endpoint.metabase.CommitInlineObjects(ctx, metabase.CommitInlineObjects{
+		Objects: opts,
+	})
+	if err != nil {
+		return nil, endpoint.ConvertMetabaseErr(err)
+	}
+	committedObjects = objects
+
+	projectID := validated[0].keyInfo.ProjectID
+
+	// the orders and the upload limits are updated once per bucket and project.
+	var totalInlineUsed int64
+	bucketInlineUsed := map[metabase.BucketName]int64{}
+	var bucketNames []metabase.BucketName
+	for _, upload := range validated {
+		if _, ok := bucketInlineUsed[upload.opts.BucketName]; !ok {
+			bucketNames = append(bucketNames, upload.opts.BucketName)
+		}
+		bucketInlineUsed[upload.opts.BucketName] += upload.inlineUsed
+		totalInlineUsed += upload.inlineUsed
+	}
+
+	for _, bucketName := range bucketNames {
+		err = endpoint.orders.UpdatePutInlineOrder(ctx, metabase.BucketLocation{
+			ProjectID: projectID, BucketName: bucketName,
+		}, bucketInlineUsed[bucketName])
+		if err != nil {
+			endpoint.log.Error("internal", zap.Error(err))
+			return nil, rpcstatus.Error(rpcstatus.Internal, "unable to update PUT inline order")
+		}
+	}
+
+	if err := endpoint.addToUploadLimits(ctx, projectID, totalInlineUsed, int64(len(objects))); err != nil {
+		return nil, err
+	}
+
+	responses := make([]InlineObjectUploadResponse, 0, len(objects))
+	for _, object := range objects {
+		beginObjectResp, makeInlineSegResp, commitObjectResp, err := endpoint.inlineObjectResponses(ctx, object)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, InlineObjectUploadResponse{
+			BeginObject:       beginObjectResp,
+			MakeInlineSegment: makeInlineSegResp,
+			CommitObject:      commitObjectResp,
+		})
+	}
+
+	endpoint.log.Debug("Object Inline Upload", zap.Stringer("Project ID", projectID), zap.String("operation", "put"), zap.String("type", "object"), zap.Int("objects", len(objects)))
+	mon.Meter("req_put_inline_object").Mark(len(objects))
+	mon.IntVal("req_put_inline_objects_batch_size").Observe(int64(len(objects)))
+
+	return responses, nil
+}
+
+// inlineObjectUpload is a validated full inline object upload.
+type inlineObjectUpload struct {
+	keyInfo    *console.APIKeyInfo
+	header     *pb.RequestHeader
+	opts       metabase.CommitSingleObject
+	inlineUsed int64
+}
+
+// validateInlineObject authorizes and validates the requests of a full inline object
+// upload and returns the arguments for committing the object.
+func (endpoint *Endpoint) validateInlineObject(ctx context.Context, beginObjectReq *pb.ObjectBeginRequest, makeInlineSegReq *pb.SegmentMakeInlineRequest, commitObjectReq *pb.ObjectCommitRequest) (_ *inlineObjectUpload, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	retention, err := protobufRetentionToMetabase(beginObjectReq.Retention)
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	var allowDelete bool
 	actions := []VerifyPermission{
@@ -441,7 +632,7 @@ func (endpoint *Endpoint) CommitInlineObject(ctx context.Context, beginObjectReq
 
 	keyInfo, err := endpoint.ValidateAuthN(ctx, beginObjectReq.Header, console.RateLimitPut, actions...)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
 
 	// TODO does it make sense to track each request separately
@@ -451,22 +642,22 @@ func (endpoint *Endpoint) CommitInlineObject(ctx context.Context, beginObjectReq
 	endpoint.usageTracking(keyInfo, commitObjectReq.Header, fmt.Sprintf("%T", commitObjectReq))
 
 	if retention.Enabled() && !endpoint.config.ObjectLockEnabled(keyInfo.ProjectID) {
-		return nil, nil, nil, rpcstatus.Error(rpcstatus.FailedPrecondition, projectNoLockErrMsg)
+		return nil, rpcstatus.Error(rpcstatus.FailedPrecondition, projectNoLockErrMsg)
 	}
 
 	maxObjectTTL, err := endpoint.getMaxObjectTTL(ctx, beginObjectReq.Header)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
 
 	// TODO unify validation part between other methods to avoid duplication
 
 	if !beginObjectReq.ExpiresAt.IsZero() {
 		if beginObjectReq.ExpiresAt.Before(now) {
-			return nil, nil, nil, rpcstatus.Error(rpcstatus.InvalidArgument, "invalid expiration time, cannot be in the past")
+			return nil, rpcstatus.Error(rpcstatus.InvalidArgument, "invalid expiration time, cannot be in the past")
 		}
 		if maxObjectTTL != nil && beginObjectReq.ExpiresAt.After(now.Add(*maxObjectTTL)) {
-			return nil, nil, nil, rpcstatus.Errorf(rpcstatus.InvalidArgument, "invalid expiration time, cannot be longer than %v", maxObjectTTL)
+			return nil, rpcstatus.Errorf(rpcstatus.InvalidArgument, "invalid expiration time, cannot be longer than %v", maxObjectTTL)
 		}
 	}
 
@@ -481,68 +672,67 @@ func (endpoint *Endpoint) CommitInlineObject(ctx context.Context, beginObjectReq
 	// we can do just basic name validation because later we are checking bucket in DB
 	err = endpoint.validateBucketNameLength(beginObjectReq.Bucket)
 	if err != nil {
-		return nil, nil, nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
 	}
 
 	objectKeyLength := len(beginObjectReq.EncryptedObjectKey)
 	if objectKeyLength > endpoint.config.MaxEncryptedObjectKeyLength {
-		return nil, nil, nil, rpcstatus.Errorf(rpcstatus.InvalidArgument, "key length is too big, got %v, maximum allowed is %v", objectKeyLength, endpoint.config.MaxEncryptedObjectKeyLength)
+		return nil, rpcstatus.Errorf(rpcstatus.InvalidArgument, "key length is too big, got %v, maximum allowed is %v", objectKeyLength, endpoint.config.MaxEncryptedObjectKeyLength)
 	}
 
 	err = endpoint.checkUploadLimits(ctx, keyInfo)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
 
 	if err := endpoint.checkObjectUploadRate(ctx, keyInfo.ProjectID, beginObjectReq.Bucket, beginObjectReq.EncryptedObjectKey); err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
 
 	// TODO this needs to be optimized to avoid DB call on each request
 	bucket, err := endpoint.buckets.GetBucket(ctx, beginObjectReq.Bucket, keyInfo.ProjectID)
 	if err != nil {
 		if buckets.ErrBucketNotFound.Has(err) {
-			return nil, nil, nil, rpcstatus.Errorf(rpcstatus.NotFound, "bucket not found: %s", beginObjectReq.Bucket)
+			return nil, rpcstatus.Errorf(rpcstatus.NotFound, "bucket not found: %s", beginObjectReq.Bucket)
 		}
 		endpoint.log.Error("unable to check bucket", zap.Error(err))
-		return nil, nil, nil, rpcstatus.Error(rpcstatus.Internal, "unable to get bucket placement")
+		return nil, rpcstatus.Error(rpcstatus.Internal, "unable to get bucket placement")
 	}
 
 	if retention.Enabled() && !bucket.ObjectLockEnabled {
-		return nil, nil, nil, rpcstatus.Errorf(rpcstatus.FailedPrecondition, "cannot specify Object Lock settings when uploading into a bucket without Object Lock enabled")
+		return nil, rpcstatus.Errorf(rpcstatus.FailedPrecondition, "cannot specify Object Lock settings when uploading into a bucket without Object Lock enabled")
+	}
+
+	if allowDelete {
+		protected, err := endpoint.checkDeletionProtection(ctx, beginObjectReq.Header, bucket.DeletionProtection)
+		if err != nil {
+			return nil, err
+		}
+		allowDelete = !protected
+	}
+
+	quota, err := endpoint.bucketQuota(ctx, beginObjectReq.Bucket, keyInfo.ProjectID)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := endpoint.ensureAttribution(ctx, beginObjectReq.Header, keyInfo, beginObjectReq.Bucket, nil, false); err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
 
 	if makeInlineSegReq.Position.Index < 0 {
-		return nil, nil, nil, rpcstatus.Error(rpcstatus.InvalidArgument, "segment index must be greater then 0")
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, "segment index must be greater then 0")
 	}
 
 	inlineUsed := int64(len(makeInlineSegReq.EncryptedInlineData))
 	if inlineUsed > endpoint.encInlineSegmentSize {
-		return nil, nil, nil, rpcstatus.Errorf(rpcstatus.InvalidArgument, "inline segment size cannot be larger than %s", endpoint.config.MaxInlineSegmentSize)
+		return nil, rpcstatus.Errorf(rpcstatus.InvalidArgument, "inline segment size cannot be larger than %s", endpoint.config.MaxInlineSegmentSize)
 	}
 
-	var committedObject *metabase.Object
-	defer func() {
-		var tags []eventkit.Tag
-		if committedObject != nil {
-			tags = []eventkit.Tag{
-				eventkit.Bool("expires", committedObject.ExpiresAt != nil),
-				eventkit.Int64("segment_count", int64(committedObject.SegmentCount)),
-				eventkit.Int64("total_plain_size", committedObject.TotalPlainSize),
-				eventkit.Int64("total_encrypted_size", committedObject.TotalEncryptedSize),
-			}
-		}
-		endpoint.usageTracking(keyInfo, commitObjectReq.Header, fmt.Sprintf("%T", commitObjectReq), tags...)
-	}()
-
 	streamID, err := uuid.New()
 	if err != nil {
 		endpoint.log.Error("internal", zap.Error(err))
-		return nil, nil, nil, rpcstatus.Error(rpcstatus.Internal, "unable to create stream id")
+		return nil, rpcstatus.Error(rpcstatus.Internal, "unable to create stream id")
 	}
 
 	encryptionParameters := storj.EncryptionParameters{
@@ -562,67 +752,89 @@ func (endpoint *Endpoint) CommitInlineObject(ctx context.Context, beginObjectReq
 		StreamID:   streamID,
 	}
 
-	object, err := endpoint.metabase.CommitInlineObject(ctx, metabase.CommitInlineObject{
-		ObjectStream: objectStream,
-		CommitInlineSegment: metabase.CommitInlineSegment{
+	return &inlineObjectUpload{
+		keyInfo:    keyInfo,
+		header:     commitObjectReq.Header,
+		inlineUsed: inlineUsed,
+		opts: metabase.CommitSingleObject{
 			ObjectStream: objectStream,
 
-			ExpiresAt:         expiresAt,
-			EncryptedKey:      makeInlineSegReq.EncryptedKey,
-			EncryptedKeyNonce: makeInlineSegReq.EncryptedKeyNonce.Bytes(),
+			ExpiresAt:  expiresAt,
+			Encryption: encryptionParameters,
+
+			EncryptedMetadata:             commitObjectReq.EncryptedMetadata,
+			EncryptedMetadataEncryptedKey: commitObjectReq.EncryptedMetadataEncryptedKey,
+			EncryptedMetadataNonce:        metadataNonce,
+
+			Retention: retention,
+
+			DisallowDelete: !allowDelete,
+
 			Position: metabase.SegmentPosition{
 				Part:  uint32(makeInlineSegReq.Position.PartNumber),
 				Index: uint32(makeInlineSegReq.Position.Index),
 			},
-			PlainSize:  int32(makeInlineSegReq.PlainSize), // TODO incompatible types int32 vs int64
-			InlineData: makeInlineSegReq.EncryptedInlineData,
+			EncryptedKey:      makeInlineSegReq.EncryptedKey,
+			EncryptedKeyNonce: makeInlineSegReq.EncryptedKeyNonce.Bytes(),
+			PlainSize:         int32(makeInlineSegReq.PlainSize), // TODO incompatible types int32 vs int64
+			InlineData:        makeInlineSegReq.EncryptedInlineData,
 
 			// don't set EncryptedETag as this method won't be used with multipart upload
-		},
-
-		ExpiresAt:  expiresAt,
-		Encryption: encryptionParameters,
 
-		EncryptedMetadata:             commitObjectReq.EncryptedMetadata,
-		EncryptedMetadataEncryptedKey: commitObjectReq.EncryptedMetadataEncryptedKey,
-		EncryptedMetadataNonce:        metadataNonce,
+			Versioned: bucket.Versioning == buckets.VersioningEnabled,
 
-		Retention: retention,
+			Quota: quota,
+		},
+	}, nil
+}
 
-		DisallowDelete: !allowDelete,
+// bucketQuota returns the object and segment quota of the bucket, which is
+// enforced by the metabase when the object is committed.
+func (endpoint *Endpoint) bucketQuota(ctx context.Context, bucketName []byte, projectID uuid.UUID) (_ metabase.BucketQuota, err error) {
+	defer mon.Task()(&ctx)(&err)
 
-		Versioned: bucket.Versioning == buckets.VersioningEnabled,
-	})
-	if err != nil {
-		return nil, nil, nil, endpoint.ConvertMetabaseErr(err)
+	if !endpoint.config.BucketQuotas {
+		return metabase.BucketQuota{}, nil
 	}
 
-	err = endpoint.orders.UpdatePutInlineOrder(ctx, metabase.BucketLocation{
-		ProjectID: keyInfo.ProjectID, BucketName: metabase.BucketName(beginObjectReq.Bucket),
-	}, inlineUsed)
+	quota, err := endpoint.buckets.GetBucketQuota(ctx, bucketName, projectID)
 	if err != nil {
-		endpoint.log.Error("internal", zap.Error(err))
-		return nil, nil, nil, rpcstatus.Error(rpcstatus.Internal, "unable to update PUT inline order")
+		endpoint.log.Error("unable to get bucket quota", zap.Error(err))
+		return metabase.BucketQuota{}, rpcstatus.Error(rpcstatus.Internal, "unable to get bucket quota")
 	}
+	return quota.Metabase(), nil
+}
 
-	if err := endpoint.addSegmentToUploadLimits(ctx, keyInfo.ProjectID, inlineUsed); err != nil {
-		return nil, nil, nil, err
+// trackInlineObjectUsage tracks the usage of a full inline object upload.
+func (endpoint *Endpoint) trackInlineObjectUsage(upload *inlineObjectUpload, committedObject *metabase.Object) {
+	var tags []eventkit.Tag
+	if committedObject != nil {
+		tags = []eventkit.Tag{
+			eventkit.Bool("expires", committedObject.ExpiresAt != nil),
+			eventkit.Int64("segment_count", int64(committedObject.SegmentCount)),
+			eventkit.Int64("total_plain_size", committedObject.TotalPlainSize),
+			eventkit.Int64("total_encrypted_size", committedObject.TotalEncryptedSize),
+		}
+	}
+	endpoint.usageTracking(upload.keyInfo, upload.header, fmt.Sprintf("%T", &pb.ObjectCommitRequest{}), tags...)
+	if committedObject != nil {
+		endpoint.recordPartnerUsage(upload.keyInfo, upload.header, []byte(committedObject.BucketName), committedObject)
 	}
+}
 
+// inlineObjectResponses returns the responses of a committed full inline object upload.
+func (endpoint *Endpoint) inlineObjectResponses(ctx context.Context, object metabase.Object) (*pb.ObjectBeginResponse, *pb.SegmentMakeInlineResponse, *pb.ObjectCommitResponse, error) {
 	pbObject, err := endpoint.objectToProto(ctx, object)
 	if err != nil {
 		endpoint.log.Error("unable to convert metabase object", zap.Error(err))
 		return nil, nil, nil, rpcstatus.Error(rpcstatus.Internal, "internal error")
 	}
 
-	endpoint.log.Debug("Object Inline Upload", zap.Stringer("Project ID", keyInfo.ProjectID), zap.String("operation", "put"), zap.String("type", "object"))
-	mon.Meter("req_put_inline_object").Mark(1)
-
 	return &pb.ObjectBeginResponse{
-			StreamId: storj.StreamID{1}, // return dummy stream id as it won't be really used later
-		}, &pb.SegmentMakeInlineResponse{}, &pb.ObjectCommitResponse{
-			Object: pbObject,
-		}, nil
+		StreamId: storj.StreamID{1}, // return dummy stream id as it won't be really used later
+	}, &pb.SegmentMakeInlineResponse{}, &pb.ObjectCommitResponse{
+		Object: pbObject,
+	}, nil
 }
 
 // GetObject gets single object metadata.
@@ -830,14 +1042,18 @@ func (endpoint *Endpoint) DownloadObject(ctx context.Context, req *pb.ObjectDown
 	}
 
 	var object metabase.Object
+	// singleSegment is the only segment of the object, when it was fetched together with the object.
+	var singleSegment *metabase.Segment
 	if len(req.ObjectVersion) == 0 {
-		object, err = endpoint.metabase.GetObjectLastCommitted(ctx, metabase.GetObjectLastCommitted{
+		var result metabase.ObjectWithSegment
+		result, err = endpoint.metabase.GetObjectWithSegment(ctx, metabase.GetObjectWithSegment{
 			ObjectLocation: metabase.ObjectLocation{
 				ProjectID:  keyInfo.ProjectID,
 				BucketName: metabase.BucketName(req.Bucket),
 				ObjectKey:  metabase.ObjectKey(req.EncryptedObjectKey),
 			},
 		})
+		object, singleSegment = result.Object, result.Segment
 	} else {
 		var sv metabase.StreamVersionID
 		sv, err = metabase.StreamVersionIDFromBytes(req.ObjectVersion)
@@ -883,14 +1099,20 @@ func (endpoint *Endpoint) DownloadObject(ctx context.Context, req *pb.ObjectDown
 		endpoint.usageTracking(keyInfo, req.Header, fmt.Sprintf("%T", req), tags...)
 	}
 
-	segments, err := endpoint.metabase.ListSegments(ctx, metabase.ListSegments{
-		ProjectID: keyInfo.ProjectID,
-		StreamID:  object.StreamID,
-		Range:     streamRange,
-		Limit:     int(req.Limit),
-	})
-	if err != nil {
-		return nil, endpoint.ConvertMetabaseErr(err)
+	var segments metabase.ListSegmentsResult
+	if singleSegment != nil && streamRange == nil {
+		// the whole object is downloaded, so its only segment is all that's needed.
+		segments.Segments = []metabase.Segment{*singleSegment}
+	} else {
+		segments, err = endpoint.metabase.ListSegments(ctx, metabase.ListSegments{
+			ProjectID: keyInfo.ProjectID,
+			StreamID:  object.StreamID,
+			Range:     streamRange,
+			Limit:     int(req.Limit),
+		})
+		if err != nil {
+			return nil, endpoint.ConvertMetabaseErr(err)
+		}
 	}
 
 	// get the download response for the first segment
@@ -1626,7 +1848,15 @@ func (endpoint *Endpoint) BeginDeleteObject(ctx context.Context, req *pb.ObjectB
 			}
 		}
 	} else {
-		deletedObjects, err = endpoint.DeleteCommittedObject(ctx, keyInfo.ProjectID, string(req.Bucket), metabase.ObjectKey(req.EncryptedObjectKey), req.ObjectVersion)
+		var protected bool
+		protected, err = endpoint.isDeletionProtected(ctx, req.Header, keyInfo.ProjectID, req.Bucket)
+		if buckets.ErrBucketNotFound.Has(err) {
+			// deleting from a missing bucket is handled the same way as without deletion protection.
+			protected, err = false, nil
+		}
+		if err == nil {
+			deletedObjects, err = endpoint.deleteCommittedObject(ctx, keyInfo.ProjectID, string(req.Bucket), metabase.ObjectKey(req.EncryptedObjectKey), req.ObjectVersion, protected)
+		}
 	}
 	if err != nil {
 		if !canRead && !canList {
@@ -2190,6 +2420,14 @@ func (endpoint *Endpoint) objectEntryToProtoListItem(ctx context.Context, bucket
 // having import cycles.
 func (endpoint *Endpoint) DeleteCommittedObject(
 	ctx context.Context, projectID uuid.UUID, bucket string, object metabase.ObjectKey, version []byte,
+) (deletedObjects []*pb.Object, err error) {
+	return endpoint.deleteCommittedObject(ctx, projectID, bucket, object, version, false)
+}
+
+// deleteCommittedObject deletes the specified object. When deletionProtected is set, only
+// a delete marker may be inserted into a versioned bucket.
+func (endpoint *Endpoint) deleteCommittedObject(
+	ctx context.Context, projectID uuid.UUID, bucket string, object metabase.ObjectKey, version []byte, deletionProtected bool,
 ) (deletedObjects []*pb.Object, err error) {
 	defer mon.Task()(&ctx, projectID.String(), bucket, object)(&err)
 
@@ -2232,15 +2470,16 @@ func (endpoint *Endpoint) DeleteCommittedObject(
 				return nil, rpcstatus.Error(rpcstatus.Internal, "unable to get bucket versioning state")
 			}
 
-			versioned = bucket.Versioning == buckets.VersioningEnabled
-			suspended = bucket.Versioning == buckets.VersioningSuspended
+			versioned, suspended = bucket.Versioning.ObjectVersioning()
 		}
 
 		result, err = endpoint.metabase.DeleteObjectLastCommitted(ctx, metabase.DeleteObjectLastCommitted{
-			ObjectLocation: req,
-			Versioned:      versioned,
-			Suspended:      suspended,
-			UseObjectLock:  bucketLockEnabled,
+			ObjectLocation:        req,
+			Versioned:             versioned,
+			Suspended:             suspended,
+			UseObjectLock:         bucketLockEnabled,
+			DeletionProtected:     deletionProtected,
+			ReturnDeletedSegments: endpoint.sendsPieceDeletions(),
 		})
 		if err != nil {
 			return nil, Error.Wrap(err)
@@ -2252,15 +2491,21 @@ func (endpoint *Endpoint) DeleteCommittedObject(
 			return nil, err
 		}
 		result, err = endpoint.metabase.DeleteObjectExactVersion(ctx, metabase.DeleteObjectExactVersion{
-			ObjectLocation: req,
-			Version:        sv.Version(),
-			UseObjectLock:  bucketLockEnabled,
+			ObjectLocation:        req,
+			Version:               sv.Version(),
+			UseObjectLock:         bucketLockEnabled,
+			DeletionProtected:     deletionProtected,
+			ReturnDeletedSegments: endpoint.sendsPieceDeletions(),
 		})
 	}
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
 
+	if len(result.DeletedSegments) > 0 {
+		endpoint.pieceDeletion.Delete(ctx, result.DeletedSegments)
+	}
+
 	deletedObjects, err = endpoint.deleteObjectResultToProto(ctx, result)
 	if err != nil {
 		endpoint.log.Error("failed to convert delete object result",
@@ -2284,6 +2529,9 @@ func (endpoint *Endpoint) DeletePendingObject(ctx context.Context, stream metaba
 	req := metabase.DeletePendingObject{
 		ObjectStream: stream,
 	}
+	if endpoint.config.PendingObjectDeletionGracePeriod > 0 {
+		req.NoCommittedSegmentsSince = time.Now().Add(-endpoint.config.PendingObjectDeletionGracePeriod)
+	}
 
 	result, err := endpoint.metabase.DeletePendingObject(ctx, req)
 	if err != nil {
@@ -2558,8 +2806,8 @@ func (endpoint *Endpoint) BeginCopyObject(ctx context.Context, req *pb.ObjectBeg
 	endpoint.versionCollector.collect(req.Header.UserAgent, mon.Func().ShortName())
 
 	now := time.Now()
-	keyInfo, err := endpoint.ValidateAuthN(ctx, req.Header, console.RateLimitPut,
-		VerifyPermission{
+	permissions := []VerifyPermission{
+		{
 			Action: macaroon.Action{
 				Op:            macaroon.ActionRead,
 				Bucket:        req.Bucket,
@@ -2567,15 +2815,21 @@ func (endpoint *Endpoint) BeginCopyObject(ctx context.Context, req *pb.ObjectBeg
 				Time:          now,
 			},
 		},
-		VerifyPermission{
+	}
+	// with cross-project copy, the destination may belong to a different project,
+	// so it's verified when the copy is finished with an API key of that project.
+	if !endpoint.config.CrossProjectCopy {
+		permissions = append(permissions, VerifyPermission{
 			Action: macaroon.Action{
 				Op:            macaroon.ActionWrite,
 				Bucket:        req.NewBucket,
 				EncryptedPath: req.NewEncryptedObjectKey,
 				Time:          now,
 			},
-		},
-	)
+		})
+	}
+
+	keyInfo, err := endpoint.ValidateAuthN(ctx, req.Header, console.RateLimitPut, permissions...)
 	if err != nil {
 		return nil, err
 	}
@@ -2592,8 +2846,19 @@ func (endpoint *Endpoint) BeginCopyObject(ctx context.Context, req *pb.ObjectBeg
 		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
 	}
 
-	// if source and target buckets are different, we need to check their geofencing configs
-	if !bytes.Equal(req.Bucket, req.NewBucket) {
+	var sourcePlacement storj.PlacementConstraint
+	if endpoint.config.CrossProjectCopy {
+		// the placement of the destination is compared when the copy is finished.
+		sourcePlacement, err = endpoint.buckets.GetBucketPlacement(ctx, req.Bucket, keyInfo.ProjectID)
+		if err != nil {
+			if buckets.ErrBucketNotFound.Has(err) {
+				return nil, rpcstatus.Errorf(rpcstatus.NotFound, "bucket not found: %s", req.Bucket)
+			}
+			endpoint.log.Error("unable to check bucket", zap.Error(err))
+			return nil, rpcstatus.Error(rpcstatus.Internal, "unable to get bucket placement")
+		}
+	} else if !bytes.Equal(req.Bucket, req.NewBucket) {
+		// if source and target buckets are different, we need to check their geofencing configs
 		// TODO we may try to combine those two DB calls into single one
 		oldBucketPlacement, err := endpoint.buckets.GetBucketPlacement(ctx, req.Bucket, keyInfo.ProjectID)
 		if err != nil {
@@ -2634,6 +2899,10 @@ func (endpoint *Endpoint) BeginCopyObject(ctx context.Context, req *pb.ObjectBeg
 		},
 		Version: version,
 		VerifyLimits: func(encryptedObjectSize int64, nSegments int64) error {
+			// the limits of the destination project are verified when the copy is finished.
+			if endpoint.config.CrossProjectCopy {
+				return nil
+			}
 			return endpoint.checkUploadLimitsForNewObject(ctx, keyInfo, encryptedObjectSize, nSegments)
 		},
 	})
@@ -2656,6 +2925,8 @@ func (endpoint *Endpoint) BeginCopyObject(ctx context.Context, req *pb.ObjectBeg
 			CipherSuite: pb.CipherSuite(result.EncryptionParameters.CipherSuite),
 			BlockSize:   int64(result.EncryptionParameters.BlockSize),
 		},
+		Placement: int32(sourcePlacement),
+		ProjectId: keyInfo.ProjectID.Bytes(),
 	})
 	if err != nil {
 		endpoint.log.Error("internal", zap.Error(err))
@@ -2780,6 +3051,38 @@ func (endpoint *Endpoint) FinishCopyObject(ctx context.Context, req *pb.ObjectFi
 		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
 	}
 
+	sourceProjectID := keyInfo.ProjectID
+	if len(streamID.ProjectId) > 0 {
+		sourceProjectID, err = uuid.FromBytes(streamID.ProjectId)
+		if err != nil {
+			return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+		}
+	}
+
+	if endpoint.config.CrossProjectCopy {
+		err = endpoint.verifyCopyDestination(ctx, streamID, sourceProjectID, keyInfo.ProjectID, req.NewBucket)
+		if err != nil {
+			return nil, err
+		}
+	} else if sourceProjectID != keyInfo.ProjectID {
+		return nil, rpcstatus.Error(rpcstatus.PermissionDenied, "copying objects between projects is not enabled")
+	}
+
+	if sourceProjectID != keyInfo.ProjectID {
+		// the data is new to the destination project, the same way as with an upload.
+		if err := endpoint.ensureAttribution(ctx, req.Header, keyInfo, req.NewBucket, nil, false); err != nil {
+			return nil, err
+		}
+	}
+
+	allowOverwrite := true
+	if !versioningEnabled {
+		allowOverwrite, err = endpoint.allowsOverwrite(ctx, req.Header, keyInfo.ProjectID, req.NewBucket, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	newStreamID, err := uuid.New()
 	if err != nil {
 		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
@@ -2787,12 +3090,13 @@ func (endpoint *Endpoint) FinishCopyObject(ctx context.Context, req *pb.ObjectFi
 
 	object, err := endpoint.metabase.FinishCopyObject(ctx, metabase.FinishCopyObject{
 		ObjectStream: metabase.ObjectStream{
-			ProjectID:  keyInfo.ProjectID,
+			ProjectID:  sourceProjectID,
 			BucketName: metabase.BucketName(streamID.Bucket),
 			ObjectKey:  metabase.ObjectKey(streamID.EncryptedObjectKey),
 			Version:    metabase.Version(streamID.Version),
 			StreamID:   streamUUID,
 		},
+		NewProjectID:                 keyInfo.ProjectID,
 		NewStreamID:                  newStreamID,
 		NewSegmentKeys:               protobufkeysToMetabase(req.NewSegmentKeys),
 		NewBucket:                    metabase.BucketName(req.NewBucket),
@@ -2803,7 +3107,7 @@ func (endpoint *Endpoint) FinishCopyObject(ctx context.Context, req *pb.ObjectFi
 		NewEncryptedMetadataKey:      req.NewEncryptedMetadataKey,
 
 		// TODO(ver): currently we always allow deletion, to not change behaviour.
-		NewDisallowDelete: false,
+		NewDisallowDelete: !allowOverwrite,
 
 		NewVersioned: versioningEnabled,
 
@@ -2824,14 +3128,45 @@ func (endpoint *Endpoint) FinishCopyObject(ctx context.Context, req *pb.ObjectFi
 		return nil, rpcstatus.Error(rpcstatus.Internal, "internal error")
 	}
 
-	endpoint.log.Debug("Object Copy Finished", zap.Stringer("Project ID", keyInfo.ProjectID), zap.String("operation", "copy"), zap.String("type", "object"))
+	endpoint.log.Debug("Object Copy Finished", zap.Stringer("Project ID", keyInfo.ProjectID), zap.Stringer("Source Project ID", sourceProjectID), zap.String("operation", "copy"), zap.String("type", "object"))
 	mon.Meter("req_copy_object_finished").Mark(1)
+	if sourceProjectID != keyInfo.ProjectID {
+		mon.Meter("req_copy_object_cross_project_finished").Mark(1)
+	}
 
 	return &pb.ObjectFinishCopyResponse{
 		Object: protoObject,
 	}, nil
 }
 
+// verifyCopyDestination verifies the destination of a copy, which was begun
+// with cross-project copy enabled. The destination wasn't verified when the
+// copy was begun, because it may belong to a different project.
+func (endpoint *Endpoint) verifyCopyDestination(ctx context.Context, streamID *internalpb.StreamID, sourceProjectID, projectID uuid.UUID, newBucket []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if sourceProjectID != projectID && streamID.CreationDate.Before(time.Now().Add(-satIDExpiration)) {
+		return rpcstatus.Error(rpcstatus.InvalidArgument, "stream ID expired")
+	}
+
+	if sourceProjectID == projectID && bytes.Equal(streamID.Bucket, newBucket) {
+		return nil
+	}
+
+	newBucketPlacement, err := endpoint.buckets.GetBucketPlacement(ctx, newBucket, projectID)
+	if err != nil {
+		if buckets.ErrBucketNotFound.Has(err) {
+			return rpcstatus.Errorf(rpcstatus.NotFound, "bucket not found: %s", newBucket)
+		}
+		endpoint.log.Error("unable to check bucket", zap.Error(err))
+		return rpcstatus.Error(rpcstatus.Internal, "unable to get bucket placement")
+	}
+	if storj.PlacementConstraint(streamID.Placement) != newBucketPlacement {
+		return rpcstatus.Error(rpcstatus.InvalidArgument, "copying object to bucket with different placement policy is not (yet) supported")
+	}
+	return nil
+}
+
 // protobufkeysToMetabase converts []*pb.EncryptedKeyAndNonce to []metabase.EncryptedKeyAndNonce.
 func protobufkeysToMetabase(protoKeys []*pb.EncryptedKeyAndNonce) []metabase.EncryptedKeyAndNonce {
 	keys := make([]metabase.EncryptedKeyAndNonce, len(protoKeys))
@@ -0,0 +1,150 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metainfo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/buckets"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/internalpb"
+	"storj.io/storj/satellite/metabase"
+)
+
+var _ internalpb.DRPCObjectLegalHoldServer = (*Endpoint)(nil)
+
+// GetObjectLegalHold returns whether an object is under an Object Lock legal hold.
+func (endpoint *Endpoint) GetObjectLegalHold(ctx context.Context, req *internalpb.GetObjectLegalHoldRequest) (_ *internalpb.GetObjectLegalHoldResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	endpoint.versionCollector.collect(req.Header.UserAgent, mon.Func().ShortName())
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionLock,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedObjectKey,
+		Time:          time.Now(),
+	}, console.RateLimitHead)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.usageTracking(keyInfo, req.Header, fmt.Sprintf("%T", req))
+
+	loc, err := endpoint.validateLegalHoldRequest(ctx, keyInfo.ProjectID, req.Bucket, req.EncryptedObjectKey, req.ObjectVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var enabled bool
+	if len(req.ObjectVersion) == 0 {
+		enabled, err = endpoint.metabase.GetObjectLastCommittedLegalHold(ctx, metabase.GetObjectLastCommittedLegalHold{
+			ObjectLocation: loc,
+		})
+	} else {
+		var sv metabase.StreamVersionID
+		sv, err = metabase.StreamVersionIDFromBytes(req.ObjectVersion)
+		if err != nil {
+			return nil, endpoint.ConvertMetabaseErr(err)
+		}
+		enabled, err = endpoint.metabase.GetObjectExactVersionLegalHold(ctx, metabase.GetObjectExactVersionLegalHold{
+			ObjectLocation: loc,
+			Version:        sv.Version(),
+		})
+	}
+	if err != nil {
+		return nil, endpoint.ConvertMetabaseErr(err)
+	}
+
+	return &internalpb.GetObjectLegalHoldResponse{Enabled: enabled}, nil
+}
+
+// SetObjectLegalHold places or removes an Object Lock legal hold on an object.
+func (endpoint *Endpoint) SetObjectLegalHold(ctx context.Context, req *internalpb.SetObjectLegalHoldRequest) (_ *internalpb.SetObjectLegalHoldResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	endpoint.versionCollector.collect(req.Header.UserAgent, mon.Func().ShortName())
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionLock,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedObjectKey,
+		Time:          time.Now(),
+	}, console.RateLimitPut)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.usageTracking(keyInfo, req.Header, fmt.Sprintf("%T", req))
+
+	loc, err := endpoint.validateLegalHoldRequest(ctx, keyInfo.ProjectID, req.Bucket, req.EncryptedObjectKey, req.ObjectVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.ObjectVersion) == 0 {
+		err = endpoint.metabase.SetObjectLastCommittedLegalHold(ctx, metabase.SetObjectLastCommittedLegalHold{
+			ObjectLocation: loc,
+			Enabled:        req.Enabled,
+		})
+	} else {
+		var sv metabase.StreamVersionID
+		sv, err = metabase.StreamVersionIDFromBytes(req.ObjectVersion)
+		if err != nil {
+			return nil, endpoint.ConvertMetabaseErr(err)
+		}
+		err = endpoint.metabase.SetObjectExactVersionLegalHold(ctx, metabase.SetObjectExactVersionLegalHold{
+			ObjectLocation: loc,
+			Version:        sv.Version(),
+			Enabled:        req.Enabled,
+		})
+	}
+	if err != nil {
+		if metabase.ErrObjectStatus.Has(err) || metabase.ErrObjectExpiration.Has(err) {
+			return nil, rpcstatus.Error(rpcstatus.FailedPrecondition, err.Error())
+		}
+		return nil, endpoint.ConvertMetabaseErr(err)
+	}
+
+	return &internalpb.SetObjectLegalHoldResponse{}, nil
+}
+
+// validateLegalHoldRequest validates the common fields of the legal hold requests
+// and checks that Object Lock is enabled for the bucket.
+func (endpoint *Endpoint) validateLegalHoldRequest(ctx context.Context, projectID uuid.UUID, bucket, encryptedObjectKey, objectVersion []byte) (metabase.ObjectLocation, error) {
+	if err := endpoint.validateBucketNameLength(bucket); err != nil {
+		return metabase.ObjectLocation{}, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	if err := validateObjectVersion(objectVersion); err != nil {
+		return metabase.ObjectLocation{}, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	if !endpoint.config.ObjectLockEnabled(projectID) {
+		return metabase.ObjectLocation{}, rpcstatus.Error(rpcstatus.FailedPrecondition, projectNoLockErrMsg)
+	}
+
+	bucketLockEnabled, err := endpoint.buckets.GetBucketObjectLockEnabled(ctx, bucket, projectID)
+	if err != nil {
+		if buckets.ErrBucketNotFound.Has(err) {
+			return metabase.ObjectLocation{}, rpcstatus.Errorf(rpcstatus.NotFound, "bucket not found: %s", string(bucket))
+		}
+		endpoint.log.Error("unable to get bucket's Object Lock configuration", zap.Error(err))
+		return metabase.ObjectLocation{}, rpcstatus.Error(rpcstatus.Internal, "unable to get bucket's Object Lock configuration")
+	}
+	if !bucketLockEnabled {
+		return metabase.ObjectLocation{}, rpcstatus.Error(rpcstatus.FailedPrecondition, bucketNoLockErrMsg)
+	}
+
+	return metabase.ObjectLocation{
+		ProjectID:  projectID,
+		BucketName: metabase.BucketName(bucket),
+		ObjectKey:  metabase.ObjectKey(encryptedObjectKey),
+	}, nil
+}
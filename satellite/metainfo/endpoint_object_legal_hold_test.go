@@ -0,0 +1,128 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metainfo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/pb"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/rpc/rpctest"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/buckets"
+	"storj.io/storj/satellite/internalpb"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestEndpoint_ObjectLegalHold(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Metainfo.UseBucketLevelObjectLock = true
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		project := planet.Uplinks[0].Projects[0]
+		endpoint := sat.Metainfo.Endpoint
+
+		userCtx, err := sat.UserContext(ctx, project.Owner.ID)
+		require.NoError(t, err)
+
+		_, apiKey, err := sat.API.Console.Service.CreateAPIKey(userCtx, project.ID, "test key", macaroon.APIKeyVersionObjectLock)
+		require.NoError(t, err)
+		header := &pb.RequestHeader{ApiKey: apiKey.SerializeRaw()}
+
+		conn, err := planet.Uplinks[0].Dialer.DialNodeURL(ctx, sat.NodeURL())
+		require.NoError(t, err)
+		defer ctx.Check(conn.Close)
+		client := internalpb.NewDRPCObjectLegalHoldClient(conn)
+
+		createBucket := func(t *testing.T, lockEnabled bool) string {
+			name := testrand.BucketName()
+			_, err := sat.DB.Buckets().CreateBucket(ctx, buckets.Bucket{
+				Name:              name,
+				ProjectID:         project.ID,
+				Versioning:        buckets.VersioningEnabled,
+				ObjectLockEnabled: lockEnabled,
+			})
+			require.NoError(t, err)
+			return name
+		}
+
+		t.Run("Place and remove", func(t *testing.T) {
+			bucketName := createBucket(t, true)
+			object := metabasetest.CreateObjectVersioned(ctx, t, sat.Metabase.DB, randObjectStream(project.ID, bucketName), 0)
+
+			_, err := client.SetObjectLegalHold(ctx, &internalpb.SetObjectLegalHoldRequest{
+				Header:             header,
+				Bucket:             []byte(bucketName),
+				EncryptedObjectKey: []byte(object.ObjectKey),
+				ObjectVersion:      object.StreamVersionID().Bytes(),
+				Enabled:            true,
+			})
+			require.NoError(t, err)
+
+			resp, err := client.GetObjectLegalHold(ctx, &internalpb.GetObjectLegalHoldRequest{
+				Header:             header,
+				Bucket:             []byte(bucketName),
+				EncryptedObjectKey: []byte(object.ObjectKey),
+			})
+			require.NoError(t, err)
+			require.True(t, resp.Enabled)
+
+			deleteReq := &pb.BeginDeleteObjectRequest{
+				Header:             header,
+				Bucket:             []byte(bucketName),
+				EncryptedObjectKey: []byte(object.ObjectKey),
+				ObjectVersion:      object.StreamVersionID().Bytes(),
+			}
+			_, err = endpoint.BeginDeleteObject(ctx, deleteReq)
+			rpctest.RequireCode(t, err, rpcstatus.PermissionDenied)
+
+			_, err = client.SetObjectLegalHold(ctx, &internalpb.SetObjectLegalHoldRequest{
+				Header:             header,
+				Bucket:             []byte(bucketName),
+				EncryptedObjectKey: []byte(object.ObjectKey),
+			})
+			require.NoError(t, err)
+
+			deleteResp, err := endpoint.BeginDeleteObject(ctx, deleteReq)
+			require.NoError(t, err)
+			require.NotNil(t, deleteResp.Object)
+		})
+
+		t.Run("Missing object", func(t *testing.T) {
+			bucketName := createBucket(t, true)
+
+			_, err := client.GetObjectLegalHold(ctx, &internalpb.GetObjectLegalHoldRequest{
+				Header:             header,
+				Bucket:             []byte(bucketName),
+				EncryptedObjectKey: []byte(metabasetest.RandObjectKey()),
+			})
+			rpctest.RequireCode(t, err, rpcstatus.NotFound)
+		})
+
+		t.Run("Object Lock disabled for bucket", func(t *testing.T) {
+			bucketName := createBucket(t, false)
+			object := metabasetest.CreateObjectVersioned(ctx, t, sat.Metabase.DB, randObjectStream(project.ID, bucketName), 0)
+
+			_, err := client.SetObjectLegalHold(ctx, &internalpb.SetObjectLegalHoldRequest{
+				Header:             header,
+				Bucket:             []byte(bucketName),
+				EncryptedObjectKey: []byte(object.ObjectKey),
+				Enabled:            true,
+			})
+			rpctest.RequireCode(t, err, rpcstatus.FailedPrecondition)
+		})
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metainfo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/internalpb"
+	"storj.io/storj/satellite/metabase"
+)
+
+var _ internalpb.DRPCObjectTagsServer = (*Endpoint)(nil)
+
+// GetObjectTagging returns the tags of an object. When the object version is
+// empty, the tags of the last committed version are returned.
+func (endpoint *Endpoint) GetObjectTagging(ctx context.Context, req *internalpb.GetObjectTaggingRequest) (_ *internalpb.GetObjectTaggingResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	endpoint.versionCollector.collect(req.Header.UserAgent, mon.Func().ShortName())
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionRead,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedObjectKey,
+		Time:          time.Now(),
+	}, console.RateLimitHead)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.usageTracking(keyInfo, req.Header, fmt.Sprintf("%T", req))
+
+	loc, err := endpoint.validateObjectTaggingRequest(keyInfo.ProjectID, req.Bucket, req.EncryptedObjectKey, req.ObjectVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags metabase.ObjectTags
+	if len(req.ObjectVersion) == 0 {
+		tags, err = endpoint.metabase.GetObjectLastCommittedTags(ctx, metabase.GetObjectLastCommittedTags{
+			ObjectLocation: loc,
+		})
+	} else {
+		var sv metabase.StreamVersionID
+		sv, err = metabase.StreamVersionIDFromBytes(req.ObjectVersion)
+		if err != nil {
+			return nil, endpoint.ConvertMetabaseErr(err)
+		}
+		tags, err = endpoint.metabase.GetObjectExactVersionTags(ctx, metabase.GetObjectExactVersionTags{
+			ObjectLocation: loc,
+			Version:        sv.Version(),
+		})
+	}
+	if err != nil {
+		return nil, endpoint.ConvertMetabaseErr(err)
+	}
+
+	resp := &internalpb.GetObjectTaggingResponse{}
+	for _, tag := range tags {
+		resp.Tags = append(resp.Tags, &internalpb.ObjectTag{
+			Key:   tag.Key,
+			Value: tag.Value,
+		})
+	}
+	return resp, nil
+}
+
+// PutObjectTagging replaces the tags of an object. Empty tags remove them.
+// When the object version is empty, the last committed version is updated.
+func (endpoint *Endpoint) PutObjectTagging(ctx context.Context, req *internalpb.PutObjectTaggingRequest) (_ *internalpb.PutObjectTaggingResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	endpoint.versionCollector.collect(req.Header.UserAgent, mon.Func().ShortName())
+
+	keyInfo, err := endpoint.validateAuth(ctx, req.Header, macaroon.Action{
+		Op:            macaroon.ActionWrite,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.EncryptedObjectKey,
+		Time:          time.Now(),
+	}, console.RateLimitPut)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.usageTracking(keyInfo, req.Header, fmt.Sprintf("%T", req))
+
+	loc, err := endpoint.validateObjectTaggingRequest(keyInfo.ProjectID, req.Bucket, req.EncryptedObjectKey, req.ObjectVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(metabase.ObjectTags, 0, len(req.Tags))
+	for _, tag := range req.Tags {
+		tags = append(tags, metabase.ObjectTag{
+			Key:   tag.Key,
+			Value: tag.Value,
+		})
+	}
+
+	if len(req.ObjectVersion) == 0 {
+		err = endpoint.metabase.SetObjectLastCommittedTags(ctx, metabase.SetObjectLastCommittedTags{
+			ObjectLocation: loc,
+			Tags:           tags,
+		})
+	} else {
+		var sv metabase.StreamVersionID
+		sv, err = metabase.StreamVersionIDFromBytes(req.ObjectVersion)
+		if err != nil {
+			return nil, endpoint.ConvertMetabaseErr(err)
+		}
+		err = endpoint.metabase.SetObjectExactVersionTags(ctx, metabase.SetObjectExactVersionTags{
+			ObjectLocation: loc,
+			Version:        sv.Version(),
+			Tags:           tags,
+		})
+	}
+	if err != nil {
+		return nil, endpoint.ConvertMetabaseErr(err)
+	}
+
+	return &internalpb.PutObjectTaggingResponse{}, nil
+}
+
+// validateObjectTaggingRequest validates the common fields of the object tagging requests.
+func (endpoint *Endpoint) validateObjectTaggingRequest(projectID uuid.UUID, bucket, encryptedObjectKey, objectVersion []byte) (metabase.ObjectLocation, error) {
+	if err := endpoint.validateBucketNameLength(bucket); err != nil {
+		return metabase.ObjectLocation{}, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	if err := validateObjectVersion(objectVersion); err != nil {
+		return metabase.ObjectLocation{}, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
+	}
+
+	return metabase.ObjectLocation{
+		ProjectID:  projectID,
+		BucketName: metabase.BucketName(bucket),
+		ObjectKey:  metabase.ObjectKey(encryptedObjectKey),
+	}, nil
+}
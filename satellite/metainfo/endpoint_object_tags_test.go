@@ -0,0 +1,140 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metainfo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/pb"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/rpc/rpctest"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/buckets"
+	"storj.io/storj/satellite/internalpb"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestEndpoint_ObjectTagging(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		project := planet.Uplinks[0].Projects[0]
+		header := &pb.RequestHeader{ApiKey: planet.Uplinks[0].APIKey[sat.ID()].SerializeRaw()}
+
+		conn, err := planet.Uplinks[0].Dialer.DialNodeURL(ctx, sat.NodeURL())
+		require.NoError(t, err)
+		defer ctx.Check(conn.Close)
+		client := internalpb.NewDRPCObjectTagsClient(conn)
+
+		bucketName := testrand.BucketName()
+		_, err = sat.DB.Buckets().CreateBucket(ctx, buckets.Bucket{
+			Name:       bucketName,
+			ProjectID:  project.ID,
+			Versioning: buckets.VersioningEnabled,
+		})
+		require.NoError(t, err)
+
+		older := metabasetest.CreateObjectVersioned(ctx, t, sat.Metabase.DB, randObjectStream(project.ID, bucketName), 0)
+		stream := randObjectStream(project.ID, bucketName)
+		stream.ObjectKey = older.ObjectKey
+		stream.Version = older.Version + 1
+		latest := metabasetest.CreateObjectVersioned(ctx, t, sat.Metabase.DB, stream, 0)
+
+		tags := []*internalpb.ObjectTag{
+			{Key: "project", Value: "storj"},
+			{Key: "state", Value: "archived"},
+		}
+
+		t.Run("last committed", func(t *testing.T) {
+			_, err := client.PutObjectTagging(ctx, &internalpb.PutObjectTaggingRequest{
+				Header:             header,
+				Bucket:             []byte(bucketName),
+				EncryptedObjectKey: []byte(latest.ObjectKey),
+				Tags:               tags,
+			})
+			require.NoError(t, err)
+
+			resp, err := client.GetObjectTagging(ctx, &internalpb.GetObjectTaggingRequest{
+				Header:             header,
+				Bucket:             []byte(bucketName),
+				EncryptedObjectKey: []byte(latest.ObjectKey),
+			})
+			require.NoError(t, err)
+			require.Len(t, resp.Tags, 2)
+			require.Equal(t, "project", resp.Tags[0].Key)
+			require.Equal(t, "storj", resp.Tags[0].Value)
+			require.Equal(t, "state", resp.Tags[1].Key)
+			require.Equal(t, "archived", resp.Tags[1].Value)
+
+			resp, err = client.GetObjectTagging(ctx, &internalpb.GetObjectTaggingRequest{
+				Header:             header,
+				Bucket:             []byte(bucketName),
+				EncryptedObjectKey: []byte(older.ObjectKey),
+				ObjectVersion:      older.StreamVersionID().Bytes(),
+			})
+			require.NoError(t, err)
+			require.Empty(t, resp.Tags)
+		})
+
+		t.Run("exact version", func(t *testing.T) {
+			_, err := client.PutObjectTagging(ctx, &internalpb.PutObjectTaggingRequest{
+				Header:             header,
+				Bucket:             []byte(bucketName),
+				EncryptedObjectKey: []byte(older.ObjectKey),
+				ObjectVersion:      older.StreamVersionID().Bytes(),
+				Tags:               tags[:1],
+			})
+			require.NoError(t, err)
+
+			resp, err := client.GetObjectTagging(ctx, &internalpb.GetObjectTaggingRequest{
+				Header:             header,
+				Bucket:             []byte(bucketName),
+				EncryptedObjectKey: []byte(older.ObjectKey),
+				ObjectVersion:      older.StreamVersionID().Bytes(),
+			})
+			require.NoError(t, err)
+			require.Len(t, resp.Tags, 1)
+			require.Equal(t, "project", resp.Tags[0].Key)
+		})
+
+		t.Run("remove", func(t *testing.T) {
+			_, err := client.PutObjectTagging(ctx, &internalpb.PutObjectTaggingRequest{
+				Header:             header,
+				Bucket:             []byte(bucketName),
+				EncryptedObjectKey: []byte(latest.ObjectKey),
+			})
+			require.NoError(t, err)
+
+			resp, err := client.GetObjectTagging(ctx, &internalpb.GetObjectTaggingRequest{
+				Header:             header,
+				Bucket:             []byte(bucketName),
+				EncryptedObjectKey: []byte(latest.ObjectKey),
+			})
+			require.NoError(t, err)
+			require.Empty(t, resp.Tags)
+		})
+
+		t.Run("errors", func(t *testing.T) {
+			_, err := client.PutObjectTagging(ctx, &internalpb.PutObjectTaggingRequest{
+				Header:             header,
+				Bucket:             []byte(bucketName),
+				EncryptedObjectKey: []byte(latest.ObjectKey),
+				Tags:               []*internalpb.ObjectTag{{Value: "missing key"}},
+			})
+			rpctest.RequireCode(t, err, rpcstatus.InvalidArgument)
+
+			_, err = client.GetObjectTagging(ctx, &internalpb.GetObjectTaggingRequest{
+				Header:             header,
+				Bucket:             []byte(bucketName),
+				EncryptedObjectKey: []byte("missing"),
+			})
+			rpctest.RequireCode(t, err, rpcstatus.NotFound)
+		})
+	})
+}
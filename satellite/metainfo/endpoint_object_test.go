@@ -13,6 +13,7 @@ import (
 	"net"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -48,6 +49,7 @@ import (
 	"storj.io/storj/satellite/metabase/metabasetest"
 	"storj.io/storj/satellite/metainfo"
 	"storj.io/storj/satellite/nodeselection"
+	"storj.io/storj/shared/throttle"
 	"storj.io/storj/storagenode"
 	"storj.io/storj/storagenode/contact"
 	"storj.io/uplink"
@@ -1917,6 +1919,39 @@ func TestEndpoint_DeleteCommittedObject(t *testing.T) {
 	testDeleteObject(t, createObject, deleteObject)
 }
 
+func TestEndpoint_DeleteObject_PieceDeletionKeepsCopies(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.Combine(
+				testplanet.ReconfigureRS(2, 2, 4, 4),
+				func(log *zap.Logger, index int, config *satellite.Config) {
+					config.Metainfo.PieceDeletion.Enabled = true
+				},
+			),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		data := testrand.Bytes(10 * memory.KiB)
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, planet.Satellites[0], "bucket", "source", data))
+
+		project, err := planet.Uplinks[0].OpenProject(ctx, planet.Satellites[0])
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		_, err = project.CopyObject(ctx, "bucket", "source", "bucket", "copy", nil)
+		require.NoError(t, err)
+
+		_, err = project.DeleteObject(ctx, "bucket", "source")
+		require.NoError(t, err)
+		planet.Satellites[0].API.Metainfo.PieceDeletion.Wait()
+
+		// the copy shares the pieces with the deleted source.
+		downloaded, err := planet.Uplinks[0].Download(ctx, planet.Satellites[0], "bucket", "copy")
+		require.NoError(t, err)
+		require.Equal(t, data, downloaded)
+	})
+}
+
 func testDeleteObject(t *testing.T,
 	createObject func(ctx context.Context, t *testing.T, planet *testplanet.Planet, bucket, key string, data []byte),
 	deleteObject func(ctx context.Context, t *testing.T, planet *testplanet.Planet, bucket, encryptedKey string, streamID uuid.UUID),
@@ -2108,6 +2143,95 @@ func testDeleteObject(t *testing.T,
 	})
 }
 
+func TestEndpoint_CopyObjectAcrossProjects(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 2,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Metainfo.CrossProjectCopy = true
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		endpoint := sat.API.Metainfo.Endpoint
+
+		sourceAPIKey := planet.Uplinks[0].APIKey[sat.ID()]
+		destinationAPIKey := planet.Uplinks[1].APIKey[sat.ID()]
+		destinationProjectID := planet.Uplinks[1].Projects[0].ID
+
+		err := planet.Uplinks[0].Upload(ctx, sat, "source", "testobject", testrand.Bytes(1*memory.KiB))
+		require.NoError(t, err)
+		require.NoError(t, planet.Uplinks[1].CreateBucket(ctx, sat, "destination"))
+
+		objects, err := sat.API.Metainfo.Metabase.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+
+		beginCopy := func() *pb.ObjectBeginCopyResponse {
+			beginResp, err := endpoint.BeginCopyObject(ctx, &pb.ObjectBeginCopyRequest{
+				Header:                &pb.RequestHeader{ApiKey: sourceAPIKey.SerializeRaw()},
+				Bucket:                []byte("source"),
+				EncryptedObjectKey:    []byte(objects[0].ObjectKey),
+				NewBucket:             []byte("destination"),
+				NewEncryptedObjectKey: []byte("copy"),
+			})
+			require.NoError(t, err)
+			require.Len(t, beginResp.SegmentKeys, 1)
+			return beginResp
+		}
+
+		finishCopy := func(beginResp *pb.ObjectBeginCopyResponse, apiKey *macaroon.APIKey, bucket string) error {
+			_, err := endpoint.FinishCopyObject(ctx, &pb.ObjectFinishCopyRequest{
+				Header: &pb.RequestHeader{
+					ApiKey:    apiKey.SerializeRaw(),
+					UserAgent: []byte("Zenko"),
+				},
+				StreamId:              beginResp.StreamId,
+				NewBucket:             []byte(bucket),
+				NewEncryptedObjectKey: []byte("copy"),
+				NewSegmentKeys: []*pb.EncryptedKeyAndNonce{{
+					Position:          beginResp.SegmentKeys[0].Position,
+					EncryptedKeyNonce: testrand.Nonce(),
+					EncryptedKey:      testrand.Bytes(32),
+				}},
+			})
+			return err
+		}
+
+		// the destination bucket doesn't exist in the destination project.
+		err = finishCopy(beginCopy(), destinationAPIKey, "missing")
+		require.True(t, errs2.IsRPC(err, rpcstatus.NotFound))
+
+		// the destination bucket doesn't exist in the source project.
+		err = finishCopy(beginCopy(), sourceAPIKey, "destination")
+		require.True(t, errs2.IsRPC(err, rpcstatus.NotFound))
+
+		require.NoError(t, finishCopy(beginCopy(), destinationAPIKey, "destination"))
+
+		objects, err = sat.API.Metainfo.Metabase.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+
+		var source, copied metabase.Object
+		for _, object := range objects {
+			if object.ProjectID == destinationProjectID {
+				copied = object
+			} else {
+				source = object
+			}
+		}
+		require.Equal(t, metabase.BucketName("destination"), copied.BucketName)
+		require.Equal(t, metabase.ObjectKey("copy"), copied.ObjectKey)
+		require.Equal(t, source.TotalEncryptedSize, copied.TotalEncryptedSize)
+		require.NotEqual(t, source.StreamID, copied.StreamID)
+
+		// the copy is attributed in the destination project like an upload.
+		attributionInfo, err := sat.API.DB.Attribution().Get(ctx, destinationProjectID, []byte("destination"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("Zenko"), attributionInfo.UserAgent)
+	})
+}
+
 func TestEndpoint_CopyObject(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 4,
@@ -2285,7 +2409,10 @@ func TestEndpoint_CopyObject(t *testing.T) {
 				NewEncryptedObjectKey: []byte("newencryptedobjectkey"),
 			})
 			assertRPCStatusCode(t, err, rpcstatus.ResourceExhausted)
-			assert.EqualError(t, err, "Exceeded Storage Limit")
+			assert.True(t, strings.HasSuffix(err.Error(), "Exceeded Storage Limit"), err.Error())
+			info, ok := throttle.Parse(err)
+			require.True(t, ok)
+			require.Equal(t, throttle.Info{Kind: throttle.KindStorage}, info)
 
 			// metabaseObjects, err := satelliteSys.API.Metainfo.Metabase.TestingAllObjects(ctx)
 			// require.NoError(t, err)
@@ -2353,7 +2480,10 @@ func TestEndpoint_CopyObject(t *testing.T) {
 				NewEncryptedObjectKey: []byte("newencryptedobjectkey1"),
 			})
 			assertRPCStatusCode(t, err, rpcstatus.ResourceExhausted)
-			assert.EqualError(t, err, "Exceeded Segments Limit")
+			assert.True(t, strings.HasSuffix(err.Error(), "Exceeded Segments Limit"), err.Error())
+			info, ok := throttle.Parse(err)
+			require.True(t, ok)
+			require.Equal(t, throttle.Info{Kind: throttle.KindSegments}, info)
 		}
 	})
 }
@@ -3026,6 +3156,43 @@ func TestEndpoint_Object_No_StorageNodes_Versioning(t *testing.T) {
 			require.Equal(t, response.StreamId.Bytes(), lposResponse.Items[0].StreamId.Bytes())
 		})
 
+		t.Run("commit follows the current versioning state", func(t *testing.T) {
+			defer ctx.Check(deleteBucket(bucketName))
+
+			require.NoError(t, createBucket(bucketName))
+
+			begin := func() *pb.BeginObjectResponse {
+				response, err := satelliteSys.API.Metainfo.Endpoint.BeginObject(ctx, &pb.BeginObjectRequest{
+					Header:             &pb.RequestHeader{ApiKey: apiKey},
+					Bucket:             []byte(bucketName),
+					EncryptedObjectKey: []byte(objectKey),
+					EncryptionParameters: &pb.EncryptionParameters{
+						CipherSuite: pb.CipherSuite_ENC_AESGCM,
+					},
+				})
+				require.NoError(t, err)
+				return response
+			}
+			commit := func(response *pb.BeginObjectResponse) pb.Object_Status {
+				committed, err := satelliteSys.API.Metainfo.Endpoint.CommitObject(ctx, &pb.ObjectCommitRequest{
+					Header:   &pb.RequestHeader{ApiKey: apiKey},
+					StreamId: response.StreamId,
+				})
+				require.NoError(t, err)
+				return committed.Object.Status
+			}
+
+			// versioning was enabled during the upload.
+			response := begin()
+			require.NoError(t, planet.Satellites[0].API.Buckets.Service.EnableBucketVersioning(ctx, []byte(bucketName), projectID))
+			require.Equal(t, pb.Object_COMMITTED_VERSIONED, commit(response))
+
+			// versioning was suspended during the upload.
+			response = begin()
+			require.NoError(t, planet.Satellites[0].API.Buckets.Service.SuspendBucketVersioning(ctx, []byte(bucketName), projectID))
+			require.Equal(t, pb.Object_COMMITTED_UNVERSIONED, commit(response))
+		})
+
 		t.Run("listing objects, all versions, version cursor handling", func(t *testing.T) {
 			defer ctx.Check(deleteBucket(bucketName))
 
@@ -3206,6 +3373,197 @@ func TestEndpoint_Object_No_StorageNodes_Versioning(t *testing.T) {
 	})
 }
 
+func TestEndpoint_CommitInlineObject(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		project := planet.Uplinks[0].Projects[0]
+		apiKey := planet.Uplinks[0].APIKey[sat.ID()]
+		bucketName := "testbucket"
+
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, sat, bucketName))
+
+		beginReq := &pb.BeginObjectRequest{
+			Header:             &pb.RequestHeader{ApiKey: apiKey.SerializeRaw()},
+			Bucket:             []byte(bucketName),
+			EncryptedObjectKey: []byte("inline-object"),
+			EncryptionParameters: &pb.EncryptionParameters{
+				CipherSuite: pb.CipherSuite_ENC_AESGCM,
+				BlockSize:   256,
+			},
+		}
+		segReq := &pb.MakeInlineSegmentRequest{
+			Header:              beginReq.Header,
+			Position:            &pb.SegmentPosition{},
+			EncryptedKey:        testrand.Bytes(32),
+			EncryptedKeyNonce:   testrand.Nonce(),
+			PlainSize:           512,
+			EncryptedInlineData: testrand.Bytes(32),
+		}
+		commitReq := &pb.CommitObjectRequest{
+			Header:                        beginReq.Header,
+			EncryptedMetadata:             testrand.Bytes(16),
+			EncryptedMetadataEncryptedKey: testrand.Bytes(32),
+			EncryptedMetadataNonce:        testrand.Nonce(),
+		}
+
+		_, _, commitResp, err := sat.Metainfo.Endpoint.CommitInlineObject(ctx, beginReq, segReq, commitReq)
+		require.NoError(t, err)
+		require.EqualValues(t, beginReq.EncryptedObjectKey, commitResp.Object.EncryptedObjectKey)
+
+		objects, err := sat.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		require.Equal(t, project.ID, objects[0].ProjectID)
+		require.Equal(t, metabase.CommittedUnversioned, objects[0].Status)
+		require.EqualValues(t, 1, objects[0].SegmentCount)
+		require.EqualValues(t, 512, objects[0].TotalPlainSize)
+		require.EqualValues(t, len(segReq.EncryptedInlineData), objects[0].TotalEncryptedSize)
+		require.Equal(t, commitReq.EncryptedMetadata, objects[0].EncryptedMetadata)
+
+		segments, err := sat.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		require.Equal(t, objects[0].StreamID, segments[0].StreamID)
+		require.True(t, segments[0].Inline())
+		require.Equal(t, segReq.EncryptedInlineData, segments[0].InlineData)
+		require.Equal(t, segReq.EncryptedKey, segments[0].EncryptedKey)
+
+		// committing again replaces the unversioned object together with its segment.
+		_, _, _, err = sat.Metainfo.Endpoint.CommitInlineObject(ctx, beginReq, segReq, commitReq)
+		require.NoError(t, err)
+
+		objects, err = sat.Metabase.DB.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+
+		segments, err = sat.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		require.Equal(t, objects[0].StreamID, segments[0].StreamID)
+	})
+}
+
+func TestEndpoint_CommitInlineObjects(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Metainfo.TestOptimizedInlineObjectUpload = true
+				config.Metainfo.InlineObjectsBatchSize = 2
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		apiKey := planet.Uplinks[0].APIKey[sat.ID()]
+		bucketName := "testbucket"
+
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, sat, bucketName))
+
+		header := &pb.RequestHeader{ApiKey: apiKey.SerializeRaw()}
+		upload := func(bucket, key string) metainfo.InlineObjectUpload {
+			return metainfo.InlineObjectUpload{
+				BeginObject: &pb.BeginObjectRequest{
+					Header:             header,
+					Bucket:             []byte(bucket),
+					EncryptedObjectKey: []byte(key),
+					EncryptionParameters: &pb.EncryptionParameters{
+						CipherSuite: pb.CipherSuite_ENC_AESGCM,
+						BlockSize:   256,
+					},
+				},
+				MakeInlineSegment: &pb.MakeInlineSegmentRequest{
+					Header:              header,
+					Position:            &pb.SegmentPosition{},
+					EncryptedKey:        testrand.Bytes(32),
+					EncryptedKeyNonce:   testrand.Nonce(),
+					PlainSize:           512,
+					EncryptedInlineData: testrand.Bytes(32),
+				},
+				CommitObject: &pb.CommitObjectRequest{
+					Header: header,
+				},
+			}
+		}
+
+		t.Run("batch", func(t *testing.T) {
+			defer ctx.Check(func() error { return sat.Metabase.DB.TestingDeleteAll(ctx) })
+
+			var requests []*pb.BatchRequestItem
+			for i := 0; i < 3; i++ {
+				upload := upload(bucketName, fmt.Sprintf("object-%d", i))
+				requests = append(requests,
+					&pb.BatchRequestItem{Request: &pb.BatchRequestItem_ObjectBegin{ObjectBegin: upload.BeginObject}},
+					&pb.BatchRequestItem{Request: &pb.BatchRequestItem_SegmentMakeInline{SegmentMakeInline: upload.MakeInlineSegment}},
+					&pb.BatchRequestItem{Request: &pb.BatchRequestItem_ObjectCommit{ObjectCommit: upload.CommitObject}},
+				)
+			}
+
+			resp, err := sat.Metainfo.Endpoint.Batch(ctx, &pb.BatchRequest{
+				Header:   header,
+				Requests: requests,
+			})
+			require.NoError(t, err)
+			require.Len(t, resp.Responses, len(requests))
+			for i := 0; i < 3; i++ {
+				commitResp := resp.Responses[3*i+2].GetObjectCommit()
+				require.NotNil(t, commitResp)
+				require.Equal(t, []byte(fmt.Sprintf("object-%d", i)), commitResp.Object.EncryptedObjectKey)
+			}
+
+			objects, err := sat.Metabase.DB.TestingAllObjects(ctx)
+			require.NoError(t, err)
+			require.Len(t, objects, 3)
+
+			segments, err := sat.Metabase.DB.TestingAllSegments(ctx)
+			require.NoError(t, err)
+			require.Len(t, segments, 3)
+		})
+
+		t.Run("same object twice", func(t *testing.T) {
+			defer ctx.Check(func() error { return sat.Metabase.DB.TestingDeleteAll(ctx) })
+
+			// the second upload overwrites the first one, so it isn't committed
+			// in the same transaction.
+			var requests []*pb.BatchRequestItem
+			for i := 0; i < 2; i++ {
+				upload := upload(bucketName, "object")
+				requests = append(requests,
+					&pb.BatchRequestItem{Request: &pb.BatchRequestItem_ObjectBegin{ObjectBegin: upload.BeginObject}},
+					&pb.BatchRequestItem{Request: &pb.BatchRequestItem_SegmentMakeInline{SegmentMakeInline: upload.MakeInlineSegment}},
+					&pb.BatchRequestItem{Request: &pb.BatchRequestItem_ObjectCommit{ObjectCommit: upload.CommitObject}},
+				)
+			}
+
+			resp, err := sat.Metainfo.Endpoint.Batch(ctx, &pb.BatchRequest{
+				Header:   header,
+				Requests: requests,
+			})
+			require.NoError(t, err)
+			require.Len(t, resp.Responses, len(requests))
+
+			objects, err := sat.Metabase.DB.TestingAllObjects(ctx)
+			require.NoError(t, err)
+			require.Len(t, objects, 1)
+		})
+
+		t.Run("all or nothing", func(t *testing.T) {
+			defer ctx.Check(func() error { return sat.Metabase.DB.TestingDeleteAll(ctx) })
+
+			_, err := sat.Metainfo.Endpoint.CommitInlineObjects(ctx, []metainfo.InlineObjectUpload{
+				upload(bucketName, "object"),
+				upload("missing-bucket", "object"),
+			})
+			require.True(t, errs2.IsRPC(err, rpcstatus.NotFound))
+
+			objects, err := sat.Metabase.DB.TestingAllObjects(ctx)
+			require.NoError(t, err)
+			require.Empty(t, objects)
+		})
+	})
+}
+
 func TestEndpoint_UploadObjectWithRetention(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount: 1, UplinkCount: 1,
@@ -6,8 +6,10 @@ package metainfo
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/spacemonkeygo/monkit/v3"
 	"go.uber.org/zap"
 
 	"storj.io/common/errs2"
@@ -20,10 +22,24 @@ import (
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/internalpb"
 	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/nodeselection"
 	"storj.io/storj/satellite/orders"
 	"storj.io/storj/satellite/overlay"
 )
 
+// selectionArm returns the node selection arm of the upload of the segment.
+func (endpoint *Endpoint) selectionArm(placement storj.PlacementConstraint, streamID []byte, part, index uint32) nodeselection.SelectionArm {
+	return endpoint.placement[placement].Experiment.Arm(streamID, part, index)
+}
+
+// selectionArmTags returns the tags of the upload metrics of a node selection arm.
+func selectionArmTags(arm nodeselection.SelectionArm, placement storj.PlacementConstraint) []monkit.SeriesTag {
+	return []monkit.SeriesTag{
+		monkit.NewSeriesTag("arm", arm.String()),
+		monkit.NewSeriesTag("placement", strconv.Itoa(int(placement))),
+	}
+}
+
 func calculateSpaceUsed(segmentSize int64, numberOfPieces int, rs storj.RedundancyScheme) (totalStored int64) {
 	pieceSize := segmentSize / int64(rs.RequiredShares)
 	return pieceSize * int64(numberOfPieces)
@@ -76,27 +92,23 @@ func (endpoint *Endpoint) beginSegment(ctx context.Context, req *pb.SegmentBegin
 		}
 	}
 
-	placement := endpoint.placement[storj.PlacementConstraint(streamID.Placement)]
-	config := endpoint.config
-	rsParams := config.RS.Override(placement.EC)
-	defaultRedundancy := storj.RedundancyScheme{
-		Algorithm:      storj.ReedSolomon,
-		RequiredShares: int16(rsParams.Min),
-		RepairShares:   int16(rsParams.Repair),
-		OptimalShares:  int16(rsParams.Success),
-		TotalShares:    int16(rsParams.Total),
-		ShareSize:      rsParams.ErasureShareSize.Int32(),
-	}
+	rsParam := endpoint.getStreamRSProto(streamID)
+	redundancy := redundancyFromProto(rsParam)
+
+	maxPieceSize := redundancy.PieceSize(req.MaxOrderLimit)
 
-	maxPieceSize := defaultRedundancy.PieceSize(req.MaxOrderLimit)
+	arm := endpoint.selectionArm(storj.PlacementConstraint(streamID.Placement), streamID.StreamId, uint32(req.Position.PartNumber), uint32(req.Position.Index))
+	armTags := selectionArmTags(arm, storj.PlacementConstraint(streamID.Placement))
 
 	nodes, err := endpoint.overlay.FindStorageNodesForUpload(ctx, overlay.FindStorageNodesRequest{
-		RequestedCount: int(defaultRedundancy.TotalShares),
+		RequestedCount: int(redundancy.TotalShares),
 		Placement:      storj.PlacementConstraint(streamID.Placement),
 		Requester:      peer.ID,
+		SelectionArm:   arm,
 	})
 
 	if err != nil {
+		mon.Meter("upload_selection_failed", armTags...).Mark(1)
 		if overlay.ErrNotEnoughNodes.Has(err) {
 			return nil, rpcstatus.Error(rpcstatus.FailedPrecondition, err.Error())
 		}
@@ -111,6 +123,10 @@ func (endpoint *Endpoint) beginSegment(ctx context.Context, req *pb.SegmentBegin
 		return nil, rpcstatus.Error(rpcstatus.Internal, "unable to create order limits")
 	}
 
+	for _, node := range nodes {
+		endpoint.successTrackers.UploadsStarted(node.ID)
+	}
+
 	id, err := uuid.FromBytes(streamID.StreamId)
 	if err != nil {
 		endpoint.log.Error("internal", zap.Error(err))
@@ -164,7 +180,7 @@ func (endpoint *Endpoint) beginSegment(ctx context.Context, req *pb.SegmentBegin
 		SegmentId:        segmentID,
 		AddressedLimits:  addressedLimits,
 		PrivateKey:       piecePrivateKey,
-		RedundancyScheme: endpoint.getRSProto(storj.PlacementConstraint(streamID.Placement)),
+		RedundancyScheme: rsParam,
 	}, nil
 }
 
@@ -225,10 +241,12 @@ func (endpoint *Endpoint) RetryBeginSegmentPieces(ctx context.Context, req *pb.R
 	for _, orderLimit := range segmentID.OriginalOrderLimits {
 		excludedIDs = append(excludedIDs, orderLimit.Limit.StorageNodeId)
 	}
+	placement := storj.PlacementConstraint(segmentID.StreamId.Placement)
 	nodes, err := endpoint.overlay.FindStorageNodesForUpload(ctx, overlay.FindStorageNodesRequest{
 		RequestedCount: len(req.RetryPieceNumbers),
-		Placement:      storj.PlacementConstraint(segmentID.StreamId.Placement),
+		Placement:      placement,
 		ExcludedIDs:    excludedIDs,
+		SelectionArm:   endpoint.selectionArm(placement, segmentID.StreamId.StreamId, uint32(segmentID.PartNumber), uint32(segmentID.Index)),
 	})
 	if err != nil {
 		if overlay.ErrNotEnoughNodes.Has(err) {
@@ -238,12 +256,25 @@ func (endpoint *Endpoint) RetryBeginSegmentPieces(ctx context.Context, req *pb.R
 		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
 	}
 
+	replacedIDs := make([]storj.NodeID, 0, len(req.RetryPieceNumbers))
+	for _, pieceNumber := range req.RetryPieceNumbers {
+		if limit := segmentID.OriginalOrderLimits[pieceNumber].GetLimit(); limit != nil {
+			replacedIDs = append(replacedIDs, limit.StorageNodeId)
+		}
+	}
+
 	addressedLimits, err := endpoint.orders.ReplacePutOrderLimits(ctx, segmentID.RootPieceId, segmentID.OriginalOrderLimits, nodes, req.RetryPieceNumbers)
 	if err != nil {
 		endpoint.log.Error("internal", zap.Error(err))
 		return nil, rpcstatus.Error(rpcstatus.Internal, "internal error")
 	}
 
+	// the uploads to the replaced nodes failed.
+	endpoint.successTrackers.UploadsFinished(replacedIDs...)
+	for _, node := range nodes {
+		endpoint.successTrackers.UploadsStarted(node.ID)
+	}
+
 	segmentID.OriginalOrderLimits = addressedLimits
 
 	amendedSegmentID, err := endpoint.packSegmentID(ctx, segmentID)
@@ -291,7 +322,7 @@ func (endpoint *Endpoint) CommitSegment(ctx context.Context, req *pb.SegmentComm
 	endpoint.usageTracking(keyInfo, req.Header, fmt.Sprintf("%T", req))
 
 	// cheap basic verification
-	rsParam := endpoint.getRSProto(storj.PlacementConstraint(streamID.Placement))
+	rsParam := endpoint.getStreamRSProto(streamID)
 	if numResults := len(req.UploadResult); numResults < int(rsParam.GetSuccessThreshold()) {
 		endpoint.log.Debug("the results of uploaded pieces for the segment is below the redundancy optimal threshold",
 			zap.Int("upload pieces results", numResults),
@@ -304,14 +335,7 @@ func (endpoint *Endpoint) CommitSegment(ctx context.Context, req *pb.SegmentComm
 		)
 	}
 
-	rs := storj.RedundancyScheme{
-		Algorithm:      storj.RedundancyAlgorithm(rsParam.Type),
-		RequiredShares: int16(rsParam.MinReq),
-		RepairShares:   int16(rsParam.RepairThreshold),
-		OptimalShares:  int16(rsParam.SuccessThreshold),
-		TotalShares:    int16(rsParam.Total),
-		ShareSize:      rsParam.ErasureShareSize,
-	}
+	rs := redundancyFromProto(rsParam)
 
 	err = endpoint.pointerVerification.VerifySizes(ctx, rs, req.SizeEncryptedData, req.UploadResult)
 	if err != nil {
@@ -325,6 +349,13 @@ func (endpoint *Endpoint) CommitSegment(ctx context.Context, req *pb.SegmentComm
 		originalLimits[i] = orderLimit.Limit
 	}
 
+	// the uploads of the segment are finished, even if the commit fails.
+	for _, limit := range originalLimits {
+		if limit != nil {
+			endpoint.successTrackers.UploadsFinished(limit.StorageNodeId)
+		}
+	}
+
 	// verify the piece upload results
 	validPieces, invalidPieces, err := endpoint.pointerVerification.SelectValidPieces(ctx, req.UploadResult, originalLimits)
 	if err != nil {
@@ -402,7 +433,7 @@ func (endpoint *Endpoint) CommitSegment(ctx context.Context, req *pb.SegmentComm
 		Placement:   storj.PlacementConstraint(streamID.Placement),
 	}
 
-	err = endpoint.validateRemoteSegment(ctx, mbCommitSegment, originalLimits)
+	err = endpoint.validateRemoteSegment(ctx, mbCommitSegment, originalLimits, endpoint.getStreamMaxSegmentSize(streamID))
 	if err != nil {
 		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
 	}
@@ -445,11 +476,21 @@ func (endpoint *Endpoint) CommitSegment(ctx context.Context, req *pb.SegmentComm
 			tracker.Increment(piece.NodeId, true)
 			validPieceSet[piece.NodeId] = struct{}{}
 		}
+		canceled := 0
 		for _, limit := range originalLimits {
 			if _, ok := validPieceSet[limit.StorageNodeId]; !ok {
 				tracker.Increment(limit.StorageNodeId, false)
+				canceled++
 			}
 		}
+
+		// report the results per node selection arm to compare the selection experiments
+		placement := storj.PlacementConstraint(streamID.Placement)
+		arm := endpoint.selectionArm(placement, streamID.StreamId, uint32(segmentID.PartNumber), uint32(segmentID.Index))
+		armTags := selectionArmTags(arm, placement)
+		mon.Meter("upload_selection_segments", armTags...).Mark(1)
+		mon.Meter("upload_selection_pieces_successful", armTags...).Mark(len(validPieces))
+		mon.Meter("upload_selection_pieces_canceled", armTags...).Mark(canceled)
 	}
 
 	// note: we collect transfer stats in CommitSegment instead because in BeginSegment
@@ -896,3 +896,71 @@ func createMetainfoClient(ctx *testcontext.Context, tb testing.TB, planet *testp
 	tb.Cleanup(func() { ctx.Check(metainfoClient.Close) })
 	return metainfoClient
 }
+
+func TestBucketDefaultsOverride(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 6, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		uplink := planet.Uplinks[0]
+		projectID := uplink.Projects[0].ID
+
+		bucketName := "defaults-bucket"
+		require.NoError(t, uplink.CreateBucket(ctx, satellite, bucketName))
+
+		override := storj.RedundancyScheme{
+			Algorithm:      storj.ReedSolomon,
+			ShareSize:      256,
+			RequiredShares: 2,
+			RepairShares:   3,
+			OptimalShares:  4,
+			TotalShares:    6,
+		}
+		require.NoError(t, satellite.API.Buckets.Service.SetBucketDefaults(ctx, []byte(bucketName), projectID, buckets.Defaults{
+			SegmentSize: 2 * buckets.MinSegmentSize.Int64(),
+			Redundancy:  override,
+		}))
+
+		// clients are told the overridden defaults, the segment size can't
+		// exceed the configured maximum.
+		expectedSegmentSize := 2 * buckets.MinSegmentSize.Int64()
+		if maxSegmentSize := satellite.Config.Metainfo.MaxSegmentSize.Int64(); expectedSegmentSize > maxSegmentSize {
+			expectedSegmentSize = maxSegmentSize
+		}
+		response, err := satellite.API.Metainfo.Endpoint.GetBucket(ctx, &pb.BucketGetRequest{
+			Header: &pb.RequestHeader{ApiKey: uplink.APIKey[satellite.ID()].SerializeRaw()},
+			Name:   []byte(bucketName),
+		})
+		require.NoError(t, err)
+		require.Equal(t, override, storj.RedundancyScheme{
+			Algorithm:      storj.RedundancyAlgorithm(response.Bucket.DefaultRedundancyScheme.Type),
+			ShareSize:      response.Bucket.DefaultRedundancyScheme.ErasureShareSize,
+			RequiredShares: int16(response.Bucket.DefaultRedundancyScheme.MinReq),
+			RepairShares:   int16(response.Bucket.DefaultRedundancyScheme.RepairThreshold),
+			OptimalShares:  int16(response.Bucket.DefaultRedundancyScheme.SuccessThreshold),
+			TotalShares:    int16(response.Bucket.DefaultRedundancyScheme.Total),
+		})
+		require.Equal(t, expectedSegmentSize, response.Bucket.DefaultSegmentSize)
+
+		require.NoError(t, uplink.Upload(ctx, satellite, bucketName, "override", testrand.Bytes(20*memory.KiB)))
+
+		segments, err := satellite.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		require.Equal(t, override, segments[0].Redundancy)
+
+		// removing the override makes new uploads use the placement defaults again.
+		require.NoError(t, satellite.API.Buckets.Service.SetBucketDefaults(ctx, []byte(bucketName), projectID, buckets.Defaults{}))
+
+		require.NoError(t, uplink.Upload(ctx, satellite, bucketName, "default", testrand.Bytes(20*memory.KiB)))
+
+		segments, err = satellite.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, 2)
+		for _, segment := range segments {
+			if segment.Redundancy != override {
+				require.Equal(t, satellite.Config.Metainfo.RS.Total, int(segment.Redundancy.TotalShares))
+			}
+		}
+	})
+}
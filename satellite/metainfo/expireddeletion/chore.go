@@ -27,6 +27,10 @@ type Config struct {
 	Enabled            bool          `help:"set if expired segment cleanup is enabled or not" releaseDefault:"true" devDefault:"true"`
 	ListLimit          int           `help:"how many expired objects to query in a batch" default:"100"`
 	AsOfSystemInterval time.Duration `help:"as of system interval" releaseDefault:"-5m" devDefault:"-1us" testDefault:"-1us" hidden:"true"`
+
+	MaxListLimit        int           `help:"maximum number of expired objects to query in a batch when the batch size is adjusted" default:"1000"`
+	TargetBatchDuration time.Duration `help:"expected duration of a single batch, the batch size is adjusted to match it; 0 disables adjusting" default:"0s"`
+	DeleteConcurrency   int           `help:"number of projects whose expired objects are deleted concurrently" default:"1"`
 }
 
 // Chore implements the expired segment cleanup chore.
@@ -85,6 +89,10 @@ func (chore *Chore) deleteExpiredObjects(ctx context.Context) (err error) {
 		ExpiredBefore:      chore.nowFn(),
 		BatchSize:          chore.config.ListLimit,
 		AsOfSystemInterval: chore.config.AsOfSystemInterval,
+
+		MaxBatchSize:        chore.config.MaxListLimit,
+		TargetBatchDuration: chore.config.TargetBatchDuration,
+		DeleteConcurrency:   chore.config.DeleteConcurrency,
 	})
 	if err != nil {
 		chore.log.Error("deleting expired objects failed", zap.Error(err))
@@ -0,0 +1,278 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package lifecycledeletion
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+	"storj.io/storj/satellite/buckets"
+	"storj.io/storj/satellite/metabase"
+)
+
+var (
+	// Error defines the lifecycledeletion chore errors class.
+	Error = errs.Class("lifecycle deletion")
+	mon   = monkit.Package()
+)
+
+// Config contains configurable values for the bucket lifecycle expiration.
+type Config struct {
+	Interval  time.Duration `help:"the time between each attempt to apply the bucket lifecycle expiration rules" releaseDefault:"24h" devDefault:"10s" testDefault:"$TESTINTERVAL"`
+	Enabled   bool          `help:"set if bucket lifecycle expiration is enabled or not" default:"false"`
+	ListLimit int           `help:"how many buckets with lifecycle configuration to query in a batch" default:"100"`
+	BatchSize int           `help:"how many object versions to delete in a single request, at most 1000" default:"100"`
+}
+
+// Chore implements the bucket lifecycle expiration chore.
+//
+// architecture: Chore
+type Chore struct {
+	log      *zap.Logger
+	config   Config
+	buckets  buckets.DB
+	metabase *metabase.DB
+
+	nowFn func() time.Time
+	Loop  *sync2.Cycle
+}
+
+// NewChore creates a new instance of the lifecycledeletion chore.
+func NewChore(log *zap.Logger, config Config, buckets buckets.DB, metabase *metabase.DB) *Chore {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+
+	return &Chore{
+		log:      log,
+		config:   config,
+		buckets:  buckets,
+		metabase: metabase,
+
+		nowFn: time.Now,
+		Loop:  sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run starts the lifecycledeletion loop service.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !chore.config.Enabled {
+		return nil
+	}
+
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := chore.RunOnce(ctx); err != nil {
+			chore.log.Error("failed to apply bucket lifecycle rules", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// Close stops the lifecycledeletion chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
+
+// TestingSetNow allows tests to have the server act as if the current time is whatever they want.
+func (chore *Chore) TestingSetNow(nowFn func() time.Time) {
+	chore.nowFn = nowFn
+}
+
+// RunOnce applies the lifecycle rules of all buckets.
+func (chore *Chore) RunOnce(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	now := chore.nowFn()
+
+	var group errs.Group
+	err = chore.buckets.IterateBucketLifecycles(ctx, chore.config.ListLimit, func(page []buckets.BucketLifecycle) error {
+		for _, bucket := range page {
+			if err := chore.applyRules(ctx, bucket, now); err != nil {
+				chore.log.Warn("unable to apply bucket lifecycle rules",
+					zap.Stringer("Project ID", bucket.ProjectID),
+					zap.String("Bucket", bucket.BucketName.String()),
+					zap.Error(err))
+				group.Add(err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	return Error.Wrap(group.Err())
+}
+
+// applyRules expires the current object versions of the bucket which are
+// older than any of the matching lifecycle rules allow. The bucket is listed
+// once for all of its rules, starting from the common prefix of the rules.
+//
+// Noncurrent versions and delete markers are never expired.
+func (chore *Chore) applyRules(ctx context.Context, bucket buckets.BucketLifecycle, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rules := bucket.Configuration.Rules
+	if len(rules) == 0 {
+		return nil
+	}
+	prefix := rulesPrefix(rules)
+
+	var cursor metabase.IterateCursor
+	var lastKey metabase.ObjectKey
+	for {
+		var expired []metabase.ObjectEntry
+		more := false
+
+		err := chore.metabase.IterateObjectsAllVersionsWithStatus(ctx, metabase.IterateObjectsWithStatus{
+			ProjectID:  bucket.ProjectID,
+			BucketName: bucket.BucketName,
+			Prefix:     prefix,
+			Cursor:     cursor,
+			Recursive:  true,
+			BatchSize:  chore.config.BatchSize,
+		}, func(ctx context.Context, it metabase.ObjectsIterator) error {
+			var entry metabase.ObjectEntry
+			for it.Next(ctx, &entry) {
+				entry.ObjectKey = prefix + entry.ObjectKey
+				cursor = metabase.IterateCursor{Key: entry.ObjectKey, Version: entry.Version}
+
+				// versions are listed from the latest, so only the first one of
+				// each key is the current version.
+				if entry.ObjectKey == lastKey {
+					continue
+				}
+				lastKey = entry.ObjectKey
+
+				if entry.Status.IsDeleteMarker() || !isExpired(rules, entry, now) {
+					continue
+				}
+
+				expired = append(expired, entry)
+				if len(expired) >= chore.config.BatchSize {
+					more = true
+					return nil
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(expired) > 0 {
+			if err := chore.expire(ctx, bucket, expired); err != nil {
+				return err
+			}
+		}
+
+		if !more {
+			return nil
+		}
+	}
+}
+
+// expire expires the given current object versions. Objects in unversioned
+// buckets are deleted, while in versioned and suspended buckets a delete marker
+// is placed on top of them, the same way as a delete request without a version does.
+// Objects in buckets with deletion protection enabled are only hidden by delete
+// markers, they are never deleted.
+func (chore *Chore) expire(ctx context.Context, bucket buckets.BucketLifecycle, expired []metabase.ObjectEntry) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if bucket.Versioning != buckets.VersioningEnabled && bucket.Versioning != buckets.VersioningSuspended {
+		items := make([]metabase.DeleteObjectVersionsItem, 0, len(expired))
+		for _, entry := range expired {
+			items = append(items, metabase.DeleteObjectVersionsItem{
+				ObjectKey: entry.ObjectKey,
+				Version:   entry.Version,
+			})
+		}
+
+		result, err := chore.metabase.DeleteObjectVersions(ctx, metabase.DeleteObjectVersions{
+			ProjectID:         bucket.ProjectID,
+			BucketName:        bucket.BucketName,
+			Items:             items,
+			UseObjectLock:     bucket.ObjectLockEnabled,
+			DeletionProtected: bucket.DeletionProtection,
+		}, nil)
+		if metabase.ErrDeletionProtected.Has(err) {
+			mon.Meter("lifecycle_deletion_objects_protected").Mark(len(items))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, item := range result.Items {
+			switch item.Status {
+			case metabase.DeleteObjectVersionDeleted:
+				mon.Meter("lifecycle_deletion_objects_deleted").Mark(1)
+			case metabase.DeleteObjectVersionLocked:
+				mon.Meter("lifecycle_deletion_objects_locked").Mark(1)
+			}
+		}
+		return nil
+	}
+
+	for _, entry := range expired {
+		_, err := chore.metabase.DeleteObjectLastCommitted(ctx, metabase.DeleteObjectLastCommitted{
+			ObjectLocation: metabase.ObjectLocation{
+				ProjectID:  bucket.ProjectID,
+				BucketName: bucket.BucketName,
+				ObjectKey:  entry.ObjectKey,
+			},
+			Versioned:         bucket.Versioning == buckets.VersioningEnabled,
+			Suspended:         bucket.Versioning == buckets.VersioningSuspended,
+			UseObjectLock:     bucket.ObjectLockEnabled,
+			DeletionProtected: bucket.DeletionProtection,
+			// the object may have been overwritten since it was listed.
+			IfLatestVersion: entry.Version,
+		})
+		switch {
+		case err == nil:
+			mon.Meter("lifecycle_deletion_delete_markers_created").Mark(1)
+		case metabase.ErrDeletionProtected.Has(err):
+			mon.Meter("lifecycle_deletion_objects_protected").Mark(1)
+		case metabase.ErrObjectLock.Has(err):
+			mon.Meter("lifecycle_deletion_objects_locked").Mark(1)
+		case metabase.ErrValueChanged.Has(err), metabase.ErrObjectNotFound.Has(err):
+			mon.Meter("lifecycle_deletion_objects_changed").Mark(1)
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+// isExpired returns whether any of the rules matching the object key expires the object.
+func isExpired(rules []buckets.LifecycleRule, entry metabase.ObjectEntry, now time.Time) bool {
+	for _, rule := range rules {
+		if strings.HasPrefix(string(entry.ObjectKey), rule.Prefix) && entry.CreatedAt.Before(rule.ExpiredBefore(now)) {
+			return true
+		}
+	}
+	return false
+}
+
+// rulesPrefix returns the longest prefix shared by the prefixes of all rules.
+func rulesPrefix(rules []buckets.LifecycleRule) metabase.ObjectKey {
+	prefix := rules[0].Prefix
+	for _, rule := range rules[1:] {
+		n := 0
+		for n < len(prefix) && n < len(rule.Prefix) && prefix[n] == rule.Prefix[n] {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+	return metabase.ObjectKey(prefix)
+}
@@ -0,0 +1,182 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package lifecycledeletion_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/buckets"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/metabasetest"
+)
+
+func TestLifecycleDeletion(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.LifecycleDeletion.Enabled = true
+				config.LifecycleDeletion.ListLimit = 1
+				config.LifecycleDeletion.BatchSize = 2
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		db := sat.Metabase.DB
+		chore := sat.Core.LifecycleDeletion.Chore
+		chore.Loop.Pause()
+
+		projectID := planet.Uplinks[0].Projects[0].ID
+
+		// nothing to do without lifecycle configurations.
+		require.NoError(t, chore.RunOnce(ctx))
+
+		for _, name := range []string{"expiring", "other"} {
+			_, err := sat.DB.Buckets().CreateBucket(ctx, buckets.Bucket{
+				ID:        testrand.UUID(),
+				Name:      name,
+				ProjectID: projectID,
+			})
+			require.NoError(t, err)
+		}
+
+		err := sat.DB.Buckets().SetBucketLifecycle(ctx, []byte("expiring"), projectID, buckets.LifecycleConfiguration{
+			Rules: []buckets.LifecycleRule{
+				{ID: "logs", Prefix: "logs/", ExpirationDays: 30},
+			},
+		})
+		require.NoError(t, err)
+
+		createObject := func(bucketName, key string) metabase.Object {
+			obj := metabasetest.RandObjectStream()
+			obj.ProjectID = projectID
+			obj.BucketName = metabase.BucketName(bucketName)
+			obj.ObjectKey = metabase.ObjectKey(key)
+			return metabasetest.CreateObject(ctx, t, db, obj, 1)
+		}
+
+		for _, key := range []string{"logs/a", "logs/b", "logs/c", "logs/d/e", "data/a", "logsa"} {
+			createObject("expiring", key)
+		}
+		createObject("other", "logs/a")
+
+		// the objects are not expired yet.
+		require.NoError(t, chore.RunOnce(ctx))
+
+		objects, err := db.TestingAllObjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, objects, 7)
+
+		chore.TestingSetNow(func() time.Time {
+			return time.Now().AddDate(0, 0, 31)
+		})
+		require.NoError(t, chore.RunOnce(ctx))
+
+		objects, err = db.TestingAllObjects(ctx)
+		require.NoError(t, err)
+
+		var remaining []string
+		for _, object := range objects {
+			remaining = append(remaining, object.BucketName.String()+"/"+string(object.ObjectKey))
+		}
+		require.ElementsMatch(t, []string{"expiring/data/a", "expiring/logsa", "other/logs/a"}, remaining)
+	})
+}
+
+func TestLifecycleDeletion_Versioned(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.LifecycleDeletion.Enabled = true
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		db := sat.Metabase.DB
+		chore := sat.Core.LifecycleDeletion.Chore
+		chore.Loop.Pause()
+
+		projectID := planet.Uplinks[0].Projects[0].ID
+		bucketName := "versioned"
+
+		_, err := sat.DB.Buckets().CreateBucket(ctx, buckets.Bucket{
+			ID:         testrand.UUID(),
+			Name:       bucketName,
+			ProjectID:  projectID,
+			Versioning: buckets.VersioningEnabled,
+		})
+		require.NoError(t, err)
+
+		err = sat.DB.Buckets().SetBucketLifecycle(ctx, []byte(bucketName), projectID, buckets.LifecycleConfiguration{
+			Rules: []buckets.LifecycleRule{
+				{ID: "logs", Prefix: "logs/", ExpirationDays: 30},
+				{ID: "tmp", Prefix: "tmp/", ExpirationDays: 1},
+			},
+		})
+		require.NoError(t, err)
+
+		createObject := func(key string, version metabase.Version) metabase.Object {
+			obj := metabasetest.RandObjectStream()
+			obj.ProjectID = projectID
+			obj.BucketName = metabase.BucketName(bucketName)
+			obj.ObjectKey = metabase.ObjectKey(key)
+			obj.Version = version
+			return metabasetest.CreateObjectVersioned(ctx, t, db, obj, 0)
+		}
+
+		// two versions of the same key, only the current one gets a delete marker.
+		createObject("logs/a", 1)
+		createObject("logs/a", 2)
+		createObject("tmp/b", 1)
+		createObject("data/c", 1)
+
+		// the current version is already a delete marker.
+		createObject("logs/d", 1)
+		_, err = db.DeleteObjectLastCommitted(ctx, metabase.DeleteObjectLastCommitted{
+			ObjectLocation: metabase.ObjectLocation{
+				ProjectID:  projectID,
+				BucketName: metabase.BucketName(bucketName),
+				ObjectKey:  "logs/d",
+			},
+			Versioned: true,
+		})
+		require.NoError(t, err)
+
+		chore.TestingSetNow(func() time.Time {
+			return time.Now().AddDate(0, 0, 31)
+		})
+
+		for i := 0; i < 2; i++ {
+			require.NoError(t, chore.RunOnce(ctx))
+
+			objects, err := db.TestingAllObjects(ctx)
+			require.NoError(t, err)
+
+			versions := map[string][]metabase.ObjectStatus{}
+			for _, object := range objects {
+				versions[string(object.ObjectKey)] = append(versions[string(object.ObjectKey)], object.Status)
+			}
+			for key := range versions {
+				sort.Slice(versions[key], func(i, k int) bool { return versions[key][i] < versions[key][k] })
+			}
+
+			require.Equal(t, map[string][]metabase.ObjectStatus{
+				"logs/a": {metabase.CommittedVersioned, metabase.CommittedVersioned, metabase.DeleteMarkerVersioned},
+				"tmp/b":  {metabase.CommittedVersioned, metabase.DeleteMarkerVersioned},
+				"data/c": {metabase.CommittedVersioned},
+				"logs/d": {metabase.CommittedVersioned, metabase.DeleteMarkerVersioned},
+			}, versions)
+		}
+	})
+}
@@ -0,0 +1,24 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+/*
+Package lifecycledeletion contains the chore which applies the bucket lifecycle
+expiration rules.
+
+The chore iterates through the buckets which have a lifecycle configuration and
+lists each of them once, starting from the common prefix of its rules. A current
+object version which matches the prefix of a rule and is older than the rule
+expiration is expired the same way as a delete request without a version:
+
+  - in unversioned buckets the object is deleted,
+  - in versioned and suspended buckets a delete marker is placed on top of it.
+
+Noncurrent versions and delete markers are kept. Objects protected by Object Lock
+are skipped. A delete marker is only placed when the listed version is still
+the latest one, so objects uploaded in the meantime aren't affected.
+
+Buckets are listed from the metabase instead of using the ranged loop, because
+the ranged loop iterates segments, which don't carry the bucket and object key
+needed to match the rules.
+*/
+package lifecycledeletion
@@ -0,0 +1,187 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package piecedeletion implements sending piece deletions to storage nodes
+// right after the segments were deleted from the metabase.
+package piecedeletion
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/context2"
+	"storj.io/common/pb"
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/nodeselection"
+)
+
+var (
+	mon = monkit.Package()
+	// Error is the default error class for piece deletion.
+	Error = errs.Class("piece deletion")
+)
+
+// Config defines configuration options for sending piece deletions.
+type Config struct {
+	Enabled             bool          `help:"send piece deletions to storage nodes when objects are deleted instead of waiting for garbage collection, requires metainfo.server-side-copy to be disabled" default:"false"`
+	MaxConcurrency      int           `help:"maximum number of storage nodes contacted concurrently, deletions above the limit are left to garbage collection" default:"100"`
+	MaxPiecesPerRequest int           `help:"maximum number of pieces sent to a storage node in a single request" default:"1000"`
+	RequestTimeout      time.Duration `help:"timeout for sending piece deletions to a single storage node" default:"15s"`
+}
+
+// Nodes returns the online storage nodes.
+type Nodes interface {
+	CachedGetOnlineNodesForGet(ctx context.Context, nodeIDs []storj.NodeID) (map[storj.NodeID]*nodeselection.SelectedNode, error)
+}
+
+// Service sends piece deletions to storage nodes.
+//
+// Sending deletions is best-effort. Pieces which weren't deleted, e.g. because the
+// node was offline, are eventually removed by garbage collection.
+type Service struct {
+	log    *zap.Logger
+	dialer rpc.Dialer
+	nodes  Nodes
+	config Config
+
+	slots   chan struct{}
+	pending sync.WaitGroup
+}
+
+// NewService creates a new piece deletion service.
+func NewService(log *zap.Logger, dialer rpc.Dialer, nodes Nodes, config Config) *Service {
+	if config.MaxConcurrency <= 0 {
+		config.MaxConcurrency = 1
+	}
+	if config.MaxPiecesPerRequest <= 0 {
+		config.MaxPiecesPerRequest = 1000
+	}
+
+	return &Service{
+		log:    log,
+		dialer: dialer,
+		nodes:  nodes,
+		config: config,
+		slots:  make(chan struct{}, config.MaxConcurrency),
+	}
+}
+
+// Enabled returns whether piece deletions should be sent to storage nodes.
+func (service *Service) Enabled() bool {
+	return service != nil && service.config.Enabled
+}
+
+// Delete sends deletions of the pieces of the deleted segments to the storage nodes.
+// The requests are sent in the background, Delete doesn't wait for them to finish.
+func (service *Service) Delete(ctx context.Context, segments []metabase.DeletedSegmentInfo) {
+	defer mon.Task()(&ctx)(nil)
+
+	pieces := groupPiecesByNode(segments)
+	if len(pieces) == 0 {
+		return
+	}
+
+	nodeIDs := make([]storj.NodeID, 0, len(pieces))
+	for nodeID := range pieces {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+
+	nodes, err := service.nodes.CachedGetOnlineNodesForGet(ctx, nodeIDs)
+	if err != nil {
+		service.log.Warn("unable to get nodes for piece deletion", zap.Error(err))
+		return
+	}
+
+	for nodeID, pieceIDs := range pieces {
+		node, ok := nodes[nodeID]
+		if !ok || node.Address == nil {
+			mon.Meter("piece_deletion_offline").Mark(len(pieceIDs))
+			continue
+		}
+
+		select {
+		case service.slots <- struct{}{}:
+		default:
+			mon.Meter("piece_deletion_skipped").Mark(len(pieceIDs))
+			continue
+		}
+
+		nodeurl := storj.NodeURL{ID: nodeID, Address: node.Address.Address}
+
+		service.pending.Add(1)
+		go func(pieceIDs []storj.PieceID) {
+			defer service.pending.Done()
+			defer func() { <-service.slots }()
+
+			ctx, cancel := context.WithTimeout(context2.WithoutCancellation(ctx), service.config.RequestTimeout)
+			defer cancel()
+
+			if err := service.deleteFromNode(ctx, nodeurl, pieceIDs); err != nil {
+				mon.Meter("piece_deletion_failed").Mark(len(pieceIDs))
+				service.log.Debug("unable to send piece deletions",
+					zap.Stringer("Node ID", nodeurl.ID),
+					zap.Int("Pieces", len(pieceIDs)),
+					zap.Error(err))
+			}
+		}(pieceIDs)
+	}
+}
+
+// deleteFromNode sends the piece deletions to a single storage node.
+func (service *Service) deleteFromNode(ctx context.Context, nodeurl storj.NodeURL, pieceIDs []storj.PieceID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	conn, err := service.dialer.DialNodeURL(ctx, nodeurl)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(conn.Close())) }()
+
+	client := pb.NewDRPCPiecestoreClient(conn)
+	for len(pieceIDs) > 0 {
+		batch := pieceIDs
+		if len(batch) > service.config.MaxPiecesPerRequest {
+			batch = batch[:service.config.MaxPiecesPerRequest]
+		}
+		pieceIDs = pieceIDs[len(batch):]
+
+		_, err := client.DeletePieces(ctx, &pb.DeletePiecesRequest{
+			PieceIds: batch,
+		})
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		mon.Meter("piece_deletion_sent").Mark(len(batch))
+	}
+	return nil
+}
+
+// Wait waits for the piece deletions sent in the background to finish.
+func (service *Service) Wait() {
+	service.pending.Wait()
+}
+
+// Close waits for the piece deletions sent in the background to finish.
+func (service *Service) Close() error {
+	service.Wait()
+	return nil
+}
+
+// groupPiecesByNode derives the piece IDs of the deleted segments and groups them by storage node.
+func groupPiecesByNode(segments []metabase.DeletedSegmentInfo) map[storj.NodeID][]storj.PieceID {
+	pieces := map[storj.NodeID][]storj.PieceID{}
+	for _, segment := range segments {
+		for _, piece := range segment.Pieces {
+			pieceID := segment.RootPieceID.Derive(piece.StorageNode, int32(piece.Number))
+			pieces[piece.StorageNode] = append(pieces[piece.StorageNode], pieceID)
+		}
+	}
+	return pieces
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package piecedeletion_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+)
+
+func TestDeleteObject(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			// all pieces are uploaded to avoid leaving long-tail garbage on the nodes.
+			Satellite: testplanet.Combine(
+				testplanet.ReconfigureRS(2, 2, 4, 4),
+				testplanet.MaxSegmentSize(13*memory.KiB),
+				func(log *zap.Logger, index int, config *satellite.Config) {
+					config.Metainfo.PieceDeletion.Enabled = true
+				},
+			),
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		uplink := planet.Uplinks[0]
+
+		require.NoError(t, uplink.Upload(ctx, sat, "testbucket", "object", testrand.Bytes(50*memory.KiB)))
+
+		var usedSpace int64
+		for _, node := range planet.StorageNodes {
+			piecesTotal, _, err := node.Storage2.Store.SpaceUsedForPieces(ctx)
+			require.NoError(t, err)
+			usedSpace += piecesTotal
+		}
+		require.NotZero(t, usedSpace)
+
+		require.NoError(t, uplink.DeleteObject(ctx, sat, "testbucket", "object"))
+
+		sat.API.Metainfo.PieceDeletion.Wait()
+		planet.WaitForStorageNodeDeleters(ctx)
+
+		for _, node := range planet.StorageNodes {
+			piecesTotal, _, err := node.Storage2.Store.SpaceUsedForPieces(ctx)
+			require.NoError(t, err)
+			require.Zero(t, piecesTotal, "StorageNode %s", node.ID())
+		}
+	})
+}
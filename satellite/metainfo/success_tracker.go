@@ -62,6 +62,8 @@ func GetNewSuccessTracker(kind string) (func() SuccessTracker, bool) {
 type SuccessTrackers struct {
 	trackers map[storj.NodeID]SuccessTracker
 	global   SuccessTracker
+
+	concurrency uploadConcurrency
 }
 
 // NewSuccessTrackers creates a new success tracker.
@@ -84,6 +86,7 @@ func (t *SuccessTrackers) BumpGeneration() {
 		tracker.BumpGeneration()
 	}
 	t.global.BumpGeneration()
+	t.concurrency.BumpGeneration()
 }
 
 // GetTracker returns the tracker for the specific uplink. Returns with the
@@ -95,6 +98,36 @@ func (t *SuccessTrackers) GetTracker(uplink storj.NodeID) SuccessTracker {
 	return t.global
 }
 
+// Global returns the tracker used for uplinks which are not whitelisted.
+func (t *SuccessTrackers) Global() SuccessTracker {
+	return t.global
+}
+
+// IncrementAll records the result in the trackers of all uplinks. It's used
+// for the results which aren't reported by an uplink, e.g. the invalid upload
+// orders settled by the nodes.
+func (t *SuccessTrackers) IncrementAll(node storj.NodeID, success bool) {
+	for _, tracker := range t.trackers {
+		tracker.Increment(node, success)
+	}
+	t.global.Increment(node, success)
+}
+
+// UploadsStarted records the uploads started on the nodes.
+func (t *SuccessTrackers) UploadsStarted(nodes ...storj.NodeID) {
+	t.concurrency.Started(nodes...)
+}
+
+// UploadsFinished records the uploads finished on the nodes, either successfully or not.
+func (t *SuccessTrackers) UploadsFinished(nodes ...storj.NodeID) {
+	t.concurrency.Finished(nodes...)
+}
+
+// InFlight implements nodeselection.UploadConcurrencyTracker.
+func (t *SuccessTrackers) InFlight(node storj.NodeID) int {
+	return t.concurrency.InFlight(node)
+}
+
 // Get returns a function that can be used to get an estimate of how good a node
 // is for a given uplink.
 func (t *SuccessTrackers) Get(uplink storj.NodeID) func(node storj.NodeID) float64 {
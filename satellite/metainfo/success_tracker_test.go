@@ -283,3 +283,52 @@ func TestBigBitList_small(t *testing.T) {
 	}
 	require.Equal(t, float64(3), tracker.Get(storj.NodeID{}))
 }
+
+func TestUploadConcurrency(t *testing.T) {
+	var c uploadConcurrency
+	a, b := storj.NodeID{1}, storj.NodeID{2}
+
+	require.Equal(t, 0, c.InFlight(a))
+
+	c.Started(a, a, b)
+	require.Equal(t, 2, c.InFlight(a))
+	require.Equal(t, 1, c.InFlight(b))
+
+	// the uploads started in the previous generation are still counted.
+	c.BumpGeneration()
+	c.Finished(a)
+	require.Equal(t, 1, c.InFlight(a))
+	require.Equal(t, 1, c.InFlight(b))
+
+	// the abandoned uploads are forgotten after two generations.
+	c.BumpGeneration()
+	require.Equal(t, 0, c.InFlight(b))
+	c.Finished(a)
+	require.Equal(t, 0, c.InFlight(a))
+
+	// the uploads finished after their generation was cleared don't hide
+	// the uploads started later.
+	c.Started(b)
+	c.BumpGeneration()
+	c.BumpGeneration()
+	c.Finished(b)
+	c.Started(b, b)
+	require.Equal(t, 2, c.InFlight(b))
+	c.Finished(b)
+	require.Equal(t, 1, c.InFlight(b))
+}
+
+func TestSuccessTrackersIncrementAll(t *testing.T) {
+	newTracker, ok := GetNewSuccessTracker("percent")
+	require.True(t, ok)
+
+	uplink := storj.NodeID{1}
+	trackers := NewSuccessTrackers([]storj.NodeID{uplink}, newTracker)
+	node := storj.NodeID{2}
+
+	trackers.GetTracker(uplink).Increment(node, true)
+	trackers.IncrementAll(node, false)
+
+	require.Equal(t, 1./2, trackers.GetTracker(uplink).Get(node))
+	require.Equal(t, 0., trackers.Global().Get(node))
+}
@@ -0,0 +1,31 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metainfo
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimitRetryAfter returns how long it takes until the limiter allows the next request.
+func rateLimitRetryAfter(limiter *rate.Limiter) time.Duration {
+	limit := limiter.Limit()
+	if limit <= 0 || limit == rate.Inf {
+		return 0
+	}
+	missing := 1 - limiter.Tokens()
+	if missing <= 0 {
+		return 0
+	}
+	return time.Duration(missing / float64(limit) * float64(time.Second))
+}
+
+// untilNextMonth returns the duration until the beginning of the next month, when
+// the monthly limits are reset.
+func untilNextMonth(now time.Time) time.Duration {
+	now = now.UTC()
+	nextMonth := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	return nextMonth.Sub(now)
+}
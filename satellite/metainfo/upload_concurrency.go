@@ -0,0 +1,105 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metainfo
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"storj.io/common/storj"
+)
+
+// uploadConcurrencyGenerations is the number of generations the started and
+// finished uploads are counted for.
+const uploadConcurrencyGenerations = 2
+
+type uploadCounterArray [uploadConcurrencyGenerations]atomic.Int64
+
+// uploadConcurrency estimates the number of uploads in progress per node.
+//
+// An upload is started when the node is selected for a segment, and it's
+// finished when the segment is committed (or a retry replaced the node). The
+// uploads, which are abandoned by the uplinks, are never finished, so they are
+// counted only until the counters of their generation are cleared.
+type uploadConcurrency struct {
+	mu   sync.Mutex
+	gen  atomic.Uint64
+	data sync.Map // storj.NodeID -> *uploadCounterArray
+}
+
+func (c *uploadConcurrency) counters(node storj.NodeID) *uploadCounterArray {
+	ctrsI, ok := c.data.Load(node)
+	if !ok {
+		ctrsI, _ = c.data.LoadOrStore(node, new(uploadCounterArray))
+	}
+	ctrs, _ := ctrsI.(*uploadCounterArray)
+	return ctrs
+}
+
+// Started records the uploads started on the nodes.
+func (c *uploadConcurrency) Started(nodes ...storj.NodeID) {
+	for _, node := range nodes {
+		gen := c.gen.Load() % uploadConcurrencyGenerations
+		c.counters(node)[gen].Add(1)
+	}
+}
+
+// Finished records the uploads finished on the nodes.
+//
+// It's not known in which generation an upload was started, hence the newest
+// generation with uploads in progress is decremented. The counters never go
+// below zero, so the uploads finished after their generation was cleared don't
+// hide the uploads started later.
+func (c *uploadConcurrency) Finished(nodes ...storj.NodeID) {
+	for _, node := range nodes {
+		ctrs := c.counters(node)
+		gen := c.gen.Load()
+		for age := uint64(0); age < uploadConcurrencyGenerations; age++ {
+			if decrementPositive(&ctrs[(gen+uploadConcurrencyGenerations-age)%uploadConcurrencyGenerations]) {
+				break
+			}
+		}
+	}
+}
+
+// decrementPositive decrements the counter when it's positive.
+func decrementPositive(ctr *atomic.Int64) bool {
+	for {
+		value := ctr.Load()
+		if value <= 0 {
+			return false
+		}
+		if ctr.CompareAndSwap(value, value-1) {
+			return true
+		}
+	}
+}
+
+// InFlight returns the estimated number of uploads in progress on the node.
+func (c *uploadConcurrency) InFlight(node storj.NodeID) int {
+	ctrsI, ok := c.data.Load(node)
+	if !ok {
+		return 0
+	}
+	ctrs, _ := ctrsI.(*uploadCounterArray)
+
+	var sum int64
+	for i := range ctrs {
+		sum += ctrs[i].Load()
+	}
+	return int(sum)
+}
+
+// BumpGeneration clears the counters of the oldest generation.
+func (c *uploadConcurrency) BumpGeneration() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	gen := c.gen.Add(1) % uploadConcurrencyGenerations
+	c.data.Range(func(_, ctrsI any) bool {
+		ctrs, _ := ctrsI.(*uploadCounterArray)
+		ctrs[gen].Store(0)
+		return true
+	})
+}
@@ -30,6 +30,7 @@ import (
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/console/consoleauth"
 	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/shared/throttle"
 )
 
 const encryptedKeySize = 48
@@ -305,7 +306,7 @@ func (endpoint *Endpoint) checkRate(ctx context.Context, apiKeyInfo *console.API
 
 		mon.Event("metainfo_rate_limit_exceeded") //mon:locked
 
-		return rpcstatus.Error(rpcstatus.ResourceExhausted, "Too Many Requests")
+		return throttle.Error(throttle.KindRate, rateLimitRetryAfter(limiter), "Too Many Requests")
 	}
 
 	return nil
@@ -436,7 +437,7 @@ func isDigit(r byte) bool {
 	return r >= '0' && r <= '9'
 }
 
-func (endpoint *Endpoint) validateRemoteSegment(ctx context.Context, commitRequest metabase.CommitSegment, originalLimits []*pb.OrderLimit) (err error) {
+func (endpoint *Endpoint) validateRemoteSegment(ctx context.Context, commitRequest metabase.CommitSegment, originalLimits []*pb.OrderLimit, maxSegmentSize int64) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	if len(originalLimits) == 0 {
@@ -446,7 +447,7 @@ func (endpoint *Endpoint) validateRemoteSegment(ctx context.Context, commitReque
 		return Error.New("invalid no order limit for piece")
 	}
 
-	maxAllowed, err := encryption.CalcEncryptedSize(endpoint.config.MaxSegmentSize.Int64(), storj.EncryptionParameters{
+	maxAllowed, err := encryption.CalcEncryptedSize(maxSegmentSize, storj.EncryptionParameters{
 		CipherSuite: storj.EncAESGCM,
 		BlockSize:   128, // intentionally low block size to allow maximum possible encryption overhead
 	})
@@ -527,7 +528,7 @@ func (endpoint *Endpoint) checkDownloadLimits(ctx context.Context, keyInfo *cons
 				zap.Stringer("Project ID", keyInfo.ProjectID),
 			)
 		}
-		return rpcstatus.Error(rpcstatus.ResourceExhausted, "Exceeded Usage Limit")
+		return throttle.Error(throttle.KindBandwidth, untilNextMonth(time.Now()), "Exceeded Usage Limit")
 	}
 	return nil
 }
@@ -547,7 +548,7 @@ func (endpoint *Endpoint) checkUploadLimitsForNewObject(
 				zap.Stringer("Project ID", keyInfo.ProjectID),
 			)
 		}
-		return rpcstatus.Error(rpcstatus.ResourceExhausted, "Exceeded Segments Limit")
+		return throttle.Error(throttle.KindSegments, 0, "Exceeded Segments Limit")
 	}
 
 	if limit.ExceedsStorage {
@@ -557,7 +558,7 @@ func (endpoint *Endpoint) checkUploadLimitsForNewObject(
 				zap.Stringer("Project ID", keyInfo.ProjectID),
 			)
 		}
-		return rpcstatus.Error(rpcstatus.ResourceExhausted, "Exceeded Storage Limit")
+		return throttle.Error(throttle.KindStorage, 0, "Exceeded Storage Limit")
 	}
 
 	return nil
@@ -640,7 +641,7 @@ func (endpoint *Endpoint) checkObjectUploadRate(ctx context.Context, projectID u
 		return struct{}{}, nil
 	})
 	if limited {
-		return rpcstatus.Error(rpcstatus.ResourceExhausted, "Too Many Requests")
+		return throttle.Error(throttle.KindObjectUploadRate, endpoint.config.UploadLimiter.SingleObjectLimit, "Too Many Requests")
 	}
 
 	return nil
@@ -28,6 +28,7 @@ import (
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/console/consoleauth"
 	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/shared/throttle"
 )
 
 type mockAPIKeys struct {
@@ -290,6 +291,11 @@ func TestEndpoint_checkRate(t *testing.T) {
 			require.Error(t, err)
 			require.True(t, errs2.IsRPC(err, rpcstatus.ResourceExhausted))
 
+			info, ok := throttle.Parse(err)
+			require.True(t, ok)
+			require.Equal(t, throttle.KindRate, info.Kind)
+			require.Positive(t, info.RetryAfter)
+
 			rate := int64(1)
 			burstProject := int64(2)
 			burstHead := int64(3)
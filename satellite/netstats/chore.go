@@ -0,0 +1,189 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package netstats periodically computes aggregate network statistics and serves them publicly.
+package netstats
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/overlay"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the error class for this package.
+	Error = errs.Class("netstats")
+)
+
+// Config contains configurable values for the network statistics chore.
+type Config struct {
+	Enabled         bool          `help:"whether to compute and serve the public network statistics" default:"false"`
+	Interval        time.Duration `help:"how often the network statistics are recomputed" default:"1h" testDefault:"$TESTINTERVAL"`
+	ExpansionFactor float64       `help:"expansion factor used to estimate the node capacity taken by the stored data" default:"2.75"`
+}
+
+// Overlay is the subset of the overlay service used for computing network statistics.
+type Overlay interface {
+	GetNetworkStats(ctx context.Context) (overlay.NetworkStats, error)
+}
+
+// Accounting is the subset of the live accounting cache used for computing network statistics.
+type Accounting interface {
+	GetAllProjectTotals(ctx context.Context) (map[uuid.UUID]accounting.Usage, error)
+}
+
+// Stats contains the aggregate network statistics.
+//
+// Nodes only report their free space, so UsedCapacity and TotalCapacity are
+// estimated from the stored data and the configured expansion factor.
+type Stats struct {
+	ActiveNodes       int64 `json:"activeNodes"`
+	VettedNodes       int64 `json:"vettedNodes"`
+	AvailableCapacity int64 `json:"availableCapacity"`
+	UsedCapacity      int64 `json:"usedCapacity"`
+	TotalCapacity     int64 `json:"totalCapacity"`
+	Countries         int64 `json:"countries"`
+
+	StoredData     int64 `json:"storedData"`
+	StoredSegments int64 `json:"storedSegments"`
+
+	// Durability is a placeholder for the estimated durability of the stored
+	// segments, it's always null until the estimation is implemented.
+	Durability *float64 `json:"durability"`
+
+	ComputedAt time.Time `json:"computedAt"`
+}
+
+// Chore periodically computes the network statistics and keeps the latest result in memory.
+//
+// architecture: Chore
+type Chore struct {
+	log        *zap.Logger
+	overlay    Overlay
+	accounting Accounting
+	config     Config
+
+	Loop *sync2.Cycle
+
+	mu    sync.RWMutex
+	stats *Stats
+}
+
+// NewChore creates a new network statistics chore.
+func NewChore(log *zap.Logger, overlay Overlay, accounting Accounting, config Config) *Chore {
+	return &Chore{
+		log:        log,
+		overlay:    overlay,
+		accounting: accounting,
+		config:     config,
+		Loop:       sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run starts the chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := chore.Update(ctx); err != nil {
+			chore.log.Error("failed to compute network statistics", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// Update recomputes the network statistics.
+func (chore *Chore) Update(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	networkStats, err := chore.overlay.GetNetworkStats(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	totals, err := chore.accounting.GetAllProjectTotals(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	stats := &Stats{
+		ActiveNodes:       networkStats.ActiveNodes,
+		VettedNodes:       networkStats.VettedNodes,
+		AvailableCapacity: networkStats.FreeDisk,
+		Countries:         networkStats.Countries,
+		ComputedAt:        time.Now().UTC(),
+	}
+	for _, usage := range totals {
+		stats.StoredData += usage.Storage
+		stats.StoredSegments += usage.Segments
+	}
+	stats.UsedCapacity = int64(float64(stats.StoredData) * chore.config.ExpansionFactor)
+	stats.TotalCapacity = stats.AvailableCapacity + stats.UsedCapacity
+
+	mon.IntVal("active_nodes").Observe(stats.ActiveNodes)
+	mon.IntVal("available_capacity").Observe(stats.AvailableCapacity)
+	mon.IntVal("total_capacity").Observe(stats.TotalCapacity)
+	mon.IntVal("stored_data").Observe(stats.StoredData)
+
+	chore.mu.Lock()
+	chore.stats = stats
+	chore.mu.Unlock()
+	return nil
+}
+
+// Stats returns the latest computed network statistics.
+// It returns false when the statistics haven't been computed yet.
+func (chore *Chore) Stats() (Stats, bool) {
+	chore.mu.RLock()
+	defer chore.mu.RUnlock()
+
+	if chore.stats == nil {
+		return Stats{}, false
+	}
+	return *chore.stats, true
+}
+
+// ServeHTTP serves the latest computed network statistics as JSON.
+func (chore *Chore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	if r.Method == http.MethodOptions {
+		return
+	}
+
+	stats, ok := chore.Stats()
+	if !ok {
+		http.Error(w, "network statistics are not available yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(chore.config.Interval.Seconds())))
+
+	err = json.NewEncoder(w).Encode(stats)
+	if err != nil {
+		chore.log.Error("failed to write network statistics response", zap.Error(err))
+	}
+}
+
+// Close stops the chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
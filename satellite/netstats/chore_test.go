@@ -0,0 +1,98 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package netstats_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/netstats"
+	"storj.io/storj/satellite/overlay"
+)
+
+type fakeOverlay struct {
+	stats overlay.NetworkStats
+}
+
+func (fake *fakeOverlay) GetNetworkStats(ctx context.Context) (overlay.NetworkStats, error) {
+	return fake.stats, nil
+}
+
+type fakeAccounting struct {
+	totals map[uuid.UUID]accounting.Usage
+}
+
+func (fake *fakeAccounting) GetAllProjectTotals(ctx context.Context) (map[uuid.UUID]accounting.Usage, error) {
+	return fake.totals, nil
+}
+
+func TestChore(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	fake := &fakeOverlay{
+		stats: overlay.NetworkStats{
+			ActiveNodes: 10,
+			VettedNodes: 7,
+			FreeDisk:    1000,
+			Countries:   3,
+		},
+	}
+	accountingTotals := &fakeAccounting{
+		totals: map[uuid.UUID]accounting.Usage{
+			testrand.UUID(): {Storage: 100, Segments: 4},
+			testrand.UUID(): {Storage: 300, Segments: 6},
+		},
+	}
+	chore := netstats.NewChore(zaptest.NewLogger(t), fake, accountingTotals, netstats.Config{
+		Interval:        time.Hour,
+		ExpansionFactor: 2,
+	})
+	defer ctx.Check(chore.Close)
+
+	// statistics are unavailable until computed
+	_, ok := chore.Stats()
+	require.False(t, ok)
+
+	recorder := httptest.NewRecorder()
+	chore.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v0/network/stats", nil))
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	require.NoError(t, chore.Update(ctx))
+
+	stats, ok := chore.Stats()
+	require.True(t, ok)
+	require.EqualValues(t, 10, stats.ActiveNodes)
+	require.EqualValues(t, 7, stats.VettedNodes)
+	require.EqualValues(t, 1000, stats.AvailableCapacity)
+	require.EqualValues(t, 3, stats.Countries)
+	require.EqualValues(t, 400, stats.StoredData)
+	require.EqualValues(t, 10, stats.StoredSegments)
+	require.EqualValues(t, 800, stats.UsedCapacity)
+	require.EqualValues(t, 1800, stats.TotalCapacity)
+	require.Nil(t, stats.Durability)
+
+	recorder = httptest.NewRecorder()
+	chore.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v0/network/stats", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "*", recorder.Header().Get("Access-Control-Allow-Origin"))
+	require.Equal(t, "public, max-age=3600", recorder.Header().Get("Cache-Control"))
+
+	var served netstats.Stats
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&served))
+	require.Equal(t, stats.ActiveNodes, served.ActiveNodes)
+	require.Equal(t, stats.AvailableCapacity, served.AvailableCapacity)
+	require.Equal(t, stats.TotalCapacity, served.TotalCapacity)
+	require.Equal(t, stats.StoredData, served.StoredData)
+}
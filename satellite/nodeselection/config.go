@@ -5,6 +5,7 @@ package nodeselection
 
 import (
 	"bytes"
+	"math"
 	"os"
 	"strings"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/zeebo/errs"
 	"gopkg.in/yaml.v3"
 
+	"storj.io/common/memory"
 	"storj.io/common/storj"
 )
 
@@ -33,6 +35,13 @@ type placementDefinition struct {
 	Selector         string
 	DownloadSelector string
 	EC               ECParameters
+	Experiment       *experimentDefinition
+}
+
+// experimentDefinition is the representation of a node selection experiment of a placement.
+type experimentDefinition struct {
+	Fraction float64
+	Selector string
 }
 
 // UploadSuccessTracker can give hints about the frequency of the long-tail cancellation per node.
@@ -40,6 +49,11 @@ type UploadSuccessTracker interface {
 	Get(uplink storj.NodeID) func(node storj.NodeID) float64
 }
 
+// UploadConcurrencyTracker estimates the number of uploads in progress per node.
+type UploadConcurrencyTracker interface {
+	InFlight(node storj.NodeID) int
+}
+
 // NoopTracker doesn't tracker uploads at all. Always returns with zero.
 type NoopTracker struct {
 }
@@ -49,20 +63,33 @@ func (n NoopTracker) Get(uplink storj.NodeID) func(node storj.NodeID) float64 {
 	return func(node storj.NodeID) float64 { return 0 }
 }
 
+// InFlight implements UploadConcurrencyTracker.
+func (n NoopTracker) InFlight(node storj.NodeID) int {
+	return 0
+}
+
 var _ UploadSuccessTracker = NoopTracker{}
+var _ UploadConcurrencyTracker = NoopTracker{}
 
 // PlacementConfigEnvironment includes all generic functions and variables, which can be used in the configuration.
 type PlacementConfigEnvironment struct {
-	tracker UploadSuccessTracker
+	tracker     UploadSuccessTracker
+	concurrency UploadConcurrencyTracker
 }
 
 // NewPlacementConfigEnvironment creates PlacementConfigEnvironment.
+// The tracker is also used as the concurrency tracker, if it implements UploadConcurrencyTracker.
 func NewPlacementConfigEnvironment(tracker UploadSuccessTracker) *PlacementConfigEnvironment {
 	if tracker == nil {
 		tracker = NoopTracker{}
 	}
+	concurrency, ok := tracker.(UploadConcurrencyTracker)
+	if !ok {
+		concurrency = NoopTracker{}
+	}
 	return &PlacementConfigEnvironment{
-		tracker: tracker,
+		tracker:     tracker,
+		concurrency: concurrency,
 	}
 }
 
@@ -71,6 +98,7 @@ func (e *PlacementConfigEnvironment) apply(env map[any]any) {
 		return
 	}
 	env["tracker"] = e.tracker
+	env["concurrency"] = e.concurrency
 }
 
 // LoadConfig loads the placement yaml file and creates the Placement definitions.
@@ -135,6 +163,21 @@ func LoadConfig(configFile string, environment *PlacementConfigEnvironment) (Pla
 			return placements, errs.New("DownloadSelector definition '%s' of placement %d is invalid: %v", downloadSelector, def.ID, err)
 		}
 
+		if def.Experiment != nil {
+			if math.IsNaN(def.Experiment.Fraction) || def.Experiment.Fraction < 0 || def.Experiment.Fraction > 1 {
+				return placements, errs.New("Experiment fraction %v of placement %d is invalid: should be between 0 and 1", def.Experiment.Fraction, def.ID)
+			}
+			experimentSelector := resolveTemplates(def.Experiment.Selector)
+			selector, err := SelectorFromString(experimentSelector, environment)
+			if err != nil {
+				return placements, errs.New("Experiment selector definition '%s' of placement %d is invalid: %v", experimentSelector, def.ID, err)
+			}
+			p.Experiment = &SelectionExperiment{
+				Fraction: def.Experiment.Fraction,
+				Selector: selector,
+			}
+		}
+
 		placements[def.ID] = p
 	}
 	return placements, nil
@@ -144,6 +187,9 @@ var supportedFilters = map[any]any{
 	"country": func(countries ...string) (NodeFilter, error) {
 		return NewCountryFilterFromString(countries)
 	},
+	"asn": func(asns ...int64) (NodeFilter, error) {
+		return NewASNFilter(asns...)
+	},
 	"all": func(filters ...NodeFilter) (NodeFilters, error) {
 		res := NodeFilters{}
 		for _, filter := range filters {
@@ -244,21 +290,38 @@ func SelectorFromString(expr string, environment *PlacementConfigEnvironment) (N
 		expr = "random()"
 	}
 	env := map[any]any{
-		"attribute": func(attribute interface{}) (NodeSelectorInit, error) {
+		"attribute": func(attribute interface{}, limit ...int64) (NodeSelectorInit, error) {
+			if len(limit) > 1 || (len(limit) == 1 && limit[0] < 1) {
+				return nil, Error.New("invalid group limit of attribute selector %s", expr)
+			}
+			maxPerGroup := 1
+			if len(limit) == 1 {
+				maxPerGroup = int(limit[0])
+			}
+
 			switch value := attribute.(type) {
 			case NodeAttribute:
-				return AttributeGroupSelector(value), nil
+				return AttributeGroupSelectorWithLimit(value, maxPerGroup), nil
 			case string:
 				attr, err := CreateNodeAttribute(value)
 				if err != nil {
 					return nil, err
 				}
-				return AttributeGroupSelector(attr), nil
+				return AttributeGroupSelectorWithLimit(attr, maxPerGroup), nil
 			default:
 				return nil, Error.New("unable to create attribute selector from %s (%T)", expr, attribute)
 			}
 		},
-		"subnet": Subnet,
+		"subnet": func(bits int64, ipv6Bits ...int64) (NodeAttribute, error) {
+			switch len(ipv6Bits) {
+			case 0:
+				return Subnet(bits), nil
+			case 1:
+				return SubnetByFamily(bits, ipv6Bits[0]), nil
+			default:
+				return nil, Error.New("subnet accepts the IPv4 and the IPv6 netmask length only: %s", expr)
+			}
+		},
 		"random": func() (NodeSelectorInit, error) {
 			return RandomSelector(), nil
 		},
@@ -278,8 +341,23 @@ func SelectorFromString(expr string, environment *PlacementConfigEnvironment) (N
 			}
 			return BalancedGroupBasedSelector(attr), nil
 		},
-		"filterbest": FilterBest,
-		"bestofn":    BestOfN,
+		"filterbest":  FilterBest,
+		"bestofn":     BestOfN,
+		"unsaturated": Unsaturated,
+		"freedisk": func(minFreeDisk, maxFreeDisk string) (NodeSelectorInit, error) {
+			minSize, err := memory.ParseString(minFreeDisk)
+			if err != nil {
+				return nil, Error.New("invalid minimum free disk of freedisk selector %s: %v", expr, err)
+			}
+			maxSize, err := memory.ParseString(maxFreeDisk)
+			if err != nil {
+				return nil, Error.New("invalid maximum free disk of freedisk selector %s: %v", expr, err)
+			}
+			if minSize < 0 || maxSize <= 0 || minSize > maxSize {
+				return nil, Error.New("invalid free disk range of freedisk selector %s", expr)
+			}
+			return FreeDiskWeightedSelector(minSize, maxSize), nil
+		},
 		"eq": func(a, b string) func(SelectedNode) bool {
 			attr, err := CreateNodeAttribute(a)
 			if err != nil {
@@ -298,7 +376,8 @@ func SelectorFromString(expr string, environment *PlacementConfigEnvironment) (N
 			}
 			return IfSelector(condition, trueAttr, falseAttr), nil
 		},
-		"dual": DualSelector,
+		"dual":   DualSelector,
+		"prefer": PreferSelector,
 	}
 	for k, v := range supportedFilters {
 		env[k] = v
@@ -4,6 +4,7 @@
 package nodeselection
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -32,6 +33,14 @@ func TestParsedConfig(t *testing.T) {
 		require.Equal(t, "eu-1", config[1].Name)
 	}
 
+	{
+		// checking selection experiment
+		require.Nil(t, config[0].Experiment)
+		require.NotNil(t, config[1].Experiment)
+		require.Equal(t, 0.1, config[1].Experiment.Fraction)
+		require.NotNil(t, config[1].Experiment.Selector)
+	}
+
 	{
 		// checking one invariant
 		node := func(ix int, owner string) SelectedNode {
@@ -171,6 +180,33 @@ func TestSelectorFromString(t *testing.T) {
 
 }
 
+func TestSubnetSelectorFromString(t *testing.T) {
+	// the IPv6 nodes share a /48 network, but they are in different /64 networks.
+	var nodes []*SelectedNode
+	for i := 0; i < 4; i++ {
+		nodes = append(nodes, &SelectedNode{
+			ID:         testidentity.MustPregeneratedIdentity(i, storj.LatestIDVersion()).ID,
+			LastIPPort: fmt.Sprintf("[2001:db8:1:%d::1]:28967", i),
+		})
+	}
+
+	for expr, expected := range map[string]int{
+		`attribute(subnet(24))`:     1,
+		`attribute(subnet(24, 64))`: 4,
+		`attribute(subnet(24, 48))`: 1,
+	} {
+		selector, err := SelectorFromString(expr, nil)
+		require.NoError(t, err, expr)
+
+		selected, err := selector(nodes, nil)(storj.NodeID{}, 4, nil, nil)
+		require.NoError(t, err, expr)
+		require.Len(t, selected, expected, expr)
+	}
+
+	_, err := SelectorFromString(`attribute(subnet(24, 48, 64))`, nil)
+	require.Error(t, err)
+}
+
 type mockTracker struct {
 }
 
@@ -0,0 +1,65 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodeselection
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+)
+
+// SelectionArm identifies the node selection implementation used for an upload.
+type SelectionArm int
+
+const (
+	// ControlArm uses the regular selector of the placement.
+	ControlArm SelectionArm = iota
+	// ExperimentArm uses the experimental selector of the placement.
+	ExperimentArm
+)
+
+// String implements fmt.Stringer.
+func (arm SelectionArm) String() string {
+	switch arm {
+	case ControlArm:
+		return "control"
+	case ExperimentArm:
+		return "experiment"
+	default:
+		return "unknown"
+	}
+}
+
+// experimentBuckets is the resolution of the fraction of uploads assigned to the experiment.
+const experimentBuckets = 10000
+
+// SelectionExperiment selects the nodes of a fraction of the uploads with an
+// experimental selector, so new algorithms can be compared with the regular one.
+type SelectionExperiment struct {
+	// Fraction is the fraction (0..1) of the uploads using the experimental selector.
+	Fraction float64
+	// Selector is the experimental node selection algorithm.
+	Selector NodeSelectorInit
+}
+
+// Arm returns the arm of the upload of the given segment. The assignment is
+// deterministic, so the arm can be recomputed when the segment is committed.
+func (experiment *SelectionExperiment) Arm(streamID []byte, part, index uint32) SelectionArm {
+	if experiment == nil || experiment.Selector == nil || math.IsNaN(experiment.Fraction) || experiment.Fraction <= 0 {
+		return ControlArm
+	}
+
+	var position [8]byte
+	binary.BigEndian.PutUint32(position[:4], part)
+	binary.BigEndian.PutUint32(position[4:], index)
+
+	h := fnv.New64a()
+	_, _ = h.Write(streamID)
+	_, _ = h.Write(position[:])
+
+	if float64(h.Sum64()%experimentBuckets) < experiment.Fraction*experimentBuckets {
+		return ExperimentArm
+	}
+	return ControlArm
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodeselection_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/nodeselection"
+)
+
+func TestSelectionExperiment_Arm(t *testing.T) {
+	var disabled *nodeselection.SelectionExperiment
+	require.Equal(t, nodeselection.ControlArm, disabled.Arm(testrand.UUID().Bytes(), 0, 0))
+
+	experiment := &nodeselection.SelectionExperiment{
+		Fraction: 0.3,
+		Selector: nodeselection.RandomSelector(),
+	}
+
+	const uploads = 10000
+	experimentCount := 0
+	for i := 0; i < uploads; i++ {
+		streamID := testrand.UUID().Bytes()
+		arm := experiment.Arm(streamID, 0, uint32(i))
+		// the same segment is always assigned to the same arm
+		require.Equal(t, arm, experiment.Arm(streamID, 0, uint32(i)))
+		if arm == nodeselection.ExperimentArm {
+			experimentCount++
+		}
+	}
+	require.InDelta(t, 0.3*uploads, experimentCount, 0.05*uploads)
+
+	experiment.Fraction = 0
+	require.Equal(t, nodeselection.ControlArm, experiment.Arm(testrand.UUID().Bytes(), 0, 0))
+
+	experiment.Fraction = 1
+	require.Equal(t, nodeselection.ExperimentArm, experiment.Arm(testrand.UUID().Bytes(), 0, 0))
+}
@@ -6,8 +6,10 @@ package nodeselection
 import (
 	"encoding/hex"
 	"fmt"
+	"math"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/zeebo/errs"
@@ -308,6 +310,45 @@ func (e ExcludedIDs) Match(node *SelectedNode) bool {
 
 var _ NodeFilter = ExcludedIDs{}
 
+// ASNFilter selects the nodes within the specified autonomous systems. Nodes with unknown ASN are never selected.
+type ASNFilter []uint32
+
+// NewASNFilter creates a filter for the autonomous system numbers.
+func NewASNFilter(asns ...int64) (ASNFilter, error) {
+	filter := make(ASNFilter, 0, len(asns))
+	for _, asn := range asns {
+		if asn <= 0 || asn > math.MaxUint32 {
+			return nil, errs.New("invalid autonomous system number: %d", asn)
+		}
+		filter = append(filter, uint32(asn))
+	}
+	return filter, nil
+}
+
+// Match implements NodeFilter interface.
+func (a ASNFilter) Match(node *SelectedNode) bool {
+	asn, ok := ASN(*node)
+	if !ok {
+		return false
+	}
+	for _, n := range a {
+		if n == asn {
+			return true
+		}
+	}
+	return false
+}
+
+func (a ASNFilter) String() string {
+	var asns []string
+	for _, asn := range a {
+		asns = append(asns, strconv.FormatUint(uint64(asn), 10))
+	}
+	return fmt.Sprintf("asn(%s)", strings.Join(asns, ","))
+}
+
+var _ NodeFilter = ASNFilter{}
+
 // ValueMatch defines how to compare tag value with the defined one.
 type ValueMatch func(a []byte, b []byte) bool
 
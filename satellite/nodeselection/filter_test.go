@@ -221,6 +221,48 @@ func TestAttributeFilter(t *testing.T) {
 
 }
 
+func TestASNFilter(t *testing.T) {
+	asnNode := func(signer storj.NodeID, asn string) *SelectedNode {
+		return &SelectedNode{
+			Tags: NodeTags{
+				{
+					Signer: signer,
+					Name:   ASNTag,
+					Value:  []byte(asn),
+				},
+			},
+		}
+	}
+
+	filter, err := FilterFromString(`asn(64500, 64501)`)
+	require.NoError(t, err)
+	require.Equal(t, "asn(64500,64501)", filter.(fmt.Stringer).String())
+
+	require.True(t, filter.Match(asnNode(storj.NodeID{}, "64500")))
+	require.True(t, filter.Match(asnNode(storj.NodeID{}, "64501")))
+	require.False(t, filter.Match(asnNode(storj.NodeID{}, "64502")))
+	require.False(t, filter.Match(asnNode(storj.NodeID{}, "invalid")))
+	require.False(t, filter.Match(&SelectedNode{}))
+
+	// only the ASN resolved by the satellite is used.
+	require.False(t, filter.Match(asnNode(testrand.NodeID(), "64500")))
+
+	filter, err = FilterFromString(`exclude(asn(64500))`)
+	require.NoError(t, err)
+	require.False(t, filter.Match(asnNode(storj.NodeID{}, "64500")))
+	require.True(t, filter.Match(asnNode(storj.NodeID{}, "64501")))
+
+	_, err = FilterFromString(`asn(0)`)
+	require.Error(t, err)
+	_, err = FilterFromString(`asn(4294967296)`)
+	require.Error(t, err)
+
+	attr, err := CreateNodeAttribute("asn")
+	require.NoError(t, err)
+	require.Equal(t, "64500", attr(*asnNode(storj.NodeID{}, "64500")))
+	require.Equal(t, "", attr(*asnNode(testrand.NodeID(), "64500")))
+}
+
 // BenchmarkNodeFilterFullTable checks performances of rule evaluation on ALL storage nodes.
 func BenchmarkNodeFilterFullTable(b *testing.B) {
 	filters := NodeFilters{}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -55,6 +56,8 @@ type SelectedNode struct {
 	Vetted      bool
 	Tags        NodeTags
 	PieceCount  int64
+	// FreeDisk is the free disk space reported by the node. It's only set for upload selection.
+	FreeDisk int64
 }
 
 // Clone returns a deep clone of the selected node.
@@ -74,7 +77,11 @@ var LastNetAttribute = mustCreateNodeAttribute("last_net")
 // Subnet can return the IP network of the node for any netmask length.
 func Subnet(bits int64) NodeAttribute {
 	return func(node SelectedNode) string {
-		addr, _, _ := strings.Cut(node.LastIPPort, ":")
+		// LastIPPort may contain an IPv6 address, which is enclosed in brackets.
+		addr, _, err := net.SplitHostPort(node.LastIPPort)
+		if err != nil {
+			addr = node.LastIPPort
+		}
 		_, network, err := net.ParseCIDR(fmt.Sprintf("%s/%d", addr, bits))
 		if err != nil {
 			return "error:" + err.Error()
@@ -83,6 +90,60 @@ func Subnet(bits int64) NodeAttribute {
 	}
 }
 
+// SubnetByFamily returns the IP network of the node with a different netmask length for IPv4 and IPv6
+// addresses, as the IPv6 networks assigned to the operators are much larger (like /48 or /56).
+func SubnetByFamily(ipv4Bits, ipv6Bits int64) NodeAttribute {
+	ipv4, ipv6 := Subnet(ipv4Bits), Subnet(ipv6Bits)
+	return func(node SelectedNode) string {
+		addr, _, err := net.SplitHostPort(node.LastIPPort)
+		if err != nil {
+			addr = node.LastIPPort
+		}
+		if ip := net.ParseIP(addr); ip != nil && ip.To4() == nil {
+			return ipv6(node)
+		}
+		return ipv4(node)
+	}
+}
+
+// MaxConcurrentUploadsTag is the name of the node tag, which announces the preferred maximum number of
+// concurrent uploads of the node. Only the tags signed by the node itself are used.
+const MaxConcurrentUploadsTag = "max_concurrent_uploads"
+
+// MaxConcurrentUploads returns the preferred maximum number of concurrent uploads announced by the node.
+func MaxConcurrentUploads(node SelectedNode) (limit int, ok bool) {
+	var signedAt time.Time
+	for _, tag := range node.Tags {
+		if tag.Name != MaxConcurrentUploadsTag || tag.Signer != node.ID || tag.SignedAt.Before(signedAt) {
+			continue
+		}
+		value, err := strconv.Atoi(string(tag.Value))
+		if err != nil || value <= 0 {
+			continue
+		}
+		limit, ok, signedAt = value, true, tag.SignedAt
+	}
+	return limit, ok
+}
+
+// ASNTag is the name of the node tag, which records the autonomous system number of the last IP address
+// of the node, as resolved by the satellite. It's stored with an empty signer, which can't be produced by
+// a signed node tag set, so the node isn't able to overwrite it.
+const ASNTag = "asn"
+
+// ASN returns the autonomous system number of the node resolved by the satellite.
+func ASN(node SelectedNode) (asn uint32, ok bool) {
+	tag, err := node.Tags.FindBySignerAndName(storj.NodeID{}, ASNTag)
+	if err != nil {
+		return 0, false
+	}
+	value, err := strconv.ParseUint(string(tag.Value), 10, 32)
+	if err != nil || value == 0 {
+		return 0, false
+	}
+	return uint32(value), true
+}
+
 func mustCreateNodeAttribute(attr string) NodeAttribute {
 	nodeAttr, err := CreateNodeAttribute(attr)
 	if err != nil {
@@ -156,6 +217,14 @@ func CreateNodeAttribute(attr string) (NodeAttribute, error) {
 		return func(node SelectedNode) string {
 			return fmt.Sprintf("%t", node.Vetted)
 		}, nil
+	case "asn":
+		return func(node SelectedNode) string {
+			asn, ok := ASN(node)
+			if !ok {
+				return ""
+			}
+			return strconv.FormatUint(uint64(asn), 10)
+		}, nil
 	default:
 		return nil, errors.New("Unsupported node attribute: " + attr)
 	}
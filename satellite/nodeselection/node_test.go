@@ -87,16 +87,42 @@ func TestSubnet(t *testing.T) {
 	}
 	require.Equal(t, "12.16.0.0/12", Subnet(12)(s))
 	require.Equal(t, "12.23.34.45/32", Subnet(32)(s))
+
+	s = SelectedNode{
+		LastIPPort: "[2001:db8:1:2:3:4:5:6]:8888",
+	}
+	require.Equal(t, "2001:db8:1:2::/64", Subnet(64)(s))
+	require.Equal(t, "2001:db8::/32", Subnet(32)(s))
+	require.Equal(t, "2001:db8:1::/48", SubnetByFamily(24, 48)(s))
+
+	s = SelectedNode{
+		LastIPPort: "12.23.34.45:8888",
+	}
+	require.Equal(t, "12.23.34.0/24", SubnetByFamily(24, 48)(s))
 }
 
 func BenchmarkSubnet(b *testing.B) {
-	var s string
-	for i := 0; i < b.N; i++ {
-		s = Subnet(25)(SelectedNode{
-			LastIPPort: fmt.Sprintf("%d.%d.%d.%d:1234", (i>>24)%256, (i>>16)%256, (i>>8)%256, i%256),
-		})
-		if strings.Contains(s, "error") {
-			b.Fatal(s)
+	b.Run("IPv4", func(b *testing.B) {
+		var s string
+		for i := 0; i < b.N; i++ {
+			s = Subnet(25)(SelectedNode{
+				LastIPPort: fmt.Sprintf("%d.%d.%d.%d:1234", (i>>24)%256, (i>>16)%256, (i>>8)%256, i%256),
+			})
+			if strings.Contains(s, "error") {
+				b.Fatal(s)
+			}
 		}
-	}
+	})
+
+	b.Run("IPv6", func(b *testing.B) {
+		var s string
+		for i := 0; i < b.N; i++ {
+			s = Subnet(64)(SelectedNode{
+				LastIPPort: fmt.Sprintf("[2001:db8:%x:%x::%x]:1234", (i>>16)%65536, (i>>8)%256, i%256),
+			})
+			if strings.Contains(s, "error") {
+				b.Fatal(s)
+			}
+		}
+	})
 }
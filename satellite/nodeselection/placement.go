@@ -33,6 +33,8 @@ type Placement struct {
 	// downloading (e.g., at random from the uploaded set, or filtered down with
 	// choice of 2).
 	DownloadSelector DownloadSelector
+	// Experiment optionally selects the nodes of a fraction of the uploads with an experimental selector.
+	Experiment *SelectionExperiment
 
 	// EC defines erasure coding parameter overrides.
 	EC ECParameters `yaml:"ec"`
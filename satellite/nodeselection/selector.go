@@ -131,6 +131,69 @@ func AttributeGroupSelector(attribute NodeAttribute) NodeSelectorInit {
 	}
 }
 
+// AttributeGroupSelectorWithLimit is like AttributeGroupSelector, but it selects up to limit nodes from
+// the same group (like last_net). The nodes are selected from distinct groups first, and a group is used
+// again only when there are not enough groups.
+func AttributeGroupSelectorWithLimit(attribute NodeAttribute, limit int) NodeSelectorInit {
+	if limit <= 1 {
+		return AttributeGroupSelector(attribute)
+	}
+	return func(nodes []*SelectedNode, filter NodeFilter) NodeSelector {
+		nodeByAttribute := make(map[string][]*SelectedNode)
+		for _, node := range nodes {
+			if filter != nil && !filter.Match(node) {
+				continue
+			}
+			a := attribute(*node)
+			nodeByAttribute[a] = append(nodeByAttribute[a], node)
+		}
+
+		var attributes []string
+		for k := range nodeByAttribute {
+			attributes = append(attributes, k)
+		}
+
+		return func(requester storj.NodeID, n int, excluded []storj.NodeID, alreadySelected []*SelectedNode) (selected []*SelectedNode, err error) {
+			if n == 0 {
+				return selected, nil
+			}
+
+			used := make(map[string]int)
+			for _, node := range alreadySelected {
+				used[attribute(*node)]++
+			}
+
+			// every pass selects at most one more node from each group.
+			for pass := 0; pass < limit && len(selected) < n; pass++ {
+				r := NewRandomOrder(len(attributes))
+				for r.Next() {
+					a := attributes[r.At()]
+					if used[a] > pass {
+						continue
+					}
+
+					nodes := nodeByAttribute[a]
+					rs := NewRandomOrder(len(nodes))
+					for rs.Next() {
+						candidate := nodes[rs.At()]
+						if included(excluded, candidate) || includedInNodes(alreadySelected, candidate) || includedInNodes(selected, candidate) {
+							continue
+						}
+						selected = append(selected, candidate.Clone())
+						used[a]++
+						break
+					}
+
+					if len(selected) >= n {
+						break
+					}
+				}
+			}
+			return selected, nil
+		}
+	}
+}
+
 // IfSelector selects the first node attribute if the condition is true, otherwise the second node attribute.
 func IfSelector(condition func(SelectedNode) bool, conditionTrue, conditionFalse NodeAttribute) NodeAttribute {
 	return func(node SelectedNode) string {
@@ -558,6 +621,126 @@ func EnoughFast(tracker UploadSuccessTracker, ratio float64, splitLine float64,
 	}
 }
 
+// Unsaturated selects `ratio` times more nodes with the delegate, and prefers the nodes which don't have more
+// uploads in progress than their announced maximum (see MaxConcurrentUploads). The saturated nodes are used
+// only when there are not enough other nodes, the least saturated first.
+func Unsaturated(tracker UploadConcurrencyTracker, ratio float64, delegate NodeSelectorInit) NodeSelectorInit {
+	return func(nodes []*SelectedNode, filter NodeFilter) NodeSelector {
+		wrappedSelector := delegate(nodes, filter)
+		return func(requester storj.NodeID, n int, excluded []storj.NodeID, alreadySelected []*SelectedNode) ([]*SelectedNode, error) {
+			nodesToSelect := int(ratio * float64(n))
+			if nodesToSelect < n {
+				nodesToSelect = n
+			}
+			selectedNodes, err := wrappedSelector(requester, nodesToSelect, excluded, alreadySelected)
+			if err != nil {
+				return selectedNodes, err
+			}
+
+			saturation := make(map[storj.NodeID]float64, len(selectedNodes))
+			for _, node := range selectedNodes {
+				if limit, ok := MaxConcurrentUploads(*node); ok {
+					saturation[node.ID] = float64(tracker.InFlight(node.ID)) / float64(limit)
+				}
+			}
+			isSaturated := func(node *SelectedNode) bool {
+				return saturation[node.ID] >= 1
+			}
+
+			// the order of the delegate is kept for the unsaturated nodes.
+			slices.SortStableFunc(selectedNodes, func(a, b *SelectedNode) int {
+				saturatedA, saturatedB := isSaturated(a), isSaturated(b)
+				switch {
+				case saturatedA && saturatedB:
+					switch {
+					case saturation[a.ID] < saturation[b.ID]:
+						return -1
+					case saturation[a.ID] > saturation[b.ID]:
+						return 1
+					}
+					return 0
+				case saturatedA:
+					return 1
+				case saturatedB:
+					return -1
+				default:
+					return 0
+				}
+			})
+
+			if len(selectedNodes) > n {
+				selectedNodes = selectedNodes[:n]
+			}
+			return selectedNodes, nil
+		}
+	}
+}
+
+// FreeDiskWeightedSelector selects nodes with a chance proportional to their reported free disk space,
+// to balance the fill rate of nodes with different capacity. The free disk space is clamped to
+// [minFreeDisk, maxFreeDisk], so nodes with little space are still selected sometimes, and a single
+// huge node doesn't attract most of the uploads.
+func FreeDiskWeightedSelector(minFreeDisk, maxFreeDisk int64) NodeSelectorInit {
+	return func(nodes []*SelectedNode, filter NodeFilter) NodeSelector {
+		var filteredNodes []*SelectedNode
+		var cumulativeWeights []float64
+		var totalWeight float64
+		for _, node := range nodes {
+			if filter != nil && !filter.Match(node) {
+				continue
+			}
+			weight := node.FreeDisk
+			if weight < minFreeDisk {
+				weight = minFreeDisk
+			}
+			if weight > maxFreeDisk {
+				weight = maxFreeDisk
+			}
+			if weight < 1 {
+				weight = 1
+			}
+			totalWeight += float64(weight)
+			filteredNodes = append(filteredNodes, node)
+			cumulativeWeights = append(cumulativeWeights, totalWeight)
+		}
+
+		return func(requester storj.NodeID, n int, excluded []storj.NodeID, alreadySelected []*SelectedNode) (selected []*SelectedNode, err error) {
+			if n == 0 || len(filteredNodes) == 0 {
+				return selected, nil
+			}
+
+			isCandidate := func(node *SelectedNode) bool {
+				return !includedInNodes(alreadySelected, node) && !included(excluded, node) && !includedInNodes(selected, node)
+			}
+
+			// weighted sampling with rejection of the nodes which can't be selected.
+			for attempts := 0; len(selected) < n && attempts < 10*n; attempts++ {
+				index := sort.SearchFloat64s(cumulativeWeights, rand.Float64()*totalWeight)
+				if index >= len(filteredNodes) {
+					continue
+				}
+				candidate := filteredNodes[index]
+				if isCandidate(candidate) {
+					selected = append(selected, candidate.Clone())
+				}
+			}
+
+			// when most of the weight is excluded, the remaining nodes are selected with equal chance.
+			if len(selected) < n {
+				mon.Counter("selector_freedisk_fallback").Inc(1)
+				r := NewRandomOrder(len(filteredNodes))
+				for r.Next() && len(selected) < n {
+					candidate := filteredNodes[r.At()]
+					if isCandidate(candidate) {
+						selected = append(selected, candidate.Clone())
+					}
+				}
+			}
+			return selected, nil
+		}
+	}
+}
+
 func pickRandom(nodes []*SelectedNode, required int) (res []*SelectedNode) {
 	r := NewRandomOrder(len(nodes))
 	for r.Next() {
@@ -600,6 +783,39 @@ func DualSelector(fraction float64, first NodeSelectorInit, second NodeSelectorI
 	}
 }
 
+// PreferSelector selects nodes matching the preferred filter first, and selects the remaining
+// nodes from the other ones with the same selector, e.g. to prefer directly reachable nodes.
+func PreferSelector(preferred NodeFilter, init NodeSelectorInit) NodeSelectorInit {
+	return func(nodes []*SelectedNode, filter NodeFilter) NodeSelector {
+		var preferredNodes, otherNodes []*SelectedNode
+		for _, node := range nodes {
+			if preferred.Match(node) {
+				preferredNodes = append(preferredNodes, node)
+			} else {
+				otherNodes = append(otherNodes, node)
+			}
+		}
+
+		preferredSelector := init(preferredNodes, filter)
+		otherSelector := init(otherNodes, filter)
+		return func(requester storj.NodeID, n int, excluded []storj.NodeID, alreadySelected []*SelectedNode) ([]*SelectedNode, error) {
+			selected, err := preferredSelector(requester, n, excluded, alreadySelected)
+			if err != nil || len(selected) >= n {
+				return selected, err
+			}
+
+			// alreadySelected belongs to the caller, appending to it could
+			// overwrite the caller's data.
+			alreadySelectedAll := make([]*SelectedNode, 0, len(alreadySelected)+len(selected))
+			alreadySelectedAll = append(alreadySelectedAll, alreadySelected...)
+			alreadySelectedAll = append(alreadySelectedAll, selected...)
+
+			selectedOthers, err := otherSelector(requester, n-len(selected), excluded, alreadySelectedAll)
+			return append(selected, selectedOthers...), err
+		}
+	}
+}
+
 // RoundWithProbability is like math.Round, but instead of rounding 2.6 to 3 all the time, it will
 // round up to 3 with 60% chance, and to 2 with 40% chance.
 func RoundWithProbability(r float64) int {
@@ -8,11 +8,14 @@ import (
 	"math"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
 
 	"storj.io/common/identity/testidentity"
+	"storj.io/common/memory"
 	"storj.io/common/storj"
 	"storj.io/common/storj/location"
 	"storj.io/common/testcontext"
@@ -224,6 +227,66 @@ func TestSelectBySubnetOneAtATime(t *testing.T) {
 	assert.InDelta(t, subnetB1Count/total, uniqueSubnet, selectionEpsilon)
 }
 
+func TestSelectBySubnetWithLimit(t *testing.T) {
+	// four subnets with 3 nodes in each.
+	var nodes []*nodeselection.SelectedNode
+	for i := 0; i < 12; i++ {
+		nodes = append(nodes, &nodeselection.SelectedNode{
+			ID:         testrand.NodeID(),
+			LastNet:    fmt.Sprintf("68.0.%d", i/3),
+			LastIPPort: fmt.Sprintf("68.0.%d.%d:1000", i/3, i),
+		})
+	}
+
+	attribute, err := nodeselection.CreateNodeAttribute("last_net")
+	require.NoError(t, err)
+	selector := nodeselection.AttributeGroupSelectorWithLimit(attribute, 2)(nodes, nil)
+
+	countBySubnet := func(selected ...[]*nodeselection.SelectedNode) map[string]int {
+		counts := map[string]int{}
+		for _, nodes := range selected {
+			for _, node := range nodes {
+				counts[node.LastNet]++
+			}
+		}
+		return counts
+	}
+
+	for i := 0; i < 100; i++ {
+		// distinct subnets are preferred.
+		selected, err := selector(storj.NodeID{}, 4, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, selected, 4)
+		require.Len(t, countBySubnet(selected), 4)
+
+		// but at most 2 nodes are selected from the same subnet.
+		selected, err = selector(storj.NodeID{}, 12, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, selected, 8)
+		for _, count := range countBySubnet(selected) {
+			require.Equal(t, 2, count)
+		}
+
+		// the already selected nodes are counted.
+		existing := []*nodeselection.SelectedNode{nodes[0], nodes[1], nodes[3]}
+		selected, err = selector(storj.NodeID{}, 12, nil, existing)
+		require.NoError(t, err)
+		require.Len(t, selected, 5)
+		for _, count := range countBySubnet(selected, existing) {
+			require.LessOrEqual(t, count, 2)
+		}
+	}
+
+	init, err := nodeselection.SelectorFromString(`attribute("last_net", 3)`, nil)
+	require.NoError(t, err)
+	selected, err := init(nodes, nil)(storj.NodeID{}, 12, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, selected, 12)
+
+	_, err = nodeselection.SelectorFromString(`attribute("last_net", 0)`, nil)
+	require.Error(t, err)
+}
+
 func TestSelectFiltered(t *testing.T) {
 
 	ctx := testcontext.New(t)
@@ -739,6 +802,133 @@ func TestFilterBestOfN(t *testing.T) {
 	})
 }
 
+type inFlightTracker map[storj.NodeID]int
+
+func (tracker inFlightTracker) InFlight(node storj.NodeID) int {
+	return tracker[node]
+}
+
+func TestUnsaturated(t *testing.T) {
+	tracker := inFlightTracker{}
+	capped := func(node *nodeselection.SelectedNode, limit string) {
+		node.Tags = append(node.Tags, nodeselection.NodeTag{
+			NodeID:   node.ID,
+			Signer:   node.ID,
+			SignedAt: time.Now(),
+			Name:     nodeselection.MaxConcurrentUploadsTag,
+			Value:    []byte(limit),
+		})
+	}
+
+	var nodes []*nodeselection.SelectedNode
+	var saturated []storj.NodeID
+	for i := 0; i < 20; i++ {
+		node := &nodeselection.SelectedNode{
+			ID: testrand.NodeID(),
+		}
+		switch {
+		case i < 10:
+			// saturated nodes.
+			capped(node, "5")
+			tracker[node.ID] = 5 + i
+			saturated = append(saturated, node.ID)
+		case i < 15:
+			// capped nodes with free capacity.
+			capped(node, "5")
+			tracker[node.ID] = 4
+		}
+		nodes = append(nodes, node)
+	}
+
+	countSaturated := func(selected []*nodeselection.SelectedNode) (count int) {
+		for _, node := range selected {
+			if slices.Contains(saturated, node.ID) {
+				count++
+			}
+		}
+		return count
+	}
+
+	selector := nodeselection.Unsaturated(tracker, 2, nodeselection.RandomSelector())(nodes, nil)
+	for i := 0; i < 100; i++ {
+		selected, err := selector(storj.NodeID{}, 10, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, selected, 10)
+		require.Equal(t, 0, countSaturated(selected))
+	}
+
+	// the saturated nodes are used when there are not enough other nodes, the least saturated first.
+	selected, err := selector(storj.NodeID{}, 12, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, selected, 12)
+	require.Equal(t, 2, countSaturated(selected))
+	require.ElementsMatch(t, saturated[:2], []storj.NodeID{selected[10].ID, selected[11].ID})
+
+	// the tags signed by others are ignored.
+	node := &nodeselection.SelectedNode{ID: testrand.NodeID()}
+	capped(node, "5")
+	node.Tags[0].Signer = testrand.NodeID()
+	_, ok := nodeselection.MaxConcurrentUploads(*node)
+	require.False(t, ok)
+
+	_, err = nodeselection.SelectorFromString(`unsaturated(concurrency, 1.5, random())`, nodeselection.NewPlacementConfigEnvironment(nil))
+	require.NoError(t, err)
+}
+
+func TestFreeDiskWeightedSelector(t *testing.T) {
+	var nodes []*nodeselection.SelectedNode
+	freeDisk := map[storj.NodeID]int64{}
+	for i := 0; i < 10; i++ {
+		node := &nodeselection.SelectedNode{
+			ID: testrand.NodeID(),
+		}
+		switch {
+		case i < 5:
+			// small nodes, below the minimum.
+			node.FreeDisk = 1 * memory.GB.Int64()
+		case i < 9:
+			node.FreeDisk = 10 * memory.TB.Int64()
+		default:
+			// huge node, above the maximum.
+			node.FreeDisk = 1000 * memory.TB.Int64()
+		}
+		freeDisk[node.ID] = node.FreeDisk
+		nodes = append(nodes, node)
+	}
+
+	selector := nodeselection.FreeDiskWeightedSelector(memory.TB.Int64(), 20*memory.TB.Int64())(nodes, nil)
+
+	histogram := map[int64]int{}
+	for i := 0; i < 10000; i++ {
+		selected, err := selector(storj.NodeID{}, 1, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, selected, 1)
+		histogram[freeDisk[selected[0].ID]]++
+	}
+
+	// weights: 5 * 1TB (min), 4 * 10TB, 1 * 20TB (max) => 5:40:20
+	require.InDelta(t, 10000*5/65, histogram[memory.GB.Int64()], 300)
+	require.InDelta(t, 10000*40/65, histogram[10*memory.TB.Int64()], 300)
+	require.InDelta(t, 10000*20/65, histogram[1000*memory.TB.Int64()], 300)
+
+	// all nodes are selected without duplicates when requested, even if most of the weight is excluded.
+	var excluded []storj.NodeID
+	for _, node := range nodes[5:] {
+		excluded = append(excluded, node.ID)
+	}
+	selected, err := selector(storj.NodeID{}, 5, excluded, nil)
+	require.NoError(t, err)
+	require.Len(t, selected, 5)
+	for _, node := range selected {
+		require.Equal(t, memory.GB.Int64(), node.FreeDisk)
+	}
+
+	_, err = nodeselection.SelectorFromString(`freedisk("1TB", "20TB")`, nil)
+	require.NoError(t, err)
+	_, err = nodeselection.SelectorFromString(`freedisk("20TB", "1TB")`, nil)
+	require.Error(t, err)
+}
+
 func TestEqSelector(t *testing.T) {
 	surgeTag, err := nodeselection.CreateNodeAttribute("tag:surge")
 	require.NoError(t, err)
@@ -991,6 +1181,54 @@ func TestDualSelector(t *testing.T) {
 	})
 }
 
+func TestPreferSelector(t *testing.T) {
+	fastFilter, err := nodeselection.NewAttributeFilter("email", "fast")
+	require.NoError(t, err)
+
+	selectorInit := nodeselection.PreferSelector(fastFilter, nodeselection.RandomSelector())
+
+	t.Run("enough preferred nodes", func(t *testing.T) {
+		nodes, _ := generateNodes(10, 10)
+		nodeSelector := selectorInit(nodes, nil)
+		for i := 0; i < 100; i++ {
+			selected, err := nodeSelector(storj.NodeID{}, 10, nil, nil)
+			require.NoError(t, err)
+			require.Len(t, selected, 10)
+			require.Equal(t, 0, countSlowNodes(selected))
+		}
+	})
+
+	t.Run("remaining from other nodes", func(t *testing.T) {
+		nodes, _ := generateNodes(10, 4)
+		nodeSelector := selectorInit(nodes, nil)
+		for i := 0; i < 100; i++ {
+			selected, err := nodeSelector(storj.NodeID{}, 10, nil, nil)
+			require.NoError(t, err)
+			require.Len(t, selected, 10)
+			require.Equal(t, 6, countSlowNodes(selected))
+		}
+	})
+
+	t.Run("already selected is not modified", func(t *testing.T) {
+		nodes, _ := generateNodes(10, 4)
+		nodeSelector := selectorInit(nodes, nil)
+
+		sentinel := &nodeselection.SelectedNode{ID: testrand.NodeID()}
+		backing := make([]*nodeselection.SelectedNode, 10)
+		for i := range backing {
+			backing[i] = sentinel
+		}
+		alreadySelected := backing[:0]
+
+		selected, err := nodeSelector(storj.NodeID{}, 10, nil, alreadySelected)
+		require.NoError(t, err)
+		require.Len(t, selected, 10)
+		for _, node := range backing {
+			require.Equal(t, sentinel, node)
+		}
+	})
+}
+
 func generateNodes(slow int, fast int) ([]*nodeselection.SelectedNode, *mockTracker) {
 	tracker := &mockTracker{
 		trustedUplink: storj.NodeID{},
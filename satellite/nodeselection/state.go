@@ -13,27 +13,49 @@ import (
 var ErrNotEnoughNodes = errs.Class("not enough nodes")
 
 // State includes a stateful selector (indexed nodes) for each placement.
-type State map[storj.PlacementConstraint]NodeSelector
+type State struct {
+	selectors map[storj.PlacementConstraint]NodeSelector
+	// experiments contains the experimental selectors of the placements with a selection experiment.
+	experiments map[storj.PlacementConstraint]NodeSelector
+}
 
 // NewState initializes the State for each placement.
 func NewState(nodes []*SelectedNode, placements PlacementDefinitions) State {
-	state := make(State)
+	state := State{
+		selectors:   make(map[storj.PlacementConstraint]NodeSelector),
+		experiments: make(map[storj.PlacementConstraint]NodeSelector),
+	}
 	for id, placement := range placements {
 		selector := placement.Selector
 		if selector == nil {
 			selector = RandomSelector()
 		}
-		state[id] = selector(nodes, placement.NodeFilter)
+		state.selectors[id] = selector(nodes, placement.NodeFilter)
+
+		if placement.Experiment != nil && placement.Experiment.Selector != nil {
+			state.experiments[id] = placement.Experiment.Selector(nodes, placement.NodeFilter)
+		}
 	}
 	return state
 }
 
 // Select picks the required nodes given a specific placement.
 func (s State) Select(requester storj.NodeID, p storj.PlacementConstraint, count int, excluded []storj.NodeID, alreadySelected []*SelectedNode) ([]*SelectedNode, error) {
-	selector, found := s[p]
+	return s.SelectWithArm(ControlArm, requester, p, count, excluded, alreadySelected)
+}
+
+// SelectWithArm picks the required nodes given a specific placement, using the
+// experimental selector of the placement for the ExperimentArm.
+func (s State) SelectWithArm(arm SelectionArm, requester storj.NodeID, p storj.PlacementConstraint, count int, excluded []storj.NodeID, alreadySelected []*SelectedNode) ([]*SelectedNode, error) {
+	selector, found := s.selectors[p]
 	if !found {
 		return nil, Error.New("Placement is not defined: %d", p)
 	}
+	if arm == ExperimentArm {
+		if experimental, ok := s.experiments[p]; ok {
+			selector = experimental
+		}
+	}
 	nodes, err := selector(requester, count, excluded, alreadySelected)
 	if len(nodes) < count {
 		return nodes, ErrNotEnoughNodes.New("requested from cache %d, found %d", count, len(nodes))
@@ -178,6 +178,38 @@ func TestState_Select_Concurrent(t *testing.T) {
 	require.NoError(t, group.Wait())
 }
 
+func TestState_SelectWithArm(t *testing.T) {
+	reputableNodes := createRandomNodes(5, "1.0.1", false, true)
+	newNodes := createRandomNodes(5, "1.0.2", false, false)
+	nodes := joinNodes(reputableNodes, newNodes)
+
+	state := nodeselection.NewState(nodes, map[storj.PlacementConstraint]nodeselection.Placement{
+		0: {
+			Selector: nodeselection.UnvettedSelector(0, nodeselection.RandomSelector()),
+			Experiment: &nodeselection.SelectionExperiment{
+				Fraction: 0.5,
+				Selector: nodeselection.UnvettedSelector(1, nodeselection.RandomSelector()),
+			},
+		},
+		1: {
+			Selector: nodeselection.UnvettedSelector(0, nodeselection.RandomSelector()),
+		},
+	})
+
+	selected, err := state.SelectWithArm(nodeselection.ControlArm, storj.NodeID{}, 0, 5, nil, nil)
+	require.NoError(t, err)
+	require.ElementsMatch(t, reputableNodes, selected)
+
+	selected, err = state.SelectWithArm(nodeselection.ExperimentArm, storj.NodeID{}, 0, 5, nil, nil)
+	require.NoError(t, err)
+	require.ElementsMatch(t, newNodes, selected)
+
+	// placements without experiment always use the regular selector
+	selected, err = state.SelectWithArm(nodeselection.ExperimentArm, storj.NodeID{}, 1, 5, nil, nil)
+	require.NoError(t, err)
+	require.ElementsMatch(t, reputableNodes, selected)
+}
+
 // createRandomNodes creates n random nodes all in the subnet.
 func createRandomNodes(n int, subnet string, shareNets bool, vetted bool) []*nodeselection.SelectedNode {
 	xs := make([]*nodeselection.SelectedNode, n)
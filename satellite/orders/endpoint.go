@@ -177,28 +177,31 @@ type Endpoint struct {
 	satelliteSignee  signing.Signee
 	DB               DB
 	nodeAPIVersionDB nodeapiversion.DB
-	ordersSemaphore  chan struct{}
+	settlements      *settlementQueue
 	ordersService    *Service
+	uploadSuccess    UploadSuccessTracker
+}
+
+// UploadSuccessTracker is the node success tracker updated with the failed
+// uploads, which are only noticed when the orders are settled.
+type UploadSuccessTracker interface {
+	IncrementAll(node storj.NodeID, success bool)
 }
 
 // NewEndpoint new orders receiving endpoint.
 //
-// ordersSemaphoreSize controls the number of concurrent clients allowed to submit orders at once.
-// A value of zero means unlimited.
+// settlementQueue controls how many nodes are allowed to submit orders at once
+// and how many settlements a single node may have pending.
 func NewEndpoint(log *zap.Logger, satelliteSignee signing.Signee, db DB, nodeAPIVersionDB nodeapiversion.DB,
-	ordersSemaphoreSize int, ordersService *Service) *Endpoint {
-	var ordersSemaphore chan struct{}
-	if ordersSemaphoreSize > 0 {
-		ordersSemaphore = make(chan struct{}, ordersSemaphoreSize)
-	}
-
+	settlementQueue SettlementQueueConfig, ordersService *Service, uploadSuccess UploadSuccessTracker) *Endpoint {
 	return &Endpoint{
 		log:              log,
 		satelliteSignee:  satelliteSignee,
 		DB:               db,
 		nodeAPIVersionDB: nodeAPIVersionDB,
-		ordersSemaphore:  ordersSemaphore,
+		settlements:      newSettlementQueue(settlementQueue),
 		ordersService:    ordersService,
+		uploadSuccess:    uploadSuccess,
 	}
 }
 
@@ -258,6 +261,16 @@ func (endpoint *Endpoint) SettlementWithWindowFinal(stream pb.DRPCOrders_Settlem
 	log := endpoint.log.Named(peer.ID.String())
 	log.Debug("SettlementWithWindow")
 
+	release, err := endpoint.settlements.Acquire(ctx, peer.ID)
+	if err != nil {
+		if ErrSettlementQueueFull.Has(err) || ErrSettlementQueueTimeout.Has(err) {
+			log.Debug("settlement rejected by queue", zap.Error(err))
+			return rpcstatus.Error(rpcstatus.ResourceExhausted, err.Error())
+		}
+		return rpcstatus.Wrap(rpcstatus.Canceled, err)
+	}
+	defer release()
+
 	type bandwidthAmount struct {
 		Settled int64
 		Dead    int64
@@ -270,6 +283,7 @@ func (endpoint *Endpoint) SettlementWithWindowFinal(stream pb.DRPCOrders_Settlem
 	var window int64
 	var request *pb.SettlementRequest
 	var receivedCount int
+	var failedUploads int
 	for {
 		request, err = stream.Recv()
 		if err != nil {
@@ -298,6 +312,12 @@ func (endpoint *Endpoint) SettlementWithWindowFinal(stream pb.DRPCOrders_Settlem
 
 		// don't process orders that aren't valid
 		if !endpoint.isValid(ctx, log, order, orderLimit, peer.ID, window) {
+			// an invalid upload order counts as a failed upload of the node. An
+			// expired order only means that the node settled it late.
+			if orderLimit.Action == pb.PieceAction_PUT && orderLimit.StorageNodeId == peer.ID &&
+				!orderLimit.OrderExpiration.Before(time.Now().UTC()) {
+				failedUploads++
+			}
 			continue
 		}
 
@@ -371,6 +391,15 @@ func (endpoint *Endpoint) SettlementWithWindowFinal(stream pb.DRPCOrders_Settlem
 		}
 	}
 
+	// the successful uploads aren't recorded, they were recorded when the
+	// segments were committed.
+	mon.Meter("settlement_failed_uploads").Mark(failedUploads)
+	if endpoint.uploadSuccess != nil {
+		for i := 0; i < failedUploads; i++ {
+			endpoint.uploadSuccess.IncrementAll(peer.ID, false)
+		}
+	}
+
 	if len(storagenodeSettled) == 0 {
 		log.Debug("no orders were successfully processed", zap.Int("received count", receivedCount))
 		status = pb.SettlementWithWindowResponse_REJECTED
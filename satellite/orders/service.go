@@ -41,6 +41,10 @@ type Config struct {
 	NodeStatusLogging   bool           `hidden:"true" help:"deprecated, log the offline/disqualification status of nodes" default:"false" testDefault:"true"`
 	OrdersSemaphoreSize int            `help:"how many concurrent orders to process at once. zero is unlimited" default:"2"`
 
+	SettlementQueueSize    int           `help:"how many settlements are processed concurrently, further settlements wait in the queue. zero is unlimited" default:"0"`
+	SettlementQueuePerNode int           `help:"how many settlements a single node may have queued or in progress at once, further settlements are rejected. zero is unlimited" default:"2"`
+	SettlementQueueTimeout time.Duration `help:"how long a settlement waits for a free slot before it is rejected. zero waits until the node disconnects" default:"1m"`
+
 	DownloadTailToleranceOverrides string `help:"how many nodes should be used for downloads for certain k. must be >= k. if not specified, this is calculated from long tail tolerance. format is comma separated like k-d,k-d,k-d e.g. 29-35,3-5." default:""`
 }
 
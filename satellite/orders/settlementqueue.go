@@ -0,0 +1,224 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package orders
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+)
+
+// settlementNodeBuckets is the number of buckets nodes are hashed into for
+// queue depth metrics. Reporting per node would create too many series.
+const settlementNodeBuckets = 16
+
+var (
+	// ErrSettlementQueueFull is returned when a node has too many settlements
+	// queued or in progress.
+	ErrSettlementQueueFull = errs.Class("settlement queue full")
+	// ErrSettlementQueueTimeout is returned when a settlement waited too long
+	// for a free slot.
+	ErrSettlementQueueTimeout = errs.Class("settlement queue timeout")
+)
+
+// SettlementQueueConfig configures how settlement streams are admitted.
+type SettlementQueueConfig struct {
+	// Size is the number of settlements processed concurrently. Zero means unlimited.
+	Size int
+	// PerNode is the number of settlements a single node may have queued or
+	// in progress at once. Zero means unlimited.
+	PerNode int
+	// Timeout is how long a settlement waits for a free slot. Zero means no timeout.
+	Timeout time.Duration
+}
+
+// SettlementQueue returns the settlement queue configuration.
+func (config Config) SettlementQueue() SettlementQueueConfig {
+	return SettlementQueueConfig{
+		Size:    config.SettlementQueueSize,
+		PerNode: config.SettlementQueuePerNode,
+		Timeout: config.SettlementQueueTimeout,
+	}
+}
+
+// settlementQueue admits settlement streams with per-node fairness.
+//
+// Every node has its own FIFO of waiting settlements. When a slot frees up,
+// it is handed to the next node in round-robin order, so a node which keeps
+// submitting settlements can't starve the others. Nodes exceeding their queue
+// limit are rejected immediately, which signals them to back off.
+type settlementQueue struct {
+	config SettlementQueueConfig
+
+	mu      sync.Mutex
+	active  int
+	nodes   map[storj.NodeID]*settlementNodeQueue
+	ready   []storj.NodeID
+	buckets [settlementNodeBuckets]int64
+}
+
+// settlementNodeQueue tracks settlements of a single node.
+type settlementNodeQueue struct {
+	// pending counts both queued and active settlements.
+	pending int
+	waiting []chan struct{}
+}
+
+func newSettlementQueue(config SettlementQueueConfig) *settlementQueue {
+	return &settlementQueue{
+		config: config,
+		nodes:  make(map[storj.NodeID]*settlementNodeQueue),
+	}
+}
+
+// Acquire waits for a free slot for nodeID. The returned release func must be
+// called once the settlement is done.
+func (queue *settlementQueue) Acquire(ctx context.Context, nodeID storj.NodeID) (release func(), err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	queue.mu.Lock()
+	node, ok := queue.nodes[nodeID]
+	if !ok {
+		node = &settlementNodeQueue{}
+		queue.nodes[nodeID] = node
+	}
+	if queue.config.PerNode > 0 && node.pending >= queue.config.PerNode {
+		queue.mu.Unlock()
+		mon.Event("settlement_queue_full")
+		return nil, ErrSettlementQueueFull.New("%d settlements pending for node", queue.config.PerNode)
+	}
+	node.pending++
+	queue.observe(nodeID, 1)
+
+	if queue.config.Size <= 0 || (queue.active < queue.config.Size && len(queue.ready) == 0) {
+		queue.active++
+		queue.mu.Unlock()
+		return queue.releaser(nodeID), nil
+	}
+
+	granted := make(chan struct{})
+	if len(node.waiting) == 0 {
+		queue.ready = append(queue.ready, nodeID)
+	}
+	node.waiting = append(node.waiting, granted)
+	queue.mu.Unlock()
+
+	var timeout <-chan time.Time
+	if queue.config.Timeout > 0 {
+		timer := time.NewTimer(queue.config.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-granted:
+		return queue.releaser(nodeID), nil
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-timeout:
+		mon.Event("settlement_queue_timeout")
+		err = ErrSettlementQueueTimeout.New("waited %s", queue.config.Timeout)
+	}
+
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	select {
+	case <-granted:
+		// the slot was handed to us while we were giving up, pass it on.
+		queue.active--
+		queue.grantLocked()
+	default:
+		queue.removeWaiterLocked(nodeID, node, granted)
+	}
+	queue.leaveLocked(nodeID, node)
+	return nil, err
+}
+
+// releaser returns a func which frees the slot held by nodeID.
+func (queue *settlementQueue) releaser(nodeID storj.NodeID) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			queue.mu.Lock()
+			defer queue.mu.Unlock()
+
+			queue.active--
+			if node, ok := queue.nodes[nodeID]; ok {
+				queue.leaveLocked(nodeID, node)
+			}
+			queue.grantLocked()
+		})
+	}
+}
+
+// grantLocked hands free slots to waiting nodes in round-robin order.
+func (queue *settlementQueue) grantLocked() {
+	for len(queue.ready) > 0 && (queue.config.Size <= 0 || queue.active < queue.config.Size) {
+		nodeID := queue.ready[0]
+		queue.ready = queue.ready[1:]
+
+		node := queue.nodes[nodeID]
+		granted := node.waiting[0]
+		node.waiting = node.waiting[1:]
+		if len(node.waiting) > 0 {
+			queue.ready = append(queue.ready, nodeID)
+		}
+
+		queue.active++
+		close(granted)
+	}
+}
+
+// removeWaiterLocked removes a waiter which gave up before being granted a slot.
+func (queue *settlementQueue) removeWaiterLocked(nodeID storj.NodeID, node *settlementNodeQueue, granted chan struct{}) {
+	for i, waiter := range node.waiting {
+		if waiter == granted {
+			node.waiting = append(node.waiting[:i], node.waiting[i+1:]...)
+			break
+		}
+	}
+	if len(node.waiting) > 0 {
+		return
+	}
+	for i, id := range queue.ready {
+		if id == nodeID {
+			queue.ready = append(queue.ready[:i], queue.ready[i+1:]...)
+			break
+		}
+	}
+}
+
+// leaveLocked decrements the pending count of a node and forgets idle nodes.
+func (queue *settlementQueue) leaveLocked(nodeID storj.NodeID, node *settlementNodeQueue) {
+	node.pending--
+	queue.observe(nodeID, -1)
+	if node.pending <= 0 && len(node.waiting) == 0 {
+		delete(queue.nodes, nodeID)
+	}
+}
+
+// observe updates and reports the queue depth of the bucket nodeID belongs to.
+func (queue *settlementQueue) observe(nodeID storj.NodeID, delta int64) {
+	bucket := int(nodeID[0]) % settlementNodeBuckets
+	queue.buckets[bucket] += delta
+	mon.IntVal("settlement_queue_depth",
+		monkit.NewSeriesTag("node_bucket", strconv.Itoa(bucket)),
+	).Observe(queue.buckets[bucket])
+}
+
+// Depth returns the number of queued and active settlements.
+func (queue *settlementQueue) Depth() (queued, active int) {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	for _, node := range queue.nodes {
+		queued += len(node.waiting)
+	}
+	return queued, queue.active
+}
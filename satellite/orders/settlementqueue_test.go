@@ -0,0 +1,118 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package orders
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+)
+
+func TestSettlementQueue_PerNodeLimit(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	queue := newSettlementQueue(SettlementQueueConfig{Size: 10, PerNode: 1})
+	node := testrand.NodeID()
+
+	release, err := queue.Acquire(ctx, node)
+	require.NoError(t, err)
+
+	_, err = queue.Acquire(ctx, node)
+	require.True(t, ErrSettlementQueueFull.Has(err))
+
+	// other nodes are not affected.
+	releaseOther, err := queue.Acquire(ctx, testrand.NodeID())
+	require.NoError(t, err)
+	releaseOther()
+
+	release()
+	release, err = queue.Acquire(ctx, node)
+	require.NoError(t, err)
+	release()
+
+	queued, active := queue.Depth()
+	require.Zero(t, queued)
+	require.Zero(t, active)
+	require.Empty(t, queue.nodes)
+}
+
+func TestSettlementQueue_Fairness(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	queue := newSettlementQueue(SettlementQueueConfig{Size: 1})
+	busy, quiet := testrand.NodeID(), testrand.NodeID()
+
+	release, err := queue.Acquire(ctx, busy)
+	require.NoError(t, err)
+
+	order := make(chan string, 4)
+	acquire := func(name string) {
+		node := busy
+		if name == "quiet" {
+			node = quiet
+		}
+		ctx.Go(func() error {
+			release, err := queue.Acquire(ctx, node)
+			if err != nil {
+				return err
+			}
+			order <- name
+			release()
+			return nil
+		})
+	}
+
+	// the busy node queues up several settlements before the quiet one.
+	acquire("busy")
+	acquire("busy")
+	acquire("busy")
+	waitQueued(t, queue, 3)
+	acquire("quiet")
+	waitQueued(t, queue, 4)
+
+	release()
+	ctx.Wait()
+	close(order)
+
+	var got []string
+	for name := range order {
+		got = append(got, name)
+	}
+	require.Equal(t, []string{"busy", "quiet", "busy", "busy"}, got)
+}
+
+func TestSettlementQueue_Timeout(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	queue := newSettlementQueue(SettlementQueueConfig{Size: 1, Timeout: 10 * time.Millisecond})
+
+	release, err := queue.Acquire(ctx, testrand.NodeID())
+	require.NoError(t, err)
+
+	_, err = queue.Acquire(ctx, testrand.NodeID())
+	require.True(t, ErrSettlementQueueTimeout.Has(err))
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = queue.Acquire(canceled, testrand.NodeID())
+	require.ErrorIs(t, err, context.Canceled)
+
+	release()
+
+	queued, active := queue.Depth()
+	require.Zero(t, queued)
+	require.Zero(t, active)
+}
+
+func waitQueued(t *testing.T, queue *settlementQueue, expected int) {
+	require.Eventually(t, func() bool {
+		queued, _ := queue.Depth()
+		return queued == expected
+	}, 5*time.Second, time.Millisecond)
+}
@@ -0,0 +1,248 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package attestation
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/identity"
+	"storj.io/common/nodetag"
+	"storj.io/common/pb"
+	"storj.io/common/signing"
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/nodeselection"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the default error class for the package.
+	Error = errs.Class("attestation")
+	// ErrIssuerNotFound is returned when the issuer isn't accepted.
+	ErrIssuerNotFound = errs.Class("attestation issuer not found")
+	// ErrInvalid is returned when an attestation or an issuer is invalid.
+	ErrInvalid = errs.Class("invalid attestation")
+)
+
+// Issuer is an external provider whose attestations are accepted.
+type Issuer struct {
+	ID   storj.NodeID `json:"id"`
+	Name string       `json:"name"`
+	// Identity is the PEM encoded certificate chain the attestations are verified with.
+	Identity  []byte    `json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// DB stores the accepted attestation issuers.
+//
+// architecture: Database
+type DB interface {
+	// Insert adds an issuer.
+	Insert(ctx context.Context, issuer Issuer) error
+	// Get returns the issuer with the ID. It returns ErrIssuerNotFound when it doesn't exist.
+	Get(ctx context.Context, id storj.NodeID) (Issuer, error)
+	// List returns all issuers.
+	List(ctx context.Context) ([]Issuer, error)
+	// Delete removes the issuer.
+	Delete(ctx context.Context, id storj.NodeID) error
+}
+
+// NodeTags stores the attestations as node tags.
+type NodeTags interface {
+	// UpdateNodeTags insert (or refresh) node tags.
+	UpdateNodeTags(ctx context.Context, tags nodeselection.NodeTags) error
+	// GetNodeTags returns all tags of a node.
+	GetNodeTags(ctx context.Context, id storj.NodeID) (nodeselection.NodeTags, error)
+	// DeleteNodeTagsBySigner deletes all tags signed by the signer.
+	DeleteNodeTagsBySigner(ctx context.Context, signer storj.NodeID) error
+}
+
+// Service manages the attestation issuers and verifies the submitted attestations.
+//
+// architecture: Service
+type Service struct {
+	log      *zap.Logger
+	issuers  DB
+	nodeTags NodeTags
+}
+
+// NewService creates a new attestation service.
+func NewService(log *zap.Logger, issuers DB, nodeTags NodeTags) *Service {
+	return &Service{
+		log:      log,
+		issuers:  issuers,
+		nodeTags: nodeTags,
+	}
+}
+
+// AddIssuer accepts the attestations signed by the identity.
+func (service *Service) AddIssuer(ctx context.Context, name string, identityPEM []byte) (_ Issuer, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Issuer{}, ErrInvalid.New("issuer name is missing")
+	}
+
+	peerIdentity, err := identity.PeerIdentityFromPEM(identityPEM)
+	if err != nil {
+		return Issuer{}, ErrInvalid.New("invalid issuer identity: %v", err)
+	}
+
+	_, err = service.issuers.Get(ctx, peerIdentity.ID)
+	switch {
+	case err == nil:
+		return Issuer{}, ErrInvalid.New("issuer %s already exists", peerIdentity.ID)
+	case !ErrIssuerNotFound.Has(err):
+		return Issuer{}, Error.Wrap(err)
+	}
+
+	issuer := Issuer{
+		ID:        peerIdentity.ID,
+		Name:      name,
+		Identity:  identityPEM,
+		CreatedAt: time.Now(),
+	}
+	if err := service.issuers.Insert(ctx, issuer); err != nil {
+		return Issuer{}, Error.Wrap(err)
+	}
+
+	service.log.Info("Attestation issuer added.", zap.Stringer("issuer", issuer.ID), zap.String("name", name))
+	return issuer, nil
+}
+
+// Issuers returns the accepted issuers.
+func (service *Service) Issuers(ctx context.Context) (_ []Issuer, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	issuers, err := service.issuers.List(ctx)
+	return issuers, Error.Wrap(err)
+}
+
+// RemoveIssuer stops accepting the attestations of the issuer and removes the ones it has signed.
+func (service *Service) RemoveIssuer(ctx context.Context, id storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if _, err := service.issuers.Get(ctx, id); err != nil {
+		return err
+	}
+
+	// the attestations are removed first, so they are not left behind
+	// without an issuer when the removal fails.
+	if err := service.nodeTags.DeleteNodeTagsBySigner(ctx, id); err != nil {
+		return Error.Wrap(err)
+	}
+	if err := service.issuers.Delete(ctx, id); err != nil {
+		return Error.Wrap(err)
+	}
+
+	service.log.Info("Attestation issuer removed.", zap.Stringer("issuer", id))
+	return nil
+}
+
+// Submit verifies the attestations of the node and stores them as node tags.
+// All attestations must be signed by accepted issuers for the node.
+func (service *Service) Submit(ctx context.Context, nodeID storj.NodeID, attestations *pb.SignedNodeTagSets) (_ nodeselection.NodeTags, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if attestations == nil || len(attestations.Tags) == 0 {
+		return nil, ErrInvalid.New("no attestations")
+	}
+
+	var tags nodeselection.NodeTags
+	for _, attestation := range attestations.Tags {
+		verified, err := service.verify(ctx, nodeID, attestation)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, verified...)
+	}
+
+	if err := service.nodeTags.UpdateNodeTags(ctx, tags); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return tags, nil
+}
+
+// NodeAttestations returns the attestations of the node signed by the accepted issuers.
+func (service *Service) NodeAttestations(ctx context.Context, nodeID storj.NodeID) (_ nodeselection.NodeTags, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	issuers, err := service.issuers.List(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	accepted := map[storj.NodeID]struct{}{}
+	for _, issuer := range issuers {
+		accepted[issuer.ID] = struct{}{}
+	}
+
+	tags, err := service.nodeTags.GetNodeTags(ctx, nodeID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	attestations := nodeselection.NodeTags{}
+	for _, tag := range tags {
+		if _, ok := accepted[tag.Signer]; ok {
+			attestations = append(attestations, tag)
+		}
+	}
+	return attestations, nil
+}
+
+// verify verifies the signature of a single attestation and returns its tags.
+func (service *Service) verify(ctx context.Context, nodeID storj.NodeID, attestation *pb.SignedNodeTagSet) (_ nodeselection.NodeTags, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	issuerID, err := storj.NodeIDFromBytes(attestation.SignerNodeId)
+	if err != nil {
+		return nil, ErrInvalid.New("invalid issuer id: %v", err)
+	}
+
+	issuer, err := service.issuers.Get(ctx, issuerID)
+	if err != nil {
+		return nil, err
+	}
+
+	peerIdentity, err := identity.PeerIdentityFromPEM(issuer.Identity)
+	if err != nil {
+		return nil, Error.New("invalid identity of issuer %s: %v", issuerID, err)
+	}
+
+	tagSet, err := nodetag.Verify(ctx, attestation, signing.SigneeFromPeerIdentity(peerIdentity))
+	if err != nil {
+		return nil, ErrInvalid.Wrap(err)
+	}
+
+	attestedID, err := storj.NodeIDFromBytes(tagSet.NodeId)
+	if err != nil {
+		return nil, ErrInvalid.New("invalid node id: %v", err)
+	}
+	if attestedID != nodeID {
+		return nil, ErrInvalid.New("attestation is signed for node %s", attestedID)
+	}
+	if len(tagSet.Tags) == 0 {
+		return nil, ErrInvalid.New("attestation of issuer %s has no tags", issuerID)
+	}
+
+	signedAt := time.Unix(tagSet.SignedAt, 0)
+	tags := make(nodeselection.NodeTags, 0, len(tagSet.Tags))
+	for _, tag := range tagSet.Tags {
+		tags = append(tags, nodeselection.NodeTag{
+			NodeID:   nodeID,
+			Name:     tag.Name,
+			Value:    tag.Value,
+			SignedAt: signedAt,
+			Signer:   issuerID,
+		})
+	}
+	return tags, nil
+}
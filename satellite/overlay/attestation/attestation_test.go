@@ -0,0 +1,111 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package attestation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/identity"
+	"storj.io/common/identity/testidentity"
+	"storj.io/common/nodetag"
+	"storj.io/common/pb"
+	"storj.io/common/signing"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/nodeselection"
+	"storj.io/storj/satellite/overlay/attestation"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestService(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		service := attestation.NewService(zaptest.NewLogger(t), db.AttestationIssuers(), db.OverlayCache())
+
+		issuer := testidentity.MustPregeneratedSignedIdentity(0, storj.LatestIDVersion())
+		unknown := testidentity.MustPregeneratedSignedIdentity(1, storj.LatestIDVersion())
+		node := testidentity.MustPregeneratedSignedIdentity(2, storj.LatestIDVersion())
+		otherNode := testidentity.MustPregeneratedSignedIdentity(3, storj.LatestIDVersion())
+
+		signedAt := time.Now().Truncate(time.Second)
+		sign := func(signer *identity.FullIdentity, nodeID storj.NodeID, name, value string) *pb.SignedNodeTagSets {
+			signed, err := nodetag.Sign(ctx, &pb.NodeTagSet{
+				NodeId:   nodeID.Bytes(),
+				SignedAt: signedAt.Unix(),
+				Tags:     []*pb.Tag{{Name: name, Value: []byte(value)}},
+			}, signing.SignerFromFullIdentity(signer))
+			require.NoError(t, err)
+			return &pb.SignedNodeTagSets{Tags: []*pb.SignedNodeTagSet{signed}}
+		}
+
+		_, err := service.AddIssuer(ctx, "invalid", []byte("not a certificate"))
+		require.True(t, attestation.ErrInvalid.Has(err))
+
+		added, err := service.AddIssuer(ctx, "Datacenter Certification", identity.EncodePeerIdentity(issuer.PeerIdentity()))
+		require.NoError(t, err)
+		require.Equal(t, issuer.ID, added.ID)
+
+		_, err = service.AddIssuer(ctx, "duplicate", identity.EncodePeerIdentity(issuer.PeerIdentity()))
+		require.True(t, attestation.ErrInvalid.Has(err))
+
+		issuers, err := service.Issuers(ctx)
+		require.NoError(t, err)
+		require.Len(t, issuers, 1)
+		require.Equal(t, "Datacenter Certification", issuers[0].Name)
+
+		// a tag signed by someone else is not an attestation.
+		require.NoError(t, db.OverlayCache().UpdateNodeTags(ctx, nodeselection.NodeTags{{
+			NodeID:   node.ID,
+			Name:     "soc2",
+			Value:    []byte("true"),
+			SignedAt: signedAt,
+			Signer:   node.ID,
+		}}))
+
+		_, err = service.Submit(ctx, node.ID, sign(unknown, node.ID, "datacenter", "certified"))
+		require.True(t, attestation.ErrIssuerNotFound.Has(err))
+
+		_, err = service.Submit(ctx, node.ID, sign(issuer, otherNode.ID, "datacenter", "certified"))
+		require.True(t, attestation.ErrInvalid.Has(err))
+
+		forged := sign(issuer, node.ID, "datacenter", "certified")
+		forged.Tags[0].Signature[0]++
+		_, err = service.Submit(ctx, node.ID, forged)
+		require.True(t, attestation.ErrInvalid.Has(err))
+
+		tags, err := service.Submit(ctx, node.ID, sign(issuer, node.ID, "datacenter", "certified"))
+		require.NoError(t, err)
+		require.Len(t, tags, 1)
+
+		attestations, err := service.NodeAttestations(ctx, node.ID)
+		require.NoError(t, err)
+		require.Len(t, attestations, 1)
+		require.Equal(t, issuer.ID, attestations[0].Signer)
+		require.Equal(t, "datacenter", attestations[0].Name)
+		require.Equal(t, []byte("certified"), attestations[0].Value)
+		require.True(t, signedAt.Equal(attestations[0].SignedAt))
+
+		// the attestations can be used in placement filters.
+		filter, err := nodeselection.FilterFromString(`tag("` + issuer.ID.String() + `","datacenter","certified")`)
+		require.NoError(t, err)
+		require.True(t, filter.Match(&nodeselection.SelectedNode{ID: node.ID, Tags: attestations}))
+
+		require.NoError(t, service.RemoveIssuer(ctx, issuer.ID))
+		require.True(t, attestation.ErrIssuerNotFound.Has(service.RemoveIssuer(ctx, issuer.ID)))
+
+		attestations, err = service.NodeAttestations(ctx, node.ID)
+		require.NoError(t, err)
+		require.Empty(t, attestations)
+
+		// the tags of other signers are kept.
+		all, err := db.OverlayCache().GetNodeTags(ctx, node.ID)
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		require.Equal(t, node.ID, all[0].Signer)
+	})
+}
@@ -0,0 +1,18 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+/*
+Package attestation accepts signed attestations about storage nodes from
+external providers, e.g. datacenter certifications or KYC checks of
+commercial node programs.
+
+An attestation is a node tag set signed by the identity of an accepted
+issuer, the same format the tag-signer tool produces. The verified
+attestations are stored as node tags with the issuer as signer, hence they
+can be used in placement filters like any other signed tag:
+
+	tag("<issuer node id>","datacenter","certified")
+
+Removing an issuer removes the attestations it has signed.
+*/
+package attestation
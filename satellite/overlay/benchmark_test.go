@@ -144,6 +144,32 @@ func BenchmarkOverlay(b *testing.B) {
 			}
 		})
 
+		b.Run("UpdateCheckInCoalesced-100x", func(b *testing.B) {
+			buffer := overlay.NewCheckInBuffer(zap.NewNop(), overlaydb, overlay.CheckInBufferConfig{BatchSize: 1000})
+			for k := 0; k < b.N; k++ {
+				var g errs2.Group
+				for i := 0; i < 100; i++ {
+					g.Go(func() error {
+						d := overlay.NodeCheckInInfo{
+							NodeID:     all[0],
+							Address:    &pb.NodeAddress{Address: "127.0.0.0:8080"},
+							LastIPPort: "127.0.0.0:8080",
+							LastNet:    "127.0.0",
+							Operator: &pb.NodeOperator{
+								Email:  "hello@example.com",
+								Wallet: "123123123123",
+							},
+							Version: &pb.NodeVersion{Version: "v1.0.0"},
+							IsUp:    true,
+						}
+						return buffer.Add(d, time.Now().UTC())
+					})
+				}
+				require.NoError(b, errs.Combine(g.Wait()...))
+				require.NoError(b, buffer.Flush(ctx))
+			}
+		})
+
 		b.Run("UpdateNodeInfo", func(b *testing.B) {
 			now := time.Now()
 			for i := 0; i < b.N; i++ {
@@ -0,0 +1,241 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/common/version"
+)
+
+// CheckInBufferConfig configures the coalescing of the check-ins of known nodes.
+type CheckInBufferConfig struct {
+	Enabled       bool          `help:"coalesce the check-ins of known nodes and write them to the database in batches" default:"false"`
+	FlushInterval time.Duration `help:"how often the coalesced check-ins are written to the database" default:"5s"`
+	BatchSize     int           `help:"the maximum number of check-ins written to the database in a single query" default:"1000"`
+}
+
+// CheckInBuffer coalesces the check-ins of known nodes and writes them to
+// the database in batches, instead of updating the node row for every
+// check-in.
+//
+// The check-ins which aren't written yet are applied to the node dossiers read
+// through the overlay service, so the check-in decisions see the latest state.
+type CheckInBuffer struct {
+	log    *zap.Logger
+	db     DB
+	config CheckInBufferConfig
+
+	Loop *sync2.Cycle
+
+	// flushMu ensures that only one flush is running at a time, so an older
+	// batch can't overwrite a newer one.
+	flushMu sync.Mutex
+
+	mu      sync.Mutex
+	pending map[storj.NodeID]*CheckInUpdate
+	// flushing contains the check-ins which are written to the database at
+	// the moment, so they are still applied to the dossiers.
+	flushing map[storj.NodeID]*CheckInUpdate
+}
+
+// NewCheckInBuffer creates a new check-in buffer.
+func NewCheckInBuffer(log *zap.Logger, db DB, config CheckInBufferConfig) *CheckInBuffer {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 1000
+	}
+	return &CheckInBuffer{
+		log:     log,
+		db:      db,
+		config:  config,
+		Loop:    sync2.NewCycle(config.FlushInterval),
+		pending: map[storj.NodeID]*CheckInUpdate{},
+	}
+}
+
+// Run writes the coalesced check-ins periodically.
+func (buffer *CheckInBuffer) Run(ctx context.Context) error {
+	return buffer.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := buffer.Flush(ctx); err != nil {
+			buffer.log.Error("failed to write coalesced check-ins", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// Close stops the loop and writes the remaining check-ins.
+func (buffer *CheckInBuffer) Close() error {
+	buffer.Loop.Close()
+	return buffer.Flush(context.Background())
+}
+
+// Add adds the check-in of a known node. It replaces the info of an earlier
+// check-in of the node, which isn't written yet.
+func (buffer *CheckInBuffer) Add(node NodeCheckInInfo, timestamp time.Time) error {
+	// check the info now, so a single invalid check-in doesn't fail the
+	// whole batch.
+	if node.Address.GetAddress() == "" {
+		return Error.New("missing the storage node address")
+	}
+	if _, err := version.NewSemVer(node.Version.GetVersion()); err != nil {
+		return Error.New("unable to convert version to semVer")
+	}
+
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+
+	update, ok := buffer.pending[node.NodeID]
+	if !ok {
+		update = &CheckInUpdate{}
+		buffer.pending[node.NodeID] = update
+	}
+	update.Node = node
+
+	if node.IsUp {
+		update.LastContactSuccess = timestamp
+	} else {
+		update.LastContactFailure = timestamp
+	}
+	switch {
+	case node.SoftwareUpdateEmailSent:
+		update.SoftwareUpdateEmailSent = timestamp
+	case !node.VersionBelowMin:
+		// the last software update email is cleared, when the node is updated.
+		update.SoftwareUpdateEmailSent = time.Time{}
+	}
+	// the email was sent by an earlier check-in, it's recorded by the
+	// SoftwareUpdateEmailSent time.
+	update.Node.SoftwareUpdateEmailSent = false
+
+	mon.IntVal("check_in_buffer_pending").Observe(int64(len(buffer.pending)))
+	return nil
+}
+
+// Apply applies the check-ins of the node, which aren't written yet, to the dossier.
+func (buffer *CheckInBuffer) Apply(dossier *NodeDossier) {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+
+	// the flushing check-ins are older than the pending ones.
+	if update, ok := buffer.flushing[dossier.Id]; ok {
+		applyCheckIn(dossier, update)
+	}
+	if update, ok := buffer.pending[dossier.Id]; ok {
+		applyCheckIn(dossier, update)
+	}
+}
+
+func applyCheckIn(dossier *NodeDossier, update *CheckInUpdate) {
+	node := update.Node
+
+	dossier.Address = node.Address
+	dossier.LastNet = node.LastNet
+	dossier.LastIPPort = node.LastIPPort
+	dossier.CountryCode = node.CountryCode
+	if node.Operator != nil {
+		dossier.Operator = *node.Operator
+	}
+	if node.Capacity != nil {
+		dossier.Capacity = *node.Capacity
+	}
+	if node.Version != nil {
+		dossier.Version = *node.Version
+	}
+
+	if !update.LastContactSuccess.IsZero() {
+		dossier.Reputation.LastContactSuccess = update.LastContactSuccess
+		dossier.LastOfflineEmail = nil
+	}
+	if !update.LastContactFailure.IsZero() {
+		dossier.Reputation.LastContactFailure = update.LastContactFailure
+	}
+
+	switch {
+	case !update.SoftwareUpdateEmailSent.IsZero():
+		sent := update.SoftwareUpdateEmailSent
+		dossier.LastSoftwareUpdateEmail = &sent
+	case !node.VersionBelowMin:
+		dossier.LastSoftwareUpdateEmail = nil
+	}
+}
+
+// Flush writes the coalesced check-ins to the database.
+func (buffer *CheckInBuffer) Flush(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	buffer.flushMu.Lock()
+	defer buffer.flushMu.Unlock()
+
+	buffer.mu.Lock()
+	flushing := buffer.pending
+	buffer.flushing = flushing
+	buffer.pending = map[storj.NodeID]*CheckInUpdate{}
+	buffer.mu.Unlock()
+
+	if len(flushing) == 0 {
+		buffer.mu.Lock()
+		buffer.flushing = nil
+		buffer.mu.Unlock()
+		return nil
+	}
+
+	updates := make([]CheckInUpdate, 0, len(flushing))
+	for _, update := range flushing {
+		updates = append(updates, *update)
+	}
+
+	var group errs.Group
+	var failed []CheckInUpdate
+	for len(updates) > 0 {
+		batchSize := buffer.config.BatchSize
+		if batchSize > len(updates) {
+			batchSize = len(updates)
+		}
+		batch := updates[:batchSize]
+		updates = updates[batchSize:]
+
+		if err := buffer.db.UpdateCheckIns(ctx, batch); err != nil {
+			group.Add(err)
+			failed = append(failed, batch...)
+			continue
+		}
+		mon.Meter("check_in_buffer_written").Mark(len(batch))
+	}
+
+	buffer.mu.Lock()
+	// the check-ins which failed to write are retried with the next flush,
+	// merged into the newer check-in of the node, if there's one.
+	for i := range failed {
+		update := &failed[i]
+		if newer, ok := buffer.pending[update.Node.NodeID]; ok {
+			mergeCheckIn(update, newer)
+			continue
+		}
+		buffer.pending[update.Node.NodeID] = update
+	}
+	buffer.flushing = nil
+	buffer.mu.Unlock()
+
+	return group.Err()
+}
+
+// mergeCheckIn merges the older check-in into the newer one.
+func mergeCheckIn(older, newer *CheckInUpdate) {
+	if newer.LastContactSuccess.IsZero() {
+		newer.LastContactSuccess = older.LastContactSuccess
+	}
+	if newer.LastContactFailure.IsZero() {
+		newer.LastContactFailure = older.LastContactFailure
+	}
+	if newer.SoftwareUpdateEmailSent.IsZero() && newer.Node.VersionBelowMin {
+		newer.SoftwareUpdateEmailSent = older.SoftwareUpdateEmailSent
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/overlay"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestCheckInBuffer(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		cache := db.OverlayCache()
+		buffer := overlay.NewCheckInBuffer(zaptest.NewLogger(t), cache, overlay.CheckInBufferConfig{BatchSize: 2})
+
+		checkIn := func(nodeID storj.NodeID, address string, isUp bool) overlay.NodeCheckInInfo {
+			return overlay.NodeCheckInInfo{
+				NodeID:     nodeID,
+				Address:    &pb.NodeAddress{Address: address},
+				LastIPPort: address,
+				LastNet:    "127.0.0",
+				Operator:   &pb.NodeOperator{Email: "a@mail.test"},
+				Capacity:   &pb.NodeCapacity{FreeDisk: 1000},
+				Version:    &pb.NodeVersion{Version: "v1.0.0"},
+				IsUp:       isUp,
+			}
+		}
+
+		start := time.Now().UTC().Truncate(time.Second).Add(-time.Hour)
+
+		var nodeIDs [3]storj.NodeID
+		for i := range nodeIDs {
+			nodeIDs[i] = testrand.NodeID()
+			require.NoError(t, cache.UpdateCheckIn(ctx, checkIn(nodeIDs[i], "127.0.0.1:8080", true), start, overlay.NodeSelectionConfig{}))
+		}
+
+		require.Error(t, buffer.Add(checkIn(nodeIDs[0], "", true), start))
+
+		success, failure := start.Add(time.Minute), start.Add(2*time.Minute)
+		for _, nodeID := range nodeIDs {
+			require.NoError(t, buffer.Add(checkIn(nodeID, "127.0.0.2:8080", true), success))
+			require.NoError(t, buffer.Add(checkIn(nodeID, "127.0.0.3:8080", false), failure))
+		}
+
+		// the check-ins aren't written yet, but they are applied to the dossiers.
+		dossier, err := cache.Get(ctx, nodeIDs[0])
+		require.NoError(t, err)
+		require.Equal(t, "127.0.0.1:8080", dossier.Address.Address)
+
+		buffer.Apply(dossier)
+		require.Equal(t, "127.0.0.3:8080", dossier.Address.Address)
+		require.Equal(t, success, dossier.Reputation.LastContactSuccess.UTC())
+		require.Equal(t, failure, dossier.Reputation.LastContactFailure.UTC())
+
+		// the coalesced check-ins are written in batches.
+		require.NoError(t, buffer.Flush(ctx))
+		for _, nodeID := range nodeIDs {
+			dossier, err := cache.Get(ctx, nodeID)
+			require.NoError(t, err)
+			require.Equal(t, "127.0.0.3:8080", dossier.Address.Address)
+			require.Equal(t, "127.0.0.3:8080", dossier.LastIPPort)
+			require.Equal(t, success, dossier.Reputation.LastContactSuccess.UTC())
+			require.Equal(t, failure, dossier.Reputation.LastContactFailure.UTC())
+		}
+
+		// unknown nodes are skipped.
+		unknown := testrand.NodeID()
+		require.NoError(t, buffer.Add(checkIn(unknown, "127.0.0.4:8080", true), success))
+		require.NoError(t, buffer.Flush(ctx))
+		_, err = cache.Get(ctx, unknown)
+		require.True(t, overlay.ErrNodeNotFound.Has(err), err)
+	})
+}
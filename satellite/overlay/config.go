@@ -4,6 +4,7 @@
 package overlay
 
 import (
+	"net"
 	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
@@ -23,6 +24,8 @@ var (
 type Config struct {
 	Node                            NodeSelectionConfig
 	NodeSelectionCache              UploadSelectionCacheConfig
+	CheckInBuffer                   CheckInBufferConfig
+	UptimeHistory                   UptimeHistoryConfig
 	GeoIP                           GeoIPConfig
 	UpdateStatsBatchSize            int           `help:"number of update requests to process per transaction" default:"100"`
 	NodeCheckInWaitPeriod           time.Duration `help:"the amount of time to wait before accepting a redundant check-in from a node (unmodified info since last check-in)" default:"2h" testDefault:"30s"`
@@ -47,11 +50,15 @@ type NodeSelectionConfig struct {
 	MinimumVersion    string        `help:"the minimum node software version for node selection queries" default:""`
 	OnlineWindow      time.Duration `help:"the amount of time without seeing a node before its considered offline" default:"4h" testDefault:"1m"`
 	DistinctIP        bool          `help:"require distinct IPs when choosing nodes for upload" releaseDefault:"true" devDefault:"false"`
+	MaxNodesPerSubnet int           `help:"the maximum number of nodes selected from the same subnet (last_net) for a segment by the default placement" default:"1"`
 	NetworkPrefixIPv4 int           `help:"the prefix to use in determining 'network' for IPv4 addresses" default:"24" hidden:"true"`
-	NetworkPrefixIPv6 int           `help:"the prefix to use in determining 'network' for IPv6 addresses" default:"64" hidden:"true"`
-	MinimumDiskSpace  memory.Size   `help:"how much disk space a node at minimum must have to be selected for upload" default:"5.00GB" testDefault:"100.00MB"`
+	// NetworkPrefixIpv6 is spelled so the flag is network-prefix-ipv6, the
+	// flag of NetworkPrefixIPv6 would be network-prefix-i-pv6.
+	NetworkPrefixIpv6 int         `help:"the prefix to use in determining 'network' for IPv6 addresses" default:"64"`
+	MinimumDiskSpace  memory.Size `help:"how much disk space a node at minimum must have to be selected for upload" default:"5.00GB" testDefault:"100.00MB"`
 
 	AsOfSystemTime AsOfSystemTimeConfig
+	Maintenance    MaintenanceConfig
 
 	UploadExcludedCountryCodes []string `help:"list of country codes to exclude from node selection for uploads (DEPRECATED: use placement definition instead)" default:"" testDefault:"FR,BE"`
 }
@@ -60,6 +67,8 @@ type NodeSelectionConfig struct {
 type GeoIPConfig struct {
 	DB            string   `help:"the location of the maxmind database containing geoip country information"`
 	MockCountries []string `help:"a mock list of countries the satellite will attribute to nodes (useful for testing)"`
+	ASNDatabase   string   `help:"the location of the maxmind database containing autonomous system information (e.g. GeoLite2-ASN)"`
+	MockASN       []string `help:"a mock list of autonomous system numbers the satellite will attribute to nodes (useful for testing)"`
 }
 
 func (aost *AsOfSystemTimeConfig) isValid() error {
@@ -75,6 +84,19 @@ func (aost *AsOfSystemTimeConfig) isValid() error {
 	return nil
 }
 
+func (config *NodeSelectionConfig) isValid() error {
+	if config.NetworkPrefixIPv4 < 0 || config.NetworkPrefixIPv4 > 8*net.IPv4len {
+		return errs.New("IPv4 network prefix must be between 0 and %d", 8*net.IPv4len)
+	}
+	if config.NetworkPrefixIpv6 < 0 || config.NetworkPrefixIpv6 > 8*net.IPv6len {
+		return errs.New("IPv6 network prefix must be between 0 and %d", 8*net.IPv6len)
+	}
+	if config.MaxNodesPerSubnet < 0 {
+		return errs.New("maximum number of nodes per subnet must not be negative")
+	}
+	return config.AsOfSystemTime.isValid()
+}
+
 // Interval returns the configured interval respecting Enabled property.
 func (aost *AsOfSystemTimeConfig) Interval() time.Duration {
 	if !aost.Enabled {
@@ -86,10 +108,15 @@ func (aost *AsOfSystemTimeConfig) Interval() time.Duration {
 // CreateDefaultPlacement creates a placement (which will be used as default) based on configuration.
 // This is used only if no placement is configured, but we need a 0 placement rule.
 func (c NodeSelectionConfig) CreateDefaultPlacement() (nodeselection.Placement, error) {
+	maxNodesPerSubnet := c.MaxNodesPerSubnet
+	if maxNodesPerSubnet < 1 {
+		maxNodesPerSubnet = 1
+	}
+
 	placement := nodeselection.Placement{
 		NodeFilter:       nodeselection.AnyFilter{},
-		Selector:         nodeselection.UnvettedSelector(c.NewNodeFraction, nodeselection.AttributeGroupSelector(nodeselection.LastNetAttribute)),
-		Invariant:        nodeselection.ClumpingByAttribute(nodeselection.LastNetAttribute, 1),
+		Selector:         nodeselection.UnvettedSelector(c.NewNodeFraction, nodeselection.AttributeGroupSelectorWithLimit(nodeselection.LastNetAttribute, maxNodesPerSubnet)),
+		Invariant:        nodeselection.ClumpingByAttribute(nodeselection.LastNetAttribute, maxNodesPerSubnet),
 		DownloadSelector: nodeselection.DefaultDownloadSelector,
 	}
 	if len(c.UploadExcludedCountryCodes) > 0 {
@@ -237,3 +237,23 @@ func TestDBDisqualifyNode(t *testing.T) {
 		}
 	})
 }
+
+func TestGetNetworkStats(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 3,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		cache := planet.Satellites[0].Overlay.DB
+
+		stats, err := cache.GetNetworkStats(ctx, time.Hour, 0)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, stats.ActiveNodes)
+		require.Positive(t, stats.FreeDisk)
+
+		_, err = cache.DisqualifyNode(ctx, planet.StorageNodes[0].ID(), time.Now(), overlay.DisqualificationReasonUnknown)
+		require.NoError(t, err)
+
+		stats, err = cache.GetNetworkStats(ctx, time.Hour, 0)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, stats.ActiveNodes)
+	})
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package deadnodes archives the records of nodes, which have been offline
+// for a long time and which aren't referenced by any segment.
+package deadnodes
+
+import (
+	"context"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/rangedloop"
+	"storj.io/storj/satellite/overlay"
+)
+
+var (
+	// Error is a standard error class for this package.
+	Error = errs.Class("deadnodes")
+	mon   = monkit.Package()
+
+	// check if Observer and Partial interfaces are satisfied.
+	_ rangedloop.Observer = (*Observer)(nil)
+	_ rangedloop.Partial  = (*observerFork)(nil)
+)
+
+// Config contains configurable values for archiving dead nodes.
+type Config struct {
+	Enabled          bool          `help:"whether to archive the records of long-dead nodes, which aren't referenced by any segment, with the ranged loop" default:"false"`
+	OfflineThreshold time.Duration `help:"how long a node has to be without any contact before its record is archived" default:"8760h0m0s" testDefault:"720h0m0s"`
+	BatchSize        int           `help:"number of nodes to archive with a single query" default:"100"`
+}
+
+// Observer implements a ranged loop observer, which archives node records.
+//
+// The observer collects the nodes referenced by the segments during the
+// loop, and at the end it moves the records of nodes without any contact
+// since OfflineThreshold before the start of the loop, which don't hold any
+// piece, from the nodes table to the archived nodes. The archived nodes can
+// be restored with overlay.DB.RestoreArchivedNode.
+type Observer struct {
+	log        *zap.Logger
+	config     Config
+	overlay    overlay.DB
+	metabaseDB *metabase.DB
+
+	startTime  time.Time
+	referenced map[metabase.NodeAlias]struct{}
+}
+
+// NewObserver creates new dead nodes ranged loop observer.
+func NewObserver(log *zap.Logger, metabaseDB *metabase.DB, overlay overlay.DB, config Config) *Observer {
+	return &Observer{
+		log:        log,
+		config:     config,
+		overlay:    overlay,
+		metabaseDB: metabaseDB,
+		referenced: map[metabase.NodeAlias]struct{}{},
+	}
+}
+
+// Start implements ranged loop observer start method.
+func (observer *Observer) Start(ctx context.Context, startTime time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	observer.startTime = startTime
+	observer.referenced = map[metabase.NodeAlias]struct{}{}
+	return nil
+}
+
+// Fork implements ranged loop observer fork method.
+func (observer *Observer) Fork(ctx context.Context) (_ rangedloop.Partial, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return &observerFork{
+		referenced: map[metabase.NodeAlias]struct{}{},
+	}, nil
+}
+
+// Join merges the nodes referenced by the partial into the observer.
+func (observer *Observer) Join(ctx context.Context, partial rangedloop.Partial) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	fork, ok := partial.(*observerFork)
+	if !ok {
+		return Error.New("expected %T but got %T", fork, partial)
+	}
+
+	for alias := range fork.referenced {
+		observer.referenced[alias] = struct{}{}
+	}
+	return nil
+}
+
+// Finish archives the dead nodes, which aren't referenced by any segment.
+func (observer *Observer) Finish(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	aliasMap, err := observer.metabaseDB.LatestNodesAliasMap(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	// Nodes without any contact since before the start of the loop couldn't
+	// have received any new piece during the loop.
+	cutoff := observer.startTime.Add(-observer.config.OfflineThreshold)
+
+	var cursor storj.NodeID
+	var archived, skipped int
+	for {
+		candidates, err := observer.overlay.GetArchivableNodes(ctx, cursor, cutoff, observer.config.BatchSize)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if len(candidates) == 0 {
+			break
+		}
+		cursor = candidates[len(candidates)-1]
+
+		unreferenced := make(storj.NodeIDList, 0, len(candidates))
+		for _, nodeID := range candidates {
+			if alias, ok := aliasMap.Alias(nodeID); ok {
+				if _, ok := observer.referenced[alias]; ok {
+					skipped++
+					continue
+				}
+			}
+			unreferenced = append(unreferenced, nodeID)
+		}
+
+		count, err := observer.overlay.ArchiveNodes(ctx, unreferenced, cutoff)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		archived += count
+
+		if len(candidates) < observer.config.BatchSize {
+			break
+		}
+	}
+
+	mon.IntVal("dead_nodes_archived").Observe(int64(archived))
+	mon.IntVal("dead_nodes_still_referenced").Observe(int64(skipped))
+
+	if archived > 0 {
+		observer.log.Info("archived dead nodes", zap.Int("archived", archived), zap.Int("still referenced", skipped))
+	}
+	return nil
+}
+
+type observerFork struct {
+	referenced map[metabase.NodeAlias]struct{}
+}
+
+// Process collects the nodes referenced by the segments.
+func (fork *observerFork) Process(ctx context.Context, segments []rangedloop.Segment) error {
+	for _, segment := range segments {
+		if segment.Inline() {
+			continue
+		}
+		for _, piece := range segment.AliasPieces {
+			fork.referenced[piece.Alias] = struct{}{}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package deadnodes_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/overlay"
+)
+
+func TestObserverArchivesDeadNodes(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.DeadNodes.Enabled = true
+				config.DeadNodes.OfflineThreshold = 24 * time.Hour
+				config.DeadNodes.BatchSize = 1
+
+				config.Metainfo.RS.Min = 2
+				config.Metainfo.RS.Repair = 3
+				config.Metainfo.RS.Success = 4
+				config.Metainfo.RS.Total = 4
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		cache := sat.Overlay.DB
+
+		err := planet.Uplinks[0].Upload(ctx, sat, "testbucket", "test/path", testrand.Bytes(50*memory.KiB))
+		require.NoError(t, err)
+
+		checkIn := func(nodeID storj.NodeID, timestamp time.Time) {
+			require.NoError(t, cache.UpdateCheckIn(ctx, overlay.NodeCheckInInfo{
+				NodeID:  nodeID,
+				IsUp:    true,
+				Address: &pb.NodeAddress{Address: "1.2.3.4"},
+				Version: &pb.NodeVersion{Version: "v0.0.0"},
+			}, timestamp, sat.Config.Overlay.Node))
+		}
+
+		// the storage node still holds a piece of the uploaded segment.
+		referencedNode := planet.StorageNodes[0]
+		referencedNode.Contact.Chore.Pause(ctx)
+		checkIn(referencedNode.ID(), time.Now().Add(-48*time.Hour))
+
+		deadNodes := storj.NodeIDList{testrand.NodeID(), testrand.NodeID()}
+		for _, nodeID := range deadNodes {
+			checkIn(nodeID, time.Now().Add(-48*time.Hour))
+		}
+
+		recentNode := testrand.NodeID()
+		checkIn(recentNode, time.Now().Add(-time.Hour))
+
+		// disqualified and exited nodes are kept, so they can't come back as new nodes.
+		disqualifiedNode, exitedNode := testrand.NodeID(), testrand.NodeID()
+		checkIn(disqualifiedNode, time.Now().Add(-48*time.Hour))
+		_, err = cache.DisqualifyNode(ctx, disqualifiedNode, time.Now().Add(-47*time.Hour), overlay.DisqualificationReasonAuditFailure)
+		require.NoError(t, err)
+		checkIn(exitedNode, time.Now().Add(-48*time.Hour))
+		_, err = cache.UpdateExitStatus(ctx, &overlay.ExitStatusRequest{
+			NodeID:         exitedNode,
+			ExitFinishedAt: time.Now().Add(-47 * time.Hour),
+			ExitSuccess:    true,
+		})
+		require.NoError(t, err)
+
+		_, err = sat.RangedLoop.RangedLoop.Service.RunOnce(ctx)
+		require.NoError(t, err)
+
+		for _, nodeID := range deadNodes {
+			_, err = cache.Get(ctx, nodeID)
+			require.True(t, overlay.ErrNodeNotFound.Has(err), "%v", err)
+		}
+		for _, nodeID := range []storj.NodeID{referencedNode.ID(), recentNode, disqualifiedNode, exitedNode} {
+			_, err = cache.Get(ctx, nodeID)
+			require.NoError(t, err)
+		}
+
+		// restore the archived node.
+		require.NoError(t, cache.RestoreArchivedNode(ctx, deadNodes[0]))
+		_, err = cache.Get(ctx, deadNodes[0])
+		require.NoError(t, err)
+
+		err = cache.RestoreArchivedNode(ctx, deadNodes[0])
+		require.True(t, overlay.ErrNodeNotFound.Has(err), "%v", err)
+
+		err = cache.RestoreArchivedNode(ctx, testrand.NodeID())
+		require.True(t, overlay.ErrNodeNotFound.Has(err), "%v", err)
+
+		// the archived node came back as a new node, so it can't be restored.
+		checkIn(deadNodes[1], time.Now())
+		err = cache.RestoreArchivedNode(ctx, deadNodes[1])
+		require.True(t, overlay.ErrNodeExists.Has(err), "%v", err)
+	})
+}
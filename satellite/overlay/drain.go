@@ -0,0 +1,55 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+)
+
+// ErrNodeNotDraining is returned when a node isn't draining.
+var ErrNodeNotDraining = errs.Class("node is not draining")
+
+// NodeDrain describes a node which is being drained before it's decommissioned.
+//
+// Draining nodes aren't selected for uploads (including repair uploads), but they
+// are still used for downloads and audited like any other node, so the data stored
+// on them stays available while it's moved elsewhere over time.
+type NodeDrain struct {
+	NodeID      storj.NodeID `json:"nodeID"`
+	Reason      string       `json:"reason,omitempty"`
+	InitiatedAt time.Time    `json:"initiatedAt"`
+}
+
+// StartNodeDrain marks the node as draining. Starting the drain of a node which
+// is already draining updates the reason, but keeps the original start time.
+func (service *Service) StartNodeDrain(ctx context.Context, nodeID storj.NodeID, reason string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return service.db.SetNodeDrain(ctx, NodeDrain{
+		NodeID:      nodeID,
+		Reason:      reason,
+		InitiatedAt: time.Now().UTC(),
+	})
+}
+
+// StopNodeDrain makes the node selectable for uploads again.
+// It returns ErrNodeNotDraining when the node isn't draining.
+func (service *Service) StopNodeDrain(ctx context.Context, nodeID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return service.db.DeleteNodeDrain(ctx, nodeID)
+}
+
+// GetNodeDrain returns the drain state of the node.
+// It returns ErrNodeNotDraining when the node isn't draining.
+func (service *Service) GetNodeDrain(ctx context.Context, nodeID storj.NodeID) (_ NodeDrain, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return service.db.GetNodeDrain(ctx, nodeID)
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/storj"
+	"storj.io/storj/private/maintenance"
+	"storj.io/storj/satellite/nodeselection"
+)
+
+// MaintenanceHonoredTag is the name of the node tag which records the last maintenance
+// window honored by the satellite. It's stored with an empty signer, which can't be
+// produced by a signed node tag set, so the node isn't able to overwrite it.
+const MaintenanceHonoredTag = "maintenance-window-honored"
+
+// MaintenanceConfig configures how declared node maintenance windows are honored.
+type MaintenanceConfig struct {
+	Enabled     bool          `help:"whether declared node maintenance windows are honored" default:"false"`
+	MaxDuration time.Duration `help:"the maximum duration of a maintenance window, longer windows are honored only up to this duration" default:"24h"`
+	Cooldown    time.Duration `help:"the minimum time between the end of an honored maintenance window and the start of the next honored one" default:"720h"`
+}
+
+// MaintenanceWindowFromTags returns the most recently signed maintenance window declared by the node.
+func MaintenanceWindowFromTags(tags nodeselection.NodeTags) (window maintenance.Window, ok bool) {
+	return latestMaintenanceWindow(tags, maintenance.Tag)
+}
+
+// honoredMaintenanceWindowFromTags returns the last maintenance window honored by the satellite.
+func honoredMaintenanceWindowFromTags(tags nodeselection.NodeTags) (window maintenance.Window, ok bool) {
+	return latestMaintenanceWindow(tags, MaintenanceHonoredTag)
+}
+
+func latestMaintenanceWindow(tags nodeselection.NodeTags, name string) (window maintenance.Window, ok bool) {
+	var signedAt time.Time
+	for _, tag := range tags {
+		if tag.Name != name || tag.SignedAt.Before(signedAt) {
+			continue
+		}
+		// only the satellite writes the honored window, see MaintenanceHonoredTag.
+		if (name == MaintenanceHonoredTag) != tag.Signer.IsZero() {
+			continue
+		}
+		parsed, err := maintenance.Parse(string(tag.Value))
+		if err != nil {
+			continue
+		}
+		window, ok, signedAt = parsed, true, tag.SignedAt
+	}
+	return window, ok
+}
+
+// activeMaintenanceWindow returns the maintenance window of the node which is honored at the given time.
+// A window is honored when it's the same as the previously honored one, or when it starts at least
+// the cooldown after the previously honored one ended. Otherwise, nodes could avoid offline penalties
+// by declaring consecutive windows.
+func (config MaintenanceConfig) activeMaintenanceWindow(tags nodeselection.NodeTags, now time.Time) (window maintenance.Window, ok bool) {
+	if !config.Enabled {
+		return maintenance.Window{}, false
+	}
+
+	window, ok = MaintenanceWindowFromTags(tags)
+	if !ok {
+		return maintenance.Window{}, false
+	}
+	window = window.Limit(config.MaxDuration)
+	if !window.Contains(now) {
+		return maintenance.Window{}, false
+	}
+
+	honored, ok := honoredMaintenanceWindowFromTags(tags)
+	if ok && !honored.Equal(window) && window.Start.Before(honored.End.Add(config.Cooldown)) {
+		return maintenance.Window{}, false
+	}
+	return window, true
+}
+
+// excludeNodesInMaintenance removes the nodes which are within their maintenance window.
+func (config MaintenanceConfig) excludeNodesInMaintenance(nodes []*nodeselection.SelectedNode, now time.Time) []*nodeselection.SelectedNode {
+	if !config.Enabled {
+		return nodes
+	}
+	filtered := nodes[:0]
+	for _, node := range nodes {
+		if _, ok := config.activeMaintenanceWindow(node.Tags, now); ok {
+			continue
+		}
+		filtered = append(filtered, node)
+	}
+	return filtered
+}
+
+// NodesInMaintenance returns the nodes which are within their declared maintenance window.
+// The offline penalties of such nodes are suppressed. The windows are recorded as honored,
+// so the cooldown applies to the next windows of the nodes.
+func (service *Service) NodesInMaintenance(ctx context.Context, nodeIDs storj.NodeIDList, now time.Time) (_ map[storj.NodeID]bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	config := service.config.Node.Maintenance
+	if !config.Enabled || len(nodeIDs) == 0 {
+		return nil, nil
+	}
+
+	tagsByNode, err := service.db.GetNodeTagsByNames(ctx, nodeIDs, []string{maintenance.Tag, MaintenanceHonoredTag})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	inMaintenance := make(map[storj.NodeID]bool)
+	var honored nodeselection.NodeTags
+	for nodeID, tags := range tagsByNode {
+		window, ok := config.activeMaintenanceWindow(tags, now)
+		if !ok {
+			continue
+		}
+		inMaintenance[nodeID] = true
+
+		if previous, ok := honoredMaintenanceWindowFromTags(tags); !ok || !previous.Equal(window) {
+			honored = append(honored, nodeselection.NodeTag{
+				NodeID:   nodeID,
+				Name:     MaintenanceHonoredTag,
+				Value:    []byte(window.String()),
+				SignedAt: now,
+			})
+		}
+	}
+
+	if len(honored) > 0 {
+		if err := service.db.UpdateNodeTags(ctx, honored); err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+	return inMaintenance, nil
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/maintenance"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/nodeselection"
+	"storj.io/storj/satellite/overlay"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestMaintenanceWindowFromTags(t *testing.T) {
+	nodeID := testrand.NodeID()
+
+	_, ok := overlay.MaintenanceWindowFromTags(nodeselection.NodeTags{
+		{NodeID: nodeID, Signer: nodeID, Name: "soc", Value: []byte("true")},
+	})
+	require.False(t, ok)
+
+	now := time.Now()
+	window, ok := overlay.MaintenanceWindowFromTags(nodeselection.NodeTags{
+		{
+			NodeID: nodeID, Signer: nodeID, SignedAt: now,
+			Name: maintenance.Tag, Value: []byte("2024-02-01T10:00:00Z/2024-02-01T12:00:00Z"),
+		},
+		{
+			NodeID: nodeID, Signer: nodeID, SignedAt: now.Add(-time.Hour),
+			Name: maintenance.Tag, Value: []byte("2024-01-01T10:00:00Z/2024-01-01T12:00:00Z"),
+		},
+		{
+			NodeID: nodeID, Signer: nodeID, SignedAt: now.Add(time.Hour),
+			Name: maintenance.Tag, Value: []byte("invalid"),
+		},
+		{
+			NodeID: nodeID, SignedAt: now.Add(time.Hour),
+			Name: maintenance.Tag, Value: []byte("2024-03-01T10:00:00Z/2024-03-01T12:00:00Z"),
+		},
+	})
+	require.True(t, ok)
+	require.Equal(t, "2024-02-01T10:00:00Z/2024-02-01T12:00:00Z", window.String())
+}
+
+func TestNodesInMaintenance(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		service, err := overlay.NewService(zaptest.NewLogger(t), db.OverlayCache(), db.NodeEvents(), nodeselection.TestPlacementDefinitions(), "", "", overlay.Config{
+			Node: overlay.NodeSelectionConfig{
+				Maintenance: overlay.MaintenanceConfig{
+					Enabled:     true,
+					MaxDuration: 4 * time.Hour,
+					Cooldown:    24 * time.Hour,
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		now := time.Now().Truncate(time.Second)
+		declare := func(nodeID storj.NodeID, window maintenance.Window, signedAt time.Time) {
+			require.NoError(t, service.UpdateNodeTags(ctx, nodeselection.NodeTags{{
+				NodeID:   nodeID,
+				Name:     maintenance.Tag,
+				Value:    []byte(window.String()),
+				SignedAt: signedAt,
+				Signer:   nodeID,
+			}}))
+		}
+
+		maintained, declaredLater, other := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+		first := maintenance.Window{Start: now.Add(-time.Hour), End: now.Add(time.Hour)}
+		declare(maintained, first, now.Add(-2*time.Hour))
+		declare(declaredLater, maintenance.Window{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour)}, now.Add(-2*time.Hour))
+
+		nodes := storj.NodeIDList{maintained, declaredLater, other}
+
+		inMaintenance, err := service.NodesInMaintenance(ctx, nodes, now)
+		require.NoError(t, err)
+		require.Equal(t, map[storj.NodeID]bool{maintained: true}, inMaintenance)
+
+		// the same window stays honored.
+		inMaintenance, err = service.NodesInMaintenance(ctx, nodes, now.Add(30*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, map[storj.NodeID]bool{maintained: true}, inMaintenance)
+
+		// a consecutive window is within the cooldown of the honored one.
+		declare(maintained, maintenance.Window{Start: first.End, End: first.End.Add(time.Hour)}, now)
+		inMaintenance, err = service.NodesInMaintenance(ctx, nodes, first.End.Add(time.Minute))
+		require.NoError(t, err)
+		require.Empty(t, inMaintenance)
+
+		// a window after the cooldown is honored again.
+		later := maintenance.Window{Start: first.End.Add(25 * time.Hour), End: first.End.Add(26 * time.Hour)}
+		declare(maintained, later, now.Add(time.Minute))
+		inMaintenance, err = service.NodesInMaintenance(ctx, nodes, later.Start.Add(time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, map[storj.NodeID]bool{maintained: true}, inMaintenance)
+
+		tags, err := service.GetNodeTags(ctx, maintained)
+		require.NoError(t, err)
+		var honored []string
+		for _, tag := range tags {
+			if tag.Name == overlay.MaintenanceHonoredTag {
+				honored = append(honored, string(tag.Value))
+			}
+		}
+		require.Equal(t, []string{later.String()}, honored)
+	})
+}
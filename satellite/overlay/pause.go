@@ -0,0 +1,38 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"storj.io/storj/satellite/nodeselection"
+)
+
+// PausedTag is the name of the node tag which announces that the node paused
+// serving the satellite. It's signed by the node itself and its value lists
+// the paused operations, e.g. "uploads" or "uploads,downloads". An empty value
+// means that the node resumed serving the satellite.
+const PausedTag = "paused"
+
+// isPaused returns whether the node announced that it paused serving the satellite.
+func isPaused(node *nodeselection.SelectedNode) bool {
+	for _, tag := range node.Tags {
+		// only the node can pause itself.
+		if tag.Name == PausedTag && tag.Signer == node.ID {
+			return len(tag.Value) > 0
+		}
+	}
+	return false
+}
+
+// excludePausedNodes removes the nodes which paused serving the satellite.
+// The uploads to them are always refused.
+func excludePausedNodes(nodes []*nodeselection.SelectedNode) []*nodeselection.SelectedNode {
+	filtered := nodes[:0]
+	for _, node := range nodes {
+		if isPaused(node) {
+			continue
+		}
+		filtered = append(filtered, node)
+	}
+	return filtered
+}
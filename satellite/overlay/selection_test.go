@@ -580,6 +580,48 @@ func TestDistinctIPs(t *testing.T) {
 	}
 }
 
+func TestDistinctIPv6Networks(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	config := overlayDefaultConfig(0)
+	config.Node.DistinctIP = true
+	config.Node.NetworkPrefixIPv4 = 24
+	config.Node.NetworkPrefixIpv6 = 64
+
+	// IPv6-only nodes, where the first three nodes share the same /64 network.
+	service, _, cleanup := runServiceWithDB(ctx, zaptest.NewLogger(t), 6, 0, config, func(i int, node *nodeselection.SelectedNode) {
+		network := 0
+		if i > 2 {
+			network = i - 2
+		}
+		ip := net.ParseIP(fmt.Sprintf("2001:db8:0:%d::%d", network, i+1))
+		lastNet, err := overlay.MaskOffLastNet(config.Node, ip, "28967")
+		require.NoError(t, err)
+
+		node.LastIPPort = net.JoinHostPort(ip.String(), "28967")
+		node.LastNet = lastNet
+		node.Address = &pb.NodeAddress{Address: node.LastIPPort}
+	})
+	defer cleanup()
+
+	nodes, err := service.FindStorageNodesForUpload(ctx, overlay.FindStorageNodesRequest{
+		RequestedCount: 4,
+	})
+	require.NoError(t, err)
+	require.Len(t, nodes, 4)
+
+	networks := make(map[string]bool)
+	for _, n := range nodes {
+		require.False(t, networks[n.LastNet])
+		networks[n.LastNet] = true
+	}
+
+	_, err = service.FindStorageNodesForUpload(ctx, overlay.FindStorageNodesRequest{
+		RequestedCount: 5,
+	})
+	require.True(t, overlay.ErrNotEnoughNodes.Has(err))
+}
+
 func TestAddrtoNetwork_Conversion(t *testing.T) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
@@ -590,7 +632,7 @@ func TestAddrtoNetwork_Conversion(t *testing.T) {
 			config := overlay.NodeSelectionConfig{
 				DistinctIP:        distinctIPEnabled,
 				NetworkPrefixIPv4: ipv4Mask,
-				NetworkPrefixIPv6: ipv6Mask,
+				NetworkPrefixIpv6: ipv6Mask,
 			}
 			resolvedIP, resolvedPort, network, err := overlay.ResolveIPAndNetwork(ctx, ipAndPort, config, overlay.MaskOffLastNet)
 			require.NoError(t, err)
@@ -606,6 +648,18 @@ func TestAddrtoNetwork_Conversion(t *testing.T) {
 	runTest(t, "fc00::1:200", "28967", true, 0, 64, "fc00::")
 	runTest(t, "fc00::1:200", "28967", true, 0, 128-16, "fc00::1:0")
 	runTest(t, "fc00::1:200", "28967", false, 0, 0, "[fc00::1:200]:28967")
+
+	runTest(t, "2001:db8:1:2:3:4:5:6", "28967", true, 24, 48, "2001:db8:1::")
+	runTest(t, "2001:db8:1:2:3:4:5:6", "28967", true, 24, 64, "2001:db8:1:2::")
+
+	t.Run("invalid prefix", func(t *testing.T) {
+		_, err := overlay.MaskOffLastNet(overlay.NodeSelectionConfig{
+			DistinctIP:        true,
+			NetworkPrefixIPv4: 24,
+			NetworkPrefixIpv6: 129,
+		}, net.ParseIP("2001:db8::1"), "28967")
+		require.Error(t, err)
+	})
 }
 
 func countCommon(reference []*nodeselection.SelectedNode, selected []*nodeselection.SelectedNode) (count int) {
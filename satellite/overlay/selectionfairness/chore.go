@@ -0,0 +1,132 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package selectionfairness
+
+import (
+	"context"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/overlay"
+)
+
+var (
+	// Error is the standard error class for node selection fairness errors.
+	Error = errs.Class("selection fairness")
+	mon   = monkit.Package()
+)
+
+// Config contains configurable values for the node selection fairness chore.
+type Config struct {
+	Enabled   bool          `help:"whether to periodically analyze the fairness of upload node selection" default:"false"`
+	Interval  time.Duration `help:"how often the fairness of upload node selection is analyzed" default:"24h"`
+	Window    time.Duration `help:"how far back the upload traffic of the nodes is analyzed" default:"168h"`
+	Tolerance float64       `help:"how many times more (or less) than its share of the free capacity a node may receive from the upload traffic before it's flagged" default:"2"`
+}
+
+// Chore periodically compares the recent upload traffic of the vetted nodes with
+// their share of the free capacity and stores the result as the fairness report.
+//
+// architecture: Chore
+type Chore struct {
+	log          *zap.Logger
+	db           DB
+	overlay      overlay.DB
+	accounting   accounting.StoragenodeAccounting
+	onlineWindow time.Duration
+	config       Config
+
+	nowFn func() time.Time
+	Loop  *sync2.Cycle
+}
+
+// NewChore creates a new node selection fairness chore.
+func NewChore(log *zap.Logger, db DB, overlay overlay.DB, accounting accounting.StoragenodeAccounting, onlineWindow time.Duration, config Config) *Chore {
+	return &Chore{
+		log:          log,
+		db:           db,
+		overlay:      overlay,
+		accounting:   accounting,
+		onlineWindow: onlineWindow,
+		config:       config,
+		nowFn:        time.Now,
+		Loop:         sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run runs the chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := chore.RunOnce(ctx); err != nil {
+			chore.log.Error("failed to analyze node selection fairness", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// RunOnce analyzes the node selection fairness once and stores the report.
+func (chore *Chore) RunOnce(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	now := chore.nowFn()
+
+	freeDisk, err := chore.overlay.VettedNodesFreeDisk(ctx, chore.onlineWindow)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	rollups, err := chore.accounting.GetRollupsSince(ctx, now.Add(-chore.config.Window))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	uploaded := make(map[storj.NodeID]int64)
+	for _, rollup := range rollups {
+		if rollup.Action != uint(pb.PieceAction_PUT) {
+			continue
+		}
+		uploaded[rollup.NodeID] += int64(rollup.Settled)
+	}
+
+	entries := Analyze(freeDisk, uploaded, chore.config.Tolerance)
+
+	var over, under int64
+	for _, entry := range entries {
+		switch entry.Status {
+		case StatusOverSelected:
+			over++
+		case StatusUnderSelected:
+			under++
+		}
+	}
+	mon.IntVal("node_selection_fairness_over_selected").Observe(over)
+	mon.IntVal("node_selection_fairness_under_selected").Observe(under)
+
+	if over > 0 || under > 0 {
+		chore.log.Info("nodes with unfair upload traffic found",
+			zap.Int64("over selected", over),
+			zap.Int64("under selected", under))
+	}
+
+	return Error.Wrap(chore.db.ReplaceReport(ctx, now, entries))
+}
+
+// TestSetNow sets nowFn on chore for testing.
+func (chore *Chore) TestSetNow(f func() time.Time) {
+	chore.nowFn = f
+}
+
+// Close closes the chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
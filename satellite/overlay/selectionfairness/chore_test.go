@@ -0,0 +1,73 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package selectionfairness_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/overlay/selectionfairness"
+)
+
+func TestChore(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.SelectionFairness.Enabled = true
+				config.SelectionFairness.Tolerance = 2
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		chore := sat.Core.Overlay.SelectionFairness
+		chore.Loop.Pause()
+
+		now := time.Now()
+		chore.TestSetNow(func() time.Time { return now })
+
+		for i, node := range planet.StorageNodes {
+			_, err := sat.Overlay.Service.TestVetNode(ctx, node.ID())
+			require.NoError(t, err)
+
+			// the first node receives most of the upload traffic.
+			amount := int64(100)
+			if i == 0 {
+				amount = 700
+			}
+			err = sat.DB.Orders().UpdateStoragenodeBandwidthSettle(ctx, node.ID(), pb.PieceAction_PUT, amount, now.Add(-time.Hour))
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, chore.RunOnce(ctx))
+
+		report, err := sat.DB.SelectionFairness().GetReport(ctx)
+		require.NoError(t, err)
+		require.WithinDuration(t, now, report.GeneratedAt, time.Millisecond)
+		require.Len(t, report.Entries, len(planet.StorageNodes))
+
+		require.Equal(t, planet.StorageNodes[0].ID(), report.Entries[0].NodeID)
+		require.Equal(t, selectionfairness.StatusOverSelected, report.Entries[0].Status)
+		require.EqualValues(t, 700, report.Entries[0].UploadedBytes)
+		for _, entry := range report.Entries[1:] {
+			require.Equal(t, selectionfairness.StatusUnderSelected, entry.Status)
+			require.EqualValues(t, 100, entry.UploadedBytes)
+		}
+
+		// the report is replaced on every run.
+		chore.TestSetNow(func() time.Time { return now.Add(30 * 24 * time.Hour) })
+		require.NoError(t, chore.RunOnce(ctx))
+
+		report, err = sat.DB.SelectionFairness().GetReport(ctx)
+		require.NoError(t, err)
+		require.Empty(t, report.Entries)
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package selectionfairness analyzes whether upload node selection spreads the
+// upload traffic over the nodes in proportion to their free capacity.
+package selectionfairness
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"storj.io/common/storj"
+)
+
+// DB stores the latest node selection fairness report.
+//
+// architecture: Database
+type DB interface {
+	// ReplaceReport replaces the stored report with the entries.
+	ReplaceReport(ctx context.Context, generatedAt time.Time, entries []Entry) error
+	// GetReport returns the stored report.
+	GetReport(ctx context.Context) (Report, error)
+}
+
+// Status is the classification of a node in the fairness report.
+type Status string
+
+const (
+	// StatusFair means that the node gets a share of the upload traffic close to its share of the free capacity.
+	StatusFair = Status("fair")
+	// StatusUnderSelected means that the node gets systematically less upload traffic than its share of the free capacity.
+	StatusUnderSelected = Status("under")
+	// StatusOverSelected means that the node gets systematically more upload traffic than its share of the free capacity.
+	StatusOverSelected = Status("over")
+)
+
+// Entry contains the fairness analysis of a single node.
+type Entry struct {
+	NodeID        storj.NodeID `json:"nodeId"`
+	FreeDisk      int64        `json:"freeDisk"`
+	UploadedBytes int64        `json:"uploadedBytes"`
+	// ExpectedShare is the share of the free capacity of the analyzed nodes the node holds.
+	ExpectedShare float64 `json:"expectedShare"`
+	// ActualShare is the share of the upload traffic of the analyzed nodes the node received.
+	ActualShare float64 `json:"actualShare"`
+	// Ratio is ActualShare divided by ExpectedShare.
+	Ratio  float64 `json:"ratio"`
+	Status Status  `json:"status"`
+}
+
+// Report is the result of a fairness analysis.
+type Report struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+	Entries     []Entry   `json:"entries"`
+}
+
+// Analyze compares the share of the upload traffic of every node with free disk space
+// to its share of the free capacity. Nodes with a ratio above tolerance are flagged as
+// over-selected and nodes with a ratio below 1/tolerance as under-selected.
+//
+// The result is ordered by descending ratio. It's empty when there's no capacity or
+// upload traffic to compare.
+func Analyze(freeDisk, uploaded map[storj.NodeID]int64, tolerance float64) []Entry {
+	var totalFree, totalUploaded int64
+	for nodeID, free := range freeDisk {
+		if free <= 0 {
+			continue
+		}
+		totalFree += free
+		totalUploaded += uploaded[nodeID]
+	}
+	if totalFree == 0 || totalUploaded == 0 {
+		return nil
+	}
+
+	entries := make([]Entry, 0, len(freeDisk))
+	for nodeID, free := range freeDisk {
+		if free <= 0 {
+			continue
+		}
+
+		entry := Entry{
+			NodeID:        nodeID,
+			FreeDisk:      free,
+			UploadedBytes: uploaded[nodeID],
+			ExpectedShare: float64(free) / float64(totalFree),
+			ActualShare:   float64(uploaded[nodeID]) / float64(totalUploaded),
+			Status:        StatusFair,
+		}
+		entry.Ratio = entry.ActualShare / entry.ExpectedShare
+
+		switch {
+		case entry.Ratio > tolerance:
+			entry.Status = StatusOverSelected
+		case entry.Ratio*tolerance < 1:
+			entry.Status = StatusUnderSelected
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, k int) bool {
+		if entries[i].Ratio == entries[k].Ratio {
+			return entries[i].NodeID.Less(entries[k].NodeID)
+		}
+		return entries[i].Ratio > entries[k].Ratio
+	})
+	return entries
+}
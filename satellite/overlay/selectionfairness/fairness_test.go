@@ -0,0 +1,57 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package selectionfairness_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/overlay/selectionfairness"
+)
+
+func TestAnalyze(t *testing.T) {
+	fair, over, under, full := testrand.NodeID(), testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	entries := selectionfairness.Analyze(map[storj.NodeID]int64{
+		fair:  400,
+		over:  100,
+		under: 500,
+		full:  0,
+	}, map[storj.NodeID]int64{
+		fair: 400,
+		over: 500,
+		// traffic of nodes without free disk space isn't compared.
+		full: 1000,
+	}, 2)
+
+	require.Len(t, entries, 3)
+	for i, expected := range []struct {
+		nodeID        storj.NodeID
+		uploaded      int64
+		expectedShare float64
+		actualShare   float64
+		status        selectionfairness.Status
+	}{
+		{over, 500, 0.1, 500.0 / 900, selectionfairness.StatusOverSelected},
+		{fair, 400, 0.4, 400.0 / 900, selectionfairness.StatusFair},
+		// nodes without any traffic are included too.
+		{under, 0, 0.5, 0, selectionfairness.StatusUnderSelected},
+	} {
+		entry := entries[i]
+		require.Equal(t, expected.nodeID, entry.NodeID)
+		require.Equal(t, expected.uploaded, entry.UploadedBytes)
+		require.InDelta(t, expected.expectedShare, entry.ExpectedShare, 1e-9)
+		require.InDelta(t, expected.actualShare, entry.ActualShare, 1e-9)
+		require.InDelta(t, expected.actualShare/expected.expectedShare, entry.Ratio, 1e-9)
+		require.Equal(t, expected.status, entry.Status)
+	}
+
+	t.Run("nothing to compare", func(t *testing.T) {
+		require.Empty(t, selectionfairness.Analyze(nil, map[storj.NodeID]int64{fair: 100}, 2))
+		require.Empty(t, selectionfairness.Analyze(map[storj.NodeID]int64{fair: 100}, nil, 2))
+	})
+}
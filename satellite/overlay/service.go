@@ -4,9 +4,11 @@
 package overlay
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"time"
 
 	"github.com/zeebo/errs"
@@ -28,6 +30,9 @@ var ErrEmptyNode = errs.New("empty node ID")
 // ErrNodeNotFound is returned if a node does not exist in database.
 var ErrNodeNotFound = errs.Class("node not found")
 
+// ErrNodeExists is returned if a node already exists in database.
+var ErrNodeExists = errs.Class("node already exists")
+
 // ErrNodeOffline is returned if a nodes is offline.
 var ErrNodeOffline = errs.Class("node is offline")
 
@@ -58,6 +63,9 @@ type DB interface {
 
 	// Get looks up the node by nodeID
 	Get(ctx context.Context, nodeID storj.NodeID) (*NodeDossier, error)
+	// GetNodesByWallet returns all the nodes with the wallet, including the disqualified and exited ones.
+	// Ethereum addresses also match their lowercase, uppercase and EIP-55 checksummed forms.
+	GetNodesByWallet(ctx context.Context, wallet string) ([]*NodeDossier, error)
 	// GetNodes gets records for all specified nodes as of the given system interval. The
 	// onlineWindow is used to determine whether each node is marked as Online. The results are
 	// returned in a slice of the same length as the input nodeIDs, and each index of the returned
@@ -67,12 +75,17 @@ type DB interface {
 	// GetParticipatingNodes returns all known participating nodes (this includes all known nodes
 	// excluding nodes that have been disqualified or gracefully exited).
 	GetParticipatingNodes(ctx context.Context, onlineWindow, asOfSystemInterval time.Duration) (_ []nodeselection.SelectedNode, err error)
+	// GetNetworkStats returns aggregate statistics about the nodes that have been online
+	// within onlineWindow and are neither disqualified nor exited.
+	GetNetworkStats(ctx context.Context, onlineWindow, asOfSystemInterval time.Duration) (NetworkStats, error)
 	// UpdateReputation updates the DB columns for all reputation fields in ReputationStatus.
 	UpdateReputation(ctx context.Context, id storj.NodeID, request ReputationUpdate) error
 	// UpdateNodeInfo updates node dossier with info requested from the node itself like node type, email, wallet, capacity, and version.
 	UpdateNodeInfo(ctx context.Context, node storj.NodeID, nodeInfo *InfoResponse) (stats *NodeDossier, err error)
 	// UpdateCheckIn updates a single storagenode's check-in stats.
 	UpdateCheckIn(ctx context.Context, node NodeCheckInInfo, timestamp time.Time, config NodeSelectionConfig) (err error)
+	// UpdateCheckIns updates the check-in stats of already known nodes in a batch. Unknown nodes are skipped.
+	UpdateCheckIns(ctx context.Context, updates []CheckInUpdate) (err error)
 	// SetNodeContained updates the contained field for the node record.
 	SetNodeContained(ctx context.Context, node storj.NodeID, contained bool) (err error)
 	// SetAllContainedNodes updates the contained field for all nodes, as necessary.
@@ -81,6 +94,9 @@ type DB interface {
 	// ActiveNodesPieceCounts returns a map of node IDs to piece counts from the db.
 	// Returns only pieces for nodes that are not disqualified.
 	ActiveNodesPieceCounts(ctx context.Context) (pieceCounts map[storj.NodeID]int64, err error)
+	// VettedNodesFreeDisk returns the free disk space of the online vetted nodes,
+	// which are not disqualified, suspended or exiting.
+	VettedNodesFreeDisk(ctx context.Context, onlineWindow time.Duration) (freeDisk map[storj.NodeID]int64, err error)
 	// UpdatePieceCounts sets the piece count field for the given node IDs.
 	UpdatePieceCounts(ctx context.Context, pieceCounts map[storj.NodeID]int64) (err error)
 
@@ -112,6 +128,16 @@ type DB interface {
 	// or gracefully exited or where last_contact_success = '0001-01-01 00:00:00+00'.
 	DQNodesLastSeenBefore(ctx context.Context, cutoff time.Time, limit int) (nodeEmails map[storj.NodeID]string, count int, err error)
 
+	// GetArchivableNodes returns up to limit node IDs greater than the cursor, in ascending order,
+	// of nodes which have not contacted the satellite since cutoff and are neither disqualified nor exited.
+	GetArchivableNodes(ctx context.Context, cursor storj.NodeID, cutoff time.Time, limit int) (storj.NodeIDList, error)
+	// ArchiveNodes moves the given nodes, which still have not contacted the satellite since cutoff
+	// and are neither disqualified nor exited, from the nodes table to the archived nodes. It returns
+	// the number of archived nodes.
+	ArchiveNodes(ctx context.Context, nodeIDs storj.NodeIDList, cutoff time.Time) (archived int, err error)
+	// RestoreArchivedNode moves an archived node back to the nodes table.
+	RestoreArchivedNode(ctx context.Context, nodeID storj.NodeID) error
+
 	// TestSuspendNodeUnknownAudit suspends a storage node for unknown audits.
 	TestSuspendNodeUnknownAudit(ctx context.Context, nodeID storj.NodeID, suspendedAt time.Time) (err error)
 	// TestUnsuspendNodeUnknownAudit unsuspends a storage node for unknown audits.
@@ -142,8 +168,32 @@ type DB interface {
 	// GetNodeTags returns all nodes for a specific node.
 	GetNodeTags(ctx context.Context, id storj.NodeID) (nodeselection.NodeTags, error)
 
+	// DeleteNodeTagsBySigner deletes all tags signed by the signer.
+	DeleteNodeTagsBySigner(ctx context.Context, signer storj.NodeID) error
+
+	// GetNodeTagsByNames returns the node tags with the given names of the given nodes.
+	GetNodeTagsByNames(ctx context.Context, ids storj.NodeIDList, names []string) (map[storj.NodeID]nodeselection.NodeTags, error)
 	// GetLastIPPortByNodeTagNames gets last IP and port from nodes where node exists in node tags with a particular name.
 	GetLastIPPortByNodeTagNames(ctx context.Context, ids storj.NodeIDList, tagName []string) (lastIPPorts map[storj.NodeID]*string, err error)
+
+	// SetNodeDrain marks the node as draining, which excludes it from upload selection.
+	// When the node is already draining only the reason is updated.
+	SetNodeDrain(ctx context.Context, drain NodeDrain) error
+	// DeleteNodeDrain stops draining the node. It returns ErrNodeNotDraining when the node isn't draining.
+	DeleteNodeDrain(ctx context.Context, nodeID storj.NodeID) error
+	// GetNodeDrain returns the drain state of the node. It returns ErrNodeNotDraining when the node isn't draining.
+	GetNodeDrain(ctx context.Context, nodeID storj.NodeID) (NodeDrain, error)
+
+	// InsertNodeUptimeTransition records that the node went online or offline.
+	InsertNodeUptimeTransition(ctx context.Context, transition NodeUptimeTransition) error
+	// GetNodeUptimeTransitions returns the transitions of the node since the specified time, sorted by time.
+	// The last transition before since, when there is one, is returned first.
+	GetNodeUptimeTransitions(ctx context.Context, nodeID storj.NodeID, since time.Time) ([]NodeUptimeTransition, error)
+	// DeleteNodeUptimeTransitionsBefore deletes the transitions older than before, for at most batchSize nodes per query.
+	DeleteNodeUptimeTransitionsBefore(ctx context.Context, before time.Time, batchSize int) (deleted int64, err error)
+	// GetMissingOfflineUptimeTransitions returns, for at most limit nodes whose last transition is online but
+	// which weren't contacted successfully since before, the offline transition at the end of their onlineWindow.
+	GetMissingOfflineUptimeTransitions(ctx context.Context, before time.Time, onlineWindow time.Duration, limit int) ([]NodeUptimeTransition, error)
 }
 
 // DisqualificationReason is disqualification reason enum type.
@@ -177,6 +227,18 @@ type NodeCheckInInfo struct {
 	VersionBelowMin         bool
 }
 
+// CheckInUpdate contains the coalesced check-ins of a known node.
+type CheckInUpdate struct {
+	// Node is the info of the latest check-in.
+	Node NodeCheckInInfo
+	// LastContactSuccess is the time of the latest successful check-in, zero if there was none.
+	LastContactSuccess time.Time
+	// LastContactFailure is the time of the latest failed check-in, zero if there was none.
+	LastContactFailure time.Time
+	// SoftwareUpdateEmailSent is the time a software update email was sent, zero if none was sent.
+	SoftwareUpdateEmailSent time.Time
+}
+
 // InfoResponse contains node dossier info requested from the storage node.
 type InfoResponse struct {
 	Operator *pb.NodeOperator
@@ -191,6 +253,8 @@ type FindStorageNodesRequest struct {
 	AlreadySelected []*nodeselection.SelectedNode
 	Placement       storj.PlacementConstraint
 	Requester       storj.NodeID
+	// SelectionArm selects whether the experimental selector of the placement is used.
+	SelectionArm nodeselection.SelectionArm
 }
 
 // NodeCriteria are the requirements for selecting nodes.
@@ -272,6 +336,14 @@ type NodeStats struct {
 	Status             ReputationStatus
 }
 
+// NetworkStats contains aggregate statistics about the active nodes.
+type NetworkStats struct {
+	ActiveNodes int64
+	VettedNodes int64
+	FreeDisk    int64
+	Countries   int64
+}
+
 // NodeLastContact contains the ID, address, and timestamp.
 type NodeLastContact struct {
 	URL                storj.NodeURL
@@ -302,8 +374,10 @@ type Service struct {
 	config               Config
 
 	GeoIP                  geoip.IPToCountry
+	ASN                    geoip.IPToASN
 	UploadSelectionCache   *UploadSelectionCache
 	DownloadSelectionCache *DownloadSelectionCache
+	CheckInBuffer          *CheckInBuffer
 	LastNetFunc            LastNetFunc
 	placementDefinitions   nodeselection.PlacementDefinitions
 }
@@ -313,7 +387,7 @@ type LastNetFunc func(config NodeSelectionConfig, ip net.IP, port string) (strin
 
 // NewService returns a new Service.
 func NewService(log *zap.Logger, db DB, nodeEvents nodeevents.DB, placements nodeselection.PlacementDefinitions, satelliteAddr, satelliteName string, config Config) (*Service, error) {
-	err := config.Node.AsOfSystemTime.isValid()
+	err := config.Node.isValid()
 	if err != nil {
 		return nil, errs.Wrap(err)
 	}
@@ -326,6 +400,16 @@ func NewService(log *zap.Logger, db DB, nodeEvents nodeevents.DB, placements nod
 		}
 	}
 
+	var asn geoip.IPToASN
+	if config.GeoIP.ASNDatabase != "" {
+		asn, err = geoip.OpenMaxmindDB(config.GeoIP.ASNDatabase)
+	} else {
+		asn, err = geoip.NewMockIPToASN(config.GeoIP.MockASN)
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
 	defaultSelection := nodeselection.NodeFilters{}
 
 	if len(config.Node.UploadExcludedCountryCodes) > 0 {
@@ -341,7 +425,7 @@ func NewService(log *zap.Logger, db DB, nodeEvents nodeevents.DB, placements nod
 	}
 
 	uploadSelectionCache, err := NewUploadSelectionCache(log, db,
-		config.NodeSelectionCache.Staleness, config.Node,
+		config.NodeSelectionCache, config.Node,
 		defaultSelection, placements,
 	)
 	if err != nil {
@@ -358,6 +442,11 @@ func NewService(log *zap.Logger, db DB, nodeEvents nodeevents.DB, placements nod
 		return nil, errs.Wrap(err)
 	}
 
+	var checkInBuffer *CheckInBuffer
+	if config.CheckInBuffer.Enabled {
+		checkInBuffer = NewCheckInBuffer(log.Named("check-in-buffer"), db, config.CheckInBuffer)
+	}
+
 	return &Service{
 		log:                  log,
 		db:                   db,
@@ -368,9 +457,11 @@ func NewService(log *zap.Logger, db DB, nodeEvents nodeevents.DB, placements nod
 		config:               config,
 
 		GeoIP: geoIP,
+		ASN:   asn,
 
 		UploadSelectionCache:   uploadSelectionCache,
 		DownloadSelectionCache: downloadSelectionCache,
+		CheckInBuffer:          checkInBuffer,
 		LastNetFunc:            MaskOffLastNet,
 
 		placementDefinitions: placements,
@@ -382,12 +473,24 @@ func (service *Service) Run(ctx context.Context) error {
 	return errs.Combine(sync2.Concurrently(
 		func() error { return service.UploadSelectionCache.Run(ctx) },
 		func() error { return service.DownloadSelectionCache.Run(ctx) },
+		func() error {
+			if service.CheckInBuffer == nil {
+				return nil
+			}
+			return service.CheckInBuffer.Run(ctx)
+		},
 	)...)
 }
 
 // Close closes resources.
 func (service *Service) Close() error {
-	return service.GeoIP.Close()
+	var group errs.Group
+	if service.CheckInBuffer != nil {
+		group.Add(service.CheckInBuffer.Close())
+	}
+	group.Add(service.GeoIP.Close())
+	group.Add(service.ASN.Close())
+	return group.Err()
 }
 
 // Get looks up the provided nodeID from the overlay.
@@ -396,7 +499,14 @@ func (service *Service) Get(ctx context.Context, nodeID storj.NodeID) (_ *NodeDo
 	if nodeID.IsZero() {
 		return nil, ErrEmptyNode
 	}
-	return service.db.Get(ctx, nodeID)
+	dossier, err := service.db.Get(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if service.CheckInBuffer != nil {
+		service.CheckInBuffer.Apply(dossier)
+	}
+	return dossier, nil
 }
 
 // CachedGetOnlineNodesForGet returns a map of nodes from the download selection cache from the suppliedIDs.
@@ -418,6 +528,12 @@ func (service *Service) GetNodeIPsFromPlacement(ctx context.Context, nodeIDs []s
 	return service.DownloadSelectionCache.GetNodeIPsFromPlacement(ctx, nodeIDs, placement)
 }
 
+// GetNetworkStats returns aggregate statistics about the currently active nodes.
+func (service *Service) GetNetworkStats(ctx context.Context) (_ NetworkStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return service.db.GetNetworkStats(ctx, service.config.Node.OnlineWindow, service.config.Node.AsOfSystemTime.Interval())
+}
+
 // IsOnline checks if a node is 'online' based on the collected statistics.
 func (service *Service) IsOnline(node *NodeDossier) bool {
 	return time.Since(node.Reputation.LastContactSuccess) < service.config.Node.OnlineWindow
@@ -599,7 +715,12 @@ func (service *Service) UpdateCheckIn(ctx context.Context, node NodeCheckInInfo,
 				zap.Error(err))
 		}
 
-		return service.db.UpdateCheckIn(ctx, node, timestamp, service.config.Node)
+		err = service.db.UpdateCheckIn(ctx, node, timestamp, service.config.Node)
+		if err != nil {
+			return err
+		}
+		service.recordUptimeTransition(ctx, node.NodeID, true, timestamp)
+		return service.updateASN(ctx, node, timestamp)
 	}
 
 	lastUp, lastDown := oldInfo.Reputation.LastContactSuccess, oldInfo.Reputation.LastContactFailure
@@ -608,6 +729,16 @@ func (service *Service) UpdateCheckIn(ctx context.Context, node NodeCheckInInfo,
 		lastContact = lastDown
 	}
 
+	wasOnline := lastDown.Before(lastUp)
+	// a node which stopped checking in went offline at the end of the online
+	// window, without any failed contact.
+	wentOfflineAt := lastUp.Add(service.config.Node.OnlineWindow)
+	silentlyOffline := wasOnline && wentOfflineAt.Before(timestamp)
+	if silentlyOffline {
+		wasOnline = false
+	}
+	stateChanged := node.IsUp != wasOnline
+
 	dbStale := lastContact.Add(service.config.NodeCheckInWaitPeriod).Before(timestamp) ||
 		(node.IsUp && lastUp.Before(lastDown)) || (!node.IsUp && lastDown.Before(lastUp))
 
@@ -658,11 +789,27 @@ func (service *Service) UpdateCheckIn(ctx context.Context, node NodeCheckInInfo,
 	if dbStale || addrChanged || walletChanged || verChanged || spaceChanged ||
 		oldInfo.LastNet != node.LastNet || oldInfo.LastIPPort != node.LastIPPort ||
 		oldInfo.CountryCode != node.CountryCode || node.SoftwareUpdateEmailSent {
-		err = service.db.UpdateCheckIn(ctx, node, timestamp, service.config.Node)
+		if service.CheckInBuffer != nil {
+			err = service.CheckInBuffer.Add(node, timestamp)
+		} else {
+			err = service.db.UpdateCheckIn(ctx, node, timestamp, service.config.Node)
+		}
 		if err != nil {
 			return Error.Wrap(err)
 		}
 
+		if silentlyOffline {
+			// the offline chore may have recorded it already, which is a no-op.
+			service.recordUptimeTransition(ctx, node.NodeID, false, wentOfflineAt)
+		}
+		if stateChanged {
+			service.recordUptimeTransition(ctx, node.NodeID, node.IsUp, timestamp)
+		}
+
+		if err := service.updateASN(ctx, node, timestamp); err != nil {
+			return Error.Wrap(err)
+		}
+
 		if service.config.SendNodeEmails && node.IsUp && oldInfo.Reputation.LastContactSuccess.Add(service.config.Node.OnlineWindow).Before(timestamp) {
 			_, err = service.nodeEvents.Insert(ctx, node.Operator.Email, nil, node.NodeID, nodeevents.Online)
 			return Error.Wrap(err)
@@ -678,6 +825,44 @@ func (service *Service) UpdateCheckIn(ctx context.Context, node NodeCheckInInfo,
 	return nil
 }
 
+// updateASN records the autonomous system of the last IP address of the node as a node tag
+// with an empty signer (see nodeselection.ASNTag), so placements can use it for the node selection.
+// The tag is only written when the autonomous system differs from the recorded one.
+func (service *Service) updateASN(ctx context.Context, node NodeCheckInInfo, timestamp time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	asn, err := service.ASN.LookupASN(node.LastIPPort)
+	if err != nil {
+		mon.Meter("asn_lookup_failed").Mark(1)
+		service.log.Debug("failed to resolve autonomous system for node",
+			zap.String("node address", node.Address.Address),
+			zap.Stringer("Node ID", node.NodeID),
+			zap.Error(err))
+		return nil
+	}
+	if asn == 0 {
+		return nil
+	}
+	value := []byte(strconv.FormatUint(uint64(asn), 10))
+
+	recorded, err := service.db.GetNodeTagsByNames(ctx, storj.NodeIDList{node.NodeID}, []string{nodeselection.ASNTag})
+	if err != nil {
+		return err
+	}
+	if tag, err := recorded[node.NodeID].FindBySignerAndName(storj.NodeID{}, nodeselection.ASNTag); err == nil && bytes.Equal(tag.Value, value) {
+		return nil
+	}
+
+	return service.db.UpdateNodeTags(ctx, nodeselection.NodeTags{
+		{
+			NodeID:   node.NodeID,
+			Name:     nodeselection.ASNTag,
+			Value:    value,
+			SignedAt: timestamp,
+		},
+	})
+}
+
 // DQNodesLastSeenBefore disqualifies nodes who have not been contacted since the cutoff time.
 func (service *Service) DQNodesLastSeenBefore(ctx context.Context, cutoff time.Time, limit int) (count int, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -767,7 +952,7 @@ func ResolveIPAndNetwork(ctx context.Context, target string, config NodeSelectio
 func MaskOffLastNet(config NodeSelectionConfig, addr net.IP, port string) (string, error) {
 	if config.DistinctIP {
 		// Filter all IPv4 Addresses into /24 subnets, and filter all IPv6 Addresses into /64 subnets
-		return truncateIPToNet(addr, config.NetworkPrefixIPv4, config.NetworkPrefixIPv6)
+		return truncateIPToNet(addr, config.NetworkPrefixIPv4, config.NetworkPrefixIpv6)
 	}
 	// The "network" here will be the full IP and port; that is, every node will be considered to
 	// be on a separate network, even if they all come from one IP (such as localhost).
@@ -780,10 +965,16 @@ func truncateIPToNet(ipAddr net.IP, ipv4Cidr, ipv6Cidr int) (network string, err
 	// If addr can be converted to 4byte notation, it is an IPv4 address, else its an IPv6 address
 	if ipv4 := ipAddr.To4(); ipv4 != nil {
 		mask := net.CIDRMask(ipv4Cidr, 32)
+		if mask == nil {
+			return "", fmt.Errorf("invalid IPv4 network prefix %d", ipv4Cidr)
+		}
 		return ipv4.Mask(mask).String(), nil
 	}
 	if ipv6 := ipAddr.To16(); ipv6 != nil {
 		mask := net.CIDRMask(ipv6Cidr, 128)
+		if mask == nil {
+			return "", fmt.Errorf("invalid IPv6 network prefix %d", ipv6Cidr)
+		}
 		return ipv6.Mask(mask).String(), nil
 	}
 	return "", fmt.Errorf("unable to get network for address %s", ipAddr.String())
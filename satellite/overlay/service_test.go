@@ -773,6 +773,55 @@ func TestUpdateCheckInNodeEventOnline(t *testing.T) {
 	})
 }
 
+func TestUpdateCheckInUptimeHistory(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		service := planet.Satellites[0].Overlay.Service
+		node := planet.StorageNodes[0]
+		node.Contact.Chore.Pause(ctx)
+		planet.Satellites[0].Core.Overlay.UptimeOffline.Loop.Pause()
+
+		start := time.Now().Add(-time.Hour)
+		history, err := service.GetNodeUptimeHistory(ctx, node.ID(), start)
+		require.NoError(t, err)
+		// the node went online when it checked in first, before the test.
+		require.True(t, history.Online)
+		require.Len(t, history.Transitions, 1)
+
+		checkInInfo := getNodeInfo(node.ID())
+		checkInInfo.IsUp = false
+		require.NoError(t, service.UpdateCheckIn(ctx, checkInInfo, time.Now()))
+		// a repeated contact failure isn't a transition.
+		require.NoError(t, service.UpdateCheckIn(ctx, checkInInfo, time.Now()))
+
+		checkInInfo.IsUp = true
+		require.NoError(t, service.UpdateCheckIn(ctx, checkInInfo, time.Now()))
+
+		history, err = service.GetNodeUptimeHistory(ctx, node.ID(), start)
+		require.NoError(t, err)
+		require.True(t, history.Online)
+		require.Len(t, history.Transitions, 3)
+		require.False(t, history.Transitions[1].Online)
+		require.True(t, history.Transitions[2].Online)
+
+		// a node which stopped checking in went offline at the end of the
+		// online window, even without a failed contact.
+		lastContact := time.Now()
+		require.NoError(t, service.UpdateCheckIn(ctx, checkInInfo, lastContact))
+		onlineWindow := planet.Satellites[0].Config.Overlay.Node.OnlineWindow
+		require.NoError(t, service.UpdateCheckIn(ctx, checkInInfo, lastContact.Add(2*onlineWindow)))
+
+		history, err = service.GetNodeUptimeHistory(ctx, node.ID(), start)
+		require.NoError(t, err)
+		require.True(t, history.Online)
+		require.Len(t, history.Transitions, 5)
+		require.False(t, history.Transitions[3].Online)
+		require.WithinDuration(t, lastContact.Add(onlineWindow), history.Transitions[3].ChangedAt, time.Second)
+		require.True(t, history.Transitions[4].Online)
+	})
+}
+
 func TestUpdateCheckInBelowMinVersionEvent(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 0,
@@ -5,6 +5,7 @@ package overlay
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -25,32 +26,55 @@ type UploadSelectionDB interface {
 type UploadSelectionCacheConfig struct {
 	Disabled  bool          `help:"disable node cache" default:"false" deprecated:"true"`
 	Staleness time.Duration `help:"how stale the node selection cache can be" releaseDefault:"3m" devDefault:"5m" testDefault:"3m"`
+	// MinimumRefreshRatio protects against partial database reads: a refreshed node set
+	// smaller than this fraction of the current one is rejected and the current one is kept.
+	MinimumRefreshRatio float64 `help:"refuse to replace the node selection cache with a node set smaller than this fraction of the current one (0 disables the check)" default:"0.5" testDefault:"0"`
+	// MaxRefreshRejections makes the cache accept a legitimately shrunk node set. A smaller
+	// node set is accepted once it has been rejected this many times in a row.
+	MaxRefreshRejections int `help:"accept a node set smaller than the minimum refresh ratio after this many consecutive rejections" default:"3"`
+}
+
+// uploadSelectionSnapshot is a node selection state together with the number of nodes it was built from.
+type uploadSelectionSnapshot struct {
+	state nodeselection.State
+	size  int
 }
 
 // UploadSelectionCache keeps a list of all the storage nodes that are qualified to store data
 // We organize the nodes by if they are reputable or a new node on the network.
 // The cache will sync with the nodes table in the database and get refreshed once the staleness time has past.
+//
+// The new node set is built in the background while the current one keeps serving requests, and swapped
+// in atomically. The previous snapshot is retained, so it's possible to roll back to it.
 type UploadSelectionCache struct {
 	log             *zap.Logger
 	db              UploadSelectionDB
+	config          UploadSelectionCacheConfig
 	selectionConfig NodeSelectionConfig
 
 	cache sync2.ReadCacheOf[nodeselection.State]
 
+	mu         sync.Mutex
+	current    *uploadSelectionSnapshot
+	previous   *uploadSelectionSnapshot
+	rollback   bool
+	rejections int
+
 	defaultFilters nodeselection.NodeFilters
 	placements     nodeselection.PlacementDefinitions
 }
 
 // NewUploadSelectionCache creates a new cache that keeps a list of all the storage nodes that are qualified to store data.
-func NewUploadSelectionCache(log *zap.Logger, db UploadSelectionDB, staleness time.Duration, config NodeSelectionConfig, defaultFilter nodeselection.NodeFilters, placements nodeselection.PlacementDefinitions) (*UploadSelectionCache, error) {
+func NewUploadSelectionCache(log *zap.Logger, db UploadSelectionDB, cacheConfig UploadSelectionCacheConfig, config NodeSelectionConfig, defaultFilter nodeselection.NodeFilters, placements nodeselection.PlacementDefinitions) (*UploadSelectionCache, error) {
 	cache := &UploadSelectionCache{
 		log:             log,
 		db:              db,
+		config:          cacheConfig,
 		selectionConfig: config,
 		defaultFilters:  defaultFilter,
 		placements:      placements,
 	}
-	return cache, cache.cache.Init(staleness/2, staleness, cache.read)
+	return cache, cache.cache.Init(cacheConfig.Staleness/2, cacheConfig.Staleness, cache.read)
 }
 
 // Run runs the background task for cache.
@@ -66,22 +90,86 @@ func (cache *UploadSelectionCache) Refresh(ctx context.Context) (err error) {
 	return err
 }
 
-// refresh calls out to the database and refreshes the cache with the most up-to-date
-// data from the nodes table, then sets time that the last refresh occurred so we know when
-// to refresh again in the future.
+// Rollback replaces the current node set with the previously retained snapshot.
+// The replaced node set becomes the new previous snapshot. It's exposed on the
+// debug panel of the API process.
+func (cache *UploadSelectionCache) Rollback(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	cache.mu.Lock()
+	if cache.previous == nil {
+		cache.mu.Unlock()
+		return Error.New("no previous node selection snapshot")
+	}
+	cache.rollback = true
+	cache.mu.Unlock()
+
+	_, err = cache.cache.RefreshAndGet(ctx, time.Now())
+	return err
+}
+
+// read calls out to the database and builds a new node selection state with the most
+// up-to-date data from the nodes table. When the new node set is suspiciously smaller
+// than the current one, the current state is returned instead, unless the smaller node
+// set was already rejected MaxRefreshRejections times in a row.
 func (cache *UploadSelectionCache) read(ctx context.Context) (_ nodeselection.State, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	cache.mu.Lock()
+	if cache.rollback {
+		cache.rollback = false
+		cache.current, cache.previous = cache.previous, cache.current
+		state := cache.current.state
+		cache.mu.Unlock()
+
+		mon.Event("refresh_cache_rollback")
+		cache.log.Info("Node selection cache rolled back to the previous snapshot")
+		return state, nil
+	}
+	cache.mu.Unlock()
+
 	reputableNodes, newNodes, err := cache.db.SelectAllStorageNodesUpload(ctx, cache.selectionConfig)
 	if err != nil {
-		return nil, Error.Wrap(err)
+		return nodeselection.State{}, Error.Wrap(err)
 	}
 
 	mon.IntVal("refresh_cache_size_reputable").Observe(int64(len(reputableNodes)))
 	mon.IntVal("refresh_cache_size_new").Observe(int64(len(newNodes)))
 
 	var allNodes = append(append([]*nodeselection.SelectedNode{}, reputableNodes...), newNodes...)
+	// nodes in maintenance are expected to go offline, hence they shouldn't receive new uploads.
+	allNodes = cache.selectionConfig.Maintenance.excludeNodesInMaintenance(allNodes, time.Now())
+	// paused nodes refuse the uploads of the satellite.
+	allNodes = excludePausedNodes(allNodes)
 	state := nodeselection.NewState(allNodes, cache.placements)
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if current := cache.current; current != nil && cache.config.MinimumRefreshRatio > 0 {
+		if float64(len(allNodes)) < float64(current.size)*cache.config.MinimumRefreshRatio {
+			if cache.rejections < cache.config.MaxRefreshRejections {
+				cache.rejections++
+				mon.Event("refresh_cache_rejected")
+				cache.log.Warn("Refreshed node selection set is too small, keeping the current one",
+					zap.Int("current", current.size),
+					zap.Int("refreshed", len(allNodes)),
+					zap.Float64("minimum ratio", cache.config.MinimumRefreshRatio),
+					zap.Int("rejections", cache.rejections))
+				return current.state, nil
+			}
+
+			mon.Event("refresh_cache_shrink_accepted")
+			cache.log.Warn("Refreshed node selection set stayed too small, accepting it",
+				zap.Int("current", current.size),
+				zap.Int("refreshed", len(allNodes)),
+				zap.Int("rejections", cache.rejections))
+		}
+	}
+
+	cache.rejections = 0
+	cache.previous = cache.current
+	cache.current = &uploadSelectionSnapshot{state: state, size: len(allNodes)}
 	return state, nil
 }
 
@@ -96,7 +184,7 @@ func (cache *UploadSelectionCache) GetNodes(ctx context.Context, req FindStorage
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
-	nodes, err := state.Select(req.Requester, req.Placement, req.RequestedCount, req.ExcludedIDs, req.AlreadySelected)
+	nodes, err := state.SelectWithArm(req.SelectionArm, req.Requester, req.Placement, req.RequestedCount, req.ExcludedIDs, req.AlreadySelected)
 	if nodeselection.ErrNotEnoughNodes.Has(err) {
 		err = ErrNotEnoughNodes.Wrap(err)
 	}
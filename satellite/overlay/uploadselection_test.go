@@ -62,7 +62,7 @@ func TestRefresh(t *testing.T) {
 	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
 		cache, err := overlay.NewUploadSelectionCache(zap.NewNop(),
 			db.OverlayCache(),
-			lowStaleness,
+			overlay.UploadSelectionCacheConfig{Staleness: lowStaleness},
 			nodeSelectionConfig,
 			nodeselection.NodeFilters{},
 			nodeselection.TestPlacementDefinitions(),
@@ -159,7 +159,7 @@ func TestRefreshConcurrent(t *testing.T) {
 	mockDB := mockdb{}
 	cache, err := overlay.NewUploadSelectionCache(zap.NewNop(),
 		&mockDB,
-		highStaleness,
+		overlay.UploadSelectionCacheConfig{Staleness: highStaleness},
 		nodeSelectionConfig,
 		nodeselection.NodeFilters{},
 		nodeselection.TestPlacementDefinitions(),
@@ -186,7 +186,7 @@ func TestRefreshConcurrent(t *testing.T) {
 	mockDB = mockdb{}
 	cache, err = overlay.NewUploadSelectionCache(zap.NewNop(),
 		&mockDB,
-		lowStaleness,
+		overlay.UploadSelectionCacheConfig{Staleness: lowStaleness},
 		nodeSelectionConfig,
 		nodeselection.NodeFilters{},
 		nodeselection.TestPlacementDefinitions(),
@@ -220,7 +220,7 @@ func TestSelectNodes(t *testing.T) {
 
 		cache, err := overlay.NewUploadSelectionCache(zap.NewNop(),
 			db.OverlayCache(),
-			lowStaleness,
+			overlay.UploadSelectionCacheConfig{Staleness: lowStaleness},
 			nodeSelectionConfig,
 			nodeselection.NodeFilters{},
 			placementRules,
@@ -396,7 +396,7 @@ func TestGetNodesConcurrent(t *testing.T) {
 	}
 	cache, err := overlay.NewUploadSelectionCache(zap.NewNop(),
 		&mockDB,
-		highStaleness,
+		overlay.UploadSelectionCacheConfig{Staleness: highStaleness},
 		nodeSelectionConfig,
 		nodeselection.NodeFilters{},
 		nodeselection.TestPlacementDefinitionsWithFraction(1),
@@ -443,7 +443,7 @@ func TestGetNodesConcurrent(t *testing.T) {
 	}
 	cache, err = overlay.NewUploadSelectionCache(zap.NewNop(),
 		&mockDB,
-		lowStaleness,
+		overlay.UploadSelectionCacheConfig{Staleness: lowStaleness},
 		nodeSelectionConfig,
 		nodeselection.NodeFilters{},
 		nodeselection.TestPlacementDefinitionsWithFraction(1),
@@ -535,7 +535,7 @@ func TestGetNodesDistinct(t *testing.T) {
 		config.DistinctIP = true
 		cache, err := overlay.NewUploadSelectionCache(zap.NewNop(),
 			&mockDB,
-			highStaleness,
+			overlay.UploadSelectionCacheConfig{Staleness: highStaleness},
 			config,
 			nodeselection.NodeFilters{},
 			nodeselection.TestPlacementDefinitions(),
@@ -576,7 +576,7 @@ func TestGetNodesDistinct(t *testing.T) {
 		config.DistinctIP = false
 		cache, err := overlay.NewUploadSelectionCache(zap.NewNop(),
 			&mockDB,
-			highStaleness,
+			overlay.UploadSelectionCacheConfig{Staleness: highStaleness},
 			config,
 			nodeselection.NodeFilters{},
 			nodeselection.TestPlacementDefinitions(),
@@ -594,6 +594,134 @@ func TestGetNodesDistinct(t *testing.T) {
 	}
 }
 
+func TestRefreshMinimumRatio(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	var nodes []*nodeselection.SelectedNode
+	for i := 0; i < 10; i++ {
+		address := fmt.Sprintf("127.0.%d.1", i)
+		nodes = append(nodes, &nodeselection.SelectedNode{
+			ID:         testrand.NodeID(),
+			Address:    &pb.NodeAddress{Address: address},
+			LastNet:    fmt.Sprintf("127.0.%d", i),
+			LastIPPort: address + ":8000",
+			Vetted:     true,
+		})
+	}
+
+	mockDB := mockdb{
+		reputable: nodes,
+	}
+	cache, err := overlay.NewUploadSelectionCache(zap.NewNop(),
+		&mockDB,
+		overlay.UploadSelectionCacheConfig{
+			Staleness:            highStaleness,
+			MinimumRefreshRatio:  0.5,
+			MaxRefreshRejections: 1,
+		},
+		nodeSelectionConfig,
+		nodeselection.NodeFilters{},
+		nodeselection.TestPlacementDefinitionsWithFraction(0),
+	)
+	require.NoError(t, err)
+
+	cacheCtx, cacheCancel := context.WithCancel(ctx)
+	defer cacheCancel()
+	ctx.Go(func() error { return cache.Run(cacheCtx) })
+
+	require.Error(t, cache.Rollback(ctx), "rollback without previous snapshot")
+
+	require.NoError(t, cache.Refresh(ctx))
+
+	// a partial read shouldn't replace the current node set
+	mockDB.mu.Lock()
+	mockDB.reputable = nodes[:4]
+	mockDB.mu.Unlock()
+
+	require.NoError(t, cache.Refresh(ctx))
+	selected, err := cache.GetNodes(ctx, overlay.FindStorageNodesRequest{RequestedCount: 10})
+	require.NoError(t, err)
+	require.Len(t, selected, 10)
+
+	// a moderately smaller node set is accepted
+	mockDB.mu.Lock()
+	mockDB.reputable = nodes[:6]
+	mockDB.mu.Unlock()
+
+	require.NoError(t, cache.Refresh(ctx))
+	_, err = cache.GetNodes(ctx, overlay.FindStorageNodesRequest{RequestedCount: 10})
+	require.Error(t, err)
+
+	// rollback restores the previous node set
+	require.NoError(t, cache.Rollback(ctx))
+	selected, err = cache.GetNodes(ctx, overlay.FindStorageNodesRequest{RequestedCount: 10})
+	require.NoError(t, err)
+	require.Len(t, selected, 10)
+
+	// a smaller node set which persists is accepted after the consecutive rejections
+	mockDB.mu.Lock()
+	mockDB.reputable = nodes[:3]
+	mockDB.mu.Unlock()
+
+	require.NoError(t, cache.Refresh(ctx))
+	selected, err = cache.GetNodes(ctx, overlay.FindStorageNodesRequest{RequestedCount: 10})
+	require.NoError(t, err)
+	require.Len(t, selected, 10)
+
+	require.NoError(t, cache.Refresh(ctx))
+	selected, err = cache.GetNodes(ctx, overlay.FindStorageNodesRequest{RequestedCount: 3})
+	require.NoError(t, err)
+	require.Len(t, selected, 3)
+	_, err = cache.GetNodes(ctx, overlay.FindStorageNodesRequest{RequestedCount: 4})
+	require.Error(t, err)
+}
+
+func TestGetNodesExcludePaused(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	var nodes []*nodeselection.SelectedNode
+	for i := 0; i < 4; i++ {
+		address := fmt.Sprintf("127.0.%d.1", i)
+		nodes = append(nodes, &nodeselection.SelectedNode{
+			ID:         testrand.NodeID(),
+			Address:    &pb.NodeAddress{Address: address},
+			LastNet:    fmt.Sprintf("127.0.%d", i),
+			LastIPPort: address + ":8000",
+			Vetted:     true,
+		})
+	}
+	pausedTag := func(node *nodeselection.SelectedNode, signer storj.NodeID, value string) nodeselection.NodeTag {
+		return nodeselection.NodeTag{NodeID: node.ID, Signer: signer, Name: overlay.PausedTag, Value: []byte(value), SignedAt: time.Now()}
+	}
+	nodes[0].Tags = nodeselection.NodeTags{pausedTag(nodes[0], nodes[0].ID, "uploads")}
+	nodes[1].Tags = nodeselection.NodeTags{pausedTag(nodes[1], nodes[1].ID, "uploads,downloads")}
+	// resumed nodes and tags which aren't signed by the node don't pause it.
+	nodes[2].Tags = nodeselection.NodeTags{pausedTag(nodes[2], nodes[2].ID, "")}
+	nodes[3].Tags = nodeselection.NodeTags{pausedTag(nodes[3], testrand.NodeID(), "uploads")}
+
+	cache, err := overlay.NewUploadSelectionCache(zap.NewNop(),
+		&mockdb{reputable: nodes},
+		overlay.UploadSelectionCacheConfig{Staleness: highStaleness},
+		nodeSelectionConfig,
+		nodeselection.NodeFilters{},
+		nodeselection.TestPlacementDefinitionsWithFraction(0),
+	)
+	require.NoError(t, err)
+
+	cacheCtx, cacheCancel := context.WithCancel(ctx)
+	defer cacheCancel()
+	ctx.Go(func() error { return cache.Run(cacheCtx) })
+
+	selected, err := cache.GetNodes(ctx, overlay.FindStorageNodesRequest{RequestedCount: 2})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []storj.NodeID{nodes[2].ID, nodes[3].ID}, []storj.NodeID{selected[0].ID, selected[1].ID})
+
+	_, err = cache.GetNodes(ctx, overlay.FindStorageNodesRequest{RequestedCount: 3})
+	require.Error(t, err)
+}
+
 func TestGetNodesError(t *testing.T) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
@@ -601,7 +729,7 @@ func TestGetNodesError(t *testing.T) {
 	mockDB := mockdb{}
 	cache, err := overlay.NewUploadSelectionCache(zap.NewNop(),
 		&mockDB,
-		highStaleness,
+		overlay.UploadSelectionCacheConfig{Staleness: highStaleness},
 		nodeSelectionConfig,
 		nodeselection.NodeFilters{},
 		nodeselection.TestPlacementDefinitions(),
@@ -630,7 +758,7 @@ func TestNewNodeFraction(t *testing.T) {
 		}
 		cache, err := overlay.NewUploadSelectionCache(zap.NewNop(),
 			db.OverlayCache(),
-			lowStaleness,
+			overlay.UploadSelectionCacheConfig{Staleness: lowStaleness},
 			nodeSelectionConfig,
 			nodeselection.NodeFilters{},
 			nodeselection.TestPlacementDefinitionsWithFraction(newNodeFraction),
@@ -692,7 +820,7 @@ func BenchmarkGetNodes(b *testing.B) {
 		generatedSelectedNodes(b, oldNodes),
 		generatedSelectedNodes(b, newNodes),
 	)
-	cache, err := overlay.NewUploadSelectionCache(log, db, 10*time.Minute, overlay.NodeSelectionConfig{
+	cache, err := overlay.NewUploadSelectionCache(log, db, overlay.UploadSelectionCacheConfig{Staleness: 10 * time.Minute}, overlay.NodeSelectionConfig{
 		NewNodeFraction: 0.1,
 	}, defaultFilter, placement)
 	require.NoError(b, err)
@@ -808,6 +936,11 @@ func (m *mockdb) UpdateCheckIn(ctx context.Context, node overlay.NodeCheckInInfo
 	panic("implement me")
 }
 
+// UpdateCheckIns satisfies nodeevents.DB interface.
+func (m *mockdb) UpdateCheckIns(ctx context.Context, updates []overlay.CheckInUpdate) (err error) {
+	panic("implement me")
+}
+
 // SetNodeContained satisfies nodeevents.DB interface.
 func (m *mockdb) SetNodeContained(ctx context.Context, node storj.NodeID, contained bool) (err error) {
 	panic("implement me")
@@ -943,7 +1076,82 @@ func (m *mockdb) GetNodeTags(ctx context.Context, id storj.NodeID) (nodeselectio
 	panic("implement me")
 }
 
+// DeleteNodeTagsBySigner satisfies nodeevents.DB interface.
+func (m *mockdb) DeleteNodeTagsBySigner(ctx context.Context, signer storj.NodeID) error {
+	panic("implement me")
+}
+
+// GetNodeTagsByNames satisfies nodeevents.DB interface.
+func (m *mockdb) GetNodeTagsByNames(ctx context.Context, ids storj.NodeIDList, names []string) (map[storj.NodeID]nodeselection.NodeTags, error) {
+	panic("implement me")
+}
+
+// GetArchivableNodes implements overlay.DB.
+func (m *mockdb) GetArchivableNodes(ctx context.Context, cursor storj.NodeID, cutoff time.Time, limit int) (storj.NodeIDList, error) {
+	panic("implement me")
+}
+
+// ArchiveNodes implements overlay.DB.
+func (m *mockdb) ArchiveNodes(ctx context.Context, nodeIDs storj.NodeIDList, cutoff time.Time) (archived int, err error) {
+	panic("implement me")
+}
+
+// RestoreArchivedNode implements overlay.DB.
+func (m *mockdb) RestoreArchivedNode(ctx context.Context, nodeID storj.NodeID) error {
+	panic("implement me")
+}
+
 // GetLastIPPortByNodeTagNames gets last IP and port from nodes where node exists in node tags with a particular name.
 func (m *mockdb) GetLastIPPortByNodeTagNames(ctx context.Context, ids storj.NodeIDList, tagName []string) (lastIPPorts map[storj.NodeID]*string, err error) {
 	panic("implement me")
 }
+
+// GetNetworkStats implements overlay.DB.
+func (m *mockdb) GetNetworkStats(ctx context.Context, onlineWindow, asOfSystemInterval time.Duration) (overlay.NetworkStats, error) {
+	panic("implement me")
+}
+
+// VettedNodesFreeDisk implements overlay.DB.
+func (m *mockdb) VettedNodesFreeDisk(ctx context.Context, onlineWindow time.Duration) (map[storj.NodeID]int64, error) {
+	panic("implement me")
+}
+
+// SetNodeDrain implements overlay.DB.
+func (m *mockdb) SetNodeDrain(ctx context.Context, drain overlay.NodeDrain) error {
+	panic("implement me")
+}
+
+// DeleteNodeDrain implements overlay.DB.
+func (m *mockdb) DeleteNodeDrain(ctx context.Context, nodeID storj.NodeID) error {
+	panic("implement me")
+}
+
+// GetNodeDrain implements overlay.DB.
+func (m *mockdb) GetNodeDrain(ctx context.Context, nodeID storj.NodeID) (overlay.NodeDrain, error) {
+	panic("implement me")
+}
+
+// GetNodesByWallet implements overlay.DB.
+func (m *mockdb) GetNodesByWallet(ctx context.Context, wallet string) ([]*overlay.NodeDossier, error) {
+	panic("implement me")
+}
+
+// InsertNodeUptimeTransition implements overlay.DB.
+func (m *mockdb) InsertNodeUptimeTransition(ctx context.Context, transition overlay.NodeUptimeTransition) error {
+	panic("implement me")
+}
+
+// GetNodeUptimeTransitions implements overlay.DB.
+func (m *mockdb) GetNodeUptimeTransitions(ctx context.Context, nodeID storj.NodeID, since time.Time) ([]overlay.NodeUptimeTransition, error) {
+	panic("implement me")
+}
+
+// DeleteNodeUptimeTransitionsBefore implements overlay.DB.
+func (m *mockdb) DeleteNodeUptimeTransitionsBefore(ctx context.Context, before time.Time, batchSize int) (int64, error) {
+	panic("implement me")
+}
+
+// GetMissingOfflineUptimeTransitions implements overlay.DB.
+func (m *mockdb) GetMissingOfflineUptimeTransitions(ctx context.Context, before time.Time, onlineWindow time.Duration, limit int) ([]overlay.NodeUptimeTransition, error) {
+	panic("implement me")
+}
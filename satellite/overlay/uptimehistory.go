@@ -0,0 +1,234 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/errs2"
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+)
+
+// UptimeHistoryConfig configures the tracking of the online and offline
+// transitions of the nodes.
+type UptimeHistoryConfig struct {
+	Enabled         bool          `help:"whether the online and offline transitions of the nodes are recorded" default:"true"`
+	Retention       time.Duration `help:"how long the transitions are kept" default:"720h"`
+	CleanupInterval time.Duration `help:"how often the transitions older than the retention are deleted" default:"24h" testDefault:"$TESTINTERVAL"`
+	CleanupBatch    int           `help:"the maximum number of nodes whose transitions are deleted in a single query" default:"1000"`
+	OfflineInterval time.Duration `help:"how often the nodes which stopped checking in for the online window are recorded as offline" default:"15m" testDefault:"$TESTINTERVAL"`
+	OfflineBatch    int           `help:"the maximum number of nodes recorded as offline in a single run" default:"1000"`
+}
+
+// NodeUptimeTransition is a change of the state of a node.
+type NodeUptimeTransition struct {
+	NodeID    storj.NodeID `json:"-"`
+	ChangedAt time.Time    `json:"changedAt"`
+	Online    bool         `json:"online"`
+}
+
+// NodeUptimeHistory is the uptime of a node in a time range.
+type NodeUptimeHistory struct {
+	NodeID storj.NodeID `json:"nodeId"`
+	Since  time.Time    `json:"since"`
+	Until  time.Time    `json:"until"`
+	// Online is the state of the node at Until. It's false when the state
+	// isn't known.
+	Online bool `json:"online"`
+	// Transitions are the changes of the state during the range. The first
+	// one is the last change before Since, when it's still retained.
+	Transitions []NodeUptimeTransition `json:"transitions"`
+	// OnlineDuration and OfflineDuration are the time spent in each state
+	// during the range. The time before the first known transition isn't
+	// counted in either.
+	OnlineDuration  time.Duration `json:"onlineDuration"`
+	OfflineDuration time.Duration `json:"offlineDuration"`
+}
+
+// GetNodeUptimeHistory returns the online and offline transitions of the node
+// since the specified time, together with the time spent in each state.
+func (service *Service) GetNodeUptimeHistory(ctx context.Context, nodeID storj.NodeID, since time.Time) (_ NodeUptimeHistory, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	transitions, err := service.db.GetNodeUptimeTransitions(ctx, nodeID, since)
+	if err != nil {
+		return NodeUptimeHistory{}, Error.Wrap(err)
+	}
+
+	return NewNodeUptimeHistory(nodeID, since, time.Now(), transitions), nil
+}
+
+// NewNodeUptimeHistory summarizes the transitions of the node in [since, until).
+// The transitions must be sorted by time, as returned by DB.GetNodeUptimeTransitions.
+func NewNodeUptimeHistory(nodeID storj.NodeID, since, until time.Time, transitions []NodeUptimeTransition) NodeUptimeHistory {
+	history := NodeUptimeHistory{
+		NodeID:      nodeID,
+		Since:       since,
+		Until:       until,
+		Transitions: transitions,
+	}
+	if history.Transitions == nil {
+		history.Transitions = []NodeUptimeTransition{}
+	}
+
+	for i, transition := range transitions {
+		start := transition.ChangedAt
+		if start.Before(since) {
+			start = since
+		}
+		end := until
+		if i+1 < len(transitions) {
+			end = transitions[i+1].ChangedAt
+		}
+		if !end.After(start) {
+			continue
+		}
+		if transition.Online {
+			history.OnlineDuration += end.Sub(start)
+		} else {
+			history.OfflineDuration += end.Sub(start)
+		}
+	}
+	if len(transitions) > 0 {
+		history.Online = transitions[len(transitions)-1].Online
+	}
+	return history
+}
+
+// recordUptimeTransition records the transition of the node when it went
+// online or offline. Failing to record it doesn't fail the check-in.
+func (service *Service) recordUptimeTransition(ctx context.Context, nodeID storj.NodeID, online bool, timestamp time.Time) {
+	if !service.config.UptimeHistory.Enabled {
+		return
+	}
+
+	err := service.db.InsertNodeUptimeTransition(ctx, NodeUptimeTransition{
+		NodeID:    nodeID,
+		ChangedAt: timestamp.UTC(),
+		Online:    online,
+	})
+	if err != nil {
+		service.log.Warn("failed to record node uptime transition",
+			zap.Stringer("Node ID", nodeID), zap.Bool("online", online), zap.Error(err))
+		return
+	}
+	if online {
+		mon.Meter("node_went_online").Mark(1)
+	} else {
+		mon.Meter("node_went_offline").Mark(1)
+	}
+}
+
+// UptimeHistoryCleanup deletes the node uptime transitions older than the retention.
+//
+// architecture: Chore
+type UptimeHistoryCleanup struct {
+	log    *zap.Logger
+	db     DB
+	config UptimeHistoryConfig
+
+	Loop *sync2.Cycle
+}
+
+// NewUptimeHistoryCleanup creates a new node uptime transitions cleanup chore.
+func NewUptimeHistoryCleanup(log *zap.Logger, db DB, config UptimeHistoryConfig) *UptimeHistoryCleanup {
+	return &UptimeHistoryCleanup{
+		log:    log,
+		db:     db,
+		config: config,
+		Loop:   sync2.NewCycle(config.CleanupInterval),
+	}
+}
+
+// Run periodically deletes the expired transitions.
+func (cleanup *UptimeHistoryCleanup) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return errs2.IgnoreCanceled(cleanup.Loop.Run(ctx, func(ctx context.Context) error {
+		deleted, err := cleanup.db.DeleteNodeUptimeTransitionsBefore(ctx, time.Now().Add(-cleanup.config.Retention), cleanup.config.CleanupBatch)
+		if err != nil {
+			cleanup.log.Error("failed to delete expired node uptime transitions", zap.Error(err))
+			return nil
+		}
+		mon.IntVal("node_uptime_transitions_deleted").Observe(deleted)
+		return nil
+	}))
+}
+
+// Close stops the chore.
+func (cleanup *UptimeHistoryCleanup) Close() error {
+	cleanup.Loop.Close()
+	return nil
+}
+
+// UptimeHistoryOffline records the offline transition of the nodes which
+// stopped checking in. A check-in only records a transition when it changes
+// the state of the node, so a node which just disappears would otherwise
+// stay online in its history.
+//
+// The transition is recorded at the end of the online window of the last
+// successful contact, the same time UpdateCheckIn uses when the node comes
+// back before the chore runs.
+//
+// architecture: Chore
+type UptimeHistoryOffline struct {
+	log          *zap.Logger
+	db           DB
+	config       UptimeHistoryConfig
+	onlineWindow time.Duration
+
+	Loop *sync2.Cycle
+}
+
+// NewUptimeHistoryOffline creates a new chore recording the nodes which stopped checking in as offline.
+func NewUptimeHistoryOffline(log *zap.Logger, db DB, config UptimeHistoryConfig, onlineWindow time.Duration) *UptimeHistoryOffline {
+	return &UptimeHistoryOffline{
+		log:          log,
+		db:           db,
+		config:       config,
+		onlineWindow: onlineWindow,
+		Loop:         sync2.NewCycle(config.OfflineInterval),
+	}
+}
+
+// Run periodically records the missing offline transitions.
+func (chore *UptimeHistoryOffline) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return errs2.IgnoreCanceled(chore.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := chore.RecordOffline(ctx, time.Now()); err != nil {
+			chore.log.Error("failed to record offline node uptime transitions", zap.Error(err))
+		}
+		return nil
+	}))
+}
+
+// RecordOffline records the offline transitions of at most one batch of
+// nodes which weren't contacted successfully since now minus the online window.
+func (chore *UptimeHistoryOffline) RecordOffline(ctx context.Context, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	transitions, err := chore.db.GetMissingOfflineUptimeTransitions(ctx, now.Add(-chore.onlineWindow), chore.onlineWindow, chore.config.OfflineBatch)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	for _, transition := range transitions {
+		if err := chore.db.InsertNodeUptimeTransition(ctx, transition); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	mon.Meter("node_went_offline").Mark(len(transitions))
+	return nil
+}
+
+// Close stops the chore.
+func (chore *UptimeHistoryOffline) Close() error {
+	chore.Loop.Close()
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/overlay"
+)
+
+func TestNewNodeUptimeHistory(t *testing.T) {
+	nodeID := testrand.NodeID()
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(7 * 24 * time.Hour)
+
+	t.Run("no transitions", func(t *testing.T) {
+		history := overlay.NewNodeUptimeHistory(nodeID, since, until, nil)
+		require.Equal(t, nodeID, history.NodeID)
+		require.False(t, history.Online)
+		require.NotNil(t, history.Transitions)
+		require.Zero(t, history.OnlineDuration)
+		require.Zero(t, history.OfflineDuration)
+	})
+
+	t.Run("transition before since", func(t *testing.T) {
+		history := overlay.NewNodeUptimeHistory(nodeID, since, until, []overlay.NodeUptimeTransition{
+			{NodeID: nodeID, ChangedAt: since.Add(-24 * time.Hour), Online: true},
+			{NodeID: nodeID, ChangedAt: since.Add(58 * time.Hour), Online: false},
+			{NodeID: nodeID, ChangedAt: since.Add(60*time.Hour + 30*time.Minute), Online: true},
+		})
+		require.True(t, history.Online)
+		require.Len(t, history.Transitions, 3)
+		require.Equal(t, 165*time.Hour+30*time.Minute, history.OnlineDuration)
+		require.Equal(t, 2*time.Hour+30*time.Minute, history.OfflineDuration)
+	})
+
+	t.Run("unknown state before the first transition", func(t *testing.T) {
+		history := overlay.NewNodeUptimeHistory(nodeID, since, until, []overlay.NodeUptimeTransition{
+			{NodeID: nodeID, ChangedAt: since.Add(24 * time.Hour), Online: false},
+		})
+		require.False(t, history.Online)
+		require.Zero(t, history.OnlineDuration)
+		require.Equal(t, 6*24*time.Hour, history.OfflineDuration)
+	})
+}
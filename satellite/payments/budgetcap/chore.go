@@ -0,0 +1,226 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package budgetcap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+	"storj.io/common/uuid"
+	"storj.io/storj/private/post"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/mailservice"
+	"storj.io/storj/satellite/payments"
+)
+
+var (
+	// Error is the standard error class for budget cap errors.
+	Error = errs.Class("budget-cap-chore")
+	mon   = monkit.Package()
+)
+
+// Config contains configurable values for the budget cap chore.
+type Config struct {
+	Enabled       bool          `help:"whether to run this chore." default:"false"`
+	Interval      time.Duration `help:"how often to estimate the spend of projects with a budget cap" default:"1h"`
+	FreezeUploads bool          `help:"whether uploads of a project are frozen when its budget cap is reached" default:"true"`
+	FreezeEgress  bool          `help:"whether egress of a project is frozen when its budget cap is reached" default:"false"`
+	EmailsEnabled bool          `help:"whether to notify project owners when the spend reaches a threshold of the budget cap" default:"false"`
+	ListLimit     int           `help:"how many budget caps to process in a batch" default:"100"`
+}
+
+// Chore estimates the current month spend of projects with a budget cap from their usage
+// and pricing, notifies the owners when thresholds are reached and freezes the projects
+// which reached their cap.
+//
+// architecture: Chore
+type Chore struct {
+	log         *zap.Logger
+	consoleDB   console.DB
+	payments    payments.Accounts
+	mailService *mailservice.Service
+	config      Config
+
+	externalAddress   string
+	generalRequestURL string
+
+	nowFn func() time.Time
+	Loop  *sync2.Cycle
+}
+
+// NewChore is a constructor for Chore.
+func NewChore(log *zap.Logger, consoleDB console.DB, payments payments.Accounts, mailService *mailservice.Service, config Config, externalAddress, generalRequestURL string) *Chore {
+	if !strings.HasSuffix(externalAddress, "/") {
+		externalAddress += "/"
+	}
+	return &Chore{
+		log:               log,
+		consoleDB:         consoleDB,
+		payments:          payments,
+		mailService:       mailService,
+		config:            config,
+		externalAddress:   externalAddress,
+		generalRequestURL: generalRequestURL,
+		nowFn:             time.Now,
+		Loop:              sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run runs the chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, func(ctx context.Context) (err error) {
+		if err := chore.checkBudgetCaps(ctx); err != nil {
+			chore.log.Error("failed to check project budget caps", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+func (chore *Chore) checkBudgetCaps(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	now := chore.nowFn()
+
+	// the charges are computed for all projects of the owner, hence they're reused within a cycle.
+	charges := make(map[uuid.UUID]payments.ProjectChargesResponse)
+
+	var cursor uuid.UUID
+	for {
+		budgetCaps, err := chore.consoleDB.ProjectBudgetCaps().List(ctx, cursor, chore.config.ListLimit)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		for i := range budgetCaps {
+			budgetCap := &budgetCaps[i]
+			if err := chore.checkBudgetCap(ctx, budgetCap, charges, now); err != nil {
+				chore.log.Error("failed to check project budget cap",
+					zap.Stringer("project", budgetCap.ProjectID), zap.Error(err))
+			}
+		}
+
+		if len(budgetCaps) < chore.config.ListLimit {
+			return nil
+		}
+		cursor = budgetCaps[len(budgetCaps)-1].ProjectID
+	}
+}
+
+func (chore *Chore) checkBudgetCap(ctx context.Context, budgetCap *console.ProjectBudgetCap, charges map[uuid.UUID]payments.ProjectChargesResponse, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	projects := chore.consoleDB.Projects()
+	budgetCaps := chore.consoleDB.ProjectBudgetCaps()
+
+	periodStart := console.BudgetCapPeriodStart(now)
+	if budgetCap.PeriodStart.Before(periodStart) {
+		// a new billing period started, the spend starts from zero again.
+		if err := console.UnfreezeProjectBudgetCap(ctx, projects, budgetCaps, budgetCap); err != nil {
+			return err
+		}
+		budgetCap.NotifiedPercent = 0
+		budgetCap.PeriodStart = periodStart
+		if err := budgetCaps.Upsert(ctx, *budgetCap); err != nil {
+			return Error.Wrap(err)
+		}
+		mon.Counter("budget_cap_period_reset").Inc(1)
+	}
+
+	project, err := projects.Get(ctx, budgetCap.ProjectID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	ownerCharges, ok := charges[project.OwnerID]
+	if !ok {
+		ownerCharges, err = chore.payments.ProjectCharges(ctx, project.OwnerID, periodStart, now)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		charges[project.OwnerID] = ownerCharges
+	}
+
+	var spend int64
+	for _, charge := range ownerCharges[project.PublicID] {
+		spend += charge.StorageMBMonthCents + charge.EgressMBCents + charge.SegmentMonthCents
+	}
+	percent := int(spend * 100 / budgetCap.CapCents)
+
+	threshold := 0
+	for _, t := range console.BudgetCapThresholds {
+		if percent >= t {
+			threshold = t
+		}
+	}
+
+	if threshold >= 100 && !budgetCap.Frozen() && (chore.config.FreezeUploads || chore.config.FreezeEgress) {
+		err = console.FreezeProjectBudgetCap(ctx, projects, budgetCaps, project, budgetCap, chore.config.FreezeUploads, chore.config.FreezeEgress, now)
+		if err != nil {
+			return err
+		}
+		chore.log.Info("project budget cap reached, project frozen",
+			zap.Stringer("project", project.ID), zap.Int64("spend", spend), zap.Int64("cap", budgetCap.CapCents))
+		mon.Counter("budget_cap_freeze").Inc(1)
+	}
+
+	if threshold <= budgetCap.NotifiedPercent {
+		return nil
+	}
+
+	if chore.config.EmailsEnabled {
+		if err := chore.notify(ctx, project, budgetCap, threshold); err != nil {
+			return err
+		}
+	}
+
+	budgetCap.NotifiedPercent = threshold
+	return Error.Wrap(budgetCaps.Upsert(ctx, *budgetCap))
+}
+
+func (chore *Chore) notify(ctx context.Context, project *console.Project, budgetCap *console.ProjectBudgetCap, threshold int) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	owner, err := chore.consoleDB.Users().Get(ctx, project.OwnerID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var frozenActions []string
+	if chore.config.FreezeUploads {
+		frozenActions = append(frozenActions, "uploads")
+	}
+	if chore.config.FreezeEgress {
+		frozenActions = append(frozenActions, "downloads")
+	}
+
+	chore.mailService.SendRenderedAsync(ctx, []post.Address{{Address: owner.Email}}, &console.BudgetCapNotificationEmail{
+		ProjectName:   project.Name,
+		Cap:           fmt.Sprintf("$%d.%02d", budgetCap.CapCents/100, budgetCap.CapCents%100),
+		Percent:       threshold,
+		Frozen:        budgetCap.Frozen(),
+		FrozenActions: strings.Join(frozenActions, " and "),
+		SignInLink:    chore.externalAddress + "login",
+		SupportLink:   chore.generalRequestURL,
+	})
+	return nil
+}
+
+// TestSetNow sets nowFn on chore for testing.
+func (chore *Chore) TestSetNow(f func() time.Time) {
+	chore.nowFn = f
+}
+
+// Close closes the chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
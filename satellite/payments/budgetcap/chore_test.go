@@ -0,0 +1,147 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package budgetcap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/console"
+)
+
+func TestBudgetCapChore(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.BudgetCap.Enabled = true
+				config.BudgetCap.FreezeUploads = true
+				config.BudgetCap.FreezeEgress = false
+				config.Console.BudgetCaps.Enabled = true
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+		budgetCaps := sat.DB.Console().ProjectBudgetCaps()
+		chore := sat.Core.Payments.BudgetCap
+
+		chore.Loop.Pause()
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "user@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		project, err := sat.AddProject(ctx, user.ID, "test")
+		require.NoError(t, err)
+
+		userCtx, err := sat.UserContext(ctx, user.ID)
+		require.NoError(t, err)
+
+		now := time.Now().UTC()
+		periodStart := console.BudgetCapPeriodStart(now)
+		chore.TestSetNow(func() time.Time { return now })
+
+		budgetCap, err := service.GetProjectBudgetCap(userCtx, project.PublicID)
+		require.NoError(t, err)
+		require.Nil(t, budgetCap)
+
+		require.True(t, console.ErrValidation.Has(service.SetProjectBudgetCap(userCtx, project.PublicID, 0)))
+
+		// $10, egress is priced at $45/TB in tests.
+		require.NoError(t, service.SetProjectBudgetCap(userCtx, project.PublicID, 1000))
+
+		addEgress := func(amount memory.Size) {
+			err := sat.DB.Orders().UpdateBucketBandwidthSettle(ctx, project.ID, []byte("testbucket"),
+				pb.PieceAction_GET, amount.Int64(), 0, periodStart)
+			require.NoError(t, err)
+		}
+
+		// $4.50 spent, no threshold reached.
+		addEgress(100 * memory.GB)
+		chore.Loop.TriggerWait()
+
+		budgetCap, err = budgetCaps.Get(ctx, project.ID)
+		require.NoError(t, err)
+		require.Zero(t, budgetCap.NotifiedPercent)
+		require.False(t, budgetCap.Frozen())
+
+		// $9.00 spent, the 80% threshold is reached.
+		addEgress(100 * memory.GB)
+		chore.Loop.TriggerWait()
+
+		budgetCap, err = budgetCaps.Get(ctx, project.ID)
+		require.NoError(t, err)
+		require.Equal(t, 80, budgetCap.NotifiedPercent)
+		require.False(t, budgetCap.Frozen())
+
+		// $13.50 spent, the cap is reached and uploads are frozen.
+		addEgress(100 * memory.GB)
+		chore.Loop.TriggerWait()
+
+		budgetCap, err = budgetCaps.Get(ctx, project.ID)
+		require.NoError(t, err)
+		require.Equal(t, 100, budgetCap.NotifiedPercent)
+		require.True(t, budgetCap.Frozen())
+		require.Nil(t, budgetCap.FrozenStorageLimit)
+
+		frozen, err := sat.DB.Console().Projects().Get(ctx, project.ID)
+		require.NoError(t, err)
+		require.NotNil(t, frozen.UserSpecifiedStorageLimit)
+		require.Zero(t, *frozen.UserSpecifiedStorageLimit)
+		require.Nil(t, frozen.UserSpecifiedBandwidthLimit)
+
+		// limits can't be raised while the project is frozen.
+		err = service.UpdateUserSpecifiedLimits(userCtx, project.PublicID, console.UpdateLimitsInfo{
+			StorageLimit: new(memory.Size),
+		})
+		require.True(t, console.ErrConflict.Has(err))
+
+		// raising the cap unfreezes the project.
+		require.NoError(t, service.SetProjectBudgetCap(userCtx, project.PublicID, 2000))
+
+		budgetCap, err = service.GetProjectBudgetCap(userCtx, project.PublicID)
+		require.NoError(t, err)
+		require.EqualValues(t, 2000, budgetCap.CapCents)
+		require.Zero(t, budgetCap.NotifiedPercent)
+		require.False(t, budgetCap.Frozen())
+
+		unfrozen, err := sat.DB.Console().Projects().Get(ctx, project.ID)
+		require.NoError(t, err)
+		require.Nil(t, unfrozen.UserSpecifiedStorageLimit)
+
+		// reaching the cap again freezes the project until the next billing period.
+		addEgress(200 * memory.GB)
+		chore.Loop.TriggerWait()
+
+		budgetCap, err = budgetCaps.Get(ctx, project.ID)
+		require.NoError(t, err)
+		require.True(t, budgetCap.Frozen())
+
+		chore.TestSetNow(func() time.Time { return periodStart.AddDate(0, 1, 1) })
+		chore.Loop.TriggerWait()
+
+		budgetCap, err = budgetCaps.Get(ctx, project.ID)
+		require.NoError(t, err)
+		require.False(t, budgetCap.Frozen())
+		require.Zero(t, budgetCap.NotifiedPercent)
+		require.Equal(t, periodStart.AddDate(0, 1, 0), budgetCap.PeriodStart.UTC())
+
+		require.NoError(t, service.RemoveProjectBudgetCap(userCtx, project.PublicID))
+
+		budgetCap, err = service.GetProjectBudgetCap(userCtx, project.PublicID)
+		require.NoError(t, err)
+		require.Nil(t, budgetCap)
+	})
+}
@@ -86,6 +86,7 @@ func TestSignupCouponCodes(t *testing.T) {
 			db.ProjectAccounting(),
 			projectUsage,
 			sat.API.Buckets.Service,
+			sat.Metabase.DB,
 			paymentsService.Accounts(),
 			// TODO: do we need a payment deposit wallet here?
 			nil,
@@ -52,6 +52,8 @@ const (
 	storageInvoiceItemDesc = " - Storage (MB-Month)"
 	egressInvoiceItemDesc  = " - Egress Bandwidth (MB)"
 	segmentInvoiceItemDesc = " - Segment Fee (Segment-Month)"
+
+	metadataInvoiceItemDesc = " - Metadata Storage (MB-Month)"
 )
 
 // Config stores needed information for payment service initialization.
@@ -65,6 +67,7 @@ type Config struct {
 	MaxParallelCalls       int    `help:"the maximum number of concurrent Stripe API calls in invoicing methods" default:"10"`
 	RemoveExpiredCredit    bool   `help:"whether to remove expired package credit or not" default:"true"`
 	UseIdempotency         bool   `help:"whether to use idempotency for create/update requests" default:"false"`
+	InvoiceMetadata        bool   `help:"whether to invoice the stored object metadata at the storage price" default:"false"`
 	Retries                RetryConfig
 }
 
@@ -103,6 +106,7 @@ type Service struct {
 	maxParallelCalls     int
 	removeExpiredCredit  bool
 	useIdempotency       bool
+	invoiceMetadata      bool
 	deleteAccountEnabled bool
 	nowFn                func() time.Time
 }
@@ -137,6 +141,7 @@ func NewService(log *zap.Logger, stripeClient Client, config Config, db DB, wall
 		maxParallelCalls:       config.MaxParallelCalls,
 		removeExpiredCredit:    config.RemoveExpiredCredit,
 		useIdempotency:         config.UseIdempotency,
+		invoiceMetadata:        config.InvoiceMetadata,
 		deleteAccountEnabled:   deleteAccountEnabled,
 		nowFn:                  time.Now,
 	}, nil
@@ -995,6 +1000,14 @@ func (service *Service) InvoiceItemsFromProjectUsage(projName string, partnerUsa
 		segmentPrice, _ := priceModel.SegmentMonthCents.Float64()
 		projectItem.UnitAmountDecimal = stripe.Float64(segmentPrice)
 		result = append(result, projectItem)
+
+		if service.invoiceMetadata {
+			projectItem = &stripe.InvoiceItemParams{}
+			projectItem.Description = stripe.String(prefix + metadataInvoiceItemDesc)
+			projectItem.Quantity = stripe.Int64(storageMBMonthDecimal(usage.MetadataSize).IntPart())
+			projectItem.UnitAmountDecimal = stripe.Float64(storagePrice)
+			result = append(result, projectItem)
+		}
 	}
 
 	service.log.Info("invoice items", zap.Any("result", result))
@@ -917,6 +917,41 @@ func TestService_InvoiceItemsFromProjectUsage(t *testing.T) {
 	})
 }
 
+func TestService_InvoiceItemsFromProjectUsage_Metadata(t *testing.T) {
+	const (
+		projectName         = "my-project"
+		hoursPerMonth       = 24 * 30
+		byteHoursPerMBMonth = hoursPerMonth * int64(memory.MB/memory.B)
+	)
+
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Payments.StripeCoinPayments.InvoiceMetadata = true
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		usage := map[string]accounting.ProjectUsage{
+			"": {
+				Storage:      10000000000, // Byte-hours
+				MetadataSize: 5000000000,  // Byte-hours
+			},
+		}
+
+		service := planet.Satellites[0].API.Payments.StripeService
+		items := service.InvoiceItemsFromProjectUsage(projectName, usage, false)
+		require.Len(t, items, 4)
+
+		item := items[3]
+		require.Equal(t, "Project "+projectName+" - Metadata Storage (MB-Month)", *item.Description)
+		require.Equal(t, int64(math.Round(usage[""].MetadataSize/float64(byteHoursPerMBMonth))), *item.Quantity)
+
+		storage, _ := service.Accounts().GetProjectUsagePriceModel("").StorageMBMonthCents.Float64()
+		require.Equal(t, storage, *item.UnitAmountDecimal)
+	})
+}
+
 func TestService_PayInvoiceFromTokenBalance(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
@@ -21,6 +21,7 @@ import (
 	"storj.io/storj/private/post"
 	"storj.io/storj/private/post/oauth2"
 	"storj.io/storj/private/server"
+	"storj.io/storj/private/tracing"
 	version_checker "storj.io/storj/private/version/checker"
 	"storj.io/storj/satellite/accounting"
 	"storj.io/storj/satellite/accounting/live"
@@ -28,11 +29,15 @@ import (
 	"storj.io/storj/satellite/accounting/rollup"
 	"storj.io/storj/satellite/accounting/rolluparchive"
 	"storj.io/storj/satellite/accounting/tally"
+	"storj.io/storj/satellite/accounting/usagewebhook"
+	"storj.io/storj/satellite/accounting/warehouse"
 	"storj.io/storj/satellite/admin"
 	"storj.io/storj/satellite/analytics"
 	"storj.io/storj/satellite/attribution"
 	"storj.io/storj/satellite/audit"
+	"storj.io/storj/satellite/bucketevents"
 	"storj.io/storj/satellite/buckets"
+	"storj.io/storj/satellite/canary"
 	"storj.io/storj/satellite/compensation"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/console/consoleauth"
@@ -51,20 +56,29 @@ import (
 	"storj.io/storj/satellite/kms"
 	"storj.io/storj/satellite/mailservice"
 	"storj.io/storj/satellite/mailservice/simulate"
+	"storj.io/storj/satellite/metabase/deferreddeletion"
+	"storj.io/storj/satellite/metabase/metadatarotation"
+	"storj.io/storj/satellite/metabase/orphanedsegments"
 	"storj.io/storj/satellite/metabase/rangedloop"
 	"storj.io/storj/satellite/metabase/zombiedeletion"
 	"storj.io/storj/satellite/metainfo"
 	"storj.io/storj/satellite/metainfo/expireddeletion"
+	"storj.io/storj/satellite/metainfo/lifecycledeletion"
+	"storj.io/storj/satellite/netstats"
 	"storj.io/storj/satellite/nodeapiversion"
 	"storj.io/storj/satellite/nodeevents"
 	"storj.io/storj/satellite/nodeselection"
 	"storj.io/storj/satellite/oidc"
 	"storj.io/storj/satellite/orders"
 	"storj.io/storj/satellite/overlay"
+	"storj.io/storj/satellite/overlay/attestation"
+	"storj.io/storj/satellite/overlay/deadnodes"
 	"storj.io/storj/satellite/overlay/offlinenodes"
+	"storj.io/storj/satellite/overlay/selectionfairness"
 	"storj.io/storj/satellite/overlay/straynodes"
 	"storj.io/storj/satellite/payments/accountfreeze"
 	"storj.io/storj/satellite/payments/billing"
+	"storj.io/storj/satellite/payments/budgetcap"
 	"storj.io/storj/satellite/payments/paymentsconfig"
 	"storj.io/storj/satellite/payments/storjscan"
 	"storj.io/storj/satellite/payments/stripe"
@@ -73,7 +87,9 @@ import (
 	"storj.io/storj/satellite/repair/repairer"
 	"storj.io/storj/satellite/reputation"
 	"storj.io/storj/satellite/revocation"
+	"storj.io/storj/satellite/sla"
 	"storj.io/storj/satellite/snopayouts"
+	"storj.io/storj/satellite/uplinktelemetry"
 	"storj.io/storj/shared/tagsql"
 )
 
@@ -132,6 +148,18 @@ type DB interface {
 	Wallets() storjscan.WalletsDB
 	// SNOPayouts returns database for payouts.
 	SNOPayouts() snopayouts.DB
+	// SLA returns database for the daily SLA records of placements.
+	SLA() sla.DB
+	// SelectionFairness returns database for the node selection fairness report.
+	SelectionFairness() selectionfairness.DB
+	// AttestationIssuers returns database for the accepted node attestation issuers.
+	AttestationIssuers() attestation.DB
+	// ZombieDeletion returns database for the zombie deletion settings of projects and buckets.
+	ZombieDeletion() zombiedeletion.DB
+	// BucketEvents returns database for the bucket event destinations and the unpublished delete events.
+	BucketEvents() bucketevents.DB
+	// WarehouseExports returns database for the progress of exporting the rollups to a data warehouse.
+	WarehouseExports() warehouse.DB
 	// Compensation tracks storage node compensation
 	Compensation() compensation.DB
 	// Revocation tracks revoked macaroons
@@ -165,19 +193,25 @@ type Config struct {
 	Server   server.Config
 	Debug    debug.Config
 
+	TraceExemplars tracing.ExemplarsConfig
+
 	Placement nodeselection.ConfigurablePlacementRule `help:"detailed placement rules in the form 'id:definition;id:definition;...' where id is a 16 bytes integer (use >10 for backward compatibility), definition is a combination of the following functions:country(2 letter country codes,...), tag(nodeId, key, bytes(value)) all(...,...)."`
 
 	Admin admin.Config
 
-	Contact      contact.Config
-	Overlay      overlay.Config
-	OfflineNodes offlinenodes.Config
-	NodeEvents   nodeevents.Config
-	StrayNodes   straynodes.Config
+	Contact           contact.Config
+	Overlay           overlay.Config
+	OfflineNodes      offlinenodes.Config
+	NodeEvents        nodeevents.Config
+	StrayNodes        straynodes.Config
+	SelectionFairness selectionfairness.Config
+	DeadNodes         deadnodes.Config
 
 	Metainfo metainfo.Config
 	Orders   orders.Config
 
+	Attribution attribution.Config
+
 	Userinfo userinfo.Config
 
 	Reputation reputation.Config
@@ -193,14 +227,21 @@ type Config struct {
 
 	RangedLoop rangedloop.Config
 
-	ExpiredDeletion expireddeletion.Config
-	ZombieDeletion  zombiedeletion.Config
+	ExpiredDeletion   expireddeletion.Config
+	ZombieDeletion    zombiedeletion.Config
+	DeferredDeletion  deferreddeletion.Config
+	LifecycleDeletion lifecycledeletion.Config
+	OrphanedSegments  orphanedsegments.Config
+	MetadataRotation  metadatarotation.Config
+	BucketEvents      bucketevents.Config
 
 	Tally            tally.Config
 	Rollup           rollup.Config
 	RollupArchive    rolluparchive.Config
 	LiveAccounting   live.Config
 	ProjectBWCleanup projectbwcleanup.Config
+	UsageWebhook     usagewebhook.Config
+	WarehouseExport  warehouse.Config
 
 	Mail mailservice.Config
 
@@ -214,8 +255,16 @@ type Config struct {
 
 	Emission emission.Config
 
+	NetworkStats netstats.Config
+
 	AccountFreeze accountfreeze.Config
 
+	BudgetCap budgetcap.Config
+
+	SLA sla.Config
+
+	Canary canary.Config
+
 	Version version_checker.Config
 
 	GracefulExit gracefulexit.Config
@@ -232,6 +281,8 @@ type Config struct {
 
 	HealthCheck healthcheck.Config
 
+	UplinkTelemetry uplinktelemetry.Config
+
 	TagAuthorities string `help:"comma-separated paths of additional cert files, used to validate signed node tags"`
 
 	SeparateConsoleAPI bool `help:"indicates whether the console API should be split out from satellite API" default:"false"`
@@ -23,10 +23,12 @@ import (
 	"storj.io/storj/satellite/durability"
 	"storj.io/storj/satellite/gc/piecetracker"
 	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/orphanedsegments"
 	"storj.io/storj/satellite/metabase/rangedloop"
 	"storj.io/storj/satellite/metrics"
 	"storj.io/storj/satellite/nodeselection"
 	"storj.io/storj/satellite/overlay"
+	"storj.io/storj/satellite/overlay/deadnodes"
 	"storj.io/storj/satellite/repair/checker"
 )
 
@@ -69,6 +71,14 @@ type RangedLoop struct {
 		Observer *piecetracker.Observer
 	}
 
+	DeadNodes struct {
+		Observer *deadnodes.Observer
+	}
+
+	OrphanedSegments struct {
+		Observer *orphanedsegments.Observer
+	}
+
 	DurabilityReport struct {
 		Observer []*durability.Report
 	}
@@ -131,6 +141,23 @@ func NewRangedLoop(log *zap.Logger, db DB, metabaseDB *metabase.DB, config *Conf
 		)
 	}
 
+	{ // setup dead nodes observer
+		peer.DeadNodes.Observer = deadnodes.NewObserver(
+			log.Named("deadnodes"),
+			metabaseDB,
+			peer.DB.OverlayCache(),
+			config.DeadNodes,
+		)
+	}
+
+	{ // setup orphaned segments observer
+		peer.OrphanedSegments.Observer = orphanedsegments.NewObserver(
+			log.Named("orphanedsegments"),
+			metabaseDB,
+			config.OrphanedSegments,
+		)
+	}
+
 	{ // setup overlay
 		placement, err := config.Placement.Parse(config.Overlay.Node.CreateDefaultPlacement, nil)
 		if err != nil {
@@ -179,6 +206,7 @@ func NewRangedLoop(log *zap.Logger, db DB, metabaseDB *metabase.DB, config *Conf
 		peer.Repair.Observer = checker.NewObserver(
 			peer.Log.Named("repair:checker"),
 			peer.DB.RepairQueue(),
+			metabaseDB,
 			peer.Overlay.Service,
 			placement,
 			config.Checker,
@@ -209,6 +237,14 @@ func NewRangedLoop(log *zap.Logger, db DB, metabaseDB *metabase.DB, config *Conf
 			observers = append(observers, peer.PieceTracker.Observer)
 		}
 
+		if config.DeadNodes.Enabled {
+			observers = append(observers, peer.DeadNodes.Observer)
+		}
+
+		if config.OrphanedSegments.Enabled {
+			observers = append(observers, peer.OrphanedSegments.Observer)
+		}
+
 		if config.DurabilityReport.Enabled {
 			sequenceObservers := []rangedloop.Observer{}
 			for _, observer := range peer.DurabilityReport.Observer {
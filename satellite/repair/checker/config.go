@@ -28,6 +28,7 @@ type Config struct {
 	RepairExcludedCountryCodes []string `help:"list of country codes to treat node from this country as offline " default:"" hidden:"true"`
 	DoDeclumping               bool     `help:"Treat pieces on the same network as in need of repair" default:"true"`
 	DoPlacementCheck           bool     `help:"Treat pieces out of segment placement as in need of repair" default:"true"`
+	LowHealthSegmentsLimit     int      `help:"number of segments with the lowest stored healthy piece count which are checked at the start of each iteration, 0 disables storing the count" default:"0"`
 }
 
 // RepairThresholdOverrides override values for repair threshold.
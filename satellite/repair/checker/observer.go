@@ -6,6 +6,7 @@ package checker
 import (
 	"context"
 	"fmt"
+	"math"
 	"reflect"
 	"sort"
 	"strconv"
@@ -21,6 +22,7 @@ import (
 	"storj.io/common/storj"
 	"storj.io/common/storj/location"
 	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/metabase/rangedloop"
 	"storj.io/storj/satellite/nodeselection"
 	"storj.io/storj/satellite/overlay"
@@ -33,12 +35,17 @@ var (
 	_ rangedloop.Partial  = (*observerFork)(nil)
 )
 
+// lowHealthMargin is the number of healthy pieces above the repair threshold up to which
+// the healthy piece count of a segment is stored in the metabase.
+const lowHealthMargin = 5
+
 // Observer implements the ranged loop Observer interface.
 //
 // architecture: Observer
 type Observer struct {
 	logger                   *zap.Logger
 	repairQueue              queue.RepairQueue
+	metabase                 *metabase.DB
 	nodesCache               *ReliabilityCache
 	overlayService           *overlay.Service
 	repairThresholdOverrides RepairThresholdOverrides
@@ -48,6 +55,7 @@ type Observer struct {
 	doDeclumping             bool
 	doPlacementCheck         bool
 	placements               nodeselection.PlacementDefinitions
+	lowHealthSegmentsLimit   int
 
 	// the following are reset on each iteration
 	startTime  time.Time
@@ -63,7 +71,7 @@ type redundancyStyle struct {
 }
 
 // NewObserver creates new checker observer instance.
-func NewObserver(logger *zap.Logger, repairQueue queue.RepairQueue, overlay *overlay.Service, placements nodeselection.PlacementDefinitions, config Config) *Observer {
+func NewObserver(logger *zap.Logger, repairQueue queue.RepairQueue, metabaseDB *metabase.DB, overlay *overlay.Service, placements nodeselection.PlacementDefinitions, config Config) *Observer {
 	excludedCountryCodes := make(map[location.CountryCode]struct{})
 	for _, countryCode := range config.RepairExcludedCountryCodes {
 		if cc := location.ToCountryCode(countryCode); cc != location.None {
@@ -80,6 +88,7 @@ func NewObserver(logger *zap.Logger, repairQueue queue.RepairQueue, overlay *ove
 		logger: logger,
 
 		repairQueue:              repairQueue,
+		metabase:                 metabaseDB,
 		nodesCache:               NewReliabilityCache(overlay, config.ReliabilityCacheStaleness),
 		overlayService:           overlay,
 		repairThresholdOverrides: config.RepairThresholdOverrides,
@@ -89,6 +98,7 @@ func NewObserver(logger *zap.Logger, repairQueue queue.RepairQueue, overlay *ove
 		doDeclumping:             config.DoDeclumping,
 		doPlacementCheck:         config.DoPlacementCheck,
 		placements:               placements,
+		lowHealthSegmentsLimit:   config.LowHealthSegmentsLimit,
 		statsCollector:           make(map[redundancyStyle]*observerRSStats),
 	}
 }
@@ -156,9 +166,69 @@ func (observer *Observer) Start(ctx context.Context, startTime time.Time) (err e
 	// Reuse the allocated slice.
 	observer.TotalStats = observer.TotalStats[:0]
 
+	if observer.lowHealthSegmentsLimit > 0 {
+		if err := observer.checkLowHealthSegments(ctx); err != nil {
+			// the segments are going to be checked by the loop anyway.
+			observer.logger.Warn("unable to check low health segments", zap.Error(err))
+		}
+	}
+
 	return nil
 }
 
+// checkLowHealthSegments checks the segments with the lowest stored healthy piece count
+// before the loop starts. Segments which dropped below the repair threshold since the
+// previous iteration, e.g. because of disqualified nodes, are queued for repair without
+// waiting for the loop to reach them.
+func (observer *Observer) checkLowHealthSegments(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	lowHealthSegments, err := observer.metabase.ListLowHealthSegments(ctx, metabase.ListLowHealthSegments{
+		MaxHealthyPieces: math.MaxInt16,
+		Limit:            observer.lowHealthSegmentsLimit,
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	// the statistics of this fork are dropped, the segments are counted by the loop.
+	fork := newObserverFork(observer).(*observerFork)
+	for _, lowHealthSegment := range lowHealthSegments {
+		segment, err := observer.metabase.GetSegmentByPosition(ctx, metabase.GetSegmentByPosition{
+			StreamID: lowHealthSegment.StreamID,
+			Position: lowHealthSegment.Position,
+		})
+		if err != nil {
+			if metabase.ErrSegmentNotFound.Has(err) {
+				continue
+			}
+			return Error.Wrap(err)
+		}
+
+		healthyPieces := lowHealthSegment.HealthyPieces
+		if err := fork.process(ctx, &rangedloop.Segment{
+			StreamID:      segment.StreamID,
+			Position:      segment.Position,
+			CreatedAt:     segment.CreatedAt,
+			ExpiresAt:     segment.ExpiresAt,
+			RepairedAt:    segment.RepairedAt,
+			RootPieceID:   segment.RootPieceID,
+			EncryptedSize: segment.EncryptedSize,
+			PlainOffset:   segment.PlainOffset,
+			PlainSize:     segment.PlainSize,
+			Redundancy:    segment.Redundancy,
+			Pieces:        segment.Pieces,
+			Placement:     segment.Placement,
+			HealthyPieces: &healthyPieces,
+		}); err != nil {
+			return err
+		}
+	}
+	mon.IntVal("checker_low_health_segments_checked").Observe(int64(len(lowHealthSegments)))
+
+	return Error.Wrap(fork.repairQueue.Flush(ctx))
+}
+
 // Fork creates a Partial to process a chunk of all the segments.
 func (observer *Observer) Fork(ctx context.Context) (_ rangedloop.Partial, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -288,11 +358,19 @@ type observerFork struct {
 	doPlacementCheck     bool
 	placements           nodeselection.PlacementDefinitions
 
+	// when set, the healthy piece count of low health segments is stored in the metabase.
+	metabase *metabase.DB
+
 	getObserverStats func(redundancyStyle) *observerRSStats
 }
 
 // newObserverFork creates new observer partial instance.
 func newObserverFork(observer *Observer) rangedloop.Partial {
+	var metabaseDB *metabase.DB
+	if observer.lowHealthSegmentsLimit > 0 {
+		metabaseDB = observer.metabase
+	}
+
 	// we can only share thread-safe objects.
 	return &observerFork{
 		repairQueue:              observer.createInsertBuffer(),
@@ -307,6 +385,7 @@ func newObserverFork(observer *Observer) rangedloop.Partial {
 		doDeclumping:             observer.doDeclumping,
 		doPlacementCheck:         observer.doPlacementCheck,
 		placements:               observer.placements,
+		metabase:                 metabaseDB,
 		getObserverStats:         observer.getObserverStats,
 	}
 }
@@ -338,6 +417,33 @@ func (fork *observerFork) Process(ctx context.Context, segments []rangedloop.Seg
 	return nil
 }
 
+// updateHealthyPieces stores the healthy piece count of the segment when it's close to the
+// repair threshold, and clears it otherwise. The database is only updated when the stored
+// count differs, so only segments with changed health are written.
+func (fork *observerFork) updateHealthyPieces(ctx context.Context, log *zap.Logger, segment *rangedloop.Segment, numHealthy, repairThreshold int) {
+	var healthyPieces *int
+	if numHealthy <= repairThreshold+lowHealthMargin {
+		healthyPieces = &numHealthy
+	}
+
+	switch {
+	case healthyPieces == nil && segment.HealthyPieces == nil:
+		return
+	case healthyPieces != nil && segment.HealthyPieces != nil && *healthyPieces == *segment.HealthyPieces:
+		return
+	}
+
+	err := fork.metabase.UpdateSegmentHealthyPieces(ctx, metabase.UpdateSegmentHealthyPieces{
+		StreamID:      segment.StreamID,
+		Position:      segment.Position,
+		HealthyPieces: healthyPieces,
+	})
+	if err != nil && !metabase.ErrSegmentNotFound.Has(err) {
+		// the count is only a hint, it's going to be updated by the next iteration.
+		log.Warn("unable to update segment healthy pieces", zap.Error(err))
+	}
+}
+
 var (
 	// initialize monkit metrics once for better performance.
 	segmentTotalCountIntVal           = mon.IntVal("checker_segment_total_count")   //mon:locked
@@ -484,6 +590,10 @@ func (fork *observerFork) process(ctx context.Context, segment *rangedloop.Segme
 	segmentHealthFloatVal.Observe(segmentHealth)
 	stats.segmentStats.segmentHealth.Observe(segmentHealth)
 
+	if fork.metabase != nil {
+		fork.updateHealthyPieces(ctx, log, segment, numHealthy, repairThreshold)
+	}
+
 	// we repair when the number of healthy pieces is less than or equal to the repair threshold and is greater or equal to
 	// minimum required pieces in redundancy
 	// except for the case when the repair and success thresholds are the same (a case usually seen during testing).
@@ -498,9 +608,11 @@ func (fork *observerFork) process(ctx context.Context, segment *rangedloop.Segme
 		fork.totalStats[segment.Placement].remoteSegmentsNeedingRepair++
 		stats.iterationAggregates.remoteSegmentsNeedingRepair++
 
+		reason := queue.ReasonHealth
 		if repairDueToForcing && !repairDueToHealth {
 			fork.totalStats[segment.Placement].remoteSegmentsNeedingRepairDueToForcing++
 			stats.iterationAggregates.remoteSegmentsNeedingRepairDueToForcing++
+			reason = queue.ReasonOutOfPlacement
 		}
 
 		err := fork.repairQueue.Insert(ctx, &queue.InjuredSegment{
@@ -509,6 +621,7 @@ func (fork *observerFork) process(ctx context.Context, segment *rangedloop.Segme
 			UpdatedAt:     time.Now().UTC(),
 			SegmentHealth: segmentHealth,
 			Placement:     segment.Placement,
+			Reason:        reason,
 		}, func() {
 			// Counters are increased after the queue has determined
 			// that the segment wasn't already queued for repair.
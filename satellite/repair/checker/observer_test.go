@@ -556,7 +556,7 @@ func BenchmarkRemoteSegment(b *testing.B) {
 			require.NoError(b, err)
 		}
 
-		observer := checker.NewObserver(zap.NewNop(), planet.Satellites[0].DB.RepairQueue(),
+		observer := checker.NewObserver(zap.NewNop(), planet.Satellites[0].DB.RepairQueue(), planet.Satellites[0].Metabase.DB,
 			planet.Satellites[0].Auditor.Overlay, nodeselection.TestPlacementDefinitionsWithFraction(0.05), planet.Satellites[0].Config.Checker)
 		segments, err := planet.Satellites[0].Metabase.DB.TestingAllSegments(ctx)
 		require.NoError(b, err)
@@ -717,3 +717,85 @@ func updateNodeStatus(ctx context.Context, satellite *testplanet.Satellite, node
 		},
 	}, timestamp, satellite.Config.Overlay.Node)
 }
+
+func TestObserver_LowHealthSegments(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Checker.LowHealthSegmentsLimit = 10
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		repairQueue := satellite.DB.RepairQueue()
+
+		rs := storj.RedundancyScheme{
+			RequiredShares: 2,
+			RepairShares:   3,
+			OptimalShares:  4,
+			TotalShares:    5,
+			ShareSize:      256,
+		}
+
+		err := planet.Uplinks[0].CreateBucket(ctx, satellite, "test-bucket")
+		require.NoError(t, err)
+
+		location := metabase.SegmentLocation{
+			ProjectID:  planet.Uplinks[0].Projects[0].ID,
+			BucketName: "test-bucket",
+			ObjectKey:  "healthy",
+		}
+		healthyStreamID := insertSegment(ctx, t, planet, rs, location, createPieces(planet, rs), nil)
+
+		location.ObjectKey = "lost"
+		lostStreamID := insertSegment(ctx, t, planet, rs, location, createLostPieces(planet, rs), nil)
+
+		_, err = satellite.RangedLoop.RangedLoop.Service.RunOnce(ctx)
+		require.NoError(t, err)
+
+		lowHealth, err := satellite.Metabase.DB.ListLowHealthSegments(ctx, metabase.ListLowHealthSegments{
+			MaxHealthyPieces: 10,
+			Limit:            10,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []metabase.LowHealthSegment{
+			{StreamID: lostStreamID, HealthyPieces: 2},
+			{StreamID: healthyStreamID, HealthyPieces: 4},
+		}, lowHealth)
+
+		injuredSegments, err := repairQueue.Select(ctx, 1, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, lostStreamID, injuredSegments[0].StreamID)
+		require.NoError(t, repairQueue.Delete(ctx, injuredSegments[0]))
+
+		// the stored low health segments are queued before the loop reaches them
+		require.NoError(t, satellite.RangedLoop.Repair.Observer.Start(ctx, time.Now()))
+
+		injuredSegments, err = repairQueue.Select(ctx, 1, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, lostStreamID, injuredSegments[0].StreamID)
+
+		// updating the pieces clears the stored count
+		segment, err := satellite.Metabase.DB.GetSegmentByPosition(ctx, metabase.GetSegmentByPosition{
+			StreamID: healthyStreamID,
+		})
+		require.NoError(t, err)
+		require.NoError(t, satellite.Metabase.DB.UpdateSegmentPieces(ctx, metabase.UpdateSegmentPieces{
+			StreamID:      segment.StreamID,
+			Position:      segment.Position,
+			OldPieces:     segment.Pieces,
+			NewRedundancy: segment.Redundancy,
+			NewPieces:     segment.Pieces,
+		}))
+
+		lowHealth, err = satellite.Metabase.DB.ListLowHealthSegments(ctx, metabase.ListLowHealthSegments{
+			MaxHealthyPieces: 10,
+			Limit:            10,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []metabase.LowHealthSegment{
+			{StreamID: lostStreamID, HealthyPieces: 2},
+		}, lowHealth)
+	})
+}
@@ -47,6 +47,26 @@ func (m *MockRepairQueue) Select(context.Context, int, []storj.PlacementConstrai
 	panic("implement me")
 }
 
+// Lease implements RepairQueue.
+func (m *MockRepairQueue) Lease(context.Context, string, int, time.Duration, []storj.PlacementConstraint, []storj.PlacementConstraint) ([]InjuredSegment, error) {
+	panic("implement me")
+}
+
+// RenewLease implements RepairQueue.
+func (m *MockRepairQueue) RenewLease(ctx context.Context, worker string, s InjuredSegment, duration time.Duration) (bool, error) {
+	panic("implement me")
+}
+
+// ReleaseLease implements RepairQueue.
+func (m *MockRepairQueue) ReleaseLease(ctx context.Context, worker string, s InjuredSegment) error {
+	panic("implement me")
+}
+
+// Leases implements RepairQueue.
+func (m *MockRepairQueue) Leases(ctx context.Context, limit int) ([]Lease, error) {
+	panic("implement me")
+}
+
 // Delete implements RepairQueue.
 func (m *MockRepairQueue) Delete(ctx context.Context, s InjuredSegment) error {
 	panic("implement me")
@@ -24,6 +24,31 @@ type InjuredSegment struct {
 	InsertedAt    time.Time
 
 	Placement storj.PlacementConstraint
+	Reason    Reason
+}
+
+// Reason describes why a segment was added to the repair queue.
+type Reason int
+
+const (
+	// ReasonHealth is used when the segment has too few healthy pieces.
+	ReasonHealth Reason = 0
+	// ReasonOutOfPlacement is used when the segment has enough healthy pieces,
+	// but some of them are stored on nodes which don't match the segment placement
+	// anymore (e.g. because the node changed its country).
+	ReasonOutOfPlacement Reason = 1
+)
+
+// String implements fmt.Stringer.
+func (reason Reason) String() string {
+	switch reason {
+	case ReasonHealth:
+		return "health"
+	case ReasonOutOfPlacement:
+		return "out_of_placement"
+	default:
+		return "unknown"
+	}
 }
 
 // Stat contains information about a segment of repair queue.
@@ -38,6 +63,15 @@ type Stat struct {
 	MaxSegmentHealth float64
 }
 
+// Lease describes a segment of the repair queue held by a repair worker.
+type Lease struct {
+	Worker    string
+	StreamID  uuid.UUID
+	Position  metabase.SegmentPosition
+	Placement storj.PlacementConstraint
+	ExpiresAt time.Time
+}
+
 // RepairQueue implements queueing for segments that need repairing.
 // Implementation can be found at satellite/satellitedb/repairqueue.go.
 //
@@ -49,6 +83,16 @@ type RepairQueue interface {
 	InsertBatch(ctx context.Context, segments []*InjuredSegment) (newlyInsertedSegments []*InjuredSegment, err error)
 	// Select gets an injured segments.
 	Select(ctx context.Context, limit int, includedPlacements []storj.PlacementConstraint, excludedPlacements []storj.PlacementConstraint) ([]InjuredSegment, error)
+	// Lease gets injured segments and leases them to the worker for the duration. Segments
+	// with an expired lease are taken over from the worker which held them.
+	Lease(ctx context.Context, worker string, limit int, duration time.Duration, includedPlacements []storj.PlacementConstraint, excludedPlacements []storj.PlacementConstraint) ([]InjuredSegment, error)
+	// RenewLease extends the lease of the worker on an injured segment. It returns false
+	// when the worker doesn't hold the lease anymore.
+	RenewLease(ctx context.Context, worker string, s InjuredSegment, duration time.Duration) (renewed bool, err error)
+	// ReleaseLease releases the lease of the worker on an injured segment, which stays in the queue.
+	ReleaseLease(ctx context.Context, worker string, s InjuredSegment) error
+	// Leases lists limit amount of unexpired leases ordered by worker.
+	Leases(ctx context.Context, limit int) ([]Lease, error)
 	// Delete removes an injured segment.
 	Delete(ctx context.Context, s InjuredSegment) error
 	// Clean removes all segments last updated before a certain time
@@ -56,6 +56,84 @@ func TestUntilEmpty(t *testing.T) {
 	})
 }
 
+func TestLease(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		repairQueue := db.RepairQueue()
+
+		segA := &queue.InjuredSegment{StreamID: testrand.UUID(), SegmentHealth: 1}
+		segB := &queue.InjuredSegment{StreamID: testrand.UUID(), SegmentHealth: 2}
+		for _, seg := range []*queue.InjuredSegment{segA, segB} {
+			_, err := repairQueue.Insert(ctx, seg)
+			require.NoError(t, err)
+		}
+
+		leased, err := repairQueue.Lease(ctx, "worker-1", 1, time.Hour, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, leased, 1)
+		require.Equal(t, segA.StreamID, leased[0].StreamID)
+
+		// a leased segment is not handed out to another worker.
+		leased, err = repairQueue.Lease(ctx, "worker-2", 10, time.Hour, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, leased, 1)
+		require.Equal(t, segB.StreamID, leased[0].StreamID)
+
+		_, err = repairQueue.Lease(ctx, "worker-3", 10, time.Hour, nil, nil)
+		require.True(t, queue.ErrEmpty.Has(err))
+		_, err = repairQueue.Select(ctx, 10, nil, nil)
+		require.True(t, queue.ErrEmpty.Has(err))
+
+		leases, err := repairQueue.Leases(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, leases, 2)
+		require.Equal(t, "worker-1", leases[0].Worker)
+		require.Equal(t, segA.StreamID, leases[0].StreamID)
+		require.Equal(t, "worker-2", leases[1].Worker)
+		require.Equal(t, segB.StreamID, leases[1].StreamID)
+
+		renewed, err := repairQueue.RenewLease(ctx, "worker-1", *segA, time.Hour)
+		require.NoError(t, err)
+		require.True(t, renewed)
+
+		renewed, err = repairQueue.RenewLease(ctx, "worker-2", *segA, time.Hour)
+		require.NoError(t, err)
+		require.False(t, renewed)
+
+		// an expired lease is taken over by another worker.
+		renewed, err = repairQueue.RenewLease(ctx, "worker-1", *segA, -time.Minute)
+		require.NoError(t, err)
+		require.True(t, renewed)
+
+		leased, err = repairQueue.Lease(ctx, "worker-3", 10, time.Hour, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, leased, 1)
+		require.Equal(t, segA.StreamID, leased[0].StreamID)
+
+		renewed, err = repairQueue.RenewLease(ctx, "worker-1", *segA, time.Hour)
+		require.NoError(t, err)
+		require.False(t, renewed)
+
+		// a released segment is retried only after the retry interval.
+		require.NoError(t, repairQueue.ReleaseLease(ctx, "worker-2", *segB))
+
+		leases, err = repairQueue.Leases(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, leases, 1)
+		require.Equal(t, "worker-3", leases[0].Worker)
+
+		_, err = repairQueue.Lease(ctx, "worker-1", 10, time.Hour, nil, nil)
+		require.True(t, queue.ErrEmpty.Has(err))
+
+		_, err = repairQueue.TestingSetAttemptedTime(ctx, segB.StreamID, segB.Position, time.Now().Add(-7*time.Hour))
+		require.NoError(t, err)
+
+		leased, err = repairQueue.Lease(ctx, "worker-1", 10, time.Hour, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, leased, 1)
+		require.Equal(t, segB.StreamID, leased[0].StreamID)
+	})
+}
+
 func TestOrder(t *testing.T) {
 	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
 		repairQueue := db.RepairQueue()
@@ -25,6 +25,7 @@ func TestInsertSelect(t *testing.T) {
 
 		seg := createInjuredSegment()
 		seg.SegmentHealth = 0.4
+		seg.Reason = queue.ReasonOutOfPlacement
 
 		alreadyInserted, err := q.Insert(ctx, seg)
 		require.NoError(t, err)
@@ -36,6 +37,7 @@ func TestInsertSelect(t *testing.T) {
 		require.Equal(t, seg.StreamID, segments[0].StreamID)
 		require.Equal(t, seg.Position, segments[0].Position)
 		require.Equal(t, seg.SegmentHealth, segments[0].SegmentHealth)
+		require.Equal(t, queue.ReasonOutOfPlacement, segments[0].Reason)
 		require.WithinDuration(t, time.Now(), segments[0].InsertedAt, 5*time.Second)
 		require.NotZero(t, segments[0].UpdatedAt)
 	})
@@ -67,6 +69,7 @@ func TestInsertBatchOfOne(t *testing.T) {
 		require.Len(t, newlyInserted, 1)
 
 		writeSegments[0].SegmentHealth = 5
+		writeSegments[0].Reason = queue.ReasonOutOfPlacement
 		newlyInserted, err = q.InsertBatch(ctx, writeSegments)
 		require.NoError(t, err)
 		require.Len(t, newlyInserted, 0)
@@ -78,6 +81,7 @@ func TestInsertBatchOfOne(t *testing.T) {
 		require.Equal(t, writeSegments[0].Position, readSegments[0].Position)
 		require.Equal(t, writeSegments[0].SegmentHealth, readSegments[0].SegmentHealth)
 		require.Equal(t, writeSegments[0].Placement, readSegments[0].Placement)
+		require.Equal(t, writeSegments[0].Reason, readSegments[0].Reason)
 	})
 }
 
@@ -0,0 +1,152 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/spf13/pflag"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+)
+
+// PlacementEgressBudgets is a configurable, comma separated list of daily
+// repair egress budgets per placement, e.g. "0:10TB,12:1TB".
+type PlacementEgressBudgets struct {
+	Budgets map[storj.PlacementConstraint]memory.Size
+}
+
+// String implements pflag.Value.
+func (p *PlacementEgressBudgets) String() string {
+	var s []string
+	for placement, budget := range p.Budgets {
+		s = append(s, fmt.Sprintf("%d:%s", placement, budget.String()))
+	}
+	sort.Strings(s)
+	return strings.Join(s, ",")
+}
+
+// Set implements pflag.Value.
+func (p *PlacementEgressBudgets) Set(s string) error {
+	p.Budgets = map[storj.PlacementConstraint]memory.Size{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		placementStr, budgetStr, ok := strings.Cut(part, ":")
+		if !ok {
+			return errs.New("Placement egress budget should be in the form <placement>:<size>: %s", part)
+		}
+		placement, err := strconv.Atoi(strings.TrimSpace(placementStr))
+		if err != nil {
+			return errs.New("Placement should be a number: %s", placementStr)
+		}
+		budgetStr = strings.TrimSpace(budgetStr)
+		// memory.Size.Set doesn't handle sizes without any digits.
+		if strings.IndexFunc(budgetStr, unicode.IsDigit) < 0 {
+			return errs.New("Invalid egress budget %q", budgetStr)
+		}
+		var budget memory.Size
+		if err := budget.Set(budgetStr); err != nil {
+			return errs.New("Invalid egress budget %q: %w", budgetStr, err)
+		}
+		p.Budgets[storj.PlacementConstraint(placement)] = budget
+	}
+	return nil
+}
+
+// Type implements pflag.Value.
+func (p PlacementEgressBudgets) Type() string {
+	return "placement-egress-budgets"
+}
+
+var _ pflag.Value = &PlacementEgressBudgets{}
+
+// egressBudget tracks the repair egress of the current UTC day.
+//
+// The budget is tracked in memory, hence every repairer process has its own budget:
+// the repair egress of the whole satellite can reach the configured budget times the
+// number of running repairer processes, and a restarted process starts with an unused
+// budget.
+type egressBudget struct {
+	daily      int64
+	placements map[storj.PlacementConstraint]int64
+
+	mu                sync.Mutex
+	day               time.Time
+	used              int64
+	usedPerPlacements map[storj.PlacementConstraint]int64
+}
+
+// newEgressBudget returns a new egress budget, or nil when repair egress is unlimited.
+func newEgressBudget(daily memory.Size, placements map[storj.PlacementConstraint]memory.Size) *egressBudget {
+	budget := &egressBudget{
+		daily:             daily.Int64(),
+		placements:        map[storj.PlacementConstraint]int64{},
+		usedPerPlacements: map[storj.PlacementConstraint]int64{},
+	}
+	for placement, size := range placements {
+		if size > 0 {
+			budget.placements[placement] = size.Int64()
+		}
+	}
+	if budget.daily <= 0 && len(budget.placements) == 0 {
+		return nil
+	}
+	return budget
+}
+
+// reset starts a new window when the day of now differs from the current window.
+func (budget *egressBudget) reset(now time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	if !day.Equal(budget.day) {
+		budget.day = day
+		budget.used = 0
+		budget.usedPerPlacements = map[storj.PlacementConstraint]int64{}
+	}
+}
+
+// exhausted returns whether the global or the placement budget is used up.
+func (budget *egressBudget) exhausted(now time.Time, placement storj.PlacementConstraint) bool {
+	if budget == nil {
+		return false
+	}
+
+	budget.mu.Lock()
+	defer budget.mu.Unlock()
+
+	budget.reset(now)
+
+	if budget.daily > 0 && budget.used >= budget.daily {
+		return true
+	}
+	if limit, ok := budget.placements[placement]; ok && budget.usedPerPlacements[placement] >= limit {
+		return true
+	}
+	return false
+}
+
+// charge adds the egress of a repair to the budget.
+func (budget *egressBudget) charge(now time.Time, placement storj.PlacementConstraint, bytes int64) {
+	if budget == nil {
+		return
+	}
+
+	budget.mu.Lock()
+	defer budget.mu.Unlock()
+
+	budget.reset(now)
+
+	budget.used += bytes
+	budget.usedPerPlacements[placement] += bytes
+}
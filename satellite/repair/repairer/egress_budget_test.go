@@ -0,0 +1,67 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/structs"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+)
+
+func TestPlacementEgressBudgets(t *testing.T) {
+	pl := Config{}
+
+	decode := structs.Decode(map[string]string{
+		"egress-budget-placements": "0:10TB, 12:1GiB",
+	}, &pl)
+
+	require.NoError(t, decode.Error)
+	require.Len(t, decode.Broken, 0)
+	require.Len(t, decode.Used, 1)
+
+	require.Equal(t, map[storj.PlacementConstraint]memory.Size{
+		0:  10 * memory.TB,
+		12: memory.GiB,
+	}, pl.EgressBudgetPlacements.Budgets)
+	require.Equal(t, "0:10.00 TB,12:1.0 GiB", pl.EgressBudgetPlacements.String())
+
+	var budgets PlacementEgressBudgets
+	require.Error(t, budgets.Set("12"))
+	require.Error(t, budgets.Set("a:1GB"))
+	require.Error(t, budgets.Set("1:abc"))
+}
+
+func TestEgressBudget(t *testing.T) {
+	require.Nil(t, newEgressBudget(0, nil))
+	require.Nil(t, newEgressBudget(0, map[storj.PlacementConstraint]memory.Size{1: 0}))
+
+	var unlimited *egressBudget
+	unlimited.charge(time.Now(), 0, 100)
+	require.False(t, unlimited.exhausted(time.Now(), 0))
+
+	now := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	budget := newEgressBudget(100, map[storj.PlacementConstraint]memory.Size{12: 10})
+	require.False(t, budget.exhausted(now, 0))
+	require.False(t, budget.exhausted(now, 12))
+
+	budget.charge(now, 12, 10)
+	require.False(t, budget.exhausted(now, 0))
+	require.True(t, budget.exhausted(now, 12))
+
+	budget.charge(now, 0, 90)
+	require.True(t, budget.exhausted(now, 0))
+	require.True(t, budget.exhausted(now, 12))
+
+	// the budget resets with the next UTC day.
+	tomorrow := time.Date(2024, 8, 2, 0, 0, 0, 0, time.UTC)
+	require.True(t, budget.exhausted(tomorrow.Add(-time.Nanosecond), 0))
+	require.False(t, budget.exhausted(tomorrow, 0))
+	require.False(t, budget.exhausted(tomorrow, 12))
+}
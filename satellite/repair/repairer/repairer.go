@@ -6,6 +6,7 @@ package repairer
 import (
 	"context"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -47,8 +48,15 @@ type Config struct {
 	DoDeclumping                  bool          `help:"repair pieces on the same network to other nodes" default:"true"`
 	DoPlacementCheck              bool          `help:"repair pieces out of segment placement" default:"true"`
 
+	LeaseDuration time.Duration `help:"how long the repairer holds the segments it takes from the repair queue, the lease is renewed while a segment is repaired and other repairers take over the segments of an expired lease, 0 disables the leases" default:"10m" testDefault:"1m"`
+	WorkerID      string        `help:"identifier of the repairer in the repair queue leases, the host name and the process ID are used when empty" default:""`
+
 	IncludedPlacements PlacementList `help:"comma separated placement IDs (numbers), which should checked by the repairer (other placements are ignored)" default:""`
 	ExcludedPlacements PlacementList `help:"comma separated placement IDs (numbers), placements which should be ignored by the repairer" default:""`
+
+	EgressBudgetDaily          memory.Size            `help:"daily repair egress budget of a single repairer process, the budget is not shared, hence the network wide limit is this value times the number of repairer processes, when exhausted only critical segments are repaired until the next UTC day, 0 means unlimited" default:"0B"`
+	EgressBudgetPlacements     PlacementEgressBudgets `help:"comma separated daily repair egress budgets of a single repairer process per placement, the budgets are not shared between repairer processes, e.g. 0:10TB,12:1TB" default:""`
+	EgressBudgetCriticalMargin int                    `help:"segments with at most this many healthy pieces above the required number are critical and repaired even when the egress budget is exhausted" default:"5"`
 }
 
 // PlacementList is a configurable, comma separated list of PlacementConstraint IDs.
@@ -99,6 +107,7 @@ type Service struct {
 	JobLimiter *semaphore.Weighted
 	Loop       *sync2.Cycle
 	repairer   *SegmentRepairer
+	workerID   string
 
 	nowFn func() time.Time
 }
@@ -112,11 +121,24 @@ func NewService(log *zap.Logger, queue queue.RepairQueue, config *Config, repair
 		JobLimiter: semaphore.NewWeighted(int64(config.MaxRepair)),
 		Loop:       sync2.NewCycle(config.Interval),
 		repairer:   repairer,
+		workerID:   workerID(config.WorkerID),
 
 		nowFn: time.Now,
 	}
 }
 
+// workerID returns the identifier of the repairer in the repair queue leases.
+func workerID(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "repairer"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
 // Close closes resources.
 func (service *Service) Close() error { return nil }
 
@@ -171,8 +193,7 @@ func (service *Service) process(ctx context.Context) (err error) {
 
 	selectCtx, selectCancel := context.WithTimeout(ctx, service.config.TotalTimeout)
 	defer selectCancel()
-	segments, err := service.queue.Select(selectCtx, service.config.SegmentsSelectBatchSize,
-		service.config.IncludedPlacements.Placements, service.config.ExcludedPlacements.Placements)
+	segments, err := service.selectSegments(selectCtx)
 	if err != nil {
 		return err
 	}
@@ -212,6 +233,16 @@ func (service *Service) process(ctx context.Context) (err error) {
 	return nil
 }
 
+// selectSegments takes the next injured segments from the repair queue.
+func (service *Service) selectSegments(ctx context.Context) ([]queue.InjuredSegment, error) {
+	if service.config.LeaseDuration <= 0 {
+		return service.queue.Select(ctx, service.config.SegmentsSelectBatchSize,
+			service.config.IncludedPlacements.Placements, service.config.ExcludedPlacements.Placements)
+	}
+	return service.queue.Lease(ctx, service.workerID, service.config.SegmentsSelectBatchSize, service.config.LeaseDuration,
+		service.config.IncludedPlacements.Placements, service.config.ExcludedPlacements.Placements)
+}
+
 func (service *Service) worker(ctx context.Context, seg queue.InjuredSegment) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -219,11 +250,33 @@ func (service *Service) worker(ctx context.Context, seg queue.InjuredSegment) (e
 
 	log := service.log.With(
 		zap.Stringer("Stream ID", seg.StreamID),
-		zap.Uint64("Position", seg.Position.Encode()))
+		zap.Uint64("Position", seg.Position.Encode()),
+		zap.Stringer("Reason", seg.Reason))
+
+	repairCtx := ctx
+	if service.config.LeaseDuration > 0 {
+		// the segment may have waited for the job limiter longer than the lease, in which
+		// case another repairer may have taken it over.
+		renewed, err := service.queue.RenewLease(ctx, service.workerID, seg, service.config.LeaseDuration)
+		if err != nil {
+			return Error.New("failed to renew lease: %v", err)
+		}
+		if !renewed {
+			mon.Counter("repair_leases_lost").Inc(1)
+			log.Debug("segment was taken over by another repairer")
+			return nil
+		}
+
+		var stop func()
+		repairCtx, stop = service.keepLease(ctx, log, seg)
+		defer stop()
+	}
+
 	log.Debug("Limiter running repair on segment")
+	mon.Meter("repair_segment_reason", monkit.NewSeriesTag("reason", seg.Reason.String())).Mark(1)
 
 	// note that shouldDelete is used even in the case where err is not null
-	shouldDelete, err := service.repairer.Repair(ctx, seg)
+	shouldDelete, err := service.repairer.Repair(repairCtx, seg)
 	if shouldDelete {
 		if err != nil {
 			log.Error("unexpected error repairing segment!", zap.Error(err))
@@ -235,6 +288,13 @@ func (service *Service) worker(ctx context.Context, seg queue.InjuredSegment) (e
 		if delErr != nil {
 			err = errs.Combine(err, Error.New("failed to remove segment from queue: %v", delErr))
 		}
+	} else if service.config.LeaseDuration > 0 {
+		// the segment is retried later, following the same interval as without leases.
+		if relErr := service.queue.ReleaseLease(ctx, service.workerID, seg); relErr != nil {
+			log.Warn("failed to release lease", zap.Error(relErr))
+		} else {
+			mon.Counter("repair_leases_released").Inc(1)
+		}
 	}
 	if err != nil {
 		return Error.Wrap(err)
@@ -254,6 +314,48 @@ func (service *Service) worker(ctx context.Context, seg queue.InjuredSegment) (e
 	return nil
 }
 
+// keepLease renews the lease on the segment while it's being repaired. The returned
+// context is canceled when the lease is lost, so that the segment isn't repaired by two
+// repairers at the same time. The returned function stops the renewal.
+func (service *Service) keepLease(ctx context.Context, log *zap.Logger, seg queue.InjuredSegment) (_ context.Context, stop func()) {
+	leaseCtx, cancel := context.WithCancel(ctx)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(service.config.LeaseDuration / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-leaseCtx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			renewed, err := service.queue.RenewLease(leaseCtx, service.workerID, seg, service.config.LeaseDuration)
+			switch {
+			case err != nil:
+				// the lease is still valid for a while, so it's tried again on the next tick.
+				log.Warn("failed to renew lease", zap.Error(err))
+			case !renewed:
+				mon.Counter("repair_leases_lost").Inc(1)
+				log.Warn("lease was taken over by another repairer, canceling repair")
+				cancel()
+				return
+			default:
+				mon.Counter("repair_leases_renewed").Inc(1)
+			}
+		}
+	}()
+
+	return leaseCtx, func() {
+		cancel()
+		<-done
+	}
+}
+
 // SetNow allows tests to have the server act as if the current time is whatever they want.
 func (service *Service) SetNow(nowFn func() time.Time) {
 	service.nowFn = nowFn
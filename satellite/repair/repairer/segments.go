@@ -123,6 +123,9 @@ type SegmentRepairer struct {
 
 	excludedCountryCodes map[location.CountryCode]struct{}
 
+	egressBudget   *egressBudget
+	criticalMargin int
+
 	nowFn                            func() time.Time
 	OnTestingCheckSegmentAlteredHook func()
 	OnTestingPiecesReportHook        func(pieces FetchResultReport)
@@ -176,6 +179,8 @@ func NewSegmentRepairer(
 		doDeclumping:               config.DoDeclumping,
 		doPlacementCheck:           config.DoPlacementCheck,
 		placements:                 placements,
+		egressBudget:               newEgressBudget(config.EgressBudgetDaily, config.EgressBudgetPlacements.Budgets),
+		criticalMargin:             config.EgressBudgetCriticalMargin,
 
 		nowFn: time.Now,
 	}
@@ -307,6 +312,18 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment queue.
 		return true, nil
 	}
 
+	if repairer.egressBudget.exhausted(repairer.nowFn(), segment.Placement) {
+		// only critical segments are repaired until the budget resets.
+		if piecesCheck.Healthy.Count()-int(newRedundancy.RequiredShares) > repairer.criticalMargin {
+			mon.Meter("repair_deferred_egress_budget").Mark(1)
+			log.Debug("repair deferred, egress budget is exhausted",
+				zap.Int("numHealthy", piecesCheck.Healthy.Count()),
+				zap.Int16("piecesRequired", newRedundancy.RequiredShares))
+			return false, nil
+		}
+		mon.Meter("repair_critical_over_egress_budget").Mark(1)
+	}
+
 	healthyRatioBeforeRepair := 0.0
 	if segment.Redundancy.TotalShares != 0 {
 		healthyRatioBeforeRepair = float64(piecesCheck.Healthy.Count()) / float64(segment.Redundancy.TotalShares)
@@ -387,6 +404,10 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment queue.
 		zap.Int("numOrderLimits", len(getOrderLimits)),
 		zapRS("RS", segment.Redundancy))
 
+	// charge the egress before downloading, so that concurrent repairs don't overshoot the budget.
+	repairer.egressBudget.charge(repairer.nowFn(), segment.Placement,
+		int64(segment.Redundancy.RequiredShares)*segment.Redundancy.PieceSize(int64(segment.EncryptedSize)))
+
 	// Download the segment using just the retrievable pieces
 	segmentReader, piecesReport, err := repairer.ec.Get(ctx, log, getOrderLimits, cachedNodesInfo, getPrivateKey, oldRedundancyStrategy, int64(segment.EncryptedSize))
 
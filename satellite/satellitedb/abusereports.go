@@ -0,0 +1,139 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/satellitedb/dbx"
+)
+
+// Ensure that abuseReports implements console.AbuseReports.
+var _ console.AbuseReports = (*abuseReports)(nil)
+
+// abuseReports is an implementation of console.AbuseReports.
+type abuseReports struct {
+	db dbx.DriverMethods
+}
+
+// Insert inserts a new abuse report.
+func (reports *abuseReports) Insert(ctx context.Context, report console.AbuseReport) (_ *console.AbuseReport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	report.CreatedAt = time.Now().UTC()
+	report.ReviewedAt = nil
+	report.ReviewedBy = ""
+
+	_, err = reports.db.ExecContext(ctx, reports.db.Rebind(`
+		INSERT INTO abuse_reports (
+			id, url, access_key_id, reason, reporter_email, status, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+	`), report.ID.Bytes(), report.URL, report.AccessKeyID, report.Reason, report.ReporterEmail, int64(report.Status), report.CreatedAt)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return &report, nil
+}
+
+// Get returns the abuse report with the specified ID.
+func (reports *abuseReports) Get(ctx context.Context, id uuid.UUID) (_ *console.AbuseReport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	row := reports.db.QueryRowContext(ctx, reports.db.Rebind(`
+		SELECT id, url, access_key_id, reason, reporter_email, status, created_at, reviewed_at, reviewed_by
+		FROM abuse_reports
+		WHERE id = ?
+	`), id.Bytes())
+
+	report, err := scanAbuseReport(row)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ListByStatus returns the oldest abuse reports with the specified status.
+func (reports *abuseReports) ListByStatus(ctx context.Context, status console.AbuseReportStatus, limit int) (_ []console.AbuseReport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if limit <= 0 {
+		return nil, Error.New("limit must be positive")
+	}
+
+	rows, err := reports.db.QueryContext(ctx, reports.db.Rebind(`
+		SELECT id, url, access_key_id, reason, reporter_email, status, created_at, reviewed_at, reviewed_by
+		FROM abuse_reports
+		WHERE status = ?
+		ORDER BY created_at ASC, id ASC
+		LIMIT ?
+	`), int64(status), int64(limit))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var list []console.AbuseReport
+	for rows.Next() {
+		report, err := scanAbuseReport(rows)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		list = append(list, report)
+	}
+	return list, Error.Wrap(rows.Err())
+}
+
+// UpdateStatus updates the status of the pending abuse report and records who reviewed it.
+func (reports *abuseReports) UpdateStatus(ctx context.Context, id uuid.UUID, status console.AbuseReportStatus, reviewedBy string, reviewedAt time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := reports.db.ExecContext(ctx, reports.db.Rebind(`
+		UPDATE abuse_reports
+		SET status = ?, reviewed_by = ?, reviewed_at = ?
+		WHERE id = ? AND status = ?
+	`), int64(status), reviewedBy, reviewedAt, id.Bytes(), int64(console.AbuseReportPending))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if affected == 0 {
+		// the report either doesn't exist or it was already reviewed.
+		if _, err := reports.Get(ctx, id); err != nil {
+			return err
+		}
+		return console.ErrAbuseReportReviewed.New("%s", id)
+	}
+	return nil
+}
+
+// scanAbuseReport scans a single abuse report row.
+func scanAbuseReport(row interface{ Scan(dest ...any) error }) (report console.AbuseReport, err error) {
+	var id []byte
+	var status int64
+	var reviewedBy sql.NullString
+	err = row.Scan(&id, &report.URL, &report.AccessKeyID, &report.Reason, &report.ReporterEmail,
+		&status, &report.CreatedAt, &report.ReviewedAt, &reviewedBy)
+	if err != nil {
+		return console.AbuseReport{}, err
+	}
+
+	report.ID, err = uuid.FromBytes(id)
+	if err != nil {
+		return console.AbuseReport{}, Error.Wrap(err)
+	}
+	report.Status = console.AbuseReportStatus(status)
+	report.ReviewedBy = reviewedBy.String
+	return report, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/overlay/attestation"
+)
+
+// Ensure that attestationIssuers implements attestation.DB.
+var _ attestation.DB = (*attestationIssuers)(nil)
+
+// attestationIssuers is an implementation of attestation.DB.
+type attestationIssuers struct {
+	db *satelliteDB
+}
+
+// Insert adds an issuer.
+func (issuers *attestationIssuers) Insert(ctx context.Context, issuer attestation.Issuer) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = issuers.db.ExecContext(ctx, issuers.db.Rebind(`
+		INSERT INTO node_attestation_issuers (id, name, identity, created_at)
+		VALUES (?, ?, ?, ?)
+	`), issuer.ID.Bytes(), issuer.Name, issuer.Identity, issuer.CreatedAt.UTC())
+	return Error.Wrap(err)
+}
+
+// Get returns the issuer with the ID. It returns attestation.ErrIssuerNotFound when it doesn't exist.
+func (issuers *attestationIssuers) Get(ctx context.Context, id storj.NodeID) (_ attestation.Issuer, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	row := issuers.db.QueryRowContext(ctx, issuers.db.Rebind(`
+		SELECT id, name, identity, created_at
+		FROM node_attestation_issuers
+		WHERE id = ?
+	`), id.Bytes())
+
+	issuer, err := scanAttestationIssuer(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return attestation.Issuer{}, attestation.ErrIssuerNotFound.New("%s", id)
+		}
+		return attestation.Issuer{}, Error.Wrap(err)
+	}
+	return issuer, nil
+}
+
+// List returns all issuers.
+func (issuers *attestationIssuers) List(ctx context.Context) (_ []attestation.Issuer, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := issuers.db.QueryContext(ctx, `
+		SELECT id, name, identity, created_at
+		FROM node_attestation_issuers
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var list []attestation.Issuer
+	for rows.Next() {
+		issuer, err := scanAttestationIssuer(rows)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		list = append(list, issuer)
+	}
+	return list, Error.Wrap(rows.Err())
+}
+
+// Delete removes the issuer.
+func (issuers *attestationIssuers) Delete(ctx context.Context, id storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = issuers.db.ExecContext(ctx, issuers.db.Rebind(`
+		DELETE FROM node_attestation_issuers WHERE id = ?
+	`), id.Bytes())
+	return Error.Wrap(err)
+}
+
+// scanAttestationIssuer scans a single attestation issuer row.
+func scanAttestationIssuer(row interface{ Scan(dest ...any) error }) (issuer attestation.Issuer, err error) {
+	var id []byte
+	err = row.Scan(&id, &issuer.Name, &issuer.Identity, &issuer.CreatedAt)
+	if err != nil {
+		return attestation.Issuer{}, err
+	}
+
+	issuer.ID, err = storj.NodeIDFromBytes(id)
+	if err != nil {
+		return attestation.Issuer{}, Error.Wrap(err)
+	}
+	return issuer, nil
+}
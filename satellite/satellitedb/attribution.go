@@ -14,6 +14,7 @@ import (
 	"storj.io/common/uuid"
 	"storj.io/storj/satellite/attribution"
 	"storj.io/storj/satellite/satellitedb/dbx"
+	"storj.io/storj/shared/dbutil"
 )
 
 const (
@@ -333,6 +334,103 @@ func (keys *attributionDB) QueryAllAttribution(ctx context.Context, start time.T
 	return results, Error.Wrap(rows.Err())
 }
 
+// IncrementPartnerRollups adds the objects and bytes of the rollups to the stored rollups.
+func (keys *attributionDB) IncrementPartnerRollups(ctx context.Context, rollups []attribution.Rollup) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(rollups) == 0 {
+		return nil
+	}
+
+	switch keys.db.impl {
+	case dbutil.Postgres, dbutil.Cockroach:
+		return Error.Wrap(keys.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) error {
+			statement := tx.Rebind(`
+				INSERT INTO bucket_partner_rollups (project_id, bucket_name, partner, interval_start, objects, bytes)
+				VALUES (?, ?, ?, ?, ?, ?)
+				ON CONFLICT (project_id, bucket_name, partner, interval_start)
+				DO UPDATE SET
+					objects = bucket_partner_rollups.objects + EXCLUDED.objects,
+					bytes   = bucket_partner_rollups.bytes + EXCLUDED.bytes
+			`)
+			for _, rollup := range rollups {
+				_, err := tx.Tx.ExecContext(ctx, statement,
+					rollup.ProjectID[:], rollup.BucketName, rollup.Partner, rollup.IntervalStart.UTC(), rollup.Objects, rollup.Bytes)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}))
+	case dbutil.Spanner:
+		return Error.Wrap(keys.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) error {
+			updateStatement := tx.Rebind(`
+				UPDATE bucket_partner_rollups SET objects = objects + ?, bytes = bytes + ?
+				WHERE project_id = ? AND bucket_name = ? AND partner = ? AND interval_start = ?
+			`)
+			insertStatement := tx.Rebind(`
+				INSERT INTO bucket_partner_rollups (project_id, bucket_name, partner, interval_start, objects, bytes)
+				VALUES (?, ?, ?, ?, ?, ?)
+			`)
+			for _, rollup := range rollups {
+				result, err := tx.Tx.ExecContext(ctx, updateStatement,
+					rollup.Objects, rollup.Bytes, rollup.ProjectID[:], rollup.BucketName, rollup.Partner, rollup.IntervalStart.UTC())
+				if err != nil {
+					return err
+				}
+				affected, err := result.RowsAffected()
+				if err != nil {
+					return err
+				}
+				if affected > 0 {
+					continue
+				}
+				_, err = tx.Tx.ExecContext(ctx, insertStatement,
+					rollup.ProjectID[:], rollup.BucketName, rollup.Partner, rollup.IntervalStart.UTC(), rollup.Objects, rollup.Bytes)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}))
+	default:
+		return Error.New("unsupported database dialect: %s", keys.db.impl)
+	}
+}
+
+// QueryPartnerRollups queries the rollups of the partner with an interval start in [start, end).
+func (keys *attributionDB) QueryPartnerRollups(ctx context.Context, partner string, start, end time.Time) (_ []attribution.Rollup, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := keys.db.DB.QueryContext(ctx, keys.db.Rebind(`
+		SELECT project_id, bucket_name, interval_start, objects, bytes
+		FROM bucket_partner_rollups
+		WHERE partner = ? AND interval_start >= ? AND interval_start < ?
+		ORDER BY project_id, bucket_name, interval_start
+	`), partner, start.UTC(), end.UTC())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var rollups []attribution.Rollup
+	for rows.Next() {
+		rollup := attribution.Rollup{Partner: partner}
+		var projectID []byte
+		err := rows.Scan(&projectID, &rollup.BucketName, &rollup.IntervalStart, &rollup.Objects, &rollup.Bytes)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		rollup.ProjectID, err = uuid.FromBytes(projectID)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		rollup.IntervalStart = rollup.IntervalStart.UTC()
+		rollups = append(rollups, rollup)
+	}
+	return rollups, Error.Wrap(rows.Err())
+}
+
 func attributionFromDBX(info *dbx.ValueAttribution) (*attribution.Info, error) {
 	userAgent := info.UserAgent
 	projectID, err := uuid.FromBytes(info.ProjectId)
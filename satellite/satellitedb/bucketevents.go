@@ -0,0 +1,181 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/bucketevents"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/satellitedb/dbx"
+	"storj.io/storj/shared/dbutil/pgutil"
+)
+
+// Ensure that bucketEvents implements bucketevents.DB.
+var _ bucketevents.DB = (*bucketEvents)(nil)
+
+// bucketEvents is an implementation of bucketevents.DB.
+type bucketEvents struct {
+	db *satelliteDB
+}
+
+// SetDestination inserts or updates the destination of a bucket.
+func (events *bucketEvents) SetDestination(ctx context.Context, destination bucketevents.Destination) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := events.db.ExecContext(ctx, events.db.Rebind(`
+		UPDATE bucket_event_destinations
+		SET topic = ?
+		WHERE project_id = ? AND bucket_name = ?
+	`), destination.Topic, destination.ProjectID, []byte(destination.BucketName))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	_, err = events.db.ExecContext(ctx, events.db.Rebind(`
+		INSERT INTO bucket_event_destinations (project_id, bucket_name, topic, created_at)
+		VALUES (?, ?, ?, ?)
+	`), destination.ProjectID, []byte(destination.BucketName), destination.Topic, destination.CreatedAt.UTC())
+	return Error.Wrap(err)
+}
+
+// GetDestination returns the destination of a bucket. It returns bucketevents.ErrDestinationNotFound when it doesn't exist.
+func (events *bucketEvents) GetDestination(ctx context.Context, projectID uuid.UUID, bucketName string) (_ bucketevents.Destination, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	destination := bucketevents.Destination{
+		ProjectID:  projectID,
+		BucketName: bucketName,
+	}
+	err = events.db.QueryRowContext(ctx, events.db.Rebind(`
+		SELECT topic, created_at
+		FROM bucket_event_destinations
+		WHERE project_id = ? AND bucket_name = ?
+	`), projectID, []byte(bucketName)).Scan(&destination.Topic, &destination.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return bucketevents.Destination{}, bucketevents.ErrDestinationNotFound.New("%s/%s", projectID, bucketName)
+	}
+	if err != nil {
+		return bucketevents.Destination{}, Error.Wrap(err)
+	}
+	return destination, nil
+}
+
+// DeleteDestination removes the destination of a bucket.
+func (events *bucketEvents) DeleteDestination(ctx context.Context, projectID uuid.UUID, bucketName string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = events.db.ExecContext(ctx, events.db.Rebind(`
+		DELETE FROM bucket_event_destinations WHERE project_id = ? AND bucket_name = ?
+	`), projectID, []byte(bucketName))
+	return Error.Wrap(err)
+}
+
+// Insert queues events. The events which are already queued are ignored.
+func (events *bucketEvents) Insert(ctx context.Context, list []bucketevents.Event) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(list) == 0 {
+		return nil
+	}
+
+	return Error.Wrap(events.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) error {
+		for _, event := range list {
+			_, err := tx.Tx.ExecContext(ctx, events.db.Rebind(`
+				INSERT INTO bucket_delete_events (
+					id, project_id, bucket_name, object_key, version, stream_id,
+					event_name, size, topic, deleted_at, attempts, next_attempt_at
+				) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				ON CONFLICT (id) DO NOTHING
+			`), event.ID, event.ProjectID, []byte(event.BucketName), []byte(event.ObjectKey),
+				int64(event.Version), event.StreamID, event.Name, event.Size, event.Topic,
+				event.DeletedAt.UTC(), event.Attempts, event.NextAttemptAt.UTC())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// ListDue returns up to limit events whose next attempt is due, the longest waiting first.
+func (events *bucketEvents) ListDue(ctx context.Context, now time.Time, limit int) (_ []bucketevents.Event, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := events.db.QueryContext(ctx, events.db.Rebind(`
+		SELECT
+			id, project_id, bucket_name, object_key, version, stream_id,
+			event_name, size, topic, deleted_at, attempts, next_attempt_at
+		FROM bucket_delete_events
+		WHERE next_attempt_at <= ?
+		ORDER BY next_attempt_at, deleted_at
+		LIMIT ?
+	`), now.UTC(), limit)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var list []bucketevents.Event
+	for rows.Next() {
+		var event bucketevents.Event
+		var bucketName, objectKey []byte
+		var version int64
+		err := rows.Scan(
+			&event.ID, &event.ProjectID, &bucketName, &objectKey, &version, &event.StreamID,
+			&event.Name, &event.Size, &event.Topic, &event.DeletedAt, &event.Attempts, &event.NextAttemptAt)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		event.BucketName = string(bucketName)
+		event.ObjectKey = metabase.ObjectKey(objectKey)
+		event.Version = metabase.Version(version)
+		list = append(list, event)
+	}
+	return list, Error.Wrap(rows.Err())
+}
+
+// Delete removes published events.
+func (events *bucketEvents) Delete(ctx context.Context, ids []uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err = events.db.ExecContext(ctx, events.db.Rebind(`
+		DELETE FROM bucket_delete_events WHERE id = ANY(?::BYTEA[])
+	`), pgutil.UUIDArray(ids))
+	return Error.Wrap(err)
+}
+
+// Retry increments the attempts of failed events and postpones their next attempt.
+func (events *bucketEvents) Retry(ctx context.Context, ids []uuid.UUID, nextAttemptAt time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err = events.db.ExecContext(ctx, events.db.Rebind(`
+		UPDATE bucket_delete_events
+		SET attempts = attempts + 1, next_attempt_at = ?
+		WHERE id = ANY(?::BYTEA[])
+	`), nextAttemptAt.UTC(), pgutil.UUIDArray(ids))
+	return Error.Wrap(err)
+}
@@ -14,6 +14,7 @@ import (
 	"storj.io/storj/satellite/buckets"
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/satellitedb/dbx"
+	"storj.io/storj/shared/tagsql"
 )
 
 type bucketsDB struct {
@@ -25,8 +26,10 @@ func (db *bucketsDB) CreateBucket(ctx context.Context, bucket buckets.Bucket) (_
 	defer mon.Task()(&ctx)(&err)
 
 	optionalFields := dbx.BucketMetainfo_Create_Fields{
-		Placement:         dbx.BucketMetainfo_Placement(int(bucket.Placement)),
-		ObjectLockEnabled: dbx.BucketMetainfo_ObjectLockEnabled(bucket.ObjectLockEnabled),
+		Placement:          dbx.BucketMetainfo_Placement(int(bucket.Placement)),
+		ObjectLockEnabled:  dbx.BucketMetainfo_ObjectLockEnabled(bucket.ObjectLockEnabled),
+		DeletionProtection: dbx.BucketMetainfo_DeletionProtection(bucket.DeletionProtection),
+		OverrideDefaults:   dbx.BucketMetainfo_OverrideDefaults(bucket.OverrideDefaults),
 	}
 	if bucket.UserAgent != nil {
 		optionalFields.UserAgent = dbx.BucketMetainfo_UserAgent(bucket.UserAgent)
@@ -136,8 +139,8 @@ func (db *bucketsDB) EnableBucketVersioning(ctx context.Context, bucketName []by
 		}
 		return buckets.ErrBucket.Wrap(err)
 	}
-	if dbxBucket.Versioning == int(buckets.VersioningUnsupported) {
-		return buckets.ErrConflict.New("versioning is unsupported for this bucket")
+	if err := buckets.Versioning(dbxBucket.Versioning).TransitionTo(buckets.VersioningEnabled); err != nil {
+		return err
 	}
 
 	_, err = db.db.Update_BucketMetainfo_By_ProjectId_And_Name_And_Versioning_GreaterOrEqual(ctx,
@@ -173,8 +176,8 @@ func (db *bucketsDB) SuspendBucketVersioning(ctx context.Context, bucketName []b
 		}
 		return buckets.ErrBucket.Wrap(err)
 	}
-	if dbxBucket.Versioning < int(buckets.VersioningEnabled) {
-		return buckets.ErrConflict.New("versioning may only be suspended for buckets with versioning enabled")
+	if err := buckets.Versioning(dbxBucket.Versioning).TransitionTo(buckets.VersioningSuspended); err != nil {
+		return err
 	}
 	if dbxBucket.ObjectLockEnabled {
 		return buckets.ErrLocked.New("versioning may not be suspended for buckets with Object Lock enabled")
@@ -293,7 +296,12 @@ func (db *bucketsDB) DeleteBucket(ctx context.Context, bucketName []byte, projec
 	if !deleted {
 		return buckets.ErrBucketNotFound.New("%s", bucketName)
 	}
-	return nil
+
+	// the quota must not apply to a new bucket with the same name.
+	_, err = db.db.ExecContext(ctx, db.db.Rebind(`
+		DELETE FROM bucket_quotas WHERE project_id = ? AND bucket_name = ?
+	`), projectID, bucketName)
+	return buckets.ErrBucket.Wrap(err)
 }
 
 // ListBuckets returns a list of buckets for a project.
@@ -420,8 +428,10 @@ func convertDBXtoBucket(dbxBucket *dbx.BucketMetainfo) (bucket buckets.Bucket, e
 			CipherSuite: storj.CipherSuite(dbxBucket.DefaultEncryptionCipherSuite),
 			BlockSize:   int32(dbxBucket.DefaultEncryptionBlockSize),
 		},
-		Versioning:        buckets.Versioning(dbxBucket.Versioning),
-		ObjectLockEnabled: dbxBucket.ObjectLockEnabled,
+		Versioning:         buckets.Versioning(dbxBucket.Versioning),
+		ObjectLockEnabled:  dbxBucket.ObjectLockEnabled,
+		DeletionProtection: dbxBucket.DeletionProtection,
+		OverrideDefaults:   dbxBucket.OverrideDefaults,
 	}
 
 	if dbxBucket.Placement != nil {
@@ -489,3 +499,292 @@ func (db *bucketsDB) GetBucketObjectLockEnabled(ctx context.Context, bucketName
 	}
 	return row.ObjectLockEnabled, nil
 }
+
+// GetBucketDeletionProtection returns whether a bucket has deletion protection enabled.
+func (db *bucketsDB) GetBucketDeletionProtection(ctx context.Context, bucketName []byte, projectID uuid.UUID) (enabled bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = db.db.QueryRowContext(ctx, db.db.Rebind(`
+		SELECT deletion_protection FROM bucket_metainfos
+		WHERE project_id = ? AND name = ?
+	`), projectID, bucketName).Scan(&enabled)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, buckets.ErrBucketNotFound.New("%s", bucketName)
+		}
+		return false, buckets.ErrBucket.Wrap(err)
+	}
+	return enabled, nil
+}
+
+// SetBucketDeletionProtection enables or disables the deletion protection of a bucket.
+func (db *bucketsDB) SetBucketDeletionProtection(ctx context.Context, bucketName []byte, projectID uuid.UUID, enabled bool) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxBucket, err := db.db.Update_BucketMetainfo_By_ProjectId_And_Name(ctx,
+		dbx.BucketMetainfo_ProjectId(projectID[:]),
+		dbx.BucketMetainfo_Name(bucketName),
+		dbx.BucketMetainfo_Update_Fields{
+			DeletionProtection: dbx.BucketMetainfo_DeletionProtection(enabled),
+		},
+	)
+	if err != nil {
+		return buckets.ErrBucket.Wrap(err)
+	}
+	if dbxBucket == nil {
+		return buckets.ErrBucketNotFound.New("%s", bucketName)
+	}
+	return nil
+}
+
+// SetBucketDefaults sets the segment size and redundancy scheme overriding the placement defaults. Zero defaults remove the override.
+func (db *bucketsDB) SetBucketDefaults(ctx context.Context, bucketName []byte, projectID uuid.UUID, defaults buckets.Defaults) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rs := defaults.Redundancy
+	dbxBucket, err := db.db.Update_BucketMetainfo_By_ProjectId_And_Name(ctx,
+		dbx.BucketMetainfo_ProjectId(projectID[:]),
+		dbx.BucketMetainfo_Name(bucketName),
+		dbx.BucketMetainfo_Update_Fields{
+			DefaultSegmentSize:              dbx.BucketMetainfo_DefaultSegmentSize(int(defaults.SegmentSize)),
+			DefaultRedundancyAlgorithm:      dbx.BucketMetainfo_DefaultRedundancyAlgorithm(int(rs.Algorithm)),
+			DefaultRedundancyShareSize:      dbx.BucketMetainfo_DefaultRedundancyShareSize(int(rs.ShareSize)),
+			DefaultRedundancyRequiredShares: dbx.BucketMetainfo_DefaultRedundancyRequiredShares(int(rs.RequiredShares)),
+			DefaultRedundancyRepairShares:   dbx.BucketMetainfo_DefaultRedundancyRepairShares(int(rs.RepairShares)),
+			DefaultRedundancyOptimalShares:  dbx.BucketMetainfo_DefaultRedundancyOptimalShares(int(rs.OptimalShares)),
+			DefaultRedundancyTotalShares:    dbx.BucketMetainfo_DefaultRedundancyTotalShares(int(rs.TotalShares)),
+			OverrideDefaults:                dbx.BucketMetainfo_OverrideDefaults(!defaults.IsZero()),
+		},
+	)
+	if err != nil {
+		return buckets.ErrBucket.Wrap(err)
+	}
+	if dbxBucket == nil {
+		return buckets.ErrBucketNotFound.New("%s", bucketName)
+	}
+	return nil
+}
+
+// GetBucketLifecycle returns the lifecycle configuration of a bucket.
+func (db *bucketsDB) GetBucketLifecycle(ctx context.Context, bucketName []byte, projectID uuid.UUID) (config buckets.LifecycleConfiguration, err error) {
+	defer mon.Task()(&ctx)(&err)
+	dbxBucket, err := db.db.Get_BucketMetainfo_By_ProjectId_And_Name(ctx,
+		dbx.BucketMetainfo_ProjectId(projectID[:]),
+		dbx.BucketMetainfo_Name(bucketName),
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return buckets.LifecycleConfiguration{}, buckets.ErrBucketNotFound.New("%s", bucketName)
+		}
+		return buckets.LifecycleConfiguration{}, buckets.ErrBucket.Wrap(err)
+	}
+
+	config, err = buckets.UnmarshalLifecycleConfiguration(dbxBucket.LifecycleConfiguration)
+	if err != nil {
+		return buckets.LifecycleConfiguration{}, buckets.ErrBucket.Wrap(err)
+	}
+	return config, nil
+}
+
+// SetBucketLifecycle sets the lifecycle configuration of a bucket. An empty configuration removes it.
+func (db *bucketsDB) SetBucketLifecycle(ctx context.Context, bucketName []byte, projectID uuid.UUID, config buckets.LifecycleConfiguration) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	data, err := config.Marshal()
+	if err != nil {
+		return buckets.ErrBucket.Wrap(err)
+	}
+
+	dbxBucket, err := db.db.Update_BucketMetainfo_By_ProjectId_And_Name(ctx,
+		dbx.BucketMetainfo_ProjectId(projectID[:]),
+		dbx.BucketMetainfo_Name(bucketName),
+		dbx.BucketMetainfo_Update_Fields{
+			LifecycleConfiguration: dbx.BucketMetainfo_LifecycleConfiguration_Raw(data),
+		},
+	)
+	if err != nil {
+		return buckets.ErrBucket.Wrap(err)
+	}
+	if dbxBucket == nil {
+		return buckets.ErrBucketNotFound.New("%s", bucketName)
+	}
+	return nil
+}
+
+// IterateBucketLifecycles iterates through all buckets with a lifecycle configuration with specific page size.
+func (db *bucketsDB) IterateBucketLifecycles(ctx context.Context, pageSize int, fn func([]buckets.BucketLifecycle) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if pageSize <= 0 {
+		return Error.New("page size must be positive")
+	}
+
+	lastProjectID, lastName := []byte{}, []byte{}
+	page := make([]buckets.BucketLifecycle, 0, pageSize)
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		page = page[:0]
+		err = withRows(db.db.QueryContext(ctx, db.db.Rebind(`
+			SELECT project_id, name, versioning, object_lock_enabled, deletion_protection, lifecycle_configuration
+			FROM bucket_metainfos
+			WHERE lifecycle_configuration IS NOT NULL
+				AND (project_id > ? OR (project_id = ? AND name > ?))
+			ORDER BY project_id ASC, name ASC
+			LIMIT ?
+		`), lastProjectID, lastProjectID, lastName, int64(pageSize)))(func(rows tagsql.Rows) error {
+			for rows.Next() {
+				var projectID, name, data []byte
+				var versioning int64
+				var objectLockEnabled, deletionProtection bool
+				if err := rows.Scan(&projectID, &name, &versioning, &objectLockEnabled, &deletionProtection, &data); err != nil {
+					return err
+				}
+
+				id, err := uuid.FromBytes(projectID)
+				if err != nil {
+					return err
+				}
+				config, err := buckets.UnmarshalLifecycleConfiguration(data)
+				if err != nil {
+					return err
+				}
+				item := buckets.BucketLifecycle{
+					ProjectID:          id,
+					BucketName:         metabase.BucketName(name),
+					Versioning:         buckets.Versioning(versioning),
+					ObjectLockEnabled:  objectLockEnabled,
+					DeletionProtection: deletionProtection,
+					Configuration:      config,
+				}
+
+				lastProjectID, lastName = projectID, name
+				page = append(page, item)
+			}
+			return nil
+		})
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		if len(page) == 0 {
+			return nil
+		}
+
+		if err := fn(page); err != nil {
+			return Error.Wrap(err)
+		}
+
+		if len(page) < pageSize {
+			return nil
+		}
+	}
+}
+
+// GetBucketQuota returns the object and segment quota of a bucket. It returns a zero quota when none is set.
+func (db *bucketsDB) GetBucketQuota(ctx context.Context, bucketName []byte, projectID uuid.UUID) (quota buckets.Quota, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = db.db.QueryRowContext(ctx, db.db.Rebind(`
+		SELECT max_objects, max_segments FROM bucket_quotas
+		WHERE project_id = ? AND bucket_name = ?
+	`), projectID, bucketName).Scan(&quota.MaxObjects, &quota.MaxSegments)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return buckets.Quota{}, nil
+		}
+		return buckets.Quota{}, buckets.ErrBucket.Wrap(err)
+	}
+	return quota, nil
+}
+
+// SetBucketQuota sets the object and segment quota of a bucket. A zero quota removes it.
+func (db *bucketsDB) SetBucketQuota(ctx context.Context, bucketName []byte, projectID uuid.UUID, quota buckets.Quota) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	exists, err := db.HasBucket(ctx, bucketName, projectID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return buckets.ErrBucketNotFound.New("%s", bucketName)
+	}
+
+	if quota.IsZero() {
+		_, err = db.db.ExecContext(ctx, db.db.Rebind(`
+			DELETE FROM bucket_quotas WHERE project_id = ? AND bucket_name = ?
+		`), projectID, bucketName)
+		return buckets.ErrBucket.Wrap(err)
+	}
+
+	result, err := db.db.ExecContext(ctx, db.db.Rebind(`
+		UPDATE bucket_quotas
+		SET max_objects = ?, max_segments = ?, updated_at = current_timestamp
+		WHERE project_id = ? AND bucket_name = ?
+	`), quota.MaxObjects, quota.MaxSegments, projectID, bucketName)
+	if err != nil {
+		return buckets.ErrBucket.Wrap(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return buckets.ErrBucket.Wrap(err)
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	_, err = db.db.ExecContext(ctx, db.db.Rebind(`
+		INSERT INTO bucket_quotas (project_id, bucket_name, max_objects, max_segments)
+		VALUES (?, ?, ?, ?)
+	`), projectID, bucketName, quota.MaxObjects, quota.MaxSegments)
+	return buckets.ErrBucket.Wrap(err)
+}
+
+// GetBucketCORS returns the CORS configuration of a bucket.
+func (db *bucketsDB) GetBucketCORS(ctx context.Context, bucketName []byte, projectID uuid.UUID) (config buckets.CORSConfiguration, err error) {
+	defer mon.Task()(&ctx)(&err)
+	dbxBucket, err := db.db.Get_BucketMetainfo_By_ProjectId_And_Name(ctx,
+		dbx.BucketMetainfo_ProjectId(projectID[:]),
+		dbx.BucketMetainfo_Name(bucketName),
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return buckets.CORSConfiguration{}, buckets.ErrBucketNotFound.New("%s", bucketName)
+		}
+		return buckets.CORSConfiguration{}, buckets.ErrBucket.Wrap(err)
+	}
+
+	config, err = buckets.UnmarshalCORSConfiguration(dbxBucket.CorsConfiguration)
+	if err != nil {
+		return buckets.CORSConfiguration{}, buckets.ErrBucket.Wrap(err)
+	}
+	return config, nil
+}
+
+// SetBucketCORS sets the CORS configuration of a bucket. An empty configuration removes it.
+func (db *bucketsDB) SetBucketCORS(ctx context.Context, bucketName []byte, projectID uuid.UUID, config buckets.CORSConfiguration) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	data, err := config.Marshal()
+	if err != nil {
+		return buckets.ErrBucket.Wrap(err)
+	}
+
+	dbxBucket, err := db.db.Update_BucketMetainfo_By_ProjectId_And_Name(ctx,
+		dbx.BucketMetainfo_ProjectId(projectID[:]),
+		dbx.BucketMetainfo_Name(bucketName),
+		dbx.BucketMetainfo_Update_Fields{
+			CorsConfiguration: dbx.BucketMetainfo_CorsConfiguration_Raw(data),
+		},
+	)
+	if err != nil {
+		return buckets.ErrBucket.Wrap(err)
+	}
+	if dbxBucket == nil {
+		return buckets.ErrBucketNotFound.New("%s", bucketName)
+	}
+	return nil
+}
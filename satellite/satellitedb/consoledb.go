@@ -80,11 +80,41 @@ func (db *ConsoleDB) WebappSessions() consoleauth.WebappSessions {
 	return &webappSessions{db: db.methods}
 }
 
+// ImpersonationAudits is a getter for ImpersonationAudits repository.
+func (db *ConsoleDB) ImpersonationAudits() console.ImpersonationAudits {
+	return &impersonationAudits{db: db.methods}
+}
+
 // AccountFreezeEvents is a getter for AccountFreezeEvents repository.
 func (db *ConsoleDB) AccountFreezeEvents() console.AccountFreezeEvents {
 	return &accountFreezeEvents{db: db.methods}
 }
 
+// AbuseReports is a getter for AbuseReports repository.
+func (db *ConsoleDB) AbuseReports() console.AbuseReports {
+	return &abuseReports{db: db.methods}
+}
+
+// Domains is a getter for Domains repository.
+func (db *ConsoleDB) Domains() console.Domains {
+	return &domains{db: db.methods}
+}
+
+// ProjectBudgetCaps is a getter for ProjectBudgetCaps repository.
+func (db *ConsoleDB) ProjectBudgetCaps() console.ProjectBudgetCaps {
+	return &projectBudgetCaps{db: db.methods}
+}
+
+// ProjectUsageWebhooks is a getter for ProjectUsageWebhooks repository.
+func (db *ConsoleDB) ProjectUsageWebhooks() console.ProjectUsageWebhooks {
+	return &projectUsageWebhooks{db: db.methods}
+}
+
+// SSOIdentities is a getter for SSOIdentities repository.
+func (db *ConsoleDB) SSOIdentities() console.SSOIdentities {
+	return &ssoIdentities{db: db.methods}
+}
+
 // WithTx is a method for executing and retrying transaction.
 func (db *ConsoleDB) WithTx(ctx context.Context, fn func(context.Context, console.DBTx) error) error {
 	if db.db == nil {
@@ -14,16 +14,21 @@ import (
 	"storj.io/storj/private/migrate"
 	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/accounting/warehouse"
 	"storj.io/storj/satellite/attribution"
 	"storj.io/storj/satellite/audit"
+	"storj.io/storj/satellite/bucketevents"
 	"storj.io/storj/satellite/buckets"
 	"storj.io/storj/satellite/compensation"
 	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/metabase/zombiedeletion"
 	"storj.io/storj/satellite/nodeapiversion"
 	"storj.io/storj/satellite/nodeevents"
 	"storj.io/storj/satellite/oidc"
 	"storj.io/storj/satellite/orders"
 	"storj.io/storj/satellite/overlay"
+	"storj.io/storj/satellite/overlay/attestation"
+	"storj.io/storj/satellite/overlay/selectionfairness"
 	"storj.io/storj/satellite/payments/billing"
 	"storj.io/storj/satellite/payments/storjscan"
 	"storj.io/storj/satellite/payments/stripe"
@@ -31,6 +36,7 @@ import (
 	"storj.io/storj/satellite/reputation"
 	"storj.io/storj/satellite/revocation"
 	"storj.io/storj/satellite/satellitedb/dbx"
+	"storj.io/storj/satellite/sla"
 	"storj.io/storj/satellite/snopayouts"
 	"storj.io/storj/shared/dbutil"
 	"storj.io/storj/shared/dbutil/pgutil"
@@ -290,6 +296,36 @@ func (dbc *satelliteDBCollection) SNOPayouts() snopayouts.DB {
 	return &snopayoutsDB{db: dbc.getByName("snopayouts")}
 }
 
+// SLA returns database for the daily SLA records of placements.
+func (dbc *satelliteDBCollection) SLA() sla.DB {
+	return &slaDB{db: dbc.getByName("sla")}
+}
+
+// AttestationIssuers returns database for the accepted node attestation issuers.
+func (dbc *satelliteDBCollection) AttestationIssuers() attestation.DB {
+	return &attestationIssuers{db: dbc.getByName("attestation")}
+}
+
+// BucketEvents returns database for the bucket event destinations and the unpublished delete events.
+func (dbc *satelliteDBCollection) BucketEvents() bucketevents.DB {
+	return &bucketEvents{db: dbc.getByName("bucketevents")}
+}
+
+// WarehouseExports returns database for the progress of exporting the rollups to a data warehouse.
+func (dbc *satelliteDBCollection) WarehouseExports() warehouse.DB {
+	return &warehouseExports{db: dbc.getByName("warehouseexports")}
+}
+
+// SelectionFairness returns database for the node selection fairness report.
+func (dbc *satelliteDBCollection) SelectionFairness() selectionfairness.DB {
+	return &selectionFairnessDB{db: dbc.getByName("selectionfairness")}
+}
+
+// ZombieDeletion returns database for the zombie deletion settings of projects and buckets.
+func (dbc *satelliteDBCollection) ZombieDeletion() zombiedeletion.DB {
+	return &zombieDeletionDB{db: dbc.getByName("zombiedeletion")}
+}
+
 // Compensation returns database for storage node compensation.
 func (dbc *satelliteDBCollection) Compensation() compensation.DB {
 	return &compensationDB{db: dbc.getByName("compensation")}
@@ -338,6 +338,19 @@ func newpgx(db *DB) *pgxDB {
 func (obj *pgxDB) Schema() []string {
 	return []string{
 
+		`CREATE TABLE abuse_reports (
+	id bytea NOT NULL,
+	url text NOT NULL,
+	access_key_id text NOT NULL,
+	reason text NOT NULL,
+	reporter_email text NOT NULL,
+	status integer NOT NULL DEFAULT 0,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	reviewed_at timestamp with time zone,
+	reviewed_by text,
+	PRIMARY KEY ( id )
+)`,
+
 		`CREATE TABLE account_freeze_events (
 	user_id bytea NOT NULL,
 	event integer NOT NULL,
@@ -367,6 +380,25 @@ func (obj *pgxDB) Schema() []string {
 	PRIMARY KEY ( name )
 )`,
 
+		`CREATE TABLE archived_nodes (
+	id bytea NOT NULL,
+	address text NOT NULL,
+	last_net text NOT NULL,
+	last_ip_port text,
+	country_code text,
+	email text NOT NULL,
+	wallet text NOT NULL,
+	wallet_features text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	last_contact_success timestamp with time zone NOT NULL,
+	disqualified timestamp with time zone,
+	disqualification_reason integer,
+	exit_finished_at timestamp with time zone,
+	exit_success boolean NOT NULL,
+	archived_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( id )
+)`,
+
 		`CREATE TABLE billing_balances (
 	user_id bytea NOT NULL,
 	balance bigint NOT NULL,
@@ -413,6 +445,49 @@ func (obj *pgxDB) Schema() []string {
 	PRIMARY KEY ( bucket_name, project_id, interval_start, action )
 )`,
 
+		`CREATE TABLE bucket_delete_events (
+	id bytea NOT NULL,
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	object_key bytea NOT NULL,
+	version bigint NOT NULL,
+	stream_id bytea NOT NULL,
+	event_name text NOT NULL,
+	size bigint NOT NULL,
+	topic text NOT NULL,
+	deleted_at timestamp with time zone NOT NULL,
+	attempts integer NOT NULL DEFAULT 0,
+	next_attempt_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+)`,
+
+		`CREATE TABLE bucket_event_destinations (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	topic text NOT NULL,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( project_id, bucket_name )
+)`,
+
+		`CREATE TABLE bucket_partner_rollups (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	partner text NOT NULL,
+	interval_start timestamp with time zone NOT NULL,
+	objects bigint NOT NULL,
+	bytes bigint NOT NULL,
+	PRIMARY KEY ( project_id, bucket_name, partner, interval_start )
+)`,
+
+		`CREATE TABLE bucket_quotas (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	max_objects bigint NOT NULL,
+	max_segments bigint NOT NULL,
+	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( project_id, bucket_name )
+)`,
+
 		`CREATE TABLE bucket_storage_tallies (
 	bucket_name bytea NOT NULL,
 	project_id bytea NOT NULL,
@@ -441,6 +516,20 @@ func (obj *pgxDB) Schema() []string {
 	PRIMARY KEY ( id )
 )`,
 
+		`CREATE TABLE domains (
+	subdomain text NOT NULL,
+	project_id bytea NOT NULL,
+	created_by bytea NOT NULL,
+	prefix text NOT NULL,
+	access_id text NOT NULL,
+	verification_token text NOT NULL,
+	status integer NOT NULL DEFAULT 0,
+	status_message text,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( subdomain )
+)`,
+
 		`CREATE TABLE graceful_exit_progress (
 	node_id bytea NOT NULL,
 	bytes_transferred bigint NOT NULL,
@@ -467,6 +556,18 @@ func (obj *pgxDB) Schema() []string {
 	PRIMARY KEY ( node_id, stream_id, position, piece_num )
 )`,
 
+		`CREATE TABLE impersonation_audits (
+	session_id bytea NOT NULL,
+	user_id bytea NOT NULL,
+	admin_email text NOT NULL,
+	reason text NOT NULL,
+	consent boolean NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	expires_at timestamp with time zone NOT NULL,
+	ended_at timestamp with time zone,
+	PRIMARY KEY ( session_id )
+)`,
+
 		`CREATE TABLE nodes (
 	id bytea NOT NULL,
 	address text NOT NULL DEFAULT '',
@@ -518,6 +619,21 @@ func (obj *pgxDB) Schema() []string {
 	PRIMARY KEY ( id )
 )`,
 
+		`CREATE TABLE node_attestation_issuers (
+	id bytea NOT NULL,
+	name text NOT NULL,
+	identity bytea NOT NULL,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( id )
+)`,
+
+		`CREATE TABLE node_drains (
+	node_id bytea NOT NULL,
+	reason text,
+	initiated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( node_id )
+)`,
+
 		`CREATE TABLE node_events (
 	id bytea NOT NULL,
 	email text NOT NULL,
@@ -530,6 +646,18 @@ func (obj *pgxDB) Schema() []string {
 	PRIMARY KEY ( id )
 )`,
 
+		`CREATE TABLE node_selection_fairness_entries (
+	node_id bytea NOT NULL,
+	free_disk bigint NOT NULL,
+	uploaded_bytes bigint NOT NULL,
+	expected_share double precision NOT NULL,
+	actual_share double precision NOT NULL,
+	ratio double precision NOT NULL,
+	status text NOT NULL,
+	generated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id )
+)`,
+
 		`CREATE TABLE node_tags (
 	node_id bytea NOT NULL,
 	name text NOT NULL,
@@ -539,6 +667,13 @@ func (obj *pgxDB) Schema() []string {
 	PRIMARY KEY ( node_id, name, signer )
 )`,
 
+		`CREATE TABLE node_uptime_transitions (
+	node_id bytea NOT NULL,
+	changed_at timestamp with time zone NOT NULL,
+	online boolean NOT NULL,
+	PRIMARY KEY ( node_id, changed_at )
+)`,
+
 		`CREATE TABLE oauth_clients (
 	id bytea NOT NULL,
 	encrypted_secret bytea NOT NULL,
@@ -582,6 +717,17 @@ func (obj *pgxDB) Schema() []string {
 	PRIMARY KEY ( node_id )
 )`,
 
+		`CREATE TABLE placement_sla_dailies (
+	day timestamp with time zone NOT NULL,
+	placement integer NOT NULL,
+	probes bigint NOT NULL DEFAULT 0,
+	failed_probes bigint NOT NULL DEFAULT 0,
+	max_repair_queue_segments bigint NOT NULL DEFAULT 0,
+	min_segment_health double precision,
+	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( day, placement )
+)`,
+
 		`CREATE TABLE projects (
 	id bytea NOT NULL,
 	public_id bytea,
@@ -627,6 +773,30 @@ func (obj *pgxDB) Schema() []string {
 	PRIMARY KEY ( project_id, interval_day )
 )`,
 
+		`CREATE TABLE project_budget_caps (
+	project_id bytea NOT NULL,
+	cap_cents bigint NOT NULL,
+	notified_percent integer NOT NULL DEFAULT 0,
+	period_start timestamp with time zone NOT NULL,
+	frozen_at timestamp with time zone,
+	frozen_storage_limit bigint,
+	frozen_bandwidth_limit bigint,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( project_id )
+)`,
+
+		`CREATE TABLE project_usage_webhooks (
+	id bytea NOT NULL,
+	project_id bytea NOT NULL,
+	url text NOT NULL,
+	secret bytea NOT NULL,
+	created_by bytea NOT NULL,
+	last_sent_day timestamp with time zone,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( project_id, id )
+)`,
+
 		`CREATE TABLE registration_tokens (
 	secret bytea NOT NULL,
 	owner_id bytea,
@@ -644,6 +814,9 @@ func (obj *pgxDB) Schema() []string {
 	inserted_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
 	segment_health double precision NOT NULL DEFAULT 1,
 	placement integer,
+	reason integer NOT NULL DEFAULT 0,
+	leased_by text,
+	lease_expires_at timestamp with time zone,
 	PRIMARY KEY ( stream_id, position )
 )`,
 
@@ -705,6 +878,15 @@ func (obj *pgxDB) Schema() []string {
 	PRIMARY KEY ( node_id )
 )`,
 
+		`CREATE TABLE sso_identities (
+	provider text NOT NULL,
+	subject text NOT NULL,
+	user_id bytea NOT NULL,
+	email text NOT NULL,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( provider, subject )
+)`,
+
 		`CREATE TABLE storagenode_bandwidth_rollups (
 	storagenode_id bytea NOT NULL,
 	interval_start timestamp with time zone NOT NULL,
@@ -906,6 +1088,13 @@ func (obj *pgxDB) Schema() []string {
 	PRIMARY KEY ( inserted_at, stream_id, position )
 )`,
 
+		`CREATE TABLE warehouse_export_markers (
+	stream text NOT NULL,
+	exported_until timestamp with time zone NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( stream )
+)`,
+
 		`CREATE TABLE webapp_sessions (
 	id bytea NOT NULL,
 	user_id bytea NOT NULL,
@@ -916,6 +1105,14 @@ func (obj *pgxDB) Schema() []string {
 	PRIMARY KEY ( id )
 )`,
 
+		`CREATE TABLE zombie_deletion_settings (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	inactive_for_seconds bigint NOT NULL,
+	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( project_id, bucket_name )
+)`,
+
 		`CREATE TABLE api_keys (
 	id bytea NOT NULL,
 	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
@@ -951,6 +1148,10 @@ func (obj *pgxDB) Schema() []string {
 	default_redundancy_total_shares integer NOT NULL,
 	placement integer,
 	created_by bytea REFERENCES users( id ),
+	lifecycle_configuration bytea,
+	deletion_protection boolean NOT NULL DEFAULT false,
+	override_defaults boolean NOT NULL DEFAULT false,
+	cors_configuration bytea,
 	PRIMARY KEY ( project_id, name )
 )`,
 
@@ -989,10 +1190,16 @@ func (obj *pgxDB) Schema() []string {
 
 		`CREATE INDEX bucket_bandwidth_rollups_archive_action_interval_project_id_index ON bucket_bandwidth_rollup_archives ( action, interval_start, project_id )`,
 
+		`CREATE INDEX bucket_delete_events_next_attempt_at_index ON bucket_delete_events ( next_attempt_at )`,
+
+		`CREATE INDEX bucket_partner_rollups_partner_interval_start_index ON bucket_partner_rollups ( partner, interval_start )`,
+
 		`CREATE INDEX bucket_storage_tallies_project_id_interval_start_index ON bucket_storage_tallies ( project_id, interval_start )`,
 
 		`CREATE INDEX bucket_storage_tallies_interval_start_index ON bucket_storage_tallies ( interval_start )`,
 
+		`CREATE INDEX domains_project_id_index ON domains ( project_id )`,
+
 		`CREATE INDEX graceful_exit_segment_transfer_nid_dr_qa_fa_lfa_index ON graceful_exit_segment_transfer_queue ( node_id, durability_ratio, queued_at, finished_at, last_failed_at )`,
 
 		`CREATE INDEX node_last_ip ON nodes ( last_net )`,
@@ -1003,8 +1210,12 @@ func (obj *pgxDB) Schema() []string {
 
 		`CREATE INDEX nodes_dis_unk_aud_exit_init_rel_last_cont_success_stored_index ON nodes ( disqualified, unknown_audit_suspended, exit_initiated_at, release, last_contact_success ) WHERE nodes.disqualified is NULL AND nodes.unknown_audit_suspended is NULL AND nodes.exit_initiated_at is NULL AND nodes.release = true`,
 
+		`CREATE INDEX nodes_wallet_index ON nodes ( wallet )`,
+
 		`CREATE INDEX node_events_email_event_created_at_index ON node_events ( email, event, created_at ) WHERE node_events.email_sent is NULL`,
 
+		`CREATE INDEX node_uptime_transitions_changed_at_index ON node_uptime_transitions ( changed_at )`,
+
 		`CREATE INDEX oauth_clients_user_id_index ON oauth_clients ( user_id )`,
 
 		`CREATE INDEX oauth_codes_user_id_index ON oauth_codes ( user_id )`,
@@ -1027,6 +1238,8 @@ func (obj *pgxDB) Schema() []string {
 
 		`CREATE INDEX repair_queue_placement_index ON repair_queue ( placement )`,
 
+		`CREATE INDEX repair_queue_lease_expires_at_index ON repair_queue ( lease_expires_at )`,
+
 		`CREATE INDEX reverification_audits_inserted_at_index ON reverification_audits ( inserted_at )`,
 
 		`CREATE INDEX storagenode_bandwidth_rollups_interval_start_index ON storagenode_bandwidth_rollups ( interval_start )`,
@@ -1072,8 +1285,12 @@ func (obj *pgxDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS api_keys`,
 
+		`DROP TABLE IF EXISTS zombie_deletion_settings`,
+
 		`DROP TABLE IF EXISTS webapp_sessions`,
 
+		`DROP TABLE IF EXISTS warehouse_export_markers`,
+
 		`DROP TABLE IF EXISTS verification_audits`,
 
 		`DROP TABLE IF EXISTS value_attributions`,
@@ -1104,6 +1321,8 @@ func (obj *pgxDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS storagenode_bandwidth_rollups`,
 
+		`DROP TABLE IF EXISTS sso_identities`,
+
 		`DROP TABLE IF EXISTS segment_pending_audits`,
 
 		`DROP TABLE IF EXISTS revocations`,
@@ -1118,10 +1337,16 @@ func (obj *pgxDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS registration_tokens`,
 
+		`DROP TABLE IF EXISTS project_usage_webhooks`,
+
+		`DROP TABLE IF EXISTS project_budget_caps`,
+
 		`DROP TABLE IF EXISTS project_bandwidth_daily_rollups`,
 
 		`DROP TABLE IF EXISTS projects`,
 
+		`DROP TABLE IF EXISTS placement_sla_dailies`,
+
 		`DROP TABLE IF EXISTS peer_identities`,
 
 		`DROP TABLE IF EXISTS oauth_tokens`,
@@ -1130,22 +1355,42 @@ func (obj *pgxDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS oauth_clients`,
 
+		`DROP TABLE IF EXISTS node_uptime_transitions`,
+
 		`DROP TABLE IF EXISTS node_tags`,
 
+		`DROP TABLE IF EXISTS node_selection_fairness_entries`,
+
 		`DROP TABLE IF EXISTS node_events`,
 
+		`DROP TABLE IF EXISTS node_drains`,
+
+		`DROP TABLE IF EXISTS node_attestation_issuers`,
+
 		`DROP TABLE IF EXISTS node_api_versions`,
 
 		`DROP TABLE IF EXISTS nodes`,
 
+		`DROP TABLE IF EXISTS impersonation_audits`,
+
 		`DROP TABLE IF EXISTS graceful_exit_segment_transfer_queue`,
 
 		`DROP TABLE IF EXISTS graceful_exit_progress`,
 
+		`DROP TABLE IF EXISTS domains`,
+
 		`DROP TABLE IF EXISTS coinpayments_transactions`,
 
 		`DROP TABLE IF EXISTS bucket_storage_tallies`,
 
+		`DROP TABLE IF EXISTS bucket_quotas`,
+
+		`DROP TABLE IF EXISTS bucket_partner_rollups`,
+
+		`DROP TABLE IF EXISTS bucket_event_destinations`,
+
+		`DROP TABLE IF EXISTS bucket_delete_events`,
+
 		`DROP TABLE IF EXISTS bucket_bandwidth_rollup_archives`,
 
 		`DROP TABLE IF EXISTS bucket_bandwidth_rollups`,
@@ -1154,11 +1399,15 @@ func (obj *pgxDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS billing_balances`,
 
+		`DROP TABLE IF EXISTS archived_nodes`,
+
 		`DROP TABLE IF EXISTS accounting_timestamps`,
 
 		`DROP TABLE IF EXISTS accounting_rollups`,
 
 		`DROP TABLE IF EXISTS account_freeze_events`,
+
+		`DROP TABLE IF EXISTS abuse_reports`,
 	}
 }
 
@@ -1261,6 +1510,19 @@ func newpgxcockroach(db *DB) *pgxcockroachDB {
 func (obj *pgxcockroachDB) Schema() []string {
 	return []string{
 
+		`CREATE TABLE abuse_reports (
+	id bytea NOT NULL,
+	url text NOT NULL,
+	access_key_id text NOT NULL,
+	reason text NOT NULL,
+	reporter_email text NOT NULL,
+	status integer NOT NULL DEFAULT 0,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	reviewed_at timestamp with time zone,
+	reviewed_by text,
+	PRIMARY KEY ( id )
+)`,
+
 		`CREATE TABLE account_freeze_events (
 	user_id bytea NOT NULL,
 	event integer NOT NULL,
@@ -1290,6 +1552,25 @@ func (obj *pgxcockroachDB) Schema() []string {
 	PRIMARY KEY ( name )
 )`,
 
+		`CREATE TABLE archived_nodes (
+	id bytea NOT NULL,
+	address text NOT NULL,
+	last_net text NOT NULL,
+	last_ip_port text,
+	country_code text,
+	email text NOT NULL,
+	wallet text NOT NULL,
+	wallet_features text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	last_contact_success timestamp with time zone NOT NULL,
+	disqualified timestamp with time zone,
+	disqualification_reason integer,
+	exit_finished_at timestamp with time zone,
+	exit_success boolean NOT NULL,
+	archived_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( id )
+)`,
+
 		`CREATE TABLE billing_balances (
 	user_id bytea NOT NULL,
 	balance bigint NOT NULL,
@@ -1336,6 +1617,49 @@ func (obj *pgxcockroachDB) Schema() []string {
 	PRIMARY KEY ( bucket_name, project_id, interval_start, action )
 )`,
 
+		`CREATE TABLE bucket_delete_events (
+	id bytea NOT NULL,
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	object_key bytea NOT NULL,
+	version bigint NOT NULL,
+	stream_id bytea NOT NULL,
+	event_name text NOT NULL,
+	size bigint NOT NULL,
+	topic text NOT NULL,
+	deleted_at timestamp with time zone NOT NULL,
+	attempts integer NOT NULL DEFAULT 0,
+	next_attempt_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+)`,
+
+		`CREATE TABLE bucket_event_destinations (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	topic text NOT NULL,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( project_id, bucket_name )
+)`,
+
+		`CREATE TABLE bucket_partner_rollups (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	partner text NOT NULL,
+	interval_start timestamp with time zone NOT NULL,
+	objects bigint NOT NULL,
+	bytes bigint NOT NULL,
+	PRIMARY KEY ( project_id, bucket_name, partner, interval_start )
+)`,
+
+		`CREATE TABLE bucket_quotas (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	max_objects bigint NOT NULL,
+	max_segments bigint NOT NULL,
+	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( project_id, bucket_name )
+)`,
+
 		`CREATE TABLE bucket_storage_tallies (
 	bucket_name bytea NOT NULL,
 	project_id bytea NOT NULL,
@@ -1364,6 +1688,20 @@ func (obj *pgxcockroachDB) Schema() []string {
 	PRIMARY KEY ( id )
 )`,
 
+		`CREATE TABLE domains (
+	subdomain text NOT NULL,
+	project_id bytea NOT NULL,
+	created_by bytea NOT NULL,
+	prefix text NOT NULL,
+	access_id text NOT NULL,
+	verification_token text NOT NULL,
+	status integer NOT NULL DEFAULT 0,
+	status_message text,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( subdomain )
+)`,
+
 		`CREATE TABLE graceful_exit_progress (
 	node_id bytea NOT NULL,
 	bytes_transferred bigint NOT NULL,
@@ -1390,6 +1728,18 @@ func (obj *pgxcockroachDB) Schema() []string {
 	PRIMARY KEY ( node_id, stream_id, position, piece_num )
 )`,
 
+		`CREATE TABLE impersonation_audits (
+	session_id bytea NOT NULL,
+	user_id bytea NOT NULL,
+	admin_email text NOT NULL,
+	reason text NOT NULL,
+	consent boolean NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	expires_at timestamp with time zone NOT NULL,
+	ended_at timestamp with time zone,
+	PRIMARY KEY ( session_id )
+)`,
+
 		`CREATE TABLE nodes (
 	id bytea NOT NULL,
 	address text NOT NULL DEFAULT '',
@@ -1441,6 +1791,21 @@ func (obj *pgxcockroachDB) Schema() []string {
 	PRIMARY KEY ( id )
 )`,
 
+		`CREATE TABLE node_attestation_issuers (
+	id bytea NOT NULL,
+	name text NOT NULL,
+	identity bytea NOT NULL,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( id )
+)`,
+
+		`CREATE TABLE node_drains (
+	node_id bytea NOT NULL,
+	reason text,
+	initiated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( node_id )
+)`,
+
 		`CREATE TABLE node_events (
 	id bytea NOT NULL,
 	email text NOT NULL,
@@ -1453,6 +1818,18 @@ func (obj *pgxcockroachDB) Schema() []string {
 	PRIMARY KEY ( id )
 )`,
 
+		`CREATE TABLE node_selection_fairness_entries (
+	node_id bytea NOT NULL,
+	free_disk bigint NOT NULL,
+	uploaded_bytes bigint NOT NULL,
+	expected_share double precision NOT NULL,
+	actual_share double precision NOT NULL,
+	ratio double precision NOT NULL,
+	status text NOT NULL,
+	generated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id )
+)`,
+
 		`CREATE TABLE node_tags (
 	node_id bytea NOT NULL,
 	name text NOT NULL,
@@ -1462,6 +1839,13 @@ func (obj *pgxcockroachDB) Schema() []string {
 	PRIMARY KEY ( node_id, name, signer )
 )`,
 
+		`CREATE TABLE node_uptime_transitions (
+	node_id bytea NOT NULL,
+	changed_at timestamp with time zone NOT NULL,
+	online boolean NOT NULL,
+	PRIMARY KEY ( node_id, changed_at )
+)`,
+
 		`CREATE TABLE oauth_clients (
 	id bytea NOT NULL,
 	encrypted_secret bytea NOT NULL,
@@ -1505,6 +1889,17 @@ func (obj *pgxcockroachDB) Schema() []string {
 	PRIMARY KEY ( node_id )
 )`,
 
+		`CREATE TABLE placement_sla_dailies (
+	day timestamp with time zone NOT NULL,
+	placement integer NOT NULL,
+	probes bigint NOT NULL DEFAULT 0,
+	failed_probes bigint NOT NULL DEFAULT 0,
+	max_repair_queue_segments bigint NOT NULL DEFAULT 0,
+	min_segment_health double precision,
+	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( day, placement )
+)`,
+
 		`CREATE TABLE projects (
 	id bytea NOT NULL,
 	public_id bytea,
@@ -1550,6 +1945,30 @@ func (obj *pgxcockroachDB) Schema() []string {
 	PRIMARY KEY ( project_id, interval_day )
 )`,
 
+		`CREATE TABLE project_budget_caps (
+	project_id bytea NOT NULL,
+	cap_cents bigint NOT NULL,
+	notified_percent integer NOT NULL DEFAULT 0,
+	period_start timestamp with time zone NOT NULL,
+	frozen_at timestamp with time zone,
+	frozen_storage_limit bigint,
+	frozen_bandwidth_limit bigint,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( project_id )
+)`,
+
+		`CREATE TABLE project_usage_webhooks (
+	id bytea NOT NULL,
+	project_id bytea NOT NULL,
+	url text NOT NULL,
+	secret bytea NOT NULL,
+	created_by bytea NOT NULL,
+	last_sent_day timestamp with time zone,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( project_id, id )
+)`,
+
 		`CREATE TABLE registration_tokens (
 	secret bytea NOT NULL,
 	owner_id bytea,
@@ -1567,6 +1986,9 @@ func (obj *pgxcockroachDB) Schema() []string {
 	inserted_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
 	segment_health double precision NOT NULL DEFAULT 1,
 	placement integer,
+	reason integer NOT NULL DEFAULT 0,
+	leased_by text,
+	lease_expires_at timestamp with time zone,
 	PRIMARY KEY ( stream_id, position )
 )`,
 
@@ -1628,6 +2050,15 @@ func (obj *pgxcockroachDB) Schema() []string {
 	PRIMARY KEY ( node_id )
 )`,
 
+		`CREATE TABLE sso_identities (
+	provider text NOT NULL,
+	subject text NOT NULL,
+	user_id bytea NOT NULL,
+	email text NOT NULL,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( provider, subject )
+)`,
+
 		`CREATE TABLE storagenode_bandwidth_rollups (
 	storagenode_id bytea NOT NULL,
 	interval_start timestamp with time zone NOT NULL,
@@ -1829,6 +2260,13 @@ func (obj *pgxcockroachDB) Schema() []string {
 	PRIMARY KEY ( inserted_at, stream_id, position )
 )`,
 
+		`CREATE TABLE warehouse_export_markers (
+	stream text NOT NULL,
+	exported_until timestamp with time zone NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( stream )
+)`,
+
 		`CREATE TABLE webapp_sessions (
 	id bytea NOT NULL,
 	user_id bytea NOT NULL,
@@ -1839,6 +2277,14 @@ func (obj *pgxcockroachDB) Schema() []string {
 	PRIMARY KEY ( id )
 )`,
 
+		`CREATE TABLE zombie_deletion_settings (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	inactive_for_seconds bigint NOT NULL,
+	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( project_id, bucket_name )
+)`,
+
 		`CREATE TABLE api_keys (
 	id bytea NOT NULL,
 	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
@@ -1874,6 +2320,10 @@ func (obj *pgxcockroachDB) Schema() []string {
 	default_redundancy_total_shares integer NOT NULL,
 	placement integer,
 	created_by bytea REFERENCES users( id ),
+	lifecycle_configuration bytea,
+	deletion_protection boolean NOT NULL DEFAULT false,
+	override_defaults boolean NOT NULL DEFAULT false,
+	cors_configuration bytea,
 	PRIMARY KEY ( project_id, name )
 )`,
 
@@ -1912,10 +2362,16 @@ func (obj *pgxcockroachDB) Schema() []string {
 
 		`CREATE INDEX bucket_bandwidth_rollups_archive_action_interval_project_id_index ON bucket_bandwidth_rollup_archives ( action, interval_start, project_id )`,
 
+		`CREATE INDEX bucket_delete_events_next_attempt_at_index ON bucket_delete_events ( next_attempt_at )`,
+
+		`CREATE INDEX bucket_partner_rollups_partner_interval_start_index ON bucket_partner_rollups ( partner, interval_start )`,
+
 		`CREATE INDEX bucket_storage_tallies_project_id_interval_start_index ON bucket_storage_tallies ( project_id, interval_start )`,
 
 		`CREATE INDEX bucket_storage_tallies_interval_start_index ON bucket_storage_tallies ( interval_start )`,
 
+		`CREATE INDEX domains_project_id_index ON domains ( project_id )`,
+
 		`CREATE INDEX graceful_exit_segment_transfer_nid_dr_qa_fa_lfa_index ON graceful_exit_segment_transfer_queue ( node_id, durability_ratio, queued_at, finished_at, last_failed_at )`,
 
 		`CREATE INDEX node_last_ip ON nodes ( last_net )`,
@@ -1926,8 +2382,12 @@ func (obj *pgxcockroachDB) Schema() []string {
 
 		`CREATE INDEX nodes_dis_unk_aud_exit_init_rel_last_cont_success_stored_index ON nodes ( disqualified, unknown_audit_suspended, exit_initiated_at, release, last_contact_success ) WHERE nodes.disqualified is NULL AND nodes.unknown_audit_suspended is NULL AND nodes.exit_initiated_at is NULL AND nodes.release = true`,
 
+		`CREATE INDEX nodes_wallet_index ON nodes ( wallet )`,
+
 		`CREATE INDEX node_events_email_event_created_at_index ON node_events ( email, event, created_at ) WHERE node_events.email_sent is NULL`,
 
+		`CREATE INDEX node_uptime_transitions_changed_at_index ON node_uptime_transitions ( changed_at )`,
+
 		`CREATE INDEX oauth_clients_user_id_index ON oauth_clients ( user_id )`,
 
 		`CREATE INDEX oauth_codes_user_id_index ON oauth_codes ( user_id )`,
@@ -1950,6 +2410,8 @@ func (obj *pgxcockroachDB) Schema() []string {
 
 		`CREATE INDEX repair_queue_placement_index ON repair_queue ( placement )`,
 
+		`CREATE INDEX repair_queue_lease_expires_at_index ON repair_queue ( lease_expires_at )`,
+
 		`CREATE INDEX reverification_audits_inserted_at_index ON reverification_audits ( inserted_at )`,
 
 		`CREATE INDEX storagenode_bandwidth_rollups_interval_start_index ON storagenode_bandwidth_rollups ( interval_start )`,
@@ -1995,8 +2457,12 @@ func (obj *pgxcockroachDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS api_keys`,
 
+		`DROP TABLE IF EXISTS zombie_deletion_settings`,
+
 		`DROP TABLE IF EXISTS webapp_sessions`,
 
+		`DROP TABLE IF EXISTS warehouse_export_markers`,
+
 		`DROP TABLE IF EXISTS verification_audits`,
 
 		`DROP TABLE IF EXISTS value_attributions`,
@@ -2027,6 +2493,8 @@ func (obj *pgxcockroachDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS storagenode_bandwidth_rollups`,
 
+		`DROP TABLE IF EXISTS sso_identities`,
+
 		`DROP TABLE IF EXISTS segment_pending_audits`,
 
 		`DROP TABLE IF EXISTS revocations`,
@@ -2041,10 +2509,16 @@ func (obj *pgxcockroachDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS registration_tokens`,
 
+		`DROP TABLE IF EXISTS project_usage_webhooks`,
+
+		`DROP TABLE IF EXISTS project_budget_caps`,
+
 		`DROP TABLE IF EXISTS project_bandwidth_daily_rollups`,
 
 		`DROP TABLE IF EXISTS projects`,
 
+		`DROP TABLE IF EXISTS placement_sla_dailies`,
+
 		`DROP TABLE IF EXISTS peer_identities`,
 
 		`DROP TABLE IF EXISTS oauth_tokens`,
@@ -2053,22 +2527,42 @@ func (obj *pgxcockroachDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS oauth_clients`,
 
+		`DROP TABLE IF EXISTS node_uptime_transitions`,
+
 		`DROP TABLE IF EXISTS node_tags`,
 
+		`DROP TABLE IF EXISTS node_selection_fairness_entries`,
+
 		`DROP TABLE IF EXISTS node_events`,
 
+		`DROP TABLE IF EXISTS node_drains`,
+
+		`DROP TABLE IF EXISTS node_attestation_issuers`,
+
 		`DROP TABLE IF EXISTS node_api_versions`,
 
 		`DROP TABLE IF EXISTS nodes`,
 
+		`DROP TABLE IF EXISTS impersonation_audits`,
+
 		`DROP TABLE IF EXISTS graceful_exit_segment_transfer_queue`,
 
 		`DROP TABLE IF EXISTS graceful_exit_progress`,
 
+		`DROP TABLE IF EXISTS domains`,
+
 		`DROP TABLE IF EXISTS coinpayments_transactions`,
 
 		`DROP TABLE IF EXISTS bucket_storage_tallies`,
 
+		`DROP TABLE IF EXISTS bucket_quotas`,
+
+		`DROP TABLE IF EXISTS bucket_partner_rollups`,
+
+		`DROP TABLE IF EXISTS bucket_event_destinations`,
+
+		`DROP TABLE IF EXISTS bucket_delete_events`,
+
 		`DROP TABLE IF EXISTS bucket_bandwidth_rollup_archives`,
 
 		`DROP TABLE IF EXISTS bucket_bandwidth_rollups`,
@@ -2077,11 +2571,15 @@ func (obj *pgxcockroachDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS billing_balances`,
 
+		`DROP TABLE IF EXISTS archived_nodes`,
+
 		`DROP TABLE IF EXISTS accounting_timestamps`,
 
 		`DROP TABLE IF EXISTS accounting_rollups`,
 
 		`DROP TABLE IF EXISTS account_freeze_events`,
+
+		`DROP TABLE IF EXISTS abuse_reports`,
 	}
 }
 
@@ -2184,6 +2682,18 @@ func newspanner(db *DB) *spannerDB {
 func (obj *spannerDB) Schema() []string {
 	return []string{
 
+		`CREATE TABLE abuse_reports (
+	id BYTES(MAX) NOT NULL,
+	url STRING(MAX) NOT NULL,
+	access_key_id STRING(MAX) NOT NULL,
+	reason STRING(MAX) NOT NULL,
+	reporter_email STRING(MAX) NOT NULL,
+	status INT64 NOT NULL DEFAULT (0),
+	created_at TIMESTAMP NOT NULL DEFAULT (current_timestamp),
+	reviewed_at TIMESTAMP,
+	reviewed_by STRING(MAX)
+) PRIMARY KEY ( id )`,
+
 		`CREATE TABLE account_freeze_events (
 	user_id BYTES(MAX) NOT NULL,
 	event INT64 NOT NULL,
@@ -2210,6 +2720,24 @@ func (obj *spannerDB) Schema() []string {
 	value TIMESTAMP NOT NULL
 ) PRIMARY KEY ( name )`,
 
+		`CREATE TABLE archived_nodes (
+	id BYTES(MAX) NOT NULL,
+	address STRING(MAX) NOT NULL,
+	last_net STRING(MAX) NOT NULL,
+	last_ip_port STRING(MAX),
+	country_code STRING(MAX),
+	email STRING(MAX) NOT NULL,
+	wallet STRING(MAX) NOT NULL,
+	wallet_features STRING(MAX) NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	last_contact_success TIMESTAMP NOT NULL,
+	disqualified TIMESTAMP,
+	disqualification_reason INT64,
+	exit_finished_at TIMESTAMP,
+	exit_success BOOL NOT NULL,
+	archived_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( id )`,
+
 		`CREATE TABLE billing_balances (
 	user_id BYTES(MAX) NOT NULL,
 	balance INT64 NOT NULL,
@@ -2254,6 +2782,45 @@ func (obj *spannerDB) Schema() []string {
 	settled INT64 NOT NULL
 ) PRIMARY KEY ( bucket_name, project_id, interval_start, action )`,
 
+		`CREATE TABLE bucket_delete_events (
+	id BYTES(MAX) NOT NULL,
+	project_id BYTES(MAX) NOT NULL,
+	bucket_name BYTES(MAX) NOT NULL,
+	object_key BYTES(MAX) NOT NULL,
+	version INT64 NOT NULL,
+	stream_id BYTES(MAX) NOT NULL,
+	event_name STRING(MAX) NOT NULL,
+	size INT64 NOT NULL,
+	topic STRING(MAX) NOT NULL,
+	deleted_at TIMESTAMP NOT NULL,
+	attempts INT64 NOT NULL DEFAULT (0),
+	next_attempt_at TIMESTAMP NOT NULL
+) PRIMARY KEY ( id )`,
+
+		`CREATE TABLE bucket_event_destinations (
+	project_id BYTES(MAX) NOT NULL,
+	bucket_name BYTES(MAX) NOT NULL,
+	topic STRING(MAX) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( project_id, bucket_name )`,
+
+		`CREATE TABLE bucket_partner_rollups (
+	project_id BYTES(MAX) NOT NULL,
+	bucket_name BYTES(MAX) NOT NULL,
+	partner STRING(MAX) NOT NULL,
+	interval_start TIMESTAMP NOT NULL,
+	objects INT64 NOT NULL,
+	bytes INT64 NOT NULL
+) PRIMARY KEY ( project_id, bucket_name, partner, interval_start )`,
+
+		`CREATE TABLE bucket_quotas (
+	project_id BYTES(MAX) NOT NULL,
+	bucket_name BYTES(MAX) NOT NULL,
+	max_objects INT64 NOT NULL,
+	max_segments INT64 NOT NULL,
+	updated_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( project_id, bucket_name )`,
+
 		`CREATE TABLE bucket_storage_tallies (
 	bucket_name BYTES(MAX) NOT NULL,
 	project_id BYTES(MAX) NOT NULL,
@@ -2280,6 +2847,19 @@ func (obj *spannerDB) Schema() []string {
 	created_at TIMESTAMP NOT NULL
 ) PRIMARY KEY ( id )`,
 
+		`CREATE TABLE domains (
+	subdomain STRING(MAX) NOT NULL,
+	project_id BYTES(MAX) NOT NULL,
+	created_by BYTES(MAX) NOT NULL,
+	prefix STRING(MAX) NOT NULL,
+	access_id STRING(MAX) NOT NULL,
+	verification_token STRING(MAX) NOT NULL,
+	status INT64 NOT NULL DEFAULT (0),
+	status_message STRING(MAX),
+	created_at TIMESTAMP NOT NULL DEFAULT (current_timestamp),
+	updated_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( subdomain )`,
+
 		`CREATE TABLE graceful_exit_progress (
 	node_id BYTES(MAX) NOT NULL,
 	bytes_transferred INT64 NOT NULL,
@@ -2304,6 +2884,17 @@ func (obj *spannerDB) Schema() []string {
 	order_limit_send_count INT64 NOT NULL DEFAULT (0)
 ) PRIMARY KEY ( node_id, stream_id, position, piece_num )`,
 
+		`CREATE TABLE impersonation_audits (
+	session_id BYTES(MAX) NOT NULL,
+	user_id BYTES(MAX) NOT NULL,
+	admin_email STRING(MAX) NOT NULL,
+	reason STRING(MAX) NOT NULL,
+	consent BOOL NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	ended_at TIMESTAMP
+) PRIMARY KEY ( session_id )`,
+
 		`CREATE TABLE nodes (
 	id BYTES(MAX) NOT NULL,
 	address STRING(MAX) NOT NULL DEFAULT (""),
@@ -2353,6 +2944,19 @@ func (obj *spannerDB) Schema() []string {
 	updated_at TIMESTAMP NOT NULL
 ) PRIMARY KEY ( id )`,
 
+		`CREATE TABLE node_attestation_issuers (
+	id BYTES(MAX) NOT NULL,
+	name STRING(MAX) NOT NULL,
+	identity BYTES(MAX) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( id )`,
+
+		`CREATE TABLE node_drains (
+	node_id BYTES(MAX) NOT NULL,
+	reason STRING(MAX),
+	initiated_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( node_id )`,
+
 		`CREATE TABLE node_events (
 	id BYTES(MAX) NOT NULL,
 	email STRING(MAX) NOT NULL,
@@ -2364,6 +2968,17 @@ func (obj *spannerDB) Schema() []string {
 	email_sent TIMESTAMP
 ) PRIMARY KEY ( id )`,
 
+		`CREATE TABLE node_selection_fairness_entries (
+	node_id BYTES(MAX) NOT NULL,
+	free_disk INT64 NOT NULL,
+	uploaded_bytes INT64 NOT NULL,
+	expected_share FLOAT64 NOT NULL,
+	actual_share FLOAT64 NOT NULL,
+	ratio FLOAT64 NOT NULL,
+	status STRING(MAX) NOT NULL,
+	generated_at TIMESTAMP NOT NULL
+) PRIMARY KEY ( node_id )`,
+
 		`CREATE TABLE node_tags (
 	node_id BYTES(MAX) NOT NULL,
 	name STRING(MAX) NOT NULL,
@@ -2372,6 +2987,12 @@ func (obj *spannerDB) Schema() []string {
 	signer BYTES(MAX) NOT NULL
 ) PRIMARY KEY ( node_id, name, signer )`,
 
+		`CREATE TABLE node_uptime_transitions (
+	node_id BYTES(MAX) NOT NULL,
+	changed_at TIMESTAMP NOT NULL,
+	online BOOL NOT NULL
+) PRIMARY KEY ( node_id, changed_at )`,
+
 		`CREATE TABLE oauth_clients (
 	id BYTES(MAX) NOT NULL,
 	encrypted_secret BYTES(MAX) NOT NULL,
@@ -2411,6 +3032,16 @@ func (obj *spannerDB) Schema() []string {
 	updated_at TIMESTAMP NOT NULL
 ) PRIMARY KEY ( node_id )`,
 
+		`CREATE TABLE placement_sla_dailies (
+	day TIMESTAMP NOT NULL,
+	placement INT64 NOT NULL,
+	probes INT64 NOT NULL DEFAULT (0),
+	failed_probes INT64 NOT NULL DEFAULT (0),
+	max_repair_queue_segments INT64 NOT NULL DEFAULT (0),
+	min_segment_health FLOAT64,
+	updated_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( day, placement )`,
+
 		`CREATE TABLE projects (
 	id BYTES(MAX) NOT NULL,
 	public_id BYTES(MAX),
@@ -2454,6 +3085,28 @@ func (obj *spannerDB) Schema() []string {
 	egress_dead INT64 NOT NULL DEFAULT (0)
 ) PRIMARY KEY ( project_id, interval_day )`,
 
+		`CREATE TABLE project_budget_caps (
+	project_id BYTES(MAX) NOT NULL,
+	cap_cents INT64 NOT NULL,
+	notified_percent INT64 NOT NULL DEFAULT (0),
+	period_start TIMESTAMP NOT NULL,
+	frozen_at TIMESTAMP,
+	frozen_storage_limit INT64,
+	frozen_bandwidth_limit INT64,
+	created_at TIMESTAMP NOT NULL DEFAULT (current_timestamp),
+	updated_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( project_id )`,
+
+		`CREATE TABLE project_usage_webhooks (
+	id BYTES(MAX) NOT NULL,
+	project_id BYTES(MAX) NOT NULL,
+	url STRING(MAX) NOT NULL,
+	secret BYTES(MAX) NOT NULL,
+	created_by BYTES(MAX) NOT NULL,
+	last_sent_day TIMESTAMP,
+	created_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( project_id, id )`,
+
 		`CREATE TABLE registration_tokens (
 	secret BYTES(MAX) NOT NULL,
 	owner_id BYTES(MAX),
@@ -2470,7 +3123,10 @@ func (obj *spannerDB) Schema() []string {
 	updated_at TIMESTAMP NOT NULL DEFAULT (current_timestamp),
 	inserted_at TIMESTAMP NOT NULL DEFAULT (current_timestamp),
 	segment_health FLOAT64 NOT NULL DEFAULT (1),
-	placement INT64
+	placement INT64,
+	reason INT64 NOT NULL DEFAULT (0),
+	leased_by STRING(MAX),
+	lease_expires_at TIMESTAMP
 ) PRIMARY KEY ( stream_id, position )`,
 
 		`CREATE TABLE reputations (
@@ -2527,6 +3183,14 @@ func (obj *spannerDB) Schema() []string {
 	reverify_count INT64 NOT NULL
 ) PRIMARY KEY ( node_id )`,
 
+		`CREATE TABLE sso_identities (
+	provider STRING(MAX) NOT NULL,
+	subject STRING(MAX) NOT NULL,
+	user_id BYTES(MAX) NOT NULL,
+	email STRING(MAX) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( provider, subject )`,
+
 		`CREATE TABLE storagenode_bandwidth_rollups (
 	storagenode_id BYTES(MAX) NOT NULL,
 	interval_start TIMESTAMP NOT NULL,
@@ -2717,6 +3381,12 @@ func (obj *spannerDB) Schema() []string {
 	encrypted_size INT64 NOT NULL
 ) PRIMARY KEY ( inserted_at, stream_id, position )`,
 
+		`CREATE TABLE warehouse_export_markers (
+	stream STRING(MAX) NOT NULL,
+	exported_until TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+) PRIMARY KEY ( stream )`,
+
 		`CREATE TABLE webapp_sessions (
 	id BYTES(MAX) NOT NULL,
 	user_id BYTES(MAX) NOT NULL,
@@ -2726,6 +3396,13 @@ func (obj *spannerDB) Schema() []string {
 	expires_at TIMESTAMP NOT NULL
 ) PRIMARY KEY ( id )`,
 
+		`CREATE TABLE zombie_deletion_settings (
+	project_id BYTES(MAX) NOT NULL,
+	bucket_name BYTES(MAX) NOT NULL,
+	inactive_for_seconds INT64 NOT NULL,
+	updated_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( project_id, bucket_name )`,
+
 		`CREATE TABLE api_keys (
 	id BYTES(MAX) NOT NULL,
 	project_id BYTES(MAX) NOT NULL,
@@ -2764,6 +3441,10 @@ func (obj *spannerDB) Schema() []string {
 	default_redundancy_total_shares INT64 NOT NULL,
 	placement INT64,
 	created_by BYTES(MAX),
+	lifecycle_configuration BYTES(MAX),
+	deletion_protection BOOL NOT NULL DEFAULT (false),
+	override_defaults BOOL NOT NULL DEFAULT (false),
+	cors_configuration BYTES(MAX),
 	CONSTRAINT bucket_metainfos_project_id_fkey FOREIGN KEY (project_id) REFERENCES projects (id),
 	CONSTRAINT bucket_metainfos_created_by_fkey FOREIGN KEY (created_by) REFERENCES users (id)
 ) PRIMARY KEY ( project_id, name )`,
@@ -2805,10 +3486,16 @@ func (obj *spannerDB) Schema() []string {
 
 		`CREATE INDEX bucket_bandwidth_rollups_archive_action_interval_project_id_index ON bucket_bandwidth_rollup_archives ( action, interval_start, project_id )`,
 
+		`CREATE INDEX bucket_delete_events_next_attempt_at_index ON bucket_delete_events ( next_attempt_at )`,
+
+		`CREATE INDEX bucket_partner_rollups_partner_interval_start_index ON bucket_partner_rollups ( partner, interval_start )`,
+
 		`CREATE INDEX bucket_storage_tallies_project_id_interval_start_index ON bucket_storage_tallies ( project_id, interval_start )`,
 
 		`CREATE INDEX bucket_storage_tallies_interval_start_index ON bucket_storage_tallies ( interval_start )`,
 
+		`CREATE INDEX domains_project_id_index ON domains ( project_id )`,
+
 		`CREATE INDEX graceful_exit_segment_transfer_nid_dr_qa_fa_lfa_index ON graceful_exit_segment_transfer_queue ( node_id, durability_ratio, queued_at, finished_at, last_failed_at )`,
 
 		`CREATE INDEX node_last_ip ON nodes ( last_net )`,
@@ -2819,8 +3506,12 @@ func (obj *spannerDB) Schema() []string {
 
 		`CREATE INDEX nodes_dis_unk_aud_exit_init_rel_last_cont_success_stored_index ON nodes ( disqualified, unknown_audit_suspended, exit_initiated_at, release, last_contact_success )`,
 
+		`CREATE INDEX nodes_wallet_index ON nodes ( wallet )`,
+
 		`CREATE INDEX node_events_email_event_created_at_index ON node_events ( email, event, created_at )`,
 
+		`CREATE INDEX node_uptime_transitions_changed_at_index ON node_uptime_transitions ( changed_at )`,
+
 		`CREATE INDEX oauth_clients_user_id_index ON oauth_clients ( user_id )`,
 
 		`CREATE INDEX oauth_codes_user_id_index ON oauth_codes ( user_id )`,
@@ -2843,6 +3534,8 @@ func (obj *spannerDB) Schema() []string {
 
 		`CREATE INDEX repair_queue_placement_index ON repair_queue ( placement )`,
 
+		`CREATE INDEX repair_queue_lease_expires_at_index ON repair_queue ( lease_expires_at )`,
+
 		`CREATE INDEX reverification_audits_inserted_at_index ON reverification_audits ( inserted_at )`,
 
 		`CREATE INDEX storagenode_bandwidth_rollups_interval_start_index ON storagenode_bandwidth_rollups ( interval_start )`,
@@ -2920,10 +3613,16 @@ func (obj *spannerDB) DropSchema() []string {
 
 		`DROP INDEX IF EXISTS bucket_bandwidth_rollups_archive_action_interval_project_id_index`,
 
+		`DROP INDEX IF EXISTS bucket_delete_events_next_attempt_at_index`,
+
+		`DROP INDEX IF EXISTS bucket_partner_rollups_partner_interval_start_index`,
+
 		`DROP INDEX IF EXISTS bucket_storage_tallies_project_id_interval_start_index`,
 
 		`DROP INDEX IF EXISTS bucket_storage_tallies_interval_start_index`,
 
+		`DROP INDEX IF EXISTS domains_project_id_index`,
+
 		`DROP INDEX IF EXISTS graceful_exit_segment_transfer_nid_dr_qa_fa_lfa_index`,
 
 		`DROP INDEX IF EXISTS node_last_ip`,
@@ -2934,8 +3633,12 @@ func (obj *spannerDB) DropSchema() []string {
 
 		`DROP INDEX IF EXISTS nodes_dis_unk_aud_exit_init_rel_last_cont_success_stored_index`,
 
+		`DROP INDEX IF EXISTS nodes_wallet_index`,
+
 		`DROP INDEX IF EXISTS node_events_email_event_created_at_index`,
 
+		`DROP INDEX IF EXISTS node_uptime_transitions_changed_at_index`,
+
 		`DROP INDEX IF EXISTS oauth_clients_user_id_index`,
 
 		`DROP INDEX IF EXISTS oauth_codes_user_id_index`,
@@ -2958,6 +3661,8 @@ func (obj *spannerDB) DropSchema() []string {
 
 		`DROP INDEX IF EXISTS repair_queue_placement_index`,
 
+		`DROP INDEX IF EXISTS repair_queue_lease_expires_at_index`,
+
 		`DROP INDEX IF EXISTS reverification_audits_inserted_at_index`,
 
 		`DROP INDEX IF EXISTS storagenode_bandwidth_rollups_interval_start_index`,
@@ -3030,12 +3735,28 @@ func (obj *spannerDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS api_keys`,
 
+		`ALTER TABLE  zombie_deletion_settings ALTER project_id SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS zombie_deletion_settings_project_id`,
+
+		`ALTER TABLE  zombie_deletion_settings ALTER bucket_name SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS zombie_deletion_settings_bucket_name`,
+
+		`DROP TABLE IF EXISTS zombie_deletion_settings`,
+
 		`ALTER TABLE  webapp_sessions ALTER id SET DEFAULT (null)`,
 
 		`DROP SEQUENCE IF EXISTS webapp_sessions_id`,
 
 		`DROP TABLE IF EXISTS webapp_sessions`,
 
+		`ALTER TABLE  warehouse_export_markers ALTER stream SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS warehouse_export_markers_stream`,
+
+		`DROP TABLE IF EXISTS warehouse_export_markers`,
+
 		`ALTER TABLE  verification_audits ALTER inserted_at SET DEFAULT (null)`,
 
 		`DROP SEQUENCE IF EXISTS verification_audits_inserted_at`,
@@ -3178,6 +3899,16 @@ func (obj *spannerDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS storagenode_bandwidth_rollups`,
 
+		`ALTER TABLE  sso_identities ALTER provider SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS sso_identities_provider`,
+
+		`ALTER TABLE  sso_identities ALTER subject SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS sso_identities_subject`,
+
+		`DROP TABLE IF EXISTS sso_identities`,
+
 		`ALTER TABLE  segment_pending_audits ALTER node_id SET DEFAULT (null)`,
 
 		`DROP SEQUENCE IF EXISTS segment_pending_audits_node_id`,
@@ -3232,6 +3963,22 @@ func (obj *spannerDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS registration_tokens`,
 
+		`ALTER TABLE  project_usage_webhooks ALTER project_id SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS project_usage_webhooks_project_id`,
+
+		`ALTER TABLE  project_usage_webhooks ALTER id SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS project_usage_webhooks_id`,
+
+		`DROP TABLE IF EXISTS project_usage_webhooks`,
+
+		`ALTER TABLE  project_budget_caps ALTER project_id SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS project_budget_caps_project_id`,
+
+		`DROP TABLE IF EXISTS project_budget_caps`,
+
 		`ALTER TABLE  project_bandwidth_daily_rollups ALTER project_id SET DEFAULT (null)`,
 
 		`DROP SEQUENCE IF EXISTS project_bandwidth_daily_rollups_project_id`,
@@ -3248,6 +3995,16 @@ func (obj *spannerDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS projects`,
 
+		`ALTER TABLE  placement_sla_dailies ALTER day SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS placement_sla_dailies_day`,
+
+		`ALTER TABLE  placement_sla_dailies ALTER placement SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS placement_sla_dailies_placement`,
+
+		`DROP TABLE IF EXISTS placement_sla_dailies`,
+
 		`ALTER TABLE  peer_identities ALTER node_id SET DEFAULT (null)`,
 
 		`DROP SEQUENCE IF EXISTS peer_identities_node_id`,
@@ -3272,6 +4029,16 @@ func (obj *spannerDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS oauth_clients`,
 
+		`ALTER TABLE  node_uptime_transitions ALTER node_id SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS node_uptime_transitions_node_id`,
+
+		`ALTER TABLE  node_uptime_transitions ALTER changed_at SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS node_uptime_transitions_changed_at`,
+
+		`DROP TABLE IF EXISTS node_uptime_transitions`,
+
 		`ALTER TABLE  node_tags ALTER node_id SET DEFAULT (null)`,
 
 		`DROP SEQUENCE IF EXISTS node_tags_node_id`,
@@ -3286,12 +4053,30 @@ func (obj *spannerDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS node_tags`,
 
+		`ALTER TABLE  node_selection_fairness_entries ALTER node_id SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS node_selection_fairness_entries_node_id`,
+
+		`DROP TABLE IF EXISTS node_selection_fairness_entries`,
+
 		`ALTER TABLE  node_events ALTER id SET DEFAULT (null)`,
 
 		`DROP SEQUENCE IF EXISTS node_events_id`,
 
 		`DROP TABLE IF EXISTS node_events`,
 
+		`ALTER TABLE  node_drains ALTER node_id SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS node_drains_node_id`,
+
+		`DROP TABLE IF EXISTS node_drains`,
+
+		`ALTER TABLE  node_attestation_issuers ALTER id SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS node_attestation_issuers_id`,
+
+		`DROP TABLE IF EXISTS node_attestation_issuers`,
+
 		`ALTER TABLE  node_api_versions ALTER id SET DEFAULT (null)`,
 
 		`DROP SEQUENCE IF EXISTS node_api_versions_id`,
@@ -3304,6 +4089,12 @@ func (obj *spannerDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS nodes`,
 
+		`ALTER TABLE  impersonation_audits ALTER session_id SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS impersonation_audits_session_id`,
+
+		`DROP TABLE IF EXISTS impersonation_audits`,
+
 		`ALTER TABLE  graceful_exit_segment_transfer_queue ALTER node_id SET DEFAULT (null)`,
 
 		`DROP SEQUENCE IF EXISTS graceful_exit_segment_transfer_queue_node_id`,
@@ -3328,6 +4119,12 @@ func (obj *spannerDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS graceful_exit_progress`,
 
+		`ALTER TABLE  domains ALTER subdomain SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS domains_subdomain`,
+
+		`DROP TABLE IF EXISTS domains`,
+
 		`ALTER TABLE  coinpayments_transactions ALTER id SET DEFAULT (null)`,
 
 		`DROP SEQUENCE IF EXISTS coinpayments_transactions_id`,
@@ -3348,6 +4145,50 @@ func (obj *spannerDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS bucket_storage_tallies`,
 
+		`ALTER TABLE  bucket_quotas ALTER project_id SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS bucket_quotas_project_id`,
+
+		`ALTER TABLE  bucket_quotas ALTER bucket_name SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS bucket_quotas_bucket_name`,
+
+		`DROP TABLE IF EXISTS bucket_quotas`,
+
+		`ALTER TABLE  bucket_partner_rollups ALTER project_id SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS bucket_partner_rollups_project_id`,
+
+		`ALTER TABLE  bucket_partner_rollups ALTER bucket_name SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS bucket_partner_rollups_bucket_name`,
+
+		`ALTER TABLE  bucket_partner_rollups ALTER partner SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS bucket_partner_rollups_partner`,
+
+		`ALTER TABLE  bucket_partner_rollups ALTER interval_start SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS bucket_partner_rollups_interval_start`,
+
+		`DROP TABLE IF EXISTS bucket_partner_rollups`,
+
+		`ALTER TABLE  bucket_event_destinations ALTER project_id SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS bucket_event_destinations_project_id`,
+
+		`ALTER TABLE  bucket_event_destinations ALTER bucket_name SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS bucket_event_destinations_bucket_name`,
+
+		`DROP TABLE IF EXISTS bucket_event_destinations`,
+
+		`ALTER TABLE  bucket_delete_events ALTER id SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS bucket_delete_events_id`,
+
+		`DROP TABLE IF EXISTS bucket_delete_events`,
+
 		`ALTER TABLE  bucket_bandwidth_rollup_archives ALTER bucket_name SET DEFAULT (null)`,
 
 		`DROP SEQUENCE IF EXISTS bucket_bandwidth_rollup_archives_bucket_name`,
@@ -3396,6 +4237,12 @@ func (obj *spannerDB) DropSchema() []string {
 
 		`DROP TABLE IF EXISTS billing_balances`,
 
+		`ALTER TABLE  archived_nodes ALTER id SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS archived_nodes_id`,
+
+		`DROP TABLE IF EXISTS archived_nodes`,
+
 		`ALTER TABLE  accounting_timestamps ALTER name SET DEFAULT (null)`,
 
 		`DROP SEQUENCE IF EXISTS accounting_timestamps_name`,
@@ -3421,6 +4268,12 @@ func (obj *spannerDB) DropSchema() []string {
 		`DROP SEQUENCE IF EXISTS account_freeze_events_event`,
 
 		`DROP TABLE IF EXISTS account_freeze_events`,
+
+		`ALTER TABLE  abuse_reports ALTER id SET DEFAULT (null)`,
+
+		`DROP SEQUENCE IF EXISTS abuse_reports_id`,
+
+		`DROP TABLE IF EXISTS abuse_reports`,
 	}
 }
 
@@ -3485,6 +4338,212 @@ nextval:
 	fmt.Fprint(f, "]")
 }
 
+type AbuseReport struct {
+	Id            []byte
+	Url           string
+	AccessKeyId   string
+	Reason        string
+	ReporterEmail string
+	Status        int
+	CreatedAt     time.Time
+	ReviewedAt    *time.Time
+	ReviewedBy    *string
+}
+
+func (AbuseReport) _Table() string { return "abuse_reports" }
+
+type AbuseReport_Create_Fields struct {
+	Status     AbuseReport_Status_Field
+	CreatedAt  AbuseReport_CreatedAt_Field
+	ReviewedAt AbuseReport_ReviewedAt_Field
+	ReviewedBy AbuseReport_ReviewedBy_Field
+}
+
+type AbuseReport_Update_Fields struct {
+	Status     AbuseReport_Status_Field
+	ReviewedAt AbuseReport_ReviewedAt_Field
+	ReviewedBy AbuseReport_ReviewedBy_Field
+}
+
+type AbuseReport_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func AbuseReport_Id(v []byte) AbuseReport_Id_Field {
+	return AbuseReport_Id_Field{_set: true, _value: v}
+}
+
+func (f AbuseReport_Id_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type AbuseReport_Url_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func AbuseReport_Url(v string) AbuseReport_Url_Field {
+	return AbuseReport_Url_Field{_set: true, _value: v}
+}
+
+func (f AbuseReport_Url_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type AbuseReport_AccessKeyId_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func AbuseReport_AccessKeyId(v string) AbuseReport_AccessKeyId_Field {
+	return AbuseReport_AccessKeyId_Field{_set: true, _value: v}
+}
+
+func (f AbuseReport_AccessKeyId_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type AbuseReport_Reason_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func AbuseReport_Reason(v string) AbuseReport_Reason_Field {
+	return AbuseReport_Reason_Field{_set: true, _value: v}
+}
+
+func (f AbuseReport_Reason_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type AbuseReport_ReporterEmail_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func AbuseReport_ReporterEmail(v string) AbuseReport_ReporterEmail_Field {
+	return AbuseReport_ReporterEmail_Field{_set: true, _value: v}
+}
+
+func (f AbuseReport_ReporterEmail_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type AbuseReport_Status_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func AbuseReport_Status(v int) AbuseReport_Status_Field {
+	return AbuseReport_Status_Field{_set: true, _value: v}
+}
+
+func (f AbuseReport_Status_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type AbuseReport_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func AbuseReport_CreatedAt(v time.Time) AbuseReport_CreatedAt_Field {
+	return AbuseReport_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f AbuseReport_CreatedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type AbuseReport_ReviewedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func AbuseReport_ReviewedAt(v time.Time) AbuseReport_ReviewedAt_Field {
+	return AbuseReport_ReviewedAt_Field{_set: true, _value: &v}
+}
+
+func AbuseReport_ReviewedAt_Raw(v *time.Time) AbuseReport_ReviewedAt_Field {
+	if v == nil {
+		return AbuseReport_ReviewedAt_Null()
+	}
+	return AbuseReport_ReviewedAt(*v)
+}
+
+func AbuseReport_ReviewedAt_Null() AbuseReport_ReviewedAt_Field {
+	return AbuseReport_ReviewedAt_Field{_set: true, _null: true}
+}
+
+func (f AbuseReport_ReviewedAt_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f AbuseReport_ReviewedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type AbuseReport_ReviewedBy_Field struct {
+	_set   bool
+	_null  bool
+	_value *string
+}
+
+func AbuseReport_ReviewedBy(v string) AbuseReport_ReviewedBy_Field {
+	return AbuseReport_ReviewedBy_Field{_set: true, _value: &v}
+}
+
+func AbuseReport_ReviewedBy_Raw(v *string) AbuseReport_ReviewedBy_Field {
+	if v == nil {
+		return AbuseReport_ReviewedBy_Null()
+	}
+	return AbuseReport_ReviewedBy(*v)
+}
+
+func AbuseReport_ReviewedBy_Null() AbuseReport_ReviewedBy_Field {
+	return AbuseReport_ReviewedBy_Field{_set: true, _null: true}
+}
+
+func (f AbuseReport_ReviewedBy_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f AbuseReport_ReviewedBy_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
 type AccountFreezeEvent struct {
 	UserId             []byte
 	Event              int
@@ -3874,6 +4933,362 @@ func (f AccountingTimestamps_Value_Field) value() any {
 	return f._value
 }
 
+type ArchivedNode struct {
+	Id                     []byte
+	Address                string
+	LastNet                string
+	LastIpPort             *string
+	CountryCode            *string
+	Email                  string
+	Wallet                 string
+	WalletFeatures         string
+	CreatedAt              time.Time
+	LastContactSuccess     time.Time
+	Disqualified           *time.Time
+	DisqualificationReason *int
+	ExitFinishedAt         *time.Time
+	ExitSuccess            bool
+	ArchivedAt             time.Time
+}
+
+func (ArchivedNode) _Table() string { return "archived_nodes" }
+
+type ArchivedNode_Create_Fields struct {
+	LastIpPort             ArchivedNode_LastIpPort_Field
+	CountryCode            ArchivedNode_CountryCode_Field
+	Disqualified           ArchivedNode_Disqualified_Field
+	DisqualificationReason ArchivedNode_DisqualificationReason_Field
+	ExitFinishedAt         ArchivedNode_ExitFinishedAt_Field
+	ArchivedAt             ArchivedNode_ArchivedAt_Field
+}
+
+type ArchivedNode_Update_Fields struct {
+}
+
+type ArchivedNode_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ArchivedNode_Id(v []byte) ArchivedNode_Id_Field {
+	return ArchivedNode_Id_Field{_set: true, _value: v}
+}
+
+func (f ArchivedNode_Id_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ArchivedNode_Address_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func ArchivedNode_Address(v string) ArchivedNode_Address_Field {
+	return ArchivedNode_Address_Field{_set: true, _value: v}
+}
+
+func (f ArchivedNode_Address_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ArchivedNode_LastNet_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func ArchivedNode_LastNet(v string) ArchivedNode_LastNet_Field {
+	return ArchivedNode_LastNet_Field{_set: true, _value: v}
+}
+
+func (f ArchivedNode_LastNet_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ArchivedNode_LastIpPort_Field struct {
+	_set   bool
+	_null  bool
+	_value *string
+}
+
+func ArchivedNode_LastIpPort(v string) ArchivedNode_LastIpPort_Field {
+	return ArchivedNode_LastIpPort_Field{_set: true, _value: &v}
+}
+
+func ArchivedNode_LastIpPort_Raw(v *string) ArchivedNode_LastIpPort_Field {
+	if v == nil {
+		return ArchivedNode_LastIpPort_Null()
+	}
+	return ArchivedNode_LastIpPort(*v)
+}
+
+func ArchivedNode_LastIpPort_Null() ArchivedNode_LastIpPort_Field {
+	return ArchivedNode_LastIpPort_Field{_set: true, _null: true}
+}
+
+func (f ArchivedNode_LastIpPort_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f ArchivedNode_LastIpPort_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ArchivedNode_CountryCode_Field struct {
+	_set   bool
+	_null  bool
+	_value *string
+}
+
+func ArchivedNode_CountryCode(v string) ArchivedNode_CountryCode_Field {
+	return ArchivedNode_CountryCode_Field{_set: true, _value: &v}
+}
+
+func ArchivedNode_CountryCode_Raw(v *string) ArchivedNode_CountryCode_Field {
+	if v == nil {
+		return ArchivedNode_CountryCode_Null()
+	}
+	return ArchivedNode_CountryCode(*v)
+}
+
+func ArchivedNode_CountryCode_Null() ArchivedNode_CountryCode_Field {
+	return ArchivedNode_CountryCode_Field{_set: true, _null: true}
+}
+
+func (f ArchivedNode_CountryCode_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f ArchivedNode_CountryCode_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ArchivedNode_Email_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func ArchivedNode_Email(v string) ArchivedNode_Email_Field {
+	return ArchivedNode_Email_Field{_set: true, _value: v}
+}
+
+func (f ArchivedNode_Email_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ArchivedNode_Wallet_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func ArchivedNode_Wallet(v string) ArchivedNode_Wallet_Field {
+	return ArchivedNode_Wallet_Field{_set: true, _value: v}
+}
+
+func (f ArchivedNode_Wallet_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ArchivedNode_WalletFeatures_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func ArchivedNode_WalletFeatures(v string) ArchivedNode_WalletFeatures_Field {
+	return ArchivedNode_WalletFeatures_Field{_set: true, _value: v}
+}
+
+func (f ArchivedNode_WalletFeatures_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ArchivedNode_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ArchivedNode_CreatedAt(v time.Time) ArchivedNode_CreatedAt_Field {
+	return ArchivedNode_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f ArchivedNode_CreatedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ArchivedNode_LastContactSuccess_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ArchivedNode_LastContactSuccess(v time.Time) ArchivedNode_LastContactSuccess_Field {
+	return ArchivedNode_LastContactSuccess_Field{_set: true, _value: v}
+}
+
+func (f ArchivedNode_LastContactSuccess_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ArchivedNode_Disqualified_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func ArchivedNode_Disqualified(v time.Time) ArchivedNode_Disqualified_Field {
+	return ArchivedNode_Disqualified_Field{_set: true, _value: &v}
+}
+
+func ArchivedNode_Disqualified_Raw(v *time.Time) ArchivedNode_Disqualified_Field {
+	if v == nil {
+		return ArchivedNode_Disqualified_Null()
+	}
+	return ArchivedNode_Disqualified(*v)
+}
+
+func ArchivedNode_Disqualified_Null() ArchivedNode_Disqualified_Field {
+	return ArchivedNode_Disqualified_Field{_set: true, _null: true}
+}
+
+func (f ArchivedNode_Disqualified_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f ArchivedNode_Disqualified_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ArchivedNode_DisqualificationReason_Field struct {
+	_set   bool
+	_null  bool
+	_value *int
+}
+
+func ArchivedNode_DisqualificationReason(v int) ArchivedNode_DisqualificationReason_Field {
+	return ArchivedNode_DisqualificationReason_Field{_set: true, _value: &v}
+}
+
+func ArchivedNode_DisqualificationReason_Raw(v *int) ArchivedNode_DisqualificationReason_Field {
+	if v == nil {
+		return ArchivedNode_DisqualificationReason_Null()
+	}
+	return ArchivedNode_DisqualificationReason(*v)
+}
+
+func ArchivedNode_DisqualificationReason_Null() ArchivedNode_DisqualificationReason_Field {
+	return ArchivedNode_DisqualificationReason_Field{_set: true, _null: true}
+}
+
+func (f ArchivedNode_DisqualificationReason_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
+
+func (f ArchivedNode_DisqualificationReason_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ArchivedNode_ExitFinishedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func ArchivedNode_ExitFinishedAt(v time.Time) ArchivedNode_ExitFinishedAt_Field {
+	return ArchivedNode_ExitFinishedAt_Field{_set: true, _value: &v}
+}
+
+func ArchivedNode_ExitFinishedAt_Raw(v *time.Time) ArchivedNode_ExitFinishedAt_Field {
+	if v == nil {
+		return ArchivedNode_ExitFinishedAt_Null()
+	}
+	return ArchivedNode_ExitFinishedAt(*v)
+}
+
+func ArchivedNode_ExitFinishedAt_Null() ArchivedNode_ExitFinishedAt_Field {
+	return ArchivedNode_ExitFinishedAt_Field{_set: true, _null: true}
+}
+
+func (f ArchivedNode_ExitFinishedAt_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
+
+func (f ArchivedNode_ExitFinishedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ArchivedNode_ExitSuccess_Field struct {
+	_set   bool
+	_null  bool
+	_value bool
+}
+
+func ArchivedNode_ExitSuccess(v bool) ArchivedNode_ExitSuccess_Field {
+	return ArchivedNode_ExitSuccess_Field{_set: true, _value: v}
+}
+
+func (f ArchivedNode_ExitSuccess_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ArchivedNode_ArchivedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ArchivedNode_ArchivedAt(v time.Time) ArchivedNode_ArchivedAt_Field {
+	return ArchivedNode_ArchivedAt_Field{_set: true, _value: v}
+}
+
+func (f ArchivedNode_ArchivedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
 type BillingBalance struct {
 	UserId      []byte
 	Balance     int64
@@ -4455,6 +5870,545 @@ func (f BucketBandwidthRollupArchive_Settled_Field) value() any {
 	return f._value
 }
 
+type BucketDeleteEvent struct {
+	Id            []byte
+	ProjectId     []byte
+	BucketName    []byte
+	ObjectKey     []byte
+	Version       int64
+	StreamId      []byte
+	EventName     string
+	Size          int64
+	Topic         string
+	DeletedAt     time.Time
+	Attempts      int
+	NextAttemptAt time.Time
+}
+
+func (BucketDeleteEvent) _Table() string { return "bucket_delete_events" }
+
+type BucketDeleteEvent_Create_Fields struct {
+	Attempts BucketDeleteEvent_Attempts_Field
+}
+
+type BucketDeleteEvent_Update_Fields struct {
+	Attempts      BucketDeleteEvent_Attempts_Field
+	NextAttemptAt BucketDeleteEvent_NextAttemptAt_Field
+}
+
+type BucketDeleteEvent_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketDeleteEvent_Id(v []byte) BucketDeleteEvent_Id_Field {
+	return BucketDeleteEvent_Id_Field{_set: true, _value: v}
+}
+
+func (f BucketDeleteEvent_Id_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketDeleteEvent_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketDeleteEvent_ProjectId(v []byte) BucketDeleteEvent_ProjectId_Field {
+	return BucketDeleteEvent_ProjectId_Field{_set: true, _value: v}
+}
+
+func (f BucketDeleteEvent_ProjectId_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketDeleteEvent_BucketName_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketDeleteEvent_BucketName(v []byte) BucketDeleteEvent_BucketName_Field {
+	return BucketDeleteEvent_BucketName_Field{_set: true, _value: v}
+}
+
+func (f BucketDeleteEvent_BucketName_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketDeleteEvent_ObjectKey_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketDeleteEvent_ObjectKey(v []byte) BucketDeleteEvent_ObjectKey_Field {
+	return BucketDeleteEvent_ObjectKey_Field{_set: true, _value: v}
+}
+
+func (f BucketDeleteEvent_ObjectKey_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketDeleteEvent_Version_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func BucketDeleteEvent_Version(v int64) BucketDeleteEvent_Version_Field {
+	return BucketDeleteEvent_Version_Field{_set: true, _value: v}
+}
+
+func (f BucketDeleteEvent_Version_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketDeleteEvent_StreamId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketDeleteEvent_StreamId(v []byte) BucketDeleteEvent_StreamId_Field {
+	return BucketDeleteEvent_StreamId_Field{_set: true, _value: v}
+}
+
+func (f BucketDeleteEvent_StreamId_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketDeleteEvent_EventName_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func BucketDeleteEvent_EventName(v string) BucketDeleteEvent_EventName_Field {
+	return BucketDeleteEvent_EventName_Field{_set: true, _value: v}
+}
+
+func (f BucketDeleteEvent_EventName_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketDeleteEvent_Size_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func BucketDeleteEvent_Size(v int64) BucketDeleteEvent_Size_Field {
+	return BucketDeleteEvent_Size_Field{_set: true, _value: v}
+}
+
+func (f BucketDeleteEvent_Size_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketDeleteEvent_Topic_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func BucketDeleteEvent_Topic(v string) BucketDeleteEvent_Topic_Field {
+	return BucketDeleteEvent_Topic_Field{_set: true, _value: v}
+}
+
+func (f BucketDeleteEvent_Topic_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketDeleteEvent_DeletedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func BucketDeleteEvent_DeletedAt(v time.Time) BucketDeleteEvent_DeletedAt_Field {
+	return BucketDeleteEvent_DeletedAt_Field{_set: true, _value: v}
+}
+
+func (f BucketDeleteEvent_DeletedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketDeleteEvent_Attempts_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func BucketDeleteEvent_Attempts(v int) BucketDeleteEvent_Attempts_Field {
+	return BucketDeleteEvent_Attempts_Field{_set: true, _value: v}
+}
+
+func (f BucketDeleteEvent_Attempts_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketDeleteEvent_NextAttemptAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func BucketDeleteEvent_NextAttemptAt(v time.Time) BucketDeleteEvent_NextAttemptAt_Field {
+	return BucketDeleteEvent_NextAttemptAt_Field{_set: true, _value: v}
+}
+
+func (f BucketDeleteEvent_NextAttemptAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketEventDestination struct {
+	ProjectId  []byte
+	BucketName []byte
+	Topic      string
+	CreatedAt  time.Time
+}
+
+func (BucketEventDestination) _Table() string { return "bucket_event_destinations" }
+
+type BucketEventDestination_Create_Fields struct {
+	CreatedAt BucketEventDestination_CreatedAt_Field
+}
+
+type BucketEventDestination_Update_Fields struct {
+	Topic BucketEventDestination_Topic_Field
+}
+
+type BucketEventDestination_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketEventDestination_ProjectId(v []byte) BucketEventDestination_ProjectId_Field {
+	return BucketEventDestination_ProjectId_Field{_set: true, _value: v}
+}
+
+func (f BucketEventDestination_ProjectId_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketEventDestination_BucketName_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketEventDestination_BucketName(v []byte) BucketEventDestination_BucketName_Field {
+	return BucketEventDestination_BucketName_Field{_set: true, _value: v}
+}
+
+func (f BucketEventDestination_BucketName_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketEventDestination_Topic_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func BucketEventDestination_Topic(v string) BucketEventDestination_Topic_Field {
+	return BucketEventDestination_Topic_Field{_set: true, _value: v}
+}
+
+func (f BucketEventDestination_Topic_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketEventDestination_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func BucketEventDestination_CreatedAt(v time.Time) BucketEventDestination_CreatedAt_Field {
+	return BucketEventDestination_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f BucketEventDestination_CreatedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketPartnerRollup struct {
+	ProjectId     []byte
+	BucketName    []byte
+	Partner       string
+	IntervalStart time.Time
+	Objects       int64
+	Bytes         int64
+}
+
+func (BucketPartnerRollup) _Table() string { return "bucket_partner_rollups" }
+
+type BucketPartnerRollup_Create_Fields struct {
+}
+
+type BucketPartnerRollup_Update_Fields struct {
+}
+
+type BucketPartnerRollup_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketPartnerRollup_ProjectId(v []byte) BucketPartnerRollup_ProjectId_Field {
+	return BucketPartnerRollup_ProjectId_Field{_set: true, _value: v}
+}
+
+func (f BucketPartnerRollup_ProjectId_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketPartnerRollup_BucketName_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketPartnerRollup_BucketName(v []byte) BucketPartnerRollup_BucketName_Field {
+	return BucketPartnerRollup_BucketName_Field{_set: true, _value: v}
+}
+
+func (f BucketPartnerRollup_BucketName_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketPartnerRollup_Partner_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func BucketPartnerRollup_Partner(v string) BucketPartnerRollup_Partner_Field {
+	return BucketPartnerRollup_Partner_Field{_set: true, _value: v}
+}
+
+func (f BucketPartnerRollup_Partner_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketPartnerRollup_IntervalStart_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func BucketPartnerRollup_IntervalStart(v time.Time) BucketPartnerRollup_IntervalStart_Field {
+	return BucketPartnerRollup_IntervalStart_Field{_set: true, _value: v}
+}
+
+func (f BucketPartnerRollup_IntervalStart_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketPartnerRollup_Objects_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func BucketPartnerRollup_Objects(v int64) BucketPartnerRollup_Objects_Field {
+	return BucketPartnerRollup_Objects_Field{_set: true, _value: v}
+}
+
+func (f BucketPartnerRollup_Objects_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketPartnerRollup_Bytes_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func BucketPartnerRollup_Bytes(v int64) BucketPartnerRollup_Bytes_Field {
+	return BucketPartnerRollup_Bytes_Field{_set: true, _value: v}
+}
+
+func (f BucketPartnerRollup_Bytes_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketQuota struct {
+	ProjectId   []byte
+	BucketName  []byte
+	MaxObjects  int64
+	MaxSegments int64
+	UpdatedAt   time.Time
+}
+
+func (BucketQuota) _Table() string { return "bucket_quotas" }
+
+type BucketQuota_Create_Fields struct {
+	UpdatedAt BucketQuota_UpdatedAt_Field
+}
+
+type BucketQuota_Update_Fields struct {
+	MaxObjects  BucketQuota_MaxObjects_Field
+	MaxSegments BucketQuota_MaxSegments_Field
+	UpdatedAt   BucketQuota_UpdatedAt_Field
+}
+
+type BucketQuota_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketQuota_ProjectId(v []byte) BucketQuota_ProjectId_Field {
+	return BucketQuota_ProjectId_Field{_set: true, _value: v}
+}
+
+func (f BucketQuota_ProjectId_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketQuota_BucketName_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketQuota_BucketName(v []byte) BucketQuota_BucketName_Field {
+	return BucketQuota_BucketName_Field{_set: true, _value: v}
+}
+
+func (f BucketQuota_BucketName_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketQuota_MaxObjects_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func BucketQuota_MaxObjects(v int64) BucketQuota_MaxObjects_Field {
+	return BucketQuota_MaxObjects_Field{_set: true, _value: v}
+}
+
+func (f BucketQuota_MaxObjects_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketQuota_MaxSegments_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func BucketQuota_MaxSegments(v int64) BucketQuota_MaxSegments_Field {
+	return BucketQuota_MaxSegments_Field{_set: true, _value: v}
+}
+
+func (f BucketQuota_MaxSegments_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketQuota_UpdatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func BucketQuota_UpdatedAt(v time.Time) BucketQuota_UpdatedAt_Field {
+	return BucketQuota_UpdatedAt_Field{_set: true, _value: v}
+}
+
+func (f BucketQuota_UpdatedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
 type BucketStorageTally struct {
 	BucketName          []byte
 	ProjectId           []byte
@@ -4838,6 +6792,217 @@ func (f CoinpaymentsTransaction_CreatedAt_Field) value() any {
 	return f._value
 }
 
+type Domain struct {
+	Subdomain         string
+	ProjectId         []byte
+	CreatedBy         []byte
+	Prefix            string
+	AccessId          string
+	VerificationToken string
+	Status            int
+	StatusMessage     *string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (Domain) _Table() string { return "domains" }
+
+type Domain_Create_Fields struct {
+	Status        Domain_Status_Field
+	StatusMessage Domain_StatusMessage_Field
+	CreatedAt     Domain_CreatedAt_Field
+	UpdatedAt     Domain_UpdatedAt_Field
+}
+
+type Domain_Update_Fields struct {
+	Status        Domain_Status_Field
+	StatusMessage Domain_StatusMessage_Field
+	UpdatedAt     Domain_UpdatedAt_Field
+}
+
+type Domain_Subdomain_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func Domain_Subdomain(v string) Domain_Subdomain_Field {
+	return Domain_Subdomain_Field{_set: true, _value: v}
+}
+
+func (f Domain_Subdomain_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type Domain_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Domain_ProjectId(v []byte) Domain_ProjectId_Field {
+	return Domain_ProjectId_Field{_set: true, _value: v}
+}
+
+func (f Domain_ProjectId_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type Domain_CreatedBy_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Domain_CreatedBy(v []byte) Domain_CreatedBy_Field {
+	return Domain_CreatedBy_Field{_set: true, _value: v}
+}
+
+func (f Domain_CreatedBy_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type Domain_Prefix_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func Domain_Prefix(v string) Domain_Prefix_Field {
+	return Domain_Prefix_Field{_set: true, _value: v}
+}
+
+func (f Domain_Prefix_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type Domain_AccessId_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func Domain_AccessId(v string) Domain_AccessId_Field {
+	return Domain_AccessId_Field{_set: true, _value: v}
+}
+
+func (f Domain_AccessId_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type Domain_VerificationToken_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func Domain_VerificationToken(v string) Domain_VerificationToken_Field {
+	return Domain_VerificationToken_Field{_set: true, _value: v}
+}
+
+func (f Domain_VerificationToken_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type Domain_Status_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func Domain_Status(v int) Domain_Status_Field {
+	return Domain_Status_Field{_set: true, _value: v}
+}
+
+func (f Domain_Status_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type Domain_StatusMessage_Field struct {
+	_set   bool
+	_null  bool
+	_value *string
+}
+
+func Domain_StatusMessage(v string) Domain_StatusMessage_Field {
+	return Domain_StatusMessage_Field{_set: true, _value: &v}
+}
+
+func Domain_StatusMessage_Raw(v *string) Domain_StatusMessage_Field {
+	if v == nil {
+		return Domain_StatusMessage_Null()
+	}
+	return Domain_StatusMessage(*v)
+}
+
+func Domain_StatusMessage_Null() Domain_StatusMessage_Field {
+	return Domain_StatusMessage_Field{_set: true, _null: true}
+}
+
+func (f Domain_StatusMessage_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f Domain_StatusMessage_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type Domain_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Domain_CreatedAt(v time.Time) Domain_CreatedAt_Field {
+	return Domain_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f Domain_CreatedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type Domain_UpdatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Domain_UpdatedAt(v time.Time) Domain_UpdatedAt_Field {
+	return Domain_UpdatedAt_Field{_set: true, _value: v}
+}
+
+func (f Domain_UpdatedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
 type GracefulExitProgress struct {
 	NodeId            []byte
 	BytesTransferred  int64
@@ -5288,6 +7453,176 @@ func (f GracefulExitSegmentTransfer_OrderLimitSendCount_Field) value() any {
 	return f._value
 }
 
+type ImpersonationAudit struct {
+	SessionId  []byte
+	UserId     []byte
+	AdminEmail string
+	Reason     string
+	Consent    bool
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	EndedAt    *time.Time
+}
+
+func (ImpersonationAudit) _Table() string { return "impersonation_audits" }
+
+type ImpersonationAudit_Create_Fields struct {
+	EndedAt ImpersonationAudit_EndedAt_Field
+}
+
+type ImpersonationAudit_Update_Fields struct {
+	EndedAt ImpersonationAudit_EndedAt_Field
+}
+
+type ImpersonationAudit_SessionId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ImpersonationAudit_SessionId(v []byte) ImpersonationAudit_SessionId_Field {
+	return ImpersonationAudit_SessionId_Field{_set: true, _value: v}
+}
+
+func (f ImpersonationAudit_SessionId_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ImpersonationAudit_UserId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ImpersonationAudit_UserId(v []byte) ImpersonationAudit_UserId_Field {
+	return ImpersonationAudit_UserId_Field{_set: true, _value: v}
+}
+
+func (f ImpersonationAudit_UserId_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ImpersonationAudit_AdminEmail_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func ImpersonationAudit_AdminEmail(v string) ImpersonationAudit_AdminEmail_Field {
+	return ImpersonationAudit_AdminEmail_Field{_set: true, _value: v}
+}
+
+func (f ImpersonationAudit_AdminEmail_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ImpersonationAudit_Reason_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func ImpersonationAudit_Reason(v string) ImpersonationAudit_Reason_Field {
+	return ImpersonationAudit_Reason_Field{_set: true, _value: v}
+}
+
+func (f ImpersonationAudit_Reason_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ImpersonationAudit_Consent_Field struct {
+	_set   bool
+	_null  bool
+	_value bool
+}
+
+func ImpersonationAudit_Consent(v bool) ImpersonationAudit_Consent_Field {
+	return ImpersonationAudit_Consent_Field{_set: true, _value: v}
+}
+
+func (f ImpersonationAudit_Consent_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ImpersonationAudit_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ImpersonationAudit_CreatedAt(v time.Time) ImpersonationAudit_CreatedAt_Field {
+	return ImpersonationAudit_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f ImpersonationAudit_CreatedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ImpersonationAudit_ExpiresAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ImpersonationAudit_ExpiresAt(v time.Time) ImpersonationAudit_ExpiresAt_Field {
+	return ImpersonationAudit_ExpiresAt_Field{_set: true, _value: v}
+}
+
+func (f ImpersonationAudit_ExpiresAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ImpersonationAudit_EndedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func ImpersonationAudit_EndedAt(v time.Time) ImpersonationAudit_EndedAt_Field {
+	return ImpersonationAudit_EndedAt_Field{_set: true, _value: &v}
+}
+
+func ImpersonationAudit_EndedAt_Raw(v *time.Time) ImpersonationAudit_EndedAt_Field {
+	if v == nil {
+		return ImpersonationAudit_EndedAt_Null()
+	}
+	return ImpersonationAudit_EndedAt(*v)
+}
+
+func ImpersonationAudit_EndedAt_Null() ImpersonationAudit_EndedAt_Field {
+	return ImpersonationAudit_EndedAt_Field{_set: true, _null: true}
+}
+
+func (f ImpersonationAudit_EndedAt_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f ImpersonationAudit_EndedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
 type Node struct {
 	Id                      []byte
 	Address                 string
@@ -6362,6 +8697,170 @@ func (f NodeApiVersion_UpdatedAt_Field) value() any {
 	return f._value
 }
 
+type NodeAttestationIssuer struct {
+	Id        []byte
+	Name      string
+	Identity  []byte
+	CreatedAt time.Time
+}
+
+func (NodeAttestationIssuer) _Table() string { return "node_attestation_issuers" }
+
+type NodeAttestationIssuer_Create_Fields struct {
+	CreatedAt NodeAttestationIssuer_CreatedAt_Field
+}
+
+type NodeAttestationIssuer_Update_Fields struct {
+}
+
+type NodeAttestationIssuer_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func NodeAttestationIssuer_Id(v []byte) NodeAttestationIssuer_Id_Field {
+	return NodeAttestationIssuer_Id_Field{_set: true, _value: v}
+}
+
+func (f NodeAttestationIssuer_Id_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type NodeAttestationIssuer_Name_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func NodeAttestationIssuer_Name(v string) NodeAttestationIssuer_Name_Field {
+	return NodeAttestationIssuer_Name_Field{_set: true, _value: v}
+}
+
+func (f NodeAttestationIssuer_Name_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type NodeAttestationIssuer_Identity_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func NodeAttestationIssuer_Identity(v []byte) NodeAttestationIssuer_Identity_Field {
+	return NodeAttestationIssuer_Identity_Field{_set: true, _value: v}
+}
+
+func (f NodeAttestationIssuer_Identity_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type NodeAttestationIssuer_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func NodeAttestationIssuer_CreatedAt(v time.Time) NodeAttestationIssuer_CreatedAt_Field {
+	return NodeAttestationIssuer_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f NodeAttestationIssuer_CreatedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type NodeDrain struct {
+	NodeId      []byte
+	Reason      *string
+	InitiatedAt time.Time
+}
+
+func (NodeDrain) _Table() string { return "node_drains" }
+
+type NodeDrain_Create_Fields struct {
+	Reason      NodeDrain_Reason_Field
+	InitiatedAt NodeDrain_InitiatedAt_Field
+}
+
+type NodeDrain_Update_Fields struct {
+}
+
+type NodeDrain_NodeId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func NodeDrain_NodeId(v []byte) NodeDrain_NodeId_Field {
+	return NodeDrain_NodeId_Field{_set: true, _value: v}
+}
+
+func (f NodeDrain_NodeId_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type NodeDrain_Reason_Field struct {
+	_set   bool
+	_null  bool
+	_value *string
+}
+
+func NodeDrain_Reason(v string) NodeDrain_Reason_Field {
+	return NodeDrain_Reason_Field{_set: true, _value: &v}
+}
+
+func NodeDrain_Reason_Raw(v *string) NodeDrain_Reason_Field {
+	if v == nil {
+		return NodeDrain_Reason_Null()
+	}
+	return NodeDrain_Reason(*v)
+}
+
+func NodeDrain_Reason_Null() NodeDrain_Reason_Field {
+	return NodeDrain_Reason_Field{_set: true, _null: true}
+}
+
+func (f NodeDrain_Reason_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f NodeDrain_Reason_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type NodeDrain_InitiatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func NodeDrain_InitiatedAt(v time.Time) NodeDrain_InitiatedAt_Field {
+	return NodeDrain_InitiatedAt_Field{_set: true, _value: v}
+}
+
+func (f NodeDrain_InitiatedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
 type NodeEvent struct {
 	Id            []byte
 	Email         string
@@ -6562,6 +9061,161 @@ func (f NodeEvent_EmailSent_Field) value() any {
 	return f._value
 }
 
+type NodeSelectionFairnessEntry struct {
+	NodeId        []byte
+	FreeDisk      int64
+	UploadedBytes int64
+	ExpectedShare float64
+	ActualShare   float64
+	Ratio         float64
+	Status        string
+	GeneratedAt   time.Time
+}
+
+func (NodeSelectionFairnessEntry) _Table() string { return "node_selection_fairness_entries" }
+
+type NodeSelectionFairnessEntry_Create_Fields struct {
+}
+
+type NodeSelectionFairnessEntry_Update_Fields struct {
+}
+
+type NodeSelectionFairnessEntry_NodeId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func NodeSelectionFairnessEntry_NodeId(v []byte) NodeSelectionFairnessEntry_NodeId_Field {
+	return NodeSelectionFairnessEntry_NodeId_Field{_set: true, _value: v}
+}
+
+func (f NodeSelectionFairnessEntry_NodeId_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type NodeSelectionFairnessEntry_FreeDisk_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func NodeSelectionFairnessEntry_FreeDisk(v int64) NodeSelectionFairnessEntry_FreeDisk_Field {
+	return NodeSelectionFairnessEntry_FreeDisk_Field{_set: true, _value: v}
+}
+
+func (f NodeSelectionFairnessEntry_FreeDisk_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type NodeSelectionFairnessEntry_UploadedBytes_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func NodeSelectionFairnessEntry_UploadedBytes(v int64) NodeSelectionFairnessEntry_UploadedBytes_Field {
+	return NodeSelectionFairnessEntry_UploadedBytes_Field{_set: true, _value: v}
+}
+
+func (f NodeSelectionFairnessEntry_UploadedBytes_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type NodeSelectionFairnessEntry_ExpectedShare_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func NodeSelectionFairnessEntry_ExpectedShare(v float64) NodeSelectionFairnessEntry_ExpectedShare_Field {
+	return NodeSelectionFairnessEntry_ExpectedShare_Field{_set: true, _value: v}
+}
+
+func (f NodeSelectionFairnessEntry_ExpectedShare_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type NodeSelectionFairnessEntry_ActualShare_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func NodeSelectionFairnessEntry_ActualShare(v float64) NodeSelectionFairnessEntry_ActualShare_Field {
+	return NodeSelectionFairnessEntry_ActualShare_Field{_set: true, _value: v}
+}
+
+func (f NodeSelectionFairnessEntry_ActualShare_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type NodeSelectionFairnessEntry_Ratio_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func NodeSelectionFairnessEntry_Ratio(v float64) NodeSelectionFairnessEntry_Ratio_Field {
+	return NodeSelectionFairnessEntry_Ratio_Field{_set: true, _value: v}
+}
+
+func (f NodeSelectionFairnessEntry_Ratio_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type NodeSelectionFairnessEntry_Status_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func NodeSelectionFairnessEntry_Status(v string) NodeSelectionFairnessEntry_Status_Field {
+	return NodeSelectionFairnessEntry_Status_Field{_set: true, _value: v}
+}
+
+func (f NodeSelectionFairnessEntry_Status_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type NodeSelectionFairnessEntry_GeneratedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func NodeSelectionFairnessEntry_GeneratedAt(v time.Time) NodeSelectionFairnessEntry_GeneratedAt_Field {
+	return NodeSelectionFairnessEntry_GeneratedAt_Field{_set: true, _value: v}
+}
+
+func (f NodeSelectionFairnessEntry_GeneratedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
 type NodeTags struct {
 	NodeId   []byte
 	Name     string
@@ -6660,6 +9314,71 @@ func (f NodeTags_Signer_Field) value() any {
 	return f._value
 }
 
+type NodeUptimeTransition struct {
+	NodeId    []byte
+	ChangedAt time.Time
+	Online    bool
+}
+
+func (NodeUptimeTransition) _Table() string { return "node_uptime_transitions" }
+
+type NodeUptimeTransition_Create_Fields struct {
+}
+
+type NodeUptimeTransition_Update_Fields struct {
+}
+
+type NodeUptimeTransition_NodeId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func NodeUptimeTransition_NodeId(v []byte) NodeUptimeTransition_NodeId_Field {
+	return NodeUptimeTransition_NodeId_Field{_set: true, _value: v}
+}
+
+func (f NodeUptimeTransition_NodeId_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type NodeUptimeTransition_ChangedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func NodeUptimeTransition_ChangedAt(v time.Time) NodeUptimeTransition_ChangedAt_Field {
+	return NodeUptimeTransition_ChangedAt_Field{_set: true, _value: v}
+}
+
+func (f NodeUptimeTransition_ChangedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type NodeUptimeTransition_Online_Field struct {
+	_set   bool
+	_null  bool
+	_value bool
+}
+
+func NodeUptimeTransition_Online(v bool) NodeUptimeTransition_Online_Field {
+	return NodeUptimeTransition_Online_Field{_set: true, _value: v}
+}
+
+func (f NodeUptimeTransition_Online_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
 type OauthClient struct {
 	Id              []byte
 	EncryptedSecret []byte
@@ -7203,6 +9922,168 @@ func (f PeerIdentity_UpdatedAt_Field) value() any {
 	return f._value
 }
 
+type PlacementSlaDaily struct {
+	Day                    time.Time
+	Placement              int
+	Probes                 int64
+	FailedProbes           int64
+	MaxRepairQueueSegments int64
+	MinSegmentHealth       *float64
+	UpdatedAt              time.Time
+}
+
+func (PlacementSlaDaily) _Table() string { return "placement_sla_dailies" }
+
+type PlacementSlaDaily_Create_Fields struct {
+	Probes                 PlacementSlaDaily_Probes_Field
+	FailedProbes           PlacementSlaDaily_FailedProbes_Field
+	MaxRepairQueueSegments PlacementSlaDaily_MaxRepairQueueSegments_Field
+	MinSegmentHealth       PlacementSlaDaily_MinSegmentHealth_Field
+	UpdatedAt              PlacementSlaDaily_UpdatedAt_Field
+}
+
+type PlacementSlaDaily_Update_Fields struct {
+	Probes                 PlacementSlaDaily_Probes_Field
+	FailedProbes           PlacementSlaDaily_FailedProbes_Field
+	MaxRepairQueueSegments PlacementSlaDaily_MaxRepairQueueSegments_Field
+	MinSegmentHealth       PlacementSlaDaily_MinSegmentHealth_Field
+	UpdatedAt              PlacementSlaDaily_UpdatedAt_Field
+}
+
+type PlacementSlaDaily_Day_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func PlacementSlaDaily_Day(v time.Time) PlacementSlaDaily_Day_Field {
+	return PlacementSlaDaily_Day_Field{_set: true, _value: v}
+}
+
+func (f PlacementSlaDaily_Day_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type PlacementSlaDaily_Placement_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func PlacementSlaDaily_Placement(v int) PlacementSlaDaily_Placement_Field {
+	return PlacementSlaDaily_Placement_Field{_set: true, _value: v}
+}
+
+func (f PlacementSlaDaily_Placement_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type PlacementSlaDaily_Probes_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func PlacementSlaDaily_Probes(v int64) PlacementSlaDaily_Probes_Field {
+	return PlacementSlaDaily_Probes_Field{_set: true, _value: v}
+}
+
+func (f PlacementSlaDaily_Probes_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type PlacementSlaDaily_FailedProbes_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func PlacementSlaDaily_FailedProbes(v int64) PlacementSlaDaily_FailedProbes_Field {
+	return PlacementSlaDaily_FailedProbes_Field{_set: true, _value: v}
+}
+
+func (f PlacementSlaDaily_FailedProbes_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type PlacementSlaDaily_MaxRepairQueueSegments_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func PlacementSlaDaily_MaxRepairQueueSegments(v int64) PlacementSlaDaily_MaxRepairQueueSegments_Field {
+	return PlacementSlaDaily_MaxRepairQueueSegments_Field{_set: true, _value: v}
+}
+
+func (f PlacementSlaDaily_MaxRepairQueueSegments_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type PlacementSlaDaily_MinSegmentHealth_Field struct {
+	_set   bool
+	_null  bool
+	_value *float64
+}
+
+func PlacementSlaDaily_MinSegmentHealth(v float64) PlacementSlaDaily_MinSegmentHealth_Field {
+	return PlacementSlaDaily_MinSegmentHealth_Field{_set: true, _value: &v}
+}
+
+func PlacementSlaDaily_MinSegmentHealth_Raw(v *float64) PlacementSlaDaily_MinSegmentHealth_Field {
+	if v == nil {
+		return PlacementSlaDaily_MinSegmentHealth_Null()
+	}
+	return PlacementSlaDaily_MinSegmentHealth(*v)
+}
+
+func PlacementSlaDaily_MinSegmentHealth_Null() PlacementSlaDaily_MinSegmentHealth_Field {
+	return PlacementSlaDaily_MinSegmentHealth_Field{_set: true, _null: true}
+}
+
+func (f PlacementSlaDaily_MinSegmentHealth_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
+
+func (f PlacementSlaDaily_MinSegmentHealth_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type PlacementSlaDaily_UpdatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func PlacementSlaDaily_UpdatedAt(v time.Time) PlacementSlaDaily_UpdatedAt_Field {
+	return PlacementSlaDaily_UpdatedAt_Field{_set: true, _value: v}
+}
+
+func (f PlacementSlaDaily_UpdatedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
 type Project struct {
 	Id                          []byte
 	PublicId                    []byte
@@ -8266,6 +11147,390 @@ func (f ProjectBandwidthDailyRollup_EgressDead_Field) value() any {
 	return f._value
 }
 
+type ProjectBudgetCap struct {
+	ProjectId            []byte
+	CapCents             int64
+	NotifiedPercent      int
+	PeriodStart          time.Time
+	FrozenAt             *time.Time
+	FrozenStorageLimit   *int64
+	FrozenBandwidthLimit *int64
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+func (ProjectBudgetCap) _Table() string { return "project_budget_caps" }
+
+type ProjectBudgetCap_Create_Fields struct {
+	NotifiedPercent      ProjectBudgetCap_NotifiedPercent_Field
+	FrozenAt             ProjectBudgetCap_FrozenAt_Field
+	FrozenStorageLimit   ProjectBudgetCap_FrozenStorageLimit_Field
+	FrozenBandwidthLimit ProjectBudgetCap_FrozenBandwidthLimit_Field
+	CreatedAt            ProjectBudgetCap_CreatedAt_Field
+	UpdatedAt            ProjectBudgetCap_UpdatedAt_Field
+}
+
+type ProjectBudgetCap_Update_Fields struct {
+	CapCents             ProjectBudgetCap_CapCents_Field
+	NotifiedPercent      ProjectBudgetCap_NotifiedPercent_Field
+	PeriodStart          ProjectBudgetCap_PeriodStart_Field
+	FrozenAt             ProjectBudgetCap_FrozenAt_Field
+	FrozenStorageLimit   ProjectBudgetCap_FrozenStorageLimit_Field
+	FrozenBandwidthLimit ProjectBudgetCap_FrozenBandwidthLimit_Field
+	UpdatedAt            ProjectBudgetCap_UpdatedAt_Field
+}
+
+type ProjectBudgetCap_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ProjectBudgetCap_ProjectId(v []byte) ProjectBudgetCap_ProjectId_Field {
+	return ProjectBudgetCap_ProjectId_Field{_set: true, _value: v}
+}
+
+func (f ProjectBudgetCap_ProjectId_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ProjectBudgetCap_CapCents_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func ProjectBudgetCap_CapCents(v int64) ProjectBudgetCap_CapCents_Field {
+	return ProjectBudgetCap_CapCents_Field{_set: true, _value: v}
+}
+
+func (f ProjectBudgetCap_CapCents_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ProjectBudgetCap_NotifiedPercent_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func ProjectBudgetCap_NotifiedPercent(v int) ProjectBudgetCap_NotifiedPercent_Field {
+	return ProjectBudgetCap_NotifiedPercent_Field{_set: true, _value: v}
+}
+
+func (f ProjectBudgetCap_NotifiedPercent_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ProjectBudgetCap_PeriodStart_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ProjectBudgetCap_PeriodStart(v time.Time) ProjectBudgetCap_PeriodStart_Field {
+	return ProjectBudgetCap_PeriodStart_Field{_set: true, _value: v}
+}
+
+func (f ProjectBudgetCap_PeriodStart_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ProjectBudgetCap_FrozenAt_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func ProjectBudgetCap_FrozenAt(v time.Time) ProjectBudgetCap_FrozenAt_Field {
+	return ProjectBudgetCap_FrozenAt_Field{_set: true, _value: &v}
+}
+
+func ProjectBudgetCap_FrozenAt_Raw(v *time.Time) ProjectBudgetCap_FrozenAt_Field {
+	if v == nil {
+		return ProjectBudgetCap_FrozenAt_Null()
+	}
+	return ProjectBudgetCap_FrozenAt(*v)
+}
+
+func ProjectBudgetCap_FrozenAt_Null() ProjectBudgetCap_FrozenAt_Field {
+	return ProjectBudgetCap_FrozenAt_Field{_set: true, _null: true}
+}
+
+func (f ProjectBudgetCap_FrozenAt_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f ProjectBudgetCap_FrozenAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ProjectBudgetCap_FrozenStorageLimit_Field struct {
+	_set   bool
+	_null  bool
+	_value *int64
+}
+
+func ProjectBudgetCap_FrozenStorageLimit(v int64) ProjectBudgetCap_FrozenStorageLimit_Field {
+	return ProjectBudgetCap_FrozenStorageLimit_Field{_set: true, _value: &v}
+}
+
+func ProjectBudgetCap_FrozenStorageLimit_Raw(v *int64) ProjectBudgetCap_FrozenStorageLimit_Field {
+	if v == nil {
+		return ProjectBudgetCap_FrozenStorageLimit_Null()
+	}
+	return ProjectBudgetCap_FrozenStorageLimit(*v)
+}
+
+func ProjectBudgetCap_FrozenStorageLimit_Null() ProjectBudgetCap_FrozenStorageLimit_Field {
+	return ProjectBudgetCap_FrozenStorageLimit_Field{_set: true, _null: true}
+}
+
+func (f ProjectBudgetCap_FrozenStorageLimit_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
+
+func (f ProjectBudgetCap_FrozenStorageLimit_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ProjectBudgetCap_FrozenBandwidthLimit_Field struct {
+	_set   bool
+	_null  bool
+	_value *int64
+}
+
+func ProjectBudgetCap_FrozenBandwidthLimit(v int64) ProjectBudgetCap_FrozenBandwidthLimit_Field {
+	return ProjectBudgetCap_FrozenBandwidthLimit_Field{_set: true, _value: &v}
+}
+
+func ProjectBudgetCap_FrozenBandwidthLimit_Raw(v *int64) ProjectBudgetCap_FrozenBandwidthLimit_Field {
+	if v == nil {
+		return ProjectBudgetCap_FrozenBandwidthLimit_Null()
+	}
+	return ProjectBudgetCap_FrozenBandwidthLimit(*v)
+}
+
+func ProjectBudgetCap_FrozenBandwidthLimit_Null() ProjectBudgetCap_FrozenBandwidthLimit_Field {
+	return ProjectBudgetCap_FrozenBandwidthLimit_Field{_set: true, _null: true}
+}
+
+func (f ProjectBudgetCap_FrozenBandwidthLimit_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
+
+func (f ProjectBudgetCap_FrozenBandwidthLimit_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ProjectBudgetCap_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ProjectBudgetCap_CreatedAt(v time.Time) ProjectBudgetCap_CreatedAt_Field {
+	return ProjectBudgetCap_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f ProjectBudgetCap_CreatedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ProjectBudgetCap_UpdatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ProjectBudgetCap_UpdatedAt(v time.Time) ProjectBudgetCap_UpdatedAt_Field {
+	return ProjectBudgetCap_UpdatedAt_Field{_set: true, _value: v}
+}
+
+func (f ProjectBudgetCap_UpdatedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ProjectUsageWebhook struct {
+	Id          []byte
+	ProjectId   []byte
+	Url         string
+	Secret      []byte
+	CreatedBy   []byte
+	LastSentDay *time.Time
+	CreatedAt   time.Time
+}
+
+func (ProjectUsageWebhook) _Table() string { return "project_usage_webhooks" }
+
+type ProjectUsageWebhook_Create_Fields struct {
+	LastSentDay ProjectUsageWebhook_LastSentDay_Field
+	CreatedAt   ProjectUsageWebhook_CreatedAt_Field
+}
+
+type ProjectUsageWebhook_Update_Fields struct {
+	LastSentDay ProjectUsageWebhook_LastSentDay_Field
+}
+
+type ProjectUsageWebhook_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ProjectUsageWebhook_Id(v []byte) ProjectUsageWebhook_Id_Field {
+	return ProjectUsageWebhook_Id_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsageWebhook_Id_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ProjectUsageWebhook_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ProjectUsageWebhook_ProjectId(v []byte) ProjectUsageWebhook_ProjectId_Field {
+	return ProjectUsageWebhook_ProjectId_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsageWebhook_ProjectId_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ProjectUsageWebhook_Url_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func ProjectUsageWebhook_Url(v string) ProjectUsageWebhook_Url_Field {
+	return ProjectUsageWebhook_Url_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsageWebhook_Url_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ProjectUsageWebhook_Secret_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ProjectUsageWebhook_Secret(v []byte) ProjectUsageWebhook_Secret_Field {
+	return ProjectUsageWebhook_Secret_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsageWebhook_Secret_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ProjectUsageWebhook_CreatedBy_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ProjectUsageWebhook_CreatedBy(v []byte) ProjectUsageWebhook_CreatedBy_Field {
+	return ProjectUsageWebhook_CreatedBy_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsageWebhook_CreatedBy_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ProjectUsageWebhook_LastSentDay_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func ProjectUsageWebhook_LastSentDay(v time.Time) ProjectUsageWebhook_LastSentDay_Field {
+	return ProjectUsageWebhook_LastSentDay_Field{_set: true, _value: &v}
+}
+
+func ProjectUsageWebhook_LastSentDay_Raw(v *time.Time) ProjectUsageWebhook_LastSentDay_Field {
+	if v == nil {
+		return ProjectUsageWebhook_LastSentDay_Null()
+	}
+	return ProjectUsageWebhook_LastSentDay(*v)
+}
+
+func ProjectUsageWebhook_LastSentDay_Null() ProjectUsageWebhook_LastSentDay_Field {
+	return ProjectUsageWebhook_LastSentDay_Field{_set: true, _null: true}
+}
+
+func (f ProjectUsageWebhook_LastSentDay_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
+
+func (f ProjectUsageWebhook_LastSentDay_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ProjectUsageWebhook_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ProjectUsageWebhook_CreatedAt(v time.Time) ProjectUsageWebhook_CreatedAt_Field {
+	return ProjectUsageWebhook_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsageWebhook_CreatedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
 type RegistrationToken struct {
 	Secret       []byte
 	OwnerId      []byte
@@ -8365,29 +11630,38 @@ func (f RegistrationToken_CreatedAt_Field) value() any {
 }
 
 type RepairQueue struct {
-	StreamId      []byte
-	Position      uint64
-	AttemptedAt   *time.Time
-	UpdatedAt     time.Time
-	InsertedAt    time.Time
-	SegmentHealth float64
-	Placement     *int
+	StreamId       []byte
+	Position       uint64
+	AttemptedAt    *time.Time
+	UpdatedAt      time.Time
+	InsertedAt     time.Time
+	SegmentHealth  float64
+	Placement      *int
+	Reason         int
+	LeasedBy       *string
+	LeaseExpiresAt *time.Time
 }
 
 func (RepairQueue) _Table() string { return "repair_queue" }
 
 type RepairQueue_Create_Fields struct {
-	AttemptedAt   RepairQueue_AttemptedAt_Field
-	UpdatedAt     RepairQueue_UpdatedAt_Field
-	InsertedAt    RepairQueue_InsertedAt_Field
-	SegmentHealth RepairQueue_SegmentHealth_Field
-	Placement     RepairQueue_Placement_Field
+	AttemptedAt    RepairQueue_AttemptedAt_Field
+	UpdatedAt      RepairQueue_UpdatedAt_Field
+	InsertedAt     RepairQueue_InsertedAt_Field
+	SegmentHealth  RepairQueue_SegmentHealth_Field
+	Placement      RepairQueue_Placement_Field
+	Reason         RepairQueue_Reason_Field
+	LeasedBy       RepairQueue_LeasedBy_Field
+	LeaseExpiresAt RepairQueue_LeaseExpiresAt_Field
 }
 
 type RepairQueue_Update_Fields struct {
-	AttemptedAt RepairQueue_AttemptedAt_Field
-	UpdatedAt   RepairQueue_UpdatedAt_Field
-	Placement   RepairQueue_Placement_Field
+	AttemptedAt    RepairQueue_AttemptedAt_Field
+	UpdatedAt      RepairQueue_UpdatedAt_Field
+	Placement      RepairQueue_Placement_Field
+	Reason         RepairQueue_Reason_Field
+	LeasedBy       RepairQueue_LeasedBy_Field
+	LeaseExpiresAt RepairQueue_LeaseExpiresAt_Field
 }
 
 type RepairQueue_StreamId_Field struct {
@@ -8535,6 +11809,83 @@ func (f RepairQueue_Placement_Field) value() any {
 	return f._value
 }
 
+type RepairQueue_Reason_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func RepairQueue_Reason(v int) RepairQueue_Reason_Field {
+	return RepairQueue_Reason_Field{_set: true, _value: v}
+}
+
+func (f RepairQueue_Reason_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type RepairQueue_LeasedBy_Field struct {
+	_set   bool
+	_null  bool
+	_value *string
+}
+
+func RepairQueue_LeasedBy(v string) RepairQueue_LeasedBy_Field {
+	return RepairQueue_LeasedBy_Field{_set: true, _value: &v}
+}
+
+func RepairQueue_LeasedBy_Raw(v *string) RepairQueue_LeasedBy_Field {
+	if v == nil {
+		return RepairQueue_LeasedBy_Null()
+	}
+	return RepairQueue_LeasedBy(*v)
+}
+
+func RepairQueue_LeasedBy_Null() RepairQueue_LeasedBy_Field {
+	return RepairQueue_LeasedBy_Field{_set: true, _null: true}
+}
+
+func (f RepairQueue_LeasedBy_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f RepairQueue_LeasedBy_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type RepairQueue_LeaseExpiresAt_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func RepairQueue_LeaseExpiresAt(v time.Time) RepairQueue_LeaseExpiresAt_Field {
+	return RepairQueue_LeaseExpiresAt_Field{_set: true, _value: &v}
+}
+
+func RepairQueue_LeaseExpiresAt_Raw(v *time.Time) RepairQueue_LeaseExpiresAt_Field {
+	if v == nil {
+		return RepairQueue_LeaseExpiresAt_Null()
+	}
+	return RepairQueue_LeaseExpiresAt(*v)
+}
+
+func RepairQueue_LeaseExpiresAt_Null() RepairQueue_LeaseExpiresAt_Field {
+	return RepairQueue_LeaseExpiresAt_Field{_set: true, _null: true}
+}
+
+func (f RepairQueue_LeaseExpiresAt_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f RepairQueue_LeaseExpiresAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
 type Reputation struct {
 	Id                          []byte
 	AuditSuccessCount           int64
@@ -9380,6 +12731,108 @@ func (f SegmentPendingAudits_ReverifyCount_Field) value() any {
 	return f._value
 }
 
+type SsoIdentity struct {
+	Provider  string
+	Subject   string
+	UserId    []byte
+	Email     string
+	CreatedAt time.Time
+}
+
+func (SsoIdentity) _Table() string { return "sso_identities" }
+
+type SsoIdentity_Create_Fields struct {
+	CreatedAt SsoIdentity_CreatedAt_Field
+}
+
+type SsoIdentity_Update_Fields struct {
+}
+
+type SsoIdentity_Provider_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func SsoIdentity_Provider(v string) SsoIdentity_Provider_Field {
+	return SsoIdentity_Provider_Field{_set: true, _value: v}
+}
+
+func (f SsoIdentity_Provider_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type SsoIdentity_Subject_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func SsoIdentity_Subject(v string) SsoIdentity_Subject_Field {
+	return SsoIdentity_Subject_Field{_set: true, _value: v}
+}
+
+func (f SsoIdentity_Subject_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type SsoIdentity_UserId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func SsoIdentity_UserId(v []byte) SsoIdentity_UserId_Field {
+	return SsoIdentity_UserId_Field{_set: true, _value: v}
+}
+
+func (f SsoIdentity_UserId_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type SsoIdentity_Email_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func SsoIdentity_Email(v string) SsoIdentity_Email_Field {
+	return SsoIdentity_Email_Field{_set: true, _value: v}
+}
+
+func (f SsoIdentity_Email_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type SsoIdentity_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func SsoIdentity_CreatedAt(v time.Time) SsoIdentity_CreatedAt_Field {
+	return SsoIdentity_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f SsoIdentity_CreatedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
 type StoragenodeBandwidthRollup struct {
 	StoragenodeId   []byte
 	IntervalStart   time.Time
@@ -12627,6 +16080,73 @@ func (f VerificationAudits_EncryptedSize_Field) value() any {
 	return f._value
 }
 
+type WarehouseExportMarker struct {
+	Stream        string
+	ExportedUntil time.Time
+	UpdatedAt     time.Time
+}
+
+func (WarehouseExportMarker) _Table() string { return "warehouse_export_markers" }
+
+type WarehouseExportMarker_Create_Fields struct {
+}
+
+type WarehouseExportMarker_Update_Fields struct {
+	ExportedUntil WarehouseExportMarker_ExportedUntil_Field
+	UpdatedAt     WarehouseExportMarker_UpdatedAt_Field
+}
+
+type WarehouseExportMarker_Stream_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func WarehouseExportMarker_Stream(v string) WarehouseExportMarker_Stream_Field {
+	return WarehouseExportMarker_Stream_Field{_set: true, _value: v}
+}
+
+func (f WarehouseExportMarker_Stream_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type WarehouseExportMarker_ExportedUntil_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func WarehouseExportMarker_ExportedUntil(v time.Time) WarehouseExportMarker_ExportedUntil_Field {
+	return WarehouseExportMarker_ExportedUntil_Field{_set: true, _value: v}
+}
+
+func (f WarehouseExportMarker_ExportedUntil_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type WarehouseExportMarker_UpdatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func WarehouseExportMarker_UpdatedAt(v time.Time) WarehouseExportMarker_UpdatedAt_Field {
+	return WarehouseExportMarker_UpdatedAt_Field{_set: true, _value: v}
+}
+
+func (f WarehouseExportMarker_UpdatedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
 type WebappSession struct {
 	Id        []byte
 	UserId    []byte
@@ -12745,6 +16265,92 @@ func (f WebappSession_ExpiresAt_Field) value() any {
 	return f._value
 }
 
+type ZombieDeletionSetting struct {
+	ProjectId          []byte
+	BucketName         []byte
+	InactiveForSeconds int64
+	UpdatedAt          time.Time
+}
+
+func (ZombieDeletionSetting) _Table() string { return "zombie_deletion_settings" }
+
+type ZombieDeletionSetting_Create_Fields struct {
+	UpdatedAt ZombieDeletionSetting_UpdatedAt_Field
+}
+
+type ZombieDeletionSetting_Update_Fields struct {
+	InactiveForSeconds ZombieDeletionSetting_InactiveForSeconds_Field
+	UpdatedAt          ZombieDeletionSetting_UpdatedAt_Field
+}
+
+type ZombieDeletionSetting_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ZombieDeletionSetting_ProjectId(v []byte) ZombieDeletionSetting_ProjectId_Field {
+	return ZombieDeletionSetting_ProjectId_Field{_set: true, _value: v}
+}
+
+func (f ZombieDeletionSetting_ProjectId_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ZombieDeletionSetting_BucketName_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ZombieDeletionSetting_BucketName(v []byte) ZombieDeletionSetting_BucketName_Field {
+	return ZombieDeletionSetting_BucketName_Field{_set: true, _value: v}
+}
+
+func (f ZombieDeletionSetting_BucketName_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ZombieDeletionSetting_InactiveForSeconds_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func ZombieDeletionSetting_InactiveForSeconds(v int64) ZombieDeletionSetting_InactiveForSeconds_Field {
+	return ZombieDeletionSetting_InactiveForSeconds_Field{_set: true, _value: v}
+}
+
+func (f ZombieDeletionSetting_InactiveForSeconds_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type ZombieDeletionSetting_UpdatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ZombieDeletionSetting_UpdatedAt(v time.Time) ZombieDeletionSetting_UpdatedAt_Field {
+	return ZombieDeletionSetting_UpdatedAt_Field{_set: true, _value: v}
+}
+
+func (f ZombieDeletionSetting_UpdatedAt_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
 type ApiKey struct {
 	Id        []byte
 	ProjectId []byte
@@ -12968,16 +16574,24 @@ type BucketMetainfo struct {
 	DefaultRedundancyTotalShares    int
 	Placement                       *int
 	CreatedBy                       []byte
+	LifecycleConfiguration          []byte
+	DeletionProtection              bool
+	OverrideDefaults                bool
+	CorsConfiguration               []byte
 }
 
 func (BucketMetainfo) _Table() string { return "bucket_metainfos" }
 
 type BucketMetainfo_Create_Fields struct {
-	UserAgent         BucketMetainfo_UserAgent_Field
-	Versioning        BucketMetainfo_Versioning_Field
-	ObjectLockEnabled BucketMetainfo_ObjectLockEnabled_Field
-	Placement         BucketMetainfo_Placement_Field
-	CreatedBy         BucketMetainfo_CreatedBy_Field
+	UserAgent              BucketMetainfo_UserAgent_Field
+	Versioning             BucketMetainfo_Versioning_Field
+	ObjectLockEnabled      BucketMetainfo_ObjectLockEnabled_Field
+	Placement              BucketMetainfo_Placement_Field
+	CreatedBy              BucketMetainfo_CreatedBy_Field
+	LifecycleConfiguration BucketMetainfo_LifecycleConfiguration_Field
+	DeletionProtection     BucketMetainfo_DeletionProtection_Field
+	OverrideDefaults       BucketMetainfo_OverrideDefaults_Field
+	CorsConfiguration      BucketMetainfo_CorsConfiguration_Field
 }
 
 type BucketMetainfo_Update_Fields struct {
@@ -12994,6 +16608,10 @@ type BucketMetainfo_Update_Fields struct {
 	DefaultRedundancyOptimalShares  BucketMetainfo_DefaultRedundancyOptimalShares_Field
 	DefaultRedundancyTotalShares    BucketMetainfo_DefaultRedundancyTotalShares_Field
 	Placement                       BucketMetainfo_Placement_Field
+	LifecycleConfiguration          BucketMetainfo_LifecycleConfiguration_Field
+	DeletionProtection              BucketMetainfo_DeletionProtection_Field
+	OverrideDefaults                BucketMetainfo_OverrideDefaults_Field
+	CorsConfiguration               BucketMetainfo_CorsConfiguration_Field
 }
 
 type BucketMetainfo_Id_Field struct {
@@ -13358,6 +16976,104 @@ func (f BucketMetainfo_CreatedBy_Field) value() any {
 	return f._value
 }
 
+type BucketMetainfo_LifecycleConfiguration_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketMetainfo_LifecycleConfiguration(v []byte) BucketMetainfo_LifecycleConfiguration_Field {
+	return BucketMetainfo_LifecycleConfiguration_Field{_set: true, _value: v}
+}
+
+func BucketMetainfo_LifecycleConfiguration_Raw(v []byte) BucketMetainfo_LifecycleConfiguration_Field {
+	if v == nil {
+		return BucketMetainfo_LifecycleConfiguration_Null()
+	}
+	return BucketMetainfo_LifecycleConfiguration(v)
+}
+
+func BucketMetainfo_LifecycleConfiguration_Null() BucketMetainfo_LifecycleConfiguration_Field {
+	return BucketMetainfo_LifecycleConfiguration_Field{_set: true, _null: true}
+}
+
+func (f BucketMetainfo_LifecycleConfiguration_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
+
+func (f BucketMetainfo_LifecycleConfiguration_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketMetainfo_DeletionProtection_Field struct {
+	_set   bool
+	_null  bool
+	_value bool
+}
+
+func BucketMetainfo_DeletionProtection(v bool) BucketMetainfo_DeletionProtection_Field {
+	return BucketMetainfo_DeletionProtection_Field{_set: true, _value: v}
+}
+
+func (f BucketMetainfo_DeletionProtection_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketMetainfo_OverrideDefaults_Field struct {
+	_set   bool
+	_null  bool
+	_value bool
+}
+
+func BucketMetainfo_OverrideDefaults(v bool) BucketMetainfo_OverrideDefaults_Field {
+	return BucketMetainfo_OverrideDefaults_Field{_set: true, _value: v}
+}
+
+func (f BucketMetainfo_OverrideDefaults_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+type BucketMetainfo_CorsConfiguration_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketMetainfo_CorsConfiguration(v []byte) BucketMetainfo_CorsConfiguration_Field {
+	return BucketMetainfo_CorsConfiguration_Field{_set: true, _value: v}
+}
+
+func BucketMetainfo_CorsConfiguration_Raw(v []byte) BucketMetainfo_CorsConfiguration_Field {
+	if v == nil {
+		return BucketMetainfo_CorsConfiguration_Null()
+	}
+	return BucketMetainfo_CorsConfiguration(v)
+}
+
+func BucketMetainfo_CorsConfiguration_Null() BucketMetainfo_CorsConfiguration_Field {
+	return BucketMetainfo_CorsConfiguration_Field{_set: true, _null: true}
+}
+
+func (f BucketMetainfo_CorsConfiguration_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
+
+func (f BucketMetainfo_CorsConfiguration_Field) value() any {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
 type ProjectInvitation struct {
 	ProjectId []byte
 	Email     string
@@ -15391,15 +19107,17 @@ func (obj *pgxImpl) Create_BucketMetainfo(ctx context.Context,
 	__default_redundancy_total_shares_val := bucket_metainfo_default_redundancy_total_shares.value()
 	__placement_val := optional.Placement.value()
 	__created_by_val := optional.CreatedBy.value()
+	__lifecycle_configuration_val := optional.LifecycleConfiguration.value()
+	__cors_configuration_val := optional.CorsConfiguration.value()
 
-	var __columns = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("id, project_id, name, user_agent, path_cipher, created_at, default_segment_size, default_encryption_cipher_suite, default_encryption_block_size, default_redundancy_algorithm, default_redundancy_share_size, default_redundancy_required_shares, default_redundancy_repair_shares, default_redundancy_optimal_shares, default_redundancy_total_shares, placement, created_by")}
-	var __placeholders = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?")}
+	var __columns = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("id, project_id, name, user_agent, path_cipher, created_at, default_segment_size, default_encryption_cipher_suite, default_encryption_block_size, default_redundancy_algorithm, default_redundancy_share_size, default_redundancy_required_shares, default_redundancy_repair_shares, default_redundancy_optimal_shares, default_redundancy_total_shares, placement, created_by, lifecycle_configuration, cors_configuration")}
+	var __placeholders = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?")}
 	var __clause = &__sqlbundle_Hole{SQL: __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("("), __columns, __sqlbundle_Literal(") VALUES ("), __placeholders, __sqlbundle_Literal(")")}}}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("INSERT INTO bucket_metainfos "), __clause, __sqlbundle_Literal(" RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("INSERT INTO bucket_metainfos "), __clause, __sqlbundle_Literal(" RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration")}}
 
 	var __values []any
-	__values = append(__values, __id_val, __project_id_val, __name_val, __user_agent_val, __path_cipher_val, __created_at_val, __default_segment_size_val, __default_encryption_cipher_suite_val, __default_encryption_block_size_val, __default_redundancy_algorithm_val, __default_redundancy_share_size_val, __default_redundancy_required_shares_val, __default_redundancy_repair_shares_val, __default_redundancy_optimal_shares_val, __default_redundancy_total_shares_val, __placement_val, __created_by_val)
+	__values = append(__values, __id_val, __project_id_val, __name_val, __user_agent_val, __path_cipher_val, __created_at_val, __default_segment_size_val, __default_encryption_cipher_suite_val, __default_encryption_block_size_val, __default_redundancy_algorithm_val, __default_redundancy_share_size_val, __default_redundancy_required_shares_val, __default_redundancy_repair_shares_val, __default_redundancy_optimal_shares_val, __default_redundancy_total_shares_val, __placement_val, __created_by_val, __lifecycle_configuration_val, __cors_configuration_val)
 
 	__optional_columns := __sqlbundle_Literals{Join: ", "}
 	__optional_placeholders := __sqlbundle_Literals{Join: ", "}
@@ -15416,6 +19134,18 @@ func (obj *pgxImpl) Create_BucketMetainfo(ctx context.Context,
 		__optional_placeholders.SQLs = append(__optional_placeholders.SQLs, __sqlbundle_Literal("?"))
 	}
 
+	if optional.DeletionProtection._set {
+		__values = append(__values, optional.DeletionProtection.value())
+		__optional_columns.SQLs = append(__optional_columns.SQLs, __sqlbundle_Literal("deletion_protection"))
+		__optional_placeholders.SQLs = append(__optional_placeholders.SQLs, __sqlbundle_Literal("?"))
+	}
+
+	if optional.OverrideDefaults._set {
+		__values = append(__values, optional.OverrideDefaults.value())
+		__optional_columns.SQLs = append(__optional_columns.SQLs, __sqlbundle_Literal("override_defaults"))
+		__optional_placeholders.SQLs = append(__optional_placeholders.SQLs, __sqlbundle_Literal("?"))
+	}
+
 	if len(__optional_columns.SQLs) == 0 {
 		if __columns.SQL == nil {
 			__clause.SQL = __sqlbundle_Literal("DEFAULT VALUES")
@@ -15428,7 +19158,7 @@ func (obj *pgxImpl) Create_BucketMetainfo(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	bucket_metainfo = &BucketMetainfo{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -17406,6 +21136,54 @@ func (obj *pgxImpl) Get_Node_By_Id(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) All_Node_By_Wallet(ctx context.Context,
+	node_wallet Node_Wallet_Field) (
+	rows []*Node, err error) {
+	defer mon.Task()(&ctx)(&err)
+	if !obj.txn && txutil.IsInsideTx(ctx) {
+		panic("using DB when inside of a transaction")
+	}
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.commit_hash, nodes.release_timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success, nodes.contained, nodes.last_offline_email, nodes.last_software_update_email, nodes.noise_proto, nodes.noise_public_key, nodes.debounce_limit, nodes.features FROM nodes WHERE nodes.wallet = ?")
+
+	var __values []any
+	__values = append(__values, node_wallet.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*Node, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				node := &Node{}
+				err = __rows.Scan(&node.Id, &node.Address, &node.LastNet, &node.LastIpPort, &node.CountryCode, &node.Protocol, &node.Email, &node.Wallet, &node.WalletFeatures, &node.FreeDisk, &node.PieceCount, &node.Major, &node.Minor, &node.Patch, &node.CommitHash, &node.ReleaseTimestamp, &node.Release, &node.Latency90, &node.VettedAt, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Disqualified, &node.DisqualificationReason, &node.UnknownAuditSuspended, &node.OfflineSuspended, &node.UnderReview, &node.ExitInitiatedAt, &node.ExitLoopCompletedAt, &node.ExitFinishedAt, &node.ExitSuccess, &node.Contained, &node.LastOfflineEmail, &node.LastSoftwareUpdateEmail, &node.NoiseProto, &node.NoisePublicKey, &node.DebounceLimit, &node.Features)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, node)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
+
+}
+
 func (obj *pgxImpl) All_Node_Id(ctx context.Context) (
 	rows []*Id_Row, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -18969,7 +22747,7 @@ func (obj *pgxImpl) Get_BucketMetainfo_By_ProjectId_And_Name(ctx context.Context
 		panic("using DB when inside of a transaction")
 	}
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ?")
 
 	var __values []any
 	__values = append(__values, bucket_metainfo_project_id.value(), bucket_metainfo_name.value())
@@ -18978,7 +22756,7 @@ func (obj *pgxImpl) Get_BucketMetainfo_By_ProjectId_And_Name(ctx context.Context
 	obj.logStmt(__stmt, __values...)
 
 	bucket_metainfo = &BucketMetainfo{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 	if err != nil {
 		return (*BucketMetainfo)(nil), obj.makeErr(err)
 	}
@@ -19151,7 +22929,7 @@ func (obj *pgxImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_GreaterOrEqual_
 		panic("using DB when inside of a transaction")
 	}
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name >= ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name >= ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
 
 	var __values []any
 	__values = append(__values, bucket_metainfo_project_id.value(), bucket_metainfo_name_greater_or_equal.value())
@@ -19171,7 +22949,7 @@ func (obj *pgxImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_GreaterOrEqual_
 
 			for __rows.Next() {
 				bucket_metainfo := &BucketMetainfo{}
-				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 				if err != nil {
 					return nil, err
 				}
@@ -19204,7 +22982,7 @@ func (obj *pgxImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_Greater_OrderBy
 		panic("using DB when inside of a transaction")
 	}
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name > ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name > ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
 
 	var __values []any
 	__values = append(__values, bucket_metainfo_project_id.value(), bucket_metainfo_name_greater.value())
@@ -19224,7 +23002,7 @@ func (obj *pgxImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_Greater_OrderBy
 
 			for __rows.Next() {
 				bucket_metainfo := &BucketMetainfo{}
-				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 				if err != nil {
 					return nil, err
 				}
@@ -21871,7 +25649,7 @@ func (obj *pgxImpl) Update_BucketMetainfo_By_ProjectId_And_Name(ctx context.Cont
 
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []any
@@ -21942,6 +25720,26 @@ func (obj *pgxImpl) Update_BucketMetainfo_By_ProjectId_And_Name(ctx context.Cont
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("placement = ?"))
 	}
 
+	if update.LifecycleConfiguration._set {
+		__values = append(__values, update.LifecycleConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("lifecycle_configuration = ?"))
+	}
+
+	if update.DeletionProtection._set {
+		__values = append(__values, update.DeletionProtection.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("deletion_protection = ?"))
+	}
+
+	if update.OverrideDefaults._set {
+		__values = append(__values, update.OverrideDefaults.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("override_defaults = ?"))
+	}
+
+	if update.CorsConfiguration._set {
+		__values = append(__values, update.CorsConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("cors_configuration = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -21955,7 +25753,7 @@ func (obj *pgxImpl) Update_BucketMetainfo_By_ProjectId_And_Name(ctx context.Cont
 	obj.logStmt(__stmt, __values...)
 
 	bucket_metainfo = &BucketMetainfo{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -21978,7 +25776,7 @@ func (obj *pgxImpl) Update_BucketMetainfo_By_ProjectId_And_Name_And_Versioning_G
 
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? AND bucket_metainfos.versioning >= ? RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? AND bucket_metainfos.versioning >= ? RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []any
@@ -22049,6 +25847,26 @@ func (obj *pgxImpl) Update_BucketMetainfo_By_ProjectId_And_Name_And_Versioning_G
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("placement = ?"))
 	}
 
+	if update.LifecycleConfiguration._set {
+		__values = append(__values, update.LifecycleConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("lifecycle_configuration = ?"))
+	}
+
+	if update.DeletionProtection._set {
+		__values = append(__values, update.DeletionProtection.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("deletion_protection = ?"))
+	}
+
+	if update.OverrideDefaults._set {
+		__values = append(__values, update.OverrideDefaults.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("override_defaults = ?"))
+	}
+
+	if update.CorsConfiguration._set {
+		__values = append(__values, update.CorsConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("cors_configuration = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -22062,7 +25880,7 @@ func (obj *pgxImpl) Update_BucketMetainfo_By_ProjectId_And_Name_And_Versioning_G
 	obj.logStmt(__stmt, __values...)
 
 	bucket_metainfo = &BucketMetainfo{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -22085,7 +25903,7 @@ func (obj *pgxImpl) Update_BucketMetainfo_By_ProjectId_And_Name_And_Versioning_G
 
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? AND bucket_metainfos.versioning >= ? AND bucket_metainfos.object_lock_enabled = false RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? AND bucket_metainfos.versioning >= ? AND bucket_metainfos.object_lock_enabled = false RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []any
@@ -22156,6 +25974,26 @@ func (obj *pgxImpl) Update_BucketMetainfo_By_ProjectId_And_Name_And_Versioning_G
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("placement = ?"))
 	}
 
+	if update.LifecycleConfiguration._set {
+		__values = append(__values, update.LifecycleConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("lifecycle_configuration = ?"))
+	}
+
+	if update.DeletionProtection._set {
+		__values = append(__values, update.DeletionProtection.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("deletion_protection = ?"))
+	}
+
+	if update.OverrideDefaults._set {
+		__values = append(__values, update.OverrideDefaults.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("override_defaults = ?"))
+	}
+
+	if update.CorsConfiguration._set {
+		__values = append(__values, update.CorsConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("cors_configuration = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -22169,7 +26007,7 @@ func (obj *pgxImpl) Update_BucketMetainfo_By_ProjectId_And_Name_And_Versioning_G
 	obj.logStmt(__stmt, __values...)
 
 	bucket_metainfo = &BucketMetainfo{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -23368,6 +27206,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM zombie_deletion_settings;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -23378,6 +27226,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM warehouse_export_markers;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -23528,6 +27386,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM sso_identities;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -23598,6 +27466,26 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM project_usage_webhooks;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM project_budget_caps;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -23618,6 +27506,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM placement_sla_dailies;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -23658,6 +27556,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM node_uptime_transitions;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -23668,6 +27576,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM node_selection_fairness_entries;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -23678,6 +27596,26 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM node_drains;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM node_attestation_issuers;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -23698,6 +27636,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM impersonation_audits;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -23718,6 +27666,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM domains;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -23738,6 +27696,46 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM bucket_quotas;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM bucket_partner_rollups;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM bucket_event_destinations;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM bucket_delete_events;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -23778,6 +27776,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM archived_nodes;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -23808,6 +27816,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM abuse_reports;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -25062,15 +29080,17 @@ func (obj *pgxcockroachImpl) Create_BucketMetainfo(ctx context.Context,
 	__default_redundancy_total_shares_val := bucket_metainfo_default_redundancy_total_shares.value()
 	__placement_val := optional.Placement.value()
 	__created_by_val := optional.CreatedBy.value()
+	__lifecycle_configuration_val := optional.LifecycleConfiguration.value()
+	__cors_configuration_val := optional.CorsConfiguration.value()
 
-	var __columns = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("id, project_id, name, user_agent, path_cipher, created_at, default_segment_size, default_encryption_cipher_suite, default_encryption_block_size, default_redundancy_algorithm, default_redundancy_share_size, default_redundancy_required_shares, default_redundancy_repair_shares, default_redundancy_optimal_shares, default_redundancy_total_shares, placement, created_by")}
-	var __placeholders = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?")}
+	var __columns = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("id, project_id, name, user_agent, path_cipher, created_at, default_segment_size, default_encryption_cipher_suite, default_encryption_block_size, default_redundancy_algorithm, default_redundancy_share_size, default_redundancy_required_shares, default_redundancy_repair_shares, default_redundancy_optimal_shares, default_redundancy_total_shares, placement, created_by, lifecycle_configuration, cors_configuration")}
+	var __placeholders = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?")}
 	var __clause = &__sqlbundle_Hole{SQL: __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("("), __columns, __sqlbundle_Literal(") VALUES ("), __placeholders, __sqlbundle_Literal(")")}}}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("INSERT INTO bucket_metainfos "), __clause, __sqlbundle_Literal(" RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("INSERT INTO bucket_metainfos "), __clause, __sqlbundle_Literal(" RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration")}}
 
 	var __values []any
-	__values = append(__values, __id_val, __project_id_val, __name_val, __user_agent_val, __path_cipher_val, __created_at_val, __default_segment_size_val, __default_encryption_cipher_suite_val, __default_encryption_block_size_val, __default_redundancy_algorithm_val, __default_redundancy_share_size_val, __default_redundancy_required_shares_val, __default_redundancy_repair_shares_val, __default_redundancy_optimal_shares_val, __default_redundancy_total_shares_val, __placement_val, __created_by_val)
+	__values = append(__values, __id_val, __project_id_val, __name_val, __user_agent_val, __path_cipher_val, __created_at_val, __default_segment_size_val, __default_encryption_cipher_suite_val, __default_encryption_block_size_val, __default_redundancy_algorithm_val, __default_redundancy_share_size_val, __default_redundancy_required_shares_val, __default_redundancy_repair_shares_val, __default_redundancy_optimal_shares_val, __default_redundancy_total_shares_val, __placement_val, __created_by_val, __lifecycle_configuration_val, __cors_configuration_val)
 
 	__optional_columns := __sqlbundle_Literals{Join: ", "}
 	__optional_placeholders := __sqlbundle_Literals{Join: ", "}
@@ -25087,6 +29107,18 @@ func (obj *pgxcockroachImpl) Create_BucketMetainfo(ctx context.Context,
 		__optional_placeholders.SQLs = append(__optional_placeholders.SQLs, __sqlbundle_Literal("?"))
 	}
 
+	if optional.DeletionProtection._set {
+		__values = append(__values, optional.DeletionProtection.value())
+		__optional_columns.SQLs = append(__optional_columns.SQLs, __sqlbundle_Literal("deletion_protection"))
+		__optional_placeholders.SQLs = append(__optional_placeholders.SQLs, __sqlbundle_Literal("?"))
+	}
+
+	if optional.OverrideDefaults._set {
+		__values = append(__values, optional.OverrideDefaults.value())
+		__optional_columns.SQLs = append(__optional_columns.SQLs, __sqlbundle_Literal("override_defaults"))
+		__optional_placeholders.SQLs = append(__optional_placeholders.SQLs, __sqlbundle_Literal("?"))
+	}
+
 	if len(__optional_columns.SQLs) == 0 {
 		if __columns.SQL == nil {
 			__clause.SQL = __sqlbundle_Literal("DEFAULT VALUES")
@@ -25099,7 +29131,7 @@ func (obj *pgxcockroachImpl) Create_BucketMetainfo(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	bucket_metainfo = &BucketMetainfo{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -27077,6 +31109,54 @@ func (obj *pgxcockroachImpl) Get_Node_By_Id(ctx context.Context,
 
 }
 
+func (obj *pgxcockroachImpl) All_Node_By_Wallet(ctx context.Context,
+	node_wallet Node_Wallet_Field) (
+	rows []*Node, err error) {
+	defer mon.Task()(&ctx)(&err)
+	if !obj.txn && txutil.IsInsideTx(ctx) {
+		panic("using DB when inside of a transaction")
+	}
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.commit_hash, nodes.release_timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success, nodes.contained, nodes.last_offline_email, nodes.last_software_update_email, nodes.noise_proto, nodes.noise_public_key, nodes.debounce_limit, nodes.features FROM nodes WHERE nodes.wallet = ?")
+
+	var __values []any
+	__values = append(__values, node_wallet.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*Node, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				node := &Node{}
+				err = __rows.Scan(&node.Id, &node.Address, &node.LastNet, &node.LastIpPort, &node.CountryCode, &node.Protocol, &node.Email, &node.Wallet, &node.WalletFeatures, &node.FreeDisk, &node.PieceCount, &node.Major, &node.Minor, &node.Patch, &node.CommitHash, &node.ReleaseTimestamp, &node.Release, &node.Latency90, &node.VettedAt, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Disqualified, &node.DisqualificationReason, &node.UnknownAuditSuspended, &node.OfflineSuspended, &node.UnderReview, &node.ExitInitiatedAt, &node.ExitLoopCompletedAt, &node.ExitFinishedAt, &node.ExitSuccess, &node.Contained, &node.LastOfflineEmail, &node.LastSoftwareUpdateEmail, &node.NoiseProto, &node.NoisePublicKey, &node.DebounceLimit, &node.Features)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, node)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
+
+}
+
 func (obj *pgxcockroachImpl) All_Node_Id(ctx context.Context) (
 	rows []*Id_Row, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -28640,7 +32720,7 @@ func (obj *pgxcockroachImpl) Get_BucketMetainfo_By_ProjectId_And_Name(ctx contex
 		panic("using DB when inside of a transaction")
 	}
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ?")
 
 	var __values []any
 	__values = append(__values, bucket_metainfo_project_id.value(), bucket_metainfo_name.value())
@@ -28649,7 +32729,7 @@ func (obj *pgxcockroachImpl) Get_BucketMetainfo_By_ProjectId_And_Name(ctx contex
 	obj.logStmt(__stmt, __values...)
 
 	bucket_metainfo = &BucketMetainfo{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 	if err != nil {
 		return (*BucketMetainfo)(nil), obj.makeErr(err)
 	}
@@ -28822,7 +32902,7 @@ func (obj *pgxcockroachImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_Greate
 		panic("using DB when inside of a transaction")
 	}
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name >= ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name >= ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
 
 	var __values []any
 	__values = append(__values, bucket_metainfo_project_id.value(), bucket_metainfo_name_greater_or_equal.value())
@@ -28842,7 +32922,7 @@ func (obj *pgxcockroachImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_Greate
 
 			for __rows.Next() {
 				bucket_metainfo := &BucketMetainfo{}
-				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 				if err != nil {
 					return nil, err
 				}
@@ -28875,7 +32955,7 @@ func (obj *pgxcockroachImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_Greate
 		panic("using DB when inside of a transaction")
 	}
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name > ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name > ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
 
 	var __values []any
 	__values = append(__values, bucket_metainfo_project_id.value(), bucket_metainfo_name_greater.value())
@@ -28895,7 +32975,7 @@ func (obj *pgxcockroachImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_Greate
 
 			for __rows.Next() {
 				bucket_metainfo := &BucketMetainfo{}
-				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 				if err != nil {
 					return nil, err
 				}
@@ -31542,7 +35622,7 @@ func (obj *pgxcockroachImpl) Update_BucketMetainfo_By_ProjectId_And_Name(ctx con
 
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []any
@@ -31613,6 +35693,26 @@ func (obj *pgxcockroachImpl) Update_BucketMetainfo_By_ProjectId_And_Name(ctx con
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("placement = ?"))
 	}
 
+	if update.LifecycleConfiguration._set {
+		__values = append(__values, update.LifecycleConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("lifecycle_configuration = ?"))
+	}
+
+	if update.DeletionProtection._set {
+		__values = append(__values, update.DeletionProtection.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("deletion_protection = ?"))
+	}
+
+	if update.OverrideDefaults._set {
+		__values = append(__values, update.OverrideDefaults.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("override_defaults = ?"))
+	}
+
+	if update.CorsConfiguration._set {
+		__values = append(__values, update.CorsConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("cors_configuration = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -31626,7 +35726,7 @@ func (obj *pgxcockroachImpl) Update_BucketMetainfo_By_ProjectId_And_Name(ctx con
 	obj.logStmt(__stmt, __values...)
 
 	bucket_metainfo = &BucketMetainfo{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -31649,7 +35749,7 @@ func (obj *pgxcockroachImpl) Update_BucketMetainfo_By_ProjectId_And_Name_And_Ver
 
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? AND bucket_metainfos.versioning >= ? RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? AND bucket_metainfos.versioning >= ? RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []any
@@ -31720,6 +35820,26 @@ func (obj *pgxcockroachImpl) Update_BucketMetainfo_By_ProjectId_And_Name_And_Ver
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("placement = ?"))
 	}
 
+	if update.LifecycleConfiguration._set {
+		__values = append(__values, update.LifecycleConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("lifecycle_configuration = ?"))
+	}
+
+	if update.DeletionProtection._set {
+		__values = append(__values, update.DeletionProtection.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("deletion_protection = ?"))
+	}
+
+	if update.OverrideDefaults._set {
+		__values = append(__values, update.OverrideDefaults.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("override_defaults = ?"))
+	}
+
+	if update.CorsConfiguration._set {
+		__values = append(__values, update.CorsConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("cors_configuration = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -31733,7 +35853,7 @@ func (obj *pgxcockroachImpl) Update_BucketMetainfo_By_ProjectId_And_Name_And_Ver
 	obj.logStmt(__stmt, __values...)
 
 	bucket_metainfo = &BucketMetainfo{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -31756,7 +35876,7 @@ func (obj *pgxcockroachImpl) Update_BucketMetainfo_By_ProjectId_And_Name_And_Ver
 
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? AND bucket_metainfos.versioning >= ? AND bucket_metainfos.object_lock_enabled = false RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? AND bucket_metainfos.versioning >= ? AND bucket_metainfos.object_lock_enabled = false RETURNING bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []any
@@ -31827,6 +35947,26 @@ func (obj *pgxcockroachImpl) Update_BucketMetainfo_By_ProjectId_And_Name_And_Ver
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("placement = ?"))
 	}
 
+	if update.LifecycleConfiguration._set {
+		__values = append(__values, update.LifecycleConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("lifecycle_configuration = ?"))
+	}
+
+	if update.DeletionProtection._set {
+		__values = append(__values, update.DeletionProtection.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("deletion_protection = ?"))
+	}
+
+	if update.OverrideDefaults._set {
+		__values = append(__values, update.OverrideDefaults.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("override_defaults = ?"))
+	}
+
+	if update.CorsConfiguration._set {
+		__values = append(__values, update.CorsConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("cors_configuration = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -31840,7 +35980,7 @@ func (obj *pgxcockroachImpl) Update_BucketMetainfo_By_ProjectId_And_Name_And_Ver
 	obj.logStmt(__stmt, __values...)
 
 	bucket_metainfo = &BucketMetainfo{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -33039,6 +37179,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM zombie_deletion_settings;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -33049,6 +37199,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM warehouse_export_markers;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -33199,6 +37359,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM sso_identities;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -33269,6 +37439,26 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM project_usage_webhooks;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM project_budget_caps;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -33289,6 +37479,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM placement_sla_dailies;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -33329,6 +37529,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM node_uptime_transitions;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -33339,6 +37549,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM node_selection_fairness_entries;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -33349,6 +37569,26 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM node_drains;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM node_attestation_issuers;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -33369,6 +37609,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM impersonation_audits;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -33389,6 +37639,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM domains;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -33409,6 +37669,46 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM bucket_quotas;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM bucket_partner_rollups;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM bucket_event_destinations;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM bucket_delete_events;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -33449,6 +37749,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM archived_nodes;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -33479,6 +37789,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM abuse_reports;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -35042,15 +39362,17 @@ func (obj *spannerImpl) Create_BucketMetainfo(ctx context.Context,
 	__default_redundancy_total_shares_val := bucket_metainfo_default_redundancy_total_shares.value()
 	__placement_val := optional.Placement.value()
 	__created_by_val := optional.CreatedBy.value()
+	__lifecycle_configuration_val := optional.LifecycleConfiguration.value()
+	__cors_configuration_val := optional.CorsConfiguration.value()
 
-	var __columns = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("id, project_id, name, user_agent, path_cipher, created_at, default_segment_size, default_encryption_cipher_suite, default_encryption_block_size, default_redundancy_algorithm, default_redundancy_share_size, default_redundancy_required_shares, default_redundancy_repair_shares, default_redundancy_optimal_shares, default_redundancy_total_shares, placement, created_by")}
-	var __placeholders = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?")}
+	var __columns = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("id, project_id, name, user_agent, path_cipher, created_at, default_segment_size, default_encryption_cipher_suite, default_encryption_block_size, default_redundancy_algorithm, default_redundancy_share_size, default_redundancy_required_shares, default_redundancy_repair_shares, default_redundancy_optimal_shares, default_redundancy_total_shares, placement, created_by, lifecycle_configuration, cors_configuration")}
+	var __placeholders = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?")}
 	var __clause = &__sqlbundle_Hole{SQL: __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("("), __columns, __sqlbundle_Literal(") VALUES ("), __placeholders, __sqlbundle_Literal(")")}}}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("INSERT INTO bucket_metainfos "), __clause, __sqlbundle_Literal(" THEN RETURN bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("INSERT INTO bucket_metainfos "), __clause, __sqlbundle_Literal(" THEN RETURN bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration")}}
 
 	var __values []any
-	__values = append(__values, __id_val, __project_id_val, __name_val, __user_agent_val, __path_cipher_val, __created_at_val, __default_segment_size_val, __default_encryption_cipher_suite_val, __default_encryption_block_size_val, __default_redundancy_algorithm_val, __default_redundancy_share_size_val, __default_redundancy_required_shares_val, __default_redundancy_repair_shares_val, __default_redundancy_optimal_shares_val, __default_redundancy_total_shares_val, __placement_val, __created_by_val)
+	__values = append(__values, __id_val, __project_id_val, __name_val, __user_agent_val, __path_cipher_val, __created_at_val, __default_segment_size_val, __default_encryption_cipher_suite_val, __default_encryption_block_size_val, __default_redundancy_algorithm_val, __default_redundancy_share_size_val, __default_redundancy_required_shares_val, __default_redundancy_repair_shares_val, __default_redundancy_optimal_shares_val, __default_redundancy_total_shares_val, __placement_val, __created_by_val, __lifecycle_configuration_val, __cors_configuration_val)
 
 	__optional_columns := __sqlbundle_Literals{Join: ", "}
 	__optional_placeholders := __sqlbundle_Literals{Join: ", "}
@@ -35067,6 +39389,18 @@ func (obj *spannerImpl) Create_BucketMetainfo(ctx context.Context,
 		__optional_placeholders.SQLs = append(__optional_placeholders.SQLs, __sqlbundle_Literal("?"))
 	}
 
+	if optional.DeletionProtection._set {
+		__values = append(__values, optional.DeletionProtection.value())
+		__optional_columns.SQLs = append(__optional_columns.SQLs, __sqlbundle_Literal("deletion_protection"))
+		__optional_placeholders.SQLs = append(__optional_placeholders.SQLs, __sqlbundle_Literal("?"))
+	}
+
+	if optional.OverrideDefaults._set {
+		__values = append(__values, optional.OverrideDefaults.value())
+		__optional_columns.SQLs = append(__optional_columns.SQLs, __sqlbundle_Literal("override_defaults"))
+		__optional_placeholders.SQLs = append(__optional_placeholders.SQLs, __sqlbundle_Literal("?"))
+	}
+
 	if len(__optional_columns.SQLs) == 0 && __columns.SQL == nil {
 
 		__optional_columns.SQLs = append(__optional_columns.SQLs, __sqlbundle_Literal("versioning"))
@@ -35100,7 +39434,7 @@ func (obj *spannerImpl) Create_BucketMetainfo(ctx context.Context,
 			}
 		}()
 	}
-	err = d.QueryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+	err = d.QueryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 	if !obj.txn {
 		if err == nil {
 			err = obj.makeErr(tx.Commit())
@@ -37269,6 +41603,54 @@ func (obj *spannerImpl) Get_Node_By_Id(ctx context.Context,
 
 }
 
+func (obj *spannerImpl) All_Node_By_Wallet(ctx context.Context,
+	node_wallet Node_Wallet_Field) (
+	rows []*Node, err error) {
+	defer mon.Task()(&ctx)(&err)
+	if !obj.txn && txutil.IsInsideTx(ctx) {
+		panic("using DB when inside of a transaction")
+	}
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_net, nodes.last_ip_port, nodes.country_code, nodes.protocol, nodes.email, nodes.wallet, nodes.wallet_features, nodes.free_disk, nodes.piece_count, nodes.major, nodes.minor, nodes.patch, nodes.commit_hash, nodes.release_timestamp, nodes.release, nodes.latency_90, nodes.vetted_at, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.disqualified, nodes.disqualification_reason, nodes.unknown_audit_suspended, nodes.offline_suspended, nodes.under_review, nodes.exit_initiated_at, nodes.exit_loop_completed_at, nodes.exit_finished_at, nodes.exit_success, nodes.contained, nodes.last_offline_email, nodes.last_software_update_email, nodes.noise_proto, nodes.noise_public_key, nodes.debounce_limit, nodes.features FROM nodes WHERE nodes.wallet = ?")
+
+	var __values []any
+	__values = append(__values, node_wallet.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*Node, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				node := &Node{}
+				err = __rows.Scan(&node.Id, &node.Address, &node.LastNet, &node.LastIpPort, &node.CountryCode, &node.Protocol, &node.Email, &node.Wallet, &node.WalletFeatures, &node.FreeDisk, &node.PieceCount, &node.Major, &node.Minor, &node.Patch, &node.CommitHash, &node.ReleaseTimestamp, &node.Release, &node.Latency90, &node.VettedAt, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Disqualified, &node.DisqualificationReason, &node.UnknownAuditSuspended, &node.OfflineSuspended, &node.UnderReview, &node.ExitInitiatedAt, &node.ExitLoopCompletedAt, &node.ExitFinishedAt, &node.ExitSuccess, &node.Contained, &node.LastOfflineEmail, &node.LastSoftwareUpdateEmail, &node.NoiseProto, &node.NoisePublicKey, &node.DebounceLimit, &node.Features)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, node)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
+
+}
+
 func (obj *spannerImpl) All_Node_Id(ctx context.Context) (
 	rows []*Id_Row, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -38835,7 +43217,7 @@ func (obj *spannerImpl) Get_BucketMetainfo_By_ProjectId_And_Name(ctx context.Con
 		panic("using DB when inside of a transaction")
 	}
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ?")
 
 	var __values []any
 	__values = append(__values, bucket_metainfo_project_id.value(), bucket_metainfo_name.value())
@@ -38844,7 +43226,7 @@ func (obj *spannerImpl) Get_BucketMetainfo_By_ProjectId_And_Name(ctx context.Con
 	obj.logStmt(__stmt, __values...)
 
 	bucket_metainfo = &BucketMetainfo{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 	if err != nil {
 		return (*BucketMetainfo)(nil), obj.makeErr(err)
 	}
@@ -39017,7 +43399,7 @@ func (obj *spannerImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_GreaterOrEq
 		panic("using DB when inside of a transaction")
 	}
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name >= ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name >= ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
 
 	var __values []any
 	__values = append(__values, bucket_metainfo_project_id.value(), bucket_metainfo_name_greater_or_equal.value())
@@ -39037,7 +43419,7 @@ func (obj *spannerImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_GreaterOrEq
 
 			for __rows.Next() {
 				bucket_metainfo := &BucketMetainfo{}
-				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 				if err != nil {
 					return nil, err
 				}
@@ -39070,7 +43452,7 @@ func (obj *spannerImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_Greater_Ord
 		panic("using DB when inside of a transaction")
 	}
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name > ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration FROM bucket_metainfos WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name > ? ORDER BY bucket_metainfos.name LIMIT ? OFFSET ?")
 
 	var __values []any
 	__values = append(__values, bucket_metainfo_project_id.value(), bucket_metainfo_name_greater.value())
@@ -39090,7 +43472,7 @@ func (obj *spannerImpl) Limited_BucketMetainfo_By_ProjectId_And_Name_Greater_Ord
 
 			for __rows.Next() {
 				bucket_metainfo := &BucketMetainfo{}
-				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+				err = __rows.Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 				if err != nil {
 					return nil, err
 				}
@@ -41745,7 +46127,7 @@ func (obj *spannerImpl) Update_BucketMetainfo_By_ProjectId_And_Name(ctx context.
 
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? THEN RETURN bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? THEN RETURN bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []any
@@ -41804,6 +46186,26 @@ func (obj *spannerImpl) Update_BucketMetainfo_By_ProjectId_And_Name(ctx context.
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("placement = ?"))
 	}
 
+	if update.LifecycleConfiguration._set {
+		__values = append(__values, update.LifecycleConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("lifecycle_configuration = ?"))
+	}
+
+	if update.DeletionProtection._set {
+		__values = append(__values, update.DeletionProtection.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("deletion_protection = ?"))
+	}
+
+	if update.OverrideDefaults._set {
+		__values = append(__values, update.OverrideDefaults.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("override_defaults = ?"))
+	}
+
+	if update.CorsConfiguration._set {
+		__values = append(__values, update.CorsConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("cors_configuration = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -41831,7 +46233,7 @@ func (obj *spannerImpl) Update_BucketMetainfo_By_ProjectId_And_Name(ctx context.
 			}
 		}()
 	}
-	err = d.QueryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+	err = d.QueryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 	if !obj.txn {
 		if err == nil {
 			err = obj.makeErr(tx.Commit())
@@ -41859,7 +46261,141 @@ func (obj *spannerImpl) Update_BucketMetainfo_By_ProjectId_And_Name_And_Versioni
 
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? AND bucket_metainfos.versioning >= ? THEN RETURN bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? AND bucket_metainfos.versioning >= ? THEN RETURN bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []any
+	var __args []any
+
+	if update.UserAgent._set {
+		__values = append(__values, update.UserAgent.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("user_agent = ?"))
+	}
+	if update.Versioning._set {
+		__values = append(__values, update.Versioning.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("versioning = ?"))
+	}
+	if update.ObjectLockEnabled._set {
+		__values = append(__values, update.ObjectLockEnabled.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("object_lock_enabled = ?"))
+	}
+	if update.DefaultSegmentSize._set {
+		__values = append(__values, update.DefaultSegmentSize.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_segment_size = ?"))
+	}
+	if update.DefaultEncryptionCipherSuite._set {
+		__values = append(__values, update.DefaultEncryptionCipherSuite.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_encryption_cipher_suite = ?"))
+	}
+	if update.DefaultEncryptionBlockSize._set {
+		__values = append(__values, update.DefaultEncryptionBlockSize.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_encryption_block_size = ?"))
+	}
+	if update.DefaultRedundancyAlgorithm._set {
+		__values = append(__values, update.DefaultRedundancyAlgorithm.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_redundancy_algorithm = ?"))
+	}
+	if update.DefaultRedundancyShareSize._set {
+		__values = append(__values, update.DefaultRedundancyShareSize.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_redundancy_share_size = ?"))
+	}
+	if update.DefaultRedundancyRequiredShares._set {
+		__values = append(__values, update.DefaultRedundancyRequiredShares.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_redundancy_required_shares = ?"))
+	}
+	if update.DefaultRedundancyRepairShares._set {
+		__values = append(__values, update.DefaultRedundancyRepairShares.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_redundancy_repair_shares = ?"))
+	}
+	if update.DefaultRedundancyOptimalShares._set {
+		__values = append(__values, update.DefaultRedundancyOptimalShares.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_redundancy_optimal_shares = ?"))
+	}
+	if update.DefaultRedundancyTotalShares._set {
+		__values = append(__values, update.DefaultRedundancyTotalShares.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_redundancy_total_shares = ?"))
+	}
+	if update.Placement._set {
+		__values = append(__values, update.Placement.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("placement = ?"))
+	}
+
+	if update.LifecycleConfiguration._set {
+		__values = append(__values, update.LifecycleConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("lifecycle_configuration = ?"))
+	}
+
+	if update.DeletionProtection._set {
+		__values = append(__values, update.DeletionProtection.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("deletion_protection = ?"))
+	}
+
+	if update.OverrideDefaults._set {
+		__values = append(__values, update.OverrideDefaults.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("override_defaults = ?"))
+	}
+
+	if update.CorsConfiguration._set {
+		__values = append(__values, update.CorsConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("cors_configuration = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return nil, emptyUpdate()
+	}
+
+	__args = append(__args, bucket_metainfo_project_id.value(), bucket_metainfo_name.value(), bucket_metainfo_versioning_greater_or_equal.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	bucket_metainfo = &BucketMetainfo{}
+	d := obj.driver
+	var tx tagsql.Tx
+	if !obj.txn {
+		tx, err = obj.db.DB.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		d = tx
+		defer func() {
+			if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+				err = obj.makeErr(errors.Join(err, txErr))
+			}
+		}()
+	}
+	err = d.QueryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
+	if !obj.txn {
+		if err == nil {
+			err = obj.makeErr(tx.Commit())
+		}
+	}
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return bucket_metainfo, nil
+}
+
+func (obj *spannerImpl) Update_BucketMetainfo_By_ProjectId_And_Name_And_Versioning_GreaterOrEqual_And_ObjectLockEnabled_Equal_False(ctx context.Context,
+	bucket_metainfo_project_id BucketMetainfo_ProjectId_Field,
+	bucket_metainfo_name BucketMetainfo_Name_Field,
+	bucket_metainfo_versioning_greater_or_equal BucketMetainfo_Versioning_Field,
+	update BucketMetainfo_Update_Fields) (
+	bucket_metainfo *BucketMetainfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	if !obj.txn && txutil.IsInsideTx(ctx) {
+		panic("using DB when inside of a transaction")
+	}
+
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? AND bucket_metainfos.versioning >= ? AND bucket_metainfos.object_lock_enabled = false THEN RETURN bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by, bucket_metainfos.lifecycle_configuration, bucket_metainfos.deletion_protection, bucket_metainfos.override_defaults, bucket_metainfos.cors_configuration")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []any
@@ -41918,118 +46454,24 @@ func (obj *spannerImpl) Update_BucketMetainfo_By_ProjectId_And_Name_And_Versioni
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("placement = ?"))
 	}
 
-	if len(__sets_sql.SQLs) == 0 {
-		return nil, emptyUpdate()
+	if update.LifecycleConfiguration._set {
+		__values = append(__values, update.LifecycleConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("lifecycle_configuration = ?"))
 	}
 
-	__args = append(__args, bucket_metainfo_project_id.value(), bucket_metainfo_name.value(), bucket_metainfo_versioning_greater_or_equal.value())
-
-	__values = append(__values, __args...)
-	__sets.SQL = __sets_sql
-
-	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __values...)
-
-	bucket_metainfo = &BucketMetainfo{}
-	d := obj.driver
-	var tx tagsql.Tx
-	if !obj.txn {
-		tx, err = obj.db.DB.BeginTx(ctx, nil)
-		if err != nil {
-			return nil, obj.makeErr(err)
-		}
-		d = tx
-		defer func() {
-			if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
-				err = obj.makeErr(errors.Join(err, txErr))
-			}
-		}()
+	if update.DeletionProtection._set {
+		__values = append(__values, update.DeletionProtection.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("deletion_protection = ?"))
 	}
-	err = d.QueryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
-	if !obj.txn {
-		if err == nil {
-			err = obj.makeErr(tx.Commit())
-		}
-	}
-	if err == sql.ErrNoRows {
-		return nil, nil
-	}
-	if err != nil {
-		return nil, obj.makeErr(err)
-	}
-	return bucket_metainfo, nil
-}
 
-func (obj *spannerImpl) Update_BucketMetainfo_By_ProjectId_And_Name_And_Versioning_GreaterOrEqual_And_ObjectLockEnabled_Equal_False(ctx context.Context,
-	bucket_metainfo_project_id BucketMetainfo_ProjectId_Field,
-	bucket_metainfo_name BucketMetainfo_Name_Field,
-	bucket_metainfo_versioning_greater_or_equal BucketMetainfo_Versioning_Field,
-	update BucketMetainfo_Update_Fields) (
-	bucket_metainfo *BucketMetainfo, err error) {
-	defer mon.Task()(&ctx)(&err)
-	if !obj.txn && txutil.IsInsideTx(ctx) {
-		panic("using DB when inside of a transaction")
+	if update.OverrideDefaults._set {
+		__values = append(__values, update.OverrideDefaults.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("override_defaults = ?"))
 	}
 
-	var __sets = &__sqlbundle_Hole{}
-
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE bucket_metainfos SET "), __sets, __sqlbundle_Literal(" WHERE bucket_metainfos.project_id = ? AND bucket_metainfos.name = ? AND bucket_metainfos.versioning >= ? AND bucket_metainfos.object_lock_enabled = false THEN RETURN bucket_metainfos.id, bucket_metainfos.project_id, bucket_metainfos.name, bucket_metainfos.user_agent, bucket_metainfos.versioning, bucket_metainfos.object_lock_enabled, bucket_metainfos.path_cipher, bucket_metainfos.created_at, bucket_metainfos.default_segment_size, bucket_metainfos.default_encryption_cipher_suite, bucket_metainfos.default_encryption_block_size, bucket_metainfos.default_redundancy_algorithm, bucket_metainfos.default_redundancy_share_size, bucket_metainfos.default_redundancy_required_shares, bucket_metainfos.default_redundancy_repair_shares, bucket_metainfos.default_redundancy_optimal_shares, bucket_metainfos.default_redundancy_total_shares, bucket_metainfos.placement, bucket_metainfos.created_by")}}
-
-	__sets_sql := __sqlbundle_Literals{Join: ", "}
-	var __values []any
-	var __args []any
-
-	if update.UserAgent._set {
-		__values = append(__values, update.UserAgent.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("user_agent = ?"))
-	}
-	if update.Versioning._set {
-		__values = append(__values, update.Versioning.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("versioning = ?"))
-	}
-	if update.ObjectLockEnabled._set {
-		__values = append(__values, update.ObjectLockEnabled.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("object_lock_enabled = ?"))
-	}
-	if update.DefaultSegmentSize._set {
-		__values = append(__values, update.DefaultSegmentSize.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_segment_size = ?"))
-	}
-	if update.DefaultEncryptionCipherSuite._set {
-		__values = append(__values, update.DefaultEncryptionCipherSuite.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_encryption_cipher_suite = ?"))
-	}
-	if update.DefaultEncryptionBlockSize._set {
-		__values = append(__values, update.DefaultEncryptionBlockSize.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_encryption_block_size = ?"))
-	}
-	if update.DefaultRedundancyAlgorithm._set {
-		__values = append(__values, update.DefaultRedundancyAlgorithm.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_redundancy_algorithm = ?"))
-	}
-	if update.DefaultRedundancyShareSize._set {
-		__values = append(__values, update.DefaultRedundancyShareSize.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_redundancy_share_size = ?"))
-	}
-	if update.DefaultRedundancyRequiredShares._set {
-		__values = append(__values, update.DefaultRedundancyRequiredShares.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_redundancy_required_shares = ?"))
-	}
-	if update.DefaultRedundancyRepairShares._set {
-		__values = append(__values, update.DefaultRedundancyRepairShares.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_redundancy_repair_shares = ?"))
-	}
-	if update.DefaultRedundancyOptimalShares._set {
-		__values = append(__values, update.DefaultRedundancyOptimalShares.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_redundancy_optimal_shares = ?"))
-	}
-	if update.DefaultRedundancyTotalShares._set {
-		__values = append(__values, update.DefaultRedundancyTotalShares.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("default_redundancy_total_shares = ?"))
-	}
-	if update.Placement._set {
-		__values = append(__values, update.Placement.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("placement = ?"))
+	if update.CorsConfiguration._set {
+		__values = append(__values, update.CorsConfiguration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("cors_configuration = ?"))
 	}
 
 	if len(__sets_sql.SQLs) == 0 {
@@ -42059,7 +46501,7 @@ func (obj *spannerImpl) Update_BucketMetainfo_By_ProjectId_And_Name_And_Versioni
 			}
 		}()
 	}
-	err = d.QueryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy)
+	err = d.QueryRowContext(ctx, __stmt, __values...).Scan(&bucket_metainfo.Id, &bucket_metainfo.ProjectId, &bucket_metainfo.Name, &bucket_metainfo.UserAgent, &bucket_metainfo.Versioning, &bucket_metainfo.ObjectLockEnabled, &bucket_metainfo.PathCipher, &bucket_metainfo.CreatedAt, &bucket_metainfo.DefaultSegmentSize, &bucket_metainfo.DefaultEncryptionCipherSuite, &bucket_metainfo.DefaultEncryptionBlockSize, &bucket_metainfo.DefaultRedundancyAlgorithm, &bucket_metainfo.DefaultRedundancyShareSize, &bucket_metainfo.DefaultRedundancyRequiredShares, &bucket_metainfo.DefaultRedundancyRepairShares, &bucket_metainfo.DefaultRedundancyOptimalShares, &bucket_metainfo.DefaultRedundancyTotalShares, &bucket_metainfo.Placement, &bucket_metainfo.CreatedBy, &bucket_metainfo.LifecycleConfiguration, &bucket_metainfo.DeletionProtection, &bucket_metainfo.OverrideDefaults, &bucket_metainfo.CorsConfiguration)
 	if !obj.txn {
 		if err == nil {
 			err = obj.makeErr(tx.Commit())
@@ -43329,6 +47771,16 @@ func (obj *spannerImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM zombie_deletion_settings;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -43339,6 +47791,16 @@ func (obj *spannerImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM warehouse_export_markers;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -43489,6 +47951,16 @@ func (obj *spannerImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM sso_identities;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -43559,6 +48031,26 @@ func (obj *spannerImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM project_usage_webhooks;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM project_budget_caps;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -43579,6 +48071,16 @@ func (obj *spannerImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM placement_sla_dailies;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -43619,6 +48121,16 @@ func (obj *spannerImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM node_uptime_transitions;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -43629,6 +48141,16 @@ func (obj *spannerImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM node_selection_fairness_entries;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -43639,6 +48161,26 @@ func (obj *spannerImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM node_drains;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM node_attestation_issuers;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -43659,6 +48201,16 @@ func (obj *spannerImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM impersonation_audits;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -43679,6 +48231,16 @@ func (obj *spannerImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM domains;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -43699,6 +48261,46 @@ func (obj *spannerImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM bucket_quotas;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM bucket_partner_rollups;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM bucket_event_destinations;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM bucket_delete_events;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -43739,6 +48341,16 @@ func (obj *spannerImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM archived_nodes;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -43769,6 +48381,16 @@ func (obj *spannerImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM abuse_reports;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -43814,6 +48436,10 @@ type Methods interface {
 		node_tags_node_id NodeTags_NodeId_Field) (
 		rows []*NodeTags, err error)
 
+	All_Node_By_Wallet(ctx context.Context,
+		node_wallet Node_Wallet_Field) (
+		rows []*Node, err error)
+
 	All_Node_Id(ctx context.Context) (
 		rows []*Id_Row, err error)
 
@@ -1,5 +1,16 @@
 -- AUTOGENERATED BY storj.io/dbx
 -- DO NOT EDIT
+CREATE TABLE abuse_reports (
+	id BYTES(MAX) NOT NULL,
+	url STRING(MAX) NOT NULL,
+	access_key_id STRING(MAX) NOT NULL,
+	reason STRING(MAX) NOT NULL,
+	reporter_email STRING(MAX) NOT NULL,
+	status INT64 NOT NULL DEFAULT (0),
+	created_at TIMESTAMP NOT NULL DEFAULT (current_timestamp),
+	reviewed_at TIMESTAMP,
+	reviewed_by STRING(MAX)
+) PRIMARY KEY ( id ) ;
 CREATE TABLE account_freeze_events (
 	user_id BYTES(MAX) NOT NULL,
 	event INT64 NOT NULL,
@@ -23,6 +34,23 @@ CREATE TABLE accounting_timestamps (
 	name STRING(MAX) NOT NULL,
 	value TIMESTAMP NOT NULL
 ) PRIMARY KEY ( name ) ;
+CREATE TABLE archived_nodes (
+	id BYTES(MAX) NOT NULL,
+	address STRING(MAX) NOT NULL,
+	last_net STRING(MAX) NOT NULL,
+	last_ip_port STRING(MAX),
+	country_code STRING(MAX),
+	email STRING(MAX) NOT NULL,
+	wallet STRING(MAX) NOT NULL,
+	wallet_features STRING(MAX) NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	last_contact_success TIMESTAMP NOT NULL,
+	disqualified TIMESTAMP,
+	disqualification_reason INT64,
+	exit_finished_at TIMESTAMP,
+	exit_success BOOL NOT NULL,
+	archived_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( id ) ;
 CREATE TABLE billing_balances (
 	user_id BYTES(MAX) NOT NULL,
 	balance INT64 NOT NULL,
@@ -62,6 +90,41 @@ CREATE TABLE bucket_bandwidth_rollup_archives (
 	allocated INT64 NOT NULL,
 	settled INT64 NOT NULL
 ) PRIMARY KEY ( bucket_name, project_id, interval_start, action ) ;
+CREATE TABLE bucket_delete_events (
+	id BYTES(MAX) NOT NULL,
+	project_id BYTES(MAX) NOT NULL,
+	bucket_name BYTES(MAX) NOT NULL,
+	object_key BYTES(MAX) NOT NULL,
+	version INT64 NOT NULL,
+	stream_id BYTES(MAX) NOT NULL,
+	event_name STRING(MAX) NOT NULL,
+	size INT64 NOT NULL,
+	topic STRING(MAX) NOT NULL,
+	deleted_at TIMESTAMP NOT NULL,
+	attempts INT64 NOT NULL DEFAULT (0),
+	next_attempt_at TIMESTAMP NOT NULL
+) PRIMARY KEY ( id ) ;
+CREATE TABLE bucket_event_destinations (
+	project_id BYTES(MAX) NOT NULL,
+	bucket_name BYTES(MAX) NOT NULL,
+	topic STRING(MAX) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( project_id, bucket_name ) ;
+CREATE TABLE bucket_partner_rollups (
+	project_id BYTES(MAX) NOT NULL,
+	bucket_name BYTES(MAX) NOT NULL,
+	partner STRING(MAX) NOT NULL,
+	interval_start TIMESTAMP NOT NULL,
+	objects INT64 NOT NULL,
+	bytes INT64 NOT NULL
+) PRIMARY KEY ( project_id, bucket_name, partner, interval_start ) ;
+CREATE TABLE bucket_quotas (
+	project_id BYTES(MAX) NOT NULL,
+	bucket_name BYTES(MAX) NOT NULL,
+	max_objects INT64 NOT NULL,
+	max_segments INT64 NOT NULL,
+	updated_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( project_id, bucket_name ) ;
 CREATE TABLE bucket_storage_tallies (
 	bucket_name BYTES(MAX) NOT NULL,
 	project_id BYTES(MAX) NOT NULL,
@@ -86,6 +149,18 @@ CREATE TABLE coinpayments_transactions (
 	timeout INT64 NOT NULL,
 	created_at TIMESTAMP NOT NULL
 ) PRIMARY KEY ( id ) ;
+CREATE TABLE domains (
+	subdomain STRING(MAX) NOT NULL,
+	project_id BYTES(MAX) NOT NULL,
+	created_by BYTES(MAX) NOT NULL,
+	prefix STRING(MAX) NOT NULL,
+	access_id STRING(MAX) NOT NULL,
+	verification_token STRING(MAX) NOT NULL,
+	status INT64 NOT NULL DEFAULT (0),
+	status_message STRING(MAX),
+	created_at TIMESTAMP NOT NULL DEFAULT (current_timestamp),
+	updated_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( subdomain ) ;
 CREATE TABLE graceful_exit_progress (
 	node_id BYTES(MAX) NOT NULL,
 	bytes_transferred INT64 NOT NULL,
@@ -108,6 +183,16 @@ CREATE TABLE graceful_exit_segment_transfer_queue (
 	finished_at TIMESTAMP,
 	order_limit_send_count INT64 NOT NULL DEFAULT (0)
 ) PRIMARY KEY ( node_id, stream_id, position, piece_num ) ;
+CREATE TABLE impersonation_audits (
+	session_id BYTES(MAX) NOT NULL,
+	user_id BYTES(MAX) NOT NULL,
+	admin_email STRING(MAX) NOT NULL,
+	reason STRING(MAX) NOT NULL,
+	consent BOOL NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	ended_at TIMESTAMP
+) PRIMARY KEY ( session_id ) ;
 CREATE TABLE nodes (
 	id BYTES(MAX) NOT NULL,
 	address STRING(MAX) NOT NULL DEFAULT (""),
@@ -155,6 +240,17 @@ CREATE TABLE node_api_versions (
 	created_at TIMESTAMP NOT NULL,
 	updated_at TIMESTAMP NOT NULL
 ) PRIMARY KEY ( id ) ;
+CREATE TABLE node_attestation_issuers (
+	id BYTES(MAX) NOT NULL,
+	name STRING(MAX) NOT NULL,
+	identity BYTES(MAX) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( id ) ;
+CREATE TABLE node_drains (
+	node_id BYTES(MAX) NOT NULL,
+	reason STRING(MAX),
+	initiated_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( node_id ) ;
 CREATE TABLE node_events (
 	id BYTES(MAX) NOT NULL,
 	email STRING(MAX) NOT NULL,
@@ -165,6 +261,16 @@ CREATE TABLE node_events (
 	last_attempted TIMESTAMP,
 	email_sent TIMESTAMP
 ) PRIMARY KEY ( id ) ;
+CREATE TABLE node_selection_fairness_entries (
+	node_id BYTES(MAX) NOT NULL,
+	free_disk INT64 NOT NULL,
+	uploaded_bytes INT64 NOT NULL,
+	expected_share FLOAT64 NOT NULL,
+	actual_share FLOAT64 NOT NULL,
+	ratio FLOAT64 NOT NULL,
+	status STRING(MAX) NOT NULL,
+	generated_at TIMESTAMP NOT NULL
+) PRIMARY KEY ( node_id ) ;
 CREATE TABLE node_tags (
 	node_id BYTES(MAX) NOT NULL,
 	name STRING(MAX) NOT NULL,
@@ -172,6 +278,11 @@ CREATE TABLE node_tags (
 	signed_at TIMESTAMP NOT NULL,
 	signer BYTES(MAX) NOT NULL
 ) PRIMARY KEY ( node_id, name, signer ) ;
+CREATE TABLE node_uptime_transitions (
+	node_id BYTES(MAX) NOT NULL,
+	changed_at TIMESTAMP NOT NULL,
+	online BOOL NOT NULL
+) PRIMARY KEY ( node_id, changed_at ) ;
 CREATE TABLE oauth_clients (
 	id BYTES(MAX) NOT NULL,
 	encrypted_secret BYTES(MAX) NOT NULL,
@@ -207,6 +318,15 @@ CREATE TABLE peer_identities (
 	chain BYTES(MAX) NOT NULL,
 	updated_at TIMESTAMP NOT NULL
 ) PRIMARY KEY ( node_id ) ;
+CREATE TABLE placement_sla_dailies (
+	day TIMESTAMP NOT NULL,
+	placement INT64 NOT NULL,
+	probes INT64 NOT NULL DEFAULT (0),
+	failed_probes INT64 NOT NULL DEFAULT (0),
+	max_repair_queue_segments INT64 NOT NULL DEFAULT (0),
+	min_segment_health FLOAT64,
+	updated_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( day, placement ) ;
 CREATE TABLE projects (
 	id BYTES(MAX) NOT NULL,
 	public_id BYTES(MAX),
@@ -248,6 +368,26 @@ CREATE TABLE project_bandwidth_daily_rollups (
 	egress_settled INT64 NOT NULL,
 	egress_dead INT64 NOT NULL DEFAULT (0)
 ) PRIMARY KEY ( project_id, interval_day ) ;
+CREATE TABLE project_budget_caps (
+	project_id BYTES(MAX) NOT NULL,
+	cap_cents INT64 NOT NULL,
+	notified_percent INT64 NOT NULL DEFAULT (0),
+	period_start TIMESTAMP NOT NULL,
+	frozen_at TIMESTAMP,
+	frozen_storage_limit INT64,
+	frozen_bandwidth_limit INT64,
+	created_at TIMESTAMP NOT NULL DEFAULT (current_timestamp),
+	updated_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( project_id ) ;
+CREATE TABLE project_usage_webhooks (
+	id BYTES(MAX) NOT NULL,
+	project_id BYTES(MAX) NOT NULL,
+	url STRING(MAX) NOT NULL,
+	secret BYTES(MAX) NOT NULL,
+	created_by BYTES(MAX) NOT NULL,
+	last_sent_day TIMESTAMP,
+	created_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( project_id, id ) ;
 CREATE TABLE registration_tokens (
 	secret BYTES(MAX) NOT NULL,
 	owner_id BYTES(MAX),
@@ -262,7 +402,10 @@ CREATE TABLE repair_queue (
 	updated_at TIMESTAMP NOT NULL DEFAULT (current_timestamp),
 	inserted_at TIMESTAMP NOT NULL DEFAULT (current_timestamp),
 	segment_health FLOAT64 NOT NULL DEFAULT (1),
-	placement INT64
+	placement INT64,
+	reason INT64 NOT NULL DEFAULT (0),
+	leased_by STRING(MAX),
+	lease_expires_at TIMESTAMP
 ) PRIMARY KEY ( stream_id, position ) ;
 CREATE TABLE reputations (
 	id BYTES(MAX) NOT NULL,
@@ -312,6 +455,13 @@ CREATE TABLE segment_pending_audits (
 	expected_share_hash BYTES(MAX) NOT NULL,
 	reverify_count INT64 NOT NULL
 ) PRIMARY KEY ( node_id ) ;
+CREATE TABLE sso_identities (
+	provider STRING(MAX) NOT NULL,
+	subject STRING(MAX) NOT NULL,
+	user_id BYTES(MAX) NOT NULL,
+	email STRING(MAX) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( provider, subject ) ;
 CREATE TABLE storagenode_bandwidth_rollups (
 	storagenode_id BYTES(MAX) NOT NULL,
 	interval_start TIMESTAMP NOT NULL,
@@ -484,6 +634,11 @@ CREATE TABLE verification_audits (
 	expires_at TIMESTAMP,
 	encrypted_size INT64 NOT NULL
 ) PRIMARY KEY ( inserted_at, stream_id, position ) ;
+CREATE TABLE warehouse_export_markers (
+	stream STRING(MAX) NOT NULL,
+	exported_until TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+) PRIMARY KEY ( stream ) ;
 CREATE TABLE webapp_sessions (
 	id BYTES(MAX) NOT NULL,
 	user_id BYTES(MAX) NOT NULL,
@@ -492,6 +647,12 @@ CREATE TABLE webapp_sessions (
 	status INT64 NOT NULL,
 	expires_at TIMESTAMP NOT NULL
 ) PRIMARY KEY ( id ) ;
+CREATE TABLE zombie_deletion_settings (
+	project_id BYTES(MAX) NOT NULL,
+	bucket_name BYTES(MAX) NOT NULL,
+	inactive_for_seconds INT64 NOT NULL,
+	updated_at TIMESTAMP NOT NULL DEFAULT (current_timestamp)
+) PRIMARY KEY ( project_id, bucket_name ) ;
 CREATE TABLE api_keys (
 	id BYTES(MAX) NOT NULL,
 	project_id BYTES(MAX) NOT NULL,
@@ -527,6 +688,10 @@ CREATE TABLE bucket_metainfos (
 	default_redundancy_total_shares INT64 NOT NULL,
 	placement INT64,
 	created_by BYTES(MAX),
+	lifecycle_configuration BYTES(MAX),
+	deletion_protection BOOL NOT NULL DEFAULT (false),
+	override_defaults BOOL NOT NULL DEFAULT (false),
+	cors_configuration BYTES(MAX),
 	CONSTRAINT bucket_metainfos_project_id_fkey FOREIGN KEY (project_id) REFERENCES projects (id),
 	CONSTRAINT bucket_metainfos_created_by_fkey FOREIGN KEY (created_by) REFERENCES users (id)
 ) PRIMARY KEY ( project_id, name ) ;
@@ -558,14 +723,19 @@ CREATE INDEX bucket_bandwidth_rollups_project_id_action_interval_index ON bucket
 CREATE INDEX bucket_bandwidth_rollups_action_interval_project_id_index ON bucket_bandwidth_rollups ( action, interval_start, project_id ) ;
 CREATE INDEX bucket_bandwidth_rollups_archive_project_id_action_interval_index ON bucket_bandwidth_rollup_archives ( project_id, action, interval_start ) ;
 CREATE INDEX bucket_bandwidth_rollups_archive_action_interval_project_id_index ON bucket_bandwidth_rollup_archives ( action, interval_start, project_id ) ;
+CREATE INDEX bucket_delete_events_next_attempt_at_index ON bucket_delete_events ( next_attempt_at ) ;
+CREATE INDEX bucket_partner_rollups_partner_interval_start_index ON bucket_partner_rollups ( partner, interval_start ) ;
 CREATE INDEX bucket_storage_tallies_project_id_interval_start_index ON bucket_storage_tallies ( project_id, interval_start ) ;
 CREATE INDEX bucket_storage_tallies_interval_start_index ON bucket_storage_tallies ( interval_start ) ;
+CREATE INDEX domains_project_id_index ON domains ( project_id ) ;
 CREATE INDEX graceful_exit_segment_transfer_nid_dr_qa_fa_lfa_index ON graceful_exit_segment_transfer_queue ( node_id, durability_ratio, queued_at, finished_at, last_failed_at ) ;
 CREATE INDEX node_last_ip ON nodes ( last_net ) ;
 CREATE INDEX nodes_dis_unk_off_exit_fin_last_success_index ON nodes ( disqualified, unknown_audit_suspended, offline_suspended, exit_finished_at, last_contact_success ) ;
 CREATE INDEX nodes_last_cont_success_free_disk_ma_mi_patch_vetted_partial_index ON nodes ( last_contact_success, free_disk, major, minor, patch, vetted_at ) ;
 CREATE INDEX nodes_dis_unk_aud_exit_init_rel_last_cont_success_stored_index ON nodes ( disqualified, unknown_audit_suspended, exit_initiated_at, release, last_contact_success ) ;
+CREATE INDEX nodes_wallet_index ON nodes ( wallet ) ;
 CREATE INDEX node_events_email_event_created_at_index ON node_events ( email, event, created_at ) ;
+CREATE INDEX node_uptime_transitions_changed_at_index ON node_uptime_transitions ( changed_at ) ;
 CREATE INDEX oauth_clients_user_id_index ON oauth_clients ( user_id ) ;
 CREATE INDEX oauth_codes_user_id_index ON oauth_codes ( user_id ) ;
 CREATE INDEX oauth_codes_client_id_index ON oauth_codes ( client_id ) ;
@@ -577,6 +747,7 @@ CREATE INDEX project_bandwidth_daily_rollup_interval_day_index ON project_bandwi
 CREATE INDEX repair_queue_updated_at_index ON repair_queue ( updated_at ) ;
 CREATE INDEX repair_queue_num_healthy_pieces_attempted_at_index ON repair_queue ( segment_health, attempted_at ) ;
 CREATE INDEX repair_queue_placement_index ON repair_queue ( placement ) ;
+CREATE INDEX repair_queue_lease_expires_at_index ON repair_queue ( lease_expires_at ) ;
 CREATE INDEX reverification_audits_inserted_at_index ON reverification_audits ( inserted_at ) ;
 CREATE INDEX storagenode_bandwidth_rollups_interval_start_index ON storagenode_bandwidth_rollups ( interval_start ) ;
 CREATE INDEX storagenode_bandwidth_rollup_archives_interval_start_index ON storagenode_bandwidth_rollup_archives ( interval_start ) ;
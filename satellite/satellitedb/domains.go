@@ -0,0 +1,179 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/satellitedb/dbx"
+)
+
+// Ensure that domains implements console.Domains.
+var _ console.Domains = (*domains)(nil)
+
+// domains is an implementation of console.Domains.
+type domains struct {
+	db dbx.DriverMethods
+}
+
+// Insert inserts a new domain.
+func (d *domains) Insert(ctx context.Context, domain console.Domain) (_ *console.Domain, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	domain.CreatedAt = time.Now().UTC()
+	domain.UpdatedAt = domain.CreatedAt
+	domain.StatusMessage = ""
+
+	_, err = d.db.ExecContext(ctx, d.db.Rebind(`
+		INSERT INTO domains (
+			subdomain, project_id, created_by, prefix, access_id, verification_token, status, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`), domain.Subdomain, domain.ProjectID.Bytes(), domain.CreatedBy.Bytes(), domain.Prefix, domain.AccessID,
+		domain.VerificationToken, int64(domain.Status), domain.CreatedAt, domain.UpdatedAt)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return &domain, nil
+}
+
+// Get returns the domain with the specified subdomain.
+func (d *domains) Get(ctx context.Context, subdomain string) (_ *console.Domain, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	row := d.db.QueryRowContext(ctx, d.db.Rebind(`
+		SELECT subdomain, project_id, created_by, prefix, access_id, verification_token, status, status_message, created_at, updated_at
+		FROM domains
+		WHERE subdomain = ?
+	`), subdomain)
+
+	domain, err := scanDomain(row)
+	if err != nil {
+		return nil, err
+	}
+	return &domain, nil
+}
+
+// ListByProjectID returns the domains of the project.
+func (d *domains) ListByProjectID(ctx context.Context, projectID uuid.UUID) (_ []console.Domain, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := d.db.QueryContext(ctx, d.db.Rebind(`
+		SELECT subdomain, project_id, created_by, prefix, access_id, verification_token, status, status_message, created_at, updated_at
+		FROM domains
+		WHERE project_id = ?
+		ORDER BY subdomain ASC
+	`), projectID.Bytes())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	return scanDomains(rows)
+}
+
+// ListByStatus returns the least recently updated domains with the specified status.
+func (d *domains) ListByStatus(ctx context.Context, status console.DomainStatus, limit int) (_ []console.Domain, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if limit <= 0 {
+		return nil, Error.New("limit must be positive")
+	}
+
+	rows, err := d.db.QueryContext(ctx, d.db.Rebind(`
+		SELECT subdomain, project_id, created_by, prefix, access_id, verification_token, status, status_message, created_at, updated_at
+		FROM domains
+		WHERE status = ?
+		ORDER BY updated_at ASC, subdomain ASC
+		LIMIT ?
+	`), int64(status), int64(limit))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	return scanDomains(rows)
+}
+
+// UpdateStatus updates the status of the domain.
+func (d *domains) UpdateStatus(ctx context.Context, subdomain string, status console.DomainStatus, message string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var statusMessage *string
+	if message != "" {
+		statusMessage = &message
+	}
+
+	result, err := d.db.ExecContext(ctx, d.db.Rebind(`
+		UPDATE domains
+		SET status = ?, status_message = ?, updated_at = ?
+		WHERE subdomain = ?
+	`), int64(status), statusMessage, time.Now().UTC(), subdomain)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// Delete deletes the domain.
+func (d *domains) Delete(ctx context.Context, subdomain string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = d.db.ExecContext(ctx, d.db.Rebind(`DELETE FROM domains WHERE subdomain = ?`), subdomain)
+	return Error.Wrap(err)
+}
+
+// scanDomains scans all the domain rows.
+func scanDomains(rows interface {
+	Next() bool
+	Err() error
+	Scan(dest ...any) error
+}) (list []console.Domain, err error) {
+	for rows.Next() {
+		domain, err := scanDomain(rows)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		list = append(list, domain)
+	}
+	return list, Error.Wrap(rows.Err())
+}
+
+// scanDomain scans a single domain row.
+func scanDomain(row interface{ Scan(dest ...any) error }) (domain console.Domain, err error) {
+	var projectID, createdBy []byte
+	var status int64
+	var statusMessage sql.NullString
+	err = row.Scan(&domain.Subdomain, &projectID, &createdBy, &domain.Prefix, &domain.AccessID, &domain.VerificationToken,
+		&status, &statusMessage, &domain.CreatedAt, &domain.UpdatedAt)
+	if err != nil {
+		return console.Domain{}, err
+	}
+
+	domain.ProjectID, err = uuid.FromBytes(projectID)
+	if err != nil {
+		return console.Domain{}, Error.Wrap(err)
+	}
+	domain.CreatedBy, err = uuid.FromBytes(createdBy)
+	if err != nil {
+		return console.Domain{}, Error.Wrap(err)
+	}
+	domain.Status = console.DomainStatus(status)
+	domain.StatusMessage = statusMessage.String
+	return domain, nil
+}
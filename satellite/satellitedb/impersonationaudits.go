@@ -0,0 +1,71 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/satellitedb/dbx"
+)
+
+// Ensure that impersonationAudits implements console.ImpersonationAudits.
+var _ console.ImpersonationAudits = (*impersonationAudits)(nil)
+
+// impersonationAudits is an implementation of console.ImpersonationAudits.
+type impersonationAudits struct {
+	db dbx.DriverMethods
+}
+
+// Insert records a new impersonation session.
+func (audits *impersonationAudits) Insert(ctx context.Context, audit console.ImpersonationAudit) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = audits.db.ExecContext(ctx, audits.db.Rebind(`
+		INSERT INTO impersonation_audits (
+			session_id, user_id, admin_email, reason, consent, created_at, expires_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+	`), audit.SessionID.Bytes(), audit.UserID.Bytes(), audit.AdminEmail, audit.Reason, audit.Consent, audit.CreatedAt, audit.ExpiresAt)
+	return Error.Wrap(err)
+}
+
+// Get returns the audit record of the impersonation session.
+func (audits *impersonationAudits) Get(ctx context.Context, sessionID uuid.UUID) (_ *console.ImpersonationAudit, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var sessionIDBytes, userIDBytes []byte
+	var audit console.ImpersonationAudit
+	err = audits.db.QueryRowContext(ctx, audits.db.Rebind(`
+		SELECT session_id, user_id, admin_email, reason, consent, created_at, expires_at, ended_at
+		FROM impersonation_audits
+		WHERE session_id = ?
+	`), sessionID.Bytes()).Scan(&sessionIDBytes, &userIDBytes, &audit.AdminEmail, &audit.Reason,
+		&audit.Consent, &audit.CreatedAt, &audit.ExpiresAt, &audit.EndedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	audit.SessionID, err = uuid.FromBytes(sessionIDBytes)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	audit.UserID, err = uuid.FromBytes(userIDBytes)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return &audit, nil
+}
+
+// End records when the impersonation session was ended.
+func (audits *impersonationAudits) End(ctx context.Context, sessionID uuid.UUID, endedAt time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = audits.db.ExecContext(ctx, audits.db.Rebind(`
+		UPDATE impersonation_audits SET ended_at = ?
+		WHERE session_id = ? AND ended_at IS NULL
+	`), endedAt, sessionID.Bytes())
+	return Error.Wrap(err)
+}
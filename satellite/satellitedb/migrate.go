@@ -2846,6 +2846,363 @@ func (db *satelliteDB) ProductionMigration() *migrate.Migration {
 					`ALTER INDEX billing_transactions_timestamp_index RENAME TO billing_transactions_tx_timestamp_index;`,
 				},
 			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add reason column to repair_queue",
+				Version:     281,
+				Action: migrate.SQL{
+					`ALTER TABLE repair_queue ADD COLUMN reason integer NOT NULL DEFAULT 0;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add abuse_reports table",
+				Version:     282,
+				Action: migrate.SQL{
+					`CREATE TABLE abuse_reports (
+						id bytea NOT NULL,
+						url text NOT NULL,
+						access_key_id text NOT NULL,
+						reason text NOT NULL,
+						reporter_email text NOT NULL,
+						status integer NOT NULL DEFAULT 0,
+						created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+						reviewed_at timestamp with time zone,
+						reviewed_by text,
+						PRIMARY KEY ( id )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add project_budget_caps table",
+				Version:     283,
+				Action: migrate.SQL{
+					`CREATE TABLE project_budget_caps (
+						project_id bytea NOT NULL,
+						cap_cents bigint NOT NULL,
+						notified_percent integer NOT NULL DEFAULT 0,
+						period_start timestamp with time zone NOT NULL,
+						frozen_at timestamp with time zone,
+						frozen_storage_limit bigint,
+						frozen_bandwidth_limit bigint,
+						created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+						updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+						PRIMARY KEY ( project_id )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add placement_sla_dailies table",
+				Version:     284,
+				Action: migrate.SQL{
+					`CREATE TABLE placement_sla_dailies (
+						day timestamp with time zone NOT NULL,
+						placement integer NOT NULL,
+						probes bigint NOT NULL DEFAULT 0,
+						failed_probes bigint NOT NULL DEFAULT 0,
+						max_repair_queue_segments bigint NOT NULL DEFAULT 0,
+						min_segment_health double precision,
+						updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+						PRIMARY KEY ( day, placement )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add node_selection_fairness_entries table",
+				Version:     285,
+				Action: migrate.SQL{
+					`CREATE TABLE node_selection_fairness_entries (
+						node_id bytea NOT NULL,
+						free_disk bigint NOT NULL,
+						uploaded_bytes bigint NOT NULL,
+						expected_share double precision NOT NULL,
+						actual_share double precision NOT NULL,
+						ratio double precision NOT NULL,
+						status text NOT NULL,
+						generated_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( node_id )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add lifecycle_configuration column to bucket_metainfos",
+				Version:     286,
+				Action: migrate.SQL{
+					`ALTER TABLE bucket_metainfos ADD COLUMN lifecycle_configuration bytea;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add archived_nodes table",
+				Version:     287,
+				Action: migrate.SQL{
+					`CREATE TABLE archived_nodes (
+						id bytea NOT NULL,
+						address text NOT NULL,
+						last_net text NOT NULL,
+						last_ip_port text,
+						country_code text,
+						email text NOT NULL,
+						wallet text NOT NULL,
+						wallet_features text NOT NULL,
+						created_at timestamp with time zone NOT NULL,
+						last_contact_success timestamp with time zone NOT NULL,
+						disqualified timestamp with time zone,
+						disqualification_reason integer,
+						exit_finished_at timestamp with time zone,
+						exit_success boolean NOT NULL,
+						archived_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+						PRIMARY KEY ( id )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add domains table",
+				Version:     288,
+				Action: migrate.SQL{
+					`CREATE TABLE domains (
+						subdomain text NOT NULL,
+						project_id bytea NOT NULL,
+						created_by bytea NOT NULL,
+						prefix text NOT NULL,
+						access_id text NOT NULL,
+						verification_token text NOT NULL,
+						status integer NOT NULL DEFAULT 0,
+						status_message text,
+						created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+						updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+						PRIMARY KEY ( subdomain )
+					);`,
+					`CREATE INDEX domains_project_id_index ON domains ( project_id );`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add zombie_deletion_settings table",
+				Version:     289,
+				Action: migrate.SQL{
+					`CREATE TABLE zombie_deletion_settings (
+						project_id bytea NOT NULL,
+						bucket_name bytea NOT NULL,
+						inactive_for_seconds bigint NOT NULL,
+						updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+						PRIMARY KEY ( project_id, bucket_name )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add deletion_protection column to bucket_metainfos",
+				Version:     290,
+				Action: migrate.SQL{
+					`ALTER TABLE bucket_metainfos ADD COLUMN deletion_protection boolean NOT NULL DEFAULT false;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add override_defaults column to bucket_metainfos",
+				Version:     291,
+				Action: migrate.SQL{
+					`ALTER TABLE bucket_metainfos ADD COLUMN override_defaults boolean NOT NULL DEFAULT false;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add project_usage_webhooks table",
+				Version:     292,
+				Action: migrate.SQL{
+					`CREATE TABLE project_usage_webhooks (
+						id bytea NOT NULL,
+						project_id bytea NOT NULL,
+						url text NOT NULL,
+						secret bytea NOT NULL,
+						created_by bytea NOT NULL,
+						last_sent_day timestamp with time zone,
+						created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+						PRIMARY KEY ( project_id, id )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add node_attestation_issuers table",
+				Version:     293,
+				Action: migrate.SQL{
+					`CREATE TABLE node_attestation_issuers (
+						id bytea NOT NULL,
+						name text NOT NULL,
+						identity bytea NOT NULL,
+						created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+						PRIMARY KEY ( id )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add lease columns to repair_queue",
+				Version:     294,
+				Action: migrate.SQL{
+					`ALTER TABLE repair_queue ADD COLUMN leased_by text;`,
+					`ALTER TABLE repair_queue ADD COLUMN lease_expires_at timestamp with time zone;`,
+					`CREATE INDEX repair_queue_lease_expires_at_index ON repair_queue ( lease_expires_at ) ;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add bucket_event_destinations and bucket_delete_events tables",
+				Version:     295,
+				Action: migrate.SQL{
+					`CREATE TABLE bucket_event_destinations (
+						project_id bytea NOT NULL,
+						bucket_name bytea NOT NULL,
+						topic text NOT NULL,
+						created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+						PRIMARY KEY ( project_id, bucket_name )
+					);`,
+					`CREATE TABLE bucket_delete_events (
+						id bytea NOT NULL,
+						project_id bytea NOT NULL,
+						bucket_name bytea NOT NULL,
+						object_key bytea NOT NULL,
+						version bigint NOT NULL,
+						stream_id bytea NOT NULL,
+						event_name text NOT NULL,
+						size bigint NOT NULL,
+						topic text NOT NULL,
+						deleted_at timestamp with time zone NOT NULL,
+						attempts integer NOT NULL DEFAULT 0,
+						next_attempt_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( id )
+					);`,
+					`CREATE INDEX bucket_delete_events_next_attempt_at_index ON bucket_delete_events ( next_attempt_at ) ;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add bucket_quotas table",
+				Version:     296,
+				Action: migrate.SQL{
+					`CREATE TABLE bucket_quotas (
+						project_id bytea NOT NULL,
+						bucket_name bytea NOT NULL,
+						max_objects bigint NOT NULL,
+						max_segments bigint NOT NULL,
+						updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+						PRIMARY KEY ( project_id, bucket_name )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add sso_identities table",
+				Version:     297,
+				Action: migrate.SQL{
+					`CREATE TABLE sso_identities (
+						provider text NOT NULL,
+						subject text NOT NULL,
+						user_id bytea NOT NULL,
+						email text NOT NULL,
+						created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+						PRIMARY KEY ( provider, subject )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add warehouse_export_markers table",
+				Version:     298,
+				Action: migrate.SQL{
+					`CREATE TABLE warehouse_export_markers (
+						stream text NOT NULL,
+						exported_until timestamp with time zone NOT NULL,
+						updated_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( stream )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add cors_configuration column to bucket_metainfos",
+				Version:     299,
+				Action: migrate.SQL{
+					`ALTER TABLE bucket_metainfos ADD COLUMN cors_configuration bytea;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add node_drains table",
+				Version:     300,
+				Action: migrate.SQL{
+					`CREATE TABLE node_drains (
+						node_id bytea NOT NULL,
+						reason text,
+						initiated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+						PRIMARY KEY ( node_id )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add bucket_partner_rollups table",
+				Version:     301,
+				Action: migrate.SQL{
+					`CREATE TABLE bucket_partner_rollups (
+						project_id bytea NOT NULL,
+						bucket_name bytea NOT NULL,
+						partner text NOT NULL,
+						interval_start timestamp with time zone NOT NULL,
+						objects bigint NOT NULL,
+						bytes bigint NOT NULL,
+						PRIMARY KEY ( project_id, bucket_name, partner, interval_start )
+					);`,
+					`CREATE INDEX bucket_partner_rollups_partner_interval_start_index ON bucket_partner_rollups ( partner, interval_start );`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add node_uptime_transitions table",
+				Version:     302,
+				Action: migrate.SQL{
+					`CREATE TABLE node_uptime_transitions (
+						node_id bytea NOT NULL,
+						changed_at timestamp with time zone NOT NULL,
+						online boolean NOT NULL,
+						PRIMARY KEY ( node_id, changed_at )
+					);`,
+					`CREATE INDEX node_uptime_transitions_changed_at_index ON node_uptime_transitions ( changed_at );`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add impersonation_audits table",
+				Version:     303,
+				Action: migrate.SQL{
+					`CREATE TABLE impersonation_audits (
+						session_id bytea NOT NULL,
+						user_id bytea NOT NULL,
+						admin_email text NOT NULL,
+						reason text NOT NULL,
+						consent boolean NOT NULL,
+						created_at timestamp with time zone NOT NULL,
+						expires_at timestamp with time zone NOT NULL,
+						ended_at timestamp with time zone,
+						PRIMARY KEY ( session_id )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add index on nodes wallet",
+				Version:     304,
+				Action: migrate.SQL{
+					`CREATE INDEX IF NOT EXISTS nodes_wallet_index ON nodes ( wallet );`,
+				},
+			},
 			// NB: after updating testdata in `testdata`, run
 			//     `go generate` to update `migratez.go`.
 		},
@@ -13,9 +13,21 @@ func (db *satelliteDB) testMigration() *migrate.Migration {
 			{
 				DB:          &db.migrationDB,
 				Description: "Testing setup",
-				Version:     280,
+				Version:     304,
 				Action: migrate.SQL{`-- AUTOGENERATED BY storj.io/dbx
 -- DO NOT EDIT
+CREATE TABLE abuse_reports (
+	id bytea NOT NULL,
+	url text NOT NULL,
+	access_key_id text NOT NULL,
+	reason text NOT NULL,
+	reporter_email text NOT NULL,
+	status integer NOT NULL DEFAULT 0,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	reviewed_at timestamp with time zone,
+	reviewed_by text,
+	PRIMARY KEY ( id )
+) ;
 CREATE TABLE account_freeze_events (
 	user_id bytea NOT NULL,
 	event integer NOT NULL,
@@ -42,6 +54,24 @@ CREATE TABLE accounting_timestamps (
 	value timestamp with time zone NOT NULL,
 	PRIMARY KEY ( name )
 ) ;
+CREATE TABLE archived_nodes (
+	id bytea NOT NULL,
+	address text NOT NULL,
+	last_net text NOT NULL,
+	last_ip_port text,
+	country_code text,
+	email text NOT NULL,
+	wallet text NOT NULL,
+	wallet_features text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	last_contact_success timestamp with time zone NOT NULL,
+	disqualified timestamp with time zone,
+	disqualification_reason integer,
+	exit_finished_at timestamp with time zone,
+	exit_success boolean NOT NULL,
+	archived_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( id )
+) ;
 CREATE TABLE billing_balances (
 	user_id bytea NOT NULL,
 	balance bigint NOT NULL,
@@ -84,6 +114,45 @@ CREATE TABLE bucket_bandwidth_rollup_archives (
 	settled bigint NOT NULL,
 	PRIMARY KEY ( bucket_name, project_id, interval_start, action )
 ) ;
+CREATE TABLE bucket_delete_events (
+	id bytea NOT NULL,
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	object_key bytea NOT NULL,
+	version bigint NOT NULL,
+	stream_id bytea NOT NULL,
+	event_name text NOT NULL,
+	size bigint NOT NULL,
+	topic text NOT NULL,
+	deleted_at timestamp with time zone NOT NULL,
+	attempts integer NOT NULL DEFAULT 0,
+	next_attempt_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+) ;
+CREATE TABLE bucket_event_destinations (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	topic text NOT NULL,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( project_id, bucket_name )
+) ;
+CREATE TABLE bucket_partner_rollups (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	partner text NOT NULL,
+	interval_start timestamp with time zone NOT NULL,
+	objects bigint NOT NULL,
+	bytes bigint NOT NULL,
+	PRIMARY KEY ( project_id, bucket_name, partner, interval_start )
+) ;
+CREATE TABLE bucket_quotas (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	max_objects bigint NOT NULL,
+	max_segments bigint NOT NULL,
+	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( project_id, bucket_name )
+) ;
 CREATE TABLE bucket_storage_tallies (
 	bucket_name bytea NOT NULL,
 	project_id bytea NOT NULL,
@@ -110,6 +179,19 @@ CREATE TABLE coinpayments_transactions (
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 ) ;
+CREATE TABLE domains (
+	subdomain text NOT NULL,
+	project_id bytea NOT NULL,
+	created_by bytea NOT NULL,
+	prefix text NOT NULL,
+	access_id text NOT NULL,
+	verification_token text NOT NULL,
+	status integer NOT NULL DEFAULT 0,
+	status_message text,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( subdomain )
+) ;
 CREATE TABLE graceful_exit_progress (
 	node_id bytea NOT NULL,
 	bytes_transferred bigint NOT NULL,
@@ -134,6 +216,17 @@ CREATE TABLE graceful_exit_segment_transfer_queue (
 	order_limit_send_count integer NOT NULL DEFAULT 0,
 	PRIMARY KEY ( node_id, stream_id, position, piece_num )
 ) ;
+CREATE TABLE impersonation_audits (
+	session_id bytea NOT NULL,
+	user_id bytea NOT NULL,
+	admin_email text NOT NULL,
+	reason text NOT NULL,
+	consent boolean NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	expires_at timestamp with time zone NOT NULL,
+	ended_at timestamp with time zone,
+	PRIMARY KEY ( session_id )
+) ;
 CREATE TABLE nodes (
 	id bytea NOT NULL,
 	address text NOT NULL DEFAULT '',
@@ -183,6 +276,19 @@ CREATE TABLE node_api_versions (
 	updated_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 ) ;
+CREATE TABLE node_attestation_issuers (
+	id bytea NOT NULL,
+	name text NOT NULL,
+	identity bytea NOT NULL,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( id )
+) ;
+CREATE TABLE node_drains (
+	node_id bytea NOT NULL,
+	reason text,
+	initiated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( node_id )
+) ;
 CREATE TABLE node_events (
 	id bytea NOT NULL,
 	email text NOT NULL,
@@ -194,6 +300,17 @@ CREATE TABLE node_events (
 	email_sent timestamp with time zone,
 	PRIMARY KEY ( id )
 ) ;
+CREATE TABLE node_selection_fairness_entries (
+	node_id bytea NOT NULL,
+	free_disk bigint NOT NULL,
+	uploaded_bytes bigint NOT NULL,
+	expected_share double precision NOT NULL,
+	actual_share double precision NOT NULL,
+	ratio double precision NOT NULL,
+	status text NOT NULL,
+	generated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id )
+) ;
 CREATE TABLE node_tags (
 	node_id bytea NOT NULL,
 	name text NOT NULL,
@@ -202,6 +319,12 @@ CREATE TABLE node_tags (
 	signer bytea NOT NULL,
 	PRIMARY KEY ( node_id, name, signer )
 ) ;
+CREATE TABLE node_uptime_transitions (
+	node_id bytea NOT NULL,
+	changed_at timestamp with time zone NOT NULL,
+	online boolean NOT NULL,
+	PRIMARY KEY ( node_id, changed_at )
+) ;
 CREATE TABLE oauth_clients (
 	id bytea NOT NULL,
 	encrypted_secret bytea NOT NULL,
@@ -241,6 +364,16 @@ CREATE TABLE peer_identities (
 	updated_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( node_id )
 ) ;
+CREATE TABLE placement_sla_dailies (
+	day timestamp with time zone NOT NULL,
+	placement integer NOT NULL,
+	probes bigint NOT NULL DEFAULT 0,
+	failed_probes bigint NOT NULL DEFAULT 0,
+	max_repair_queue_segments bigint NOT NULL DEFAULT 0,
+	min_segment_health double precision,
+	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( day, placement )
+) ;
 CREATE TABLE projects (
 	id bytea NOT NULL,
 	public_id bytea,
@@ -284,6 +417,28 @@ CREATE TABLE project_bandwidth_daily_rollups (
 	egress_dead bigint NOT NULL DEFAULT 0,
 	PRIMARY KEY ( project_id, interval_day )
 ) ;
+CREATE TABLE project_budget_caps (
+	project_id bytea NOT NULL,
+	cap_cents bigint NOT NULL,
+	notified_percent integer NOT NULL DEFAULT 0,
+	period_start timestamp with time zone NOT NULL,
+	frozen_at timestamp with time zone,
+	frozen_storage_limit bigint,
+	frozen_bandwidth_limit bigint,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( project_id )
+) ;
+CREATE TABLE project_usage_webhooks (
+	id bytea NOT NULL,
+	project_id bytea NOT NULL,
+	url text NOT NULL,
+	secret bytea NOT NULL,
+	created_by bytea NOT NULL,
+	last_sent_day timestamp with time zone,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( project_id, id )
+) ;
 CREATE TABLE registration_tokens (
 	secret bytea NOT NULL,
 	owner_id bytea,
@@ -300,6 +455,9 @@ CREATE TABLE repair_queue (
 	inserted_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
 	segment_health double precision NOT NULL DEFAULT 1,
 	placement integer,
+	reason integer NOT NULL DEFAULT 0,
+	leased_by text,
+	lease_expires_at timestamp with time zone,
 	PRIMARY KEY ( stream_id, position )
 ) ;
 CREATE TABLE reputations (
@@ -355,6 +513,14 @@ CREATE TABLE segment_pending_audits (
 	reverify_count bigint NOT NULL,
 	PRIMARY KEY ( node_id )
 ) ;
+CREATE TABLE sso_identities (
+	provider text NOT NULL,
+	subject text NOT NULL,
+	user_id bytea NOT NULL,
+	email text NOT NULL,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( provider, subject )
+) ;
 CREATE TABLE storagenode_bandwidth_rollups (
 	storagenode_id bytea NOT NULL,
 	interval_start timestamp with time zone NOT NULL,
@@ -541,6 +707,12 @@ CREATE TABLE verification_audits (
 	encrypted_size integer NOT NULL,
 	PRIMARY KEY ( inserted_at, stream_id, position )
 ) ;
+CREATE TABLE warehouse_export_markers (
+	stream text NOT NULL,
+	exported_until timestamp with time zone NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( stream )
+) ;
 CREATE TABLE webapp_sessions (
 	id bytea NOT NULL,
 	user_id bytea NOT NULL,
@@ -550,6 +722,13 @@ CREATE TABLE webapp_sessions (
 	expires_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 ) ;
+CREATE TABLE zombie_deletion_settings (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	inactive_for_seconds bigint NOT NULL,
+	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( project_id, bucket_name )
+) ;
 CREATE TABLE api_keys (
 	id bytea NOT NULL,
 	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
@@ -584,6 +763,10 @@ CREATE TABLE bucket_metainfos (
 	default_redundancy_total_shares integer NOT NULL,
 	placement integer,
 	created_by bytea REFERENCES users( id ),
+	lifecycle_configuration bytea,
+	deletion_protection boolean NOT NULL DEFAULT false,
+	override_defaults boolean NOT NULL DEFAULT false,
+	cors_configuration bytea,
 	PRIMARY KEY ( project_id, name )
 ) ;
 CREATE TABLE project_invitations (
@@ -612,14 +795,19 @@ CREATE INDEX bucket_bandwidth_rollups_project_id_action_interval_index ON bucket
 CREATE INDEX bucket_bandwidth_rollups_action_interval_project_id_index ON bucket_bandwidth_rollups ( action, interval_start, project_id ) ;
 CREATE INDEX bucket_bandwidth_rollups_archive_project_id_action_interval_index ON bucket_bandwidth_rollup_archives ( project_id, action, interval_start ) ;
 CREATE INDEX bucket_bandwidth_rollups_archive_action_interval_project_id_index ON bucket_bandwidth_rollup_archives ( action, interval_start, project_id ) ;
+CREATE INDEX bucket_delete_events_next_attempt_at_index ON bucket_delete_events ( next_attempt_at ) ;
+CREATE INDEX bucket_partner_rollups_partner_interval_start_index ON bucket_partner_rollups ( partner, interval_start ) ;
 CREATE INDEX bucket_storage_tallies_project_id_interval_start_index ON bucket_storage_tallies ( project_id, interval_start ) ;
 CREATE INDEX bucket_storage_tallies_interval_start_index ON bucket_storage_tallies ( interval_start ) ;
+CREATE INDEX domains_project_id_index ON domains ( project_id ) ;
 CREATE INDEX graceful_exit_segment_transfer_nid_dr_qa_fa_lfa_index ON graceful_exit_segment_transfer_queue ( node_id, durability_ratio, queued_at, finished_at, last_failed_at ) ;
 CREATE INDEX node_last_ip ON nodes ( last_net ) ;
 CREATE INDEX nodes_dis_unk_off_exit_fin_last_success_index ON nodes ( disqualified, unknown_audit_suspended, offline_suspended, exit_finished_at, last_contact_success ) ;
 CREATE INDEX nodes_last_cont_success_free_disk_ma_mi_patch_vetted_partial_index ON nodes ( last_contact_success, free_disk, major, minor, patch, vetted_at ) WHERE nodes.disqualified is NULL AND nodes.unknown_audit_suspended is NULL AND nodes.exit_initiated_at is NULL AND nodes.release = true AND nodes.last_net != '' ;
 CREATE INDEX nodes_dis_unk_aud_exit_init_rel_last_cont_success_stored_index ON nodes ( disqualified, unknown_audit_suspended, exit_initiated_at, release, last_contact_success ) WHERE nodes.disqualified is NULL AND nodes.unknown_audit_suspended is NULL AND nodes.exit_initiated_at is NULL AND nodes.release = true ;
+CREATE INDEX nodes_wallet_index ON nodes ( wallet ) ;
 CREATE INDEX node_events_email_event_created_at_index ON node_events ( email, event, created_at ) WHERE node_events.email_sent is NULL ;
+CREATE INDEX node_uptime_transitions_changed_at_index ON node_uptime_transitions ( changed_at ) ;
 CREATE INDEX oauth_clients_user_id_index ON oauth_clients ( user_id ) ;
 CREATE INDEX oauth_codes_user_id_index ON oauth_codes ( user_id ) ;
 CREATE INDEX oauth_codes_client_id_index ON oauth_codes ( client_id ) ;
@@ -631,6 +819,7 @@ CREATE INDEX project_bandwidth_daily_rollup_interval_day_index ON project_bandwi
 CREATE INDEX repair_queue_updated_at_index ON repair_queue ( updated_at ) ;
 CREATE INDEX repair_queue_num_healthy_pieces_attempted_at_index ON repair_queue ( segment_health, attempted_at ) ;
 CREATE INDEX repair_queue_placement_index ON repair_queue ( placement ) ;
+CREATE INDEX repair_queue_lease_expires_at_index ON repair_queue ( lease_expires_at ) ;
 CREATE INDEX reverification_audits_inserted_at_index ON reverification_audits ( inserted_at ) ;
 CREATE INDEX storagenode_bandwidth_rollups_interval_start_index ON storagenode_bandwidth_rollups ( interval_start ) ;
 CREATE INDEX storagenode_bandwidth_rollup_archives_interval_start_index ON storagenode_bandwidth_rollup_archives ( interval_start ) ;
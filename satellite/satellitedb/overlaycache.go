@@ -6,6 +6,7 @@ package satellitedb
 import (
 	"context"
 	"database/sql"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"sort"
@@ -20,6 +21,7 @@ import (
 	"storj.io/common/storj"
 	"storj.io/common/storj/location"
 	"storj.io/common/version"
+	"storj.io/storj/private/blockchain"
 	"storj.io/storj/satellite/nodeselection"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/satellitedb/dbx"
@@ -69,13 +71,14 @@ func (cache *overlaycache) selectAllStorageNodesUpload(ctx context.Context, sele
 	switch cache.db.impl {
 	case dbutil.Cockroach, dbutil.Postgres:
 		query := `
-			SELECT id, address, email, wallet, last_net, last_ip_port, vetted_at, country_code, noise_proto, noise_public_key, debounce_limit, features, country_code, piece_count
+			SELECT id, address, email, wallet, last_net, last_ip_port, vetted_at, country_code, noise_proto, noise_public_key, debounce_limit, features, country_code, piece_count, free_disk
 			FROM nodes
 			` + cache.db.impl.AsOfSystemInterval(selectionCfg.AsOfSystemTime.Interval()) + `
 			WHERE disqualified IS NULL
 				AND unknown_audit_suspended IS NULL
 				AND offline_suspended IS NULL
 				AND exit_initiated_at IS NULL
+				AND NOT EXISTS (SELECT 1 FROM node_drains WHERE node_drains.node_id = nodes.id)
 				AND free_disk >= $1
 				AND last_contact_success > $2
 		`
@@ -99,13 +102,14 @@ func (cache *overlaycache) selectAllStorageNodesUpload(ctx context.Context, sele
 		rows, err = cache.db.Query(ctx, query, args...)
 	case dbutil.Spanner:
 		query := `
-			SELECT id, address, email, wallet, last_net, last_ip_port, vetted_at, country_code, noise_proto, noise_public_key, debounce_limit, features, country_code, piece_count
+			SELECT id, address, email, wallet, last_net, last_ip_port, vetted_at, country_code, noise_proto, noise_public_key, debounce_limit, features, country_code, piece_count, free_disk
 			FROM nodes
 			` + cache.db.impl.AsOfSystemInterval(selectionCfg.AsOfSystemTime.Interval()) + `
 			WHERE disqualified IS NULL
 				AND unknown_audit_suspended IS NULL
 				AND offline_suspended IS NULL
 				AND exit_initiated_at IS NULL
+				AND NOT EXISTS (SELECT 1 FROM node_drains WHERE node_drains.node_id = nodes.id)
 				AND free_disk >= ?
 				AND last_contact_success > ?
 		`
@@ -146,7 +150,7 @@ func (cache *overlaycache) selectAllStorageNodesUpload(ctx context.Context, sele
 		var vettedAt *time.Time
 		var noise noiseScanner
 		err = rows.Scan(&node.ID, &node.Address.Address, &email, &wallet, &node.LastNet, &lastIPPort, &vettedAt, &node.CountryCode, &noise.Proto,
-			&noise.PublicKey, &node.Address.DebounceLimit, &node.Address.Features, &node.CountryCode, &node.PieceCount)
+			&noise.PublicKey, &node.Address.DebounceLimit, &node.Address.Features, &node.CountryCode, &node.PieceCount, &node.FreeDisk)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -377,6 +381,65 @@ func (cache *overlaycache) Get(ctx context.Context, id storj.NodeID) (dossier *o
 	return convertDBNode(ctx, node)
 }
 
+// GetNodesByWallet returns all the nodes with the wallet, including the disqualified and exited ones.
+// Ethereum addresses also match their lowercase, uppercase and EIP-55 checksummed forms.
+func (cache *overlaycache) GetNodesByWallet(ctx context.Context, wallet string) (dossiers []*overlay.NodeDossier, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	seen := map[string]bool{}
+	for _, form := range walletForms(wallet) {
+		nodes, err := cache.db.All_Node_By_Wallet(ctx, dbx.Node_Wallet(form))
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		for _, node := range nodes {
+			if seen[string(node.Id)] {
+				continue
+			}
+			seen[string(node.Id)] = true
+
+			dossier, err := convertDBNode(ctx, node)
+			if err != nil {
+				return nil, Error.Wrap(err)
+			}
+			dossiers = append(dossiers, dossier)
+		}
+	}
+	return dossiers, nil
+}
+
+// walletForms returns the distinct spellings a node operator may have used
+// for the wallet. Nodes report the wallet as configured, so an Ethereum
+// address can be stored lowercase, uppercase or EIP-55 checksummed. Looking
+// up each form keeps the query on nodes_wallet_index.
+func walletForms(wallet string) []string {
+	forms := []string{wallet}
+	add := func(form string) {
+		for _, existing := range forms {
+			if existing == form {
+				return
+			}
+		}
+		forms = append(forms, form)
+	}
+
+	digits := strings.TrimPrefix(strings.TrimPrefix(wallet, "0x"), "0X")
+	raw, err := hex.DecodeString(digits)
+	if err != nil || len(digits) == len(wallet) {
+		return forms
+	}
+	address, err := blockchain.BytesToAddress(raw)
+	if err != nil {
+		return forms
+	}
+
+	add(strings.ToLower(address.Hex()))
+	add(address.Hex())
+	add("0x" + strings.ToUpper(address.Hex()[2:]))
+	return forms
+}
+
 // GetOnlineNodesForAuditRepair returns a map of nodes for the supplied nodeIDs.
 func (cache *overlaycache) GetOnlineNodesForAuditRepair(ctx context.Context, nodeIDs []storj.NodeID, onlineWindow time.Duration) (nodes map[storj.NodeID]*overlay.NodeReputation, err error) {
 	for {
@@ -643,6 +706,51 @@ func (cache *overlaycache) GetNodes(ctx context.Context, nodeIDs storj.NodeIDLis
 	return records, Error.Wrap(err)
 }
 
+// GetNetworkStats returns aggregate statistics about the nodes that have been online
+// within onlineWindow and are neither disqualified nor exited.
+func (cache *overlaycache) GetNetworkStats(ctx context.Context, onlineWindow, asOfSystemInterval time.Duration) (stats overlay.NetworkStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var query string
+	switch cache.db.impl {
+	case dbutil.Cockroach, dbutil.Postgres:
+		query = `
+			SELECT
+				COUNT(*),
+				COUNT(vetted_at),
+				COALESCE(SUM(free_disk), 0),
+				COUNT(DISTINCT NULLIF(country_code, ''))
+			FROM nodes
+				` + cache.db.impl.AsOfSystemInterval(asOfSystemInterval) + `
+			WHERE disqualified IS NULL
+				AND exit_finished_at IS NULL
+				AND last_contact_success > $1
+		`
+	case dbutil.Spanner:
+		query = `
+			SELECT
+				COUNT(*),
+				COUNT(vetted_at),
+				COALESCE(SUM(free_disk), 0),
+				COUNT(DISTINCT NULLIF(country_code, ''))
+			FROM nodes
+				` + cache.db.impl.AsOfSystemInterval(asOfSystemInterval) + `
+			WHERE disqualified IS NULL
+				AND exit_finished_at IS NULL
+				AND last_contact_success > ?
+		`
+	default:
+		return overlay.NetworkStats{}, Error.New("unsupported implementation")
+	}
+
+	err = cache.db.QueryRowContext(ctx, query, time.Now().Add(-onlineWindow)).Scan(
+		&stats.ActiveNodes, &stats.VettedNodes, &stats.FreeDisk, &stats.Countries)
+	if err != nil {
+		return overlay.NetworkStats{}, Error.Wrap(err)
+	}
+	return stats, nil
+}
+
 // GetParticipatingNodes returns all known participating nodes (this includes all known nodes
 // excluding nodes that have been disqualified or gracefully exited).
 func (cache *overlaycache) GetParticipatingNodes(ctx context.Context, onlineWindow, asOfSystemInterval time.Duration) (records []nodeselection.SelectedNode, err error) {
@@ -1061,6 +1169,38 @@ func (cache *overlaycache) ActiveNodesPieceCounts(ctx context.Context) (_ map[st
 	return pieceCounts, nodeIDErrs.Err()
 }
 
+// VettedNodesFreeDisk returns the free disk space of the online vetted nodes,
+// which are not disqualified, suspended or exiting.
+func (cache *overlaycache) VettedNodesFreeDisk(ctx context.Context, onlineWindow time.Duration) (_ map[storj.NodeID]int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	freeDisk := make(map[storj.NodeID]int64)
+	err = withRows(cache.db.Query(ctx, cache.db.Rebind(`
+		SELECT id, free_disk
+		FROM nodes
+		WHERE disqualified IS NULL
+			AND exit_initiated_at IS NULL
+			AND unknown_audit_suspended IS NULL
+			AND offline_suspended IS NULL
+			AND vetted_at IS NOT NULL
+			AND last_contact_success > ?
+	`), time.Now().Add(-onlineWindow)))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var nodeID storj.NodeID
+			var free int64
+			if err := rows.Scan(&nodeID, &free); err != nil {
+				return err
+			}
+			freeDisk[nodeID] = free
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return freeDisk, nil
+}
+
 func (cache *overlaycache) UpdatePieceCounts(ctx context.Context, pieceCounts map[storj.NodeID]int64) (err error) {
 	defer mon.Task()(&ctx)(&err)
 	if len(pieceCounts) == 0 {
@@ -1911,6 +2051,240 @@ func (cache *overlaycache) UpdateCheckIn(ctx context.Context, node overlay.NodeC
 	return nil
 }
 
+// UpdateCheckIns updates the check-in info of already known nodes in a batch.
+// Unknown nodes are skipped.
+func (cache *overlaycache) UpdateCheckIns(ctx context.Context, updates []overlay.CheckInUpdate) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	// sort the updates to avoid deadlocks between concurrent batches.
+	sort.Slice(updates, func(i, k int) bool {
+		return updates[i].Node.NodeID.Less(updates[k].Node.NodeID)
+	})
+
+	nullTime := func(t time.Time) *time.Time {
+		if t.IsZero() {
+			return nil
+		}
+		return &t
+	}
+
+	var (
+		nodeIDs                 []storj.NodeID
+		addresses               []string
+		lastNets                []string
+		emails                  []string
+		wallets                 []string
+		freeDisks               []int64
+		majors                  []int64
+		minors                  []int64
+		patches                 []int64
+		commitHashes            []string
+		releaseTimestamps       []time.Time
+		releases                []bool
+		lastContactSuccesses    []*time.Time
+		lastContactFailures     []*time.Time
+		lastIPPorts             []string
+		walletFeatures          []string
+		countryCodes            []string
+		softwareUpdateEmailSent []*time.Time
+		versionBelowMin         []bool
+		noiseProtos             []int32
+		noisePublicKeys         [][]byte
+		debounceLimits          []int32
+		features                []int64
+	)
+
+	for _, update := range updates {
+		node := update.Node
+
+		if node.Address.GetAddress() == "" {
+			return Error.New("error UpdateCheckIns: missing the storage node address")
+		}
+
+		semVer, err := version.NewSemVer(node.Version.GetVersion())
+		if err != nil {
+			return Error.New("unable to convert version to semVer")
+		}
+
+		encodedWalletFeatures, err := encodeWalletFeatures(node.Operator.GetWalletFeatures())
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		// -1 is stored as NULL, as there is no noise info.
+		noiseProto := int32(-1)
+		var noisePublicKey []byte
+		if node.Address.NoiseInfo != nil {
+			noiseProto = int32(node.Address.NoiseInfo.Proto)
+			noisePublicKey = node.Address.NoiseInfo.PublicKey
+		}
+
+		nodeIDs = append(nodeIDs, node.NodeID)
+		addresses = append(addresses, node.Address.GetAddress())
+		lastNets = append(lastNets, node.LastNet)
+		emails = append(emails, node.Operator.GetEmail())
+		wallets = append(wallets, node.Operator.GetWallet())
+		freeDisks = append(freeDisks, node.Capacity.GetFreeDisk())
+		majors = append(majors, int64(semVer.Major))
+		minors = append(minors, int64(semVer.Minor))
+		patches = append(patches, int64(semVer.Patch))
+		commitHashes = append(commitHashes, node.Version.GetCommitHash())
+		releaseTimestamps = append(releaseTimestamps, node.Version.GetTimestamp())
+		releases = append(releases, node.Version.GetRelease())
+		lastContactSuccesses = append(lastContactSuccesses, nullTime(update.LastContactSuccess))
+		lastContactFailures = append(lastContactFailures, nullTime(update.LastContactFailure))
+		lastIPPorts = append(lastIPPorts, node.LastIPPort)
+		walletFeatures = append(walletFeatures, encodedWalletFeatures)
+		countryCodes = append(countryCodes, node.CountryCode.String())
+		softwareUpdateEmailSent = append(softwareUpdateEmailSent, nullTime(update.SoftwareUpdateEmailSent))
+		versionBelowMin = append(versionBelowMin, node.VersionBelowMin)
+		noiseProtos = append(noiseProtos, noiseProto)
+		noisePublicKeys = append(noisePublicKeys, noisePublicKey)
+		debounceLimits = append(debounceLimits, node.Address.GetDebounceLimit())
+		features = append(features, int64(node.Address.GetFeatures()))
+	}
+
+	switch cache.db.impl {
+	case dbutil.Postgres, dbutil.Cockroach:
+		_, err = cache.db.ExecContext(ctx, `
+			UPDATE nodes
+			SET
+				address = update.address,
+				last_net = update.last_net,
+				protocol = $24,
+				email = update.email,
+				wallet = update.wallet,
+				free_disk = update.free_disk,
+				major = update.major, minor = update.minor, patch = update.patch,
+				commit_hash = update.commit_hash, release_timestamp = update.release_timestamp, release = update.release,
+				last_contact_success = COALESCE(update.last_contact_success, nodes.last_contact_success),
+				last_contact_failure = COALESCE(update.last_contact_failure, nodes.last_contact_failure),
+				last_ip_port = update.last_ip_port,
+				wallet_features = update.wallet_features,
+				country_code = update.country_code,
+				noise_proto = NULLIF(update.noise_proto, -1),
+				noise_public_key = update.noise_public_key,
+				debounce_limit = update.debounce_limit,
+				features = update.features,
+				last_software_update_email = CASE
+					WHEN update.software_update_email_sent IS NOT NULL THEN update.software_update_email_sent
+					WHEN update.version_below_min IS FALSE THEN NULL
+					ELSE nodes.last_software_update_email
+				END,
+				last_offline_email = CASE WHEN update.last_contact_success IS NOT NULL
+					THEN NULL
+					ELSE nodes.last_offline_email
+				END
+			FROM (
+				SELECT
+					unnest($1::bytea[]) AS id,
+					unnest($2::text[]) AS address,
+					unnest($3::text[]) AS last_net,
+					unnest($4::text[]) AS email,
+					unnest($5::text[]) AS wallet,
+					unnest($6::int8[]) AS free_disk,
+					unnest($7::int8[]) AS major,
+					unnest($8::int8[]) AS minor,
+					unnest($9::int8[]) AS patch,
+					unnest($10::text[]) AS commit_hash,
+					unnest($11::timestamptz[]) AS release_timestamp,
+					unnest($12::bool[]) AS release,
+					unnest($13::timestamptz[]) AS last_contact_success,
+					unnest($14::timestamptz[]) AS last_contact_failure,
+					unnest($15::text[]) AS last_ip_port,
+					unnest($16::text[]) AS wallet_features,
+					unnest($17::text[]) AS country_code,
+					unnest($18::timestamptz[]) AS software_update_email_sent,
+					unnest($19::bool[]) AS version_below_min,
+					unnest($20::int4[]) AS noise_proto,
+					unnest($21::bytea[]) AS noise_public_key,
+					unnest($22::int4[]) AS debounce_limit,
+					unnest($23::int8[]) AS features
+			) AS update
+			WHERE nodes.id = update.id
+		`,
+			pgutil.NodeIDArray(nodeIDs), pgutil.TextArray(addresses), pgutil.TextArray(lastNets),
+			pgutil.TextArray(emails), pgutil.TextArray(wallets), pgutil.Int8Array(freeDisks),
+			pgutil.Int8Array(majors), pgutil.Int8Array(minors), pgutil.Int8Array(patches),
+			pgutil.TextArray(commitHashes), pgutil.TimestampTZArray(releaseTimestamps), pgutil.BoolArray(releases),
+			pgutil.NullTimestampTZArray(lastContactSuccesses), pgutil.NullTimestampTZArray(lastContactFailures),
+			pgutil.TextArray(lastIPPorts), pgutil.TextArray(walletFeatures), pgutil.TextArray(countryCodes),
+			pgutil.NullTimestampTZArray(softwareUpdateEmailSent), pgutil.BoolArray(versionBelowMin),
+			pgutil.Int4Array(noiseProtos), pgutil.NullByteaArray(noisePublicKeys),
+			pgutil.Int4Array(debounceLimits), pgutil.Int8Array(features),
+			pb.NodeTransport_TCP_TLS_RPC,
+		)
+
+	case dbutil.Spanner:
+		// Spanner doesn't support updating from a subquery, so the nodes are
+		// updated one by one.
+		for i := range nodeIDs {
+			var noiseProto sql.NullInt64
+			if noiseProtos[i] >= 0 {
+				noiseProto = sql.NullInt64{Int64: int64(noiseProtos[i]), Valid: true}
+			}
+
+			_, err = cache.db.ExecContext(ctx, `
+				UPDATE nodes
+				SET
+					address=?, last_net=?, protocol=?,
+					email=?, wallet=?, free_disk=?,
+					major=?, minor=?, patch=?,
+					commit_hash=?, release_timestamp=?, release=?,
+					last_contact_success = CASE WHEN CAST(? AS bool) IS TRUE
+						THEN CAST(? AS TIMESTAMP)
+						ELSE nodes.last_contact_success
+					END,
+					last_contact_failure = CASE WHEN CAST(? AS bool) IS TRUE
+						THEN CAST(? AS TIMESTAMP)
+						ELSE nodes.last_contact_failure
+					END,
+					last_ip_port=?, wallet_features=?, country_code=?,
+					noise_proto=?, noise_public_key=?,
+					debounce_limit=?, features=?,
+					last_software_update_email = CASE
+						WHEN CAST(? AS bool) IS TRUE THEN CAST(? AS TIMESTAMP)
+						WHEN CAST(? AS bool) IS FALSE THEN NULL
+						ELSE nodes.last_software_update_email
+					END,
+					last_offline_email = CASE WHEN CAST(? AS bool) IS TRUE
+						THEN NULL
+						ELSE nodes.last_offline_email
+					END
+				WHERE id = ?
+			`,
+				addresses[i], lastNets[i], int(pb.NodeTransport_TCP_TLS_RPC),
+				emails[i], wallets[i], freeDisks[i],
+				majors[i], minors[i], patches[i],
+				commitHashes[i], releaseTimestamps[i], releases[i],
+				lastContactSuccesses[i] != nil, updates[i].LastContactSuccess,
+				lastContactFailures[i] != nil, updates[i].LastContactFailure,
+
+				lastIPPorts[i], walletFeatures[i], countryCodes[i],
+				noiseProto, noisePublicKeys[i],
+				int(debounceLimits[i]), features[i],
+
+				softwareUpdateEmailSent[i] != nil, updates[i].SoftwareUpdateEmailSent, versionBelowMin[i],
+				lastContactSuccesses[i] != nil,
+
+				nodeIDs[i].Bytes(),
+			)
+			if err != nil {
+				break
+			}
+		}
+
+	default:
+		err = errs.New("Error: Implementation not supported")
+	}
+
+	return Error.Wrap(err)
+}
+
 // SetNodeContained updates the contained field for the node record. If
 // `contained` is true, the contained field in the record is set to the current
 // database time, if it is not already set. If `contained` is false, the
@@ -2210,6 +2584,16 @@ func (cache *overlaycache) UpdateNodeTags(ctx context.Context, tags nodeselectio
 	return nil
 }
 
+// DeleteNodeTagsBySigner deletes all tags signed by the signer.
+func (cache *overlaycache) DeleteNodeTagsBySigner(ctx context.Context, signer storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = cache.db.ExecContext(ctx, cache.db.Rebind(`
+		DELETE FROM node_tags WHERE signer = ?
+	`), signer.Bytes())
+	return Error.Wrap(err)
+}
+
 func (cache *overlaycache) GetNodeTags(ctx context.Context, id storj.NodeID) (nodeselection.NodeTags, error) {
 	rows, err := cache.db.All_NodeTags_By_NodeId(ctx, dbx.NodeTags_NodeId(id.Bytes()))
 	if err != nil {
@@ -2237,6 +2621,48 @@ func (cache *overlaycache) GetNodeTags(ctx context.Context, id storj.NodeID) (no
 	return tags, err
 }
 
+// GetNodeTagsByNames returns the node tags with the given names of the given nodes.
+func (cache *overlaycache) GetNodeTagsByNames(ctx context.Context, ids storj.NodeIDList, names []string) (tagsByNode map[storj.NodeID]nodeselection.NodeTags, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var rows tagsql.Rows
+
+	switch cache.db.impl {
+	case dbutil.Cockroach, dbutil.Postgres:
+		rows, err = cache.db.Query(ctx, cache.db.Rebind(`
+			SELECT node_id, name, value, signed_at, signer FROM node_tags
+			WHERE node_id = any($1::bytea[])
+				AND name = any($2::text[])
+		`), pgutil.NodeIDArray(ids), pgutil.TextArray(names))
+	case dbutil.Spanner:
+		rows, err = cache.db.Query(ctx, cache.db.Rebind(`
+			SELECT node_id, name, value, signed_at, signer FROM node_tags
+			WHERE node_id IN (SELECT node_id FROM UNNEST(?) node_id)
+				AND name IN (SELECT name FROM UNNEST(?) name)
+		`), ids.Bytes(), names)
+	default:
+		err = errors.New("error: unsupported implementation")
+	}
+
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() {
+		err = errs.Combine(err, rows.Close())
+	}()
+
+	tagsByNode = make(map[storj.NodeID]nodeselection.NodeTags)
+	for rows.Next() {
+		var tag nodeselection.NodeTag
+		err = rows.Scan(&tag.NodeID, &tag.Name, &tag.Value, &tag.SignedAt, &tag.Signer)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		tagsByNode[tag.NodeID] = append(tagsByNode[tag.NodeID], tag)
+	}
+	return tagsByNode, Error.Wrap(rows.Err())
+}
+
 // GetLastIPPortByNodeTagNames gets last IP and port from nodes where node exists in node tags with a particular name.
 func (cache *overlaycache) GetLastIPPortByNodeTagNames(ctx context.Context, ids storj.NodeIDList, tagNames []string) (lastIPPorts map[storj.NodeID]*string, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -2285,3 +2711,455 @@ func (cache *overlaycache) GetLastIPPortByNodeTagNames(ctx context.Context, ids
 	}
 	return lastIPPorts, Error.Wrap(rows.Err())
 }
+
+// archivedNodeColumns are the columns of the nodes table, which are kept when a node is archived.
+const archivedNodeColumns = `
+	id, address, last_net, last_ip_port, country_code,
+	email, wallet, wallet_features,
+	created_at, last_contact_success,
+	disqualified, disqualification_reason,
+	exit_finished_at, exit_success`
+
+// GetArchivableNodes returns up to limit node IDs greater than the cursor, in ascending order,
+// of nodes which have not contacted the satellite since cutoff. Disqualified and exited nodes are
+// never archived, so their identity can't check in again as a new node.
+func (cache *overlaycache) GetArchivableNodes(ctx context.Context, cursor storj.NodeID, cutoff time.Time, limit int) (nodeIDs storj.NodeIDList, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var rows tagsql.Rows
+	switch cache.db.impl {
+	case dbutil.Cockroach, dbutil.Postgres:
+		rows, err = cache.db.Query(ctx, `
+			SELECT id
+			FROM nodes
+			WHERE id > $1
+				AND last_contact_success < $2
+				AND last_contact_failure < $2
+				AND disqualified IS NULL
+				AND exit_finished_at IS NULL
+			ORDER BY id
+			LIMIT $3
+		`, cursor, cutoff, limit)
+	case dbutil.Spanner:
+		rows, err = cache.db.Query(ctx, `
+			SELECT id
+			FROM nodes
+			WHERE id > ?
+				AND last_contact_success < ?
+				AND last_contact_failure < ?
+				AND disqualified IS NULL
+				AND exit_finished_at IS NULL
+			ORDER BY id
+			LIMIT ?
+		`, cursor.Bytes(), cutoff, cutoff, limit)
+	default:
+		err = errors.New("error: unsupported implementation")
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var id storj.NodeID
+		if err := rows.Scan(&id); err != nil {
+			return nil, Error.Wrap(err)
+		}
+		nodeIDs = append(nodeIDs, id)
+	}
+	return nodeIDs, Error.Wrap(rows.Err())
+}
+
+// ArchiveNodes moves the given nodes, which still have not contacted the satellite since cutoff
+// and are neither disqualified nor exited, from the nodes table to the archived nodes. It returns
+// the number of archived nodes.
+func (cache *overlaycache) ArchiveNodes(ctx context.Context, nodeIDs storj.NodeIDList, cutoff time.Time) (archived int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(nodeIDs) == 0 {
+		return 0, nil
+	}
+
+	switch cache.db.impl {
+	case dbutil.Cockroach, dbutil.Postgres:
+		result, err := cache.db.ExecContext(ctx, `
+			WITH archived AS (
+				DELETE FROM nodes
+				WHERE id = any($1::bytea[])
+					AND last_contact_success < $2
+					AND last_contact_failure < $2
+					AND disqualified IS NULL
+					AND exit_finished_at IS NULL
+				RETURNING `+archivedNodeColumns+`
+			)
+			INSERT INTO archived_nodes (`+archivedNodeColumns+`)
+			SELECT `+archivedNodeColumns+` FROM archived
+			ON CONFLICT (id) DO UPDATE SET
+				address = EXCLUDED.address,
+				last_net = EXCLUDED.last_net,
+				last_ip_port = EXCLUDED.last_ip_port,
+				country_code = EXCLUDED.country_code,
+				email = EXCLUDED.email,
+				wallet = EXCLUDED.wallet,
+				wallet_features = EXCLUDED.wallet_features,
+				created_at = EXCLUDED.created_at,
+				last_contact_success = EXCLUDED.last_contact_success,
+				disqualified = EXCLUDED.disqualified,
+				disqualification_reason = EXCLUDED.disqualification_reason,
+				exit_finished_at = EXCLUDED.exit_finished_at,
+				exit_success = EXCLUDED.exit_success,
+				archived_at = current_timestamp
+		`, pgutil.NodeIDArray(nodeIDs), cutoff)
+		if err != nil {
+			return 0, Error.Wrap(err)
+		}
+		affected, err := result.RowsAffected()
+		return int(affected), Error.Wrap(err)
+	case dbutil.Spanner:
+		err = cache.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) error {
+			// a node may have been archived before, when it came back later as a new node.
+			_, err := tx.Tx.ExecContext(ctx, `
+				DELETE FROM archived_nodes
+				WHERE id IN (
+					SELECT id FROM nodes
+					WHERE id IN (SELECT node_id FROM UNNEST(?) AS node_id)
+						AND last_contact_success < ?
+						AND last_contact_failure < ?
+						AND disqualified IS NULL
+						AND exit_finished_at IS NULL
+				)
+			`, nodeIDs.Bytes(), cutoff, cutoff)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.Tx.ExecContext(ctx, `
+				INSERT INTO archived_nodes (`+archivedNodeColumns+`)
+				SELECT `+archivedNodeColumns+` FROM nodes
+				WHERE id IN (SELECT node_id FROM UNNEST(?) AS node_id)
+					AND last_contact_success < ?
+					AND last_contact_failure < ?
+					AND disqualified IS NULL
+					AND exit_finished_at IS NULL
+			`, nodeIDs.Bytes(), cutoff, cutoff)
+			if err != nil {
+				return err
+			}
+
+			result, err := tx.Tx.ExecContext(ctx, `
+				DELETE FROM nodes
+				WHERE id IN (SELECT node_id FROM UNNEST(?) AS node_id)
+					AND last_contact_success < ?
+					AND last_contact_failure < ?
+					AND disqualified IS NULL
+					AND exit_finished_at IS NULL
+			`, nodeIDs.Bytes(), cutoff, cutoff)
+			if err != nil {
+				return err
+			}
+			affected, err := result.RowsAffected()
+			archived = int(affected)
+			return err
+		})
+		return archived, Error.Wrap(err)
+	default:
+		return 0, Error.New("unsupported implementation")
+	}
+}
+
+// RestoreArchivedNode moves an archived node back to the nodes table.
+func (cache *overlaycache) RestoreArchivedNode(ctx context.Context, nodeID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var exists, insert, remove string
+	switch cache.db.impl {
+	case dbutil.Cockroach, dbutil.Postgres:
+		exists = `SELECT EXISTS (SELECT 1 FROM nodes WHERE id = $1)`
+		insert = `INSERT INTO nodes (` + archivedNodeColumns + `)
+			SELECT ` + archivedNodeColumns + ` FROM archived_nodes WHERE id = $1`
+		remove = `DELETE FROM archived_nodes WHERE id = $1`
+	case dbutil.Spanner:
+		exists = `SELECT EXISTS (SELECT 1 FROM nodes WHERE id = ?)`
+		insert = `INSERT INTO nodes (` + archivedNodeColumns + `)
+			SELECT ` + archivedNodeColumns + ` FROM archived_nodes WHERE id = ?`
+		remove = `DELETE FROM archived_nodes WHERE id = ?`
+	default:
+		return Error.New("unsupported implementation")
+	}
+
+	return cache.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) error {
+		var found bool
+		if err := tx.Tx.QueryRowContext(ctx, exists, nodeID.Bytes()).Scan(&found); err != nil {
+			return Error.Wrap(err)
+		}
+		if found {
+			return overlay.ErrNodeExists.New("%v", nodeID)
+		}
+
+		result, err := tx.Tx.ExecContext(ctx, insert, nodeID.Bytes())
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if affected == 0 {
+			return overlay.ErrNodeNotFound.New("%v is not archived", nodeID)
+		}
+
+		_, err = tx.Tx.ExecContext(ctx, remove, nodeID.Bytes())
+		return Error.Wrap(err)
+	})
+}
+
+// SetNodeDrain marks the node as draining, which excludes it from upload selection.
+// When the node is already draining only the reason is updated.
+func (cache *overlaycache) SetNodeDrain(ctx context.Context, drain overlay.NodeDrain) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var reason *string
+	if drain.Reason != "" {
+		reason = &drain.Reason
+	}
+
+	switch cache.db.impl {
+	case dbutil.Cockroach, dbutil.Postgres:
+		_, err = cache.db.ExecContext(ctx, `
+			INSERT INTO node_drains (node_id, reason, initiated_at) VALUES ($1, $2, $3)
+			ON CONFLICT (node_id) DO UPDATE SET reason = EXCLUDED.reason
+		`, drain.NodeID.Bytes(), reason, drain.InitiatedAt.UTC())
+		return Error.Wrap(err)
+	case dbutil.Spanner:
+		// INSERT OR UPDATE would overwrite initiated_at, and the read-write
+		// transaction is retried when a concurrent insert conflicts with it.
+		return cache.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) error {
+			result, err := tx.Tx.ExecContext(ctx, `
+				UPDATE node_drains SET reason = ? WHERE node_id = ?
+			`, reason, drain.NodeID.Bytes())
+			if err != nil {
+				return Error.Wrap(err)
+			}
+			affected, err := result.RowsAffected()
+			if err != nil {
+				return Error.Wrap(err)
+			}
+			if affected > 0 {
+				return nil
+			}
+
+			_, err = tx.Tx.ExecContext(ctx, `
+				INSERT INTO node_drains (node_id, reason, initiated_at) VALUES (?, ?, ?)
+			`, drain.NodeID.Bytes(), reason, drain.InitiatedAt.UTC())
+			return Error.Wrap(err)
+		})
+	default:
+		return Error.New("unsupported implementation")
+	}
+}
+
+// DeleteNodeDrain stops draining the node. It returns overlay.ErrNodeNotDraining when the node isn't draining.
+func (cache *overlaycache) DeleteNodeDrain(ctx context.Context, nodeID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := cache.db.ExecContext(ctx, cache.db.Rebind(`
+		DELETE FROM node_drains WHERE node_id = ?
+	`), nodeID.Bytes())
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if affected == 0 {
+		return overlay.ErrNodeNotDraining.New("%v", nodeID)
+	}
+	return nil
+}
+
+// GetNodeDrain returns the drain state of the node. It returns overlay.ErrNodeNotDraining when the node isn't draining.
+func (cache *overlaycache) GetNodeDrain(ctx context.Context, nodeID storj.NodeID) (_ overlay.NodeDrain, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	drain := overlay.NodeDrain{NodeID: nodeID}
+	var reason *string
+	err = cache.db.QueryRowContext(ctx, cache.db.Rebind(`
+		SELECT reason, initiated_at FROM node_drains WHERE node_id = ?
+	`), nodeID.Bytes()).Scan(&reason, &drain.InitiatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return overlay.NodeDrain{}, overlay.ErrNodeNotDraining.New("%v", nodeID)
+		}
+		return overlay.NodeDrain{}, Error.Wrap(err)
+	}
+	if reason != nil {
+		drain.Reason = *reason
+	}
+	return drain, nil
+}
+
+// InsertNodeUptimeTransition records that the node went online or offline.
+func (cache *overlaycache) InsertNodeUptimeTransition(ctx context.Context, transition overlay.NodeUptimeTransition) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var query string
+	switch cache.db.impl {
+	case dbutil.Cockroach, dbutil.Postgres:
+		query = `
+			INSERT INTO node_uptime_transitions (node_id, changed_at, online) VALUES ($1, $2, $3)
+			ON CONFLICT (node_id, changed_at) DO UPDATE SET online = EXCLUDED.online
+		`
+	case dbutil.Spanner:
+		query = `
+			INSERT OR UPDATE INTO node_uptime_transitions (node_id, changed_at, online) VALUES (?, ?, ?)
+		`
+	default:
+		return Error.New("unsupported implementation")
+	}
+
+	_, err = cache.db.ExecContext(ctx, query, transition.NodeID.Bytes(), transition.ChangedAt.UTC(), transition.Online)
+	return Error.Wrap(err)
+}
+
+// GetNodeUptimeTransitions returns the transitions of the node since the specified time, sorted by time.
+// The last transition before since, when there is one, is returned first.
+func (cache *overlaycache) GetNodeUptimeTransitions(ctx context.Context, nodeID storj.NodeID, since time.Time) (transitions []overlay.NodeUptimeTransition, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	scan := func(rows tagsql.Rows) error {
+		for rows.Next() {
+			transition := overlay.NodeUptimeTransition{NodeID: nodeID}
+			if err := rows.Scan(&transition.ChangedAt, &transition.Online); err != nil {
+				return err
+			}
+			transitions = append(transitions, transition)
+		}
+		return nil
+	}
+
+	err = withRows(cache.db.QueryContext(ctx, cache.db.Rebind(`
+		SELECT changed_at, online FROM node_uptime_transitions
+		WHERE node_id = ? AND changed_at < ?
+		ORDER BY changed_at DESC
+		LIMIT 1
+	`), nodeID.Bytes(), since))(scan)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	err = withRows(cache.db.QueryContext(ctx, cache.db.Rebind(`
+		SELECT changed_at, online FROM node_uptime_transitions
+		WHERE node_id = ? AND changed_at >= ?
+		ORDER BY changed_at ASC
+	`), nodeID.Bytes(), since))(scan)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return transitions, nil
+}
+
+// GetMissingOfflineUptimeTransitions returns, for at most limit nodes whose last transition is online but
+// which weren't contacted successfully since before, the offline transition at the end of their onlineWindow.
+func (cache *overlaycache) GetMissingOfflineUptimeTransitions(ctx context.Context, before time.Time, onlineWindow time.Duration, limit int) (transitions []overlay.NodeUptimeTransition, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if limit <= 0 {
+		return nil, Error.New("expected limit to be positive; got %d", limit)
+	}
+
+	err = withRows(cache.db.QueryContext(ctx, cache.db.Rebind(`
+		SELECT nodes.id, nodes.last_contact_success FROM nodes
+		WHERE nodes.last_contact_success < ?
+			AND nodes.last_contact_failure < nodes.last_contact_success
+			AND COALESCE((
+				SELECT node_uptime_transitions.online FROM node_uptime_transitions
+				WHERE node_uptime_transitions.node_id = nodes.id
+				ORDER BY node_uptime_transitions.changed_at DESC
+				LIMIT 1
+			), false)
+		LIMIT ?
+	`), before, limit))(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var nodeID storj.NodeID
+			var lastContactSuccess time.Time
+			if err := rows.Scan(&nodeID, &lastContactSuccess); err != nil {
+				return err
+			}
+			transitions = append(transitions, overlay.NodeUptimeTransition{
+				NodeID:    nodeID,
+				ChangedAt: lastContactSuccess.Add(onlineWindow).UTC(),
+				Online:    false,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return transitions, nil
+}
+
+// DeleteNodeUptimeTransitionsBefore deletes the transitions older than before, for at most batchSize nodes per query.
+func (cache *overlaycache) DeleteNodeUptimeTransitionsBefore(ctx context.Context, before time.Time, batchSize int) (deleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if batchSize <= 0 {
+		return 0, Error.New("expected batch size to be positive; got %d", batchSize)
+	}
+
+	var query string
+	switch cache.db.impl {
+	case dbutil.Cockroach, dbutil.Postgres:
+		query = `DELETE FROM node_uptime_transitions WHERE node_id = any($1::bytea[]) AND changed_at < $2`
+	case dbutil.Spanner:
+		query = `DELETE FROM node_uptime_transitions WHERE node_id IN UNNEST(?) AND changed_at < ?`
+	default:
+		return 0, Error.New("unsupported implementation")
+	}
+
+	for {
+		var nodeIDs storj.NodeIDList
+		err = withRows(cache.db.QueryContext(ctx, cache.db.Rebind(`
+			SELECT DISTINCT node_id FROM node_uptime_transitions
+			WHERE changed_at < ?
+			LIMIT ?
+		`), before, batchSize))(func(rows tagsql.Rows) error {
+			for rows.Next() {
+				var nodeID storj.NodeID
+				if err := rows.Scan(&nodeID); err != nil {
+					return err
+				}
+				nodeIDs = append(nodeIDs, nodeID)
+			}
+			return nil
+		})
+		if err != nil {
+			return deleted, Error.Wrap(err)
+		}
+		if len(nodeIDs) == 0 {
+			return deleted, nil
+		}
+
+		var result sql.Result
+		switch cache.db.impl {
+		case dbutil.Cockroach, dbutil.Postgres:
+			result, err = cache.db.ExecContext(ctx, query, pgutil.NodeIDArray(nodeIDs), before)
+		case dbutil.Spanner:
+			result, err = cache.db.ExecContext(ctx, query, nodeIDs.Bytes(), before)
+		}
+		if err != nil {
+			return deleted, Error.Wrap(err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return deleted, Error.Wrap(err)
+		}
+		deleted += affected
+
+		if len(nodeIDs) < batchSize {
+			return deleted, nil
+		}
+	}
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
 
 	"storj.io/common/identity/testidentity"
 	"storj.io/common/pb"
@@ -928,3 +929,235 @@ func TestOverlayCache_GetLastIPPortByNodeTagName(t *testing.T) {
 		require.False(t, ok)
 	}, satellitedbtest.WithSpanner())
 }
+
+func TestOverlayCache_NodeDrain(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		cache := db.OverlayCache()
+
+		draining := addNode(ctx, t, cache, "draining", "127.0.0.1", time.Second, false, false, false, false, false)
+		other := addNode(ctx, t, cache, "other", "127.0.0.2", time.Second, false, false, false, false, false)
+
+		selectUpload := func() map[storj.NodeID]bool {
+			reputable, new, err := cache.SelectAllStorageNodesUpload(ctx, overlay.NodeSelectionConfig{
+				OnlineWindow: time.Hour,
+			})
+			require.NoError(t, err)
+			selected := map[storj.NodeID]bool{}
+			for _, node := range append(reputable, new...) {
+				selected[node.ID] = true
+			}
+			return selected
+		}
+
+		_, err := cache.GetNodeDrain(ctx, draining.id)
+		require.True(t, overlay.ErrNodeNotDraining.Has(err))
+
+		initiatedAt := time.Now().Truncate(time.Second).UTC()
+		require.NoError(t, cache.SetNodeDrain(ctx, overlay.NodeDrain{
+			NodeID:      draining.id,
+			InitiatedAt: initiatedAt,
+		}))
+
+		// setting it again only updates the reason.
+		require.NoError(t, cache.SetNodeDrain(ctx, overlay.NodeDrain{
+			NodeID:      draining.id,
+			Reason:      "hardware migration",
+			InitiatedAt: initiatedAt.Add(time.Hour),
+		}))
+
+		drain, err := cache.GetNodeDrain(ctx, draining.id)
+		require.NoError(t, err)
+		require.Equal(t, draining.id, drain.NodeID)
+		require.Equal(t, "hardware migration", drain.Reason)
+		require.WithinDuration(t, initiatedAt, drain.InitiatedAt, time.Second)
+
+		selected := selectUpload()
+		require.False(t, selected[draining.id])
+		require.True(t, selected[other.id])
+
+		// draining nodes are still used for downloads.
+		downloadable, err := cache.GetNodes(ctx, []storj.NodeID{draining.id}, time.Hour, 0)
+		require.NoError(t, err)
+		require.Len(t, downloadable, 1)
+		require.True(t, downloadable[0].Online)
+
+		require.NoError(t, cache.DeleteNodeDrain(ctx, draining.id))
+		require.True(t, overlay.ErrNodeNotDraining.Has(cache.DeleteNodeDrain(ctx, draining.id)))
+
+		selected = selectUpload()
+		require.True(t, selected[draining.id])
+		require.True(t, selected[other.id])
+
+		// concurrent drains of the same node don't conflict.
+		var group errgroup.Group
+		for i := 0; i < 4; i++ {
+			group.Go(func() error {
+				return cache.SetNodeDrain(ctx, overlay.NodeDrain{
+					NodeID:      other.id,
+					InitiatedAt: initiatedAt,
+				})
+			})
+		}
+		require.NoError(t, group.Wait())
+
+		_, err = cache.GetNodeDrain(ctx, other.id)
+		require.NoError(t, err)
+	}, satellitedbtest.WithSpanner())
+}
+
+func TestOverlayCache_GetNodesByWallet(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		cache := db.OverlayCache()
+
+		// addNode uses the same wallet for all the nodes.
+		active := addNode(ctx, t, cache, "active", "127.0.0.1", time.Second, false, false, false, false, false)
+		disqualified := addNode(ctx, t, cache, "disqualified", "127.0.0.2", time.Second, true, false, false, false, false)
+		exited := addNode(ctx, t, cache, "exited", "127.0.0.3", time.Second, false, false, false, false, true)
+
+		other := testrand.NodeID()
+		require.NoError(t, cache.UpdateCheckIn(ctx, overlay.NodeCheckInInfo{
+			IsUp:       true,
+			NodeID:     other,
+			Address:    &pb.NodeAddress{Address: "other"},
+			LastIPPort: "127.0.0.4",
+			LastNet:    "127.0.0.4",
+			Version:    &pb.NodeVersion{Version: "v0.0.0"},
+			Operator: &pb.NodeOperator{
+				Email:  "other@storj.io",
+				Wallet: "0x00000000000000000000000000000000000abcde",
+			},
+		}, time.Now(), overlay.NodeSelectionConfig{}))
+
+		for _, wallet := range []string{active.wallet, strings.ToLower(active.wallet), strings.ToUpper(active.wallet)} {
+			dossiers, err := cache.GetNodesByWallet(ctx, wallet)
+			require.NoError(t, err)
+
+			var ids []storj.NodeID
+			for _, dossier := range dossiers {
+				require.Equal(t, active.wallet, dossier.Operator.Wallet)
+				ids = append(ids, dossier.Id)
+			}
+			require.ElementsMatch(t, []storj.NodeID{active.id, disqualified.id, exited.id}, ids)
+		}
+
+		// the lowercase wallet is found with its checksummed form.
+		dossiers, err := cache.GetNodesByWallet(ctx, "0x00000000000000000000000000000000000aBCdE")
+		require.NoError(t, err)
+		require.Len(t, dossiers, 1)
+		require.Equal(t, other, dossiers[0].Id)
+
+		dossiers, err = cache.GetNodesByWallet(ctx, "0x0000000000000000000000000000000000000002")
+		require.NoError(t, err)
+		require.Empty(t, dossiers)
+	}, satellitedbtest.WithSpanner())
+}
+
+func TestOverlayCache_GetMissingOfflineUptimeTransitions(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		cache := db.OverlayCache()
+
+		now := time.Now().UTC().Truncate(time.Second)
+		window := 4 * time.Hour
+
+		checkIn := func(lastContact time.Time, online bool) storj.NodeID {
+			nodeID := testrand.NodeID()
+			require.NoError(t, cache.UpdateCheckIn(ctx, overlay.NodeCheckInInfo{
+				IsUp:       true,
+				NodeID:     nodeID,
+				Address:    &pb.NodeAddress{Address: "127.0.0.1:0"},
+				LastIPPort: "127.0.0.1:0",
+				LastNet:    "127.0.0",
+				Version:    &pb.NodeVersion{Version: "v0.0.0"},
+			}, lastContact, overlay.NodeSelectionConfig{}))
+			require.NoError(t, cache.InsertNodeUptimeTransition(ctx, overlay.NodeUptimeTransition{
+				NodeID:    nodeID,
+				ChangedAt: lastContact,
+				Online:    online,
+			}))
+			return nodeID
+		}
+
+		silent := checkIn(now.Add(-5*time.Hour), true)
+		// a node contacted within the window is still online.
+		_ = checkIn(now.Add(-time.Hour), true)
+		// a node whose offline transition is recorded already.
+		_ = checkIn(now.Add(-5*time.Hour), false)
+
+		transitions, err := cache.GetMissingOfflineUptimeTransitions(ctx, now.Add(-window), window, 10)
+		require.NoError(t, err)
+		require.Len(t, transitions, 1)
+		require.Equal(t, silent, transitions[0].NodeID)
+		require.Equal(t, now.Add(-time.Hour), transitions[0].ChangedAt.UTC())
+		require.False(t, transitions[0].Online)
+
+		require.NoError(t, cache.InsertNodeUptimeTransition(ctx, transitions[0]))
+
+		transitions, err = cache.GetMissingOfflineUptimeTransitions(ctx, now.Add(-window), window, 10)
+		require.NoError(t, err)
+		require.Empty(t, transitions)
+	}, satellitedbtest.WithSpanner())
+}
+
+func TestOverlayCache_NodeUptimeTransitions(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		cache := db.OverlayCache()
+
+		nodeID, other := testrand.NodeID(), testrand.NodeID()
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		for i, online := range []bool{true, false, true} {
+			require.NoError(t, cache.InsertNodeUptimeTransition(ctx, overlay.NodeUptimeTransition{
+				NodeID:    nodeID,
+				ChangedAt: start.Add(time.Duration(i) * 24 * time.Hour),
+				Online:    online,
+			}))
+		}
+		require.NoError(t, cache.InsertNodeUptimeTransition(ctx, overlay.NodeUptimeTransition{
+			NodeID:    other,
+			ChangedAt: start,
+			Online:    true,
+		}))
+
+		// inserting the same transition again doesn't fail.
+		require.NoError(t, cache.InsertNodeUptimeTransition(ctx, overlay.NodeUptimeTransition{
+			NodeID:    nodeID,
+			ChangedAt: start,
+			Online:    true,
+		}))
+
+		states := func(transitions []overlay.NodeUptimeTransition) (changes []time.Time, online []bool) {
+			for _, transition := range transitions {
+				require.Equal(t, nodeID, transition.NodeID)
+				changes = append(changes, transition.ChangedAt.UTC())
+				online = append(online, transition.Online)
+			}
+			return changes, online
+		}
+
+		transitions, err := cache.GetNodeUptimeTransitions(ctx, nodeID, start)
+		require.NoError(t, err)
+		changes, online := states(transitions)
+		require.Equal(t, []time.Time{start, start.Add(24 * time.Hour), start.Add(48 * time.Hour)}, changes)
+		require.Equal(t, []bool{true, false, true}, online)
+
+		// the last transition before since is returned first.
+		transitions, err = cache.GetNodeUptimeTransitions(ctx, nodeID, start.Add(36*time.Hour))
+		require.NoError(t, err)
+		changes, online = states(transitions)
+		require.Equal(t, []time.Time{start.Add(24 * time.Hour), start.Add(48 * time.Hour)}, changes)
+		require.Equal(t, []bool{false, true}, online)
+
+		deleted, err := cache.DeleteNodeUptimeTransitionsBefore(ctx, start.Add(36*time.Hour), 1)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, deleted)
+
+		transitions, err = cache.GetNodeUptimeTransitions(ctx, nodeID, start)
+		require.NoError(t, err)
+		changes, _ = states(transitions)
+		require.Equal(t, []time.Time{start.Add(48 * time.Hour)}, changes)
+
+		transitions, err = cache.GetNodeUptimeTransitions(ctx, other, start)
+		require.NoError(t, err)
+		require.Empty(t, transitions)
+	}, satellitedbtest.WithSpanner())
+}
@@ -568,7 +568,8 @@ func (db *ProjectAccounting) GetProjectTotalByPartner(ctx context.Context, proje
 			bucket_storage_tallies.inline,
 			bucket_storage_tallies.remote,
 			bucket_storage_tallies.total_segments_count,
-			bucket_storage_tallies.object_count
+			bucket_storage_tallies.object_count,
+			bucket_storage_tallies.metadata_size
 		FROM
 			bucket_storage_tallies
 		WHERE
@@ -624,7 +625,7 @@ func (db *ProjectAccounting) GetProjectTotalByPartner(ctx context.Context, proje
 			tally := accounting.BucketStorageTally{}
 
 			var inline, remote int64
-			err = storageTalliesRows.Scan(&tally.IntervalStart, &tally.TotalBytes, &inline, &remote, &tally.TotalSegmentCount, &tally.ObjectCount)
+			err = storageTalliesRows.Scan(&tally.IntervalStart, &tally.TotalBytes, &inline, &remote, &tally.TotalSegmentCount, &tally.ObjectCount, &tally.MetadataSize)
 			if err != nil {
 				return nil, errs.Combine(err, storageTalliesRows.Close())
 			}
@@ -641,6 +642,7 @@ func (db *ProjectAccounting) GetProjectTotalByPartner(ctx context.Context, proje
 			usage.Storage += memory.Size(tally.TotalBytes).Float64() * hours
 			usage.SegmentCount += float64(tally.TotalSegmentCount) * hours
 			usage.ObjectCount += float64(tally.ObjectCount) * hours
+			usage.MetadataSize += float64(tally.MetadataSize) * hours
 
 			prevTally = &tally
 		}
@@ -236,6 +236,7 @@ func TestGetProjectTotal(t *testing.T) {
 			require.InDelta(t, usage.Storage, float64(tallies[0].Bytes()+tallies[1].Bytes()), epsilon)
 			require.InDelta(t, usage.SegmentCount, float64(tallies[0].TotalSegmentCount+tallies[1].TotalSegmentCount), epsilon)
 			require.InDelta(t, usage.ObjectCount, float64(tallies[0].ObjectCount+tallies[1].ObjectCount), epsilon)
+			require.InDelta(t, usage.MetadataSize, float64(tallies[0].MetadataSize+tallies[1].MetadataSize), epsilon)
 			require.Equal(t, usage.Egress, expectedEgress)
 			require.Equal(t, usage.Since, tallies[0].IntervalStart)
 			require.Equal(t, usage.Before, tallies[2].IntervalStart.Add(time.Minute))
@@ -246,6 +247,7 @@ func TestGetProjectTotal(t *testing.T) {
 			require.InDelta(t, usage.Storage, float64(tallies[0].Bytes()), epsilon)
 			require.InDelta(t, usage.SegmentCount, float64(tallies[0].TotalSegmentCount), epsilon)
 			require.InDelta(t, usage.ObjectCount, float64(tallies[0].ObjectCount), epsilon)
+			require.InDelta(t, usage.MetadataSize, float64(tallies[0].MetadataSize), epsilon)
 			require.Equal(t, usage.Egress, expectedEgress)
 			require.Equal(t, usage.Since, tallies[0].IntervalStart)
 			require.Equal(t, usage.Before, tallies[2].IntervalStart)
@@ -434,6 +436,7 @@ func randTally(bucketName string, projectID uuid.UUID, intervalStart time.Time)
 		TotalBytes:        int64(testrand.Intn(1000)),
 		ObjectCount:       int64(testrand.Intn(1000)),
 		TotalSegmentCount: int64(testrand.Intn(1000)),
+		MetadataSize:      int64(testrand.Intn(1000)),
 	}
 }
 
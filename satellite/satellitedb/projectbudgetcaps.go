@@ -0,0 +1,136 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/satellitedb/dbx"
+)
+
+// Ensure that projectBudgetCaps implements console.ProjectBudgetCaps.
+var _ console.ProjectBudgetCaps = (*projectBudgetCaps)(nil)
+
+// projectBudgetCaps is an implementation of console.ProjectBudgetCaps.
+type projectBudgetCaps struct {
+	db dbx.DriverMethods
+}
+
+// Get returns the budget cap of the project. It returns sql.ErrNoRows when the project has no cap.
+func (caps *projectBudgetCaps) Get(ctx context.Context, projectID uuid.UUID) (_ *console.ProjectBudgetCap, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	row := caps.db.QueryRowContext(ctx, caps.db.Rebind(`
+		SELECT project_id, cap_cents, notified_percent, period_start, frozen_at,
+			frozen_storage_limit, frozen_bandwidth_limit, created_at, updated_at
+		FROM project_budget_caps
+		WHERE project_id = ?
+	`), projectID.Bytes())
+
+	budgetCap, err := scanProjectBudgetCap(row)
+	if err != nil {
+		return nil, err
+	}
+	return &budgetCap, nil
+}
+
+// Upsert inserts or updates the budget cap of the project.
+func (caps *projectBudgetCaps) Upsert(ctx context.Context, budgetCap console.ProjectBudgetCap) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	now := time.Now().UTC()
+
+	result, err := caps.db.ExecContext(ctx, caps.db.Rebind(`
+		UPDATE project_budget_caps
+		SET cap_cents = ?, notified_percent = ?, period_start = ?, frozen_at = ?,
+			frozen_storage_limit = ?, frozen_bandwidth_limit = ?, updated_at = ?
+		WHERE project_id = ?
+	`), budgetCap.CapCents, int64(budgetCap.NotifiedPercent), budgetCap.PeriodStart, budgetCap.FrozenAt,
+		budgetCap.FrozenStorageLimit, budgetCap.FrozenBandwidthLimit, now, budgetCap.ProjectID.Bytes())
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	_, err = caps.db.ExecContext(ctx, caps.db.Rebind(`
+		INSERT INTO project_budget_caps (
+			project_id, cap_cents, notified_percent, period_start, frozen_at,
+			frozen_storage_limit, frozen_bandwidth_limit, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`), budgetCap.ProjectID.Bytes(), budgetCap.CapCents, int64(budgetCap.NotifiedPercent), budgetCap.PeriodStart, budgetCap.FrozenAt,
+		budgetCap.FrozenStorageLimit, budgetCap.FrozenBandwidthLimit, now, now)
+	return Error.Wrap(err)
+}
+
+// Delete deletes the budget cap of the project.
+func (caps *projectBudgetCaps) Delete(ctx context.Context, projectID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = caps.db.ExecContext(ctx, caps.db.Rebind(`
+		DELETE FROM project_budget_caps WHERE project_id = ?
+	`), projectID.Bytes())
+	return Error.Wrap(err)
+}
+
+// List returns the budget caps of projects with IDs greater than the cursor, ordered by project ID.
+func (caps *projectBudgetCaps) List(ctx context.Context, cursor uuid.UUID, limit int) (_ []console.ProjectBudgetCap, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if limit <= 0 {
+		return nil, Error.New("limit must be positive")
+	}
+
+	rows, err := caps.db.QueryContext(ctx, caps.db.Rebind(`
+		SELECT project_id, cap_cents, notified_percent, period_start, frozen_at,
+			frozen_storage_limit, frozen_bandwidth_limit, created_at, updated_at
+		FROM project_budget_caps
+		WHERE project_id > ?
+		ORDER BY project_id ASC
+		LIMIT ?
+	`), cursor.Bytes(), int64(limit))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var list []console.ProjectBudgetCap
+	for rows.Next() {
+		budgetCap, err := scanProjectBudgetCap(rows)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		list = append(list, budgetCap)
+	}
+	return list, Error.Wrap(rows.Err())
+}
+
+// scanProjectBudgetCap scans a single project budget cap row.
+func scanProjectBudgetCap(row interface{ Scan(dest ...any) error }) (budgetCap console.ProjectBudgetCap, err error) {
+	var projectID []byte
+	var notifiedPercent int64
+	err = row.Scan(&projectID, &budgetCap.CapCents, &notifiedPercent, &budgetCap.PeriodStart, &budgetCap.FrozenAt,
+		&budgetCap.FrozenStorageLimit, &budgetCap.FrozenBandwidthLimit, &budgetCap.CreatedAt, &budgetCap.UpdatedAt)
+	if err != nil {
+		return console.ProjectBudgetCap{}, err
+	}
+
+	budgetCap.ProjectID, err = uuid.FromBytes(projectID)
+	if err != nil {
+		return console.ProjectBudgetCap{}, Error.Wrap(err)
+	}
+	budgetCap.NotifiedPercent = int(notifiedPercent)
+	return budgetCap, nil
+}
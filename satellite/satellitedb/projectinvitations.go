@@ -5,11 +5,15 @@ package satellitedb
 
 import (
 	"context"
+	"time"
+
+	"github.com/zeebo/errs"
 
 	"storj.io/common/uuid"
 	"storj.io/storj/private/slices2"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/satellitedb/dbx"
+	"storj.io/storj/shared/dbutil/pgutil"
 )
 
 // Ensure that projectInvitations implements console.ProjectInvitations.
@@ -17,7 +21,7 @@ var _ console.ProjectInvitations = (*projectInvitations)(nil)
 
 // projectInvitations is an implementation of console.ProjectInvitations.
 type projectInvitations struct {
-	db dbx.Methods
+	db dbx.DriverMethods
 }
 
 // Upsert updates a project member invitation if it exists and inserts it otherwise.
@@ -96,6 +100,72 @@ func (invites *projectInvitations) Delete(ctx context.Context, projectID uuid.UU
 	return err
 }
 
+// DeleteBefore deletes project member invitations created prior to some time from the database.
+func (invites *projectInvitations) DeleteBefore(
+	ctx context.Context, before time.Time, asOfSystemTimeInterval time.Duration, pageSize int) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if pageSize <= 0 {
+		return Error.New("expected page size to be positive; got %d", pageSize)
+	}
+
+	var cursorProjectID uuid.UUID
+	var cursorEmail string
+	projectIDs := make([]uuid.UUID, 0, pageSize)
+	emails := make([]string, 0, pageSize)
+	aost := invites.db.AsOfSystemInterval(asOfSystemTimeInterval)
+	for {
+		projectIDs, emails = projectIDs[:0], emails[:0]
+
+		// Select page of expired records
+		rows, err := invites.db.QueryContext(ctx, `
+			SELECT project_id, email FROM project_invitations
+			`+aost+`
+			WHERE (project_id, email) > ($1, $2) AND created_at < $3
+			ORDER BY project_id, email LIMIT $4
+		`, cursorProjectID, cursorEmail, before, pageSize)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		for rows.Next() {
+			var projectID uuid.UUID
+			var email string
+			if err = rows.Scan(&projectID, &email); err != nil {
+				return Error.Wrap(errs.Combine(err, rows.Close()))
+			}
+			projectIDs = append(projectIDs, projectID)
+			emails = append(emails, email)
+		}
+		if err = errs.Combine(rows.Err(), rows.Close()); err != nil {
+			return Error.Wrap(err)
+		}
+
+		if len(projectIDs) == 0 {
+			return nil
+		}
+
+		// Delete all expired records in the page
+		_, err = invites.db.ExecContext(ctx, `
+			DELETE FROM project_invitations
+			WHERE (project_id, email) IN (
+				SELECT unnest($1::bytea[]), unnest($2::text[])
+			)
+			AND created_at < $3
+		`, pgutil.UUIDArray(projectIDs), pgutil.TextArray(emails), before)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		if len(projectIDs) < pageSize {
+			return nil
+		}
+
+		// Advance the cursor to the next page
+		cursorProjectID, cursorEmail = projectIDs[len(projectIDs)-1], emails[len(emails)-1]
+	}
+}
+
 // projectInvitationFromDBX converts a project member invitation from the database to a *console.ProjectInvitation.
 func projectInvitationFromDBX(dbxInvite *dbx.ProjectInvitation) (_ *console.ProjectInvitation, err error) {
 	if dbxInvite == nil {
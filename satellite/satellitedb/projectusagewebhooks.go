@@ -0,0 +1,160 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/satellitedb/dbx"
+)
+
+// Ensure that projectUsageWebhooks implements console.ProjectUsageWebhooks.
+var _ console.ProjectUsageWebhooks = (*projectUsageWebhooks)(nil)
+
+// projectUsageWebhooks is an implementation of console.ProjectUsageWebhooks.
+type projectUsageWebhooks struct {
+	db dbx.DriverMethods
+}
+
+// Insert inserts a new webhook.
+func (webhooks *projectUsageWebhooks) Insert(ctx context.Context, webhook console.ProjectUsageWebhook) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	createdAt := webhook.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	_, err = webhooks.db.ExecContext(ctx, webhooks.db.Rebind(`
+		INSERT INTO project_usage_webhooks (
+			id, project_id, url, secret, created_by, last_sent_day, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+	`), webhook.ID.Bytes(), webhook.ProjectID.Bytes(), webhook.URL, webhook.Secret, webhook.CreatedBy.Bytes(),
+		webhook.LastSentDay, createdAt.UTC())
+	return Error.Wrap(err)
+}
+
+// Get returns the webhook of the project. It returns sql.ErrNoRows when it doesn't exist.
+func (webhooks *projectUsageWebhooks) Get(ctx context.Context, projectID, id uuid.UUID) (_ *console.ProjectUsageWebhook, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	row := webhooks.db.QueryRowContext(ctx, webhooks.db.Rebind(`
+		SELECT id, project_id, url, secret, created_by, last_sent_day, created_at
+		FROM project_usage_webhooks
+		WHERE project_id = ? AND id = ?
+	`), projectID.Bytes(), id.Bytes())
+
+	webhook, err := scanProjectUsageWebhook(row)
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// ListByProjectID returns the webhooks of the project.
+func (webhooks *projectUsageWebhooks) ListByProjectID(ctx context.Context, projectID uuid.UUID) (_ []console.ProjectUsageWebhook, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := webhooks.db.QueryContext(ctx, webhooks.db.Rebind(`
+		SELECT id, project_id, url, secret, created_by, last_sent_day, created_at
+		FROM project_usage_webhooks
+		WHERE project_id = ?
+		ORDER BY created_at ASC, id ASC
+	`), projectID.Bytes())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var list []console.ProjectUsageWebhook
+	for rows.Next() {
+		webhook, err := scanProjectUsageWebhook(rows)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		list = append(list, webhook)
+	}
+	return list, Error.Wrap(rows.Err())
+}
+
+// List returns the webhooks after the cursor, ordered by project ID and ID.
+func (webhooks *projectUsageWebhooks) List(ctx context.Context, cursor console.ProjectUsageWebhookCursor, limit int) (_ []console.ProjectUsageWebhook, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if limit <= 0 {
+		return nil, Error.New("limit must be positive")
+	}
+
+	rows, err := webhooks.db.QueryContext(ctx, webhooks.db.Rebind(`
+		SELECT id, project_id, url, secret, created_by, last_sent_day, created_at
+		FROM project_usage_webhooks
+		WHERE project_id > ? OR (project_id = ? AND id > ?)
+		ORDER BY project_id ASC, id ASC
+		LIMIT ?
+	`), cursor.ProjectID.Bytes(), cursor.ProjectID.Bytes(), cursor.ID.Bytes(), int64(limit))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var list []console.ProjectUsageWebhook
+	for rows.Next() {
+		webhook, err := scanProjectUsageWebhook(rows)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		list = append(list, webhook)
+	}
+	return list, Error.Wrap(rows.Err())
+}
+
+// UpdateLastSentDay updates the last day whose usage was delivered by the webhook.
+func (webhooks *projectUsageWebhooks) UpdateLastSentDay(ctx context.Context, projectID, id uuid.UUID, day time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = webhooks.db.ExecContext(ctx, webhooks.db.Rebind(`
+		UPDATE project_usage_webhooks
+		SET last_sent_day = ?
+		WHERE project_id = ? AND id = ?
+	`), day.UTC(), projectID.Bytes(), id.Bytes())
+	return Error.Wrap(err)
+}
+
+// Delete deletes the webhook of the project.
+func (webhooks *projectUsageWebhooks) Delete(ctx context.Context, projectID, id uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = webhooks.db.ExecContext(ctx, webhooks.db.Rebind(`
+		DELETE FROM project_usage_webhooks WHERE project_id = ? AND id = ?
+	`), projectID.Bytes(), id.Bytes())
+	return Error.Wrap(err)
+}
+
+// scanProjectUsageWebhook scans a single project usage webhook row.
+func scanProjectUsageWebhook(row interface{ Scan(dest ...any) error }) (webhook console.ProjectUsageWebhook, err error) {
+	var id, projectID, createdBy []byte
+	err = row.Scan(&id, &projectID, &webhook.URL, &webhook.Secret, &createdBy, &webhook.LastSentDay, &webhook.CreatedAt)
+	if err != nil {
+		return console.ProjectUsageWebhook{}, err
+	}
+
+	webhook.ID, err = uuid.FromBytes(id)
+	if err != nil {
+		return console.ProjectUsageWebhook{}, Error.Wrap(err)
+	}
+	webhook.ProjectID, err = uuid.FromBytes(projectID)
+	if err != nil {
+		return console.ProjectUsageWebhook{}, Error.Wrap(err)
+	}
+	webhook.CreatedBy, err = uuid.FromBytes(createdBy)
+	if err != nil {
+		return console.ProjectUsageWebhook{}, Error.Wrap(err)
+	}
+	return webhook, nil
+}
@@ -86,14 +86,14 @@ func (r *repairQueue) Insert(ctx context.Context, seg *queue.InjuredSegment) (al
 		query = `
 			INSERT INTO repair_queue
 			(
-				stream_id, position, segment_health, placement
+				stream_id, position, segment_health, placement, reason
 			)
 			VALUES (
-				$1, $2, $3, $4
+				$1, $2, $3, $4, $5
 			)
 			ON CONFLICT (stream_id, position)
 			DO UPDATE
-			SET segment_health=$3, updated_at=current_timestamp, placement=$4
+			SET segment_health=$3, updated_at=current_timestamp, placement=$4, reason=$5
 			RETURNING (xmax != 0) AS alreadyInserted
 		`
 	case dbutil.Cockroach:
@@ -105,18 +105,18 @@ func (r *repairQueue) Insert(ctx context.Context, seg *queue.InjuredSegment) (al
 			)
 			INSERT INTO repair_queue
 			(
-				stream_id, position, segment_health, placement
+				stream_id, position, segment_health, placement, reason
 			)
 			VALUES (
-				$1, $2, $3, $4
+				$1, $2, $3, $4, $5
 			)
 			ON CONFLICT (stream_id, position)
 			DO UPDATE
-			SET segment_health=$3, updated_at=current_timestamp, placement=$4
+			SET segment_health=$3, updated_at=current_timestamp, placement=$4, reason=$5
 			RETURNING (SELECT alreadyInserted FROM inserted)
 		`
 	}
-	rows, err := r.db.QueryContext(ctx, query, seg.StreamID, seg.Position.Encode(), seg.SegmentHealth, seg.Placement)
+	rows, err := r.db.QueryContext(ctx, query, seg.StreamID, seg.Position.Encode(), seg.SegmentHealth, seg.Placement, seg.Reason)
 	if err != nil {
 		return false, err
 	}
@@ -156,17 +156,18 @@ func (r *repairQueue) InsertBatch(
 		query = `
 			INSERT INTO repair_queue
 			(
-				stream_id, position, segment_health, placement
+				stream_id, position, segment_health, placement, reason
 			)
 			VALUES (
 				UNNEST($1::BYTEA[]),
 				UNNEST($2::INT8[]),
 				UNNEST($3::double precision[]),
-				UNNEST($4::INT2[])
+				UNNEST($4::INT2[]),
+				UNNEST($5::INT2[])
 			)
 			ON CONFLICT (stream_id, position)
 			DO UPDATE
-			SET segment_health=EXCLUDED.segment_health, updated_at=current_timestamp, placement=EXCLUDED.placement
+			SET segment_health=EXCLUDED.segment_health, updated_at=current_timestamp, placement=EXCLUDED.placement, reason=EXCLUDED.reason
 			RETURNING NOT(xmax != 0) AS newlyInserted
 		`
 	case dbutil.Cockroach:
@@ -177,20 +178,22 @@ func (r *repairQueue) InsertBatch(
 					UNNEST($1::BYTEA[]) AS stream_id,
 					UNNEST($2::INT8[]) AS position,
 					UNNEST($3::double precision[]) AS segment_health,
-					UNNEST($4::INT2[]) AS placement
+					UNNEST($4::INT2[]) AS placement,
+					UNNEST($5::INT2[]) AS reason
 			),
 			do_insert AS (
 				INSERT INTO repair_queue (
-					stream_id, position, segment_health, placement
+					stream_id, position, segment_health, placement, reason
 				)
-				SELECT stream_id, position, segment_health, placement
+				SELECT stream_id, position, segment_health, placement, reason
 				FROM to_insert
 				ON CONFLICT (stream_id, position)
 				DO UPDATE
 				SET
 					segment_health=EXCLUDED.segment_health,
 					updated_at=current_timestamp,
-					placement=EXCLUDED.placement
+					placement=EXCLUDED.placement,
+					reason=EXCLUDED.reason
 				RETURNING false
 			)
 			SELECT
@@ -207,6 +210,7 @@ func (r *repairQueue) InsertBatch(
 		Positions      []int64
 		SegmentHealths []float64
 		placements     []int16
+		reasons        []int16
 	}
 
 	for _, segment := range segments {
@@ -214,6 +218,7 @@ func (r *repairQueue) InsertBatch(
 		insertData.Positions = append(insertData.Positions, int64(segment.Position.Encode()))
 		insertData.SegmentHealths = append(insertData.SegmentHealths, segment.SegmentHealth)
 		insertData.placements = append(insertData.placements, int16(segment.Placement))
+		insertData.reasons = append(insertData.reasons, int16(segment.Reason))
 	}
 
 	rows, err := r.db.QueryContext(
@@ -222,6 +227,7 @@ func (r *repairQueue) InsertBatch(
 		pgutil.Int8Array(insertData.Positions),
 		pgutil.Float8Array(insertData.SegmentHealths),
 		pgutil.Int2Array(insertData.placements),
+		pgutil.Int2Array(insertData.reasons),
 	)
 
 	if err != nil {
@@ -249,22 +255,7 @@ func (r *repairQueue) InsertBatch(
 
 func (r *repairQueue) Select(ctx context.Context, n int, includedPlacements []storj.PlacementConstraint, excludedPlacements []storj.PlacementConstraint) (segments []queue.InjuredSegment, err error) {
 	defer mon.Task()(&ctx)(&err)
-	restriction := ""
-
-	placementsToString := func(placements []storj.PlacementConstraint) string {
-		var ps []string
-		for _, p := range placements {
-			ps = append(ps, fmt.Sprintf("%d", p))
-		}
-		return strings.Join(ps, ",")
-	}
-	if len(includedPlacements) > 0 {
-		restriction += fmt.Sprintf(" AND placement IN (%s)", placementsToString(includedPlacements))
-	}
-
-	if len(excludedPlacements) > 0 {
-		restriction += fmt.Sprintf(" AND placement NOT IN (%s)", placementsToString(excludedPlacements))
-	}
+	restriction := placementRestriction(includedPlacements, excludedPlacements)
 
 	var rows tagsql.Rows
 	switch r.db.impl {
@@ -272,18 +263,20 @@ func (r *repairQueue) Select(ctx context.Context, n int, includedPlacements []st
 		rows, err = r.db.QueryContext(ctx, `
 			UPDATE repair_queue SET attempted_at = now() WHERE (stream_id, position) IN (
 				SELECT stream_id, position FROM repair_queue
-				WHERE (attempted_at IS NULL OR attempted_at < now() - interval '6 hours') `+restriction+`
+				WHERE (attempted_at IS NULL OR attempted_at < now() - interval '6 hours')
+					AND (lease_expires_at IS NULL OR lease_expires_at < now()) `+restriction+`
 				ORDER BY segment_health ASC, attempted_at NULLS FIRST FOR UPDATE SKIP LOCKED
 				LIMIT `+strconv.Itoa(n)+`
-			) RETURNING stream_id, position, attempted_at, updated_at, inserted_at, segment_health, placement
+			) RETURNING stream_id, position, attempted_at, updated_at, inserted_at, segment_health, placement, reason
 		`)
 	case dbutil.Cockroach:
 		rows, err = r.db.QueryContext(ctx, `
 			UPDATE repair_queue SET attempted_at = now()
-			WHERE (attempted_at IS NULL OR attempted_at < now() - interval '6 hours') `+restriction+`
+			WHERE (attempted_at IS NULL OR attempted_at < now() - interval '6 hours')
+				AND (lease_expires_at IS NULL OR lease_expires_at < now()) `+restriction+`
 			ORDER BY segment_health ASC, attempted_at NULLS FIRST
 			LIMIT `+strconv.Itoa(n)+`
-			RETURNING stream_id, position, attempted_at, updated_at, inserted_at, segment_health, placement
+			RETURNING stream_id, position, attempted_at, updated_at, inserted_at, segment_health, placement, reason
 		`)
 	default:
 		return segments, errs.New("unhandled database: %v", r.db.impl)
@@ -293,10 +286,75 @@ func (r *repairQueue) Select(ctx context.Context, n int, includedPlacements []st
 		for rows.Next() {
 			var segment queue.InjuredSegment
 			err := rows.Scan(&segment.StreamID, &segment.Position, &segment.AttemptedAt,
-				&segment.UpdatedAt, &segment.InsertedAt, &segment.SegmentHealth, &segment.Placement)
+				&segment.UpdatedAt, &segment.InsertedAt, &segment.SegmentHealth, &segment.Placement, &segment.Reason)
+			if err != nil {
+				return errs.Wrap(err)
+			}
+			segments = append(segments, segment)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(segments) == 0 {
+		return nil, queue.ErrEmpty.New("")
+	}
+
+	return segments, nil
+}
+
+func (r *repairQueue) Lease(ctx context.Context, worker string, n int, duration time.Duration, includedPlacements []storj.PlacementConstraint, excludedPlacements []storj.PlacementConstraint) (segments []queue.InjuredSegment, err error) {
+	defer mon.Task()(&ctx)(&err)
+	restriction := placementRestriction(includedPlacements, excludedPlacements)
+
+	// segments which aren't leased follow the same retry interval as Select, while
+	// segments with an expired lease are taken over immediately, as the worker which
+	// held them either died or didn't get to them in time.
+	var lock string
+	switch r.db.impl {
+	case dbutil.Postgres:
+		lock = "FOR UPDATE SKIP LOCKED"
+	case dbutil.Cockroach:
+		lock = "FOR UPDATE"
+	default:
+		return segments, errs.New("unhandled database: %v", r.db.impl)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE repair_queue AS rq SET
+			attempted_at = now(),
+			leased_by = $1,
+			lease_expires_at = now() + $2 * interval '1 microsecond'
+		FROM (
+			SELECT stream_id, position, leased_by FROM repair_queue
+			WHERE (
+				(lease_expires_at IS NULL AND (attempted_at IS NULL OR attempted_at < now() - interval '6 hours'))
+				OR lease_expires_at < now()
+			) `+restriction+`
+			ORDER BY segment_health ASC, attempted_at NULLS FIRST `+lock+`
+			LIMIT `+strconv.Itoa(n)+`
+		) AS selected
+		WHERE rq.stream_id = selected.stream_id AND rq.position = selected.position
+		RETURNING rq.stream_id, rq.position, rq.attempted_at, rq.updated_at, rq.inserted_at,
+			rq.segment_health, rq.placement, rq.reason, selected.leased_by
+	`, worker, duration.Microseconds())
+
+	var stolen int64
+	err = withRows(rows, err)(func(rows tagsql.Rows) error {
+		for rows.Next() {
+			var segment queue.InjuredSegment
+			var previousWorker *string
+			err := rows.Scan(&segment.StreamID, &segment.Position, &segment.AttemptedAt,
+				&segment.UpdatedAt, &segment.InsertedAt, &segment.SegmentHealth, &segment.Placement, &segment.Reason,
+				&previousWorker)
 			if err != nil {
 				return errs.Wrap(err)
 			}
+			if previousWorker != nil && *previousWorker != worker {
+				stolen++
+			}
 			segments = append(segments, segment)
 		}
 		return nil
@@ -305,6 +363,9 @@ func (r *repairQueue) Select(ctx context.Context, n int, includedPlacements []st
 		return nil, err
 	}
 
+	mon.Counter("repair_queue_leases_acquired").Inc(int64(len(segments)))
+	mon.Counter("repair_queue_leases_stolen").Inc(stolen)
+
 	if len(segments) == 0 {
 		return nil, queue.ErrEmpty.New("")
 	}
@@ -312,6 +373,64 @@ func (r *repairQueue) Select(ctx context.Context, n int, includedPlacements []st
 	return segments, nil
 }
 
+func (r *repairQueue) RenewLease(ctx context.Context, worker string, seg queue.InjuredSegment, duration time.Duration) (renewed bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE repair_queue SET lease_expires_at = now() + $1 * interval '1 microsecond'
+		WHERE stream_id = $2 AND position = $3 AND leased_by = $4
+	`, duration.Microseconds(), seg.StreamID, seg.Position.Encode(), worker)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+	return affected > 0, nil
+}
+
+func (r *repairQueue) ReleaseLease(ctx context.Context, worker string, seg queue.InjuredSegment) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = r.db.ExecContext(ctx, `
+		UPDATE repair_queue SET leased_by = NULL, lease_expires_at = NULL
+		WHERE stream_id = $1 AND position = $2 AND leased_by = $3
+	`, seg.StreamID, seg.Position.Encode(), worker)
+	return Error.Wrap(err)
+}
+
+func (r *repairQueue) Leases(ctx context.Context, limit int) (leases []queue.Lease, err error) {
+	defer mon.Task()(&ctx)(&err)
+	if limit <= 0 || limit > RepairQueueSelectLimit {
+		limit = RepairQueueSelectLimit
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT leased_by, stream_id, position, placement, lease_expires_at
+		FROM repair_queue
+		WHERE lease_expires_at > now()
+		ORDER BY leased_by, lease_expires_at
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var lease queue.Lease
+		err = rows.Scan(&lease.Worker, &lease.StreamID, &lease.Position, &lease.Placement, &lease.ExpiresAt)
+		if err != nil {
+			return leases, Error.Wrap(err)
+		}
+		leases = append(leases, lease)
+	}
+
+	return leases, Error.Wrap(rows.Err())
+}
+
 func (r *repairQueue) Delete(ctx context.Context, seg queue.InjuredSegment) (err error) {
 	defer mon.Task()(&ctx)(&err)
 	_, err = r.db.ExecContext(ctx, r.db.Rebind(`DELETE FROM repair_queue WHERE stream_id = ? AND position = ?`), seg.StreamID, seg.Position.Encode())
@@ -331,7 +450,7 @@ func (r *repairQueue) SelectN(ctx context.Context, limit int) (segs []queue.Inju
 	}
 	// TODO: strictly enforce order-by or change tests
 	rows, err := r.db.QueryContext(ctx,
-		r.db.Rebind(`SELECT stream_id, position, attempted_at, updated_at, inserted_at, segment_health, placement
+		r.db.Rebind(`SELECT stream_id, position, attempted_at, updated_at, inserted_at, segment_health, placement, reason
 					FROM repair_queue LIMIT ?`), limit,
 	)
 	if err != nil {
@@ -342,7 +461,7 @@ func (r *repairQueue) SelectN(ctx context.Context, limit int) (segs []queue.Inju
 	for rows.Next() {
 		var seg queue.InjuredSegment
 		err = rows.Scan(&seg.StreamID, &seg.Position, &seg.AttemptedAt,
-			&seg.UpdatedAt, &seg.InsertedAt, &seg.SegmentHealth, &seg.Placement)
+			&seg.UpdatedAt, &seg.InsertedAt, &seg.SegmentHealth, &seg.Placement, &seg.Reason)
 		if err != nil {
 			return segs, Error.Wrap(err)
 		}
@@ -376,3 +495,25 @@ func (r *repairQueue) TestingSetAttemptedTime(ctx context.Context, streamID uuid
 	count, err := res.RowsAffected()
 	return count, Error.Wrap(err)
 }
+
+// placementRestriction returns the conditions selecting only segments of the included
+// placements and none of the excluded placements.
+func placementRestriction(includedPlacements []storj.PlacementConstraint, excludedPlacements []storj.PlacementConstraint) string {
+	placementsToString := func(placements []storj.PlacementConstraint) string {
+		var ps []string
+		for _, p := range placements {
+			ps = append(ps, fmt.Sprintf("%d", p))
+		}
+		return strings.Join(ps, ",")
+	}
+
+	restriction := ""
+	if len(includedPlacements) > 0 {
+		restriction += fmt.Sprintf(" AND placement IN (%s)", placementsToString(includedPlacements))
+	}
+
+	if len(excludedPlacements) > 0 {
+		restriction += fmt.Sprintf(" AND placement NOT IN (%s)", placementsToString(excludedPlacements))
+	}
+	return restriction
+}
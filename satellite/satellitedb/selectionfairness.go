@@ -0,0 +1,97 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/overlay/selectionfairness"
+	"storj.io/storj/satellite/satellitedb/dbx"
+	"storj.io/storj/shared/dbutil/pgutil"
+)
+
+// Ensure that selectionFairnessDB implements selectionfairness.DB.
+var _ selectionfairness.DB = (*selectionFairnessDB)(nil)
+
+// selectionFairnessDB is an implementation of selectionfairness.DB.
+type selectionFairnessDB struct {
+	db *satelliteDB
+}
+
+// ReplaceReport replaces the stored report with the entries.
+func (db *selectionFairnessDB) ReplaceReport(ctx context.Context, generatedAt time.Time, entries []selectionfairness.Entry) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodeIDs := make([]storj.NodeID, 0, len(entries))
+	freeDisks := make([]int64, 0, len(entries))
+	uploadedBytes := make([]int64, 0, len(entries))
+	expectedShares := make([]float64, 0, len(entries))
+	actualShares := make([]float64, 0, len(entries))
+	ratios := make([]float64, 0, len(entries))
+	statuses := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		nodeIDs = append(nodeIDs, entry.NodeID)
+		freeDisks = append(freeDisks, entry.FreeDisk)
+		uploadedBytes = append(uploadedBytes, entry.UploadedBytes)
+		expectedShares = append(expectedShares, entry.ExpectedShare)
+		actualShares = append(actualShares, entry.ActualShare)
+		ratios = append(ratios, entry.Ratio)
+		statuses = append(statuses, string(entry.Status))
+	}
+
+	return Error.Wrap(db.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) error {
+		_, err := tx.Tx.ExecContext(ctx, `DELETE FROM node_selection_fairness_entries`)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+
+		_, err = tx.Tx.ExecContext(ctx, `
+			INSERT INTO node_selection_fairness_entries (
+				node_id, free_disk, uploaded_bytes, expected_share, actual_share, ratio, status, generated_at
+			)
+			SELECT
+				unnest($1::bytea[]), unnest($2::int8[]), unnest($3::int8[]),
+				unnest($4::float8[]), unnest($5::float8[]), unnest($6::float8[]), unnest($7::text[]),
+				$8
+		`, pgutil.NodeIDArray(nodeIDs), pgutil.Int8Array(freeDisks), pgutil.Int8Array(uploadedBytes),
+			pgutil.Float8Array(expectedShares), pgutil.Float8Array(actualShares), pgutil.Float8Array(ratios),
+			pgutil.TextArray(statuses), generatedAt.UTC())
+		return err
+	}))
+}
+
+// GetReport returns the stored report ordered by descending ratio.
+func (db *selectionFairnessDB) GetReport(ctx context.Context) (report selectionfairness.Report, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT node_id, free_disk, uploaded_bytes, expected_share, actual_share, ratio, status, generated_at
+		FROM node_selection_fairness_entries
+		ORDER BY ratio DESC, node_id ASC
+	`)
+	if err != nil {
+		return selectionfairness.Report{}, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var entry selectionfairness.Entry
+		var status string
+		err := rows.Scan(&entry.NodeID, &entry.FreeDisk, &entry.UploadedBytes,
+			&entry.ExpectedShare, &entry.ActualShare, &entry.Ratio, &status, &report.GeneratedAt)
+		if err != nil {
+			return selectionfairness.Report{}, Error.Wrap(err)
+		}
+		entry.Status = selectionfairness.Status(status)
+		report.Entries = append(report.Entries, entry)
+	}
+	return report, Error.Wrap(rows.Err())
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/sla"
+)
+
+// Ensure that slaDB implements sla.DB.
+var _ sla.DB = (*slaDB)(nil)
+
+// slaDB is an implementation of sla.DB.
+type slaDB struct {
+	db *satelliteDB
+}
+
+// Record adds the sample to the daily record of its placement.
+func (db *slaDB) Record(ctx context.Context, sample sla.Sample) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	day := sla.Day(sample.Day)
+	now := time.Now().UTC()
+
+	var failed int64
+	if sample.Failed {
+		failed = 1
+	}
+
+	query := `
+		UPDATE placement_sla_dailies
+		SET probes = probes + 1, failed_probes = failed_probes + ?,
+			max_repair_queue_segments = GREATEST(max_repair_queue_segments, ?),
+			updated_at = ?`
+	args := []any{failed, sample.RepairQueueSegments, now}
+	if sample.MinSegmentHealth != nil {
+		query += `, min_segment_health = LEAST(COALESCE(min_segment_health, ?), ?)`
+		args = append(args, *sample.MinSegmentHealth, *sample.MinSegmentHealth)
+	}
+	query += ` WHERE day = ? AND placement = ?`
+	args = append(args, day, int64(sample.Placement))
+
+	result, err := db.db.ExecContext(ctx, db.db.Rebind(query), args...)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	_, err = db.db.ExecContext(ctx, db.db.Rebind(`
+		INSERT INTO placement_sla_dailies (
+			day, placement, probes, failed_probes, max_repair_queue_segments, min_segment_health, updated_at
+		) VALUES (?, ?, 1, ?, ?, ?, ?)
+	`), day, int64(sample.Placement), failed, sample.RepairQueueSegments, sample.MinSegmentHealth, now)
+	return Error.Wrap(err)
+}
+
+// List returns the daily records of all placements for the days in [since, until),
+// ordered by day and placement.
+func (db *slaDB) List(ctx context.Context, since, until time.Time) (_ []sla.DailyRecord, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.db.QueryContext(ctx, db.db.Rebind(`
+		SELECT day, placement, probes, failed_probes, max_repair_queue_segments, min_segment_health, updated_at
+		FROM placement_sla_dailies
+		WHERE day >= ? AND day < ?
+		ORDER BY day ASC, placement ASC
+	`), since.UTC(), until.UTC())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var records []sla.DailyRecord
+	for rows.Next() {
+		var record sla.DailyRecord
+		var placement int64
+		err := rows.Scan(&record.Day, &placement, &record.Probes, &record.FailedProbes,
+			&record.MaxRepairQueueSegments, &record.MinSegmentHealth, &record.UpdatedAt)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		record.Placement = storj.PlacementConstraint(placement)
+		records = append(records, record)
+	}
+	return records, Error.Wrap(rows.Err())
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/satellitedb/dbx"
+)
+
+// Ensure that ssoIdentities implements console.SSOIdentities.
+var _ console.SSOIdentities = (*ssoIdentities)(nil)
+
+// ssoIdentities is an implementation of console.SSOIdentities.
+type ssoIdentities struct {
+	db dbx.DriverMethods
+}
+
+// Get returns the identity. It returns sql.ErrNoRows when it isn't linked to any user.
+func (identities *ssoIdentities) Get(ctx context.Context, provider, subject string) (_ *console.SSOIdentity, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	identity := console.SSOIdentity{}
+	var userID []byte
+	err = identities.db.QueryRowContext(ctx, identities.db.Rebind(`
+		SELECT provider, subject, user_id, email, created_at
+		FROM sso_identities
+		WHERE provider = ? AND subject = ?
+	`), provider, subject).Scan(&identity.Provider, &identity.Subject, &userID, &identity.Email, &identity.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	identity.UserID, err = uuid.FromBytes(userID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return &identity, nil
+}
+
+// Insert links the identity to its user.
+func (identities *ssoIdentities) Insert(ctx context.Context, identity console.SSOIdentity) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	createdAt := identity.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	_, err = identities.db.ExecContext(ctx, identities.db.Rebind(`
+		INSERT INTO sso_identities (
+			provider, subject, user_id, email, created_at
+		) VALUES (?, ?, ?, ?, ?)
+	`), identity.Provider, identity.Subject, identity.UserID.Bytes(), identity.Email, createdAt.UTC())
+	return Error.Wrap(err)
+}
+
+// Delete unlinks the identity.
+func (identities *ssoIdentities) Delete(ctx context.Context, provider, subject string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = identities.db.ExecContext(ctx, identities.db.Rebind(`
+		DELETE FROM sso_identities WHERE provider = ? AND subject = ?
+	`), provider, subject)
+	return Error.Wrap(err)
+}
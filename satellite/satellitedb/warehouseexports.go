@@ -0,0 +1,203 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/pb"
+	"storj.io/storj/satellite/accounting/warehouse"
+)
+
+// Ensure that warehouseExports implements warehouse.DB.
+var _ warehouse.DB = (*warehouseExports)(nil)
+
+// warehouseExports is an implementation of warehouse.DB.
+type warehouseExports struct {
+	db *satelliteDB
+}
+
+// GetMarker returns the time until which the stream was exported.
+func (exports *warehouseExports) GetMarker(ctx context.Context, stream warehouse.Stream) (_ time.Time, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var exportedUntil time.Time
+	err = exports.db.QueryRowContext(ctx, exports.db.Rebind(`
+		SELECT exported_until
+		FROM warehouse_export_markers
+		WHERE stream = ?
+	`), string(stream)).Scan(&exportedUntil)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, Error.Wrap(err)
+	}
+	return exportedUntil.UTC(), nil
+}
+
+// SetMarker moves the marker of the stream from previous to next.
+func (exports *warehouseExports) SetMarker(ctx context.Context, stream warehouse.Stream, previous, next time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var result sql.Result
+	if previous.IsZero() {
+		result, err = exports.db.ExecContext(ctx, exports.db.Rebind(`
+			INSERT INTO warehouse_export_markers (stream, exported_until, updated_at)
+			VALUES (?, ?, ?)
+			ON CONFLICT (stream) DO NOTHING
+		`), string(stream), next.UTC(), time.Now().UTC())
+	} else {
+		result, err = exports.db.ExecContext(ctx, exports.db.Rebind(`
+			UPDATE warehouse_export_markers
+			SET exported_until = ?, updated_at = ?
+			WHERE stream = ? AND exported_until = ?
+		`), next.UTC(), time.Now().UTC(), string(stream), previous.UTC())
+	}
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if affected == 0 {
+		return warehouse.ErrMarkerConflict.New("%s", stream)
+	}
+	return nil
+}
+
+// BucketBandwidthRollups returns the bucket bandwidth rollups of the hours in [since, before).
+func (exports *warehouseExports) BucketBandwidthRollups(ctx context.Context, since, before time.Time) (_ []warehouse.BucketBandwidthRollup, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// the rollups are ordered, so the rows of a batch are the same every time it's read.
+	rows, err := exports.db.QueryContext(ctx, exports.db.Rebind(`
+		SELECT project_id, bucket_name, interval_start, action, inline, allocated, settled
+		FROM bucket_bandwidth_rollups
+		WHERE interval_start >= ? AND interval_start < ?
+		ORDER BY interval_start, project_id, bucket_name, action
+	`), since.UTC(), before.UTC())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(rows.Close())) }()
+
+	var rollups []warehouse.BucketBandwidthRollup
+	for rows.Next() {
+		var rollup warehouse.BucketBandwidthRollup
+		var bucketName []byte
+		var action int32
+		err := rows.Scan(&rollup.ProjectID, &bucketName, &rollup.IntervalStart, &action,
+			&rollup.Inline, &rollup.Allocated, &rollup.Settled)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		rollup.BucketName = string(bucketName)
+		rollup.IntervalStart = rollup.IntervalStart.UTC()
+		rollup.Action = pb.PieceAction(action).String()
+		rollups = append(rollups, rollup)
+	}
+	return rollups, Error.Wrap(rows.Err())
+}
+
+// StoragenodeBandwidthRollups returns the storage node bandwidth rollups of the hours in [since, before).
+func (exports *warehouseExports) StoragenodeBandwidthRollups(ctx context.Context, since, before time.Time) (_ []warehouse.StoragenodeBandwidthRollup, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := exports.db.QueryContext(ctx, exports.db.Rebind(`
+		SELECT storagenode_id, interval_start, action, COALESCE(allocated, 0), settled
+		FROM storagenode_bandwidth_rollups
+		WHERE interval_start >= ? AND interval_start < ?
+		ORDER BY interval_start, storagenode_id, action
+	`), since.UTC(), before.UTC())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(rows.Close())) }()
+
+	var rollups []warehouse.StoragenodeBandwidthRollup
+	for rows.Next() {
+		var rollup warehouse.StoragenodeBandwidthRollup
+		var action int32
+		err := rows.Scan(&rollup.NodeID, &rollup.IntervalStart, &action, &rollup.Allocated, &rollup.Settled)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		rollup.IntervalStart = rollup.IntervalStart.UTC()
+		rollup.Action = pb.PieceAction(action).String()
+		rollups = append(rollups, rollup)
+	}
+	return rollups, Error.Wrap(rows.Err())
+}
+
+// BucketStorageTallies returns the bucket storage tallies started in [since, before).
+func (exports *warehouseExports) BucketStorageTallies(ctx context.Context, since, before time.Time) (_ []warehouse.BucketStorageTally, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := exports.db.QueryContext(ctx, exports.db.Rebind(`
+		SELECT project_id, bucket_name, interval_start,
+			total_bytes, inline, remote,
+			total_segments_count, inline_segments_count, remote_segments_count,
+			object_count, metadata_size
+		FROM bucket_storage_tallies
+		WHERE interval_start >= ? AND interval_start < ?
+		ORDER BY interval_start, project_id, bucket_name
+	`), since.UTC(), before.UTC())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(rows.Close())) }()
+
+	var tallies []warehouse.BucketStorageTally
+	for rows.Next() {
+		var tally warehouse.BucketStorageTally
+		var bucketName []byte
+		err := rows.Scan(&tally.ProjectID, &bucketName, &tally.IntervalStart,
+			&tally.TotalBytes, &tally.InlineBytes, &tally.RemoteBytes,
+			&tally.TotalSegmentCount, &tally.InlineSegmentCount, &tally.RemoteSegmentCount,
+			&tally.ObjectCount, &tally.MetadataSize)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		tally.BucketName = string(bucketName)
+		tally.IntervalStart = tally.IntervalStart.UTC()
+		tallies = append(tallies, tally)
+	}
+	return tallies, Error.Wrap(rows.Err())
+}
+
+// StoragenodeStorageTallies returns the storage node storage tallies ended in [since, before).
+func (exports *warehouseExports) StoragenodeStorageTallies(ctx context.Context, since, before time.Time) (_ []warehouse.StoragenodeStorageTally, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := exports.db.QueryContext(ctx, exports.db.Rebind(`
+		SELECT node_id, interval_end_time, data_total
+		FROM storagenode_storage_tallies
+		WHERE interval_end_time >= ? AND interval_end_time < ?
+		ORDER BY interval_end_time, node_id
+	`), since.UTC(), before.UTC())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(rows.Close())) }()
+
+	var tallies []warehouse.StoragenodeStorageTally
+	for rows.Next() {
+		var tally warehouse.StoragenodeStorageTally
+		err := rows.Scan(&tally.NodeID, &tally.IntervalEndTime, &tally.DataTotal)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		tally.IntervalEndTime = tally.IntervalEndTime.UTC()
+		tallies = append(tallies, tally)
+	}
+	return tallies, Error.Wrap(rows.Err())
+}
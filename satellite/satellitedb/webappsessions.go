@@ -53,6 +53,21 @@ func (db *webappSessions) UpdateExpiration(ctx context.Context, sessionID uuid.U
 	return err
 }
 
+// UpdateStatus updates the status of the session.
+func (db *webappSessions) UpdateStatus(ctx context.Context, sessionID uuid.UUID, status int) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.db.Update_WebappSession_By_Id(
+		ctx,
+		dbx.WebappSession_Id(sessionID.Bytes()),
+		dbx.WebappSession_Update_Fields{
+			Status: dbx.WebappSession_Status(status),
+		},
+	)
+
+	return err
+}
+
 // GetBySessionID gets the session info from the session ID.
 func (db *webappSessions) GetBySessionID(ctx context.Context, sessionID uuid.UUID) (session consoleauth.WebappSession, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -0,0 +1,120 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/metabase/zombiedeletion"
+)
+
+// Ensure that zombieDeletionDB implements zombiedeletion.DB.
+var _ zombiedeletion.DB = (*zombieDeletionDB)(nil)
+
+// zombieDeletionDB is an implementation of zombiedeletion.DB.
+type zombieDeletionDB struct {
+	db *satelliteDB
+}
+
+// Set stores the inactivity period of the project or the bucket.
+func (db *zombieDeletionDB) Set(ctx context.Context, override metabase.ZombieDeletionOverride) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if override.InactiveFor <= 0 {
+		return Error.New("inactivity period must be positive")
+	}
+
+	seconds := int64(override.InactiveFor / time.Second)
+	now := time.Now().UTC()
+
+	result, err := db.db.ExecContext(ctx, db.db.Rebind(`
+		UPDATE zombie_deletion_settings
+		SET inactive_for_seconds = ?, updated_at = ?
+		WHERE project_id = ? AND bucket_name = ?
+	`), seconds, now, override.ProjectID, []byte(override.BucketName))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	_, err = db.db.ExecContext(ctx, db.db.Rebind(`
+		INSERT INTO zombie_deletion_settings (
+			project_id, bucket_name, inactive_for_seconds, updated_at
+		) VALUES (?, ?, ?, ?)
+	`), override.ProjectID, []byte(override.BucketName), seconds, now)
+	return Error.Wrap(err)
+}
+
+// Get returns the inactivity period of the project or the bucket.
+func (db *zombieDeletionDB) Get(ctx context.Context, projectID uuid.UUID, bucketName metabase.BucketName) (_ time.Duration, ok bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var seconds int64
+	err = db.db.QueryRowContext(ctx, db.db.Rebind(`
+		SELECT inactive_for_seconds
+		FROM zombie_deletion_settings
+		WHERE project_id = ? AND bucket_name = ?
+	`), projectID, []byte(bucketName)).Scan(&seconds)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, Error.Wrap(err)
+	}
+	return time.Duration(seconds) * time.Second, true, nil
+}
+
+// Delete removes the inactivity period of the project or the bucket.
+func (db *zombieDeletionDB) Delete(ctx context.Context, projectID uuid.UUID, bucketName metabase.BucketName) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.db.ExecContext(ctx, db.db.Rebind(`
+		DELETE FROM zombie_deletion_settings
+		WHERE project_id = ? AND bucket_name = ?
+	`), projectID, []byte(bucketName))
+	return Error.Wrap(err)
+}
+
+// List returns all the configured inactivity periods.
+func (db *zombieDeletionDB) List(ctx context.Context) (_ []metabase.ZombieDeletionOverride, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT project_id, bucket_name, inactive_for_seconds
+		FROM zombie_deletion_settings
+		ORDER BY project_id, bucket_name
+	`)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var overrides []metabase.ZombieDeletionOverride
+	for rows.Next() {
+		var override metabase.ZombieDeletionOverride
+		var bucketName []byte
+		var seconds int64
+		if err := rows.Scan(&override.ProjectID, &bucketName, &seconds); err != nil {
+			return nil, Error.Wrap(err)
+		}
+		override.BucketName = metabase.BucketName(bucketName)
+		override.InactiveFor = time.Duration(seconds) * time.Second
+		overrides = append(overrides, override)
+	}
+	return overrides, Error.Wrap(rows.Err())
+}
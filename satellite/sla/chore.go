@@ -0,0 +1,136 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package sla
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/storj/satellite/overlay"
+	"storj.io/storj/satellite/repair/queue"
+)
+
+var (
+	// Error is the standard error class for SLA errors.
+	Error = errs.Class("sla")
+	mon   = monkit.Package()
+)
+
+// Config contains configurable values for the SLA tracking chore.
+type Config struct {
+	Enabled        bool          `help:"whether to track the daily SLA metrics of placements" default:"false"`
+	Interval       time.Duration `help:"how often the placements are probed" default:"5m"`
+	ProbeNodeCount int           `help:"how many nodes must be selectable for an upload for the availability probe of a placement to succeed" default:"110"`
+}
+
+// Chore periodically probes whether uploads are possible in every placement and
+// samples the repair queue, then adds the results to the daily SLA records.
+//
+// architecture: Chore
+type Chore struct {
+	log         *zap.Logger
+	db          DB
+	overlay     *overlay.Service
+	repairQueue queue.RepairQueue
+	placements  []storj.PlacementConstraint
+	config      Config
+
+	nowFn func() time.Time
+	Loop  *sync2.Cycle
+}
+
+// NewChore creates a new SLA tracking chore.
+func NewChore(log *zap.Logger, db DB, overlay *overlay.Service, repairQueue queue.RepairQueue, placements []storj.PlacementConstraint, config Config) *Chore {
+	return &Chore{
+		log:         log,
+		db:          db,
+		overlay:     overlay,
+		repairQueue: repairQueue,
+		placements:  placements,
+		config:      config,
+		nowFn:       time.Now,
+		Loop:        sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run runs the chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := chore.RunOnce(ctx); err != nil {
+			chore.log.Error("failed to record SLA metrics", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// RunOnce probes all placements once and records the results.
+func (chore *Chore) RunOnce(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	day := Day(chore.nowFn())
+
+	stats, err := chore.repairQueue.Stat(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var group errs.Group
+	for _, placement := range chore.placements {
+		sample := Sample{
+			Day:       day,
+			Placement: placement,
+			Failed:    !chore.probe(ctx, placement),
+		}
+
+		for _, stat := range stats {
+			if stat.Placement != placement {
+				continue
+			}
+			sample.RepairQueueSegments += int64(stat.Count)
+			if sample.MinSegmentHealth == nil || stat.MinSegmentHealth < *sample.MinSegmentHealth {
+				health := stat.MinSegmentHealth
+				sample.MinSegmentHealth = &health
+			}
+		}
+
+		if sample.Failed {
+			mon.Counter("sla_probe_failed", monkit.NewSeriesTag("placement", fmt.Sprintf("%d", placement))).Inc(1)
+		}
+
+		group.Add(Error.Wrap(chore.db.Record(ctx, sample)))
+	}
+	return group.Err()
+}
+
+// probe returns whether enough nodes can be selected for an upload in the placement.
+func (chore *Chore) probe(ctx context.Context, placement storj.PlacementConstraint) bool {
+	nodes, err := chore.overlay.FindStorageNodesForUpload(ctx, overlay.FindStorageNodesRequest{
+		RequestedCount: chore.config.ProbeNodeCount,
+		Placement:      placement,
+	})
+	if err != nil {
+		chore.log.Debug("placement availability probe failed", zap.Uint16("placement", uint16(placement)), zap.Error(err))
+		return false
+	}
+	return len(nodes) >= chore.config.ProbeNodeCount
+}
+
+// TestSetNow sets nowFn on chore for testing.
+func (chore *Chore) TestSetNow(f func() time.Time) {
+	chore.nowFn = f
+}
+
+// Close closes the chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
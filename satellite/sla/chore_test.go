@@ -0,0 +1,111 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package sla_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/repair/queue"
+	"storj.io/storj/satellite/sla"
+)
+
+func TestChore(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.SLA.Enabled = true
+				config.SLA.ProbeNodeCount = 4
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		chore := sat.Core.SLA.Chore
+		chore.Loop.Pause()
+
+		now := time.Now()
+		day := sla.Day(now)
+		chore.TestSetNow(func() time.Time { return now })
+
+		require.NoError(t, chore.RunOnce(ctx))
+
+		records, err := sat.DB.SLA().List(ctx, day, day.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		require.NotEmpty(t, records)
+
+		var record *sla.DailyRecord
+		for i := range records {
+			if records[i].Placement == storj.DefaultPlacement {
+				record = &records[i]
+			}
+		}
+		require.NotNil(t, record)
+		require.True(t, record.Day.Equal(day))
+		require.EqualValues(t, 1, record.Probes)
+		require.Zero(t, record.FailedProbes)
+		require.Zero(t, record.MaxRepairQueueSegments)
+		require.Nil(t, record.MinSegmentHealth)
+
+		_, err = sat.DB.RepairQueue().InsertBatch(ctx, []*queue.InjuredSegment{
+			{StreamID: testrand.UUID(), Placement: storj.DefaultPlacement, SegmentHealth: 0.75},
+			{StreamID: testrand.UUID(), Placement: storj.DefaultPlacement, SegmentHealth: 0.5},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, chore.RunOnce(ctx))
+
+		records, err = sat.DB.SLA().List(ctx, day, day.AddDate(0, 0, 1))
+		require.NoError(t, err)
+
+		summaries := sla.Summarize(records)
+		var summary *sla.Summary
+		for i := range summaries {
+			if summaries[i].Placement == storj.DefaultPlacement {
+				summary = &summaries[i]
+			}
+		}
+		require.NotNil(t, summary)
+		require.Equal(t, 1, summary.Days)
+		require.EqualValues(t, 2, summary.Probes)
+		require.Zero(t, summary.FailedProbes)
+		require.Equal(t, 1.0, summary.Availability)
+		require.EqualValues(t, 2, summary.MaxRepairQueueSegments)
+		require.NotNil(t, summary.MinSegmentHealth)
+		require.Equal(t, 0.5, *summary.MinSegmentHealth)
+
+		// the next day gets a new record.
+		chore.TestSetNow(func() time.Time { return now.AddDate(0, 0, 1) })
+		require.NoError(t, chore.RunOnce(ctx))
+
+		records, err = sat.DB.SLA().List(ctx, day.AddDate(0, 0, 1), day.AddDate(0, 0, 2))
+		require.NoError(t, err)
+		for _, record := range records {
+			require.EqualValues(t, 1, record.Probes)
+		}
+	})
+}
+
+func TestSummarize(t *testing.T) {
+	health := func(v float64) *float64 { return &v }
+
+	summaries := sla.Summarize([]sla.DailyRecord{
+		{Placement: 10, Probes: 100, FailedProbes: 10, MaxRepairQueueSegments: 5, MinSegmentHealth: health(0.7)},
+		{Placement: 0, Probes: 100, MaxRepairQueueSegments: 1},
+		{Placement: 10, Probes: 100, FailedProbes: 0, MaxRepairQueueSegments: 3, MinSegmentHealth: health(0.4)},
+	})
+
+	require.Equal(t, []sla.Summary{
+		{Placement: 0, Days: 1, Probes: 100, Availability: 1, MaxRepairQueueSegments: 1},
+		{Placement: 10, Days: 2, Probes: 200, FailedProbes: 10, Availability: 0.95, MaxRepairQueueSegments: 5, MinSegmentHealth: health(0.4)},
+	}, summaries)
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package sla tracks the daily availability and durability proxy metrics of
+// placements, which are used to back SLA claims.
+package sla
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"storj.io/common/storj"
+)
+
+// DB stores the daily SLA records of placements.
+//
+// architecture: Database
+type DB interface {
+	// Record adds the sample to the daily record of its placement.
+	Record(ctx context.Context, sample Sample) error
+	// List returns the daily records of all placements for the days in [since, until),
+	// ordered by day and placement.
+	List(ctx context.Context, since, until time.Time) ([]DailyRecord, error)
+}
+
+// Sample is a single measurement of a placement.
+type Sample struct {
+	Day       time.Time
+	Placement storj.PlacementConstraint
+	// Failed is whether the availability probe failed.
+	Failed bool
+	// RepairQueueSegments is the number of segments of the placement waiting for repair.
+	RepairQueueSegments int64
+	// MinSegmentHealth is the lowest health of the segments waiting for repair,
+	// nil when no segment is waiting.
+	MinSegmentHealth *float64
+}
+
+// DailyRecord contains the SLA metrics of a placement for a single UTC day.
+type DailyRecord struct {
+	Day                    time.Time                 `json:"day"`
+	Placement              storj.PlacementConstraint `json:"placement"`
+	Probes                 int64                     `json:"probes"`
+	FailedProbes           int64                     `json:"failedProbes"`
+	MaxRepairQueueSegments int64                     `json:"maxRepairQueueSegments"`
+	MinSegmentHealth       *float64                  `json:"minSegmentHealth"`
+	UpdatedAt              time.Time                 `json:"updatedAt"`
+}
+
+// Availability returns the ratio of successful probes.
+func (record DailyRecord) Availability() float64 {
+	return availability(record.Probes, record.FailedProbes)
+}
+
+// Summary aggregates the daily records of a placement.
+type Summary struct {
+	Placement              storj.PlacementConstraint `json:"placement"`
+	Days                   int                       `json:"days"`
+	Probes                 int64                     `json:"probes"`
+	FailedProbes           int64                     `json:"failedProbes"`
+	Availability           float64                   `json:"availability"`
+	MaxRepairQueueSegments int64                     `json:"maxRepairQueueSegments"`
+	MinSegmentHealth       *float64                  `json:"minSegmentHealth"`
+}
+
+// Summarize aggregates the daily records per placement. The result is ordered by placement.
+func Summarize(records []DailyRecord) []Summary {
+	byPlacement := map[storj.PlacementConstraint]*Summary{}
+	for _, record := range records {
+		summary, ok := byPlacement[record.Placement]
+		if !ok {
+			summary = &Summary{Placement: record.Placement}
+			byPlacement[record.Placement] = summary
+		}
+
+		summary.Days++
+		summary.Probes += record.Probes
+		summary.FailedProbes += record.FailedProbes
+		if record.MaxRepairQueueSegments > summary.MaxRepairQueueSegments {
+			summary.MaxRepairQueueSegments = record.MaxRepairQueueSegments
+		}
+		if record.MinSegmentHealth != nil && (summary.MinSegmentHealth == nil || *record.MinSegmentHealth < *summary.MinSegmentHealth) {
+			health := *record.MinSegmentHealth
+			summary.MinSegmentHealth = &health
+		}
+	}
+
+	summaries := make([]Summary, 0, len(byPlacement))
+	for _, summary := range byPlacement {
+		summary.Availability = availability(summary.Probes, summary.FailedProbes)
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, k int) bool {
+		return summaries[i].Placement < summaries[k].Placement
+	})
+	return summaries
+}
+
+// Day returns the beginning of the UTC day containing t.
+func Day(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func availability(probes, failed int64) float64 {
+	if probes == 0 {
+		return 0
+	}
+	return float64(probes-failed) / float64(probes)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
+	"storj.io/common/signing"
 	"storj.io/common/storj"
 )
 
@@ -80,15 +81,17 @@ type Payment struct {
 //
 // architecture: Service
 type Service struct {
-	log *zap.Logger
-	db  DB
+	log    *zap.Logger
+	db     DB
+	signer signing.Signer
 }
 
 // NewService returns a new Service.
-func NewService(log *zap.Logger, db DB) *Service {
+func NewService(log *zap.Logger, db DB, signer signing.Signer) *Service {
 	return &Service{
-		log: log,
-		db:  db,
+		log:    log,
+		db:     db,
+		signer: signer,
 	}
 }
 
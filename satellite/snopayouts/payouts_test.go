@@ -4,11 +4,15 @@
 package snopayouts_test
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
 
+	"storj.io/common/identity/testidentity"
+	"storj.io/common/signing"
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
@@ -159,3 +163,69 @@ func TestPayoutDB(t *testing.T) {
 		}
 	}, satellitedbtest.WithSpanner())
 }
+
+func TestPayoutStatement(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		snoPayoutDB := db.SNOPayouts()
+		nodeID := testrand.NodeID()
+
+		satelliteIdentity := testidentity.MustPregeneratedSignedIdentity(0, storj.LatestIDVersion())
+		service := snopayouts.NewService(zaptest.NewLogger(t), snoPayoutDB, signing.SignerFromFullIdentity(satelliteIdentity))
+
+		paystub := snopayouts.Paystub{
+			Period:      "2020-01",
+			NodeID:      nodeID,
+			Codes:       "1",
+			UsageAtRest: 1,
+			CompAtRest:  2,
+			Held:        3,
+			Disposed:    4,
+			Paid:        5,
+		}
+		require.NoError(t, snoPayoutDB.TestCreatePaystub(ctx, paystub))
+
+		for _, payment := range []snopayouts.Payment{
+			{NodeID: nodeID, Period: "2020-01", Amount: 5, Receipt: "receipt-1"},
+			{NodeID: nodeID, Period: "2020-02", Amount: 6, Receipt: "receipt-2"},
+		} {
+			require.NoError(t, snoPayoutDB.TestCreatePayment(ctx, payment))
+		}
+
+		_, err := service.GenerateStatement(ctx, nodeID, "2020-02")
+		require.True(t, snopayouts.ErrNoDataForPeriod.Has(err))
+
+		signed, err := service.GenerateStatement(ctx, nodeID, "2020-01")
+		require.NoError(t, err)
+
+		statement, err := snopayouts.VerifyStatement(ctx, signing.SigneeFromPeerIdentity(satelliteIdentity.PeerIdentity()), nodeID, signed)
+		require.NoError(t, err)
+		require.Equal(t, satelliteIdentity.ID, statement.SatelliteID)
+		require.Equal(t, nodeID, statement.NodeID)
+		require.Equal(t, "2020-01", statement.Period)
+
+		statement.Paystub.Created = time.Time{} // created is chosen by the database layer
+		require.Equal(t, paystub, statement.Paystub)
+		require.Len(t, statement.Payments, 1)
+		require.Equal(t, "receipt-1", statement.Payments[0].Receipt)
+
+		t.Run("tampered", func(t *testing.T) {
+			tampered := signed
+			tampered.Statement = bytes.Replace(signed.Statement, []byte(`"amount":5`), []byte(`"amount":500`), 1)
+			require.NotEqual(t, signed.Statement, tampered.Statement)
+
+			_, err := snopayouts.VerifyStatement(ctx, signing.SigneeFromPeerIdentity(satelliteIdentity.PeerIdentity()), nodeID, tampered)
+			require.Error(t, err)
+		})
+
+		t.Run("different satellite", func(t *testing.T) {
+			otherIdentity := testidentity.MustPregeneratedSignedIdentity(1, storj.LatestIDVersion())
+			_, err := snopayouts.VerifyStatement(ctx, signing.SigneeFromPeerIdentity(otherIdentity.PeerIdentity()), nodeID, signed)
+			require.Error(t, err)
+		})
+
+		t.Run("different node", func(t *testing.T) {
+			_, err := snopayouts.VerifyStatement(ctx, signing.SigneeFromPeerIdentity(satelliteIdentity.PeerIdentity()), testrand.NodeID(), signed)
+			require.Error(t, err)
+		})
+	}, satellitedbtest.WithSpanner())
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package snopayouts
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/signing"
+	"storj.io/common/storj"
+	"storj.io/storj/shared/payoutstatement"
+)
+
+// Statement is the payout statement of a node for a specific period.
+type Statement struct {
+	payoutstatement.Header
+
+	// Paystub contains the usage, rates, held and disposed amounts of the period.
+	Paystub Paystub `json:"paystub"`
+	// Payments contains the payments, with transaction references, made for the period.
+	Payments []Payment `json:"payments"`
+}
+
+// GenerateStatement generates a signed payout statement for the node in the specified period.
+func (service *Service) GenerateStatement(ctx context.Context, nodeID storj.NodeID, period string) (_ payoutstatement.Signed, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	paystub, err := service.db.GetPaystub(ctx, nodeID, period)
+	if err != nil {
+		return payoutstatement.Signed{}, Error.Wrap(err)
+	}
+
+	allPayments, err := service.db.GetAllPayments(ctx, nodeID)
+	if err != nil {
+		return payoutstatement.Signed{}, Error.Wrap(err)
+	}
+
+	payments := []Payment{}
+	for _, payment := range allPayments {
+		if payment.Period == period {
+			payments = append(payments, payment)
+		}
+	}
+
+	signed, err := payoutstatement.Sign(ctx, service.signer, Statement{
+		Header: payoutstatement.Header{
+			SatelliteID: service.signer.ID(),
+			NodeID:      nodeID,
+			Period:      period,
+			Generated:   time.Now().UTC(),
+		},
+		Paystub:  paystub,
+		Payments: payments,
+	})
+	if err != nil {
+		return payoutstatement.Signed{}, Error.Wrap(err)
+	}
+	return signed, nil
+}
+
+// VerifyStatement verifies that the statement was signed by the satellite for the node and returns its content.
+func VerifyStatement(ctx context.Context, satellite signing.Signee, nodeID storj.NodeID, signed payoutstatement.Signed) (_ Statement, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var statement Statement
+	if _, err := payoutstatement.Verify(ctx, satellite, nodeID, signed, &statement); err != nil {
+		return Statement{}, Error.Wrap(err)
+	}
+	return statement, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uplinktelemetry
+
+import (
+	"storj.io/common/storj"
+)
+
+const (
+	// SchemaVersion1 is the first version of the telemetry report schema.
+	SchemaVersion1 = 1
+
+	// LatestSchemaVersion is the latest version of the telemetry report
+	// schema supported by the satellite.
+	LatestSchemaVersion = SchemaVersion1
+)
+
+// maxTransferCount is the maximum number of transfers accepted per node and
+// counter in a single report.
+const maxTransferCount = 1 << 40
+
+// Report is an anonymized summary of the transfers made by an uplink or a
+// gateway, submitted voluntarily by the client.
+//
+// Reports don't contain any information about the client, the project or the
+// transferred data, only the outcome of the transfers per storage node.
+type Report struct {
+	// Version is the schema version of the report. Reports with an unknown
+	// version are rejected, so the schema can evolve without misinterpreting
+	// the submitted values.
+	Version int `json:"version"`
+
+	// UserAgent identifies the client software, e.g. "uplink/v1.110.0".
+	UserAgent string `json:"userAgent"`
+
+	Nodes []NodeReport `json:"nodes"`
+}
+
+// NodeReport contains the transfer statistics of a single storage node.
+type NodeReport struct {
+	NodeID storj.NodeID `json:"nodeId"`
+
+	UploadSuccesses int64 `json:"uploadSuccesses"`
+	UploadFailures  int64 `json:"uploadFailures"`
+	// UploadLongTailCanceled is the number of uploads canceled because
+	// enough other nodes finished first.
+	UploadLongTailCanceled int64 `json:"uploadLongTailCanceled"`
+
+	DownloadSuccesses        int64 `json:"downloadSuccesses"`
+	DownloadFailures         int64 `json:"downloadFailures"`
+	DownloadLongTailCanceled int64 `json:"downloadLongTailCanceled"`
+
+	// DialLatencyMillis is the average time it took to dial the node.
+	// Zero means that the latency wasn't measured.
+	DialLatencyMillis float64 `json:"dialLatencyMillis"`
+}
+
+// Verify verifies the report fields.
+func (report *Report) Verify(maxNodes int) error {
+	switch {
+	case report.Version <= 0:
+		return ErrInvalidReport.New("version missing")
+	case report.Version > LatestSchemaVersion:
+		return ErrInvalidReport.New("unsupported version %d, latest supported version is %d", report.Version, LatestSchemaVersion)
+	case len(report.Nodes) == 0:
+		return ErrInvalidReport.New("nodes missing")
+	case len(report.Nodes) > maxNodes:
+		return ErrInvalidReport.New("too many nodes %d, maximum is %d", len(report.Nodes), maxNodes)
+	}
+
+	seen := make(map[storj.NodeID]struct{}, len(report.Nodes))
+	for i, node := range report.Nodes {
+		if node.NodeID.IsZero() {
+			return ErrInvalidReport.New("nodes[%d]: node ID missing", i)
+		}
+		if _, ok := seen[node.NodeID]; ok {
+			return ErrInvalidReport.New("nodes[%d]: duplicate node ID %s", i, node.NodeID)
+		}
+		seen[node.NodeID] = struct{}{}
+
+		if node.UploadSuccesses < 0 || node.UploadFailures < 0 || node.UploadLongTailCanceled < 0 ||
+			node.DownloadSuccesses < 0 || node.DownloadFailures < 0 || node.DownloadLongTailCanceled < 0 {
+			return ErrInvalidReport.New("nodes[%d]: negative transfer count", i)
+		}
+		if node.UploadSuccesses > maxTransferCount || node.UploadFailures > maxTransferCount || node.UploadLongTailCanceled > maxTransferCount ||
+			node.DownloadSuccesses > maxTransferCount || node.DownloadFailures > maxTransferCount || node.DownloadLongTailCanceled > maxTransferCount {
+			return ErrInvalidReport.New("nodes[%d]: transfer count is too large", i)
+		}
+		if node.DialLatencyMillis < 0 {
+			return ErrInvalidReport.New("nodes[%d]: negative dial latency", i)
+		}
+	}
+
+	return nil
+}
+
+// successes returns the number of successful transfers of the node.
+func (node *NodeReport) successes() int64 {
+	return node.UploadSuccesses + node.DownloadSuccesses
+}
+
+// failures returns the number of unsuccessful transfers of the node. Long tail
+// cancellations are counted as failures, the same way as when the satellite
+// sees them while committing a segment.
+func (node *NodeReport) failures() int64 {
+	return node.UploadFailures + node.UploadLongTailCanceled +
+		node.DownloadFailures + node.DownloadLongTailCanceled
+}
@@ -0,0 +1,273 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package uplinktelemetry receives opt-in transfer telemetry from uplinks and gateways.
+package uplinktelemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/common/errs2"
+	"storj.io/common/macaroon"
+	"storj.io/common/memory"
+	"storj.io/common/uuid"
+	"storj.io/storj/private/web"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/revocation"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the error class for this package.
+	Error = errs.Class("uplinktelemetry")
+
+	// ErrInvalidReport is returned when the submitted report is malformed.
+	ErrInvalidReport = errs.Class("invalid telemetry report")
+
+	// ErrUnauthorized is returned when the report isn't signed with a valid API key.
+	ErrUnauthorized = errs.Class("unauthorized telemetry report")
+)
+
+// maxTrackerIncrements is the maximum number of results a single report can
+// add to the success tracker per node, so a single client can't dominate it.
+const maxTrackerIncrements = 16
+
+// Config contains configurable values for the uplink telemetry endpoint.
+type Config struct {
+	Enabled              bool          `help:"whether the uplink telemetry ingestion endpoint is enabled" default:"false"`
+	Address              string        `help:"the address to listen on for uplink telemetry reports" default:":10600" testDefault:"127.0.0.1:0"`
+	MaxReportSize        memory.Size   `help:"maximum size of a single telemetry report" default:"256KiB"`
+	MaxNodesPerReport    int           `help:"maximum number of nodes in a single telemetry report" default:"1000"`
+	UpdateSuccessTracker bool          `help:"whether the reported transfer results are added to the success tracker of the reporting project" default:"false"`
+	MaxReporters         int           `help:"maximum number of reporting projects with their own success tracker" default:"10000"`
+	TrackerTickDuration  time.Duration `help:"how often to bump the generation of the success trackers of the reporting projects" default:"10m"`
+
+	RateLimit web.RateLimiterConfig
+}
+
+// APIKeys is the subset of the API keys database used to authenticate the reports.
+type APIKeys interface {
+	GetByHead(ctx context.Context, head []byte) (*console.APIKeyInfo, error)
+}
+
+// Server handles the telemetry reports submitted by uplinks.
+//
+// architecture: Endpoint
+type Server struct {
+	log         *zap.Logger
+	config      Config
+	apiKeys     APIKeys
+	revocations revocation.DB
+	trackers    *Trackers
+
+	rateLimiter *web.RateLimiter
+
+	listener net.Listener
+	server   http.Server
+}
+
+// NewServer creates a new uplink telemetry server. The trackers can be nil,
+// in which case the reports are only used for metrics.
+func NewServer(log *zap.Logger, listener net.Listener, apiKeys APIKeys, revocations revocation.DB, trackers *Trackers, config Config) *Server {
+	srv := &Server{
+		log:         log,
+		config:      config,
+		apiKeys:     apiKeys,
+		revocations: revocations,
+		trackers:    trackers,
+		rateLimiter: web.NewIPRateLimiter(config.RateLimit, log),
+		listener:    listener,
+	}
+
+	router := mux.NewRouter()
+	router.Handle("/v1/telemetry", srv.rateLimiter.Limit(http.HandlerFunc(srv.handleReport))).Methods(http.MethodPost)
+
+	srv.server = http.Server{
+		Handler: router,
+	}
+
+	return srv
+}
+
+func (s *Server) handleReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	reporter, err := s.authenticate(ctx, r)
+	if err != nil {
+		mon.Event("uplink_telemetry_report_unauthorized")
+		web.ServeJSONError(ctx, s.log, w, http.StatusUnauthorized, err)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, s.config.MaxReportSize.Int64())
+
+	var report Report
+	if err = json.NewDecoder(r.Body).Decode(&report); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			mon.Event("uplink_telemetry_report_too_large")
+			web.ServeJSONError(ctx, s.log, w, http.StatusRequestEntityTooLarge, ErrInvalidReport.New("report is larger than %s", s.config.MaxReportSize))
+			return
+		}
+		mon.Event("uplink_telemetry_report_malformed")
+		web.ServeJSONError(ctx, s.log, w, http.StatusBadRequest, ErrInvalidReport.Wrap(err))
+		return
+	}
+
+	if err = report.Verify(s.config.MaxNodesPerReport); err != nil {
+		mon.Event("uplink_telemetry_report_invalid")
+		web.ServeJSONError(ctx, s.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.Ingest(ctx, reporter, report)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authenticate verifies the API key in the Authorization header and returns
+// the ID of the project the key belongs to.
+func (s *Server) authenticate(ctx context.Context, r *http.Request) (_ uuid.UUID, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	serialized, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || serialized == "" {
+		return uuid.UUID{}, ErrUnauthorized.New("API key missing")
+	}
+
+	key, err := macaroon.ParseAPIKey(serialized)
+	if err != nil {
+		return uuid.UUID{}, ErrUnauthorized.Wrap(err)
+	}
+
+	info, err := s.apiKeys.GetByHead(ctx, key.Head())
+	if err != nil {
+		return uuid.UUID{}, ErrUnauthorized.New("invalid API key")
+	}
+
+	err = key.Check(ctx, info.Secret, info.Version, macaroon.Action{
+		Op:   macaroon.ActionProjectInfo,
+		Time: time.Now(),
+	}, s.revocations)
+	if err != nil {
+		return uuid.UUID{}, ErrUnauthorized.Wrap(err)
+	}
+
+	return info.ProjectID, nil
+}
+
+// Ingest processes a verified report of the reporter project.
+func (s *Server) Ingest(ctx context.Context, reporter uuid.UUID, report Report) {
+	defer mon.Task()(&ctx)(nil)
+
+	var tracker SuccessTracker
+	if s.trackers != nil {
+		tracker = s.trackers.ensure(reporter)
+		if tracker == nil {
+			mon.Event("uplink_telemetry_too_many_reporters")
+		}
+	}
+
+	versionTag := monkit.NewSeriesTag("version", strconv.Itoa(report.Version))
+	mon.Meter("uplink_telemetry_reports", versionTag).Mark(1)
+	mon.IntVal("uplink_telemetry_report_nodes").Observe(int64(len(report.Nodes)))
+
+	for _, node := range report.Nodes {
+		mon.Counter("uplink_telemetry_upload_successes").Inc(node.UploadSuccesses)
+		mon.Counter("uplink_telemetry_upload_failures").Inc(node.UploadFailures)
+		mon.Counter("uplink_telemetry_upload_long_tail_canceled").Inc(node.UploadLongTailCanceled)
+		mon.Counter("uplink_telemetry_download_successes").Inc(node.DownloadSuccesses)
+		mon.Counter("uplink_telemetry_download_failures").Inc(node.DownloadFailures)
+		mon.Counter("uplink_telemetry_download_long_tail_canceled").Inc(node.DownloadLongTailCanceled)
+		if node.DialLatencyMillis > 0 {
+			mon.FloatVal("uplink_telemetry_dial_latency_ms").Observe(node.DialLatencyMillis)
+		}
+
+		if tracker != nil {
+			successes, failures := trackerIncrements(node.successes(), node.failures())
+			for i := int64(0); i < successes; i++ {
+				tracker.Increment(node.NodeID, true)
+			}
+			for i := int64(0); i < failures; i++ {
+				tracker.Increment(node.NodeID, false)
+			}
+		}
+	}
+}
+
+// trackerIncrements scales down the reported results to at most
+// maxTrackerIncrements, keeping the success rate.
+func trackerIncrements(successes, failures int64) (int64, int64) {
+	total := successes + failures
+	if total <= maxTrackerIncrements {
+		return successes, failures
+	}
+
+	scaledSuccesses := (successes*maxTrackerIncrements + total/2) / total
+	return scaledSuccesses, maxTrackerIncrements - scaledSuccesses
+}
+
+// Run starts the uplink telemetry server.
+func (s *Server) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	var group errgroup.Group
+	group.Go(func() error {
+		s.rateLimiter.Run(ctx)
+		return nil
+	})
+	if s.trackers != nil {
+		group.Go(func() error {
+			ticker := time.NewTicker(s.config.TrackerTickDuration)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+					s.trackers.BumpGeneration()
+				}
+			}
+		})
+	}
+	group.Go(func() error {
+		<-ctx.Done()
+		return s.server.Shutdown(context.Background())
+	})
+	group.Go(func() error {
+		defer cancel()
+		err := s.server.Serve(s.listener)
+		if errs2.IsCanceled(err) || errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+		return err
+	})
+
+	return group.Wait()
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	return s.server.Close()
+}
+
+// TestGetAddress returns the address of this server for tests.
+func (s *Server) TestGetAddress() string {
+	return s.listener.Addr().String()
+}
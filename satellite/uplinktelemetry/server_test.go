@@ -0,0 +1,136 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uplinktelemetry_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/uplinktelemetry"
+)
+
+func TestUplinkTelemetry(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.UplinkTelemetry.Enabled = true
+				config.UplinkTelemetry.UpdateSuccessTracker = true
+				config.UplinkTelemetry.MaxNodesPerReport = 2
+				config.UplinkTelemetry.RateLimit.Burst = 100
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		url := "http://" + sat.API.UplinkTelemetry.Server.TestGetAddress() + "/v1/telemetry"
+		apiKey := planet.Uplinks[0].APIKey[sat.ID()]
+		projectID := planet.Uplinks[0].Projects[0].ID
+
+		submitWithKey := func(t *testing.T, key string, body any) int {
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			if key != "" {
+				request.Header.Set("Authorization", "Bearer "+key)
+			}
+
+			resp, err := http.DefaultClient.Do(request)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			return resp.StatusCode
+		}
+		submit := func(t *testing.T, body any) int {
+			return submitWithKey(t, apiKey.Serialize(), body)
+		}
+
+		goodNode, badNode := testrand.NodeID(), testrand.NodeID()
+
+		t.Run("unauthorized", func(t *testing.T) {
+			report := uplinktelemetry.Report{
+				Version: uplinktelemetry.SchemaVersion1,
+				Nodes:   []uplinktelemetry.NodeReport{{NodeID: badNode, UploadFailures: 100}},
+			}
+			require.Equal(t, http.StatusUnauthorized, submitWithKey(t, "", report))
+			require.Equal(t, http.StatusUnauthorized, submitWithKey(t, "not an API key", report))
+
+			restricted, err := apiKey.Restrict(macaroon.Caveat{NotAfter: &time.Time{}})
+			require.NoError(t, err)
+			require.Equal(t, http.StatusUnauthorized, submitWithKey(t, restricted.Serialize(), report))
+
+			require.Nil(t, sat.API.UplinkTelemetry.Trackers.GetTracker(projectID))
+		})
+
+		t.Run("invalid", func(t *testing.T) {
+			require.Equal(t, http.StatusBadRequest, submit(t, "not a report"))
+			require.Equal(t, http.StatusBadRequest, submit(t, uplinktelemetry.Report{
+				Nodes: []uplinktelemetry.NodeReport{{NodeID: goodNode}},
+			}))
+			require.Equal(t, http.StatusBadRequest, submit(t, uplinktelemetry.Report{
+				Version: uplinktelemetry.LatestSchemaVersion + 1,
+				Nodes:   []uplinktelemetry.NodeReport{{NodeID: goodNode}},
+			}))
+			require.Equal(t, http.StatusBadRequest, submit(t, uplinktelemetry.Report{
+				Version: uplinktelemetry.SchemaVersion1,
+				Nodes:   []uplinktelemetry.NodeReport{{NodeID: goodNode}, {NodeID: goodNode}},
+			}))
+			require.Equal(t, http.StatusBadRequest, submit(t, uplinktelemetry.Report{
+				Version: uplinktelemetry.SchemaVersion1,
+				Nodes:   []uplinktelemetry.NodeReport{{NodeID: goodNode}, {NodeID: badNode}, {NodeID: testrand.NodeID()}},
+			}))
+			require.Equal(t, http.StatusBadRequest, submit(t, uplinktelemetry.Report{
+				Version: uplinktelemetry.SchemaVersion1,
+				Nodes:   []uplinktelemetry.NodeReport{{NodeID: goodNode, UploadFailures: -1}},
+			}))
+
+			request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(request)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+		})
+
+		t.Run("success tracker", func(t *testing.T) {
+			require.Equal(t, http.StatusNoContent, submit(t, uplinktelemetry.Report{
+				Version:   uplinktelemetry.SchemaVersion1,
+				UserAgent: "uplink/v1.110.0",
+				Nodes: []uplinktelemetry.NodeReport{
+					{
+						NodeID:            goodNode,
+						UploadSuccesses:   1000,
+						DownloadSuccesses: 1000,
+						DialLatencyMillis: 15,
+					},
+					{
+						NodeID:                 badNode,
+						UploadSuccesses:        10,
+						UploadLongTailCanceled: 50,
+						DownloadFailures:       40,
+					},
+				},
+			}))
+
+			tracker := sat.API.UplinkTelemetry.Trackers.GetTracker(projectID)
+			require.NotNil(t, tracker)
+			require.Greater(t, tracker.Get(goodNode), tracker.Get(badNode))
+
+			// the reports don't change the scores seen by other uplinks.
+			require.True(t, math.IsNaN(sat.API.SuccessTrackers.Global().Get(badNode)))
+		})
+	})
+}
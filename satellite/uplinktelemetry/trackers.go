@@ -0,0 +1,75 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uplinktelemetry
+
+import (
+	"sync"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+)
+
+// SuccessTracker is the node success tracker updated with the reports.
+type SuccessTracker interface {
+	Increment(node storj.NodeID, success bool)
+	Get(node storj.NodeID) float64
+	BumpGeneration()
+}
+
+// Trackers keeps a separate success tracker for every reporting project, the
+// same way as metainfo keeps separate trackers for the trusted uplinks. The
+// reports of a project never change the node scores seen by other projects.
+type Trackers struct {
+	newTracker   func() SuccessTracker
+	maxReporters int
+
+	mu       sync.Mutex
+	trackers map[uuid.UUID]SuccessTracker
+}
+
+// NewTrackers creates a new set of per-reporter trackers, which holds at most
+// maxReporters trackers.
+func NewTrackers(newTracker func() SuccessTracker, maxReporters int) *Trackers {
+	return &Trackers{
+		newTracker:   newTracker,
+		maxReporters: maxReporters,
+		trackers:     make(map[uuid.UUID]SuccessTracker),
+	}
+}
+
+// GetTracker returns the tracker of the reporter or nil, when the reporter
+// hasn't submitted any reports.
+func (t *Trackers) GetTracker(reporter uuid.UUID) SuccessTracker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.trackers[reporter]
+}
+
+// ensure returns the tracker of the reporter, creating it when needed. It
+// returns nil when the maximum number of reporters is reached.
+func (t *Trackers) ensure(reporter uuid.UUID) SuccessTracker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tracker, ok := t.trackers[reporter]
+	if !ok {
+		if len(t.trackers) >= t.maxReporters {
+			return nil
+		}
+		tracker = t.newTracker()
+		t.trackers[reporter] = tracker
+	}
+	return tracker
+}
+
+// BumpGeneration bumps the generation of all the trackers.
+func (t *Trackers) BumpGeneration() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, tracker := range t.trackers {
+		tracker.BumpGeneration()
+	}
+}
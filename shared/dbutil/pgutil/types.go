@@ -83,6 +83,21 @@ func TextArray(stringSlice []string) *pgtype.TextArray {
 	}
 }
 
+// BoolArray returns an object usable by pg drivers for passing a []bool slice
+// into a database as type BOOL[].
+func BoolArray(bools []bool) *pgtype.BoolArray {
+	pgtypeBoolArray := make([]pgtype.Bool, len(bools))
+	for i, someBool := range bools {
+		pgtypeBoolArray[i].Bool = someBool
+		pgtypeBoolArray[i].Status = pgtype.Present
+	}
+	return &pgtype.BoolArray{
+		Elements:   pgtypeBoolArray,
+		Dimensions: []pgtype.ArrayDimension{{Length: int32(len(bools)), LowerBound: 1}},
+		Status:     pgtype.Present,
+	}
+}
+
 // TimestampTZArray returns an object usable by pg drivers for passing a []time.Time
 // slice into a database as type TIMESTAMPTZ[].
 func TimestampTZArray(timeSlice []time.Time) *pgtype.TimestamptzArray {
@@ -92,3 +92,15 @@ func IsConstraintError(err error) bool {
 		return false
 	})
 }
+
+// IsCorruptError checks if given error indicates that the database file is corrupt.
+func IsCorruptError(err error) bool {
+	return errs.IsFunc(err, func(err error) bool {
+		if e, ok := err.(sqlite3.Error); ok { //nolint: errorlint // IsFunc implements the unwrap loop.
+			if e.Code == sqlite3.ErrCorrupt || e.Code == sqlite3.ErrNotADB {
+				return true
+			}
+		}
+		return false
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package payoutstatement implements the payout statements, which are signed
+// by the satellite and verified by the storage nodes.
+package payoutstatement
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/signing"
+	"storj.io/common/storj"
+)
+
+// Error is the default error class for payout statements.
+var Error = errs.Class("payout statement")
+
+// Header contains the fields of a statement, which identify who issued it,
+// for which node and for which period.
+type Header struct {
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	NodeID      storj.NodeID `json:"nodeId"`
+	Period      string       `json:"period"`
+	Generated   time.Time    `json:"generated"`
+}
+
+// Signed is a statement signed by the satellite.
+type Signed struct {
+	// Statement is the JSON encoded statement, which is used for the signature.
+	// It must start with the fields of Header.
+	Statement json.RawMessage `json:"statement"`
+	Signature []byte          `json:"signature"`
+}
+
+// Sign encodes the statement as JSON and signs it.
+func Sign(ctx context.Context, satellite signing.Signer, statement interface{}) (_ Signed, err error) {
+	data, err := json.Marshal(statement)
+	if err != nil {
+		return Signed{}, Error.Wrap(err)
+	}
+
+	signature, err := satellite.HashAndSign(ctx, data)
+	if err != nil {
+		return Signed{}, Error.Wrap(err)
+	}
+
+	return Signed{
+		Statement: data,
+		Signature: signature,
+	}, nil
+}
+
+// Verify verifies that the statement was signed by the satellite for the node
+// and decodes it into statement, when it's not nil.
+func Verify(ctx context.Context, satellite signing.Signee, nodeID storj.NodeID, signed Signed, statement interface{}) (_ Header, err error) {
+	if err := satellite.HashAndVerifySignature(ctx, signed.Statement, signed.Signature); err != nil {
+		return Header{}, Error.Wrap(err)
+	}
+
+	var header Header
+	if err := json.Unmarshal(signed.Statement, &header); err != nil {
+		return Header{}, Error.Wrap(err)
+	}
+	if header.SatelliteID != satellite.ID() {
+		return Header{}, Error.New("statement is issued by a different satellite: %s", header.SatelliteID)
+	}
+	if header.NodeID != nodeID {
+		return Header{}, Error.New("statement is issued for a different node: %s", header.NodeID)
+	}
+
+	if statement != nil {
+		if err := json.Unmarshal(signed.Statement, statement); err != nil {
+			return Header{}, Error.Wrap(err)
+		}
+	}
+
+	return header, nil
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payoutstatement_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/identity/testidentity"
+	"storj.io/common/signing"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/shared/payoutstatement"
+)
+
+func TestSignVerify(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	satellite := testidentity.MustPregeneratedSignedIdentity(0, storj.LatestIDVersion())
+	other := testidentity.MustPregeneratedSignedIdentity(1, storj.LatestIDVersion())
+	nodeID := testrand.NodeID()
+
+	type statement struct {
+		payoutstatement.Header
+		Amount int64 `json:"amount"`
+	}
+
+	expected := statement{
+		Header: payoutstatement.Header{
+			SatelliteID: satellite.ID,
+			NodeID:      nodeID,
+			Period:      "2020-01",
+			Generated:   time.Now().UTC().Truncate(time.Second),
+		},
+		Amount: 5,
+	}
+
+	signed, err := payoutstatement.Sign(ctx, signing.SignerFromFullIdentity(satellite), expected)
+	require.NoError(t, err)
+
+	var decoded statement
+	header, err := payoutstatement.Verify(ctx, signing.SigneeFromPeerIdentity(satellite.PeerIdentity()), nodeID, signed, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, expected.Header, header)
+	require.Equal(t, expected, decoded)
+
+	t.Run("tampered", func(t *testing.T) {
+		tampered := signed
+		tampered.Statement = bytes.Replace(signed.Statement, []byte(`"amount":5`), []byte(`"amount":500`), 1)
+		require.NotEqual(t, signed.Statement, tampered.Statement)
+
+		_, err := payoutstatement.Verify(ctx, signing.SigneeFromPeerIdentity(satellite.PeerIdentity()), nodeID, tampered, nil)
+		require.True(t, payoutstatement.Error.Has(err), err)
+	})
+
+	t.Run("different satellite", func(t *testing.T) {
+		_, err := payoutstatement.Verify(ctx, signing.SigneeFromPeerIdentity(other.PeerIdentity()), nodeID, signed, nil)
+		require.True(t, payoutstatement.Error.Has(err), err)
+	})
+
+	t.Run("different node", func(t *testing.T) {
+		_, err := payoutstatement.Verify(ctx, signing.SigneeFromPeerIdentity(satellite.PeerIdentity()), testrand.NodeID(), signed, nil)
+		require.True(t, payoutstatement.Error.Has(err), err)
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package throttle implements the format of the throttling details that are sent
+// with ResourceExhausted errors. It has no satellite dependencies, so clients can
+// parse the errors without importing the satellite.
+package throttle
+
+import (
+	"strings"
+	"time"
+
+	"storj.io/common/errs2"
+	"storj.io/common/rpc/rpcstatus"
+)
+
+// Kind describes which limit caused a request to be rejected.
+type Kind string
+
+const (
+	// KindRate is used when the request rate limit of the project was exceeded.
+	KindRate Kind = "rate"
+	// KindObjectUploadRate is used when the same object location is uploaded too often.
+	KindObjectUploadRate Kind = "object-upload-rate"
+	// KindBandwidth is used when the monthly bandwidth limit of the project was exceeded.
+	KindBandwidth Kind = "bandwidth"
+	// KindStorage is used when the storage limit of the project was exceeded.
+	KindStorage Kind = "storage"
+	// KindSegments is used when the segment limit of the project was exceeded.
+	KindSegments Kind = "segments"
+	// KindDeleteRate is used when the project deleted too many objects in a short time.
+	KindDeleteRate Kind = "delete-rate"
+)
+
+const (
+	kindField       = "limit-kind="
+	retryAfterField = "retry-after="
+)
+
+// Info contains the machine-readable details of a throttling error.
+type Info struct {
+	Kind Kind
+	// RetryAfter is how long the client should wait before retrying. Zero means
+	// that the limit won't be lifted by waiting and the request should not be retried.
+	RetryAfter time.Duration
+}
+
+// Error returns a ResourceExhausted error with the throttling details.
+//
+// The details are prepended to the message, because older clients match
+// the end of the message (e.g. "Too Many Requests") to detect the error type.
+func Error(kind Kind, retryAfter time.Duration, message string) error {
+	details := kindField + string(kind)
+	if retryAfter > 0 {
+		details += " " + retryAfterField + retryAfter.Round(time.Millisecond).String()
+	}
+	return rpcstatus.Error(rpcstatus.ResourceExhausted, details+": "+message)
+}
+
+// Parse extracts the throttling details from an error created with Error.
+func Parse(err error) (info Info, ok bool) {
+	if err == nil || !errs2.IsRPC(err, rpcstatus.ResourceExhausted) {
+		return Info{}, false
+	}
+
+	message := err.Error()
+	start := strings.Index(message, kindField)
+	if start < 0 {
+		return Info{}, false
+	}
+	details, _, _ := strings.Cut(message[start:], ":")
+
+	for _, field := range strings.Fields(details) {
+		switch {
+		case strings.HasPrefix(field, kindField):
+			info.Kind = Kind(strings.TrimPrefix(field, kindField))
+		case strings.HasPrefix(field, retryAfterField):
+			retryAfter, err := time.ParseDuration(strings.TrimPrefix(field, retryAfterField))
+			if err != nil {
+				return Info{}, false
+			}
+			info.RetryAfter = retryAfter
+		}
+	}
+	return info, info.Kind != ""
+}
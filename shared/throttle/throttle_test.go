@@ -0,0 +1,45 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package throttle_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/errs2"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/storj/shared/throttle"
+)
+
+func TestErrorAndParse(t *testing.T) {
+	err := throttle.Error(throttle.KindRate, 1500*time.Millisecond, "Too Many Requests")
+	require.True(t, errs2.IsRPC(err, rpcstatus.ResourceExhausted))
+	// older clients detect the error type by the end of the message.
+	require.True(t, strings.HasSuffix(err.Error(), "Too Many Requests"))
+
+	info, ok := throttle.Parse(err)
+	require.True(t, ok)
+	require.Equal(t, throttle.Info{Kind: throttle.KindRate, RetryAfter: 1500 * time.Millisecond}, info)
+
+	err = throttle.Error(throttle.KindStorage, 0, "Exceeded Storage Limit")
+	require.True(t, strings.HasSuffix(err.Error(), "Exceeded Storage Limit"))
+
+	info, ok = throttle.Parse(err)
+	require.True(t, ok)
+	require.Equal(t, throttle.Info{Kind: throttle.KindStorage}, info)
+
+	for _, err := range []error{
+		nil,
+		errors.New("limit-kind=rate: Too Many Requests"),
+		rpcstatus.Error(rpcstatus.ResourceExhausted, "Too Many Requests"),
+		rpcstatus.Error(rpcstatus.ResourceExhausted, "limit-kind=rate retry-after=invalid: Too Many Requests"),
+	} {
+		_, ok := throttle.Parse(err)
+		require.False(t, ok)
+	}
+}
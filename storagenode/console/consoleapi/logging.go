@@ -0,0 +1,127 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"storj.io/storj/storagenode/logging"
+)
+
+// ErrLoggingAPI - console logging api error type.
+var ErrLoggingAPI = errs.Class("consoleapi logging")
+
+// Logging is an api controller that shows and changes the log levels of the subsystems.
+type Logging struct {
+	log    *zap.Logger
+	levels *logging.Levels
+}
+
+// NewLogging is a constructor for logging controller. levels is nil when the
+// subsystem logging isn't enabled.
+func NewLogging(log *zap.Logger, levels *logging.Levels) *Logging {
+	return &Logging{
+		log:    log,
+		levels: levels,
+	}
+}
+
+// Levels returns the log levels of the subsystems.
+func (controller *Logging) Levels(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	if controller.levels == nil {
+		controller.serveJSONError(w, http.StatusNotFound, ErrLoggingAPI.New("subsystem logging is not enabled"))
+		return
+	}
+
+	response := map[string]string{}
+	for _, subsystem := range controller.levels.Subsystems() {
+		level, err := controller.levels.Level(subsystem)
+		if err != nil {
+			controller.serveJSONError(w, http.StatusInternalServerError, ErrLoggingAPI.Wrap(err))
+			return
+		}
+		response[subsystem] = level.String()
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrLoggingAPI.Wrap(err)))
+		return
+	}
+}
+
+// SetLevel changes the log level of a subsystem.
+func (controller *Logging) SetLevel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	if controller.levels == nil {
+		controller.serveJSONError(w, http.StatusNotFound, ErrLoggingAPI.New("subsystem logging is not enabled"))
+		return
+	}
+
+	// the requests must be JSON, so a browser can't send them from another
+	// site without a preflight request, which the dashboard doesn't allow.
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get(contentType))
+	if err != nil || mediaType != applicationJSON {
+		controller.serveJSONError(w, http.StatusUnsupportedMediaType, ErrLoggingAPI.New("expected %s content type", applicationJSON))
+		return
+	}
+
+	var request struct {
+		Level string `json:"level"`
+	}
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controller.serveJSONError(w, http.StatusBadRequest, ErrLoggingAPI.Wrap(err))
+		return
+	}
+
+	var level zapcore.Level
+	if err = level.UnmarshalText([]byte(request.Level)); err != nil {
+		controller.serveJSONError(w, http.StatusBadRequest, ErrLoggingAPI.Wrap(err))
+		return
+	}
+
+	if err = controller.levels.SetLevel(mux.Vars(r)["subsystem"], level); err != nil {
+		status := http.StatusInternalServerError
+		if logging.ErrUnknownSubsystem.Has(err) {
+			status = http.StatusNotFound
+		}
+		controller.serveJSONError(w, status, ErrLoggingAPI.Wrap(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (controller *Logging) serveJSONError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to encode error response", zap.Error(ErrLoggingAPI.Wrap(err)))
+	}
+}
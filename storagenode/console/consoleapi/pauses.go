@@ -0,0 +1,162 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/storagenode/satellitepause"
+	"storj.io/storj/storagenode/trust"
+)
+
+// ErrPausesAPI - console satellite pauses api error type.
+var ErrPausesAPI = errs.Class("consoleapi pauses")
+
+// Pause is the pause of serving a satellite as returned by the API.
+type Pause struct {
+	SatelliteID storj.NodeID `json:"satelliteID"`
+	Downloads   bool         `json:"downloads"`
+	PausedAt    time.Time    `json:"pausedAt"`
+}
+
+// Pauses is an api controller that pauses and resumes serving satellites.
+type Pauses struct {
+	log     *zap.Logger
+	service *satellitepause.Service
+}
+
+// NewPauses is a constructor for satellite pauses controller.
+func NewPauses(log *zap.Logger, service *satellitepause.Service) *Pauses {
+	return &Pauses{
+		log:     log,
+		service: service,
+	}
+}
+
+// ListPauses returns the satellites whose serving is paused.
+func (pauses *Pauses) ListPauses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	list, err := pauses.service.List(ctx)
+	if err != nil {
+		pauses.serveJSONError(w, http.StatusInternalServerError, ErrPausesAPI.Wrap(err))
+		return
+	}
+
+	response := make([]Pause, 0, len(list))
+	for _, pause := range list {
+		response = append(response, Pause{
+			SatelliteID: pause.SatelliteID,
+			Downloads:   pause.Downloads,
+			PausedAt:    pause.PausedAt,
+		})
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		pauses.log.Error("failed to encode json response", zap.Error(ErrPausesAPI.Wrap(err)))
+		return
+	}
+}
+
+// Pause pauses serving the satellite. The uploads are always paused, the
+// downloads are paused when the request asks for it.
+func (pauses *Pauses) Pause(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	satelliteID, ok := pauses.parseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		Downloads bool `json:"downloads"`
+	}
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		pauses.serveJSONError(w, http.StatusBadRequest, ErrPausesAPI.Wrap(err))
+		return
+	}
+
+	if err = pauses.service.Pause(ctx, satelliteID, request.Downloads); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, trust.ErrUntrusted) {
+			status = http.StatusNotFound
+		}
+		pauses.serveJSONError(w, status, ErrPausesAPI.Wrap(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Resume resumes serving the satellite.
+func (pauses *Pauses) Resume(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	satelliteID, ok := pauses.parseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err = pauses.service.Resume(ctx, satelliteID); err != nil {
+		pauses.serveJSONError(w, http.StatusInternalServerError, ErrPausesAPI.Wrap(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseRequest returns the satellite of the request. The requests must be
+// JSON, so a browser can't send them from another site without a preflight
+// request, which the dashboard doesn't allow.
+func (pauses *Pauses) parseRequest(w http.ResponseWriter, r *http.Request) (_ storj.NodeID, ok bool) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get(contentType))
+	if err != nil || mediaType != applicationJSON {
+		pauses.serveJSONError(w, http.StatusUnsupportedMediaType, ErrPausesAPI.New("expected %s content type", applicationJSON))
+		return storj.NodeID{}, false
+	}
+
+	satelliteID, err := storj.NodeIDFromString(mux.Vars(r)["id"])
+	if err != nil {
+		pauses.serveJSONError(w, http.StatusBadRequest, ErrPausesAPI.Wrap(err))
+		return storj.NodeID{}, false
+	}
+	return satelliteID, true
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (pauses *Pauses) serveJSONError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		pauses.log.Error("failed to encode error response", zap.Error(ErrPausesAPI.Wrap(err)))
+	}
+}
@@ -5,6 +5,8 @@ package consoleapi
 
 import (
 	"encoding/json"
+	"fmt"
+	"mime"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -12,12 +14,17 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/common/storj"
+	"storj.io/storj/shared/payoutstatement"
 	"storj.io/storj/storagenode/payouts"
+	"storj.io/storj/storagenode/trust"
 )
 
 // ErrPayoutAPI - console payouts api error type.
 var ErrPayoutAPI = errs.Class("consoleapi payouts")
 
+// maxStatementSize is the maximum size of a signed payout statement.
+const maxStatementSize = 1 << 20
+
 // Payout is an api controller that exposes all payouts related api.
 type Payout struct {
 	service *payouts.Service
@@ -172,6 +179,26 @@ func (payout *Payout) HeldHistory(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// HeldAmountEvents returns held amount dispositions and held rate changes from all satellites.
+func (payout *Payout) HeldAmountEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	events, err := payout.service.HeldAmountEvents(ctx)
+	if err != nil {
+		payout.serveJSONError(w, http.StatusInternalServerError, ErrPayoutAPI.Wrap(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(events); err != nil {
+		payout.log.Error("failed to encode json response", zap.Error(ErrPayoutAPI.Wrap(err)))
+		return
+	}
+}
+
 // PayoutHistory retrieves paystubs for specific period from all satellites and transaction receipts if exists.
 func (payout *Payout) PayoutHistory(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -200,6 +227,86 @@ func (payout *Payout) PayoutHistory(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// PayoutStatement returns the downloadable payout statement of the satellite specified by query parameter id for specific period.
+func (payout *Payout) PayoutStatement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	period, ok := mux.Vars(r)["period"]
+	if !ok {
+		payout.serveJSONError(w, http.StatusBadRequest, ErrPayoutAPI.New("period is missing"))
+		return
+	}
+
+	satelliteID, err := storj.NodeIDFromString(r.URL.Query().Get("id"))
+	if err != nil {
+		payout.serveJSONError(w, http.StatusBadRequest, ErrPayoutAPI.Wrap(err))
+		return
+	}
+
+	statement, err := payout.service.SatellitePayoutStatement(ctx, satelliteID, period)
+	if err != nil {
+		switch {
+		case payouts.ErrBadPeriod.Has(err):
+			payout.serveJSONError(w, http.StatusBadRequest, ErrPayoutAPI.Wrap(err))
+		case payouts.ErrNoPayStubForPeriod.Has(err):
+			payout.serveJSONError(w, http.StatusNotFound, ErrPayoutAPI.Wrap(err))
+		default:
+			payout.serveJSONError(w, http.StatusInternalServerError, ErrPayoutAPI.Wrap(err))
+		}
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "payout-statement-"+satelliteID.String()+"-"+period+".json"))
+	if err := json.NewEncoder(w).Encode(statement); err != nil {
+		payout.log.Error("failed to encode json response", zap.Error(ErrPayoutAPI.Wrap(err)))
+		return
+	}
+}
+
+// VerifyPayoutStatement verifies the payout statement, which was signed by the
+// satellite, and returns its header.
+func (payout *Payout) VerifyPayoutStatement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	// the requests must be JSON, so a browser can't send them from another
+	// site without a preflight request, which the dashboard doesn't allow.
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get(contentType))
+	if err != nil || mediaType != applicationJSON {
+		payout.serveJSONError(w, http.StatusUnsupportedMediaType, ErrPayoutAPI.New("expected %s content type", applicationJSON))
+		return
+	}
+
+	var signed payoutstatement.Signed
+	if err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxStatementSize)).Decode(&signed); err != nil {
+		payout.serveJSONError(w, http.StatusBadRequest, ErrPayoutAPI.Wrap(err))
+		return
+	}
+
+	header, err := payout.service.VerifyPayoutStatement(ctx, signed)
+	if err != nil {
+		switch {
+		case errs.Is(err, trust.ErrUntrusted), payoutstatement.Error.Has(err):
+			payout.serveJSONError(w, http.StatusBadRequest, ErrPayoutAPI.Wrap(err))
+		default:
+			payout.serveJSONError(w, http.StatusInternalServerError, ErrPayoutAPI.Wrap(err))
+		}
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(header); err != nil {
+		payout.log.Error("failed to encode json response", zap.Error(ErrPayoutAPI.Wrap(err)))
+		return
+	}
+}
+
 // HeldAmountPeriods retrieves all periods in which we have some payouts data.
 // Have optional parameter - satelliteID.
 // If satelliteID specified - will retrieve periods only for concrete satellite.
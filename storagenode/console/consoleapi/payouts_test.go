@@ -4,6 +4,7 @@
 package consoleapi_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -14,9 +15,12 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"storj.io/common/signing"
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
 	"storj.io/storj/private/testplanet"
+	"storj.io/storj/shared/payoutstatement"
 	"storj.io/storj/storagenode/payouts"
 	"storj.io/storj/storagenode/reputation"
 )
@@ -442,6 +446,105 @@ func TestHeldAmountApi(t *testing.T) {
 
 				require.Equal(t, string(expected2)+"\n", string(body2))
 			})
+
+			t.Run("PayoutStatement", func(t *testing.T) {
+				err := payoutsDB.StorePayment(ctx, payouts.Payment{
+					SatelliteID: satellite.ID(),
+					Period:      period,
+					Amount:      17,
+					Receipt:     "receipt",
+				})
+				require.NoError(t, err)
+
+				url := fmt.Sprintf("%s/statements/%s?id=%s", baseURL, period, satellite.ID().String())
+				res, err := httpGet(ctx, url)
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				defer func() {
+					err = res.Body.Close()
+					require.NoError(t, err)
+				}()
+				require.Equal(t, http.StatusOK, res.StatusCode)
+				require.Contains(t, res.Header.Get("Content-Disposition"), "attachment")
+
+				var statement payouts.PayoutStatement
+				require.NoError(t, json.NewDecoder(res.Body).Decode(&statement))
+				require.Equal(t, satellite.ID(), statement.SatelliteID)
+				require.Equal(t, satellite.Addr(), statement.SatelliteURL)
+				require.Equal(t, period, statement.Period)
+				require.Equal(t, paystub.Paid, statement.PayStub.Paid)
+				require.Equal(t, paystub.Held, statement.PayStub.Held)
+				require.Equal(t, "receipt", statement.Receipt)
+
+				// should return 404 cause no payouts for the period.
+				url = fmt.Sprintf("%s/statements/%s?id=%s", baseURL, "2020-01", satellite.ID().String())
+				res2, err := httpGet(ctx, url)
+				require.NoError(t, err)
+				require.NotNil(t, res2)
+				defer func() {
+					err = res2.Body.Close()
+					require.NoError(t, err)
+				}()
+				require.Equal(t, http.StatusNotFound, res2.StatusCode)
+
+				// should return 400 cause of wrong satellite id.
+				url = fmt.Sprintf("%s/statements/%s?id=%s", baseURL, period, "123")
+				res3, err := httpGet(ctx, url)
+				require.NoError(t, err)
+				require.NotNil(t, res3)
+				defer func() {
+					err = res3.Body.Close()
+					require.NoError(t, err)
+				}()
+				require.Equal(t, http.StatusBadRequest, res3.StatusCode)
+			})
+
+			t.Run("VerifyPayoutStatement", func(t *testing.T) {
+				verify := func(statement payoutstatement.Header, tamper bool) *http.Response {
+					signed, err := payoutstatement.Sign(ctx, signing.SignerFromFullIdentity(satellite.Identity), statement)
+					require.NoError(t, err)
+					if tamper {
+						signed.Statement = bytes.Replace(signed.Statement, []byte(period), []byte("2020-01"), 1)
+					}
+
+					body, err := json.Marshal(signed)
+					require.NoError(t, err)
+
+					req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/statements/verify", bytes.NewReader(body))
+					require.NoError(t, err)
+					req.Header.Set("Content-Type", "application/json")
+
+					res, err := http.DefaultClient.Do(req)
+					require.NoError(t, err)
+					return res
+				}
+
+				statement := payoutstatement.Header{
+					SatelliteID: satellite.ID(),
+					NodeID:      sno.ID(),
+					Period:      period,
+					Generated:   time.Now().UTC().Truncate(time.Second),
+				}
+
+				res := verify(statement, false)
+				defer func() { require.NoError(t, res.Body.Close()) }()
+				require.Equal(t, http.StatusOK, res.StatusCode)
+
+				var header payoutstatement.Header
+				require.NoError(t, json.NewDecoder(res.Body).Decode(&header))
+				require.Equal(t, statement, header)
+
+				// should return 400 cause the statement was changed after signing.
+				res2 := verify(statement, true)
+				defer func() { require.NoError(t, res2.Body.Close()) }()
+				require.Equal(t, http.StatusBadRequest, res2.StatusCode)
+
+				// should return 400 cause the statement was issued for a different node.
+				statement.NodeID = testrand.NodeID()
+				res3 := verify(statement, false)
+				defer func() { require.NoError(t, res3.Body.Close()) }()
+				require.Equal(t, http.StatusBadRequest, res3.StatusCode)
+			})
 		},
 	)
 }
@@ -21,8 +21,10 @@ import (
 	"storj.io/storj/private/web"
 	"storj.io/storj/storagenode/console"
 	"storj.io/storj/storagenode/console/consoleapi"
+	"storj.io/storj/storagenode/logging"
 	"storj.io/storj/storagenode/notifications"
 	"storj.io/storj/storagenode/payouts"
+	"storj.io/storj/storagenode/satellitepause"
 )
 
 var (
@@ -46,6 +48,8 @@ type Server struct {
 	service       *console.Service
 	notifications *notifications.Service
 	payout        *payouts.Service
+	pauses        *satellitepause.Service
+	logLevels     *logging.Levels
 	listener      net.Listener
 	assets        fs.FS
 
@@ -53,7 +57,7 @@ type Server struct {
 }
 
 // NewServer creates new instance of storagenode console web server.
-func NewServer(logger *zap.Logger, assets fs.FS, notifications *notifications.Service, service *console.Service, payout *payouts.Service, listener net.Listener) *Server {
+func NewServer(logger *zap.Logger, assets fs.FS, notifications *notifications.Service, service *console.Service, payout *payouts.Service, pauses *satellitepause.Service, logLevels *logging.Levels, listener net.Listener) *Server {
 	server := Server{
 		log:           logger,
 		service:       service,
@@ -61,6 +65,8 @@ func NewServer(logger *zap.Logger, assets fs.FS, notifications *notifications.Se
 		assets:        assets,
 		notifications: notifications,
 		payout:        payout,
+		pauses:        pauses,
+		logLevels:     logLevels,
 	}
 
 	router := mux.NewRouter()
@@ -75,6 +81,17 @@ func NewServer(logger *zap.Logger, assets fs.FS, notifications *notifications.Se
 	storageNodeRouter.HandleFunc("/satellites/{id}/pricing", storageNodeController.Pricing).Methods(http.MethodGet)
 	storageNodeRouter.HandleFunc("/estimated-payout", storageNodeController.EstimatedPayout).Methods(http.MethodGet)
 
+	pausesController := consoleapi.NewPauses(server.log, server.pauses)
+	storageNodeRouter.HandleFunc("/pauses", pausesController.ListPauses).Methods(http.MethodGet)
+	storageNodeRouter.HandleFunc("/satellites/{id}/pause", pausesController.Pause).Methods(http.MethodPost)
+	storageNodeRouter.HandleFunc("/satellites/{id}/resume", pausesController.Resume).Methods(http.MethodPost)
+
+	loggingController := consoleapi.NewLogging(server.log, server.logLevels)
+	loggingRouter := router.PathPrefix("/api/logging").Subrouter()
+	loggingRouter.StrictSlash(true)
+	loggingRouter.HandleFunc("/levels", loggingController.Levels).Methods(http.MethodGet)
+	loggingRouter.HandleFunc("/levels/{subsystem}", loggingController.SetLevel).Methods(http.MethodPut)
+
 	notificationController := consoleapi.NewNotifications(server.log, server.notifications)
 	notificationRouter := router.PathPrefix("/api/notifications").Subrouter()
 	notificationRouter.StrictSlash(true)
@@ -88,8 +105,11 @@ func NewServer(logger *zap.Logger, assets fs.FS, notifications *notifications.Se
 	payoutRouter.HandleFunc("/paystubs/{period}", payoutController.PayStubMonthly).Methods(http.MethodGet)
 	payoutRouter.HandleFunc("/paystubs/{start}/{end}", payoutController.PayStubPeriod).Methods(http.MethodGet)
 	payoutRouter.HandleFunc("/held-history", payoutController.HeldHistory).Methods(http.MethodGet)
+	payoutRouter.HandleFunc("/held-events", payoutController.HeldAmountEvents).Methods(http.MethodGet)
 	payoutRouter.HandleFunc("/periods", payoutController.HeldAmountPeriods).Methods(http.MethodGet)
 	payoutRouter.HandleFunc("/payout-history/{period}", payoutController.PayoutHistory).Methods(http.MethodGet)
+	payoutRouter.HandleFunc("/statements/{period}", payoutController.PayoutStatement).Methods(http.MethodGet)
+	payoutRouter.HandleFunc("/statements/verify", payoutController.VerifyPayoutStatement).Methods(http.MethodPost)
 
 	staticServer := http.FileServer(http.FS(server.assets))
 	router.PathPrefix("/static/").Handler(web.CacheHandler(staticServer))
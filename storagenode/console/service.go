@@ -22,6 +22,7 @@ import (
 	"storj.io/storj/storagenode/operator"
 	"storj.io/storj/storagenode/payouts/estimatedpayouts"
 	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/preflight"
 	"storj.io/storj/storagenode/pricing"
 	"storj.io/storj/storagenode/reputation"
 	"storj.io/storj/storagenode/satellites"
@@ -65,6 +66,9 @@ type Service struct {
 
 	quicStats      *contact.QUICStats
 	configuredPort string
+
+	trashChore *pieces.TrashChore
+	dbHealth   *preflight.DatabaseHealth
 }
 
 // NewService returns new instance of Service.
@@ -72,7 +76,7 @@ func NewService(log *zap.Logger, bandwidth bandwidth.DB, pieceStore *pieces.Stor
 	allocatedDiskSpace memory.Size, walletAddress string, versionInfo version.Info, trust *trust.Pool,
 	reputationDB reputation.DB, storageUsageDB storageusage.DB, pricingDB pricing.DB, satelliteDB satellites.DB,
 	pingStats *contact.PingStats, contact *contact.Service, estimation *estimatedpayouts.Service, usageCache *pieces.BlobsUsageCache,
-	walletFeatures operator.WalletFeatures, port string, quicStats *contact.QUICStats) (*Service, error) {
+	walletFeatures operator.WalletFeatures, port string, quicStats *contact.QUICStats, trashChore *pieces.TrashChore, dbHealth *preflight.DatabaseHealth) (*Service, error) {
 	if log == nil {
 		return nil, errs.New("log can't be nil")
 	}
@@ -126,6 +130,8 @@ func NewService(log *zap.Logger, bandwidth bandwidth.DB, pieceStore *pieces.Stor
 		walletFeatures:     walletFeatures,
 		quicStats:          quicStats,
 		configuredPort:     port,
+		trashChore:         trashChore,
+		dbHealth:           dbHealth,
 	}, nil
 }
 
@@ -160,6 +166,12 @@ type Dashboard struct {
 	ConfiguredPort   string    `json:"configuredPort"`
 	QUICStatus       string    `json:"quicStatus"`
 	LastQUICPingedAt time.Time `json:"lastQuicPingedAt"`
+
+	TrashRestoreInProgress bool `json:"trashRestoreInProgress"`
+
+	ReadOnly           bool                        `json:"readOnly"`
+	CorruptDatabases   []preflight.CorruptDatabase `json:"corruptDatabases"`
+	RepairInstructions string                      `json:"repairInstructions"`
 }
 
 // GetDashboardData returns stale dashboard data.
@@ -180,6 +192,16 @@ func (s *Service) GetDashboardData(ctx context.Context) (_ *Dashboard, err error
 	data.LastQUICPingedAt = s.quicStats.WhenLastPinged()
 	data.ConfiguredPort = s.configuredPort
 
+	if s.trashChore != nil {
+		data.TrashRestoreInProgress = s.trashChore.RestoreInProgress()
+	}
+
+	data.CorruptDatabases = s.dbHealth.Corrupt()
+	if len(data.CorruptDatabases) > 0 {
+		data.ReadOnly = true
+		data.RepairInstructions = preflight.DatabaseRepairInstructions
+	}
+
 	stats, err := s.reputationDB.All(ctx)
 	if err != nil {
 		return nil, SNOServiceErr.Wrap(err)
@@ -0,0 +1,40 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package contact
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/nodetag"
+	"storj.io/common/pb"
+	"storj.io/common/signing"
+	"storj.io/common/storj"
+	"storj.io/storj/private/maintenance"
+)
+
+// SignMaintenanceWindow validates the maintenance window, formatted as <start>/<end> in RFC3339,
+// and returns it as a node tag signed by the node itself.
+func SignMaintenanceWindow(ctx context.Context, signer signing.Signer, nodeID storj.NodeID, window string) (_ *pb.SignedNodeTagSet, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	parsed, err := maintenance.Parse(window)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	signed, err := nodetag.Sign(ctx, &pb.NodeTagSet{
+		NodeId:   nodeID.Bytes(),
+		SignedAt: time.Now().Unix(),
+		Tags: []*pb.Tag{
+			{
+				Name:  maintenance.Tag,
+				Value: []byte(parsed.String()),
+			},
+		},
+	}, signer)
+	return signed, errs.Wrap(err)
+}
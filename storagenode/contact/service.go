@@ -21,6 +21,7 @@ import (
 	"storj.io/common/rpc"
 	"storj.io/common/storj"
 	"storj.io/common/sync2"
+	"storj.io/storj/storagenode/satellitepause"
 	"storj.io/storj/storagenode/trust"
 )
 
@@ -43,6 +44,10 @@ type Config struct {
 	Interval time.Duration `help:"how frequently the node contact chore should run" releaseDefault:"1h" devDefault:"30s"`
 
 	Tags SignedTags `help:"protobuf serialized signed node tags in hex (base64) format"`
+
+	MaintenanceWindow string `user:"true" help:"upcoming maintenance window announced to the satellites, as <start>/<end> in RFC3339 format, e.g. 2024-01-01T10:00:00Z/2024-01-01T12:00:00Z" default:""`
+
+	MaxConcurrentUploads int `user:"true" help:"preferred maximum number of concurrent uploads announced to the satellites, which select the node less often when it has more uploads in progress, 0 means no preference" default:"0"`
 }
 
 // SignedTags represents base64 encoded signed tags.
@@ -117,11 +122,12 @@ type Service struct {
 
 	initialized sync2.Fence
 
-	tags *pb.SignedNodeTagSets
+	tags   *pb.SignedNodeTagSets
+	pauses *satellitepause.Service
 }
 
 // NewService creates a new contact service.
-func NewService(log *zap.Logger, dialer rpc.Dialer, self NodeInfo, trust *trust.Pool, quicStats *QUICStats, tags *pb.SignedNodeTagSets) *Service {
+func NewService(log *zap.Logger, dialer rpc.Dialer, self NodeInfo, trust *trust.Pool, quicStats *QUICStats, tags *pb.SignedNodeTagSets, pauses *satellitepause.Service) *Service {
 	return &Service{
 		log:       log,
 		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
@@ -130,6 +136,7 @@ func NewService(log *zap.Logger, dialer rpc.Dialer, self NodeInfo, trust *trust.
 		self:      self,
 		quicStats: quicStats,
 		tags:      tags,
+		pauses:    pauses,
 	}
 }
 
@@ -182,6 +189,11 @@ func (service *Service) pingSatelliteOnce(ctx context.Context, id storj.NodeID)
 	}
 	defer func() { err = errs.Combine(err, conn.Close()) }()
 
+	tags, err := service.signedTags(ctx, id)
+	if err != nil {
+		return errPingSatellite.Wrap(err)
+	}
+
 	self := service.Local()
 	var features uint64
 	if self.FastOpen {
@@ -195,7 +207,7 @@ func (service *Service) pingSatelliteOnce(ctx context.Context, id storj.NodeID)
 		NoiseKeyAttestation: self.NoiseKeyAttestation,
 		DebounceLimit:       int32(self.DebounceLimit),
 		Features:            features,
-		SignedTags:          service.tags,
+		SignedTags:          tags,
 	})
 	service.quicStats.SetStatus(false)
 	if err != nil {
@@ -214,6 +226,24 @@ func (service *Service) pingSatelliteOnce(ctx context.Context, id storj.NodeID)
 	return nil
 }
 
+// signedTags returns the tags announced to the satellite, including whether
+// the operator paused serving it.
+func (service *Service) signedTags(ctx context.Context, id storj.NodeID) (*pb.SignedNodeTagSets, error) {
+	if service.pauses == nil {
+		return service.tags, nil
+	}
+
+	pauseTag, err := service.pauses.SignedTag(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := &pb.SignedNodeTagSets{}
+	tags.Tags = append(tags.Tags, service.tags.GetTags()...)
+	tags.Tags = append(tags.Tags, pauseTag)
+	return tags, nil
+}
+
 // RequestPingMeQUIC sends pings request to satellite for a pingBack via QUIC.
 func (service *Service) RequestPingMeQUIC(ctx context.Context) (stats *QUICStats, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -0,0 +1,43 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package contact
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/nodetag"
+	"storj.io/common/pb"
+	"storj.io/common/signing"
+	"storj.io/common/storj"
+)
+
+// MaxConcurrentUploadsTag is the name of the node tag announcing the preferred maximum
+// number of concurrent uploads.
+const MaxConcurrentUploadsTag = "max_concurrent_uploads"
+
+// SignMaxConcurrentUploads returns the preferred maximum number of concurrent uploads as a
+// node tag signed by the node itself.
+func SignMaxConcurrentUploads(ctx context.Context, signer signing.Signer, nodeID storj.NodeID, limit int) (_ *pb.SignedNodeTagSet, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if limit <= 0 {
+		return nil, Error.New("maximum number of concurrent uploads should be positive: %d", limit)
+	}
+
+	signed, err := nodetag.Sign(ctx, &pb.NodeTagSet{
+		NodeId:   nodeID.Bytes(),
+		SignedAt: time.Now().Unix(),
+		Tags: []*pb.Tag{
+			{
+				Name:  MaxConcurrentUploadsTag,
+				Value: []byte(strconv.Itoa(limit)),
+			},
+		},
+	}, signer)
+	return signed, errs.Wrap(err)
+}
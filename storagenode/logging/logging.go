@@ -0,0 +1,210 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package logging
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"storj.io/common/memory"
+)
+
+// Error is the default error class for the logging package.
+var Error = errs.Class("logging")
+
+// ErrUnknownSubsystem is returned for subsystems which don't exist.
+var ErrUnknownSubsystem = errs.Class("unknown logging subsystem")
+
+const (
+	// Default is the name of the level used by the loggers which don't belong to a subsystem.
+	Default = "default"
+	// Piecestore is the subsystem which handles uploads, downloads and deletes of pieces.
+	Piecestore = "piecestore"
+	// GC is the subsystem which handles the garbage collection and the trash.
+	GC = "gc"
+	// Orders is the subsystem which sends the orders to the satellites.
+	Orders = "orders"
+	// Contact is the subsystem which checks in with the satellites.
+	Contact = "contact"
+)
+
+// subsystemLoggers are the names of the loggers belonging to a subsystem. A logger
+// belongs to a subsystem when its name or the name of its parent matches.
+var subsystemLoggers = map[string][]string{
+	Piecestore: {"piecestore", "piecedeleter", "blobscache"},
+	GC:         {"retain", "pieces:trash", "lazyfilewalker.gc-filewalker"},
+	Orders:     {"orders"},
+	Contact:    {"contact"},
+}
+
+// Config contains the configuration of the storage node logging.
+type Config struct {
+	Enabled bool `help:"if true, logs are written as configured by the logging.* options instead of the log.* options" default:"false"`
+
+	Level      string `help:"the log level of the loggers which don't belong to a subsystem" default:"info"`
+	Piecestore string `help:"the log level of the piecestore subsystem, defaults to logging.level" default:""`
+	GC         string `help:"the log level of the garbage collection subsystem, defaults to logging.level" default:""`
+	Orders     string `help:"the log level of the orders subsystem, defaults to logging.level" default:""`
+	Contact    string `help:"the log level of the contact subsystem, defaults to logging.level" default:""`
+
+	JSON   bool   `help:"if true, logs are written as JSON" default:"false"`
+	Output string `help:"can be stdout, stderr, or a file path. Log files are rotated" default:"stderr"`
+
+	MaxSize    memory.Size   `help:"the size after which the log file is rotated" default:"100MB"`
+	MaxAge     time.Duration `help:"the time after which the log file is rotated, 0 disables time based rotation" default:"24h"`
+	MaxBackups int           `help:"the number of rotated log files to keep, 0 keeps all of them" default:"10"`
+}
+
+// Levels contains the log levels of the subsystems, which can be changed at runtime.
+type Levels struct {
+	// levels isn't modified after creation, the levels are changed atomically.
+	levels map[string]zap.AtomicLevel
+}
+
+// NewLevels creates the subsystem log levels from the configuration.
+func NewLevels(config Config) (*Levels, error) {
+	defaultLevel, err := parseLevel(config.Level, zapcore.InfoLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := &Levels{
+		levels: map[string]zap.AtomicLevel{
+			Default: zap.NewAtomicLevelAt(defaultLevel),
+		},
+	}
+	for subsystem, value := range map[string]string{
+		Piecestore: config.Piecestore,
+		GC:         config.GC,
+		Orders:     config.Orders,
+		Contact:    config.Contact,
+	} {
+		level, err := parseLevel(value, defaultLevel)
+		if err != nil {
+			return nil, err
+		}
+		levels.levels[subsystem] = zap.NewAtomicLevelAt(level)
+	}
+	return levels, nil
+}
+
+func parseLevel(value string, defaultLevel zapcore.Level) (zapcore.Level, error) {
+	if value == "" {
+		return defaultLevel, nil
+	}
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(value)); err != nil {
+		return level, Error.New("invalid log level %q", value)
+	}
+	return level, nil
+}
+
+// Level returns the log level of the subsystem.
+func (levels *Levels) Level(subsystem string) (zapcore.Level, error) {
+	level, ok := levels.levels[subsystem]
+	if !ok {
+		return zapcore.InfoLevel, ErrUnknownSubsystem.New("%q", subsystem)
+	}
+	return level.Level(), nil
+}
+
+// SetLevel changes the log level of the subsystem.
+func (levels *Levels) SetLevel(subsystem string, level zapcore.Level) error {
+	atomic, ok := levels.levels[subsystem]
+	if !ok {
+		return ErrUnknownSubsystem.New("%q", subsystem)
+	}
+	atomic.SetLevel(level)
+	return nil
+}
+
+// Subsystems returns the names of the subsystems, including Default.
+func (levels *Levels) Subsystems() []string {
+	subsystems := make([]string, 0, len(levels.levels))
+	for subsystem := range levels.levels {
+		subsystems = append(subsystems, subsystem)
+	}
+	sort.Strings(subsystems)
+	return subsystems
+}
+
+// enabled returns whether an entry of the logger with the level should be logged.
+func (levels *Levels) enabled(loggerName string, level zapcore.Level) bool {
+	return levels.levels[subsystemOf(loggerName)].Enabled(level)
+}
+
+// subsystemOf returns the subsystem of the logger.
+func subsystemOf(loggerName string) string {
+	for subsystem, names := range subsystemLoggers {
+		for _, name := range names {
+			if loggerName == name || strings.HasPrefix(loggerName, name+".") || strings.HasPrefix(loggerName, name+":") {
+				return subsystem
+			}
+		}
+	}
+	return Default
+}
+
+// Wrap returns a core which filters the entries of the core by the level of their subsystem.
+func (levels *Levels) Wrap(core zapcore.Core) zapcore.Core {
+	return &levelCore{Core: core, levels: levels}
+}
+
+// levelCore filters the entries by the level of their subsystem.
+type levelCore struct {
+	zapcore.Core
+	levels *Levels
+}
+
+// With implements zapcore.Core.
+func (core *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: core.Core.With(fields), levels: core.levels}
+}
+
+// Check implements zapcore.Core.
+func (core *levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !core.levels.enabled(entry.LoggerName, entry.Level) {
+		return checked
+	}
+	return core.Core.Check(entry, checked)
+}
+
+// NewLogger creates a logger, which writes the logs as configured and filters them by
+// the levels of their subsystems. The returned function closes the log file.
+func NewLogger(config Config, levels *Levels) (_ *zap.Logger, close func() error, err error) {
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	var encoder zapcore.Encoder
+	if config.JSON {
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	} else {
+		encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	}
+
+	var output zapcore.WriteSyncer
+	close = func() error { return nil }
+	switch config.Output {
+	case "", "stderr":
+		output = zapcore.Lock(os.Stderr)
+	case "stdout":
+		output = zapcore.Lock(os.Stdout)
+	default:
+		file, err := OpenRotatingFile(config.Output, config.MaxSize.Int64(), config.MaxAge, config.MaxBackups)
+		if err != nil {
+			return nil, nil, err
+		}
+		output, close = file, file.Close
+	}
+
+	core := levels.Wrap(zapcore.NewCore(encoder, output, zapcore.DebugLevel))
+	return zap.New(core, zap.ErrorOutput(zapcore.Lock(os.Stderr))), close, nil
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package logging_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"storj.io/storj/storagenode/logging"
+)
+
+func TestLevels(t *testing.T) {
+	levels, err := logging.NewLevels(logging.Config{
+		Level:      "warn",
+		Piecestore: "debug",
+		GC:         "error",
+	})
+	require.NoError(t, err)
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := zap.New(levels.Wrap(core))
+
+	log.Named("piecestore").Debug("upload")
+	log.Named("piecestore:cache").Debug("cache")
+	log.Named("retain").Warn("retain")
+	log.Named("pieces:trash").Error("trash")
+	log.Named("orders").Info("orders")
+	log.Named("orders").Warn("orders")
+	log.Named("contact:chore").Warn("contact")
+	log.Named("collector").Info("collector")
+	require.Equal(t, []string{"upload", "cache", "trash", "orders", "contact"}, messages(logs))
+
+	level, err := levels.Level(logging.Orders)
+	require.NoError(t, err)
+	require.Equal(t, zapcore.WarnLevel, level)
+
+	require.NoError(t, levels.SetLevel(logging.Orders, zapcore.DebugLevel))
+	require.NoError(t, levels.SetLevel(logging.Default, zapcore.InfoLevel))
+	require.True(t, logging.ErrUnknownSubsystem.Has(levels.SetLevel("unknown", zapcore.DebugLevel)))
+
+	logs.TakeAll()
+	log.Named("orders").Debug("orders")
+	log.Named("collector").Info("collector")
+	log.Named("collector").Debug("collector")
+	require.Equal(t, []string{"orders", "collector"}, messages(logs))
+
+	require.Equal(t, []string{logging.Contact, logging.Default, logging.GC, logging.Orders, logging.Piecestore}, levels.Subsystems())
+
+	_, err = logging.NewLevels(logging.Config{Level: "info", Contact: "loud"})
+	require.Error(t, err)
+}
+
+func messages(logs *observer.ObservedLogs) (messages []string) {
+	for _, entry := range logs.TakeAll() {
+		messages = append(messages, entry.Message)
+	}
+	return messages
+}
@@ -0,0 +1,162 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// rotatedTimeFormat is the format of the time added to the name of a rotated log file.
+const rotatedTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotatingFile is a log file, which is rotated when it reaches its maximum size or age.
+// The rotated files are renamed by adding the rotation time to their name.
+type RotatingFile struct {
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	now      func() time.Time
+}
+
+// OpenRotatingFile opens the log file at path for appending. A maxSize or maxAge of 0
+// disables the corresponding rotation, a maxBackups of 0 keeps all the rotated files.
+func OpenRotatingFile(path string, maxSize int64, maxAge time.Duration, maxBackups int) (*RotatingFile, error) {
+	file := &RotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+		now:        time.Now,
+	}
+	if err := file.open(); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// SetNow sets the function used to get the current time. It's used for testing.
+func (file *RotatingFile) SetNow(now func() time.Time) {
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	file.now = now
+	file.openedAt = now()
+}
+
+func (file *RotatingFile) open() error {
+	f, err := os.OpenFile(file.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return Error.Wrap(errs.Combine(err, f.Close()))
+	}
+	file.file = f
+	file.size = info.Size()
+	file.openedAt = file.now()
+	return nil
+}
+
+// Write writes p to the file, rotating it first when needed.
+func (file *RotatingFile) Write(p []byte) (n int, err error) {
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	if file.file == nil {
+		return 0, Error.New("file is closed")
+	}
+
+	tooLarge := file.maxSize > 0 && file.size > 0 && file.size+int64(len(p)) > file.maxSize
+	tooOld := file.maxAge > 0 && file.now().Sub(file.openedAt) >= file.maxAge
+	if tooLarge || tooOld {
+		if err := file.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err = file.file.Write(p)
+	file.size += int64(n)
+	return n, Error.Wrap(err)
+}
+
+// rotate renames the current file, opens a new one and removes the old backups.
+func (file *RotatingFile) rotate() error {
+	if err := file.file.Close(); err != nil {
+		return Error.Wrap(err)
+	}
+	file.file = nil
+
+	ext := filepath.Ext(file.path)
+	rotated := strings.TrimSuffix(file.path, ext) + "-" + file.now().UTC().Format(rotatedTimeFormat) + ext
+	if err := os.Rename(file.path, rotated); err != nil {
+		return Error.Wrap(err)
+	}
+
+	if err := file.open(); err != nil {
+		return err
+	}
+	return file.removeOldBackups()
+}
+
+// removeOldBackups removes the oldest rotated files above maxBackups.
+func (file *RotatingFile) removeOldBackups() error {
+	if file.maxBackups <= 0 {
+		return nil
+	}
+
+	ext := filepath.Ext(file.path)
+	backups, err := filepath.Glob(strings.TrimSuffix(file.path, ext) + "-*" + ext)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if len(backups) <= file.maxBackups {
+		return nil
+	}
+
+	// the rotation time in the names sorts them from the oldest to the newest.
+	sort.Strings(backups)
+	var group errs.Group
+	for _, backup := range backups[:len(backups)-file.maxBackups] {
+		group.Add(os.Remove(backup))
+	}
+	return Error.Wrap(group.Err())
+}
+
+// Sync commits the content of the file to the disk.
+func (file *RotatingFile) Sync() error {
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	if file.file == nil {
+		return nil
+	}
+	return Error.Wrap(file.file.Sync())
+}
+
+// Close closes the file.
+func (file *RotatingFile) Close() error {
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	if file.file == nil {
+		return nil
+	}
+	err := file.file.Close()
+	file.file = nil
+	return Error.Wrap(err)
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package logging_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/storagenode/logging"
+)
+
+func TestRotatingFile(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	path := ctx.File("log", "node.log")
+	file, err := logging.OpenRotatingFile(path, 10, time.Hour, 2)
+	require.NoError(t, err)
+	defer ctx.Check(file.Close)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	file.SetNow(func() time.Time { return now })
+
+	write := func(data string) {
+		_, err := file.Write([]byte(data))
+		require.NoError(t, err)
+	}
+	backups := func() []string {
+		matches, err := filepath.Glob(filepath.Join(filepath.Dir(path), "node-*.log"))
+		require.NoError(t, err)
+		return matches
+	}
+
+	write("12345")
+	write("12345")
+	require.Empty(t, backups())
+
+	// size based rotation.
+	now = now.Add(time.Second)
+	write("abc")
+	require.Len(t, backups(), 1)
+	requireContent(t, path, "abc")
+	requireContent(t, backups()[0], "1234512345")
+
+	// time based rotation.
+	now = now.Add(time.Hour)
+	write("def")
+	require.Len(t, backups(), 2)
+	requireContent(t, path, "def")
+
+	// the oldest backups are removed.
+	now = now.Add(time.Hour)
+	write("ghi")
+	require.Len(t, backups(), 2)
+	for _, backup := range backups() {
+		require.False(t, strings.HasSuffix(backup, "2024-01-01T00-00-01.000.log"))
+	}
+
+	// the size of an existing file is taken into account.
+	require.NoError(t, file.Close())
+	file, err = logging.OpenRotatingFile(path, 5, 0, 0)
+	require.NoError(t, err)
+	_, err = file.Write([]byte("jkl"))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.Len(t, backups(), 3)
+}
+
+func requireContent(t *testing.T, path, expected string) {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(data))
+}
@@ -20,6 +20,7 @@ import (
 	"storj.io/common/sync2"
 	"storj.io/storj/storagenode/contact"
 	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/preflight"
 )
 
 var (
@@ -58,6 +59,8 @@ type Config struct {
 	MinimumDiskSpace          memory.Size   `help:"how much disk space a node at minimum has to advertise" default:"500GB"`
 	MinimumBandwidth          memory.Size   `help:"how much bandwidth a node at minimum has to advertise (deprecated)" default:"0TB"`
 	NotifyLowDiskCooldown     time.Duration `help:"minimum length of time between capacity reports" default:"10m" hidden:"true"`
+
+	ReportNoCapacityDuringTrashRestore bool `help:"report no free disk space to satellites while a trash restore is in progress" default:"false"`
 }
 
 // Service which monitors disk usage.
@@ -66,6 +69,8 @@ type Config struct {
 type Service struct {
 	log                   *zap.Logger
 	store                 *pieces.Store
+	trashChore            *pieces.TrashChore
+	dbHealth              *preflight.DatabaseHealth
 	contact               *contact.Service
 	allocatedDiskSpace    int64
 	cooldown              *sync2.Cooldown
@@ -76,10 +81,12 @@ type Service struct {
 }
 
 // NewService creates a new storage node monitoring service.
-func NewService(log *zap.Logger, store *pieces.Store, contact *contact.Service, allocatedDiskSpace int64, interval time.Duration, reportCapacity func(context.Context), config Config) *Service {
+func NewService(log *zap.Logger, store *pieces.Store, trashChore *pieces.TrashChore, contact *contact.Service, allocatedDiskSpace int64, interval time.Duration, reportCapacity func(context.Context), config Config, dbHealth *preflight.DatabaseHealth) *Service {
 	return &Service{
 		log:                   log,
 		store:                 store,
+		trashChore:            trashChore,
+		dbHealth:              dbHealth,
 		contact:               contact,
 		allocatedDiskSpace:    allocatedDiskSpace,
 		cooldown:              sync2.NewCooldown(config.NotifyLowDiskCooldown),
@@ -236,6 +243,20 @@ func (service *Service) updateNodeInformation(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
+
+	// while restoring the trash, we prefer to use the disk IO for the restore
+	// instead of receiving new pieces.
+	if service.Config.ReportNoCapacityDuringTrashRestore && service.restoringTrash() {
+		service.log.Debug("trash restore in progress, reporting no free disk space")
+		freeSpace = 0
+	}
+
+	// uploads are rejected in read-only mode, so satellites shouldn't select the node.
+	if service.dbHealth.ReadOnly() {
+		service.log.Debug("node is in read-only mode, reporting no free disk space")
+		freeSpace = 0
+	}
+
 	service.contact.UpdateSelf(&pb.NodeCapacity{
 		FreeDisk: freeSpace,
 	})
@@ -318,6 +339,11 @@ func (service *Service) DiskSpace(ctx context.Context) (_ DiskSpace, err error)
 	}, nil
 }
 
+// restoringTrash returns true when a trash restore is in progress.
+func (service *Service) restoringTrash() bool {
+	return service.trashChore != nil && service.trashChore.RestoreInProgress()
+}
+
 // isLowerThanAllocated checks if the disk space is lower than allocated.
 func isLowerThanAllocated(actual, allocated int64) bool {
 	return actual > 0 && actual < allocated
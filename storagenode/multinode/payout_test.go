@@ -65,7 +65,7 @@ func TestPayoutsEndpointSummary(t *testing.T) {
 		require.NoError(t, err)
 		require.NoError(t, trustPool.Refresh(ctx))
 
-		payoutsService, err := payouts.NewService(log, db.Payout(), db.Reputation(), db.Satellites(), nil)
+		payoutsService, err := payouts.NewService(log, db.Payout(), db.Reputation(), db.Satellites(), nil, nil, testrand.NodeID())
 		require.NoError(t, err)
 		estimatedPayoutsService := estimatedpayouts.NewService(db.Bandwidth(), db.Reputation(), db.StorageUsage(), db.Pricing(), db.Satellites(), trustPool)
 		endpoint := multinode.NewPayoutEndpoint(log, service, db.Payout(), estimatedPayoutsService, payoutsService)
@@ -158,7 +158,7 @@ func TestPayoutsEndpointEstimations(t *testing.T) {
 		require.NoError(t, err)
 		require.NoError(t, trustPool.Refresh(ctx))
 
-		payoutsService, err := payouts.NewService(log, db.Payout(), db.Reputation(), db.Satellites(), nil)
+		payoutsService, err := payouts.NewService(log, db.Payout(), db.Reputation(), db.Satellites(), nil, nil, testrand.NodeID())
 		require.NoError(t, err)
 		estimatedPayoutsService := estimatedpayouts.NewService(db.Bandwidth(), db.Reputation(), db.StorageUsage(), db.Pricing(), db.Satellites(), trustPool)
 		endpoint := multinode.NewPayoutEndpoint(log, service, db.Payout(), estimatedPayoutsService, payoutsService)
@@ -229,7 +229,7 @@ func TestPayoutsUndistributedEndpoint(t *testing.T) {
 		require.NoError(t, err)
 		require.NoError(t, trustPool.Refresh(ctx))
 
-		payoutsService, err := payouts.NewService(log, db.Payout(), db.Reputation(), db.Satellites(), nil)
+		payoutsService, err := payouts.NewService(log, db.Payout(), db.Reputation(), db.Satellites(), nil, nil, testrand.NodeID())
 		require.NoError(t, err)
 		estimatedPayoutsService := estimatedpayouts.NewService(db.Bandwidth(), db.Reputation(), db.StorageUsage(), db.Pricing(), db.Satellites(), trustPool)
 		endpoint := multinode.NewPayoutEndpoint(log, service, db.Payout(), estimatedPayoutsService, payoutsService)
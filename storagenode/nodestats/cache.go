@@ -48,6 +48,7 @@ type Cache struct {
 	db                CacheStorage
 	service           *Service
 	payoutEndpoint    *payouts.Endpoint
+	payoutService     *payouts.Service
 	reputationService *reputation.Service
 	trust             *trust.Pool
 
@@ -60,13 +61,14 @@ type Cache struct {
 
 // NewCache creates new caching service instance.
 func NewCache(log *zap.Logger, config Config, db CacheStorage, service *Service,
-	payoutEndpoint *payouts.Endpoint, reputationService *reputation.Service, trust *trust.Pool) *Cache {
+	payoutEndpoint *payouts.Endpoint, payoutService *payouts.Service, reputationService *reputation.Service, trust *trust.Pool) *Cache {
 
 	cache := &Cache{
 		log:               log,
 		db:                db,
 		service:           service,
 		payoutEndpoint:    payoutEndpoint,
+		payoutService:     payoutService,
 		reputationService: reputationService,
 		trust:             trust,
 		maxSleep:          config.MaxSleep,
@@ -89,11 +91,9 @@ func (cache *Cache) Run(ctx context.Context) error {
 			return err
 		}
 
-		for i := 0; i < len(stubHistory); i++ {
-			err := cache.db.Payout.StorePayStub(ctx, stubHistory[i])
-			if err != nil {
-				return err
-			}
+		err = cache.payoutService.StorePayStubs(ctx, satelliteID, stubHistory)
+		if err != nil {
+			return err
 		}
 
 		paymentHistory, err := cache.payoutEndpoint.GetAllPayments(ctx, satelliteID)
@@ -257,7 +257,7 @@ func (cache *Cache) CacheHeldAmount(ctx context.Context) (err error) {
 		}
 
 		if payStub != nil {
-			if err = cache.db.Payout.StorePayStub(ctx, *payStub); err != nil {
+			if err = cache.payoutService.StorePayStubs(ctx, satellite, []payouts.PayStub{*payStub}); err != nil {
 				return err
 			}
 		}
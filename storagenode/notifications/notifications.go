@@ -35,6 +35,8 @@ const (
 	TypeDisqualification Type = 2
 	// TypeSuspension is a notification type which describes node's suspension status.
 	TypeSuspension Type = 3
+	// TypeHeldAmount is a notification type which describes node's held amount disposition or held rate change.
+	TypeHeldAmount Type = 4
 )
 
 // NewNotification holds notification entity info which is being received from satellite or local client.
@@ -211,7 +211,7 @@ func TestSatellitePayStubPeriodCached(t *testing.T) {
 		heldAmountDB := db.Payout()
 		reputationDB := db.Reputation()
 		satellitesDB := db.Satellites()
-		service, err := payouts.NewService(nil, heldAmountDB, reputationDB, satellitesDB, nil)
+		service, err := payouts.NewService(nil, heldAmountDB, reputationDB, satellitesDB, nil, nil, testrand.NodeID())
 		require.NoError(t, err)
 
 		payStub := payouts.PayStub{
@@ -262,7 +262,7 @@ func TestAllPayStubPeriodCached(t *testing.T) {
 		heldAmountDB := db.Payout()
 		reputationDB := db.Reputation()
 		satellitesDB := db.Satellites()
-		service, err := payouts.NewService(nil, heldAmountDB, reputationDB, satellitesDB, nil)
+		service, err := payouts.NewService(nil, heldAmountDB, reputationDB, satellitesDB, nil, nil, testrand.NodeID())
 		require.NoError(t, err)
 
 		payStub := payouts.PayStub{
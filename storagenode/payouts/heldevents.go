@@ -0,0 +1,225 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/private/currency"
+	"storj.io/storj/storagenode/notifications"
+)
+
+// HeldAmountEventType describes what happened to the held amount of the node.
+type HeldAmountEventType string
+
+const (
+	// HeldAmountDisposed is used when the satellite disposed (returned) held amount to the node.
+	HeldAmountDisposed HeldAmountEventType = "disposed"
+	// HeldRateChanged is used when the satellite changed the rate of the held amount.
+	HeldRateChanged HeldAmountEventType = "held-rate-changed"
+)
+
+// HeldAmountEvent is a held amount disposition or held rate change found in the paystubs.
+type HeldAmountEvent struct {
+	SatelliteID      storj.NodeID        `json:"satelliteId"`
+	Period           string              `json:"period"`
+	Type             HeldAmountEventType `json:"type"`
+	Disposed         int64               `json:"disposed"`
+	HeldRate         float64             `json:"heldRate"`
+	PreviousHeldRate float64             `json:"previousHeldRate"`
+}
+
+// key identifies the event within the satellite history.
+func (event HeldAmountEvent) key() string {
+	return string(event.Type) + "/" + event.Period
+}
+
+// HeldAmountEvents retrieves held amount dispositions and held rate changes of all satellites.
+func (service *Service) HeldAmountEvents(ctx context.Context) (events []HeldAmountEvent, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	periods, err := service.db.AllPeriods(ctx)
+	if err != nil {
+		return nil, ErrPayoutService.Wrap(err)
+	}
+
+	paystubs := map[storj.NodeID][]PayStub{}
+	for _, period := range periods {
+		periodPaystubs, err := service.db.AllPayStubs(ctx, period)
+		if err != nil {
+			return nil, ErrPayoutService.Wrap(err)
+		}
+		for _, paystub := range periodPaystubs {
+			paystubs[paystub.SatelliteID] = append(paystubs[paystub.SatelliteID], paystub)
+		}
+	}
+
+	for _, satellitePaystubs := range paystubs {
+		events = append(events, heldAmountEvents(satellitePaystubs)...)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Period != events[j].Period {
+			return events[i].Period < events[j].Period
+		}
+		if events[i].SatelliteID != events[j].SatelliteID {
+			return events[i].SatelliteID.Less(events[j].SatelliteID)
+		}
+		return events[i].Type < events[j].Type
+	})
+
+	return events, nil
+}
+
+// StorePayStubs stores the paystubs received from the satellite and adds a notification
+// for every held amount disposition or held rate change that wasn't known before.
+//
+// No notifications are added when there were no paystubs of the satellite before,
+// so the initial sync of the payout history doesn't flood the dashboard.
+func (service *Service) StorePayStubs(ctx context.Context, satelliteID storj.NodeID, paystubs []PayStub) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	before, err := service.satellitePayStubs(ctx, satelliteID)
+	if err != nil {
+		return ErrPayoutService.Wrap(err)
+	}
+
+	for _, paystub := range paystubs {
+		if err := service.db.StorePayStub(ctx, paystub); err != nil {
+			return ErrPayoutService.Wrap(err)
+		}
+	}
+
+	if len(before) == 0 || service.notifications == nil {
+		return nil
+	}
+
+	after, err := service.satellitePayStubs(ctx, satelliteID)
+	if err != nil {
+		return ErrPayoutService.Wrap(err)
+	}
+
+	known := map[string]bool{}
+	for _, event := range heldAmountEvents(before) {
+		known[event.key()] = true
+	}
+
+	for _, event := range heldAmountEvents(after) {
+		if known[event.key()] {
+			continue
+		}
+
+		_, err := service.notifications.Receive(ctx, newHeldAmountNotification(event))
+		if err != nil {
+			service.log.Error("failed to add held amount notification", zap.Stringer("Satellite ID", satelliteID), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// satellitePayStubs returns all stored paystubs of the satellite.
+func (service *Service) satellitePayStubs(ctx context.Context, satelliteID storj.NodeID) (paystubs []PayStub, err error) {
+	periods, err := service.db.SatellitePeriods(ctx, satelliteID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, period := range periods {
+		paystub, err := service.db.GetPayStub(ctx, satelliteID, period)
+		if err != nil {
+			if ErrNoPayStubForPeriod.Has(err) {
+				continue
+			}
+			return nil, err
+		}
+		paystubs = append(paystubs, *paystub)
+	}
+
+	return paystubs, nil
+}
+
+// heldAmountEvents finds the held amount dispositions and held rate changes in
+// the paystubs of a single satellite.
+func heldAmountEvents(paystubs []PayStub) (events []HeldAmountEvent) {
+	sort.Slice(paystubs, func(i, j int) bool {
+		return paystubs[i].Period < paystubs[j].Period
+	})
+
+	previousRate, hasPreviousRate := 0.0, false
+	for _, paystub := range paystubs {
+		rate, hasRate := paystubHeldRate(paystub)
+
+		if paystub.Disposed > 0 {
+			events = append(events, HeldAmountEvent{
+				SatelliteID:      paystub.SatelliteID,
+				Period:           paystub.Period,
+				Type:             HeldAmountDisposed,
+				Disposed:         paystub.Disposed,
+				HeldRate:         rate,
+				PreviousHeldRate: previousRate,
+			})
+		}
+
+		if !hasRate {
+			continue
+		}
+
+		if hasPreviousRate && rate != previousRate {
+			events = append(events, HeldAmountEvent{
+				SatelliteID:      paystub.SatelliteID,
+				Period:           paystub.Period,
+				Type:             HeldRateChanged,
+				Disposed:         paystub.Disposed,
+				HeldRate:         rate,
+				PreviousHeldRate: previousRate,
+			})
+		}
+		previousRate, hasPreviousRate = rate, true
+	}
+
+	return events
+}
+
+// paystubHeldRate returns the held rate in percents applied in the paystub.
+// It returns false when nothing was earned in the period.
+func paystubHeldRate(paystub PayStub) (rate float64, ok bool) {
+	if paystub.SurgePercent == 0 {
+		paystub.SurgePercent = 100
+	}
+
+	_, surge := paystub.GetEarnedWithSurge()
+	if surge <= 0 {
+		return 0, false
+	}
+
+	return math.Round(float64(paystub.Held) / float64(surge) * 100), true
+}
+
+// newHeldAmountNotification returns a notification about the held amount event.
+func newHeldAmountNotification(event HeldAmountEvent) notifications.NewNotification {
+	notification := notifications.NewNotification{
+		SenderID: event.SatelliteID,
+		Type:     notifications.TypeHeldAmount,
+	}
+
+	switch event.Type {
+	case HeldAmountDisposed:
+		notification.Title = "Held amount was returned for " + event.Period
+		notification.Message = fmt.Sprintf("Satellite %s returned $%s of held amount to your StorageNode in the paystub for %s",
+			event.SatelliteID, currency.NewMicroUnit(event.Disposed).FloatString(), event.Period)
+	case HeldRateChanged:
+		notification.Title = "Held rate changed for " + event.Period
+		notification.Message = fmt.Sprintf("Satellite %s changed the held rate of your StorageNode from %.0f%% to %.0f%% in the paystub for %s",
+			event.SatelliteID, event.PreviousHeldRate, event.HeldRate, event.Period)
+	}
+
+	return notification
+}
@@ -141,6 +141,15 @@ type SatellitePayoutForPeriod struct {
 	Distributed    int64   `json:"distributed"`
 }
 
+// PayoutStatement is the payout statement of the node from a specific satellite for a specific period.
+type PayoutStatement struct {
+	SatelliteID  storj.NodeID `json:"satelliteId"`
+	SatelliteURL string       `json:"satelliteURL"`
+	Period       string       `json:"period"`
+	PayStub      PayStub      `json:"paystub"`
+	Receipt      string       `json:"receipt"`
+}
+
 // HeldAmountHistory contains held amount history for satellite.
 type HeldAmountHistory struct {
 	SatelliteID storj.NodeID    `json:"satelliteId"`
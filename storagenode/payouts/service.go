@@ -6,6 +6,7 @@ package payouts
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -17,6 +18,8 @@ import (
 
 	"storj.io/common/storj"
 	"storj.io/storj/private/date"
+	"storj.io/storj/shared/payoutstatement"
+	"storj.io/storj/storagenode/notifications"
 	"storj.io/storj/storagenode/reputation"
 	"storj.io/storj/storagenode/satellites"
 	"storj.io/storj/storagenode/trust"
@@ -39,15 +42,18 @@ type Service struct {
 	log *zap.Logger
 
 	stefanSatellite storj.NodeID
+	nodeID          storj.NodeID
 
 	db           DB
 	reputationDB reputation.DB
 	satellitesDB satellites.DB
 	trust        *trust.Pool
+
+	notifications *notifications.Service
 }
 
 // NewService creates new instance of service.
-func NewService(log *zap.Logger, db DB, reputationDB reputation.DB, satelliteDB satellites.DB, trust *trust.Pool) (_ *Service, err error) {
+func NewService(log *zap.Logger, db DB, reputationDB reputation.DB, satelliteDB satellites.DB, trust *trust.Pool, notifications *notifications.Service, nodeID storj.NodeID) (_ *Service, err error) {
 	id, err := storj.NodeIDFromString("118UWpMCHzs6CvSgWd9BfFVjw5K9pZbJjkfZJexMtSkmKxvvAW")
 	if err != nil {
 		return &Service{}, err
@@ -56,10 +62,12 @@ func NewService(log *zap.Logger, db DB, reputationDB reputation.DB, satelliteDB
 	return &Service{
 		log:             log,
 		stefanSatellite: id,
+		nodeID:          nodeID,
 		db:              db,
 		reputationDB:    reputationDB,
 		satellitesDB:    satelliteDB,
 		trust:           trust,
+		notifications:   notifications,
 	}, nil
 }
 
@@ -289,6 +297,67 @@ func (service *Service) AllSatellitesPayoutPeriod(ctx context.Context, period st
 	return result, nil
 }
 
+// SatellitePayoutStatement retrieves the payout statement of specific satellite for specific month,
+// which consists of the paystub and the payment receipt synced from the satellite.
+func (service *Service) SatellitePayoutStatement(ctx context.Context, satelliteID storj.NodeID, period string) (_ *PayoutStatement, err error) {
+	defer mon.Task()(&ctx, &satelliteID, &period)(&err)
+
+	if _, err := date.PeriodToTime(period); err != nil {
+		return nil, ErrBadPeriod.Wrap(err)
+	}
+
+	payStub, err := service.db.GetPayStub(ctx, satelliteID, period)
+	if err != nil {
+		if ErrNoPayStubForPeriod.Has(err) {
+			return nil, err
+		}
+		return nil, ErrPayoutService.Wrap(err)
+	}
+
+	receipt, err := service.db.GetReceipt(ctx, satelliteID, period)
+	if err != nil && !ErrNoPayStubForPeriod.Has(err) {
+		return nil, ErrPayoutService.Wrap(err)
+	}
+
+	satelliteURL, err := service.trust.GetNodeURL(ctx, satelliteID)
+	if err != nil && !errs.Is(err, trust.ErrUntrusted) {
+		return nil, ErrPayoutService.Wrap(err)
+	}
+
+	payStub.UsageAtRestTbM()
+
+	return &PayoutStatement{
+		SatelliteID:  satelliteID,
+		SatelliteURL: satelliteURL.Address,
+		Period:       period,
+		PayStub:      *payStub,
+		Receipt:      receipt,
+	}, nil
+}
+
+// VerifyPayoutStatement verifies that the payout statement was signed by a
+// trusted satellite for this node and returns its header.
+func (service *Service) VerifyPayoutStatement(ctx context.Context, signed payoutstatement.Signed) (_ payoutstatement.Header, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// the satellite is taken from the statement to find the signee, the
+	// signature verification below confirms it.
+	var unverified payoutstatement.Header
+	if err := json.Unmarshal(signed.Statement, &unverified); err != nil {
+		return payoutstatement.Header{}, payoutstatement.Error.Wrap(err)
+	}
+
+	signee, err := service.trust.GetSignee(ctx, unverified.SatelliteID)
+	if err != nil {
+		if errs.Is(err, trust.ErrUntrusted) {
+			return payoutstatement.Header{}, err
+		}
+		return payoutstatement.Header{}, ErrPayoutService.Wrap(err)
+	}
+
+	return payoutstatement.Verify(ctx, signee, service.nodeID, signed, nil)
+}
+
 // HeldAmountHistory retrieves held amount history for all satellites.
 func (service *Service) HeldAmountHistory(ctx context.Context) (_ []HeldAmountHistory, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -19,6 +19,7 @@ import (
 	"storj.io/common/testrand"
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/notifications"
 	"storj.io/storj/storagenode/payouts"
 	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
 	"storj.io/storj/storagenode/trust"
@@ -126,7 +127,7 @@ func TestServiceHeldAmountHistory(t *testing.T) {
 			},
 		}
 
-		service, err := payouts.NewService(log, payoutsDB, db.Reputation(), db.Satellites(), pool)
+		service, err := payouts.NewService(log, payoutsDB, db.Reputation(), db.Satellites(), pool, nil, testrand.NodeID())
 		require.NoError(t, err)
 
 		history, err := service.HeldAmountHistory(ctx)
@@ -269,3 +270,59 @@ func TestService_AllSatellitesPayoutPeriod(t *testing.T) {
 		}
 	})
 }
+
+func TestServiceStorePayStubsNotifications(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		notificationService := notifications.NewService(log, db.Notifications())
+
+		service, err := payouts.NewService(log, db.Payout(), db.Reputation(), db.Satellites(), nil, notificationService, testrand.NodeID())
+		require.NoError(t, err)
+
+		satelliteID := testrand.NodeID()
+		paystub := func(period string, held, disposed int64) payouts.PayStub {
+			return payouts.PayStub{
+				SatelliteID:  satelliteID,
+				Period:       period,
+				CompAtRest:   1000,
+				SurgePercent: 100,
+				Held:         held,
+				Disposed:     disposed,
+			}
+		}
+
+		listNotifications := func() []notifications.Notification {
+			page, err := db.Notifications().List(ctx, notifications.Cursor{Limit: 10, Page: 1})
+			require.NoError(t, err)
+			return page.Notifications
+		}
+
+		// the initial sync of the history doesn't notify.
+		require.NoError(t, service.StorePayStubs(ctx, satelliteID, []payouts.PayStub{
+			paystub("2021-01", 750, 0),
+			paystub("2021-02", 750, 0),
+			paystub("2021-03", 500, 0),
+		}))
+		require.Empty(t, listNotifications())
+
+		// storing the same paystub again doesn't notify.
+		require.NoError(t, service.StorePayStubs(ctx, satelliteID, []payouts.PayStub{paystub("2021-03", 500, 0)}))
+		require.Empty(t, listNotifications())
+
+		require.NoError(t, service.StorePayStubs(ctx, satelliteID, []payouts.PayStub{paystub("2021-04", 250, 1500)}))
+		notified := listNotifications()
+		require.Len(t, notified, 2)
+		for _, notification := range notified {
+			require.Equal(t, notifications.TypeHeldAmount, notification.Type)
+			require.Equal(t, satelliteID, notification.SenderID)
+		}
+
+		events, err := service.HeldAmountEvents(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []payouts.HeldAmountEvent{
+			{SatelliteID: satelliteID, Period: "2021-03", Type: payouts.HeldRateChanged, HeldRate: 50, PreviousHeldRate: 75},
+			{SatelliteID: satelliteID, Period: "2021-04", Type: payouts.HeldAmountDisposed, Disposed: 1500, HeldRate: 25, PreviousHeldRate: 50},
+			{SatelliteID: satelliteID, Period: "2021-04", Type: payouts.HeldRateChanged, Disposed: 1500, HeldRate: 25, PreviousHeldRate: 50},
+		}, events)
+	})
+}
@@ -25,6 +25,7 @@ import (
 	"storj.io/common/peertls/tlsopts"
 	"storj.io/common/process"
 	"storj.io/common/rpc"
+	"storj.io/common/signing"
 	"storj.io/common/storj"
 	"storj.io/common/version"
 	"storj.io/storj/private/emptyfs"
@@ -45,6 +46,7 @@ import (
 	"storj.io/storj/storagenode/healthcheck"
 	"storj.io/storj/storagenode/inspector"
 	"storj.io/storj/storagenode/internalpb"
+	"storj.io/storj/storagenode/logging"
 	"storj.io/storj/storagenode/monitor"
 	"storj.io/storj/storagenode/multinode"
 	"storj.io/storj/storagenode/nodestats"
@@ -61,6 +63,7 @@ import (
 	"storj.io/storj/storagenode/pricing"
 	"storj.io/storj/storagenode/reputation"
 	"storj.io/storj/storagenode/retain"
+	"storj.io/storj/storagenode/satellitepause"
 	"storj.io/storj/storagenode/satellites"
 	"storj.io/storj/storagenode/storagenodedb"
 	"storj.io/storj/storagenode/storageusage"
@@ -104,6 +107,8 @@ type DB interface {
 	UsedSpacePerPrefix() pieces.UsedSpacePerPrefixDB
 
 	Preflight(ctx context.Context) error
+	// DatabaseHealth returns the tracker of corrupt databases.
+	DatabaseHealth() *preflight.DatabaseHealth
 }
 
 // Config is all the configuration parameters for a Storage Node.
@@ -141,6 +146,8 @@ type Config struct {
 	GracefulExit gracefulexit.Config
 
 	ForgetSatellite forgetsatellite.Config
+
+	Logging logging.Config
 }
 
 // DatabaseConfig returns the storagenodedb.Config that should be used with this Config.
@@ -205,6 +212,7 @@ func isAddressValid(addrstring string) error {
 type Peer struct {
 	// core dependencies
 	Log         *zap.Logger
+	LogLevels   *logging.Levels
 	Identity    *identity.FullIdentity
 	DB          DB
 	UsedSerials *usedserials.Table
@@ -255,6 +263,7 @@ type Peer struct {
 	Storage2 struct {
 		// TODO: lift things outside of it to organize better
 		Trust          *trust.Pool
+		Pauses         *satellitepause.Service
 		Store          *pieces.Store
 		TrashChore     *pieces.TrashChore
 		BlobsCache     *pieces.BlobsUsageCache
@@ -321,11 +330,15 @@ type Peer struct {
 }
 
 // New creates a new Storage Node.
-func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB extensions.RevocationDB, config Config, versionInfo version.Info, atomicLogLevel *zap.AtomicLevel) (*Peer, error) {
+//
+// logLevels are the subsystem log levels used by log, which can be changed with the console API.
+// It's nil when the subsystem logging isn't enabled.
+func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB extensions.RevocationDB, config Config, versionInfo version.Info, atomicLogLevel *zap.AtomicLevel, logLevels *logging.Levels) (*Peer, error) {
 	peer := &Peer{
-		Log:      log,
-		Identity: full,
-		DB:       db,
+		Log:       log,
+		LogLevels: logLevels,
+		Identity:  full,
+		DB:        db,
 
 		Servers:  lifecycle.NewGroup(process.NamedLog(log, "servers")),
 		Services: lifecycle.NewGroup(process.NamedLog(log, "services")),
@@ -412,6 +425,12 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			Name: "trust",
 			Run:  peer.Storage2.Trust.Run,
 		})
+
+		peer.Storage2.Pauses = satellitepause.NewService(process.NamedLog(log, "satellitepause"), peer.DB.Satellites(), peer.Storage2.Trust, signing.SignerFromFullIdentity(peer.Identity))
+		peer.Services.Add(lifecycle.Item{
+			Name: "satellitepause",
+			Run:  peer.Storage2.Pauses.Run,
+		})
 	}
 
 	{ // setup debug
@@ -482,7 +501,21 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 		peer.Contact.QUICStats = contact.NewQUICStats(peer.Server.IsQUICEnabled())
 
 		tags := pb.SignedNodeTagSets(config.Contact.Tags)
-		peer.Contact.Service = contact.NewService(process.NamedLog(peer.Log, "contact:service"), peer.Dialer, self, peer.Storage2.Trust, peer.Contact.QUICStats, &tags)
+		if config.Contact.MaintenanceWindow != "" {
+			maintenanceTag, err := contact.SignMaintenanceWindow(context.Background(), signing.SignerFromFullIdentity(peer.Identity), peer.ID(), config.Contact.MaintenanceWindow)
+			if err != nil {
+				return nil, errs.Combine(err, peer.Close())
+			}
+			tags.Tags = append(tags.Tags, maintenanceTag)
+		}
+		if config.Contact.MaxConcurrentUploads > 0 {
+			uploadsTag, err := contact.SignMaxConcurrentUploads(context.Background(), signing.SignerFromFullIdentity(peer.Identity), peer.ID(), config.Contact.MaxConcurrentUploads)
+			if err != nil {
+				return nil, errs.Combine(err, peer.Close())
+			}
+			tags.Tags = append(tags.Tags, uploadsTag)
+		}
+		peer.Contact.Service = contact.NewService(process.NamedLog(peer.Log, "contact:service"), peer.Dialer, self, peer.Storage2.Trust, peer.Contact.QUICStats, &tags, peer.Storage2.Pauses)
 
 		peer.Contact.Chore = contact.NewChore(process.NamedLog(peer.Log, "contact:chore"), config.Contact.Interval, peer.Contact.Service)
 		peer.Services.Add(lifecycle.Item{
@@ -570,12 +603,14 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 		peer.Storage2.Monitor = monitor.NewService(
 			process.NamedLog(log, "piecestore:monitor"),
 			peer.Storage2.Store,
+			peer.Storage2.TrashChore,
 			peer.Contact.Service,
 			config.Storage.AllocatedDiskSpace.Int64(),
 			// TODO: use config.Storage.Monitor.Interval, but for some reason is not set
 			config.Storage.KBucketRefreshInterval,
 			peer.Contact.Chore.Trigger,
 			config.Storage2.Monitor,
+			peer.DB.DatabaseHealth(),
 		)
 		peer.Services.Add(lifecycle.Item{
 			Name:  "piecestore:monitor",
@@ -625,7 +660,9 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.OrdersStore,
 			peer.Bandwidth.Cache,
 			peer.UsedSerials,
+			peer.Storage2.Pauses,
 			config.Storage2,
+			peer.DB.DatabaseHealth(),
 		)
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
@@ -675,6 +712,8 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.DB.Reputation(),
 			peer.DB.Satellites(),
 			peer.Storage2.Trust,
+			peer.Notifications.Service,
+			peer.Identity.ID,
 		)
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
@@ -714,6 +753,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			},
 			peer.NodeStats.Service,
 			peer.Payout.Endpoint,
+			peer.Payout.Service,
 			peer.Reputation,
 			peer.Storage2.Trust,
 		)
@@ -761,6 +801,8 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			config.Operator.WalletFeatures,
 			port,
 			peer.Contact.QUICStats,
+			peer.Storage2.TrashChore,
+			peer.DB.DatabaseHealth(),
 		)
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
@@ -785,6 +827,8 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.Notifications.Service,
 			peer.Console.Service,
 			peer.Payout.Service,
+			peer.Storage2.Pauses,
+			peer.LogLevels,
 			peer.Console.Listener,
 		)
 
@@ -30,6 +30,7 @@ type TrashChore struct {
 	mu         sync.Mutex
 	done       bool
 	satellites map[storj.NodeID]*sync2.Workplace
+	restoring  map[storj.NodeID]struct{}
 }
 
 const (
@@ -49,6 +50,7 @@ func NewTrashChore(log *zap.Logger, choreInterval, trashExpiryInterval time.Dura
 
 		Cycle:      sync2.NewCycle(choreInterval),
 		satellites: map[storj.NodeID]*sync2.Workplace{},
+		restoring:  map[storj.NodeID]struct{}{},
 	}
 }
 
@@ -131,6 +133,9 @@ func (chore *TrashChore) StartRestore(ctx context.Context, satellite storj.NodeI
 	place.Start(chore.root, jobRestoreTrash, func(jobID interface{}) bool {
 		return jobID == jobEmptyTrash
 	}, func(ctx context.Context) {
+		chore.setRestoring(satellite, true)
+		defer chore.setRestoring(satellite, false)
+
 		chore.log.Info("restore trash started", zap.Stringer("Satellite ID", satellite))
 		err := chore.store.RestoreTrash(ctx, satellite)
 		if err != nil {
@@ -143,6 +148,24 @@ func (chore *TrashChore) StartRestore(ctx context.Context, satellite storj.NodeI
 	return nil
 }
 
+// RestoreInProgress returns true when a trash restore is running for any satellite.
+func (chore *TrashChore) RestoreInProgress() bool {
+	chore.mu.Lock()
+	defer chore.mu.Unlock()
+	return len(chore.restoring) > 0
+}
+
+// setRestoring marks whether a trash restore is running for the specified satellite.
+func (chore *TrashChore) setRestoring(satellite storj.NodeID, restoring bool) {
+	chore.mu.Lock()
+	defer chore.mu.Unlock()
+	if restoring {
+		chore.restoring[satellite] = struct{}{}
+	} else {
+		delete(chore.restoring, satellite)
+	}
+}
+
 // ensurePlace creates a work place for the specified satellite.
 func (chore *TrashChore) ensurePlace(satellite storj.NodeID) *sync2.Workplace {
 	chore.mu.Lock()
@@ -41,7 +41,9 @@ import (
 	"storj.io/storj/storagenode/orders/ordersfile"
 	"storj.io/storj/storagenode/pieces"
 	"storj.io/storj/storagenode/piecestore/usedserials"
+	"storj.io/storj/storagenode/preflight"
 	"storj.io/storj/storagenode/retain"
+	"storj.io/storj/storagenode/satellitepause"
 	"storj.io/storj/storagenode/trust"
 	"storj.io/uplink/private/piecestore"
 )
@@ -75,6 +77,8 @@ type Config struct {
 	ReportCapacityThreshold memory.Size   `help:"threshold below which to immediately notify satellite of capacity" default:"5GB" hidden:"true"`
 	MaxUsedSerialsSize      memory.Size   `help:"amount of memory allowed for used serials store - once surpassed, serials will be dropped at random" default:"1MB"`
 
+	RejectUploadsDuringTrashRestore bool `help:"reject new uploads while a trash restore is in progress" default:"false"`
+
 	MinUploadSpeed                    memory.Size   `help:"a client upload speed should not be lower than MinUploadSpeed in bytes-per-second (E.g: 1Mb), otherwise, it will be flagged as slow-connection and potentially be closed" default:"0Mb"`
 	MinUploadSpeedGraceDuration       time.Duration `help:"if MinUploadSpeed is configured, after a period of time after the client initiated the upload, the server will flag unusually slow upload client" default:"0h0m10s"`
 	MinUploadSpeedCongestionThreshold float64       `help:"if the portion defined by the total number of alive connection per MaxConcurrentRequest reaches this threshold, a slow upload client will no longer be monitored and flagged" default:"0.8"`
@@ -106,16 +110,18 @@ type Endpoint struct {
 
 	store        *pieces.Store
 	trashChore   *pieces.TrashChore
+	dbHealth     *preflight.DatabaseHealth
 	usage        bandwidth.DB
 	ordersStore  *orders.FileStore
 	usedSerials  *usedserials.Table
 	pieceDeleter *pieces.Deleter
+	pauses       *satellitepause.Service
 
 	liveRequests int32
 }
 
 // NewEndpoint creates a new piecestore endpoint.
-func NewEndpoint(log *zap.Logger, ident *identity.FullIdentity, trust *trust.Pool, monitor *monitor.Service, retain *retain.Service, pingStats pingStatsSource, store *pieces.Store, trashChore *pieces.TrashChore, pieceDeleter *pieces.Deleter, ordersStore *orders.FileStore, usage bandwidth.DB, usedSerials *usedserials.Table, config Config) (*Endpoint, error) {
+func NewEndpoint(log *zap.Logger, ident *identity.FullIdentity, trust *trust.Pool, monitor *monitor.Service, retain *retain.Service, pingStats pingStatsSource, store *pieces.Store, trashChore *pieces.TrashChore, pieceDeleter *pieces.Deleter, ordersStore *orders.FileStore, usage bandwidth.DB, usedSerials *usedserials.Table, pauses *satellitepause.Service, config Config, dbHealth *preflight.DatabaseHealth) (*Endpoint, error) {
 	return &Endpoint{
 		log:    log,
 		config: config,
@@ -128,10 +134,12 @@ func NewEndpoint(log *zap.Logger, ident *identity.FullIdentity, trust *trust.Poo
 
 		store:        store,
 		trashChore:   trashChore,
+		dbHealth:     dbHealth,
 		ordersStore:  ordersStore,
 		usage:        usage,
 		usedSerials:  usedSerials,
 		pieceDeleter: pieceDeleter,
+		pauses:       pauses,
 
 		liveRequests: 0,
 	}, nil
@@ -300,6 +308,16 @@ func (endpoint *Endpoint) Upload(stream pb.DRPCPiecestore_UploadStream) (err err
 		return rpcstatus.Error(rpcstatus.Unavailable, errMsg)
 	}
 
+	if endpoint.config.RejectUploadsDuringTrashRestore && endpoint.trashChore != nil && endpoint.trashChore.RestoreInProgress() {
+		mon.Event("upload_rejected_trash_restore")
+		return rpcstatus.Error(rpcstatus.Unavailable, "storage node is restoring trash")
+	}
+
+	if endpoint.dbHealth.ReadOnly() {
+		mon.Event("upload_rejected_read_only")
+		return rpcstatus.Error(rpcstatus.Unavailable, "storage node is in read-only mode because of a corrupt database")
+	}
+
 	startTime := time.Now().UTC()
 
 	// TODO: set maximum message size
@@ -327,6 +345,10 @@ func (endpoint *Endpoint) Upload(stream pb.DRPCPiecestore_UploadStream) (err err
 		return rpcstatus.Errorf(rpcstatus.InvalidArgument, "expected put or put repair action got %v", limit.Action)
 	}
 
+	if err := endpoint.checkPaused(ctx, limit); err != nil {
+		return err
+	}
+
 	if err := endpoint.verifyOrderLimit(ctx, limit); err != nil {
 		return err
 	}
@@ -590,6 +612,19 @@ func (endpoint *Endpoint) Upload(stream pb.DRPCPiecestore_UploadStream) (err err
 	}
 }
 
+// checkPaused returns an error when the operator paused serving the action for the satellite.
+func (endpoint *Endpoint) checkPaused(ctx context.Context, limit *pb.OrderLimit) error {
+	rejects, err := endpoint.pauses.Rejects(ctx, limit.SatelliteId, limit.Action)
+	if err != nil {
+		return rpcstatus.Wrap(rpcstatus.Unavailable, err)
+	}
+	if rejects {
+		mon.Event("request_rejected_satellite_paused")
+		return rpcstatus.Errorf(rpcstatus.Unavailable, "storage node paused serving %v of the satellite", limit.Action)
+	}
+	return nil
+}
+
 // isCongested identifies state of congestion. If the total number of
 // connections is above 80% of the MaxConcurrentRequests, then it is defined
 // as congestion.
@@ -638,6 +673,10 @@ func (endpoint *Endpoint) Download(stream pb.DRPCPiecestore_DownloadStream) (err
 			"expected get or get repair or audit action got %v", limit.Action)
 	}
 
+	if err := endpoint.checkPaused(ctx, limit); err != nil {
+		return err
+	}
+
 	if chunk.ChunkSize > limit.Limit {
 		return rpcstatus.Errorf(rpcstatus.InvalidArgument,
 			"requested more that order limit allows, limit=%v requested=%v", limit.Limit, chunk.ChunkSize)
@@ -5,6 +5,7 @@ package piecestore_test
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"os"
 	"strconv"
@@ -31,6 +32,7 @@ import (
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/storagenode"
 	"storj.io/storj/storagenode/bandwidth"
+	"storj.io/storj/storagenode/blobstore"
 	"storj.io/storj/storagenode/blobstore/testblobs"
 	"storj.io/uplink/private/piecestore"
 )
@@ -771,6 +773,88 @@ func TestTooManyRequests(t *testing.T) {
 	})
 }
 
+// restoreBlockingDB blocks trash restores until release is closed.
+type restoreBlockingDB struct {
+	storagenode.DB
+	blobs *restoreBlockingBlobs
+}
+
+func (db *restoreBlockingDB) Pieces() blobstore.Blobs { return db.blobs }
+
+type restoreBlockingBlobs struct {
+	blobstore.Blobs
+	release chan struct{}
+}
+
+func (blobs *restoreBlockingBlobs) RestoreTrash(ctx context.Context, namespace []byte) ([][]byte, error) {
+	select {
+	case <-blobs.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return blobs.Blobs.RestoreTrash(ctx, namespace)
+}
+
+func TestRejectUploadsDuringTrashRestore(t *testing.T) {
+	release := make(chan struct{})
+
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			StorageNodeDB: func(index int, db storagenode.DB, log *zap.Logger) (storagenode.DB, error) {
+				return &restoreBlockingDB{
+					DB:    db,
+					blobs: &restoreBlockingBlobs{Blobs: db.Pieces(), release: release},
+				}, nil
+			},
+			StorageNode: func(index int, config *storagenode.Config) {
+				config.Storage2.RejectUploadsDuringTrashRestore = true
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		node := planet.StorageNodes[0]
+		trashChore := node.Storage2.TrashChore
+
+		require.NoError(t, trashChore.StartRestore(ctx, planet.Satellites[0].ID()))
+		require.Eventually(t, trashChore.RestoreInProgress, 10*time.Second, 10*time.Millisecond)
+
+		client, err := planet.Uplinks[0].DialPiecestore(ctx, node)
+		require.NoError(t, err)
+		defer ctx.Check(client.Close)
+
+		upload := func() error {
+			orderLimit, piecePrivateKey := GenerateOrderLimit(
+				t,
+				planet.Satellites[0].ID(),
+				node.ID(),
+				testrand.PieceID(),
+				pb.PieceAction_PUT,
+				testrand.SerialNumber(),
+				24*time.Hour,
+				24*time.Hour,
+				int64(10000),
+			)
+			signer := signing.SignerFromFullIdentity(planet.Satellites[0].Identity)
+			orderLimit, err := signing.SignOrderLimit(ctx, signer, orderLimit)
+			require.NoError(t, err)
+
+			_, err = client.UploadReader(ctx, orderLimit, piecePrivateKey, bytes.NewReader(make([]byte, orderLimit.Limit)))
+			return err
+		}
+
+		err = upload()
+		require.Error(t, err)
+		require.True(t, errs2.IsRPC(err, rpcstatus.Unavailable))
+
+		close(release)
+		require.Eventually(t, func() bool {
+			return !trashChore.RestoreInProgress()
+		}, 10*time.Second, 10*time.Millisecond)
+
+		require.NoError(t, upload())
+	})
+}
+
 func GenerateOrderLimit(t *testing.T, satellite storj.NodeID, storageNode storj.NodeID, pieceID storj.PieceID, action pb.PieceAction, serialNumber storj.SerialNumber, pieceExpiration, orderExpiration time.Duration, limit int64) (*pb.OrderLimit, storj.PiecePrivateKey) {
 	piecePublicKey, piecePrivateKey, err := storj.NewPieceKey()
 	require.NoError(t, err)
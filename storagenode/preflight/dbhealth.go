@@ -0,0 +1,94 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package preflight
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// DatabaseRepairInstructions explains how an operator can repair a corrupt database.
+const DatabaseRepairInstructions = "Stop the node, back up the database file, and repair it with the sqlite3 command line tool " +
+	"(for example `sqlite3 broken.db \".recover\" | sqlite3 repaired.db`), then replace the broken file with the repaired one. " +
+	"If the database can't be repaired, move it out of the storage directory and restart the node to recreate it empty."
+
+// CorruptDatabase describes a database which was found to be corrupt.
+type CorruptDatabase struct {
+	Name       string    `json:"name"`
+	Error      string    `json:"error"`
+	DetectedAt time.Time `json:"detectedAt"`
+}
+
+// DatabaseHealth tracks databases which were found to be corrupt.
+//
+// While any database is corrupt the node runs in read-only mode: downloads
+// are still served, but uploads are rejected.
+type DatabaseHealth struct {
+	mu      sync.Mutex
+	corrupt map[string]CorruptDatabase
+}
+
+// NewDatabaseHealth creates a new DatabaseHealth with all databases healthy.
+func NewDatabaseHealth() *DatabaseHealth {
+	return &DatabaseHealth{
+		corrupt: make(map[string]CorruptDatabase),
+	}
+}
+
+// MarkCorrupt marks a database as corrupt.
+func (health *DatabaseHealth) MarkCorrupt(dbName string, err error) {
+	health.mu.Lock()
+	defer health.mu.Unlock()
+
+	if _, ok := health.corrupt[dbName]; ok {
+		return
+	}
+
+	mon.Event("database_corrupt")
+	health.corrupt[dbName] = CorruptDatabase{
+		Name:       dbName,
+		Error:      err.Error(),
+		DetectedAt: time.Now(),
+	}
+}
+
+// MarkHealthy marks a database as healthy, e.g. after it was rebuilt.
+func (health *DatabaseHealth) MarkHealthy(dbName string) {
+	health.mu.Lock()
+	defer health.mu.Unlock()
+
+	delete(health.corrupt, dbName)
+}
+
+// ReadOnly returns whether the node should reject uploads because of corrupt databases.
+func (health *DatabaseHealth) ReadOnly() bool {
+	if health == nil {
+		return false
+	}
+
+	health.mu.Lock()
+	defer health.mu.Unlock()
+
+	return len(health.corrupt) > 0
+}
+
+// Corrupt returns the databases which are currently corrupt, sorted by name.
+func (health *DatabaseHealth) Corrupt() []CorruptDatabase {
+	if health == nil {
+		return nil
+	}
+
+	health.mu.Lock()
+	defer health.mu.Unlock()
+
+	corrupt := make([]CorruptDatabase, 0, len(health.corrupt))
+	for _, db := range health.corrupt {
+		corrupt = append(corrupt, db)
+	}
+	sort.Slice(corrupt, func(i, k int) bool {
+		return corrupt[i].Name < corrupt[k].Name
+	})
+	return corrupt
+}
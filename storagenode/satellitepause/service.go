@@ -0,0 +1,235 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package satellitepause implements pausing the serving of specific
+// satellites by the node operator, e.g. while handling a dispute or a
+// migration with one satellite.
+package satellitepause
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/nodetag"
+	"storj.io/common/pb"
+	"storj.io/common/signing"
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/storj/storagenode/satellites"
+	"storj.io/storj/storagenode/trust"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the default error class for the package.
+	Error = errs.Class("satellite pause")
+)
+
+const (
+	// Tag is the name of the node tag which announces to a satellite that
+	// the node paused serving it.
+	Tag = "paused"
+
+	// TagUploads is the value of the tag when only the uploads are paused.
+	TagUploads = "uploads"
+	// TagUploadsAndDownloads is the value of the tag when the uploads and the downloads are paused.
+	TagUploadsAndDownloads = "uploads,downloads"
+)
+
+// Service keeps the satellites whose serving is paused.
+//
+// A paused satellite can't upload pieces to the node. When the downloads are
+// paused too, the node doesn't serve the downloads and the repair downloads
+// of the satellite, but the audits are still served, so the node isn't
+// disqualified while paused.
+//
+// architecture: Service
+type Service struct {
+	log    *zap.Logger
+	db     satellites.DB
+	trust  *trust.Pool
+	signer signing.Signer
+
+	loaded sync2.Fence
+
+	mu     sync.RWMutex
+	paused map[storj.NodeID]satellites.Pause
+}
+
+// NewService creates a new satellite pause service.
+func NewService(log *zap.Logger, db satellites.DB, trust *trust.Pool, signer signing.Signer) *Service {
+	return &Service{
+		log:    log,
+		db:     db,
+		trust:  trust,
+		signer: signer,
+		paused: map[storj.NodeID]satellites.Pause{},
+	}
+}
+
+// Run loads the persisted pauses.
+func (service *Service) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pauses, err := service.db.ListPauses(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	service.mu.Lock()
+	for _, pause := range pauses {
+		service.paused[pause.SatelliteID] = pause
+		service.log.Info("serving of satellite is paused",
+			zap.Stringer("Satellite ID", pause.SatelliteID),
+			zap.Bool("downloads", pause.Downloads))
+	}
+	service.mu.Unlock()
+
+	service.loaded.Release()
+	return nil
+}
+
+// Pause pauses serving the satellite. The uploads are always paused, the
+// downloads only when downloads is true.
+func (service *Service) Pause(ctx context.Context, satelliteID storj.NodeID, downloads bool) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := service.trust.VerifySatelliteID(ctx, satelliteID); err != nil {
+		return Error.Wrap(err)
+	}
+
+	if !service.loaded.Wait(ctx) {
+		return ctx.Err()
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	pause, ok := service.paused[satelliteID]
+	if !ok {
+		pause = satellites.Pause{
+			SatelliteID: satelliteID,
+			PausedAt:    time.Now().UTC(),
+		}
+	}
+	pause.Downloads = downloads
+
+	if err := service.db.SetPause(ctx, pause); err != nil {
+		return Error.Wrap(err)
+	}
+	service.paused[satelliteID] = pause
+
+	service.log.Info("paused serving of satellite",
+		zap.Stringer("Satellite ID", satelliteID),
+		zap.Bool("downloads", downloads))
+	return nil
+}
+
+// Resume resumes serving the satellite.
+func (service *Service) Resume(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !service.loaded.Wait(ctx) {
+		return ctx.Err()
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	if err := service.db.DeletePause(ctx, satelliteID); err != nil {
+		return Error.Wrap(err)
+	}
+	delete(service.paused, satelliteID)
+
+	service.log.Info("resumed serving of satellite", zap.Stringer("Satellite ID", satelliteID))
+	return nil
+}
+
+// List returns the pauses of the satellites.
+func (service *Service) List(ctx context.Context) (_ []satellites.Pause, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !service.loaded.Wait(ctx) {
+		return nil, ctx.Err()
+	}
+
+	service.mu.RLock()
+	defer service.mu.RUnlock()
+
+	pauses := make([]satellites.Pause, 0, len(service.paused))
+	for _, pause := range service.paused {
+		pauses = append(pauses, pause)
+	}
+	return pauses, nil
+}
+
+// Get returns the pause of the satellite, if it's paused.
+func (service *Service) Get(ctx context.Context, satelliteID storj.NodeID) (_ satellites.Pause, paused bool, err error) {
+	if service == nil {
+		return satellites.Pause{}, false, nil
+	}
+	if !service.loaded.Wait(ctx) {
+		return satellites.Pause{}, false, ctx.Err()
+	}
+
+	service.mu.RLock()
+	defer service.mu.RUnlock()
+
+	pause, paused := service.paused[satelliteID]
+	return pause, paused, nil
+}
+
+// Rejects returns whether the satellite is paused for the action.
+func (service *Service) Rejects(ctx context.Context, satelliteID storj.NodeID, action pb.PieceAction) (bool, error) {
+	pause, paused, err := service.Get(ctx, satelliteID)
+	if err != nil || !paused {
+		return false, err
+	}
+
+	switch action {
+	case pb.PieceAction_PUT, pb.PieceAction_PUT_REPAIR:
+		return true, nil
+	case pb.PieceAction_GET, pb.PieceAction_GET_REPAIR:
+		return pause.Downloads, nil
+	default:
+		return false, nil
+	}
+}
+
+// SignedTag returns the node tag announcing the pause state to the satellite.
+// The value of the tag is empty when the satellite isn't paused, so the
+// satellite forgets a pause after it was resumed.
+func (service *Service) SignedTag(ctx context.Context, satelliteID storj.NodeID) (_ *pb.SignedNodeTagSet, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pause, paused, err := service.Get(ctx, satelliteID)
+	if err != nil {
+		return nil, err
+	}
+
+	var value string
+	switch {
+	case paused && pause.Downloads:
+		value = TagUploadsAndDownloads
+	case paused:
+		value = TagUploads
+	}
+
+	signed, err := nodetag.Sign(ctx, &pb.NodeTagSet{
+		NodeId:   service.signer.ID().Bytes(),
+		SignedAt: time.Now().Unix(),
+		Tags: []*pb.Tag{
+			{
+				Name:  Tag,
+				Value: []byte(value),
+			},
+		},
+	}, service.signer)
+	return signed, Error.Wrap(err)
+}
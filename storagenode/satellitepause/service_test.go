@@ -0,0 +1,80 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitepause_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/pb"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/storagenode/satellitepause"
+)
+
+func TestPauseSatellite(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		node := planet.StorageNodes[0]
+		service := node.Storage2.Pauses
+
+		node.Contact.Chore.Pause(ctx)
+
+		pausedTag := func() string {
+			require.NoError(t, node.Contact.Service.PingSatellites(ctx, 10*time.Second))
+
+			tags, err := sat.Overlay.Service.GetNodeTags(ctx, node.ID())
+			require.NoError(t, err)
+			for _, tag := range tags {
+				if tag.Name == satellitepause.Tag {
+					return string(tag.Value)
+				}
+			}
+			return "<missing>"
+		}
+
+		require.Error(t, service.Pause(ctx, testrand.NodeID(), false))
+
+		require.NoError(t, service.Pause(ctx, sat.ID(), false))
+		rejects, err := service.Rejects(ctx, sat.ID(), pb.PieceAction_PUT)
+		require.NoError(t, err)
+		require.True(t, rejects)
+		rejects, err = service.Rejects(ctx, sat.ID(), pb.PieceAction_GET)
+		require.NoError(t, err)
+		require.False(t, rejects)
+		require.Equal(t, satellitepause.TagUploads, pausedTag())
+
+		require.NoError(t, service.Pause(ctx, sat.ID(), true))
+		rejects, err = service.Rejects(ctx, sat.ID(), pb.PieceAction_GET_REPAIR)
+		require.NoError(t, err)
+		require.True(t, rejects)
+		// the audits are served, so the node isn't disqualified while paused.
+		rejects, err = service.Rejects(ctx, sat.ID(), pb.PieceAction_GET_AUDIT)
+		require.NoError(t, err)
+		require.False(t, rejects)
+		require.Equal(t, satellitepause.TagUploadsAndDownloads, pausedTag())
+
+		// the pause is persisted.
+		pauses, err := node.DB.Satellites().ListPauses(ctx)
+		require.NoError(t, err)
+		require.Len(t, pauses, 1)
+		require.Equal(t, sat.ID(), pauses[0].SatelliteID)
+		require.True(t, pauses[0].Downloads)
+
+		require.NoError(t, service.Resume(ctx, sat.ID()))
+		rejects, err = service.Rejects(ctx, sat.ID(), pb.PieceAction_PUT)
+		require.NoError(t, err)
+		require.False(t, rejects)
+		require.Equal(t, "", pausedTag())
+
+		pauses, err = node.DB.Satellites().ListPauses(ctx)
+		require.NoError(t, err)
+		require.Empty(t, pauses)
+	})
+}
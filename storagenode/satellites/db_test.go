@@ -5,12 +5,14 @@ package satellites_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/satellites"
 	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
 )
 
@@ -34,3 +36,31 @@ func TestSatellitesDB(t *testing.T) {
 		require.Equal(t, satellites[0].Address, "test_addr2")
 	})
 }
+
+func TestSatellitePauses(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		satellitesDB := db.Satellites()
+		id := testrand.NodeID()
+		pausedAt := time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC)
+
+		pauses, err := satellitesDB.ListPauses(ctx)
+		require.NoError(t, err)
+		require.Empty(t, pauses)
+
+		require.NoError(t, satellitesDB.SetPause(ctx, satellites.Pause{SatelliteID: id, PausedAt: pausedAt}))
+		// updating the pause keeps the time it started.
+		require.NoError(t, satellitesDB.SetPause(ctx, satellites.Pause{SatelliteID: id, Downloads: true, PausedAt: pausedAt.Add(time.Hour)}))
+
+		pauses, err = satellitesDB.ListPauses(ctx)
+		require.NoError(t, err)
+		require.Len(t, pauses, 1)
+		require.Equal(t, id, pauses[0].SatelliteID)
+		require.True(t, pauses[0].Downloads)
+		require.True(t, pausedAt.Equal(pauses[0].PausedAt))
+
+		require.NoError(t, satellitesDB.DeletePause(ctx, id))
+		pauses, err = satellitesDB.ListPauses(ctx)
+		require.NoError(t, err)
+		require.Empty(t, pauses)
+	})
+}
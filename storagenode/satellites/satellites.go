@@ -55,6 +55,14 @@ type Satellite struct {
 	Status      Status
 }
 
+// Pause is the pause of serving a satellite set by the node operator.
+type Pause struct {
+	SatelliteID storj.NodeID
+	// Downloads is whether the downloads are paused in addition to the uploads.
+	Downloads bool
+	PausedAt  time.Time
+}
+
 // DB works with satellite database.
 //
 // architecture: Database
@@ -83,4 +91,10 @@ type DB interface {
 	CompleteGracefulExit(ctx context.Context, satelliteID storj.NodeID, finishedAt time.Time, exitStatus Status, completionReceipt []byte) error
 	// ListGracefulExits lists all graceful exit records
 	ListGracefulExits(ctx context.Context) ([]ExitProgress, error)
+	// SetPause inserts or updates the pause of serving a satellite.
+	SetPause(ctx context.Context, pause Pause) error
+	// DeletePause removes the pause of serving a satellite.
+	DeletePause(ctx context.Context, satelliteID storj.NodeID) error
+	// ListPauses lists the satellites whose serving is paused.
+	ListPauses(ctx context.Context) ([]Pause, error)
 }
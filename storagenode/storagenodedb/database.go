@@ -7,6 +7,7 @@ package storagenodedb
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -34,6 +35,7 @@ import (
 	"storj.io/storj/storagenode/payouts"
 	"storj.io/storj/storagenode/pieces"
 	"storj.io/storj/storagenode/pieces/lazyfilewalker"
+	"storj.io/storj/storagenode/preflight"
 	"storj.io/storj/storagenode/pricing"
 	"storj.io/storj/storagenode/reputation"
 	"storj.io/storj/storagenode/satellites"
@@ -61,7 +63,7 @@ type DBContainer interface {
 }
 
 // withTx is a helper method which executes callback in transaction scope.
-func withTx(ctx context.Context, db tagsql.DB, cb func(tx tagsql.Tx) error) error {
+func withTx(ctx context.Context, db tagsql.DB, cb func(tx tagsql.Tx) error) (err error) {
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -136,6 +138,8 @@ type DB struct {
 	SQLDBs map[string]DBContainer
 
 	cache statcache.Cache
+
+	health *preflight.DatabaseHealth
 }
 
 // OpenNew creates a new master database for storage node.
@@ -178,6 +182,8 @@ func OpenNew(ctx context.Context, log *zap.Logger, config Config) (*DB, error) {
 
 		cache: cache,
 
+		health: preflight.NewDatabaseHealth(),
+
 		dbDirectory: filepath.Dir(config.Info2),
 
 		deprecatedInfoDB:       deprecatedInfoDB,
@@ -277,6 +283,8 @@ func OpenExisting(ctx context.Context, log *zap.Logger, config Config) (*DB, err
 
 		cache: cache,
 
+		health: preflight.NewDatabaseHealth(),
+
 		dbDirectory: filepath.Dir(config.Info2),
 
 		deprecatedInfoDB:       deprecatedInfoDB,
@@ -402,7 +410,19 @@ func (db *DB) openDatabaseWithStat(ctx context.Context, dbName string, registerS
 		wal = "&_journal=MEMORY"
 	}
 
-	sqlDB, err := tagsql.Open(ctx, driver, "file:"+path+"?_busy_timeout=10000"+wal)
+	dataSourceName := "file:" + path + "?_busy_timeout=10000" + wal
+	sqlDB, err := tagsql.Open(ctx, driver, dataSourceName)
+	if sqliteutil.IsCorruptError(err) && db.health != nil {
+		// Keep a handle to the corrupt database, so that queries fail instead
+		// of the node refusing to start. Recover decides whether it can be rebuilt.
+		db.health.MarkCorrupt(dbName, err)
+
+		var rawDB *sql.DB
+		rawDB, err = sql.Open(driver, dataSourceName)
+		if err == nil {
+			sqlDB = tagsql.Wrap(rawDB)
+		}
+	}
 	if err != nil {
 		return ErrDatabase.New("%s opening file %q failed: %w", dbName, path, err)
 	}
@@ -2313,6 +2333,19 @@ func (db *DB) Migration(ctx context.Context) *migrate.Migration {
 					`DELETE FROM piece_space_used WHERE satellite_id IS NULL;`,
 				},
 			},
+			{
+				DB:          &db.satellitesDB.DB,
+				Description: "Create satellite_pauses table",
+				Version:     62,
+				Action: migrate.SQL{
+					`CREATE TABLE satellite_pauses (
+						satellite_id BLOB NOT NULL,
+						downloads INTEGER NOT NULL,
+						paused_at TIMESTAMP NOT NULL,
+						PRIMARY KEY (satellite_id)
+					);`,
+				},
+			},
 		},
 	}
 }
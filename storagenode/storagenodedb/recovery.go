@@ -0,0 +1,221 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagenodedb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/shared/dbutil/dbschema"
+	"storj.io/storj/shared/dbutil/sqliteutil"
+	"storj.io/storj/shared/tagsql"
+	"storj.io/storj/storagenode/preflight"
+)
+
+// ErrRebuild represents an error while rebuilding a corrupt database.
+var ErrRebuild = errs.Class("rebuild")
+
+// rebuildableDatabases contains the databases which only hold data derived
+// from the blobs on disk. When such a database is corrupt, it can be recreated
+// empty and the filewalkers repopulate it.
+var rebuildableDatabases = map[string]bool{
+	PieceSpaceUsedDBName:       true,
+	GCFilewalkerProgressDBName: true,
+	UsedSpacePerPrefixDBName:   true,
+	UsedSerialsDBName:          true,
+}
+
+// DatabaseHealth returns the tracker of corrupt databases.
+func (db *DB) DatabaseHealth() *preflight.DatabaseHealth {
+	return db.health
+}
+
+// CheckIntegrity runs a quick integrity check on all databases and returns
+// the ones which are corrupt.
+func (db *DB) CheckIntegrity(ctx context.Context) (corrupt map[string]error, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	corrupt = make(map[string]error)
+	for dbName, dbContainer := range db.SQLDBs {
+		sqlDB := dbContainer.GetDB()
+		if sqlDB == nil {
+			continue
+		}
+
+		var result string
+		err := sqlDB.QueryRowContext(ctx, `PRAGMA quick_check(1)`).Scan(&result)
+		switch {
+		case sqliteutil.IsCorruptError(err):
+			corrupt[dbName] = err
+		case err != nil:
+			return nil, ErrDatabase.New("%s integrity check failed: %w", dbName, err)
+		case result != "ok":
+			corrupt[dbName] = ErrDatabase.New("%s integrity check: %s", dbName, result)
+		}
+	}
+	return corrupt, nil
+}
+
+// Recover checks the integrity of all databases. Corrupt databases which
+// only contain data derived from the blobs are rebuilt, the remaining ones
+// are reported to DatabaseHealth, which puts the node in read-only mode.
+func (db *DB) Recover(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	corrupt, err := db.CheckIntegrity(ctx)
+	if err != nil {
+		return err
+	}
+
+	dbNames := make([]string, 0, len(corrupt))
+	for dbName := range corrupt {
+		dbNames = append(dbNames, dbName)
+	}
+	sort.Strings(dbNames)
+
+	for _, dbName := range dbNames {
+		log := db.log.With(zap.String("database", dbName))
+		log.Error("database is corrupt", zap.Error(corrupt[dbName]))
+
+		if !rebuildableDatabases[dbName] {
+			db.health.MarkCorrupt(dbName, corrupt[dbName])
+			log.Error("database can't be rebuilt automatically, node is running in read-only mode",
+				zap.String("instructions", preflight.DatabaseRepairInstructions))
+			continue
+		}
+
+		if err := db.Rebuild(ctx, dbName); err != nil {
+			db.health.MarkCorrupt(dbName, errs.Combine(corrupt[dbName], err))
+			log.Error("failed to rebuild database, node is running in read-only mode",
+				zap.Error(err),
+				zap.String("instructions", preflight.DatabaseRepairInstructions))
+			continue
+		}
+
+		log.Warn("database was rebuilt, its content will be repopulated from the stored pieces")
+	}
+	return nil
+}
+
+// Rebuild replaces a corrupt database with an empty one using the current
+// schema. The corrupt file is kept next to it for inspection. Only databases
+// whose content can be derived from the blobs can be rebuilt.
+func (db *DB) Rebuild(ctx context.Context, dbName string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	schema, ok := Schema()[dbName]
+	if !rebuildableDatabases[dbName] || !ok {
+		return ErrRebuild.New("%s can't be rebuilt", dbName)
+	}
+
+	if err := db.closeDatabase(dbName); err != nil {
+		return ErrRebuild.Wrap(err)
+	}
+
+	path := db.filepathFromDBName(dbName)
+	backup := fmt.Sprintf("%s.corrupt-%d", path, time.Now().Unix())
+	if err := os.Rename(path, backup); err != nil && !os.IsNotExist(err) {
+		return ErrRebuild.New("%s failed to move %q: %w", dbName, path, err)
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(path + suffix); err != nil && !os.IsNotExist(err) {
+			return ErrRebuild.New("%s failed to remove %q: %w", dbName, path+suffix, err)
+		}
+	}
+
+	if err := db.openDatabase(ctx, dbName); err != nil {
+		return ErrRebuild.Wrap(err)
+	}
+
+	// the rebuilt database must be at the version the migration expects it to be.
+	sqlDB := db.rawDatabaseFromName(dbName)
+	version := -1
+	for _, step := range db.Migration(ctx).Steps {
+		if *step.DB == sqlDB {
+			version = step.Version
+		}
+	}
+
+	err = withTx(ctx, sqlDB, func(tx tagsql.Tx) error {
+		for _, query := range createStatements(schema) {
+			if _, err := tx.ExecContext(ctx, query); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.ExecContext(ctx, `CREATE TABLE `+VersionTable+` (version int, commited_at text)`); err != nil { //nolint:misspell
+			return err
+		}
+		if version >= 0 {
+			_, err := tx.ExecContext(ctx, `INSERT INTO `+VersionTable+` (version, commited_at) VALUES (?, ?)`, //nolint:misspell
+				version, time.Now().String())
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return ErrRebuild.New("%s failed to create schema: %w", dbName, err)
+	}
+
+	db.health.MarkHealthy(dbName)
+	return nil
+}
+
+// createStatements returns the statements creating the tables and indexes of
+// the schema. The schema is the one generated from the migrations, so the
+// rebuilt database matches what Preflight expects.
+func createStatements(schema *dbschema.Schema) []string {
+	var statements []string
+	for _, table := range schema.Tables {
+		var definitions []string
+		for _, column := range table.Columns {
+			definition := column.Name + " " + column.Type
+			if !column.IsNullable {
+				definition += " NOT NULL"
+			}
+			if column.Default != "" {
+				definition += " DEFAULT " + column.Default
+			}
+			if ref := column.Reference; ref != nil {
+				definition += " REFERENCES " + ref.Table + "(" + ref.Column + ")"
+				if ref.OnDelete != "" {
+					definition += " ON DELETE " + ref.OnDelete
+				}
+				if ref.OnUpdate != "" {
+					definition += " ON UPDATE " + ref.OnUpdate
+				}
+			}
+			definitions = append(definitions, definition)
+		}
+		if len(table.PrimaryKey) > 0 {
+			definitions = append(definitions, "PRIMARY KEY ("+strings.Join(table.PrimaryKey, ", ")+")")
+		}
+		for _, unique := range table.Unique {
+			definitions = append(definitions, "UNIQUE ("+strings.Join(unique, ", ")+")")
+		}
+		for _, check := range table.Checks {
+			definitions = append(definitions, "CHECK ("+check+")")
+		}
+		statements = append(statements, "CREATE TABLE "+table.Name+" (\n\t"+strings.Join(definitions, ",\n\t")+"\n)")
+	}
+
+	for _, index := range schema.Indexes {
+		statement := "CREATE INDEX "
+		if index.Unique {
+			statement = "CREATE UNIQUE INDEX "
+		}
+		statement += index.Name + " ON " + index.Table + "(" + strings.Join(index.Columns, ", ") + ")"
+		if index.Partial != "" {
+			statement += " WHERE " + index.Partial
+		}
+		statements = append(statements, statement)
+	}
+	return statements
+}
@@ -219,3 +219,46 @@ func (db *satellitesDB) DeleteSatellite(ctx context.Context, satelliteID storj.N
 	_, err = db.ExecContext(ctx, "DELETE FROM satellites WHERE node_id = ?", satelliteID)
 	return ErrSatellitesDB.Wrap(err)
 }
+
+// SetPause inserts or updates the pause of serving a satellite.
+func (db *satellitesDB) SetPause(ctx context.Context, pause satellites.Pause) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.ExecContext(ctx,
+		`INSERT INTO satellite_pauses (satellite_id, downloads, paused_at) VALUES(?,?,?) ON CONFLICT (satellite_id) DO UPDATE SET downloads = EXCLUDED.downloads`,
+		pause.SatelliteID,
+		pause.Downloads,
+		pause.PausedAt.UTC(),
+	)
+	return ErrSatellitesDB.Wrap(err)
+}
+
+// DeletePause removes the pause of serving a satellite.
+func (db *satellitesDB) DeletePause(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.ExecContext(ctx, "DELETE FROM satellite_pauses WHERE satellite_id = ?", satelliteID)
+	return ErrSatellitesDB.Wrap(err)
+}
+
+// ListPauses lists the satellites whose serving is paused.
+func (db *satellitesDB) ListPauses(ctx context.Context) (pauses []satellites.Pause, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.QueryContext(ctx, "SELECT satellite_id, downloads, paused_at FROM satellite_pauses")
+	if err != nil {
+		return nil, ErrSatellitesDB.Wrap(err)
+	}
+	defer func() {
+		err = ErrSatellitesDB.Wrap(errs.Combine(err, rows.Close()))
+	}()
+
+	for rows.Next() {
+		var pause satellites.Pause
+		if err := rows.Scan(&pause.SatelliteID, &pause.Downloads, &pause.PausedAt); err != nil {
+			return nil, err
+		}
+		pauses = append(pauses, pause)
+	}
+	return pauses, rows.Err()
+}
@@ -661,6 +661,27 @@ func Schema() map[string]*dbschema.Schema {
 						},
 					},
 				},
+				{
+					Name:       "satellite_pauses",
+					PrimaryKey: []string{"satellite_id"},
+					Columns: []*dbschema.Column{
+						{
+							Name:       "downloads",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						{
+							Name:       "paused_at",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						{
+							Name:       "satellite_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+					},
+				},
 				{
 					Name:       "satellites",
 					PrimaryKey: []string{"node_id"},
@@ -0,0 +1,122 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagenodedbtest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+func TestRecover(t *testing.T) {
+	openCorrupted := func(ctx *testcontext.Context, t *testing.T, dbName string) *storagenodedb.DB {
+		log := zaptest.NewLogger(t)
+
+		storageDir := ctx.Dir("storage")
+		cfg := storagenodedb.Config{
+			Storage: storageDir,
+			Info:    filepath.Join(storageDir, "piecestore.db"),
+			Info2:   filepath.Join(storageDir, "info.db"),
+			Pieces:  storageDir,
+
+			TestingDisableWAL: true,
+		}
+
+		db, err := storagenodedb.OpenNew(ctx, log, cfg)
+		require.NoError(t, err)
+		require.NoError(t, db.MigrateToLatest(ctx))
+		require.NoError(t, db.Close())
+
+		garbage := make([]byte, 4096)
+		for i := range garbage {
+			garbage[i] = byte(i)
+		}
+		require.NoError(t, os.WriteFile(filepath.Join(storageDir, dbName+".db"), garbage, 0644))
+
+		db, err = storagenodedb.OpenExisting(ctx, log, cfg)
+		require.NoError(t, err)
+		return db
+	}
+
+	for _, dbName := range []string{
+		storagenodedb.PieceSpaceUsedDBName,
+		storagenodedb.GCFilewalkerProgressDBName,
+		storagenodedb.UsedSpacePerPrefixDBName,
+		storagenodedb.UsedSerialsDBName,
+	} {
+		t.Run("rebuildable "+dbName, func(t *testing.T) {
+			ctx := testcontext.New(t)
+			db := openCorrupted(ctx, t, dbName)
+			defer ctx.Check(db.Close)
+
+			corrupt, err := db.CheckIntegrity(ctx)
+			require.NoError(t, err)
+			require.Contains(t, corrupt, dbName)
+
+			require.NoError(t, db.Recover(ctx))
+			require.False(t, db.DatabaseHealth().ReadOnly())
+
+			require.NoError(t, db.CheckVersion(ctx))
+			require.NoError(t, db.Preflight(ctx))
+		})
+	}
+
+	t.Run("rebuilt piece space used", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		db := openCorrupted(ctx, t, storagenodedb.PieceSpaceUsedDBName)
+		defer ctx.Check(db.Close)
+
+		require.NoError(t, db.Recover(ctx))
+
+		satelliteID := testrand.NodeID()
+		require.NoError(t, db.PieceSpaceUsedDB().UpdatePieceTotalsForSatellite(ctx, satelliteID, pieces.SatelliteUsage{Total: 10, ContentSize: 8}))
+
+		piecesTotal, _, err := db.PieceSpaceUsedDB().GetPieceTotals(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 10, piecesTotal)
+	})
+
+	t.Run("rebuilt used space per prefix", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		db := openCorrupted(ctx, t, storagenodedb.UsedSpacePerPrefixDBName)
+		defer ctx.Check(db.Close)
+
+		require.NoError(t, db.Recover(ctx))
+
+		satelliteID := testrand.NodeID()
+		for _, total := range []int64{10, 20} {
+			require.NoError(t, db.UsedSpacePerPrefix().Store(ctx, pieces.PrefixUsedSpace{
+				SatelliteID: satelliteID,
+				Prefix:      "aa",
+				TotalBytes:  total,
+			}))
+		}
+
+		usedSpaces, err := db.UsedSpacePerPrefix().Get(ctx, satelliteID)
+		require.NoError(t, err)
+		require.Len(t, usedSpaces, 1)
+		require.EqualValues(t, 20, usedSpaces[0].TotalBytes)
+	})
+
+	t.Run("not rebuildable", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		db := openCorrupted(ctx, t, storagenodedb.SatellitesDBName)
+		defer ctx.Check(db.Close)
+
+		require.NoError(t, db.Recover(ctx))
+		require.True(t, db.DatabaseHealth().ReadOnly())
+
+		corrupt := db.DatabaseHealth().Corrupt()
+		require.Len(t, corrupt, 1)
+		require.Equal(t, storagenodedb.SatellitesDBName, corrupt[0].Name)
+	})
+}
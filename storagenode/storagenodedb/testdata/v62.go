@@ -0,0 +1,58 @@
+// Copyright (C) 2024 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testdata
+
+import "storj.io/storj/storagenode/storagenodedb"
+
+var v62 = MultiDBState{
+	Version: 62,
+	DBStates: DBStates{
+		storagenodedb.UsedSerialsDBName:     v61.DBStates[storagenodedb.UsedSerialsDBName],
+		storagenodedb.StorageUsageDBName:    v61.DBStates[storagenodedb.StorageUsageDBName],
+		storagenodedb.PieceSpaceUsedDBName:  v61.DBStates[storagenodedb.PieceSpaceUsedDBName],
+		storagenodedb.PieceInfoDBName:       v61.DBStates[storagenodedb.PieceInfoDBName],
+		storagenodedb.PieceExpirationDBName: v61.DBStates[storagenodedb.PieceExpirationDBName],
+		storagenodedb.OrdersDBName:          v61.DBStates[storagenodedb.OrdersDBName],
+		storagenodedb.BandwidthDBName:       v61.DBStates[storagenodedb.BandwidthDBName],
+		storagenodedb.SatellitesDBName: &DBState{
+			SQL: `
+				CREATE TABLE satellites (
+					node_id BLOB NOT NULL,
+					address TEXT,
+					added_at TIMESTAMP NOT NULL,
+					status INTEGER NOT NULL,
+					PRIMARY KEY (node_id)
+				);
+				CREATE TABLE satellite_exit_progress (
+					satellite_id BLOB NOT NULL,
+					initiated_at TIMESTAMP,
+					finished_at TIMESTAMP,
+					starting_disk_usage INTEGER NOT NULL,
+					bytes_deleted INTEGER NOT NULL,
+					completion_receipt BLOB,
+					FOREIGN KEY (satellite_id) REFERENCES satellites (node_id)
+				);
+				CREATE TABLE satellite_pauses (
+					satellite_id BLOB NOT NULL,
+					downloads INTEGER NOT NULL,
+					paused_at TIMESTAMP NOT NULL,
+					PRIMARY KEY (satellite_id)
+				);
+				INSERT INTO satellites (node_id, 															 added_at, 					  status) VALUES
+									   (X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000', '2019-09-10 20:00:00+00:00', 0);
+				INSERT INTO satellite_exit_progress VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000','2019-09-10 20:00:00+00:00', null, 100, 0, null);
+			`,
+			NewData: `
+				INSERT INTO satellite_pauses VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000', 0, '2024-01-20 10:00:00+00:00');
+			`,
+		},
+		storagenodedb.DeprecatedInfoDBName:       v61.DBStates[storagenodedb.DeprecatedInfoDBName],
+		storagenodedb.NotificationsDBName:        v61.DBStates[storagenodedb.NotificationsDBName],
+		storagenodedb.HeldAmountDBName:           v61.DBStates[storagenodedb.HeldAmountDBName],
+		storagenodedb.PricingDBName:              v61.DBStates[storagenodedb.PricingDBName],
+		storagenodedb.APIKeysDBName:              v61.DBStates[storagenodedb.APIKeysDBName],
+		storagenodedb.GCFilewalkerProgressDBName: v61.DBStates[storagenodedb.GCFilewalkerProgressDBName],
+		storagenodedb.UsedSpacePerPrefixDBName:   v61.DBStates[storagenodedb.UsedSpacePerPrefixDBName],
+	},
+}
@@ -67,6 +67,7 @@ export class FrontendConfig {
     altObjBrowserPagingThreshold: number;
     domainsPageEnabled: boolean;
     activeSessionsViewEnabled: boolean;
+    objectPreviewEnabled: boolean;
 }
 
 export class MultiCaptchaConfig {
@@ -81,6 +81,9 @@ export function newNodeModule(service: StorageNodeService): StoreModule<StorageN
                 state.suspendedSatellites = nodeInfo.satellites.filter((satellite: SatelliteInfo) => satellite.suspended);
 
                 state.satellites = nodeInfo.satellites;
+
+                state.corruptDatabases = nodeInfo.corruptDatabases;
+                state.repairInstructions = nodeInfo.repairInstructions;
             },
             [SELECT_SATELLITE](state: StorageNodeState, satelliteInfo: Satellite): void {
                 const selectedSatellite = state.satellites.find(satellite => satelliteInfo.id === satellite.id);
@@ -3,6 +3,7 @@
 
 import {
     BandwidthUsed,
+    CorruptDatabase,
     EgressUsed,
     IngressUsed,
     Node,
@@ -33,4 +34,6 @@ export class StorageNodeState {
     public ingressSummary = 0;
     public satellitesScores: SatelliteScores[] = [];
     public audits: SatelliteScores = new SatelliteScores();
+    public corruptDatabases: CorruptDatabase[] = [];
+    public repairInstructions = '';
 }
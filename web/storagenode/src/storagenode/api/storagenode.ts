@@ -2,6 +2,7 @@
 // See LICENSE for copying information.
 
 import {
+    CorruptDatabase,
     Dashboard,
     Satellite,
     SatelliteByDayInfo,
@@ -44,8 +45,15 @@ export class StorageNodeApi {
         const diskSpace: Traffic = new Traffic(data.diskSpace.used, data.diskSpace.available, data.diskSpace.trash, data.diskSpace.overused);
         const bandwidth: Traffic = new Traffic(data.bandwidth.used);
 
+        const corruptDatabasesJson = data.corruptDatabases || [];
+
+        const corruptDatabases: CorruptDatabase[] = corruptDatabasesJson.map((db: any) => { // eslint-disable-line @typescript-eslint/no-explicit-any
+            return new CorruptDatabase(db.name, db.error, new Date(db.detectedAt));
+        });
+
         return new Dashboard(data.nodeID, data.wallet, data.walletFeatures || [], satellites, diskSpace, bandwidth,
-            new Date(data.lastPinged), new Date(data.startedAt), data.version, data.allowedVersion, data.upToDate, data.quicStatus, data.configuredPort, new Date(data.lastQuicPingedAt));
+            new Date(data.lastPinged), new Date(data.startedAt), data.version, data.allowedVersion, data.upToDate, data.quicStatus, data.configuredPort, new Date(data.lastQuicPingedAt),
+            corruptDatabases, data.repairInstructions || '');
     }
 
     /**
@@ -47,6 +47,7 @@ export enum NotificationTypes {
     AuditCheckFailure = 1,
     Disqualification = 2,
     Suspension = 3,
+    HeldAmount = 4,
 }
 
 /**
@@ -79,6 +79,19 @@ export class Dashboard {
         public quicStatus: string,
         public configuredPort: string,
         public lastQuicPingedAt: Date,
+        public corruptDatabases: CorruptDatabase[] = [],
+        public repairInstructions: string = '',
+    ) { }
+}
+
+/**
+ * CorruptDatabase describes a node database which was found to be corrupt.
+ */
+export class CorruptDatabase {
+    public constructor(
+        public name: string = '',
+        public error: string = '',
+        public detectedAt: Date = new Date(),
     ) { }
 }
 